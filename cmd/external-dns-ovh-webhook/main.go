@@ -0,0 +1,77 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Command external-dns-ovh-webhook runs the OVH provider behind the webhook provider API, so that it can be
+// versioned and deployed apart from the controller, which uses it with --provider=webhook.
+//
+// It takes the flags of external-dns: the --ovh-* flags, the domain filter flags, --dry-run,
+// --provider-batch-size, the logging flags, --webhook-server-address for the webhook API and --metrics-address
+// for the metrics and the liveness probe.
+package main
+
+import (
+	"context"
+	"net/http"
+	"os"
+
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+	log "github.com/sirupsen/logrus"
+
+	"sigs.k8s.io/external-dns/endpoint"
+	"sigs.k8s.io/external-dns/pkg/apis/externaldns"
+	"sigs.k8s.io/external-dns/provider/ovh"
+	webhookapi "sigs.k8s.io/external-dns/provider/webhook/api"
+)
+
+func main() {
+	cfg := externaldns.NewConfig()
+	// the provider is OVH, and the required source is not used
+	args := append([]string{"--provider=ovh", "--source=service"}, os.Args[1:]...)
+	if err := cfg.ParseFlags(args); err != nil {
+		log.Fatalf("flag parsing error: %v", err)
+	}
+	if cfg.LogFormat == "json" {
+		log.SetFormatter(&log.JSONFormatter{})
+	}
+	level, err := log.ParseLevel(cfg.LogLevel)
+	if err != nil {
+		log.Fatalf("failed to parse log level: %v", err)
+	}
+	log.SetLevel(level)
+	log.Infof("external-dns-ovh-webhook version %s", externaldns.Version)
+
+	domainFilter := endpoint.NewDomainFilterWithExclusions(cfg.DomainFilter, cfg.ExcludeDomains)
+	if cfg.RegexDomainFilter != nil && cfg.RegexDomainFilter.String() != "" {
+		domainFilter = endpoint.NewRegexDomainFilter(cfg.RegexDomainFilter, cfg.RegexDomainExclusion)
+	}
+	p, err := ovh.NewOVHProviderFromConfig(context.Background(), cfg, domainFilter)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	go serveMetrics(cfg.MetricsAddress)
+	log.Infof("serving the webhook provider API on %s", cfg.WebhookServerAddress)
+	webhookapi.StartHTTPApi(p, nil, cfg.WebhookProviderReadTimeout, cfg.WebhookProviderWriteTimeout, cfg.WebhookServerAddress)
+}
+
+// serveMetrics serves the metrics, including the ones of the OVHcloud API calls, and a liveness probe.
+func serveMetrics(address string) {
+	http.HandleFunc("/healthz", func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	})
+	http.Handle("/metrics", promhttp.Handler())
+	log.Fatal(http.ListenAndServe(address, nil))
+}
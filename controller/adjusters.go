@@ -0,0 +1,304 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"slices"
+	"sort"
+	"strings"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+	"golang.org/x/net/idna"
+
+	"sigs.k8s.io/external-dns/endpoint"
+	"sigs.k8s.io/external-dns/pkg/apis/externaldns"
+	"sigs.k8s.io/external-dns/pkg/resolver"
+	"sigs.k8s.io/external-dns/registry"
+)
+
+const (
+	// TTLClampAdjuster keeps TTLs within --min-ttl and --max-ttl.
+	TTLClampAdjuster = "ttl-clamp"
+	// CNAMEFlattenAdjuster replaces CNAME records by the A and AAAA records their targets resolve to.
+	CNAMEFlattenAdjuster = "cname-flatten"
+	// IDNNormalizeAdjuster converts internationalized DNS names and targets to their ASCII form.
+	IDNNormalizeAdjuster = "idn-normalize"
+	// WebhookAdjuster sends endpoints to the /adjustendpoints route of --endpoint-adjuster-webhook-url.
+	WebhookAdjuster = "webhook"
+	// ProviderAdjuster runs the adjustments required by the provider.
+	ProviderAdjuster = "provider"
+)
+
+// EndpointAdjusters lists the names accepted by --endpoint-adjuster.
+var EndpointAdjusters = []string{TTLClampAdjuster, CNAMEFlattenAdjuster, IDNNormalizeAdjuster, WebhookAdjuster, ProviderAdjuster}
+
+// EndpointAdjuster modifies desired endpoints before the plan is calculated.
+type EndpointAdjuster interface {
+	Name() string
+	Adjust(ctx context.Context, endpoints []*endpoint.Endpoint) ([]*endpoint.Endpoint, error)
+}
+
+// AdjusterChain runs endpoint adjusters in order, each one receiving the endpoints returned by the previous one.
+type AdjusterChain []EndpointAdjuster
+
+// NewAdjusterChain builds the chain of adjusters named in cfg.EndpointAdjusters. TTLs are clamped
+// first when --min-ttl or --max-ttl is set, and the provider adjustments run last, unless their
+// adjusters are placed explicitly.
+func NewAdjusterChain(cfg *externaldns.Config, reg registry.Registry) (AdjusterChain, error) {
+	names := cfg.EndpointAdjusters
+	if (cfg.MinTTL > 0 || cfg.MaxTTL > 0) && !slices.Contains(names, TTLClampAdjuster) {
+		names = append([]string{TTLClampAdjuster}, names...)
+	}
+	if !slices.Contains(names, ProviderAdjuster) {
+		names = append(append([]string{}, names...), ProviderAdjuster)
+	}
+
+	chain := make(AdjusterChain, 0, len(names))
+	for _, name := range names {
+		switch name {
+		case TTLClampAdjuster:
+			chain = append(chain, &ttlClampAdjuster{minTTL: cfg.MinTTL, maxTTL: cfg.MaxTTL})
+		case CNAMEFlattenAdjuster:
+			chain = append(chain, &cnameFlattenAdjuster{resolver: resolver.Default()})
+		case IDNNormalizeAdjuster:
+			chain = append(chain, idnNormalizeAdjuster{})
+		case WebhookAdjuster:
+			if cfg.AdjusterWebhookURL == "" {
+				return nil, fmt.Errorf("the %s endpoint adjuster requires --endpoint-adjuster-webhook-url", WebhookAdjuster)
+			}
+			adjuster, err := newWebhookAdjuster(cfg.AdjusterWebhookURL, cfg.AdjusterWebhookTimeout, cfg.AdjusterWebhookFailurePolicy == "skip")
+			if err != nil {
+				return nil, err
+			}
+			chain = append(chain, adjuster)
+		case ProviderAdjuster:
+			chain = append(chain, &providerAdjuster{registry: reg})
+		default:
+			return nil, fmt.Errorf("unknown endpoint adjuster %q", name)
+		}
+	}
+	return chain, nil
+}
+
+// Adjust runs the endpoints through every adjuster of the chain.
+func (c AdjusterChain) Adjust(ctx context.Context, endpoints []*endpoint.Endpoint) ([]*endpoint.Endpoint, error) {
+	for _, adjuster := range c {
+		var err error
+		endpoints, err = adjuster.Adjust(ctx, endpoints)
+		if err != nil {
+			return nil, fmt.Errorf("%s endpoint adjuster: %w", adjuster.Name(), err)
+		}
+	}
+	return endpoints, nil
+}
+
+// Contains reports whether the chain has an adjuster with the given name.
+func (c AdjusterChain) Contains(name string) bool {
+	for _, adjuster := range c {
+		if adjuster.Name() == name {
+			return true
+		}
+	}
+	return false
+}
+
+type ttlClampAdjuster struct {
+	minTTL time.Duration
+	maxTTL time.Duration
+}
+
+func (a *ttlClampAdjuster) Name() string { return TTLClampAdjuster }
+
+// Adjust raises TTLs below the minimum TTL to it, and lowers TTLs above the maximum TTL to it.
+// Endpoints without a TTL keep the provider default. A zero bound is disabled.
+func (a *ttlClampAdjuster) Adjust(_ context.Context, endpoints []*endpoint.Endpoint) ([]*endpoint.Endpoint, error) {
+	minTTL, maxTTL := endpoint.TTL(a.minTTL.Seconds()), endpoint.TTL(a.maxTTL.Seconds())
+	for _, ep := range endpoints {
+		if !ep.RecordTTL.IsConfigured() {
+			continue
+		}
+		switch {
+		case minTTL > 0 && ep.RecordTTL < minTTL:
+			log.Warnf("TTL %d of %s record %q from %s is below the minimum TTL, using %d", ep.RecordTTL, ep.RecordType, ep.DNSName, ep.Labels[endpoint.ResourceLabelKey], minTTL)
+			ep.RecordTTL = minTTL
+		case maxTTL > 0 && ep.RecordTTL > maxTTL:
+			log.Warnf("TTL %d of %s record %q from %s is above the maximum TTL, using %d", ep.RecordTTL, ep.RecordType, ep.DNSName, ep.Labels[endpoint.ResourceLabelKey], maxTTL)
+			ep.RecordTTL = maxTTL
+		}
+	}
+	return endpoints, nil
+}
+
+// ipResolver resolves host names, it is implemented by net.Resolver and resolver.Resolver.
+type ipResolver interface {
+	LookupIPAddr(ctx context.Context, host string) ([]net.IPAddr, error)
+}
+
+type cnameFlattenAdjuster struct {
+	resolver ipResolver
+	// flattened are the endpoints each CNAME endpoint was last flattened to, by name and set identifier.
+	flattened map[string]flattenedCNAME
+}
+
+// flattenedCNAME is the flattening of a CNAME endpoint with the given targets.
+type flattenedCNAME struct {
+	targets   endpoint.Targets
+	endpoints []*endpoint.Endpoint
+}
+
+func (a *cnameFlattenAdjuster) Name() string { return CNAMEFlattenAdjuster }
+
+// Adjust replaces each CNAME endpoint by A and AAAA endpoints pointing at the addresses its targets
+// resolve to. When its targets fail to resolve, an endpoint is replaced by the endpoints it was last
+// flattened to if its targets did not change since, and else skipped until they resolve, so that a
+// resolver failure never turns flattened records back into a CNAME record.
+func (a *cnameFlattenAdjuster) Adjust(ctx context.Context, endpoints []*endpoint.Endpoint) ([]*endpoint.Endpoint, error) {
+	result := make([]*endpoint.Endpoint, 0, len(endpoints))
+	flattened := make(map[string]flattenedCNAME, len(a.flattened))
+	for _, ep := range endpoints {
+		if ep.RecordType != endpoint.RecordTypeCNAME {
+			result = append(result, ep)
+			continue
+		}
+
+		key := ep.DNSName + " " + ep.SetIdentifier
+		eps, err := a.flatten(ctx, ep)
+		if err != nil {
+			previous, ok := a.flattened[key]
+			if !ok || !previous.targets.Same(ep.Targets) {
+				log.Warnf("Skipping CNAME record %q until it can be flattened: %v", ep.DNSName, err)
+				continue
+			}
+			log.Warnf("Keeping the addresses CNAME record %q was last flattened to: %v", ep.DNSName, err)
+			eps = previous.endpoints
+		}
+		flattened[key] = flattenedCNAME{targets: ep.Targets, endpoints: eps}
+		for _, flat := range eps {
+			result = append(result, flat.DeepCopy())
+		}
+	}
+	a.flattened = flattened
+	return result, nil
+}
+
+// flatten returns the A and AAAA endpoints pointing at the addresses the targets of ep resolve to.
+func (a *cnameFlattenAdjuster) flatten(ctx context.Context, ep *endpoint.Endpoint) ([]*endpoint.Endpoint, error) {
+	var ipv4s, ipv6s []string
+	for _, target := range ep.Targets {
+		addrs, err := a.resolver.LookupIPAddr(ctx, target)
+		if err != nil {
+			return nil, fmt.Errorf("failed to resolve %q: %w", target, err)
+		}
+		if len(addrs) == 0 {
+			return nil, fmt.Errorf("%q has no addresses", target)
+		}
+		for _, addr := range addrs {
+			if addr.IP.To4() != nil {
+				ipv4s = append(ipv4s, addr.IP.String())
+			} else {
+				ipv6s = append(ipv6s, addr.IP.String())
+			}
+		}
+	}
+
+	var eps []*endpoint.Endpoint
+	if len(ipv4s) > 0 {
+		eps = append(eps, flattenedEndpoint(ep, endpoint.RecordTypeA, ipv4s))
+	}
+	if len(ipv6s) > 0 {
+		eps = append(eps, flattenedEndpoint(ep, endpoint.RecordTypeAAAA, ipv6s))
+	}
+	return eps, nil
+}
+
+func flattenedEndpoint(ep *endpoint.Endpoint, recordType string, targets []string) *endpoint.Endpoint {
+	flattened := ep.DeepCopy()
+	flattened.RecordType = recordType
+	flattened.Targets = endpoint.NewTargets(uniqueSorted(targets)...)
+	return flattened
+}
+
+func uniqueSorted(values []string) []string {
+	sort.Strings(values)
+	unique := values[:0]
+	for i, v := range values {
+		if i == 0 || v != values[i-1] {
+			unique = append(unique, v)
+		}
+	}
+	return unique
+}
+
+type idnNormalizeAdjuster struct{}
+
+func (idnNormalizeAdjuster) Name() string { return IDNNormalizeAdjuster }
+
+// Adjust converts the DNS names, and the targets of CNAME, MX, NS and SRV endpoints, to their
+// lowercase ASCII form. Names that cannot be converted are kept as is.
+func (idnNormalizeAdjuster) Adjust(_ context.Context, endpoints []*endpoint.Endpoint) ([]*endpoint.Endpoint, error) {
+	for _, ep := range endpoints {
+		ep.DNSName = toASCII(ep.DNSName)
+		switch ep.RecordType {
+		case endpoint.RecordTypeCNAME, endpoint.RecordTypeNS:
+			for i, target := range ep.Targets {
+				ep.Targets[i] = toASCII(target)
+			}
+		case endpoint.RecordTypeMX, endpoint.RecordTypeSRV:
+			// The host name is the last field of MX and SRV targets.
+			for i, target := range ep.Targets {
+				fields := strings.Fields(target)
+				if len(fields) > 0 {
+					fields[len(fields)-1] = toASCII(fields[len(fields)-1])
+					ep.Targets[i] = strings.Join(fields, " ")
+				}
+			}
+		}
+	}
+	return endpoints, nil
+}
+
+// toASCII converts name to its lowercase ASCII form, leaving labels such as wildcards and
+// underscored service labels untouched.
+func toASCII(name string) string {
+	labels := strings.Split(name, ".")
+	for i, label := range labels {
+		if label == "" || label == "*" || strings.HasPrefix(label, "_") {
+			continue
+		}
+		converted, err := idna.Lookup.ToASCII(label)
+		if err != nil {
+			log.Warnf("Failed to convert label %q of %q to its ASCII form: %v", label, name, err)
+			converted = strings.ToLower(label)
+		}
+		labels[i] = converted
+	}
+	return strings.Join(labels, ".")
+}
+
+type providerAdjuster struct {
+	registry registry.Registry
+}
+
+func (a *providerAdjuster) Name() string { return ProviderAdjuster }
+
+func (a *providerAdjuster) Adjust(_ context.Context, endpoints []*endpoint.Endpoint) ([]*endpoint.Endpoint, error) {
+	return a.registry.AdjustEndpoints(endpoints)
+}
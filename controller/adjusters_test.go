@@ -0,0 +1,291 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"errors"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"sigs.k8s.io/external-dns/endpoint"
+	"sigs.k8s.io/external-dns/internal/testutils"
+	"sigs.k8s.io/external-dns/pkg/apis/externaldns"
+	"sigs.k8s.io/external-dns/provider/inmemory"
+	"sigs.k8s.io/external-dns/registry"
+)
+
+// ttlProvider sets a TTL of 600 on every endpoint it adjusts.
+type ttlProvider struct {
+	*inmemory.InMemoryProvider
+}
+
+func (p ttlProvider) AdjustEndpoints(endpoints []*endpoint.Endpoint) ([]*endpoint.Endpoint, error) {
+	for _, ep := range endpoints {
+		ep.RecordTTL = 600
+	}
+	return endpoints, nil
+}
+
+func adjusterNames(chain AdjusterChain) []string {
+	names := make([]string, 0, len(chain))
+	for _, adjuster := range chain {
+		names = append(names, adjuster.Name())
+	}
+	return names
+}
+
+func TestNewAdjusterChain(t *testing.T) {
+	reg, err := registry.NewNoopRegistry(inmemory.NewInMemoryProvider())
+	require.NoError(t, err)
+
+	for _, tt := range []struct {
+		title      string
+		adjusters  []string
+		webhookURL string
+		minTTL     time.Duration
+		expected   []string
+		err        string
+	}{
+		{
+			title:     "provider adjustments run last by default",
+			adjusters: []string{IDNNormalizeAdjuster, TTLClampAdjuster},
+			expected:  []string{IDNNormalizeAdjuster, TTLClampAdjuster, ProviderAdjuster},
+		},
+		{
+			title:     "provider adjustments placed explicitly",
+			adjusters: []string{ProviderAdjuster, TTLClampAdjuster, CNAMEFlattenAdjuster},
+			expected:  []string{ProviderAdjuster, TTLClampAdjuster, CNAMEFlattenAdjuster},
+		},
+		{
+			title:    "ttl clamped first with --min-ttl",
+			minTTL:   time.Minute,
+			expected: []string{TTLClampAdjuster, ProviderAdjuster},
+		},
+		{
+			title:     "ttl clamped first with --min-ttl and other adjusters",
+			adjusters: []string{IDNNormalizeAdjuster},
+			minTTL:    time.Minute,
+			expected:  []string{TTLClampAdjuster, IDNNormalizeAdjuster, ProviderAdjuster},
+		},
+		{
+			title:     "ttl clamp placed explicitly with --min-ttl",
+			adjusters: []string{ProviderAdjuster, TTLClampAdjuster},
+			minTTL:    time.Minute,
+			expected:  []string{ProviderAdjuster, TTLClampAdjuster},
+		},
+		{
+			title:      "webhook",
+			adjusters:  []string{WebhookAdjuster},
+			webhookURL: "http://localhost:8889",
+			expected:   []string{WebhookAdjuster, ProviderAdjuster},
+		},
+		{
+			title:     "webhook without URL",
+			adjusters: []string{WebhookAdjuster},
+			err:       "the webhook endpoint adjuster requires --endpoint-adjuster-webhook-url",
+		},
+		{
+			title:     "unknown adjuster",
+			adjusters: []string{"round-robin"},
+			err:       `unknown endpoint adjuster "round-robin"`,
+		},
+	} {
+		t.Run(tt.title, func(t *testing.T) {
+			cfg := externaldns.NewConfig()
+			cfg.EndpointAdjusters = tt.adjusters
+			cfg.AdjusterWebhookURL = tt.webhookURL
+			cfg.MinTTL = tt.minTTL
+
+			chain, err := NewAdjusterChain(cfg, reg)
+			if tt.err != "" {
+				assert.EqualError(t, err, tt.err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, adjusterNames(chain))
+		})
+	}
+}
+
+func TestAdjusterChainOrder(t *testing.T) {
+	reg, err := registry.NewNoopRegistry(ttlProvider{inmemory.NewInMemoryProvider()})
+	require.NoError(t, err)
+
+	cfg := externaldns.NewConfig()
+	cfg.MaxTTL = 5 * time.Minute
+
+	// The provider raises TTLs after they got clamped.
+	cfg.EndpointAdjusters = []string{TTLClampAdjuster}
+	chain, err := NewAdjusterChain(cfg, reg)
+	require.NoError(t, err)
+	endpoints, err := chain.Adjust(context.Background(), []*endpoint.Endpoint{endpoint.NewEndpoint("a.example.com", endpoint.RecordTypeA, "1.2.3.4")})
+	require.NoError(t, err)
+	assert.Equal(t, endpoint.TTL(600), endpoints[0].RecordTTL)
+
+	// TTLs are clamped after the provider adjustments.
+	cfg.EndpointAdjusters = []string{ProviderAdjuster, TTLClampAdjuster}
+	chain, err = NewAdjusterChain(cfg, reg)
+	require.NoError(t, err)
+	endpoints, err = chain.Adjust(context.Background(), []*endpoint.Endpoint{endpoint.NewEndpoint("a.example.com", endpoint.RecordTypeA, "1.2.3.4")})
+	require.NoError(t, err)
+	assert.Equal(t, endpoint.TTL(300), endpoints[0].RecordTTL)
+}
+
+func TestTTLClampAdjuster(t *testing.T) {
+	for _, tt := range []struct {
+		title    string
+		minTTL   time.Duration
+		maxTTL   time.Duration
+		ttls     []endpoint.TTL
+		expected []endpoint.TTL
+	}{
+		{
+			title:    "no bounds",
+			ttls:     []endpoint.TTL{0, 1, 300, 86400},
+			expected: []endpoint.TTL{0, 1, 300, 86400},
+		},
+		{
+			title:    "minimum only",
+			minTTL:   time.Minute,
+			ttls:     []endpoint.TTL{0, 1, 60, 86400},
+			expected: []endpoint.TTL{0, 60, 60, 86400},
+		},
+		{
+			title:    "maximum only",
+			maxTTL:   time.Hour,
+			ttls:     []endpoint.TTL{0, 1, 3600, 86400},
+			expected: []endpoint.TTL{0, 1, 3600, 3600},
+		},
+		{
+			title:    "both bounds",
+			minTTL:   30 * time.Second,
+			maxTTL:   time.Hour,
+			ttls:     []endpoint.TTL{0, 1, 300, 86400},
+			expected: []endpoint.TTL{0, 30, 300, 3600},
+		},
+	} {
+		t.Run(tt.title, func(t *testing.T) {
+			endpoints := make([]*endpoint.Endpoint, 0, len(tt.ttls))
+			for _, ttl := range tt.ttls {
+				endpoints = append(endpoints, endpoint.NewEndpointWithTTL("foo.example.com", endpoint.RecordTypeA, ttl, "1.2.3.4"))
+			}
+
+			adjuster := &ttlClampAdjuster{minTTL: tt.minTTL, maxTTL: tt.maxTTL}
+			res, err := adjuster.Adjust(context.Background(), endpoints)
+			require.NoError(t, err)
+
+			ttls := make([]endpoint.TTL, 0, len(res))
+			for _, ep := range res {
+				ttls = append(ttls, ep.RecordTTL)
+			}
+			assert.Equal(t, tt.expected, ttls)
+		})
+	}
+}
+
+type failingAdjuster struct{}
+
+func (failingAdjuster) Name() string { return "failing" }
+
+func (failingAdjuster) Adjust(context.Context, []*endpoint.Endpoint) ([]*endpoint.Endpoint, error) {
+	return nil, errors.New("boom")
+}
+
+func TestAdjusterChainError(t *testing.T) {
+	chain := AdjusterChain{idnNormalizeAdjuster{}, failingAdjuster{}}
+	_, err := chain.Adjust(context.Background(), nil)
+	assert.EqualError(t, err, "failing endpoint adjuster: boom")
+	assert.True(t, chain.Contains(IDNNormalizeAdjuster))
+	assert.False(t, chain.Contains(ProviderAdjuster))
+}
+
+type fakeResolver map[string][]string
+
+func (r fakeResolver) LookupIPAddr(_ context.Context, host string) ([]net.IPAddr, error) {
+	ips, ok := r[host]
+	if !ok {
+		return nil, errors.New("no such host")
+	}
+	addrs := make([]net.IPAddr, 0, len(ips))
+	for _, ip := range ips {
+		addrs = append(addrs, net.IPAddr{IP: net.ParseIP(ip)})
+	}
+	return addrs, nil
+}
+
+func TestCNAMEFlattenAdjuster(t *testing.T) {
+	resolver := fakeResolver{
+		"lb.example.net":  {"1.2.3.5", "2001:db8::1", "1.2.3.4"},
+		"lb2.example.net": {"1.2.3.4"},
+	}
+	adjuster := &cnameFlattenAdjuster{resolver: resolver}
+
+	endpoints, err := adjuster.Adjust(context.Background(), []*endpoint.Endpoint{
+		endpoint.NewEndpointWithTTL("www.example.com", endpoint.RecordTypeCNAME, 300, "lb.example.net", "lb2.example.net").WithSetIdentifier("eu"),
+		endpoint.NewEndpoint("broken.example.com", endpoint.RecordTypeCNAME, "missing.example.net"),
+		endpoint.NewEndpoint("a.example.com", endpoint.RecordTypeA, "5.6.7.8"),
+	})
+	require.NoError(t, err)
+
+	assert.True(t, testutils.SameEndpoints(endpoints, []*endpoint.Endpoint{
+		endpoint.NewEndpointWithTTL("www.example.com", endpoint.RecordTypeA, 300, "1.2.3.4", "1.2.3.5").WithSetIdentifier("eu"),
+		endpoint.NewEndpointWithTTL("www.example.com", endpoint.RecordTypeAAAA, 300, "2001:db8::1").WithSetIdentifier("eu"),
+		endpoint.NewEndpoint("a.example.com", endpoint.RecordTypeA, "5.6.7.8"),
+	}), "unexpected endpoints: %v", endpoints)
+
+	// the addresses last resolved are kept while the targets fail to resolve
+	delete(resolver, "lb2.example.net")
+	endpoints, err = adjuster.Adjust(context.Background(), []*endpoint.Endpoint{
+		endpoint.NewEndpointWithTTL("www.example.com", endpoint.RecordTypeCNAME, 300, "lb.example.net", "lb2.example.net").WithSetIdentifier("eu"),
+		endpoint.NewEndpoint("other.example.com", endpoint.RecordTypeCNAME, "lb2.example.net"),
+	})
+	require.NoError(t, err)
+
+	assert.True(t, testutils.SameEndpoints(endpoints, []*endpoint.Endpoint{
+		endpoint.NewEndpointWithTTL("www.example.com", endpoint.RecordTypeA, 300, "1.2.3.4", "1.2.3.5").WithSetIdentifier("eu"),
+		endpoint.NewEndpointWithTTL("www.example.com", endpoint.RecordTypeAAAA, 300, "2001:db8::1").WithSetIdentifier("eu"),
+	}), "unexpected endpoints: %v", endpoints)
+
+	// but not once the targets changed
+	endpoints, err = adjuster.Adjust(context.Background(), []*endpoint.Endpoint{
+		endpoint.NewEndpointWithTTL("www.example.com", endpoint.RecordTypeCNAME, 300, "lb2.example.net").WithSetIdentifier("eu"),
+	})
+	require.NoError(t, err)
+	assert.Empty(t, endpoints)
+}
+
+func TestIDNNormalizeAdjuster(t *testing.T) {
+	endpoints, err := idnNormalizeAdjuster{}.Adjust(context.Background(), []*endpoint.Endpoint{
+		endpoint.NewEndpoint("Bücher.Example.com", endpoint.RecordTypeCNAME, "münchen.example.net"),
+		endpoint.NewEndpoint("*.café.example.com", endpoint.RecordTypeMX, "10 mail.café.example.com"),
+		endpoint.NewEndpoint("_sip._tcp.café.example.com", endpoint.RecordTypeSRV, "10 5 5060 sip.café.example.com"),
+		endpoint.NewEndpoint("café.example.com", endpoint.RecordTypeTXT, "café"),
+	})
+	require.NoError(t, err)
+
+	assert.True(t, testutils.SameEndpoints(endpoints, []*endpoint.Endpoint{
+		endpoint.NewEndpoint("xn--bcher-kva.example.com", endpoint.RecordTypeCNAME, "xn--mnchen-3ya.example.net"),
+		endpoint.NewEndpoint("*.xn--caf-dma.example.com", endpoint.RecordTypeMX, "10 mail.xn--caf-dma.example.com"),
+		endpoint.NewEndpoint("_sip._tcp.xn--caf-dma.example.com", endpoint.RecordTypeSRV, "10 5 5060 sip.xn--caf-dma.example.com"),
+		endpoint.NewEndpoint("xn--caf-dma.example.com", endpoint.RecordTypeTXT, "café"),
+	}), "unexpected endpoints: %v", endpoints)
+}
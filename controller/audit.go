@@ -0,0 +1,188 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/util/uuid"
+	"k8s.io/client-go/dynamic"
+
+	"sigs.k8s.io/external-dns/endpoint"
+	"sigs.k8s.io/external-dns/plan"
+)
+
+const (
+	auditManagedByLabel = "app.kubernetes.io/managed-by"
+	auditPlanIDLabel    = "externaldns.k8s.io/plan-id"
+)
+
+// DNSChangeGVR is the resource of the DNSChange objects recording the applied changes.
+var DNSChangeGVR = schema.GroupVersionResource{
+	Group:    "externaldns.k8s.io",
+	Version:  "v1alpha1",
+	Resource: "dnschanges",
+}
+
+// Auditor records every change applied to the DNS provider as a DNSChange object, giving an
+// audit log that can be read with kubectl. Objects older than the TTL are removed.
+type Auditor struct {
+	client    dynamic.ResourceInterface
+	ownerID   string
+	ttl       time.Duration
+	lastClean time.Time
+}
+
+// NewAuditor returns an Auditor creating DNSChange objects in the given namespace.
+// A zero ttl keeps the objects forever.
+func NewAuditor(client dynamic.Interface, namespace, ownerID string, ttl time.Duration) *Auditor {
+	return &Auditor{
+		client:  client.Resource(DNSChangeGVR).Namespace(namespace),
+		ownerID: ownerID,
+		ttl:     ttl,
+	}
+}
+
+// Record creates a DNSChange object per change, all sharing a plan ID. applyErr is the error
+// returned when applying the changes, which is recorded as their outcome. Failures to create
+// the objects are logged, they never fail the synchronization.
+func (a *Auditor) Record(ctx context.Context, changes *plan.Changes, applyErr error) {
+	planID := string(uuid.NewUUID())
+	now := time.Now().UTC()
+	outcome, message := "Succeeded", ""
+	if applyErr != nil {
+		outcome, message = "Failed", applyErr.Error()
+	}
+
+	var objects []*unstructured.Unstructured
+	for _, ep := range changes.Create {
+		objects = append(objects, a.newDNSChange(planID, len(objects), "create", nil, ep, changes.ReasonsOf(ep)))
+	}
+	for i, ep := range changes.UpdateNew {
+		var previous *endpoint.Endpoint
+		if i < len(changes.UpdateOld) {
+			previous = changes.UpdateOld[i]
+		}
+		objects = append(objects, a.newDNSChange(planID, len(objects), "update", previous, ep, changes.ReasonsOf(ep)))
+	}
+	for _, ep := range changes.Delete {
+		objects = append(objects, a.newDNSChange(planID, len(objects), "delete", ep, nil, changes.ReasonsOf(ep)))
+	}
+
+	for _, obj := range objects {
+		_ = unstructured.SetNestedField(obj.Object, now.Format(time.RFC3339), "spec", "appliedAt")
+		_ = unstructured.SetNestedField(obj.Object, outcome, "spec", "outcome")
+		if message != "" {
+			_ = unstructured.SetNestedField(obj.Object, message, "spec", "message")
+		}
+		if _, err := a.client.Create(ctx, obj, metav1.CreateOptions{}); err != nil {
+			log.Warnf("Failed to record DNSChange %s: %v", obj.GetName(), err)
+		}
+	}
+}
+
+// newDNSChange returns the DNSChange object of a change. previous and desired are nil for
+// creations and deletions respectively.
+func (a *Auditor) newDNSChange(planID string, index int, action string, previous, desired *endpoint.Endpoint, reasons []plan.Reason) *unstructured.Unstructured {
+	ep := desired
+	if ep == nil {
+		ep = previous
+	}
+	spec := map[string]interface{}{
+		"planID": planID,
+		"action": action,
+		"owner":  a.ownerID,
+		"record": map[string]interface{}{
+			"dnsName":       ep.DNSName,
+			"recordType":    ep.RecordType,
+			"setIdentifier": ep.SetIdentifier,
+		},
+	}
+	if resource := ep.Labels[endpoint.ResourceLabelKey]; resource != "" {
+		spec["resource"] = resource
+	}
+	if previous != nil {
+		spec["old"] = auditValue(previous)
+	}
+	if desired != nil {
+		spec["new"] = auditValue(desired)
+	}
+	if len(reasons) > 0 {
+		values := make([]interface{}, 0, len(reasons))
+		for _, r := range reasons {
+			value := map[string]interface{}{"code": r.Code}
+			if r.Detail != "" {
+				value["detail"] = r.Detail
+			}
+			values = append(values, value)
+		}
+		spec["reasons"] = values
+	}
+
+	obj := &unstructured.Unstructured{Object: map[string]interface{}{"spec": spec}}
+	obj.SetAPIVersion(DNSChangeGVR.GroupVersion().String())
+	obj.SetKind("DNSChange")
+	obj.SetName(fmt.Sprintf("%s-%d", planID, index))
+	obj.SetLabels(map[string]string{
+		auditManagedByLabel: "external-dns",
+		auditPlanIDLabel:    planID,
+	})
+	return obj
+}
+
+// auditValue returns the value of a record as recorded in a DNSChange object.
+func auditValue(ep *endpoint.Endpoint) map[string]interface{} {
+	targets := make([]interface{}, 0, len(ep.Targets))
+	for _, t := range ep.Targets {
+		targets = append(targets, t)
+	}
+	return map[string]interface{}{
+		"targets": targets,
+		"ttl":     int64(ep.RecordTTL),
+	}
+}
+
+// Cleanup deletes the DNSChange objects older than the TTL. It lists the objects at most once
+// per tenth of the TTL.
+func (a *Auditor) Cleanup(ctx context.Context, now time.Time) {
+	if a.ttl <= 0 || now.Sub(a.lastClean) < a.ttl/10 {
+		return
+	}
+	a.lastClean = now
+
+	list, err := a.client.List(ctx, metav1.ListOptions{LabelSelector: auditManagedByLabel + "=external-dns"})
+	if err != nil {
+		log.Warnf("Failed to list DNSChange objects: %v", err)
+		return
+	}
+	for _, item := range list.Items {
+		appliedAt, _, _ := unstructured.NestedString(item.Object, "spec", "appliedAt")
+		at, err := time.Parse(time.RFC3339, appliedAt)
+		if err != nil || now.Sub(at) < a.ttl {
+			continue
+		}
+		if err := a.client.Delete(ctx, item.GetName(), metav1.DeleteOptions{}); err != nil {
+			log.Warnf("Failed to delete DNSChange %s: %v", item.GetName(), err)
+		}
+	}
+}
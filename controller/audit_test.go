@@ -0,0 +1,172 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	fakeDynamic "k8s.io/client-go/dynamic/fake"
+
+	"sigs.k8s.io/external-dns/endpoint"
+	"sigs.k8s.io/external-dns/internal/testutils"
+	"sigs.k8s.io/external-dns/plan"
+	"sigs.k8s.io/external-dns/registry"
+)
+
+func newFakeAuditClient() *fakeDynamic.FakeDynamicClient {
+	return fakeDynamic.NewSimpleDynamicClientWithCustomListKinds(runtime.NewScheme(), map[schema.GroupVersionResource]string{
+		DNSChangeGVR: "DNSChangeList",
+	})
+}
+
+func listDNSChanges(t *testing.T, client *fakeDynamic.FakeDynamicClient) []unstructured.Unstructured {
+	t.Helper()
+	list, err := client.Resource(DNSChangeGVR).Namespace("audit").List(context.Background(), metav1.ListOptions{})
+	require.NoError(t, err)
+	return list.Items
+}
+
+func TestAuditorRecord(t *testing.T) {
+	client := newFakeAuditClient()
+	auditor := NewAuditor(client, "audit", "owner", time.Hour)
+
+	created := endpoint.NewEndpointWithTTL("new.example.org", endpoint.RecordTypeA, 300, "1.2.3.4")
+	created.Labels[endpoint.ResourceLabelKey] = "service/default/new"
+	auditor.Record(context.Background(), &plan.Changes{
+		Create:    []*endpoint.Endpoint{created},
+		UpdateOld: []*endpoint.Endpoint{endpoint.NewEndpoint("app.example.org", endpoint.RecordTypeCNAME, "old.example.org")},
+		UpdateNew: []*endpoint.Endpoint{endpoint.NewEndpoint("app.example.org", endpoint.RecordTypeCNAME, "new.example.org")},
+		Delete:    []*endpoint.Endpoint{endpoint.NewEndpoint("gone.example.org", endpoint.RecordTypeA, "1.2.3.5")},
+	}, nil)
+
+	items := listDNSChanges(t, client)
+	require.Len(t, items, 3)
+	specs := map[string]map[string]interface{}{}
+	planIDs := map[string]bool{}
+	for _, item := range items {
+		assert.Equal(t, "DNSChange", item.GetKind())
+		assert.Equal(t, "external-dns", item.GetLabels()[auditManagedByLabel])
+		spec, _, err := unstructured.NestedMap(item.Object, "spec")
+		require.NoError(t, err)
+		assert.Equal(t, "Succeeded", spec["outcome"])
+		assert.Equal(t, "owner", spec["owner"])
+		assert.Equal(t, item.GetLabels()[auditPlanIDLabel], spec["planID"])
+		planIDs[spec["planID"].(string)] = true
+		specs[spec["action"].(string)] = spec
+	}
+	assert.Len(t, planIDs, 1)
+
+	assert.Equal(t, "new.example.org", specs["create"]["record"].(map[string]interface{})["dnsName"])
+	assert.Equal(t, "service/default/new", specs["create"]["resource"])
+	assert.Equal(t, map[string]interface{}{"targets": []interface{}{"1.2.3.4"}, "ttl": int64(300)}, specs["create"]["new"])
+	assert.NotContains(t, specs["create"], "old")
+
+	assert.Equal(t, []interface{}{"old.example.org"}, specs["update"]["old"].(map[string]interface{})["targets"])
+	assert.Equal(t, []interface{}{"new.example.org"}, specs["update"]["new"].(map[string]interface{})["targets"])
+
+	assert.Equal(t, "gone.example.org", specs["delete"]["record"].(map[string]interface{})["dnsName"])
+	assert.NotContains(t, specs["delete"], "new")
+}
+
+func TestAuditorRecordReasons(t *testing.T) {
+	client := newFakeAuditClient()
+	auditor := NewAuditor(client, "audit", "owner", 0)
+
+	created := endpoint.NewEndpoint("new.example.org", endpoint.RecordTypeA, "1.2.3.4")
+	changes := &plan.Changes{Create: []*endpoint.Endpoint{created}}
+	changes.AddReasons(created, plan.Reason{Code: plan.ReasonNewEndpoint, Detail: "service/default/new"})
+	auditor.Record(context.Background(), changes, nil)
+
+	items := listDNSChanges(t, client)
+	require.Len(t, items, 1)
+	reasons, _, err := unstructured.NestedSlice(items[0].Object, "spec", "reasons")
+	require.NoError(t, err)
+	assert.Equal(t, []interface{}{map[string]interface{}{"code": "NewEndpoint", "detail": "service/default/new"}}, reasons)
+}
+
+func TestAuditorRecordFailure(t *testing.T) {
+	client := newFakeAuditClient()
+	auditor := NewAuditor(client, "audit", "owner", 0)
+
+	auditor.Record(context.Background(), &plan.Changes{
+		Create: []*endpoint.Endpoint{endpoint.NewEndpoint("new.example.org", endpoint.RecordTypeA, "1.2.3.4")},
+	}, errors.New("throttled"))
+
+	items := listDNSChanges(t, client)
+	require.Len(t, items, 1)
+	outcome, _, _ := unstructured.NestedString(items[0].Object, "spec", "outcome")
+	message, _, _ := unstructured.NestedString(items[0].Object, "spec", "message")
+	assert.Equal(t, "Failed", outcome)
+	assert.Equal(t, "throttled", message)
+}
+
+func TestAuditorCleanup(t *testing.T) {
+	client := newFakeAuditClient()
+	auditor := NewAuditor(client, "audit", "owner", time.Hour)
+	changes := &plan.Changes{
+		Create: []*endpoint.Endpoint{endpoint.NewEndpoint("new.example.org", endpoint.RecordTypeA, "1.2.3.4")},
+	}
+	auditor.Record(context.Background(), changes, nil)
+
+	now := time.Now()
+	auditor.Cleanup(context.Background(), now)
+	assert.Len(t, listDNSChanges(t, client), 1)
+
+	auditor.Cleanup(context.Background(), now.Add(2*time.Hour))
+	assert.Empty(t, listDNSChanges(t, client))
+
+	// listing is throttled to a tenth of the TTL
+	auditor.Record(context.Background(), changes, nil)
+	auditor.Cleanup(context.Background(), now.Add(2*time.Hour+time.Minute))
+	assert.Len(t, listDNSChanges(t, client), 1)
+
+	auditor.Cleanup(context.Background(), now.Add(2*time.Hour+10*time.Minute))
+	assert.Empty(t, listDNSChanges(t, client))
+}
+
+func TestRunOnceWithAuditor(t *testing.T) {
+	source := new(testutils.MockSource)
+	source.On("Endpoints").Return([]*endpoint.Endpoint{
+		endpoint.NewEndpoint("a.example.com", endpoint.RecordTypeA, "1.2.3.4"),
+	}, nil)
+	r, err := registry.NewNoopRegistry(&zoneFailingProvider{failingZone: "none"})
+	require.NoError(t, err)
+
+	client := newFakeAuditClient()
+	ctrl := &Controller{
+		Source:             source,
+		Registry:           r,
+		Policy:             &plan.SyncPolicy{},
+		ManagedRecordTypes: []string{endpoint.RecordTypeA},
+		Auditor:            NewAuditor(client, "audit", "owner", 0),
+	}
+
+	require.NoError(t, ctrl.RunOnce(context.Background()))
+	items := listDNSChanges(t, client)
+	require.Len(t, items, 1)
+	dnsName, _, _ := unstructured.NestedString(items[0].Object, "spec", "record", "dnsName")
+	assert.Equal(t, "a.example.com", dnsName)
+}
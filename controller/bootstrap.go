@@ -0,0 +1,129 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"io"
+	"strings"
+
+	"sigs.k8s.io/external-dns/endpoint"
+	"sigs.k8s.io/external-dns/plan"
+	"sigs.k8s.io/external-dns/registry"
+)
+
+// BootstrapCandidates returns the existing records that no owner manages and that a source desires, matching
+// an endpoint by name, type and set identifier, e.g. when enabling ExternalDNS on a zone whose records were
+// created by hand. Each record is labelled with the resource of its endpoint.
+func (c *Controller) BootstrapCandidates(ctx context.Context) ([]*endpoint.Endpoint, error) {
+	p, err := c.calculatePlan(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	type key struct {
+		dnsName       string
+		recordType    string
+		setIdentifier string
+	}
+	keyOf := func(ep *endpoint.Endpoint) key {
+		return key{strings.ToLower(strings.TrimSuffix(ep.DNSName, ".")), ep.RecordType, ep.SetIdentifier}
+	}
+	desired := make(map[key]*endpoint.Endpoint, len(p.Desired))
+	for _, ep := range p.Desired {
+		desired[keyOf(ep)] = ep
+	}
+
+	var candidates []*endpoint.Endpoint
+	for _, r := range p.Current {
+		if r.Labels[endpoint.OwnerLabelKey] != "" || r.RecordType == endpoint.RecordTypeTXT {
+			continue
+		}
+		if !plan.IsManagedRecord(r.RecordType, c.ManagedRecordTypes, c.ExcludeRecordTypes) || !p.DomainFilter.Match(r.DNSName) {
+			continue
+		}
+		ep, ok := desired[keyOf(r)]
+		if !ok {
+			continue
+		}
+		candidate := r.DeepCopy()
+		if candidate.Labels == nil {
+			candidate.Labels = endpoint.Labels{}
+		}
+		if resource := ep.Labels[endpoint.ResourceLabelKey]; resource != "" {
+			candidate.Labels[endpoint.ResourceLabelKey] = resource
+		}
+		candidates = append(candidates, candidate)
+	}
+	sortEndpoints(candidates)
+	return candidates, nil
+}
+
+// Bootstrap seeds the ownership of the given records, as returned by BootstrapCandidates, with the owner of
+// the registry, so that they get managed from the next synchronization on.
+func Bootstrap(ctx context.Context, reg registry.Registry, records []*endpoint.Endpoint) error {
+	transferrer, ok := reg.(registry.OwnershipTransferrer)
+	if !ok {
+		return fmt.Errorf("the registry does not support seeding the ownership of records")
+	}
+	if len(records) == 0 {
+		return nil
+	}
+	return transferrer.TransferOwnership(ctx, records, reg.OwnerID())
+}
+
+// WriteBootstrap prints the records about to be taken over, with the resource desiring them.
+func WriteBootstrap(w io.Writer, records []*endpoint.Endpoint) error {
+	if len(records) == 0 {
+		_, err := fmt.Fprintln(w, "No records to take over")
+		return err
+	}
+	for _, r := range records {
+		recordType := r.RecordType
+		if r.SetIdentifier != "" {
+			recordType += " (" + r.SetIdentifier + ")"
+		}
+		line := fmt.Sprintf("%s %s %s", r.DNSName, recordType, strings.Join(r.Targets, ","))
+		if resource := r.Labels[endpoint.ResourceLabelKey]; resource != "" {
+			line += " < " + resource
+		}
+		if _, err := fmt.Fprintln(w, line); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// ConfirmBootstrap asks on w whether to take over the given number of records, and reads the answer from r.
+// Anything but yes, including the end of r, declines.
+func ConfirmBootstrap(r io.Reader, w io.Writer, count int, ownerID string) (bool, error) {
+	if _, err := fmt.Fprintf(w, "Take over these %d records as owner %q? [y/N] ", count, ownerID); err != nil {
+		return false, err
+	}
+	answer, err := bufio.NewReader(r).ReadString('\n')
+	if err != nil && err != io.EOF {
+		return false, err
+	}
+	switch strings.ToLower(strings.TrimSpace(answer)) {
+	case "y", "yes":
+		return true, nil
+	default:
+		return false, nil
+	}
+}
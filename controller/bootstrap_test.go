@@ -0,0 +1,104 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"bytes"
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"sigs.k8s.io/external-dns/endpoint"
+	"sigs.k8s.io/external-dns/internal/testutils"
+	"sigs.k8s.io/external-dns/plan"
+	"sigs.k8s.io/external-dns/provider/inmemory"
+	"sigs.k8s.io/external-dns/registry"
+)
+
+func TestBootstrap(t *testing.T) {
+	ctx := context.Background()
+	p := inmemory.NewInMemoryProvider(inmemory.InMemoryInitZones([]string{"example.org"}))
+	// records created by hand, and by another owner
+	require.NoError(t, p.ApplyChanges(ctx, &plan.Changes{Create: []*endpoint.Endpoint{
+		endpoint.NewEndpoint("api.example.org", endpoint.RecordTypeA, "1.1.1.1"),
+		endpoint.NewEndpoint("www.example.org", endpoint.RecordTypeA, "1.1.1.1"),
+		endpoint.NewEndpoint("legacy.example.org", endpoint.RecordTypeA, "1.1.1.1"),
+	}}))
+	other, err := registry.NewTXTRegistry(p, "", "", "red", 0, "", []string{endpoint.RecordTypeA}, nil, false, nil, false)
+	require.NoError(t, err)
+	require.NoError(t, other.ApplyChanges(ctx, &plan.Changes{Create: []*endpoint.Endpoint{
+		endpoint.NewEndpoint("red.example.org", endpoint.RecordTypeA, "1.1.1.1"),
+	}}))
+	reg, err := registry.NewTXTRegistry(p, "", "", "blue", 0, "", []string{endpoint.RecordTypeA}, nil, false, nil, false)
+	require.NoError(t, err)
+
+	desired := []*endpoint.Endpoint{
+		endpoint.NewEndpoint("api.example.org", endpoint.RecordTypeA, "2.2.2.2"),
+		endpoint.NewEndpoint("www.example.org", endpoint.RecordTypeA, "1.1.1.1"),
+		endpoint.NewEndpoint("red.example.org", endpoint.RecordTypeA, "1.1.1.1"),
+		endpoint.NewEndpoint("new.example.org", endpoint.RecordTypeA, "1.1.1.1"),
+	}
+	desired[0].Labels[endpoint.ResourceLabelKey] = "service/default/api"
+	source := new(testutils.MockSource)
+	source.On("Endpoints").Return(desired, nil)
+	ctrl := &Controller{
+		Source:             source,
+		Registry:           reg,
+		Policy:             &plan.SyncPolicy{},
+		ManagedRecordTypes: []string{endpoint.RecordTypeA},
+	}
+
+	records, err := ctrl.BootstrapCandidates(ctx)
+	require.NoError(t, err)
+	var out bytes.Buffer
+	require.NoError(t, WriteBootstrap(&out, records))
+	assert.Equal(t, "api.example.org A 1.1.1.1 < service/default/api\nwww.example.org A 1.1.1.1\n", out.String())
+
+	require.NoError(t, Bootstrap(ctx, reg, records))
+	assert.Equal(t, map[string]string{
+		"api.example.org":    "blue",
+		"www.example.org":    "blue",
+		"red.example.org":    "red",
+		"legacy.example.org": "",
+	}, ownersByName(t, reg))
+
+	records, err = ctrl.BootstrapCandidates(ctx)
+	require.NoError(t, err)
+	assert.Empty(t, records)
+	out.Reset()
+	require.NoError(t, WriteBootstrap(&out, records))
+	assert.Equal(t, "No records to take over\n", out.String())
+}
+
+func TestBootstrapUnsupportedRegistry(t *testing.T) {
+	reg, err := registry.NewNoopRegistry(inmemory.NewInMemoryProvider())
+	require.NoError(t, err)
+	assert.EqualError(t, Bootstrap(context.Background(), reg, []*endpoint.Endpoint{endpoint.NewEndpoint("www.example.org", endpoint.RecordTypeA, "1.1.1.1")}), "the registry does not support seeding the ownership of records")
+}
+
+func TestConfirmBootstrap(t *testing.T) {
+	for answer, confirmed := range map[string]bool{"y\n": true, "YES\n": true, "n\n": false, "\n": false, "": false} {
+		var out bytes.Buffer
+		ok, err := ConfirmBootstrap(strings.NewReader(answer), &out, 2, "blue")
+		require.NoError(t, err)
+		assert.Equal(t, confirmed, ok, "answer %q", answer)
+		assert.Equal(t, `Take over these 2 records as owner "blue"? [y/N] `, out.String())
+	}
+}
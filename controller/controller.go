@@ -20,6 +20,9 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"slices"
+	"sort"
+	"strings"
 	"sync"
 	"time"
 
@@ -75,6 +78,54 @@ var (
 			Help:      "Timestamp of last successful sync with the DNS provider",
 		},
 	)
+	cutoverPercent = metrics.NewGaugeWithOpts(
+		prometheus.GaugeOpts{
+			Namespace: "external_dns",
+			Subsystem: "controller",
+			Name:      "cutover_percent",
+			Help:      "Percentage of the traffic taken over from another owner by the ongoing cutover",
+		},
+	)
+	pinnedNames = metrics.NewGaugeWithOpts(
+		prometheus.GaugeOpts{
+			Namespace: "external_dns",
+			Subsystem: "controller",
+			Name:      "pinned_names",
+			Help:      "Number of DNS names whose records are pinned at their current values",
+		},
+	)
+	tombstonedRecords = metrics.NewGaugeWithOpts(
+		prometheus.GaugeOpts{
+			Namespace: "external_dns",
+			Subsystem: "controller",
+			Name:      "tombstoned_records",
+			Help:      "Number of records whose deletion is held back by the deletion delay",
+		},
+	)
+	scheduledChanges = metrics.NewGaugeWithOpts(
+		prometheus.GaugeOpts{
+			Namespace: "external_dns",
+			Subsystem: "controller",
+			Name:      "scheduled_changes",
+			Help:      "Number of scheduled changes whose time did not come yet",
+		},
+	)
+	pendingChanges = metrics.NewGaugeWithOpts(
+		prometheus.GaugeOpts{
+			Namespace: "external_dns",
+			Subsystem: "controller",
+			Name:      "pending_changes",
+			Help:      "Number of changes held back until the write interval elapses or they get approved",
+		},
+	)
+	deadLetterZones = metrics.NewGaugeWithOpts(
+		prometheus.GaugeOpts{
+			Namespace: "external_dns",
+			Subsystem: "controller",
+			Name:      "dead_letter_zones",
+			Help:      "Number of zones whose changes repeatedly failed to apply",
+		},
+	)
 	lastReconcileTimestamp = metrics.NewGaugeWithOpts(
 		prometheus.GaugeOpts{
 			Namespace: "external_dns",
@@ -145,6 +196,14 @@ var (
 			Help:      "Number of DNS A-records that exists both in source and registry.",
 		},
 	)
+	zoneConflictsTotal = metrics.NewCounterWithOpts(
+		prometheus.CounterOpts{
+			Namespace: "external_dns",
+			Subsystem: "controller",
+			Name:      "zone_conflicts_total",
+			Help:      "Number of changes refused by the provider because their zone changed since its records were listed.",
+		},
+	)
 	verifiedAAAARecords = metrics.NewGaugeWithOpts(
 		prometheus.GaugeOpts{
 			Namespace: "external_dns",
@@ -162,6 +221,12 @@ func init() {
 	metrics.RegisterMetric.MustRegister(registryEndpointsTotal)
 	metrics.RegisterMetric.MustRegister(lastSyncTimestamp)
 	metrics.RegisterMetric.MustRegister(lastReconcileTimestamp)
+	metrics.RegisterMetric.MustRegister(deadLetterZones)
+	metrics.RegisterMetric.MustRegister(cutoverPercent)
+	metrics.RegisterMetric.MustRegister(pinnedNames)
+	metrics.RegisterMetric.MustRegister(tombstonedRecords)
+	metrics.RegisterMetric.MustRegister(scheduledChanges)
+	metrics.RegisterMetric.MustRegister(pendingChanges)
 	metrics.RegisterMetric.MustRegister(deprecatedRegistryErrors)
 	metrics.RegisterMetric.MustRegister(deprecatedSourceErrors)
 	metrics.RegisterMetric.MustRegister(controllerNoChangesTotal)
@@ -171,6 +236,7 @@ func init() {
 	metrics.RegisterMetric.MustRegister(sourceAAAARecords)
 	metrics.RegisterMetric.MustRegister(verifiedARecords)
 	metrics.RegisterMetric.MustRegister(verifiedAAAARecords)
+	metrics.RegisterMetric.MustRegister(zoneConflictsTotal)
 }
 
 // Controller is responsible for orchestrating the different components.
@@ -200,34 +266,175 @@ type Controller struct {
 	ExcludeRecordTypes []string
 	// MinEventSyncInterval is used as window for batching events
 	MinEventSyncInterval time.Duration
+	// PropertyComparator, when set, compares the provider specific properties of the records instead of an exact match.
+	PropertyComparator plan.PropertyComparator
+	// PersistsLabels tells whether the provider stores the labels of the records, the records whose resource
+	// changed being updated then.
+	PersistsLabels bool
+	// SupportsViews tells whether the provider selects the DNS view of records, the dns-view property of
+	// desired endpoints being dropped otherwise.
+	SupportsViews bool
+	// TXTEncoding is the encoding of the targets of TXT records taken by the provider, the targets of the
+	// desired TXT endpoints being left as they are when empty.
+	TXTEncoding string
+	// Fallbacks, when set, replace the endpoints the provider cannot create, such as alias endpoints, by
+	// their best alternative.
+	Fallbacks *RecordTypeFallbacks
+	// ZoneApexes are the names of zone apexes, whose NS records are never updated nor deleted, in addition
+	// to the zones of the provider and the ones the plan finds on its own.
+	ZoneApexes []string
+	// ZoneQueue, when set, makes changes apply zone by zone, a zone failing to apply being retried
+	// with its own backoff instead of failing the whole synchronization.
+	ZoneQueue *ZoneQueue
+	// Cutover, when set, progressively takes over the records of another owner.
+	Cutover *Cutover
+	// Scheduler, when set, switches the targets of endpoints to their scheduled targets once their time comes.
+	Scheduler *Scheduler
+	// PreviewDomain, when set, is the domain of the preview environments, whose records are deleted once not
+	// desired anymore whatever the policy.
+	PreviewDomain string
+	// Finalizer, when set, makes the deletion of namespaces or DNSEndpoint objects wait for their records to be deleted.
+	Finalizer *Finalizer
+	// Overrides, when set, merges the overrides declared in a ConfigMap over the desired endpoints.
+	Overrides *Overrides
+	// Pinner, when set, keeps the records of pinned DNS names at their values when they got pinned.
+	Pinner *Pinner
+	// DeletionDelay, when set, holds back the deletion of records that disappeared from the sources
+	// for a number of synchronizations.
+	DeletionDelay *DeletionDelay
+	// Adjusters, when set, adjust the desired endpoints instead of the registry alone.
+	Adjusters AdjusterChain
+	// WriteGate, when set, holds changes back until the write interval elapses or they get approved,
+	// while sources and the provider keep being read at every interval.
+	WriteGate *WriteGate
+	// Auditor, when set, records the applied changes as DNSChange objects.
+	Auditor *Auditor
+	// RecordExporter, when set, publishes a metric series per managed record.
+	RecordExporter *RecordExporter
+	// ZoneCollisions, when set, records Events on the resources of the endpoints whose hostname matched
+	// a zone name configured under several zone IDs.
+	ZoneCollisions *ZoneCollisionEvents
+	// Ownership, when set, keeps the records read from the registry to serve the ownership report.
+	Ownership *OwnershipReporter
+	// Health, when set, is kept up to date with the registry availability, and stops the control loop
+	// from applying changes once in lameduck.
+	Health *Health
+	// ObservedVersions, when set, records the version of the source resources incorporated by
+	// every successful synchronization.
+	ObservedVersions *ObservedVersions
+	// Sidecar, when set, runs the webhook provider as a child process: synchronizations are skipped
+	// while it is not ready.
+	Sidecar *SidecarSupervisor
 }
 
 // RunOnce runs a single iteration of a reconciliation loop.
 func (c *Controller) RunOnce(ctx context.Context) error {
 	lastReconcileTimestamp.Gauge.SetToCurrentTime()
 
+	if c.Sidecar != nil {
+		if err := c.Sidecar.Ready(); err != nil {
+			return provider.NewSoftError(err)
+		}
+	}
+
 	c.runAtMutex.Lock()
 	c.lastRunAt = time.Now()
 	c.runAtMutex.Unlock()
 
+	plan, err := c.calculatePlan(ctx)
+	if err != nil {
+		return err
+	}
+	ctx = context.WithValue(ctx, provider.RecordsContextKey, plan.Current)
+	if c.ZoneCollisions != nil {
+		// the zones of the hostnames are found while the records are read and the changes applied
+		defer func() { c.ZoneCollisions.Record(plan.Desired, plan.Changes.Delete) }()
+	}
+	if c.DeletionDelay != nil {
+		plan.Changes = c.DeletionDelay.Apply(plan.Changes)
+	}
+
+	if c.WriteGate != nil {
+		if !c.WriteGate.Allow(ctx, plan.Changes, time.Now()) {
+			pending, _ := c.WriteGate.Pending()
+			pendingChanges.Gauge.Set(float64(len(pending.Changes)))
+			return nil
+		}
+		pendingChanges.Gauge.Set(0)
+	}
+
+	if c.ZoneQueue != nil {
+		if err := c.applyByZone(ctx, plan.Changes); err != nil {
+			return err
+		}
+	} else if plan.Changes.HasChanges() {
+		err = c.applyChanges(ctx, plan.Changes)
+		if err != nil {
+			registryErrorsTotal.Counter.Inc()
+			deprecatedRegistryErrors.Counter.Inc()
+			if errors.Is(err, provider.ErrZoneChanged) {
+				// another actor changed the zone: the changes are planned again shortly from its current records
+				zoneConflictsTotal.Counter.Inc()
+				log.Warnf("Planning the changes again, a zone changed in the meantime: %v", err)
+				c.ScheduleRunOnce(time.Now())
+				return provider.NewSoftError(err)
+			}
+			return err
+		}
+	} else {
+		controllerNoChangesTotal.Counter.Inc()
+		log.Info("All records are already up to date")
+	}
+
+	if c.WriteGate != nil && plan.Changes.HasChanges() {
+		c.WriteGate.Written(ctx, time.Now())
+	}
+
+	lastSyncTimestamp.Gauge.SetToCurrentTime()
+	if c.ObservedVersions != nil {
+		c.ObservedVersions.Synced(plan.Desired, time.Now())
+	}
+	if c.Finalizer != nil {
+		c.Finalizer.Sync(ctx, plan.Desired, plan.Current)
+	}
+
+	if c.Auditor != nil {
+		c.Auditor.Cleanup(ctx, time.Now())
+	}
+
+	return nil
+}
+
+// calculatePlan reads the registry and the sources, and calculates the plan moving the records
+// of the former towards the endpoints of the latter.
+func (c *Controller) calculatePlan(ctx context.Context) (*plan.Plan, error) {
 	records, err := c.Registry.Records(ctx)
+	if c.Health != nil {
+		c.Health.SetRegistryResult(err)
+	}
 	if err != nil {
 		registryErrorsTotal.Counter.Inc()
 		deprecatedRegistryErrors.Counter.Inc()
-		return err
+		return nil, err
 	}
 
 	registryEndpointsTotal.Gauge.Set(float64(len(records)))
 	regARecords, regAAAARecords := countAddressRecords(records)
 	registryARecords.Gauge.Set(float64(regARecords))
 	registryAAAARecords.Gauge.Set(float64(regAAAARecords))
+	if c.RecordExporter != nil {
+		c.RecordExporter.Export(records)
+	}
+	if c.Ownership != nil {
+		c.Ownership.Update(records)
+	}
 	ctx = context.WithValue(ctx, provider.RecordsContextKey, records)
 
 	endpoints, err := c.Source.Endpoints(ctx)
 	if err != nil {
 		sourceErrorsTotal.Counter.Inc()
 		deprecatedSourceErrors.Counter.Inc()
-		return err
+		return nil, err
 	}
 	sourceEndpointsTotal.Gauge.Set(float64(len(endpoints)))
 	srcARecords, srcAAAARecords := countAddressRecords(endpoints)
@@ -236,39 +443,210 @@ func (c *Controller) RunOnce(ctx context.Context) error {
 	vARecords, vAAAARecords := countMatchingAddressRecords(endpoints, records)
 	verifiedARecords.Gauge.Set(float64(vARecords))
 	verifiedAAAARecords.Gauge.Set(float64(vAAAARecords))
-	endpoints, err = c.Registry.AdjustEndpoints(endpoints)
+	var adoptedOwnerIDs []string
+	if c.Cutover != nil {
+		percent := c.Cutover.Percent(time.Now())
+		cutoverPercent.Gauge.Set(float64(percent))
+		c.Cutover.AdjustEndpoints(endpoints, records, percent)
+		adoptedOwnerIDs = c.Cutover.AdoptedOwnerIDs(percent)
+		if adopter, ok := c.Registry.(registry.OwnerAdopter); ok {
+			adopter.AdoptOwners(adoptedOwnerIDs)
+		} else if len(adoptedOwnerIDs) > 0 {
+			log.Warnf("The registry cannot take over the records of owner %q", c.Cutover.FromOwnerID)
+			adoptedOwnerIDs = nil
+		}
+	}
+	if c.Finalizer != nil {
+		if err := c.Finalizer.Refresh(ctx); err != nil {
+			return nil, err
+		}
+		endpoints = c.Finalizer.Apply(endpoints)
+	}
+	if c.Scheduler != nil {
+		var next time.Time
+		endpoints, next = c.Scheduler.Apply(endpoints, time.Now())
+		if !next.IsZero() {
+			c.runAtMutex.Lock()
+			c.nextRunAt = earliest(c.nextRunAt, next)
+			c.runAtMutex.Unlock()
+		}
+	}
+	if c.Overrides != nil {
+		endpoints, err = c.Overrides.Apply(ctx, endpoints)
+		if err != nil {
+			return nil, err
+		}
+	}
+	if c.Pinner != nil {
+		endpoints = c.Pinner.Apply(endpoints, records, c.Registry.OwnerID())
+	}
+	if !c.SupportsViews {
+		dropDNSViews(endpoints)
+	}
+	if c.TXTEncoding != "" {
+		encodeTXTTargets(endpoints, c.TXTEncoding)
+	}
+	if c.Fallbacks != nil {
+		endpoints = c.Fallbacks.Apply(ctx, endpoints, records)
+	}
+	if c.Adjusters != nil {
+		endpoints, err = c.Adjusters.Adjust(ctx, endpoints)
+	} else {
+		endpoints, err = c.Registry.AdjustEndpoints(endpoints)
+	}
 	if err != nil {
-		return fmt.Errorf("adjusting endpoints: %w", err)
+		return nil, fmt.Errorf("adjusting endpoints: %w", err)
 	}
 	registryFilter := c.Registry.GetDomainFilter()
 
 	plan := &plan.Plan{
-		Policies:       []plan.Policy{c.Policy},
-		Current:        records,
-		Desired:        endpoints,
-		DomainFilter:   endpoint.MatchAllDomainFilters{c.DomainFilter, registryFilter},
-		ManagedRecords: c.ManagedRecordTypes,
-		ExcludeRecords: c.ExcludeRecordTypes,
-		OwnerID:        c.Registry.OwnerID(),
+		Policies:           []plan.Policy{c.Policy},
+		Current:            records,
+		Desired:            endpoints,
+		DomainFilter:       endpoint.MatchAllDomainFilters{c.DomainFilter, registryFilter},
+		ManagedRecords:     c.ManagedRecordTypes,
+		ExcludeRecords:     c.ExcludeRecordTypes,
+		OwnerID:            c.Registry.OwnerID(),
+		AdoptedOwnerIDs:    adoptedOwnerIDs,
+		PersistsLabels:     c.PersistsLabels,
+		PropertyComparator: c.PropertyComparator,
+		ZoneApexes:         slices.Concat(c.ZoneApexes, providerZones(registryFilter)),
 	}
 
-	plan = plan.Calculate()
+	calculated := plan.Calculate()
+	if c.PreviewDomain != "" {
+		collectPreviewRecords(plan, calculated.Changes, c.PreviewDomain)
+	}
+	if c.Finalizer != nil {
+		c.Finalizer.AddDeletions(plan, calculated.Changes)
+	}
+	return calculated, nil
+}
 
-	if plan.Changes.HasChanges() {
-		err = c.Registry.ApplyChanges(ctx, plan.Changes)
-		if err != nil {
+// providerZones returns the names of the zones of the provider, as told by the domain filter it returns.
+// Providers not listing their zones return none.
+func providerZones(filter endpoint.DomainFilterInterface) []string {
+	var filters []string
+	switch f := filter.(type) {
+	case endpoint.DomainFilter:
+		filters = f.Filters
+	case *endpoint.DomainFilter:
+		if f != nil {
+			filters = f.Filters
+		}
+	}
+	zones := make([]string, 0, len(filters))
+	for _, zone := range filters {
+		// AWS returns the zones prefixed by a dot as well, to match their subdomains only
+		if zone = strings.TrimPrefix(zone, "."); zone != "" {
+			zones = append(zones, zone)
+		}
+	}
+	return zones
+}
+
+// applyChanges applies changes through the registry, recording them when auditing is enabled.
+func (c *Controller) applyChanges(ctx context.Context, changes *plan.Changes) error {
+	logChangeReasons(changes)
+	err := c.Registry.ApplyChanges(ctx, changes)
+	if c.Auditor != nil {
+		c.Auditor.Record(ctx, changes, err)
+	}
+	return err
+}
+
+// logChangeReasons logs the reasons of each of the changes.
+func logChangeReasons(changes *plan.Changes) {
+	logReasons := func(action string, endpoints []*endpoint.Endpoint) {
+		for _, ep := range endpoints {
+			if reasons := changes.ReasonsOf(ep); len(reasons) > 0 {
+				log.Infof("Planned %s of %s record %q: %s", action, ep.RecordType, ep.DNSName, plan.JoinReasons(reasons))
+			}
+		}
+	}
+	logReasons("creation", changes.Create)
+	logReasons("update", changes.UpdateNew)
+	logReasons("deletion", changes.Delete)
+}
+
+// applyByZone applies changes zone by zone. Zones that are backing off after a failure are skipped,
+// and a run is scheduled for when the first of them may be retried.
+func (c *Controller) applyByZone(ctx context.Context, changes *plan.Changes) error {
+	perZone := c.ZoneQueue.Split(changes)
+	zones := make([]string, 0, len(perZone))
+	for zone := range perZone {
+		zones = append(zones, zone)
+	}
+	sort.Strings(zones)
+
+	var failed []string
+	applied := false
+	for _, zone := range zones {
+		zoneChanges := perZone[zone]
+		if !zoneChanges.HasChanges() {
+			c.ZoneQueue.Succeeded(zone)
+			continue
+		}
+		if ready, retryAt := c.ZoneQueue.Ready(zone, time.Now()); !ready {
+			log.Infof("Skipping changes to zone %q, which is backing off until %s", zone, retryAt.Format(time.RFC3339))
+			failed = append(failed, zone)
+			continue
+		}
+		if err := c.applyChanges(ctx, zoneChanges); err != nil {
 			registryErrorsTotal.Counter.Inc()
 			deprecatedRegistryErrors.Counter.Inc()
-			return err
+			retryAt := c.ZoneQueue.Failed(zone, err, time.Now())
+			log.Errorf("Failed to apply changes to zone %q, retrying after %s: %v", zone, retryAt.Format(time.RFC3339), err)
+			failed = append(failed, zone)
+			continue
 		}
-	} else {
+		c.ZoneQueue.Succeeded(zone)
+		applied = true
+	}
+	deadLetterZones.Gauge.Set(float64(len(c.ZoneQueue.DeadLetters())))
+
+	if len(failed) > 0 {
+		if retryAt, ok := c.ZoneQueue.NextRetry(); ok {
+			c.runAtMutex.Lock()
+			c.nextRunAt = earliest(c.nextRunAt, retryAt)
+			c.runAtMutex.Unlock()
+		}
+		return provider.NewSoftErrorf("changes to zones %s were not applied", strings.Join(failed, ", "))
+	}
+	if !applied {
 		controllerNoChangesTotal.Counter.Inc()
 		log.Info("All records are already up to date")
 	}
+	return nil
+}
 
-	lastSyncTimestamp.Gauge.SetToCurrentTime()
+// dropDNSViews removes the dns-view property of endpoints, which the provider would not honor: its records
+// are read without view and would never match.
+func dropDNSViews(endpoints []*endpoint.Endpoint) {
+	for _, ep := range endpoints {
+		if view := ep.DNSView(); view != "" {
+			log.Warnf("Ignoring DNS view %q of endpoint %s: the provider does not support views", view, ep)
+			ep.DeleteProviderSpecificProperty(endpoint.DNSViewProperty)
+		}
+	}
+}
 
-	return nil
+// encodeTXTTargets encodes the targets of the TXT endpoints as the provider takes them: quoted and split into
+// character strings of at most 255 bytes, or as values without quotes.
+func encodeTXTTargets(endpoints []*endpoint.Endpoint, encoding string) {
+	for _, ep := range endpoints {
+		if ep.RecordType != endpoint.RecordTypeTXT {
+			continue
+		}
+		for i, target := range ep.Targets {
+			txt := endpoint.NewTXTTarget(target)
+			if encoding == provider.TXTEncodingQuoted {
+				ep.Targets[i] = txt.String()
+			} else {
+				ep.Targets[i] = string(txt)
+			}
+		}
+	}
 }
 
 func earliest(r time.Time, times ...time.Time) time.Time {
@@ -357,7 +735,9 @@ func (c *Controller) Run(ctx context.Context) {
 	ticker := time.NewTicker(time.Second)
 	defer ticker.Stop()
 	for {
-		if c.ShouldRunOnce(time.Now()) {
+		if c.Health != nil && c.Health.Lameduck() {
+			log.Debug("Skipping synchronization while shutting down")
+		} else if c.ShouldRunOnce(time.Now()) {
 			if err := c.RunOnce(ctx); err != nil {
 				if errors.Is(err, provider.SoftError) {
 					log.Errorf("Failed to do run once: %v", err)
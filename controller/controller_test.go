@@ -19,13 +19,17 @@ package controller
 import (
 	"context"
 	"errors"
+	"fmt"
 	"math"
 	"reflect"
+	"regexp"
 	"sort"
+	"strings"
 	"testing"
 	"time"
 
 	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
 
 	"sigs.k8s.io/external-dns/endpoint"
 	"sigs.k8s.io/external-dns/internal/testutils"
@@ -238,6 +242,38 @@ func TestRunOnce(t *testing.T) {
 	assert.Equal(t, math.Float64bits(1), valueFromMetric(verifiedAAAARecords.Gauge))
 }
 
+// zoneChangedProvider refuses the changes, their zone having changed since its records were listed.
+type zoneChangedProvider struct {
+	mockProvider
+}
+
+func (p *zoneChangedProvider) ApplyChanges(_ context.Context, _ *plan.Changes) error {
+	return fmt.Errorf("zone example.org: %w", provider.ErrZoneChanged)
+}
+
+func TestRunOnceZoneChanged(t *testing.T) {
+	source := getTestSource()
+	r, err := registry.NewNoopRegistry(&zoneChangedProvider{mockProvider: *getTestProvider().(*mockProvider)})
+	require.NoError(t, err)
+
+	ctrl := &Controller{
+		Source:             source,
+		Registry:           r,
+		Policy:             &plan.SyncPolicy{},
+		ManagedRecordTypes: getTestConfig().ManagedDNSRecordTypes,
+	}
+	now := time.Now()
+	ctrl.nextRunAt = now.Add(time.Hour)
+	conflicts := testutil.ToFloat64(zoneConflictsTotal.Counter)
+
+	// the changes are planned again shortly, without failing the controller
+	err = ctrl.RunOnce(context.Background())
+	require.ErrorIs(t, err, provider.ErrZoneChanged)
+	require.ErrorIs(t, err, provider.SoftError)
+	assert.InDelta(t, conflicts+1, testutil.ToFloat64(zoneConflictsTotal.Counter), 0)
+	assert.True(t, ctrl.nextRunAt.Before(now.Add(time.Minute)), "next run at %s", ctrl.nextRunAt)
+}
+
 // TestRun tests that Run correctly starts and stops
 func TestRun(t *testing.T) {
 	source := getTestSource()
@@ -362,8 +398,44 @@ func testControllerFiltersDomains(t *testing.T, configuredEndpoints []*endpoint.
 	assert.Equal(t, 1, provider.RecordsCallCount)
 	require.Len(t, provider.ApplyChangesCalls, len(expectedChanges))
 	for i, change := range expectedChanges {
-		assert.Equal(t, *change, *provider.ApplyChangesCalls[i])
+		applied := *provider.ApplyChangesCalls[i]
+		applied.Reasons = nil
+		assert.Equal(t, *change, applied)
+	}
+}
+
+func TestControllerProtectsProviderZoneApexes(t *testing.T) {
+	source := new(testutils.MockSource)
+	source.On("Endpoints").Return([]*endpoint.Endpoint{}, nil)
+	provider := &filteredMockProvider{
+		domainFilter: endpoint.NewDomainFilter([]string{"dev.example.org", ".dev.example.org"}),
+		RecordsStore: []*endpoint.Endpoint{
+			endpoint.NewEndpoint("dev.example.org", endpoint.RecordTypeNS, "ns1.example.net"),
+			endpoint.NewEndpoint("team.dev.example.org", endpoint.RecordTypeNS, "ns.team.example.net"),
+		},
 	}
+	r, err := registry.NewNoopRegistry(provider)
+	require.NoError(t, err)
+
+	// no zone apex is configured, the NS records at the apex of a zone of the provider are kept anyway
+	ctrl := &Controller{
+		Source:             source,
+		Registry:           r,
+		Policy:             &plan.SyncPolicy{},
+		DomainFilter:       endpoint.DomainFilter{},
+		ManagedRecordTypes: []string{endpoint.RecordTypeNS},
+	}
+	require.NoError(t, ctrl.RunOnce(context.Background()))
+	require.Len(t, provider.ApplyChangesCalls, 1)
+	assert.Equal(t, []*endpoint.Endpoint{provider.RecordsStore[1]}, provider.ApplyChangesCalls[0].Delete)
+}
+
+func TestProviderZones(t *testing.T) {
+	assert.Equal(t, []string{"example.org", "example.org"}, providerZones(endpoint.NewDomainFilter([]string{"example.org", ".example.org"})))
+	filter := endpoint.NewDomainFilter([]string{"example.com"})
+	assert.Equal(t, []string{"example.com"}, providerZones(&filter))
+	assert.Empty(t, providerZones(endpoint.NewRegexDomainFilter(regexp.MustCompile(`example\.com$`), nil)))
+	assert.Empty(t, providerZones(nil))
 }
 
 func TestControllerSkipsEmptyChanges(t *testing.T) {
@@ -739,3 +811,30 @@ func TestAAAARecords(t *testing.T) {
 	assert.Equal(t, math.Float64bits(2), valueFromMetric(sourceAAAARecords.Gauge))
 	assert.Equal(t, math.Float64bits(1), valueFromMetric(registryAAAARecords.Gauge))
 }
+
+func TestDropDNSViews(t *testing.T) {
+	endpoints := []*endpoint.Endpoint{
+		endpoint.NewEndpoint("app.example.com", endpoint.RecordTypeA, "10.0.0.1").WithProviderSpecific(endpoint.DNSViewProperty, "internal").WithProviderSpecific("weight", "10"),
+		endpoint.NewEndpoint("other.example.com", endpoint.RecordTypeA, "10.0.0.1"),
+	}
+
+	dropDNSViews(endpoints)
+
+	assert.Equal(t, endpoint.ProviderSpecific{{Name: "weight", Value: "10"}}, endpoints[0].ProviderSpecific)
+	assert.Empty(t, endpoints[1].ProviderSpecific)
+}
+
+func TestEncodeTXTTargets(t *testing.T) {
+	long := strings.Repeat("k", 300)
+	endpoints := []*endpoint.Endpoint{
+		endpoint.NewEndpoint("dkim.example.com", endpoint.RecordTypeTXT, long, `"v=spf1 -all"`),
+		endpoint.NewEndpoint("app.example.com", endpoint.RecordTypeCNAME, "lb.example.com"),
+	}
+
+	encodeTXTTargets(endpoints, provider.TXTEncodingQuoted)
+	assert.Equal(t, endpoint.Targets{`"` + long[:255] + `" "` + long[255:] + `"`, `"v=spf1 -all"`}, endpoints[0].Targets)
+	assert.Equal(t, endpoint.Targets{"lb.example.com"}, endpoints[1].Targets)
+
+	encodeTXTTargets(endpoints, provider.TXTEncodingRaw)
+	assert.Equal(t, endpoint.Targets{long, "v=spf1 -all"}, endpoints[0].Targets)
+}
@@ -0,0 +1,171 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+
+	"sigs.k8s.io/external-dns/endpoint"
+)
+
+// CutoverStep is a step of a cutover schedule: Percent of the traffic goes to this owner once
+// After has elapsed since the start of the cutover.
+type CutoverStep struct {
+	After   time.Duration
+	Percent int
+}
+
+// ParseCutoverSchedule parses a cutover schedule such as "0s=10,15m=50,1h=100", a comma separated
+// list of durations since the start of the cutover and the percentage of traffic to take over then.
+func ParseCutoverSchedule(schedule string) ([]CutoverStep, error) {
+	steps := []CutoverStep{}
+	for step := range strings.SplitSeq(schedule, ",") {
+		after, percent, ok := strings.Cut(strings.TrimSpace(step), "=")
+		if !ok {
+			return nil, fmt.Errorf("invalid cutover step %q: expected <duration>=<percent>", step)
+		}
+		d, err := time.ParseDuration(after)
+		if err != nil || d < 0 {
+			return nil, fmt.Errorf("invalid cutover step %q: invalid duration %q", step, after)
+		}
+		p, err := strconv.Atoi(percent)
+		if err != nil || p < 0 || p > 100 {
+			return nil, fmt.Errorf("invalid cutover step %q: percent must be between 0 and 100", step)
+		}
+		steps = append(steps, CutoverStep{After: d, Percent: p})
+	}
+	sort.SliceStable(steps, func(i, j int) bool { return steps[i].After < steps[j].After })
+	if steps[len(steps)-1].Percent != 100 {
+		return nil, fmt.Errorf("invalid cutover schedule %q: the last step must take over 100 percent", schedule)
+	}
+	return steps, nil
+}
+
+// Cutover progressively moves the traffic of the names served by another owner, typically the
+// ExternalDNS of another cluster, to this owner following a schedule.
+//
+// While both owners serve a name, and when the provider supports weighted records, the records
+// of this owner are published next to the weighted records of the other owner, with a weight
+// giving them the scheduled share of the traffic. Once the schedule reaches 100 percent, the
+// records of the other owner for the names this owner desires are taken over.
+type Cutover struct {
+	// FromOwnerID is the owner the records are taken over from.
+	FromOwnerID string
+	// Start is the time the cutover starts.
+	Start time.Time
+	// Schedule is sorted by increasing durations.
+	Schedule []CutoverStep
+	// SetIdentifier identifies the weighted records of this owner.
+	SetIdentifier string
+	// WeightProperty is the provider specific property holding the weight of records,
+	// empty when the provider does not support weighted records.
+	WeightProperty string
+	// MaxWeight is the highest weight the provider accepts.
+	MaxWeight int
+}
+
+// Percent returns the percentage of the traffic this owner takes over at now.
+func (c *Cutover) Percent(now time.Time) int {
+	percent := 0
+	elapsed := now.Sub(c.Start)
+	for _, step := range c.Schedule {
+		if elapsed < step.After {
+			break
+		}
+		percent = step.Percent
+	}
+	return percent
+}
+
+// AdoptedOwnerIDs returns the owners whose records are taken over at the given percentage.
+func (c *Cutover) AdoptedOwnerIDs(percent int) []string {
+	if percent < 100 {
+		return nil
+	}
+	return []string{c.FromOwnerID}
+}
+
+// AdjustEndpoints makes desired endpoints served by both owners weighted, with a weight giving
+// them percent of the traffic, and keeps the endpoints that were weighted weighted.
+func (c *Cutover) AdjustEndpoints(desired, current []*endpoint.Endpoint, percent int) {
+	if c.WeightProperty == "" {
+		return
+	}
+
+	type key struct{ dnsName, recordType string }
+	fromWeights := map[key]int{}
+	ownWeights := map[key]string{}
+	for _, ep := range current {
+		if ep.SetIdentifier == "" {
+			continue
+		}
+		weight, ok := ep.GetProviderSpecificProperty(c.WeightProperty)
+		if !ok {
+			continue
+		}
+		k := key{ep.DNSName, ep.RecordType}
+		switch {
+		case ep.Labels[endpoint.OwnerLabelKey] == c.FromOwnerID:
+			w, err := strconv.Atoi(weight)
+			if err != nil {
+				log.Warnf("Ignoring invalid weight %q of %s record %q owned by %q", weight, ep.RecordType, ep.DNSName, c.FromOwnerID)
+				continue
+			}
+			fromWeights[k] += w
+		case ep.SetIdentifier == c.SetIdentifier:
+			ownWeights[k] = weight
+		}
+	}
+
+	for _, ep := range desired {
+		if ep.SetIdentifier != "" {
+			continue
+		}
+		k := key{ep.DNSName, ep.RecordType}
+		if fromWeight, ok := fromWeights[k]; ok {
+			ep.SetIdentifier = c.SetIdentifier
+			ep.SetProviderSpecificProperty(c.WeightProperty, strconv.Itoa(c.weight(ep, fromWeight, percent)))
+		} else if weight, ok := ownWeights[k]; ok {
+			// the other owner records are gone, keep the record as it is rather than recreating it unweighted
+			ep.SetIdentifier = c.SetIdentifier
+			ep.SetProviderSpecificProperty(c.WeightProperty, weight)
+		}
+	}
+}
+
+// weight returns the weight giving percent of the traffic next to records weighing fromWeight.
+func (c *Cutover) weight(ep *endpoint.Endpoint, fromWeight, percent int) int {
+	switch {
+	case percent <= 0:
+		return 0
+	case percent >= 100 || fromWeight == 0:
+		return c.MaxWeight
+	}
+	weight := int(math.Round(float64(fromWeight) * float64(percent) / float64(100-percent)))
+	if weight > c.MaxWeight {
+		log.Warnf("Weight of %s record %q capped to %d, taking over less than %d percent of the traffic: lower the weights of owner %q", ep.RecordType, ep.DNSName, c.MaxWeight, percent, c.FromOwnerID)
+		return c.MaxWeight
+	}
+	return weight
+}
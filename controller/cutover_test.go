@@ -0,0 +1,225 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"sigs.k8s.io/external-dns/endpoint"
+	"sigs.k8s.io/external-dns/internal/testutils"
+	"sigs.k8s.io/external-dns/plan"
+	"sigs.k8s.io/external-dns/provider/inmemory"
+	"sigs.k8s.io/external-dns/registry"
+)
+
+func TestParseCutoverSchedule(t *testing.T) {
+	steps, err := ParseCutoverSchedule("1h=100, 0s=10,15m=50")
+	require.NoError(t, err)
+	assert.Equal(t, []CutoverStep{
+		{After: 0, Percent: 10},
+		{After: 15 * time.Minute, Percent: 50},
+		{After: time.Hour, Percent: 100},
+	}, steps)
+
+	for _, schedule := range []string{"", "10", "1x=10", "-1m=10", "0s=101", "0s=-1", "0s=ten", "0s=10,1h=50"} {
+		_, err := ParseCutoverSchedule(schedule)
+		assert.Error(t, err, schedule)
+	}
+}
+
+func TestCutoverPercent(t *testing.T) {
+	start := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	c := &Cutover{
+		Start:    start,
+		Schedule: []CutoverStep{{After: 0, Percent: 10}, {After: 15 * time.Minute, Percent: 50}, {After: time.Hour, Percent: 100}},
+	}
+
+	assert.Equal(t, 0, c.Percent(start.Add(-time.Second)))
+	assert.Equal(t, 10, c.Percent(start))
+	assert.Equal(t, 10, c.Percent(start.Add(14*time.Minute)))
+	assert.Equal(t, 50, c.Percent(start.Add(15*time.Minute)))
+	assert.Equal(t, 100, c.Percent(start.Add(2*time.Hour)))
+
+	assert.Empty(t, c.AdoptedOwnerIDs(50))
+}
+
+func TestCutoverAdjustEndpoints(t *testing.T) {
+	c := &Cutover{FromOwnerID: "blue", SetIdentifier: "green", WeightProperty: "aws/weight", MaxWeight: 255}
+
+	blue := func(name string, weight string) *endpoint.Endpoint {
+		ep := endpoint.NewEndpoint(name, endpoint.RecordTypeA, "1.1.1.1").WithSetIdentifier("blue").WithProviderSpecific("aws/weight", weight)
+		ep.Labels[endpoint.OwnerLabelKey] = "blue"
+		return ep
+	}
+	current := []*endpoint.Endpoint{
+		blue("weighted.example.com", "100"),
+		blue("split.example.com", "30"),
+		blue("split.example.com", "70"),
+		blue("light.example.com", "1"),
+		endpoint.NewEndpoint("plain.example.com", endpoint.RecordTypeA, "1.1.1.1"),
+		endpoint.NewEndpoint("done.example.com", endpoint.RecordTypeA, "2.2.2.2").WithSetIdentifier("green").WithProviderSpecific("aws/weight", "42"),
+	}
+
+	for _, tt := range []struct {
+		percent  int
+		expected map[string]string
+	}{
+		{percent: 0, expected: map[string]string{"weighted.example.com": "0", "split.example.com": "0", "light.example.com": "0", "done.example.com": "42"}},
+		{percent: 25, expected: map[string]string{"weighted.example.com": "33", "split.example.com": "33", "light.example.com": "0", "done.example.com": "42"}},
+		{percent: 90, expected: map[string]string{"weighted.example.com": "255", "split.example.com": "255", "light.example.com": "9", "done.example.com": "42"}},
+		{percent: 100, expected: map[string]string{"weighted.example.com": "255", "split.example.com": "255", "light.example.com": "255", "done.example.com": "42"}},
+	} {
+		desired := []*endpoint.Endpoint{
+			endpoint.NewEndpoint("weighted.example.com", endpoint.RecordTypeA, "2.2.2.2"),
+			endpoint.NewEndpoint("split.example.com", endpoint.RecordTypeA, "2.2.2.2"),
+			endpoint.NewEndpoint("light.example.com", endpoint.RecordTypeA, "2.2.2.2"),
+			endpoint.NewEndpoint("plain.example.com", endpoint.RecordTypeA, "2.2.2.2"),
+			endpoint.NewEndpoint("done.example.com", endpoint.RecordTypeA, "2.2.2.2"),
+			endpoint.NewEndpoint("custom.example.com", endpoint.RecordTypeA, "2.2.2.2").WithSetIdentifier("custom"),
+		}
+		c.AdjustEndpoints(desired, current, tt.percent)
+
+		weights := map[string]string{}
+		for _, ep := range desired {
+			if weight, ok := ep.GetProviderSpecificProperty("aws/weight"); ok {
+				assert.Equal(t, "green", ep.SetIdentifier)
+				weights[ep.DNSName] = weight
+			}
+		}
+		assert.Equal(t, tt.expected, weights, "percent %d", tt.percent)
+		assert.Empty(t, desired[3].SetIdentifier)
+		assert.Equal(t, "custom", desired[5].SetIdentifier)
+	}
+}
+
+// cutoverController returns a controller owning records as owner in p, desiring endpoints.
+func cutoverController(t *testing.T, p *inmemory.InMemoryProvider, owner string, endpoints []*endpoint.Endpoint) *Controller {
+	t.Helper()
+	source := new(testutils.MockSource)
+	source.On("Endpoints").Return(endpoints, nil)
+	r, err := registry.NewTXTRegistry(p, "", "", owner, 0, "", []string{endpoint.RecordTypeA}, nil, false, nil, false)
+	require.NoError(t, err)
+	return &Controller{
+		Source:             source,
+		Registry:           r,
+		Policy:             &plan.SyncPolicy{},
+		ManagedRecordTypes: []string{endpoint.RecordTypeA},
+	}
+}
+
+// cutoverRecords returns the A records of p, as seen by a TXT registry, keyed by name and set identifier.
+func cutoverRecords(t *testing.T, p *inmemory.InMemoryProvider) map[string]*endpoint.Endpoint {
+	t.Helper()
+	r, err := registry.NewTXTRegistry(p, "", "", "observer", 0, "", []string{endpoint.RecordTypeA}, nil, false, nil, false)
+	require.NoError(t, err)
+	records, err := r.Records(context.Background())
+	require.NoError(t, err)
+	result := map[string]*endpoint.Endpoint{}
+	for _, ep := range records {
+		if ep.RecordType == endpoint.RecordTypeA {
+			result[ep.DNSName+"/"+ep.SetIdentifier] = ep
+		}
+	}
+	return result
+}
+
+func TestRunOnceCutoverWeighted(t *testing.T) {
+	ctx := context.Background()
+	p := inmemory.NewInMemoryProvider(inmemory.InMemoryInitZones([]string{"example.com"}))
+
+	blue := cutoverController(t, p, "blue", []*endpoint.Endpoint{
+		endpoint.NewEndpoint("app.example.com", endpoint.RecordTypeA, "1.1.1.1").WithSetIdentifier("blue").WithProviderSpecific("aws/weight", "100"),
+		endpoint.NewEndpoint("other.example.com", endpoint.RecordTypeA, "1.1.1.1"),
+	})
+	require.NoError(t, blue.RunOnce(ctx))
+
+	green := cutoverController(t, p, "green", []*endpoint.Endpoint{
+		endpoint.NewEndpoint("app.example.com", endpoint.RecordTypeA, "2.2.2.2"),
+	})
+	green.Cutover = &Cutover{
+		FromOwnerID:    "blue",
+		Start:          time.Now(),
+		Schedule:       []CutoverStep{{After: 0, Percent: 25}, {After: time.Hour, Percent: 100}},
+		SetIdentifier:  "green",
+		WeightProperty: "aws/weight",
+		MaxWeight:      255,
+	}
+
+	// both owners serve the name, green getting 25 percent of the traffic
+	require.NoError(t, green.RunOnce(ctx))
+	records := cutoverRecords(t, p)
+	require.Len(t, records, 3)
+	assert.Equal(t, "blue", records["app.example.com/blue"].Labels[endpoint.OwnerLabelKey])
+	assert.Equal(t, "green", records["app.example.com/green"].Labels[endpoint.OwnerLabelKey])
+	weight, _ := records["app.example.com/green"].GetProviderSpecificProperty("aws/weight")
+	assert.Equal(t, "33", weight)
+
+	// once the schedule reaches 100 percent, green takes over the name only
+	green.Cutover.Start = time.Now().Add(-2 * time.Hour)
+	require.NoError(t, green.RunOnce(ctx))
+	records = cutoverRecords(t, p)
+	require.Len(t, records, 2)
+	assert.Contains(t, records, "app.example.com/green")
+	assert.Equal(t, "blue", records["other.example.com/"].Labels[endpoint.OwnerLabelKey])
+
+	// the record stays weighted afterwards
+	require.NoError(t, green.RunOnce(ctx))
+	assert.Equal(t, records, cutoverRecords(t, p))
+}
+
+func TestRunOnceCutoverUnweighted(t *testing.T) {
+	ctx := context.Background()
+	p := inmemory.NewInMemoryProvider(inmemory.InMemoryInitZones([]string{"example.com"}))
+
+	blue := cutoverController(t, p, "blue", []*endpoint.Endpoint{
+		endpoint.NewEndpoint("app.example.com", endpoint.RecordTypeA, "1.1.1.1"),
+		endpoint.NewEndpoint("same.example.com", endpoint.RecordTypeA, "1.1.1.1"),
+		endpoint.NewEndpoint("other.example.com", endpoint.RecordTypeA, "1.1.1.1"),
+	})
+	require.NoError(t, blue.RunOnce(ctx))
+
+	green := cutoverController(t, p, "green", []*endpoint.Endpoint{
+		endpoint.NewEndpoint("app.example.com", endpoint.RecordTypeA, "2.2.2.2"),
+		endpoint.NewEndpoint("same.example.com", endpoint.RecordTypeA, "1.1.1.1"),
+	})
+	green.Cutover = &Cutover{
+		FromOwnerID: "blue",
+		Start:       time.Now(),
+		Schedule:    []CutoverStep{{After: 0, Percent: 50}, {After: time.Hour, Percent: 100}},
+	}
+
+	// without weighted records, nothing changes before the schedule reaches 100 percent
+	require.NoError(t, green.RunOnce(ctx))
+	records := cutoverRecords(t, p)
+	assert.Equal(t, "blue", records["app.example.com/"].Labels[endpoint.OwnerLabelKey])
+	assert.Equal(t, endpoint.Targets{"1.1.1.1"}, records["app.example.com/"].Targets)
+
+	green.Cutover.Start = time.Now().Add(-2 * time.Hour)
+	require.NoError(t, green.RunOnce(ctx))
+	records = cutoverRecords(t, p)
+	require.Len(t, records, 3)
+	assert.Equal(t, "green", records["app.example.com/"].Labels[endpoint.OwnerLabelKey])
+	assert.Equal(t, endpoint.Targets{"2.2.2.2"}, records["app.example.com/"].Targets)
+	// ownership moves even when the record does not change
+	assert.Equal(t, "green", records["same.example.com/"].Labels[endpoint.OwnerLabelKey])
+	assert.Equal(t, "blue", records["other.example.com/"].Labels[endpoint.OwnerLabelKey])
+}
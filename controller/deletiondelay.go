@@ -0,0 +1,78 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"strings"
+
+	log "github.com/sirupsen/logrus"
+
+	"sigs.k8s.io/external-dns/endpoint"
+	"sigs.k8s.io/external-dns/plan"
+)
+
+// DeletionDelay holds back the deletion of records that disappeared from the sources for a number
+// of synchronizations, so that a source transiently returning fewer endpoints, such as an informer
+// being resynchronized or the API server having a hiccup, does not remove records at once.
+//
+// Records whose deletion is held back are tombstoned in memory: they are deleted once their
+// deletion got planned by enough consecutive synchronizations, and forgotten as soon as they are
+// desired again. After a restart, deletions are held back again from scratch.
+type DeletionDelay struct {
+	cycles     int
+	tombstones map[string]int
+}
+
+// NewDeletionDelay returns a DeletionDelay deleting records after their deletion got planned by
+// the given number of consecutive synchronizations on top of the first one.
+func NewDeletionDelay(cycles int) *DeletionDelay {
+	return &DeletionDelay{
+		cycles:     cycles,
+		tombstones: map[string]int{},
+	}
+}
+
+func tombstoneKey(ep *endpoint.Endpoint) string {
+	return strings.ToLower(strings.TrimSuffix(ep.DNSName, ".")) + "/" + ep.RecordType + "/" + ep.SetIdentifier
+}
+
+// Apply removes from changes the deletions that are still held back, and returns them.
+func (d *DeletionDelay) Apply(changes *plan.Changes) *plan.Changes {
+	tombstones := make(map[string]int, len(changes.Delete))
+	deletions := make([]*endpoint.Endpoint, 0, len(changes.Delete))
+	for _, ep := range changes.Delete {
+		key := tombstoneKey(ep)
+		seen := d.tombstones[key]
+		if seen >= d.cycles {
+			deletions = append(deletions, ep)
+			continue
+		}
+		if seen == 0 {
+			log.Infof("Holding back the deletion of %s record %s for %d synchronizations", ep.RecordType, ep.DNSName, d.cycles)
+		}
+		tombstones[key] = seen + 1
+	}
+	for key := range d.tombstones {
+		if _, ok := tombstones[key]; !ok {
+			log.Debugf("Forgetting the tombstone of %s", key)
+		}
+	}
+	d.tombstones = tombstones
+	tombstonedRecords.Gauge.Set(float64(len(tombstones)))
+	changes.Delete = deletions
+	return changes
+}
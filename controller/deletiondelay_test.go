@@ -0,0 +1,111 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"sigs.k8s.io/external-dns/endpoint"
+	"sigs.k8s.io/external-dns/internal/testutils"
+	"sigs.k8s.io/external-dns/plan"
+	"sigs.k8s.io/external-dns/provider/inmemory"
+	"sigs.k8s.io/external-dns/registry"
+)
+
+func TestDeletionDelayApply(t *testing.T) {
+	app := endpoint.NewEndpoint("app.example.com", endpoint.RecordTypeA, "1.1.1.1")
+	api := endpoint.NewEndpoint("api.example.com", endpoint.RecordTypeA, "1.1.1.1")
+	created := endpoint.NewEndpoint("new.example.com", endpoint.RecordTypeA, "1.1.1.1")
+	deletions := func(changes *plan.Changes) []string {
+		names := []string{}
+		for _, ep := range changes.Delete {
+			names = append(names, ep.DNSName)
+		}
+		return names
+	}
+
+	d := NewDeletionDelay(2)
+	changes := d.Apply(&plan.Changes{Create: []*endpoint.Endpoint{created}, Delete: []*endpoint.Endpoint{app}})
+	assert.Empty(t, deletions(changes))
+	assert.Equal(t, []*endpoint.Endpoint{created}, changes.Create, "other changes are not held back")
+
+	assert.Empty(t, deletions(d.Apply(&plan.Changes{Delete: []*endpoint.Endpoint{app, api}})))
+	assert.Equal(t, []string{"app.example.com"}, deletions(d.Apply(&plan.Changes{Delete: []*endpoint.Endpoint{app, api}})))
+
+	// a record desired again is forgotten, its deletion being held back from scratch afterwards
+	assert.Empty(t, deletions(d.Apply(&plan.Changes{})))
+	assert.Empty(t, deletions(d.Apply(&plan.Changes{Delete: []*endpoint.Endpoint{api}})))
+	assert.Empty(t, deletions(d.Apply(&plan.Changes{Delete: []*endpoint.Endpoint{api}})))
+	assert.Equal(t, []string{"api.example.com"}, deletions(d.Apply(&plan.Changes{Delete: []*endpoint.Endpoint{api}})))
+
+	// records of the same name and type with distinct set identifiers are held back on their own
+	d = NewDeletionDelay(1)
+	weighted := endpoint.NewEndpoint("app.example.com", endpoint.RecordTypeA, "2.2.2.2").WithSetIdentifier("b")
+	assert.Empty(t, deletions(d.Apply(&plan.Changes{Delete: []*endpoint.Endpoint{app}})))
+	assert.Equal(t, []string{"app.example.com"}, deletions(d.Apply(&plan.Changes{Delete: []*endpoint.Endpoint{app, weighted}})))
+}
+
+func TestRunOnceDeletionDelay(t *testing.T) {
+	ctx := context.Background()
+	p := inmemory.NewInMemoryProvider(inmemory.InMemoryInitZones([]string{"example.com"}))
+	r, err := registry.NewTXTRegistry(p, "", "", "me", 0, "", []string{endpoint.RecordTypeA}, nil, false, nil, false)
+	require.NoError(t, err)
+
+	source := new(testutils.MockSource)
+	ctrl := &Controller{
+		Source:             source,
+		Registry:           r,
+		Policy:             &plan.SyncPolicy{},
+		ManagedRecordTypes: []string{endpoint.RecordTypeA},
+		DeletionDelay:      NewDeletionDelay(2),
+	}
+	exists := func() bool {
+		t.Helper()
+		records, err := p.Records(ctx)
+		require.NoError(t, err)
+		for _, ep := range records {
+			if ep.RecordType == endpoint.RecordTypeA {
+				return true
+			}
+		}
+		return false
+	}
+
+	source.On("Endpoints").Return([]*endpoint.Endpoint{endpoint.NewEndpoint("app.example.com", endpoint.RecordTypeA, "1.1.1.1")}, nil).Once()
+	require.NoError(t, ctrl.RunOnce(ctx))
+	require.True(t, exists())
+
+	// a record missing from the sources for a single synchronization is kept
+	source.On("Endpoints").Return([]*endpoint.Endpoint{}, nil).Once()
+	require.NoError(t, ctrl.RunOnce(ctx))
+	assert.True(t, exists())
+	source.On("Endpoints").Return([]*endpoint.Endpoint{endpoint.NewEndpoint("app.example.com", endpoint.RecordTypeA, "1.1.1.1")}, nil).Once()
+	require.NoError(t, ctrl.RunOnce(ctx))
+	assert.True(t, exists())
+
+	// and deleted once missing for long enough
+	source.On("Endpoints").Return([]*endpoint.Endpoint{}, nil).Times(3)
+	require.NoError(t, ctrl.RunOnce(ctx))
+	require.NoError(t, ctrl.RunOnce(ctx))
+	assert.True(t, exists())
+	require.NoError(t, ctrl.RunOnce(ctx))
+	assert.False(t, exists())
+}
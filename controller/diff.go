@@ -0,0 +1,248 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strings"
+
+	"golang.org/x/net/publicsuffix"
+
+	"sigs.k8s.io/external-dns/endpoint"
+	"sigs.k8s.io/external-dns/plan"
+	"sigs.k8s.io/external-dns/provider"
+)
+
+const (
+	colorReset  = "\x1b[0m"
+	colorBold   = "\x1b[1m"
+	colorRed    = "\x1b[31m"
+	colorGreen  = "\x1b[32m"
+	colorYellow = "\x1b[33m"
+)
+
+// ZoneDiff holds the changes a synchronization would make to the records of a zone.
+type ZoneDiff struct {
+	Zone   string               `json:"zone"`
+	Create []*endpoint.Endpoint `json:"create,omitempty"`
+	Update []RecordUpdate       `json:"update,omitempty"`
+	Delete []*endpoint.Endpoint `json:"delete,omitempty"`
+	// Reasons are the reasons of the changes, in the order of the changes.
+	Reasons []RecordReasons `json:"reasons,omitempty"`
+}
+
+// RecordReasons holds the reasons of the change of a record.
+type RecordReasons struct {
+	Action        string        `json:"action"`
+	DNSName       string        `json:"dnsName"`
+	RecordType    string        `json:"recordType"`
+	SetIdentifier string        `json:"setIdentifier,omitempty"`
+	Reasons       []plan.Reason `json:"reasons"`
+}
+
+// RecordUpdate holds a record before and after being updated.
+type RecordUpdate struct {
+	Old *endpoint.Endpoint `json:"old"`
+	New *endpoint.Endpoint `json:"new"`
+}
+
+// Diff reads the registry and the sources, and returns the changes a synchronization would make
+// to the records, grouped by zone. Deletions are not held back by the deletion delay, and nothing
+// is applied.
+func (c *Controller) Diff(ctx context.Context, zones []string) ([]ZoneDiff, error) {
+	plan, err := c.calculatePlan(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return NewZoneDiffs(plan.Changes, zones), nil
+}
+
+// NewZoneDiffs groups changes by zone, the zones being the given domain filters. Changes to names
+// outside of all of them are grouped under their registrable domain. Zones and records are sorted
+// by name.
+func NewZoneDiffs(changes *plan.Changes, zones []string) []ZoneDiff {
+	zoneIDName := newZoneIDName(zones)
+	perZone := map[string]*ZoneDiff{}
+	zoneDiff := func(ep *endpoint.Endpoint) *ZoneDiff {
+		zone := recordZone(zoneIDName, ep.DNSName)
+		if _, ok := perZone[zone]; !ok {
+			perZone[zone] = &ZoneDiff{Zone: zone}
+		}
+		return perZone[zone]
+	}
+	for _, ep := range changes.Create {
+		d := zoneDiff(ep)
+		d.Create = append(d.Create, ep)
+	}
+	for i, ep := range changes.UpdateNew {
+		d := zoneDiff(ep)
+		d.Update = append(d.Update, RecordUpdate{Old: changes.UpdateOld[i], New: ep})
+	}
+	for _, ep := range changes.Delete {
+		d := zoneDiff(ep)
+		d.Delete = append(d.Delete, ep)
+	}
+
+	diffs := make([]ZoneDiff, 0, len(perZone))
+	for _, d := range perZone {
+		sortEndpoints(d.Create)
+		sort.SliceStable(d.Update, func(i, j int) bool { return endpointLess(d.Update[i].New, d.Update[j].New) })
+		sortEndpoints(d.Delete)
+		d.Reasons = zoneReasons(changes, d)
+		diffs = append(diffs, *d)
+	}
+	sort.Slice(diffs, func(i, j int) bool { return diffs[i].Zone < diffs[j].Zone })
+	return diffs
+}
+
+// zoneReasons returns the reasons of the changes of d.
+func zoneReasons(changes *plan.Changes, d *ZoneDiff) []RecordReasons {
+	var reasons []RecordReasons
+	add := func(action string, ep *endpoint.Endpoint) {
+		if r := changes.ReasonsOf(ep); len(r) > 0 {
+			reasons = append(reasons, RecordReasons{
+				Action:        action,
+				DNSName:       ep.DNSName,
+				RecordType:    ep.RecordType,
+				SetIdentifier: ep.SetIdentifier,
+				Reasons:       r,
+			})
+		}
+	}
+	for _, ep := range d.Create {
+		add("create", ep)
+	}
+	for _, u := range d.Update {
+		add("update", u.New)
+	}
+	for _, ep := range d.Delete {
+		add("delete", ep)
+	}
+	return reasons
+}
+
+// recordZone returns the zone, among the given ones, of a DNS name, else its registrable domain.
+func recordZone(zoneIDName provider.ZoneIDName, dnsName string) string {
+	if _, zone := zoneIDName.FindZone(dnsName); zone != "" {
+		return zone
+	}
+	name := strings.ToLower(strings.TrimSuffix(dnsName, "."))
+	if zone, _ := publicsuffix.EffectiveTLDPlusOne(name); zone != "" {
+		return zone
+	}
+	return name
+}
+
+func sortEndpoints(endpoints []*endpoint.Endpoint) {
+	sort.SliceStable(endpoints, func(i, j int) bool { return endpointLess(endpoints[i], endpoints[j]) })
+}
+
+func endpointLess(a, b *endpoint.Endpoint) bool {
+	if a.DNSName != b.DNSName {
+		return a.DNSName < b.DNSName
+	}
+	if a.RecordType != b.RecordType {
+		return a.RecordType < b.RecordType
+	}
+	return a.SetIdentifier < b.SetIdentifier
+}
+
+// WriteDiff prints the changes of every zone as text, colorized when color is set.
+func WriteDiff(w io.Writer, diffs []ZoneDiff, color bool) error {
+	paint := func(code, s string) string {
+		if !color {
+			return s
+		}
+		return code + s + colorReset
+	}
+	if len(diffs) == 0 {
+		_, err := fmt.Fprintln(w, "No changes")
+		return err
+	}
+	for _, d := range diffs {
+		if _, err := fmt.Fprintf(w, "%s: %d to create, %d to update, %d to delete\n", paint(colorBold, d.Zone), len(d.Create), len(d.Update), len(d.Delete)); err != nil {
+			return err
+		}
+		lines := make([]string, 0, len(d.Create)+len(d.Update)+len(d.Delete))
+		for _, ep := range d.Create {
+			lines = append(lines, paint(colorGreen, "  + "+recordName(ep)+" "+recordValue(ep)))
+		}
+		for _, u := range d.Update {
+			lines = append(lines, paint(colorYellow, "  ~ "+recordName(u.New)+" "+recordValue(u.Old)+" => "+recordValue(u.New)))
+		}
+		for _, ep := range d.Delete {
+			lines = append(lines, paint(colorRed, "  - "+recordName(ep)+" "+recordValue(ep)))
+		}
+		for _, line := range lines {
+			if _, err := fmt.Fprintln(w, line); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// WriteDiffJSON prints the changes of every zone as JSON.
+func WriteDiffJSON(w io.Writer, diffs []ZoneDiff) error {
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(struct {
+		Zones []ZoneDiff `json:"zones"`
+	}{Zones: diffs})
+}
+
+// DiffColor returns whether the diff gets colorized for the given --diff-color mode, auto
+// colorizing it when the standard output is a terminal.
+func DiffColor(mode string) bool {
+	switch mode {
+	case "always":
+		return true
+	case "never":
+		return false
+	}
+	info, err := os.Stdout.Stat()
+	return err == nil && info.Mode()&os.ModeCharDevice != 0
+}
+
+func recordName(ep *endpoint.Endpoint) string {
+	if ep.SetIdentifier != "" {
+		return fmt.Sprintf("%s (%s)", ep.DNSName, ep.SetIdentifier)
+	}
+	return ep.DNSName
+}
+
+func recordValue(ep *endpoint.Endpoint) string {
+	parts := make([]string, 0, 4)
+	if ep.RecordTTL.IsConfigured() {
+		parts = append(parts, fmt.Sprintf("%d", ep.RecordTTL))
+	}
+	parts = append(parts, ep.RecordType, strings.Join(ep.Targets, ","))
+	if len(ep.ProviderSpecific) > 0 {
+		properties := make([]string, 0, len(ep.ProviderSpecific))
+		for _, p := range ep.ProviderSpecific {
+			properties = append(properties, p.Name+"="+p.Value)
+		}
+		sort.Strings(properties)
+		parts = append(parts, "["+strings.Join(properties, " ")+"]")
+	}
+	return strings.Join(parts, " ")
+}
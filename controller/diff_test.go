@@ -0,0 +1,172 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"sigs.k8s.io/external-dns/endpoint"
+	"sigs.k8s.io/external-dns/internal/testutils"
+	"sigs.k8s.io/external-dns/plan"
+	"sigs.k8s.io/external-dns/provider/inmemory"
+	"sigs.k8s.io/external-dns/registry"
+)
+
+func testDiffChanges() *plan.Changes {
+	return &plan.Changes{
+		Create: []*endpoint.Endpoint{
+			endpoint.NewEndpointWithTTL("new.example.com", endpoint.RecordTypeA, 300, "1.1.1.1"),
+			endpoint.NewEndpoint("app.example.co.uk", endpoint.RecordTypeCNAME, "lb.example.net"),
+			endpoint.NewEndpoint("api.dev.example.org", endpoint.RecordTypeA, "2.2.2.2").WithSetIdentifier("eu"),
+		},
+		UpdateOld: []*endpoint.Endpoint{endpoint.NewEndpointWithTTL("www.example.com", endpoint.RecordTypeA, 300, "1.1.1.1")},
+		UpdateNew: []*endpoint.Endpoint{
+			endpoint.NewEndpointWithTTL("www.example.com", endpoint.RecordTypeA, 60, "2.2.2.2").WithProviderSpecific("alias", "false"),
+		},
+		Delete: []*endpoint.Endpoint{endpoint.NewEndpoint("old.example.com", endpoint.RecordTypeAAAA, "2001:db8::1")},
+	}
+}
+
+func TestNewZoneDiffs(t *testing.T) {
+	diffs := NewZoneDiffs(testDiffChanges(), []string{"dev.example.org", ".example.com"})
+
+	require.Len(t, diffs, 3)
+	assert.Equal(t, "dev.example.org", diffs[0].Zone)
+	assert.Equal(t, "example.co.uk", diffs[1].Zone, "names outside of the domain filters are grouped by registrable domain")
+	assert.Equal(t, "example.com", diffs[2].Zone)
+	assert.Len(t, diffs[2].Create, 1)
+	require.Len(t, diffs[2].Update, 1)
+	assert.Equal(t, endpoint.Targets{"1.1.1.1"}, diffs[2].Update[0].Old.Targets)
+	assert.Equal(t, endpoint.Targets{"2.2.2.2"}, diffs[2].Update[0].New.Targets)
+	assert.Len(t, diffs[2].Delete, 1)
+
+	assert.Empty(t, NewZoneDiffs(&plan.Changes{}, nil))
+}
+
+func TestWriteDiff(t *testing.T) {
+	diffs := NewZoneDiffs(testDiffChanges(), []string{"example.com"})
+
+	var b bytes.Buffer
+	require.NoError(t, WriteDiff(&b, diffs, false))
+	assert.Equal(t, `example.co.uk: 1 to create, 0 to update, 0 to delete
+  + app.example.co.uk CNAME lb.example.net
+example.com: 1 to create, 1 to update, 1 to delete
+  + new.example.com 300 A 1.1.1.1
+  ~ www.example.com 300 A 1.1.1.1 => 60 A 2.2.2.2 [alias=false]
+  - old.example.com AAAA 2001:db8::1
+example.org: 1 to create, 0 to update, 0 to delete
+  + api.dev.example.org (eu) A 2.2.2.2
+`, b.String())
+
+	b.Reset()
+	require.NoError(t, WriteDiff(&b, diffs[1:2], true))
+	assert.Equal(t, "\x1b[1mexample.com\x1b[0m: 1 to create, 1 to update, 1 to delete\n"+
+		"\x1b[32m  + new.example.com 300 A 1.1.1.1\x1b[0m\n"+
+		"\x1b[33m  ~ www.example.com 300 A 1.1.1.1 => 60 A 2.2.2.2 [alias=false]\x1b[0m\n"+
+		"\x1b[31m  - old.example.com AAAA 2001:db8::1\x1b[0m\n", b.String())
+
+	b.Reset()
+	require.NoError(t, WriteDiff(&b, nil, true))
+	assert.Equal(t, "No changes\n", b.String())
+}
+
+func TestWriteDiffJSON(t *testing.T) {
+	var b bytes.Buffer
+	require.NoError(t, WriteDiffJSON(&b, NewZoneDiffs(testDiffChanges(), []string{"example.com"})))
+
+	var decoded struct {
+		Zones []ZoneDiff `json:"zones"`
+	}
+	require.NoError(t, json.Unmarshal(b.Bytes(), &decoded))
+	require.Len(t, decoded.Zones, 3)
+	assert.Equal(t, "example.com", decoded.Zones[1].Zone)
+	assert.Equal(t, "www.example.com", decoded.Zones[1].Update[0].New.DNSName)
+	assert.Equal(t, endpoint.TTL(60), decoded.Zones[1].Update[0].New.RecordTTL)
+	assert.Equal(t, "old.example.com", decoded.Zones[1].Delete[0].DNSName)
+	assert.Nil(t, decoded.Zones[0].Update)
+	assert.Nil(t, decoded.Zones[0].Reasons)
+}
+
+func TestWriteDiffJSONReasons(t *testing.T) {
+	changes := testDiffChanges()
+	changes.AddReasons(changes.UpdateNew[0],
+		plan.Reason{Code: plan.ReasonTTLChanged, Detail: "300 -> 60"},
+		plan.Reason{Code: plan.ReasonPropertyChanged, Detail: "alias"})
+	changes.AddReasons(changes.Create[2], plan.Reason{Code: plan.ReasonNewEndpoint, Detail: "ingress/default/api"})
+
+	var b bytes.Buffer
+	require.NoError(t, WriteDiffJSON(&b, NewZoneDiffs(changes, []string{"example.com"})))
+
+	var decoded struct {
+		Zones []ZoneDiff `json:"zones"`
+	}
+	require.NoError(t, json.Unmarshal(b.Bytes(), &decoded))
+	require.Len(t, decoded.Zones, 3)
+	assert.Nil(t, decoded.Zones[0].Reasons)
+	assert.Equal(t, []RecordReasons{{
+		Action:     "update",
+		DNSName:    "www.example.com",
+		RecordType: endpoint.RecordTypeA,
+		Reasons:    []plan.Reason{{Code: "TTLChanged", Detail: "300 -> 60"}, {Code: "PropertyChanged", Detail: "alias"}},
+	}}, decoded.Zones[1].Reasons)
+	assert.Equal(t, []RecordReasons{{
+		Action:        "create",
+		DNSName:       "api.dev.example.org",
+		RecordType:    endpoint.RecordTypeA,
+		SetIdentifier: "eu",
+		Reasons:       []plan.Reason{{Code: "NewEndpoint", Detail: "ingress/default/api"}},
+	}}, decoded.Zones[2].Reasons)
+}
+
+func TestDiffColor(t *testing.T) {
+	assert.True(t, DiffColor("always"))
+	assert.False(t, DiffColor("never"))
+}
+
+func TestControllerDiff(t *testing.T) {
+	ctx := context.Background()
+	p := inmemory.NewInMemoryProvider(inmemory.InMemoryInitZones([]string{"example.com"}))
+	r, err := registry.NewTXTRegistry(p, "", "", "me", 0, "", []string{endpoint.RecordTypeA}, nil, false, nil, false)
+	require.NoError(t, err)
+
+	source := new(testutils.MockSource)
+	source.On("Endpoints").Return([]*endpoint.Endpoint{endpoint.NewEndpoint("app.example.com", endpoint.RecordTypeA, "1.1.1.1")}, nil)
+	ctrl := &Controller{
+		Source:             source,
+		Registry:           r,
+		Policy:             &plan.SyncPolicy{},
+		ManagedRecordTypes: []string{endpoint.RecordTypeA},
+	}
+
+	diffs, err := ctrl.Diff(ctx, []string{"example.com"})
+	require.NoError(t, err)
+	require.Len(t, diffs, 1)
+	assert.Equal(t, "example.com", diffs[0].Zone)
+	require.Len(t, diffs[0].Create, 1)
+	assert.Equal(t, "app.example.com", diffs[0].Create[0].DNSName)
+
+	// nothing gets applied
+	records, err := p.Records(ctx)
+	require.NoError(t, err)
+	assert.Empty(t, records)
+}
@@ -22,6 +22,7 @@ import (
 	"net/http"
 	"os"
 	"os/signal"
+	"strings"
 	"syscall"
 	"time"
 
@@ -31,12 +32,18 @@ import (
 	"github.com/go-logr/logr"
 	"github.com/prometheus/client_golang/prometheus/promhttp"
 	log "github.com/sirupsen/logrus"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/tools/record"
 	"k8s.io/klog/v2"
 
 	"sigs.k8s.io/external-dns/endpoint"
 	"sigs.k8s.io/external-dns/pkg/apis/externaldns"
 	"sigs.k8s.io/external-dns/pkg/apis/externaldns/validation"
+	"sigs.k8s.io/external-dns/pkg/featuregate"
+	extdnshttp "sigs.k8s.io/external-dns/pkg/http"
 	"sigs.k8s.io/external-dns/pkg/metrics"
+	"sigs.k8s.io/external-dns/pkg/resolver"
+	"sigs.k8s.io/external-dns/pkg/secrets"
 	"sigs.k8s.io/external-dns/plan"
 	"sigs.k8s.io/external-dns/provider"
 	"sigs.k8s.io/external-dns/provider/akamai"
@@ -46,6 +53,7 @@ import (
 	"sigs.k8s.io/external-dns/provider/azure"
 	"sigs.k8s.io/external-dns/provider/civo"
 	"sigs.k8s.io/external-dns/provider/cloudflare"
+	"sigs.k8s.io/external-dns/provider/constellix"
 	"sigs.k8s.io/external-dns/provider/coredns"
 	"sigs.k8s.io/external-dns/provider/digitalocean"
 	"sigs.k8s.io/external-dns/provider/dnsimple"
@@ -53,15 +61,21 @@ import (
 	"sigs.k8s.io/external-dns/provider/gandi"
 	"sigs.k8s.io/external-dns/provider/godaddy"
 	"sigs.k8s.io/external-dns/provider/google"
+	"sigs.k8s.io/external-dns/provider/hurricaneelectric"
 	"sigs.k8s.io/external-dns/provider/ibmcloud"
 	"sigs.k8s.io/external-dns/provider/inmemory"
+	"sigs.k8s.io/external-dns/provider/ionoscloud"
 	"sigs.k8s.io/external-dns/provider/linode"
+	providermetrics "sigs.k8s.io/external-dns/provider/metrics"
+	"sigs.k8s.io/external-dns/provider/mythicbeasts"
+	"sigs.k8s.io/external-dns/provider/njalla"
 	"sigs.k8s.io/external-dns/provider/ns1"
 	"sigs.k8s.io/external-dns/provider/oci"
 	"sigs.k8s.io/external-dns/provider/ovh"
 	"sigs.k8s.io/external-dns/provider/pdns"
 	"sigs.k8s.io/external-dns/provider/pihole"
 	"sigs.k8s.io/external-dns/provider/plural"
+	"sigs.k8s.io/external-dns/provider/rest"
 	"sigs.k8s.io/external-dns/provider/rfc2136"
 	"sigs.k8s.io/external-dns/provider/scaleway"
 	"sigs.k8s.io/external-dns/provider/tencentcloud"
@@ -69,6 +83,7 @@ import (
 	"sigs.k8s.io/external-dns/provider/ultradns"
 	"sigs.k8s.io/external-dns/provider/webhook"
 	webhookapi "sigs.k8s.io/external-dns/provider/webhook/api"
+	"sigs.k8s.io/external-dns/provider/yandex"
 	"sigs.k8s.io/external-dns/registry"
 	"sigs.k8s.io/external-dns/source"
 )
@@ -85,10 +100,23 @@ func Execute() {
 
 	configureLogger(cfg)
 
+	if err := featuregate.Default.Set(cfg.FeatureGates); err != nil {
+		log.Fatalf("feature gates: %v", err)
+	}
+	if enabled := featuregate.Default.EnabledFeatures(); len(enabled) > 0 {
+		log.Infof("Enabled features: %s", strings.Join(enabled, ", "))
+	}
+
 	if cfg.DryRun {
 		log.Info("running in dry-run mode. No changes to DNS records will be made.")
 	}
 
+	if cfg.ShardCount > 1 {
+		// the shards must not claim the records of each other
+		cfg.TXTOwnerID = fmt.Sprintf("%s-shard-%d", cfg.TXTOwnerID, cfg.ShardIndex)
+		log.Infof("Managing the DNS names of shard %d of %d, as owner %s", cfg.ShardIndex, cfg.ShardCount, cfg.TXTOwnerID)
+	}
+
 	if log.GetLevel() < log.DebugLevel {
 		// Klog V2 is used by k8s.io/apimachinery/pkg/labels and can throw (a lot) of irrelevant logs
 		// See https://github.com/kubernetes-sigs/external-dns/issues/2348
@@ -100,14 +128,20 @@ func Execute() {
 
 	ctx, cancel := context.WithCancel(context.Background())
 
-	go serveMetrics(cfg.MetricsAddress)
-	go handleSigterm(cancel)
+	health := NewHealth()
+	if cfg.Command == "run" {
+		// the other commands may run next to a controller serving on the same address
+		go serveMetrics(cfg.MetricsAddress, health)
+	}
+	go handleSigterm(cancel, health, cfg.LameduckDuration)
 
 	// Create a source.Config from the flags passed by the user.
+	observedVersions := NewObservedVersions()
 	sourceCfg := source.NewSourceConfig(cfg)
+	sourceCfg.AppliedVersions = observedVersions
 
 	// Lookup all the selected sources by names and pass them the desired configuration.
-	sources, err := source.ByNames(ctx, &source.SingletonClientGenerator{
+	clientGenerator := &source.SingletonClientGenerator{
 		KubeConfig:   cfg.KubeConfig,
 		APIServerURL: cfg.APIServerURL,
 		// If update events are enabled, disable timeout.
@@ -117,26 +151,351 @@ func Execute() {
 			}
 			return cfg.RequestTimeout
 		}(),
-	}, cfg.Sources, sourceCfg)
+	}
+	sources, err := source.ByNames(ctx, clientGenerator, cfg.Sources, sourceCfg)
 	if err != nil {
 		log.Fatal(err)
 	}
+	// Sources wait for their informers caches to sync when created.
+	health.SetSourcesSynced()
 
 	// Filter targets
 	targetFilter := endpoint.NewTargetNetFilterWithExclusions(cfg.TargetNetFilter, cfg.ExcludeTargetNets)
 
+	if cfg.SourceFailurePolicy == "skip" && cfg.Policy == "sync" {
+		log.Warn("sources failing to return their endpoints are skipped: with the sync policy, their records get deleted until they recover")
+	}
+
+	domainRewrites, err := source.ParseDomainRewrites(cfg.DomainRewrites)
+	if err != nil {
+		log.Fatal(err)
+	}
+
 	// Combine multiple sources into a single, deduplicated source.
-	endpointsSource := source.NewDedupSource(source.NewMultiSource(sources, sourceCfg.DefaultTargets))
+	// Names are rewritten beforehand, as rewriting can make endpoints of different sources identical.
+	endpointsSource := source.NewDomainRewriteSource(source.NewMultiSource(sources, sourceCfg.DefaultTargets, cfg.SourceTimeout, cfg.SourceFailurePolicy == "skip"), domainRewrites)
+	if cfg.PreviewNamespaceLabel != "" {
+		kubeClient, err := clientGenerator.KubeClient()
+		if err != nil {
+			log.Fatal(err)
+		}
+		endpointsSource = source.NewPreviewSource(endpointsSource, kubeClient, cfg.PreviewNamespaceLabel, cfg.PreviewDomain, cfg.PreviewMaxLifetime)
+	}
+	endpointsSource = source.NewDedupSource(endpointsSource)
+	if cfg.ChaosFlapDomain != "" {
+		log.Warnf("Adding %d synthetic records flapping under %s on every synchronization, for testing only", cfg.ChaosFlapRate, cfg.ChaosFlapDomain)
+		endpointsSource = source.NewChaosSource(endpointsSource, cfg.ChaosFlapDomain, cfg.ChaosFlapRate)
+	}
 	endpointsSource = source.NewNAT64Source(endpointsSource, cfg.NAT64Networks)
 	endpointsSource = source.NewTargetFilterSource(endpointsSource, targetFilter)
+	if cfg.ValidateHostnames {
+		var recorder record.EventRecorder
+		if kubeClient, err := clientGenerator.KubeClient(); err != nil {
+			log.Warnf("Not recording Events of the rejected DNS names: %v", err)
+		} else {
+			recorder = source.NewEventRecorder(kubeClient)
+		}
+		endpointsSource = source.NewHostnameValidationSource(endpointsSource, recorder)
+	}
+	if cfg.ShardCount > 1 {
+		endpointsSource = source.NewShardSource(endpointsSource, cfg.ShardIndex, cfg.ShardCount)
+	}
 
 	domainFilter := createDomainFilter(cfg)
+
+	var sidecar *SidecarSupervisor
+	if cfg.WebhookSidecarCommand != "" {
+		healthURL := cfg.WebhookSidecarHealthURL
+		if healthURL == "" {
+			healthURL = cfg.WebhookProviderURL
+		}
+		sidecar = NewSidecarSupervisor(cfg.WebhookSidecarCommand, cfg.WebhookSidecarArgs, healthURL, cfg.WebhookSidecarBackoff, cfg.WebhookSidecarMaxBackoff)
+		sidecar.Start(ctx)
+		health.AddCheck(sidecar.Ready)
+		// the webhook provider negotiates with the sidecar when it gets built
+		if err := sidecar.WaitReady(ctx, cfg.WebhookSidecarStartupTimeout); err != nil {
+			log.Fatal(err)
+		}
+	}
+
+	p, err := BuildProvider(ctx, cfg, domainFilter, endpointsSource)
+	if err != nil {
+		log.Fatal(err)
+	}
+	var zoneCollisions *provider.ZoneCollisions
+	if _, ok := p.(provider.ZoneCollisionsSetter); ok {
+		zoneCollisions = provider.NewZoneCollisions(provider.ZoneCollisionPolicy(cfg.ZoneCollisionPolicy))
+		provider.SetZoneCollisions(p, zoneCollisions)
+	}
+
+	if cfg.WebhookServer {
+		webhookapi.StartHTTPApi(p, nil, cfg.WebhookProviderReadTimeout, cfg.WebhookProviderWriteTimeout, cfg.WebhookServerAddress)
+		os.Exit(0)
+	}
+
+	// instrumented beneath the cache, so that only the calls reaching the provider are counted
+	p = providermetrics.NewInstrumentedProvider(p, cfg.Provider)
+
+	if cfg.ProviderCacheTime > 0 {
+		p = provider.NewCachedProvider(
+			p,
+			cfg.ProviderCacheTime,
+		)
+	}
+
+	reg, err := selectRegistry(cfg, p)
+	if err != nil {
+		log.Fatal(err)
+	}
+	if cfg.RegistryCacheMaxStaleness > 0 {
+		reg = registry.NewCachedRegistry(reg, cfg.RegistryCacheMaxStaleness, cfg.RegistryCacheZones, nil)
+	}
+
+	if cfg.TransferOwnershipTo != "" {
+		if err := TransferOwnership(ctx, reg, cfg.TransferOwnershipNames, cfg.TransferOwnershipTo, cfg.DryRun); err != nil {
+			log.Fatal(err)
+		}
+		os.Exit(0)
+	}
+
+	if cfg.Command == "ownership" {
+		report, err := Ownership(ctx, reg, cfg.DomainFilter, OwnershipFilter{Namespaces: cfg.OwnershipNamespaces, Owners: cfg.OwnershipOwners})
+		if err != nil {
+			log.Fatal(err)
+		}
+		if cfg.OwnershipOutput == "json" {
+			err = WriteOwnershipJSON(os.Stdout, report)
+		} else {
+			err = WriteOwnership(os.Stdout, report)
+		}
+		if err != nil {
+			log.Fatal(err)
+		}
+		os.Exit(0)
+	}
+
+	policy, exists := plan.Policies[cfg.Policy]
+	if !exists {
+		log.Fatalf("unknown policy: %s", cfg.Policy)
+	}
+
+	ctrl := Controller{
+		Source:               endpointsSource,
+		Registry:             reg,
+		Policy:               policy,
+		Interval:             cfg.Interval,
+		DomainFilter:         domainFilter,
+		ManagedRecordTypes:   cfg.ManagedDNSRecordTypes,
+		ExcludeRecordTypes:   cfg.ExcludeDNSRecordTypes,
+		MinEventSyncInterval: cfg.MinEventSyncInterval,
+		PropertyComparator:   provider.PropertyComparator(p),
+		PersistsLabels:       provider.PersistsLabels(p),
+		SupportsViews:        provider.SupportsViews(p),
+		TXTEncoding:          provider.TXTEncoding(p),
+		Fallbacks:            NewRecordTypeFallbacks(provider.SupportsAlias(p), cfg.ZoneApexes),
+		ZoneApexes:           cfg.ZoneApexes,
+		PreviewDomain:        strings.ToLower(strings.Trim(cfg.PreviewDomain, ".")),
+		Pinner:               NewPinner(cfg.PinnedRecords),
+		Scheduler:            NewScheduler(),
+		Ownership:            NewOwnershipReporter(cfg.DomainFilter),
+		Health:               health,
+		ObservedVersions:     observedVersions,
+		Sidecar:              sidecar,
+	}
+	http.Handle("/observed", observedVersions)
+	log.Debugf("serving 'observed' on 'localhost:%s/observed'", cfg.MetricsAddress)
+	http.Handle("/scheduled", ctrl.Scheduler)
+	log.Debugf("serving 'scheduled' on 'localhost:%s/scheduled'", cfg.MetricsAddress)
+	http.Handle("/ownership", ctrl.Ownership)
+	log.Debugf("serving 'ownership' on 'localhost:%s/ownership'", cfg.MetricsAddress)
+
+	// --min-ttl and --max-ttl are enforced by the ttl-clamp adjuster, first in the chain unless placed explicitly.
+	if len(cfg.EndpointAdjusters) > 0 || cfg.MinTTL > 0 || cfg.MaxTTL > 0 {
+		ctrl.Adjusters, err = NewAdjusterChain(cfg, reg)
+		if err != nil {
+			log.Fatal(err)
+		}
+	}
+
+	if cfg.CutoverFromOwnerID != "" {
+		ctrl.Cutover, err = newCutover(cfg)
+		if err != nil {
+			log.Fatal(err)
+		}
+	}
+
+	if len(cfg.Finalizers) > 0 {
+		client, err := clientGenerator.DynamicKubernetesClient()
+		if err != nil {
+			log.Fatal(err)
+		}
+		groupVersion, err := schema.ParseGroupVersion(cfg.CRDSourceAPIVersion)
+		if err != nil {
+			log.Fatal(err)
+		}
+		// the resource of DNSEndpoint objects, named like the crd source does
+		dnsEndpoints := groupVersion.WithResource(strings.ToLower(cfg.CRDSourceKind) + "s")
+		ctrl.Finalizer, err = NewFinalizer(client, cfg.FinalizerName, cfg.Finalizers, dnsEndpoints)
+		if err != nil {
+			log.Fatal(err)
+		}
+	}
+
+	if cfg.DeletionDelayCycles > 0 {
+		ctrl.DeletionDelay = NewDeletionDelay(cfg.DeletionDelayCycles)
+	}
+
+	if cfg.ZoneRetryBackoff > 0 {
+		ctrl.ZoneQueue = NewZoneQueue(cfg.DomainFilter, cfg.ZoneRetryBackoff, cfg.ZoneRetryMaxBackoff, cfg.ZoneDeadLetterThreshold)
+		http.Handle("/deadletters", ctrl.ZoneQueue)
+		log.Debugf("serving 'deadletters' on 'localhost:%s/deadletters'", cfg.MetricsAddress)
+	}
+
+	if cfg.WriteInterval > 0 || cfg.WriteApproval {
+		ctrl.WriteGate = NewWriteGate(cfg.WriteInterval, cfg.WriteApproval, cfg.WriteApprovalThreshold, cfg.WriteApprovalExpiry)
+		ctrl.WriteGate.OnApprove = func() { ctrl.ScheduleRunOnce(time.Now()) }
+		if cfg.WriteApprovalNamespace != "" {
+			client, err := clientGenerator.DynamicKubernetesClient()
+			if err != nil {
+				log.Fatal(err)
+			}
+			ctrl.WriteGate.Store = NewPendingPlanStore(client, cfg.WriteApprovalNamespace, cfg.TXTOwnerID)
+		}
+		http.Handle("/pending", ctrl.WriteGate)
+		log.Debugf("serving 'pending' on 'localhost:%s/pending'", cfg.MetricsAddress)
+	}
+
+	if cfg.OverridesConfigMap != "" {
+		client, err := clientGenerator.KubeClient()
+		if err != nil {
+			log.Fatal(err)
+		}
+		namespace, name, _ := strings.Cut(cfg.OverridesConfigMap, "/")
+		ctrl.Overrides = NewOverrides(client, namespace, name)
+	}
+
+	if cfg.AuditNamespace != "" && !cfg.DryRun {
+		client, err := clientGenerator.DynamicKubernetesClient()
+		if err != nil {
+			log.Fatal(err)
+		}
+		ctrl.Auditor = NewAuditor(client, cfg.AuditNamespace, cfg.TXTOwnerID, cfg.AuditTTL)
+	}
+
+	if cfg.ExportRecordMetrics {
+		ctrl.RecordExporter = NewRecordExporter(cfg.DomainFilter)
+	}
+
+	if zoneCollisions != nil {
+		if kubeClient, err := clientGenerator.KubeClient(); err != nil {
+			log.Warnf("Not recording Events of the ambiguous zone matches: %v", err)
+		} else {
+			ctrl.ZoneCollisions = NewZoneCollisionEvents(zoneCollisions, source.NewEventRecorder(kubeClient))
+		}
+	}
+
+	if cfg.Command == "diff" {
+		diffs, err := ctrl.Diff(ctx, cfg.DomainFilter)
+		if err != nil {
+			log.Fatal(err)
+		}
+		if cfg.DiffOutput == "json" {
+			err = WriteDiffJSON(os.Stdout, diffs)
+		} else {
+			err = WriteDiff(os.Stdout, diffs, DiffColor(cfg.DiffColor))
+		}
+		if err != nil {
+			log.Fatal(err)
+		}
+
+		os.Exit(0)
+	}
+
+	if cfg.Command == "bootstrap" {
+		records, err := ctrl.BootstrapCandidates(ctx)
+		if err != nil {
+			log.Fatal(err)
+		}
+		if err := WriteBootstrap(os.Stdout, records); err != nil {
+			log.Fatal(err)
+		}
+		if len(records) == 0 || cfg.DryRun {
+			os.Exit(0)
+		}
+		if !cfg.BootstrapYes {
+			confirmed, err := ConfirmBootstrap(os.Stdin, os.Stdout, len(records), reg.OwnerID())
+			if err != nil {
+				log.Fatal(err)
+			}
+			if !confirmed {
+				log.Info("Not taking over the records")
+				os.Exit(0)
+			}
+		}
+		if err := Bootstrap(ctx, reg, records); err != nil {
+			log.Fatal(err)
+		}
+		log.Infof("Took over %d records as owner %q", len(records), reg.OwnerID())
+
+		os.Exit(0)
+	}
+
+	if cfg.Once {
+		err := ctrl.RunOnce(ctx)
+		if err != nil {
+			log.Fatal(err)
+		}
+
+		os.Exit(0)
+	}
+
+	if cfg.UpdateEvents {
+		// Add RunOnce as the handler function that will be called when ingress/service sources have changed.
+		// Note that k8s Informers will perform an initial list operation, which results in the handler
+		// function initially being called for every Service/Ingress that exists
+		ctrl.Source.AddEventHandler(ctx, func() { ctrl.ScheduleRunOnce(time.Now()) })
+	}
+
+	ctrl.ScheduleRunOnce(time.Now())
+	ctrl.Run(ctx)
+}
+
+// BuildProvider creates the DNS provider selected by cfg.Provider, restricted to the
+// zones and domains matched by domainFilter and the zone filters configured in cfg.
+// endpointsSource is only consulted by providers that read extra data from the
+// desired endpoints, such as ibmcloud.
+func BuildProvider(ctx context.Context, cfg *externaldns.Config, domainFilter endpoint.DomainFilter, endpointsSource source.Source) (provider.Provider, error) {
 	zoneNameFilter := endpoint.NewDomainFilter(cfg.ZoneNameFilter)
 	zoneIDFilter := provider.NewZoneIDFilter(cfg.ZoneIDFilter)
 	zoneTypeFilter := provider.NewZoneTypeFilter(cfg.AWSZoneType)
 	zoneTagFilter := provider.NewZoneTagFilter(cfg.AWSZoneTagFilter)
 
-	var p provider.Provider
+	userAgent := cfg.HTTPUserAgent
+	if userAgent == "" {
+		userAgent = fmt.Sprintf("%s (owner %s)", externaldns.UserAgent(), cfg.TXTOwnerID)
+	}
+	if err := extdnshttp.Configure(extdnshttp.Config{
+		Timeout:   cfg.HTTPClientTimeout,
+		ProxyURL:  cfg.HTTPProxy,
+		CAFile:    cfg.HTTPCAFile,
+		CertFile:  cfg.HTTPClientCertFile,
+		KeyFile:   cfg.HTTPClientKeyFile,
+		UserAgent: userAgent,
+	}); err != nil {
+		return nil, err
+	}
+	// after the HTTP clients, DNS over HTTPS going through them
+	if err := resolver.Configure(resolver.Config{Address: cfg.DNSResolver, Timeout: cfg.DNSResolverTimeout}); err != nil {
+		return nil, err
+	}
+	if err := secrets.Configure(ctx, cfg.Secrets, cfg.SecretRefreshInterval); err != nil {
+		return nil, err
+	}
+
+	var (
+		p   provider.Provider
+		err error
+	)
 	switch cfg.Provider {
 	case "akamai":
 		p, err = akamai.NewAkamaiProvider(
@@ -152,7 +511,7 @@ func Execute() {
 				DryRun:                cfg.DryRun,
 			}, nil)
 	case "alibabacloud":
-		p, err = alibabacloud.NewAlibabaCloudProvider(cfg.AlibabaCloudConfigFile, domainFilter, zoneIDFilter, cfg.AlibabaCloudZoneType, cfg.DryRun)
+		p, err = alibabacloud.NewAlibabaCloudProvider(cfg.AlibabaCloudConfigFile, domainFilter, zoneIDFilter, cfg.AlibabaCloudZoneType, cfg.AlibabaCloudManageVPCAssociations, cfg.DryRun)
 	case "aws":
 		configs := aws.CreateV2Configs(cfg)
 		clients := make(map[string]aws.Route53API, len(configs))
@@ -186,9 +545,9 @@ func Execute() {
 		}
 		p, err = awssd.NewAWSSDProvider(domainFilter, cfg.AWSZoneType, cfg.DryRun, cfg.AWSSDServiceCleanup, cfg.TXTOwnerID, cfg.AWSSDCreateTag, sd.NewFromConfig(aws.CreateDefaultV2Config(cfg)))
 	case "azure-dns", "azure":
-		p, err = azure.NewAzureProvider(cfg.AzureConfigFile, domainFilter, zoneNameFilter, zoneIDFilter, cfg.AzureSubscriptionID, cfg.AzureResourceGroup, cfg.AzureUserAssignedIdentityClientID, cfg.AzureActiveDirectoryAuthorityHost, cfg.AzureZonesCacheDuration, cfg.DryRun)
+		p, err = azure.NewAzureProvider(cfg.AzureConfigFile, domainFilter, zoneNameFilter, zoneIDFilter, cfg.AzureSubscriptionID, cfg.AzureResourceGroup, cfg.AzureUserAssignedIdentityClientID, cfg.AzureActiveDirectoryAuthorityHost, cfg.AzureZonesCacheDuration, cfg.AzureResourceGraphDiscovery, cfg.AzureDiscoverySubscriptionIDs, cfg.DryRun)
 	case "azure-private-dns":
-		p, err = azure.NewAzurePrivateDNSProvider(cfg.AzureConfigFile, domainFilter, zoneNameFilter, zoneIDFilter, cfg.AzureSubscriptionID, cfg.AzureResourceGroup, cfg.AzureUserAssignedIdentityClientID, cfg.AzureActiveDirectoryAuthorityHost, cfg.AzureZonesCacheDuration, cfg.DryRun)
+		p, err = azure.NewAzurePrivateDNSProvider(cfg.AzureConfigFile, domainFilter, zoneNameFilter, zoneIDFilter, cfg.AzureSubscriptionID, cfg.AzureResourceGroup, cfg.AzureUserAssignedIdentityClientID, cfg.AzureActiveDirectoryAuthorityHost, cfg.AzureZonesCacheDuration, cfg.AzureResourceGraphDiscovery, cfg.AzureDiscoverySubscriptionIDs, cfg.DryRun)
 	case "ultradns":
 		p, err = ultradns.NewUltraDNSProvider(domainFilter, cfg.DryRun)
 	case "civo":
@@ -205,13 +564,18 @@ func Execute() {
 				Enabled:              cfg.CloudflareCustomHostnames,
 				MinTLSVersion:        cfg.CloudflareCustomHostnamesMinTLSVersion,
 				CertificateAuthority: cfg.CloudflareCustomHostnamesCertificateAuthority,
-			})
+			},
+			cfg.CloudflareRecordCommentLabels,
+			cfg.CloudflareProxiedDrift == "keep",
+			cfg.ProviderBatchSize)
+	case "constellix":
+		p, err = constellix.NewConstellixProvider(domainFilter, cfg.DryRun)
 	case "google":
-		p, err = google.NewGoogleProvider(ctx, cfg.GoogleProject, domainFilter, zoneIDFilter, cfg.GoogleBatchChangeSize, cfg.GoogleBatchChangeInterval, cfg.GoogleZoneVisibility, cfg.DryRun)
+		p, err = google.NewGoogleProvider(ctx, cfg.GoogleProject, cfg.GoogleAdditionalProjects, domainFilter, zoneIDFilter, cfg.GoogleBatchChangeSize, cfg.GoogleBatchChangeInterval, cfg.GoogleZoneVisibility, cfg.DryRun)
 	case "digitalocean":
 		p, err = digitalocean.NewDigitalOceanProvider(ctx, domainFilter, cfg.DryRun, cfg.DigitalOceanAPIPageSize)
 	case "ovh":
-		p, err = ovh.NewOVHProvider(ctx, domainFilter, cfg.OVHEndpoint, cfg.OVHApiRateLimit, cfg.OVHEnableCNAMERelative, cfg.DryRun)
+		p, err = ovh.NewOVHProviderFromConfig(ctx, cfg, domainFilter)
 	case "linode":
 		p, err = linode.NewLinodeProvider(domainFilter, cfg.DryRun)
 	case "dnsimple":
@@ -304,72 +668,30 @@ func Execute() {
 				APIVersion:            cfg.PiholeApiVersion,
 			},
 		)
+	case "hurricane-electric":
+		p, err = hurricaneelectric.NewHurricaneElectricProviderFromConfig(cfg, domainFilter)
 	case "ibmcloud":
 		p, err = ibmcloud.NewIBMCloudProvider(cfg.IBMCloudConfigFile, domainFilter, zoneIDFilter, endpointsSource, cfg.IBMCloudProxied, cfg.DryRun)
+	case "ionoscloud":
+		p, err = ionoscloud.NewIONOSCloudProvider(domainFilter, cfg.DryRun)
+	case "mythicbeasts":
+		p, err = mythicbeasts.NewMythicBeastsProvider(ctx, domainFilter, cfg.DryRun)
+	case "njalla":
+		p, err = njalla.NewNjallaProvider(domainFilter, cfg.DryRun)
 	case "plural":
 		p, err = plural.NewPluralProvider(cfg.PluralCluster, cfg.PluralProvider)
+	case "rest":
+		p, err = rest.NewRESTProvider(cfg.RESTMappingFile, domainFilter, cfg.DryRun)
 	case "tencentcloud":
 		p, err = tencentcloud.NewTencentCloudProvider(domainFilter, zoneIDFilter, cfg.TencentCloudConfigFile, cfg.TencentCloudZoneType, cfg.DryRun)
+	case "yandex":
+		p, err = yandex.NewYandexProvider(ctx, cfg.YandexFolderID, cfg.YandexAuthKeyFile, cfg.YandexZoneVisibility, domainFilter, cfg.DryRun)
 	case "webhook":
 		p, err = webhook.NewWebhookProvider(cfg.WebhookProviderURL)
 	default:
-		log.Fatalf("unknown dns provider: %s", cfg.Provider)
+		return nil, fmt.Errorf("unknown dns provider: %s", cfg.Provider)
 	}
-	if err != nil {
-		log.Fatal(err)
-	}
-
-	if cfg.WebhookServer {
-		webhookapi.StartHTTPApi(p, nil, cfg.WebhookProviderReadTimeout, cfg.WebhookProviderWriteTimeout, "127.0.0.1:8888")
-		os.Exit(0)
-	}
-
-	if cfg.ProviderCacheTime > 0 {
-		p = provider.NewCachedProvider(
-			p,
-			cfg.ProviderCacheTime,
-		)
-	}
-
-	reg, err := selectRegistry(cfg, p)
-	if err != nil {
-		log.Fatal(err)
-	}
-
-	policy, exists := plan.Policies[cfg.Policy]
-	if !exists {
-		log.Fatalf("unknown policy: %s", cfg.Policy)
-	}
-
-	ctrl := Controller{
-		Source:               endpointsSource,
-		Registry:             reg,
-		Policy:               policy,
-		Interval:             cfg.Interval,
-		DomainFilter:         domainFilter,
-		ManagedRecordTypes:   cfg.ManagedDNSRecordTypes,
-		ExcludeRecordTypes:   cfg.ExcludeDNSRecordTypes,
-		MinEventSyncInterval: cfg.MinEventSyncInterval,
-	}
-
-	if cfg.Once {
-		err := ctrl.RunOnce(ctx)
-		if err != nil {
-			log.Fatal(err)
-		}
-
-		os.Exit(0)
-	}
-
-	if cfg.UpdateEvents {
-		// Add RunOnce as the handler function that will be called when ingress/service sources have changed.
-		// Note that k8s Informers will perform an initial list operation, which results in the handler
-		// function initially being called for every Service/Ingress that exists
-		ctrl.Source.AddEventHandler(ctx, func() { ctrl.ScheduleRunOnce(time.Now()) })
-	}
-
-	ctrl.ScheduleRunOnce(time.Now())
-	ctrl.Run(ctx)
+	return p, err
 }
 
 // This function configures the logger format and level based on the provided configuration.
@@ -400,7 +722,7 @@ func selectRegistry(cfg *externaldns.Config, p provider.Provider) (registry.Regi
 				},
 			}
 		}
-		r, err = registry.NewDynamoDBRegistry(p, cfg.TXTOwnerID, dynamodb.NewFromConfig(aws.CreateDefaultV2Config(cfg), dynamodbOpts...), cfg.AWSDynamoDBTable, cfg.TXTPrefix, cfg.TXTSuffix, cfg.TXTWildcardReplacement, cfg.ManagedDNSRecordTypes, cfg.ExcludeDNSRecordTypes, []byte(cfg.TXTEncryptAESKey), cfg.TXTCacheInterval)
+		r, err = registry.NewDynamoDBRegistry(p, cfg.TXTOwnerID, dynamodb.NewFromConfig(aws.CreateDefaultV2Config(cfg), dynamodbOpts...), cfg.AWSDynamoDBTable, cfg.TXTPrefix, cfg.TXTSuffix, cfg.TXTWildcardReplacement, cfg.ManagedDNSRecordTypes, cfg.ExcludeDNSRecordTypes, []byte(cfg.TXTEncryptAESKey), cfg.TXTCacheInterval, cfg.AWSDynamoDBCreateTable, cfg.AWSDynamoDBItemTTL, cfg.AWSDynamoDBReplicaRegions)
 	case "noop":
 		r, err = registry.NewNoopRegistry(p)
 	case "txt":
@@ -410,7 +732,19 @@ func selectRegistry(cfg *externaldns.Config, p provider.Provider) (registry.Regi
 	default:
 		log.Fatalf("unknown registry: %s", cfg.Registry)
 	}
-	return r, err
+	if err != nil || cfg.SecondaryRegistry == "" {
+		return r, err
+	}
+
+	secondaryCfg := *cfg
+	secondaryCfg.Registry = cfg.SecondaryRegistry
+	secondaryCfg.SecondaryRegistry = ""
+	secondary, err := selectRegistry(&secondaryCfg, registry.NewOwnershipRecordsProvider(p))
+	if err != nil {
+		return nil, fmt.Errorf("secondary registry: %w", err)
+	}
+	log.Infof("Writing the ownership of records to the %s registry too, and comparing it to the %s registry", cfg.SecondaryRegistry, cfg.Registry)
+	return registry.NewDualRegistry(r, secondary, []byte(cfg.TXTEncryptAESKey)), nil
 }
 
 // RegexDomainFilter overrides DomainFilter
@@ -422,27 +756,61 @@ func createDomainFilter(cfg *externaldns.Config) endpoint.DomainFilter {
 	}
 }
 
+// newCutover returns the Cutover taking over the records of cfg.CutoverFromOwnerID.
+func newCutover(cfg *externaldns.Config) (*Cutover, error) {
+	start, err := time.Parse(time.RFC3339, cfg.CutoverStart)
+	if err != nil {
+		return nil, fmt.Errorf("parsing cutover start: %w", err)
+	}
+	schedule, err := ParseCutoverSchedule(cfg.CutoverSchedule)
+	if err != nil {
+		return nil, err
+	}
+	cutover := &Cutover{
+		FromOwnerID:   cfg.CutoverFromOwnerID,
+		Start:         start,
+		Schedule:      schedule,
+		SetIdentifier: cfg.TXTOwnerID,
+	}
+	switch cfg.Provider {
+	case "aws":
+		cutover.WeightProperty = "aws/weight"
+		cutover.MaxWeight = 255
+	default:
+		log.Infof("Provider %s does not support weighted records: the records of owner %q are taken over at once when the cutover schedule reaches 100 percent", cfg.Provider, cfg.CutoverFromOwnerID)
+	}
+	return cutover, nil
+}
+
 // handleSigterm listens for a SIGTERM signal and triggers the provided cancel function
 // to gracefully terminate the application. It logs a message when the signal is received.
-func handleSigterm(cancel func()) {
+// When lameduck is set, health enters lameduck first: readiness fails and no more changes are applied,
+// and the application keeps running for that long so that load balancers and other replicas notice.
+func handleSigterm(cancel func(), health *Health, lameduck time.Duration) {
 	signals := make(chan os.Signal, 1)
 	signal.Notify(signals, syscall.SIGTERM)
 	<-signals
-	log.Info("Received SIGTERM. Terminating...")
+	if lameduck > 0 {
+		log.Infof("Received SIGTERM. Terminating in %s...", lameduck)
+		health.EnterLameduck()
+		time.Sleep(lameduck)
+	} else {
+		log.Info("Received SIGTERM. Terminating...")
+	}
 	cancel()
 }
 
 // serveMetrics starts an HTTP server that serves health and metrics endpoints.
-// The /healthz endpoint returns a 200 OK status to indicate the service is healthy.
+// The /healthz endpoint returns a 200 OK status to indicate the service is alive.
+// The /readyz endpoint returns a 200 OK status once the service is ready, a 503 with the reason otherwise.
 // The /metrics endpoint serves Prometheus metrics.
 // The server listens on the specified address and logs debug information about the endpoints.
-func serveMetrics(address string) {
-	http.HandleFunc("/healthz", func(w http.ResponseWriter, _ *http.Request) {
-		w.WriteHeader(http.StatusOK)
-		_, _ = w.Write([]byte("OK"))
-	})
+func serveMetrics(address string, health *Health) {
+	http.HandleFunc("/healthz", health.ServeLiveness)
+	http.HandleFunc("/readyz", health.ServeReadiness)
 
 	log.Debugf("serving 'healthz' on 'localhost:%s/healthz'", address)
+	log.Debugf("serving 'readyz' on 'localhost:%s/readyz'", address)
 	log.Debugf("serving 'metrics' on 'localhost:%s/metrics'", address)
 	log.Debugf("registered '%d' metrics", len(metrics.RegisterMetric.Metrics))
 
@@ -200,7 +200,7 @@ func TestHandleSigterm(t *testing.T) {
 	log.SetOutput(&logOutput)
 	defer log.SetOutput(os.Stderr)
 
-	go handleSigterm(cancel)
+	go handleSigterm(cancel, NewHealth(), 0)
 
 	// Simulate sending a SIGTERM signal
 	sigChan := make(chan os.Signal, 1)
@@ -219,6 +219,39 @@ func TestHandleSigterm(t *testing.T) {
 	}
 }
 
+func TestHandleSigtermLameduck(t *testing.T) {
+	cancelCalled := make(chan bool, 1)
+	cancel := func() {
+		cancelCalled <- true
+	}
+	health := NewHealth()
+
+	sigChan := make(chan os.Signal, 1)
+	signal.Notify(sigChan, syscall.SIGTERM)
+	defer signal.Stop(sigChan)
+
+	go handleSigterm(cancel, health, 200*time.Millisecond)
+
+	// handleSigterm registers its own channel asynchronously, keep signaling until it enters lameduck
+	require.Eventually(t, func() bool {
+		_ = syscall.Kill(syscall.Getpid(), syscall.SIGTERM)
+		return health.Lameduck()
+	}, time.Second, 10*time.Millisecond)
+	assert.EqualError(t, health.Ready(), "shutting down")
+
+	select {
+	case <-cancelCalled:
+		t.Fatal("cancel function was called before the lameduck duration elapsed")
+	case <-time.After(50 * time.Millisecond):
+	}
+
+	select {
+	case <-cancelCalled:
+	case <-time.After(1 * time.Second):
+		t.Fatal("cancel function was not called")
+	}
+}
+
 func getRandomPort() (int, error) {
 	addr, err := net.ResolveTCPAddr("tcp", "localhost:0")
 	if err != nil {
@@ -240,7 +273,8 @@ func TestServeMetrics(t *testing.T) {
 	require.NoError(t, err)
 	addresse := fmt.Sprintf("localhost:%d", port)
 
-	go serveMetrics(fmt.Sprintf(":%d", port))
+	health := NewHealth()
+	go serveMetrics(fmt.Sprintf(":%d", port), health)
 
 	// Wait for the TCP socket to be ready
 	require.Eventually(t, func() bool {
@@ -256,6 +290,16 @@ func TestServeMetrics(t *testing.T) {
 	require.NoError(t, err)
 	assert.Equal(t, http.StatusOK, resp.StatusCode)
 
+	resp, err = http.Get(fmt.Sprintf("http://%s/readyz", addresse))
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusServiceUnavailable, resp.StatusCode)
+
+	health.SetSourcesSynced()
+	health.SetRegistryResult(nil)
+	resp, err = http.Get(fmt.Sprintf("http://%s/readyz", addresse))
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+
 	resp, err = http.Get(fmt.Sprintf("http://%s/metrics", addresse))
 	require.NoError(t, err)
 	assert.Equal(t, http.StatusOK, resp.StatusCode)
@@ -0,0 +1,89 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+
+	log "github.com/sirupsen/logrus"
+
+	"sigs.k8s.io/external-dns/endpoint"
+	"sigs.k8s.io/external-dns/pkg/resolver"
+)
+
+// aliasProperty is the provider specific property requesting an alias record, see the alias annotation.
+const aliasProperty = "alias"
+
+// RecordTypeFallbacks replaces the endpoints the provider cannot create by their best alternative, along
+// the chain ALIAS -> CNAME -> A: alias endpoints fall back to CNAME endpoints, and CNAME endpoints at a zone
+// apex, where only alias records may point at another name, to the A and AAAA endpoints their targets
+// resolve to.
+type RecordTypeFallbacks struct {
+	supportsAlias bool
+	zoneApexes    []string
+	flattener     *cnameFlattenAdjuster
+}
+
+// NewRecordTypeFallbacks returns the fallbacks of a provider, depending on whether it supports alias
+// records, given the zone apexes known beyond those of the SOA records.
+func NewRecordTypeFallbacks(supportsAlias bool, zoneApexes []string) *RecordTypeFallbacks {
+	return &RecordTypeFallbacks{
+		supportsAlias: supportsAlias,
+		zoneApexes:    zoneApexes,
+		flattener:     &cnameFlattenAdjuster{resolver: resolver.Default()},
+	}
+}
+
+// Apply returns the desired endpoints where the endpoints the provider cannot create are replaced by their
+// fallbacks. The zone apexes are those given at creation, and the names of the current SOA records.
+func (f *RecordTypeFallbacks) Apply(ctx context.Context, desired, current []*endpoint.Endpoint) []*endpoint.Endpoint {
+	if f.supportsAlias {
+		return desired
+	}
+
+	apexes := make(map[string]bool, len(f.zoneApexes))
+	for _, apex := range f.zoneApexes {
+		apexes[pinKey(apex)] = true
+	}
+	for _, ep := range current {
+		if ep.RecordType == endpoint.RecordTypeSOA {
+			apexes[pinKey(ep.DNSName)] = true
+		}
+	}
+
+	result := make([]*endpoint.Endpoint, 0, len(desired))
+	var apexCNAMEs []*endpoint.Endpoint
+	for _, ep := range desired {
+		if alias, ok := ep.GetProviderSpecificProperty(aliasProperty); ok {
+			ep.DeleteProviderSpecificProperty(aliasProperty)
+			if alias == "true" && ep.RecordType != endpoint.RecordTypeCNAME {
+				log.Infof("Falling back from an alias record to a CNAME record for %s: the provider does not support alias records", ep.DNSName)
+				ep.RecordType = endpoint.RecordTypeCNAME
+			}
+		}
+		if ep.RecordType != endpoint.RecordTypeCNAME || !apexes[pinKey(ep.DNSName)] {
+			result = append(result, ep)
+			continue
+		}
+
+		log.Infof("Falling back from a CNAME record to address records for %s: it is at a zone apex", ep.DNSName)
+		apexCNAMEs = append(apexCNAMEs, ep)
+	}
+	// flattened at once, so that the flattener remembers each of them
+	flattened, _ := f.flattener.Adjust(ctx, apexCNAMEs)
+	return append(result, flattened...)
+}
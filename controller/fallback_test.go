@@ -0,0 +1,65 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"sigs.k8s.io/external-dns/endpoint"
+	"sigs.k8s.io/external-dns/internal/testutils"
+)
+
+func TestRecordTypeFallbacks(t *testing.T) {
+	desired := func() []*endpoint.Endpoint {
+		return []*endpoint.Endpoint{
+			endpoint.NewEndpoint("www.example.com", endpoint.RecordTypeA, "lb.example.net").WithProviderSpecific(aliasProperty, "true"),
+			endpoint.NewEndpoint("example.com", endpoint.RecordTypeCNAME, "lb.example.net").WithProviderSpecific(aliasProperty, "true"),
+			endpoint.NewEndpoint("example.org", endpoint.RecordTypeCNAME, "lb.example.net"),
+			endpoint.NewEndpoint("api.example.com", endpoint.RecordTypeCNAME, "lb.example.net").WithProviderSpecific(aliasProperty, "false"),
+			endpoint.NewEndpoint("broken.example.org", endpoint.RecordTypeCNAME, "missing.example.net"),
+		}
+	}
+	current := []*endpoint.Endpoint{
+		endpoint.NewEndpoint("example.com", endpoint.RecordTypeSOA, "ns1.example.com. hostmaster.example.com. 1 7200 3600 1209600 3600"),
+	}
+
+	fallbacks := NewRecordTypeFallbacks(false, []string{"example.org.", "broken.example.org"})
+	fallbacks.flattener.resolver = fakeResolver{"lb.example.net": {"1.2.3.4", "2001:db8::1"}}
+
+	// the alias property is deleted from the endpoints having it
+	withoutAlias := func(ep *endpoint.Endpoint) *endpoint.Endpoint {
+		ep.ProviderSpecific = endpoint.ProviderSpecific{}
+		return ep
+	}
+
+	endpoints := fallbacks.Apply(context.Background(), desired(), current)
+	assert.True(t, testutils.SameEndpoints(endpoints, []*endpoint.Endpoint{
+		withoutAlias(endpoint.NewEndpoint("www.example.com", endpoint.RecordTypeCNAME, "lb.example.net")),
+		withoutAlias(endpoint.NewEndpoint("example.com", endpoint.RecordTypeA, "1.2.3.4")),
+		withoutAlias(endpoint.NewEndpoint("example.com", endpoint.RecordTypeAAAA, "2001:db8::1")),
+		endpoint.NewEndpoint("example.org", endpoint.RecordTypeA, "1.2.3.4"),
+		endpoint.NewEndpoint("example.org", endpoint.RecordTypeAAAA, "2001:db8::1"),
+		withoutAlias(endpoint.NewEndpoint("api.example.com", endpoint.RecordTypeCNAME, "lb.example.net")),
+		// broken.example.org is skipped until its target resolves
+	}), "unexpected endpoints: %v", endpoints)
+
+	endpoints = NewRecordTypeFallbacks(true, nil).Apply(context.Background(), desired(), current)
+	assert.True(t, testutils.SameEndpoints(endpoints, desired()), "unexpected endpoints: %v", endpoints)
+}
@@ -0,0 +1,188 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+
+	log "github.com/sirupsen/logrus"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/dynamic"
+
+	"sigs.k8s.io/external-dns/endpoint"
+	"sigs.k8s.io/external-dns/plan"
+)
+
+const (
+	// DefaultFinalizerName is the finalizer blocking the deletion of objects until their records are deleted.
+	DefaultFinalizerName = "external-dns.alpha.kubernetes.io/records"
+
+	// FinalizeNamespaces makes the deletion of namespaces wait for the records of their resources.
+	FinalizeNamespaces = "namespace"
+	// FinalizeDNSEndpoints makes the deletion of DNSEndpoint objects wait for their records.
+	FinalizeDNSEndpoints = "dnsendpoint"
+)
+
+// NamespaceGVR is the resource of namespaces.
+var NamespaceGVR = schema.GroupVersionResource{Version: "v1", Resource: "namespaces"}
+
+// finalizedKind is a kind of objects whose deletion waits for their records.
+type finalizedKind struct {
+	gvr schema.GroupVersionResource
+	// owns returns whether the endpoints of a resource, labelled as kind/namespace/name, belong to obj.
+	owns func(obj *unstructured.Unstructured, resource string) bool
+}
+
+type finalizedObject struct {
+	kind *finalizedKind
+	obj  *unstructured.Unstructured
+}
+
+// Finalizer adds a finalizer to the namespaces or DNSEndpoint objects whose resources have records, so
+// that their deletion waits until their records are deleted: the endpoints of objects being deleted are
+// not desired anymore, their records are deleted whatever the policy, and the finalizer is removed
+// once they are all gone, guaranteeing no records are left behind.
+//
+// Records are matched to objects with their resource label, so the registry must keep it.
+type Finalizer struct {
+	client  dynamic.Interface
+	name    string
+	kinds   []*finalizedKind
+	objects []finalizedObject
+}
+
+// NewFinalizer returns a Finalizer of the given kinds of objects, FinalizeNamespaces or FinalizeDNSEndpoints,
+// DNSEndpoint objects being of the dnsEndpoints resource.
+func NewFinalizer(client dynamic.Interface, name string, kinds []string, dnsEndpoints schema.GroupVersionResource) (*Finalizer, error) {
+	f := &Finalizer{client: client, name: name}
+	for _, kind := range kinds {
+		switch kind {
+		case FinalizeNamespaces:
+			f.kinds = append(f.kinds, &finalizedKind{
+				gvr: NamespaceGVR,
+				owns: func(obj *unstructured.Unstructured, resource string) bool {
+					parts := strings.Split(resource, "/")
+					return len(parts) == 3 && parts[1] == obj.GetName()
+				},
+			})
+		case FinalizeDNSEndpoints:
+			f.kinds = append(f.kinds, &finalizedKind{
+				gvr: dnsEndpoints,
+				owns: func(obj *unstructured.Unstructured, resource string) bool {
+					return resource == fmt.Sprintf("crd/%s/%s", obj.GetNamespace(), obj.GetName())
+				},
+			})
+		default:
+			return nil, fmt.Errorf("unknown kind of finalized objects %q", kind)
+		}
+	}
+	return f, nil
+}
+
+// Refresh lists the objects of the finalized kinds.
+func (f *Finalizer) Refresh(ctx context.Context) error {
+	var objects []finalizedObject
+	for _, kind := range f.kinds {
+		list, err := f.client.Resource(kind.gvr).List(ctx, metav1.ListOptions{})
+		if err != nil {
+			return fmt.Errorf("listing %s: %w", kind.gvr.Resource, err)
+		}
+		for i := range list.Items {
+			objects = append(objects, finalizedObject{kind: kind, obj: &list.Items[i]})
+		}
+	}
+	f.objects = objects
+	return nil
+}
+
+// finalizing returns whether o is being deleted and waits for its records.
+func (f *Finalizer) finalizing(o finalizedObject) bool {
+	return o.obj.GetDeletionTimestamp() != nil && slices.Contains(o.obj.GetFinalizers(), f.name)
+}
+
+// finalized returns whether ep belongs to an object being deleted which waits for its records.
+func (f *Finalizer) finalized(ep *endpoint.Endpoint) bool {
+	resource := ep.Labels[endpoint.ResourceLabelKey]
+	if resource == "" {
+		return false
+	}
+	for _, o := range f.objects {
+		if f.finalizing(o) && o.kind.owns(o.obj, resource) {
+			return true
+		}
+	}
+	return false
+}
+
+// Apply returns the desired endpoints without the ones of objects being deleted.
+func (f *Finalizer) Apply(endpoints []*endpoint.Endpoint) []*endpoint.Endpoint {
+	result := make([]*endpoint.Endpoint, 0, len(endpoints))
+	for _, ep := range endpoints {
+		if !f.finalized(ep) {
+			result = append(result, ep)
+		}
+	}
+	return result
+}
+
+// AddDeletions adds to the changes calculated for a plan the deletion of the records of objects being
+// deleted, whatever the policy.
+func (f *Finalizer) AddDeletions(p *plan.Plan, changes *plan.Changes) {
+	forceDeletions(p, changes, nil, f.finalized)
+}
+
+// Sync adds the finalizer to the objects owning desired endpoints, and removes it from the objects being
+// deleted which own no current records anymore. Objects failing to be updated are updated at the next
+// synchronization.
+func (f *Finalizer) Sync(ctx context.Context, desired, current []*endpoint.Endpoint) {
+	owns := func(o finalizedObject, endpoints []*endpoint.Endpoint) bool {
+		return slices.ContainsFunc(endpoints, func(ep *endpoint.Endpoint) bool {
+			resource := ep.Labels[endpoint.ResourceLabelKey]
+			return resource != "" && o.kind.owns(o.obj, resource)
+		})
+	}
+
+	for _, o := range f.objects {
+		finalizers := o.obj.GetFinalizers()
+		switch {
+		case o.obj.GetDeletionTimestamp() == nil && !slices.Contains(finalizers, f.name) && owns(o, desired):
+			o.obj.SetFinalizers(append(finalizers, f.name))
+			f.update(ctx, o, "Adding finalizer to")
+		case f.finalizing(o) && !owns(o, current):
+			o.obj.SetFinalizers(slices.DeleteFunc(finalizers, func(name string) bool { return name == f.name }))
+			f.update(ctx, o, "Records deleted, removing finalizer from")
+		}
+	}
+}
+
+func (f *Finalizer) update(ctx context.Context, o finalizedObject, action string) {
+	name := o.obj.GetName()
+	client := dynamic.ResourceInterface(f.client.Resource(o.kind.gvr))
+	if namespace := o.obj.GetNamespace(); namespace != "" {
+		name = namespace + "/" + name
+		client = f.client.Resource(o.kind.gvr).Namespace(namespace)
+	}
+	log.Infof("%s %s %s", action, o.kind.gvr.Resource, name)
+	if _, err := client.Update(ctx, o.obj, metav1.UpdateOptions{}); err != nil {
+		log.Warnf("Failed to update the finalizers of %s %s: %v", o.kind.gvr.Resource, name, err)
+	}
+}
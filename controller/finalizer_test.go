@@ -0,0 +1,161 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	fakeDynamic "k8s.io/client-go/dynamic/fake"
+
+	"sigs.k8s.io/external-dns/endpoint"
+	"sigs.k8s.io/external-dns/internal/testutils"
+	"sigs.k8s.io/external-dns/plan"
+	"sigs.k8s.io/external-dns/provider/inmemory"
+	"sigs.k8s.io/external-dns/registry"
+)
+
+var testDNSEndpointGVR = schema.GroupVersionResource{Group: "externaldns.k8s.io", Version: "v1alpha1", Resource: "dnsendpoints"}
+
+func newFinalizerTestClient(objects ...runtime.Object) *fakeDynamic.FakeDynamicClient {
+	return fakeDynamic.NewSimpleDynamicClientWithCustomListKinds(runtime.NewScheme(), map[schema.GroupVersionResource]string{
+		NamespaceGVR:       "NamespaceList",
+		testDNSEndpointGVR: "DNSEndpointList",
+	}, objects...)
+}
+
+func newTestObject(apiVersion, kind, namespace, name string) *unstructured.Unstructured {
+	obj := &unstructured.Unstructured{}
+	obj.SetAPIVersion(apiVersion)
+	obj.SetKind(kind)
+	obj.SetNamespace(namespace)
+	obj.SetName(name)
+	return obj
+}
+
+func newResourceTestEndpoint(name, resource string) *endpoint.Endpoint {
+	ep := endpoint.NewEndpoint(name, endpoint.RecordTypeA, "1.1.1.1")
+	ep.Labels[endpoint.ResourceLabelKey] = resource
+	return ep
+}
+
+func TestNewFinalizerUnknownKind(t *testing.T) {
+	_, err := NewFinalizer(newFinalizerTestClient(), DefaultFinalizerName, []string{"pod"}, testDNSEndpointGVR)
+	assert.EqualError(t, err, `unknown kind of finalized objects "pod"`)
+}
+
+func TestFinalizerApply(t *testing.T) {
+	now := metav1.Now()
+	deleted := newTestObject("externaldns.k8s.io/v1alpha1", "DNSEndpoint", "dns", "old")
+	deleted.SetDeletionTimestamp(&now)
+	deleted.SetFinalizers([]string{DefaultFinalizerName})
+	unfinalized := newTestObject("externaldns.k8s.io/v1alpha1", "DNSEndpoint", "dns", "other")
+	unfinalized.SetDeletionTimestamp(&now)
+	client := newFinalizerTestClient(deleted, unfinalized, newTestObject("externaldns.k8s.io/v1alpha1", "DNSEndpoint", "dns", "new"))
+
+	f, err := NewFinalizer(client, DefaultFinalizerName, []string{FinalizeDNSEndpoints}, testDNSEndpointGVR)
+	require.NoError(t, err)
+	require.NoError(t, f.Refresh(context.Background()))
+
+	endpoints := f.Apply([]*endpoint.Endpoint{
+		newResourceTestEndpoint("old.example.com", "crd/dns/old"),
+		newResourceTestEndpoint("other.example.com", "crd/dns/other"),
+		newResourceTestEndpoint("new.example.com", "crd/dns/new"),
+		newResourceTestEndpoint("svc.example.com", "service/dns/old"),
+		endpoint.NewEndpoint("static.example.com", endpoint.RecordTypeA, "1.1.1.1"),
+	})
+	names := []string{}
+	for _, ep := range endpoints {
+		names = append(names, ep.DNSName)
+	}
+	assert.Equal(t, []string{"other.example.com", "new.example.com", "svc.example.com", "static.example.com"}, names,
+		"only the endpoints of objects being deleted with the finalizer are dropped")
+}
+
+func TestRunOnceFinalizer(t *testing.T) {
+	ctx := context.Background()
+	p := inmemory.NewInMemoryProvider(inmemory.InMemoryInitZones([]string{"example.com"}))
+	r, err := registry.NewTXTRegistry(p, "", "", "me", 0, "", []string{endpoint.RecordTypeA}, nil, false, nil, false)
+	require.NoError(t, err)
+
+	client := newFinalizerTestClient(newTestObject("v1", "Namespace", "", "app"), newTestObject("v1", "Namespace", "", "other"))
+	f, err := NewFinalizer(client, DefaultFinalizerName, []string{FinalizeNamespaces}, testDNSEndpointGVR)
+	require.NoError(t, err)
+
+	source := new(testutils.MockSource)
+	ctrl := &Controller{
+		Source:             source,
+		Registry:           r,
+		Policy:             &plan.UpsertOnlyPolicy{},
+		ManagedRecordTypes: []string{endpoint.RecordTypeA},
+		Finalizer:          f,
+	}
+	namespace := func(name string) *unstructured.Unstructured {
+		t.Helper()
+		obj, err := client.Resource(NamespaceGVR).Get(ctx, name, metav1.GetOptions{})
+		require.NoError(t, err)
+		return obj
+	}
+	names := func() []string {
+		t.Helper()
+		records, err := p.Records(ctx)
+		require.NoError(t, err)
+		names := []string{}
+		for _, ep := range records {
+			if ep.RecordType == endpoint.RecordTypeA {
+				names = append(names, ep.DNSName)
+			}
+		}
+		return names
+	}
+	source.On("Endpoints").Return([]*endpoint.Endpoint{
+		newResourceTestEndpoint("app.example.com", "ingress/app/web"),
+		newResourceTestEndpoint("other.example.com", "ingress/other/web"),
+	}, nil).Once()
+
+	// namespaces whose resources have records get the finalizer
+	require.NoError(t, ctrl.RunOnce(ctx))
+	assert.ElementsMatch(t, []string{"app.example.com", "other.example.com"}, names())
+	assert.Equal(t, []string{DefaultFinalizerName}, namespace("app").GetFinalizers())
+	assert.Equal(t, []string{DefaultFinalizerName}, namespace("other").GetFinalizers())
+
+	// the records of a namespace being deleted are deleted whatever the policy, even though its resources
+	// are not deleted yet, and its finalizer is kept until they are gone
+	now := metav1.Now()
+	app := namespace("app")
+	app.SetDeletionTimestamp(&now)
+	_, err = client.Resource(NamespaceGVR).Update(ctx, app, metav1.UpdateOptions{})
+	require.NoError(t, err)
+	source.On("Endpoints").Return([]*endpoint.Endpoint{
+		newResourceTestEndpoint("app.example.com", "ingress/app/web"),
+		newResourceTestEndpoint("other.example.com", "ingress/other/web"),
+	}, nil).Twice()
+	require.NoError(t, ctrl.RunOnce(ctx))
+	assert.Equal(t, []string{"other.example.com"}, names())
+	assert.Equal(t, []string{DefaultFinalizerName}, namespace("app").GetFinalizers())
+
+	// and removed once they are
+	require.NoError(t, ctrl.RunOnce(ctx))
+	assert.Empty(t, namespace("app").GetFinalizers())
+	assert.Equal(t, []string{DefaultFinalizerName}, namespace("other").GetFinalizers())
+}
@@ -0,0 +1,117 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"sync"
+)
+
+// Health tracks whether ExternalDNS is ready to manage records. It is ready once the sources caches
+// are synced and the registry listed the records of the provider, for as long as the registry stays
+// available and ExternalDNS is not shutting down.
+type Health struct {
+	mu            sync.RWMutex
+	sourcesSynced bool
+	recordsListed bool
+	registryErr   error
+	lameduck      bool
+	checks        []func() error
+}
+
+// NewHealth returns a Health that is not ready yet.
+func NewHealth() *Health {
+	return &Health{}
+}
+
+// SetSourcesSynced records that the sources caches are synced.
+func (h *Health) SetSourcesSynced() {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.sourcesSynced = true
+}
+
+// SetRegistryResult records the outcome of listing records from the registry.
+func (h *Health) SetRegistryResult(err error) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.registryErr = err
+	if err == nil {
+		h.recordsListed = true
+	}
+}
+
+// AddCheck adds a check ExternalDNS must pass to be ready, such as the webhook provider sidecar being ready.
+func (h *Health) AddCheck(check func() error) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.checks = append(h.checks, check)
+}
+
+// EnterLameduck records that ExternalDNS is shutting down: it is no longer ready, and stops applying changes.
+func (h *Health) EnterLameduck() {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.lameduck = true
+}
+
+// Lameduck returns whether ExternalDNS is shutting down.
+func (h *Health) Lameduck() bool {
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+	return h.lameduck
+}
+
+// Ready returns nil when ExternalDNS is ready, or the reason it is not.
+func (h *Health) Ready() error {
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+	switch {
+	case h.lameduck:
+		return errors.New("shutting down")
+	case !h.sourcesSynced:
+		return errors.New("sources are not synced")
+	case h.registryErr != nil:
+		return fmt.Errorf("registry is unavailable: %w", h.registryErr)
+	case !h.recordsListed:
+		return errors.New("records were not listed from the provider yet")
+	}
+	for _, check := range h.checks {
+		if err := check(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// ServeLiveness reports that the process is alive, including while shutting down.
+func (h *Health) ServeLiveness(w http.ResponseWriter, _ *http.Request) {
+	w.WriteHeader(http.StatusOK)
+	_, _ = w.Write([]byte("OK"))
+}
+
+// ServeReadiness reports whether ExternalDNS is ready, with the reason when it is not.
+func (h *Health) ServeReadiness(w http.ResponseWriter, _ *http.Request) {
+	if err := h.Ready(); err != nil {
+		http.Error(w, err.Error(), http.StatusServiceUnavailable)
+		return
+	}
+	w.WriteHeader(http.StatusOK)
+	_, _ = w.Write([]byte("OK"))
+}
@@ -0,0 +1,138 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"sigs.k8s.io/external-dns/endpoint"
+	"sigs.k8s.io/external-dns/internal/testutils"
+	"sigs.k8s.io/external-dns/plan"
+	"sigs.k8s.io/external-dns/registry"
+)
+
+func TestHealthReady(t *testing.T) {
+	h := NewHealth()
+	assert.EqualError(t, h.Ready(), "sources are not synced")
+
+	h.SetSourcesSynced()
+	assert.EqualError(t, h.Ready(), "records were not listed from the provider yet")
+
+	h.SetRegistryResult(errors.New("provider down"))
+	assert.EqualError(t, h.Ready(), "registry is unavailable: provider down")
+
+	h.SetRegistryResult(nil)
+	assert.NoError(t, h.Ready())
+
+	// the registry becoming unavailable again makes it not ready
+	h.SetRegistryResult(errors.New("provider down"))
+	assert.Error(t, h.Ready())
+	h.SetRegistryResult(nil)
+	assert.NoError(t, h.Ready())
+
+	// added checks must pass too
+	var checkErr error
+	h.AddCheck(func() error { return checkErr })
+	assert.NoError(t, h.Ready())
+	checkErr = errors.New("sidecar down")
+	assert.EqualError(t, h.Ready(), "sidecar down")
+	checkErr = nil
+
+	assert.False(t, h.Lameduck())
+	h.EnterLameduck()
+	assert.True(t, h.Lameduck())
+	assert.EqualError(t, h.Ready(), "shutting down")
+}
+
+func TestHealthHandlers(t *testing.T) {
+	h := NewHealth()
+
+	rec := httptest.NewRecorder()
+	h.ServeReadiness(rec, httptest.NewRequest(http.MethodGet, "/readyz", nil))
+	assert.Equal(t, http.StatusServiceUnavailable, rec.Code)
+	assert.Contains(t, rec.Body.String(), "sources are not synced")
+
+	h.SetSourcesSynced()
+	h.SetRegistryResult(nil)
+	rec = httptest.NewRecorder()
+	h.ServeReadiness(rec, httptest.NewRequest(http.MethodGet, "/readyz", nil))
+	assert.Equal(t, http.StatusOK, rec.Code)
+
+	// liveness holds while shutting down
+	h.EnterLameduck()
+	rec = httptest.NewRecorder()
+	h.ServeReadiness(rec, httptest.NewRequest(http.MethodGet, "/readyz", nil))
+	assert.Equal(t, http.StatusServiceUnavailable, rec.Code)
+	rec = httptest.NewRecorder()
+	h.ServeLiveness(rec, httptest.NewRequest(http.MethodGet, "/healthz", nil))
+	assert.Equal(t, http.StatusOK, rec.Code)
+}
+
+func TestRunOnceUpdatesHealth(t *testing.T) {
+	source := new(testutils.MockSource)
+	source.On("Endpoints").Return([]*endpoint.Endpoint{}, nil)
+
+	health := NewHealth()
+	health.SetSourcesSynced()
+
+	r, err := registry.NewNoopRegistry(&errorMockProvider{})
+	require.NoError(t, err)
+	ctrl := &Controller{
+		Source:   source,
+		Registry: r,
+		Policy:   &plan.SyncPolicy{},
+		Health:   health,
+	}
+	require.Error(t, ctrl.RunOnce(context.Background()))
+	assert.EqualError(t, health.Ready(), "registry is unavailable: error for testing")
+
+	r, err = registry.NewNoopRegistry(&filteredMockProvider{})
+	require.NoError(t, err)
+	ctrl.Registry = r
+	require.NoError(t, ctrl.RunOnce(context.Background()))
+	assert.NoError(t, health.Ready())
+}
+
+func TestRunSkipsWhileLameduck(t *testing.T) {
+	source := new(testutils.MockSource)
+	source.On("Endpoints").Return([]*endpoint.Endpoint{}, nil)
+
+	p := &filteredMockProvider{}
+	r, err := registry.NewNoopRegistry(p)
+	require.NoError(t, err)
+
+	health := NewHealth()
+	health.EnterLameduck()
+	ctrl := &Controller{
+		Source:   source,
+		Registry: r,
+		Policy:   &plan.SyncPolicy{},
+		Health:   health,
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	ctrl.Run(ctx)
+	assert.Zero(t, p.RecordsCallCount)
+}
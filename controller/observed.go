@@ -0,0 +1,127 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"encoding/json"
+	"net/http"
+	"sync"
+	"time"
+
+	"sigs.k8s.io/external-dns/endpoint"
+)
+
+// ObservedVersions keeps track of the version of every source resource whose endpoints were
+// incorporated into the last successful synchronization, so users can tell whether a recent edit
+// of a resource has propagated to the DNS provider.
+//
+// Every successful synchronization starts a new epoch. A resource keeps the epoch and the time of
+// the synchronization that first incorporated its current version.
+type ObservedVersions struct {
+	mu        sync.RWMutex
+	epoch     uint64
+	syncedAt  time.Time
+	resources map[string]AppliedVersion
+}
+
+// AppliedVersion is the version of a source resource incorporated by a successful synchronization.
+type AppliedVersion struct {
+	endpoint.ObservedVersion
+	Epoch    uint64    `json:"epoch"`
+	SyncedAt time.Time `json:"syncedAt"`
+}
+
+// SyncStatus is the status of the synchronizations, as served by ObservedVersions.
+type SyncStatus struct {
+	Epoch     uint64                    `json:"epoch"`
+	SyncedAt  time.Time                 `json:"syncedAt,omitzero"`
+	Resources map[string]AppliedVersion `json:"resources"`
+}
+
+// NewObservedVersions returns an ObservedVersions without any synchronization.
+func NewObservedVersions() *ObservedVersions {
+	return &ObservedVersions{resources: map[string]AppliedVersion{}}
+}
+
+// Synced starts a new epoch with the versions of the resources the applied endpoints were
+// generated from. Resources without endpoints anymore are forgotten.
+func (o *ObservedVersions) Synced(endpoints []*endpoint.Endpoint, now time.Time) {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+
+	o.epoch++
+	o.syncedAt = now
+	resources := make(map[string]AppliedVersion, len(o.resources))
+	for _, ep := range endpoints {
+		resource := ep.Labels[endpoint.ResourceLabelKey]
+		if resource == "" || ep.ObservedVersion.IsZero() {
+			continue
+		}
+		if _, ok := resources[resource]; ok {
+			continue
+		}
+		applied, ok := o.resources[resource]
+		if !ok || applied.ObservedVersion != ep.ObservedVersion {
+			applied = AppliedVersion{ObservedVersion: ep.ObservedVersion, Epoch: o.epoch, SyncedAt: now}
+		}
+		resources[resource] = applied
+	}
+	o.resources = resources
+}
+
+// AppliedVersion returns the version of the resource, identified by its resource label, that
+// was incorporated into the last successful synchronization.
+func (o *ObservedVersions) AppliedVersion(resource string) (endpoint.ObservedVersion, bool) {
+	o.mu.RLock()
+	defer o.mu.RUnlock()
+	applied, ok := o.resources[resource]
+	return applied.ObservedVersion, ok
+}
+
+// Status returns the current epoch and the applied version of every resource.
+func (o *ObservedVersions) Status() SyncStatus {
+	o.mu.RLock()
+	defer o.mu.RUnlock()
+	resources := make(map[string]AppliedVersion, len(o.resources))
+	for resource, applied := range o.resources {
+		resources[resource] = applied
+	}
+	return SyncStatus{Epoch: o.epoch, SyncedAt: o.syncedAt, Resources: resources}
+}
+
+// ServeHTTP serves the sync status as JSON, restricted to a single resource with the resource
+// query parameter, e.g. ?resource=ingress/default/web.
+func (o *ObservedVersions) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		w.Header().Set("Allow", "GET")
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	status := o.Status()
+	if resource := r.URL.Query().Get("resource"); resource != "" {
+		applied, ok := status.Resources[resource]
+		if !ok {
+			http.Error(w, "resource not synchronized: "+resource, http.StatusNotFound)
+			return
+		}
+		status.Resources = map[string]AppliedVersion{resource: applied}
+	}
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(status); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+	}
+}
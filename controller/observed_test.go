@@ -0,0 +1,107 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"sigs.k8s.io/external-dns/endpoint"
+)
+
+func observedEndpoint(dnsName, resource string, generation int64, resourceVersion string) *endpoint.Endpoint {
+	ep := endpoint.NewEndpoint(dnsName, endpoint.RecordTypeA, "1.2.3.4")
+	ep.Labels[endpoint.ResourceLabelKey] = resource
+	ep.ObservedVersion = endpoint.ObservedVersion{Generation: generation, ResourceVersion: resourceVersion}
+	return ep
+}
+
+func TestObservedVersionsSynced(t *testing.T) {
+	o := NewObservedVersions()
+	_, ok := o.AppliedVersion("ingress/default/web")
+	assert.False(t, ok)
+
+	first := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	o.Synced([]*endpoint.Endpoint{
+		observedEndpoint("web.example.org", "ingress/default/web", 1, "100"),
+		observedEndpoint("www.example.org", "ingress/default/web", 1, "100"),
+		observedEndpoint("api.example.org", "service/default/api", 0, "200"),
+		endpoint.NewEndpoint("static.example.org", endpoint.RecordTypeA, "1.2.3.4"),
+	}, first)
+
+	version, ok := o.AppliedVersion("ingress/default/web")
+	require.True(t, ok)
+	assert.Equal(t, endpoint.ObservedVersion{Generation: 1, ResourceVersion: "100"}, version)
+
+	status := o.Status()
+	assert.Equal(t, uint64(1), status.Epoch)
+	assert.Equal(t, first, status.SyncedAt)
+	assert.Len(t, status.Resources, 2)
+
+	second := first.Add(time.Minute)
+	o.Synced([]*endpoint.Endpoint{
+		observedEndpoint("web.example.org", "ingress/default/web", 2, "101"),
+		observedEndpoint("api.example.org", "service/default/api", 0, "200"),
+	}, second)
+
+	status = o.Status()
+	assert.Equal(t, uint64(2), status.Epoch)
+	assert.Equal(t, AppliedVersion{ObservedVersion: endpoint.ObservedVersion{Generation: 2, ResourceVersion: "101"}, Epoch: 2, SyncedAt: second}, status.Resources["ingress/default/web"])
+	assert.Equal(t, AppliedVersion{ObservedVersion: endpoint.ObservedVersion{ResourceVersion: "200"}, Epoch: 1, SyncedAt: first}, status.Resources["service/default/api"], "unchanged resources keep the epoch they were first synchronized at")
+
+	o.Synced(nil, second.Add(time.Minute))
+	_, ok = o.AppliedVersion("ingress/default/web")
+	assert.False(t, ok, "resources without endpoints are forgotten")
+}
+
+func TestObservedVersionsServeHTTP(t *testing.T) {
+	o := NewObservedVersions()
+	o.Synced([]*endpoint.Endpoint{
+		observedEndpoint("web.example.org", "ingress/default/web", 3, "100"),
+		observedEndpoint("api.example.org", "service/default/api", 0, "200"),
+	}, time.Now())
+
+	rec := httptest.NewRecorder()
+	o.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/observed", nil))
+	require.Equal(t, http.StatusOK, rec.Code)
+	var status SyncStatus
+	require.NoError(t, json.NewDecoder(rec.Body).Decode(&status))
+	assert.Equal(t, uint64(1), status.Epoch)
+	assert.Len(t, status.Resources, 2)
+
+	rec = httptest.NewRecorder()
+	o.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/observed?resource=ingress/default/web", nil))
+	require.Equal(t, http.StatusOK, rec.Code)
+	status = SyncStatus{}
+	require.NoError(t, json.NewDecoder(rec.Body).Decode(&status))
+	require.Len(t, status.Resources, 1)
+	assert.Equal(t, int64(3), status.Resources["ingress/default/web"].Generation)
+
+	rec = httptest.NewRecorder()
+	o.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/observed?resource=ingress/default/missing", nil))
+	assert.Equal(t, http.StatusNotFound, rec.Code)
+
+	rec = httptest.NewRecorder()
+	o.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/observed", nil))
+	assert.Equal(t, http.StatusMethodNotAllowed, rec.Code)
+}
@@ -0,0 +1,156 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/prometheus/client_golang/prometheus"
+	log "github.com/sirupsen/logrus"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+	"sigs.k8s.io/yaml"
+
+	"sigs.k8s.io/external-dns/endpoint"
+	"sigs.k8s.io/external-dns/pkg/metrics"
+)
+
+// OverridesConfigMapKey is the key of the overrides ConfigMap holding the overrides, as a YAML list.
+const OverridesConfigMapKey = "overrides.yaml"
+
+var overridesTotal = metrics.NewGaugeWithOpts(
+	prometheus.GaugeOpts{
+		Namespace: "external_dns",
+		Subsystem: "controller",
+		Name:      "overrides",
+		Help:      "Number of overrides declared in the overrides ConfigMap",
+	},
+)
+
+func init() {
+	metrics.RegisterMetric.MustRegister(overridesTotal)
+}
+
+// Override changes the desired endpoints of a DNS name, and of a record type when set: it forces
+// their TTL or targets, or suppresses them.
+type Override struct {
+	DNSName    string   `json:"dnsName"`
+	RecordType string   `json:"recordType,omitempty"`
+	TTL        *int64   `json:"ttl,omitempty"`
+	Targets    []string `json:"targets,omitempty"`
+	Suppress   bool     `json:"suppress,omitempty"`
+}
+
+func (o Override) matches(ep *endpoint.Endpoint) bool {
+	return pinKey(ep.DNSName) == pinKey(o.DNSName) && (o.RecordType == "" || strings.EqualFold(o.RecordType, ep.RecordType))
+}
+
+// ParseOverrides parses and validates the overrides held by an overrides ConfigMap.
+func ParseOverrides(data string) ([]Override, error) {
+	var overrides []Override
+	if err := yaml.UnmarshalStrict([]byte(data), &overrides); err != nil {
+		return nil, fmt.Errorf("parsing overrides: %w", err)
+	}
+	for i, o := range overrides {
+		switch {
+		case o.DNSName == "":
+			return nil, fmt.Errorf("override %d: dnsName is required", i)
+		case !o.Suppress && o.TTL == nil && len(o.Targets) == 0:
+			return nil, fmt.Errorf("override of %s: one of ttl, targets or suppress is required", o.DNSName)
+		case o.Suppress && (o.TTL != nil || len(o.Targets) > 0):
+			return nil, fmt.Errorf("override of %s: suppress cannot be combined with ttl nor targets", o.DNSName)
+		case o.TTL != nil && *o.TTL < 0:
+			return nil, fmt.Errorf("override of %s: ttl cannot be negative", o.DNSName)
+		}
+		overrides[i].RecordType = strings.ToUpper(o.RecordType)
+	}
+	return overrides, nil
+}
+
+// ApplyOverrides returns the desired endpoints with the overrides merged over them, in order. An
+// override forcing the targets of a record type no endpoint is desired for adds that endpoint.
+func ApplyOverrides(desired []*endpoint.Endpoint, overrides []Override) []*endpoint.Endpoint {
+	for _, o := range overrides {
+		matched := false
+		result := desired[:0:0]
+		for _, ep := range desired {
+			if !o.matches(ep) {
+				result = append(result, ep)
+				continue
+			}
+			matched = true
+			if o.Suppress {
+				log.Infof("Overriding %s %s: suppressed", ep.RecordType, ep.DNSName)
+				continue
+			}
+			if o.TTL != nil {
+				ep.RecordTTL = endpoint.TTL(*o.TTL)
+			}
+			if len(o.Targets) > 0 {
+				ep.Targets = endpoint.NewTargets(o.Targets...)
+			}
+			log.Infof("Overriding %s %s: %s", ep.RecordType, ep.DNSName, ep)
+			result = append(result, ep)
+		}
+		if !matched && o.RecordType != "" && len(o.Targets) > 0 {
+			ep := endpoint.NewEndpoint(o.DNSName, o.RecordType, o.Targets...)
+			if o.TTL != nil {
+				ep.RecordTTL = endpoint.TTL(*o.TTL)
+			}
+			log.Infof("Overriding %s %s: added %s", ep.RecordType, ep.DNSName, ep)
+			result = append(result, ep)
+		}
+		desired = result
+	}
+	return desired
+}
+
+// Overrides merges the overrides declared in a ConfigMap over the desired endpoints, as a break-glass
+// control of the records. The ConfigMap is read at every synchronization, so that changes to it
+// apply at the next one; its absence means no override.
+type Overrides struct {
+	client    kubernetes.Interface
+	namespace string
+	name      string
+}
+
+// NewOverrides returns Overrides reading the ConfigMap name in namespace.
+func NewOverrides(client kubernetes.Interface, namespace, name string) *Overrides {
+	return &Overrides{client: client, namespace: namespace, name: name}
+}
+
+// Apply reads the overrides, and merges them over the desired endpoints. Failing to read or to parse
+// them fails, rather than applying changes the overrides may be there to prevent.
+func (o *Overrides) Apply(ctx context.Context, desired []*endpoint.Endpoint) ([]*endpoint.Endpoint, error) {
+	cm, err := o.client.CoreV1().ConfigMaps(o.namespace).Get(ctx, o.name, metav1.GetOptions{})
+	if apierrors.IsNotFound(err) {
+		overridesTotal.Gauge.Set(0)
+		return desired, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("reading overrides ConfigMap %s/%s: %w", o.namespace, o.name, err)
+	}
+	overrides, err := ParseOverrides(cm.Data[OverridesConfigMapKey])
+	if err != nil {
+		return nil, fmt.Errorf("overrides ConfigMap %s/%s: %w", o.namespace, o.name, err)
+	}
+	overridesTotal.Gauge.Set(float64(len(overrides)))
+	return ApplyOverrides(desired, overrides), nil
+}
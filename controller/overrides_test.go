@@ -0,0 +1,124 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+
+	"sigs.k8s.io/external-dns/endpoint"
+)
+
+func TestParseOverrides(t *testing.T) {
+	overrides, err := ParseOverrides(`
+- dnsName: api.example.org
+  recordType: a
+  ttl: 60
+  targets: [192.0.2.1]
+- dnsName: legacy.example.org
+  suppress: true
+`)
+	require.NoError(t, err)
+	ttl := int64(60)
+	assert.Equal(t, []Override{
+		{DNSName: "api.example.org", RecordType: "A", TTL: &ttl, Targets: []string{"192.0.2.1"}},
+		{DNSName: "legacy.example.org", Suppress: true},
+	}, overrides)
+
+	overrides, err = ParseOverrides("")
+	require.NoError(t, err)
+	assert.Empty(t, overrides)
+
+	for _, tt := range []struct {
+		data string
+		err  string
+	}{
+		{data: "- recordType: A\n  ttl: 60", err: "override 0: dnsName is required"},
+		{data: "- dnsName: api.example.org", err: "override of api.example.org: one of ttl, targets or suppress is required"},
+		{data: "- dnsName: api.example.org\n  suppress: true\n  ttl: 60", err: "override of api.example.org: suppress cannot be combined with ttl nor targets"},
+		{data: "- dnsName: api.example.org\n  ttl: -1", err: "override of api.example.org: ttl cannot be negative"},
+		{data: "- dnsName: api.example.org\n  target: 192.0.2.1", err: "parsing overrides"},
+	} {
+		_, err := ParseOverrides(tt.data)
+		assert.ErrorContains(t, err, tt.err)
+	}
+}
+
+func TestApplyOverrides(t *testing.T) {
+	desired := []*endpoint.Endpoint{
+		endpoint.NewEndpointWithTTL("api.example.org", endpoint.RecordTypeA, 300, "10.0.0.1"),
+		endpoint.NewEndpointWithTTL("api.example.org", endpoint.RecordTypeAAAA, 300, "2001:db8::1"),
+		endpoint.NewEndpoint("www.example.org", endpoint.RecordTypeCNAME, "lb.example.org"),
+		endpoint.NewEndpoint("legacy.example.org", endpoint.RecordTypeA, "10.0.0.2"),
+		endpoint.NewEndpoint("other.example.org", endpoint.RecordTypeA, "10.0.0.3"),
+	}
+	ttl, zero := int64(60), int64(0)
+	result := ApplyOverrides(desired, []Override{
+		{DNSName: "API.example.org.", TTL: &ttl},
+		{DNSName: "www.example.org", RecordType: endpoint.RecordTypeCNAME, Targets: []string{"failover.example.net"}, TTL: &zero},
+		{DNSName: "legacy.example.org", Suppress: true},
+		{DNSName: "maintenance.example.org", RecordType: endpoint.RecordTypeA, Targets: []string{"192.0.2.1"}},
+		{DNSName: "absent.example.org", Targets: []string{"192.0.2.1"}},
+	})
+
+	got := map[string]string{}
+	for _, ep := range result {
+		got[ep.RecordType+" "+ep.DNSName] = fmt.Sprintf("%s/%d", ep.Targets, ep.RecordTTL)
+	}
+	assert.Equal(t, map[string]string{
+		"A api.example.org":         "10.0.0.1/60",
+		"AAAA api.example.org":      "2001:db8::1/60",
+		"CNAME www.example.org":     "failover.example.net/0",
+		"A other.example.org":       "10.0.0.3/0",
+		"A maintenance.example.org": "192.0.2.1/0",
+	}, got)
+}
+
+func TestOverridesApply(t *testing.T) {
+	client := fake.NewSimpleClientset()
+	o := NewOverrides(client, "external-dns", "overrides")
+	desired := []*endpoint.Endpoint{endpoint.NewEndpoint("legacy.example.org", endpoint.RecordTypeA, "10.0.0.2")}
+
+	// no ConfigMap, no override
+	result, err := o.Apply(context.Background(), desired)
+	require.NoError(t, err)
+	assert.Len(t, result, 1)
+
+	cm := &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "external-dns", Name: "overrides"},
+		Data:       map[string]string{OverridesConfigMapKey: "- dnsName: legacy.example.org\n  suppress: true\n"},
+	}
+	_, err = client.CoreV1().ConfigMaps("external-dns").Create(context.Background(), cm, metav1.CreateOptions{})
+	require.NoError(t, err)
+	result, err = o.Apply(context.Background(), desired)
+	require.NoError(t, err)
+	assert.Empty(t, result)
+
+	// invalid overrides fail the synchronization instead of being ignored
+	cm.Data[OverridesConfigMapKey] = "- suppress: true\n"
+	_, err = client.CoreV1().ConfigMaps("external-dns").Update(context.Background(), cm, metav1.UpdateOptions{})
+	require.NoError(t, err)
+	_, err = o.Apply(context.Background(), desired)
+	assert.EqualError(t, err, "overrides ConfigMap external-dns/overrides: override 0: dnsName is required")
+}
@@ -0,0 +1,73 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"fmt"
+	"slices"
+
+	log "github.com/sirupsen/logrus"
+
+	"sigs.k8s.io/external-dns/endpoint"
+	"sigs.k8s.io/external-dns/registry"
+)
+
+// TransferOwnership hands the records of the given names owned by the registry owner over to newOwnerID,
+// so that another ExternalDNS instance manages them from then on. With dryRun, the records are only logged.
+func TransferOwnership(ctx context.Context, reg registry.Registry, names []string, newOwnerID string, dryRun bool) error {
+	transferrer, ok := reg.(registry.OwnershipTransferrer)
+	if !ok {
+		return fmt.Errorf("the registry does not support transferring the ownership of records")
+	}
+
+	records, err := reg.Records(ctx)
+	if err != nil {
+		return err
+	}
+
+	var transferred []*endpoint.Endpoint
+	found := map[string]bool{}
+	for _, r := range records {
+		if !slices.Contains(names, r.DNSName) || r.RecordType == endpoint.RecordTypeTXT {
+			continue
+		}
+		found[r.DNSName] = true
+		if owner := r.Labels[endpoint.OwnerLabelKey]; owner != reg.OwnerID() {
+			log.Warnf("Skipping %s record %q owned by %q instead of %q", r.RecordType, r.DNSName, owner, reg.OwnerID())
+			continue
+		}
+		transferred = append(transferred, r)
+	}
+	for _, name := range names {
+		if !found[name] {
+			log.Warnf("No record found for %q", name)
+		}
+	}
+
+	for _, r := range transferred {
+		if dryRun {
+			log.Infof("Would transfer the ownership of %s record %q from %q to %q", r.RecordType, r.DNSName, reg.OwnerID(), newOwnerID)
+		} else {
+			log.Infof("Transferring the ownership of %s record %q from %q to %q", r.RecordType, r.DNSName, reg.OwnerID(), newOwnerID)
+		}
+	}
+	if dryRun || len(transferred) == 0 {
+		return nil
+	}
+	return transferrer.TransferOwnership(ctx, transferred, newOwnerID)
+}
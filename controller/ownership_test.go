@@ -0,0 +1,80 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"testing"
+
+	log "github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"sigs.k8s.io/external-dns/endpoint"
+	"sigs.k8s.io/external-dns/internal/testutils"
+	"sigs.k8s.io/external-dns/plan"
+	"sigs.k8s.io/external-dns/provider/inmemory"
+	"sigs.k8s.io/external-dns/registry"
+)
+
+func ownersByName(t *testing.T, reg registry.Registry) map[string]string {
+	t.Helper()
+	records, err := reg.Records(context.Background())
+	require.NoError(t, err)
+	owners := map[string]string{}
+	for _, r := range records {
+		if r.RecordType == endpoint.RecordTypeA {
+			owners[r.DNSName] = r.Labels[endpoint.OwnerLabelKey]
+		}
+	}
+	return owners
+}
+
+func TestTransferOwnership(t *testing.T) {
+	ctx := context.Background()
+	p := inmemory.NewInMemoryProvider()
+	require.NoError(t, p.CreateZone("example.org"))
+
+	other, err := registry.NewTXTRegistry(p, "", "", "red", 0, "", []string{endpoint.RecordTypeA}, nil, false, nil, false)
+	require.NoError(t, err)
+	require.NoError(t, other.ApplyChanges(ctx, &plan.Changes{Create: []*endpoint.Endpoint{
+		endpoint.NewEndpoint("red.example.org", endpoint.RecordTypeA, "1.1.1.1"),
+	}}))
+	reg, err := registry.NewTXTRegistry(p, "", "", "blue", 0, "", []string{endpoint.RecordTypeA}, nil, false, nil, false)
+	require.NoError(t, err)
+	require.NoError(t, reg.ApplyChanges(ctx, &plan.Changes{Create: []*endpoint.Endpoint{
+		endpoint.NewEndpoint("api.example.org", endpoint.RecordTypeA, "1.1.1.1"),
+		endpoint.NewEndpoint("www.example.org", endpoint.RecordTypeA, "1.1.1.1"),
+	}}))
+	names := []string{"api.example.org", "red.example.org", "missing.example.org"}
+
+	hook := testutils.LogsUnderTestWithLogLevel(log.InfoLevel, t)
+	require.NoError(t, TransferOwnership(ctx, reg, names, "green", true))
+	testutils.TestHelperLogContainsWithLogLevel(`Would transfer the ownership of A record "api.example.org" from "blue" to "green"`, log.InfoLevel, hook, t)
+	testutils.TestHelperLogContainsWithLogLevel(`Skipping A record "red.example.org" owned by "red" instead of "blue"`, log.WarnLevel, hook, t)
+	testutils.TestHelperLogContainsWithLogLevel(`No record found for "missing.example.org"`, log.WarnLevel, hook, t)
+	assert.Equal(t, map[string]string{"api.example.org": "blue", "www.example.org": "blue", "red.example.org": "red"}, ownersByName(t, reg))
+
+	require.NoError(t, TransferOwnership(ctx, reg, names, "green", false))
+	assert.Equal(t, map[string]string{"api.example.org": "green", "www.example.org": "blue", "red.example.org": "red"}, ownersByName(t, reg))
+}
+
+func TestTransferOwnershipUnsupportedRegistry(t *testing.T) {
+	reg, err := registry.NewNoopRegistry(inmemory.NewInMemoryProvider())
+	require.NoError(t, err)
+	assert.EqualError(t, TransferOwnership(context.Background(), reg, []string{"www.example.org"}, "green", false), "the registry does not support transferring the ownership of records")
+}
@@ -0,0 +1,219 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"slices"
+	"sort"
+	"strings"
+	"sync"
+
+	"sigs.k8s.io/external-dns/endpoint"
+	"sigs.k8s.io/external-dns/registry"
+)
+
+// ZoneOwnership holds the records of a zone managed by ExternalDNS, sorted by name.
+type ZoneOwnership struct {
+	Zone    string        `json:"zone"`
+	Records []OwnedRecord `json:"records"`
+}
+
+// OwnedRecord is a record managed by ExternalDNS with its owner chain: the ExternalDNS instance owning
+// it, then the namespace and the source resource it was created for, when known.
+type OwnedRecord struct {
+	Name          string `json:"name"`
+	RecordType    string `json:"recordType"`
+	SetIdentifier string `json:"setIdentifier,omitempty"`
+	Owner         string `json:"owner"`
+	Namespace     string `json:"namespace,omitempty"`
+	Resource      string `json:"resource,omitempty"`
+}
+
+// OwnershipFilter restricts an ownership report to the records of some namespaces and owners, an empty
+// list matching all of them.
+type OwnershipFilter struct {
+	Namespaces []string
+	Owners     []string
+}
+
+func (f OwnershipFilter) match(r OwnedRecord) bool {
+	return (len(f.Namespaces) == 0 || slices.Contains(f.Namespaces, r.Namespace)) &&
+		(len(f.Owners) == 0 || slices.Contains(f.Owners, r.Owner))
+}
+
+// NewOwnershipReport groups the records with an owner matching filter by zone, the zones being the given
+// domain filters. Records outside of all of them are grouped under their registrable domain. Records
+// without owner are not managed by ExternalDNS and are skipped.
+func NewOwnershipReport(records []*endpoint.Endpoint, zones []string, filter OwnershipFilter) []ZoneOwnership {
+	zoneIDName := newZoneIDName(zones)
+	perZone := map[string]*ZoneOwnership{}
+	for _, r := range records {
+		owner := r.Labels[endpoint.OwnerLabelKey]
+		if owner == "" {
+			continue
+		}
+		resource := r.Labels[endpoint.ResourceLabelKey]
+		record := OwnedRecord{
+			Name:          r.DNSName,
+			RecordType:    r.RecordType,
+			SetIdentifier: r.SetIdentifier,
+			Owner:         owner,
+			Namespace:     labelledNamespace(resource),
+			Resource:      resource,
+		}
+		if !filter.match(record) {
+			continue
+		}
+		zone := recordZone(zoneIDName, r.DNSName)
+		if _, ok := perZone[zone]; !ok {
+			perZone[zone] = &ZoneOwnership{Zone: zone}
+		}
+		perZone[zone].Records = append(perZone[zone].Records, record)
+	}
+
+	report := make([]ZoneOwnership, 0, len(perZone))
+	for _, z := range perZone {
+		sort.Slice(z.Records, func(i, j int) bool {
+			a, b := z.Records[i], z.Records[j]
+			if a.Name != b.Name {
+				return a.Name < b.Name
+			}
+			if a.RecordType != b.RecordType {
+				return a.RecordType < b.RecordType
+			}
+			return a.SetIdentifier < b.SetIdentifier
+		})
+		report = append(report, *z)
+	}
+	sort.Slice(report, func(i, j int) bool { return report[i].Zone < report[j].Zone })
+	return report
+}
+
+// labelledNamespace returns the namespace of a resource labelled as kind/namespace/name.
+func labelledNamespace(resource string) string {
+	parts := strings.Split(resource, "/")
+	if len(parts) != 3 {
+		return ""
+	}
+	return parts[1]
+}
+
+// Ownership reads the registry and returns the records it manages matching filter, grouped by zone.
+func Ownership(ctx context.Context, reg registry.Registry, zones []string, filter OwnershipFilter) ([]ZoneOwnership, error) {
+	records, err := reg.Records(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return NewOwnershipReport(records, zones, filter), nil
+}
+
+// WriteOwnership prints the records of every zone as text, grouped by name, with their owner chain.
+func WriteOwnership(w io.Writer, report []ZoneOwnership) error {
+	if len(report) == 0 {
+		_, err := fmt.Fprintln(w, "No records")
+		return err
+	}
+	for _, z := range report {
+		if _, err := fmt.Fprintf(w, "%s: %d records\n", z.Zone, len(z.Records)); err != nil {
+			return err
+		}
+		for i, r := range z.Records {
+			if i == 0 || r.Name != z.Records[i-1].Name {
+				if _, err := fmt.Fprintf(w, "  %s\n", r.Name); err != nil {
+					return err
+				}
+			}
+			chain := []string{"owner " + r.Owner}
+			if r.Namespace != "" {
+				chain = append(chain, "namespace "+r.Namespace)
+			}
+			if r.Resource != "" {
+				chain = append(chain, r.Resource)
+			}
+			recordType := r.RecordType
+			if r.SetIdentifier != "" {
+				recordType += " (" + r.SetIdentifier + ")"
+			}
+			if _, err := fmt.Fprintf(w, "    %s: %s\n", recordType, strings.Join(chain, " > ")); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// WriteOwnershipJSON prints the records of every zone as JSON.
+func WriteOwnershipJSON(w io.Writer, report []ZoneOwnership) error {
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(struct {
+		Zones []ZoneOwnership `json:"zones"`
+	}{Zones: report})
+}
+
+// OwnershipReporter keeps the records read from the registry by the last synchronization, to serve the
+// ownership report over HTTP.
+type OwnershipReporter struct {
+	zones []string
+
+	mu      sync.Mutex
+	records []*endpoint.Endpoint
+}
+
+// NewOwnershipReporter returns an OwnershipReporter grouping records by the given zones.
+func NewOwnershipReporter(zones []string) *OwnershipReporter {
+	return &OwnershipReporter{zones: zones}
+}
+
+// Update replaces the reported records with the ones read from the registry.
+func (o *OwnershipReporter) Update(records []*endpoint.Endpoint) {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	o.records = records
+}
+
+// ServeHTTP serves the ownership report as JSON, or as text with ?output=text, restricted to some namespaces
+// and owners with the namespace and owner query parameters, e.g. ?namespace=team-a&namespace=team-b.
+func (o *OwnershipReporter) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		w.Header().Set("Allow", "GET")
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	query := r.URL.Query()
+	filter := OwnershipFilter{Namespaces: query["namespace"], Owners: query["owner"]}
+	o.mu.Lock()
+	report := NewOwnershipReport(o.records, o.zones, filter)
+	o.mu.Unlock()
+
+	var err error
+	if query.Get("output") == "text" {
+		w.Header().Set("Content-Type", "text/plain; charset=utf-8")
+		err = WriteOwnership(w, report)
+	} else {
+		w.Header().Set("Content-Type", "application/json")
+		err = WriteOwnershipJSON(w, report)
+	}
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+	}
+}
@@ -0,0 +1,185 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"sigs.k8s.io/external-dns/endpoint"
+	"sigs.k8s.io/external-dns/internal/testutils"
+	"sigs.k8s.io/external-dns/plan"
+	"sigs.k8s.io/external-dns/provider/inmemory"
+	"sigs.k8s.io/external-dns/registry"
+)
+
+func ownedEndpoint(name, recordType, owner, resource string) *endpoint.Endpoint {
+	ep := endpoint.NewEndpoint(name, recordType, "1.1.1.1")
+	if owner != "" {
+		ep.Labels[endpoint.OwnerLabelKey] = owner
+	}
+	if resource != "" {
+		ep.Labels[endpoint.ResourceLabelKey] = resource
+	}
+	return ep
+}
+
+func testOwnershipRecords() []*endpoint.Endpoint {
+	return []*endpoint.Endpoint{
+		ownedEndpoint("www.example.com", endpoint.RecordTypeA, "cluster-a", "ingress/team-a/web"),
+		ownedEndpoint("api.example.com", endpoint.RecordTypeAAAA, "cluster-a", "service/team-b/api"),
+		ownedEndpoint("api.example.com", endpoint.RecordTypeA, "cluster-a", "service/team-b/api"),
+		ownedEndpoint("app.example.co.uk", endpoint.RecordTypeA, "cluster-b", "crd/team-a/app").WithSetIdentifier("eu"),
+		ownedEndpoint("static.example.com", endpoint.RecordTypeA, "cluster-b", ""),
+		ownedEndpoint("manual.example.com", endpoint.RecordTypeA, "", ""),
+	}
+}
+
+func TestNewOwnershipReport(t *testing.T) {
+	report := NewOwnershipReport(testOwnershipRecords(), []string{"example.com"}, OwnershipFilter{})
+
+	require.Len(t, report, 2)
+	assert.Equal(t, "example.co.uk", report[0].Zone, "names outside of the domain filters are grouped by registrable domain")
+	assert.Equal(t, "example.com", report[1].Zone)
+	require.Len(t, report[1].Records, 4, "records without owner are skipped")
+	assert.Equal(t, OwnedRecord{Name: "api.example.com", RecordType: "A", Owner: "cluster-a", Namespace: "team-b", Resource: "service/team-b/api"}, report[1].Records[0])
+	assert.Equal(t, "AAAA", report[1].Records[1].RecordType)
+	assert.Equal(t, OwnedRecord{Name: "static.example.com", RecordType: "A", Owner: "cluster-b"}, report[1].Records[2])
+
+	assert.Empty(t, NewOwnershipReport(nil, nil, OwnershipFilter{}))
+}
+
+func TestNewOwnershipReportFilter(t *testing.T) {
+	report := NewOwnershipReport(testOwnershipRecords(), []string{"example.com"}, OwnershipFilter{Namespaces: []string{"team-a"}})
+	require.Len(t, report, 2)
+	assert.Equal(t, "app.example.co.uk", report[0].Records[0].Name)
+	assert.Equal(t, "www.example.com", report[1].Records[0].Name)
+
+	report = NewOwnershipReport(testOwnershipRecords(), []string{"example.com"}, OwnershipFilter{Namespaces: []string{"team-a"}, Owners: []string{"cluster-a"}})
+	require.Len(t, report, 1)
+	require.Len(t, report[0].Records, 1)
+	assert.Equal(t, "www.example.com", report[0].Records[0].Name)
+}
+
+func TestWriteOwnership(t *testing.T) {
+	report := NewOwnershipReport(testOwnershipRecords(), []string{"example.com"}, OwnershipFilter{})
+
+	var b bytes.Buffer
+	require.NoError(t, WriteOwnership(&b, report))
+	assert.Equal(t, `example.co.uk: 1 records
+  app.example.co.uk
+    A (eu): owner cluster-b > namespace team-a > crd/team-a/app
+example.com: 4 records
+  api.example.com
+    A: owner cluster-a > namespace team-b > service/team-b/api
+    AAAA: owner cluster-a > namespace team-b > service/team-b/api
+  static.example.com
+    A: owner cluster-b
+  www.example.com
+    A: owner cluster-a > namespace team-a > ingress/team-a/web
+`, b.String())
+
+	b.Reset()
+	require.NoError(t, WriteOwnership(&b, nil))
+	assert.Equal(t, "No records\n", b.String())
+}
+
+func TestWriteOwnershipJSON(t *testing.T) {
+	report := NewOwnershipReport(testOwnershipRecords(), []string{"example.com"}, OwnershipFilter{Owners: []string{"cluster-b"}})
+
+	var b bytes.Buffer
+	require.NoError(t, WriteOwnershipJSON(&b, report))
+	var decoded struct {
+		Zones []ZoneOwnership `json:"zones"`
+	}
+	require.NoError(t, json.Unmarshal(b.Bytes(), &decoded))
+	assert.Equal(t, report, decoded.Zones)
+}
+
+func TestOwnership(t *testing.T) {
+	ctx := context.Background()
+	p := inmemory.NewInMemoryProvider()
+	require.NoError(t, p.CreateZone("example.org"))
+	reg, err := registry.NewTXTRegistry(p, "", "", "blue", 0, "", []string{endpoint.RecordTypeA}, nil, false, nil, false)
+	require.NoError(t, err)
+	require.NoError(t, reg.ApplyChanges(ctx, &plan.Changes{Create: []*endpoint.Endpoint{
+		ownedEndpoint("api.example.org", endpoint.RecordTypeA, "", "ingress/team-a/api"),
+	}}))
+
+	report, err := Ownership(ctx, reg, []string{"example.org"}, OwnershipFilter{})
+	require.NoError(t, err)
+	require.Len(t, report, 1)
+	assert.Equal(t, []OwnedRecord{{Name: "api.example.org", RecordType: "A", Owner: "blue", Namespace: "team-a", Resource: "ingress/team-a/api"}}, report[0].Records)
+}
+
+func TestOwnershipReporterServeHTTP(t *testing.T) {
+	o := NewOwnershipReporter([]string{"example.com"})
+	o.Update(testOwnershipRecords())
+
+	rec := httptest.NewRecorder()
+	o.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/ownership?namespace=team-b", nil))
+	require.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, "application/json", rec.Header().Get("Content-Type"))
+	var decoded struct {
+		Zones []ZoneOwnership `json:"zones"`
+	}
+	require.NoError(t, json.NewDecoder(rec.Body).Decode(&decoded))
+	require.Len(t, decoded.Zones, 1)
+	assert.Len(t, decoded.Zones[0].Records, 2)
+
+	rec = httptest.NewRecorder()
+	o.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/ownership?owner=cluster-b&output=text", nil))
+	require.Equal(t, http.StatusOK, rec.Code)
+	assert.Contains(t, rec.Body.String(), "  static.example.com\n    A: owner cluster-b\n")
+	assert.NotContains(t, rec.Body.String(), "cluster-a")
+
+	rec = httptest.NewRecorder()
+	o.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/ownership", nil))
+	assert.Equal(t, http.StatusMethodNotAllowed, rec.Code)
+}
+
+func TestControllerUpdatesOwnership(t *testing.T) {
+	ctx := context.Background()
+	p := inmemory.NewInMemoryProvider(inmemory.InMemoryInitZones([]string{"example.com"}))
+	r, err := registry.NewTXTRegistry(p, "", "", "me", 0, "", []string{endpoint.RecordTypeA}, nil, false, nil, false)
+	require.NoError(t, err)
+	app := endpoint.NewEndpoint("app.example.com", endpoint.RecordTypeA, "1.1.1.1")
+	require.NoError(t, r.ApplyChanges(ctx, &plan.Changes{Create: []*endpoint.Endpoint{app}}))
+
+	source := new(testutils.MockSource)
+	source.On("Endpoints").Return([]*endpoint.Endpoint{app}, nil)
+	ctrl := &Controller{
+		Source:             source,
+		Registry:           r,
+		Policy:             &plan.SyncPolicy{},
+		ManagedRecordTypes: []string{endpoint.RecordTypeA},
+		Ownership:          NewOwnershipReporter([]string{"example.com"}),
+	}
+	_, err = ctrl.calculatePlan(ctx)
+	require.NoError(t, err)
+
+	rec := httptest.NewRecorder()
+	ctrl.Ownership.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/ownership?output=text", nil))
+	assert.Equal(t, "example.com: 1 records\n  app.example.com\n    A: owner me\n", rec.Body.String())
+}
@@ -0,0 +1,110 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"time"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/dynamic"
+)
+
+// PendingPlanApprovedAnnotation approves a PendingPlan object when set to "true".
+const PendingPlanApprovedAnnotation = "externaldns.k8s.io/approved"
+
+// PendingPlanGVR is the resource of the PendingPlan objects publishing changes waiting for approval.
+var PendingPlanGVR = schema.GroupVersionResource{
+	Group:    "externaldns.k8s.io",
+	Version:  "v1alpha1",
+	Resource: "pendingplans",
+}
+
+// PendingPlanStore publishes pending changes for review, and reports whether they got approved.
+type PendingPlanStore interface {
+	Publish(ctx context.Context, pending PendingChanges) error
+	Approved(ctx context.Context, id string) (bool, error)
+	Delete(ctx context.Context, id string) error
+}
+
+// pendingPlanObjects stores pending changes as PendingPlan objects, approved by annotating them
+// with PendingPlanApprovedAnnotation, e.g. with kubectl annotate.
+type pendingPlanObjects struct {
+	client  dynamic.ResourceInterface
+	ownerID string
+}
+
+// NewPendingPlanStore returns a PendingPlanStore creating PendingPlan objects in the given namespace.
+func NewPendingPlanStore(client dynamic.Interface, namespace, ownerID string) PendingPlanStore {
+	return &pendingPlanObjects{
+		client:  client.Resource(PendingPlanGVR).Namespace(namespace),
+		ownerID: ownerID,
+	}
+}
+
+func pendingPlanName(id string) string {
+	return "plan-" + id
+}
+
+func (s *pendingPlanObjects) Publish(ctx context.Context, pending PendingChanges) error {
+	changes := make([]interface{}, 0, len(pending.Changes))
+	for _, c := range pending.Changes {
+		changes = append(changes, c)
+	}
+	spec := map[string]interface{}{
+		"id":      pending.ID,
+		"owner":   s.ownerID,
+		"since":   pending.Since.UTC().Format(time.RFC3339),
+		"changes": changes,
+	}
+	if pending.ExpiresAt != nil {
+		spec["expiresAt"] = pending.ExpiresAt.UTC().Format(time.RFC3339)
+	}
+
+	obj := &unstructured.Unstructured{Object: map[string]interface{}{"spec": spec}}
+	obj.SetAPIVersion(PendingPlanGVR.GroupVersion().String())
+	obj.SetKind("PendingPlan")
+	obj.SetName(pendingPlanName(pending.ID))
+	obj.SetLabels(map[string]string{auditManagedByLabel: "external-dns"})
+	_, err := s.client.Create(ctx, obj, metav1.CreateOptions{})
+	if apierrors.IsAlreadyExists(err) {
+		return nil
+	}
+	return err
+}
+
+func (s *pendingPlanObjects) Approved(ctx context.Context, id string) (bool, error) {
+	obj, err := s.client.Get(ctx, pendingPlanName(id), metav1.GetOptions{})
+	if apierrors.IsNotFound(err) {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+	return obj.GetAnnotations()[PendingPlanApprovedAnnotation] == "true", nil
+}
+
+func (s *pendingPlanObjects) Delete(ctx context.Context, id string) error {
+	err := s.client.Delete(ctx, pendingPlanName(id), metav1.DeleteOptions{})
+	if apierrors.IsNotFound(err) {
+		return nil
+	}
+	return err
+}
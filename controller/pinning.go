@@ -0,0 +1,112 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"sort"
+	"strings"
+
+	log "github.com/sirupsen/logrus"
+
+	"sigs.k8s.io/external-dns/endpoint"
+)
+
+// Pinner freezes the records of pinned DNS names at their values when they got pinned, typically
+// during incident response. A DNS name is pinned when it is listed on the command line, or when an
+// endpoint desired for it carries the pinned property.
+//
+// The values are recorded in memory: after a restart, the records of names still pinned are frozen
+// at their values at that time.
+type Pinner struct {
+	names     map[string]bool
+	snapshots map[string][]*endpoint.Endpoint
+}
+
+// NewPinner returns a Pinner pinning the given DNS names, on top of the ones pinned by endpoints.
+func NewPinner(names []string) *Pinner {
+	p := &Pinner{
+		names:     make(map[string]bool, len(names)),
+		snapshots: map[string][]*endpoint.Endpoint{},
+	}
+	for _, name := range names {
+		p.names[pinKey(name)] = true
+	}
+	return p
+}
+
+func pinKey(name string) string {
+	return strings.ToLower(strings.TrimSuffix(name, "."))
+}
+
+// Apply returns the desired endpoints where the endpoints of pinned DNS names are replaced by the
+// records owned by ownerID those names had when they got pinned, so that plans keep asserting them.
+func (p *Pinner) Apply(desired, current []*endpoint.Endpoint, ownerID string) []*endpoint.Endpoint {
+	pinned := make(map[string]bool, len(p.names))
+	for name := range p.names {
+		pinned[name] = true
+	}
+	for _, ep := range desired {
+		if v, ok := ep.GetProviderSpecificProperty(endpoint.PinnedProperty); ok {
+			if v == "true" {
+				pinned[pinKey(ep.DNSName)] = true
+			}
+			ep.DeleteProviderSpecificProperty(endpoint.PinnedProperty)
+		}
+	}
+
+	for name := range p.snapshots {
+		if !pinned[name] {
+			log.Infof("Unpinning records of %s", name)
+			delete(p.snapshots, name)
+		}
+	}
+	for name := range pinned {
+		if _, ok := p.snapshots[name]; ok {
+			continue
+		}
+		snapshot := []*endpoint.Endpoint{}
+		for _, ep := range current {
+			if pinKey(ep.DNSName) == name && ep.Labels[endpoint.OwnerLabelKey] == ownerID {
+				snapshot = append(snapshot, ep.DeepCopy())
+			}
+		}
+		log.Infof("Pinning %d records of %s at their current values", len(snapshot), name)
+		p.snapshots[name] = snapshot
+	}
+	pinnedNames.Gauge.Set(float64(len(p.snapshots)))
+
+	if len(p.snapshots) == 0 {
+		return desired
+	}
+	result := make([]*endpoint.Endpoint, 0, len(desired))
+	for _, ep := range desired {
+		if _, ok := p.snapshots[pinKey(ep.DNSName)]; !ok {
+			result = append(result, ep)
+		}
+	}
+	names := make([]string, 0, len(p.snapshots))
+	for name := range p.snapshots {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		for _, ep := range p.snapshots[name] {
+			result = append(result, ep.DeepCopy())
+		}
+	}
+	return result
+}
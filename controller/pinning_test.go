@@ -0,0 +1,147 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"sigs.k8s.io/external-dns/endpoint"
+	"sigs.k8s.io/external-dns/internal/testutils"
+	"sigs.k8s.io/external-dns/plan"
+	"sigs.k8s.io/external-dns/provider/inmemory"
+	"sigs.k8s.io/external-dns/registry"
+)
+
+func TestPinnerApply(t *testing.T) {
+	owned := func(e *endpoint.Endpoint, owner string) *endpoint.Endpoint {
+		e.Labels[endpoint.OwnerLabelKey] = owner
+		return e
+	}
+	current := []*endpoint.Endpoint{
+		owned(endpoint.NewEndpoint("cli.example.com", endpoint.RecordTypeA, "1.1.1.1"), "me"),
+		owned(endpoint.NewEndpoint("cli.example.com", endpoint.RecordTypeAAAA, "2001:db8::1"), "me"),
+		owned(endpoint.NewEndpoint("cli.example.com", endpoint.RecordTypeTXT, "foreign"), "other"),
+		owned(endpoint.NewEndpoint("annotated.example.com", endpoint.RecordTypeA, "1.1.1.1"), "me"),
+		owned(endpoint.NewEndpoint("free.example.com", endpoint.RecordTypeA, "1.1.1.1"), "me"),
+	}
+	desired := func() []*endpoint.Endpoint {
+		return []*endpoint.Endpoint{
+			endpoint.NewEndpoint("cli.example.com", endpoint.RecordTypeA, "2.2.2.2"),
+			endpoint.NewEndpoint("annotated.example.com", endpoint.RecordTypeA, "2.2.2.2").WithProviderSpecific(endpoint.PinnedProperty, "true"),
+			endpoint.NewEndpoint("free.example.com", endpoint.RecordTypeA, "2.2.2.2").WithProviderSpecific(endpoint.PinnedProperty, "false"),
+			endpoint.NewEndpoint("new.example.com", endpoint.RecordTypeA, "2.2.2.2"),
+		}
+	}
+
+	p := NewPinner([]string{"CLI.example.com."})
+	result := p.Apply(desired(), current, "me")
+
+	byName := map[string][]string{}
+	for _, ep := range result {
+		byName[ep.DNSName] = append(byName[ep.DNSName], ep.RecordType+"="+ep.Targets.String())
+		_, ok := ep.GetProviderSpecificProperty(endpoint.PinnedProperty)
+		assert.False(t, ok, "pinned property is not passed along")
+	}
+	assert.Equal(t, map[string][]string{
+		"cli.example.com":       {"A=1.1.1.1", "AAAA=2001:db8::1"},
+		"annotated.example.com": {"A=1.1.1.1"},
+		"free.example.com":      {"A=2.2.2.2"},
+		"new.example.com":       {"A=2.2.2.2"},
+	}, byName)
+
+	// values are frozen when pinned, not when applied
+	changed := []*endpoint.Endpoint{owned(endpoint.NewEndpoint("annotated.example.com", endpoint.RecordTypeA, "3.3.3.3"), "me")}
+	result = p.Apply(desired(), changed, "me")
+	for _, ep := range result {
+		if ep.DNSName == "annotated.example.com" {
+			assert.Equal(t, endpoint.Targets{"1.1.1.1"}, ep.Targets)
+		}
+	}
+
+	// unpinned names get their desired endpoints back
+	result = p.Apply([]*endpoint.Endpoint{endpoint.NewEndpoint("annotated.example.com", endpoint.RecordTypeA, "2.2.2.2")}, changed, "me")
+	require.Len(t, result, 3)
+	assert.Equal(t, "cli.example.com", result[1].DNSName)
+	assert.Equal(t, endpoint.Targets{"2.2.2.2"}, result[0].Targets)
+}
+
+func TestRunOncePinnedRecords(t *testing.T) {
+	ctx := context.Background()
+	p := inmemory.NewInMemoryProvider(inmemory.InMemoryInitZones([]string{"example.com"}))
+	r, err := registry.NewTXTRegistry(p, "", "", "me", 0, "", []string{endpoint.RecordTypeA}, nil, false, nil, false)
+	require.NoError(t, err)
+
+	source := new(testutils.MockSource)
+	source.On("Endpoints").Return([]*endpoint.Endpoint{endpoint.NewEndpoint("app.example.com", endpoint.RecordTypeA, "1.1.1.1")}, nil).Once()
+	ctrl := &Controller{
+		Source:             source,
+		Registry:           r,
+		Policy:             &plan.SyncPolicy{},
+		ManagedRecordTypes: []string{endpoint.RecordTypeA},
+		Pinner:             NewPinner([]string{"app.example.com"}),
+	}
+	targets := func() endpoint.Targets {
+		t.Helper()
+		records, err := p.Records(ctx)
+		require.NoError(t, err)
+		for _, ep := range records {
+			if ep.RecordType == endpoint.RecordTypeA {
+				return ep.Targets
+			}
+		}
+		return nil
+	}
+
+	// a pinned name that does not exist yet is not created
+	require.NoError(t, ctrl.RunOnce(ctx))
+	assert.Nil(t, targets())
+
+	// once created and pinned, changes are refused
+	ctrl.Pinner = nil
+	source.On("Endpoints").Return([]*endpoint.Endpoint{endpoint.NewEndpoint("app.example.com", endpoint.RecordTypeA, "1.1.1.1")}, nil).Once()
+	require.NoError(t, ctrl.RunOnce(ctx))
+	require.Equal(t, endpoint.Targets{"1.1.1.1"}, targets())
+
+	ctrl.Pinner = NewPinner([]string{"app.example.com"})
+	source.On("Endpoints").Return([]*endpoint.Endpoint{endpoint.NewEndpoint("app.example.com", endpoint.RecordTypeA, "2.2.2.2")}, nil).Twice()
+	require.NoError(t, ctrl.RunOnce(ctx))
+	assert.Equal(t, endpoint.Targets{"1.1.1.1"}, targets())
+
+	// and changes made out of band are reverted
+	records, err := p.Records(ctx)
+	require.NoError(t, err)
+	for _, ep := range records {
+		if ep.RecordType == endpoint.RecordTypeA {
+			updated := ep.DeepCopy()
+			updated.Targets = endpoint.Targets{"3.3.3.3"}
+			require.NoError(t, p.ApplyChanges(ctx, &plan.Changes{UpdateOld: []*endpoint.Endpoint{ep}, UpdateNew: []*endpoint.Endpoint{updated}}))
+		}
+	}
+	require.Equal(t, endpoint.Targets{"3.3.3.3"}, targets())
+	require.NoError(t, ctrl.RunOnce(ctx))
+	assert.Equal(t, endpoint.Targets{"1.1.1.1"}, targets())
+
+	// unpinned, the desired values apply
+	ctrl.Pinner = NewPinner(nil)
+	source.On("Endpoints").Return([]*endpoint.Endpoint{endpoint.NewEndpoint("app.example.com", endpoint.RecordTypeA, "2.2.2.2")}, nil).Once()
+	require.NoError(t, ctrl.RunOnce(ctx))
+	assert.Equal(t, endpoint.Targets{"2.2.2.2"}, targets())
+}
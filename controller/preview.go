@@ -0,0 +1,51 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"sigs.k8s.io/external-dns/endpoint"
+	"sigs.k8s.io/external-dns/plan"
+)
+
+// collectPreviewRecords adds to the changes calculated for a plan the deletion of the records under the
+// preview domain which are not desired anymore, whatever the policy, so that the records of deleted or
+// expired preview environments are garbage-collected.
+func collectPreviewRecords(p *plan.Plan, changes *plan.Changes, domain string) {
+	forceDeletions(p, changes, endpoint.NewDomainFilter([]string{domain}), nil)
+}
+
+// forceDeletions adds to the changes calculated for a plan the deletions the sync policy would make of
+// the records matching filter and match, when not nil, whatever the policy.
+func forceDeletions(p *plan.Plan, changes *plan.Changes, filter endpoint.DomainFilterInterface, match func(*endpoint.Endpoint) bool) {
+	forced := *p
+	forced.Policies = []plan.Policy{&plan.SyncPolicy{}}
+	if filter != nil {
+		forced.DomainFilter = append(endpoint.MatchAllDomainFilters{filter}, p.DomainFilter...)
+	}
+
+	deleted := map[endpoint.EndpointKey]bool{}
+	for _, ep := range changes.Delete {
+		deleted[ep.Key()] = true
+	}
+	forcedChanges := forced.Calculate().Changes
+	for _, ep := range forcedChanges.Delete {
+		if !deleted[ep.Key()] && (match == nil || match(ep)) {
+			changes.Delete = append(changes.Delete, ep)
+			changes.AddReasons(ep, forcedChanges.ReasonsOf(ep)...)
+		}
+	}
+}
@@ -0,0 +1,74 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"sigs.k8s.io/external-dns/endpoint"
+	"sigs.k8s.io/external-dns/internal/testutils"
+	"sigs.k8s.io/external-dns/plan"
+	"sigs.k8s.io/external-dns/provider/inmemory"
+	"sigs.k8s.io/external-dns/registry"
+)
+
+func TestRunOncePreviewRecords(t *testing.T) {
+	ctx := context.Background()
+	p := inmemory.NewInMemoryProvider(inmemory.InMemoryInitZones([]string{"example.com"}))
+	r, err := registry.NewTXTRegistry(p, "", "", "me", 0, "", []string{endpoint.RecordTypeA}, nil, false, nil, false)
+	require.NoError(t, err)
+
+	source := new(testutils.MockSource)
+	ctrl := &Controller{
+		Source:             source,
+		Registry:           r,
+		Policy:             &plan.UpsertOnlyPolicy{},
+		ManagedRecordTypes: []string{endpoint.RecordTypeA},
+		PreviewDomain:      "preview.example.com",
+	}
+	names := func() []string {
+		t.Helper()
+		records, err := p.Records(ctx)
+		require.NoError(t, err)
+		names := []string{}
+		for _, ep := range records {
+			if ep.RecordType == endpoint.RecordTypeA {
+				names = append(names, ep.DNSName)
+			}
+		}
+		return names
+	}
+
+	source.On("Endpoints").Return([]*endpoint.Endpoint{
+		endpoint.NewEndpoint("app.example.com", endpoint.RecordTypeA, "1.1.1.1"),
+		endpoint.NewEndpoint("app.pr-1.preview.example.com", endpoint.RecordTypeA, "1.1.1.1"),
+		endpoint.NewEndpoint("app.pr-2.preview.example.com", endpoint.RecordTypeA, "1.1.1.1"),
+	}, nil).Once()
+	require.NoError(t, ctrl.RunOnce(ctx))
+	require.ElementsMatch(t, []string{"app.example.com", "app.pr-1.preview.example.com", "app.pr-2.preview.example.com"}, names())
+
+	// the records of a preview environment are deleted whatever the policy, the other ones are kept
+	source.On("Endpoints").Return([]*endpoint.Endpoint{
+		endpoint.NewEndpoint("app.pr-2.preview.example.com", endpoint.RecordTypeA, "1.1.1.1"),
+	}, nil).Once()
+	require.NoError(t, ctrl.RunOnce(ctx))
+	assert.ElementsMatch(t, []string{"app.example.com", "app.pr-2.preview.example.com"}, names())
+}
@@ -0,0 +1,65 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+
+	"sigs.k8s.io/external-dns/endpoint"
+	"sigs.k8s.io/external-dns/pkg/metrics"
+	"sigs.k8s.io/external-dns/provider"
+)
+
+var managedRecords = metrics.NewGaugeVecWithOpts(
+	prometheus.GaugeOpts{
+		Namespace: "external_dns",
+		Subsystem: "registry",
+		Name:      "managed_record",
+		Help:      "Records owned by an ExternalDNS instance, with a value of 1. Only exported with --export-record-metrics.",
+	},
+	[]string{"name", "record_type", "set_identifier", "zone", "owner", "resource"},
+)
+
+func init() {
+	metrics.RegisterMetric.MustRegister(managedRecords)
+}
+
+// RecordExporter publishes a series per record owned by an ExternalDNS instance, so that
+// dashboards can enumerate the managed records and alerts can fire on unexpected changes.
+type RecordExporter struct {
+	zones provider.ZoneIDName
+}
+
+// NewRecordExporter returns a RecordExporter labelling records with the zone, among the given
+// ones, they belong to. Records outside of all zones get an empty zone label.
+func NewRecordExporter(zones []string) *RecordExporter {
+	return &RecordExporter{zones: newZoneIDName(zones)}
+}
+
+// Export replaces the exported series with the ones of the given records. Records without owner
+// are not managed by ExternalDNS and are skipped.
+func (e *RecordExporter) Export(records []*endpoint.Endpoint) {
+	managedRecords.GaugeVec.Reset()
+	for _, r := range records {
+		owner := r.Labels[endpoint.OwnerLabelKey]
+		if owner == "" {
+			continue
+		}
+		_, zone := e.zones.FindZone(r.DNSName)
+		managedRecords.GaugeVec.WithLabelValues(r.DNSName, r.RecordType, r.SetIdentifier, zone, owner, r.Labels[endpoint.ResourceLabelKey]).Set(1)
+	}
+}
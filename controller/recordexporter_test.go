@@ -0,0 +1,49 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+
+	"sigs.k8s.io/external-dns/endpoint"
+)
+
+func TestRecordExporter(t *testing.T) {
+	exporter := NewRecordExporter([]string{"example.org", ".sub.example.org"})
+
+	owned := endpoint.NewEndpoint("app.sub.example.org", endpoint.RecordTypeA, "1.2.3.4")
+	owned.Labels[endpoint.OwnerLabelKey] = "default"
+	owned.Labels[endpoint.ResourceLabelKey] = "service/default/app"
+	weighted := endpoint.NewEndpoint("www.example.org", endpoint.RecordTypeCNAME, "app.sub.example.org").WithSetIdentifier("blue")
+	weighted.Labels[endpoint.OwnerLabelKey] = "other"
+	outside := endpoint.NewEndpoint("app.example.com", endpoint.RecordTypeA, "1.2.3.5")
+	outside.Labels[endpoint.OwnerLabelKey] = "default"
+	unowned := endpoint.NewEndpoint("manual.example.org", endpoint.RecordTypeA, "1.2.3.6")
+
+	exporter.Export([]*endpoint.Endpoint{owned, weighted, outside, unowned})
+	assert.Equal(t, 3, testutil.CollectAndCount(managedRecords.GaugeVec))
+	assert.InDelta(t, 1, testutil.ToFloat64(managedRecords.GaugeVec.WithLabelValues("app.sub.example.org", "A", "", "sub.example.org", "default", "service/default/app")), 0)
+	assert.InDelta(t, 1, testutil.ToFloat64(managedRecords.GaugeVec.WithLabelValues("www.example.org", "CNAME", "blue", "example.org", "other", "")), 0)
+	assert.InDelta(t, 1, testutil.ToFloat64(managedRecords.GaugeVec.WithLabelValues("app.example.com", "A", "", "", "default", "")), 0)
+
+	// records gone from the registry are no longer exported
+	exporter.Export([]*endpoint.Endpoint{owned})
+	assert.Equal(t, 1, testutil.CollectAndCount(managedRecords.GaugeVec))
+}
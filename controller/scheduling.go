@@ -0,0 +1,150 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"encoding/json"
+	"net/http"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+
+	"sigs.k8s.io/external-dns/endpoint"
+)
+
+// ScheduledChange is a switch of the targets of an endpoint scheduled at a given time.
+type ScheduledChange struct {
+	DNSName          string           `json:"dnsName"`
+	RecordType       string           `json:"recordType"`
+	SetIdentifier    string           `json:"setIdentifier,omitempty"`
+	At               time.Time        `json:"at"`
+	Targets          endpoint.Targets `json:"targets"`
+	ScheduledTargets endpoint.Targets `json:"scheduledTargets"`
+	// Due is whether the time of the change passed, the endpoint being desired with its scheduled targets.
+	Due bool `json:"due"`
+}
+
+// Scheduler switches the targets of the endpoints carrying the scheduled-targets and scheduled-at
+// properties once their time comes, typically to cut traffic over at a planned time.
+//
+// Scheduled changes are persisted by the resources carrying them: they are read again from the
+// sources at every synchronization, so they survive restarts.
+type Scheduler struct {
+	mu      sync.Mutex
+	changes []ScheduledChange
+	due     map[string]bool
+}
+
+// NewScheduler returns a Scheduler without scheduled changes.
+func NewScheduler() *Scheduler {
+	return &Scheduler{due: map[string]bool{}}
+}
+
+// Apply returns the desired endpoints where the endpoints whose scheduled time passed at now get
+// their scheduled targets, along with the time the next scheduled change is due, zero without any.
+func (s *Scheduler) Apply(desired []*endpoint.Endpoint, now time.Time) ([]*endpoint.Endpoint, time.Time) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	changes := []ScheduledChange{}
+	due := map[string]bool{}
+	var next time.Time
+	pending := 0
+	for _, ep := range desired {
+		targets, hasTargets := ep.GetProviderSpecificProperty(endpoint.ScheduledTargetsProperty)
+		at, hasAt := ep.GetProviderSpecificProperty(endpoint.ScheduledAtProperty)
+		ep.DeleteProviderSpecificProperty(endpoint.ScheduledTargetsProperty)
+		ep.DeleteProviderSpecificProperty(endpoint.ScheduledAtProperty)
+		if !hasTargets && !hasAt {
+			continue
+		}
+		if !hasTargets || !hasAt {
+			log.Warnf("Ignoring the scheduled change of %s record %s, which needs both the scheduled targets and time", ep.RecordType, ep.DNSName)
+			continue
+		}
+		dueAt, err := time.Parse(time.RFC3339, at)
+		if err != nil {
+			log.Warnf("Ignoring the scheduled change of %s record %s: invalid time %q: %v", ep.RecordType, ep.DNSName, at, err)
+			continue
+		}
+		change := ScheduledChange{
+			DNSName:          ep.DNSName,
+			RecordType:       ep.RecordType,
+			SetIdentifier:    ep.SetIdentifier,
+			At:               dueAt,
+			Targets:          ep.Targets,
+			ScheduledTargets: parseScheduledTargets(targets),
+			Due:              !now.Before(dueAt),
+		}
+		if len(change.ScheduledTargets) == 0 {
+			log.Warnf("Ignoring the scheduled change of %s record %s, which has no scheduled targets", ep.RecordType, ep.DNSName)
+			continue
+		}
+		if change.Due {
+			key := ep.DNSName + "/" + ep.RecordType + "/" + ep.SetIdentifier
+			if !s.due[key] {
+				log.Infof("Switching %s record %s to its targets scheduled at %s: %s", ep.RecordType, ep.DNSName, dueAt.Format(time.RFC3339), change.ScheduledTargets)
+			}
+			due[key] = true
+			ep.Targets = change.ScheduledTargets
+		} else {
+			pending++
+			if next.IsZero() || dueAt.Before(next) {
+				next = dueAt
+			}
+		}
+		changes = append(changes, change)
+	}
+	sort.SliceStable(changes, func(i, j int) bool {
+		if !changes[i].At.Equal(changes[j].At) {
+			return changes[i].At.Before(changes[j].At)
+		}
+		return changes[i].DNSName < changes[j].DNSName
+	})
+	s.changes = changes
+	s.due = due
+	scheduledChanges.Gauge.Set(float64(pending))
+	return desired, next
+}
+
+func parseScheduledTargets(value string) endpoint.Targets {
+	targets := endpoint.Targets{}
+	for _, target := range strings.Split(value, ",") {
+		if target = strings.TrimSuffix(strings.TrimSpace(target), "."); target != "" {
+			targets = append(targets, target)
+		}
+	}
+	return targets
+}
+
+// Changes returns the scheduled changes seen by the last synchronization, sorted by time.
+func (s *Scheduler) Changes() []ScheduledChange {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]ScheduledChange{}, s.changes...)
+}
+
+// ServeHTTP serves the scheduled changes as JSON.
+func (s *Scheduler) ServeHTTP(w http.ResponseWriter, _ *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(s.Changes()); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+	}
+}
@@ -0,0 +1,146 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"encoding/json"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"sigs.k8s.io/external-dns/endpoint"
+	"sigs.k8s.io/external-dns/internal/testutils"
+	"sigs.k8s.io/external-dns/plan"
+	"sigs.k8s.io/external-dns/provider/inmemory"
+	"sigs.k8s.io/external-dns/registry"
+)
+
+func scheduledEndpoint(name, target, scheduledTargets, at string) *endpoint.Endpoint {
+	ep := endpoint.NewEndpoint(name, endpoint.RecordTypeA, target)
+	if scheduledTargets != "" {
+		ep = ep.WithProviderSpecific(endpoint.ScheduledTargetsProperty, scheduledTargets)
+	}
+	if at != "" {
+		ep = ep.WithProviderSpecific(endpoint.ScheduledAtProperty, at)
+	}
+	return ep
+}
+
+func TestSchedulerApply(t *testing.T) {
+	now := time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC)
+	s := NewScheduler()
+
+	desired, next := s.Apply([]*endpoint.Endpoint{
+		scheduledEndpoint("past.example.com", "1.1.1.1", "2.2.2.2, 3.3.3.3", "2025-06-01T11:00:00Z"),
+		scheduledEndpoint("later.example.com", "1.1.1.1", "2.2.2.2", "2025-06-01T14:00:00Z"),
+		scheduledEndpoint("soon.example.com", "1.1.1.1", "2.2.2.2", "2025-06-01T13:00:00+00:00"),
+		scheduledEndpoint("plain.example.com", "1.1.1.1", "", ""),
+	}, now)
+	assert.Equal(t, time.Date(2025, 6, 1, 13, 0, 0, 0, time.UTC), next.UTC(), "the earliest pending change is returned")
+	assert.Equal(t, endpoint.Targets{"2.2.2.2", "3.3.3.3"}, desired[0].Targets)
+	assert.Equal(t, endpoint.Targets{"1.1.1.1"}, desired[1].Targets)
+	assert.Equal(t, endpoint.Targets{"1.1.1.1"}, desired[2].Targets)
+	assert.Equal(t, endpoint.Targets{"1.1.1.1"}, desired[3].Targets)
+	for _, ep := range desired {
+		assert.Empty(t, ep.ProviderSpecific, "the scheduling properties are stripped from %s", ep.DNSName)
+	}
+
+	changes := s.Changes()
+	require.Len(t, changes, 3)
+	assert.Equal(t, "past.example.com", changes[0].DNSName)
+	assert.True(t, changes[0].Due)
+	assert.Equal(t, endpoint.Targets{"1.1.1.1"}, changes[0].Targets)
+	assert.Equal(t, "soon.example.com", changes[1].DNSName)
+	assert.False(t, changes[1].Due)
+	assert.Equal(t, "later.example.com", changes[2].DNSName)
+}
+
+func TestSchedulerApplyInvalid(t *testing.T) {
+	now := time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC)
+	for _, ep := range []*endpoint.Endpoint{
+		scheduledEndpoint("targets.example.com", "1.1.1.1", "2.2.2.2", ""),
+		scheduledEndpoint("time.example.com", "1.1.1.1", "", "2025-06-01T11:00:00Z"),
+		scheduledEndpoint("invalid.example.com", "1.1.1.1", "2.2.2.2", "tomorrow"),
+		scheduledEndpoint("empty.example.com", "1.1.1.1", " , ", "2025-06-01T11:00:00Z"),
+	} {
+		t.Run(ep.DNSName, func(t *testing.T) {
+			s := NewScheduler()
+			desired, next := s.Apply([]*endpoint.Endpoint{ep}, now)
+			assert.True(t, next.IsZero())
+			assert.Equal(t, endpoint.Targets{"1.1.1.1"}, desired[0].Targets)
+			assert.Empty(t, desired[0].ProviderSpecific)
+			assert.Empty(t, s.Changes())
+		})
+	}
+}
+
+func TestSchedulerServeHTTP(t *testing.T) {
+	s := NewScheduler()
+	s.Apply([]*endpoint.Endpoint{scheduledEndpoint("app.example.com", "1.1.1.1", "2.2.2.2", "2025-06-01T11:00:00Z")}, time.Date(2025, 6, 1, 10, 0, 0, 0, time.UTC))
+
+	w := httptest.NewRecorder()
+	s.ServeHTTP(w, httptest.NewRequest("GET", "/scheduled", nil))
+	assert.Equal(t, "application/json", w.Header().Get("Content-Type"))
+	var changes []ScheduledChange
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &changes))
+	require.Len(t, changes, 1)
+	assert.Equal(t, "app.example.com", changes[0].DNSName)
+	assert.Equal(t, endpoint.Targets{"2.2.2.2"}, changes[0].ScheduledTargets)
+	assert.False(t, changes[0].Due)
+}
+
+func TestRunOnceScheduler(t *testing.T) {
+	ctx := context.Background()
+	p := inmemory.NewInMemoryProvider(inmemory.InMemoryInitZones([]string{"example.com"}))
+	r, err := registry.NewTXTRegistry(p, "", "", "me", 0, "", []string{endpoint.RecordTypeA}, nil, false, nil, false)
+	require.NoError(t, err)
+
+	source := new(testutils.MockSource)
+	ctrl := &Controller{
+		Source:             source,
+		Registry:           r,
+		Policy:             &plan.SyncPolicy{},
+		ManagedRecordTypes: []string{endpoint.RecordTypeA},
+		Scheduler:          NewScheduler(),
+	}
+	targets := func(name string) endpoint.Targets {
+		t.Helper()
+		records, err := p.Records(ctx)
+		require.NoError(t, err)
+		for _, ep := range records {
+			if ep.DNSName == name && ep.RecordType == endpoint.RecordTypeA {
+				return ep.Targets
+			}
+		}
+		return nil
+	}
+
+	at := time.Now().Add(time.Hour)
+	ctrl.nextRunAt = at.Add(time.Hour)
+	source.On("Endpoints").Return([]*endpoint.Endpoint{
+		scheduledEndpoint("app.example.com", "1.1.1.1", "2.2.2.2", at.Format(time.RFC3339)),
+		scheduledEndpoint("done.example.com", "1.1.1.1", "2.2.2.2", time.Now().Add(-time.Hour).Format(time.RFC3339)),
+	}, nil).Once()
+	require.NoError(t, ctrl.RunOnce(ctx))
+	assert.Equal(t, endpoint.Targets{"1.1.1.1"}, targets("app.example.com"))
+	assert.Equal(t, endpoint.Targets{"2.2.2.2"}, targets("done.example.com"))
+	assert.True(t, at.Truncate(time.Second).Equal(ctrl.nextRunAt), "the next synchronization happens when the change is due")
+}
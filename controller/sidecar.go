@@ -0,0 +1,267 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"os/exec"
+	"sync"
+	"syscall"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	log "github.com/sirupsen/logrus"
+
+	"sigs.k8s.io/external-dns/pkg/metrics"
+	webhookapi "sigs.k8s.io/external-dns/provider/webhook/api"
+)
+
+const (
+	// sidecarProbeInterval is the interval the health of the sidecar is checked at.
+	sidecarProbeInterval = 2 * time.Second
+	// sidecarProbeTimeout bounds every health check of the sidecar.
+	sidecarProbeTimeout = 2 * time.Second
+	// sidecarStopTimeout is how long the sidecar is given to exit once asked to, before being killed.
+	sidecarStopTimeout = 10 * time.Second
+)
+
+var (
+	sidecarRestartsTotal = metrics.NewCounterWithOpts(
+		prometheus.CounterOpts{
+			Namespace: "external_dns",
+			Subsystem: "webhook_sidecar",
+			Name:      "restarts_total",
+			Help:      "Number of times the webhook provider sidecar process got restarted",
+		},
+	)
+	sidecarReady = metrics.NewGaugeWithOpts(
+		prometheus.GaugeOpts{
+			Namespace: "external_dns",
+			Subsystem: "webhook_sidecar",
+			Name:      "ready",
+			Help:      "Whether the webhook provider sidecar process is ready (1) or not (0)",
+		},
+	)
+)
+
+func init() {
+	metrics.RegisterMetric.MustRegister(sidecarRestartsTotal)
+	metrics.RegisterMetric.MustRegister(sidecarReady)
+}
+
+// SidecarSupervisor runs a webhook provider as a child process of ExternalDNS. It restarts the process
+// with an exponential backoff whenever it exits, and checks its health so that synchronizations only
+// run while the webhook provider is able to serve them.
+type SidecarSupervisor struct {
+	command    string
+	args       []string
+	healthURL  string
+	backoff    time.Duration
+	maxBackoff time.Duration
+	client     *http.Client
+
+	// probeInterval is the interval the health of the sidecar is checked at.
+	probeInterval time.Duration
+
+	mu      sync.RWMutex
+	running bool
+	ready   bool
+	// readySinceStart tells whether the current process got ready once, to reset the backoff when it exits.
+	readySinceStart bool
+	lastErr         error
+}
+
+// NewSidecarSupervisor returns a SidecarSupervisor running command with args, and checking its health
+// with GET requests to healthURL. Restarts are delayed by backoff, doubled on every restart of a process
+// that never got ready, up to maxBackoff.
+func NewSidecarSupervisor(command string, args []string, healthURL string, backoff, maxBackoff time.Duration) *SidecarSupervisor {
+	if maxBackoff < backoff {
+		maxBackoff = backoff
+	}
+	return &SidecarSupervisor{
+		command:       command,
+		args:          args,
+		healthURL:     healthURL,
+		backoff:       backoff,
+		maxBackoff:    maxBackoff,
+		client:        &http.Client{Timeout: sidecarProbeTimeout},
+		probeInterval: sidecarProbeInterval,
+		lastErr:       errors.New("not started"),
+	}
+}
+
+// Start launches the sidecar, and keeps supervising it until ctx is done, when the sidecar is stopped.
+func (s *SidecarSupervisor) Start(ctx context.Context) {
+	go s.supervise(ctx)
+	go s.probe(ctx)
+}
+
+// Ready returns nil when the sidecar is running and healthy, or the reason it is not.
+func (s *SidecarSupervisor) Ready() error {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	if s.ready {
+		return nil
+	}
+	if s.lastErr != nil {
+		return fmt.Errorf("webhook provider sidecar is not ready: %w", s.lastErr)
+	}
+	return errors.New("webhook provider sidecar is not ready")
+}
+
+// WaitReady blocks until the sidecar is ready, ctx is done or timeout elapses.
+func (s *SidecarSupervisor) WaitReady(ctx context.Context, timeout time.Duration) error {
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+	ticker := time.NewTicker(s.probeInterval / 10)
+	defer ticker.Stop()
+	for {
+		err := s.Ready()
+		if err == nil {
+			return nil
+		}
+		select {
+		case <-ctx.Done():
+			return err
+		case <-ticker.C:
+		}
+	}
+}
+
+func (s *SidecarSupervisor) supervise(ctx context.Context) {
+	backoff := s.backoff
+	for {
+		wasReady, err := s.run(ctx)
+		if ctx.Err() != nil {
+			return
+		}
+		if wasReady {
+			backoff = s.backoff
+		}
+		sidecarRestartsTotal.Counter.Inc()
+		log.Errorf("Webhook provider sidecar %s exited, restarting it in %s: %v", s.command, backoff, err)
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(backoff):
+		}
+		backoff = min(2*backoff, s.maxBackoff)
+	}
+}
+
+// run runs the sidecar until it exits, returning whether it got ready meanwhile and why it exited.
+func (s *SidecarSupervisor) run(ctx context.Context) (bool, error) {
+	cmd := exec.CommandContext(ctx, s.command, s.args...)
+	cmd.Stdout = os.Stdout
+	cmd.Stderr = os.Stderr
+	cmd.Cancel = func() error {
+		return cmd.Process.Signal(syscall.SIGTERM)
+	}
+	cmd.WaitDelay = sidecarStopTimeout
+
+	if err := cmd.Start(); err != nil {
+		return s.setExited(err), err
+	}
+	log.Infof("Started webhook provider sidecar %s (pid %d)", s.command, cmd.Process.Pid)
+	s.mu.Lock()
+	s.running = true
+	s.readySinceStart = false
+	s.lastErr = errors.New("starting")
+	s.mu.Unlock()
+
+	err := cmd.Wait()
+	if err == nil {
+		err = errors.New("exited with status 0")
+	}
+	return s.setExited(err), err
+}
+
+func (s *SidecarSupervisor) setExited(err error) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.running = false
+	s.ready = false
+	s.lastErr = err
+	sidecarReady.Gauge.Set(0)
+	return s.readySinceStart
+}
+
+func (s *SidecarSupervisor) probe(ctx context.Context) {
+	ticker := time.NewTicker(s.probeInterval)
+	defer ticker.Stop()
+	for {
+		s.mu.RLock()
+		running := s.running
+		s.mu.RUnlock()
+		if running {
+			s.setProbeResult(s.check(ctx))
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// check sends a GET request to the health URL of the sidecar, expecting a 200 status.
+// It negotiates like the webhook provider, so that the webhook URL itself can be used for health checks.
+func (s *SidecarSupervisor) check(ctx context.Context) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, s.healthURL, nil)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Accept", webhookapi.MediaTypeFormatAndVersion)
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("health check %s returned status %d", s.healthURL, resp.StatusCode)
+	}
+	return nil
+}
+
+func (s *SidecarSupervisor) setProbeResult(err error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if !s.running {
+		// the process exited while being checked
+		return
+	}
+	if err != nil {
+		if s.ready {
+			log.Warnf("Webhook provider sidecar %s became unhealthy: %v", s.command, err)
+		}
+		s.ready = false
+		s.lastErr = err
+		sidecarReady.Gauge.Set(0)
+		return
+	}
+	if !s.ready {
+		log.Infof("Webhook provider sidecar %s is ready", s.command)
+	}
+	s.ready = true
+	s.readySinceStart = true
+	s.lastErr = nil
+	sidecarReady.Gauge.Set(1)
+}
@@ -0,0 +1,151 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"sigs.k8s.io/external-dns/endpoint"
+	"sigs.k8s.io/external-dns/internal/testutils"
+	"sigs.k8s.io/external-dns/plan"
+	"sigs.k8s.io/external-dns/provider"
+	webhookapi "sigs.k8s.io/external-dns/provider/webhook/api"
+	"sigs.k8s.io/external-dns/registry"
+)
+
+func newTestSidecarSupervisor(args []string, healthURL string) *SidecarSupervisor {
+	s := NewSidecarSupervisor("sh", append([]string{"-c"}, args...), healthURL, 10*time.Millisecond, 40*time.Millisecond)
+	s.probeInterval = 10 * time.Millisecond
+	return s
+}
+
+func TestSidecarSupervisorReady(t *testing.T) {
+	var healthy atomic.Bool
+	healthy.Store(true)
+	svr := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, webhookapi.MediaTypeFormatAndVersion, r.Header.Get("Accept"))
+		if !healthy.Load() {
+			w.WriteHeader(http.StatusServiceUnavailable)
+		}
+	}))
+	defer svr.Close()
+
+	s := newTestSidecarSupervisor([]string{"exec sleep 60"}, svr.URL)
+	assert.EqualError(t, s.Ready(), "webhook provider sidecar is not ready: not started")
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	s.Start(ctx)
+	require.NoError(t, s.WaitReady(ctx, 5*time.Second))
+
+	healthy.Store(false)
+	assert.Eventually(t, func() bool { return s.Ready() != nil }, 5*time.Second, 10*time.Millisecond)
+	assert.ErrorContains(t, s.Ready(), "returned status 503")
+
+	healthy.Store(true)
+	require.NoError(t, s.WaitReady(ctx, 5*time.Second))
+}
+
+func TestSidecarSupervisorWaitReadyTimeout(t *testing.T) {
+	svr := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer svr.Close()
+
+	s := newTestSidecarSupervisor([]string{"exec sleep 60"}, svr.URL)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	s.Start(ctx)
+	assert.ErrorContains(t, s.WaitReady(ctx, 100*time.Millisecond), "returned status 500")
+}
+
+func TestSidecarSupervisorRestarts(t *testing.T) {
+	svr := httptest.NewServer(http.HandlerFunc(func(http.ResponseWriter, *http.Request) {}))
+	defer svr.Close()
+
+	starts := filepath.Join(t.TempDir(), "starts")
+	s := newTestSidecarSupervisor([]string{"echo started >> " + starts + "; exit 3"}, svr.URL)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	s.Start(ctx)
+
+	assert.Eventually(t, func() bool {
+		b, _ := os.ReadFile(starts)
+		return strings.Count(string(b), "started") >= 3
+	}, 5*time.Second, 10*time.Millisecond)
+	assert.Error(t, s.Ready())
+	assert.ErrorContains(t, s.Ready(), "exit status 3")
+}
+
+func TestSidecarSupervisorMissingCommand(t *testing.T) {
+	s := NewSidecarSupervisor(filepath.Join(t.TempDir(), "missing"), nil, "http://localhost:0", time.Millisecond, time.Millisecond)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	s.Start(ctx)
+	assert.Eventually(t, func() bool {
+		err := s.Ready()
+		return err != nil && strings.Contains(err.Error(), "no such file or directory")
+	}, 5*time.Second, 10*time.Millisecond)
+}
+
+func TestSidecarSupervisorStopsWithContext(t *testing.T) {
+	svr := httptest.NewServer(http.HandlerFunc(func(http.ResponseWriter, *http.Request) {}))
+	defer svr.Close()
+
+	stopped := filepath.Join(t.TempDir(), "stopped")
+	s := newTestSidecarSupervisor([]string{"trap 'echo stopped > " + stopped + "; exit 0' TERM; while true; do sleep 0.01; done"}, svr.URL)
+	ctx, cancel := context.WithCancel(context.Background())
+	s.Start(ctx)
+	require.NoError(t, s.WaitReady(ctx, 5*time.Second))
+
+	cancel()
+	assert.Eventually(t, func() bool {
+		_, err := os.Stat(stopped)
+		return err == nil
+	}, 5*time.Second, 10*time.Millisecond)
+}
+
+func TestRunOnceSkipsWhileSidecarNotReady(t *testing.T) {
+	source := new(testutils.MockSource)
+	source.On("Endpoints").Return([]*endpoint.Endpoint{}, nil)
+
+	p := &filteredMockProvider{}
+	r, err := registry.NewNoopRegistry(p)
+	require.NoError(t, err)
+
+	ctrl := &Controller{
+		Source:   source,
+		Registry: r,
+		Policy:   &plan.SyncPolicy{},
+		Sidecar:  NewSidecarSupervisor("true", nil, "http://localhost:0", time.Second, time.Second),
+	}
+	err = ctrl.RunOnce(context.Background())
+	require.ErrorIs(t, err, provider.SoftError)
+	assert.ErrorContains(t, err, "webhook provider sidecar is not ready")
+	assert.Zero(t, p.RecordsCallCount)
+}
@@ -0,0 +1,100 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+
+	"sigs.k8s.io/external-dns/endpoint"
+	webhookapi "sigs.k8s.io/external-dns/provider/webhook/api"
+)
+
+// webhookAdjuster posts the desired endpoints to a remote server, and continues with the endpoints
+// it responds with. The server can thereby modify, add or drop endpoints, e.g. to enforce an
+// organization policy. It speaks the /adjustendpoints route of the webhook provider API.
+type webhookAdjuster struct {
+	client *http.Client
+	url    string
+	// skipOnFailure passes the endpoints through unchanged when the server cannot be reached or
+	// responds with an error, instead of failing the synchronization.
+	skipOnFailure bool
+}
+
+func newWebhookAdjuster(serverURL string, timeout time.Duration, skipOnFailure bool) (*webhookAdjuster, error) {
+	u, err := url.Parse(serverURL)
+	if err != nil {
+		return nil, fmt.Errorf("invalid endpoint adjuster webhook URL: %w", err)
+	}
+	return &webhookAdjuster{
+		client:        &http.Client{Timeout: timeout},
+		url:           u.JoinPath(webhookapi.UrlAdjustEndpoints).String(),
+		skipOnFailure: skipOnFailure,
+	}, nil
+}
+
+func (a *webhookAdjuster) Name() string { return WebhookAdjuster }
+
+func (a *webhookAdjuster) Adjust(ctx context.Context, endpoints []*endpoint.Endpoint) ([]*endpoint.Endpoint, error) {
+	adjusted, err := a.post(ctx, endpoints)
+	if err != nil {
+		if a.skipOnFailure {
+			log.Warnf("Skipping the endpoint adjuster webhook: %v", err)
+			return endpoints, nil
+		}
+		return nil, err
+	}
+	log.Debugf("Endpoint adjuster webhook returned %d endpoints out of %d", len(adjusted), len(endpoints))
+	return adjusted, nil
+}
+
+func (a *webhookAdjuster) post(ctx context.Context, endpoints []*endpoint.Endpoint) ([]*endpoint.Endpoint, error) {
+	b := new(bytes.Buffer)
+	if err := json.NewEncoder(b).Encode(endpoints); err != nil {
+		return nil, fmt.Errorf("failed to encode endpoints: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, a.url, b)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set(webhookapi.ContentTypeHeader, webhookapi.MediaTypeFormatAndVersion)
+	req.Header.Set("Accept", webhookapi.MediaTypeFormatAndVersion)
+
+	resp, err := a.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("%s responded with code %d", a.url, resp.StatusCode)
+	}
+
+	var adjusted []*endpoint.Endpoint
+	if err := json.NewDecoder(resp.Body).Decode(&adjusted); err != nil {
+		return nil, fmt.Errorf("failed to decode the response of %s: %w", a.url, err)
+	}
+	return adjusted, nil
+}
@@ -0,0 +1,109 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"sigs.k8s.io/external-dns/endpoint"
+	webhookapi "sigs.k8s.io/external-dns/provider/webhook/api"
+)
+
+// newPolicyServer returns a webhook server dropping the endpoints of internal.example.com and
+// marking the others as adjusted.
+func newPolicyServer(t *testing.T) *httptest.Server {
+	mux := http.NewServeMux()
+	mux.HandleFunc(webhookapi.UrlAdjustEndpoints, func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodPost, r.Method)
+		assert.Equal(t, webhookapi.MediaTypeFormatAndVersion, r.Header.Get(webhookapi.ContentTypeHeader))
+
+		var endpoints []*endpoint.Endpoint
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&endpoints))
+		adjusted := []*endpoint.Endpoint{}
+		for _, ep := range endpoints {
+			if ep.DNSName == "internal.example.com" {
+				continue
+			}
+			ep.SetProviderSpecificProperty("adjusted", "true")
+			adjusted = append(adjusted, ep)
+		}
+		w.Header().Set(webhookapi.ContentTypeHeader, webhookapi.MediaTypeFormatAndVersion)
+		json.NewEncoder(w).Encode(adjusted)
+	})
+	return httptest.NewServer(mux)
+}
+
+func TestWebhookAdjuster(t *testing.T) {
+	server := newPolicyServer(t)
+	defer server.Close()
+
+	adjuster, err := newWebhookAdjuster(server.URL, time.Second, false)
+	require.NoError(t, err)
+
+	endpoints, err := adjuster.Adjust(context.Background(), []*endpoint.Endpoint{
+		endpoint.NewEndpoint("a.example.com", endpoint.RecordTypeA, "1.2.3.4"),
+		endpoint.NewEndpoint("internal.example.com", endpoint.RecordTypeA, "10.0.0.1"),
+	})
+	require.NoError(t, err)
+	require.Len(t, endpoints, 1)
+	assert.Equal(t, "a.example.com", endpoints[0].DNSName)
+	v, ok := endpoints[0].GetProviderSpecificProperty("adjusted")
+	assert.True(t, ok)
+	assert.Equal(t, "true", v)
+}
+
+func TestWebhookAdjusterFailure(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer server.Close()
+
+	desired := []*endpoint.Endpoint{endpoint.NewEndpoint("a.example.com", endpoint.RecordTypeA, "1.2.3.4")}
+
+	adjuster, err := newWebhookAdjuster(server.URL, time.Second, false)
+	require.NoError(t, err)
+	_, err = adjuster.Adjust(context.Background(), desired)
+	assert.EqualError(t, err, server.URL+"/adjustendpoints responded with code 500")
+
+	adjuster, err = newWebhookAdjuster(server.URL, time.Second, true)
+	require.NoError(t, err)
+	endpoints, err := adjuster.Adjust(context.Background(), desired)
+	require.NoError(t, err)
+	assert.Equal(t, desired, endpoints)
+}
+
+func TestWebhookAdjusterTimeout(t *testing.T) {
+	done := make(chan struct{})
+	server := httptest.NewServer(http.HandlerFunc(func(http.ResponseWriter, *http.Request) {
+		<-done
+	}))
+	defer server.Close()
+	defer close(done)
+
+	adjuster, err := newWebhookAdjuster(server.URL, 50*time.Millisecond, false)
+	require.NoError(t, err)
+	_, err = adjuster.Adjust(context.Background(), nil)
+	assert.ErrorContains(t, err, "Client.Timeout exceeded")
+}
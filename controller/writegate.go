@@ -0,0 +1,284 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+
+	"sigs.k8s.io/external-dns/endpoint"
+	"sigs.k8s.io/external-dns/plan"
+)
+
+// WriteGate decouples applying changes from observing sources and the provider: the control loop
+// keeps reading at every interval, while changes are only applied once the write interval elapsed
+// since the last write and, when approval is required, once they got approved.
+//
+// Changes held back are pending. They are identified by a hash of their content and of the time
+// they became pending, so an approval only applies to the changes that were reviewed: if the
+// pending changes differ at the next synchronization, or expire, they need to be approved again.
+type WriteGate struct {
+	interval        time.Duration
+	requireApproval bool
+	threshold       int
+	expiry          time.Duration
+	// OnApprove, when set, is called after pending changes got approved, e.g. to schedule a run.
+	OnApprove func()
+	// Store, when set, publishes the changes requiring approval for review, and is asked whether
+	// they got approved.
+	Store PendingPlanStore
+
+	mu          sync.Mutex
+	lastWriteAt time.Time
+	pending     *PendingChanges
+	approvedID  string
+}
+
+// PendingChanges describes changes held back by a WriteGate.
+type PendingChanges struct {
+	ID               string     `json:"id"`
+	Since            time.Time  `json:"since"`
+	ExpiresAt        *time.Time `json:"expiresAt,omitempty"`
+	ApprovalRequired bool       `json:"approvalRequired"`
+	Changes          []string   `json:"changes"`
+	// Reasons are the reasons of the changes, by line of Changes.
+	Reasons map[string][]plan.Reason `json:"reasons,omitempty"`
+
+	digest string
+}
+
+// NewWriteGate returns a WriteGate applying changes at most once per interval. When
+// requireApproval is set, plans of more than threshold changes are only applied once approved,
+// within expiry after they became pending. A zero expiry never expires pending changes.
+func NewWriteGate(interval time.Duration, requireApproval bool, threshold int, expiry time.Duration) *WriteGate {
+	return &WriteGate{interval: interval, requireApproval: requireApproval, threshold: threshold, expiry: expiry}
+}
+
+// Allow reports whether changes may be applied now. Otherwise, they become the pending changes.
+func (g *WriteGate) Allow(ctx context.Context, changes *plan.Changes, now time.Time) bool {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	if !changes.HasChanges() {
+		g.reset(ctx)
+		return true
+	}
+
+	summary := summarizeChanges(changes)
+	digest := changesDigest(summary)
+	switch {
+	case g.pending == nil:
+	case g.pending.digest != digest:
+		g.reset(ctx)
+	case g.pending.expired(now):
+		log.Infof("Pending changes %s expired", g.pending.ID)
+		g.reset(ctx)
+	}
+	if g.pending == nil {
+		g.pending = g.newPending(ctx, changes, summary, digest, now)
+	}
+
+	if g.interval > 0 && now.Before(g.lastWriteAt.Add(g.interval)) {
+		log.Infof("Holding %d changes until %s", len(summary), g.lastWriteAt.Add(g.interval).Format(time.RFC3339))
+		return false
+	}
+	if g.pending.ApprovalRequired && g.approvedID != g.pending.ID {
+		if g.Store != nil {
+			approved, err := g.Store.Approved(ctx, g.pending.ID)
+			if err != nil {
+				log.Warnf("Failed to get the approval of pending changes %s: %v", g.pending.ID, err)
+			} else if approved {
+				log.Infof("Pending changes %s approved", g.pending.ID)
+				g.approvedID = g.pending.ID
+			}
+		}
+		if g.approvedID != g.pending.ID {
+			log.Infof("Holding %d changes until they get approved as %s", len(summary), g.pending.ID)
+			return false
+		}
+	}
+	return true
+}
+
+func (g *WriteGate) newPending(ctx context.Context, changes *plan.Changes, summary []string, digest string, now time.Time) *PendingChanges {
+	pending := &PendingChanges{
+		ID:               changesID(digest, now),
+		Since:            now,
+		ApprovalRequired: g.requireApproval && changeCount(changes) > g.threshold,
+		Changes:          summary,
+		Reasons:          summaryReasons(changes),
+		digest:           digest,
+	}
+	if g.expiry > 0 {
+		expiresAt := now.Add(g.expiry)
+		pending.ExpiresAt = &expiresAt
+	}
+	if pending.ApprovalRequired && g.Store != nil {
+		if err := g.Store.Publish(ctx, *pending); err != nil {
+			log.Warnf("Failed to publish pending changes %s: %v", pending.ID, err)
+		}
+	}
+	return pending
+}
+
+// reset forgets the pending changes and their approval.
+func (g *WriteGate) reset(ctx context.Context) {
+	if g.pending != nil && g.pending.ApprovalRequired && g.Store != nil {
+		if err := g.Store.Delete(ctx, g.pending.ID); err != nil {
+			log.Warnf("Failed to delete pending changes %s: %v", g.pending.ID, err)
+		}
+	}
+	g.pending = nil
+	g.approvedID = ""
+}
+
+func (p *PendingChanges) expired(now time.Time) bool {
+	return p.ExpiresAt != nil && !now.Before(*p.ExpiresAt)
+}
+
+// Written records that changes were applied.
+func (g *WriteGate) Written(ctx context.Context, now time.Time) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	g.lastWriteAt = now
+	g.reset(ctx)
+}
+
+// Pending returns the changes held back, if any.
+func (g *WriteGate) Pending() (PendingChanges, bool) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	if g.pending == nil {
+		return PendingChanges{}, false
+	}
+	return *g.pending, true
+}
+
+// Approve approves the pending changes with the given ID.
+func (g *WriteGate) Approve(id string) error {
+	g.mu.Lock()
+	if g.pending == nil || g.pending.ID != id {
+		g.mu.Unlock()
+		return fmt.Errorf("no pending changes with ID %q", id)
+	}
+	if g.pending.expired(time.Now()) {
+		g.mu.Unlock()
+		return fmt.Errorf("pending changes %q expired", id)
+	}
+	g.approvedID = id
+	g.mu.Unlock()
+
+	log.Infof("Pending changes %s approved", id)
+	if g.OnApprove != nil {
+		g.OnApprove()
+	}
+	return nil
+}
+
+// ServeHTTP serves the pending changes as JSON on GET, and approves them on POST with their ID
+// as the id query parameter.
+func (g *WriteGate) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	switch r.Method {
+	case http.MethodGet:
+		pending, _ := g.Pending()
+		w.Header().Set("Content-Type", "application/json")
+		if err := json.NewEncoder(w).Encode(pending); err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+		}
+	case http.MethodPost:
+		if err := g.Approve(r.URL.Query().Get("id")); err != nil {
+			http.Error(w, err.Error(), http.StatusConflict)
+			return
+		}
+		w.WriteHeader(http.StatusAccepted)
+	default:
+		w.Header().Set("Allow", "GET, POST")
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+	}
+}
+
+// summarizeChanges returns a sorted, human readable line per change.
+func summarizeChanges(changes *plan.Changes) []string {
+	var summary []string
+	add := func(action string, endpoints []*endpoint.Endpoint) {
+		for _, ep := range endpoints {
+			summary = append(summary, summaryLine(action, ep))
+		}
+	}
+	add("create", changes.Create)
+	add("update-old", changes.UpdateOld)
+	add("update-new", changes.UpdateNew)
+	add("delete", changes.Delete)
+	sort.Strings(summary)
+	return summary
+}
+
+// summaryLine returns the line of the summary of the changes describing the change of ep.
+func summaryLine(action string, ep *endpoint.Endpoint) string {
+	line := fmt.Sprintf("%s %s %s %s", action, ep.RecordType, ep.DNSName, strings.Join(ep.Targets, ","))
+	if ep.RecordTTL.IsConfigured() {
+		line += fmt.Sprintf(" ttl=%d", ep.RecordTTL)
+	}
+	if ep.SetIdentifier != "" {
+		line += " (" + ep.SetIdentifier + ")"
+	}
+	return line
+}
+
+// summaryReasons returns the reasons of the changes by line of their summary.
+func summaryReasons(changes *plan.Changes) map[string][]plan.Reason {
+	reasons := map[string][]plan.Reason{}
+	add := func(action string, endpoints []*endpoint.Endpoint) {
+		for _, ep := range endpoints {
+			if r := changes.ReasonsOf(ep); len(r) > 0 {
+				reasons[summaryLine(action, ep)] = r
+			}
+		}
+	}
+	add("create", changes.Create)
+	add("update-new", changes.UpdateNew)
+	add("delete", changes.Delete)
+	if len(reasons) == 0 {
+		return nil
+	}
+	return reasons
+}
+
+// changeCount returns the number of records a plan changes.
+func changeCount(changes *plan.Changes) int {
+	return len(changes.Create) + len(changes.UpdateNew) + len(changes.Delete)
+}
+
+func changesDigest(summary []string) string {
+	sum := sha256.Sum256([]byte(strings.Join(summary, "\n")))
+	return hex.EncodeToString(sum[:])
+}
+
+func changesID(digest string, since time.Time) string {
+	sum := sha256.Sum256([]byte(digest + since.UTC().Format(time.RFC3339Nano)))
+	return hex.EncodeToString(sum[:])[:12]
+}
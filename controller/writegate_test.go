@@ -0,0 +1,240 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	fakeDynamic "k8s.io/client-go/dynamic/fake"
+
+	"sigs.k8s.io/external-dns/endpoint"
+	"sigs.k8s.io/external-dns/internal/testutils"
+	"sigs.k8s.io/external-dns/plan"
+	"sigs.k8s.io/external-dns/provider/inmemory"
+	"sigs.k8s.io/external-dns/registry"
+)
+
+func createChanges(endpoints ...*endpoint.Endpoint) *plan.Changes {
+	return &plan.Changes{Create: endpoints}
+}
+
+func TestWriteGateInterval(t *testing.T) {
+	ctx := context.Background()
+	gate := NewWriteGate(time.Hour, false, 0, 0)
+	now := time.Now()
+	changes := createChanges(endpoint.NewEndpoint("a.example.com", endpoint.RecordTypeA, "1.2.3.4"))
+
+	// the first write is not held back
+	assert.True(t, gate.Allow(ctx, changes, now))
+	gate.Written(ctx, now)
+	_, ok := gate.Pending()
+	assert.False(t, ok)
+
+	assert.True(t, gate.Allow(ctx, &plan.Changes{}, now.Add(time.Minute)), "no changes are always allowed")
+	assert.False(t, gate.Allow(ctx, changes, now.Add(time.Minute)))
+	pending, ok := gate.Pending()
+	require.True(t, ok)
+	assert.Equal(t, []string{"create A a.example.com 1.2.3.4"}, pending.Changes)
+	assert.Equal(t, now.Add(time.Minute), pending.Since)
+	assert.False(t, pending.ApprovalRequired)
+
+	// the pending changes keep their age while they do not change
+	assert.False(t, gate.Allow(ctx, changes, now.Add(2*time.Minute)))
+	pending, _ = gate.Pending()
+	assert.Equal(t, now.Add(time.Minute), pending.Since)
+
+	assert.True(t, gate.Allow(ctx, changes, now.Add(time.Hour)))
+}
+
+func TestWriteGateApproval(t *testing.T) {
+	ctx := context.Background()
+	gate := NewWriteGate(0, true, 0, 0)
+	approvals := 0
+	gate.OnApprove = func() { approvals++ }
+	now := time.Now()
+	changes := createChanges(endpoint.NewEndpoint("a.example.com", endpoint.RecordTypeA, "1.2.3.4"))
+
+	assert.False(t, gate.Allow(ctx, changes, now))
+	pending, ok := gate.Pending()
+	require.True(t, ok)
+	assert.True(t, pending.ApprovalRequired)
+	assert.Error(t, gate.Approve("unknown"))
+	require.NoError(t, gate.Approve(pending.ID))
+	assert.Equal(t, 1, approvals)
+
+	// the approval does not cover different changes, nor the same changes pending again
+	other := createChanges(endpoint.NewEndpointWithTTL("a.example.com", endpoint.RecordTypeA, 60, "1.2.3.4"))
+	assert.False(t, gate.Allow(ctx, other, now))
+	assert.False(t, gate.Allow(ctx, changes, now.Add(time.Second)))
+	assert.Error(t, gate.Approve(pending.ID))
+	pending, _ = gate.Pending()
+	require.NoError(t, gate.Approve(pending.ID))
+	assert.True(t, gate.Allow(ctx, changes, now.Add(time.Second)))
+
+	// approvals are consumed by writes
+	gate.Written(ctx, now)
+	assert.False(t, gate.Allow(ctx, changes, now))
+}
+
+func TestWriteGateApprovalThreshold(t *testing.T) {
+	ctx := context.Background()
+	gate := NewWriteGate(0, true, 2, 0)
+	now := time.Now()
+
+	small := &plan.Changes{
+		UpdateOld: []*endpoint.Endpoint{endpoint.NewEndpoint("a.example.com", endpoint.RecordTypeA, "1.2.3.4")},
+		UpdateNew: []*endpoint.Endpoint{endpoint.NewEndpoint("a.example.com", endpoint.RecordTypeA, "1.2.3.5")},
+		Delete:    []*endpoint.Endpoint{endpoint.NewEndpoint("b.example.com", endpoint.RecordTypeA, "1.2.3.4")},
+	}
+	assert.True(t, gate.Allow(ctx, small, now))
+
+	large := createChanges(
+		endpoint.NewEndpoint("a.example.com", endpoint.RecordTypeA, "1.2.3.4"),
+		endpoint.NewEndpoint("b.example.com", endpoint.RecordTypeA, "1.2.3.4"),
+		endpoint.NewEndpoint("c.example.com", endpoint.RecordTypeA, "1.2.3.4"),
+	)
+	assert.False(t, gate.Allow(ctx, large, now))
+}
+
+func TestWriteGateApprovalExpiry(t *testing.T) {
+	ctx := context.Background()
+	gate := NewWriteGate(0, true, 0, time.Hour)
+	now := time.Now()
+	changes := createChanges(endpoint.NewEndpoint("a.example.com", endpoint.RecordTypeA, "1.2.3.4"))
+
+	assert.False(t, gate.Allow(ctx, changes, now.Add(-2*time.Hour)))
+	expired, _ := gate.Pending()
+	require.NotNil(t, expired.ExpiresAt)
+	assert.Equal(t, now.Add(-time.Hour), *expired.ExpiresAt)
+	assert.EqualError(t, gate.Approve(expired.ID), `pending changes "`+expired.ID+`" expired`)
+
+	// expired changes become pending again, with a new ID
+	assert.False(t, gate.Allow(ctx, changes, now))
+	pending, _ := gate.Pending()
+	assert.NotEqual(t, expired.ID, pending.ID)
+	assert.Equal(t, now, pending.Since)
+	require.NoError(t, gate.Approve(pending.ID))
+	assert.True(t, gate.Allow(ctx, changes, now))
+}
+
+func TestWriteGatePendingPlanStore(t *testing.T) {
+	ctx := context.Background()
+	client := fakeDynamic.NewSimpleDynamicClientWithCustomListKinds(runtime.NewScheme(), map[schema.GroupVersionResource]string{
+		PendingPlanGVR: "PendingPlanList",
+	})
+	plans := client.Resource(PendingPlanGVR).Namespace("dns")
+	gate := NewWriteGate(0, true, 0, time.Hour)
+	gate.Store = NewPendingPlanStore(client, "dns", "owner")
+	now := time.Now()
+	changes := createChanges(endpoint.NewEndpoint("a.example.com", endpoint.RecordTypeA, "1.2.3.4"))
+
+	assert.False(t, gate.Allow(ctx, changes, now))
+	pending, _ := gate.Pending()
+	obj, err := plans.Get(ctx, "plan-"+pending.ID, metav1.GetOptions{})
+	require.NoError(t, err)
+	assert.Equal(t, "PendingPlan", obj.GetKind())
+	spec, _, _ := unstructured.NestedMap(obj.Object, "spec")
+	assert.Equal(t, pending.ID, spec["id"])
+	assert.Equal(t, "owner", spec["owner"])
+	assert.Equal(t, []interface{}{"create A a.example.com 1.2.3.4"}, spec["changes"])
+	assert.Equal(t, now.Add(time.Hour).UTC().Format(time.RFC3339), spec["expiresAt"])
+
+	// not approved yet
+	assert.False(t, gate.Allow(ctx, changes, now))
+
+	obj.SetAnnotations(map[string]string{PendingPlanApprovedAnnotation: "true"})
+	_, err = plans.Update(ctx, obj, metav1.UpdateOptions{})
+	require.NoError(t, err)
+	assert.True(t, gate.Allow(ctx, changes, now))
+
+	// applied changes are not pending anymore
+	gate.Written(ctx, now)
+	list, err := plans.List(ctx, metav1.ListOptions{})
+	require.NoError(t, err)
+	assert.Empty(t, list.Items)
+}
+
+func TestWriteGateServeHTTP(t *testing.T) {
+	gate := NewWriteGate(0, true, 0, 0)
+	changes := createChanges(endpoint.NewEndpoint("a.example.com", endpoint.RecordTypeA, "1.2.3.4").WithSetIdentifier("eu"))
+	changes.AddReasons(changes.Create[0], plan.Reason{Code: plan.ReasonNewEndpoint, Detail: "service/default/a"})
+	gate.Allow(context.Background(), changes, time.Now())
+
+	rec := httptest.NewRecorder()
+	gate.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/pending", nil))
+	require.Equal(t, http.StatusOK, rec.Code)
+	var pending PendingChanges
+	require.NoError(t, json.NewDecoder(rec.Body).Decode(&pending))
+	assert.Equal(t, []string{"create A a.example.com 1.2.3.4 (eu)"}, pending.Changes)
+	assert.Equal(t, map[string][]plan.Reason{
+		"create A a.example.com 1.2.3.4 (eu)": {{Code: plan.ReasonNewEndpoint, Detail: "service/default/a"}},
+	}, pending.Reasons)
+
+	rec = httptest.NewRecorder()
+	gate.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/pending?id=unknown", nil))
+	assert.Equal(t, http.StatusConflict, rec.Code)
+
+	rec = httptest.NewRecorder()
+	gate.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/pending?id="+pending.ID, nil))
+	assert.Equal(t, http.StatusAccepted, rec.Code)
+
+	rec = httptest.NewRecorder()
+	gate.ServeHTTP(rec, httptest.NewRequest(http.MethodDelete, "/pending", nil))
+	assert.Equal(t, http.StatusMethodNotAllowed, rec.Code)
+}
+
+func TestRunOnceWriteApproval(t *testing.T) {
+	ctx := context.Background()
+	p := inmemory.NewInMemoryProvider(inmemory.InMemoryInitZones([]string{"example.com"}))
+	source := new(testutils.MockSource)
+	source.On("Endpoints").Return([]*endpoint.Endpoint{endpoint.NewEndpoint("a.example.com", endpoint.RecordTypeA, "1.2.3.4")}, nil)
+	r, err := registry.NewNoopRegistry(p)
+	require.NoError(t, err)
+	ctrl := &Controller{
+		Source:             source,
+		Registry:           r,
+		Policy:             &plan.SyncPolicy{},
+		ManagedRecordTypes: []string{endpoint.RecordTypeA},
+		WriteGate:          NewWriteGate(0, true, 0, 0),
+	}
+
+	require.NoError(t, ctrl.RunOnce(ctx))
+	records, err := p.Records(ctx)
+	require.NoError(t, err)
+	assert.Empty(t, records)
+
+	pending, ok := ctrl.WriteGate.Pending()
+	require.True(t, ok)
+	require.NoError(t, ctrl.WriteGate.Approve(pending.ID))
+	require.NoError(t, ctrl.RunOnce(ctx))
+	records, err = p.Records(ctx)
+	require.NoError(t, err)
+	assert.Len(t, records, 1)
+	_, ok = ctrl.WriteGate.Pending()
+	assert.False(t, ok)
+}
@@ -0,0 +1,67 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/client-go/tools/record"
+
+	"sigs.k8s.io/external-dns/endpoint"
+	"sigs.k8s.io/external-dns/provider"
+	"sigs.k8s.io/external-dns/source"
+)
+
+// ZoneCollisionEvents records Events on the resources of the endpoints whose hostname matched a zone
+// name configured under several zone IDs of the provider.
+type ZoneCollisionEvents struct {
+	collisions *provider.ZoneCollisions
+	recorder   record.EventRecorder
+	// reported are the endpoints recorded by the last call to Record, by resource and hostname, so that
+	// an Event is recorded once as long as the hostname keeps matching ambiguously.
+	reported map[string]bool
+}
+
+// NewZoneCollisionEvents returns a ZoneCollisionEvents recording the ambiguous zone matches of
+// collisions with recorder.
+func NewZoneCollisionEvents(collisions *provider.ZoneCollisions, recorder record.EventRecorder) *ZoneCollisionEvents {
+	return &ZoneCollisionEvents{collisions: collisions, recorder: recorder}
+}
+
+// Record records an Event on the resource of each of the endpoints whose hostname matched ambiguously
+// since the last call.
+func (e *ZoneCollisionEvents) Record(endpoints ...[]*endpoint.Endpoint) {
+	hostnames := e.collisions.Take()
+	reported := map[string]bool{}
+	for _, eps := range endpoints {
+		for _, ep := range eps {
+			message, ok := hostnames[ep.DNSName]
+			resource := ep.Labels[endpoint.ResourceLabelKey]
+			if !ok || resource == "" {
+				continue
+			}
+			key := resource + " " + ep.DNSName
+			if reported[key] {
+				continue
+			}
+			reported[key] = true
+			if ref := source.ResourceReference(resource); ref != nil && !e.reported[key] {
+				e.recorder.Event(ref, corev1.EventTypeWarning, "AmbiguousZone", message)
+			}
+		}
+	}
+	e.reported = reported
+}
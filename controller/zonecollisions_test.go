@@ -0,0 +1,121 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"k8s.io/client-go/tools/record"
+
+	"sigs.k8s.io/external-dns/endpoint"
+	"sigs.k8s.io/external-dns/internal/testutils"
+	"sigs.k8s.io/external-dns/plan"
+	"sigs.k8s.io/external-dns/provider"
+	"sigs.k8s.io/external-dns/registry"
+)
+
+func TestZoneCollisionEvents(t *testing.T) {
+	collisions := provider.NewZoneCollisions(provider.ZoneCollisionFail)
+	zones := provider.NewZoneFinder(collisions)
+	zones.Add("zone-a", "example.org")
+	zones.Add("zone-b", "example.org")
+	recorder := record.NewFakeRecorder(10)
+	events := NewZoneCollisionEvents(collisions, recorder)
+
+	ambiguous := endpoint.NewEndpoint("app.example.org", endpoint.RecordTypeA, "1.2.3.4")
+	ambiguous.Labels[endpoint.ResourceLabelKey] = "ingress/default/app"
+	unlabelled := endpoint.NewEndpoint("app.example.org", endpoint.RecordTypeAAAA, "2001:db8::1")
+	other := endpoint.NewEndpoint("other.example.com", endpoint.RecordTypeA, "1.2.3.5")
+	other.Labels[endpoint.ResourceLabelKey] = "service/default/other"
+	desired := []*endpoint.Endpoint{ambiguous, unlabelled, other}
+
+	zones.FindZone("app.example.org")
+	events.Record(desired)
+	assert.Equal(t, []string{
+		`Warning AmbiguousZone Hostname "app.example.org" matches zone "example.org" under several zone IDs [zone-a zone-b], skipping it`,
+	}, drainEvents(recorder))
+
+	// hostnames still matching ambiguously are not reported again
+	zones.FindZone("app.example.org")
+	events.Record(desired)
+	assert.Empty(t, drainEvents(recorder))
+
+	// nor are the hostnames whose zone was not looked up
+	events.Record(desired)
+	assert.Empty(t, drainEvents(recorder))
+
+	// but they are when they match ambiguously again
+	zones.FindZone("app.example.org")
+	events.Record(nil, desired)
+	assert.Len(t, drainEvents(recorder), 1)
+}
+
+// zoneFindingProvider finds the zones of the created records, as the providers telling apart zones
+// by their IDs do.
+type zoneFindingProvider struct {
+	filteredMockProvider
+	zones provider.ZoneFinder
+}
+
+func (p *zoneFindingProvider) ApplyChanges(ctx context.Context, changes *plan.Changes) error {
+	for _, ep := range changes.Create {
+		p.zones.FindZone(ep.DNSName)
+	}
+	return p.filteredMockProvider.ApplyChanges(ctx, changes)
+}
+
+func TestControllerRecordsZoneCollisionEvents(t *testing.T) {
+	desired := endpoint.NewEndpoint("app.example.org", endpoint.RecordTypeA, "1.2.3.4")
+	desired.Labels[endpoint.ResourceLabelKey] = "ingress/default/app"
+	source := new(testutils.MockSource)
+	source.On("Endpoints").Return([]*endpoint.Endpoint{desired}, nil)
+	collisions := provider.NewZoneCollisions(provider.ZoneCollisionWarn)
+	p := &zoneFindingProvider{zones: provider.NewZoneFinder(collisions)}
+	p.zones.Add("zone-a", "example.org")
+	p.zones.Add("zone-b", "example.org")
+	r, err := registry.NewNoopRegistry(p)
+	require.NoError(t, err)
+	recorder := record.NewFakeRecorder(10)
+
+	ctrl := &Controller{
+		Source:             source,
+		Registry:           r,
+		Policy:             &plan.SyncPolicy{},
+		DomainFilter:       endpoint.DomainFilter{},
+		ManagedRecordTypes: []string{endpoint.RecordTypeA},
+		ZoneCollisions:     NewZoneCollisionEvents(collisions, recorder),
+	}
+	require.NoError(t, ctrl.RunOnce(context.Background()))
+	assert.Equal(t, []string{
+		`Warning AmbiguousZone Hostname "app.example.org" matches zone "example.org" under several zone IDs [zone-a zone-b], using "zone-a"`,
+	}, drainEvents(recorder))
+}
+
+func drainEvents(recorder *record.FakeRecorder) []string {
+	var events []string
+	for {
+		select {
+		case event := <-recorder.Events:
+			events = append(events, event)
+		default:
+			return events
+		}
+	}
+}
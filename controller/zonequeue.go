@@ -0,0 +1,189 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"encoding/json"
+	"net/http"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"k8s.io/client-go/util/workqueue"
+
+	"sigs.k8s.io/external-dns/endpoint"
+	"sigs.k8s.io/external-dns/plan"
+	"sigs.k8s.io/external-dns/provider"
+)
+
+// DeadLetter describes a zone whose changes keep failing to apply.
+type DeadLetter struct {
+	Zone        string    `json:"zone"`
+	Failures    int       `json:"failures"`
+	LastError   string    `json:"lastError"`
+	LastFailure time.Time `json:"lastFailure"`
+	NextRetry   time.Time `json:"nextRetry"`
+}
+
+// zoneState is the failure state of a zone.
+type zoneState struct {
+	lastError   string
+	lastFailure time.Time
+	retryAt     time.Time
+}
+
+// ZoneQueue splits changes by zone and tracks the zones failing to apply, so that each of them
+// is retried with its own exponential backoff without holding back the other zones.
+type ZoneQueue struct {
+	zones           provider.ZoneIDName
+	limiter         workqueue.TypedRateLimiter[string]
+	deadLetterAfter int
+
+	mu     sync.Mutex
+	states map[string]*zoneState
+}
+
+// NewZoneQueue returns a ZoneQueue for the given zones. Changes to names outside of all zones
+// are grouped under the empty zone name. A zone failing to apply is retried after baseDelay,
+// doubled after each consecutive failure up to maxDelay, and reported as a dead letter after
+// deadLetterAfter consecutive failures.
+func NewZoneQueue(zones []string, baseDelay, maxDelay time.Duration, deadLetterAfter int) *ZoneQueue {
+	return &ZoneQueue{
+		zones:           newZoneIDName(zones),
+		limiter:         workqueue.NewTypedItemExponentialFailureRateLimiter[string](baseDelay, maxDelay),
+		deadLetterAfter: deadLetterAfter,
+		states:          map[string]*zoneState{},
+	}
+}
+
+// newZoneIDName returns the zones named after the given domain filters.
+func newZoneIDName(zones []string) provider.ZoneIDName {
+	zoneIDName := provider.ZoneIDName{}
+	for _, zone := range zones {
+		// domain filters may be written ".example.com" to only match subdomains
+		zone = strings.TrimSuffix(strings.TrimPrefix(strings.ToLower(zone), "."), ".")
+		if zone != "" {
+			zoneIDName.Add(zone, zone)
+		}
+	}
+	return zoneIDName
+}
+
+// Split groups changes by zone.
+func (q *ZoneQueue) Split(changes *plan.Changes) map[string]*plan.Changes {
+	perZone := map[string]*plan.Changes{}
+	zoneChanges := func(e *endpoint.Endpoint) *plan.Changes {
+		_, zone := q.zones.FindZone(e.DNSName)
+		if _, ok := perZone[zone]; !ok {
+			perZone[zone] = &plan.Changes{Reasons: changes.Reasons}
+		}
+		return perZone[zone]
+	}
+	for _, e := range changes.Create {
+		c := zoneChanges(e)
+		c.Create = append(c.Create, e)
+	}
+	// UpdateOld and UpdateNew are index-aligned and share the same names, so they stay aligned per zone.
+	for _, e := range changes.UpdateOld {
+		c := zoneChanges(e)
+		c.UpdateOld = append(c.UpdateOld, e)
+	}
+	for _, e := range changes.UpdateNew {
+		c := zoneChanges(e)
+		c.UpdateNew = append(c.UpdateNew, e)
+	}
+	for _, e := range changes.Delete {
+		c := zoneChanges(e)
+		c.Delete = append(c.Delete, e)
+	}
+	return perZone
+}
+
+// Ready returns whether the changes of zone may be applied at now, along with the time the
+// zone may be retried when it is still backing off.
+func (q *ZoneQueue) Ready(zone string, now time.Time) (bool, time.Time) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	state, ok := q.states[zone]
+	if !ok || !now.Before(state.retryAt) {
+		return true, time.Time{}
+	}
+	return false, state.retryAt
+}
+
+// Failed records that applying the changes of zone failed at now, and returns the time the zone
+// may be retried.
+func (q *ZoneQueue) Failed(zone string, err error, now time.Time) time.Time {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	retryAt := now.Add(q.limiter.When(zone))
+	q.states[zone] = &zoneState{lastError: err.Error(), lastFailure: now, retryAt: retryAt}
+	return retryAt
+}
+
+// Succeeded resets the failure state of zone, once its changes were applied or it has no changes left.
+func (q *ZoneQueue) Succeeded(zone string) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	q.limiter.Forget(zone)
+	delete(q.states, zone)
+}
+
+// NextRetry returns the earliest time a failing zone may be retried, if any zone is failing.
+func (q *ZoneQueue) NextRetry() (time.Time, bool) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	var next time.Time
+	for _, state := range q.states {
+		if next.IsZero() || state.retryAt.Before(next) {
+			next = state.retryAt
+		}
+	}
+	return next, !next.IsZero()
+}
+
+// DeadLetters returns the zones that failed to apply at least as many consecutive times as the
+// dead letter threshold, sorted by zone name.
+func (q *ZoneQueue) DeadLetters() []DeadLetter {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	deadLetters := []DeadLetter{}
+	for zone, state := range q.states {
+		failures := q.limiter.NumRequeues(zone)
+		if failures < q.deadLetterAfter {
+			continue
+		}
+		deadLetters = append(deadLetters, DeadLetter{
+			Zone:        zone,
+			Failures:    failures,
+			LastError:   state.lastError,
+			LastFailure: state.lastFailure,
+			NextRetry:   state.retryAt,
+		})
+	}
+	sort.Slice(deadLetters, func(i, j int) bool { return deadLetters[i].Zone < deadLetters[j].Zone })
+	return deadLetters
+}
+
+// ServeHTTP serves the dead letters as JSON.
+func (q *ZoneQueue) ServeHTTP(w http.ResponseWriter, _ *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(q.DeadLetters()); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+	}
+}
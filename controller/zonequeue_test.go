@@ -0,0 +1,195 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"math"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"sigs.k8s.io/external-dns/endpoint"
+	"sigs.k8s.io/external-dns/internal/testutils"
+	"sigs.k8s.io/external-dns/plan"
+	"sigs.k8s.io/external-dns/provider"
+	"sigs.k8s.io/external-dns/registry"
+)
+
+func TestZoneQueueSplit(t *testing.T) {
+	q := NewZoneQueue([]string{"example.com", ".sub.example.com", "example.org."}, time.Second, time.Minute, 3)
+
+	changes := &plan.Changes{
+		Create:    []*endpoint.Endpoint{endpoint.NewEndpoint("a.example.com", endpoint.RecordTypeA, "1.2.3.4"), endpoint.NewEndpoint("a.sub.example.com", endpoint.RecordTypeA, "1.2.3.4")},
+		UpdateOld: []*endpoint.Endpoint{endpoint.NewEndpoint("b.example.org", endpoint.RecordTypeA, "1.2.3.4"), endpoint.NewEndpoint("b.example.com", endpoint.RecordTypeA, "1.2.3.4")},
+		UpdateNew: []*endpoint.Endpoint{endpoint.NewEndpoint("b.example.org", endpoint.RecordTypeA, "5.6.7.8"), endpoint.NewEndpoint("b.example.com", endpoint.RecordTypeA, "5.6.7.8")},
+		Delete:    []*endpoint.Endpoint{endpoint.NewEndpoint("c.example.net", endpoint.RecordTypeA, "1.2.3.4")},
+	}
+
+	perZone := q.Split(changes)
+	require.Len(t, perZone, 4)
+
+	assert.Equal(t, []*endpoint.Endpoint{changes.Create[0]}, perZone["example.com"].Create)
+	assert.Equal(t, []*endpoint.Endpoint{changes.UpdateOld[1]}, perZone["example.com"].UpdateOld)
+	assert.Equal(t, []*endpoint.Endpoint{changes.UpdateNew[1]}, perZone["example.com"].UpdateNew)
+	assert.Equal(t, []*endpoint.Endpoint{changes.Create[1]}, perZone["sub.example.com"].Create)
+	assert.Equal(t, []*endpoint.Endpoint{changes.UpdateOld[0]}, perZone["example.org"].UpdateOld)
+	assert.Equal(t, []*endpoint.Endpoint{changes.UpdateNew[0]}, perZone["example.org"].UpdateNew)
+	assert.Equal(t, []*endpoint.Endpoint{changes.Delete[0]}, perZone[""].Delete)
+}
+
+func TestZoneQueueBackoff(t *testing.T) {
+	q := NewZoneQueue([]string{"example.com"}, time.Second, 4*time.Second, 3)
+	now := time.Now()
+
+	ready, _ := q.Ready("example.com", now)
+	assert.True(t, ready)
+	_, ok := q.NextRetry()
+	assert.False(t, ok)
+
+	for i, delay := range []time.Duration{time.Second, 2 * time.Second, 4 * time.Second, 4 * time.Second} {
+		retryAt := q.Failed("example.com", errors.New("boom"), now)
+		assert.Equal(t, now.Add(delay), retryAt, "failure %d", i+1)
+
+		ready, until := q.Ready("example.com", now)
+		assert.False(t, ready)
+		assert.Equal(t, retryAt, until)
+		ready, _ = q.Ready("example.com", retryAt)
+		assert.True(t, ready)
+
+		next, ok := q.NextRetry()
+		assert.True(t, ok)
+		assert.Equal(t, retryAt, next)
+	}
+
+	q.Succeeded("example.com")
+	ready, _ = q.Ready("example.com", now)
+	assert.True(t, ready)
+	_, ok = q.NextRetry()
+	assert.False(t, ok)
+	assert.Equal(t, now.Add(time.Second), q.Failed("example.com", errors.New("boom"), now))
+}
+
+func TestZoneQueueDeadLetters(t *testing.T) {
+	q := NewZoneQueue([]string{"example.com", "example.org"}, time.Second, time.Minute, 2)
+	now := time.Now()
+
+	q.Failed("example.org", errors.New("first"), now)
+	assert.Empty(t, q.DeadLetters())
+
+	q.Failed("example.com", errors.New("first"), now)
+	q.Failed("example.org", errors.New("second"), now)
+	q.Failed("example.org", errors.New("third"), now)
+	q.Failed("example.com", errors.New("second"), now)
+
+	assert.Equal(t, []DeadLetter{
+		{Zone: "example.com", Failures: 2, LastError: "second", LastFailure: now, NextRetry: now.Add(2 * time.Second)},
+		{Zone: "example.org", Failures: 3, LastError: "third", LastFailure: now, NextRetry: now.Add(4 * time.Second)},
+	}, q.DeadLetters())
+
+	rec := httptest.NewRecorder()
+	q.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/deadletters", nil))
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, "application/json", rec.Header().Get("Content-Type"))
+	var served []DeadLetter
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &served))
+	require.Len(t, served, 2)
+	assert.Equal(t, "example.com", served[0].Zone)
+	assert.Equal(t, "third", served[1].LastError)
+
+	q.Succeeded("example.com")
+	deadLetters := q.DeadLetters()
+	require.Len(t, deadLetters, 1)
+	assert.Equal(t, "example.org", deadLetters[0].Zone)
+}
+
+// zoneFailingProvider fails to apply changes touching its failing zone.
+type zoneFailingProvider struct {
+	filteredMockProvider
+	failingZone string
+}
+
+func (p *zoneFailingProvider) ApplyChanges(ctx context.Context, changes *plan.Changes) error {
+	for _, e := range changes.Create {
+		if strings.HasSuffix(e.DNSName, p.failingZone) {
+			return errors.New("zone unavailable")
+		}
+	}
+	return p.filteredMockProvider.ApplyChanges(ctx, changes)
+}
+
+func TestRunOnceWithZoneQueue(t *testing.T) {
+	source := new(testutils.MockSource)
+	source.On("Endpoints").Return([]*endpoint.Endpoint{
+		endpoint.NewEndpoint("a.example.com", endpoint.RecordTypeA, "1.2.3.4"),
+		endpoint.NewEndpoint("a.example.org", endpoint.RecordTypeA, "1.2.3.4"),
+	}, nil)
+
+	p := &zoneFailingProvider{failingZone: "example.org"}
+	r, err := registry.NewNoopRegistry(p)
+	require.NoError(t, err)
+
+	ctrl := &Controller{
+		Source:             source,
+		Registry:           r,
+		Policy:             &plan.SyncPolicy{},
+		Interval:           24 * time.Hour,
+		ManagedRecordTypes: []string{endpoint.RecordTypeA},
+		ZoneQueue:          NewZoneQueue([]string{"example.com", "example.org"}, time.Hour, time.Hour, 1),
+	}
+
+	require.True(t, ctrl.ShouldRunOnce(time.Now()))
+	err = ctrl.RunOnce(context.Background())
+	require.Error(t, err)
+	assert.ErrorIs(t, err, provider.SoftError)
+	assert.Contains(t, err.Error(), "example.org")
+
+	// the healthy zone was applied regardless of the failing one
+	require.Len(t, p.ApplyChangesCalls, 1)
+	assert.Equal(t, "a.example.com", p.ApplyChangesCalls[0].Create[0].DNSName)
+
+	deadLetters := ctrl.ZoneQueue.DeadLetters()
+	require.Len(t, deadLetters, 1)
+	assert.Equal(t, "example.org", deadLetters[0].Zone)
+	assert.Equal(t, "zone unavailable", deadLetters[0].LastError)
+	assert.Equal(t, math.Float64bits(1), valueFromMetric(deadLetterZones.Gauge))
+
+	// a retry is scheduled for the failing zone before the next interval
+	assert.WithinDuration(t, deadLetters[0].NextRetry, ctrl.nextRunAt, time.Second)
+
+	// the failing zone is not retried before its backoff expires
+	p.failingZone = "none"
+	p.ApplyChangesCalls = nil
+	err = ctrl.RunOnce(context.Background())
+	require.Error(t, err)
+	require.Len(t, p.ApplyChangesCalls, 1)
+	assert.Equal(t, "a.example.com", p.ApplyChangesCalls[0].Create[0].DNSName)
+
+	// and is applied once it is ready again
+	ctrl.ZoneQueue.Succeeded("example.org")
+	p.ApplyChangesCalls = nil
+	require.NoError(t, ctrl.RunOnce(context.Background()))
+	assert.Len(t, p.ApplyChangesCalls, 2)
+	assert.Empty(t, ctrl.ZoneQueue.DeadLetters())
+}
@@ -0,0 +1,319 @@
+//go:build e2e
+
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package e2e
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/util/wait"
+	k8syaml "k8s.io/apimachinery/pkg/util/yaml"
+	"k8s.io/client-go/discovery/cached/memory"
+	"k8s.io/client-go/dynamic"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/restmapper"
+	"k8s.io/client-go/tools/clientcmd"
+
+	"sigs.k8s.io/external-dns/controller"
+	"sigs.k8s.io/external-dns/endpoint"
+	"sigs.k8s.io/external-dns/pkg/apis/externaldns"
+	"sigs.k8s.io/external-dns/provider"
+	"sigs.k8s.io/external-dns/provider/inmemory"
+	webhookapi "sigs.k8s.io/external-dns/provider/webhook/api"
+)
+
+const fieldManager = "external-dns-e2e"
+
+// harness holds what the scenarios share: the cluster, the external-dns binary and the provider.
+type harness struct {
+	kubeconfig string
+	binary     string
+	zone       string
+	timeout    time.Duration
+	// providerFlags select and configure the provider external-dns runs with.
+	providerFlags []string
+	// records reads the records of the provider back.
+	records provider.Provider
+
+	kube    kubernetes.Interface
+	dynamic dynamic.Interface
+	mapper  *restmapper.DeferredDiscoveryRESTMapper
+}
+
+var h harness
+
+func TestMain(m *testing.M) {
+	os.Exit(run(m))
+}
+
+func run(m *testing.M) int {
+	ctx := context.Background()
+	tmp, err := os.MkdirTemp("", "external-dns-e2e")
+	if err != nil {
+		log.Fatal(err)
+	}
+	defer os.RemoveAll(tmp)
+
+	h.zone = getenv("E2E_ZONE", "e2e.example.org")
+	h.timeout, err = time.ParseDuration(getenv("E2E_TIMEOUT", "1m"))
+	if err != nil {
+		log.Fatalf("invalid E2E_TIMEOUT: %v", err)
+	}
+
+	h.kubeconfig = os.Getenv("E2E_KUBECONFIG")
+	if h.kubeconfig == "" {
+		cluster := getenv("E2E_KIND_CLUSTER", "external-dns-e2e")
+		h.kubeconfig = filepath.Join(tmp, "kubeconfig")
+		log.Infof("Creating kind cluster %q", cluster)
+		if err := command(ctx, "kind", "create", "cluster", "--name", cluster, "--kubeconfig", h.kubeconfig, "--wait", "2m"); err != nil {
+			log.Fatal(err)
+		}
+		if os.Getenv("E2E_KEEP_CLUSTER") == "" {
+			defer func() {
+				if err := command(ctx, "kind", "delete", "cluster", "--name", cluster); err != nil {
+					log.Error(err)
+				}
+			}()
+		}
+	}
+	if err := h.connect(); err != nil {
+		log.Fatal(err)
+	}
+
+	h.binary = os.Getenv("E2E_EXTERNAL_DNS_BINARY")
+	if h.binary == "" {
+		h.binary = filepath.Join(tmp, "external-dns")
+		log.Info("Building external-dns")
+		if err := command(ctx, "go", "build", "-o", h.binary, ".."); err != nil {
+			log.Fatal(err)
+		}
+	}
+
+	if err := h.setupProvider(ctx); err != nil {
+		log.Fatal(err)
+	}
+
+	crd, err := os.ReadFile("../config/crd/standard/dnsendpoint.yaml")
+	if err != nil {
+		log.Fatal(err)
+	}
+	if err := h.apply(ctx, "", string(crd)); err != nil {
+		log.Fatalf("installing the DNSEndpoint CRD: %v", err)
+	}
+
+	return m.Run()
+}
+
+// setupProvider serves an in-memory provider through the webhook API, unless E2E_PROVIDER_FLAGS
+// selects another provider, whose records are then read back with a provider built in-process.
+func (h *harness) setupProvider(ctx context.Context) error {
+	if flags := os.Getenv("E2E_PROVIDER_FLAGS"); flags != "" {
+		h.providerFlags = strings.Fields(flags)
+		cfg := externaldns.NewConfig()
+		// No source is used, but the flag is required by the external-dns flag set.
+		if err := cfg.ParseFlags(append([]string{"--source=fake"}, h.providerFlags...)); err != nil {
+			return fmt.Errorf("parsing E2E_PROVIDER_FLAGS: %w", err)
+		}
+		if len(cfg.DomainFilter) == 0 {
+			cfg.DomainFilter = []string{h.zone}
+		}
+		var err error
+		h.records, err = controller.BuildProvider(ctx, cfg, endpoint.NewDomainFilterWithExclusions(cfg.DomainFilter, cfg.ExcludeDomains), nil)
+		return err
+	}
+
+	h.records = inmemory.NewInMemoryProvider(inmemory.InMemoryInitZones([]string{h.zone}))
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		return err
+	}
+	addr := l.Addr().String()
+	_ = l.Close()
+	started := make(chan struct{})
+	go webhookapi.StartHTTPApi(h.records, started, 10*time.Second, 10*time.Second, addr)
+	<-started
+	h.providerFlags = []string{"--provider=webhook", "--webhook-provider-url=http://" + addr}
+	return nil
+}
+
+func (h *harness) connect() error {
+	config, err := clientcmd.BuildConfigFromFlags("", h.kubeconfig)
+	if err != nil {
+		return err
+	}
+	if h.kube, err = kubernetes.NewForConfig(config); err != nil {
+		return err
+	}
+	if h.dynamic, err = dynamic.NewForConfig(config); err != nil {
+		return err
+	}
+	h.mapper = restmapper.NewDeferredDiscoveryRESTMapper(memory.NewMemCacheClient(h.kube.Discovery()))
+	return nil
+}
+
+// apply creates or updates the objects of the manifests, in namespace when they do not set one.
+func (h *harness) apply(ctx context.Context, namespace, manifests string) error {
+	return h.forEachObject(ctx, namespace, manifests, func(ri dynamic.ResourceInterface, obj *unstructured.Unstructured) error {
+		_, err := ri.Apply(ctx, obj.GetName(), obj, metav1.ApplyOptions{FieldManager: fieldManager, Force: true})
+		return err
+	})
+}
+
+// delete deletes the objects of the manifests, in namespace when they do not set one.
+func (h *harness) delete(ctx context.Context, namespace, manifests string) error {
+	return h.forEachObject(ctx, namespace, manifests, func(ri dynamic.ResourceInterface, obj *unstructured.Unstructured) error {
+		err := ri.Delete(ctx, obj.GetName(), metav1.DeleteOptions{})
+		if apierrors.IsNotFound(err) {
+			return nil
+		}
+		return err
+	})
+}
+
+func (h *harness) forEachObject(ctx context.Context, namespace, manifests string, fn func(dynamic.ResourceInterface, *unstructured.Unstructured) error) error {
+	decoder := k8syaml.NewYAMLOrJSONDecoder(strings.NewReader(manifests), 4096)
+	for {
+		obj := &unstructured.Unstructured{}
+		if err := decoder.Decode(&obj.Object); err != nil {
+			if errors.Is(err, io.EOF) {
+				return nil
+			}
+			return err
+		}
+		if len(obj.Object) == 0 {
+			continue
+		}
+
+		// The resources of a CRD are only known once it is established: retry until the mapping is found.
+		var ri dynamic.ResourceInterface
+		err := wait.PollUntilContextTimeout(ctx, time.Second, 30*time.Second, true, func(context.Context) (bool, error) {
+			gvk := obj.GroupVersionKind()
+			mapping, err := h.mapper.RESTMapping(gvk.GroupKind(), gvk.Version)
+			if err != nil {
+				h.mapper.Reset()
+				return false, nil
+			}
+			ri = h.dynamic.Resource(mapping.Resource)
+			if mapping.Scope.Name() == "namespace" {
+				if obj.GetNamespace() == "" {
+					obj.SetNamespace(namespace)
+				}
+				ri = h.dynamic.Resource(mapping.Resource).Namespace(obj.GetNamespace())
+			}
+			return true, nil
+		})
+		if err != nil {
+			return fmt.Errorf("no resource found for %s: %w", obj.GroupVersionKind(), err)
+		}
+		if err := fn(ri, obj); err != nil {
+			return fmt.Errorf("%s %s: %w", obj.GetKind(), obj.GetName(), err)
+		}
+	}
+}
+
+// runExternalDNS runs external-dns once with the flags of the scenario and of the provider.
+func (h *harness) runExternalDNS(ctx context.Context, t *testing.T, s *Scenario) {
+	args := append([]string{"--once", "--kubeconfig=" + h.kubeconfig, "--metrics-address=127.0.0.1:0"}, s.Args...)
+	args = append(args, h.providerFlags...)
+	out, err := exec.CommandContext(ctx, h.binary, args...).CombinedOutput()
+	if err != nil {
+		t.Fatalf("external-dns failed: %v\n%s", err, out)
+	}
+	t.Logf("external-dns output:\n%s", out)
+}
+
+func TestScenarios(t *testing.T) {
+	scenarios, err := LoadScenarios(getenv("E2E_SCENARIOS", "scenarios"), h.zone)
+	require.NoError(t, err)
+
+	for _, s := range scenarios {
+		t.Run(s.Name, func(t *testing.T) {
+			ctx := t.Context()
+			namespace := "e2e-" + s.Name
+			_, err := h.kube.CoreV1().Namespaces().Create(ctx, &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: namespace}}, metav1.CreateOptions{})
+			if err != nil && !apierrors.IsAlreadyExists(err) {
+				t.Fatal(err)
+			}
+			t.Cleanup(func() {
+				_ = h.kube.CoreV1().Namespaces().Delete(context.Background(), namespace, metav1.DeleteOptions{})
+			})
+
+			for i, step := range s.Steps {
+				name := step.Name
+				if name == "" {
+					name = fmt.Sprintf("step %d", i+1)
+				}
+				if step.Apply != "" {
+					require.NoError(t, h.apply(ctx, namespace, step.Apply), name)
+				}
+				if step.Delete != "" {
+					require.NoError(t, h.delete(ctx, namespace, step.Delete), name)
+				}
+
+				// The provider may take some time to report the changes: run again until they show up.
+				var checkErr error
+				deadline := time.Now().Add(h.timeout)
+				for {
+					h.runExternalDNS(ctx, t, s)
+					records, err := h.records.Records(ctx)
+					require.NoError(t, err, name)
+					if checkErr = step.Check(records); checkErr == nil || time.Now().After(deadline) {
+						break
+					}
+					time.Sleep(5 * time.Second)
+				}
+				require.NoError(t, checkErr, name)
+			}
+		})
+	}
+}
+
+func command(ctx context.Context, name string, args ...string) error {
+	var out bytes.Buffer
+	cmd := exec.CommandContext(ctx, name, args...)
+	cmd.Stdout = &out
+	cmd.Stderr = &out
+	if err := cmd.Run(); err != nil {
+		return fmt.Errorf("%s %s: %w\n%s", name, strings.Join(args, " "), err, out.String())
+	}
+	return nil
+}
+
+func getenv(key, fallback string) string {
+	if value := os.Getenv(key); value != "" {
+		return value
+	}
+	return fallback
+}
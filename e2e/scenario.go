@@ -0,0 +1,181 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package e2e runs declarative end-to-end scenarios: Kubernetes objects are applied to a cluster,
+// usually created with kind, external-dns is run against them, and the records of the provider
+// are checked against the expected ones.
+package e2e
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/goccy/go-yaml"
+	"k8s.io/apimachinery/pkg/util/validation"
+
+	"sigs.k8s.io/external-dns/endpoint"
+)
+
+// ZonePlaceholder is replaced by the zone the records are created in when loading a scenario.
+const ZonePlaceholder = "${ZONE}"
+
+// reservedFlags are set by the harness, and cannot be set by scenarios.
+var reservedFlags = []string{"--once", "--kubeconfig", "--metrics-address", "--provider"}
+
+// Scenario is a sequence of steps applied to a cluster, each checked against the records of the provider.
+type Scenario struct {
+	// Name identifies the scenario, and names the namespace its objects are created in.
+	Name        string `yaml:"name"`
+	Description string `yaml:"description"`
+	// Args are the external-dns flags selecting and configuring the sources, the provider ones excepted.
+	Args  []string `yaml:"args"`
+	Steps []Step   `yaml:"steps"`
+}
+
+// Step applies or deletes Kubernetes objects, runs external-dns once, then checks the records.
+type Step struct {
+	Name string `yaml:"name"`
+	// Apply holds the YAML manifests of the objects to create or update.
+	Apply string `yaml:"apply"`
+	// Delete holds the YAML manifests of the objects to delete.
+	Delete string `yaml:"delete"`
+	// Expect lists the records that must exist once external-dns has run.
+	Expect []Record `yaml:"expect"`
+	// Absent lists the records that must not exist once external-dns has run.
+	Absent []Record `yaml:"absent"`
+}
+
+// Record matches the records of a name and type. Targets and TTL are only checked when set.
+type Record struct {
+	DNSName       string   `yaml:"dnsName"`
+	RecordType    string   `yaml:"recordType"`
+	SetIdentifier string   `yaml:"setIdentifier"`
+	Targets       []string `yaml:"targets"`
+	TTL           int64    `yaml:"ttl"`
+}
+
+func (r Record) String() string {
+	if r.SetIdentifier != "" {
+		return fmt.Sprintf("%s %s (%s)", r.RecordType, r.DNSName, r.SetIdentifier)
+	}
+	return fmt.Sprintf("%s %s", r.RecordType, r.DNSName)
+}
+
+// LoadScenarios loads the scenarios of all the YAML files of a directory, sorted by file name.
+func LoadScenarios(dir, zone string) ([]*Scenario, error) {
+	paths, err := filepath.Glob(filepath.Join(dir, "*.yaml"))
+	if err != nil {
+		return nil, err
+	}
+	slices.Sort(paths)
+
+	var scenarios []*Scenario
+	names := map[string]string{}
+	for _, path := range paths {
+		s, err := LoadScenario(path, zone)
+		if err != nil {
+			return nil, err
+		}
+		if other, ok := names[s.Name]; ok {
+			return nil, fmt.Errorf("%s: scenario %q is already defined in %s", path, s.Name, other)
+		}
+		names[s.Name] = path
+		scenarios = append(scenarios, s)
+	}
+	return scenarios, nil
+}
+
+// LoadScenario loads a scenario from a YAML file, replacing ZonePlaceholder with zone.
+func LoadScenario(path, zone string) (*Scenario, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var s Scenario
+	if err := yaml.UnmarshalWithOptions([]byte(strings.ReplaceAll(string(data), ZonePlaceholder, zone)), &s, yaml.Strict()); err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	if err := s.validate(); err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	return &s, nil
+}
+
+func (s *Scenario) validate() error {
+	if errs := validation.IsDNS1123Label(s.Name); len(errs) > 0 {
+		return fmt.Errorf("invalid scenario name %q: %s", s.Name, strings.Join(errs, ", "))
+	}
+	for _, arg := range s.Args {
+		for _, flag := range reservedFlags {
+			if arg == flag || strings.HasPrefix(arg, flag+"=") {
+				return fmt.Errorf("flag %s is set by the harness", flag)
+			}
+		}
+	}
+	if len(s.Steps) == 0 {
+		return errors.New("a scenario needs at least one step")
+	}
+	for i, step := range s.Steps {
+		if step.Apply == "" && step.Delete == "" {
+			return fmt.Errorf("step %d neither applies nor deletes objects", i+1)
+		}
+		for _, r := range slices.Concat(step.Expect, step.Absent) {
+			if r.DNSName == "" || r.RecordType == "" {
+				return fmt.Errorf("step %d: records need a dnsName and a recordType", i+1)
+			}
+		}
+	}
+	return nil
+}
+
+// Check returns an error listing the expected records missing from records, or not matching them,
+// and the absent records found in records.
+func (step Step) Check(records []*endpoint.Endpoint) error {
+	var errs []error
+	for _, r := range step.Expect {
+		ep := findRecord(records, r)
+		if ep == nil {
+			errs = append(errs, fmt.Errorf("%s: not found", r))
+			continue
+		}
+		if len(r.Targets) > 0 && !endpoint.NewTargets(r.Targets...).Same(ep.Targets) {
+			errs = append(errs, fmt.Errorf("%s: expected targets %v, got %v", r, r.Targets, []string(ep.Targets)))
+		}
+		if r.TTL > 0 && endpoint.TTL(r.TTL) != ep.RecordTTL {
+			errs = append(errs, fmt.Errorf("%s: expected TTL %d, got %d", r, r.TTL, ep.RecordTTL))
+		}
+	}
+	for _, r := range step.Absent {
+		if ep := findRecord(records, r); ep != nil {
+			errs = append(errs, fmt.Errorf("%s: expected absent, got targets %v", r, []string(ep.Targets)))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+func findRecord(records []*endpoint.Endpoint, r Record) *endpoint.Endpoint {
+	name := strings.TrimSuffix(strings.ToLower(r.DNSName), ".")
+	for _, ep := range records {
+		if strings.TrimSuffix(strings.ToLower(ep.DNSName), ".") == name && ep.RecordType == r.RecordType && ep.SetIdentifier == r.SetIdentifier {
+			return ep
+		}
+	}
+	return nil
+}
@@ -0,0 +1,119 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package e2e
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"sigs.k8s.io/external-dns/endpoint"
+)
+
+func TestLoadScenarios(t *testing.T) {
+	scenarios, err := LoadScenarios("scenarios", "e2e.example.org")
+	require.NoError(t, err)
+	require.NotEmpty(t, scenarios)
+	for _, s := range scenarios {
+		assert.Contains(t, s.Args, "--domain-filter=e2e.example.org", s.Name)
+	}
+}
+
+func TestLoadScenarioInvalid(t *testing.T) {
+	for _, tt := range []struct {
+		name     string
+		scenario string
+		err      string
+	}{
+		{
+			name:     "invalid name",
+			scenario: "name: Not_A_Label\nsteps: [{apply: x}]\n",
+			err:      `invalid scenario name "Not_A_Label"`,
+		},
+		{
+			name:     "reserved flag",
+			scenario: "name: reserved\nargs: [--provider=aws]\nsteps: [{apply: x}]\n",
+			err:      "flag --provider is set by the harness",
+		},
+		{
+			name:     "no steps",
+			scenario: "name: empty\n",
+			err:      "a scenario needs at least one step",
+		},
+		{
+			name:     "empty step",
+			scenario: "name: empty-step\nsteps: [{expect: [{dnsName: a.example.org, recordType: A}]}]\n",
+			err:      "step 1 neither applies nor deletes objects",
+		},
+		{
+			name:     "incomplete record",
+			scenario: "name: incomplete\nsteps: [{apply: x, absent: [{dnsName: a.example.org}]}]\n",
+			err:      "step 1: records need a dnsName and a recordType",
+		},
+		{
+			name:     "unknown field",
+			scenario: "name: unknown\nsteps: [{apply: x, expected: []}]\n",
+			err:      "unknown field",
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), "scenario.yaml")
+			require.NoError(t, os.WriteFile(path, []byte(tt.scenario), 0o600))
+			_, err := LoadScenario(path, "example.org")
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.err)
+		})
+	}
+}
+
+func TestStepCheck(t *testing.T) {
+	records := []*endpoint.Endpoint{
+		endpoint.NewEndpointWithTTL("a.example.org", endpoint.RecordTypeA, 300, "192.0.2.2", "192.0.2.1"),
+		endpoint.NewEndpoint("b.example.org.", endpoint.RecordTypeCNAME, "a.example.org"),
+		endpoint.NewEndpoint("c.example.org", endpoint.RecordTypeA, "192.0.2.3").WithSetIdentifier("eu"),
+	}
+
+	require.NoError(t, Step{
+		Expect: []Record{
+			{DNSName: "a.example.org", RecordType: "A", Targets: []string{"192.0.2.1", "192.0.2.2"}, TTL: 300},
+			{DNSName: "B.example.org", RecordType: "CNAME"},
+			{DNSName: "c.example.org", RecordType: "A", SetIdentifier: "eu"},
+		},
+		Absent: []Record{
+			{DNSName: "a.example.org", RecordType: "AAAA"},
+			{DNSName: "c.example.org", RecordType: "A"},
+		},
+	}.Check(records))
+
+	err := Step{
+		Expect: []Record{
+			{DNSName: "a.example.org", RecordType: "A", Targets: []string{"192.0.2.1"}, TTL: 60},
+			{DNSName: "d.example.org", RecordType: "A"},
+		},
+		Absent: []Record{
+			{DNSName: "b.example.org", RecordType: "CNAME"},
+		},
+	}.Check(records)
+	require.Error(t, err)
+	assert.Equal(t, `A a.example.org: expected targets [192.0.2.1], got [192.0.2.1 192.0.2.2]
+A a.example.org: expected TTL 60, got 300
+A d.example.org: not found
+CNAME b.example.org: expected absent, got targets [a.example.org]`, err.Error())
+}
@@ -17,8 +17,10 @@ limitations under the License.
 package endpoint
 
 import (
+	"encoding/hex"
 	"fmt"
 	"net/netip"
+	"slices"
 	"sort"
 	"strconv"
 	"strings"
@@ -47,8 +49,34 @@ const (
 	RecordTypeMX = "MX"
 	// RecordTypeNAPTR is a RecordType enum value
 	RecordTypeNAPTR = "NAPTR"
+	// RecordTypeSOA is a RecordType enum value
+	RecordTypeSOA = "SOA"
+	// RecordTypeCAA is a RecordType enum value
+	RecordTypeCAA = "CAA"
+	// RecordTypeTLSA is a RecordType enum value
+	RecordTypeTLSA = "TLSA"
+	// RecordTypeSSHFP is a RecordType enum value
+	RecordTypeSSHFP = "SSHFP"
 )
 
+// PinnedProperty is the provider specific property marking the endpoints whose DNS name is pinned:
+// its records are kept at their values when it got pinned until it is unpinned.
+const PinnedProperty = "pinned"
+
+const (
+	// ScheduledTargetsProperty is the provider specific property holding the comma separated targets
+	// an endpoint switches to at the time of its ScheduledAtProperty.
+	ScheduledTargetsProperty = "scheduled-targets"
+	// ScheduledAtProperty is the provider specific property holding the RFC 3339 time at which an
+	// endpoint switches to its ScheduledTargetsProperty.
+	ScheduledAtProperty = "scheduled-at"
+)
+
+// DNSViewProperty is the provider specific property selecting the DNS view holding the records of an
+// endpoint, with the providers supporting split DNS: records of the same name in different views are
+// distinct records.
+const DNSViewProperty = "dns-view"
+
 // TTL is a structure defining the TTL of a DNS record
 type TTL int64
 
@@ -98,8 +126,10 @@ func (t Targets) Same(o Targets) bool {
 	if len(t) != len(o) {
 		return false
 	}
-	sort.Stable(t)
-	sort.Stable(o)
+	if len(t) > 1 {
+		sort.Stable(t)
+		sort.Stable(o)
+	}
 
 	for i, e := range t {
 		if !strings.EqualFold(e, o[i]) {
@@ -222,6 +252,20 @@ type Endpoint struct {
 	// ProviderSpecific stores provider specific config
 	// +optional
 	ProviderSpecific ProviderSpecific `json:"providerSpecific,omitempty"`
+	// ObservedVersion is the version of the source resource the endpoint was generated from.
+	// It is neither persisted nor part of the DNSEndpoint API.
+	ObservedVersion ObservedVersion `json:"-"`
+}
+
+// ObservedVersion identifies the version of a Kubernetes resource a source observed.
+type ObservedVersion struct {
+	Generation      int64  `json:"generation,omitempty"`
+	ResourceVersion string `json:"resourceVersion,omitempty"`
+}
+
+// IsZero returns true when no version was observed.
+func (v ObservedVersion) IsZero() bool {
+	return v.Generation == 0 && v.ResourceVersion == ""
 }
 
 // NewEndpoint initialization method to be used to create an endpoint
@@ -231,20 +275,20 @@ func NewEndpoint(dnsName, recordType string, targets ...string) *Endpoint {
 
 // NewEndpointWithTTL initialization method to be used to create an endpoint with a TTL struct
 func NewEndpointWithTTL(dnsName, recordType string, ttl TTL, targets ...string) *Endpoint {
-	cleanTargets := make([]string, len(targets))
-	for idx, target := range targets {
-		cleanTargets[idx] = strings.TrimSuffix(target, ".")
-	}
-
-	for _, label := range strings.Split(dnsName, ".") {
+	for label := range strings.SplitSeq(dnsName, ".") {
 		if len(label) > 63 {
 			log.Errorf("label %s in %s is longer than 63 characters. Cannot create endpoint", label, dnsName)
 			return nil
 		}
 	}
 
+	cleanTargets := make([]string, len(targets))
+	for idx, target := range targets {
+		cleanTargets[idx] = intern(strings.TrimSuffix(target, "."))
+	}
+
 	return &Endpoint{
-		DNSName:    strings.TrimSuffix(dnsName, "."),
+		DNSName:    intern(strings.TrimSuffix(dnsName, ".")),
 		Targets:    cleanTargets,
 		RecordType: recordType,
 		Labels:     NewLabels(),
@@ -312,6 +356,12 @@ func (e *Endpoint) Key() EndpointKey {
 	}
 }
 
+// DNSView returns the DNS view holding the records of the endpoint, empty for the default view.
+func (e *Endpoint) DNSView() string {
+	view, _ := e.GetProviderSpecificProperty(DNSViewProperty)
+	return view
+}
+
 // IsOwnedBy returns true if the endpoint owner label matches the given ownerID, false otherwise
 func (e *Endpoint) IsOwnedBy(ownerID string) bool {
 	endpointOwner, ok := e.Labels[OwnerLabelKey]
@@ -337,6 +387,20 @@ func FilterEndpointsByOwnerID(ownerID string, eps []*Endpoint) []*Endpoint {
 	return filtered
 }
 
+// FilterEndpointsByOwnerIDs returns the endpoints owned by any of the given owners.
+func FilterEndpointsByOwnerIDs(ownerIDs []string, eps []*Endpoint) []*Endpoint {
+	filtered := []*Endpoint{}
+	for _, ep := range eps {
+		if endpointOwner, ok := ep.Labels[OwnerLabelKey]; !ok || !slices.Contains(ownerIDs, endpointOwner) {
+			log.Debugf(`Skipping endpoint %v because owner id does not match, found: "%s", required one of: %q`, ep, endpointOwner, ownerIDs)
+		} else {
+			filtered = append(filtered, ep)
+		}
+	}
+
+	return filtered
+}
+
 // DNSEndpointSpec defines the desired state of DNSEndpoint
 // +kubebuilder:object:generate=true
 type DNSEndpointSpec struct {
@@ -407,6 +471,12 @@ func (e *Endpoint) CheckEndpoint() bool {
 		return e.Targets.ValidateMXRecord()
 	case RecordTypeSRV:
 		return e.Targets.ValidateSRVRecord()
+	case RecordTypeCAA:
+		return e.Targets.ValidateCAARecord()
+	case RecordTypeTLSA:
+		return e.Targets.ValidateTLSARecord()
+	case RecordTypeSSHFP:
+		return e.Targets.ValidateSSHFPRecord()
 	}
 	return true
 }
@@ -450,3 +520,70 @@ func (t Targets) ValidateSRVRecord() bool {
 	}
 	return true
 }
+
+func (t Targets) ValidateCAARecord() bool {
+	for _, target := range t {
+		// CAA records must have flags, a tag and a value, e.g. '0 issue "letsencrypt.org"'
+		// as per https://www.rfc-editor.org/rfc/rfc8659.txt
+		targetParts := strings.Fields(strings.TrimSpace(target))
+		if len(targetParts) < 3 {
+			log.Debugf("Invalid CAA record target: %s. CAA records must have flags, a tag and a value, e.g. '0 issue \"letsencrypt.org\"'", target)
+			return false
+		}
+		if _, err := strconv.ParseUint(targetParts[0], 10, 8); err != nil {
+			log.Debugf("Invalid CAA record target: %s. Invalid flags in target.", target)
+			return false
+		}
+		for _, r := range targetParts[1] {
+			if (r < 'a' || r > 'z') && (r < 'A' || r > 'Z') && (r < '0' || r > '9') {
+				log.Debugf("Invalid CAA record target: %s. Invalid tag in target.", target)
+				return false
+			}
+		}
+	}
+	return true
+}
+
+func (t Targets) ValidateTLSARecord() bool {
+	for _, target := range t {
+		// TLSA records must have a usage, a selector, a matching type and hexadecimal data, e.g. '3 1 1 0123abcd'
+		// as per https://www.rfc-editor.org/rfc/rfc6698.txt
+		if !validateHexRData(target, 3) {
+			log.Debugf("Invalid TLSA record target: %s. TLSA records must have a usage, a selector, a matching type and hexadecimal data, e.g. '3 1 1 0123abcd'", target)
+			return false
+		}
+	}
+	return true
+}
+
+func (t Targets) ValidateSSHFPRecord() bool {
+	for _, target := range t {
+		// SSHFP records must have an algorithm, a fingerprint type and a hexadecimal fingerprint, e.g. '4 2 0123abcd'
+		// as per https://www.rfc-editor.org/rfc/rfc4255.txt
+		if !validateHexRData(target, 2) {
+			log.Debugf("Invalid SSHFP record target: %s. SSHFP records must have an algorithm, a fingerprint type and a hexadecimal fingerprint, e.g. '4 2 0123abcd'", target)
+			return false
+		}
+	}
+	return true
+}
+
+// validateHexRData returns whether target is made of the given number of 8 bit integers followed by
+// hexadecimal data, which may be split by whitespace.
+func validateHexRData(target string, integers int) bool {
+	targetParts := strings.Fields(target)
+	if len(targetParts) <= integers {
+		return false
+	}
+	for _, part := range targetParts[:integers] {
+		if _, err := strconv.ParseUint(part, 10, 8); err != nil {
+			return false
+		}
+	}
+	data := strings.Join(targetParts[integers:], "")
+	if len(data)%2 != 0 {
+		return false
+	}
+	_, err := hex.DecodeString(data)
+	return err == nil
+}
@@ -531,6 +531,96 @@ func TestPDNScheckEndpoint(t *testing.T) {
 			},
 			expected: false,
 		},
+		{
+			description: "Valid CAA record targets",
+			endpoint: Endpoint{
+				DNSName:    "example.com",
+				RecordType: RecordTypeCAA,
+				Targets:    Targets{`0 issue "letsencrypt.org"`, "128 iodef mailto:security@example.com"},
+			},
+			expected: true,
+		},
+		{
+			description: "Invalid CAA record with missing value",
+			endpoint: Endpoint{
+				DNSName:    "example.com",
+				RecordType: RecordTypeCAA,
+				Targets:    Targets{"0 issue"},
+			},
+			expected: false,
+		},
+		{
+			description: "Invalid CAA record with out of range flags",
+			endpoint: Endpoint{
+				DNSName:    "example.com",
+				RecordType: RecordTypeCAA,
+				Targets:    Targets{`256 issue "letsencrypt.org"`},
+			},
+			expected: false,
+		},
+		{
+			description: "Invalid CAA record with non-alphanumeric tag",
+			endpoint: Endpoint{
+				DNSName:    "example.com",
+				RecordType: RecordTypeCAA,
+				Targets:    Targets{`0 is-sue "letsencrypt.org"`},
+			},
+			expected: false,
+		},
+		{
+			description: "Valid TLSA record targets",
+			endpoint: Endpoint{
+				DNSName:    "_443._tcp.example.com",
+				RecordType: RecordTypeTLSA,
+				Targets:    Targets{"3 1 1 0C72AC70B745AC19998811B131D662C9AC69DBDBE7CB23E5B514B56664C5D3D6", "2 0 1 0c72ac70 b745ac19"},
+			},
+			expected: true,
+		},
+		{
+			description: "Invalid TLSA record with missing data",
+			endpoint: Endpoint{
+				DNSName:    "_443._tcp.example.com",
+				RecordType: RecordTypeTLSA,
+				Targets:    Targets{"3 1 1"},
+			},
+			expected: false,
+		},
+		{
+			description: "Invalid TLSA record with non-hexadecimal data",
+			endpoint: Endpoint{
+				DNSName:    "_443._tcp.example.com",
+				RecordType: RecordTypeTLSA,
+				Targets:    Targets{"3 1 1 0c72zz"},
+			},
+			expected: false,
+		},
+		{
+			description: "Valid SSHFP record target",
+			endpoint: Endpoint{
+				DNSName:    "host.example.com",
+				RecordType: RecordTypeSSHFP,
+				Targets:    Targets{"4 2 8e8ea5a6b2e5b0a6e1c0b1b8a4b8d37f8c58ee8b4b3c3f14cbbd2e7e1c2d4a5b"},
+			},
+			expected: true,
+		},
+		{
+			description: "Invalid SSHFP record with out of range algorithm",
+			endpoint: Endpoint{
+				DNSName:    "host.example.com",
+				RecordType: RecordTypeSSHFP,
+				Targets:    Targets{"256 2 8e8ea5a6"},
+			},
+			expected: false,
+		},
+		{
+			description: "Invalid SSHFP record with odd length fingerprint",
+			endpoint: Endpoint{
+				DNSName:    "host.example.com",
+				RecordType: RecordTypeSSHFP,
+				Targets:    Targets{"4 2 8e8"},
+			},
+			expected: false,
+		},
 	}
 
 	for _, tt := range tests {
@@ -0,0 +1,31 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package endpoint
+
+import "unique"
+
+// intern returns a canonical copy of s. Endpoints of large clusters repeat the
+// same strings many times, e.g. load balancer targets shared by many services,
+// owner IDs and resource labels, or names present both in the desired records
+// and in the records of the provider. Interning them lets all endpoints share
+// a single copy, which is released once no endpoint refers to it anymore.
+func intern(s string) string {
+	if s == "" {
+		return s
+	}
+	return unique.Make(s).Value()
+}
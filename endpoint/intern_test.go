@@ -0,0 +1,65 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package endpoint
+
+import (
+	"strings"
+	"testing"
+	"unsafe"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// fresh returns a copy of s backed by its own memory.
+func fresh(s string) string {
+	return strings.Clone(s)
+}
+
+func TestIntern(t *testing.T) {
+	a, b := intern(fresh("192.0.2.1")), intern(fresh("192.0.2.1"))
+	assert.Equal(t, "192.0.2.1", a)
+	assert.Equal(t, unsafe.StringData(a), unsafe.StringData(b))
+	assert.Empty(t, intern(""))
+}
+
+func TestNewEndpointInternsStrings(t *testing.T) {
+	e1 := NewEndpoint(fresh("foo.example.com."), fresh("A"), fresh("192.0.2.1."))
+	e2 := NewEndpoint(fresh("foo.example.com"), fresh("A"), fresh("192.0.2.1"))
+
+	assert.Equal(t, "foo.example.com", e1.DNSName)
+	assert.Equal(t, unsafe.StringData(e1.DNSName), unsafe.StringData(e2.DNSName))
+	assert.Equal(t, unsafe.StringData(e1.Targets[0]), unsafe.StringData(e2.Targets[0]))
+}
+
+func TestNewLabelsFromStringInternsValues(t *testing.T) {
+	text := "heritage=external-dns,external-dns/owner=cluster-1,external-dns/resource=service/default/foo"
+	l1, err := NewLabelsFromStringPlain(fresh(text))
+	assert.NoError(t, err)
+	l2, err := NewLabelsFromStringPlain(fresh(text))
+	assert.NoError(t, err)
+
+	assert.Equal(t, Labels{OwnerLabelKey: "cluster-1", ResourceLabelKey: "service/default/foo"}, l1)
+	assert.Equal(t, unsafe.StringData(l1[OwnerLabelKey]), unsafe.StringData(l2[OwnerLabelKey]))
+	assert.Equal(t, unsafe.StringData(l1[ResourceLabelKey]), unsafe.StringData(l2[ResourceLabelKey]))
+}
+
+func BenchmarkNewEndpointWithTTL(b *testing.B) {
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		NewEndpointWithTTL("svc.namespace.cluster.example.com", RecordTypeA, 300, "192.0.2.1", "192.0.2.2")
+	}
+}
@@ -63,11 +63,10 @@ func NewLabelsFromStringPlain(labelText string) (Labels, error) {
 	tokens := strings.Split(labelText, ",")
 	foundExternalDNSHeritage := false
 	for _, token := range tokens {
-		if len(strings.Split(token, "=")) != 2 {
+		key, val, ok := strings.Cut(token, "=")
+		if !ok || strings.Contains(val, "=") {
 			continue
 		}
-		key := strings.Split(token, "=")[0]
-		val := strings.Split(token, "=")[1]
 		if key == "heritage" && val != heritage {
 			return nil, ErrInvalidHeritage
 		}
@@ -76,7 +75,7 @@ func NewLabelsFromStringPlain(labelText string) (Labels, error) {
 			continue
 		}
 		if strings.HasPrefix(key, heritage) {
-			endpointLabels[strings.TrimPrefix(key, heritage+"/")] = val
+			endpointLabels[intern(strings.TrimPrefix(key, heritage+"/"))] = intern(val)
 		}
 	}
 
@@ -0,0 +1,228 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package endpoint
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// ProviderSpecificPropertySchema describes a provider-specific property understood by a provider.
+type ProviderSpecificPropertySchema struct {
+	// Name is the name of the property, e.g. aws/weight.
+	Name string
+	// Provider is the provider understanding the property.
+	Provider string
+	// Validate, when set, returns an error for values the provider does not understand.
+	Validate func(value string) error
+}
+
+var (
+	providerSpecificSchemaMutex sync.RWMutex
+	// providerSpecificSchemas holds the known properties by name.
+	providerSpecificSchemas = map[string]ProviderSpecificPropertySchema{}
+	// providerSpecificNamespaces holds the name prefixes whose properties are all known: a property
+	// in one of them that is not known is a typo.
+	providerSpecificNamespaces = map[string]string{}
+)
+
+// RegisterProviderSpecificNamespace declares that all the properties named with the given prefix
+// are registered, so that unknown ones get reported.
+func RegisterProviderSpecificNamespace(prefix, provider string) {
+	providerSpecificSchemaMutex.Lock()
+	defer providerSpecificSchemaMutex.Unlock()
+	providerSpecificNamespaces[prefix] = provider
+}
+
+// RegisterProviderSpecificProperty registers a provider-specific property.
+func RegisterProviderSpecificProperty(schema ProviderSpecificPropertySchema) {
+	providerSpecificSchemaMutex.Lock()
+	defer providerSpecificSchemaMutex.Unlock()
+	providerSpecificSchemas[schema.Name] = schema
+}
+
+// ValidateProviderSpecificProperty returns an error when a property is unknown in a namespace
+// whose properties are all registered, suggesting the closest known name, or when its value is
+// invalid. Properties outside of these namespaces, e.g. those of webhook providers, are accepted.
+func ValidateProviderSpecificProperty(name, value string) error {
+	providerSpecificSchemaMutex.RLock()
+	defer providerSpecificSchemaMutex.RUnlock()
+
+	if schema, ok := providerSpecificSchemas[name]; ok {
+		if schema.Validate == nil {
+			return nil
+		}
+		if err := schema.Validate(value); err != nil {
+			return fmt.Errorf("invalid value %q of %s property %q: %w", value, schema.Provider, name, err)
+		}
+		return nil
+	}
+
+	for prefix, provider := range providerSpecificNamespaces {
+		if !strings.HasPrefix(name, prefix) {
+			continue
+		}
+		if suggestion := closestProviderSpecificProperty(name, prefix); suggestion != "" {
+			return fmt.Errorf("unknown %s property %q, did you mean %q?", provider, name, suggestion)
+		}
+		return fmt.Errorf("unknown %s property %q", provider, name)
+	}
+	return nil
+}
+
+// closestProviderSpecificProperty returns the known property of the namespace closest to name,
+// if close enough to be a typo.
+func closestProviderSpecificProperty(name, prefix string) string {
+	var names []string
+	for n := range providerSpecificSchemas {
+		if strings.HasPrefix(n, prefix) {
+			names = append(names, n)
+		}
+	}
+	sort.Strings(names)
+
+	closest, best := "", 4
+	for _, n := range names {
+		if d := editDistance(name, n); d < best {
+			closest, best = n, d
+		}
+	}
+	return closest
+}
+
+// editDistance returns the Levenshtein distance between a and b.
+func editDistance(a, b string) int {
+	previous := make([]int, len(b)+1)
+	current := make([]int, len(b)+1)
+	for j := range previous {
+		previous[j] = j
+	}
+	for i := 1; i <= len(a); i++ {
+		current[0] = i
+		for j := 1; j <= len(b); j++ {
+			cost := 1
+			if a[i-1] == b[j-1] {
+				cost = 0
+			}
+			current[j] = min(previous[j]+1, current[j-1]+1, previous[j-1]+cost)
+		}
+		previous, current = current, previous
+	}
+	return previous[len(b)]
+}
+
+func validateBool(value string) error {
+	_, err := strconv.ParseBool(value)
+	return err
+}
+
+func validateIntRange(lower, upper int) func(string) error {
+	return func(value string) error {
+		n, err := strconv.Atoi(value)
+		if err != nil {
+			return err
+		}
+		if n < lower || n > upper {
+			return fmt.Errorf("must be between %d and %d", lower, upper)
+		}
+		return nil
+	}
+}
+
+// validateCoordinates checks a "latitude,longitude" pair of decimal degrees.
+func validateCoordinates(value string) error {
+	latitude, longitude, found := strings.Cut(value, ",")
+	if !found {
+		return fmt.Errorf("must be a latitude and a longitude separated by a comma")
+	}
+	for _, c := range []struct {
+		name  string
+		value string
+		limit float64
+	}{{"latitude", latitude, 90}, {"longitude", longitude, 180}} {
+		f, err := strconv.ParseFloat(strings.TrimSpace(c.value), 64)
+		if err != nil {
+			return fmt.Errorf("invalid %s: %w", c.name, err)
+		}
+		if math.Abs(f) > c.limit {
+			return fmt.Errorf("%s must be between -%g and %g", c.name, c.limit, c.limit)
+		}
+	}
+	return nil
+}
+
+func validateOneOf(values ...string) func(string) error {
+	return func(value string) error {
+		for _, v := range values {
+			if value == v {
+				return nil
+			}
+		}
+		return fmt.Errorf("must be one of %s", strings.Join(values, ", "))
+	}
+}
+
+func init() {
+	const cloudflarePrefix = "external-dns.alpha.kubernetes.io/cloudflare-"
+
+	RegisterProviderSpecificNamespace("aws/", "aws")
+	RegisterProviderSpecificNamespace("scw/", "scaleway")
+	RegisterProviderSpecificNamespace("alibabacloud/", "alibabacloud")
+	RegisterProviderSpecificNamespace("constellix/", "constellix")
+	RegisterProviderSpecificNamespace("oci/", "oci")
+	RegisterProviderSpecificNamespace("ovh/", "ovh")
+	RegisterProviderSpecificNamespace("ibmcloud-", "ibmcloud")
+	RegisterProviderSpecificNamespace(cloudflarePrefix, "cloudflare")
+
+	for _, schema := range []ProviderSpecificPropertySchema{
+		{Name: "aws/target-hosted-zone", Provider: "aws"},
+		{Name: "aws/evaluate-target-health", Provider: "aws", Validate: validateBool},
+		{Name: "aws/weight", Provider: "aws", Validate: validateIntRange(0, 255)},
+		{Name: "aws/region", Provider: "aws"},
+		{Name: "aws/failover", Provider: "aws", Validate: validateOneOf("PRIMARY", "SECONDARY")},
+		{Name: "aws/geolocation-continent-code", Provider: "aws"},
+		{Name: "aws/geolocation-country-code", Provider: "aws"},
+		{Name: "aws/geolocation-subdivision-code", Provider: "aws"},
+		{Name: "aws/multi-value-answer", Provider: "aws"},
+		{Name: "aws/geoproximity-region", Provider: "aws"},
+		{Name: "aws/geoproximity-local-zone-group", Provider: "aws"},
+		{Name: "aws/geoproximity-coordinates", Provider: "aws", Validate: validateCoordinates},
+		{Name: "aws/geoproximity-bias", Provider: "aws", Validate: validateIntRange(-99, 99)},
+		{Name: "aws/cidr-routing-collection-id", Provider: "aws"},
+		{Name: "aws/cidr-routing-location-name", Provider: "aws"},
+		{Name: "aws/health-check-id", Provider: "aws"},
+		{Name: "scw/priority", Provider: "scaleway", Validate: validateIntRange(0, math.MaxInt32)},
+		{Name: "alibabacloud/vpc-ids", Provider: "alibabacloud"},
+		{Name: "constellix/failover", Provider: "constellix", Validate: validateBool},
+		{Name: "constellix/pool-return", Provider: "constellix", Validate: validateIntRange(1, math.MaxInt32)},
+		{Name: "constellix/health-checks", Provider: "constellix"},
+		{Name: "oci/view-id", Provider: "oci"},
+		{Name: "oci/weight", Provider: "oci", Validate: validateIntRange(0, 255)},
+		{Name: "ovh/ttl", Provider: "ovh", Validate: validateIntRange(0, math.MaxInt32)},
+		{Name: "ibmcloud-proxied", Provider: "ibmcloud", Validate: validateBool},
+		{Name: "ibmcloud-vpc", Provider: "ibmcloud"},
+		{Name: cloudflarePrefix + "proxied", Provider: "cloudflare", Validate: validateBool},
+		{Name: cloudflarePrefix + "custom-hostname", Provider: "cloudflare"},
+		{Name: cloudflarePrefix + "region-key", Provider: "cloudflare"},
+	} {
+		RegisterProviderSpecificProperty(schema)
+	}
+}
@@ -0,0 +1,81 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package endpoint
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestValidateProviderSpecificProperty(t *testing.T) {
+	for _, tt := range []struct {
+		name  string
+		value string
+		err   string
+	}{
+		{name: "aws/weight", value: "100"},
+		{name: "aws/weight", value: "heavy", err: `invalid value "heavy" of aws property "aws/weight": strconv.Atoi: parsing "heavy": invalid syntax`},
+		{name: "aws/weight", value: "300", err: `invalid value "300" of aws property "aws/weight": must be between 0 and 255`},
+		{name: "aws/failover", value: "PRIMARY"},
+		{name: "aws/failover", value: "primary", err: `invalid value "primary" of aws property "aws/failover": must be one of PRIMARY, SECONDARY`},
+		{name: "aws/geoproximity-coordinates", value: "49.22, -74.01"},
+		{name: "aws/geoproximity-coordinates", value: "49.22", err: `invalid value "49.22" of aws property "aws/geoproximity-coordinates": must be a latitude and a longitude separated by a comma`},
+		{name: "aws/geoproximity-coordinates", value: "91,0", err: `invalid value "91,0" of aws property "aws/geoproximity-coordinates": latitude must be between -90 and 90`},
+		{name: "aws/geoproximity-bias", value: "-99"},
+		{name: "aws/geoproximity-bias", value: "100", err: `invalid value "100" of aws property "aws/geoproximity-bias": must be between -99 and 99`},
+		{name: "aws/wieght", value: "100", err: `unknown aws property "aws/wieght", did you mean "aws/weight"?`},
+		{name: "aws/something-else", value: "1", err: `unknown aws property "aws/something-else"`},
+		{name: "scw/priority", value: "10"},
+		{name: "scw/prio", value: "10", err: `unknown scaleway property "scw/prio"`},
+		{name: "external-dns.alpha.kubernetes.io/cloudflare-proxied", value: "true"},
+		{name: "external-dns.alpha.kubernetes.io/cloudflare-proxied", value: "yes", err: `invalid value "yes" of cloudflare property "external-dns.alpha.kubernetes.io/cloudflare-proxied": strconv.ParseBool: parsing "yes": invalid syntax`},
+		{name: "external-dns.alpha.kubernetes.io/cloudflare-proxy", value: "true", err: `unknown cloudflare property "external-dns.alpha.kubernetes.io/cloudflare-proxy", did you mean "external-dns.alpha.kubernetes.io/cloudflare-proxied"?`},
+		{name: "constellix/pool-return", value: "0", err: `invalid value "0" of constellix property "constellix/pool-return": must be between 1 and 2147483647`},
+		{name: "ibmcloud-proxied", value: "false"},
+		{name: "ovh/ttl", value: "60"},
+		{name: "ovh/ttl", value: "-1", err: `invalid value "-1" of ovh property "ovh/ttl": must be between 0 and 2147483647`},
+		{name: "ovh/tll", value: "60", err: `unknown ovh property "ovh/tll", did you mean "ovh/ttl"?`},
+		// properties outside of the registered namespaces are accepted
+		{name: "webhook/anything", value: "x"},
+		{name: PinnedProperty, value: "true"},
+		{name: "alias", value: "true"},
+	} {
+		err := ValidateProviderSpecificProperty(tt.name, tt.value)
+		if tt.err == "" {
+			assert.NoError(t, err, tt.name)
+		} else {
+			assert.EqualError(t, err, tt.err)
+		}
+	}
+}
+
+func TestRegisterProviderSpecificProperty(t *testing.T) {
+	RegisterProviderSpecificNamespace("test/", "test")
+	RegisterProviderSpecificProperty(ProviderSpecificPropertySchema{Name: "test/mode", Provider: "test", Validate: validateOneOf("fast", "slow")})
+
+	assert.NoError(t, ValidateProviderSpecificProperty("test/mode", "fast"))
+	assert.EqualError(t, ValidateProviderSpecificProperty("test/mode", "medium"), `invalid value "medium" of test property "test/mode": must be one of fast, slow`)
+	assert.EqualError(t, ValidateProviderSpecificProperty("test/mdoe", "fast"), `unknown test property "test/mdoe", did you mean "test/mode"?`)
+}
+
+func TestEditDistance(t *testing.T) {
+	assert.Equal(t, 0, editDistance("weight", "weight"))
+	assert.Equal(t, 2, editDistance("wieght", "weight"))
+	assert.Equal(t, 6, editDistance("", "weight"))
+	assert.Equal(t, 1, editDistance("region", "regions"))
+}
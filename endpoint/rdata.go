@@ -0,0 +1,180 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package endpoint
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+)
+
+// MXTarget is the parsed RDATA of an MX record target, e.g. "10 mail.example.com".
+// It lets providers whose API takes the preference as a separate field keep the value
+// given in the endpoint instead of falling back to a default.
+type MXTarget struct {
+	Priority uint16
+	Host     string
+}
+
+// NewMXTarget parses an MX record target of the form "<preference> <exchange>".
+func NewMXTarget(target string) (MXTarget, error) {
+	parts := strings.Fields(strings.TrimSpace(target))
+	if len(parts) != 2 {
+		return MXTarget{}, fmt.Errorf("mx target %q needs to be of form '10 example.com'", target)
+	}
+	priority, err := strconv.ParseUint(parts[0], 10, 16)
+	if err != nil {
+		return MXTarget{}, fmt.Errorf("invalid priority in mx target %q: %w", target, err)
+	}
+	return MXTarget{Priority: uint16(priority), Host: parts[1]}, nil
+}
+
+// String returns the MX target in the RDATA form used by endpoints.
+func (t MXTarget) String() string {
+	return fmt.Sprintf("%d %s", t.Priority, t.Host)
+}
+
+// SRVTarget is the parsed RDATA of an SRV record target, e.g. "10 5 5060 sip.example.com".
+type SRVTarget struct {
+	Priority uint16
+	Weight   uint16
+	Port     uint16
+	Host     string
+}
+
+// NewSRVTarget parses an SRV record target of the form "<priority> <weight> <port> <target>".
+func NewSRVTarget(target string) (SRVTarget, error) {
+	parts := strings.Fields(strings.TrimSpace(target))
+	if len(parts) != 4 {
+		return SRVTarget{}, fmt.Errorf("srv target %q needs to be of form '10 5 5060 example.com'", target)
+	}
+	var values [3]uint16
+	for i, part := range parts[:3] {
+		value, err := strconv.ParseUint(part, 10, 16)
+		if err != nil {
+			return SRVTarget{}, fmt.Errorf("invalid integer value in srv target %q: %w", target, err)
+		}
+		values[i] = uint16(value)
+	}
+	return SRVTarget{Priority: values[0], Weight: values[1], Port: values[2], Host: parts[3]}, nil
+}
+
+// String returns the SRV target in the RDATA form used by endpoints.
+func (t SRVTarget) String() string {
+	return fmt.Sprintf("%d %d %d %s", t.Priority, t.Weight, t.Port, t.Host)
+}
+
+// MaxTXTStringLength is the maximum length, in bytes, of a character string of a TXT record.
+const MaxTXTStringLength = 255
+
+// TXTTarget is the value of a TXT record target, e.g. a DKIM public key, whatever the character strings it
+// was split into.
+type TXTTarget string
+
+// NewTXTTarget parses a TXT record target, either in its presentation form of quoted character strings, e.g.
+// `"v=DKIM1; k=rsa; " "p=MIIB..."`, the strings being concatenated, or as a value without quotes.
+func NewTXTTarget(target string) TXTTarget {
+	strs, ok := parseTXTStrings(strings.TrimSpace(target))
+	if !ok {
+		return TXTTarget(target)
+	}
+	return TXTTarget(strings.Join(strs, ""))
+}
+
+// parseTXTStrings parses a sequence of quoted character strings separated by spaces, in which quotes and
+// backslashes are escaped with a backslash, as well as bytes given as \DDD.
+func parseTXTStrings(s string) ([]string, bool) {
+	if !strings.HasPrefix(s, `"`) {
+		return nil, false
+	}
+	var strs []string
+	for s != "" {
+		if s[0] != '"' {
+			return nil, false
+		}
+		var str strings.Builder
+		i := 1
+		for ; i < len(s) && s[i] != '"'; i++ {
+			if s[i] != '\\' {
+				str.WriteByte(s[i])
+				continue
+			}
+			if i+3 < len(s) && isDigits(s[i+1:i+4]) {
+				b, err := strconv.ParseUint(s[i+1:i+4], 10, 8)
+				if err != nil {
+					return nil, false
+				}
+				str.WriteByte(byte(b))
+				i += 3
+				continue
+			}
+			if i+1 == len(s) {
+				return nil, false
+			}
+			i++
+			str.WriteByte(s[i])
+		}
+		if i == len(s) {
+			return nil, false
+		}
+		strs = append(strs, str.String())
+		s = strings.TrimLeft(s[i+1:], " \t")
+	}
+	return strs, true
+}
+
+func isDigits(s string) bool {
+	for _, c := range s {
+		if c < '0' || c > '9' {
+			return false
+		}
+	}
+	return true
+}
+
+// Strings returns the value split into character strings of at most MaxTXTStringLength bytes, not splitting
+// UTF-8 encoded characters.
+func (t TXTTarget) Strings() []string {
+	value := string(t)
+	if value == "" {
+		return []string{""}
+	}
+	var strs []string
+	for len(value) > MaxTXTStringLength {
+		n := MaxTXTStringLength
+		for n > 0 && !utf8.RuneStart(value[n]) {
+			n--
+		}
+		if n == 0 {
+			n = MaxTXTStringLength
+		}
+		strs = append(strs, value[:n])
+		value = value[n:]
+	}
+	return append(strs, value)
+}
+
+// String returns the TXT target in its presentation form, its character strings being quoted and separated
+// by spaces, e.g. `"v=spf1 include:example.com ~all"`.
+func (t TXTTarget) String() string {
+	strs := t.Strings()
+	for i, str := range strs {
+		strs[i] = `"` + strings.NewReplacer(`\`, `\\`, `"`, `\"`).Replace(str) + `"`
+	}
+	return strings.Join(strs, " ")
+}
@@ -0,0 +1,78 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package endpoint
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewMXTarget(t *testing.T) {
+	target, err := NewMXTarget("10 mail.example.com")
+	require.NoError(t, err)
+	assert.Equal(t, MXTarget{Priority: 10, Host: "mail.example.com"}, target)
+	assert.Equal(t, "10 mail.example.com", target.String())
+
+	for _, invalid := range []string{"mail.example.com", "ten mail.example.com", "70000 mail.example.com", "10 mail example.com"} {
+		_, err := NewMXTarget(invalid)
+		assert.Error(t, err, invalid)
+	}
+}
+
+func TestNewSRVTarget(t *testing.T) {
+	target, err := NewSRVTarget("10 5 5060 sip.example.com")
+	require.NoError(t, err)
+	assert.Equal(t, SRVTarget{Priority: 10, Weight: 5, Port: 5060, Host: "sip.example.com"}, target)
+	assert.Equal(t, "10 5 5060 sip.example.com", target.String())
+
+	for _, invalid := range []string{"sip.example.com", "10 5 sip.example.com", "10 5 port sip.example.com", "10 5 70000 sip.example.com"} {
+		_, err := NewSRVTarget(invalid)
+		assert.Error(t, err, invalid)
+	}
+}
+
+func TestNewTXTTarget(t *testing.T) {
+	for target, value := range map[string]string{
+		"v=spf1 -all":                    "v=spf1 -all",
+		`"v=spf1 -all"`:                  "v=spf1 -all",
+		`"v=DKIM1; " "p=MIIB"`:           "v=DKIM1; p=MIIB",
+		`"say \"hi\"" "\\o/" "\065\066"`: `say "hi"\o/AB`,
+		`"unterminated`:                  `"unterminated`,
+		`"quoted" unquoted`:              `"quoted" unquoted`,
+		`""`:                             "",
+	} {
+		assert.Equal(t, TXTTarget(value), NewTXTTarget(target), target)
+	}
+}
+
+func TestTXTTargetStrings(t *testing.T) {
+	long := strings.Repeat("a", 300)
+	assert.Equal(t, []string{strings.Repeat("a", 255), strings.Repeat("a", 45)}, TXTTarget(long).Strings())
+	assert.Equal(t, []string{""}, TXTTarget("").Strings())
+
+	// multi-byte characters are not split
+	multiByte := strings.Repeat("a", 254) + "é"
+	assert.Equal(t, []string{strings.Repeat("a", 254), "é"}, TXTTarget(multiByte).Strings())
+
+	assert.Equal(t, `"v=spf1 -all"`, TXTTarget("v=spf1 -all").String())
+	assert.Equal(t, `"say \"hi\" \\o/"`, TXTTarget(`say "hi" \o/`).String())
+	assert.Equal(t, `"`+strings.Repeat("a", 255)+`" "`+strings.Repeat("a", 45)+`"`, TXTTarget(long).String())
+	assert.Equal(t, TXTTarget(long), NewTXTTarget(TXTTarget(long).String()))
+}
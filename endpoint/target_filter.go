@@ -17,6 +17,7 @@ limitations under the License.
 package endpoint
 
 import (
+	"fmt"
 	"net"
 	"strings"
 
@@ -41,11 +42,9 @@ func prepareTargetFilters(filters []string) []*net.IPNet {
 	fs := make([]*net.IPNet, 0)
 
 	for _, filter := range filters {
-		filter = strings.TrimSpace(filter)
-
-		_, filterNet, err := net.ParseCIDR(filter)
+		filterNet, err := ParseTargetNet(filter)
 		if err != nil {
-			log.Errorf("Invalid target net filter: %s", filter)
+			log.Errorf("Invalid target net filter: %s", strings.TrimSpace(filter))
 
 			continue
 		}
@@ -55,6 +54,25 @@ func prepareTargetFilters(filters []string) []*net.IPNet {
 	return fs
 }
 
+// ParseTargetNet parses a target net filter entry, which is either a CIDR or a
+// single IP address. A single address is treated as a /32 (or /128 for IPv6).
+func ParseTargetNet(filter string) (*net.IPNet, error) {
+	filter = strings.TrimSpace(filter)
+
+	if _, filterNet, err := net.ParseCIDR(filter); err == nil {
+		return filterNet, nil
+	}
+
+	ip := net.ParseIP(filter)
+	if ip == nil {
+		return nil, fmt.Errorf("invalid target net %q, expected a CIDR or an IP address", filter)
+	}
+	if ip4 := ip.To4(); ip4 != nil {
+		return &net.IPNet{IP: ip4, Mask: net.CIDRMask(32, 32)}, nil
+	}
+	return &net.IPNet{IP: ip, Mask: net.CIDRMask(128, 128)}, nil
+}
+
 // NewTargetNetFilterWithExclusions returns a new TargetNetFilter, given a list of matches and exclusions
 func NewTargetNetFilterWithExclusions(targetFilterNets []string, excludeNets []string) TargetNetFilter {
 	return TargetNetFilter{FilterNets: prepareTargetFilters(targetFilterNets), excludeNets: prepareTargetFilters(excludeNets)}
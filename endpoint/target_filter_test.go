@@ -66,6 +66,30 @@ var targetFilterTests = []targetFilterTest{
 		[]string{"10.1.2.3"},
 		false,
 	},
+	{
+		[]string{"10.1.2.3"},
+		[]string{},
+		[]string{"10.1.2.3"},
+		true,
+	},
+	{
+		[]string{"10.1.2.3"},
+		[]string{},
+		[]string{"10.1.2.4"},
+		false,
+	},
+	{
+		[]string{},
+		[]string{"2001:db8::1"},
+		[]string{"2001:db8::1"},
+		false,
+	},
+	{
+		[]string{"10.0.0.0/8"},
+		[]string{},
+		[]string{"www.example.com"},
+		false,
+	},
 }
 
 func TestTargetFilterWithExclusions(t *testing.T) {
@@ -94,3 +118,26 @@ func TestMatchTargetFilterReturnsProperEmptyVal(t *testing.T) {
 	assert.Equal(t, true, matchFilter(emptyFilters, "sometarget.com", true))
 	assert.Equal(t, false, matchFilter(emptyFilters, "sometarget.com", false))
 }
+
+func TestParseTargetNet(t *testing.T) {
+	for _, tt := range []struct {
+		filter   string
+		expected string
+		err      string
+	}{
+		{filter: "10.0.0.0/8", expected: "10.0.0.0/8"},
+		{filter: " 10.1.2.3/16 ", expected: "10.1.0.0/16"},
+		{filter: "10.1.2.3", expected: "10.1.2.3/32"},
+		{filter: "2001:db8::1", expected: "2001:db8::1/128"},
+		{filter: "0", err: `invalid target net "0", expected a CIDR or an IP address`},
+		{filter: "10.0.0.0/33", err: `invalid target net "10.0.0.0/33", expected a CIDR or an IP address`},
+	} {
+		filterNet, err := ParseTargetNet(tt.filter)
+		if tt.err != "" {
+			assert.EqualError(t, err, tt.err)
+			continue
+		}
+		assert.NoError(t, err)
+		assert.Equal(t, tt.expected, filterNet.String())
+	}
+}
@@ -0,0 +1,224 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package planfuzz helps property-testing providers: it generates random but
+// reproducible endpoint sets and checks that a provider converges to them when
+// driven through the planner the same way the controller does.
+package planfuzz
+
+import (
+	"context"
+	"fmt"
+	"math/rand"
+	"slices"
+	"sort"
+	"strings"
+
+	"sigs.k8s.io/external-dns/endpoint"
+	"sigs.k8s.io/external-dns/plan"
+	"sigs.k8s.io/external-dns/provider"
+)
+
+// ManagedRecords are the record types produced by the Generator and managed by Converge.
+var ManagedRecords = []string{endpoint.RecordTypeA, endpoint.RecordTypeAAAA, endpoint.RecordTypeCNAME, endpoint.RecordTypeTXT}
+
+var (
+	labels  = []string{"", "www", "api", "app", "mail", "a.b"}
+	ttls    = []endpoint.TTL{0, 60, 300}
+	targets = map[string][]string{
+		endpoint.RecordTypeA:    {"192.0.2.1", "192.0.2.2", "192.0.2.3", "192.0.2.4"},
+		endpoint.RecordTypeAAAA: {"2001:db8::1", "2001:db8::2", "2001:db8::3"},
+		endpoint.RecordTypeTXT:  {"\"heritage=external-dns\"", "\"v=spf1 -all\"", "\"fuzz\""},
+	}
+)
+
+// Generator produces random endpoint sets within a zone. Names and targets are
+// drawn from small pools so that consecutive sets overlap and exercise
+// updates, partial target changes and record type changes.
+type Generator struct {
+	rand *rand.Rand
+	zone string
+}
+
+// NewGenerator returns a Generator for zone. The same seed always yields the
+// same sequence of endpoint sets.
+func NewGenerator(seed int64, zone string) *Generator {
+	return &Generator{rand: rand.New(rand.NewSource(seed)), zone: zone}
+}
+
+// Intn exposes the random source of the generator so that tests can derive
+// further choices from the same seed.
+func (g *Generator) Intn(n int) int {
+	return g.rand.Intn(n)
+}
+
+// Endpoints returns a random set of endpoints with at most one endpoint per
+// name and type. A name holding a CNAME holds no other record.
+func (g *Generator) Endpoints() []*endpoint.Endpoint {
+	var endpoints []*endpoint.Endpoint
+	for _, label := range labels {
+		name := g.zone
+		if label != "" {
+			name = label + "." + g.zone
+		}
+		switch g.rand.Intn(4) {
+		case 0:
+			// name not in use
+		case 1:
+			if label == "" {
+				// a CNAME cannot live at the zone apex
+				continue
+			}
+			target := labels[1+g.rand.Intn(len(labels)-1)] + ".target.example"
+			endpoints = append(endpoints, endpoint.NewEndpointWithTTL(name, endpoint.RecordTypeCNAME, g.ttl(), target))
+		default:
+			for _, recordType := range []string{endpoint.RecordTypeA, endpoint.RecordTypeAAAA, endpoint.RecordTypeTXT} {
+				if g.rand.Intn(2) == 0 {
+					continue
+				}
+				endpoints = append(endpoints, endpoint.NewEndpointWithTTL(name, recordType, g.ttl(), g.targets(targets[recordType])...))
+			}
+		}
+	}
+	return endpoints
+}
+
+func (g *Generator) ttl() endpoint.TTL {
+	return ttls[g.rand.Intn(len(ttls))]
+}
+
+// targets returns a non-empty random subset of pool in random order.
+func (g *Generator) targets(pool []string) []string {
+	n := 1 + g.rand.Intn(len(pool))
+	picked := make([]string, 0, n)
+	for _, i := range g.rand.Perm(len(pool))[:n] {
+		picked = append(picked, pool[i])
+	}
+	return picked
+}
+
+// Converge plans the changes needed to move the records of p to desired,
+// applies them, and verifies that p then reports exactly the desired records
+// and that planning again yields no further changes.
+func Converge(ctx context.Context, p provider.Provider, desired []*endpoint.Endpoint) error {
+	changes, err := calculate(ctx, p, desired)
+	if err != nil {
+		return err
+	}
+	if err := p.ApplyChanges(ctx, changes); err != nil {
+		return fmt.Errorf("applying %s: %w", describe(changes), err)
+	}
+
+	records, err := p.Records(ctx)
+	if err != nil {
+		return fmt.Errorf("listing records: %w", err)
+	}
+	if err := Compare(records, desired); err != nil {
+		return fmt.Errorf("after applying %s: %w", describe(changes), err)
+	}
+
+	again, err := calculate(ctx, p, desired)
+	if err != nil {
+		return err
+	}
+	if again.HasChanges() {
+		return fmt.Errorf("not idempotent, planned %s after applying %s", describe(again), describe(changes))
+	}
+	return nil
+}
+
+func calculate(ctx context.Context, p provider.Provider, desired []*endpoint.Endpoint) (*plan.Changes, error) {
+	records, err := p.Records(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("listing records: %w", err)
+	}
+	adjusted, err := p.AdjustEndpoints(copyEndpoints(desired))
+	if err != nil {
+		return nil, fmt.Errorf("adjusting endpoints: %w", err)
+	}
+	pl := &plan.Plan{
+		Current:        records,
+		Desired:        adjusted,
+		ManagedRecords: ManagedRecords,
+	}
+	return pl.Calculate().Changes, nil
+}
+
+// Compare returns an error describing the differences between the records
+// reported by a provider and the desired endpoints. Targets are compared
+// regardless of order, and TTLs only when the desired endpoint sets one.
+func Compare(records, desired []*endpoint.Endpoint) error {
+	byKey := map[string]*endpoint.Endpoint{}
+	var problems []string
+	for _, r := range records {
+		key := recordKey(r)
+		if _, ok := byKey[key]; ok {
+			problems = append(problems, fmt.Sprintf("%s reported more than once", key))
+		}
+		byKey[key] = r
+	}
+	for _, d := range desired {
+		key := recordKey(d)
+		r, ok := byKey[key]
+		if !ok {
+			problems = append(problems, fmt.Sprintf("%s missing", key))
+			continue
+		}
+		delete(byKey, key)
+		if !sameTargets(r.Targets, d.Targets) {
+			problems = append(problems, fmt.Sprintf("%s has targets %v, expected %v", key, r.Targets, d.Targets))
+		}
+		if d.RecordTTL.IsConfigured() && r.RecordTTL != d.RecordTTL {
+			problems = append(problems, fmt.Sprintf("%s has TTL %d, expected %d", key, r.RecordTTL, d.RecordTTL))
+		}
+	}
+	for key := range byKey {
+		problems = append(problems, fmt.Sprintf("%s unexpected", key))
+	}
+	if len(problems) == 0 {
+		return nil
+	}
+	sort.Strings(problems)
+	return fmt.Errorf("records differ from desired state: %s", strings.Join(problems, "; "))
+}
+
+func recordKey(e *endpoint.Endpoint) string {
+	return strings.TrimSuffix(strings.ToLower(e.DNSName), ".") + " " + e.RecordType
+}
+
+// sameTargets compares targets as multisets, so duplicated targets are not
+// mistaken for a single one.
+func sameTargets(a, b endpoint.Targets) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	sa, sb := slices.Clone(a), slices.Clone(b)
+	sort.Strings(sa)
+	sort.Strings(sb)
+	return slices.Equal(sa, sb)
+}
+
+func copyEndpoints(endpoints []*endpoint.Endpoint) []*endpoint.Endpoint {
+	copied := make([]*endpoint.Endpoint, 0, len(endpoints))
+	for _, e := range endpoints {
+		copied = append(copied, e.DeepCopy())
+	}
+	return copied
+}
+
+func describe(c *plan.Changes) string {
+	return fmt.Sprintf("changes create=%v updateOld=%v updateNew=%v delete=%v", c.Create, c.UpdateOld, c.UpdateNew, c.Delete)
+}
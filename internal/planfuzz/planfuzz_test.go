@@ -0,0 +1,121 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package planfuzz
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"sigs.k8s.io/external-dns/endpoint"
+	"sigs.k8s.io/external-dns/provider/inmemory"
+)
+
+func TestGeneratorIsReproducible(t *testing.T) {
+	a, b := NewGenerator(42, "example.com"), NewGenerator(42, "example.com")
+	for i := 0; i < 10; i++ {
+		assert.Equal(t, a.Endpoints(), b.Endpoints())
+	}
+}
+
+func TestGeneratorEndpoints(t *testing.T) {
+	g := NewGenerator(1, "example.com")
+	for i := 0; i < 100; i++ {
+		types := map[string][]string{}
+		for _, e := range g.Endpoints() {
+			assert.NotEmpty(t, e.Targets)
+			assert.Contains(t, ManagedRecords, e.RecordType)
+			assert.NotContains(t, types[e.DNSName], e.RecordType, "duplicate endpoint %s", e)
+			types[e.DNSName] = append(types[e.DNSName], e.RecordType)
+		}
+		for name, recordTypes := range types {
+			if name == "example.com" || len(recordTypes) > 1 {
+				assert.NotContains(t, recordTypes, endpoint.RecordTypeCNAME, name)
+			}
+		}
+	}
+}
+
+func TestCompare(t *testing.T) {
+	desired := []*endpoint.Endpoint{
+		endpoint.NewEndpointWithTTL("a.example.com", endpoint.RecordTypeA, 60, "192.0.2.1", "192.0.2.2"),
+		endpoint.NewEndpoint("b.example.com", endpoint.RecordTypeA, "192.0.2.3"),
+	}
+
+	for _, tc := range []struct {
+		name    string
+		records []*endpoint.Endpoint
+		err     string
+	}{
+		{
+			name: "same records in another order",
+			records: []*endpoint.Endpoint{
+				endpoint.NewEndpointWithTTL("b.example.com.", endpoint.RecordTypeA, 300, "192.0.2.3"),
+				endpoint.NewEndpointWithTTL("a.example.com", endpoint.RecordTypeA, 60, "192.0.2.2", "192.0.2.1"),
+			},
+		},
+		{
+			name: "duplicated target",
+			records: []*endpoint.Endpoint{
+				endpoint.NewEndpointWithTTL("a.example.com", endpoint.RecordTypeA, 60, "192.0.2.1", "192.0.2.1"),
+				endpoint.NewEndpoint("b.example.com", endpoint.RecordTypeA, "192.0.2.3"),
+			},
+			err: "records differ from desired state: a.example.com A has targets 192.0.2.1;192.0.2.1, expected 192.0.2.1;192.0.2.2",
+		},
+		{
+			name: "wrong TTL, missing and unexpected records",
+			records: []*endpoint.Endpoint{
+				endpoint.NewEndpointWithTTL("a.example.com", endpoint.RecordTypeA, 300, "192.0.2.1", "192.0.2.2"),
+				endpoint.NewEndpoint("c.example.com", endpoint.RecordTypeA, "192.0.2.3"),
+			},
+			err: "records differ from desired state: a.example.com A has TTL 300, expected 60; b.example.com A missing; c.example.com A unexpected",
+		},
+		{
+			name: "split record set",
+			records: []*endpoint.Endpoint{
+				endpoint.NewEndpointWithTTL("a.example.com", endpoint.RecordTypeA, 60, "192.0.2.1"),
+				endpoint.NewEndpointWithTTL("a.example.com", endpoint.RecordTypeA, 60, "192.0.2.2"),
+				endpoint.NewEndpoint("b.example.com", endpoint.RecordTypeA, "192.0.2.3"),
+			},
+			err: "records differ from desired state: a.example.com A has targets 192.0.2.2, expected 192.0.2.1;192.0.2.2; a.example.com A reported more than once",
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			err := Compare(tc.records, desired)
+			if tc.err == "" {
+				assert.NoError(t, err)
+				return
+			}
+			assert.EqualError(t, err, tc.err)
+		})
+	}
+}
+
+func TestConverge(t *testing.T) {
+	im := inmemory.NewInMemoryProvider(inmemory.InMemoryInitZones([]string{"example.com"}))
+	g := NewGenerator(7, "example.com")
+	for i := 0; i < 20; i++ {
+		require.NoError(t, Converge(context.Background(), im, g.Endpoints()), "step %d", i)
+	}
+	require.NoError(t, Converge(context.Background(), im, nil))
+
+	records, err := im.Records(context.Background())
+	require.NoError(t, err)
+	assert.Empty(t, records)
+}
@@ -27,6 +27,7 @@ import (
 	"k8s.io/apimachinery/pkg/labels"
 
 	"sigs.k8s.io/external-dns/endpoint"
+	"sigs.k8s.io/external-dns/pkg/featuregate"
 
 	"github.com/alecthomas/kingpin/v2"
 	"github.com/sirupsen/logrus"
@@ -41,12 +42,20 @@ type Config struct {
 	APIServerURL                                  string
 	KubeConfig                                    string
 	RequestTimeout                                time.Duration
+	SourceTimeout                                 time.Duration
+	SourceFailurePolicy                           string
+	ChaosFlapDomain                               string
+	ChaosFlapRate                                 int
+	ShardCount                                    int
+	ShardIndex                                    int
 	DefaultTargets                                []string
 	GlooNamespaces                                []string
 	SkipperRouteGroupVersion                      string
 	Sources                                       []string
 	Namespace                                     string
 	AnnotationFilter                              string
+	AuditNamespace                                string
+	AuditTTL                                      time.Duration
 	LabelFilter                                   string
 	IngressClassNames                             []string
 	FQDNTemplate                                  string
@@ -60,28 +69,59 @@ type Config struct {
 	GatewayName                                   string
 	GatewayNamespace                              string
 	GatewayLabelFilter                            string
+	GatewayRouteEvents                            bool
 	Compatibility                                 string
 	PodSourceDomain                               string
 	PublishInternal                               bool
 	PublishHostIP                                 bool
 	AlwaysPublishNotReadyAddresses                bool
 	ConnectorSourceServer                         string
+	ConnectorSourceTLS                            bool
+	ConnectorSourceTLSCA                          string
+	ConnectorSourceTLSClientCert                  string
+	ConnectorSourceTLSClientKey                   string
+	ConnectorSourceTokenFile                      string
+	ConnectorSourceProtocol                       int
+	ConnectorSourceMaxSize                        int
+	ConnectorSourceRetries                        int
+	PrometheusSDURLs                              []string
+	PrometheusSDFiles                             []string
+	PrometheusSDRelabelConfig                     string
 	Provider                                      string
 	ProviderCacheTime                             time.Duration
+	ProviderBatchSize                             int
+	HTTPClientTimeout                             time.Duration
+	HTTPProxy                                     string
+	HTTPCAFile                                    string
+	HTTPClientCertFile                            string
+	HTTPClientKeyFile                             string
+	HTTPUserAgent                                 string
+	DNSResolver                                   string
+	DNSResolverTimeout                            time.Duration
+	Secrets                                       []string
+	SecretRefreshInterval                         time.Duration
 	GoogleProject                                 string
+	GoogleAdditionalProjects                      []string
 	GoogleBatchChangeSize                         int
 	GoogleBatchChangeInterval                     time.Duration
 	GoogleZoneVisibility                          string
 	DomainFilter                                  []string
+	DomainRewrites                                []string
+	PreviewNamespaceLabel                         string
+	PreviewDomain                                 string
+	PreviewMaxLifetime                            time.Duration
 	ExcludeDomains                                []string
 	RegexDomainFilter                             *regexp.Regexp
 	RegexDomainExclusion                          *regexp.Regexp
 	ZoneNameFilter                                []string
 	ZoneIDFilter                                  []string
+	ZoneCollisionPolicy                           string
 	TargetNetFilter                               []string
+	ValidateHostnames                             bool
 	ExcludeTargetNets                             []string
 	AlibabaCloudConfigFile                        string
 	AlibabaCloudZoneType                          string
+	AlibabaCloudManageVPCAssociations             bool
 	AWSZoneType                                   string
 	AWSZoneTagFilter                              []string
 	AWSAssumeRole                                 string
@@ -100,18 +140,25 @@ type Config struct {
 	AWSZoneMatchParent                            bool
 	AWSDynamoDBRegion                             string
 	AWSDynamoDBTable                              string
+	AWSDynamoDBCreateTable                        bool
+	AWSDynamoDBItemTTL                            time.Duration
+	AWSDynamoDBReplicaRegions                     []string
 	AzureConfigFile                               string
 	AzureResourceGroup                            string
 	AzureSubscriptionID                           string
 	AzureUserAssignedIdentityClientID             string
 	AzureActiveDirectoryAuthorityHost             string
 	AzureZonesCacheDuration                       time.Duration
+	AzureResourceGraphDiscovery                   bool
+	AzureDiscoverySubscriptionIDs                 []string
 	CloudflareProxied                             bool
+	CloudflareProxiedDrift                        string
 	CloudflareCustomHostnames                     bool
 	CloudflareCustomHostnamesMinTLSVersion        string
 	CloudflareCustomHostnamesCertificateAuthority string
 	CloudflareDNSRecordsPerPage                   int
 	CloudflareRegionKey                           string
+	CloudflareRecordCommentLabels                 bool
 	CoreDNSPrefix                                 string
 	AkamaiServiceConsumerDomain                   string
 	AkamaiClientToken                             string
@@ -126,8 +173,26 @@ type Config struct {
 	OCIZoneCacheDuration                          time.Duration
 	InMemoryZones                                 []string
 	OVHEndpoint                                   string
+	OVHAuthMethod                                 string
+	OVHCredentialsFile                            string
 	OVHApiRateLimit                               int
 	OVHEnableCNAMERelative                        bool
+	OVHRecordsFetchMode                           string
+	OVHDefaultTTL                                 int64
+	OVHMaxAttempts                                int
+	OVHRetryBackoff                               time.Duration
+	OVHCachePersistence                           string
+	OVHCache                                      bool
+	OVHCacheTTL                                   time.Duration
+	OVHCacheExcludedZones                         []string
+	OVHSOAResolver                                string
+	OVHSOATransport                               string
+	OVHSOATimeout                                 time.Duration
+	OVHSkipFailedZones                            bool
+	OVHDNSSECEnabledZones                         []string
+	OVHDNSSECDisabledZones                        []string
+	OVHCreateZones                                bool
+	OVHCheckZoneSerials                           bool
 	PDNSServer                                    string
 	PDNSServerID                                  string
 	PDNSAPIKey                                    string `secure:"yes"`
@@ -136,8 +201,20 @@ type Config struct {
 	TLSClientCert                                 string
 	TLSClientCertKey                              string
 	Policy                                        string
+	EndpointAdjusters                             []string
+	AdjusterWebhookURL                            string
+	AdjusterWebhookTimeout                        time.Duration
+	AdjusterWebhookFailurePolicy                  string
 	Registry                                      string
+	SecondaryRegistry                             string
+	RegistryCacheMaxStaleness                     time.Duration
+	RegistryCacheZones                            []string
 	TXTOwnerID                                    string
+	CutoverFromOwnerID                            string
+	CutoverStart                                  string
+	CutoverSchedule                               string
+	TransferOwnershipTo                           string
+	TransferOwnershipNames                        []string
 	TXTPrefix                                     string
 	TXTSuffix                                     string
 	TXTEncryptEnabled                             bool
@@ -145,11 +222,31 @@ type Config struct {
 	TXTNewFormatOnly                              bool
 	Interval                                      time.Duration
 	MinEventSyncInterval                          time.Duration
+	MinTTL                                        time.Duration
+	MaxTTL                                        time.Duration
+	ZoneRetryBackoff                              time.Duration
+	ZoneRetryMaxBackoff                           time.Duration
+	ZoneDeadLetterThreshold                       int
+	WriteInterval                                 time.Duration
+	WriteApproval                                 bool
+	WriteApprovalThreshold                        int
+	WriteApprovalExpiry                           time.Duration
+	WriteApprovalNamespace                        string
 	Once                                          bool
+	Command                                       string
+	DiffOutput                                    string
+	DiffColor                                     string
+	OwnershipOutput                               string
+	BootstrapYes                                  bool
+	OwnershipNamespaces                           []string
+	OwnershipOwners                               []string
 	DryRun                                        bool
 	UpdateEvents                                  bool
 	LogFormat                                     string
 	MetricsAddress                                string
+	ExportRecordMetrics                           bool
+	LameduckDuration                              time.Duration
+	FeatureGates                                  []string
 	LogLevel                                      string
 	TXTCacheInterval                              time.Duration
 	TXTWildcardReplacement                        string
@@ -191,6 +288,12 @@ type Config struct {
 	DigitalOceanAPIPageSize                       int
 	ManagedDNSRecordTypes                         []string
 	ExcludeDNSRecordTypes                         []string
+	ZoneApexes                                    []string
+	PinnedRecords                                 []string
+	OverridesConfigMap                            string
+	DeletionDelayCycles                           int
+	Finalizers                                    []string
+	FinalizerName                                 string
 	GoDaddyAPIKey                                 string `secure:"yes"`
 	GoDaddySecretKey                              string `secure:"yes"`
 	GoDaddyTTL                                    int64
@@ -200,83 +303,133 @@ type Config struct {
 	IBMCloudConfigFile                            string
 	TencentCloudConfigFile                        string
 	TencentCloudZoneType                          string
+	YandexFolderID                                string
+	YandexAuthKeyFile                             string
+	YandexZoneVisibility                          string
 	PiholeServer                                  string
 	PiholePassword                                string `secure:"yes"`
 	PiholeTLSInsecureSkipVerify                   bool
 	PiholeApiVersion                              string
+	HurricaneElectricState                        string
 	PluralCluster                                 string
 	PluralProvider                                string
+	RESTMappingFile                               string
 	WebhookProviderURL                            string
 	WebhookProviderReadTimeout                    time.Duration
 	WebhookProviderWriteTimeout                   time.Duration
 	WebhookServer                                 bool
+	WebhookServerAddress                          string
+	WebhookSidecarCommand                         string
+	WebhookSidecarArgs                            []string
+	WebhookSidecarHealthURL                       string
+	WebhookSidecarBackoff                         time.Duration
+	WebhookSidecarMaxBackoff                      time.Duration
+	WebhookSidecarStartupTimeout                  time.Duration
 	TraefikDisableLegacy                          bool
 	TraefikDisableNew                             bool
 	NAT64Networks                                 []string
 	ExcludeUnschedulable                          bool
+	ExcludeNodeTaints                             []string
+	ExcludeNotReadyNodesAfter                     time.Duration
 }
 
 var defaultConfig = &Config{
-	AkamaiAccessToken:           "",
-	AkamaiClientSecret:          "",
-	AkamaiClientToken:           "",
-	AkamaiEdgercPath:            "",
-	AkamaiEdgercSection:         "",
-	AkamaiServiceConsumerDomain: "",
-	AlibabaCloudConfigFile:      "/etc/kubernetes/alibaba-cloud.json",
-	AnnotationFilter:            "",
-	APIServerURL:                "",
-	AWSAPIRetries:               3,
-	AWSAssumeRole:               "",
-	AWSAssumeRoleExternalID:     "",
-	AWSBatchChangeInterval:      time.Second,
-	AWSBatchChangeSize:          1000,
-	AWSBatchChangeSizeBytes:     32000,
-	AWSBatchChangeSizeValues:    1000,
-	AWSDynamoDBRegion:           "",
-	AWSDynamoDBTable:            "external-dns",
-	AWSEvaluateTargetHealth:     true,
-	AWSPreferCNAME:              false,
-	AWSSDCreateTag:              map[string]string{},
-	AWSSDServiceCleanup:         false,
-	AWSZoneCacheDuration:        0 * time.Second,
-	AWSZoneMatchParent:          false,
-	AWSZoneTagFilter:            []string{},
-	AWSZoneType:                 "",
-	AzureConfigFile:             "/etc/kubernetes/azure.json",
-	AzureResourceGroup:          "",
-	AzureSubscriptionID:         "",
-	AzureZonesCacheDuration:     0 * time.Second,
-	CFAPIEndpoint:               "",
-	CFPassword:                  "",
-	CFUsername:                  "",
+	AdjusterWebhookFailurePolicy:  "fail",
+	AdjusterWebhookTimeout:        10 * time.Second,
+	AkamaiAccessToken:             "",
+	AkamaiClientSecret:            "",
+	AkamaiClientToken:             "",
+	AkamaiEdgercPath:              "",
+	AkamaiEdgercSection:           "",
+	AkamaiServiceConsumerDomain:   "",
+	AlibabaCloudConfigFile:        "/etc/kubernetes/alibaba-cloud.json",
+	AnnotationFilter:              "",
+	APIServerURL:                  "",
+	AuditNamespace:                "",
+	AuditTTL:                      7 * 24 * time.Hour,
+	AWSAPIRetries:                 3,
+	AWSAssumeRole:                 "",
+	AWSAssumeRoleExternalID:       "",
+	AWSBatchChangeInterval:        time.Second,
+	AWSBatchChangeSize:            1000,
+	AWSBatchChangeSizeBytes:       32000,
+	AWSBatchChangeSizeValues:      1000,
+	AWSDynamoDBCreateTable:        false,
+	AWSDynamoDBItemTTL:            0,
+	AWSDynamoDBRegion:             "",
+	AWSDynamoDBReplicaRegions:     []string{},
+	AWSDynamoDBTable:              "external-dns",
+	AWSEvaluateTargetHealth:       true,
+	AWSPreferCNAME:                false,
+	AWSSDCreateTag:                map[string]string{},
+	AWSSDServiceCleanup:           false,
+	AWSZoneCacheDuration:          0 * time.Second,
+	AWSZoneMatchParent:            false,
+	AWSZoneTagFilter:              []string{},
+	AWSZoneType:                   "",
+	AzureConfigFile:               "/etc/kubernetes/azure.json",
+	AzureDiscoverySubscriptionIDs: []string{},
+	AzureResourceGraphDiscovery:   false,
+	AzureResourceGroup:            "",
+	AzureSubscriptionID:           "",
+	AzureZonesCacheDuration:       0 * time.Second,
+	CFAPIEndpoint:                 "",
+	CFPassword:                    "",
+	CFUsername:                    "",
+	ChaosFlapDomain:               "",
+	ChaosFlapRate:                 10,
 	CloudflareCustomHostnamesCertificateAuthority: "google",
 	CloudflareCustomHostnames:                     false,
 	CloudflareCustomHostnamesMinTLSVersion:        "1.0",
 	CloudflareDNSRecordsPerPage:                   100,
 	CloudflareProxied:                             false,
+	CloudflareProxiedDrift:                        "repair",
+	CloudflareRecordCommentLabels:                 false,
 	CloudflareRegionKey:                           "earth",
 
 	CombineFQDNAndAnnotation:     false,
+	Command:                      "run",
 	Compatibility:                "",
+	ConnectorSourceMaxSize:       16 << 20,
+	ConnectorSourceProtocol:      1,
+	ConnectorSourceRetries:       3,
 	ConnectorSourceServer:        "localhost:8080",
+	ConnectorSourceTLS:           false,
+	ConnectorSourceTLSCA:         "",
+	ConnectorSourceTLSClientCert: "",
+	ConnectorSourceTLSClientKey:  "",
+	ConnectorSourceTokenFile:     "",
 	CoreDNSPrefix:                "/skydns/",
 	CRDSourceAPIVersion:          "externaldns.k8s.io/v1alpha1",
 	CRDSourceKind:                "DNSEndpoint",
+	CutoverFromOwnerID:           "",
+	CutoverSchedule:              "",
+	CutoverStart:                 "",
 	DefaultTargets:               []string{},
+	DeletionDelayCycles:          0,
+	DiffColor:                    "auto",
+	DiffOutput:                   "text",
 	DigitalOceanAPIPageSize:      50,
 	DomainFilter:                 []string{},
+	DomainRewrites:               []string{},
 	DryRun:                       false,
+	EndpointAdjusters:            []string{},
 	ExcludeDNSRecordTypes:        []string{},
 	ExcludeDomains:               []string{},
+	ExcludeNodeTaints:            []string{},
+	ExcludeNotReadyNodesAfter:    0,
 	ExcludeTargetNets:            []string{},
 	ExcludeUnschedulable:         true,
 	ExoscaleAPIEnvironment:       "api",
 	ExoscaleAPIKey:               "",
 	ExoscaleAPISecret:            "",
 	ExoscaleAPIZone:              "ch-gva-2",
+	ExportRecordMetrics:          false,
 	ExposeInternalIPV6:           true,
+	FeatureGates:                 []string{},
 	FQDNTemplate:                 "",
+	FinalizerName:                "external-dns.alpha.kubernetes.io/records",
+	Finalizers:                   []string{},
 	GatewayLabelFilter:           "",
 	GatewayName:                  "",
 	GatewayNamespace:             "",
@@ -289,6 +442,14 @@ var defaultConfig = &Config{
 	GoogleBatchChangeSize:        1000,
 	GoogleProject:                "",
 	GoogleZoneVisibility:         "",
+	HTTPCAFile:                   "",
+	HTTPClientCertFile:           "",
+	HTTPClientKeyFile:            "",
+	HTTPClientTimeout:            0,
+	HTTPProxy:                    "",
+	HTTPUserAgent:                "",
+	DNSResolver:                  "",
+	DNSResolverTimeout:           0,
 	IBMCloudConfigFile:           "/etc/kubernetes/ibmcloud.json",
 	IBMCloudProxied:              false,
 	IgnoreHostnameAnnotation:     false,
@@ -299,11 +460,14 @@ var defaultConfig = &Config{
 	Interval:                     time.Minute,
 	KubeConfig:                   "",
 	LabelFilter:                  labels.Everything().String(),
+	LameduckDuration:             0,
 	LogFormat:                    "text",
 	LogLevel:                     logrus.InfoLevel.String(),
 	ManagedDNSRecordTypes:        []string{endpoint.RecordTypeA, endpoint.RecordTypeAAAA, endpoint.RecordTypeCNAME},
 	MetricsAddress:               ":7979",
+	MaxTTL:                       0,
 	MinEventSyncInterval:         5 * time.Second,
+	MinTTL:                       0,
 	Namespace:                    "",
 	NAT64Networks:                []string{},
 	NS1Endpoint:                  "",
@@ -313,27 +477,52 @@ var defaultConfig = &Config{
 	OCIZoneScope:                 "GLOBAL",
 	Once:                         false,
 	OVHApiRateLimit:              20,
+	OVHCache:                     true,
+	OVHCachePersistence:          "",
+	OVHCacheTTL:                  0,
+	OVHDefaultTTL:                0,
 	OVHEnableCNAMERelative:       false,
 	OVHEndpoint:                  "ovh-eu",
+	OVHAuthMethod:                "application",
+	OVHMaxAttempts:               3,
+	OVHRecordsFetchMode:          "record",
+	OVHRetryBackoff:              time.Second,
+	OVHSOAResolver:               "",
+	OVHSOATimeout:                2 * time.Second,
+	OVHSOATransport:              "udp",
+	OVHSkipFailedZones:           false,
+	OverridesConfigMap:           "",
+	OwnershipNamespaces:          []string{},
+	OwnershipOutput:              "text",
+	OwnershipOwners:              []string{},
 	PDNSAPIKey:                   "",
 	PDNSServer:                   "http://localhost:8081",
 	PDNSServerID:                 "localhost",
 	PDNSSkipTLSVerify:            false,
 	PiholeApiVersion:             "5",
+	HurricaneElectricState:       "",
 	PiholePassword:               "",
 	PiholeServer:                 "",
 	PiholeTLSInsecureSkipVerify:  false,
 	PluralCluster:                "",
 	PluralProvider:               "",
+	RESTMappingFile:              "",
 	PodSourceDomain:              "",
+	PinnedRecords:                []string{},
 	Policy:                       "sync",
+	PreviewDomain:                "",
+	PreviewMaxLifetime:           0,
+	PreviewNamespaceLabel:        "",
 	Provider:                     "",
+	ProviderBatchSize:            0,
 	ProviderCacheTime:            0,
 	PublishHostIP:                false,
 	PublishInternal:              false,
 	RegexDomainExclusion:         regexp.MustCompile(""),
 	RegexDomainFilter:            regexp.MustCompile(""),
 	Registry:                     "txt",
+	RegistryCacheMaxStaleness:    0,
+	RegistryCacheZones:           []string{},
 	RequestTimeout:               time.Second * 30,
 	RFC2136BatchChangeSize:       50,
 	RFC2136GSSTSIG:               false,
@@ -352,17 +541,29 @@ var defaultConfig = &Config{
 	RFC2136TSIGSecretAlg:         "",
 	RFC2136UseTLS:                false,
 	RFC2136Zone:                  []string{},
+	SecondaryRegistry:            "",
+	SecretRefreshInterval:        5 * time.Minute,
+	Secrets:                      []string{},
 	ServiceTypeFilter:            []string{},
+	ShardCount:                   1,
+	ShardIndex:                   0,
 	SkipperRouteGroupVersion:     "zalando.org/v1",
+	SourceFailurePolicy:          "fail",
 	Sources:                      nil,
+	SourceTimeout:                0,
 	TargetNetFilter:              []string{},
 	TencentCloudConfigFile:       "/etc/kubernetes/tencent-cloud.json",
 	TencentCloudZoneType:         "",
+	YandexFolderID:               "",
+	YandexAuthKeyFile:            "",
+	YandexZoneVisibility:         "",
 	TLSCA:                        "",
 	TLSClientCert:                "",
 	TLSClientCertKey:             "",
 	TraefikDisableLegacy:         false,
 	TraefikDisableNew:            false,
+	TransferOwnershipNames:       []string{},
+	TransferOwnershipTo:          "",
 	TransIPAccountName:           "",
 	TransIPPrivateKeyFile:        "",
 	TXTCacheInterval:             0,
@@ -374,11 +575,29 @@ var defaultConfig = &Config{
 	TXTSuffix:                    "",
 	TXTWildcardReplacement:       "",
 	UpdateEvents:                 false,
+	ValidateHostnames:            false,
 	WebhookProviderReadTimeout:   5 * time.Second,
 	WebhookProviderURL:           "http://localhost:8888",
 	WebhookProviderWriteTimeout:  10 * time.Second,
 	WebhookServer:                false,
+	WebhookServerAddress:         "127.0.0.1:8888",
+	WebhookSidecarArgs:           []string{},
+	WebhookSidecarBackoff:        time.Second,
+	WebhookSidecarCommand:        "",
+	WebhookSidecarHealthURL:      "",
+	WebhookSidecarMaxBackoff:     time.Minute,
+	WebhookSidecarStartupTimeout: time.Minute,
+	WriteApproval:                false,
+	WriteApprovalExpiry:          time.Hour,
+	WriteApprovalNamespace:       "",
+	WriteApprovalThreshold:       0,
+	WriteInterval:                0,
+	ZoneApexes:                   []string{},
+	ZoneCollisionPolicy:          "warn",
+	ZoneDeadLetterThreshold:      5,
 	ZoneIDFilter:                 []string{},
+	ZoneRetryBackoff:             0,
+	ZoneRetryMaxBackoff:          10 * time.Minute,
 }
 
 // NewConfig returns new Config object
@@ -422,10 +641,11 @@ func allLogLevelsAsStrings() []string {
 func (cfg *Config) ParseFlags(args []string) error {
 	app := App(cfg)
 
-	_, err := app.Parse(args)
+	command, err := app.Parse(args)
 	if err != nil {
 		return err
 	}
+	cfg.Command = command
 
 	return nil
 }
@@ -459,24 +679,46 @@ func App(cfg *Config) *kingpin.Application {
 	app.Flag("combine-fqdn-annotation", "Combine FQDN template and Annotations instead of overwriting").BoolVar(&cfg.CombineFQDNAndAnnotation)
 	app.Flag("compatibility", "Process annotation semantics from legacy implementations (optional, options: mate, molecule, kops-dns-controller)").Default(defaultConfig.Compatibility).EnumVar(&cfg.Compatibility, "", "mate", "molecule", "kops-dns-controller")
 	app.Flag("connector-source-server", "The server to connect for connector source, valid only when using connector source").Default(defaultConfig.ConnectorSourceServer).StringVar(&cfg.ConnectorSourceServer)
+	app.Flag("connector-source-tls", "When using the connector source, connect to the server over TLS (default: false)").BoolVar(&cfg.ConnectorSourceTLS)
+	app.Flag("connector-source-tls-ca", "When using the connector source over TLS, the path to the certificate authority to verify the server (default: system roots)").Default(defaultConfig.ConnectorSourceTLSCA).StringVar(&cfg.ConnectorSourceTLSCA)
+	app.Flag("connector-source-tls-client-cert", "When using the connector source over TLS, the path to the certificate to present as a client (optional)").Default(defaultConfig.ConnectorSourceTLSClientCert).StringVar(&cfg.ConnectorSourceTLSClientCert)
+	app.Flag("connector-source-tls-client-key", "When using the connector source over TLS, the path to the key of the client certificate (optional)").Default(defaultConfig.ConnectorSourceTLSClientKey).StringVar(&cfg.ConnectorSourceTLSClientKey)
+	app.Flag("connector-source-token-file", "When using the connector source, the path to a file holding a token sent to authenticate to the server; requires --connector-source-protocol=2 (optional)").Default(defaultConfig.ConnectorSourceTokenFile).StringVar(&cfg.ConnectorSourceTokenFile)
+	app.Flag("connector-source-protocol", "When using the connector source, the version of the protocol spoken with the server; 1 where the server sends the endpoints on connection, 2 where it answers a request carrying the token (default: 1)").Default(strconv.Itoa(defaultConfig.ConnectorSourceProtocol)).IntVar(&cfg.ConnectorSourceProtocol)
+	app.Flag("connector-source-max-size", "When using the connector source, the maximum size in bytes of the responses of the server; 0 for no limit (default: 16MiB)").Default(strconv.Itoa(defaultConfig.ConnectorSourceMaxSize)).IntVar(&cfg.ConnectorSourceMaxSize)
+	app.Flag("connector-source-retries", "When using the connector source, the number of times a failed connection to the server is retried, with an exponential backoff (default: 3)").Default(strconv.Itoa(defaultConfig.ConnectorSourceRetries)).IntVar(&cfg.ConnectorSourceRetries)
+	app.Flag("prometheus-sd-url", "When using the prometheus-sd source, the URL of a Prometheus HTTP service discovery endpoint to get targets from; specify multiple times for multiple endpoints").StringsVar(&cfg.PrometheusSDURLs)
+	app.Flag("prometheus-sd-file", "When using the prometheus-sd source, the path to a Prometheus file service discovery file, in JSON or YAML, to read targets from; specify multiple times for multiple files").StringsVar(&cfg.PrometheusSDFiles)
+	app.Flag("prometheus-sd-relabel-config", "When using the prometheus-sd source, the path to a YAML file of Prometheus relabeling rules selecting the targets and setting the __dns_name__ label of their hostnames (optional)").Default(defaultConfig.PrometheusSDRelabelConfig).StringVar(&cfg.PrometheusSDRelabelConfig)
 	app.Flag("crd-source-apiversion", "API version of the CRD for crd source, e.g. `externaldns.k8s.io/v1alpha1`, valid only when using crd source").Default(defaultConfig.CRDSourceAPIVersion).StringVar(&cfg.CRDSourceAPIVersion)
 	app.Flag("crd-source-kind", "Kind of the CRD for the crd source in API group and version specified by crd-source-apiversion").Default(defaultConfig.CRDSourceKind).StringVar(&cfg.CRDSourceKind)
 	app.Flag("default-targets", "Set globally default host/IP that will apply as a target instead of source addresses. Specify multiple times for multiple targets (optional)").StringsVar(&cfg.DefaultTargets)
+	app.Flag("min-ttl", "The minimum TTL of records; record TTLs set lower, for instance through annotations, are raised to it with a warning (default: disabled)").Default(defaultConfig.MinTTL.String()).DurationVar(&cfg.MinTTL)
+	app.Flag("max-ttl", "The maximum TTL of records; record TTLs set higher, for instance through annotations, are lowered to it with a warning (default: disabled)").Default(defaultConfig.MaxTTL.String()).DurationVar(&cfg.MaxTTL)
 	app.Flag("exclude-record-types", "Record types to exclude from management; specify multiple times to exclude many; (optional)").Default().StringsVar(&cfg.ExcludeDNSRecordTypes)
-	app.Flag("exclude-target-net", "Exclude target nets (optional)").StringsVar(&cfg.ExcludeTargetNets)
+	app.Flag("zone-apex", "The apex of a zone, whose NS records are never updated nor deleted, in addition to the registrable domains, the zones of the providers listing them and the names of SOA records, SOA records being never updated nor deleted either; specify multiple times for multiple zones (optional)").StringsVar(&cfg.ZoneApexes)
+	app.Flag("overrides-configmap", "The namespace/name of a ConfigMap whose overrides.yaml key declares overrides merged over the desired endpoints before planning, forcing their TTL or targets, or suppressing them (optional)").Default(defaultConfig.OverridesConfigMap).StringVar(&cfg.OverridesConfigMap)
+	app.Flag("deletion-delay-cycles", "Hold back the deletion of records that disappeared from the sources until their deletion got planned by this many more synchronizations, protecting against sources transiently returning fewer endpoints; 0 deletes them at once (default: 0)").Default(strconv.Itoa(defaultConfig.DeletionDelayCycles)).IntVar(&cfg.DeletionDelayCycles)
+	app.Flag("finalizer", "Add a finalizer to the objects of a kind whose resources have records, so that their deletion waits until their records are deleted, whatever the policy; specify multiple times for multiple kinds (optional, options: namespace, dnsendpoint)").EnumsVar(&cfg.Finalizers, "namespace", "dnsendpoint")
+	app.Flag("finalizer-name", "The name of the finalizer added with --finalizer, which must differ between ExternalDNS instances sharing a cluster").Default(defaultConfig.FinalizerName).StringVar(&cfg.FinalizerName)
+	app.Flag("pinned-record", "Pin the records of a DNS name at their current values: changes to them are refused, and they are restored if changed out of band, until the name is unpinned; specify multiple times to pin many names (optional)").StringsVar(&cfg.PinnedRecords)
+	app.Flag("exclude-target-net", "Exclude targets in the given net (CIDR or IP address); applies to all sources; specify multiple times for multiple nets (optional)").StringsVar(&cfg.ExcludeTargetNets)
 	app.Flag("exclude-unschedulable", "Exclude nodes that are considered unschedulable (default: true)").Default(strconv.FormatBool(defaultConfig.ExcludeUnschedulable)).BoolVar(&cfg.ExcludeUnschedulable)
+	app.Flag("exclude-node-taint", "When using the node source, exclude nodes with a taint of the given key; specify multiple times for multiple keys (optional)").StringsVar(&cfg.ExcludeNodeTaints)
+	app.Flag("exclude-not-ready-nodes-after", "When using the node source, exclude nodes which have not been ready for longer than this duration; nodes not ready more recently are kept so that their records do not flap (default: disabled)").Default(defaultConfig.ExcludeNotReadyNodesAfter.String()).DurationVar(&cfg.ExcludeNotReadyNodesAfter)
 	app.Flag("expose-internal-ipv6", "When using the node source, expose internal IPv6 addresses (optional). Default is true.").BoolVar(&cfg.ExposeInternalIPV6)
 	app.Flag("fqdn-template", "A templated string that's used to generate DNS names from sources that don't define a hostname themselves, or to add a hostname suffix when paired with the fake source (optional). Accepts comma separated list for multiple global FQDN.").Default(defaultConfig.FQDNTemplate).StringVar(&cfg.FQDNTemplate)
 	app.Flag("gateway-label-filter", "Filter Gateways of Route endpoints via label selector (default: all gateways)").StringVar(&cfg.GatewayLabelFilter)
 	app.Flag("gateway-name", "Limit Gateways of Route endpoints to a specific name (default: all names)").StringVar(&cfg.GatewayName)
 	app.Flag("gateway-namespace", "Limit Gateways of Route endpoints to a specific namespace (default: all namespaces)").StringVar(&cfg.GatewayNamespace)
+	app.Flag("gateway-route-events", "Record Kubernetes events on the Gateway API routes whose hostnames match no listener of their Gateways, or are narrowed to the more specific hostnames of the listeners they match; requires the permission to create events (default: false)").BoolVar(&cfg.GatewayRouteEvents)
 	app.Flag("ignore-hostname-annotation", "Ignore hostname annotation when generating DNS names, valid only when --fqdn-template is set (default: false)").BoolVar(&cfg.IgnoreHostnameAnnotation)
 	app.Flag("ignore-ingress-rules-spec", "Ignore the spec.rules section in Ingress resources (default: false)").BoolVar(&cfg.IgnoreIngressRulesSpec)
 	app.Flag("ignore-ingress-tls-spec", "Ignore the spec.tls section in Ingress resources (default: false)").BoolVar(&cfg.IgnoreIngressTLSSpec)
 	app.Flag("ignore-non-host-network-pods", "Ignore pods not running on host network when using pod source (default: true)").BoolVar(&cfg.IgnoreNonHostNetworkPods)
 	app.Flag("ingress-class", "Require an Ingress to have this class name (defaults to any class; specify multiple times to allow more than one class)").StringsVar(&cfg.IngressClassNames)
 	app.Flag("label-filter", "Filter resources queried for endpoints by label selector; currently supported by source types crd, gateway-httproute, gateway-grpcroute, gateway-tlsroute, gateway-tcproute, gateway-udproute, ingress, node, openshift-route, service and ambassador-host").Default(defaultConfig.LabelFilter).StringVar(&cfg.LabelFilter)
-	managedRecordTypesHelp := fmt.Sprintf("Record types to manage; specify multiple times to include many; (default: %s) (supported records: A, AAAA, CNAME, NS, SRV, TXT)", strings.Join(defaultConfig.ManagedDNSRecordTypes, ","))
+	managedRecordTypesHelp := fmt.Sprintf("Record types to manage; specify multiple times to include many; (default: %s) (supported records: A, AAAA, CNAME, NS, SRV, TXT, and CAA, NAPTR, TLSA and SSHFP with the OVH provider)", strings.Join(defaultConfig.ManagedDNSRecordTypes, ","))
 	app.Flag("managed-record-types", managedRecordTypesHelp).Default(defaultConfig.ManagedDNSRecordTypes...).StringsVar(&cfg.ManagedDNSRecordTypes)
 	app.Flag("namespace", "Limit resources queried for endpoints to a specific namespace (default: all namespaces)").Default(defaultConfig.Namespace).StringVar(&cfg.Namespace)
 	app.Flag("nat64-networks", "Adding an A record for each AAAA record in NAT64-enabled networks; specify multiple times for multiple possible nets (optional)").StringsVar(&cfg.NAT64Networks)
@@ -485,27 +727,52 @@ func App(cfg *Config) *kingpin.Application {
 	app.Flag("publish-host-ip", "Allow external-dns to publish host-ip for headless services (optional)").BoolVar(&cfg.PublishHostIP)
 	app.Flag("publish-internal-services", "Allow external-dns to publish DNS records for ClusterIP services (optional)").BoolVar(&cfg.PublishInternal)
 	app.Flag("service-type-filter", "The service types to take care about (default: all, expected: ClusterIP, NodePort, LoadBalancer or ExternalName)").StringsVar(&cfg.ServiceTypeFilter)
-	app.Flag("source", "The resource types that are queried for endpoints; specify multiple times for multiple sources (required, options: service, ingress, node, pod, fake, connector, gateway-httproute, gateway-grpcroute, gateway-tlsroute, gateway-tcproute, gateway-udproute, istio-gateway, istio-virtualservice, cloudfoundry, contour-httpproxy, gloo-proxy, crd, empty, skipper-routegroup, openshift-route, ambassador-host, kong-tcpingress, f5-virtualserver, f5-transportserver, traefik-proxy)").Required().PlaceHolder("source").EnumsVar(&cfg.Sources, "service", "ingress", "node", "pod", "gateway-httproute", "gateway-grpcroute", "gateway-tlsroute", "gateway-tcproute", "gateway-udproute", "istio-gateway", "istio-virtualservice", "cloudfoundry", "contour-httpproxy", "gloo-proxy", "fake", "connector", "crd", "empty", "skipper-routegroup", "openshift-route", "ambassador-host", "kong-tcpingress", "f5-virtualserver", "f5-transportserver", "traefik-proxy")
-	app.Flag("target-net-filter", "Limit possible targets by a net filter; specify multiple times for multiple possible nets (optional)").StringsVar(&cfg.TargetNetFilter)
+	app.Flag("source", "The resource types that are queried for endpoints; specify multiple times for multiple sources (required, options: service, ingress, node, pod, fake, connector, gateway-httproute, gateway-grpcroute, gateway-tlsroute, gateway-tcproute, gateway-udproute, istio-gateway, istio-virtualservice, cloudfoundry, contour-httpproxy, gloo-proxy, crd, empty, skipper-routegroup, openshift-route, ambassador-host, kong-tcpingress, f5-virtualserver, f5-transportserver, traefik-proxy, prometheus-sd)").Required().PlaceHolder("source").EnumsVar(&cfg.Sources, "service", "ingress", "node", "pod", "gateway-httproute", "gateway-grpcroute", "gateway-tlsroute", "gateway-tcproute", "gateway-udproute", "istio-gateway", "istio-virtualservice", "cloudfoundry", "contour-httpproxy", "gloo-proxy", "fake", "connector", "crd", "empty", "skipper-routegroup", "openshift-route", "ambassador-host", "kong-tcpingress", "f5-virtualserver", "f5-transportserver", "traefik-proxy", "prometheus-sd")
+	app.Flag("source-failure-policy", "How to handle a source failing or timing out: fail the whole synchronization, or skip that source's endpoints for this run (default: fail, options: fail, skip); skipping only suits policies that do not delete records").Default(defaultConfig.SourceFailurePolicy).EnumVar(&cfg.SourceFailurePolicy, "fail", "skip")
+	app.Flag("chaos-flap-domain", "For testing only: add synthetic A records under this domain to the endpoints of the sources, --chaos-flap-rate of them appearing or disappearing on every synchronization, to rehearse change limits, alerts and provider rate limits (default: disabled)").Default(defaultConfig.ChaosFlapDomain).StringVar(&cfg.ChaosFlapDomain)
+	app.Flag("chaos-flap-rate", "The number of synthetic records appearing or disappearing on every synchronization when --chaos-flap-domain is set (default: 10)").Default(strconv.Itoa(defaultConfig.ChaosFlapRate)).IntVar(&cfg.ChaosFlapRate)
+	app.Flag("shard-count", "Split the DNS names of the endpoints of the sources into this number of shards by hash, each instance of ExternalDNS managing the names of its --shard-index, as the owner --txt-owner-id followed by -shard-INDEX; 0 or 1 for no sharding (default: 1)").Default(strconv.Itoa(defaultConfig.ShardCount)).IntVar(&cfg.ShardCount)
+	app.Flag("shard-index", "The shard of the DNS names managed by this instance when --shard-count is greater than 1, from 0 to --shard-count minus 1 (default: 0)").Default(strconv.Itoa(defaultConfig.ShardIndex)).IntVar(&cfg.ShardIndex)
+	app.Flag("source-timeout", "Time given to each source to return its endpoints, sources being queried concurrently. 0s means no timeout").Default(defaultConfig.SourceTimeout.String()).DurationVar(&cfg.SourceTimeout)
+	app.Flag("target-net-filter", "Limit possible targets by a net filter (CIDR or IP address); applies to all sources; specify multiple times for multiple possible nets (optional)").StringsVar(&cfg.TargetNetFilter)
+	app.Flag("validate-hostnames", "Reject the endpoints of sources whose DNS name is invalid as per RFC 1035 and RFC 1123, logging them and recording an Event on the resource they come from, instead of passing them to the provider; with the sync policy, the existing records of rejected names get deleted (default: disabled)").Default(strconv.FormatBool(defaultConfig.ValidateHostnames)).BoolVar(&cfg.ValidateHostnames)
 	app.Flag("traefik-disable-legacy", "Disable listeners on Resources under the traefik.containo.us API Group").Default(strconv.FormatBool(defaultConfig.TraefikDisableLegacy)).BoolVar(&cfg.TraefikDisableLegacy)
 	app.Flag("traefik-disable-new", "Disable listeners on Resources under the traefik.io API Group").Default(strconv.FormatBool(defaultConfig.TraefikDisableNew)).BoolVar(&cfg.TraefikDisableNew)
 
 	// Flags related to providers
-	providers := []string{"akamai", "alibabacloud", "aws", "aws-sd", "azure", "azure-dns", "azure-private-dns", "civo", "cloudflare", "coredns", "digitalocean", "dnsimple", "exoscale", "gandi", "godaddy", "google", "ibmcloud", "inmemory", "linode", "ns1", "oci", "ovh", "pdns", "pihole", "plural", "rfc2136", "scaleway", "skydns", "tencentcloud", "transip", "ultradns", "webhook"}
+	providers := []string{"akamai", "alibabacloud", "aws", "aws-sd", "azure", "azure-dns", "azure-private-dns", "civo", "cloudflare", "constellix", "coredns", "digitalocean", "dnsimple", "exoscale", "gandi", "godaddy", "google", "hurricane-electric", "ibmcloud", "inmemory", "ionoscloud", "linode", "mythicbeasts", "njalla", "ns1", "oci", "ovh", "pdns", "pihole", "plural", "rest", "rfc2136", "scaleway", "skydns", "tencentcloud", "transip", "ultradns", "webhook", "yandex"}
 	app.Flag("provider", "The DNS provider where the DNS records will be created (required, options: "+strings.Join(providers, ", ")+")").Required().PlaceHolder("provider").EnumVar(&cfg.Provider, providers...)
 	app.Flag("provider-cache-time", "The time to cache the DNS provider record list requests.").Default(defaultConfig.ProviderCacheTime.String()).DurationVar(&cfg.ProviderCacheTime)
+	app.Flag("provider-batch-size", "The maximum number of changes applied at once by the providers supporting batches, larger plans being split into several batches; 0 for no limit (supported by: cloudflare, ovh)").Default(strconv.Itoa(defaultConfig.ProviderBatchSize)).IntVar(&cfg.ProviderBatchSize)
+	app.Flag("http-client-timeout", "The timeout of the HTTP requests of the provider clients built from the shared HTTP transport; 0s means no timeout (default: disabled)").Default(defaultConfig.HTTPClientTimeout.String()).DurationVar(&cfg.HTTPClientTimeout)
+	app.Flag("http-proxy", "The URL of the proxy the provider clients built from the shared HTTP transport go through (default: the proxy of the HTTPS_PROXY, HTTP_PROXY and NO_PROXY environment variables)").Default(defaultConfig.HTTPProxy).StringVar(&cfg.HTTPProxy)
+	app.Flag("http-ca-file", "The path to the certificate authorities the provider clients built from the shared HTTP transport verify servers with (default: the system certificate authorities)").Default(defaultConfig.HTTPCAFile).StringVar(&cfg.HTTPCAFile)
+	app.Flag("http-client-cert-file", "The path to the certificate the provider clients built from the shared HTTP transport present to servers (optional, requires --http-client-key-file)").Default(defaultConfig.HTTPClientCertFile).StringVar(&cfg.HTTPClientCertFile)
+	app.Flag("http-client-key-file", "The path to the key of the certificate set by --http-client-cert-file (optional)").Default(defaultConfig.HTTPClientKeyFile).StringVar(&cfg.HTTPClientKeyFile)
+	app.Flag("http-user-agent", "The user agent sent by the provider clients built from the shared HTTP transport (default: ExternalDNS/<version> followed by the owner id)").Default(defaultConfig.HTTPUserAgent).StringVar(&cfg.HTTPUserAgent)
+	app.Flag("dns-resolver", "The resolver of the DNS queries of ExternalDNS, e.g. checking the serials of the zones or resolving host names, as [udp://|tcp://|tls://]HOST[:PORT], tls being DNS over TLS, or as an https:// URL for DNS over HTTPS (default: the system resolver and the name servers of the zones)").Default(defaultConfig.DNSResolver).StringVar(&cfg.DNSResolver)
+	app.Flag("dns-resolver-timeout", "The timeout of the DNS queries of ExternalDNS; 0s for the defaults of the DNS clients (default: 0s)").Default(defaultConfig.DNSResolverTimeout.String()).DurationVar(&cfg.DNSResolverTimeout)
+	app.Flag("secret", "Load the credential a provider reads from the environment variable NAME from a reference instead, as NAME=REFERENCE, the reference being env:NAME, file:PATH, vault:PATH#KEY or aws-sm:ID, optionally followed by #KEY to pick a key of a JSON secret, e.g. CF_API_TOKEN=vault:secret/data/cloudflare#token; specify multiple times for multiple credentials (supported by: cloudflare, ovh, rfc2136 with EXTERNAL_DNS_RFC2136_TSIG_SECRET)").StringsVar(&cfg.Secrets)
+	app.Flag("secret-refresh-interval", "The interval credentials loaded with --secret are fetched again at, so that rotated credentials get picked up; 0s to never fetch them again").Default(defaultConfig.SecretRefreshInterval.String()).DurationVar(&cfg.SecretRefreshInterval)
 	app.Flag("domain-filter", "Limit possible target zones by a domain suffix; specify multiple times for multiple domains (optional)").Default("").StringsVar(&cfg.DomainFilter)
+	app.Flag("domain-rewrite", "Rewrite the DNS names of a domain suffix to another one, e.g. cluster.local=example.com; CNAME targets are rewritten as well; specify multiple times for multiple rules, the first matching rule applies (optional)").StringsVar(&cfg.DomainRewrites)
+	app.Flag("preview-namespace-label", "Label of the namespaces of preview environments: the DNS names of their resources are moved under --preview-domain, e.g. app.example.com to app.pr-123.preview.example.com, the environment being the value of the label or else the name of the namespace (optional)").Default(defaultConfig.PreviewNamespaceLabel).StringVar(&cfg.PreviewNamespaceLabel)
+	app.Flag("preview-domain", "The domain the DNS names of preview environments are moved under; records under it which are not desired anymore are deleted whatever the policy (required with --preview-namespace-label)").Default(defaultConfig.PreviewDomain).StringVar(&cfg.PreviewDomain)
+	app.Flag("preview-max-lifetime", "The age after which the records of a preview environment are deleted even though its namespace still exists; 0s for no limit").Default(defaultConfig.PreviewMaxLifetime.String()).DurationVar(&cfg.PreviewMaxLifetime)
 	app.Flag("exclude-domains", "Exclude subdomains (optional)").Default("").StringsVar(&cfg.ExcludeDomains)
 	app.Flag("regex-domain-filter", "Limit possible domains and target zones by a Regex filter; Overrides domain-filter (optional)").Default(defaultConfig.RegexDomainFilter.String()).RegexpVar(&cfg.RegexDomainFilter)
 	app.Flag("regex-domain-exclusion", "Regex filter that excludes domains and target zones matched by regex-domain-filter (optional); Require 'regex-domain-filter' ").Default(defaultConfig.RegexDomainExclusion.String()).RegexpVar(&cfg.RegexDomainExclusion)
 	app.Flag("zone-name-filter", "Filter target zones by zone domain (For now, only AzureDNS provider is using this flag); specify multiple times for multiple zones (optional)").Default("").StringsVar(&cfg.ZoneNameFilter)
 	app.Flag("zone-id-filter", "Filter target zones by hosted zone id; specify multiple times for multiple zones (optional)").Default("").StringsVar(&cfg.ZoneIDFilter)
+	app.Flag("zone-collision-policy", "What to do with a hostname whose most specific matching zone name is configured under several zone IDs: warn and use the zone with the lowest ID, or warn and skip the endpoint (default: warn, options: warn, fail)").Default(defaultConfig.ZoneCollisionPolicy).EnumVar(&cfg.ZoneCollisionPolicy, "warn", "fail")
 	app.Flag("google-project", "When using the Google provider, current project is auto-detected, when running on GCP. Specify other project with this. Must be specified when running outside GCP.").Default(defaultConfig.GoogleProject).StringVar(&cfg.GoogleProject)
+	app.Flag("google-additional-project", "When using the Google provider, also manage the zones of this project, with API quota tracked per project; use project=/path/to/credentials.json to manage it with dedicated credentials (optional, specify multiple times for multiple projects)").StringsVar(&cfg.GoogleAdditionalProjects)
 	app.Flag("google-batch-change-size", "When using the Google provider, set the maximum number of changes that will be applied in each batch.").Default(strconv.Itoa(defaultConfig.GoogleBatchChangeSize)).IntVar(&cfg.GoogleBatchChangeSize)
 	app.Flag("google-batch-change-interval", "When using the Google provider, set the interval between batch changes.").Default(defaultConfig.GoogleBatchChangeInterval.String()).DurationVar(&cfg.GoogleBatchChangeInterval)
 	app.Flag("google-zone-visibility", "When using the Google provider, filter for zones with this visibility (optional, options: public, private)").Default(defaultConfig.GoogleZoneVisibility).EnumVar(&cfg.GoogleZoneVisibility, "", "public", "private")
 	app.Flag("alibaba-cloud-config-file", "When using the Alibaba Cloud provider, specify the Alibaba Cloud configuration file (required when --provider=alibabacloud)").Default(defaultConfig.AlibabaCloudConfigFile).StringVar(&cfg.AlibabaCloudConfigFile)
 	app.Flag("alibaba-cloud-zone-type", "When using the Alibaba Cloud provider, filter for zones of this type (optional, options: public, private)").Default(defaultConfig.AlibabaCloudZoneType).EnumVar(&cfg.AlibabaCloudZoneType, "", "public", "private")
+	app.Flag("alibaba-cloud-manage-vpc-associations", "When using the Alibaba Cloud provider with private zones, bind each zone to the VPCs declared by the alibabacloud-vpc-ids annotation of its records, besides the VPC of the configuration, and unbind it from any other VPC (default: disabled)").BoolVar(&cfg.AlibabaCloudManageVPCAssociations)
 	app.Flag("aws-zone-type", "When using the AWS provider, filter for zones of this type (optional, options: public, private)").Default(defaultConfig.AWSZoneType).EnumVar(&cfg.AWSZoneType, "", "public", "private")
 	app.Flag("aws-zone-tags", "When using the AWS provider, filter for zones with these tags").Default("").StringsVar(&cfg.AWSZoneTagFilter)
 	app.Flag("aws-profile", "When using the AWS provider, name of the profile to use").Default("").StringsVar(&cfg.AWSProfiles)
@@ -527,15 +794,22 @@ func App(cfg *Config) *kingpin.Application {
 	app.Flag("azure-subscription-id", "When using the Azure provider, override the Azure subscription to use (optional)").Default(defaultConfig.AzureSubscriptionID).StringVar(&cfg.AzureSubscriptionID)
 	app.Flag("azure-user-assigned-identity-client-id", "When using the Azure provider, override the client id of user assigned identity in config file (optional)").Default("").StringVar(&cfg.AzureUserAssignedIdentityClientID)
 	app.Flag("azure-zones-cache-duration", "When using the Azure provider, set the zones list cache TTL (0s to disable).").Default(defaultConfig.AzureZonesCacheDuration.String()).DurationVar(&cfg.AzureZonesCacheDuration)
+	app.Flag("azure-resource-graph-discovery", "When using the Azure provider, discover zones in every resource group and subscription visible to the credentials through Azure Resource Graph instead of a single resource group (default: disabled)").BoolVar(&cfg.AzureResourceGraphDiscovery)
+	app.Flag("azure-discovery-subscription-id", "When using the Azure provider with --azure-resource-graph-discovery, limit zone discovery to this subscription; specify multiple times for multiple subscriptions (default: all visible subscriptions)").StringsVar(&cfg.AzureDiscoverySubscriptionIDs)
 	app.Flag("tencent-cloud-config-file", "When using the Tencent Cloud provider, specify the Tencent Cloud configuration file (required when --provider=tencentcloud)").Default(defaultConfig.TencentCloudConfigFile).StringVar(&cfg.TencentCloudConfigFile)
 	app.Flag("tencent-cloud-zone-type", "When using the Tencent Cloud provider, filter for zones with visibility (optional, options: public, private)").Default(defaultConfig.TencentCloudZoneType).EnumVar(&cfg.TencentCloudZoneType, "", "public", "private")
+	app.Flag("yandex-folder-id", "When using the Yandex Cloud provider, specify the folder whose zones are managed (required when --provider=yandex)").Default(defaultConfig.YandexFolderID).StringVar(&cfg.YandexFolderID)
+	app.Flag("yandex-auth-key-file", "When using the Yandex Cloud provider, specify the authorized key file of the service account to authenticate as (optional, defaults to the service account of the compute instance)").Default(defaultConfig.YandexAuthKeyFile).StringVar(&cfg.YandexAuthKeyFile)
+	app.Flag("yandex-zone-visibility", "When using the Yandex Cloud provider, filter for zones with this visibility (optional, options: public, private)").Default(defaultConfig.YandexZoneVisibility).EnumVar(&cfg.YandexZoneVisibility, "", "public", "private")
 
 	app.Flag("cloudflare-proxied", "When using the Cloudflare provider, specify if the proxy mode must be enabled (default: disabled)").BoolVar(&cfg.CloudflareProxied)
+	app.Flag("cloudflare-proxied-drift", "When using the Cloudflare provider, specify what to do about the proxied state of records changed outside of ExternalDNS, e.g. in the Cloudflare dashboard, when their endpoints do not set it with an annotation: repair it to the default or keep it (default: repair, options: repair, keep)").Default(defaultConfig.CloudflareProxiedDrift).EnumVar(&cfg.CloudflareProxiedDrift, "repair", "keep")
 	app.Flag("cloudflare-custom-hostnames", "When using the Cloudflare provider, specify if the Custom Hostnames feature will be used. Requires \"Cloudflare for SaaS\" enabled. (default: disabled)").BoolVar(&cfg.CloudflareCustomHostnames)
 	app.Flag("cloudflare-custom-hostnames-min-tls-version", "When using the Cloudflare provider with the Custom Hostnames, specify which Minimum TLS Version will be used by default. (default: 1.0, options: 1.0, 1.1, 1.2, 1.3)").Default("1.0").EnumVar(&cfg.CloudflareCustomHostnamesMinTLSVersion, "1.0", "1.1", "1.2", "1.3")
 	app.Flag("cloudflare-custom-hostnames-certificate-authority", "When using the Cloudflare provider with the Custom Hostnames, specify which Cerrtificate Authority will be used by default. (default: google, options: google, ssl_com, lets_encrypt)").Default("google").EnumVar(&cfg.CloudflareCustomHostnamesCertificateAuthority, "google", "ssl_com", "lets_encrypt")
 	app.Flag("cloudflare-dns-records-per-page", "When using the Cloudflare provider, specify how many DNS records listed per page, max possible 5,000 (default: 100)").Default(strconv.Itoa(defaultConfig.CloudflareDNSRecordsPerPage)).IntVar(&cfg.CloudflareDNSRecordsPerPage)
 	app.Flag("cloudflare-region-key", "When using the Cloudflare provider, specify the region (default: earth)").StringVar(&cfg.CloudflareRegionKey)
+	app.Flag("cloudflare-record-comment-labels", "When using the Cloudflare provider, store the ownership labels in the comment of the DNS records instead of TXT registry records (default: disabled)").BoolVar(&cfg.CloudflareRecordCommentLabels)
 	app.Flag("coredns-prefix", "When using the CoreDNS provider, specify the prefix name").Default(defaultConfig.CoreDNSPrefix).StringVar(&cfg.CoreDNSPrefix)
 	app.Flag("akamai-serviceconsumerdomain", "When using the Akamai provider, specify the base URL (required when --provider=akamai and edgerc-path not specified)").Default(defaultConfig.AkamaiServiceConsumerDomain).StringVar(&cfg.AkamaiServiceConsumerDomain)
 	app.Flag("akamai-client-token", "When using the Akamai provider, specify the client token (required when --provider=akamai and edgerc-path not specified)").Default(defaultConfig.AkamaiClientToken).StringVar(&cfg.AkamaiClientToken)
@@ -550,7 +824,25 @@ func App(cfg *Config) *kingpin.Application {
 	app.Flag("oci-zones-cache-duration", "When using the OCI provider, set the zones list cache TTL (0s to disable).").Default(defaultConfig.OCIZoneCacheDuration.String()).DurationVar(&cfg.OCIZoneCacheDuration)
 	app.Flag("inmemory-zone", "Provide a list of pre-configured zones for the inmemory provider; specify multiple times for multiple zones (optional)").Default("").StringsVar(&cfg.InMemoryZones)
 	app.Flag("ovh-endpoint", "When using the OVH provider, specify the endpoint (default: ovh-eu)").Default(defaultConfig.OVHEndpoint).StringVar(&cfg.OVHEndpoint)
-	app.Flag("ovh-api-rate-limit", "When using the OVH provider, specify the API request rate limit, X operations by seconds (default: 20)").Default(strconv.Itoa(defaultConfig.OVHApiRateLimit)).IntVar(&cfg.OVHApiRateLimit)
+	app.Flag("ovh-auth-method", "When using the OVH provider, the authentication method: application, with the OVH_APPLICATION_KEY, OVH_APPLICATION_SECRET and OVH_CONSUMER_KEY credentials, or oauth2, with the OVH_CLIENT_ID and OVH_CLIENT_SECRET credentials of a service account (default: application, options: application, oauth2)").Default(defaultConfig.OVHAuthMethod).EnumVar(&cfg.OVHAuthMethod, "application", "oauth2")
+	app.Flag("ovh-credentials-file", "When using the OVH provider, read the credentials from this file in the format of ovh.conf, e.g. a mounted Secret, at every synchronization so that rotated credentials get picked up; its credentials override the ones of the environment (optional)").StringVar(&cfg.OVHCredentialsFile)
+	app.Flag("ovh-api-rate-limit", "When using the OVH provider, specify the API request rate limit, X operations by seconds; calls slow down further when the API reports its quota is close to exhaustion (default: 20)").Default(strconv.Itoa(defaultConfig.OVHApiRateLimit)).IntVar(&cfg.OVHApiRateLimit)
+	app.Flag("ovh-records-fetch-mode", "When using the OVH provider, how the records of the zones are got from the API: with one call per record, with batch calls getting many records at once, or by parsing the BIND export of the zones, falling back to one call per record when they fail (default: record, options: record, batch, export)").Default(defaultConfig.OVHRecordsFetchMode).EnumVar(&cfg.OVHRecordsFetchMode, "record", "batch", "export")
+	app.Flag("ovh-default-ttl", "When using the OVH provider, the TTL in seconds of the records whose endpoint has no TTL, unless set with the ovh-ttl annotation; 0 for the TTL of the zone (default: 0)").Default(strconv.FormatInt(defaultConfig.OVHDefaultTTL, 10)).Int64Var(&cfg.OVHDefaultTTL)
+	app.Flag("ovh-api-max-attempts", "When using the OVH provider, the maximum number of attempts of the API calls failing with a 429 or 5xx response, retried with a jittered exponential backoff respecting Retry-After; record creations are only retried on 429 responses; 1 for no retry (default: 3)").Default(strconv.Itoa(defaultConfig.OVHMaxAttempts)).IntVar(&cfg.OVHMaxAttempts)
+	app.Flag("ovh-api-retry-backoff", "When using the OVH provider, the wait before the first retry of a failed API call, doubled at each further attempt up to 30s (default: 1s)").Default(defaultConfig.OVHRetryBackoff.String()).DurationVar(&cfg.OVHRetryBackoff)
+	app.Flag("ovh-cache-persistence", "When using the OVH provider, persist the records cache so that the records of the zones whose SOA serial did not change are not got again after a restart, as file:PATH or configmap:NAMESPACE/NAME, the ConfigMap being created when missing (default: disabled)").Default(defaultConfig.OVHCachePersistence).StringVar(&cfg.OVHCachePersistence)
+	app.Flag("ovh-cache", "When using the OVH provider, cache the records of the zones and only get them again from the API once the SOA serial of their zone changed; --no-ovh-cache gets them at every synchronization (default: true)").Default(strconv.FormatBool(defaultConfig.OVHCache)).BoolVar(&cfg.OVHCache)
+	app.Flag("ovh-cache-ttl", "When using the OVH provider, get the cached records of a zone again from the API after this duration, even when the SOA serial of the zone did not change; 0 for never (default: 0)").Default(defaultConfig.OVHCacheTTL.String()).DurationVar(&cfg.OVHCacheTTL)
+	app.Flag("ovh-cache-exclude-zone", "When using the OVH provider, never cache the records of this zone; specify multiple times for multiple zones (optional)").StringsVar(&cfg.OVHCacheExcludedZones)
+	app.Flag("ovh-soa-resolver", "When using the OVH provider, the address, as HOST or HOST:PORT, of the resolver queried for the SOA serials of the cached zones, e.g. when the SOA servers of the zones are not reachable from the cluster; the answers of a resolver may lag behind the SOA servers by the TTL of the SOA records (default: the SOA server of each zone)").Default(defaultConfig.OVHSOAResolver).StringVar(&cfg.OVHSOAResolver)
+	app.Flag("ovh-soa-transport", "When using the OVH provider, the transport of the queries for the SOA serials of the cached zones, dot being DNS over TLS on port 853 unless set by --ovh-soa-resolver (default: udp, options: udp, tcp, dot)").Default(defaultConfig.OVHSOATransport).EnumVar(&cfg.OVHSOATransport, "udp", "tcp", "dot")
+	app.Flag("ovh-soa-timeout", "When using the OVH provider, the timeout of the queries for the SOA serials of the cached zones, whose records are got again from the API when it expires (default: 2s)").Default(defaultConfig.OVHSOATimeout.String()).DurationVar(&cfg.OVHSOATimeout)
+	app.Flag("ovh-skip-failed-zones", "When using the OVH provider, skip the zones whose records could not be got, e.g. a suspended zone, until the next synchronization, instead of failing the synchronization of all the zones; fails when no zone could be got (default: false)").Default(strconv.FormatBool(defaultConfig.OVHSkipFailedZones)).BoolVar(&cfg.OVHSkipFailedZones)
+	app.Flag("ovh-dnssec-enable-zone", "When using the OVH provider, enable DNSSEC on this zone, logging the DS records to publish at the registrar once enabled; specify multiple times for multiple zones (optional)").StringsVar(&cfg.OVHDNSSECEnabledZones)
+	app.Flag("ovh-dnssec-disable-zone", "When using the OVH provider, disable DNSSEC on this zone; specify multiple times for multiple zones; DNSSEC is left as is on the zones neither enabled nor disabled (optional)").StringsVar(&cfg.OVHDNSSECDisabledZones)
+	app.Flag("ovh-create-zones", "When using the OVH provider, order the missing zones of the endpoints to create, the most specific domain of the domain filter they belong to; the records are created once OVHcloud has created the zone. WARNING: the zones are paid right away with the preferred payment method of the account, waiving the retraction period (default: disabled)").BoolVar(&cfg.OVHCreateZones)
+	app.Flag("ovh-check-zone-serials", "When using the OVH provider, refuse to apply changes to a zone whose SOA serial changed since its records were got, e.g. changed by another actor, the changes being planned again shortly from its current records; costs an API call per changed zone (default: disabled)").BoolVar(&cfg.OVHCheckZoneSerials)
 	app.Flag("ovh-enable-cname-relative", "When using the OVH provider, specify if CNAME should be treated as relative on target without final dot (default: false)").Default(strconv.FormatBool(defaultConfig.OVHEnableCNAMERelative)).BoolVar(&cfg.OVHEnableCNAMERelative)
 	app.Flag("pdns-server", "When using the PowerDNS/PDNS provider, specify the URL to the pdns server (required when --provider=pdns)").Default(defaultConfig.PDNSServer).StringVar(&cfg.PDNSServer)
 	app.Flag("pdns-server-id", "When using the PowerDNS/PDNS provider, specify the id of the server to retrieve. Should be `localhost` except when the server is behind a proxy (optional when --provider=pdns) (default: localhost)").Default(defaultConfig.PDNSServerID).StringVar(&cfg.PDNSServerID)
@@ -609,44 +901,106 @@ func App(cfg *Config) *kingpin.Application {
 	app.Flag("pihole-tls-skip-verify", "When using the Pihole provider, disable verification of any TLS certificates").BoolVar(&cfg.PiholeTLSInsecureSkipVerify)
 	app.Flag("pihole-api-version", "When using the Pihole provider, specify the pihole API version (default: 5, options: 5, 6)").Default(defaultConfig.PiholeApiVersion).StringVar(&cfg.PiholeApiVersion)
 
+	// Flags related to the Hurricane Electric provider
+	app.Flag("hurricane-electric-state", "When using the Hurricane Electric provider, persist the records pushed through dynamic DNS so that they are still known after a restart, as file:PATH or configmap:NAMESPACE/NAME, the ConfigMap being created when missing (default: disabled)").Default(defaultConfig.HurricaneElectricState).StringVar(&cfg.HurricaneElectricState)
+
 	// Flags related to the Plural provider
 	app.Flag("plural-cluster", "When using the plural provider, specify the cluster name you're running with").Default(defaultConfig.PluralCluster).StringVar(&cfg.PluralCluster)
 	app.Flag("plural-provider", "When using the plural provider, specify the provider name you're running with").Default(defaultConfig.PluralProvider).StringVar(&cfg.PluralProvider)
 
+	// Flags related to the generic REST provider
+	app.Flag("rest-mapping-file", "When using the generic REST provider, specify the file describing the requests and fields of the DNS API (required when --provider=rest)").Default(defaultConfig.RESTMappingFile).StringVar(&cfg.RESTMappingFile)
+
 	// Flags related to policies
 	app.Flag("policy", "Modify how DNS records are synchronized between sources and providers (default: sync, options: sync, upsert-only, create-only)").Default(defaultConfig.Policy).EnumVar(&cfg.Policy, "sync", "upsert-only", "create-only")
 
+	app.Flag("endpoint-adjuster", "Adjust the desired endpoints through an ordered chain of adjusters; specify multiple times for multiple adjusters; the provider adjustments run last unless placed explicitly (optional, options: ttl-clamp, cname-flatten, idn-normalize, webhook, provider)").EnumsVar(&cfg.EndpointAdjusters, "ttl-clamp", "cname-flatten", "idn-normalize", "webhook", "provider")
+	app.Flag("endpoint-adjuster-webhook-url", "The URL of the webhook server called by the webhook endpoint adjuster with the desired endpoints; it serves the /adjustendpoints route of the webhook provider API and responds with the endpoints to continue with (optional)").StringVar(&cfg.AdjusterWebhookURL)
+	app.Flag("endpoint-adjuster-webhook-timeout", "The timeout of the calls to the endpoint adjuster webhook (default: 10s)").Default(defaultConfig.AdjusterWebhookTimeout.String()).DurationVar(&cfg.AdjusterWebhookTimeout)
+	app.Flag("endpoint-adjuster-webhook-failure-policy", "How to handle the endpoint adjuster webhook failing: fail the whole synchronization, or skip its adjustments for this run (default: fail, options: fail, skip)").Default(defaultConfig.AdjusterWebhookFailurePolicy).EnumVar(&cfg.AdjusterWebhookFailurePolicy, "fail", "skip")
+
 	// Flags related to the registry
 	app.Flag("registry", "The registry implementation to use to keep track of DNS record ownership (default: txt, options: txt, noop, dynamodb, aws-sd)").Default(defaultConfig.Registry).EnumVar(&cfg.Registry, "txt", "noop", "dynamodb", "aws-sd")
+	app.Flag("secondary-registry", "A second registry to keep track of DNS record ownership in, during a migration between registries: it is written the same ownership as --registry, which keeps driving the changes, and its ownership is compared to it (default: disabled, options: txt, dynamodb)").Default(defaultConfig.SecondaryRegistry).EnumVar(&cfg.SecondaryRegistry, "", "txt", "dynamodb")
+	app.Flag("registry-cache-max-staleness", "Cache the records read through the registry, ownership included, for at most this duration; applying changes invalidates the cache (default: disabled)").Default(defaultConfig.RegistryCacheMaxStaleness.String()).DurationVar(&cfg.RegistryCacheMaxStaleness)
+	app.Flag("registry-cache-zone", "When caching the registry records, a zone whose SOA serial is checked on each synchronization: a changed serial invalidates the cache; specify multiple times for multiple zones (optional)").StringsVar(&cfg.RegistryCacheZones)
 	app.Flag("txt-owner-id", "When using the TXT or DynamoDB registry, a name that identifies this instance of ExternalDNS (default: default)").Default(defaultConfig.TXTOwnerID).StringVar(&cfg.TXTOwnerID)
-	app.Flag("txt-prefix", "When using the TXT registry, a custom string that's prefixed to each ownership DNS record (optional). Could contain record type template like '%{record_type}-prefix-'. Mutual exclusive with txt-suffix!").Default(defaultConfig.TXTPrefix).StringVar(&cfg.TXTPrefix)
-	app.Flag("txt-suffix", "When using the TXT registry, a custom string that's suffixed to the host portion of each ownership DNS record (optional). Could contain record type template like '-%{record_type}-suffix'. Mutual exclusive with txt-prefix!").Default(defaultConfig.TXTSuffix).StringVar(&cfg.TXTSuffix)
+	app.Flag("txt-prefix", "When using the TXT registry, a custom string that's prefixed to each ownership DNS record (optional). Could contain the '%{record_type}', '%{zone}' and '%{owner}' variables, like '%{record_type}-prefix-'. Mutual exclusive with txt-suffix!").Default(defaultConfig.TXTPrefix).StringVar(&cfg.TXTPrefix)
+	app.Flag("txt-suffix", "When using the TXT registry, a custom string that's suffixed to the host portion of each ownership DNS record (optional). Could contain the '%{record_type}', '%{zone}' and '%{owner}' variables, like '-%{record_type}-suffix'. Mutual exclusive with txt-prefix!").Default(defaultConfig.TXTSuffix).StringVar(&cfg.TXTSuffix)
 	app.Flag("txt-wildcard-replacement", "When using the TXT registry, a custom string that's used instead of an asterisk for TXT records corresponding to wildcard DNS records (optional)").Default(defaultConfig.TXTWildcardReplacement).StringVar(&cfg.TXTWildcardReplacement)
 	app.Flag("txt-encrypt-enabled", "When using the TXT registry, set if TXT records should be encrypted before stored (default: disabled)").BoolVar(&cfg.TXTEncryptEnabled)
 	app.Flag("txt-encrypt-aes-key", "When using the TXT registry, set TXT record decryption and encryption 32 byte aes key (required when --txt-encrypt=true)").Default(defaultConfig.TXTEncryptAESKey).StringVar(&cfg.TXTEncryptAESKey)
 	app.Flag("txt-new-format-only", "When using the TXT registry, only use new format records which include record type information (e.g., prefix: 'a-'). Reduces number of TXT records (default: disabled)").BoolVar(&cfg.TXTNewFormatOnly)
+	app.Flag("cutover-from-owner-id", "When using the TXT registry, the owner id whose records are progressively taken over following --cutover-schedule, e.g. the ExternalDNS of the cluster traffic is moved away from (optional)").Default(defaultConfig.CutoverFromOwnerID).StringVar(&cfg.CutoverFromOwnerID)
+	app.Flag("cutover-start", "The time the cutover starts, in RFC 3339 format (required when --cutover-from-owner-id is set)").Default(defaultConfig.CutoverStart).StringVar(&cfg.CutoverStart)
+	app.Flag("cutover-schedule", "The percentages of traffic taken over after durations since the cutover start, e.g. '0s=10,15m=50,1h=100'; the last step must be 100 (required when --cutover-from-owner-id is set)").Default(defaultConfig.CutoverSchedule).StringVar(&cfg.CutoverSchedule)
+	app.Flag("transfer-ownership-to", "Instead of synchronizing, hand the records of the --transfer-ownership-name names owned by --txt-owner-id over to this owner id, then exit; honors --dry-run (optional)").Default(defaultConfig.TransferOwnershipTo).StringVar(&cfg.TransferOwnershipTo)
+	app.Flag("transfer-ownership-name", "A DNS name whose records are handed over with --transfer-ownership-to; specify multiple times for multiple names (required when --transfer-ownership-to is set)").StringsVar(&cfg.TransferOwnershipNames)
 	app.Flag("dynamodb-region", "When using the DynamoDB registry, the AWS region of the DynamoDB table (optional)").Default(cfg.AWSDynamoDBRegion).StringVar(&cfg.AWSDynamoDBRegion)
 	app.Flag("dynamodb-table", "When using the DynamoDB registry, the name of the DynamoDB table (default: \"external-dns\")").Default(defaultConfig.AWSDynamoDBTable).StringVar(&cfg.AWSDynamoDBTable)
+	app.Flag("dynamodb-create-table", "When using the DynamoDB registry, create the DynamoDB table with on-demand capacity if it does not exist (default: disabled)").BoolVar(&cfg.AWSDynamoDBCreateTable)
+	app.Flag("dynamodb-item-ttl", "When using the DynamoDB registry, expire the ownership records after this duration unless the owner refreshes them, so that DynamoDB removes the records of owners which are gone (default: disabled)").Default(defaultConfig.AWSDynamoDBItemTTL.String()).DurationVar(&cfg.AWSDynamoDBItemTTL)
+	app.Flag("dynamodb-replica-region", "When using the DynamoDB registry, a region the DynamoDB table is replicated in as a global table; a created table gets a replica in it, and a missing replica is warned about; specify multiple times for multiple regions (optional)").StringsVar(&cfg.AWSDynamoDBReplicaRegions)
 
 	// Flags related to the main control loop
 	app.Flag("txt-cache-interval", "The interval between cache synchronizations in duration format (default: disabled)").Default(defaultConfig.TXTCacheInterval.String()).DurationVar(&cfg.TXTCacheInterval)
 	app.Flag("interval", "The interval between two consecutive synchronizations in duration format (default: 1m)").Default(defaultConfig.Interval.String()).DurationVar(&cfg.Interval)
 	app.Flag("min-event-sync-interval", "The minimum interval between two consecutive synchronizations triggered from kubernetes events in duration format (default: 5s)").Default(defaultConfig.MinEventSyncInterval.String()).DurationVar(&cfg.MinEventSyncInterval)
+	app.Flag("zone-retry-backoff", "When set, changes are applied zone by zone, the zones being the --domain-filter entries, and a zone failing to apply is retried after this delay, doubled after each consecutive failure, without holding back the other zones (default: disabled)").Default(defaultConfig.ZoneRetryBackoff.String()).DurationVar(&cfg.ZoneRetryBackoff)
+	app.Flag("zone-retry-max-backoff", "The maximum delay before retrying a zone failing to apply, when --zone-retry-backoff is set (default: 10m)").Default(defaultConfig.ZoneRetryMaxBackoff.String()).DurationVar(&cfg.ZoneRetryMaxBackoff)
+	app.Flag("zone-dead-letter-threshold", "The number of consecutive failures after which a zone is reported as a dead letter, when --zone-retry-backoff is set (default: 5)").Default(strconv.Itoa(defaultConfig.ZoneDeadLetterThreshold)).IntVar(&cfg.ZoneDeadLetterThreshold)
+	app.Flag("write-interval", "The minimum interval between two consecutive writes to the DNS provider; sources and the provider keep being read at every --interval, changes being held back in the meantime (default: disabled)").Default(defaultConfig.WriteInterval.String()).DurationVar(&cfg.WriteInterval)
+	app.Flag("write-approval", "When enabled, changes are only applied once approved through the /pending endpoint of the metrics address, or by annotating their PendingPlan object when --write-approval-namespace is set (default: disabled)").BoolVar(&cfg.WriteApproval)
+	app.Flag("write-approval-threshold", "When --write-approval is enabled, plans changing at most this number of records are applied without approval (default: 0)").Default(strconv.Itoa(defaultConfig.WriteApprovalThreshold)).IntVar(&cfg.WriteApprovalThreshold)
+	app.Flag("write-approval-expiry", "When --write-approval is enabled, the time after which pending changes that were not applied expire and need to be approved again; 0s never expires them (default: 1h)").Default(defaultConfig.WriteApprovalExpiry.String()).DurationVar(&cfg.WriteApprovalExpiry)
+	app.Flag("write-approval-namespace", "When --write-approval is enabled, publish the changes waiting for approval as PendingPlan objects in this namespace; requires the PendingPlan CRD (default: disabled)").Default(defaultConfig.WriteApprovalNamespace).StringVar(&cfg.WriteApprovalNamespace)
 	app.Flag("once", "When enabled, exits the synchronization loop after the first iteration (default: disabled)").BoolVar(&cfg.Once)
 	app.Flag("dry-run", "When enabled, prints DNS record changes rather than actually performing them (default: disabled)").BoolVar(&cfg.DryRun)
 	app.Flag("events", "When enabled, in addition to running every interval, the reconciliation loop will get triggered when supported sources change (default: disabled)").BoolVar(&cfg.UpdateEvents)
 
+	// Commands
+	app.Command("run", "Synchronize the DNS records with the sources (default)").Default()
+	app.Command("diff", "Print the changes a synchronization would make to the DNS records, grouped by zone, then exit")
+	app.Flag("diff-output", "The format in which the diff command prints the changes (default: text, options: text, json)").Default(defaultConfig.DiffOutput).EnumVar(&cfg.DiffOutput, "text", "json")
+	app.Flag("diff-color", "Whether the diff command colorizes the changes printed as text; auto colorizes them when printing to a terminal (default: auto, options: auto, always, never)").Default(defaultConfig.DiffColor).EnumVar(&cfg.DiffColor, "auto", "always", "never")
+	app.Command("ownership", "Print the records managed by ExternalDNS grouped by zone and name, with the owner id, namespace and resource owning them, then exit")
+	app.Flag("ownership-output", "The format in which the ownership command prints the records (default: text, options: text, json)").Default(defaultConfig.OwnershipOutput).EnumVar(&cfg.OwnershipOutput, "text", "json")
+	app.Flag("ownership-namespace", "Restrict the records printed by the ownership command to the ones of resources in this namespace; specify multiple times for multiple namespaces (default: all namespaces)").StringsVar(&cfg.OwnershipNamespaces)
+	app.Command("bootstrap", "Take over the existing records that no owner manages and that the sources desire, by seeding their ownership in the registry after confirmation, then exit; with --dry-run, only print them")
+	app.Flag("bootstrap-yes", "Take over the records printed by the bootstrap command without asking for confirmation (default: false)").BoolVar(&cfg.BootstrapYes)
+	app.Flag("ownership-owner", "Restrict the records printed by the ownership command to the ones owned by this owner id; specify multiple times for multiple owner ids (default: all owner ids)").StringsVar(&cfg.OwnershipOwners)
+
 	// Miscellaneous flags
 	app.Flag("log-format", "The format in which log messages are printed (default: text, options: text, json)").Default(defaultConfig.LogFormat).EnumVar(&cfg.LogFormat, "text", "json")
 	app.Flag("metrics-address", "Specify where to serve the metrics and health check endpoint (default: :7979)").Default(defaultConfig.MetricsAddress).StringVar(&cfg.MetricsAddress)
+	app.Flag("audit-namespace", "When set, record every change applied to the DNS provider as a DNSChange object in this namespace; requires the DNSChange CRD (default: disabled)").Default(defaultConfig.AuditNamespace).StringVar(&cfg.AuditNamespace)
+	app.Flag("audit-ttl", "How long to keep the DNSChange objects recording applied changes, 0 keeps them forever (default: 168h)").Default(defaultConfig.AuditTTL.String()).DurationVar(&cfg.AuditTTL)
+	app.Flag("export-record-metrics", "When enabled, export a metric series per record owned by an ExternalDNS instance, labelled with its type, zone, owner and source resource (default: disabled)").BoolVar(&cfg.ExportRecordMetrics)
+	app.Flag("feature-gates", featureGatesHelp()).StringsVar(&cfg.FeatureGates)
+	app.Flag("lameduck-duration", "On SIGTERM, how long to keep running with the readiness endpoint failing and without applying changes before exiting, so that other replicas can take over (default: disabled)").Default(defaultConfig.LameduckDuration.String()).DurationVar(&cfg.LameduckDuration)
 	app.Flag("log-level", "Set the level of logging. (default: info, options: panic, debug, info, warning, error, fatal)").Default(defaultConfig.LogLevel).EnumVar(&cfg.LogLevel, allLogLevelsAsStrings()...)
 
 	// Webhook provider
 	app.Flag("webhook-provider-url", "The URL of the remote endpoint to call for the webhook provider (default: http://localhost:8888)").Default(defaultConfig.WebhookProviderURL).StringVar(&cfg.WebhookProviderURL)
 	app.Flag("webhook-provider-read-timeout", "The read timeout for the webhook provider in duration format (default: 5s)").Default(defaultConfig.WebhookProviderReadTimeout.String()).DurationVar(&cfg.WebhookProviderReadTimeout)
 	app.Flag("webhook-provider-write-timeout", "The write timeout for the webhook provider in duration format (default: 10s)").Default(defaultConfig.WebhookProviderWriteTimeout.String()).DurationVar(&cfg.WebhookProviderWriteTimeout)
+	app.Flag("webhook-sidecar-command", "When set, runs this binary as the webhook provider, restarting it whenever it exits; synchronizations are skipped while it is not ready (optional, requires --provider=webhook)").Default(defaultConfig.WebhookSidecarCommand).StringVar(&cfg.WebhookSidecarCommand)
+	app.Flag("webhook-sidecar-arg", "An argument passed to the webhook provider sidecar command; specify multiple times for multiple arguments (optional)").StringsVar(&cfg.WebhookSidecarArgs)
+	app.Flag("webhook-sidecar-health-url", "The URL checked with GET requests to tell whether the webhook provider sidecar is ready (default: the webhook provider URL)").Default(defaultConfig.WebhookSidecarHealthURL).StringVar(&cfg.WebhookSidecarHealthURL)
+	app.Flag("webhook-sidecar-backoff", "The delay before restarting the webhook provider sidecar once it exited, doubled while it keeps exiting before getting ready (default: 1s)").Default(defaultConfig.WebhookSidecarBackoff.String()).DurationVar(&cfg.WebhookSidecarBackoff)
+	app.Flag("webhook-sidecar-max-backoff", "The maximum delay before restarting the webhook provider sidecar (default: 1m)").Default(defaultConfig.WebhookSidecarMaxBackoff.String()).DurationVar(&cfg.WebhookSidecarMaxBackoff)
+	app.Flag("webhook-sidecar-startup-timeout", "How long to wait for the webhook provider sidecar to get ready at startup before failing (default: 1m)").Default(defaultConfig.WebhookSidecarStartupTimeout.String()).DurationVar(&cfg.WebhookSidecarStartupTimeout)
 
 	app.Flag("webhook-server", "When enabled, runs as a webhook server instead of a controller. (default: false).").BoolVar(&cfg.WebhookServer)
+	app.Flag("webhook-server-address", "The address, as HOST:PORT, the webhook server listens on, e.g. 0.0.0.0:8888 when its provider is deployed apart from the controller (default: 127.0.0.1:8888)").Default(defaultConfig.WebhookServerAddress).StringVar(&cfg.WebhookServerAddress)
 
 	return app
 }
+
+func featureGatesHelp() string {
+	help := "Enable or disable experimental features with key=value pairs, e.g. Feature=true; specify multiple times or separate the pairs with commas (optional)"
+	if known := featuregate.Default.KnownFeatures(); len(known) > 0 {
+		help += "; known features: " + strings.Join(known, ", ")
+	}
+	return help
+}
@@ -38,10 +38,17 @@ var (
 		GlooNamespaces:                         []string{"gloo-system"},
 		SkipperRouteGroupVersion:               "zalando.org/v1",
 		Sources:                                []string{"service"},
+		SourceFailurePolicy:                    "fail",
+		ChaosFlapRate:                          10,
+		ShardCount:                             1,
+		AdjusterWebhookFailurePolicy:           "fail",
+		AdjusterWebhookTimeout:                 10 * time.Second,
 		Namespace:                              "",
 		FQDNTemplate:                           "",
 		Compatibility:                          "",
 		Provider:                               "google",
+		SecretRefreshInterval:                  5 * time.Minute,
+		FinalizerName:                          "external-dns.alpha.kubernetes.io/records",
 		GoogleProject:                          "",
 		GoogleBatchChangeSize:                  1000,
 		GoogleBatchChangeInterval:              time.Second,
@@ -74,6 +81,7 @@ var (
 		AzureResourceGroup:                     "",
 		AzureSubscriptionID:                    "",
 		CloudflareProxied:                      false,
+		CloudflareProxiedDrift:                 "repair",
 		CloudflareCustomHostnames:              false,
 		CloudflareCustomHostnamesMinTLSVersion: "1.0",
 		CloudflareCustomHostnamesCertificateAuthority: "google",
@@ -91,7 +99,14 @@ var (
 		OCIZoneCacheDuration:                          0 * time.Second,
 		InMemoryZones:                                 []string{""},
 		OVHEndpoint:                                   "ovh-eu",
+		OVHAuthMethod:                                 "application",
 		OVHApiRateLimit:                               20,
+		OVHRecordsFetchMode:                           "record",
+		OVHMaxAttempts:                                3,
+		OVHRetryBackoff:                               time.Second,
+		OVHCache:                                      true,
+		OVHSOATransport:                               "udp",
+		OVHSOATimeout:                                 2 * time.Second,
 		PDNSServer:                                    "http://localhost:8081",
 		PDNSServerID:                                  "localhost",
 		PDNSAPIKey:                                    "",
@@ -103,13 +118,25 @@ var (
 		TXTNewFormatOnly:                              false,
 		Interval:                                      time.Minute,
 		MinEventSyncInterval:                          5 * time.Second,
+		ZoneRetryMaxBackoff:                           10 * time.Minute,
+		AuditTTL:                                      7 * 24 * time.Hour,
+		ZoneCollisionPolicy:                           "warn",
+		ZoneDeadLetterThreshold:                       5,
+		WriteApprovalExpiry:                           time.Hour,
 		Once:                                          false,
+		Command:                                       "run",
+		DiffOutput:                                    "text",
+		DiffColor:                                     "auto",
+		OwnershipOutput:                               "text",
 		DryRun:                                        false,
 		UpdateEvents:                                  false,
 		LogFormat:                                     "text",
 		MetricsAddress:                                ":7979",
 		LogLevel:                                      logrus.InfoLevel.String(),
 		ConnectorSourceServer:                         "localhost:8080",
+		ConnectorSourceProtocol:                       1,
+		ConnectorSourceMaxSize:                        16 << 20,
+		ConnectorSourceRetries:                        3,
 		ExoscaleAPIEnvironment:                        "api",
 		ExoscaleAPIZone:                               "ch-gva-2",
 		ExoscaleAPIKey:                                "",
@@ -128,11 +155,21 @@ var (
 		IBMCloudConfigFile:                            "/etc/kubernetes/ibmcloud.json",
 		TencentCloudConfigFile:                        "/etc/kubernetes/tencent-cloud.json",
 		TencentCloudZoneType:                          "",
+		YandexFolderID:                                "",
+		YandexAuthKeyFile:                             "",
+		YandexZoneVisibility:                          "",
+		RESTMappingFile:                               "",
 		PiholeApiVersion:                              "5",
+		HurricaneElectricState:                        "",
 		WebhookProviderURL:                            "http://localhost:8888",
 		WebhookProviderReadTimeout:                    5 * time.Second,
 		WebhookProviderWriteTimeout:                   10 * time.Second,
+		WebhookServerAddress:                          "127.0.0.1:8888",
+		WebhookSidecarBackoff:                         time.Second,
+		WebhookSidecarMaxBackoff:                      time.Minute,
+		WebhookSidecarStartupTimeout:                  time.Minute,
 		ExcludeUnschedulable:                          true,
+		ValidateHostnames:                             false,
 	}
 
 	overriddenConfig = &Config{
@@ -142,6 +179,13 @@ var (
 		GlooNamespaces:                         []string{"gloo-not-system", "gloo-second-system"},
 		SkipperRouteGroupVersion:               "zalando.org/v2",
 		Sources:                                []string{"service", "ingress", "connector"},
+		SourceTimeout:                          10 * time.Second,
+		SourceFailurePolicy:                    "skip",
+		ChaosFlapDomain:                        "chaos.example.org",
+		ChaosFlapRate:                          5,
+		ShardCount:                             4,
+		ShardIndex:                             2,
+		GatewayRouteEvents:                     true,
 		Namespace:                              "namespace",
 		IgnoreHostnameAnnotation:               true,
 		IgnoreNonHostNetworkPods:               false,
@@ -151,10 +195,19 @@ var (
 		Compatibility:                          "mate",
 		Provider:                               "google",
 		GoogleProject:                          "project",
+		GoogleAdditionalProjects:               []string{"other", "another=/creds.json"},
 		GoogleBatchChangeSize:                  100,
 		GoogleBatchChangeInterval:              time.Second * 2,
 		GoogleZoneVisibility:                   "private",
 		DomainFilter:                           []string{"example.org", "company.com"},
+		DomainRewrites:                         []string{"cluster.local=example.org"},
+		PreviewNamespaceLabel:                  "preview",
+		PreviewDomain:                          "preview.example.org",
+		PreviewMaxLifetime:                     72 * time.Hour,
+		EndpointAdjusters:                      []string{"idn-normalize", "webhook"},
+		AdjusterWebhookURL:                     "http://localhost:8889",
+		AdjusterWebhookTimeout:                 5 * time.Second,
+		AdjusterWebhookFailurePolicy:           "skip",
 		ExcludeDomains:                         []string{"xapi.example.org", "xapi.company.com"},
 		RegexDomainFilter:                      regexp.MustCompile("(example\\.org|company\\.com)$"),
 		RegexDomainExclusion:                   regexp.MustCompile("xapi\\.(example\\.org|company\\.com)$"),
@@ -162,7 +215,10 @@ var (
 		ZoneIDFilter:                           []string{"/hostedzone/ZTST1", "/hostedzone/ZTST2"},
 		TargetNetFilter:                        []string{"10.0.0.0/9", "10.1.0.0/9"},
 		ExcludeTargetNets:                      []string{"1.0.0.0/9", "1.1.0.0/9"},
+		ExcludeNodeTaints:                      []string{"example.com/draining", "node.kubernetes.io/out-of-service"},
+		ExcludeNotReadyNodesAfter:              5 * time.Minute,
 		AlibabaCloudConfigFile:                 "/etc/kubernetes/alibaba-cloud.json",
+		AlibabaCloudManageVPCAssociations:      true,
 		AWSZoneType:                            "private",
 		AWSZoneTagFilter:                       []string{"tag=foo"},
 		AWSZoneMatchParent:                     true,
@@ -180,15 +236,22 @@ var (
 		AWSSDServiceCleanup:                    true,
 		AWSSDCreateTag:                         map[string]string{"key1": "value1", "key2": "value2"},
 		AWSDynamoDBTable:                       "custom-table",
+		AWSDynamoDBCreateTable:                 true,
+		AWSDynamoDBItemTTL:                     24 * time.Hour,
+		AWSDynamoDBReplicaRegions:              []string{"us-east-1", "eu-west-1"},
 		AzureConfigFile:                        "azure.json",
 		AzureResourceGroup:                     "arg",
 		AzureSubscriptionID:                    "arg",
+		AzureResourceGraphDiscovery:            true,
+		AzureDiscoverySubscriptionIDs:          []string{"sub1", "sub2"},
 		CloudflareProxied:                      true,
+		CloudflareProxiedDrift:                 "keep",
 		CloudflareCustomHostnames:              true,
 		CloudflareCustomHostnamesMinTLSVersion: "1.3",
 		CloudflareCustomHostnamesCertificateAuthority: "google",
 		CloudflareDNSRecordsPerPage:                   5000,
 		CloudflareRegionKey:                           "us",
+		CloudflareRecordCommentLabels:                 true,
 		CoreDNSPrefix:                                 "/coredns/",
 		AkamaiServiceConsumerDomain:                   "oooo-xxxxxxxxxxxxxxxx-xxxxxxxxxxxxxxxx.luna.akamaiapis.net",
 		AkamaiClientToken:                             "o184671d5307a388180fbf7f11dbdf46",
@@ -201,7 +264,36 @@ var (
 		OCIZoneCacheDuration:                          30 * time.Second,
 		InMemoryZones:                                 []string{"example.org", "company.com"},
 		OVHEndpoint:                                   "ovh-ca",
+		OVHAuthMethod:                                 "oauth2",
+		OVHCredentialsFile:                            "/etc/ovh/credentials.conf",
 		OVHApiRateLimit:                               42,
+		OVHRecordsFetchMode:                           "batch",
+		OVHDefaultTTL:                                 300,
+		OVHMaxAttempts:                                5,
+		OVHRetryBackoff:                               2 * time.Second,
+		OVHCachePersistence:                           "configmap:external-dns/ovh-cache",
+		OVHCache:                                      false,
+		OVHCacheTTL:                                   time.Hour,
+		OVHCacheExcludedZones:                         []string{"example.org", "example.com"},
+		OVHSOAResolver:                                "1.1.1.1",
+		OVHSOATransport:                               "dot",
+		OVHSOATimeout:                                 5 * time.Second,
+		OVHSkipFailedZones:                            true,
+		OVHDNSSECEnabledZones:                         []string{"example.org"},
+		OVHDNSSECDisabledZones:                        []string{"example.com"},
+		OVHCreateZones:                                true,
+		OVHCheckZoneSerials:                           true,
+		ProviderBatchSize:                             100,
+		HTTPClientTimeout:                             20 * time.Second,
+		HTTPProxy:                                     "http://proxy.example.org:3128",
+		HTTPCAFile:                                    "/etc/ssl/http-ca.pem",
+		HTTPClientCertFile:                            "/etc/ssl/http-client.pem",
+		HTTPClientKeyFile:                             "/etc/ssl/http-client-key.pem",
+		HTTPUserAgent:                                 "external-dns-test",
+		DNSResolver:                                   "https://dns.example.org/dns-query",
+		DNSResolverTimeout:                            3 * time.Second,
+		Secrets:                                       []string{"CF_API_TOKEN=vault:secret/data/cloudflare#token", "OVH_CONSUMER_KEY=file:/var/run/secrets/ovh/consumer-key"},
+		SecretRefreshInterval:                         time.Minute,
 		PDNSServer:                                    "http://ns.example.com:8081",
 		PDNSServerID:                                  "localhost",
 		PDNSAPIKey:                                    "some-secret-key",
@@ -212,19 +304,60 @@ var (
 		PodSourceDomain:                               "example.org",
 		Policy:                                        "upsert-only",
 		Registry:                                      "noop",
+		SecondaryRegistry:                             "dynamodb",
+		RegistryCacheMaxStaleness:                     10 * time.Minute,
+		RegistryCacheZones:                            []string{"example.org", "example.com"},
 		TXTOwnerID:                                    "owner-1",
+		CutoverFromOwnerID:                            "owner-0",
+		CutoverStart:                                  "2025-01-01T00:00:00Z",
+		CutoverSchedule:                               "0s=10,1h=100",
+		TransferOwnershipTo:                           "team-b",
+		TransferOwnershipNames:                        []string{"api.example.org", "www.example.org"},
 		TXTPrefix:                                     "associated-txt-record",
 		TXTCacheInterval:                              12 * time.Hour,
 		TXTNewFormatOnly:                              true,
 		Interval:                                      10 * time.Minute,
 		MinEventSyncInterval:                          50 * time.Second,
+		MinTTL:                                        30 * time.Second,
+		MaxTTL:                                        time.Hour,
+		ZoneRetryBackoff:                              30 * time.Second,
+		ZoneRetryMaxBackoff:                           time.Hour,
+		ZoneCollisionPolicy:                           "fail",
+		ZoneDeadLetterThreshold:                       3,
+		WriteInterval:                                 10 * time.Minute,
+		WriteApproval:                                 true,
+		WriteApprovalThreshold:                        10,
+		WriteApprovalExpiry:                           30 * time.Minute,
+		WriteApprovalNamespace:                        "dns",
 		Once:                                          true,
+		Command:                                       "run",
+		DiffOutput:                                    "json",
+		DiffColor:                                     "never",
+		OwnershipOutput:                               "json",
+		OwnershipNamespaces:                           []string{"team-a", "team-b"},
+		OwnershipOwners:                               []string{"cluster-a"},
 		DryRun:                                        true,
 		UpdateEvents:                                  true,
 		LogFormat:                                     "json",
 		MetricsAddress:                                "127.0.0.1:9099",
+		ExportRecordMetrics:                           true,
+		FeatureGates:                                  []string{"SomeFeature=true", "OtherFeature=false"},
+		AuditNamespace:                                "external-dns",
+		AuditTTL:                                      24 * time.Hour,
+		LameduckDuration:                              15 * time.Second,
 		LogLevel:                                      logrus.DebugLevel.String(),
 		ConnectorSourceServer:                         "localhost:8081",
+		ConnectorSourceTLS:                            true,
+		ConnectorSourceTLSCA:                          "/path/to/connector-ca.pem",
+		ConnectorSourceTLSClientCert:                  "/path/to/connector-cert.pem",
+		ConnectorSourceTLSClientKey:                   "/path/to/connector-key.pem",
+		ConnectorSourceTokenFile:                      "/path/to/connector-token",
+		ConnectorSourceProtocol:                       2,
+		ConnectorSourceMaxSize:                        1 << 20,
+		ConnectorSourceRetries:                        5,
+		PrometheusSDURLs:                              []string{"http://sd1.example.org/targets", "http://sd2.example.org/targets"},
+		PrometheusSDFiles:                             []string{"/etc/sd/targets.json"},
+		PrometheusSDRelabelConfig:                     "/etc/sd/relabel.yaml",
 		ExoscaleAPIEnvironment:                        "api1",
 		ExoscaleAPIZone:                               "zone1",
 		ExoscaleAPIKey:                                "1",
@@ -237,6 +370,12 @@ var (
 		TransIPPrivateKeyFile:                         "/path/to/transip.key",
 		DigitalOceanAPIPageSize:                       100,
 		ManagedDNSRecordTypes:                         []string{endpoint.RecordTypeA, endpoint.RecordTypeAAAA, endpoint.RecordTypeCNAME, endpoint.RecordTypeNS},
+		ZoneApexes:                                    []string{"example.org", "example.com"},
+		PinnedRecords:                                 []string{"api.example.org", "www.example.org"},
+		OverridesConfigMap:                            "external-dns/overrides",
+		DeletionDelayCycles:                           3,
+		Finalizers:                                    []string{"namespace", "dnsendpoint"},
+		FinalizerName:                                 "external-dns.alpha.kubernetes.io/records-blue",
 		RFC2136BatchChangeSize:                        100,
 		RFC2136Host:                                   []string{"rfc2136-host1", "rfc2136-host2"},
 		RFC2136LoadBalancingStrategy:                  "round-robin",
@@ -244,11 +383,24 @@ var (
 		IBMCloudConfigFile:                            "ibmcloud.json",
 		TencentCloudConfigFile:                        "tencent-cloud.json",
 		TencentCloudZoneType:                          "private",
+		YandexFolderID:                                "b1g0000000000000000",
+		YandexAuthKeyFile:                             "yandex-key.json",
+		YandexZoneVisibility:                          "private",
+		RESTMappingFile:                               "rest-mapping.yaml",
 		PiholeApiVersion:                              "6",
+		HurricaneElectricState:                        "configmap:external-dns/he-state",
 		WebhookProviderURL:                            "http://localhost:8888",
 		WebhookProviderReadTimeout:                    5 * time.Second,
 		WebhookProviderWriteTimeout:                   10 * time.Second,
+		WebhookServerAddress:                          "0.0.0.0:8888",
+		WebhookSidecarCommand:                         "/usr/local/bin/external-dns-ovh-webhook",
+		WebhookSidecarArgs:                            []string{"--port=8888", "--log-level=debug"},
+		WebhookSidecarHealthURL:                       "http://localhost:8080/healthz",
+		WebhookSidecarBackoff:                         2 * time.Second,
+		WebhookSidecarMaxBackoff:                      30 * time.Second,
+		WebhookSidecarStartupTimeout:                  2 * time.Minute,
 		ExcludeUnschedulable:                          false,
+		ValidateHostnames:                             true,
 	}
 )
 
@@ -281,6 +433,13 @@ func TestParseFlags(t *testing.T) {
 				"--source=service",
 				"--source=ingress",
 				"--source=connector",
+				"--source-timeout=10s",
+				"--source-failure-policy=skip",
+				"--chaos-flap-domain=chaos.example.org",
+				"--chaos-flap-rate=5",
+				"--shard-count=4",
+				"--shard-index=2",
+				"--gateway-route-events",
 				"--namespace=namespace",
 				"--fqdn-template={{.Name}}.service.example.com",
 				"--no-ignore-non-host-network-pods",
@@ -290,18 +449,25 @@ func TestParseFlags(t *testing.T) {
 				"--compatibility=mate",
 				"--provider=google",
 				"--google-project=project",
+				"--google-additional-project=other",
+				"--google-additional-project=another=/creds.json",
 				"--google-batch-change-size=100",
 				"--google-batch-change-interval=2s",
 				"--google-zone-visibility=private",
 				"--azure-config-file=azure.json",
 				"--azure-resource-group=arg",
 				"--azure-subscription-id=arg",
+				"--azure-resource-graph-discovery",
+				"--azure-discovery-subscription-id=sub1",
+				"--azure-discovery-subscription-id=sub2",
 				"--cloudflare-proxied",
+				"--cloudflare-proxied-drift=keep",
 				"--cloudflare-custom-hostnames",
 				"--cloudflare-custom-hostnames-min-tls-version=1.3",
 				"--cloudflare-custom-hostnames-certificate-authority=google",
 				"--cloudflare-dns-records-per-page=5000",
 				"--cloudflare-region-key=us",
+				"--cloudflare-record-comment-labels",
 				"--coredns-prefix=/coredns/",
 				"--akamai-serviceconsumerdomain=oooo-xxxxxxxxxxxxxxxx-xxxxxxxxxxxxxxxx.luna.akamaiapis.net",
 				"--akamai-client-token=o184671d5307a388180fbf7f11dbdf46",
@@ -312,7 +478,46 @@ func TestParseFlags(t *testing.T) {
 				"--inmemory-zone=example.org",
 				"--inmemory-zone=company.com",
 				"--ovh-endpoint=ovh-ca",
+				"--ovh-auth-method=oauth2",
+				"--ovh-credentials-file=/etc/ovh/credentials.conf",
 				"--ovh-api-rate-limit=42",
+				"--ovh-records-fetch-mode=batch",
+				"--ovh-default-ttl=300",
+				"--ovh-api-max-attempts=5",
+				"--ovh-api-retry-backoff=2s",
+				"--ovh-cache-persistence=configmap:external-dns/ovh-cache",
+				"--no-ovh-cache",
+				"--ovh-cache-ttl=1h",
+				"--ovh-cache-exclude-zone=example.org",
+				"--ovh-cache-exclude-zone=example.com",
+				"--ovh-soa-resolver=1.1.1.1",
+				"--ovh-soa-transport=dot",
+				"--ovh-soa-timeout=5s",
+				"--ovh-skip-failed-zones",
+				"--ovh-dnssec-enable-zone=example.org",
+				"--ovh-dnssec-disable-zone=example.com",
+				"--ovh-create-zones",
+				"--ovh-check-zone-serials",
+				"--provider-batch-size=100",
+				"--http-client-timeout=20s",
+				"--http-proxy=http://proxy.example.org:3128",
+				"--http-ca-file=/etc/ssl/http-ca.pem",
+				"--http-client-cert-file=/etc/ssl/http-client.pem",
+				"--http-client-key-file=/etc/ssl/http-client-key.pem",
+				"--http-user-agent=external-dns-test",
+				"--dns-resolver=https://dns.example.org/dns-query",
+				"--dns-resolver-timeout=3s",
+				"--secret=CF_API_TOKEN=vault:secret/data/cloudflare#token",
+				"--secret=OVH_CONSUMER_KEY=file:/var/run/secrets/ovh/consumer-key",
+				"--secret-refresh-interval=1m",
+				"--webhook-server-address=0.0.0.0:8888",
+				"--webhook-sidecar-command=/usr/local/bin/external-dns-ovh-webhook",
+				"--webhook-sidecar-arg=--port=8888",
+				"--webhook-sidecar-arg=--log-level=debug",
+				"--webhook-sidecar-health-url=http://localhost:8080/healthz",
+				"--webhook-sidecar-backoff=2s",
+				"--webhook-sidecar-max-backoff=30s",
+				"--webhook-sidecar-startup-timeout=2m",
 				"--pdns-server=http://ns.example.com:8081",
 				"--pdns-server-id=localhost",
 				"--pdns-api-key=some-secret-key",
@@ -326,6 +531,15 @@ func TestParseFlags(t *testing.T) {
 				"--pod-source-domain=example.org",
 				"--domain-filter=example.org",
 				"--domain-filter=company.com",
+				"--domain-rewrite=cluster.local=example.org",
+				"--preview-namespace-label=preview",
+				"--preview-domain=preview.example.org",
+				"--preview-max-lifetime=72h",
+				"--endpoint-adjuster=idn-normalize",
+				"--endpoint-adjuster=webhook",
+				"--endpoint-adjuster-webhook-url=http://localhost:8889",
+				"--endpoint-adjuster-webhook-timeout=5s",
+				"--endpoint-adjuster-webhook-failure-policy=skip",
 				"--exclude-domains=xapi.example.org",
 				"--exclude-domains=xapi.company.com",
 				"--regex-domain-filter=(example\\.org|company\\.com)$",
@@ -338,6 +552,9 @@ func TestParseFlags(t *testing.T) {
 				"--target-net-filter=10.1.0.0/9",
 				"--exclude-target-net=1.0.0.0/9",
 				"--exclude-target-net=1.1.0.0/9",
+				"--exclude-node-taint=example.com/draining",
+				"--exclude-node-taint=node.kubernetes.io/out-of-service",
+				"--exclude-not-ready-nodes-after=5m",
 				"--aws-zone-type=private",
 				"--aws-zone-tags=tag=foo",
 				"--aws-zone-match-parent",
@@ -357,22 +574,72 @@ func TestParseFlags(t *testing.T) {
 				"--aws-sd-create-tag=key2=value2",
 				"--no-aws-evaluate-target-health",
 				"--pihole-api-version=6",
+				"--hurricane-electric-state=configmap:external-dns/he-state",
 				"--policy=upsert-only",
 				"--registry=noop",
+				"--secondary-registry=dynamodb",
+				"--registry-cache-max-staleness=10m",
+				"--registry-cache-zone=example.org",
+				"--registry-cache-zone=example.com",
 				"--txt-owner-id=owner-1",
+				"--cutover-from-owner-id=owner-0",
+				"--cutover-start=2025-01-01T00:00:00Z",
+				"--cutover-schedule=0s=10,1h=100",
+				"--transfer-ownership-to=team-b",
+				"--transfer-ownership-name=api.example.org",
+				"--transfer-ownership-name=www.example.org",
 				"--txt-prefix=associated-txt-record",
 				"--txt-cache-interval=12h",
 				"--txt-new-format-only",
 				"--dynamodb-table=custom-table",
+				"--dynamodb-create-table",
+				"--dynamodb-item-ttl=24h",
+				"--dynamodb-replica-region=us-east-1",
+				"--dynamodb-replica-region=eu-west-1",
 				"--interval=10m",
 				"--min-event-sync-interval=50s",
+				"--min-ttl=30s",
+				"--max-ttl=1h",
+				"--zone-retry-backoff=30s",
+				"--zone-retry-max-backoff=1h",
+				"--zone-collision-policy=fail",
+				"--zone-dead-letter-threshold=3",
+				"--write-interval=10m",
+				"--write-approval",
+				"--write-approval-threshold=10",
+				"--write-approval-expiry=30m",
+				"--write-approval-namespace=dns",
 				"--once",
+				"--diff-output=json",
+				"--diff-color=never",
+				"--ownership-output=json",
+				"--ownership-namespace=team-a",
+				"--ownership-namespace=team-b",
+				"--ownership-owner=cluster-a",
 				"--dry-run",
 				"--events",
 				"--log-format=json",
 				"--metrics-address=127.0.0.1:9099",
+				"--export-record-metrics",
+				"--feature-gates=SomeFeature=true",
+				"--feature-gates=OtherFeature=false",
+				"--audit-namespace=external-dns",
+				"--audit-ttl=24h",
+				"--lameduck-duration=15s",
 				"--log-level=debug",
 				"--connector-source-server=localhost:8081",
+				"--connector-source-tls",
+				"--connector-source-tls-ca=/path/to/connector-ca.pem",
+				"--connector-source-tls-client-cert=/path/to/connector-cert.pem",
+				"--connector-source-tls-client-key=/path/to/connector-key.pem",
+				"--connector-source-token-file=/path/to/connector-token",
+				"--connector-source-protocol=2",
+				"--connector-source-max-size=1048576",
+				"--connector-source-retries=5",
+				"--prometheus-sd-url=http://sd1.example.org/targets",
+				"--prometheus-sd-url=http://sd2.example.org/targets",
+				"--prometheus-sd-file=/etc/sd/targets.json",
+				"--prometheus-sd-relabel-config=/etc/sd/relabel.yaml",
 				"--exoscale-apienv=api1",
 				"--exoscale-apizone=zone1",
 				"--exoscale-apikey=1",
@@ -388,7 +655,17 @@ func TestParseFlags(t *testing.T) {
 				"--managed-record-types=AAAA",
 				"--managed-record-types=CNAME",
 				"--managed-record-types=NS",
+				"--zone-apex=example.org",
+				"--zone-apex=example.com",
+				"--pinned-record=api.example.org",
+				"--pinned-record=www.example.org",
+				"--overrides-configmap=external-dns/overrides",
+				"--deletion-delay-cycles=3",
+				"--finalizer=namespace",
+				"--finalizer=dnsendpoint",
+				"--finalizer-name=external-dns.alpha.kubernetes.io/records-blue",
 				"--no-exclude-unschedulable",
+				"--validate-hostnames",
 				"--rfc2136-batch-change-size=100",
 				"--rfc2136-load-balancing-strategy=round-robin",
 				"--rfc2136-host=rfc2136-host1",
@@ -397,6 +674,11 @@ func TestParseFlags(t *testing.T) {
 				"--ibmcloud-config-file=ibmcloud.json",
 				"--tencent-cloud-config-file=tencent-cloud.json",
 				"--tencent-cloud-zone-type=private",
+				"--alibaba-cloud-manage-vpc-associations",
+				"--yandex-folder-id=b1g0000000000000000",
+				"--yandex-auth-key-file=yandex-key.json",
+				"--yandex-zone-visibility=private",
+				"--rest-mapping-file=rest-mapping.yaml",
 			},
 			envVars:  map[string]string{},
 			expected: overriddenConfig,
@@ -412,6 +694,13 @@ func TestParseFlags(t *testing.T) {
 				"EXTERNAL_DNS_GLOO_NAMESPACE":                                    "gloo-not-system\ngloo-second-system",
 				"EXTERNAL_DNS_SKIPPER_ROUTEGROUP_GROUPVERSION":                   "zalando.org/v2",
 				"EXTERNAL_DNS_SOURCE":                                            "service\ningress\nconnector",
+				"EXTERNAL_DNS_SOURCE_TIMEOUT":                                    "10s",
+				"EXTERNAL_DNS_SOURCE_FAILURE_POLICY":                             "skip",
+				"EXTERNAL_DNS_CHAOS_FLAP_DOMAIN":                                 "chaos.example.org",
+				"EXTERNAL_DNS_CHAOS_FLAP_RATE":                                   "5",
+				"EXTERNAL_DNS_SHARD_COUNT":                                       "4",
+				"EXTERNAL_DNS_SHARD_INDEX":                                       "2",
+				"EXTERNAL_DNS_GATEWAY_ROUTE_EVENTS":                              "1",
 				"EXTERNAL_DNS_NAMESPACE":                                         "namespace",
 				"EXTERNAL_DNS_FQDN_TEMPLATE":                                     "{{.Name}}.service.example.com",
 				"EXTERNAL_DNS_IGNORE_NON_HOST_NETWORK_PODS":                      "0",
@@ -421,18 +710,23 @@ func TestParseFlags(t *testing.T) {
 				"EXTERNAL_DNS_COMPATIBILITY":                                     "mate",
 				"EXTERNAL_DNS_PROVIDER":                                          "google",
 				"EXTERNAL_DNS_GOOGLE_PROJECT":                                    "project",
+				"EXTERNAL_DNS_GOOGLE_ADDITIONAL_PROJECT":                         "other\nanother=/creds.json",
 				"EXTERNAL_DNS_GOOGLE_BATCH_CHANGE_SIZE":                          "100",
 				"EXTERNAL_DNS_GOOGLE_BATCH_CHANGE_INTERVAL":                      "2s",
 				"EXTERNAL_DNS_GOOGLE_ZONE_VISIBILITY":                            "private",
 				"EXTERNAL_DNS_AZURE_CONFIG_FILE":                                 "azure.json",
 				"EXTERNAL_DNS_AZURE_RESOURCE_GROUP":                              "arg",
 				"EXTERNAL_DNS_AZURE_SUBSCRIPTION_ID":                             "arg",
+				"EXTERNAL_DNS_AZURE_RESOURCE_GRAPH_DISCOVERY":                    "1",
+				"EXTERNAL_DNS_AZURE_DISCOVERY_SUBSCRIPTION_ID":                   "sub1\nsub2",
 				"EXTERNAL_DNS_CLOUDFLARE_PROXIED":                                "1",
+				"EXTERNAL_DNS_CLOUDFLARE_PROXIED_DRIFT":                          "keep",
 				"EXTERNAL_DNS_CLOUDFLARE_CUSTOM_HOSTNAMES":                       "1",
 				"EXTERNAL_DNS_CLOUDFLARE_CUSTOM_HOSTNAMES_MIN_TLS_VERSION":       "1.3",
 				"EXTERNAL_DNS_CLOUDFLARE_CUSTOM_HOSTNAMES_CERTIFICATE_AUTHORITY": "google",
 				"EXTERNAL_DNS_CLOUDFLARE_DNS_RECORDS_PER_PAGE":                   "5000",
 				"EXTERNAL_DNS_CLOUDFLARE_REGION_KEY":                             "us",
+				"EXTERNAL_DNS_CLOUDFLARE_RECORD_COMMENT_LABELS":                  "1",
 				"EXTERNAL_DNS_COREDNS_PREFIX":                                    "/coredns/",
 				"EXTERNAL_DNS_AKAMAI_SERVICECONSUMERDOMAIN":                      "oooo-xxxxxxxxxxxxxxxx-xxxxxxxxxxxxxxxx.luna.akamaiapis.net",
 				"EXTERNAL_DNS_AKAMAI_CLIENT_TOKEN":                               "o184671d5307a388180fbf7f11dbdf46",
@@ -445,14 +739,60 @@ func TestParseFlags(t *testing.T) {
 				"EXTERNAL_DNS_OCI_ZONES_CACHE_DURATION":                          "30s",
 				"EXTERNAL_DNS_INMEMORY_ZONE":                                     "example.org\ncompany.com",
 				"EXTERNAL_DNS_OVH_ENDPOINT":                                      "ovh-ca",
+				"EXTERNAL_DNS_OVH_AUTH_METHOD":                                   "oauth2",
+				"EXTERNAL_DNS_OVH_CREDENTIALS_FILE":                              "/etc/ovh/credentials.conf",
 				"EXTERNAL_DNS_OVH_API_RATE_LIMIT":                                "42",
+				"EXTERNAL_DNS_OVH_RECORDS_FETCH_MODE":                            "batch",
+				"EXTERNAL_DNS_OVH_DEFAULT_TTL":                                   "300",
+				"EXTERNAL_DNS_OVH_API_MAX_ATTEMPTS":                              "5",
+				"EXTERNAL_DNS_OVH_API_RETRY_BACKOFF":                             "2s",
+				"EXTERNAL_DNS_OVH_CACHE_PERSISTENCE":                             "configmap:external-dns/ovh-cache",
+				"EXTERNAL_DNS_OVH_CACHE":                                         "0",
+				"EXTERNAL_DNS_OVH_CACHE_TTL":                                     "1h",
+				"EXTERNAL_DNS_OVH_CACHE_EXCLUDE_ZONE":                            "example.org\nexample.com",
+				"EXTERNAL_DNS_OVH_SOA_RESOLVER":                                  "1.1.1.1",
+				"EXTERNAL_DNS_OVH_SOA_TRANSPORT":                                 "dot",
+				"EXTERNAL_DNS_OVH_SOA_TIMEOUT":                                   "5s",
+				"EXTERNAL_DNS_OVH_SKIP_FAILED_ZONES":                             "1",
+				"EXTERNAL_DNS_OVH_DNSSEC_ENABLE_ZONE":                            "example.org",
+				"EXTERNAL_DNS_OVH_DNSSEC_DISABLE_ZONE":                           "example.com",
+				"EXTERNAL_DNS_OVH_CREATE_ZONES":                                  "1",
+				"EXTERNAL_DNS_OVH_CHECK_ZONE_SERIALS":                            "1",
+				"EXTERNAL_DNS_PROVIDER_BATCH_SIZE":                               "100",
+				"EXTERNAL_DNS_HTTP_CLIENT_TIMEOUT":                               "20s",
+				"EXTERNAL_DNS_HTTP_PROXY":                                        "http://proxy.example.org:3128",
+				"EXTERNAL_DNS_HTTP_CA_FILE":                                      "/etc/ssl/http-ca.pem",
+				"EXTERNAL_DNS_HTTP_CLIENT_CERT_FILE":                             "/etc/ssl/http-client.pem",
+				"EXTERNAL_DNS_HTTP_CLIENT_KEY_FILE":                              "/etc/ssl/http-client-key.pem",
+				"EXTERNAL_DNS_HTTP_USER_AGENT":                                   "external-dns-test",
+				"EXTERNAL_DNS_DNS_RESOLVER":                                      "https://dns.example.org/dns-query",
+				"EXTERNAL_DNS_DNS_RESOLVER_TIMEOUT":                              "3s",
+				"EXTERNAL_DNS_SECRET":                                            "CF_API_TOKEN=vault:secret/data/cloudflare#token\nOVH_CONSUMER_KEY=file:/var/run/secrets/ovh/consumer-key",
+				"EXTERNAL_DNS_SECRET_REFRESH_INTERVAL":                           "1m",
+				"EXTERNAL_DNS_WEBHOOK_SERVER_ADDRESS":                            "0.0.0.0:8888",
+				"EXTERNAL_DNS_WEBHOOK_SIDECAR_COMMAND":                           "/usr/local/bin/external-dns-ovh-webhook",
+				"EXTERNAL_DNS_WEBHOOK_SIDECAR_ARG":                               "--port=8888\n--log-level=debug",
+				"EXTERNAL_DNS_WEBHOOK_SIDECAR_HEALTH_URL":                        "http://localhost:8080/healthz",
+				"EXTERNAL_DNS_WEBHOOK_SIDECAR_BACKOFF":                           "2s",
+				"EXTERNAL_DNS_WEBHOOK_SIDECAR_MAX_BACKOFF":                       "30s",
+				"EXTERNAL_DNS_WEBHOOK_SIDECAR_STARTUP_TIMEOUT":                   "2m",
 				"EXTERNAL_DNS_POD_SOURCE_DOMAIN":                                 "example.org",
 				"EXTERNAL_DNS_DOMAIN_FILTER":                                     "example.org\ncompany.com",
+				"EXTERNAL_DNS_DOMAIN_REWRITE":                                    "cluster.local=example.org",
+				"EXTERNAL_DNS_PREVIEW_NAMESPACE_LABEL":                           "preview",
+				"EXTERNAL_DNS_PREVIEW_DOMAIN":                                    "preview.example.org",
+				"EXTERNAL_DNS_PREVIEW_MAX_LIFETIME":                              "72h",
+				"EXTERNAL_DNS_ENDPOINT_ADJUSTER":                                 "idn-normalize\nwebhook",
+				"EXTERNAL_DNS_ENDPOINT_ADJUSTER_WEBHOOK_URL":                     "http://localhost:8889",
+				"EXTERNAL_DNS_ENDPOINT_ADJUSTER_WEBHOOK_TIMEOUT":                 "5s",
+				"EXTERNAL_DNS_ENDPOINT_ADJUSTER_WEBHOOK_FAILURE_POLICY":          "skip",
 				"EXTERNAL_DNS_EXCLUDE_DOMAINS":                                   "xapi.example.org\nxapi.company.com",
 				"EXTERNAL_DNS_REGEX_DOMAIN_FILTER":                               "(example\\.org|company\\.com)$",
 				"EXTERNAL_DNS_REGEX_DOMAIN_EXCLUSION":                            "xapi\\.(example\\.org|company\\.com)$",
 				"EXTERNAL_DNS_TARGET_NET_FILTER":                                 "10.0.0.0/9\n10.1.0.0/9",
 				"EXTERNAL_DNS_EXCLUDE_TARGET_NET":                                "1.0.0.0/9\n1.1.0.0/9",
+				"EXTERNAL_DNS_EXCLUDE_NODE_TAINT":                                "example.com/draining\nnode.kubernetes.io/out-of-service",
+				"EXTERNAL_DNS_EXCLUDE_NOT_READY_NODES_AFTER":                     "5m",
 				"EXTERNAL_DNS_PDNS_SERVER":                                       "http://ns.example.com:8081",
 				"EXTERNAL_DNS_PDNS_ID":                                           "localhost",
 				"EXTERNAL_DNS_PDNS_API_KEY":                                      "some-secret-key",
@@ -480,22 +820,66 @@ func TestParseFlags(t *testing.T) {
 				"EXTERNAL_DNS_AWS_SD_SERVICE_CLEANUP":                            "true",
 				"EXTERNAL_DNS_AWS_SD_CREATE_TAG":                                 "key1=value1\nkey2=value2",
 				"EXTERNAL_DNS_DYNAMODB_TABLE":                                    "custom-table",
+				"EXTERNAL_DNS_DYNAMODB_CREATE_TABLE":                             "1",
+				"EXTERNAL_DNS_DYNAMODB_ITEM_TTL":                                 "24h",
+				"EXTERNAL_DNS_DYNAMODB_REPLICA_REGION":                           "us-east-1\neu-west-1",
 				"EXTERNAL_DNS_PIHOLE_API_VERSION":                                "6",
+				"EXTERNAL_DNS_HURRICANE_ELECTRIC_STATE":                          "configmap:external-dns/he-state",
 				"EXTERNAL_DNS_POLICY":                                            "upsert-only",
 				"EXTERNAL_DNS_REGISTRY":                                          "noop",
+				"EXTERNAL_DNS_SECONDARY_REGISTRY":                                "dynamodb",
+				"EXTERNAL_DNS_REGISTRY_CACHE_MAX_STALENESS":                      "10m",
+				"EXTERNAL_DNS_REGISTRY_CACHE_ZONE":                               "example.org\nexample.com",
 				"EXTERNAL_DNS_TXT_OWNER_ID":                                      "owner-1",
+				"EXTERNAL_DNS_CUTOVER_FROM_OWNER_ID":                             "owner-0",
+				"EXTERNAL_DNS_CUTOVER_START":                                     "2025-01-01T00:00:00Z",
+				"EXTERNAL_DNS_CUTOVER_SCHEDULE":                                  "0s=10,1h=100",
+				"EXTERNAL_DNS_TRANSFER_OWNERSHIP_TO":                             "team-b",
+				"EXTERNAL_DNS_TRANSFER_OWNERSHIP_NAME":                           "api.example.org\nwww.example.org",
 				"EXTERNAL_DNS_TXT_PREFIX":                                        "associated-txt-record",
 				"EXTERNAL_DNS_TXT_CACHE_INTERVAL":                                "12h",
 				"EXTERNAL_DNS_TXT_NEW_FORMAT_ONLY":                               "1",
 				"EXTERNAL_DNS_INTERVAL":                                          "10m",
 				"EXTERNAL_DNS_MIN_EVENT_SYNC_INTERVAL":                           "50s",
+				"EXTERNAL_DNS_MIN_TTL":                                           "30s",
+				"EXTERNAL_DNS_MAX_TTL":                                           "1h",
+				"EXTERNAL_DNS_ZONE_RETRY_BACKOFF":                                "30s",
+				"EXTERNAL_DNS_ZONE_RETRY_MAX_BACKOFF":                            "1h",
+				"EXTERNAL_DNS_ZONE_COLLISION_POLICY":                             "fail",
+				"EXTERNAL_DNS_ZONE_DEAD_LETTER_THRESHOLD":                        "3",
+				"EXTERNAL_DNS_WRITE_INTERVAL":                                    "10m",
+				"EXTERNAL_DNS_WRITE_APPROVAL":                                    "1",
+				"EXTERNAL_DNS_WRITE_APPROVAL_THRESHOLD":                          "10",
+				"EXTERNAL_DNS_WRITE_APPROVAL_EXPIRY":                             "30m",
+				"EXTERNAL_DNS_WRITE_APPROVAL_NAMESPACE":                          "dns",
 				"EXTERNAL_DNS_ONCE":                                              "1",
+				"EXTERNAL_DNS_DIFF_OUTPUT":                                       "json",
+				"EXTERNAL_DNS_DIFF_COLOR":                                        "never",
+				"EXTERNAL_DNS_OWNERSHIP_OUTPUT":                                  "json",
+				"EXTERNAL_DNS_OWNERSHIP_NAMESPACE":                               "team-a\nteam-b",
+				"EXTERNAL_DNS_OWNERSHIP_OWNER":                                   "cluster-a",
 				"EXTERNAL_DNS_DRY_RUN":                                           "1",
 				"EXTERNAL_DNS_EVENTS":                                            "1",
 				"EXTERNAL_DNS_LOG_FORMAT":                                        "json",
 				"EXTERNAL_DNS_METRICS_ADDRESS":                                   "127.0.0.1:9099",
+				"EXTERNAL_DNS_EXPORT_RECORD_METRICS":                             "1",
+				"EXTERNAL_DNS_FEATURE_GATES":                                     "SomeFeature=true\nOtherFeature=false",
+				"EXTERNAL_DNS_AUDIT_NAMESPACE":                                   "external-dns",
+				"EXTERNAL_DNS_AUDIT_TTL":                                         "24h",
+				"EXTERNAL_DNS_LAMEDUCK_DURATION":                                 "15s",
 				"EXTERNAL_DNS_LOG_LEVEL":                                         "debug",
 				"EXTERNAL_DNS_CONNECTOR_SOURCE_SERVER":                           "localhost:8081",
+				"EXTERNAL_DNS_CONNECTOR_SOURCE_TLS":                              "1",
+				"EXTERNAL_DNS_CONNECTOR_SOURCE_TLS_CA":                           "/path/to/connector-ca.pem",
+				"EXTERNAL_DNS_CONNECTOR_SOURCE_TLS_CLIENT_CERT":                  "/path/to/connector-cert.pem",
+				"EXTERNAL_DNS_CONNECTOR_SOURCE_TLS_CLIENT_KEY":                   "/path/to/connector-key.pem",
+				"EXTERNAL_DNS_CONNECTOR_SOURCE_TOKEN_FILE":                       "/path/to/connector-token",
+				"EXTERNAL_DNS_CONNECTOR_SOURCE_PROTOCOL":                         "2",
+				"EXTERNAL_DNS_CONNECTOR_SOURCE_MAX_SIZE":                         "1048576",
+				"EXTERNAL_DNS_CONNECTOR_SOURCE_RETRIES":                          "5",
+				"EXTERNAL_DNS_PROMETHEUS_SD_URL":                                 "http://sd1.example.org/targets\nhttp://sd2.example.org/targets",
+				"EXTERNAL_DNS_PROMETHEUS_SD_FILE":                                "/etc/sd/targets.json",
+				"EXTERNAL_DNS_PROMETHEUS_SD_RELABEL_CONFIG":                      "/etc/sd/relabel.yaml",
 				"EXTERNAL_DNS_EXOSCALE_APIENV":                                   "api1",
 				"EXTERNAL_DNS_EXOSCALE_APIZONE":                                  "zone1",
 				"EXTERNAL_DNS_EXOSCALE_APIKEY":                                   "1",
@@ -508,7 +892,14 @@ func TestParseFlags(t *testing.T) {
 				"EXTERNAL_DNS_TRANSIP_KEYFILE":                                   "/path/to/transip.key",
 				"EXTERNAL_DNS_DIGITALOCEAN_API_PAGE_SIZE":                        "100",
 				"EXTERNAL_DNS_MANAGED_RECORD_TYPES":                              "A\nAAAA\nCNAME\nNS",
+				"EXTERNAL_DNS_ZONE_APEX":                                         "example.org\nexample.com",
+				"EXTERNAL_DNS_PINNED_RECORD":                                     "api.example.org\nwww.example.org",
+				"EXTERNAL_DNS_OVERRIDES_CONFIGMAP":                               "external-dns/overrides",
+				"EXTERNAL_DNS_DELETION_DELAY_CYCLES":                             "3",
+				"EXTERNAL_DNS_FINALIZER":                                         "namespace\ndnsendpoint",
+				"EXTERNAL_DNS_FINALIZER_NAME":                                    "external-dns.alpha.kubernetes.io/records-blue",
 				"EXTERNAL_DNS_EXCLUDE_UNSCHEDULABLE":                             "false",
+				"EXTERNAL_DNS_VALIDATE_HOSTNAMES":                                "true",
 				"EXTERNAL_DNS_RFC2136_BATCH_CHANGE_SIZE":                         "100",
 				"EXTERNAL_DNS_RFC2136_LOAD_BALANCING_STRATEGY":                   "round-robin",
 				"EXTERNAL_DNS_RFC2136_HOST":                                      "rfc2136-host1\nrfc2136-host2",
@@ -516,6 +907,11 @@ func TestParseFlags(t *testing.T) {
 				"EXTERNAL_DNS_IBMCLOUD_CONFIG_FILE":                              "ibmcloud.json",
 				"EXTERNAL_DNS_TENCENT_CLOUD_CONFIG_FILE":                         "tencent-cloud.json",
 				"EXTERNAL_DNS_TENCENT_CLOUD_ZONE_TYPE":                           "private",
+				"EXTERNAL_DNS_ALIBABA_CLOUD_MANAGE_VPC_ASSOCIATIONS":             "1",
+				"EXTERNAL_DNS_YANDEX_FOLDER_ID":                                  "b1g0000000000000000",
+				"EXTERNAL_DNS_YANDEX_AUTH_KEY_FILE":                              "yandex-key.json",
+				"EXTERNAL_DNS_YANDEX_ZONE_VISIBILITY":                            "private",
+				"EXTERNAL_DNS_REST_MAPPING_FILE":                                 "rest-mapping.yaml",
 			},
 			expected: overriddenConfig,
 		},
@@ -561,3 +957,30 @@ func TestPasswordsNotLogged(t *testing.T) {
 	assert.False(t, strings.Contains(s, "pdns-api-key"))
 	assert.False(t, strings.Contains(s, "tsig-secret"))
 }
+
+func TestParseFlagsCommand(t *testing.T) {
+	cfg := NewConfig()
+	require.NoError(t, cfg.ParseFlags([]string{"--source=service", "--provider=google"}))
+	assert.Equal(t, "run", cfg.Command)
+
+	cfg = NewConfig()
+	require.NoError(t, cfg.ParseFlags([]string{"diff", "--source=service", "--provider=google", "--diff-output=json"}))
+	assert.Equal(t, "diff", cfg.Command)
+	assert.Equal(t, "json", cfg.DiffOutput)
+
+	cfg = NewConfig()
+	require.NoError(t, cfg.ParseFlags([]string{"--source=service", "--provider=google", "diff"}))
+	assert.Equal(t, "diff", cfg.Command)
+
+	cfg = NewConfig()
+	require.NoError(t, cfg.ParseFlags([]string{"ownership", "--source=service", "--provider=google", "--ownership-namespace=team-a"}))
+	assert.Equal(t, "ownership", cfg.Command)
+	assert.Equal(t, []string{"team-a"}, cfg.OwnershipNamespaces)
+
+	cfg = NewConfig()
+	require.NoError(t, cfg.ParseFlags([]string{"bootstrap", "--source=service", "--provider=google", "--bootstrap-yes"}))
+	assert.Equal(t, "bootstrap", cfg.Command)
+	assert.True(t, cfg.BootstrapYes)
+
+	assert.Error(t, NewConfig().ParseFlags([]string{"apply", "--source=service", "--provider=google"}))
+}
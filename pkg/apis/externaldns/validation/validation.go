@@ -19,12 +19,31 @@ package validation
 import (
 	"errors"
 	"fmt"
+	"net/url"
+	"slices"
+	"strings"
+	"time"
 
 	"k8s.io/apimachinery/pkg/labels"
+	k8svalidation "k8s.io/apimachinery/pkg/util/validation"
 
+	"sigs.k8s.io/external-dns/endpoint"
 	"sigs.k8s.io/external-dns/pkg/apis/externaldns"
+	"sigs.k8s.io/external-dns/pkg/featuregate"
+	"sigs.k8s.io/external-dns/pkg/secrets"
 )
 
+// txtAffixVariables removes the variables supported in TXT registry prefixes and suffixes.
+var txtAffixVariables = strings.NewReplacer("%{record_type}", "", "%{zone}", "", "%{owner}", "")
+
+// zoneCollisionProviders are the providers finding the zones of hostnames among zones told apart by
+// their IDs, which resolve the ambiguous matches with --zone-collision-policy.
+var zoneCollisionProviders = []string{
+	"akamai", "azure", "azure-dns", "azure-private-dns", "civo", "cloudflare", "constellix", "digitalocean",
+	"gandi", "google", "ibmcloud", "ionoscloud", "linode", "mythicbeasts", "njalla", "ns1", "oci", "ovh",
+	"rest", "scaleway", "tencentcloud", "transip", "ultradns", "yandex",
+}
+
 // ValidateConfig performs validation on the Config object
 func ValidateConfig(cfg *externaldns.Config) error {
 	// TODO: Should probably return field.ErrorList
@@ -81,6 +100,225 @@ func ValidateConfig(cfg *externaldns.Config) error {
 		}
 	}
 
+	for _, rule := range cfg.DomainRewrites {
+		if from, to, ok := strings.Cut(rule, "="); !ok || strings.Trim(from, "*.") == "" || strings.Trim(to, "*.") == "" {
+			return fmt.Errorf("invalid --domain-rewrite %q, expected from=to", rule)
+		}
+	}
+
+	if (cfg.PreviewNamespaceLabel == "") != (cfg.PreviewDomain == "") {
+		return errors.New("--preview-namespace-label and --preview-domain must be set together")
+	}
+	if cfg.PreviewMaxLifetime < 0 {
+		return errors.New("--preview-max-lifetime must not be negative")
+	}
+	if len(cfg.Finalizers) > 0 {
+		if cfg.Registry == "noop" {
+			return errors.New("--finalizer needs a registry keeping the resources of records, not the noop registry")
+		}
+		if errs := k8svalidation.IsQualifiedName(cfg.FinalizerName); len(errs) > 0 {
+			return fmt.Errorf("invalid --finalizer-name %q: %s", cfg.FinalizerName, strings.Join(errs, ", "))
+		}
+	}
+
+	for _, filter := range cfg.TargetNetFilter {
+		if _, err := endpoint.ParseTargetNet(filter); err != nil {
+			return fmt.Errorf("--target-net-filter: %w", err)
+		}
+	}
+	for _, filter := range cfg.ExcludeTargetNets {
+		if _, err := endpoint.ParseTargetNet(filter); err != nil {
+			return fmt.Errorf("--exclude-target-net: %w", err)
+		}
+	}
+
+	seenAdjusters := map[string]bool{}
+	for _, adjuster := range cfg.EndpointAdjusters {
+		if seenAdjusters[adjuster] {
+			return fmt.Errorf("endpoint adjuster %q is specified more than once", adjuster)
+		}
+		seenAdjusters[adjuster] = true
+	}
+	if seenAdjusters["webhook"] && cfg.AdjusterWebhookURL == "" {
+		return errors.New("the webhook endpoint adjuster requires --endpoint-adjuster-webhook-url")
+	}
+
+	if cfg.WriteInterval < 0 {
+		return errors.New("--write-interval cannot be negative")
+	}
+	if cfg.WriteApproval && cfg.Once {
+		return errors.New("--write-approval cannot be used with --once, which exits before changes can be approved")
+	}
+	if cfg.WriteApprovalThreshold < 0 || cfg.WriteApprovalExpiry < 0 {
+		return errors.New("--write-approval-threshold and --write-approval-expiry cannot be negative")
+	}
+	if cfg.WriteApprovalNamespace != "" && !cfg.WriteApproval {
+		return errors.New("--write-approval-namespace requires --write-approval")
+	}
+
+	if _, err := featuregate.Default.Parse(cfg.FeatureGates); err != nil {
+		return fmt.Errorf("invalid --feature-gates: %w", err)
+	}
+
+	if cfg.ProviderBatchSize < 0 {
+		return errors.New("--provider-batch-size cannot be negative")
+	}
+
+	if cfg.ConnectorSourceMaxSize < 0 {
+		return errors.New("--connector-source-max-size cannot be negative")
+	}
+
+	if cfg.ConnectorSourceRetries < 0 {
+		return errors.New("--connector-source-retries cannot be negative")
+	}
+
+	if cfg.ConnectorSourceTokenFile != "" && cfg.ConnectorSourceProtocol < 2 {
+		return errors.New("--connector-source-token-file requires --connector-source-protocol=2")
+	}
+
+	if cfg.AuditTTL < 0 {
+		return errors.New("--audit-ttl cannot be negative")
+	}
+
+	if cfg.ShardCount < 0 {
+		return errors.New("--shard-count cannot be negative")
+	}
+	if cfg.ShardCount <= 1 && cfg.ShardIndex != 0 {
+		return errors.New("--shard-index requires --shard-count greater than 1")
+	}
+	if cfg.ShardIndex < 0 || cfg.ShardCount > 1 && cfg.ShardIndex >= cfg.ShardCount {
+		return fmt.Errorf("--shard-index must be between 0 and %d", cfg.ShardCount-1)
+	}
+
+	if cfg.MinTTL < 0 || cfg.MaxTTL < 0 {
+		return errors.New("--min-ttl and --max-ttl cannot be negative")
+	}
+	if cfg.MaxTTL > 0 && cfg.MaxTTL < cfg.MinTTL {
+		return errors.New("--max-ttl cannot be lower than --min-ttl")
+	}
+
+	if cfg.CutoverFromOwnerID != "" {
+		if cfg.Registry != "txt" {
+			return errors.New("--cutover-from-owner-id requires the txt registry")
+		}
+		if cfg.CutoverFromOwnerID == cfg.TXTOwnerID {
+			return errors.New("--cutover-from-owner-id must differ from --txt-owner-id")
+		}
+		if _, err := time.Parse(time.RFC3339, cfg.CutoverStart); err != nil {
+			return fmt.Errorf("--cutover-start is not a valid RFC 3339 time: %w", err)
+		}
+		if cfg.CutoverSchedule == "" {
+			return errors.New("--cutover-schedule is required when --cutover-from-owner-id is set")
+		}
+	}
+
+	if cfg.SecondaryRegistry != "" {
+		if cfg.Registry != "txt" && cfg.Registry != "dynamodb" {
+			return errors.New("--secondary-registry requires the txt or dynamodb registry")
+		}
+		if cfg.SecondaryRegistry == cfg.Registry {
+			return errors.New("--secondary-registry must differ from --registry")
+		}
+	}
+
+	if cfg.HTTPClientTimeout < 0 {
+		return errors.New("--http-client-timeout cannot be negative")
+	}
+	if cfg.HTTPProxy != "" {
+		if u, err := url.Parse(cfg.HTTPProxy); err != nil || u.Scheme == "" || u.Host == "" {
+			return fmt.Errorf("--http-proxy %q is not a valid URL", cfg.HTTPProxy)
+		}
+	}
+	if (cfg.HTTPClientCertFile == "") != (cfg.HTTPClientKeyFile == "") {
+		return errors.New("--http-client-cert-file and --http-client-key-file must be set together")
+	}
+
+	for _, secret := range cfg.Secrets {
+		name, ref, ok := strings.Cut(secret, "=")
+		if !ok || name == "" {
+			return fmt.Errorf("--secret %q must be NAME=REFERENCE", secret)
+		}
+		if _, err := secrets.ParseReference(ref); err != nil {
+			return fmt.Errorf("--secret %s: %w", name, err)
+		}
+	}
+	if cfg.SecretRefreshInterval < 0 {
+		return errors.New("--secret-refresh-interval cannot be negative")
+	}
+
+	if cfg.OVHDefaultTTL < 0 {
+		return errors.New("--ovh-default-ttl cannot be negative")
+	}
+	if cfg.OVHMaxAttempts < 0 {
+		return errors.New("--ovh-api-max-attempts cannot be negative")
+	}
+	if cfg.OVHRetryBackoff < 0 {
+		return errors.New("--ovh-api-retry-backoff cannot be negative")
+	}
+	if cfg.OVHCachePersistence != "" && !validPersistence(cfg.OVHCachePersistence) {
+		return fmt.Errorf("--ovh-cache-persistence %q must be file:PATH or configmap:NAMESPACE/NAME", cfg.OVHCachePersistence)
+	}
+
+	if cfg.HurricaneElectricState != "" && !validPersistence(cfg.HurricaneElectricState) {
+		return fmt.Errorf("--hurricane-electric-state %q must be file:PATH or configmap:NAMESPACE/NAME", cfg.HurricaneElectricState)
+	}
+
+	if cfg.ZoneCollisionPolicy == "fail" && !slices.Contains(zoneCollisionProviders, cfg.Provider) {
+		return fmt.Errorf("--zone-collision-policy=fail is not supported by --provider=%s", cfg.Provider)
+	}
+
+	if cfg.DeletionDelayCycles < 0 {
+		return errors.New("--deletion-delay-cycles cannot be negative")
+	}
+
+	if cfg.OverridesConfigMap != "" {
+		if namespace, name, ok := strings.Cut(cfg.OverridesConfigMap, "/"); !ok || namespace == "" || name == "" || strings.Contains(name, "/") {
+			return fmt.Errorf("--overrides-configmap %q must be namespace/name", cfg.OverridesConfigMap)
+		}
+	}
+
+	if cfg.WebhookSidecarCommand != "" {
+		if cfg.Provider != "webhook" {
+			return errors.New("--webhook-sidecar-command requires --provider=webhook")
+		}
+		if cfg.WebhookSidecarBackoff <= 0 || cfg.WebhookSidecarMaxBackoff <= 0 || cfg.WebhookSidecarStartupTimeout <= 0 {
+			return errors.New("--webhook-sidecar-backoff, --webhook-sidecar-max-backoff and --webhook-sidecar-startup-timeout must be positive")
+		}
+	}
+
+	if cfg.RegistryCacheMaxStaleness < 0 {
+		return errors.New("--registry-cache-max-staleness cannot be negative")
+	}
+	if len(cfg.RegistryCacheZones) > 0 && cfg.RegistryCacheMaxStaleness == 0 {
+		return errors.New("--registry-cache-zone requires --registry-cache-max-staleness")
+	}
+
+	if cfg.AWSDynamoDBItemTTL < 0 {
+		return errors.New("--dynamodb-item-ttl cannot be negative")
+	}
+	// The records are refreshed once half of their TTL has elapsed, at the next synchronization reading them.
+	if cfg.AWSDynamoDBItemTTL > 0 && (cfg.AWSDynamoDBItemTTL < 2*cfg.Interval || cfg.AWSDynamoDBItemTTL < 2*cfg.TXTCacheInterval) {
+		return errors.New("--dynamodb-item-ttl must be at least twice --interval and --txt-cache-interval")
+	}
+
+	if cfg.ChaosFlapDomain != "" && cfg.ChaosFlapRate <= 0 {
+		return errors.New("--chaos-flap-rate must be positive")
+	}
+
+	if cfg.TransferOwnershipTo != "" {
+		if cfg.Registry != "txt" {
+			return errors.New("--transfer-ownership-to requires the txt registry")
+		}
+		if cfg.TransferOwnershipTo == cfg.TXTOwnerID {
+			return errors.New("--transfer-ownership-to must differ from --txt-owner-id")
+		}
+		if len(cfg.TransferOwnershipNames) == 0 {
+			return errors.New("--transfer-ownership-name is required when --transfer-ownership-to is set")
+		}
+	} else if len(cfg.TransferOwnershipNames) > 0 {
+		return errors.New("--transfer-ownership-name requires --transfer-ownership-to")
+	}
+
 	if cfg.IgnoreHostnameAnnotation && cfg.FQDNTemplate == "" {
 		return errors.New("FQDN Template must be set if ignoring annotations")
 	}
@@ -88,6 +326,11 @@ func ValidateConfig(cfg *externaldns.Config) error {
 	if len(cfg.TXTPrefix) > 0 && len(cfg.TXTSuffix) > 0 {
 		return errors.New("txt-prefix and txt-suffix are mutual exclusive")
 	}
+	for flag, affix := range map[string]string{"--txt-prefix": cfg.TXTPrefix, "--txt-suffix": cfg.TXTSuffix} {
+		if strings.Contains(txtAffixVariables.Replace(affix), "%{") {
+			return fmt.Errorf("%s %q contains an unknown variable, supported variables are %%{record_type}, %%{zone} and %%{owner}", flag, affix)
+		}
+	}
 
 	_, err := labels.Parse(cfg.LabelFilter)
 	if err != nil {
@@ -95,3 +338,17 @@ func ValidateConfig(cfg *externaldns.Config) error {
 	}
 	return nil
 }
+
+// validPersistence tells whether persistence is file:PATH or configmap:NAMESPACE/NAME.
+func validPersistence(persistence string) bool {
+	kind, ref, _ := strings.Cut(persistence, ":")
+	namespace, name, isNamespaced := strings.Cut(ref, "/")
+	switch {
+	case kind == "file" && ref != "":
+		return true
+	case kind == "configmap" && isNamespaced && namespace != "" && name != "" && !strings.Contains(name, "/"):
+		return true
+	default:
+		return false
+	}
+}
@@ -17,7 +17,9 @@ limitations under the License.
 package validation
 
 import (
+	"fmt"
 	"testing"
+	"time"
 
 	"sigs.k8s.io/external-dns/pkg/apis/externaldns"
 
@@ -72,6 +74,461 @@ func TestValidateBadIgnoreHostnameAnnotationsConfig(t *testing.T) {
 	assert.Error(t, ValidateConfig(cfg))
 }
 
+func TestValidateTTLBounds(t *testing.T) {
+	for _, tt := range []struct {
+		minTTL, maxTTL time.Duration
+		err            string
+	}{
+		{minTTL: 0, maxTTL: 0},
+		{minTTL: time.Minute, maxTTL: 0},
+		{minTTL: 0, maxTTL: time.Hour},
+		{minTTL: time.Minute, maxTTL: time.Minute},
+		{minTTL: -time.Minute, maxTTL: 0, err: "--min-ttl and --max-ttl cannot be negative"},
+		{minTTL: 0, maxTTL: -time.Hour, err: "--min-ttl and --max-ttl cannot be negative"},
+		{minTTL: time.Hour, maxTTL: time.Minute, err: "--max-ttl cannot be lower than --min-ttl"},
+	} {
+		cfg := newValidConfig(t)
+		cfg.MinTTL = tt.minTTL
+		cfg.MaxTTL = tt.maxTTL
+
+		err := ValidateConfig(cfg)
+		if tt.err == "" {
+			assert.NoError(t, err)
+		} else {
+			assert.EqualError(t, err, tt.err)
+		}
+	}
+}
+
+func TestValidateShards(t *testing.T) {
+	for _, tt := range []struct {
+		count, index int
+		err          string
+	}{
+		{count: 1, index: 0},
+		{count: 0, index: 0},
+		{count: 4, index: 3},
+		{count: -1, index: 0, err: "--shard-count cannot be negative"},
+		{count: 1, index: 1, err: "--shard-index requires --shard-count greater than 1"},
+		{count: 4, index: 4, err: "--shard-index must be between 0 and 3"},
+		{count: 4, index: -1, err: "--shard-index must be between 0 and 3"},
+	} {
+		cfg := newValidConfig(t)
+		cfg.ShardCount = tt.count
+		cfg.ShardIndex = tt.index
+
+		err := ValidateConfig(cfg)
+		if tt.err == "" {
+			assert.NoError(t, err)
+		} else {
+			assert.EqualError(t, err, tt.err)
+		}
+	}
+}
+
+func TestValidateAuditTTL(t *testing.T) {
+	cfg := newValidConfig(t)
+	cfg.AuditTTL = -time.Hour
+
+	assert.EqualError(t, ValidateConfig(cfg), "--audit-ttl cannot be negative")
+}
+
+func TestValidateFeatureGates(t *testing.T) {
+	cfg := newValidConfig(t)
+	cfg.FeatureGates = []string{"UnknownFeature=true"}
+
+	assert.EqualError(t, ValidateConfig(cfg), `invalid --feature-gates: unknown feature "UnknownFeature"`)
+}
+
+func TestValidateProviderBatchSize(t *testing.T) {
+	cfg := newValidConfig(t)
+	cfg.ProviderBatchSize = -1
+
+	assert.EqualError(t, ValidateConfig(cfg), "--provider-batch-size cannot be negative")
+}
+
+func TestValidateConnectorSource(t *testing.T) {
+	cfg := newValidConfig(t)
+	cfg.ConnectorSourceMaxSize = -1
+	assert.EqualError(t, ValidateConfig(cfg), "--connector-source-max-size cannot be negative")
+
+	cfg = newValidConfig(t)
+	cfg.ConnectorSourceRetries = -1
+	assert.EqualError(t, ValidateConfig(cfg), "--connector-source-retries cannot be negative")
+
+	cfg = newValidConfig(t)
+	cfg.ConnectorSourceTokenFile = "/var/run/secrets/connector/token"
+	cfg.ConnectorSourceProtocol = 1
+	assert.EqualError(t, ValidateConfig(cfg), "--connector-source-token-file requires --connector-source-protocol=2")
+
+	cfg.ConnectorSourceProtocol = 2
+	assert.NoError(t, ValidateConfig(cfg))
+}
+
+func TestValidateSecondaryRegistry(t *testing.T) {
+	cfg := newValidConfig(t)
+	cfg.Registry = "txt"
+	cfg.SecondaryRegistry = "dynamodb"
+	assert.NoError(t, ValidateConfig(cfg))
+
+	cfg.SecondaryRegistry = "txt"
+	assert.EqualError(t, ValidateConfig(cfg), "--secondary-registry must differ from --registry")
+
+	cfg.Registry = "noop"
+	assert.EqualError(t, ValidateConfig(cfg), "--secondary-registry requires the txt or dynamodb registry")
+}
+
+func TestValidateRegistryCache(t *testing.T) {
+	cfg := newValidConfig(t)
+	cfg.RegistryCacheMaxStaleness = -time.Minute
+	assert.EqualError(t, ValidateConfig(cfg), "--registry-cache-max-staleness cannot be negative")
+
+	cfg.RegistryCacheMaxStaleness = 0
+	cfg.RegistryCacheZones = []string{"example.org"}
+	assert.EqualError(t, ValidateConfig(cfg), "--registry-cache-zone requires --registry-cache-max-staleness")
+
+	cfg.RegistryCacheMaxStaleness = 10 * time.Minute
+	assert.NoError(t, ValidateConfig(cfg))
+}
+
+func TestValidateDynamoDBItemTTL(t *testing.T) {
+	cfg := newValidConfig(t)
+	cfg.AWSDynamoDBItemTTL = -time.Hour
+	assert.EqualError(t, ValidateConfig(cfg), "--dynamodb-item-ttl cannot be negative")
+
+	cfg.Interval = time.Minute
+	cfg.AWSDynamoDBItemTTL = time.Minute
+	assert.EqualError(t, ValidateConfig(cfg), "--dynamodb-item-ttl must be at least twice --interval and --txt-cache-interval")
+
+	cfg.AWSDynamoDBItemTTL = 2 * time.Minute
+	assert.NoError(t, ValidateConfig(cfg))
+
+	cfg.TXTCacheInterval = time.Hour
+	assert.EqualError(t, ValidateConfig(cfg), "--dynamodb-item-ttl must be at least twice --interval and --txt-cache-interval")
+}
+
+func TestValidateDomainRewrites(t *testing.T) {
+	for _, tt := range []struct {
+		rules []string
+		err   string
+	}{
+		{rules: []string{"cluster.local=example.com", "*.svc.internal=*.example.org"}},
+		{rules: []string{"cluster.local"}, err: `invalid --domain-rewrite "cluster.local", expected from=to`},
+		{rules: []string{"*.=example.com"}, err: `invalid --domain-rewrite "*.=example.com", expected from=to`},
+		{rules: []string{"cluster.local="}, err: `invalid --domain-rewrite "cluster.local=", expected from=to`},
+	} {
+		cfg := newValidConfig(t)
+		cfg.DomainRewrites = tt.rules
+
+		err := ValidateConfig(cfg)
+		if tt.err == "" {
+			assert.NoError(t, err)
+		} else {
+			assert.EqualError(t, err, tt.err)
+		}
+	}
+}
+
+func TestValidatePreview(t *testing.T) {
+	cfg := newValidConfig(t)
+	cfg.PreviewNamespaceLabel = "preview"
+	cfg.PreviewDomain = "preview.example.com"
+	cfg.PreviewMaxLifetime = 72 * time.Hour
+	assert.NoError(t, ValidateConfig(cfg))
+
+	cfg.PreviewMaxLifetime = -time.Hour
+	assert.EqualError(t, ValidateConfig(cfg), "--preview-max-lifetime must not be negative")
+
+	cfg = newValidConfig(t)
+	cfg.PreviewNamespaceLabel = "preview"
+	assert.EqualError(t, ValidateConfig(cfg), "--preview-namespace-label and --preview-domain must be set together")
+
+	cfg = newValidConfig(t)
+	cfg.PreviewDomain = "preview.example.com"
+	assert.EqualError(t, ValidateConfig(cfg), "--preview-namespace-label and --preview-domain must be set together")
+}
+
+func TestValidateFinalizer(t *testing.T) {
+	cfg := newValidConfig(t)
+	cfg.Finalizers = []string{"namespace"}
+	cfg.FinalizerName = "external-dns.alpha.kubernetes.io/records"
+	assert.NoError(t, ValidateConfig(cfg))
+
+	cfg.FinalizerName = "records/of/external-dns"
+	assert.ErrorContains(t, ValidateConfig(cfg), `invalid --finalizer-name "records/of/external-dns"`)
+
+	cfg.FinalizerName = "external-dns.alpha.kubernetes.io/records"
+	cfg.Registry = "noop"
+	assert.EqualError(t, ValidateConfig(cfg), "--finalizer needs a registry keeping the resources of records, not the noop registry")
+}
+
+func TestValidateTargetNets(t *testing.T) {
+	cfg := newValidConfig(t)
+	cfg.TargetNetFilter = []string{"203.0.113.0/24", "198.51.100.7"}
+	cfg.ExcludeTargetNets = []string{"10.0.0.0/8", "172.16.0.0/12", "192.168.0.0/16"}
+	assert.NoError(t, ValidateConfig(cfg))
+
+	cfg.TargetNetFilter = []string{"203.0.113.0/33"}
+	assert.EqualError(t, ValidateConfig(cfg), `--target-net-filter: invalid target net "203.0.113.0/33", expected a CIDR or an IP address`)
+
+	cfg.TargetNetFilter = nil
+	cfg.ExcludeTargetNets = []string{"private"}
+	assert.EqualError(t, ValidateConfig(cfg), `--exclude-target-net: invalid target net "private", expected a CIDR or an IP address`)
+}
+
+func TestValidateEndpointAdjusters(t *testing.T) {
+	cfg := newValidConfig(t)
+	cfg.EndpointAdjusters = []string{"idn-normalize", "provider", "ttl-clamp"}
+	assert.NoError(t, ValidateConfig(cfg))
+
+	cfg.EndpointAdjusters = []string{"ttl-clamp", "provider", "ttl-clamp"}
+	assert.EqualError(t, ValidateConfig(cfg), `endpoint adjuster "ttl-clamp" is specified more than once`)
+
+	cfg.EndpointAdjusters = []string{"webhook"}
+	assert.EqualError(t, ValidateConfig(cfg), "the webhook endpoint adjuster requires --endpoint-adjuster-webhook-url")
+
+	cfg.AdjusterWebhookURL = "http://localhost:8889"
+	assert.NoError(t, ValidateConfig(cfg))
+}
+
+func TestValidateWriteGate(t *testing.T) {
+	cfg := newValidConfig(t)
+	cfg.WriteInterval = -time.Minute
+	assert.EqualError(t, ValidateConfig(cfg), "--write-interval cannot be negative")
+
+	cfg.WriteInterval = 10 * time.Minute
+	cfg.WriteApproval = true
+	assert.NoError(t, ValidateConfig(cfg))
+
+	cfg.Once = true
+	assert.EqualError(t, ValidateConfig(cfg), "--write-approval cannot be used with --once, which exits before changes can be approved")
+
+	cfg.Once = false
+	cfg.WriteApprovalExpiry = -time.Hour
+	assert.EqualError(t, ValidateConfig(cfg), "--write-approval-threshold and --write-approval-expiry cannot be negative")
+
+	cfg.WriteApprovalExpiry = time.Hour
+	cfg.WriteApprovalNamespace = "dns"
+	assert.NoError(t, ValidateConfig(cfg))
+
+	cfg.WriteApproval = false
+	assert.EqualError(t, ValidateConfig(cfg), "--write-approval-namespace requires --write-approval")
+}
+
+func TestValidateCutover(t *testing.T) {
+	for _, tt := range []struct {
+		title    string
+		registry string
+		from     string
+		start    string
+		schedule string
+		err      string
+	}{
+		{title: "disabled", registry: "txt"},
+		{title: "valid", registry: "txt", from: "blue", start: "2025-01-01T00:00:00Z", schedule: "0s=10,1h=100"},
+		{title: "other registry", registry: "noop", from: "blue", start: "2025-01-01T00:00:00Z", schedule: "0s=100", err: "--cutover-from-owner-id requires the txt registry"},
+		{title: "same owner", registry: "txt", from: "green", start: "2025-01-01T00:00:00Z", schedule: "0s=100", err: "--cutover-from-owner-id must differ from --txt-owner-id"},
+		{title: "missing start", registry: "txt", from: "blue", schedule: "0s=100", err: `--cutover-start is not a valid RFC 3339 time: parsing time "" as "2006-01-02T15:04:05Z07:00": cannot parse "" as "2006"`},
+		{title: "missing schedule", registry: "txt", from: "blue", start: "2025-01-01T00:00:00Z", err: "--cutover-schedule is required when --cutover-from-owner-id is set"},
+	} {
+		t.Run(tt.title, func(t *testing.T) {
+			cfg := newValidConfig(t)
+			cfg.Registry = tt.registry
+			cfg.TXTOwnerID = "green"
+			cfg.CutoverFromOwnerID = tt.from
+			cfg.CutoverStart = tt.start
+			cfg.CutoverSchedule = tt.schedule
+
+			err := ValidateConfig(cfg)
+			if tt.err == "" {
+				assert.NoError(t, err)
+			} else {
+				assert.EqualError(t, err, tt.err)
+			}
+		})
+	}
+}
+
+func TestValidateChaosFlap(t *testing.T) {
+	cfg := newValidConfig(t)
+	cfg.ChaosFlapDomain = "chaos.example.org"
+	cfg.ChaosFlapRate = 10
+	assert.NoError(t, ValidateConfig(cfg))
+
+	cfg.ChaosFlapRate = 0
+	assert.EqualError(t, ValidateConfig(cfg), "--chaos-flap-rate must be positive")
+}
+
+func TestValidateHTTPClient(t *testing.T) {
+	cfg := newValidConfig(t)
+	cfg.HTTPClientTimeout = 30 * time.Second
+	cfg.HTTPProxy = "http://proxy.example.org:3128"
+	cfg.HTTPClientCertFile = "/etc/ssl/client.pem"
+	cfg.HTTPClientKeyFile = "/etc/ssl/client-key.pem"
+	assert.NoError(t, ValidateConfig(cfg))
+
+	cfg.HTTPClientTimeout = -time.Second
+	assert.EqualError(t, ValidateConfig(cfg), "--http-client-timeout cannot be negative")
+	cfg.HTTPClientTimeout = 0
+
+	cfg.HTTPProxy = "proxy.example.org"
+	assert.EqualError(t, ValidateConfig(cfg), `--http-proxy "proxy.example.org" is not a valid URL`)
+	cfg.HTTPProxy = ""
+
+	cfg.HTTPClientKeyFile = ""
+	assert.EqualError(t, ValidateConfig(cfg), "--http-client-cert-file and --http-client-key-file must be set together")
+}
+
+func TestValidateSecrets(t *testing.T) {
+	cfg := newValidConfig(t)
+	cfg.Secrets = []string{"CF_API_TOKEN=vault:secret/data/cloudflare#token", "OVH_CONSUMER_KEY=file:/var/run/secrets/ovh/consumer-key"}
+	assert.NoError(t, ValidateConfig(cfg))
+
+	cfg.Secrets = []string{"CF_API_TOKEN"}
+	assert.EqualError(t, ValidateConfig(cfg), `--secret "CF_API_TOKEN" must be NAME=REFERENCE`)
+
+	cfg.Secrets = []string{"CF_API_TOKEN=consul:cloudflare"}
+	assert.ErrorContains(t, ValidateConfig(cfg), `unsupported scheme "consul"`)
+
+	cfg.Secrets = nil
+	cfg.SecretRefreshInterval = -time.Second
+	assert.EqualError(t, ValidateConfig(cfg), "--secret-refresh-interval cannot be negative")
+}
+
+func TestValidateTXTAffixVariables(t *testing.T) {
+	cfg := newValidConfig(t)
+	cfg.TXTPrefix = "%{zone}-%{owner}-%{record_type}-"
+	assert.NoError(t, ValidateConfig(cfg))
+
+	cfg.TXTPrefix = ""
+	cfg.TXTSuffix = "-%{namespace}"
+	assert.EqualError(t, ValidateConfig(cfg), `--txt-suffix "-%{namespace}" contains an unknown variable, supported variables are %{record_type}, %{zone} and %{owner}`)
+}
+
+func TestValidateOVHDefaultTTL(t *testing.T) {
+	cfg := newValidConfig(t)
+	cfg.OVHDefaultTTL = 300
+	assert.NoError(t, ValidateConfig(cfg))
+
+	cfg.OVHDefaultTTL = -1
+	assert.EqualError(t, ValidateConfig(cfg), "--ovh-default-ttl cannot be negative")
+}
+
+func TestValidateOVHRetries(t *testing.T) {
+	cfg := newValidConfig(t)
+	cfg.OVHMaxAttempts = 5
+	cfg.OVHRetryBackoff = 2 * time.Second
+	assert.NoError(t, ValidateConfig(cfg))
+
+	cfg.OVHMaxAttempts = -1
+	assert.EqualError(t, ValidateConfig(cfg), "--ovh-api-max-attempts cannot be negative")
+
+	cfg.OVHMaxAttempts = 3
+	cfg.OVHRetryBackoff = -time.Second
+	assert.EqualError(t, ValidateConfig(cfg), "--ovh-api-retry-backoff cannot be negative")
+}
+
+func TestValidateOVHCachePersistence(t *testing.T) {
+	cfg := newValidConfig(t)
+	for _, valid := range []string{"file:/var/cache/external-dns/ovh.json.gz", "configmap:external-dns/ovh-cache"} {
+		cfg.OVHCachePersistence = valid
+		assert.NoError(t, ValidateConfig(cfg), valid)
+	}
+	for _, invalid := range []string{"/var/cache/ovh.json.gz", "file:", "configmap:ovh-cache", "configmap:external-dns/", "configmap:a/b/c", "redis:cache"} {
+		cfg.OVHCachePersistence = invalid
+		assert.EqualError(t, ValidateConfig(cfg), fmt.Sprintf("--ovh-cache-persistence %q must be file:PATH or configmap:NAMESPACE/NAME", invalid))
+	}
+}
+
+func TestValidateZoneCollisionPolicy(t *testing.T) {
+	cfg := newValidConfig(t)
+	cfg.Provider = "google"
+	cfg.ZoneCollisionPolicy = "fail"
+	assert.NoError(t, ValidateConfig(cfg))
+
+	cfg.Provider = "aws"
+	assert.EqualError(t, ValidateConfig(cfg), "--zone-collision-policy=fail is not supported by --provider=aws")
+
+	cfg.ZoneCollisionPolicy = "warn"
+	assert.NoError(t, ValidateConfig(cfg))
+}
+
+func TestValidateHurricaneElectricState(t *testing.T) {
+	cfg := newValidConfig(t)
+	cfg.HurricaneElectricState = "configmap:external-dns/he-state"
+	assert.NoError(t, ValidateConfig(cfg))
+
+	cfg.HurricaneElectricState = "he-state.json"
+	assert.EqualError(t, ValidateConfig(cfg), `--hurricane-electric-state "he-state.json" must be file:PATH or configmap:NAMESPACE/NAME`)
+}
+
+func TestValidateDeletionDelayCycles(t *testing.T) {
+	cfg := newValidConfig(t)
+	cfg.DeletionDelayCycles = 3
+	assert.NoError(t, ValidateConfig(cfg))
+
+	cfg.DeletionDelayCycles = -1
+	assert.EqualError(t, ValidateConfig(cfg), "--deletion-delay-cycles cannot be negative")
+}
+
+func TestValidateOverridesConfigMap(t *testing.T) {
+	cfg := newValidConfig(t)
+	cfg.OverridesConfigMap = "external-dns/overrides"
+	assert.NoError(t, ValidateConfig(cfg))
+
+	for _, value := range []string{"overrides", "/overrides", "external-dns/", "a/b/c"} {
+		cfg.OverridesConfigMap = value
+		assert.EqualError(t, ValidateConfig(cfg), fmt.Sprintf("--overrides-configmap %q must be namespace/name", value))
+	}
+}
+
+func TestValidateWebhookSidecar(t *testing.T) {
+	cfg := newValidConfig(t)
+	cfg.WebhookSidecarCommand = "/usr/local/bin/external-dns-ovh-webhook"
+	cfg.WebhookSidecarBackoff = time.Second
+	cfg.WebhookSidecarMaxBackoff = time.Minute
+	cfg.WebhookSidecarStartupTimeout = time.Minute
+	assert.EqualError(t, ValidateConfig(cfg), "--webhook-sidecar-command requires --provider=webhook")
+
+	cfg.Provider = "webhook"
+	assert.NoError(t, ValidateConfig(cfg))
+
+	cfg.WebhookSidecarBackoff = 0
+	assert.EqualError(t, ValidateConfig(cfg), "--webhook-sidecar-backoff, --webhook-sidecar-max-backoff and --webhook-sidecar-startup-timeout must be positive")
+}
+
+func TestValidateTransferOwnership(t *testing.T) {
+	for _, tt := range []struct {
+		title    string
+		registry string
+		to       string
+		names    []string
+		err      string
+	}{
+		{title: "disabled", registry: "txt"},
+		{title: "valid", registry: "txt", to: "blue", names: []string{"www.example.org"}},
+		{title: "other registry", registry: "dynamodb", to: "blue", names: []string{"www.example.org"}, err: "--transfer-ownership-to requires the txt registry"},
+		{title: "same owner", registry: "txt", to: "green", names: []string{"www.example.org"}, err: "--transfer-ownership-to must differ from --txt-owner-id"},
+		{title: "missing names", registry: "txt", to: "blue", err: "--transfer-ownership-name is required when --transfer-ownership-to is set"},
+		{title: "names without owner", registry: "txt", names: []string{"www.example.org"}, err: "--transfer-ownership-name requires --transfer-ownership-to"},
+	} {
+		t.Run(tt.title, func(t *testing.T) {
+			cfg := newValidConfig(t)
+			cfg.Registry = tt.registry
+			cfg.TXTOwnerID = "green"
+			cfg.TransferOwnershipTo = tt.to
+			cfg.TransferOwnershipNames = tt.names
+
+			err := ValidateConfig(cfg)
+			if tt.err == "" {
+				assert.NoError(t, err)
+			} else {
+				assert.EqualError(t, err, tt.err)
+			}
+		})
+	}
+}
+
 func TestValidateBadRfc2136Config(t *testing.T) {
 	cfg := externaldns.NewConfig()
 
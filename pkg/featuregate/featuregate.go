@@ -0,0 +1,153 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package featuregate lets experimental behavior ship disabled, to be enabled per cluster with --feature-gates,
+// in the manner of the feature gates of the Kubernetes components.
+package featuregate
+
+import (
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+
+	log "github.com/sirupsen/logrus"
+)
+
+// Feature is the name of a feature gate.
+type Feature string
+
+// Stage is the maturity of a feature.
+type Stage string
+
+const (
+	// Alpha features are disabled by default, and may change or go away without notice.
+	Alpha = Stage("ALPHA")
+	// Beta features are usually enabled by default, and are not removed without a deprecation.
+	Beta = Stage("BETA")
+	// GA features are always enabled; their gate is kept for a while so that setting it still works.
+	GA = Stage("GA")
+	// Deprecated features are going away.
+	Deprecated = Stage("DEPRECATED")
+)
+
+// FeatureSpec describes a feature.
+type FeatureSpec struct {
+	Default     bool
+	Stage       Stage
+	Description string
+}
+
+// FeatureGate tells which features are enabled.
+type FeatureGate struct {
+	known   map[Feature]FeatureSpec
+	mutex   sync.RWMutex
+	enabled map[Feature]bool
+}
+
+// Default is the feature gate of the known features, set from --feature-gates.
+var Default = New(features)
+
+// New returns a feature gate of the given features, all set to their default.
+func New(known map[Feature]FeatureSpec) *FeatureGate {
+	return &FeatureGate{known: known, enabled: map[Feature]bool{}}
+}
+
+// Enabled tells whether a feature is enabled. It panics on unknown features, which are programming errors.
+func (g *FeatureGate) Enabled(f Feature) bool {
+	spec, ok := g.known[f]
+	if !ok {
+		panic(fmt.Sprintf("feature %q is not registered", f))
+	}
+	g.mutex.RLock()
+	defer g.mutex.RUnlock()
+	if enabled, ok := g.enabled[f]; ok {
+		return enabled
+	}
+	return spec.Default
+}
+
+// Set enables or disables features from key=value pairs, each entry holding one or more pairs
+// separated by commas, e.g. "Foo=true,Bar=false". Features not mentioned keep their current state.
+func (g *FeatureGate) Set(entries []string) error {
+	enabled, err := g.Parse(entries)
+	if err != nil {
+		return err
+	}
+	g.mutex.Lock()
+	defer g.mutex.Unlock()
+	for f, e := range enabled {
+		if spec := g.known[f]; spec.Stage == Deprecated {
+			log.Warnf("Feature %s is deprecated and will be removed in a future release", f)
+		}
+		g.enabled[f] = e
+	}
+	return nil
+}
+
+// Parse parses key=value pairs as Set does, without changing the state of the features.
+func (g *FeatureGate) Parse(entries []string) (map[Feature]bool, error) {
+	enabled := map[Feature]bool{}
+	for _, entry := range entries {
+		for _, pair := range strings.Split(entry, ",") {
+			pair = strings.TrimSpace(pair)
+			if pair == "" {
+				continue
+			}
+			key, value, found := strings.Cut(pair, "=")
+			if !found {
+				return nil, fmt.Errorf("missing value for feature %q, expected %s=true or %s=false", key, key, key)
+			}
+			f := Feature(strings.TrimSpace(key))
+			spec, ok := g.known[f]
+			if !ok {
+				return nil, fmt.Errorf("unknown feature %q", f)
+			}
+			e, err := strconv.ParseBool(strings.TrimSpace(value))
+			if err != nil {
+				return nil, fmt.Errorf("invalid value %q for feature %q: %w", value, f, err)
+			}
+			if spec.Stage == GA && !e {
+				return nil, fmt.Errorf("feature %q is GA and cannot be disabled", f)
+			}
+			enabled[f] = e
+		}
+	}
+	return enabled, nil
+}
+
+// KnownFeatures describes the known features, sorted by name, e.g. "Foo=true|false (ALPHA - default=false)".
+func (g *FeatureGate) KnownFeatures() []string {
+	known := make([]string, 0, len(g.known))
+	for f, spec := range g.known {
+		known = append(known, fmt.Sprintf("%s=true|false (%s - default=%t)", f, spec.Stage, spec.Default))
+	}
+	slices.Sort(known)
+	return known
+}
+
+// EnabledFeatures returns the names of the enabled features, sorted.
+func (g *FeatureGate) EnabledFeatures() []string {
+	var enabled []string
+	for f := range g.known {
+		if g.Enabled(f) {
+			enabled = append(enabled, string(f))
+		}
+	}
+	slices.Sort(enabled)
+	return enabled
+}
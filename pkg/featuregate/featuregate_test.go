@@ -0,0 +1,93 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package featuregate
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	alphaFeature      Feature = "AlphaFeature"
+	betaFeature       Feature = "BetaFeature"
+	gaFeature         Feature = "GAFeature"
+	deprecatedFeature Feature = "DeprecatedFeature"
+)
+
+func testGate() *FeatureGate {
+	return New(map[Feature]FeatureSpec{
+		alphaFeature:      {Default: false, Stage: Alpha},
+		betaFeature:       {Default: true, Stage: Beta},
+		gaFeature:         {Default: true, Stage: GA},
+		deprecatedFeature: {Default: false, Stage: Deprecated},
+	})
+}
+
+func TestFeatureGateDefaults(t *testing.T) {
+	g := testGate()
+	assert.False(t, g.Enabled(alphaFeature))
+	assert.True(t, g.Enabled(betaFeature))
+	assert.True(t, g.Enabled(gaFeature))
+	assert.Equal(t, []string{"BetaFeature", "GAFeature"}, g.EnabledFeatures())
+	assert.Panics(t, func() { g.Enabled("UnknownFeature") })
+}
+
+func TestFeatureGateSet(t *testing.T) {
+	g := testGate()
+	require.NoError(t, g.Set([]string{"AlphaFeature=true, BetaFeature=false", "DeprecatedFeature=true"}))
+	assert.True(t, g.Enabled(alphaFeature))
+	assert.False(t, g.Enabled(betaFeature))
+	assert.True(t, g.Enabled(deprecatedFeature))
+
+	// features not mentioned keep their state
+	require.NoError(t, g.Set([]string{"BetaFeature=true"}))
+	assert.True(t, g.Enabled(alphaFeature))
+	assert.True(t, g.Enabled(betaFeature))
+
+	require.NoError(t, g.Set(nil))
+	assert.Equal(t, []string{"AlphaFeature", "BetaFeature", "DeprecatedFeature", "GAFeature"}, g.EnabledFeatures())
+}
+
+func TestFeatureGateParseErrors(t *testing.T) {
+	for _, tt := range []struct {
+		entry string
+		err   string
+	}{
+		{entry: "UnknownFeature=true", err: `unknown feature "UnknownFeature"`},
+		{entry: "AlphaFeature", err: `missing value for feature "AlphaFeature", expected AlphaFeature=true or AlphaFeature=false`},
+		{entry: "AlphaFeature=yes", err: `invalid value "yes" for feature "AlphaFeature": strconv.ParseBool: parsing "yes": invalid syntax`},
+		{entry: "GAFeature=false", err: `feature "GAFeature" is GA and cannot be disabled`},
+	} {
+		t.Run(tt.entry, func(t *testing.T) {
+			g := testGate()
+			require.EqualError(t, g.Set([]string{"AlphaFeature=true," + tt.entry}), tt.err)
+			// a failing Set changes nothing
+			assert.False(t, g.Enabled(alphaFeature))
+		})
+	}
+}
+
+func TestKnownFeatures(t *testing.T) {
+	assert.Equal(t, []string{
+		"AlphaFeature=true|false (ALPHA - default=false)",
+		"BetaFeature=true|false (BETA - default=true)",
+		"DeprecatedFeature=true|false (DEPRECATED - default=false)",
+		"GAFeature=true|false (GA - default=true)",
+	}, testGate().KnownFeatures())
+}
@@ -0,0 +1,22 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package featuregate
+
+// features lists the known features. Experimental behavior is added as an Alpha feature disabled by default,
+// checked with featuregate.Default.Enabled, and promoted to Beta then GA once proven.
+// The gate of a GA feature is removed a couple of releases after its promotion.
+var features = map[Feature]FeatureSpec{}
@@ -0,0 +1,190 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package http builds the HTTP transports and clients the DNS providers talk to their API with,
+// following the global HTTP client configuration: timeout, proxy, TLS and user agent. Requests
+// are instrumented with Prometheus metrics and OpenTelemetry traces.
+package http
+
+import (
+	"crypto/tls"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"go.opentelemetry.io/contrib/instrumentation/net/http/otelhttp"
+
+	"sigs.k8s.io/external-dns/pkg/apis/externaldns"
+	"sigs.k8s.io/external-dns/pkg/metrics"
+	"sigs.k8s.io/external-dns/pkg/tlsutils"
+)
+
+// Config configures the transports built by NewTransport and NewClient.
+type Config struct {
+	// Timeout, when positive, bounds the duration of the requests of the clients.
+	Timeout time.Duration
+	// ProxyURL, when set, is the proxy requests go through, instead of the one of the environment.
+	ProxyURL string
+	// CAFile, when set, holds the certificate authorities servers are verified with.
+	CAFile string
+	// CertFile and KeyFile, when set, hold the client certificate presented to servers.
+	CertFile string
+	KeyFile  string
+	// UserAgent is sent with every request. Clients setting a user agent of their own keep it,
+	// followed by this one.
+	UserAgent string
+}
+
+var requestDuration = metrics.NewHistogramVecWithOpts(
+	prometheus.HistogramOpts{
+		Namespace: "external_dns",
+		Subsystem: "http",
+		Name:      "request_duration_seconds",
+		Help:      "Duration in seconds of the HTTP requests to the DNS provider APIs, by component, host, method and status.",
+		Buckets:   []float64{0.05, 0.1, 0.25, 0.5, 1, 2.5, 5, 10, 30},
+	},
+	[]string{"component", "host", "method", "status"},
+)
+
+func init() {
+	metrics.RegisterMetric.MustRegister(requestDuration)
+}
+
+var (
+	mu        sync.RWMutex
+	current   = Config{UserAgent: externaldns.UserAgent()}
+	tlsConfig *tls.Config
+	proxy     func(*http.Request) (*url.URL, error)
+)
+
+// Configure sets the configuration of the transports and clients built afterwards.
+func Configure(cfg Config) error {
+	var proxyFunc func(*http.Request) (*url.URL, error)
+	if cfg.ProxyURL != "" {
+		proxyURL, err := url.Parse(cfg.ProxyURL)
+		if err != nil {
+			return fmt.Errorf("invalid HTTP proxy %q: %w", cfg.ProxyURL, err)
+		}
+		proxyFunc = http.ProxyURL(proxyURL)
+	}
+	var clientTLS *tls.Config
+	if cfg.CAFile != "" || cfg.CertFile != "" || cfg.KeyFile != "" {
+		var err error
+		clientTLS, err = tlsutils.NewTLSConfig(cfg.CertFile, cfg.KeyFile, cfg.CAFile, "", false, tls.VersionTLS12)
+		if err != nil {
+			return err
+		}
+	}
+	if cfg.UserAgent == "" {
+		cfg.UserAgent = externaldns.UserAgent()
+	}
+
+	mu.Lock()
+	defer mu.Unlock()
+	current = cfg
+	tlsConfig = clientTLS
+	proxy = proxyFunc
+	return nil
+}
+
+// NewTransport returns a transport for the clients of the given component, e.g. a provider name.
+// clientTLS, when set, replaces the configured TLS settings, for components with TLS settings of
+// their own.
+func NewTransport(component string, clientTLS *tls.Config) http.RoundTripper {
+	mu.RLock()
+	defer mu.RUnlock()
+
+	if clientTLS == nil {
+		clientTLS = tlsConfig
+	}
+	// Without proxy nor TLS settings, requests go through http.DefaultTransport as of when they
+	// are sent, so that replacing it, e.g. to mock an API in tests, applies.
+	var base http.RoundTripper
+	if proxy != nil || clientTLS != nil {
+		transport, ok := http.DefaultTransport.(*http.Transport)
+		if ok {
+			transport = transport.Clone()
+		} else {
+			transport = &http.Transport{Proxy: http.ProxyFromEnvironment}
+		}
+		if proxy != nil {
+			transport.Proxy = proxy
+		}
+		if clientTLS != nil {
+			transport.TLSClientConfig = clientTLS.Clone()
+		}
+		base = transport
+	}
+
+	return otelhttp.NewTransport(
+		&instrumentedTransport{component: component, userAgent: current.UserAgent, next: base},
+		otelhttp.WithSpanNameFormatter(func(_ string, r *http.Request) string {
+			return component + " " + r.Method
+		}),
+	)
+}
+
+// NewClient returns a client for the given component, e.g. a provider name, built from NewTransport.
+func NewClient(component string) *http.Client {
+	transport := NewTransport(component, nil)
+	mu.RLock()
+	defer mu.RUnlock()
+	return &http.Client{Transport: transport, Timeout: current.Timeout}
+}
+
+// instrumentedTransport sets the user agent of requests and observes their duration.
+type instrumentedTransport struct {
+	component string
+	userAgent string
+	// next is the transport requests are sent with, http.DefaultTransport when nil.
+	next http.RoundTripper
+}
+
+func (t *instrumentedTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	next := t.next
+	if next == nil {
+		next = http.DefaultTransport
+	}
+	req = req.Clone(req.Context())
+	req.Header.Set("User-Agent", userAgent(req.Header.Get("User-Agent"), t.userAgent))
+
+	start := time.Now()
+	resp, err := next.RoundTrip(req)
+	status := "error"
+	if err == nil {
+		status = strconv.Itoa(resp.StatusCode)
+	}
+	requestDuration.HistogramVec.WithLabelValues(t.component, req.URL.Host, req.Method, status).Observe(time.Since(start).Seconds())
+	return resp, err
+}
+
+// userAgent returns the user agent of a request setting the given one, with the configured one.
+// The default user agent of ExternalDNS gets replaced by the configured one.
+func userAgent(requested, configured string) string {
+	switch {
+	case requested == "" || requested == configured:
+		return configured
+	case strings.Contains(requested, externaldns.UserAgent()):
+		return strings.Replace(requested, externaldns.UserAgent(), configured, 1)
+	default:
+		return requested + " " + configured
+	}
+}
@@ -0,0 +1,102 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package http
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"sigs.k8s.io/external-dns/pkg/apis/externaldns"
+)
+
+func configure(t *testing.T, cfg Config) {
+	t.Helper()
+	require.NoError(t, Configure(cfg))
+	t.Cleanup(func() { require.NoError(t, Configure(Config{})) })
+}
+
+func TestNewClient(t *testing.T) {
+	var userAgents []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		userAgents = append(userAgents, r.Header.Get("User-Agent"))
+		w.WriteHeader(http.StatusTeapot)
+	}))
+	defer server.Close()
+	configure(t, Config{Timeout: 5 * time.Second, UserAgent: "ExternalDNS/test (owner default)"})
+
+	client := NewClient("test-client")
+	assert.Equal(t, 5*time.Second, client.Timeout)
+
+	resp, err := client.Get(server.URL)
+	require.NoError(t, err)
+	resp.Body.Close()
+	assert.Equal(t, http.StatusTeapot, resp.StatusCode)
+
+	req, err := http.NewRequest(http.MethodGet, server.URL, nil)
+	require.NoError(t, err)
+	req.Header.Set("User-Agent", "go-sdk/1.0")
+	resp, err = client.Do(req)
+	require.NoError(t, err)
+	resp.Body.Close()
+
+	assert.Equal(t, []string{"ExternalDNS/test (owner default)", "go-sdk/1.0 ExternalDNS/test (owner default)"}, userAgents)
+	assert.Equal(t, 1, testutil.CollectAndCount(requestDuration.HistogramVec.MustCurryWith(map[string]string{"component": "test-client"})))
+}
+
+func TestNewTransportProxy(t *testing.T) {
+	var proxied []string
+	proxy := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		proxied = append(proxied, r.URL.String())
+	}))
+	defer proxy.Close()
+	configure(t, Config{ProxyURL: proxy.URL})
+
+	client := &http.Client{Transport: NewTransport("test-proxy", nil)}
+	resp, err := client.Get("http://api.example.org/zones")
+	require.NoError(t, err)
+	resp.Body.Close()
+
+	assert.Equal(t, []string{"http://api.example.org/zones"}, proxied)
+}
+
+func TestConfigure(t *testing.T) {
+	assert.Error(t, Configure(Config{ProxyURL: "://proxy"}))
+	assert.Error(t, Configure(Config{CAFile: "/nonexistent/ca.pem"}))
+	assert.Error(t, Configure(Config{CertFile: "/etc/ssl/client.pem"}))
+}
+
+func TestUserAgent(t *testing.T) {
+	configured := "ExternalDNS/v1 (owner default)"
+	for _, tt := range []struct {
+		requested string
+		expected  string
+	}{
+		{"", configured},
+		{configured, configured},
+		{externaldns.UserAgent(), configured},
+		{externaldns.UserAgent() + " linodego/1.0", configured + " linodego/1.0"},
+		{"go-ovh/1.0", "go-ovh/1.0 " + configured},
+	} {
+		assert.Equal(t, tt.expected, userAgent(tt.requested, configured), tt.requested)
+	}
+}
@@ -54,7 +54,7 @@ func NewMetricsRegister() *MetricRegistry {
 //	}
 func (m *MetricRegistry) MustRegister(cs IMetric) {
 	switch v := cs.(type) {
-	case CounterMetric, GaugeMetric, CounterVecMetric:
+	case CounterMetric, GaugeMetric, CounterVecMetric, GaugeVecMetric, HistogramVecMetric:
 		if _, exists := m.mName[cs.Get().FQDN]; exists {
 			return
 		} else {
@@ -68,6 +68,10 @@ func (m *MetricRegistry) MustRegister(cs IMetric) {
 			m.Registerer.MustRegister(metric.Gauge)
 		case CounterVecMetric:
 			m.Registerer.MustRegister(metric.CounterVec)
+		case GaugeVecMetric:
+			m.Registerer.MustRegister(metric.GaugeVec)
+		case HistogramVecMetric:
+			m.Registerer.MustRegister(metric.HistogramVec)
 		}
 		log.Debugf("Register metric: %s", cs.Get().FQDN)
 	default:
@@ -60,8 +60,10 @@ func TestMustRegister(t *testing.T) {
 				NewGaugeWithOpts(prometheus.GaugeOpts{Name: "test_gauge_3"}),
 				NewCounterWithOpts(prometheus.CounterOpts{Name: "test_counter_3"}),
 				NewCounterVecWithOpts(prometheus.CounterOpts{Name: "test_counter_vec_3"}, []string{"label"}),
+				NewGaugeVecWithOpts(prometheus.GaugeOpts{Name: "test_gauge_vec_3"}, []string{"label"}),
+				NewHistogramVecWithOpts(prometheus.HistogramOpts{Name: "test_histogram_vec_3"}, []string{"label"}),
 			},
-			expected: 3,
+			expected: 5,
 		},
 		{
 			name: "unsupported metric",
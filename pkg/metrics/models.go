@@ -68,6 +68,24 @@ func (g CounterVecMetric) Get() *Metric {
 	return &g.Metric
 }
 
+type GaugeVecMetric struct {
+	Metric
+	GaugeVec *prometheus.GaugeVec
+}
+
+func (g GaugeVecMetric) Get() *Metric {
+	return &g.Metric
+}
+
+type HistogramVecMetric struct {
+	Metric
+	HistogramVec *prometheus.HistogramVec
+}
+
+func (g HistogramVecMetric) Get() *Metric {
+	return &g.Metric
+}
+
 func NewGaugeWithOpts(opts prometheus.GaugeOpts) GaugeMetric {
 	return GaugeMetric{
 		Metric: Metric{
@@ -109,3 +127,31 @@ func NewCounterVecWithOpts(opts prometheus.CounterOpts, labelNames []string) Cou
 		CounterVec: prometheus.NewCounterVec(opts, labelNames),
 	}
 }
+
+func NewGaugeVecWithOpts(opts prometheus.GaugeOpts, labelNames []string) GaugeVecMetric {
+	return GaugeVecMetric{
+		Metric: Metric{
+			Type:      "gauge",
+			Name:      opts.Name,
+			FQDN:      fmt.Sprintf("%s_%s", opts.Subsystem, opts.Name),
+			Namespace: opts.Namespace,
+			Subsystem: opts.Subsystem,
+			Help:      opts.Help,
+		},
+		GaugeVec: prometheus.NewGaugeVec(opts, labelNames),
+	}
+}
+
+func NewHistogramVecWithOpts(opts prometheus.HistogramOpts, labelNames []string) HistogramVecMetric {
+	return HistogramVecMetric{
+		Metric: Metric{
+			Type:      "histogram",
+			Name:      opts.Name,
+			FQDN:      fmt.Sprintf("%s_%s", opts.Subsystem, opts.Name),
+			Namespace: opts.Namespace,
+			Subsystem: opts.Subsystem,
+			Help:      opts.Help,
+		},
+		HistogramVec: prometheus.NewHistogramVec(opts, labelNames),
+	}
+}
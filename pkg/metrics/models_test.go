@@ -81,3 +81,45 @@ func TestNewCounterVecWithOpts(t *testing.T) {
 	assert.Equal(t, "test_subsystem_test_counter_vec", counterVecMetric.FQDN)
 	assert.NotNil(t, counterVecMetric.CounterVec)
 }
+
+func TestNewGaugeVecWithOpts(t *testing.T) {
+	opts := prometheus.GaugeOpts{
+		Name:      "test_gauge_vec",
+		Namespace: "test_namespace",
+		Subsystem: "test_subsystem",
+		Help:      "This is a test gauge vector",
+	}
+
+	labelNames := []string{"label1", "label2"}
+
+	gaugeVecMetric := NewGaugeVecWithOpts(opts, labelNames)
+
+	assert.Equal(t, "gauge", gaugeVecMetric.Type)
+	assert.Equal(t, "test_gauge_vec", gaugeVecMetric.Name)
+	assert.Equal(t, "test_namespace", gaugeVecMetric.Namespace)
+	assert.Equal(t, "test_subsystem", gaugeVecMetric.Subsystem)
+	assert.Equal(t, "This is a test gauge vector", gaugeVecMetric.Help)
+	assert.Equal(t, "test_subsystem_test_gauge_vec", gaugeVecMetric.FQDN)
+	assert.NotNil(t, gaugeVecMetric.GaugeVec)
+}
+
+func TestNewHistogramVecWithOpts(t *testing.T) {
+	opts := prometheus.HistogramOpts{
+		Name:      "test_histogram_vec",
+		Namespace: "test_namespace",
+		Subsystem: "test_subsystem",
+		Help:      "This is a test histogram vector",
+	}
+
+	labelNames := []string{"label1", "label2"}
+
+	histogramVecMetric := NewHistogramVecWithOpts(opts, labelNames)
+
+	assert.Equal(t, "histogram", histogramVecMetric.Type)
+	assert.Equal(t, "test_histogram_vec", histogramVecMetric.Name)
+	assert.Equal(t, "test_namespace", histogramVecMetric.Namespace)
+	assert.Equal(t, "test_subsystem", histogramVecMetric.Subsystem)
+	assert.Equal(t, "This is a test histogram vector", histogramVecMetric.Help)
+	assert.Equal(t, "test_subsystem_test_histogram_vec", histogramVecMetric.FQDN)
+	assert.NotNil(t, histogramVecMetric.HistogramVec)
+}
@@ -0,0 +1,212 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package resolver builds the DNS client ExternalDNS queries DNS with, e.g. to check the serials of zones or
+// resolve host names, following the global resolver configuration: the system resolver and the name
+// servers of the zones, or a resolver reached over UDP, TCP, DNS over TLS or DNS over HTTPS.
+package resolver
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/miekg/dns"
+
+	extdnshttp "sigs.k8s.io/external-dns/pkg/http"
+)
+
+// maxMessageSize bounds the size of the DNS over HTTPS responses read.
+const maxMessageSize = 65535
+
+// Config configures the resolver returned by Default.
+type Config struct {
+	// Address is the resolver the queries are sent to, as [udp://|tcp://|tls://]HOST[:PORT], or as an
+	// https:// URL for DNS over HTTPS. When empty, host names are resolved with the system resolver, and
+	// the other queries are sent to the name servers of the zones.
+	Address string
+	// Timeout, when positive, bounds the duration of the queries.
+	Timeout time.Duration
+}
+
+// Resolver sends DNS queries, to a configured resolver or to the servers asked for.
+type Resolver struct {
+	// address is HOST:PORT, or the URL of a DNS over HTTPS resolver, empty when none is configured.
+	address string
+	client  *dns.Client
+	https   *http.Client
+	timeout time.Duration
+}
+
+var (
+	mu      sync.RWMutex
+	current = &Resolver{client: new(dns.Client)}
+)
+
+// New returns a resolver with the given configuration.
+func New(cfg Config) (*Resolver, error) {
+	r := &Resolver{client: new(dns.Client), timeout: cfg.Timeout}
+	if cfg.Address == "" {
+		return r, nil
+	}
+
+	scheme, host := "udp", cfg.Address
+	if u, err := url.Parse(cfg.Address); err == nil && u.Scheme != "" && u.Host != "" {
+		scheme, host = u.Scheme, u.Host
+	}
+	port := "53"
+	switch scheme {
+	case "udp":
+	case "tcp":
+		r.client.Net = "tcp"
+	case "tls":
+		r.client.Net = "tcp-tls"
+		port = "853"
+	case "https":
+		r.address = cfg.Address
+		r.https = extdnshttp.NewClient("resolver")
+		return r, nil
+	default:
+		return nil, fmt.Errorf("unsupported DNS resolver %q, expected [udp://|tcp://|tls://]HOST[:PORT] or an https:// URL", cfg.Address)
+	}
+	if _, _, err := net.SplitHostPort(host); err != nil {
+		host = net.JoinHostPort(strings.Trim(host, "[]"), port)
+	}
+	r.address = host
+	return r, nil
+}
+
+// Configure sets the resolver returned by Default afterwards.
+func Configure(cfg Config) error {
+	r, err := New(cfg)
+	if err != nil {
+		return err
+	}
+	mu.Lock()
+	defer mu.Unlock()
+	current = r
+	return nil
+}
+
+// Default returns the configured resolver.
+func Default() *Resolver {
+	mu.RLock()
+	defer mu.RUnlock()
+	return current
+}
+
+// Configured returns whether the queries are sent to a configured resolver.
+func (r *Resolver) Configured() bool {
+	return r.address != ""
+}
+
+// ExchangeContext sends m to the configured resolver, asking for recursion, else to server, as HOST:PORT.
+func (r *Resolver) ExchangeContext(ctx context.Context, m *dns.Msg, server string) (*dns.Msg, time.Duration, error) {
+	if r.timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, r.timeout)
+		defer cancel()
+	}
+	if !r.Configured() {
+		return r.client.ExchangeContext(ctx, m, server)
+	}
+
+	m = m.Copy()
+	m.RecursionDesired = true
+	if r.https != nil {
+		return r.exchangeHTTPS(ctx, m)
+	}
+	return r.client.ExchangeContext(ctx, m, r.address)
+}
+
+// exchangeHTTPS sends m to the DNS over HTTPS resolver, as per RFC 8484.
+func (r *Resolver) exchangeHTTPS(ctx context.Context, m *dns.Msg) (*dns.Msg, time.Duration, error) {
+	// the ID is zero to make the responses cacheable, see RFC 8484
+	id := m.Id
+	m.Id = 0
+	packed, err := m.Pack()
+	if err != nil {
+		return nil, 0, err
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, r.address, bytes.NewReader(packed))
+	if err != nil {
+		return nil, 0, err
+	}
+	req.Header.Set("Content-Type", "application/dns-message")
+	req.Header.Set("Accept", "application/dns-message")
+
+	start := time.Now()
+	resp, err := r.https.Do(req)
+	if err != nil {
+		return nil, 0, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, 0, fmt.Errorf("DNS over HTTPS query to %s failed with status %s", r.address, resp.Status)
+	}
+	body, err := io.ReadAll(io.LimitReader(resp.Body, maxMessageSize))
+	if err != nil {
+		return nil, 0, err
+	}
+	in := new(dns.Msg)
+	if err := in.Unpack(body); err != nil {
+		return nil, 0, fmt.Errorf("invalid DNS over HTTPS response from %s: %w", r.address, err)
+	}
+	in.Id = id
+	return in, time.Since(start), nil
+}
+
+// LookupIPAddr returns the IPv4 and IPv6 addresses of host, resolved by the configured resolver, else by
+// the system resolver. It implements the lookup of net.Resolver.
+func (r *Resolver) LookupIPAddr(ctx context.Context, host string) ([]net.IPAddr, error) {
+	if !r.Configured() {
+		if r.timeout > 0 {
+			var cancel context.CancelFunc
+			ctx, cancel = context.WithTimeout(ctx, r.timeout)
+			defer cancel()
+		}
+		return net.DefaultResolver.LookupIPAddr(ctx, host)
+	}
+
+	var addrs []net.IPAddr
+	for _, qtype := range []uint16{dns.TypeA, dns.TypeAAAA} {
+		m := new(dns.Msg)
+		m.SetQuestion(dns.Fqdn(host), qtype)
+		in, _, err := r.ExchangeContext(ctx, m, "")
+		if err != nil {
+			return nil, &net.DNSError{Err: err.Error(), Name: host, Server: r.address}
+		}
+		for _, rr := range in.Answer {
+			switch rr := rr.(type) {
+			case *dns.A:
+				addrs = append(addrs, net.IPAddr{IP: rr.A})
+			case *dns.AAAA:
+				addrs = append(addrs, net.IPAddr{IP: rr.AAAA})
+			}
+		}
+	}
+	if len(addrs) == 0 {
+		return nil, &net.DNSError{Err: "no such host", Name: host, Server: r.address, IsNotFound: true}
+	}
+	return addrs, nil
+}
@@ -0,0 +1,171 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package resolver
+
+import (
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/miekg/dns"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// answer answers the A, AAAA and SOA queries for app.example.org and example.org, telling in the TXT
+// record of the additional section whether recursion was desired.
+func answer(req *dns.Msg) *dns.Msg {
+	resp := new(dns.Msg)
+	resp.SetReply(req)
+	q := req.Question[0]
+	switch {
+	case q.Name == "app.example.org." && q.Qtype == dns.TypeA:
+		resp.Answer = append(resp.Answer, &dns.A{Hdr: dns.RR_Header{Name: q.Name, Rrtype: dns.TypeA, Class: dns.ClassINET}, A: net.ParseIP("192.0.2.1")})
+	case q.Name == "app.example.org." && q.Qtype == dns.TypeAAAA:
+		resp.Answer = append(resp.Answer, &dns.AAAA{Hdr: dns.RR_Header{Name: q.Name, Rrtype: dns.TypeAAAA, Class: dns.ClassINET}, AAAA: net.ParseIP("2001:db8::1")})
+	case q.Name == "example.org." && q.Qtype == dns.TypeSOA:
+		resp.Answer = append(resp.Answer, &dns.SOA{Hdr: dns.RR_Header{Name: q.Name, Rrtype: dns.TypeSOA, Class: dns.ClassINET}, Ns: "ns.example.org.", Mbox: "admin.example.org.", Serial: 42})
+	}
+	return resp
+}
+
+func startUDPServer(t *testing.T) string {
+	t.Helper()
+	conn, err := net.ListenPacket("udp", "127.0.0.1:0")
+	require.NoError(t, err)
+	server := &dns.Server{PacketConn: conn, Handler: dns.HandlerFunc(func(w dns.ResponseWriter, req *dns.Msg) {
+		_ = w.WriteMsg(answer(req))
+	})}
+	go func() { _ = server.ActivateAndServe() }()
+	t.Cleanup(func() { _ = server.Shutdown() })
+	return conn.LocalAddr().String()
+}
+
+func TestNew(t *testing.T) {
+	for _, tc := range []struct {
+		address  string
+		expected string
+		net      string
+		https    bool
+		err      bool
+	}{
+		{address: ""},
+		{address: "10.0.0.10", expected: "10.0.0.10:53"},
+		{address: "udp://10.0.0.10:5353", expected: "10.0.0.10:5353"},
+		{address: "tcp://[2001:db8::53]", expected: "[2001:db8::53]:53", net: "tcp"},
+		{address: "tls://dns.example.org", expected: "dns.example.org:853", net: "tcp-tls"},
+		{address: "https://dns.example.org/dns-query", expected: "https://dns.example.org/dns-query", https: true},
+		{address: "quic://dns.example.org", err: true},
+	} {
+		t.Run(tc.address, func(t *testing.T) {
+			r, err := New(Config{Address: tc.address})
+			if tc.err {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tc.expected, r.address)
+			assert.Equal(t, tc.expected != "", r.Configured())
+			assert.Equal(t, tc.net, r.client.Net)
+			assert.Equal(t, tc.https, r.https != nil)
+		})
+	}
+}
+
+func TestExchange(t *testing.T) {
+	address := startUDPServer(t)
+
+	// without a configured resolver, queries are sent to the server asked for
+	m := new(dns.Msg)
+	m.SetQuestion("example.org.", dns.TypeSOA)
+	unconfigured, err := New(Config{})
+	require.NoError(t, err)
+	in, _, err := unconfigured.ExchangeContext(t.Context(), m, address)
+	require.NoError(t, err)
+	require.Len(t, in.Answer, 1)
+	assert.Equal(t, uint32(42), in.Answer[0].(*dns.SOA).Serial)
+
+	// a configured resolver gets the queries sent to any server
+	configured, err := New(Config{Address: "udp://" + address})
+	require.NoError(t, err)
+	m.RecursionDesired = false
+	in, _, err = configured.ExchangeContext(t.Context(), m, "192.0.2.53:53")
+	require.NoError(t, err)
+	require.Len(t, in.Answer, 1)
+	assert.False(t, m.RecursionDesired, "the query is not changed")
+
+	addrs, err := configured.LookupIPAddr(t.Context(), "app.example.org")
+	require.NoError(t, err)
+	assert.Equal(t, []net.IPAddr{{IP: net.ParseIP("192.0.2.1").To4()}, {IP: net.ParseIP("2001:db8::1")}}, addrs)
+
+	_, err = configured.LookupIPAddr(t.Context(), "missing.example.org")
+	var dnsErr *net.DNSError
+	require.ErrorAs(t, err, &dnsErr)
+	assert.True(t, dnsErr.IsNotFound)
+}
+
+func TestExchangeHTTPS(t *testing.T) {
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodPost, r.Method)
+		assert.Equal(t, "application/dns-message", r.Header.Get("Content-Type"))
+		body, err := io.ReadAll(r.Body)
+		require.NoError(t, err)
+		req := new(dns.Msg)
+		require.NoError(t, req.Unpack(body))
+		assert.Zero(t, req.Id)
+		assert.True(t, req.RecursionDesired)
+		packed, err := answer(req).Pack()
+		require.NoError(t, err)
+		w.Header().Set("Content-Type", "application/dns-message")
+		_, _ = w.Write(packed)
+	}))
+	defer server.Close()
+
+	r, err := New(Config{Address: server.URL + "/dns-query"})
+	require.NoError(t, err)
+	r.https = server.Client()
+	m := new(dns.Msg)
+	m.SetQuestion("example.org.", dns.TypeSOA)
+	in, _, err := r.ExchangeContext(t.Context(), m, "")
+	require.NoError(t, err)
+	assert.Equal(t, m.Id, in.Id)
+	require.Len(t, in.Answer, 1)
+	assert.Equal(t, uint32(42), in.Answer[0].(*dns.SOA).Serial)
+
+	failing := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusBadGateway)
+	}))
+	defer failing.Close()
+	r, err = New(Config{Address: failing.URL})
+	require.NoError(t, err)
+	r.https = failing.Client()
+	_, _, err = r.ExchangeContext(t.Context(), m, "")
+	require.Error(t, err)
+}
+
+func TestConfigure(t *testing.T) {
+	t.Cleanup(func() { require.NoError(t, Configure(Config{})) })
+
+	require.NoError(t, Configure(Config{Address: "10.0.0.10"}))
+	assert.True(t, Default().Configured())
+	require.Error(t, Configure(Config{Address: "quic://10.0.0.10"}))
+	assert.True(t, Default().Configured(), "an invalid configuration is not applied")
+	require.NoError(t, Configure(Config{}))
+	assert.False(t, Default().Configured())
+}
@@ -0,0 +1,120 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package secrets
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	v4 "github.com/aws/aws-sdk-go-v2/aws/signer/v4"
+	"github.com/aws/aws-sdk-go-v2/config"
+
+	extdnshttp "sigs.k8s.io/external-dns/pkg/http"
+)
+
+// awsSecretsManagerFetcher fetches the string of a secret of AWS Secrets Manager, with the
+// credentials and region of the default AWS configuration. The region of a secret given by ARN
+// is the one of the ARN.
+type awsSecretsManagerFetcher struct {
+	id     string
+	client *http.Client
+	// endpoint, when set, replaces the regional endpoint of AWS Secrets Manager.
+	endpoint   string
+	loadConfig func(ctx context.Context) (aws.Config, error)
+}
+
+func newAWSSecretsManagerFetcher(id string) *awsSecretsManagerFetcher {
+	return &awsSecretsManagerFetcher{
+		id:     id,
+		client: extdnshttp.NewClient("aws-secrets-manager"),
+		loadConfig: func(ctx context.Context) (aws.Config, error) {
+			return config.LoadDefaultConfig(ctx)
+		},
+	}
+}
+
+func (f *awsSecretsManagerFetcher) Fetch(ctx context.Context) (string, error) {
+	cfg, err := f.loadConfig(ctx)
+	if err != nil {
+		return "", fmt.Errorf("loading AWS configuration: %w", err)
+	}
+	region := cfg.Region
+	// arn:aws:secretsmanager:<region>:<account>:secret:<name>
+	if parts := strings.Split(f.id, ":"); len(parts) > 3 && parts[0] == "arn" {
+		region = parts[3]
+	}
+	if region == "" {
+		return "", fmt.Errorf("no AWS region to read secret %s from", f.id)
+	}
+	endpoint := f.endpoint
+	if endpoint == "" {
+		endpoint = fmt.Sprintf("https://secretsmanager.%s.amazonaws.com/", region)
+	}
+
+	payload, err := json.Marshal(map[string]string{"SecretId": f.id})
+	if err != nil {
+		return "", err
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, endpoint, bytes.NewReader(payload))
+	if err != nil {
+		return "", err
+	}
+	req.Header.Set("Content-Type", "application/x-amz-json-1.1")
+	req.Header.Set("X-Amz-Target", "secretsmanager.GetSecretValue")
+
+	credentials, err := cfg.Credentials.Retrieve(ctx)
+	if err != nil {
+		return "", fmt.Errorf("retrieving AWS credentials: %w", err)
+	}
+	hash := sha256.Sum256(payload)
+	if err := v4.NewSigner().SignHTTP(ctx, credentials, req, hex.EncodeToString(hash[:]), "secretsmanager", region, time.Now()); err != nil {
+		return "", err
+	}
+
+	resp, err := f.client.Do(req)
+	if err != nil {
+		return "", err
+	}
+	defer resp.Body.Close()
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return "", err
+	}
+	if resp.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("reading AWS secret %s: %s: %s", f.id, resp.Status, strings.TrimSpace(string(body)))
+	}
+
+	var secret struct {
+		SecretString *string `json:"SecretString"`
+	}
+	if err := json.Unmarshal(body, &secret); err != nil {
+		return "", fmt.Errorf("decoding AWS secret %s: %w", f.id, err)
+	}
+	if secret.SecretString == nil {
+		return "", fmt.Errorf("AWS secret %s has no string value", f.id)
+	}
+	return *secret.SecretString, nil
+}
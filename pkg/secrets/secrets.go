@@ -0,0 +1,196 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package secrets loads the credentials of the providers from environment variables, files,
+// HashiCorp Vault or AWS Secrets Manager, and refreshes them so that rotated credentials get
+// picked up without restarting.
+//
+// Secrets are configured under the name of the environment variable the providers read them
+// from otherwise, e.g. CF_API_TOKEN or OVH_APPLICATION_SECRET.
+package secrets
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+)
+
+// Fetcher fetches the current value of a secret.
+type Fetcher interface {
+	Fetch(ctx context.Context) (string, error)
+}
+
+// ParseReference returns the fetcher of a secret reference, one of:
+//   - env:NAME, the environment variable NAME,
+//   - file:PATH, the content of the file at PATH,
+//   - vault:PATH#KEY, the key KEY of the secret at PATH of the Vault server of VAULT_ADDR,
+//   - aws-sm:ID, the secret ID, a name or an ARN, of AWS Secrets Manager.
+//
+// A #KEY suffix picks the key KEY of the secret, read as a JSON object. Vault secrets require it.
+func ParseReference(ref string) (Fetcher, error) {
+	scheme, location, ok := strings.Cut(ref, ":")
+	if !ok || location == "" {
+		return nil, fmt.Errorf("invalid secret reference %q: expected <scheme>:<location>", ref)
+	}
+	location, key, _ := strings.Cut(location, "#")
+	switch scheme {
+	case "env":
+		return keyFetcher{envFetcher(location), key}, nil
+	case "file":
+		return keyFetcher{fileFetcher(location), key}, nil
+	case "vault":
+		if key == "" {
+			return nil, fmt.Errorf("invalid secret reference %q: the key of Vault secrets is required", ref)
+		}
+		return newVaultFetcher(location, key), nil
+	case "aws-sm":
+		return keyFetcher{newAWSSecretsManagerFetcher(location), key}, nil
+	default:
+		return nil, fmt.Errorf("invalid secret reference %q: unsupported scheme %q, expected env, file, vault or aws-sm", ref, scheme)
+	}
+}
+
+type envFetcher string
+
+func (f envFetcher) Fetch(_ context.Context) (string, error) {
+	value, ok := os.LookupEnv(string(f))
+	if !ok {
+		return "", fmt.Errorf("environment variable %s is not set", string(f))
+	}
+	return value, nil
+}
+
+type fileFetcher string
+
+func (f fileFetcher) Fetch(_ context.Context) (string, error) {
+	content, err := os.ReadFile(string(f))
+	if err != nil {
+		return "", err
+	}
+	return strings.TrimSpace(string(content)), nil
+}
+
+// keyFetcher picks a key of the JSON object fetched by another fetcher, if any.
+type keyFetcher struct {
+	Fetcher
+	key string
+}
+
+func (f keyFetcher) Fetch(ctx context.Context) (string, error) {
+	value, err := f.Fetcher.Fetch(ctx)
+	if err != nil || f.key == "" {
+		return value, err
+	}
+	var object map[string]any
+	if err := json.Unmarshal([]byte(value), &object); err != nil {
+		return "", fmt.Errorf("secret is not a JSON object: %w", err)
+	}
+	return pick(object, f.key)
+}
+
+// pick returns the value of a key of a secret object.
+func pick(object map[string]any, key string) (string, error) {
+	value, ok := object[key]
+	if !ok {
+		return "", fmt.Errorf("secret has no key %q", key)
+	}
+	if s, ok := value.(string); ok {
+		return s, nil
+	}
+	return fmt.Sprint(value), nil
+}
+
+type secret struct {
+	fetcher   Fetcher
+	value     string
+	fetchedAt time.Time
+}
+
+var (
+	mu              sync.Mutex
+	configured      = map[string]*secret{}
+	refreshInterval time.Duration
+)
+
+// Configure sets the secrets looked up by name, given as NAME=REFERENCE, and the interval they are
+// refreshed at, never when zero. Every secret is fetched right away, so that misconfigured
+// secrets are reported upfront.
+func Configure(ctx context.Context, references []string, refresh time.Duration) error {
+	secrets := make(map[string]*secret, len(references))
+	for _, reference := range references {
+		name, ref, ok := strings.Cut(reference, "=")
+		if !ok || name == "" {
+			return fmt.Errorf("invalid secret %q: expected NAME=REFERENCE", reference)
+		}
+		fetcher, err := ParseReference(ref)
+		if err != nil {
+			return err
+		}
+		value, err := fetcher.Fetch(ctx)
+		if err != nil {
+			return fmt.Errorf("fetching secret %s: %w", name, err)
+		}
+		secrets[name] = &secret{fetcher: fetcher, value: value, fetchedAt: time.Now()}
+	}
+
+	mu.Lock()
+	defer mu.Unlock()
+	configured = secrets
+	refreshInterval = refresh
+	return nil
+}
+
+// Lookup returns the value of the secret configured under the given name, refetched once the
+// refresh interval elapsed since it was last fetched. When refetching fails, the previous value
+// is returned and refetching is retried at the next lookup. ok is false when no secret is
+// configured under that name.
+func Lookup(ctx context.Context, name string) (value string, ok bool) {
+	mu.Lock()
+	defer mu.Unlock()
+
+	s, ok := configured[name]
+	if !ok {
+		return "", false
+	}
+	if refreshInterval > 0 && time.Since(s.fetchedAt) >= refreshInterval {
+		value, err := s.fetcher.Fetch(ctx)
+		if err != nil {
+			log.Warnf("Failed to refresh secret %s, keeping its previous value: %v", name, err)
+			return s.value, true
+		}
+		if value != s.value {
+			log.Infof("Secret %s got rotated", name)
+		}
+		s.value = value
+		s.fetchedAt = time.Now()
+	}
+	return s.value, true
+}
+
+// Get returns the value of the secret configured under the given name, or the value of the
+// environment variable of that name when none is.
+func Get(ctx context.Context, name string) string {
+	if value, ok := Lookup(ctx, name); ok {
+		return value
+	}
+	return os.Getenv(name)
+}
@@ -0,0 +1,193 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package secrets
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/credentials"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func configure(t *testing.T, references []string, refresh time.Duration) {
+	t.Helper()
+	require.NoError(t, Configure(context.Background(), references, refresh))
+	t.Cleanup(func() { require.NoError(t, Configure(context.Background(), nil, 0)) })
+}
+
+func TestParseReference(t *testing.T) {
+	for _, ref := range []string{"", "env:", "token", "vault:secret/data/ovh", "consul:ovh"} {
+		_, err := ParseReference(ref)
+		assert.Error(t, err, ref)
+	}
+	for _, ref := range []string{"env:CF_API_TOKEN", "file:/var/run/secrets/token#token", "vault:secret/data/ovh#application_key", "aws-sm:external-dns/cloudflare"} {
+		_, err := ParseReference(ref)
+		assert.NoError(t, err, ref)
+	}
+}
+
+func TestFetchEnvAndFile(t *testing.T) {
+	t.Setenv("SECRETS_TEST_TOKEN", "from-env")
+	t.Setenv("SECRETS_TEST_JSON", `{"token":"from-json","port":53}`)
+	path := filepath.Join(t.TempDir(), "token")
+	require.NoError(t, os.WriteFile(path, []byte("from-file\n"), 0o600))
+
+	for ref, expected := range map[string]string{
+		"env:SECRETS_TEST_TOKEN":      "from-env",
+		"env:SECRETS_TEST_JSON#token": "from-json",
+		"env:SECRETS_TEST_JSON#port":  "53",
+		"file:" + path:                "from-file",
+	} {
+		fetcher, err := ParseReference(ref)
+		require.NoError(t, err)
+		value, err := fetcher.Fetch(context.Background())
+		require.NoError(t, err, ref)
+		assert.Equal(t, expected, value, ref)
+	}
+
+	for _, ref := range []string{"env:SECRETS_TEST_UNSET", "env:SECRETS_TEST_TOKEN#token", "env:SECRETS_TEST_JSON#missing", "file:" + path + ".missing"} {
+		fetcher, err := ParseReference(ref)
+		require.NoError(t, err)
+		_, err = fetcher.Fetch(context.Background())
+		assert.Error(t, err, ref)
+	}
+}
+
+func TestLookupRefreshesRotatedSecrets(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "token")
+	require.NoError(t, os.WriteFile(path, []byte("v1"), 0o600))
+	t.Setenv("SECRETS_TEST_FALLBACK", "from-env")
+
+	assert.Error(t, Configure(context.Background(), []string{"CF_API_TOKEN"}, 0))
+	assert.Error(t, Configure(context.Background(), []string{"CF_API_TOKEN=file:" + path + ".missing"}, 0))
+
+	configure(t, []string{"CF_API_TOKEN=file:" + path}, time.Millisecond)
+	value, ok := Lookup(context.Background(), "CF_API_TOKEN")
+	require.True(t, ok)
+	assert.Equal(t, "v1", value)
+
+	require.NoError(t, os.WriteFile(path, []byte("v2"), 0o600))
+	time.Sleep(2 * time.Millisecond)
+	assert.Equal(t, "v2", Get(context.Background(), "CF_API_TOKEN"))
+
+	require.NoError(t, os.Remove(path))
+	time.Sleep(2 * time.Millisecond)
+	assert.Equal(t, "v2", Get(context.Background(), "CF_API_TOKEN"), "the previous value is kept when refreshing fails")
+
+	_, ok = Lookup(context.Background(), "SECRETS_TEST_FALLBACK")
+	assert.False(t, ok)
+	assert.Equal(t, "from-env", Get(context.Background(), "SECRETS_TEST_FALLBACK"))
+}
+
+func TestVaultFetcher(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("X-Vault-Token") != "s.token" {
+			w.WriteHeader(http.StatusForbidden)
+			return
+		}
+		switch r.URL.Path {
+		case "/v1/secret/data/ovh":
+			w.Write([]byte(`{"data":{"data":{"application_key":"kv2-key"},"metadata":{"version":3}}}`))
+		case "/v1/kv/ovh":
+			w.Write([]byte(`{"data":{"application_key":"kv1-key"}}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+	t.Setenv("VAULT_ADDR", server.URL)
+	t.Setenv("VAULT_TOKEN", "s.token")
+
+	for ref, expected := range map[string]string{
+		"vault:secret/data/ovh#application_key": "kv2-key",
+		"vault:/kv/ovh#application_key":         "kv1-key",
+	} {
+		fetcher, err := ParseReference(ref)
+		require.NoError(t, err)
+		value, err := fetcher.Fetch(context.Background())
+		require.NoError(t, err, ref)
+		assert.Equal(t, expected, value, ref)
+	}
+
+	fetcher, err := ParseReference("vault:secret/data/missing#key")
+	require.NoError(t, err)
+	_, err = fetcher.Fetch(context.Background())
+	assert.ErrorContains(t, err, "404")
+
+	t.Setenv("VAULT_TOKEN", "")
+	tokenFile := filepath.Join(t.TempDir(), "token")
+	require.NoError(t, os.WriteFile(tokenFile, []byte("s.token\n"), 0o600))
+	t.Setenv("VAULT_TOKEN_FILE", tokenFile)
+	fetcher, err = ParseReference("vault:kv/ovh#application_key")
+	require.NoError(t, err)
+	value, err := fetcher.Fetch(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, "kv1-key", value)
+}
+
+func TestAWSSecretsManagerFetcher(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("X-Amz-Target") != "secretsmanager.GetSecretValue" || !strings.Contains(r.Header.Get("Authorization"), "/eu-west-3/secretsmanager/aws4_request") {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		var input struct{ SecretId string }
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&input))
+		switch input.SecretId {
+		case "external-dns/cloudflare":
+			w.Write([]byte(`{"Name":"external-dns/cloudflare","SecretString":"{\"token\":\"cf-token\"}"}`))
+		case "external-dns/binary":
+			w.Write([]byte(`{"Name":"external-dns/binary","SecretBinary":"AAE="}`))
+		default:
+			w.WriteHeader(http.StatusBadRequest)
+			w.Write([]byte(`{"__type":"ResourceNotFoundException"}`))
+		}
+	}))
+	defer server.Close()
+
+	fetcher := func(id, key string) Fetcher {
+		f := newAWSSecretsManagerFetcher(id)
+		f.endpoint = server.URL
+		f.loadConfig = func(context.Context) (aws.Config, error) {
+			return aws.Config{Region: "eu-west-3", Credentials: credentials.NewStaticCredentialsProvider("AKID", "SECRET", "")}, nil
+		}
+		return keyFetcher{f, key}
+	}
+
+	value, err := fetcher("external-dns/cloudflare", "token").Fetch(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, "cf-token", value)
+
+	_, err = fetcher("external-dns/binary", "").Fetch(context.Background())
+	assert.ErrorContains(t, err, "no string value")
+
+	_, err = fetcher("external-dns/missing", "").Fetch(context.Background())
+	assert.ErrorContains(t, err, "ResourceNotFoundException")
+
+	_, err = fetcher("arn:aws:secretsmanager:us-east-1:123456789012:secret:external-dns/cloudflare", "").Fetch(context.Background())
+	assert.Error(t, err, "the region of the ARN is used")
+}
@@ -0,0 +1,93 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package secrets
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"strings"
+
+	extdnshttp "sigs.k8s.io/external-dns/pkg/http"
+)
+
+// vaultFetcher fetches a key of a secret of a Vault KV secrets engine, version 1 or 2. The
+// server, token and namespace are read from VAULT_ADDR, VAULT_TOKEN or the file of
+// VAULT_TOKEN_FILE, and VAULT_NAMESPACE, at every fetch so that a renewed token gets picked up.
+type vaultFetcher struct {
+	path   string
+	key    string
+	client *http.Client
+}
+
+func newVaultFetcher(path, key string) *vaultFetcher {
+	return &vaultFetcher{path: strings.Trim(path, "/"), key: key, client: extdnshttp.NewClient("vault")}
+}
+
+func (f *vaultFetcher) Fetch(ctx context.Context) (string, error) {
+	addr := os.Getenv("VAULT_ADDR")
+	if addr == "" {
+		return "", fmt.Errorf("VAULT_ADDR is not set")
+	}
+	token := os.Getenv("VAULT_TOKEN")
+	if tokenFile := os.Getenv("VAULT_TOKEN_FILE"); token == "" && tokenFile != "" {
+		content, err := os.ReadFile(tokenFile)
+		if err != nil {
+			return "", fmt.Errorf("reading Vault token: %w", err)
+		}
+		token = strings.TrimSpace(string(content))
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, strings.TrimSuffix(addr, "/")+"/v1/"+f.path, nil)
+	if err != nil {
+		return "", err
+	}
+	req.Header.Set("X-Vault-Token", token)
+	if namespace := os.Getenv("VAULT_NAMESPACE"); namespace != "" {
+		req.Header.Set("X-Vault-Namespace", namespace)
+	}
+	resp, err := f.client.Do(req)
+	if err != nil {
+		return "", err
+	}
+	defer resp.Body.Close()
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return "", err
+	}
+	if resp.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("reading Vault secret %s: %s: %s", f.path, resp.Status, strings.TrimSpace(string(body)))
+	}
+
+	var secret struct {
+		Data map[string]any `json:"data"`
+	}
+	if err := json.Unmarshal(body, &secret); err != nil {
+		return "", fmt.Errorf("decoding Vault secret %s: %w", f.path, err)
+	}
+	data := secret.Data
+	// The KV secrets engine version 2 nests the secret under data, next to its metadata.
+	if nested, ok := data["data"].(map[string]any); ok {
+		if _, ok := data["metadata"]; ok {
+			data = nested
+		}
+	}
+	return pick(data, f.key)
+}
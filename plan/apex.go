@@ -0,0 +1,87 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package plan
+
+import (
+	"slices"
+	"strings"
+
+	log "github.com/sirupsen/logrus"
+	"golang.org/x/net/publicsuffix"
+
+	"sigs.k8s.io/external-dns/endpoint"
+)
+
+// protectZoneApexes removes from the changes the updates and deletions of the records a zone cannot do without:
+// its SOA record, and its NS records at the zone apex. The zone apexes are the ZoneApexes of the plan, the
+// names of the current SOA records, and the registrable domains, which are the apexes of the zones holding
+// their records whatever the provider.
+func (p *Plan) protectZoneApexes(changes *Changes) {
+	apexes := make(map[string]bool, len(p.ZoneApexes))
+	for _, apex := range p.ZoneApexes {
+		apexes[planKeyDNSName(apex)] = true
+	}
+	for _, current := range p.Current {
+		if current.RecordType == endpoint.RecordTypeSOA {
+			apexes[planKeyDNSName(current.DNSName)] = true
+		}
+	}
+	protected := func(e *endpoint.Endpoint) bool {
+		switch e.RecordType {
+		case endpoint.RecordTypeSOA:
+			return true
+		case endpoint.RecordTypeNS:
+			return apexes[planKeyDNSName(e.DNSName)] || isRegistrableDomain(e.DNSName)
+		default:
+			return false
+		}
+	}
+
+	changes.Delete = slices.DeleteFunc(changes.Delete, func(e *endpoint.Endpoint) bool {
+		if protected(e) {
+			log.Warnf("Not deleting the %s record of %s: it is at a zone apex", e.RecordType, e.DNSName)
+			return true
+		}
+		return false
+	})
+
+	if len(changes.UpdateOld) != len(changes.UpdateNew) {
+		// The updates are not in pairs: filter each side on its own.
+		changes.UpdateOld = slices.DeleteFunc(changes.UpdateOld, protected)
+		changes.UpdateNew = slices.DeleteFunc(changes.UpdateNew, protected)
+		return
+	}
+	updateOld := changes.UpdateOld[:0]
+	updateNew := changes.UpdateNew[:0]
+	for i, old := range changes.UpdateOld {
+		if protected(old) || protected(changes.UpdateNew[i]) {
+			log.Warnf("Not updating the %s record of %s: it is at a zone apex", old.RecordType, old.DNSName)
+			continue
+		}
+		updateOld = append(updateOld, old)
+		updateNew = append(updateNew, changes.UpdateNew[i])
+	}
+	changes.UpdateOld = updateOld
+	changes.UpdateNew = updateNew
+}
+
+// isRegistrableDomain returns whether name is a registrable domain, such as example.com or example.co.uk.
+func isRegistrableDomain(name string) bool {
+	name = strings.ToLower(strings.TrimSuffix(name, "."))
+	domain, err := publicsuffix.EffectiveTLDPlusOne(name)
+	return err == nil && domain == name
+}
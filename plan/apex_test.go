@@ -0,0 +1,97 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package plan
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"sigs.k8s.io/external-dns/endpoint"
+)
+
+func TestProtectZoneApexes(t *testing.T) {
+	apexNS := endpoint.NewEndpoint("example.org", endpoint.RecordTypeNS, "ns1.example.net", "ns2.example.net")
+	soaApexNS := endpoint.NewEndpoint("example.com", endpoint.RecordTypeNS, "ns1.example.net")
+	delegationNS := endpoint.NewEndpoint("dev.example.org", endpoint.RecordTypeNS, "ns.dev.example.net")
+	soa := endpoint.NewEndpoint("example.com", endpoint.RecordTypeSOA, "ns1.example.net. hostmaster.example.com. 1 7200 3600 1209600 300")
+	otherNS := endpoint.NewEndpoint("other.example.org", endpoint.RecordTypeNS, "ns.other.example.net")
+
+	p := &Plan{
+		Policies: []Policy{&SyncPolicy{}},
+		Current:  []*endpoint.Endpoint{apexNS, soaApexNS, delegationNS, soa, otherNS},
+		Desired: []*endpoint.Endpoint{
+			endpoint.NewEndpoint("example.org", endpoint.RecordTypeNS, "ns3.example.net"),
+			endpoint.NewEndpoint("example.com", endpoint.RecordTypeNS, "ns3.example.net"),
+			endpoint.NewEndpoint("dev.example.org", endpoint.RecordTypeNS, "ns3.example.net"),
+		},
+		ManagedRecords: []string{endpoint.RecordTypeNS, endpoint.RecordTypeSOA},
+		ZoneApexes:     []string{"Example.org."},
+	}
+	changes := p.Calculate().Changes
+
+	assert.Empty(t, changes.Create)
+	assert.Equal(t, []*endpoint.Endpoint{delegationNS}, changes.UpdateOld)
+	assert.Len(t, changes.UpdateNew, 1)
+	assert.Equal(t, "dev.example.org", changes.UpdateNew[0].DNSName)
+	assert.Equal(t, []*endpoint.Endpoint{otherNS}, changes.Delete)
+}
+
+func TestProtectZoneApexesDelete(t *testing.T) {
+	changes := &Changes{
+		Delete: []*endpoint.Endpoint{
+			endpoint.NewEndpoint("example.org", endpoint.RecordTypeNS, "ns1.example.net"),
+			endpoint.NewEndpoint("example.org", endpoint.RecordTypeSOA, "ns1.example.net. hostmaster.example.org. 1 7200 3600 1209600 300"),
+			endpoint.NewEndpoint("example.org", endpoint.RecordTypeA, "192.0.2.1"),
+		},
+		UpdateOld: []*endpoint.Endpoint{
+			endpoint.NewEndpoint("example.org", endpoint.RecordTypeSOA, "ns1.example.net. hostmaster.example.org. 1 7200 3600 1209600 300"),
+		},
+	}
+	(&Plan{ZoneApexes: []string{"example.org"}}).protectZoneApexes(changes)
+
+	assert.Len(t, changes.Delete, 1)
+	assert.Equal(t, endpoint.RecordTypeA, changes.Delete[0].RecordType)
+	assert.Empty(t, changes.UpdateOld)
+}
+
+func TestProtectZoneApexesWithoutConfiguredApexes(t *testing.T) {
+	apexNS := endpoint.NewEndpoint("example.org", endpoint.RecordTypeNS, "ns1.example.net")
+	publicSuffixApexNS := endpoint.NewEndpoint("example.co.uk", endpoint.RecordTypeNS, "ns1.example.net")
+	delegationNS := endpoint.NewEndpoint("dev.example.org", endpoint.RecordTypeNS, "ns.dev.example.net")
+
+	p := &Plan{
+		Policies:       []Policy{&SyncPolicy{}},
+		Current:        []*endpoint.Endpoint{apexNS, publicSuffixApexNS, delegationNS},
+		Desired:        []*endpoint.Endpoint{endpoint.NewEndpoint("example.org", endpoint.RecordTypeNS, "ns3.example.net")},
+		ManagedRecords: []string{endpoint.RecordTypeNS},
+	}
+	changes := p.Calculate().Changes
+
+	assert.Empty(t, changes.Create)
+	assert.Empty(t, changes.UpdateOld)
+	assert.Empty(t, changes.UpdateNew)
+	assert.Equal(t, []*endpoint.Endpoint{delegationNS}, changes.Delete)
+}
+
+func TestIsRegistrableDomain(t *testing.T) {
+	assert.True(t, isRegistrableDomain("example.com"))
+	assert.True(t, isRegistrableDomain("Example.co.uk."))
+	assert.False(t, isRegistrableDomain("www.example.com"))
+	assert.False(t, isRegistrableDomain("co.uk"))
+	assert.False(t, isRegistrableDomain(""))
+}
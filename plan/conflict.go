@@ -51,6 +51,10 @@ func (s PerResource) ResolveCreate(candidates []*endpoint.Endpoint) *endpoint.En
 // ResolveUpdate uses "current" record as base and updates it accordingly with new version of same resource
 // if it doesn't exist then pick min
 func (s PerResource) ResolveUpdate(current *endpoint.Endpoint, candidates []*endpoint.Endpoint) *endpoint.Endpoint {
+	// a single candidate is picked whatever resource it comes from
+	if len(candidates) == 1 {
+		return candidates[0]
+	}
 	currentResource := current.Labels[endpoint.ResourceLabelKey] // resource which has already acquired the DNS
 	// TODO: sort candidates only needed because we can still have two endpoints from same resource here. We sort for consistency
 	// TODO: remove once single endpoint can have multiple targets
@@ -0,0 +1,128 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package plan
+
+import (
+	"sort"
+	"strings"
+
+	"sigs.k8s.io/external-dns/endpoint"
+)
+
+// orderChanges orders changes so that records are created or updated after the records they point
+// to, and deleted before them. Providers applying changes in order then never publish a record
+// pointing to a name that does not exist yet, or no longer exists.
+func orderChanges(changes *Changes) {
+	changes.Create = permute(changes.Create, dependencyOrder(changes.Create))
+	order := dependencyOrder(changes.UpdateNew)
+	changes.UpdateNew = permute(changes.UpdateNew, order)
+	changes.UpdateOld = permute(changes.UpdateOld, order)
+	order = dependencyOrder(changes.Delete)
+	for i, j := 0, len(order)-1; i < j; i, j = i+1, j-1 {
+		order[i], order[j] = order[j], order[i]
+	}
+	changes.Delete = permute(changes.Delete, order)
+}
+
+// dependencyOrder returns the indexes of endpoints sorted so that each endpoint comes after the
+// endpoints it points to. Endpoints are otherwise sorted by DNS name, record type and set identifier,
+// and dependency cycles are broken arbitrarily.
+func dependencyOrder(endpoints []*endpoint.Endpoint) []int {
+	sorted := make([]int, len(endpoints))
+	for i := range sorted {
+		sorted[i] = i
+	}
+	if len(endpoints) < 2 {
+		return sorted
+	}
+	sort.SliceStable(sorted, func(i, j int) bool {
+		a, b := endpoints[sorted[i]], endpoints[sorted[j]]
+		if a.DNSName != b.DNSName {
+			return a.DNSName < b.DNSName
+		}
+		if a.RecordType != b.RecordType {
+			return a.RecordType < b.RecordType
+		}
+		return a.SetIdentifier < b.SetIdentifier
+	})
+
+	byName := make(map[string][]int, len(endpoints))
+	for _, i := range sorted {
+		name := planKeyDNSName(endpoints[i].DNSName)
+		byName[name] = append(byName[name], i)
+	}
+
+	const (
+		unvisited = iota
+		visiting
+		visited
+	)
+	state := make([]uint8, len(endpoints))
+	order := make([]int, 0, len(endpoints))
+	var visit func(i int)
+	visit = func(i int) {
+		if state[i] != unvisited {
+			return
+		}
+		state[i] = visiting
+		for _, name := range dependencies(endpoints[i]) {
+			for _, j := range byName[name] {
+				visit(j)
+			}
+		}
+		state[i] = visited
+		order = append(order, i)
+	}
+	for _, i := range sorted {
+		visit(i)
+	}
+	return order
+}
+
+// dependencies returns the DNS names the endpoint points to.
+func dependencies(e *endpoint.Endpoint) []string {
+	names := make([]string, 0, len(e.Targets))
+	for _, target := range e.Targets {
+		switch e.RecordType {
+		case endpoint.RecordTypeCNAME, endpoint.RecordTypeNS:
+		case endpoint.RecordTypeMX, endpoint.RecordTypeSRV:
+			// MX and SRV targets end with the host name, after the priority, weight and port
+			fields := strings.Fields(target)
+			if len(fields) == 0 {
+				continue
+			}
+			target = fields[len(fields)-1]
+		default:
+			if alias, ok := e.GetProviderSpecificProperty("alias"); !ok || alias != "true" {
+				return nil
+			}
+		}
+		names = append(names, planKeyDNSName(target))
+	}
+	return names
+}
+
+func permute(endpoints []*endpoint.Endpoint, order []int) []*endpoint.Endpoint {
+	if len(endpoints) < 2 {
+		return endpoints
+	}
+	result := make([]*endpoint.Endpoint, len(order))
+	for i, j := range order {
+		result[i] = endpoints[j]
+	}
+	return result
+}
@@ -0,0 +1,76 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package plan
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"sigs.k8s.io/external-dns/endpoint"
+)
+
+func TestChangesOrderedByDependencies(t *testing.T) {
+	cname := func(name, target string) *endpoint.Endpoint {
+		return endpoint.NewEndpoint(name, endpoint.RecordTypeCNAME, target)
+	}
+	current := []*endpoint.Endpoint{
+		cname("a-old.example.com", "b-old.example.com"),
+		cname("b-old.example.com", "c-old.example.com"),
+		endpoint.NewEndpoint("c-old.example.com", endpoint.RecordTypeA, "1.1.1.1"),
+		cname("moved.example.com", "c-old.example.com"),
+	}
+	desired := []*endpoint.Endpoint{
+		cname("a.example.com", "b.example.com."),
+		cname("b.example.com", "Z.example.com"),
+		endpoint.NewEndpoint("z.example.com", endpoint.RecordTypeA, "1.1.1.1"),
+		endpoint.NewEndpoint("mx.example.com", endpoint.RecordTypeMX, "10 y.example.com"),
+		endpoint.NewEndpoint("y.example.com", endpoint.RecordTypeA, "1.1.1.1"),
+		endpoint.NewEndpoint("alias.example.com", endpoint.RecordTypeA, "x.example.com").WithProviderSpecific("alias", "true"),
+		cname("x.example.com", "z.example.com"),
+		cname("moved.example.com", "y.example.com"),
+	}
+
+	for range 10 {
+		p := &Plan{
+			Policies:       []Policy{&SyncPolicy{}},
+			Current:        current,
+			Desired:        desired,
+			ManagedRecords: []string{endpoint.RecordTypeA, endpoint.RecordTypeCNAME, endpoint.RecordTypeMX},
+		}
+		changes := p.Calculate().Changes
+
+		names := func(endpoints []*endpoint.Endpoint) []string {
+			result := []string{}
+			for _, e := range endpoints {
+				result = append(result, e.DNSName)
+			}
+			return result
+		}
+		assert.Equal(t, []string{"z.example.com", "b.example.com", "a.example.com", "x.example.com", "alias.example.com", "y.example.com", "mx.example.com"}, names(changes.Create))
+		assert.Equal(t, []string{"moved.example.com"}, names(changes.UpdateNew))
+		assert.Equal(t, []string{"a-old.example.com", "b-old.example.com", "c-old.example.com"}, names(changes.Delete))
+	}
+}
+
+func TestDependencyOrderCycle(t *testing.T) {
+	endpoints := []*endpoint.Endpoint{
+		endpoint.NewEndpoint("b.example.com", endpoint.RecordTypeCNAME, "a.example.com"),
+		endpoint.NewEndpoint("a.example.com", endpoint.RecordTypeCNAME, "b.example.com"),
+	}
+	assert.ElementsMatch(t, []int{0, 1}, dependencyOrder(endpoints))
+}
@@ -18,6 +18,7 @@ package plan
 
 import (
 	"fmt"
+	"slices"
 	"strings"
 
 	"github.com/google/go-cmp/cmp"
@@ -27,8 +28,36 @@ import (
 )
 
 // PropertyComparator is used in Plan for comparing the previous and current custom annotations.
+// It returns true when both values of the provider specific property are equal; an unset property has an empty value.
 type PropertyComparator func(name string, previous string, current string) bool
 
+// PropertyRules declare how a provider compares the provider specific properties of its records,
+// so that the properties it does not report as they were set do not cause updates on every synchronization.
+type PropertyRules struct {
+	// Significant, when not empty, are the only properties compared: the others are ignored.
+	Significant []string
+	// Defaults are the values the provider gives to properties left unset: an unset property equals its default.
+	Defaults map[string]string
+	// Ignored are properties never compared, e.g. those the provider reports but which cannot be changed.
+	Ignored []string
+}
+
+// Comparator returns the PropertyComparator applying the rules.
+func (r PropertyRules) Comparator() PropertyComparator {
+	return func(name, previous, current string) bool {
+		if slices.Contains(r.Ignored, name) || (len(r.Significant) > 0 && !slices.Contains(r.Significant, name)) {
+			return true
+		}
+		if previous == "" {
+			previous = r.Defaults[name]
+		}
+		if current == "" {
+			current = r.Defaults[name]
+		}
+		return previous == current
+	}
+}
+
 // Plan can convert a list of desired and current records to a series of create,
 // update and delete actions.
 type Plan struct {
@@ -49,6 +78,19 @@ type Plan struct {
 	ExcludeRecords []string
 	// OwnerID of records to manage
 	OwnerID string
+	// AdoptedOwnerIDs are owners whose records are taken over by OwnerID: they are managed as if
+	// owned by OwnerID, and updated to be owned by OwnerID.
+	AdoptedOwnerIDs []string
+	// PersistsLabels tells whether the provider stores the labels of the records alongside them, in which
+	// case the records whose resource changed are updated.
+	PersistsLabels bool
+	// PropertyComparator compares the provider specific properties of the current and desired records.
+	// When nil, they must be exactly the same.
+	PropertyComparator PropertyComparator
+	// ZoneApexes are the names of zone apexes, whose NS records are never updated nor deleted, in
+	// addition to the names of current SOA records and the registrable domains. SOA records are never
+	// updated nor deleted.
+	ZoneApexes []string
 }
 
 // Changes holds lists of actions to be executed by dns providers
@@ -61,12 +103,16 @@ type Changes struct {
 	UpdateNew []*endpoint.Endpoint `json:"updateNew,omitempty"`
 	// Records that need to be deleted
 	Delete []*endpoint.Endpoint `json:"delete,omitempty"`
+	// Reasons are the reasons of the changes, by endpoint of Create, UpdateNew and Delete. They are not sent
+	// to webhook providers.
+	Reasons map[*endpoint.Endpoint][]Reason `json:"-"`
 }
 
 // planKey is a key for a row in `planTable`.
 type planKey struct {
 	dnsName       string
 	setIdentifier string
+	view          string
 }
 
 // planTable is a supplementary struct for Plan
@@ -92,8 +138,8 @@ type planTable struct {
 	resolver ConflictResolver
 }
 
-func newPlanTable() planTable { // TODO: make resolver configurable
-	return planTable{map[planKey]*planTableRow{}, PerResource{}}
+func newPlanTable(size int) planTable { // TODO: make resolver configurable
+	return planTable{make(map[planKey]*planTableRow, size), PerResource{}}
 }
 
 // planTableRow represents a set of current and desired domain resource records.
@@ -124,34 +170,41 @@ func (t planTableRow) String() string {
 }
 
 func (t planTable) addCurrent(e *endpoint.Endpoint) {
-	key := t.newPlanKey(e)
-	t.rows[key].current = append(t.rows[key].current, e)
-	t.rows[key].records[e.RecordType].current = e
+	row, records := t.row(e)
+	row.current = append(row.current, e)
+	records.current = e
 }
 
 func (t planTable) addCandidate(e *endpoint.Endpoint) {
-	key := t.newPlanKey(e)
-	t.rows[key].candidates = append(t.rows[key].candidates, e)
-	t.rows[key].records[e.RecordType].candidates = append(t.rows[key].records[e.RecordType].candidates, e)
+	row, records := t.row(e)
+	row.candidates = append(row.candidates, e)
+	records.candidates = append(records.candidates, e)
 }
 
-func (t *planTable) newPlanKey(e *endpoint.Endpoint) planKey {
+// row returns the row of e and its grouping for the record type of e, creating them when missing.
+// Each endpoint costs a single lookup in the rows and in the record types of its row.
+func (t planTable) row(e *endpoint.Endpoint) (*planTableRow, *domainEndpoints) {
 	key := planKey{
-		dnsName:       normalizeDNSName(e.DNSName),
+		dnsName:       planKeyDNSName(e.DNSName),
 		setIdentifier: e.SetIdentifier,
+		view:          e.DNSView(),
 	}
 
-	if _, ok := t.rows[key]; !ok {
-		t.rows[key] = &planTableRow{
-			records: make(map[string]*domainEndpoints),
+	row, ok := t.rows[key]
+	if !ok {
+		row = &planTableRow{
+			records: make(map[string]*domainEndpoints, 1),
 		}
+		t.rows[key] = row
 	}
 
-	if _, ok := t.rows[key].records[e.RecordType]; !ok {
-		t.rows[key].records[e.RecordType] = &domainEndpoints{}
+	records, ok := row.records[e.RecordType]
+	if !ok {
+		records = &domainEndpoints{}
+		row.records[e.RecordType] = records
 	}
 
-	return key
+	return row, records
 }
 
 func (c *Changes) HasChanges() bool {
@@ -165,7 +218,7 @@ func (c *Changes) HasChanges() bool {
 // state. It then passes those changes to the current policy for further
 // processing. It returns a copy of Plan with the changes populated.
 func (p *Plan) Calculate() *Plan {
-	t := newPlanTable()
+	t := newPlanTable(len(p.Current) + len(p.Desired))
 
 	if p.DomainFilter == nil {
 		p.DomainFilter = endpoint.MatchAllDomainFilters(nil)
@@ -179,6 +232,19 @@ func (p *Plan) Calculate() *Plan {
 	}
 
 	changes := &Changes{}
+	// the reasons of the changes are attached once the policies applied, which may return other changes
+	reasons := &Changes{}
+
+	// records of adopted owners are only taken over for the names this owner desires
+	var desiredNames map[string]bool
+	if len(p.AdoptedOwnerIDs) > 0 {
+		desiredNames = make(map[string]bool, len(t.rows))
+		for key, row := range t.rows {
+			if len(row.candidates) > 0 {
+				desiredNames[key.dnsName] = true
+			}
+		}
+	}
 
 	for key, row := range t.rows {
 		// dns name not taken
@@ -186,14 +252,22 @@ func (p *Plan) Calculate() *Plan {
 			recordsByType := t.resolver.ResolveRecordTypes(key, row)
 			for _, records := range recordsByType {
 				if len(records.candidates) > 0 {
-					changes.Create = append(changes.Create, t.resolver.ResolveCreate(records.candidates))
+					create := t.resolver.ResolveCreate(records.candidates)
+					changes.Create = append(changes.Create, create)
+					reasons.AddReasons(create, Reason{Code: ReasonNewEndpoint, Detail: create.Labels[endpoint.ResourceLabelKey]})
 				}
 			}
 		}
 
 		// dns name released or possibly owned by a different external dns
 		if len(row.current) > 0 && len(row.candidates) == 0 {
-			changes.Delete = append(changes.Delete, row.current...)
+			for _, current := range row.current {
+				if p.isAdopted(current) && !desiredNames[key.dnsName] {
+					continue
+				}
+				changes.Delete = append(changes.Delete, current)
+				reasons.AddReasons(current, Reason{Code: ReasonEndpointRemoved, Detail: current.Labels[endpoint.ResourceLabelKey]})
+			}
 		}
 
 		// dns name is taken
@@ -206,11 +280,13 @@ func (p *Plan) Calculate() *Plan {
 				// record type not desired
 				if records.current != nil && len(records.candidates) == 0 {
 					changes.Delete = append(changes.Delete, records.current)
+					reasons.AddReasons(records.current, Reason{Code: ReasonRecordTypeRemoved})
 				}
 
 				// new record type desired
 				if records.current == nil && len(records.candidates) > 0 {
 					update := t.resolver.ResolveCreate(records.candidates)
+					reasons.AddReasons(update, Reason{Code: ReasonNewRecordType, Detail: update.Labels[endpoint.ResourceLabelKey]})
 					// creates are evaluated after all domain records have been processed to
 					// validate that this external dns has ownership claim on the domain before
 					// adding the records to planned changes.
@@ -221,8 +297,12 @@ func (p *Plan) Calculate() *Plan {
 				if records.current != nil && len(records.candidates) > 0 {
 					update := t.resolver.ResolveUpdate(records.current, records.candidates)
 
-					if shouldUpdateTTL(update, records.current) || targetChanged(update, records.current) || p.shouldUpdateProviderSpecific(update, records.current) {
+					if updateReasons := p.updateReasons(update, records.current); len(updateReasons) > 0 {
+						reasons.AddReasons(update, updateReasons...)
 						inheritOwner(records.current, update)
+						if p.isAdopted(records.current) {
+							update.Labels[endpoint.OwnerLabelKey] = p.OwnerID
+						}
 						changes.UpdateNew = append(changes.UpdateNew, update)
 						changes.UpdateOld = append(changes.UpdateOld, records.current)
 					}
@@ -233,7 +313,7 @@ func (p *Plan) Calculate() *Plan {
 				// only add creates if the external dns has ownership claim on the domain
 				ownersMatch := true
 				for _, current := range row.current {
-					if p.OwnerID != "" && !current.IsOwnedBy(p.OwnerID) {
+					if p.OwnerID != "" && !current.IsOwnedBy(p.OwnerID) && !p.isAdopted(current) {
 						ownersMatch = false
 					}
 				}
@@ -255,10 +335,19 @@ func (p *Plan) Calculate() *Plan {
 
 	// filter out updates this external dns does not have ownership claim over
 	if p.OwnerID != "" {
-		changes.Delete = endpoint.FilterEndpointsByOwnerID(p.OwnerID, changes.Delete)
+		ownerIDs := append([]string{p.OwnerID}, p.AdoptedOwnerIDs...)
+		changes.Delete = endpoint.FilterEndpointsByOwnerIDs(ownerIDs, changes.Delete)
 		changes.Delete = endpoint.RemoveDuplicates(changes.Delete)
-		changes.UpdateOld = endpoint.FilterEndpointsByOwnerID(p.OwnerID, changes.UpdateOld)
-		changes.UpdateNew = endpoint.FilterEndpointsByOwnerID(p.OwnerID, changes.UpdateNew)
+		changes.UpdateOld = endpoint.FilterEndpointsByOwnerIDs(ownerIDs, changes.UpdateOld)
+		changes.UpdateNew = endpoint.FilterEndpointsByOwnerIDs(ownerIDs, changes.UpdateNew)
+	}
+
+	p.protectZoneApexes(changes)
+
+	orderChanges(changes)
+
+	for _, ep := range slices.Concat(changes.Create, changes.UpdateNew, changes.Delete) {
+		changes.AddReasons(ep, reasons.ReasonsOf(ep)...)
 	}
 
 	plan := &Plan{
@@ -273,6 +362,19 @@ func (p *Plan) Calculate() *Plan {
 	return plan
 }
 
+// isAdopted returns whether the endpoint is owned by an adopted owner.
+func (p *Plan) isAdopted(e *endpoint.Endpoint) bool {
+	return p.OwnerID != "" && len(p.AdoptedOwnerIDs) > 0 && slices.Contains(p.AdoptedOwnerIDs, e.Labels[endpoint.OwnerLabelKey])
+}
+
+// resourceChanged returns whether the desired endpoint comes from another resource than the one recorded
+// in the labels of the current endpoint, so that the provider keeps recording which resource the record
+// comes from. It is only the case with a provider persisting labels and a registry, as told by an owner ID.
+func (p *Plan) resourceChanged(desired, current *endpoint.Endpoint) bool {
+	resource := desired.Labels[endpoint.ResourceLabelKey]
+	return p.PersistsLabels && p.OwnerID != "" && resource != "" && resource != current.Labels[endpoint.ResourceLabelKey]
+}
+
 func inheritOwner(from, to *endpoint.Endpoint) {
 	if to.Labels == nil {
 		to.Labels = map[string]string{}
@@ -295,23 +397,46 @@ func shouldUpdateTTL(desired, current *endpoint.Endpoint) bool {
 }
 
 func (p *Plan) shouldUpdateProviderSpecific(desired, current *endpoint.Endpoint) bool {
+	return len(p.changedProviderSpecific(desired, current)) > 0
+}
+
+// changedProviderSpecific returns the sorted names of the provider specific properties differing between
+// the desired and current endpoints.
+func (p *Plan) changedProviderSpecific(desired, current *endpoint.Endpoint) []string {
+	var changed []string
+	if p.PropertyComparator != nil {
+		names := map[string]bool{}
+		for _, property := range slices.Concat(current.ProviderSpecific, desired.ProviderSpecific) {
+			names[property.Name] = true
+		}
+		for name := range names {
+			previous, _ := current.GetProviderSpecificProperty(name)
+			value, _ := desired.GetProviderSpecificProperty(name)
+			if !p.PropertyComparator(name, previous, value) {
+				log.Debugf("Provider specific property %q of %s changed from %q to %q", name, desired.DNSName, previous, value)
+				changed = append(changed, name)
+			}
+		}
+		slices.Sort(changed)
+		return changed
+	}
+
 	desiredProperties := map[string]endpoint.ProviderSpecificProperty{}
 
 	for _, d := range desired.ProviderSpecific {
 		desiredProperties[d.Name] = d
 	}
 	for _, c := range current.ProviderSpecific {
-		if d, ok := desiredProperties[c.Name]; ok {
-			if c.Value != d.Value {
-				return true
-			}
-			delete(desiredProperties, c.Name)
-		} else {
-			return true
+		if d, ok := desiredProperties[c.Name]; !ok || c.Value != d.Value {
+			changed = append(changed, c.Name)
 		}
+		delete(desiredProperties, c.Name)
 	}
-
-	return len(desiredProperties) > 0
+	for name := range desiredProperties {
+		changed = append(changed, name)
+	}
+	slices.Sort(changed)
+	return slices.Compact(changed)
 }
 
 // filterRecordsForPlan removes records that are not relevant to the planner.
@@ -322,7 +447,7 @@ func (p *Plan) shouldUpdateProviderSpecific(desired, current *endpoint.Endpoint)
 // only record with this property. The behavior of the planner may need to be
 // made more sophisticated to codify this.
 func filterRecordsForPlan(records []*endpoint.Endpoint, domainFilter endpoint.MatchAllDomainFilters, managedRecords, excludeRecords []string) []*endpoint.Endpoint {
-	filtered := []*endpoint.Endpoint{}
+	filtered := make([]*endpoint.Endpoint, 0, len(records))
 
 	for _, record := range records {
 		// Ignore records that do not match the domain filter provided
@@ -341,11 +466,13 @@ func filterRecordsForPlan(records []*endpoint.Endpoint, domainFilter endpoint.Ma
 // normalizeDNSName converts a DNS name to a canonical form, so that we can use string equality
 // it: removes space, converts to lower case, ensures there is a trailing dot
 func normalizeDNSName(dnsName string) string {
-	s := strings.TrimSpace(strings.ToLower(dnsName))
-	if !strings.HasSuffix(s, ".") {
-		s += "."
-	}
-	return s
+	return planKeyDNSName(dnsName) + "."
+}
+
+// planKeyDNSName is normalizeDNSName without the trailing dot. It does not allocate
+// for names that are already in lower case, which is the common case for sources.
+func planKeyDNSName(dnsName string) string {
+	return strings.TrimSuffix(strings.TrimSpace(strings.ToLower(dnsName)), ".")
 }
 
 func IsManagedRecord(record string, managedRecords, excludeRecords []string) bool {
@@ -19,10 +19,12 @@ package plan
 import (
 	"bytes"
 	"encoding/json"
+	"fmt"
 	"strings"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 	"github.com/stretchr/testify/suite"
 
 	"sigs.k8s.io/external-dns/endpoint"
@@ -506,6 +508,68 @@ func (suite *PlanTestSuite) TestSyncSecondRoundWithOwnerInherited() {
 	validateEntries(suite.T(), changes.Delete, expectedDelete)
 }
 
+func (suite *PlanTestSuite) TestSyncSecondRoundWithResourceChange() {
+	current := []*endpoint.Endpoint{suite.fooV1Cname}
+	desired := []*endpoint.Endpoint{{
+		DNSName:    suite.fooV1Cname.DNSName,
+		Targets:    suite.fooV1Cname.Targets,
+		RecordType: suite.fooV1Cname.RecordType,
+		Labels: map[string]string{
+			endpoint.ResourceLabelKey: "ingress/default/foo-v1-renamed",
+		},
+	}}
+
+	expectedUpdateOld := []*endpoint.Endpoint{suite.fooV1Cname}
+	expectedUpdateNew := []*endpoint.Endpoint{{
+		DNSName:    suite.fooV1Cname.DNSName,
+		Targets:    suite.fooV1Cname.Targets,
+		RecordType: suite.fooV1Cname.RecordType,
+		Labels: map[string]string{
+			endpoint.ResourceLabelKey: "ingress/default/foo-v1-renamed",
+			endpoint.OwnerLabelKey:    "pwner",
+		},
+	}}
+
+	p := &Plan{
+		Policies:       []Policy{&SyncPolicy{}},
+		Current:        current,
+		Desired:        desired,
+		ManagedRecords: []string{endpoint.RecordTypeA, endpoint.RecordTypeCNAME},
+		OwnerID:        "pwner",
+		PersistsLabels: true,
+	}
+
+	changes := p.Calculate().Changes
+	validateEntries(suite.T(), changes.Create, []*endpoint.Endpoint{})
+	validateEntries(suite.T(), changes.UpdateNew, expectedUpdateNew)
+	validateEntries(suite.T(), changes.UpdateOld, expectedUpdateOld)
+	validateEntries(suite.T(), changes.Delete, []*endpoint.Endpoint{})
+
+	// Without a provider persisting labels, the resource is not compared.
+	p.PersistsLabels = false
+	changes = p.Calculate().Changes
+	validateEntries(suite.T(), changes.Create, []*endpoint.Endpoint{})
+	validateEntries(suite.T(), changes.UpdateNew, []*endpoint.Endpoint{})
+	validateEntries(suite.T(), changes.UpdateOld, []*endpoint.Endpoint{})
+	validateEntries(suite.T(), changes.Delete, []*endpoint.Endpoint{})
+	p.PersistsLabels = true
+
+	// Without a registry persisting labels, the resource is not compared.
+	p.OwnerID = ""
+	changes = p.Calculate().Changes
+	validateEntries(suite.T(), changes.UpdateNew, []*endpoint.Endpoint{})
+
+	// Nor is it when the desired endpoint does not tell its resource.
+	p.OwnerID = "pwner"
+	p.Desired = []*endpoint.Endpoint{{
+		DNSName:    suite.fooV1Cname.DNSName,
+		Targets:    suite.fooV1Cname.Targets,
+		RecordType: suite.fooV1Cname.RecordType,
+	}}
+	changes = p.Calculate().Changes
+	validateEntries(suite.T(), changes.UpdateNew, []*endpoint.Endpoint{})
+}
+
 func (suite *PlanTestSuite) TestIdempotency() {
 	current := []*endpoint.Endpoint{suite.fooV1Cname, suite.fooV2Cname}
 	desired := []*endpoint.Endpoint{suite.fooV1Cname, suite.fooV2Cname}
@@ -1160,3 +1224,219 @@ func TestShouldUpdateProviderSpecific(tt *testing.T) {
 		})
 	}
 }
+
+func BenchmarkCalculate(b *testing.B) {
+	current, desired := benchmarkEndpoints(50000)
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		p := &Plan{
+			Policies:       []Policy{&SyncPolicy{}},
+			Current:        current,
+			Desired:        desired,
+			ManagedRecords: []string{endpoint.RecordTypeA, endpoint.RecordTypeAAAA, endpoint.RecordTypeCNAME},
+			OwnerID:        "owner",
+		}
+		p.Calculate()
+	}
+}
+
+// benchmarkEndpoints returns n current and n desired endpoints owned by the same
+// owner, of which a tenth are updated, a tenth deleted and a tenth created.
+func benchmarkEndpoints(n int) (current, desired []*endpoint.Endpoint) {
+	for i := 0; i < n; i++ {
+		name := fmt.Sprintf("svc-%d.ns-%d.example.com", i, i%100)
+		target := fmt.Sprintf("10.%d.%d.%d", i>>16&0xff, i>>8&0xff, i&0xff)
+		cur := endpoint.NewEndpoint(name, endpoint.RecordTypeA, target)
+		cur.Labels[endpoint.OwnerLabelKey] = "owner"
+		des := endpoint.NewEndpoint(name, endpoint.RecordTypeA, target)
+		switch i % 10 {
+		case 0:
+			des.Targets = endpoint.Targets{"192.0.2.1"}
+		case 1:
+			des = nil
+		case 2:
+			cur = nil
+		}
+		if cur != nil {
+			current = append(current, cur)
+		}
+		if des != nil {
+			desired = append(desired, des)
+		}
+	}
+	return current, desired
+}
+
+func TestAdoptedOwners(t *testing.T) {
+	owned := func(e *endpoint.Endpoint, owner string) *endpoint.Endpoint {
+		e.Labels = endpoint.Labels{endpoint.OwnerLabelKey: owner}
+		return e
+	}
+	current := []*endpoint.Endpoint{
+		owned(endpoint.NewEndpoint("changed.example.com", endpoint.RecordTypeA, "1.1.1.1"), "blue"),
+		owned(endpoint.NewEndpoint("same.example.com", endpoint.RecordTypeA, "1.1.1.1"), "blue"),
+		owned(endpoint.NewEndpoint("weighted.example.com", endpoint.RecordTypeA, "1.1.1.1").WithSetIdentifier("blue"), "blue"),
+		owned(endpoint.NewEndpoint("undesired.example.com", endpoint.RecordTypeA, "1.1.1.1"), "blue"),
+		owned(endpoint.NewEndpoint("stranger.example.com", endpoint.RecordTypeA, "1.1.1.1"), "red"),
+	}
+	desired := []*endpoint.Endpoint{
+		endpoint.NewEndpoint("changed.example.com", endpoint.RecordTypeA, "2.2.2.2"),
+		endpoint.NewEndpoint("same.example.com", endpoint.RecordTypeA, "1.1.1.1"),
+		endpoint.NewEndpoint("weighted.example.com", endpoint.RecordTypeA, "2.2.2.2").WithSetIdentifier("green"),
+		endpoint.NewEndpoint("stranger.example.com", endpoint.RecordTypeA, "2.2.2.2"),
+	}
+
+	p := &Plan{
+		Policies:        []Policy{&SyncPolicy{}},
+		Current:         current,
+		Desired:         desired,
+		ManagedRecords:  []string{endpoint.RecordTypeA},
+		OwnerID:         "green",
+		AdoptedOwnerIDs: []string{"blue"},
+	}
+	changes := p.Calculate().Changes
+
+	validateEntries(t, changes.Create, []*endpoint.Endpoint{desired[2]})
+	validateEntries(t, changes.UpdateOld, []*endpoint.Endpoint{current[0], current[1]})
+	validateEntries(t, changes.UpdateNew, []*endpoint.Endpoint{desired[0], desired[1]})
+	validateEntries(t, changes.Delete, []*endpoint.Endpoint{current[2]})
+	for _, e := range changes.UpdateNew {
+		assert.Equal(t, "green", e.Labels[endpoint.OwnerLabelKey])
+	}
+
+	// without adoption, the records of other owners are left alone
+	p.AdoptedOwnerIDs = nil
+	changes = p.Calculate().Changes
+	validateEntries(t, changes.Create, []*endpoint.Endpoint{desired[2]})
+	assert.Empty(t, changes.UpdateOld)
+	assert.Empty(t, changes.UpdateNew)
+	assert.Empty(t, changes.Delete)
+}
+
+func TestPlanReasons(t *testing.T) {
+	withResource := func(e *endpoint.Endpoint, resource string) *endpoint.Endpoint {
+		e.Labels[endpoint.ResourceLabelKey] = resource
+		return e
+	}
+	current := []*endpoint.Endpoint{
+		withResource(endpoint.NewEndpointWithTTL("changed.example.com", endpoint.RecordTypeA, 300, "1.1.1.1", "2.2.2.2"), "ingress/default/app"),
+		withResource(endpoint.NewEndpoint("gone.example.com", endpoint.RecordTypeA, "1.1.1.1"), "ingress/default/gone"),
+		endpoint.NewEndpoint("property.example.com", endpoint.RecordTypeCNAME, "lb.example.com").WithProviderSpecific("alias", "false"),
+	}
+	desired := []*endpoint.Endpoint{
+		withResource(endpoint.NewEndpointWithTTL("changed.example.com", endpoint.RecordTypeA, 60, "1.1.1.1", "3.3.3.3"), "ingress/default/app"),
+		withResource(endpoint.NewEndpoint("new.example.com", endpoint.RecordTypeA, "1.1.1.1"), "ingress/default/new"),
+		endpoint.NewEndpoint("property.example.com", endpoint.RecordTypeCNAME, "lb.example.com").WithProviderSpecific("alias", "true"),
+	}
+
+	p := &Plan{
+		Policies:       []Policy{&SyncPolicy{}},
+		Current:        current,
+		Desired:        desired,
+		ManagedRecords: []string{endpoint.RecordTypeA, endpoint.RecordTypeCNAME},
+	}
+	changes := p.Calculate().Changes
+
+	require.Len(t, changes.Create, 1)
+	assert.Equal(t, []Reason{{Code: ReasonNewEndpoint, Detail: "ingress/default/new"}}, changes.ReasonsOf(changes.Create[0]))
+	require.Len(t, changes.Delete, 1)
+	assert.Equal(t, []Reason{{Code: ReasonEndpointRemoved, Detail: "ingress/default/gone"}}, changes.ReasonsOf(changes.Delete[0]))
+	require.Len(t, changes.UpdateNew, 2)
+	for _, ep := range changes.UpdateNew {
+		switch ep.DNSName {
+		case "changed.example.com":
+			assert.Equal(t, []Reason{
+				{Code: ReasonTTLChanged, Detail: "300 -> 60"},
+				{Code: ReasonTargetAdded, Detail: "3.3.3.3"},
+				{Code: ReasonTargetRemoved, Detail: "2.2.2.2"},
+			}, changes.ReasonsOf(ep))
+			assert.Equal(t, "TTLChanged 300 -> 60, TargetAdded 3.3.3.3, TargetRemoved 2.2.2.2", JoinReasons(changes.ReasonsOf(ep)))
+		case "property.example.com":
+			assert.Equal(t, []Reason{{Code: ReasonPropertyChanged, Detail: "alias"}}, changes.ReasonsOf(ep))
+		}
+	}
+	for _, ep := range changes.UpdateOld {
+		assert.Empty(t, changes.ReasonsOf(ep))
+	}
+}
+
+func TestPropertyRules(t *testing.T) {
+	compare := PropertyRules{
+		Significant: []string{"weight", "proxied"},
+		Defaults:    map[string]string{"weight": "10"},
+		Ignored:     []string{"proxied"},
+	}.Comparator()
+
+	assert.True(t, compare("weight", "10", "10"))
+	assert.False(t, compare("weight", "10", "20"))
+	assert.True(t, compare("weight", "", "10"), "unset equals the default")
+	assert.True(t, compare("weight", "10", ""), "unset equals the default")
+	assert.False(t, compare("weight", "", "20"))
+	assert.True(t, compare("proxied", "true", "false"), "ignored")
+	assert.True(t, compare("region", "us-east-1", "eu-west-1"), "not significant")
+
+	compare = PropertyRules{}.Comparator()
+	assert.False(t, compare("region", "", "eu-west-1"))
+	assert.True(t, compare("region", "eu-west-1", "eu-west-1"))
+}
+
+func TestPlanPropertyComparator(t *testing.T) {
+	current := []*endpoint.Endpoint{
+		endpoint.NewEndpoint("defaulted.example.com", endpoint.RecordTypeA, "1.1.1.1").WithProviderSpecific("weight", "10"),
+		endpoint.NewEndpoint("other.example.com", endpoint.RecordTypeA, "1.1.1.1"),
+		endpoint.NewEndpoint("changed.example.com", endpoint.RecordTypeA, "1.1.1.1").WithProviderSpecific("weight", "10"),
+	}
+	desired := []*endpoint.Endpoint{
+		endpoint.NewEndpoint("defaulted.example.com", endpoint.RecordTypeA, "1.1.1.1"),
+		endpoint.NewEndpoint("other.example.com", endpoint.RecordTypeA, "1.1.1.1").WithProviderSpecific("aws/region", "us-east-1"),
+		endpoint.NewEndpoint("changed.example.com", endpoint.RecordTypeA, "1.1.1.1").WithProviderSpecific("weight", "20"),
+	}
+
+	p := &Plan{
+		Policies:       []Policy{&SyncPolicy{}},
+		Current:        current,
+		Desired:        desired,
+		ManagedRecords: []string{endpoint.RecordTypeA},
+	}
+	changed := []*endpoint.Endpoint{current[2]}
+	changedDesired := []*endpoint.Endpoint{desired[2]}
+	changes := p.Calculate().Changes
+	assert.Len(t, changes.UpdateNew, 3)
+
+	p.PropertyComparator = PropertyRules{
+		Significant: []string{"weight"},
+		Defaults:    map[string]string{"weight": "10"},
+	}.Comparator()
+	changes = p.Calculate().Changes
+	validateEntries(t, changes.UpdateOld, changed)
+	validateEntries(t, changes.UpdateNew, changedDesired)
+}
+
+func TestPlanDNSViews(t *testing.T) {
+	current := []*endpoint.Endpoint{
+		endpoint.NewEndpoint("app.example.com", endpoint.RecordTypeA, "1.1.1.1"),
+		endpoint.NewEndpoint("app.example.com", endpoint.RecordTypeA, "10.0.0.1").WithProviderSpecific(endpoint.DNSViewProperty, "internal"),
+	}
+	for _, ep := range current {
+		ep.Labels[endpoint.OwnerLabelKey] = "owner"
+	}
+	desired := []*endpoint.Endpoint{
+		endpoint.NewEndpoint("app.example.com", endpoint.RecordTypeA, "1.1.1.1"),
+		endpoint.NewEndpoint("app.example.com", endpoint.RecordTypeA, "10.0.0.2").WithProviderSpecific(endpoint.DNSViewProperty, "internal"),
+		endpoint.NewEndpoint("app.example.com", endpoint.RecordTypeA, "10.0.1.1").WithProviderSpecific(endpoint.DNSViewProperty, "lab"),
+	}
+
+	p := &Plan{
+		Policies:       []Policy{&SyncPolicy{}},
+		Current:        current,
+		Desired:        desired,
+		ManagedRecords: []string{endpoint.RecordTypeA},
+		OwnerID:        "owner",
+	}
+	changes := p.Calculate().Changes
+	validateEntries(t, changes.Create, []*endpoint.Endpoint{desired[2]})
+	validateEntries(t, changes.UpdateOld, []*endpoint.Endpoint{current[1]})
+	validateEntries(t, changes.UpdateNew, []*endpoint.Endpoint{desired[1]})
+	assert.Empty(t, changes.Delete)
+}
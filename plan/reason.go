@@ -0,0 +1,136 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package plan
+
+import (
+	"fmt"
+	"strings"
+
+	"sigs.k8s.io/external-dns/endpoint"
+)
+
+// Codes of the reasons of the changes.
+const (
+	// ReasonNewEndpoint is the reason of the creation of a record at a name without records, its detail being
+	// the resource of the desired endpoint.
+	ReasonNewEndpoint = "NewEndpoint"
+	// ReasonNewRecordType is the reason of the creation of a record of a type not yet at its name.
+	ReasonNewRecordType = "NewRecordType"
+	// ReasonEndpointRemoved is the reason of the deletion of a record at a name no endpoint is desired at anymore,
+	// its detail being the resource the record came from.
+	ReasonEndpointRemoved = "EndpointRemoved"
+	// ReasonRecordTypeRemoved is the reason of the deletion of a record of a type not desired at its name anymore.
+	ReasonRecordTypeRemoved = "RecordTypeRemoved"
+	// ReasonTTLChanged is the reason of an update changing the TTL, its detail being the previous and new TTLs.
+	ReasonTTLChanged = "TTLChanged"
+	// ReasonTargetAdded and ReasonTargetRemoved are the reasons of an update adding or removing a target,
+	// their detail.
+	ReasonTargetAdded   = "TargetAdded"
+	ReasonTargetRemoved = "TargetRemoved"
+	// ReasonTargetsChanged is the reason of an update changing the number of times targets are repeated.
+	ReasonTargetsChanged = "TargetsChanged"
+	// ReasonPropertyChanged is the reason of an update changing a provider specific property, its detail.
+	ReasonPropertyChanged = "PropertyChanged"
+	// ReasonOwnerMismatch is the reason of an update taking over a record of an adopted owner, its detail.
+	ReasonOwnerMismatch = "OwnerMismatch"
+	// ReasonResourceChanged is the reason of an update recording that a record comes from another resource,
+	// its detail being the previous and new resources.
+	ReasonResourceChanged = "ResourceChanged"
+)
+
+// Reason is a machine-readable reason of a change, e.g. {TargetAdded 192.0.2.1}.
+type Reason struct {
+	Code   string `json:"code"`
+	Detail string `json:"detail,omitempty"`
+}
+
+func (r Reason) String() string {
+	if r.Detail == "" {
+		return r.Code
+	}
+	return r.Code + " " + r.Detail
+}
+
+// JoinReasons returns the reasons separated by commas.
+func JoinReasons(reasons []Reason) string {
+	s := make([]string, 0, len(reasons))
+	for _, r := range reasons {
+		s = append(s, r.String())
+	}
+	return strings.Join(s, ", ")
+}
+
+// ReasonsOf returns the reasons of the change of ep, an endpoint of Create, UpdateNew or Delete.
+func (c *Changes) ReasonsOf(ep *endpoint.Endpoint) []Reason {
+	return c.Reasons[ep]
+}
+
+// AddReasons adds reasons to the change of ep, an endpoint of Create, UpdateNew or Delete.
+func (c *Changes) AddReasons(ep *endpoint.Endpoint, reasons ...Reason) {
+	if len(reasons) == 0 {
+		return
+	}
+	if c.Reasons == nil {
+		c.Reasons = map[*endpoint.Endpoint][]Reason{}
+	}
+	c.Reasons[ep] = append(c.Reasons[ep], reasons...)
+}
+
+// updateReasons returns the reasons of the update of current to desired.
+func (p *Plan) updateReasons(desired, current *endpoint.Endpoint) []Reason {
+	var reasons []Reason
+	if shouldUpdateTTL(desired, current) {
+		reasons = append(reasons, Reason{Code: ReasonTTLChanged, Detail: fmt.Sprintf("%d -> %d", current.RecordTTL, desired.RecordTTL)})
+	}
+	if targetChanged(desired, current) {
+		n := len(reasons)
+		for _, t := range desired.Targets {
+			if !containsTarget(current.Targets, t) {
+				reasons = append(reasons, Reason{Code: ReasonTargetAdded, Detail: t})
+			}
+		}
+		for _, t := range current.Targets {
+			if !containsTarget(desired.Targets, t) {
+				reasons = append(reasons, Reason{Code: ReasonTargetRemoved, Detail: t})
+			}
+		}
+		if len(reasons) == n {
+			// the same targets, but not as many times
+			reasons = append(reasons, Reason{Code: ReasonTargetsChanged})
+		}
+	}
+	for _, name := range p.changedProviderSpecific(desired, current) {
+		reasons = append(reasons, Reason{Code: ReasonPropertyChanged, Detail: name})
+	}
+	if p.isAdopted(current) {
+		reasons = append(reasons, Reason{Code: ReasonOwnerMismatch, Detail: fmt.Sprintf("owned by %q, adopted by %q", current.Labels[endpoint.OwnerLabelKey], p.OwnerID)})
+	}
+	if p.resourceChanged(desired, current) {
+		reasons = append(reasons, Reason{Code: ReasonResourceChanged, Detail: current.Labels[endpoint.ResourceLabelKey] + " -> " + desired.Labels[endpoint.ResourceLabelKey]})
+	}
+	return reasons
+}
+
+// containsTarget returns whether targets contain target, compared the way Targets.Same does.
+func containsTarget(targets endpoint.Targets, target string) bool {
+	for _, t := range targets {
+		if (endpoint.Targets{t}).Same(endpoint.Targets{target}) {
+			return true
+		}
+	}
+	return false
+}
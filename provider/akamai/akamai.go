@@ -254,7 +254,7 @@ func (p AkamaiProvider) Records(context.Context) (endpoints []*endpoint.Endpoint
 
 // ApplyChanges applies a given set of changes in a given zone.
 func (p AkamaiProvider) ApplyChanges(ctx context.Context, changes *plan.Changes) error {
-	zoneNameIDMapper := provider.ZoneIDName{}
+	zoneNameIDMapper := p.NewZoneFinder()
 	zones, err := p.fetchZones()
 	if err != nil {
 		log.Errorf("Failed to fetch zones from Akamai")
@@ -262,7 +262,7 @@ func (p AkamaiProvider) ApplyChanges(ctx context.Context, changes *plan.Changes)
 	}
 
 	for _, z := range zones.Zones {
-		zoneNameIDMapper[z.Zone] = z.Zone
+		zoneNameIDMapper.Add(z.Zone, z.Zone)
 	}
 	log.Debugf("Processing zones: [%v]", zoneNameIDMapper)
 
@@ -361,7 +361,7 @@ func ttlAsInt(src endpoint.TTL) int {
 }
 
 // Create Endpoint Recordsets
-func (p AkamaiProvider) createRecordsets(zoneNameIDMapper provider.ZoneIDName, endpoints []*endpoint.Endpoint) error {
+func (p AkamaiProvider) createRecordsets(zoneNameIDMapper provider.ZoneFinder, endpoints []*endpoint.Endpoint) error {
 	if len(endpoints) == 0 {
 		log.Info("No endpoints to create")
 		return nil
@@ -402,7 +402,7 @@ func (p AkamaiProvider) createRecordsets(zoneNameIDMapper provider.ZoneIDName, e
 	return nil
 }
 
-func (p AkamaiProvider) deleteRecordsets(zoneNameIDMapper provider.ZoneIDName, endpoints []*endpoint.Endpoint) error {
+func (p AkamaiProvider) deleteRecordsets(zoneNameIDMapper provider.ZoneFinder, endpoints []*endpoint.Endpoint) error {
 	for _, endpoint := range endpoints {
 		zoneName, _ := zoneNameIDMapper.FindZone(endpoint.DNSName)
 		if zoneName == "" {
@@ -434,7 +434,7 @@ func (p AkamaiProvider) deleteRecordsets(zoneNameIDMapper provider.ZoneIDName, e
 }
 
 // Update endpoint recordsets
-func (p AkamaiProvider) updateNewRecordsets(zoneNameIDMapper provider.ZoneIDName, endpoints []*endpoint.Endpoint) error {
+func (p AkamaiProvider) updateNewRecordsets(zoneNameIDMapper provider.ZoneFinder, endpoints []*endpoint.Endpoint) error {
 	for _, endpoint := range endpoints {
 		zoneName, _ := zoneNameIDMapper.FindZone(endpoint.DNSName)
 		if zoneName == "" {
@@ -465,9 +465,9 @@ func (p AkamaiProvider) updateNewRecordsets(zoneNameIDMapper provider.ZoneIDName
 }
 
 // edgeChangesByZone separates a multi-zone change into a single change per zone.
-func edgeChangesByZone(zoneMap provider.ZoneIDName, endpoints []*endpoint.Endpoint) map[string][]*endpoint.Endpoint {
-	createsByZone := make(map[string][]*endpoint.Endpoint, len(zoneMap))
-	for _, z := range zoneMap {
+func edgeChangesByZone(zoneMap provider.ZoneFinder, endpoints []*endpoint.Endpoint) map[string][]*endpoint.Endpoint {
+	createsByZone := make(map[string][]*endpoint.Endpoint, len(zoneMap.ZoneIDName))
+	for _, z := range zoneMap.ZoneIDName {
 		createsByZone[z] = make([]*endpoint.Endpoint, 0)
 	}
 	for _, ep := range endpoints {
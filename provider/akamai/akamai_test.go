@@ -258,7 +258,7 @@ func TestAkamaiRecordsFilters(t *testing.T) {
 }
 
 // TestCreateRecords tests create function
-// (p AkamaiProvider) createRecordsets(zoneNameIDMapper provider.ZoneIDName, endpoints []*endpoint.Endpoint) error
+// (p AkamaiProvider) createRecordsets(zoneNameIDMapper provider.ZoneFinder, endpoints []*endpoint.Endpoint) error
 func TestCreateRecords(t *testing.T) {
 	stub := newStub()
 	domfilter := endpoint.DomainFilter{}
@@ -266,7 +266,7 @@ func TestCreateRecords(t *testing.T) {
 	c, err := createAkamaiStubProvider(stub, domfilter, idfilter)
 	assert.Nil(t, err)
 
-	zoneNameIDMapper := provider.ZoneIDName{"example.com": "example.com"}
+	zoneNameIDMapper := provider.ZoneFinder{ZoneIDName: provider.ZoneIDName{"example.com": "example.com"}}
 	endpoints := make([]*endpoint.Endpoint, 0)
 	endpoints = append(endpoints, endpoint.NewEndpoint("www.example.com", endpoint.RecordTypeA, "10.0.0.2", "10.0.0.3"))
 	endpoints = append(endpoints, endpoint.NewEndpoint("www.example.com", endpoint.RecordTypeTXT, "heritage=external-dns,external-dns/owner=default"))
@@ -282,7 +282,7 @@ func TestCreateRecordsDomainFilter(t *testing.T) {
 	c, err := createAkamaiStubProvider(stub, domfilter, idfilter)
 	assert.Nil(t, err)
 
-	zoneNameIDMapper := provider.ZoneIDName{"example.com": "example.com"}
+	zoneNameIDMapper := provider.ZoneFinder{ZoneIDName: provider.ZoneIDName{"example.com": "example.com"}}
 	endpoints := make([]*endpoint.Endpoint, 0)
 	endpoints = append(endpoints, endpoint.NewEndpoint("www.example.com", endpoint.RecordTypeA, "10.0.0.2", "10.0.0.3"))
 	endpoints = append(endpoints, endpoint.NewEndpoint("www.example.com", endpoint.RecordTypeTXT, "heritage=external-dns,external-dns/owner=default"))
@@ -300,7 +300,7 @@ func TestDeleteRecords(t *testing.T) {
 	c, err := createAkamaiStubProvider(stub, domfilter, idfilter)
 	assert.Nil(t, err)
 
-	zoneNameIDMapper := provider.ZoneIDName{"example.com": "example.com"}
+	zoneNameIDMapper := provider.ZoneFinder{ZoneIDName: provider.ZoneIDName{"example.com": "example.com"}}
 	endpoints := make([]*endpoint.Endpoint, 0)
 	endpoints = append(endpoints, endpoint.NewEndpoint("www.example.com", endpoint.RecordTypeA, "10.0.0.2", "10.0.0.3"))
 	endpoints = append(endpoints, endpoint.NewEndpoint("www.example.com", endpoint.RecordTypeTXT, "heritage=external-dns,external-dns/owner=default"))
@@ -316,7 +316,7 @@ func TestDeleteRecordsDomainFilter(t *testing.T) {
 	c, err := createAkamaiStubProvider(stub, domfilter, idfilter)
 	assert.Nil(t, err)
 
-	zoneNameIDMapper := provider.ZoneIDName{"example.com": "example.com"}
+	zoneNameIDMapper := provider.ZoneFinder{ZoneIDName: provider.ZoneIDName{"example.com": "example.com"}}
 	endpoints := make([]*endpoint.Endpoint, 0)
 	endpoints = append(endpoints, endpoint.NewEndpoint("www.example.com", endpoint.RecordTypeA, "10.0.0.2", "10.0.0.3"))
 	endpoints = append(endpoints, endpoint.NewEndpoint("www.example.com", endpoint.RecordTypeTXT, "heritage=external-dns,external-dns/owner=default"))
@@ -334,7 +334,7 @@ func TestUpdateRecords(t *testing.T) {
 	c, err := createAkamaiStubProvider(stub, domfilter, idfilter)
 	assert.Nil(t, err)
 
-	zoneNameIDMapper := provider.ZoneIDName{"example.com": "example.com"}
+	zoneNameIDMapper := provider.ZoneFinder{ZoneIDName: provider.ZoneIDName{"example.com": "example.com"}}
 	endpoints := make([]*endpoint.Endpoint, 0)
 	endpoints = append(endpoints, endpoint.NewEndpoint("www.example.com", endpoint.RecordTypeA, "10.0.0.2", "10.0.0.3"))
 	endpoints = append(endpoints, endpoint.NewEndpoint("www.example.com", endpoint.RecordTypeTXT, "heritage=external-dns,external-dns/owner=default"))
@@ -350,7 +350,7 @@ func TestUpdateRecordsDomainFilter(t *testing.T) {
 	c, err := createAkamaiStubProvider(stub, domfilter, idfilter)
 	assert.Nil(t, err)
 
-	zoneNameIDMapper := provider.ZoneIDName{"example.com": "example.com"}
+	zoneNameIDMapper := provider.ZoneFinder{ZoneIDName: provider.ZoneIDName{"example.com": "example.com"}}
 	endpoints := make([]*endpoint.Endpoint, 0)
 	endpoints = append(endpoints, endpoint.NewEndpoint("www.example.com", endpoint.RecordTypeA, "10.0.0.2", "10.0.0.3"))
 	endpoints = append(endpoints, endpoint.NewEndpoint("www.example.com", endpoint.RecordTypeTXT, "heritage=external-dns,external-dns/owner=default"))
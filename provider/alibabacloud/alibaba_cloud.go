@@ -44,6 +44,10 @@ const (
 	nullHostAlibabaCloud                    = "@"
 	pVTZDoamin                              = "pvtz.aliyuncs.com"
 	defaultAlibabaCloudRequestScheme        = "https"
+
+	// providerSpecificVPCIDs lists the VPCs, besides the one of the configuration, a private
+	// zone is bound to, as comma separated vpc-id or region-id:vpc-id entries.
+	providerSpecificVPCIDs = "alibabacloud/vpc-ids"
 )
 
 // AlibabaCloudDNSAPI is a minimal implementation of DNS API that we actually use, used primarily for unit testing.
@@ -65,6 +69,7 @@ type AlibabaCloudPrivateZoneAPI interface {
 	DescribeZoneRecords(request *pvtz.DescribeZoneRecordsRequest) (response *pvtz.DescribeZoneRecordsResponse, err error)
 	DescribeZones(request *pvtz.DescribeZonesRequest) (response *pvtz.DescribeZonesResponse, err error)
 	DescribeZoneInfo(request *pvtz.DescribeZoneInfoRequest) (response *pvtz.DescribeZoneInfoResponse, err error)
+	BindZoneVpc(request *pvtz.BindZoneVpcRequest) (response *pvtz.BindZoneVpcResponse, err error)
 }
 
 // AlibabaCloudProvider implements the DNS provider for Alibaba Cloud.
@@ -76,6 +81,9 @@ type AlibabaCloudProvider struct {
 	EvaluateTargetHealth bool
 	AssumeRole           string
 	vpcID                string // Private Zone only
+	regionID             string
+	manageVPCs           bool     // Private Zone only
+	privateZoneNames     []string // Private Zone only, as of the last listing
 	dryRun               bool
 	dnsClient            AlibabaCloudDNSAPI
 	pvtzClient           AlibabaCloudPrivateZoneAPI
@@ -94,10 +102,11 @@ type alibabaCloudConfig struct {
 	ExpireTime      time.Time `json:"-" yaml:"-"`
 }
 
-// NewAlibabaCloudProvider creates a new Alibaba Cloud provider.
+// NewAlibabaCloudProvider creates a new Alibaba Cloud provider. When manageVPCs is set, the
+// VPCs private zones are bound to are managed through the alibabacloud/vpc-ids property.
 //
 // Returns the provider or an error if a provider could not be created.
-func NewAlibabaCloudProvider(configFile string, domainFilter endpoint.DomainFilter, zoneIDFileter provider.ZoneIDFilter, zoneType string, dryRun bool) (*AlibabaCloudProvider, error) {
+func NewAlibabaCloudProvider(configFile string, domainFilter endpoint.DomainFilter, zoneIDFileter provider.ZoneIDFilter, zoneType string, manageVPCs bool, dryRun bool) (*AlibabaCloudProvider, error) {
 	cfg := alibabaCloudConfig{}
 	if configFile != "" {
 		contents, err := os.ReadFile(configFile)
@@ -164,6 +173,8 @@ func NewAlibabaCloudProvider(configFile string, domainFilter endpoint.DomainFilt
 		domainFilter: domainFilter,
 		zoneIDFilter: zoneIDFileter,
 		vpcID:        cfg.VPCID,
+		regionID:     cfg.RegionID,
+		manageVPCs:   manageVPCs,
 		dryRun:       dryRun,
 		dnsClient:    dnsClient,
 		pvtzClient:   pvtzClient,
@@ -687,7 +698,8 @@ func (p *AlibabaCloudProvider) splitDNSName(dnsName string, hostedZoneDomains []
 	return rr, domain
 }
 
-func (p *AlibabaCloudProvider) matchVPC(zoneID string) bool {
+// matchVPC returns the VPCs the zone is bound to, and whether the VPC of the configuration is one of them.
+func (p *AlibabaCloudProvider) matchVPC(zoneID string) ([]pvtz.Vpc, bool) {
 	request := pvtz.CreateDescribeZoneInfoRequest()
 	request.ZoneId = zoneID
 	request.Domain = pVTZDoamin
@@ -695,16 +707,17 @@ func (p *AlibabaCloudProvider) matchVPC(zoneID string) bool {
 	response, err := p.getPvtzClient().DescribeZoneInfo(request)
 	if err != nil {
 		log.Errorf("Failed to describe zone info %s in Alibaba Cloud DNS: %v", zoneID, err)
-		return false
+		return nil, false
 	}
+	var vpcs []pvtz.Vpc
 	foundVPC := false
 	for _, vpc := range response.BindVpcs.Vpc {
 		if vpc.VpcId == p.vpcID {
 			foundVPC = true
-			break
 		}
+		vpcs = append(vpcs, pvtz.Vpc{RegionId: vpc.RegionId, VpcId: vpc.VpcId, VpcType: vpc.VpcType})
 	}
-	return foundVPC
+	return vpcs, foundVPC
 }
 
 func (p *AlibabaCloudProvider) privateZones() ([]pvtz.Zone, error) {
@@ -730,9 +743,11 @@ func (p *AlibabaCloudProvider) privateZones() ([]pvtz.Zone, error) {
 			if !p.domainFilter.Match(zone.ZoneName) {
 				continue
 			}
-			if !p.matchVPC(zone.ZoneId) {
+			vpcs, ok := p.matchVPC(zone.ZoneId)
+			if !ok {
 				continue
 			}
+			zone.Vpcs.Vpc = vpcs
 			zones = append(zones, zone)
 		}
 		nextPage := getNextPageNumber(int64(response.PageNumber), defaultAlibabaCloudPageSize, int64(response.TotalItems))
@@ -803,6 +818,7 @@ func (p *AlibabaCloudProvider) getPrivateZones() (map[string]*alibabaPrivateZone
 		result[zone.ZoneName] = &privateZone
 	}
 	log.Infof("Found %d Alibaba Cloud Private Zone record(s).", recordsCount)
+	p.privateZoneNames = keys(result)
 	return result, nil
 }
 
@@ -828,6 +844,10 @@ func (p *AlibabaCloudProvider) privateZoneRecords() (endpoints []*endpoint.Endpo
 	}
 
 	for _, zone := range zones {
+		vpcIDs := ""
+		if p.manageVPCs {
+			vpcIDs = formatVPCIDs(p.additionalVPCs(zone.Vpcs.Vpc))
+		}
 		recordMap := p.groupPrivateZoneRecords(zone)
 		for _, recordList := range recordMap {
 			name := p.getDNSName(recordList[0].Rr, zone.ZoneName)
@@ -845,6 +865,9 @@ func (p *AlibabaCloudProvider) privateZoneRecords() (endpoints []*endpoint.Endpo
 				targets = append(targets, target)
 			}
 			ep := endpoint.NewEndpointWithTTL(name, recordType, endpoint.TTL(ttl), targets...)
+			if vpcIDs != "" {
+				ep.SetProviderSpecificProperty(providerSpecificVPCIDs, vpcIDs)
+			}
 			endpoints = append(endpoints, ep)
 		}
 	}
@@ -973,6 +996,9 @@ func (p *AlibabaCloudProvider) applyChangesForPrivateZone(changes *plan.Changes)
 	p.createPrivateZoneRecords(zones, changes.Create)
 	p.deletePrivateZoneRecords(zones, changes.Delete)
 	p.updatePrivateZoneRecords(zones, changes.UpdateNew)
+	if p.manageVPCs {
+		return p.bindPrivateZoneVPCs(zones, append(changes.Create, changes.UpdateNew...))
+	}
 	return nil
 }
 
@@ -1077,3 +1103,142 @@ func keys[T any](value map[string]T) []string {
 	}
 	return results
 }
+
+// AdjustEndpoints gives every endpoint of a private zone the VPCs declared by any endpoint of
+// that zone, so that they agree on the VPCs the zone is bound to. The property is dropped
+// unless the VPCs of private zones are managed.
+func (p *AlibabaCloudProvider) AdjustEndpoints(endpoints []*endpoint.Endpoint) ([]*endpoint.Endpoint, error) {
+	if !p.privateZone || !p.manageVPCs {
+		for _, ep := range endpoints {
+			ep.DeleteProviderSpecificProperty(providerSpecificVPCIDs)
+		}
+		return endpoints, nil
+	}
+
+	vpcsByZone := map[string][]pvtz.Vpc{}
+	for _, ep := range endpoints {
+		_, zone := p.splitDNSName(ep.DNSName, p.privateZoneNames)
+		value, ok := ep.GetProviderSpecificProperty(providerSpecificVPCIDs)
+		if !ok {
+			continue
+		}
+		vpcs, err := p.parseVPCIDs(value)
+		if err != nil {
+			log.Warnf("Ignoring invalid %s of %s record %s: %v", providerSpecificVPCIDs, ep.RecordType, ep.DNSName, err)
+			continue
+		}
+		vpcsByZone[zone] = append(vpcsByZone[zone], vpcs...)
+	}
+	for _, ep := range endpoints {
+		_, zone := p.splitDNSName(ep.DNSName, p.privateZoneNames)
+		if vpcIDs := formatVPCIDs(p.additionalVPCs(vpcsByZone[zone])); vpcIDs != "" {
+			ep.SetProviderSpecificProperty(providerSpecificVPCIDs, vpcIDs)
+		} else {
+			ep.DeleteProviderSpecificProperty(providerSpecificVPCIDs)
+		}
+	}
+	return endpoints, nil
+}
+
+// bindPrivateZoneVPCs binds the private zones of the endpoints to the VPC of the configuration
+// and to the VPCs of their alibabacloud/vpc-ids property, unbinding them from any other VPC.
+func (p *AlibabaCloudProvider) bindPrivateZoneVPCs(zones map[string]*alibabaPrivateZone, endpoints []*endpoint.Endpoint) error {
+	zoneNames := keys(zones)
+	desired := map[string][]pvtz.Vpc{}
+	for _, endpoint := range endpoints {
+		_, domain := p.splitDNSName(endpoint.DNSName, zoneNames)
+		if zones[domain] == nil {
+			continue
+		}
+		if _, ok := desired[domain]; ok {
+			continue
+		}
+		value, _ := endpoint.GetProviderSpecificProperty(providerSpecificVPCIDs)
+		vpcs, err := p.parseVPCIDs(value)
+		if err != nil {
+			log.Errorf("Failed to parse %s of %s record named '%s': %v", providerSpecificVPCIDs, endpoint.RecordType, endpoint.DNSName, err)
+			continue
+		}
+		desired[domain] = p.additionalVPCs(vpcs)
+	}
+
+	for domain, vpcs := range desired {
+		zone := zones[domain]
+		if formatVPCIDs(vpcs) == formatVPCIDs(p.additionalVPCs(zone.Vpcs.Vpc)) {
+			continue
+		}
+
+		bound := make([]pvtz.BindZoneVpcVpcs, 0, len(vpcs)+1)
+		for _, vpc := range zone.Vpcs.Vpc {
+			if vpc.VpcId == p.vpcID {
+				bound = append(bound, pvtz.BindZoneVpcVpcs{RegionId: vpc.RegionId, VpcId: vpc.VpcId})
+			}
+		}
+		for _, vpc := range vpcs {
+			bound = append(bound, pvtz.BindZoneVpcVpcs{RegionId: vpc.RegionId, VpcId: vpc.VpcId})
+		}
+
+		if p.dryRun {
+			log.Infof("Dry run: Bind Alibaba Cloud Private Zone '%s' to VPCs %v", domain, bound)
+			continue
+		}
+		request := pvtz.CreateBindZoneVpcRequest()
+		request.ZoneId = zone.ZoneId
+		request.Vpcs = &bound
+		request.Domain = pVTZDoamin
+		request.Scheme = defaultAlibabaCloudRequestScheme
+		if _, err := p.getPvtzClient().BindZoneVpc(request); err != nil {
+			log.Errorf("Failed to bind Alibaba Cloud Private Zone '%s' to VPCs %v: %v", domain, bound, err)
+			return err
+		}
+		log.Infof("Bind Alibaba Cloud Private Zone '%s' to VPCs %v", domain, bound)
+	}
+	return nil
+}
+
+// additionalVPCs returns the VPCs other than the one of the configuration, sorted and without duplicates.
+func (p *AlibabaCloudProvider) additionalVPCs(vpcs []pvtz.Vpc) []pvtz.Vpc {
+	seen := map[string]bool{}
+	var result []pvtz.Vpc
+	for _, vpc := range vpcs {
+		key := vpc.RegionId + ":" + vpc.VpcId
+		if vpc.VpcId == p.vpcID || seen[key] {
+			continue
+		}
+		seen[key] = true
+		result = append(result, pvtz.Vpc{RegionId: vpc.RegionId, VpcId: vpc.VpcId})
+	}
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].RegionId+":"+result[i].VpcId < result[j].RegionId+":"+result[j].VpcId
+	})
+	return result
+}
+
+// parseVPCIDs parses a list of vpc-id or region-id:vpc-id entries, the region defaulting to the one of the configuration.
+func (p *AlibabaCloudProvider) parseVPCIDs(value string) ([]pvtz.Vpc, error) {
+	var vpcs []pvtz.Vpc
+	for _, entry := range strings.Split(value, ",") {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+		regionID, vpcID, found := strings.Cut(entry, ":")
+		if !found {
+			regionID, vpcID = p.regionID, entry
+		}
+		if regionID == "" || vpcID == "" {
+			return nil, fmt.Errorf("invalid VPC '%s', expected vpc-id or region-id:vpc-id", entry)
+		}
+		vpcs = append(vpcs, pvtz.Vpc{RegionId: regionID, VpcId: vpcID})
+	}
+	return vpcs, nil
+}
+
+// formatVPCIDs formats VPCs as a list of region-id:vpc-id entries.
+func formatVPCIDs(vpcs []pvtz.Vpc) string {
+	entries := make([]string, 0, len(vpcs))
+	for _, vpc := range vpcs {
+		entries = append(entries, vpc.RegionId+":"+vpc.VpcId)
+	}
+	return strings.Join(entries, ",")
+}
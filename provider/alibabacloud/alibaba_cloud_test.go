@@ -121,8 +121,9 @@ func (m *MockAlibabaCloudDNSAPI) DescribeDomainRecords(request *alidns.DescribeD
 }
 
 type MockAlibabaCloudPrivateZoneAPI struct {
-	zone    pvtz.Zone
-	records []pvtz.Record
+	zone     pvtz.Zone
+	records  []pvtz.Record
+	bindings [][]pvtz.BindZoneVpcVpcs
 }
 
 func NewMockAlibabaCloudPrivateZoneAPI() *MockAlibabaCloudPrivateZoneAPI {
@@ -220,6 +221,16 @@ func (m *MockAlibabaCloudPrivateZoneAPI) DescribeZoneInfo(request *pvtz.Describe
 	return response, nil
 }
 
+func (m *MockAlibabaCloudPrivateZoneAPI) BindZoneVpc(request *pvtz.BindZoneVpcRequest) (response *pvtz.BindZoneVpcResponse, err error) {
+	m.bindings = append(m.bindings, *request.Vpcs)
+	m.zone.Vpcs.Vpc = nil
+	for _, vpc := range *request.Vpcs {
+		m.zone.Vpcs.Vpc = append(m.zone.Vpcs.Vpc, pvtz.Vpc{RegionId: vpc.RegionId, VpcId: vpc.VpcId})
+	}
+	response = pvtz.CreateBindZoneVpcResponse()
+	return response, nil
+}
+
 func newTestAlibabaCloudProvider(private bool) *AlibabaCloudProvider {
 	cfg := alibabaCloudConfig{
 		VPCID: "vpc-xxxxxx",
@@ -468,3 +479,77 @@ func TestAlibabaCloudProvider_TXTEndpoint_PrivateZone(t *testing.T) {
 		t.Errorf("Failed to unescapeTXTRecordValue: %s", p.unescapeTXTRecordValue(recordValue))
 	}
 }
+
+func TestAlibabaCloudProvider_PrivateZoneVPCs(t *testing.T) {
+	p := newTestAlibabaCloudProvider(true)
+	p.manageVPCs = true
+	p.regionID = "cn-beijing"
+	api := p.pvtzClient.(*MockAlibabaCloudPrivateZoneAPI)
+	api.zone.Vpcs.Vpc = append(api.zone.Vpcs.Vpc, pvtz.Vpc{RegionId: "cn-hangzhou", VpcId: "vpc-old"})
+
+	ctx := context.Background()
+	endpoints, err := p.Records(ctx)
+	assert.NoError(t, err)
+	assert.Len(t, endpoints, 2)
+	for _, ep := range endpoints {
+		vpcIDs, _ := ep.GetProviderSpecificProperty(providerSpecificVPCIDs)
+		assert.Equal(t, "cn-hangzhou:vpc-old", vpcIDs)
+	}
+
+	desired, err := p.AdjustEndpoints([]*endpoint.Endpoint{
+		endpoint.NewEndpoint("abc.container-service.top", "A", "1.2.3.4").
+			WithProviderSpecific(providerSpecificVPCIDs, "vpc-new, vpc-xxxxxx"),
+		endpoint.NewEndpoint("xyz.container-service.top", "A", "4.3.2.1").
+			WithProviderSpecific(providerSpecificVPCIDs, "cn-shanghai:vpc-other"),
+		endpoint.NewEndpoint("www.example.org", "A", "4.3.2.1"),
+	})
+	assert.NoError(t, err)
+	for _, ep := range desired[:2] {
+		vpcIDs, _ := ep.GetProviderSpecificProperty(providerSpecificVPCIDs)
+		assert.Equal(t, "cn-beijing:vpc-new,cn-shanghai:vpc-other", vpcIDs)
+	}
+	_, ok := desired[2].GetProviderSpecificProperty(providerSpecificVPCIDs)
+	assert.False(t, ok)
+
+	err = p.ApplyChanges(ctx, &plan.Changes{
+		Create:    []*endpoint.Endpoint{desired[1]},
+		UpdateOld: []*endpoint.Endpoint{endpoints[0]},
+		UpdateNew: []*endpoint.Endpoint{desired[0]},
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, [][]pvtz.BindZoneVpcVpcs{{
+		{RegionId: "cn-beijing", VpcId: "vpc-xxxxxx"},
+		{RegionId: "cn-beijing", VpcId: "vpc-new"},
+		{RegionId: "cn-shanghai", VpcId: "vpc-other"},
+	}}, api.bindings)
+
+	// Removing the VPCs from every endpoint of the zone unbinds it from them.
+	err = p.ApplyChanges(ctx, &plan.Changes{
+		UpdateNew: []*endpoint.Endpoint{endpoint.NewEndpoint("abc.container-service.top", "A", "1.2.3.4")},
+	})
+	assert.NoError(t, err)
+	assert.Len(t, api.bindings, 2)
+	assert.Equal(t, []pvtz.BindZoneVpcVpcs{{RegionId: "cn-beijing", VpcId: "vpc-xxxxxx"}}, api.bindings[1])
+}
+
+func TestAlibabaCloudProvider_PrivateZoneVPCsUnmanaged(t *testing.T) {
+	p := newTestAlibabaCloudProvider(true)
+	api := p.pvtzClient.(*MockAlibabaCloudPrivateZoneAPI)
+	api.zone.Vpcs.Vpc = append(api.zone.Vpcs.Vpc, pvtz.Vpc{RegionId: "cn-hangzhou", VpcId: "vpc-old"})
+
+	endpoints, err := p.Records(context.Background())
+	assert.NoError(t, err)
+	for _, ep := range endpoints {
+		assert.Empty(t, ep.ProviderSpecific)
+	}
+
+	desired, err := p.AdjustEndpoints([]*endpoint.Endpoint{
+		endpoint.NewEndpoint("abc.container-service.top", "A", "1.2.3.4").WithProviderSpecific(providerSpecificVPCIDs, "vpc-new"),
+	})
+	assert.NoError(t, err)
+	assert.Empty(t, desired[0].ProviderSpecific)
+
+	err = p.ApplyChanges(context.Background(), &plan.Changes{UpdateNew: desired})
+	assert.NoError(t, err)
+	assert.Empty(t, api.bindings)
+}
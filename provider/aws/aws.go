@@ -61,8 +61,16 @@ const (
 	providerSpecificGeolocationCountryCode     = "aws/geolocation-country-code"
 	providerSpecificGeolocationSubdivisionCode = "aws/geolocation-subdivision-code"
 	providerSpecificMultiValueAnswer           = "aws/multi-value-answer"
+	providerSpecificGeoProximityRegion         = "aws/geoproximity-region"
+	providerSpecificGeoProximityLocalZoneGroup = "aws/geoproximity-local-zone-group"
+	providerSpecificGeoProximityCoordinates    = "aws/geoproximity-coordinates"
+	providerSpecificGeoProximityBias           = "aws/geoproximity-bias"
+	providerSpecificCidrRoutingCollectionID    = "aws/cidr-routing-collection-id"
+	providerSpecificCidrRoutingLocationName    = "aws/cidr-routing-location-name"
 	providerSpecificHealthCheckID              = "aws/health-check-id"
 	sameZoneAlias                              = "same-zone"
+	// defaultCidrRoutingLocationName is the location of a CIDR collection matching the IP addresses of no other location.
+	defaultCidrRoutingLocationName = "*"
 	// Currently supported up to 10 health checks or hosted zones.
 	// https://docs.aws.amazon.com/Route53/latest/APIReference/API_ListTagsForResources.html#API_ListTagsForResources_RequestSyntax
 	batchSize = 10
@@ -542,6 +550,11 @@ func (p *AWSProvider) records(ctx context.Context, zones map[string]*profiledZon
 									ep.WithProviderSpecific(providerSpecificGeolocationSubdivisionCode, *r.GeoLocation.SubdivisionCode)
 								}
 							}
+						case r.GeoProximityLocation != nil:
+							withGeoProximityLocation(ep, r.GeoProximityLocation)
+						case r.CidrRoutingConfig != nil:
+							ep.WithProviderSpecific(providerSpecificCidrRoutingCollectionID, aws.ToString(r.CidrRoutingConfig.CollectionId))
+							ep.WithProviderSpecific(providerSpecificCidrRoutingLocationName, aws.ToString(r.CidrRoutingConfig.LocationName))
 						default:
 							// one of the above needs to be set, otherwise SetIdentifier doesn't make sense
 						}
@@ -582,10 +595,12 @@ func (p *AWSProvider) requiresDeleteCreate(old *endpoint.Endpoint, newE *endpoin
 	}
 
 	// a change of routing policy
-	// defaults to true for geolocation properties if any geolocation property exists in old/new but not the other
-	for _, propType := range [7]string{providerSpecificWeight, providerSpecificRegion, providerSpecificFailover,
-		providerSpecificFailover, providerSpecificGeolocationContinentCode, providerSpecificGeolocationCountryCode,
-		providerSpecificGeolocationSubdivisionCode} {
+	// defaults to true for geolocation and geoproximity properties if any of them exists in old/new but not the other
+	for _, propType := range []string{providerSpecificWeight, providerSpecificRegion, providerSpecificFailover,
+		providerSpecificGeolocationContinentCode, providerSpecificGeolocationCountryCode,
+		providerSpecificGeolocationSubdivisionCode, providerSpecificGeoProximityRegion,
+		providerSpecificGeoProximityLocalZoneGroup, providerSpecificGeoProximityCoordinates,
+		providerSpecificCidrRoutingCollectionID} {
 		_, oldPolicy := old.GetProviderSpecificProperty(propType)
 		_, newPolicy := newE.GetProviderSpecificProperty(propType)
 		if oldPolicy != newPolicy {
@@ -768,6 +783,17 @@ func (p *AWSProvider) newChanges(action route53types.ChangeAction, endpoints []*
 	return changes
 }
 
+// SupportsAlias reports that endpoints with the alias property are created as Route53 alias records.
+func (p *AWSProvider) SupportsAlias() bool {
+	return true
+}
+
+// TXTEncoding reports that Route53 takes and returns the targets of TXT records quoted, in character strings
+// of at most 255 bytes.
+func (p *AWSProvider) TXTEncoding() string {
+	return provider.TXTEncodingQuoted
+}
+
 // AdjustEndpoints modifies the provided endpoints (coming from various sources) to match
 // the endpoints that the provider returns in `Records` so that the change plan will not have
 // unneeded (potentially failing) changes.
@@ -781,6 +807,8 @@ func (p *AWSProvider) AdjustEndpoints(endpoints []*endpoint.Endpoint) ([]*endpoi
 	for _, ep := range endpoints {
 		alias := false
 
+		adjustGeoProximityAndCidrRouting(ep)
+
 		if aliasString, ok := ep.GetProviderSpecificProperty(providerSpecificAlias); ok {
 			alias = aliasString == "true"
 			if alias {
@@ -926,6 +954,18 @@ func (p *AWSProvider) newChange(action route53types.ChangeAction, ep *endpoint.E
 		if useGeolocation {
 			change.ResourceRecordSet.GeoLocation = geolocation
 		}
+
+		change.ResourceRecordSet.GeoProximityLocation = geoProximityLocation(ep)
+
+		if prop, ok := ep.GetProviderSpecificProperty(providerSpecificCidrRoutingCollectionID); ok {
+			change.ResourceRecordSet.CidrRoutingConfig = &route53types.CidrRoutingConfig{
+				CollectionId: aws.String(prop),
+				LocationName: aws.String(defaultCidrRoutingLocationName),
+			}
+			if location, ok := ep.GetProviderSpecificProperty(providerSpecificCidrRoutingLocationName); ok {
+				change.ResourceRecordSet.CidrRoutingConfig.LocationName = aws.String(location)
+			}
+		}
 	}
 
 	if prop, ok := ep.GetProviderSpecificProperty(providerSpecificHealthCheckID); ok {
@@ -939,6 +979,86 @@ func (p *AWSProvider) newChange(action route53types.ChangeAction, ep *endpoint.E
 	return change
 }
 
+// adjustGeoProximityAndCidrRouting normalizes the geoproximity and CIDR routing properties of the endpoint
+// so that they match the ones of the records read back from Route53.
+func adjustGeoProximityAndCidrRouting(ep *endpoint.Endpoint) {
+	properties := []string{providerSpecificGeoProximityRegion, providerSpecificGeoProximityLocalZoneGroup,
+		providerSpecificGeoProximityCoordinates, providerSpecificGeoProximityBias,
+		providerSpecificCidrRoutingCollectionID, providerSpecificCidrRoutingLocationName}
+	if ep.SetIdentifier == "" {
+		// as any routing policy, they require a set identifier to tell the records of the same name apart
+		for _, prop := range properties {
+			if _, ok := ep.GetProviderSpecificProperty(prop); ok {
+				log.Warnf("Ignoring %s of endpoint %s without a set identifier", prop, ep.DNSName)
+				ep.DeleteProviderSpecificProperty(prop)
+			}
+		}
+		return
+	}
+
+	if prop, ok := ep.GetProviderSpecificProperty(providerSpecificGeoProximityCoordinates); ok {
+		latitude, longitude, _ := strings.Cut(prop, ",")
+		ep.SetProviderSpecificProperty(providerSpecificGeoProximityCoordinates, strings.TrimSpace(latitude)+","+strings.TrimSpace(longitude))
+	}
+	if prop, ok := ep.GetProviderSpecificProperty(providerSpecificGeoProximityBias); ok && (prop == "0" || geoProximityLocation(ep) == nil) {
+		ep.DeleteProviderSpecificProperty(providerSpecificGeoProximityBias)
+	}
+
+	if _, ok := ep.GetProviderSpecificProperty(providerSpecificCidrRoutingCollectionID); ok {
+		if _, ok := ep.GetProviderSpecificProperty(providerSpecificCidrRoutingLocationName); !ok {
+			ep.SetProviderSpecificProperty(providerSpecificCidrRoutingLocationName, defaultCidrRoutingLocationName)
+		}
+	} else if _, ok := ep.GetProviderSpecificProperty(providerSpecificCidrRoutingLocationName); ok {
+		log.Warnf("Ignoring %s of endpoint %s without %s", providerSpecificCidrRoutingLocationName, ep.DNSName, providerSpecificCidrRoutingCollectionID)
+		ep.DeleteProviderSpecificProperty(providerSpecificCidrRoutingLocationName)
+	}
+}
+
+// geoProximityLocation returns the geoproximity location of the endpoint, or nil if it has none.
+func geoProximityLocation(ep *endpoint.Endpoint) *route53types.GeoProximityLocation {
+	location := &route53types.GeoProximityLocation{}
+	if prop, ok := ep.GetProviderSpecificProperty(providerSpecificGeoProximityRegion); ok {
+		location.AWSRegion = aws.String(prop)
+	} else if prop, ok := ep.GetProviderSpecificProperty(providerSpecificGeoProximityLocalZoneGroup); ok {
+		location.LocalZoneGroup = aws.String(prop)
+	} else if prop, ok := ep.GetProviderSpecificProperty(providerSpecificGeoProximityCoordinates); ok {
+		latitude, longitude, _ := strings.Cut(prop, ",")
+		location.Coordinates = &route53types.Coordinates{
+			Latitude:  aws.String(strings.TrimSpace(latitude)),
+			Longitude: aws.String(strings.TrimSpace(longitude)),
+		}
+	} else {
+		return nil
+	}
+
+	if prop, ok := ep.GetProviderSpecificProperty(providerSpecificGeoProximityBias); ok {
+		bias, err := strconv.ParseInt(prop, 10, 32)
+		if err != nil {
+			log.Errorf("Failed parsing value of %s: %s: %v; using bias of 0", providerSpecificGeoProximityBias, prop, err)
+			bias = 0
+		}
+		location.Bias = aws.Int32(int32(bias))
+	}
+	return location
+}
+
+// withGeoProximityLocation sets the provider specific properties of a geoproximity location on the endpoint.
+func withGeoProximityLocation(ep *endpoint.Endpoint, location *route53types.GeoProximityLocation) {
+	switch {
+	case location.AWSRegion != nil:
+		ep.WithProviderSpecific(providerSpecificGeoProximityRegion, *location.AWSRegion)
+	case location.LocalZoneGroup != nil:
+		ep.WithProviderSpecific(providerSpecificGeoProximityLocalZoneGroup, *location.LocalZoneGroup)
+	case location.Coordinates != nil:
+		ep.WithProviderSpecific(providerSpecificGeoProximityCoordinates,
+			aws.ToString(location.Coordinates.Latitude)+","+aws.ToString(location.Coordinates.Longitude))
+	}
+	// a bias of 0 is the default, not reporting it avoids updating endpoints that do not set it
+	if bias := aws.ToInt32(location.Bias); bias != 0 {
+		ep.WithProviderSpecific(providerSpecificGeoProximityBias, strconv.Itoa(int(bias)))
+	}
+}
+
 // searches for `changes` that are contained in `queue` and returns the `changes` separated by whether they were found in the queue (`foundChanges`) or not (`notFoundChanges`)
 func findChangesInQueue(changes Route53Changes, queue Route53Changes) (foundChanges, notFoundChanges Route53Changes) {
 	if queue == nil {
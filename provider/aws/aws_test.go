@@ -1961,6 +1961,92 @@ func TestAWSCreateRecordsWithCNAME(t *testing.T) {
 	})
 }
 
+func TestAWSCreateRecordsWithGeoProximityAndCidrRouting(t *testing.T) {
+	provider, _ := newAWSProvider(t, endpoint.NewDomainFilter([]string{"ext-dns-test-2.teapot.zalan.do."}), provider.NewZoneIDFilter([]string{}), provider.NewZoneTypeFilter(""), defaultEvaluateTargetHealth, false, nil)
+
+	records := []*endpoint.Endpoint{
+		endpoint.NewEndpointWithTTL("geoproximity-test.zone-1.ext-dns-test-2.teapot.zalan.do", endpoint.RecordTypeA, endpoint.TTL(defaultTTL), "1.2.3.4").WithSetIdentifier("region").
+			WithProviderSpecific(providerSpecificGeoProximityRegion, "eu-west-1").WithProviderSpecific(providerSpecificGeoProximityBias, "20"),
+		endpoint.NewEndpointWithTTL("geoproximity-test.zone-1.ext-dns-test-2.teapot.zalan.do", endpoint.RecordTypeA, endpoint.TTL(defaultTTL), "4.3.2.1").WithSetIdentifier("coordinates").
+			WithProviderSpecific(providerSpecificGeoProximityCoordinates, "49.22, -74.01").WithProviderSpecific(providerSpecificGeoProximityBias, "0"),
+		endpoint.NewEndpointWithTTL("cidr-test.zone-1.ext-dns-test-2.teapot.zalan.do", endpoint.RecordTypeA, endpoint.TTL(defaultTTL), "1.2.3.4").WithSetIdentifier("office").
+			WithProviderSpecific(providerSpecificCidrRoutingCollectionID, "collection").WithProviderSpecific(providerSpecificCidrRoutingLocationName, "office"),
+		endpoint.NewEndpointWithTTL("cidr-test.zone-1.ext-dns-test-2.teapot.zalan.do", endpoint.RecordTypeA, endpoint.TTL(defaultTTL), "4.3.2.1").WithSetIdentifier("default").
+			WithProviderSpecific(providerSpecificCidrRoutingCollectionID, "collection"),
+		endpoint.NewEndpointWithTTL("no-set-identifier-test.zone-1.ext-dns-test-2.teapot.zalan.do", endpoint.RecordTypeA, endpoint.TTL(defaultTTL), "1.2.3.4").
+			WithProviderSpecific(providerSpecificGeoProximityRegion, "eu-west-1"),
+	}
+
+	adjusted, err := provider.AdjustEndpoints(records)
+	require.NoError(t, err)
+	require.NoError(t, provider.ApplyChanges(context.Background(), &plan.Changes{
+		Create: adjusted,
+	}))
+
+	recordSets := listAWSRecords(t, provider.clients[defaultAWSProfile], "/hostedzone/zone-1.ext-dns-test-2.teapot.zalan.do.")
+
+	validateRecords(t, recordSets, []route53types.ResourceRecordSet{
+		{
+			Name:            aws.String("geoproximity-test.zone-1.ext-dns-test-2.teapot.zalan.do."),
+			Type:            route53types.RRTypeA,
+			TTL:             aws.Int64(300),
+			ResourceRecords: []route53types.ResourceRecord{{Value: aws.String("1.2.3.4")}},
+			SetIdentifier:   aws.String("region"),
+			GeoProximityLocation: &route53types.GeoProximityLocation{
+				AWSRegion: aws.String("eu-west-1"),
+				Bias:      aws.Int32(20),
+			},
+		},
+		{
+			Name:            aws.String("geoproximity-test.zone-1.ext-dns-test-2.teapot.zalan.do."),
+			Type:            route53types.RRTypeA,
+			TTL:             aws.Int64(300),
+			ResourceRecords: []route53types.ResourceRecord{{Value: aws.String("4.3.2.1")}},
+			SetIdentifier:   aws.String("coordinates"),
+			GeoProximityLocation: &route53types.GeoProximityLocation{
+				Coordinates: &route53types.Coordinates{Latitude: aws.String("49.22"), Longitude: aws.String("-74.01")},
+			},
+		},
+		{
+			Name:              aws.String("cidr-test.zone-1.ext-dns-test-2.teapot.zalan.do."),
+			Type:              route53types.RRTypeA,
+			TTL:               aws.Int64(300),
+			ResourceRecords:   []route53types.ResourceRecord{{Value: aws.String("1.2.3.4")}},
+			SetIdentifier:     aws.String("office"),
+			CidrRoutingConfig: &route53types.CidrRoutingConfig{CollectionId: aws.String("collection"), LocationName: aws.String("office")},
+		},
+		{
+			Name:              aws.String("cidr-test.zone-1.ext-dns-test-2.teapot.zalan.do."),
+			Type:              route53types.RRTypeA,
+			TTL:               aws.Int64(300),
+			ResourceRecords:   []route53types.ResourceRecord{{Value: aws.String("4.3.2.1")}},
+			SetIdentifier:     aws.String("default"),
+			CidrRoutingConfig: &route53types.CidrRoutingConfig{CollectionId: aws.String("collection"), LocationName: aws.String("*")},
+		},
+		{
+			Name:            aws.String("no-set-identifier-test.zone-1.ext-dns-test-2.teapot.zalan.do."),
+			Type:            route53types.RRTypeA,
+			TTL:             aws.Int64(300),
+			ResourceRecords: []route53types.ResourceRecord{{Value: aws.String("1.2.3.4")}},
+		},
+	})
+
+	// the records read back match the adjusted endpoints, so that they do not get updated again
+	current, err := provider.Records(context.Background())
+	require.NoError(t, err)
+	validateEndpoints(t, provider, current, []*endpoint.Endpoint{
+		endpoint.NewEndpointWithTTL("geoproximity-test.zone-1.ext-dns-test-2.teapot.zalan.do", endpoint.RecordTypeA, endpoint.TTL(defaultTTL), "1.2.3.4").WithSetIdentifier("region").
+			WithProviderSpecific(providerSpecificGeoProximityRegion, "eu-west-1").WithProviderSpecific(providerSpecificGeoProximityBias, "20"),
+		endpoint.NewEndpointWithTTL("geoproximity-test.zone-1.ext-dns-test-2.teapot.zalan.do", endpoint.RecordTypeA, endpoint.TTL(defaultTTL), "4.3.2.1").WithSetIdentifier("coordinates").
+			WithProviderSpecific(providerSpecificGeoProximityCoordinates, "49.22,-74.01"),
+		endpoint.NewEndpointWithTTL("cidr-test.zone-1.ext-dns-test-2.teapot.zalan.do", endpoint.RecordTypeA, endpoint.TTL(defaultTTL), "1.2.3.4").WithSetIdentifier("office").
+			WithProviderSpecific(providerSpecificCidrRoutingCollectionID, "collection").WithProviderSpecific(providerSpecificCidrRoutingLocationName, "office"),
+		endpoint.NewEndpointWithTTL("cidr-test.zone-1.ext-dns-test-2.teapot.zalan.do", endpoint.RecordTypeA, endpoint.TTL(defaultTTL), "4.3.2.1").WithSetIdentifier("default").
+			WithProviderSpecific(providerSpecificCidrRoutingCollectionID, "collection").WithProviderSpecific(providerSpecificCidrRoutingLocationName, "*"),
+		endpoint.NewEndpointWithTTL("no-set-identifier-test.zone-1.ext-dns-test-2.teapot.zalan.do", endpoint.RecordTypeA, endpoint.TTL(defaultTTL), "1.2.3.4"),
+	})
+}
+
 func TestAWSCreateRecordsWithALIAS(t *testing.T) {
 	for key, evaluateTargetHealth := range map[string]bool{
 		"true":  true,
@@ -2336,6 +2422,12 @@ func TestRequiresDeleteCreate(t *testing.T) {
 	oldSetIdentifier := endpoint.NewEndpointWithTTL("setIdentifier", endpoint.RecordTypeA, endpoint.TTL(defaultTTL), "8.8.8.8").WithSetIdentifier("old")
 	newSetIdentifier := endpoint.NewEndpointWithTTL("setIdentifier", endpoint.RecordTypeA, endpoint.TTL(defaultTTL), "8.8.8.8").WithSetIdentifier("new")
 
+	oldGeoProximity := endpoint.NewEndpointWithTTL("policy", endpoint.RecordTypeA, endpoint.TTL(defaultTTL), "8.8.8.8").WithSetIdentifier("nochange").WithProviderSpecific(providerSpecificGeoProximityRegion, "us-east-1")
+	newGeoProximity := endpoint.NewEndpointWithTTL("policy", endpoint.RecordTypeA, endpoint.TTL(defaultTTL), "8.8.8.8").WithSetIdentifier("nochange").WithProviderSpecific(providerSpecificCidrRoutingCollectionID, "collection")
+
+	assert.False(t, provider.requiresDeleteCreate(oldGeoProximity, oldGeoProximity), "actual and expected endpoints don't match. %+v:%+v", oldGeoProximity, oldGeoProximity)
+	assert.True(t, provider.requiresDeleteCreate(oldGeoProximity, newGeoProximity), "actual and expected endpoints don't match. %+v:%+v", oldGeoProximity, newGeoProximity)
+
 	assert.False(t, provider.requiresDeleteCreate(oldSetIdentifier, oldSetIdentifier), "actual and expected endpoints don't match. %+v:%+v", oldSetIdentifier, oldSetIdentifier)
 	assert.True(t, provider.requiresDeleteCreate(oldSetIdentifier, newSetIdentifier), "actual and expected endpoints don't match. %+v:%+v", oldSetIdentifier, newSetIdentifier)
 }
@@ -25,6 +25,7 @@ import (
 
 	log "github.com/sirupsen/logrus"
 
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore/arm"
 	azcoreruntime "github.com/Azure/azure-sdk-for-go/sdk/azcore/runtime"
 	"github.com/Azure/azure-sdk-for-go/sdk/azcore/to"
 	dns "github.com/Azure/azure-sdk-for-go/sdk/resourcemanager/dns/armdns"
@@ -57,18 +58,22 @@ type AzureProvider struct {
 	zoneNameFilter               endpoint.DomainFilter
 	zoneIDFilter                 provider.ZoneIDFilter
 	dryRun                       bool
+	subscriptionID               string
 	resourceGroup                string
 	userAssignedIdentityClientID string
 	activeDirectoryAuthorityHost string
 	zonesClient                  ZonesClient
 	zonesCache                   *zonesCache[dns.Zone]
 	recordSetsClient             RecordSetsClient
+	resourceGraphClient          ResourceGraphClient
+	discoverySubscriptionIDs     []string
+	zoneLocations                zoneLocations[RecordSetsClient]
 }
 
 // NewAzureProvider creates a new Azure provider.
 //
 // Returns the provider or an error if a provider could not be created.
-func NewAzureProvider(configFile string, domainFilter endpoint.DomainFilter, zoneNameFilter endpoint.DomainFilter, zoneIDFilter provider.ZoneIDFilter, subscriptionID string, resourceGroup string, userAssignedIdentityClientID string, activeDirectoryAuthorityHost string, zonesCacheDuration time.Duration, dryRun bool) (*AzureProvider, error) {
+func NewAzureProvider(configFile string, domainFilter endpoint.DomainFilter, zoneNameFilter endpoint.DomainFilter, zoneIDFilter provider.ZoneIDFilter, subscriptionID string, resourceGroup string, userAssignedIdentityClientID string, activeDirectoryAuthorityHost string, zonesCacheDuration time.Duration, resourceGraphDiscovery bool, discoverySubscriptionIDs []string, dryRun bool) (*AzureProvider, error) {
 	cfg, err := getConfig(configFile, subscriptionID, resourceGroup, userAssignedIdentityClientID, activeDirectoryAuthorityHost)
 	if err != nil {
 		return nil, fmt.Errorf("failed to read Azure config file '%s': %v", configFile, err)
@@ -86,18 +91,31 @@ func NewAzureProvider(configFile string, domainFilter endpoint.DomainFilter, zon
 	if err != nil {
 		return nil, err
 	}
-	return &AzureProvider{
+	p := &AzureProvider{
 		domainFilter:                 domainFilter,
 		zoneNameFilter:               zoneNameFilter,
 		zoneIDFilter:                 zoneIDFilter,
 		dryRun:                       dryRun,
+		subscriptionID:               cfg.SubscriptionID,
 		resourceGroup:                cfg.ResourceGroup,
 		userAssignedIdentityClientID: cfg.UserAssignedIdentityID,
 		activeDirectoryAuthorityHost: cfg.ActiveDirectoryAuthorityHost,
 		zonesClient:                  zonesClient,
 		zonesCache:                   &zonesCache[dns.Zone]{duration: zonesCacheDuration},
 		recordSetsClient:             recordSetsClient,
-	}, nil
+		discoverySubscriptionIDs:     discoverySubscriptionIDs,
+		zoneLocations: zoneLocations[RecordSetsClient]{
+			newClient: func(subscriptionID string) (RecordSetsClient, error) {
+				return dns.NewRecordSetsClient(subscriptionID, cred, clientOpts)
+			},
+		},
+	}
+	if resourceGraphDiscovery {
+		if p.resourceGraphClient, err = newResourceGraphClient(cred, clientOpts); err != nil {
+			return nil, err
+		}
+	}
+	return p, nil
 }
 
 // Records gets the current records.
@@ -110,7 +128,11 @@ func (p *AzureProvider) Records(ctx context.Context) (endpoints []*endpoint.Endp
 	}
 
 	for _, zone := range zones {
-		pager := p.recordSetsClient.NewListAllByDNSZonePager(p.resourceGroup, *zone.Name, &dns.RecordSetsClientListAllByDNSZoneOptions{Top: nil})
+		recordSetsClient, resourceGroup, err := p.zoneLocations.lookup(*zone.ID, p.subscriptionID, p.resourceGroup, p.recordSetsClient)
+		if err != nil {
+			return nil, provider.NewSoftError(err)
+		}
+		pager := recordSetsClient.NewListAllByDNSZonePager(resourceGroup, *zone.Name, &dns.RecordSetsClientListAllByDNSZoneOptions{Top: nil})
 		for pager.More() {
 			nextResult, err := pager.NextPage(ctx)
 			if err != nil {
@@ -174,23 +196,47 @@ func (p *AzureProvider) zones(ctx context.Context) ([]dns.Zone, error) {
 		log.Debugf("Using cached Azure DNS zones for resource group: %s zone count: %d.", p.resourceGroup, len(p.zonesCache.Get()))
 		return p.zonesCache.Get(), nil
 	}
-	var zones []dns.Zone
-	pager := p.zonesClient.NewListByResourceGroupPager(p.resourceGroup, &dns.ZonesClientListByResourceGroupOptions{Top: nil})
-	for pager.More() {
-		nextResult, err := pager.NextPage(ctx)
+	var candidates []*dns.Zone
+	if p.resourceGraphClient != nil {
+		ids, err := p.resourceGraphClient.ListZoneIDs(ctx, dnsZoneResourceType, p.discoverySubscriptionIDs)
 		if err != nil {
-			return nil, err
+			return nil, fmt.Errorf("failed to discover Azure DNS zones through resource graph: %w", err)
 		}
-		for _, zone := range nextResult.Value {
-			if zone.Name != nil && p.domainFilter.Match(*zone.Name) && p.zoneIDFilter.Match(*zone.ID) {
-				zones = append(zones, *zone)
-			} else if zone.Name != nil && len(p.zoneNameFilter.Filters) > 0 && p.zoneNameFilter.Match(*zone.Name) {
-				// Handle zoneNameFilter
-				zones = append(zones, *zone)
+		for _, id := range ids {
+			rid, err := arm.ParseResourceID(id)
+			if err != nil {
+				log.Debugf("Skipping discovered zone with invalid resource ID '%s': %v", id, err)
+				continue
 			}
+			candidates = append(candidates, &dns.Zone{ID: to.Ptr(id), Name: to.Ptr(rid.Name)})
+		}
+	} else {
+		pager := p.zonesClient.NewListByResourceGroupPager(p.resourceGroup, &dns.ZonesClientListByResourceGroupOptions{Top: nil})
+		for pager.More() {
+			nextResult, err := pager.NextPage(ctx)
+			if err != nil {
+				return nil, err
+			}
+			candidates = append(candidates, nextResult.Value...)
+		}
+	}
+	var zones []dns.Zone
+	var zoneIDs []string
+	for _, zone := range candidates {
+		if zone.Name != nil && p.domainFilter.Match(*zone.Name) && p.zoneIDFilter.Match(*zone.ID) {
+			zones = append(zones, *zone)
+		} else if zone.Name != nil && len(p.zoneNameFilter.Filters) > 0 && p.zoneNameFilter.Match(*zone.Name) {
+			// Handle zoneNameFilter
+			zones = append(zones, *zone)
+		} else {
+			continue
+		}
+		if zone.ID != nil {
+			zoneIDs = append(zoneIDs, *zone.ID)
 		}
 	}
 	log.Debugf("Found %d Azure DNS zone(s). Updating zones cache", len(zones))
+	p.zoneLocations.reset(zoneIDs)
 	p.zonesCache.Reset(zones)
 	return zones, nil
 }
@@ -204,21 +250,21 @@ func (p *AzureProvider) SupportedRecordType(recordType string) bool {
 	}
 }
 
-type azureChangeMap map[string][]*endpoint.Endpoint
+type azureChangeMap map[zoneRef][]*endpoint.Endpoint
 
 func (p *AzureProvider) mapChanges(zones []dns.Zone, changes *plan.Changes) (azureChangeMap, azureChangeMap) {
 	ignored := map[string]bool{}
 	deleted := azureChangeMap{}
 	updated := azureChangeMap{}
-	zoneNameIDMapper := provider.ZoneIDName{}
+	zoneNameIDMapper := p.NewZoneFinder()
 	for _, z := range zones {
-		if z.Name != nil {
-			zoneNameIDMapper.Add(*z.Name, *z.Name)
+		if z.ID != nil && z.Name != nil {
+			zoneNameIDMapper.Add(*z.ID, *z.Name)
 		}
 	}
 	mapChange := func(changeMap azureChangeMap, change *endpoint.Endpoint) {
-		zone, _ := zoneNameIDMapper.FindZone(change.DNSName)
-		if zone == "" {
+		zoneID, zoneName := zoneNameIDMapper.FindZone(change.DNSName)
+		if zoneID == "" {
 			if _, ok := ignored[change.DNSName]; !ok {
 				ignored[change.DNSName] = true
 				log.Infof("Ignoring changes to '%s' because a suitable Azure DNS zone was not found.", change.DNSName)
@@ -226,6 +272,7 @@ func (p *AzureProvider) mapChanges(zones []dns.Zone, changes *plan.Changes) (azu
 			return
 		}
 		// Ensure the record type is suitable
+		zone := zoneRef{id: zoneID, name: zoneName}
 		changeMap[zone] = append(changeMap[zone], change)
 	}
 
@@ -247,21 +294,25 @@ func (p *AzureProvider) deleteRecords(ctx context.Context, deleted azureChangeMa
 	// Delete records first
 	for zone, endpoints := range deleted {
 		for _, ep := range endpoints {
-			name := p.recordSetNameForZone(zone, ep)
+			name := p.recordSetNameForZone(zone.name, ep)
 			if !p.domainFilter.Match(ep.DNSName) {
 				log.Debugf("Skipping deletion of record %s because it was filtered out by the specified --domain-filter", ep.DNSName)
 				continue
 			}
 			if p.dryRun {
-				log.Infof("Would delete %s record named '%s' for Azure DNS zone '%s'.", ep.RecordType, name, zone)
+				log.Infof("Would delete %s record named '%s' for Azure DNS zone '%s'.", ep.RecordType, name, zone.name)
 			} else {
-				log.Infof("Deleting %s record named '%s' for Azure DNS zone '%s'.", ep.RecordType, name, zone)
-				if _, err := p.recordSetsClient.Delete(ctx, p.resourceGroup, zone, name, dns.RecordType(ep.RecordType), nil); err != nil {
+				log.Infof("Deleting %s record named '%s' for Azure DNS zone '%s'.", ep.RecordType, name, zone.name)
+				recordSetsClient, resourceGroup, err := p.zoneLocations.lookup(zone.id, p.subscriptionID, p.resourceGroup, p.recordSetsClient)
+				if err == nil {
+					_, err = recordSetsClient.Delete(ctx, resourceGroup, zone.name, name, dns.RecordType(ep.RecordType), nil)
+				}
+				if err != nil {
 					log.Errorf(
 						"Failed to delete %s record named '%s' for Azure DNS zone '%s': %v",
 						ep.RecordType,
 						name,
-						zone,
+						zone.name,
 						err,
 					)
 				}
@@ -273,7 +324,7 @@ func (p *AzureProvider) deleteRecords(ctx context.Context, deleted azureChangeMa
 func (p *AzureProvider) updateRecords(ctx context.Context, updated azureChangeMap) {
 	for zone, endpoints := range updated {
 		for _, ep := range endpoints {
-			name := p.recordSetNameForZone(zone, ep)
+			name := p.recordSetNameForZone(zone.name, ep)
 			if !p.domainFilter.Match(ep.DNSName) {
 				log.Debugf("Skipping update of record %s because it was filtered out by the specified --domain-filter", ep.DNSName)
 				continue
@@ -284,7 +335,7 @@ func (p *AzureProvider) updateRecords(ctx context.Context, updated azureChangeMa
 					ep.RecordType,
 					name,
 					ep.Targets,
-					zone,
+					zone.name,
 				)
 				continue
 			}
@@ -294,15 +345,19 @@ func (p *AzureProvider) updateRecords(ctx context.Context, updated azureChangeMa
 				ep.RecordType,
 				name,
 				ep.Targets,
-				zone,
+				zone.name,
 			)
 
-			recordSet, err := p.newRecordSet(ep)
+			recordSetsClient, resourceGroup, err := p.zoneLocations.lookup(zone.id, p.subscriptionID, p.resourceGroup, p.recordSetsClient)
+			var recordSet dns.RecordSet
+			if err == nil {
+				recordSet, err = p.newRecordSet(ep)
+			}
 			if err == nil {
-				_, err = p.recordSetsClient.CreateOrUpdate(
+				_, err = recordSetsClient.CreateOrUpdate(
 					ctx,
-					p.resourceGroup,
-					zone,
+					resourceGroup,
+					zone.name,
 					name,
 					dns.RecordType(ep.RecordType),
 					recordSet,
@@ -315,7 +370,7 @@ func (p *AzureProvider) updateRecords(ctx context.Context, updated azureChangeMa
 					ep.RecordType,
 					name,
 					ep.Targets,
-					zone,
+					zone.name,
 					err,
 				)
 			}
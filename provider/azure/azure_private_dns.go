@@ -23,6 +23,7 @@ import (
 	"strings"
 	"time"
 
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore/arm"
 	azcoreruntime "github.com/Azure/azure-sdk-for-go/sdk/azcore/runtime"
 	"github.com/Azure/azure-sdk-for-go/sdk/azcore/to"
 	privatedns "github.com/Azure/azure-sdk-for-go/sdk/resourcemanager/privatedns/armprivatedns"
@@ -52,18 +53,22 @@ type AzurePrivateDNSProvider struct {
 	zoneNameFilter               endpoint.DomainFilter
 	zoneIDFilter                 provider.ZoneIDFilter
 	dryRun                       bool
+	subscriptionID               string
 	resourceGroup                string
 	userAssignedIdentityClientID string
 	activeDirectoryAuthorityHost string
 	zonesClient                  PrivateZonesClient
 	zonesCache                   *zonesCache[privatedns.PrivateZone]
 	recordSetsClient             PrivateRecordSetsClient
+	resourceGraphClient          ResourceGraphClient
+	discoverySubscriptionIDs     []string
+	zoneLocations                zoneLocations[PrivateRecordSetsClient]
 }
 
 // NewAzurePrivateDNSProvider creates a new Azure Private DNS provider.
 //
 // Returns the provider or an error if a provider could not be created.
-func NewAzurePrivateDNSProvider(configFile string, domainFilter endpoint.DomainFilter, zoneNameFilter endpoint.DomainFilter, zoneIDFilter provider.ZoneIDFilter, subscriptionID string, resourceGroup string, userAssignedIdentityClientID string, activeDirectoryAuthorityHost string, zonesCacheDuration time.Duration, dryRun bool) (*AzurePrivateDNSProvider, error) {
+func NewAzurePrivateDNSProvider(configFile string, domainFilter endpoint.DomainFilter, zoneNameFilter endpoint.DomainFilter, zoneIDFilter provider.ZoneIDFilter, subscriptionID string, resourceGroup string, userAssignedIdentityClientID string, activeDirectoryAuthorityHost string, zonesCacheDuration time.Duration, resourceGraphDiscovery bool, discoverySubscriptionIDs []string, dryRun bool) (*AzurePrivateDNSProvider, error) {
 	cfg, err := getConfig(configFile, subscriptionID, resourceGroup, userAssignedIdentityClientID, activeDirectoryAuthorityHost)
 	if err != nil {
 		return nil, fmt.Errorf("failed to read Azure config file '%s': %v", configFile, err)
@@ -81,18 +86,31 @@ func NewAzurePrivateDNSProvider(configFile string, domainFilter endpoint.DomainF
 	if err != nil {
 		return nil, err
 	}
-	return &AzurePrivateDNSProvider{
+	p := &AzurePrivateDNSProvider{
 		domainFilter:                 domainFilter,
 		zoneNameFilter:               zoneNameFilter,
 		zoneIDFilter:                 zoneIDFilter,
 		dryRun:                       dryRun,
+		subscriptionID:               cfg.SubscriptionID,
 		resourceGroup:                cfg.ResourceGroup,
 		userAssignedIdentityClientID: cfg.UserAssignedIdentityID,
 		activeDirectoryAuthorityHost: cfg.ActiveDirectoryAuthorityHost,
 		zonesClient:                  zonesClient,
 		zonesCache:                   &zonesCache[privatedns.PrivateZone]{duration: zonesCacheDuration},
 		recordSetsClient:             recordSetsClient,
-	}, nil
+		discoverySubscriptionIDs:     discoverySubscriptionIDs,
+		zoneLocations: zoneLocations[PrivateRecordSetsClient]{
+			newClient: func(subscriptionID string) (PrivateRecordSetsClient, error) {
+				return privatedns.NewRecordSetsClient(subscriptionID, cred, clientOpts)
+			},
+		},
+	}
+	if resourceGraphDiscovery {
+		if p.resourceGraphClient, err = newResourceGraphClient(cred, clientOpts); err != nil {
+			return nil, err
+		}
+	}
+	return p, nil
 }
 
 // Records gets the current records.
@@ -107,7 +125,11 @@ func (p *AzurePrivateDNSProvider) Records(ctx context.Context) (endpoints []*end
 	log.Debugf("Retrieving Azure Private DNS Records for resource group '%s'", p.resourceGroup)
 
 	for _, zone := range zones {
-		pager := p.recordSetsClient.NewListPager(p.resourceGroup, *zone.Name, &privatedns.RecordSetsClientListOptions{Top: nil})
+		recordSetsClient, resourceGroup, err := p.zoneLocations.lookup(*zone.ID, p.subscriptionID, p.resourceGroup, p.recordSetsClient)
+		if err != nil {
+			return nil, provider.NewSoftError(err)
+		}
+		pager := recordSetsClient.NewListPager(resourceGroup, *zone.Name, &privatedns.RecordSetsClientListOptions{Top: nil})
 		for pager.More() {
 			nextResult, err := pager.NextPage(ctx)
 			if err != nil {
@@ -184,46 +206,70 @@ func (p *AzurePrivateDNSProvider) zones(ctx context.Context) ([]privatedns.Priva
 		log.Debugf("Using cached Azure Private DNS zones for resource group: %s zone count: %d.", p.resourceGroup, len(p.zonesCache.Get()))
 		return p.zonesCache.Get(), nil
 	}
-	var zones []privatedns.PrivateZone
-
-	pager := p.zonesClient.NewListByResourceGroupPager(p.resourceGroup, &privatedns.PrivateZonesClientListByResourceGroupOptions{Top: nil})
-	for pager.More() {
-		nextResult, err := pager.NextPage(ctx)
+	var candidates []*privatedns.PrivateZone
+	if p.resourceGraphClient != nil {
+		ids, err := p.resourceGraphClient.ListZoneIDs(ctx, privateDNSZoneResourceType, p.discoverySubscriptionIDs)
 		if err != nil {
-			return nil, err
+			return nil, fmt.Errorf("failed to discover Azure Private DNS zones through resource graph: %w", err)
 		}
-		for _, zone := range nextResult.Value {
-			log.Debugf("Validating Zone: %v", *zone.Name)
-
-			if zone.Name != nil && p.domainFilter.Match(*zone.Name) && p.zoneIDFilter.Match(*zone.ID) {
-				zones = append(zones, *zone)
-			} else if zone.Name != nil && len(p.zoneNameFilter.Filters) > 0 && p.zoneNameFilter.Match(*zone.Name) {
-				// Handle zoneNameFilter
-				zones = append(zones, *zone)
+		for _, id := range ids {
+			rid, err := arm.ParseResourceID(id)
+			if err != nil {
+				log.Debugf("Skipping discovered zone with invalid resource ID '%s': %v", id, err)
+				continue
 			}
+			candidates = append(candidates, &privatedns.PrivateZone{ID: to.Ptr(id), Name: to.Ptr(rid.Name)})
+		}
+	} else {
+		pager := p.zonesClient.NewListByResourceGroupPager(p.resourceGroup, &privatedns.PrivateZonesClientListByResourceGroupOptions{Top: nil})
+		for pager.More() {
+			nextResult, err := pager.NextPage(ctx)
+			if err != nil {
+				return nil, err
+			}
+			candidates = append(candidates, nextResult.Value...)
+		}
+	}
+
+	var zones []privatedns.PrivateZone
+	var zoneIDs []string
+	for _, zone := range candidates {
+		log.Debugf("Validating Zone: %v", *zone.Name)
+
+		if zone.Name != nil && p.domainFilter.Match(*zone.Name) && p.zoneIDFilter.Match(*zone.ID) {
+			zones = append(zones, *zone)
+		} else if zone.Name != nil && len(p.zoneNameFilter.Filters) > 0 && p.zoneNameFilter.Match(*zone.Name) {
+			// Handle zoneNameFilter
+			zones = append(zones, *zone)
+		} else {
+			continue
+		}
+		if zone.ID != nil {
+			zoneIDs = append(zoneIDs, *zone.ID)
 		}
 	}
 
 	log.Debugf("Found %d Azure Private DNS zone(s). Updating zones cache", len(zones))
+	p.zoneLocations.reset(zoneIDs)
 	p.zonesCache.Reset(zones)
 	return zones, nil
 }
 
-type azurePrivateDNSChangeMap map[string][]*endpoint.Endpoint
+type azurePrivateDNSChangeMap map[zoneRef][]*endpoint.Endpoint
 
 func (p *AzurePrivateDNSProvider) mapChanges(zones []privatedns.PrivateZone, changes *plan.Changes) (azurePrivateDNSChangeMap, azurePrivateDNSChangeMap) {
 	ignored := map[string]bool{}
 	deleted := azurePrivateDNSChangeMap{}
 	updated := azurePrivateDNSChangeMap{}
-	zoneNameIDMapper := provider.ZoneIDName{}
+	zoneNameIDMapper := p.NewZoneFinder()
 	for _, z := range zones {
-		if z.Name != nil {
-			zoneNameIDMapper.Add(*z.Name, *z.Name)
+		if z.ID != nil && z.Name != nil {
+			zoneNameIDMapper.Add(*z.ID, *z.Name)
 		}
 	}
 	mapChange := func(changeMap azurePrivateDNSChangeMap, change *endpoint.Endpoint) {
-		zone, _ := zoneNameIDMapper.FindZone(change.DNSName)
-		if zone == "" {
+		zoneID, zoneName := zoneNameIDMapper.FindZone(change.DNSName)
+		if zoneID == "" {
 			if _, ok := ignored[change.DNSName]; !ok {
 				ignored[change.DNSName] = true
 				log.Infof("Ignoring changes to '%s' because a suitable Azure Private DNS zone was not found.", change.DNSName)
@@ -231,6 +277,7 @@ func (p *AzurePrivateDNSProvider) mapChanges(zones []privatedns.PrivateZone, cha
 			return
 		}
 		// Ensure the record type is suitable
+		zone := zoneRef{id: zoneID, name: zoneName}
 		changeMap[zone] = append(changeMap[zone], change)
 	}
 
@@ -253,21 +300,25 @@ func (p *AzurePrivateDNSProvider) deleteRecords(ctx context.Context, deleted azu
 	// Delete records first
 	for zone, endpoints := range deleted {
 		for _, ep := range endpoints {
-			name := p.recordSetNameForZone(zone, ep)
+			name := p.recordSetNameForZone(zone.name, ep)
 			if !p.domainFilter.Match(ep.DNSName) {
 				log.Debugf("Skipping deletion of record %s because it was filtered out by the specified --domain-filter", ep.DNSName)
 				continue
 			}
 			if p.dryRun {
-				log.Infof("Would delete %s record named '%s' for Azure Private DNS zone '%s'.", ep.RecordType, name, zone)
+				log.Infof("Would delete %s record named '%s' for Azure Private DNS zone '%s'.", ep.RecordType, name, zone.name)
 			} else {
-				log.Infof("Deleting %s record named '%s' for Azure Private DNS zone '%s'.", ep.RecordType, name, zone)
-				if _, err := p.recordSetsClient.Delete(ctx, p.resourceGroup, zone, privatedns.RecordType(ep.RecordType), name, nil); err != nil {
+				log.Infof("Deleting %s record named '%s' for Azure Private DNS zone '%s'.", ep.RecordType, name, zone.name)
+				recordSetsClient, resourceGroup, err := p.zoneLocations.lookup(zone.id, p.subscriptionID, p.resourceGroup, p.recordSetsClient)
+				if err == nil {
+					_, err = recordSetsClient.Delete(ctx, resourceGroup, zone.name, privatedns.RecordType(ep.RecordType), name, nil)
+				}
+				if err != nil {
 					log.Errorf(
 						"Failed to delete %s record named '%s' for Azure Private DNS zone '%s': %v",
 						ep.RecordType,
 						name,
-						zone,
+						zone.name,
 						err,
 					)
 				}
@@ -280,7 +331,7 @@ func (p *AzurePrivateDNSProvider) updateRecords(ctx context.Context, updated azu
 	log.Debugf("Records to be updated: %d", len(updated))
 	for zone, endpoints := range updated {
 		for _, ep := range endpoints {
-			name := p.recordSetNameForZone(zone, ep)
+			name := p.recordSetNameForZone(zone.name, ep)
 			if !p.domainFilter.Match(ep.DNSName) {
 				log.Debugf("Skipping update of record %s because it was filtered out by the specified --domain-filter", ep.DNSName)
 				continue
@@ -291,7 +342,7 @@ func (p *AzurePrivateDNSProvider) updateRecords(ctx context.Context, updated azu
 					ep.RecordType,
 					name,
 					ep.Targets,
-					zone,
+					zone.name,
 				)
 				continue
 			}
@@ -301,15 +352,19 @@ func (p *AzurePrivateDNSProvider) updateRecords(ctx context.Context, updated azu
 				ep.RecordType,
 				name,
 				ep.Targets,
-				zone,
+				zone.name,
 			)
 
-			recordSet, err := p.newRecordSet(ep)
+			recordSetsClient, resourceGroup, err := p.zoneLocations.lookup(zone.id, p.subscriptionID, p.resourceGroup, p.recordSetsClient)
+			var recordSet privatedns.RecordSet
+			if err == nil {
+				recordSet, err = p.newRecordSet(ep)
+			}
 			if err == nil {
-				_, err = p.recordSetsClient.CreateOrUpdate(
+				_, err = recordSetsClient.CreateOrUpdate(
 					ctx,
-					p.resourceGroup,
-					zone,
+					resourceGroup,
+					zone.name,
 					privatedns.RecordType(ep.RecordType),
 					name,
 					recordSet,
@@ -322,7 +377,7 @@ func (p *AzurePrivateDNSProvider) updateRecords(ctx context.Context, updated azu
 					ep.RecordType,
 					name,
 					ep.Targets,
-					zone,
+					zone.name,
 					err,
 				)
 			}
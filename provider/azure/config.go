@@ -18,7 +18,9 @@ package azure
 
 import (
 	"encoding/json"
+	"errors"
 	"fmt"
+	"io/fs"
 	"os"
 	"strings"
 
@@ -29,6 +31,9 @@ import (
 	log "github.com/sirupsen/logrus"
 )
 
+// federatedTokenFileEnv is set by the Azure workload identity webhook on federated pods.
+const federatedTokenFileEnv = "AZURE_FEDERATED_TOKEN_FILE"
+
 // config represents common config items for Azure DNS and Azure Private DNS
 type config struct {
 	Cloud                        string `json:"cloud" yaml:"cloud"`
@@ -45,14 +50,27 @@ type config struct {
 }
 
 func getConfig(configFile, subscriptionID, resourceGroup, userAssignedIdentityClientID, activeDirectoryAuthorityHost string) (*config, error) {
+	cfg := &config{}
 	contents, err := os.ReadFile(configFile)
-	if err != nil {
+	switch {
+	case err == nil:
+		if err := json.Unmarshal(contents, &cfg); err != nil {
+			return nil, fmt.Errorf("failed to parse Azure config file '%s': %v", configFile, err)
+		}
+	case errors.Is(err, fs.ErrNotExist) && os.Getenv(federatedTokenFileEnv) != "":
+		// Workload identity federation injects everything needed in the environment,
+		// so there is no need to mount a config file holding secrets.
+		log.Infof("Azure config file '%s' not found, using workload identity federation from the environment.", configFile)
+		cfg = &config{
+			TenantID:                     os.Getenv("AZURE_TENANT_ID"),
+			ClientID:                     os.Getenv("AZURE_CLIENT_ID"),
+			SubscriptionID:               os.Getenv("AZURE_SUBSCRIPTION_ID"),
+			ActiveDirectoryAuthorityHost: os.Getenv("AZURE_AUTHORITY_HOST"),
+			UseWorkloadIdentityExtension: true,
+		}
+	default:
 		return nil, fmt.Errorf("failed to read Azure config file '%s': %v", configFile, err)
 	}
-	cfg := &config{}
-	if err := json.Unmarshal(contents, &cfg); err != nil {
-		return nil, fmt.Errorf("failed to parse Azure config file '%s': %v", configFile, err)
-	}
 	// If a subscription ID was given, override what was present in the config file
 	if subscriptionID != "" {
 		cfg.SubscriptionID = subscriptionID
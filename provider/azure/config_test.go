@@ -59,3 +59,27 @@ func TestOverrideConfiguration(t *testing.T) {
 	assert.Equal(t, cfg.ResourceGroup, "rg-override")
 	assert.Equal(t, cfg.ActiveDirectoryAuthorityHost, "aad-endpoint-override")
 }
+
+func TestGetConfigWorkloadIdentityWithoutFile(t *testing.T) {
+	t.Setenv(federatedTokenFileEnv, "/var/run/secrets/azure/tokens/azure-identity-token")
+	t.Setenv("AZURE_TENANT_ID", "tenant")
+	t.Setenv("AZURE_CLIENT_ID", "client")
+	t.Setenv("AZURE_SUBSCRIPTION_ID", "subscription")
+	t.Setenv("AZURE_AUTHORITY_HOST", "https://login.microsoftonline.com/")
+
+	cfg, err := getConfig(path.Join(t.TempDir(), "missing.json"), "", "rg", "", "")
+	if err != nil {
+		t.Fatalf("got unexpected err %v", err)
+	}
+	assert.Equal(t, "tenant", cfg.TenantID)
+	assert.Equal(t, "client", cfg.ClientID)
+	assert.Equal(t, "subscription", cfg.SubscriptionID)
+	assert.Equal(t, "rg", cfg.ResourceGroup)
+	assert.True(t, cfg.UseWorkloadIdentityExtension)
+}
+
+func TestGetConfigMissingFileWithoutWorkloadIdentity(t *testing.T) {
+	t.Setenv(federatedTokenFileEnv, "")
+	_, err := getConfig(path.Join(t.TempDir(), "missing.json"), "", "", "", "")
+	assert.Error(t, err)
+}
@@ -0,0 +1,182 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package azure
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore"
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore/arm"
+	azcoreruntime "github.com/Azure/azure-sdk-for-go/sdk/azcore/runtime"
+)
+
+const (
+	resourceGraphAPIVersion = "2021-03-01"
+
+	dnsZoneResourceType        = "microsoft.network/dnszones"
+	privateDNSZoneResourceType = "microsoft.network/privatednszones"
+)
+
+// ResourceGraphClient lists the resource IDs of DNS zones visible to the credentials
+// through Azure Resource Graph, regardless of their resource group or subscription.
+type ResourceGraphClient interface {
+	ListZoneIDs(ctx context.Context, resourceType string, subscriptionIDs []string) ([]string, error)
+}
+
+type resourceGraphClient struct {
+	endpoint string
+	pipeline azcoreruntime.Pipeline
+}
+
+type resourceGraphRequest struct {
+	Subscriptions []string                    `json:"subscriptions,omitempty"`
+	Query         string                      `json:"query"`
+	Options       resourceGraphRequestOptions `json:"options"`
+}
+
+type resourceGraphRequestOptions struct {
+	SkipToken    string `json:"$skipToken,omitempty"`
+	ResultFormat string `json:"resultFormat"`
+}
+
+type resourceGraphResponse struct {
+	SkipToken string `json:"$skipToken"`
+	Data      []struct {
+		ID string `json:"id"`
+	} `json:"data"`
+}
+
+func newResourceGraphClient(cred azcore.TokenCredential, clientOpts *arm.ClientOptions) (*resourceGraphClient, error) {
+	client, err := arm.NewClient("external-dns", "v1", cred, clientOpts)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create resource graph client: %w", err)
+	}
+	return &resourceGraphClient{
+		endpoint: client.Endpoint(),
+		pipeline: client.Pipeline(),
+	}, nil
+}
+
+// ListZoneIDs runs a Resource Graph query for the given resource type, following pagination.
+// An empty list of subscriptions queries every subscription the credentials have access to.
+func (c *resourceGraphClient) ListZoneIDs(ctx context.Context, resourceType string, subscriptionIDs []string) ([]string, error) {
+	var ids []string
+	body := resourceGraphRequest{
+		Subscriptions: subscriptionIDs,
+		Query:         fmt.Sprintf("Resources | where type =~ '%s' | project id", resourceType),
+		Options:       resourceGraphRequestOptions{ResultFormat: "objectArray"},
+	}
+	for {
+		req, err := azcoreruntime.NewRequest(ctx, http.MethodPost, strings.TrimSuffix(c.endpoint, "/")+"/providers/Microsoft.ResourceGraph/resources")
+		if err != nil {
+			return nil, err
+		}
+		query := req.Raw().URL.Query()
+		query.Set("api-version", resourceGraphAPIVersion)
+		req.Raw().URL.RawQuery = query.Encode()
+		if err := azcoreruntime.MarshalAsJSON(req, body); err != nil {
+			return nil, err
+		}
+		resp, err := c.pipeline.Do(req)
+		if err != nil {
+			return nil, err
+		}
+		if !azcoreruntime.HasStatusCode(resp, http.StatusOK) {
+			return nil, azcoreruntime.NewResponseError(resp)
+		}
+		var result resourceGraphResponse
+		if err := azcoreruntime.UnmarshalAsJSON(resp, &result); err != nil {
+			return nil, err
+		}
+		for _, row := range result.Data {
+			ids = append(ids, row.ID)
+		}
+		if result.SkipToken == "" {
+			return ids, nil
+		}
+		body.Options.SkipToken = result.SkipToken
+	}
+}
+
+// zoneRef identifies a zone by its resource ID along with the name the record sets APIs expect.
+type zoneRef struct {
+	id   string
+	name string
+}
+
+// zoneLocation identifies where a zone lives when it may sit outside of the configured
+// subscription and resource group.
+type zoneLocation struct {
+	subscriptionID string
+	resourceGroup  string
+}
+
+// zoneLocationFromID extracts the subscription and resource group from a zone resource ID.
+func zoneLocationFromID(id string) (zoneLocation, bool) {
+	rid, err := arm.ParseResourceID(id)
+	if err != nil || rid.SubscriptionID == "" || rid.ResourceGroupName == "" {
+		return zoneLocation{}, false
+	}
+	return zoneLocation{subscriptionID: rid.SubscriptionID, resourceGroup: rid.ResourceGroupName}, true
+}
+
+// zoneLocations remembers where each managed zone lives and lazily creates one record sets
+// client per extra subscription, so that changes are sent to the zone's own resource group.
+// Zones are known by their resource ID, as zones of the same name may live in several resource
+// groups or subscriptions.
+type zoneLocations[T any] struct {
+	newClient func(subscriptionID string) (T, error)
+	byID      map[string]zoneLocation
+	clients   map[string]T
+}
+
+// reset replaces the known locations with the ones derived from the given zone IDs.
+func (z *zoneLocations[T]) reset(zoneIDs []string) {
+	z.byID = make(map[string]zoneLocation, len(zoneIDs))
+	for _, id := range zoneIDs {
+		if location, ok := zoneLocationFromID(id); ok {
+			z.byID[id] = location
+		}
+	}
+}
+
+// lookup returns the client and resource group to use for the zone with the given ID. Zones with
+// an unknown location fall back to the configured resource group and default client.
+func (z *zoneLocations[T]) lookup(zoneID, subscriptionID, resourceGroup string, defaultClient T) (T, string, error) {
+	location, ok := z.byID[zoneID]
+	if !ok {
+		return defaultClient, resourceGroup, nil
+	}
+	if z.newClient == nil || strings.EqualFold(location.subscriptionID, subscriptionID) {
+		return defaultClient, location.resourceGroup, nil
+	}
+	if client, ok := z.clients[location.subscriptionID]; ok {
+		return client, location.resourceGroup, nil
+	}
+	client, err := z.newClient(location.subscriptionID)
+	if err != nil {
+		return client, "", fmt.Errorf("failed to create client for subscription '%s': %w", location.subscriptionID, err)
+	}
+	if z.clients == nil {
+		z.clients = map[string]T{}
+	}
+	z.clients[location.subscriptionID] = client
+	return client, location.resourceGroup, nil
+}
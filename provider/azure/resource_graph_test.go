@@ -0,0 +1,173 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package azure
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	dns "github.com/Azure/azure-sdk-for-go/sdk/resourcemanager/dns/armdns"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"sigs.k8s.io/external-dns/endpoint"
+	"sigs.k8s.io/external-dns/plan"
+	"sigs.k8s.io/external-dns/provider"
+)
+
+type mockResourceGraphClient struct {
+	ids             []string
+	err             error
+	resourceType    string
+	subscriptionIDs []string
+}
+
+func (client *mockResourceGraphClient) ListZoneIDs(ctx context.Context, resourceType string, subscriptionIDs []string) ([]string, error) {
+	client.resourceType = resourceType
+	client.subscriptionIDs = subscriptionIDs
+	return client.ids, client.err
+}
+
+func TestZoneLocationFromID(t *testing.T) {
+	location, ok := zoneLocationFromID("/subscriptions/sub/resourceGroups/rg/providers/Microsoft.Network/dnszones/example.com")
+	assert.True(t, ok)
+	assert.Equal(t, zoneLocation{subscriptionID: "sub", resourceGroup: "rg"}, location)
+
+	_, ok = zoneLocationFromID("/dnszones/example.com")
+	assert.False(t, ok)
+}
+
+func TestZoneLocationsLookup(t *testing.T) {
+	created := []string{}
+	locations := zoneLocations[string]{
+		newClient: func(subscriptionID string) (string, error) {
+			if subscriptionID == "broken" {
+				return "", errors.New("boom")
+			}
+			created = append(created, subscriptionID)
+			return "client-" + subscriptionID, nil
+		},
+	}
+	const (
+		sameID   = "/subscriptions/default/resourceGroups/rg-same/providers/Microsoft.Network/dnszones/same.com"
+		otherID  = "/subscriptions/other/resourceGroups/rg-other/providers/Microsoft.Network/dnszones/other.com"
+		other2ID = "/subscriptions/other/resourceGroups/rg-other2/providers/Microsoft.Network/dnszones/other2.com"
+		brokenID = "/subscriptions/broken/resourceGroups/rg/providers/Microsoft.Network/dnszones/broken.com"
+		legacyID = "/dnszones/legacy.com"
+	)
+	locations.reset([]string{sameID, otherID, other2ID, brokenID, legacyID})
+
+	client, rg, err := locations.lookup(legacyID, "default", "rg-default", "default-client")
+	require.NoError(t, err)
+	assert.Equal(t, "default-client", client)
+	assert.Equal(t, "rg-default", rg)
+
+	client, rg, err = locations.lookup(sameID, "default", "rg-default", "default-client")
+	require.NoError(t, err)
+	assert.Equal(t, "default-client", client)
+	assert.Equal(t, "rg-same", rg)
+
+	client, rg, err = locations.lookup(otherID, "default", "rg-default", "default-client")
+	require.NoError(t, err)
+	assert.Equal(t, "client-other", client)
+	assert.Equal(t, "rg-other", rg)
+
+	client, rg, err = locations.lookup(other2ID, "default", "rg-default", "default-client")
+	require.NoError(t, err)
+	assert.Equal(t, "client-other", client)
+	assert.Equal(t, "rg-other2", rg)
+	assert.Equal(t, []string{"other"}, created, "clients should be created once per subscription")
+
+	_, _, err = locations.lookup(brokenID, "default", "rg-default", "default-client")
+	assert.Error(t, err)
+}
+
+func TestAzureZonesResourceGraphDiscovery(t *testing.T) {
+	graphClient := &mockResourceGraphClient{
+		ids: []string{
+			"/subscriptions/sub/resourceGroups/rg1/providers/Microsoft.Network/dnszones/example.com",
+			"/subscriptions/sub/resourceGroups/rg2/providers/Microsoft.Network/dnszones/example.org",
+			"not-a-resource-id",
+		},
+	}
+	recordSetsClient := newMockRecordSetsClient(nil)
+	p := newAzureProvider(endpoint.NewDomainFilter([]string{"example.com"}), endpoint.NewDomainFilter([]string{}), provider.NewZoneIDFilter([]string{""}), false, "rg-default", "", "", nil, &recordSetsClient)
+	p.subscriptionID = "sub"
+	p.resourceGraphClient = graphClient
+	p.discoverySubscriptionIDs = []string{"sub"}
+
+	zones, err := p.zones(context.Background())
+	require.NoError(t, err)
+	require.Len(t, zones, 1)
+	assert.Equal(t, "example.com", *zones[0].Name)
+	assert.Equal(t, dnsZoneResourceType, graphClient.resourceType)
+	assert.Equal(t, []string{"sub"}, graphClient.subscriptionIDs)
+
+	_, rg, err := p.zoneLocations.lookup(*zones[0].ID, p.subscriptionID, p.resourceGroup, p.recordSetsClient)
+	require.NoError(t, err)
+	assert.Equal(t, "rg1", rg)
+
+	graphClient.err = errors.New("query failed")
+	_, err = p.zones(context.Background())
+	assert.Error(t, err)
+}
+
+func TestAzureSameNamedZonesInSubscriptions(t *testing.T) {
+	const (
+		zoneAID = "/subscriptions/sub-a/resourceGroups/rg-a/providers/Microsoft.Network/dnszones/example.com"
+		zoneBID = "/subscriptions/sub-b/resourceGroups/rg-b/providers/Microsoft.Network/dnszones/example.com"
+	)
+	graphClient := &mockResourceGraphClient{ids: []string{zoneBID, zoneAID}}
+	recordSetsClientA := newMockRecordSetsClient([]*dns.RecordSet{
+		createMockRecordSet("a", endpoint.RecordTypeA, "1.2.3.4"),
+	})
+	recordSetsClientB := newMockRecordSetsClient([]*dns.RecordSet{
+		createMockRecordSet("b", endpoint.RecordTypeA, "1.2.3.5"),
+	})
+	p := newAzureProvider(endpoint.NewDomainFilter([]string{"example.com"}), endpoint.NewDomainFilter([]string{}), provider.NewZoneIDFilter([]string{""}), false, "rg-default", "", "", nil, &recordSetsClientA)
+	p.subscriptionID = "sub-a"
+	p.resourceGraphClient = graphClient
+	p.zoneLocations.newClient = func(subscriptionID string) (RecordSetsClient, error) {
+		require.Equal(t, "sub-b", subscriptionID)
+		return &recordSetsClientB, nil
+	}
+
+	zones, err := p.zones(context.Background())
+	require.NoError(t, err)
+	require.Len(t, zones, 2)
+	for _, id := range []string{zoneAID, zoneBID} {
+		_, rg, err := p.zoneLocations.lookup(id, p.subscriptionID, p.resourceGroup, p.recordSetsClient)
+		require.NoError(t, err)
+		assert.Equal(t, map[string]string{zoneAID: "rg-a", zoneBID: "rg-b"}[id], rg)
+	}
+
+	// the records of each zone are read from its own subscription
+	records, err := p.Records(context.Background())
+	require.NoError(t, err)
+	validateAzureEndpoints(t, records, []*endpoint.Endpoint{
+		endpoint.NewEndpoint("a.example.com", endpoint.RecordTypeA, "1.2.3.4"),
+		endpoint.NewEndpoint("b.example.com", endpoint.RecordTypeA, "1.2.3.5"),
+	})
+
+	// the changes go to a single zone, the one with the lowest ID
+	require.NoError(t, p.ApplyChanges(context.Background(), &plan.Changes{
+		Create: []*endpoint.Endpoint{endpoint.NewEndpoint("app.example.com", endpoint.RecordTypeA, "1.2.3.6")},
+	}))
+	assert.Len(t, recordSetsClientA.updatedEndpoints, 1)
+	assert.Empty(t, recordSetsClientB.updatedEndpoints)
+}
@@ -0,0 +1,132 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	log "github.com/sirupsen/logrus"
+
+	"sigs.k8s.io/external-dns/endpoint"
+	"sigs.k8s.io/external-dns/plan"
+)
+
+// BatchProvider is implemented by providers able to apply a plan in several batches of limited size,
+// e.g. to stay under the limits of their API.
+// Such providers apply their changes with ApplyChangesInBatches, so that the chunking is transparent
+// to the registries and the controller.
+type BatchProvider interface {
+	// MaxBatchSize returns the maximum number of changes applied in one batch, 0 meaning no limit.
+	MaxBatchSize() int
+	// ApplyChangesBatch applies a batch of at most MaxBatchSize changes.
+	ApplyChangesBatch(ctx context.Context, changes *plan.Changes) error
+}
+
+// ApplyChangesInBatches applies the changes with p, split in batches of at most p.MaxBatchSize changes.
+// The batches are applied one after the other, stopping at the first one failing.
+func ApplyChangesInBatches(ctx context.Context, p BatchProvider, changes *plan.Changes) error {
+	batches := SplitChanges(changes, p.MaxBatchSize())
+	for i, batch := range batches {
+		if len(batches) > 1 {
+			log.Debugf("Applying batch %d of %d", i+1, len(batches))
+		}
+		if err := p.ApplyChangesBatch(ctx, batch); err != nil {
+			if len(batches) > 1 {
+				return fmt.Errorf("applying batch %d of %d: %w", i+1, len(batches), err)
+			}
+			return err
+		}
+	}
+	return nil
+}
+
+// changeKind tells which list of plan.Changes a change belongs to.
+type changeKind int
+
+const (
+	changeCreate changeKind = iota
+	changeUpdate
+	changeDelete
+)
+
+// batchedChange is a single change of a plan, an update carrying both its old and new endpoint.
+type batchedChange struct {
+	kind     changeKind
+	endpoint *endpoint.Endpoint
+	old      *endpoint.Endpoint
+}
+
+// SplitChanges splits changes in batches of at most size changes, an update counting as one change.
+// The changes of a DNS name are kept in the same batch as long as they fit in a batch on their own,
+// so that a record is never deleted and recreated across batches. A size of 0 or less means no limit.
+func SplitChanges(changes *plan.Changes, size int) []*plan.Changes {
+	total := len(changes.Create) + len(changes.UpdateNew) + len(changes.Delete)
+	if size <= 0 || total <= size {
+		return []*plan.Changes{changes}
+	}
+
+	var names []string
+	byName := map[string][]batchedChange{}
+	add := func(c batchedChange) {
+		if _, ok := byName[c.endpoint.DNSName]; !ok {
+			names = append(names, c.endpoint.DNSName)
+		}
+		byName[c.endpoint.DNSName] = append(byName[c.endpoint.DNSName], c)
+	}
+	for _, ep := range changes.Create {
+		add(batchedChange{kind: changeCreate, endpoint: ep})
+	}
+	for i, ep := range changes.UpdateNew {
+		add(batchedChange{kind: changeUpdate, endpoint: ep, old: changes.UpdateOld[i]})
+	}
+	for _, ep := range changes.Delete {
+		add(batchedChange{kind: changeDelete, endpoint: ep})
+	}
+
+	var batches []*plan.Changes
+	batch, count := &plan.Changes{}, 0
+	flush := func() {
+		if count > 0 {
+			batches = append(batches, batch)
+			batch, count = &plan.Changes{}, 0
+		}
+	}
+	for _, name := range names {
+		group := byName[name]
+		if count+len(group) > size {
+			flush()
+		}
+		for _, c := range group {
+			switch c.kind {
+			case changeCreate:
+				batch.Create = append(batch.Create, c.endpoint)
+			case changeUpdate:
+				batch.UpdateOld = append(batch.UpdateOld, c.old)
+				batch.UpdateNew = append(batch.UpdateNew, c.endpoint)
+			case changeDelete:
+				batch.Delete = append(batch.Delete, c.endpoint)
+			}
+			count++
+			if count == size {
+				flush()
+			}
+		}
+	}
+	flush()
+	return batches
+}
@@ -0,0 +1,112 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package provider
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"sigs.k8s.io/external-dns/endpoint"
+	"sigs.k8s.io/external-dns/plan"
+)
+
+type batchRecorder struct {
+	size    int
+	batches []*plan.Changes
+	failAt  int
+}
+
+func (b *batchRecorder) MaxBatchSize() int { return b.size }
+
+func (b *batchRecorder) ApplyChangesBatch(_ context.Context, changes *plan.Changes) error {
+	b.batches = append(b.batches, changes)
+	if len(b.batches) == b.failAt {
+		return NewSoftError(errors.New("rate limited"))
+	}
+	return nil
+}
+
+func batchTestChanges() *plan.Changes {
+	return &plan.Changes{
+		Create: []*endpoint.Endpoint{
+			endpoint.NewEndpoint("a.example.org", endpoint.RecordTypeA, "1.2.3.4"),
+			endpoint.NewEndpoint("b.example.org", endpoint.RecordTypeA, "1.2.3.4"),
+			endpoint.NewEndpoint("c.example.org", endpoint.RecordTypeCNAME, "a.example.org"),
+		},
+		UpdateOld: []*endpoint.Endpoint{
+			endpoint.NewEndpoint("d.example.org", endpoint.RecordTypeA, "1.2.3.4"),
+		},
+		UpdateNew: []*endpoint.Endpoint{
+			endpoint.NewEndpoint("d.example.org", endpoint.RecordTypeA, "5.6.7.8"),
+		},
+		Delete: []*endpoint.Endpoint{
+			endpoint.NewEndpoint("b.example.org", endpoint.RecordTypeAAAA, "::1"),
+			endpoint.NewEndpoint("e.example.org", endpoint.RecordTypeA, "1.2.3.4"),
+		},
+	}
+}
+
+func TestSplitChanges(t *testing.T) {
+	changes := batchTestChanges()
+
+	assert.Equal(t, []*plan.Changes{changes}, SplitChanges(changes, 0))
+	assert.Equal(t, []*plan.Changes{changes}, SplitChanges(changes, 6))
+
+	batches := SplitChanges(changes, 2)
+	require.Len(t, batches, 4)
+	// the changes of b.example.org stay together
+	assert.Equal(t, &plan.Changes{Create: changes.Create[:1]}, batches[0])
+	assert.Equal(t, &plan.Changes{
+		Create: changes.Create[1:2],
+		Delete: changes.Delete[:1],
+	}, batches[1])
+	assert.Equal(t, &plan.Changes{
+		Create:    changes.Create[2:],
+		UpdateOld: changes.UpdateOld,
+		UpdateNew: changes.UpdateNew,
+	}, batches[2])
+	assert.Equal(t, &plan.Changes{Delete: changes.Delete[1:]}, batches[3])
+
+	// changes of a name not fitting in a batch are split
+	batches = SplitChanges(changes, 1)
+	require.Len(t, batches, 6)
+	for _, batch := range batches {
+		assert.Equal(t, 1, len(batch.Create)+len(batch.UpdateNew)+len(batch.Delete))
+		assert.Len(t, batch.UpdateOld, len(batch.UpdateNew))
+	}
+}
+
+func TestApplyChangesInBatches(t *testing.T) {
+	p := &batchRecorder{size: 2}
+	require.NoError(t, ApplyChangesInBatches(context.Background(), p, batchTestChanges()))
+	assert.Len(t, p.batches, 4)
+
+	p = &batchRecorder{size: 2, failAt: 2}
+	err := ApplyChangesInBatches(context.Background(), p, batchTestChanges())
+	require.EqualError(t, err, "applying batch 2 of 4: soft error\nrate limited")
+	assert.ErrorIs(t, err, SoftError)
+	assert.Len(t, p.batches, 2)
+
+	p = &batchRecorder{failAt: 1}
+	err = ApplyChangesInBatches(context.Background(), p, batchTestChanges())
+	require.EqualError(t, err, "soft error\nrate limited")
+	assert.Len(t, p.batches, 1)
+}
@@ -60,6 +60,8 @@ type CachedProvider struct {
 	RefreshDelay time.Duration
 	lastRead     time.Time
 	cache        []*endpoint.Endpoint
+	// pages are the records of the pages listed so far, cached once the last page is listed.
+	pages []*endpoint.Endpoint
 }
 
 func NewCachedProvider(provider Provider, refreshDelay time.Duration) *CachedProvider {
@@ -86,6 +88,36 @@ func (c *CachedProvider) Records(ctx context.Context) ([]*endpoint.Endpoint, err
 	}
 	return c.cache, nil
 }
+
+// RecordsPage lists a page of the records of the wrapped provider, caching them once the last page is
+// listed, or all of them at once while the cache is fresh or when it does not list them page by page.
+func (c *CachedProvider) RecordsPage(ctx context.Context, token string) (*RecordsPage, error) {
+	pp, ok := c.Provider.(PaginatedProvider)
+	if !ok || (token == "" && !c.needRefresh()) {
+		records, err := c.Records(ctx)
+		if err != nil {
+			return nil, err
+		}
+		return &RecordsPage{Endpoints: records}, nil
+	}
+	if token == "" {
+		log.Info("Records cache provider: refreshing records list cache")
+		c.pages = []*endpoint.Endpoint{}
+	}
+	page, err := pp.RecordsPage(ctx, token)
+	if err != nil {
+		c.cache, c.pages = nil, nil
+		return nil, err
+	}
+	c.pages = append(c.pages, page.Endpoints...)
+	if page.NextToken == "" {
+		c.cache, c.pages = c.pages, nil
+		c.lastRead = time.Now()
+		cachedRecordsCallsTotal.CounterVec.WithLabelValues("false").Inc()
+	}
+	return page, nil
+}
+
 func (c *CachedProvider) ApplyChanges(ctx context.Context, changes *plan.Changes) error {
 	if !changes.HasChanges() {
 		log.Info("Records cache provider: no changes to be applied")
@@ -96,6 +128,41 @@ func (c *CachedProvider) ApplyChanges(ctx context.Context, changes *plan.Changes
 	return c.Provider.ApplyChanges(ctx, changes)
 }
 
+// PersistsLabels reports whether the wrapped provider stores endpoint labels natively.
+func (c *CachedProvider) PersistsLabels() bool {
+	return PersistsLabels(c.Provider)
+}
+
+// SupportsViews reports whether the wrapped provider selects the DNS view of records.
+func (c *CachedProvider) SupportsViews() bool {
+	return SupportsViews(c.Provider)
+}
+
+// SupportsAlias reports whether the wrapped provider creates alias records.
+func (c *CachedProvider) SupportsAlias() bool {
+	return SupportsAlias(c.Provider)
+}
+
+// TXTEncoding returns the encoding of the TXT targets of the wrapped provider.
+func (c *CachedProvider) TXTEncoding() string {
+	return TXTEncoding(c.Provider)
+}
+
+// ZoneVersions returns the version of the zones read by the wrapped provider.
+func (c *CachedProvider) ZoneVersions() map[string]string {
+	return ZoneVersions(c.Provider)
+}
+
+// PropertyComparator returns the comparator of the provider specific properties of the wrapped provider.
+func (c *CachedProvider) PropertyComparator() plan.PropertyComparator {
+	return PropertyComparator(c.Provider)
+}
+
+// SetZoneCollisions gives the ZoneCollisions to the wrapped provider.
+func (c *CachedProvider) SetZoneCollisions(zc *ZoneCollisions) {
+	SetZoneCollisions(c.Provider, zc)
+}
+
 func (c *CachedProvider) Reset() {
 	c.cache = nil
 	c.lastRead = time.Time{}
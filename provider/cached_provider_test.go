@@ -204,3 +204,92 @@ func TestCachedProviderForcesCacheRefreshOnUpdate(t *testing.T) {
 		})
 	})
 }
+
+type labelPersistingProvider struct {
+	*testProviderFunc
+}
+
+func (p labelPersistingProvider) PersistsLabels() bool {
+	return true
+}
+
+// countingPaginatedProvider counts the pages it lists.
+type countingPaginatedProvider struct {
+	testPaginatedProvider
+	calls int
+}
+
+func (p *countingPaginatedProvider) RecordsPage(ctx context.Context, token string) (*RecordsPage, error) {
+	p.calls++
+	return p.testPaginatedProvider.RecordsPage(ctx, token)
+}
+
+func TestCachedProviderRecordsPage(t *testing.T) {
+	a := endpoint.NewEndpoint("a.example.org", endpoint.RecordTypeA, "1.2.3.4")
+	b := endpoint.NewEndpoint("b.example.org", endpoint.RecordTypeA, "5.6.7.8")
+	paginated := &countingPaginatedProvider{testPaginatedProvider: testPaginatedProvider{
+		testProviderFunc: testProviderFunc{records: recordsNotCalled(t)},
+		pages: map[string]*RecordsPage{
+			"":     {Endpoints: []*endpoint.Endpoint{a}, NextToken: "next"},
+			"next": {Endpoints: []*endpoint.Endpoint{b}},
+		},
+	}}
+	p := NewCachedProvider(paginated, time.Minute)
+	collect := func() [][]*endpoint.Endpoint {
+		var pages [][]*endpoint.Endpoint
+		require.NoError(t, EachRecordsPage(context.Background(), p, func(records []*endpoint.Endpoint) error {
+			pages = append(pages, records)
+			return nil
+		}))
+		return pages
+	}
+
+	// the pages are listed from the provider, then cached
+	assert.Equal(t, [][]*endpoint.Endpoint{{a}, {b}}, collect())
+	assert.Equal(t, 2, paginated.calls)
+	assert.Equal(t, [][]*endpoint.Endpoint{{a, b}}, collect())
+	assert.Equal(t, 2, paginated.calls)
+	records, err := p.Records(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, []*endpoint.Endpoint{a, b}, records)
+
+	// a failing page leaves the cache empty
+	p.Reset()
+	paginated.err = errors.New("failed")
+	_, err = p.RecordsPage(context.Background(), "")
+	require.EqualError(t, err, "failed")
+	paginated.err = nil
+	assert.Equal(t, [][]*endpoint.Endpoint{{a}, {b}}, collect())
+	assert.Equal(t, 5, paginated.calls)
+}
+
+func TestCachedProviderPersistsLabels(t *testing.T) {
+	assert.False(t, PersistsLabels(NewCachedProvider(newTestProviderFunc(t), time.Minute)))
+	assert.True(t, PersistsLabels(NewCachedProvider(labelPersistingProvider{newTestProviderFunc(t)}, time.Minute)))
+}
+
+type viewSupportingProvider struct {
+	*testProviderFunc
+}
+
+func (p viewSupportingProvider) SupportsViews() bool {
+	return true
+}
+
+func TestCachedProviderSupportsViews(t *testing.T) {
+	assert.False(t, SupportsViews(NewCachedProvider(newTestProviderFunc(t), time.Minute)))
+	assert.True(t, SupportsViews(NewCachedProvider(viewSupportingProvider{newTestProviderFunc(t)}, time.Minute)))
+}
+
+type aliasSupportingProvider struct {
+	*testProviderFunc
+}
+
+func (p aliasSupportingProvider) SupportsAlias() bool {
+	return true
+}
+
+func TestCachedProviderSupportsAlias(t *testing.T) {
+	assert.False(t, SupportsAlias(NewCachedProvider(newTestProviderFunc(t), time.Minute)))
+	assert.True(t, SupportsAlias(NewCachedProvider(aliasSupportingProvider{newTestProviderFunc(t)}, time.Minute)))
+}
@@ -452,7 +452,7 @@ func (p *CivoProvider) ApplyChanges(ctx context.Context, changes *plan.Changes)
 
 	zonesByID := make(map[string]civogo.DNSDomain)
 
-	zoneNameIDMapper := provider.ZoneIDName{}
+	zoneNameIDMapper := p.NewZoneFinder()
 
 	for _, z := range zones {
 		zoneNameIDMapper.Add(z.ID, z.Name)
@@ -495,7 +495,7 @@ func (p *CivoProvider) ApplyChanges(ctx context.Context, changes *plan.Changes)
 	return p.submitChanges(ctx, civoChange)
 }
 
-func endpointsByZone(zoneNameIDMapper provider.ZoneIDName, endpoints []*endpoint.Endpoint) map[string][]*endpoint.Endpoint {
+func endpointsByZone(zoneNameIDMapper provider.ZoneFinder, endpoints []*endpoint.Endpoint) map[string][]*endpoint.Endpoint {
 	endpointsByZone := make(map[string][]*endpoint.Endpoint)
 
 	for _, ep := range endpoints {
@@ -27,11 +27,14 @@ import (
 	"sort"
 	"strconv"
 	"strings"
+	"sync"
 
 	cloudflare "github.com/cloudflare/cloudflare-go"
 	log "github.com/sirupsen/logrus"
 
 	"sigs.k8s.io/external-dns/endpoint"
+	extdnshttp "sigs.k8s.io/external-dns/pkg/http"
+	"sigs.k8s.io/external-dns/pkg/secrets"
 	"sigs.k8s.io/external-dns/plan"
 	"sigs.k8s.io/external-dns/provider"
 	"sigs.k8s.io/external-dns/source"
@@ -42,6 +45,8 @@ const (
 	cloudFlareCreate = "CREATE"
 	// cloudFlareDelete is a ChangeAction enum value
 	cloudFlareDelete = "DELETE"
+	// proxiedMixed is the proxied state reported for records whose targets do not share the same state
+	proxiedMixed = "mixed"
 	// cloudFlareUpdate is a ChangeAction enum value
 	cloudFlareUpdate = "UPDATE"
 	// defaultTTL 1 = automatic
@@ -202,6 +207,16 @@ type CloudFlareProvider struct {
 	DryRun                bool
 	DNSRecordsPerPage     int
 	RegionKey             string
+	// RecordCommentLabels stores the labels of the endpoints in the comment of their DNS records
+	RecordCommentLabels bool
+	// BatchSize is the maximum number of changes applied at once, 0 meaning no limit.
+	BatchSize int
+	// keepProxiedDrift keeps the proxied state set out of band, e.g. in the Cloudflare dashboard,
+	// of the records whose endpoints do not set it explicitly, instead of repairing it.
+	keepProxiedDrift bool
+	// proxiedStates holds the proxied state of the records seen by the last call to Records, by name and type.
+	proxiedStates      map[string]bool
+	proxiedStatesMutex sync.Mutex
 }
 
 // cloudFlareChange differentiates between ChangActions
@@ -220,13 +235,18 @@ type RecordParamsTypes interface {
 
 // updateDNSRecordParam is a function that returns the appropriate Record Param based on the cloudFlareChange passed in
 func updateDNSRecordParam(cfc cloudFlareChange) cloudflare.UpdateDNSRecordParams {
-	return cloudflare.UpdateDNSRecordParams{
+	params := cloudflare.UpdateDNSRecordParams{
 		Name:    cfc.ResourceRecord.Name,
 		TTL:     cfc.ResourceRecord.TTL,
 		Proxied: cfc.ResourceRecord.Proxied,
 		Type:    cfc.ResourceRecord.Type,
 		Content: cfc.ResourceRecord.Content,
 	}
+	// a nil comment keeps the current one, so records are only touched when labels are persisted
+	if cfc.ResourceRecord.Comment != "" {
+		params.Comment = &cfc.ResourceRecord.Comment
+	}
+	return params
 }
 
 // createDataLocalizationRegionalHostnameParams is a function that returns the appropriate RegionalHostname Param based on the cloudFlareChange passed in
@@ -253,18 +273,19 @@ func getCreateDNSRecordParam(cfc cloudFlareChange) cloudflare.CreateDNSRecordPar
 		Proxied: cfc.ResourceRecord.Proxied,
 		Type:    cfc.ResourceRecord.Type,
 		Content: cfc.ResourceRecord.Content,
+		Comment: cfc.ResourceRecord.Comment,
 	}
 }
 
 // NewCloudFlareProvider initializes a new CloudFlare DNS based Provider.
-func NewCloudFlareProvider(domainFilter endpoint.DomainFilter, zoneIDFilter provider.ZoneIDFilter, proxiedByDefault bool, dryRun bool, dnsRecordsPerPage int, regionKey string, customHostnamesConfig CustomHostnamesConfig) (*CloudFlareProvider, error) {
+func NewCloudFlareProvider(domainFilter endpoint.DomainFilter, zoneIDFilter provider.ZoneIDFilter, proxiedByDefault bool, dryRun bool, dnsRecordsPerPage int, regionKey string, customHostnamesConfig CustomHostnamesConfig, recordCommentLabels bool, keepProxiedDrift bool, batchSize int) (*CloudFlareProvider, error) {
 	// initialize via chosen auth method and returns new API object
 	var (
 		config *cloudflare.API
 		err    error
 	)
-	if os.Getenv("CF_API_TOKEN") != "" {
-		token := os.Getenv("CF_API_TOKEN")
+	ctx := context.Background()
+	if token := secrets.Get(ctx, "CF_API_TOKEN"); token != "" {
 		if strings.HasPrefix(token, "file:") {
 			tokenBytes, err := os.ReadFile(strings.TrimPrefix(token, "file:"))
 			if err != nil {
@@ -272,9 +293,9 @@ func NewCloudFlareProvider(domainFilter endpoint.DomainFilter, zoneIDFilter prov
 			}
 			token = strings.TrimSpace(string(tokenBytes))
 		}
-		config, err = cloudflare.NewWithAPIToken(token)
+		config, err = cloudflare.NewWithAPIToken(token, cloudflare.HTTPClient(extdnshttp.NewClient("cloudflare")))
 	} else {
-		config, err = cloudflare.New(os.Getenv("CF_API_KEY"), os.Getenv("CF_API_EMAIL"))
+		config, err = cloudflare.New(secrets.Get(ctx, "CF_API_KEY"), secrets.Get(ctx, "CF_API_EMAIL"), cloudflare.HTTPClient(extdnshttp.NewClient("cloudflare")))
 	}
 	if err != nil {
 		return nil, fmt.Errorf("failed to initialize cloudflare provider: %w", err)
@@ -289,9 +310,17 @@ func NewCloudFlareProvider(domainFilter endpoint.DomainFilter, zoneIDFilter prov
 		DryRun:                dryRun,
 		DNSRecordsPerPage:     dnsRecordsPerPage,
 		RegionKey:             regionKey,
+		RecordCommentLabels:   recordCommentLabels,
+		keepProxiedDrift:      keepProxiedDrift,
+		BatchSize:             batchSize,
 	}, nil
 }
 
+// PersistsLabels reports whether the labels of the endpoints are stored in record comments.
+func (p *CloudFlareProvider) PersistsLabels() bool {
+	return p.RecordCommentLabels
+}
+
 // Zones returns the list of hosted zones.
 func (p *CloudFlareProvider) Zones(ctx context.Context) ([]cloudflare.Zone, error) {
 	var result []cloudflare.Zone
@@ -341,8 +370,26 @@ func (p *CloudFlareProvider) Zones(ctx context.Context) ([]cloudflare.Zone, erro
 	return result, nil
 }
 
+// refreshCredentials picks up the credentials configured as secrets, which may have been rotated.
+func (p *CloudFlareProvider) refreshCredentials(ctx context.Context) {
+	z, ok := p.Client.(zoneService)
+	if !ok {
+		return
+	}
+	for name, credential := range map[string]*string{
+		"CF_API_TOKEN": &z.service.APIToken,
+		"CF_API_KEY":   &z.service.APIKey,
+		"CF_API_EMAIL": &z.service.APIEmail,
+	} {
+		if value, ok := secrets.Lookup(ctx, name); ok && *credential != "" {
+			*credential = value
+		}
+	}
+}
+
 // Records returns the list of records.
 func (p *CloudFlareProvider) Records(ctx context.Context) ([]*endpoint.Endpoint, error) {
+	p.refreshCredentials(ctx)
 	zones, err := p.Zones(ctx)
 	if err != nil {
 		return nil, err
@@ -364,14 +411,47 @@ func (p *CloudFlareProvider) Records(ctx context.Context) ([]*endpoint.Endpoint,
 		// As CloudFlare does not support "sets" of targets, but instead returns
 		// a single entry for each name/type/target, we have to group by name
 		// and record to allow the planner to calculate the correct plan. See #992.
-		endpoints = append(endpoints, groupByNameAndTypeWithCustomHostnames(records, chs)...)
+		endpoints = append(endpoints, groupByNameAndTypeWithCustomHostnames(records, chs, p.RecordCommentLabels)...)
+	}
+
+	if p.keepProxiedDrift {
+		p.recordProxiedStates(endpoints)
 	}
 
 	return endpoints, nil
 }
 
-// ApplyChanges applies a given set of changes in a given zone.
+func proxiedStateKey(name, recordType string) string {
+	return strings.ToLower(strings.TrimSuffix(name, ".")) + "/" + recordType
+}
+
+// recordProxiedStates remembers the proxied state of the records, for AdjustEndpoints to keep them.
+// Records whose targets do not share the same state are left out, so that they get repaired.
+func (p *CloudFlareProvider) recordProxiedStates(endpoints []*endpoint.Endpoint) {
+	states := make(map[string]bool, len(endpoints))
+	for _, e := range endpoints {
+		if v, ok := e.GetProviderSpecificProperty(source.CloudflareProxiedKey); ok && v != proxiedMixed {
+			states[proxiedStateKey(e.DNSName, e.RecordType)] = v == "true"
+		}
+	}
+	p.proxiedStatesMutex.Lock()
+	p.proxiedStates = states
+	p.proxiedStatesMutex.Unlock()
+}
+
+// ApplyChanges applies a given set of changes in a given zone, in batches of at most BatchSize changes.
 func (p *CloudFlareProvider) ApplyChanges(ctx context.Context, changes *plan.Changes) error {
+	p.refreshCredentials(ctx)
+	return provider.ApplyChangesInBatches(ctx, p, changes)
+}
+
+// MaxBatchSize returns the maximum number of changes applied at once.
+func (p *CloudFlareProvider) MaxBatchSize() int {
+	return p.BatchSize
+}
+
+// ApplyChangesBatch applies a batch of changes.
+func (p *CloudFlareProvider) ApplyChangesBatch(ctx context.Context, changes *plan.Changes) error {
 	var cloudflareChanges []*cloudFlareChange
 
 	// if custom hostnames are enabled, deleting first allows to avoid conflicts with the new ones
@@ -394,6 +474,12 @@ func (p *CloudFlareProvider) ApplyChanges(ctx context.Context, changes *plan.Cha
 
 		add, remove, leave := provider.Difference(current.Targets, desired.Targets)
 
+		currentProxied, _ := current.GetProviderSpecificProperty(source.CloudflareProxiedKey)
+		desiredProxied, _ := desired.GetProviderSpecificProperty(source.CloudflareProxiedKey)
+		if currentProxied != desiredProxied {
+			log.Infof("Changing proxied state of %s record %q from %s to %s", desired.RecordType, desired.DNSName, currentProxied, desiredProxied)
+		}
+
 		for _, a := range remove {
 			cloudflareChanges = append(cloudflareChanges, p.newCloudFlareChange(cloudFlareDelete, current, a, current))
 		}
@@ -729,9 +815,19 @@ func (p *CloudFlareProvider) submitChanges(ctx context.Context, changes []*cloud
 
 // AdjustEndpoints modifies the endpoints as needed by the specific provider
 func (p *CloudFlareProvider) AdjustEndpoints(endpoints []*endpoint.Endpoint) ([]*endpoint.Endpoint, error) {
+	p.proxiedStatesMutex.Lock()
+	proxiedStates := p.proxiedStates
+	p.proxiedStatesMutex.Unlock()
+
 	var adjustedEndpoints []*endpoint.Endpoint
 	for _, e := range endpoints {
 		proxied := shouldBeProxied(e, p.proxiedByDefault)
+		if _, explicit := e.GetProviderSpecificProperty(source.CloudflareProxiedKey); p.keepProxiedDrift && !explicit && !recordTypeProxyNotSupported[e.RecordType] {
+			// without an explicit state, keep the one of the existing records, the default applying to new ones
+			if current, ok := proxiedStates[proxiedStateKey(e.DNSName, e.RecordType)]; ok {
+				proxied = current
+			}
+		}
 		if proxied {
 			e.RecordTTL = 0
 		}
@@ -756,7 +852,7 @@ func (p *CloudFlareProvider) AdjustEndpoints(endpoints []*endpoint.Endpoint) ([]
 // changesByZone separates a multi-zone change into a single change per zone.
 func (p *CloudFlareProvider) changesByZone(zones []cloudflare.Zone, changeSet []*cloudFlareChange) map[string][]*cloudFlareChange {
 	changes := make(map[string][]*cloudFlareChange)
-	zoneNameIDMapper := provider.ZoneIDName{}
+	zoneNameIDMapper := p.NewZoneFinder()
 
 	for _, z := range zones {
 		zoneNameIDMapper.Add(z.ID, z.Name)
@@ -825,6 +921,10 @@ func (p *CloudFlareProvider) newCloudFlareChange(action string, ep *endpoint.End
 			RegionKey: regionKey,
 		}
 	}
+	comment := ""
+	if p.RecordCommentLabels && ep.Labels[endpoint.OwnerLabelKey] != "" {
+		comment = ep.Labels.SerializePlain(false)
+	}
 	return &cloudFlareChange{
 		Action: action,
 		ResourceRecord: cloudflare.DNSRecord{
@@ -835,6 +935,7 @@ func (p *CloudFlareProvider) newCloudFlareChange(action string, ep *endpoint.End
 			Proxied: &proxied,
 			Type:    ep.RecordType,
 			Content: target,
+			Comment: comment,
 		},
 		RegionalHostname:    regionalHostname,
 		CustomHostnamesPrev: prevCustomHostnames,
@@ -928,6 +1029,10 @@ func shouldBeProxied(ep *endpoint.Endpoint, proxiedByDefault bool) bool {
 
 	for _, v := range ep.ProviderSpecific {
 		if v.Name == source.CloudflareProxiedKey {
+			if v.Value == proxiedMixed {
+				// existing records whose targets do not share the same state, the default applies
+				break
+			}
 			b, err := strconv.ParseBool(v.Value)
 			if err != nil {
 				log.Errorf("Failed to parse annotation [%q]: %v", source.CloudflareProxiedKey, err)
@@ -967,7 +1072,7 @@ func getEndpointCustomHostnames(ep *endpoint.Endpoint) []string {
 	return []string{}
 }
 
-func groupByNameAndTypeWithCustomHostnames(records DNSRecordsMap, chs CustomHostnamesMap) []*endpoint.Endpoint {
+func groupByNameAndTypeWithCustomHostnames(records DNSRecordsMap, chs CustomHostnamesMap, commentLabels bool) []*endpoint.Endpoint {
 	var endpoints []*endpoint.Endpoint
 
 	// group supported records by name and type
@@ -1007,19 +1112,21 @@ func groupByNameAndTypeWithCustomHostnames(records DNSRecordsMap, chs CustomHost
 			records[0].Type,
 			endpoint.TTL(records[0].TTL),
 			targets...)
-		proxied := false
-		if records[0].Proxied != nil {
-			proxied = *records[0].Proxied
-		}
 		if e == nil {
 			continue
 		}
-		e = e.WithProviderSpecific(source.CloudflareProxiedKey, strconv.FormatBool(proxied))
+		e = e.WithProviderSpecific(source.CloudflareProxiedKey, groupProxiedState(records))
 		// noop (customHostnames is empty) if the custom hostnames feature is not in use
 		if customHostnames, ok := customHostnames[records[0].Name]; ok {
 			sort.Strings(customHostnames)
 			e = e.WithProviderSpecific(source.CloudflareCustomHostnameKey, strings.Join(customHostnames, ","))
 		}
+		// comments not written by external-dns are left alone, the registry falls back to TXT records for them
+		if commentLabels && records[0].Comment != "" {
+			if labels, err := endpoint.NewLabelsFromStringPlain(records[0].Comment); err == nil {
+				e.Labels = labels
+			}
+		}
 
 		endpoints = append(endpoints, e)
 	}
@@ -1027,6 +1134,21 @@ func groupByNameAndTypeWithCustomHostnames(records DNSRecordsMap, chs CustomHost
 	return endpoints
 }
 
+// groupProxiedState returns the proxied state of a group of records sharing a name and a type,
+// or proxiedMixed when their targets do not share the same state, e.g. after one of them got
+// edited in the Cloudflare dashboard. As it never matches a desired state, the records get repaired.
+func groupProxiedState(records []cloudflare.DNSRecord) string {
+	state := ""
+	for _, r := range records {
+		proxied := strconv.FormatBool(r.Proxied != nil && *r.Proxied)
+		if state != "" && state != proxied {
+			return proxiedMixed
+		}
+		state = proxied
+	}
+	return state
+}
+
 // boolPtr is used as a helper function to return a pointer to a boolean
 // Needed because some parameters require a pointer.
 func boolPtr(b bool) *bool {
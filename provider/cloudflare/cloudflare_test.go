@@ -31,10 +31,12 @@ import (
 	"github.com/maxatome/go-testdeep/td"
 	log "github.com/sirupsen/logrus"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 	"sigs.k8s.io/external-dns/endpoint"
 	"sigs.k8s.io/external-dns/internal/testutils"
 	"sigs.k8s.io/external-dns/plan"
 	"sigs.k8s.io/external-dns/provider"
+	"sigs.k8s.io/external-dns/source"
 )
 
 type MockAction struct {
@@ -123,9 +125,10 @@ func getDNSRecordFromRecordParams(rp any) cloudflare.DNSRecord {
 			Proxied: params.Proxied,
 			Type:    params.Type,
 			Content: params.Content,
+			Comment: params.Comment,
 		}
 	case cloudflare.UpdateDNSRecordParams:
-		return cloudflare.DNSRecord{
+		record := cloudflare.DNSRecord{
 			ID:      params.ID,
 			Name:    params.Name,
 			TTL:     params.TTL,
@@ -133,6 +136,10 @@ func getDNSRecordFromRecordParams(rp any) cloudflare.DNSRecord {
 			Type:    params.Type,
 			Content: params.Content,
 		}
+		if params.Comment != nil {
+			record.Comment = *params.Comment
+		}
+		return record
 	default:
 		return cloudflare.DNSRecord{}
 	}
@@ -1098,7 +1105,10 @@ func TestCloudflareProvider(t *testing.T) {
 				true,
 				5000,
 				"",
-				CustomHostnamesConfig{Enabled: false})
+				CustomHostnamesConfig{Enabled: false},
+				false,
+				false,
+				0)
 			if err != nil && !tc.ShouldFail {
 				t.Errorf("should not fail, %s", err)
 			}
@@ -1179,6 +1189,110 @@ func TestCloudflareApplyChanges(t *testing.T) {
 	}
 }
 
+func TestCloudflareRecordCommentLabels(t *testing.T) {
+	client := NewMockCloudFlareClientWithRecords(map[string][]cloudflare.DNSRecord{
+		"001": {
+			{
+				ID:      "1234567890",
+				Name:    "foobar.bar.com",
+				Type:    endpoint.RecordTypeA,
+				TTL:     120,
+				Content: "1.2.3.4",
+				Comment: "heritage=external-dns,external-dns/owner=default,external-dns/resource=service/default/foobar",
+			},
+			{
+				ID:      "2345678901",
+				Name:    "manual.bar.com",
+				Type:    endpoint.RecordTypeA,
+				TTL:     120,
+				Content: "1.2.3.5",
+				Comment: "added by hand",
+			},
+		},
+	})
+	provider := &CloudFlareProvider{
+		Client:              client,
+		RecordCommentLabels: true,
+	}
+	assert.True(t, provider.PersistsLabels())
+
+	records, err := provider.Records(context.Background())
+	require.NoError(t, err)
+	require.Len(t, records, 2)
+	for _, r := range records {
+		switch r.DNSName {
+		case "foobar.bar.com":
+			assert.Equal(t, endpoint.Labels{
+				endpoint.OwnerLabelKey:    "default",
+				endpoint.ResourceLabelKey: "service/default/foobar",
+			}, r.Labels)
+		case "manual.bar.com":
+			assert.Empty(t, r.Labels)
+		}
+	}
+
+	changes := &plan.Changes{
+		Create: []*endpoint.Endpoint{{
+			DNSName:    "new.bar.com",
+			RecordType: endpoint.RecordTypeA,
+			Targets:    endpoint.Targets{"1.2.3.6"},
+			Labels:     endpoint.Labels{endpoint.OwnerLabelKey: "default"},
+		}},
+		UpdateOld: []*endpoint.Endpoint{{
+			DNSName:    "foobar.bar.com",
+			RecordType: endpoint.RecordTypeA,
+			Targets:    endpoint.Targets{"1.2.3.4"},
+			Labels:     endpoint.Labels{endpoint.OwnerLabelKey: "default"},
+		}},
+		UpdateNew: []*endpoint.Endpoint{{
+			DNSName:    "foobar.bar.com",
+			RecordType: endpoint.RecordTypeA,
+			RecordTTL:  300,
+			Targets:    endpoint.Targets{"1.2.3.4"},
+			Labels:     endpoint.Labels{endpoint.OwnerLabelKey: "other"},
+		}},
+	}
+	require.NoError(t, provider.ApplyChanges(context.Background(), changes))
+
+	assert.Equal(t, "heritage=external-dns,external-dns/owner=default", client.Records["001"][generateDNSRecordID(endpoint.RecordTypeA, "new.bar.com", "1.2.3.6")].Comment)
+	assert.Equal(t, "heritage=external-dns,external-dns/owner=other", client.Records["001"]["1234567890"].Comment)
+}
+
+func TestCloudflareRecordCommentLabelsDisabled(t *testing.T) {
+	client := NewMockCloudFlareClientWithRecords(map[string][]cloudflare.DNSRecord{
+		"001": {
+			{
+				ID:      "1234567890",
+				Name:    "foobar.bar.com",
+				Type:    endpoint.RecordTypeA,
+				TTL:     120,
+				Content: "1.2.3.4",
+				Comment: "heritage=external-dns,external-dns/owner=default",
+			},
+		},
+	})
+	provider := &CloudFlareProvider{
+		Client: client,
+	}
+	assert.False(t, provider.PersistsLabels())
+
+	records, err := provider.Records(context.Background())
+	require.NoError(t, err)
+	require.Len(t, records, 1)
+	assert.Empty(t, records[0].Labels)
+
+	changes := &plan.Changes{
+		Create: []*endpoint.Endpoint{{
+			DNSName:    "new.bar.com",
+			RecordType: endpoint.RecordTypeA,
+			Targets:    endpoint.Targets{"1.2.3.6"},
+			Labels:     endpoint.Labels{endpoint.OwnerLabelKey: "default"},
+		}},
+	}
+	require.NoError(t, provider.ApplyChanges(context.Background(), changes))
+	assert.Empty(t, client.Records["001"][generateDNSRecordID(endpoint.RecordTypeA, "new.bar.com", "1.2.3.6")].Comment)
+}
+
 func TestCloudflareDryRunApplyChanges(t *testing.T) {
 	changes := &plan.Changes{}
 	client := NewMockCloudFlareClient()
@@ -1505,7 +1619,7 @@ func TestCloudflareGroupByNameAndType(t *testing.T) {
 		for _, r := range tc.Records {
 			records[newDNSRecordIndex(r)] = r
 		}
-		endpoints := groupByNameAndTypeWithCustomHostnames(records, CustomHostnamesMap{})
+		endpoints := groupByNameAndTypeWithCustomHostnames(records, CustomHostnamesMap{}, false)
 		// Targets order could be random with underlying map
 		for _, ep := range endpoints {
 			slices.Sort(ep.Targets)
@@ -1521,6 +1635,7 @@ func TestProviderPropertiesIdempotency(t *testing.T) {
 	testCases := []struct {
 		Name                     string
 		ProviderProxiedByDefault bool
+		KeepProxiedDrift         bool
 		RecordsAreProxied        *bool
 		ShouldBeUpdated          bool
 	}{
@@ -1548,6 +1663,20 @@ func TestProviderPropertiesIdempotency(t *testing.T) {
 			RecordsAreProxied:        proxyEnabled,
 			ShouldBeUpdated:          true,
 		},
+		{
+			Name:                     "ProxyDefault: false, KeepProxiedDrift: true, IsProxied: true, ExpectUpdates: false",
+			ProviderProxiedByDefault: false,
+			KeepProxiedDrift:         true,
+			RecordsAreProxied:        proxyEnabled,
+			ShouldBeUpdated:          false,
+		},
+		{
+			Name:                     "ProxyDefault: true, KeepProxiedDrift: true, IsProxied: false, ExpectUpdates: false",
+			ProviderProxiedByDefault: true,
+			KeepProxiedDrift:         true,
+			RecordsAreProxied:        proxyDisabled,
+			ShouldBeUpdated:          false,
+		},
 	}
 
 	for _, test := range testCases {
@@ -1568,6 +1697,7 @@ func TestProviderPropertiesIdempotency(t *testing.T) {
 			provider := &CloudFlareProvider{
 				Client:           client,
 				proxiedByDefault: test.ProviderProxiedByDefault,
+				keepProxiedDrift: test.KeepProxiedDrift,
 			}
 			ctx := context.Background()
 
@@ -1618,6 +1748,88 @@ func TestProviderPropertiesIdempotency(t *testing.T) {
 	}
 }
 
+func TestCloudflareProxiedDrift(t *testing.T) {
+	testCases := []struct {
+		Name             string
+		KeepProxiedDrift bool
+		Annotation       string
+		ExpectedProxied  bool
+	}{
+		{
+			Name:            "repair to the default",
+			ExpectedProxied: false,
+		},
+		{
+			Name:             "mixed states are repaired even when keeping drift",
+			KeepProxiedDrift: true,
+			ExpectedProxied:  false,
+		},
+		{
+			Name:             "annotation takes precedence",
+			KeepProxiedDrift: true,
+			Annotation:       "true",
+			ExpectedProxied:  true,
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.Name, func(t *testing.T) {
+			// one of the targets got proxied in the Cloudflare dashboard
+			client := NewMockCloudFlareClientWithRecords(map[string][]cloudflare.DNSRecord{
+				"001": {
+					{
+						ID:      "1234567890",
+						Name:    "foobar.bar.com",
+						Type:    endpoint.RecordTypeA,
+						TTL:     1,
+						Content: "1.2.3.4",
+						Proxied: proxyEnabled,
+					},
+					{
+						ID:      "2345678901",
+						Name:    "foobar.bar.com",
+						Type:    endpoint.RecordTypeA,
+						TTL:     1,
+						Content: "2.3.4.5",
+						Proxied: proxyDisabled,
+					},
+				},
+			})
+
+			provider := &CloudFlareProvider{
+				Client:           client,
+				keepProxiedDrift: test.KeepProxiedDrift,
+			}
+			ctx := context.Background()
+
+			current, err := provider.Records(ctx)
+			require.NoError(t, err)
+			require.Len(t, current, 1)
+			proxied, _ := current[0].GetProviderSpecificProperty(source.CloudflareProxiedKey)
+			assert.Equal(t, proxiedMixed, proxied)
+
+			desired := endpoint.NewEndpoint("foobar.bar.com", endpoint.RecordTypeA, "1.2.3.4", "2.3.4.5")
+			if test.Annotation != "" {
+				desired.SetProviderSpecificProperty(source.CloudflareProxiedKey, test.Annotation)
+			}
+			adjusted, err := provider.AdjustEndpoints([]*endpoint.Endpoint{desired})
+			require.NoError(t, err)
+
+			changes := (&plan.Plan{
+				Current:        current,
+				Desired:        adjusted,
+				ManagedRecords: []string{endpoint.RecordTypeA},
+			}).Calculate().Changes
+			require.Len(t, changes.UpdateNew, 1)
+
+			require.NoError(t, provider.ApplyChanges(ctx, changes))
+			for _, record := range client.Records["001"] {
+				assert.Equal(t, test.ExpectedProxied, *record.Proxied, record.Content)
+			}
+		})
+	}
+}
+
 func TestCloudflareComplexUpdate(t *testing.T) {
 	client := NewMockCloudFlareClientWithRecords(map[string][]cloudflare.DNSRecord{
 		"001": ExampleDomain,
@@ -1766,7 +1978,10 @@ func TestCloudFlareProvider_Region(t *testing.T) {
 		false,
 		50,
 		"us",
-		CustomHostnamesConfig{Enabled: false})
+		CustomHostnamesConfig{Enabled: false},
+		false,
+		false,
+		0)
 	if err != nil {
 		t.Fatal(err)
 	}
@@ -1786,7 +2001,10 @@ func TestCloudFlareProvider_newCloudFlareChange(t *testing.T) {
 		false,
 		50,
 		"us",
-		CustomHostnamesConfig{Enabled: false})
+		CustomHostnamesConfig{Enabled: false},
+		false,
+		false,
+		0)
 	if err != nil {
 		t.Fatal(err)
 	}
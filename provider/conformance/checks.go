@@ -0,0 +1,162 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package conformance
+
+import (
+	"context"
+	"fmt"
+
+	"sigs.k8s.io/external-dns/endpoint"
+	"sigs.k8s.io/external-dns/plan"
+	"sigs.k8s.io/external-dns/provider"
+)
+
+// Targets are taken from the documentation ranges of RFC 5737 so that test
+// records never point at a real host.
+const (
+	target1 = "192.0.2.1"
+	target2 = "192.0.2.2"
+	target3 = "198.51.100.3"
+)
+
+func (h *harness) create(ctx context.Context, endpoints ...*endpoint.Endpoint) error {
+	if err := h.apply(ctx, &plan.Changes{Create: endpoints}); err != nil {
+		return err
+	}
+	for _, ep := range endpoints {
+		if err := h.expect(ctx, ep); err != nil {
+			return fmt.Errorf("after create: %w", err)
+		}
+	}
+	return nil
+}
+
+func (h *harness) update(ctx context.Context, current, desired *endpoint.Endpoint) error {
+	changes := &plan.Changes{
+		UpdateOld: []*endpoint.Endpoint{current},
+		UpdateNew: []*endpoint.Endpoint{desired},
+	}
+	if err := h.apply(ctx, changes); err != nil {
+		return err
+	}
+	if err := h.expect(ctx, desired); err != nil {
+		return fmt.Errorf("after update: %w", err)
+	}
+	return nil
+}
+
+func checkCreate(ctx context.Context, h *harness) error {
+	return h.create(ctx, endpoint.NewEndpointWithTTL(h.name("a"), endpoint.RecordTypeA, h.ttl, target1))
+}
+
+func checkCreateCNAME(ctx context.Context, h *harness) error {
+	return h.create(ctx, endpoint.NewEndpointWithTTL(h.name("cname"), endpoint.RecordTypeCNAME, h.ttl, "target."+h.zone))
+}
+
+func checkCreateTXT(ctx context.Context, h *harness) error {
+	value := fmt.Sprintf("\"heritage=external-dns,external-dns/owner=%s\"", h.scope)
+	return h.create(ctx, endpoint.NewEndpointWithTTL(h.name("txt"), endpoint.RecordTypeTXT, h.ttl, value))
+}
+
+func checkMultiTarget(ctx context.Context, h *harness) error {
+	return h.create(ctx, endpoint.NewEndpointWithTTL(h.name("multi"), endpoint.RecordTypeA, h.ttl, target1, target2, target3))
+}
+
+func checkUpdateTargets(ctx context.Context, h *harness) error {
+	old := endpoint.NewEndpointWithTTL(h.name("targets"), endpoint.RecordTypeA, h.ttl, target1)
+	if err := h.create(ctx, old); err != nil {
+		return err
+	}
+	return h.update(ctx, old, endpoint.NewEndpointWithTTL(old.DNSName, endpoint.RecordTypeA, h.ttl, target2, target3))
+}
+
+func checkUpdateTTL(ctx context.Context, h *harness) error {
+	old := endpoint.NewEndpointWithTTL(h.name("ttl"), endpoint.RecordTypeA, h.ttl, target1)
+	if err := h.create(ctx, old); err != nil {
+		return err
+	}
+	return h.update(ctx, old, endpoint.NewEndpointWithTTL(old.DNSName, endpoint.RecordTypeA, h.ttl*2, target1))
+}
+
+func checkReplaceType(ctx context.Context, h *harness) error {
+	cname := endpoint.NewEndpointWithTTL(h.name("replace"), endpoint.RecordTypeCNAME, h.ttl, "target."+h.zone)
+	if err := h.create(ctx, cname); err != nil {
+		return err
+	}
+	a := endpoint.NewEndpointWithTTL(cname.DNSName, endpoint.RecordTypeA, h.ttl, target1)
+	changes := &plan.Changes{
+		Create: []*endpoint.Endpoint{a},
+		Delete: []*endpoint.Endpoint{cname},
+	}
+	if err := h.apply(ctx, changes); err != nil {
+		return err
+	}
+	if err := h.expectAbsent(ctx, cname.DNSName, endpoint.RecordTypeCNAME); err != nil {
+		return fmt.Errorf("after replace: %w", err)
+	}
+	if err := h.expect(ctx, a); err != nil {
+		return fmt.Errorf("after replace: %w", err)
+	}
+	return nil
+}
+
+func checkDelete(ctx context.Context, h *harness) error {
+	ep := endpoint.NewEndpointWithTTL(h.name("delete"), endpoint.RecordTypeA, h.ttl, target1, target2)
+	if err := h.create(ctx, ep); err != nil {
+		return err
+	}
+	if err := h.apply(ctx, &plan.Changes{Delete: []*endpoint.Endpoint{ep}}); err != nil {
+		return err
+	}
+	if err := h.expectAbsent(ctx, ep.DNSName, endpoint.RecordTypeA); err != nil {
+		return fmt.Errorf("after delete: %w", err)
+	}
+	return nil
+}
+
+func checkIdempotency(ctx context.Context, h *harness) error {
+	desired := func() []*endpoint.Endpoint {
+		return []*endpoint.Endpoint{
+			endpoint.NewEndpointWithTTL(h.name("idem-a"), endpoint.RecordTypeA, h.ttl, target1, target2),
+			endpoint.NewEndpoint(h.name("idem-default-ttl"), endpoint.RecordTypeA, target3),
+			endpoint.NewEndpointWithTTL(h.name("idem-cname"), endpoint.RecordTypeCNAME, h.ttl, "target."+h.zone),
+		}
+	}
+	if err := h.create(ctx, desired()...); err != nil {
+		return err
+	}
+	current, err := h.owned(ctx)
+	if err != nil {
+		return err
+	}
+	adjusted, err := h.provider.AdjustEndpoints(desired())
+	if err != nil {
+		return fmt.Errorf("adjusting endpoints: %w", err)
+	}
+	p := &plan.Plan{
+		Current:            current,
+		Desired:            adjusted,
+		ManagedRecords:     []string{endpoint.RecordTypeA, endpoint.RecordTypeCNAME},
+		PropertyComparator: provider.PropertyComparator(h.provider),
+	}
+	changes := p.Calculate().Changes
+	if changes.HasChanges() {
+		return fmt.Errorf("planned changes for records that are already up to date: create=%v updateOld=%v updateNew=%v delete=%v",
+			changes.Create, changes.UpdateOld, changes.UpdateNew, changes.Delete)
+	}
+	return nil
+}
@@ -0,0 +1,105 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Command conformance runs the provider conformance suite against a sandbox
+// zone. Flags of the suite come first; everything after "--" is parsed as
+// regular external-dns flags and selects and configures the provider:
+//
+//	go run provider/conformance/cmd/main.go --zone=sandbox.example.com -- --provider=webhook
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"os"
+	"os/signal"
+	"strings"
+	"syscall"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+
+	"sigs.k8s.io/external-dns/controller"
+	"sigs.k8s.io/external-dns/endpoint"
+	"sigs.k8s.io/external-dns/pkg/apis/externaldns"
+	"sigs.k8s.io/external-dns/provider/conformance"
+)
+
+func main() {
+	var (
+		cfg    conformance.Config
+		ttl    int64
+		checks string
+		list   bool
+	)
+	fs := flag.NewFlagSet("conformance", flag.ExitOnError)
+	fs.StringVar(&cfg.Zone, "zone", "", "Sandbox zone in which test records are created (required)")
+	fs.Int64Var(&ttl, "ttl", 300, "TTL of the test records")
+	fs.DurationVar(&cfg.Settle, "settle", 0, "Time to wait after each change before reading records back")
+	fs.StringVar(&cfg.Prefix, "prefix", "edns-conformance", "Prefix of the names of the test records")
+	fs.StringVar(&checks, "checks", "", "Comma separated list of checks to run; all checks run when empty")
+	fs.BoolVar(&list, "list", false, "List the available checks and exit")
+	_ = fs.Parse(os.Args[1:])
+
+	if list {
+		for _, c := range conformance.Checks() {
+			fmt.Printf("%-16s %s\n", c.Name, c.Description)
+		}
+		return
+	}
+
+	cfg.TTL = endpoint.TTL(ttl)
+	if checks != "" {
+		cfg.Checks = strings.Split(checks, ",")
+	}
+
+	edCfg := externaldns.NewConfig()
+	// No source is used, but the flag is required by the external-dns flag set.
+	if err := edCfg.ParseFlags(append([]string{"--source=fake"}, fs.Args()...)); err != nil {
+		log.Fatalf("flag parsing error: %v", err)
+	}
+	if len(edCfg.DomainFilter) == 0 {
+		edCfg.DomainFilter = []string{cfg.Zone}
+	}
+
+	ctx, cancel := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+	defer cancel()
+
+	domainFilter := endpoint.NewDomainFilterWithExclusions(edCfg.DomainFilter, edCfg.ExcludeDomains)
+	p, err := controller.BuildProvider(ctx, edCfg, domainFilter, nil)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	results, err := conformance.Run(ctx, p, cfg)
+	if err != nil {
+		log.Fatal(err)
+	}
+	failed := 0
+	for _, r := range results {
+		if r.Err != nil {
+			failed++
+			fmt.Printf("FAIL %-16s %s: %v\n", r.Check, r.Duration.Round(time.Millisecond), r.Err)
+			continue
+		}
+		fmt.Printf("PASS %-16s %s\n", r.Check, r.Duration.Round(time.Millisecond))
+	}
+	fmt.Printf("%d/%d checks passed\n", len(results)-failed, len(results))
+	if failed > 0 {
+		os.Exit(1)
+	}
+}
@@ -0,0 +1,301 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package conformance implements a black-box test suite that can be run
+// against any provider.Provider. The suite creates, updates and deletes
+// uniquely named records inside a sandbox zone and verifies what the provider
+// reports back through Records, covering the behaviour the controller relies
+// on: change ordering, multi-target record sets, TTL handling and idempotency.
+package conformance
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"sigs.k8s.io/external-dns/endpoint"
+	"sigs.k8s.io/external-dns/plan"
+	"sigs.k8s.io/external-dns/provider"
+)
+
+const (
+	defaultTTL    endpoint.TTL = 300
+	defaultPrefix              = "edns-conformance"
+)
+
+// Config describes the sandbox zone the suite runs against.
+type Config struct {
+	// Zone is the DNS zone in which test records are created. The provider must
+	// be allowed to manage it, and it should not hold any production records.
+	Zone string
+	// TTL is the TTL set on records that have one. Defaults to 300.
+	TTL endpoint.TTL
+	// Settle is how long to wait after each ApplyChanges before reading the
+	// records back, for providers whose API is eventually consistent.
+	Settle time.Duration
+	// Prefix is prepended to the name of every record the suite creates.
+	// Defaults to "edns-conformance".
+	Prefix string
+	// Checks limits the run to the named checks. All checks run when empty.
+	Checks []string
+}
+
+// Check is a single conformance check.
+type Check struct {
+	Name        string
+	Description string
+	run         func(ctx context.Context, h *harness) error
+}
+
+// Result is the outcome of a single check.
+type Result struct {
+	Check    string
+	Err      error
+	Duration time.Duration
+}
+
+// Checks returns all checks of the suite in the order they are run.
+func Checks() []Check {
+	return []Check{
+		{Name: "create", Description: "creates a single-target A record with a TTL", run: checkCreate},
+		{Name: "create-cname", Description: "creates a CNAME record", run: checkCreateCNAME},
+		{Name: "create-txt", Description: "creates a quoted TXT record as used by the TXT registry", run: checkCreateTXT},
+		{Name: "multi-target", Description: "creates an A record set with several targets", run: checkMultiTarget},
+		{Name: "update-targets", Description: "replaces and adds targets of an existing record set", run: checkUpdateTargets},
+		{Name: "update-ttl", Description: "changes the TTL of an existing record set", run: checkUpdateTTL},
+		{Name: "replace-type", Description: "deletes a CNAME and creates an A record of the same name in one batch", run: checkReplaceType},
+		{Name: "delete", Description: "deletes an existing record set", run: checkDelete},
+		{Name: "idempotency", Description: "plans no changes once the desired records exist", run: checkIdempotency},
+	}
+}
+
+// Run executes the selected checks against p and returns their results. Records
+// created by a check are removed when it finishes, whether it passed or not.
+func Run(ctx context.Context, p provider.Provider, cfg Config) ([]Result, error) {
+	checks, err := selectChecks(cfg)
+	if err != nil {
+		return nil, err
+	}
+	results := make([]Result, 0, len(checks))
+	for _, c := range checks {
+		results = append(results, runCheck(ctx, p, cfg, c))
+	}
+	return results, nil
+}
+
+// RunTests executes the selected checks against p as subtests of t.
+func RunTests(t *testing.T, p provider.Provider, cfg Config) {
+	t.Helper()
+	checks, err := selectChecks(cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, c := range checks {
+		t.Run(c.Name, func(t *testing.T) {
+			if r := runCheck(context.Background(), p, cfg, c); r.Err != nil {
+				t.Error(r.Err)
+			}
+		})
+	}
+}
+
+func selectChecks(cfg Config) ([]Check, error) {
+	if cfg.Zone == "" {
+		return nil, fmt.Errorf("conformance: a sandbox zone is required")
+	}
+	all := Checks()
+	if len(cfg.Checks) == 0 {
+		return all, nil
+	}
+	byName := make(map[string]Check, len(all))
+	for _, c := range all {
+		byName[c.Name] = c
+	}
+	selected := make([]Check, 0, len(cfg.Checks))
+	for _, name := range cfg.Checks {
+		c, ok := byName[name]
+		if !ok {
+			return nil, fmt.Errorf("conformance: unknown check %q", name)
+		}
+		selected = append(selected, c)
+	}
+	return selected, nil
+}
+
+func runCheck(ctx context.Context, p provider.Provider, cfg Config, c Check) Result {
+	h := newHarness(p, cfg, c.Name)
+	start := time.Now()
+	err := c.run(ctx, h)
+	if cerr := h.cleanup(ctx); err == nil && cerr != nil {
+		err = fmt.Errorf("cleanup: %w", cerr)
+	}
+	return Result{Check: c.Name, Err: err, Duration: time.Since(start)}
+}
+
+// harness holds the state of a single check run.
+type harness struct {
+	provider provider.Provider
+	zone     string
+	ttl      endpoint.TTL
+	settle   time.Duration
+	// scope is embedded in every record name created by the check, so that
+	// cleanup only ever touches records created by this run.
+	scope string
+}
+
+func newHarness(p provider.Provider, cfg Config, check string) *harness {
+	h := &harness{
+		provider: p,
+		zone:     strings.TrimSuffix(strings.ToLower(cfg.Zone), "."),
+		ttl:      cfg.TTL,
+		settle:   cfg.Settle,
+	}
+	if !h.ttl.IsConfigured() {
+		h.ttl = defaultTTL
+	}
+	prefix := cfg.Prefix
+	if prefix == "" {
+		prefix = defaultPrefix
+	}
+	h.scope = fmt.Sprintf("%s-%s-%s", prefix, check, randomSuffix())
+	return h
+}
+
+func randomSuffix() string {
+	b := make([]byte, 3)
+	_, _ = rand.Read(b)
+	return hex.EncodeToString(b)
+}
+
+// name returns a fully qualified record name owned by the check.
+func (h *harness) name(label string) string {
+	return fmt.Sprintf("%s-%s.%s", label, h.scope, h.zone)
+}
+
+// apply adjusts the desired endpoints the same way the controller does and
+// submits changes to the provider.
+func (h *harness) apply(ctx context.Context, changes *plan.Changes) error {
+	var err error
+	if changes.Create, err = h.provider.AdjustEndpoints(changes.Create); err != nil {
+		return fmt.Errorf("adjusting endpoints: %w", err)
+	}
+	if changes.UpdateNew, err = h.provider.AdjustEndpoints(changes.UpdateNew); err != nil {
+		return fmt.Errorf("adjusting endpoints: %w", err)
+	}
+	if err := h.provider.ApplyChanges(ctx, changes); err != nil {
+		return fmt.Errorf("applying changes: %w", err)
+	}
+	if h.settle > 0 {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(h.settle):
+		}
+	}
+	return nil
+}
+
+// owned returns the records reported by the provider that were created by the check.
+func (h *harness) owned(ctx context.Context) ([]*endpoint.Endpoint, error) {
+	records, err := h.provider.Records(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("listing records: %w", err)
+	}
+	var owned []*endpoint.Endpoint
+	for _, r := range records {
+		if strings.Contains(normalizeName(r.DNSName), h.scope) {
+			owned = append(owned, r)
+		}
+	}
+	return owned, nil
+}
+
+// lookup returns the record set of the given name and type, or nil when the
+// provider does not report it. A record set split across several endpoints is
+// an error, since the planner expects a single endpoint per name and type.
+func (h *harness) lookup(ctx context.Context, name, recordType string) (*endpoint.Endpoint, error) {
+	records, err := h.owned(ctx)
+	if err != nil {
+		return nil, err
+	}
+	var found []*endpoint.Endpoint
+	for _, r := range records {
+		if normalizeName(r.DNSName) == name && r.RecordType == recordType {
+			found = append(found, r)
+		}
+	}
+	switch len(found) {
+	case 0:
+		return nil, nil
+	case 1:
+		return found[0], nil
+	default:
+		return nil, fmt.Errorf("%s %s is reported as %d endpoints instead of a single record set", recordType, name, len(found))
+	}
+}
+
+// expect verifies that the provider reports want with the same targets and,
+// if want has one, the same TTL.
+func (h *harness) expect(ctx context.Context, want *endpoint.Endpoint) error {
+	got, err := h.lookup(ctx, want.DNSName, want.RecordType)
+	if err != nil {
+		return err
+	}
+	if got == nil {
+		return fmt.Errorf("%s %s not found", want.RecordType, want.DNSName)
+	}
+	if !sameTargets(got.Targets, want.Targets) {
+		return fmt.Errorf("%s %s has targets %v, expected %v", want.RecordType, want.DNSName, got.Targets, want.Targets)
+	}
+	if want.RecordTTL.IsConfigured() && got.RecordTTL != want.RecordTTL {
+		return fmt.Errorf("%s %s has TTL %d, expected %d", want.RecordType, want.DNSName, got.RecordTTL, want.RecordTTL)
+	}
+	return nil
+}
+
+// expectAbsent verifies that the provider does not report a record set of the given name and type.
+func (h *harness) expectAbsent(ctx context.Context, name, recordType string) error {
+	got, err := h.lookup(ctx, name, recordType)
+	if err != nil {
+		return err
+	}
+	if got != nil {
+		return fmt.Errorf("%s %s still exists with targets %v", recordType, name, got.Targets)
+	}
+	return nil
+}
+
+// cleanup deletes every record created by the check.
+func (h *harness) cleanup(ctx context.Context) error {
+	records, err := h.owned(ctx)
+	if err != nil || len(records) == 0 {
+		return err
+	}
+	return h.provider.ApplyChanges(ctx, &plan.Changes{Delete: records})
+}
+
+func normalizeName(name string) string {
+	return strings.TrimSuffix(strings.ToLower(name), ".")
+}
+
+// sameTargets compares targets regardless of order without sorting the inputs.
+func sameTargets(a, b endpoint.Targets) bool {
+	return append(endpoint.Targets{}, a...).Same(append(endpoint.Targets{}, b...))
+}
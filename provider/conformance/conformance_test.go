@@ -0,0 +1,132 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package conformance
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"sigs.k8s.io/external-dns/endpoint"
+	"sigs.k8s.io/external-dns/provider"
+	"sigs.k8s.io/external-dns/provider/inmemory"
+)
+
+func newInMemoryProvider() provider.Provider {
+	return inmemory.NewInMemoryProvider(inmemory.InMemoryInitZones([]string{"example.com"}))
+}
+
+func TestInMemoryConformance(t *testing.T) {
+	RunTests(t, newInMemoryProvider(), Config{Zone: "example.com"})
+}
+
+func TestRunCleansUp(t *testing.T) {
+	p := newInMemoryProvider()
+	results, err := Run(context.Background(), p, Config{Zone: "example.com.", TTL: 60, Prefix: "test"})
+	require.NoError(t, err)
+	require.Len(t, results, len(Checks()))
+	for _, r := range results {
+		assert.NoError(t, r.Err, r.Check)
+	}
+
+	records, err := p.Records(context.Background())
+	require.NoError(t, err)
+	assert.Empty(t, records)
+}
+
+func TestRunSelectedChecks(t *testing.T) {
+	results, err := Run(context.Background(), newInMemoryProvider(), Config{Zone: "example.com", Checks: []string{"delete", "create"}})
+	require.NoError(t, err)
+	require.Len(t, results, 2)
+	assert.Equal(t, "delete", results[0].Check)
+	assert.Equal(t, "create", results[1].Check)
+}
+
+func TestRunInvalidConfig(t *testing.T) {
+	_, err := Run(context.Background(), newInMemoryProvider(), Config{})
+	assert.EqualError(t, err, "conformance: a sandbox zone is required")
+
+	_, err = Run(context.Background(), newInMemoryProvider(), Config{Zone: "example.com", Checks: []string{"unknown"}})
+	assert.EqualError(t, err, `conformance: unknown check "unknown"`)
+}
+
+// splittingProvider reports every target of a record set as its own endpoint.
+type splittingProvider struct {
+	provider.Provider
+}
+
+func (p splittingProvider) Records(ctx context.Context) ([]*endpoint.Endpoint, error) {
+	records, err := p.Provider.Records(ctx)
+	if err != nil {
+		return nil, err
+	}
+	var split []*endpoint.Endpoint
+	for _, r := range records {
+		for _, target := range r.Targets {
+			split = append(split, endpoint.NewEndpointWithTTL(r.DNSName, r.RecordType, r.RecordTTL, target))
+		}
+	}
+	return split, nil
+}
+
+// ttlDroppingProvider does not report the TTL of records.
+type ttlDroppingProvider struct {
+	provider.Provider
+}
+
+func (p ttlDroppingProvider) Records(ctx context.Context) ([]*endpoint.Endpoint, error) {
+	records, err := p.Provider.Records(ctx)
+	if err != nil {
+		return nil, err
+	}
+	for _, r := range records {
+		r.RecordTTL = 0
+	}
+	return records, nil
+}
+
+func TestRunDetectsNonConformingProviders(t *testing.T) {
+	for _, tc := range []struct {
+		name     string
+		provider provider.Provider
+		check    string
+		err      string
+	}{
+		{
+			name:     "split record sets",
+			provider: splittingProvider{newInMemoryProvider()},
+			check:    "multi-target",
+			err:      "is reported as 3 endpoints instead of a single record set",
+		},
+		{
+			name:     "missing TTL",
+			provider: ttlDroppingProvider{newInMemoryProvider()},
+			check:    "update-ttl",
+			err:      "has TTL 0, expected 300",
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			results, err := Run(context.Background(), tc.provider, Config{Zone: "example.com", Checks: []string{tc.check}})
+			require.NoError(t, err)
+			require.Len(t, results, 1)
+			require.Error(t, results[0].Err)
+			assert.Contains(t, results[0].Err.Error(), tc.err)
+		})
+	}
+}
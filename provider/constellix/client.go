@@ -0,0 +1,271 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package constellix
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha1"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"sigs.k8s.io/external-dns/pkg/apis/externaldns"
+)
+
+const (
+	defaultAPIURL = "https://api.dns.constellix.com/v4"
+
+	// Record modes.
+	modeStandard = "standard"
+	modeFailover = "failover"
+	modePools    = "pools"
+)
+
+// Domain is a Constellix domain.
+type Domain struct {
+	ID   int    `json:"id"`
+	Name string `json:"name"`
+}
+
+// Record is a Constellix record. Its value depends on its mode: a list of
+// StandardValue, a FailoverValue, or a list of pool IDs.
+type Record struct {
+	ID     int             `json:"id,omitempty"`
+	Name   string          `json:"name"`
+	Type   string          `json:"type"`
+	TTL    int             `json:"ttl"`
+	Mode   string          `json:"mode"`
+	Region string          `json:"region,omitempty"`
+	Value  json.RawMessage `json:"value"`
+}
+
+// StandardValue is one of the values of a record in standard mode.
+type StandardValue struct {
+	Value   string `json:"value"`
+	Enabled bool   `json:"enabled"`
+}
+
+// FailoverValue is the value of a record in failover mode, answering with the
+// first healthy of its values.
+type FailoverValue struct {
+	Enabled bool                 `json:"enabled"`
+	Values  []FailoverValueEntry `json:"values"`
+}
+
+// FailoverValueEntry is one of the values of a failover record.
+type FailoverValueEntry struct {
+	Value        string `json:"value"`
+	Order        int    `json:"order"`
+	SonarCheckID int    `json:"sonarCheckId,omitempty"`
+	Enabled      bool   `json:"enabled"`
+}
+
+// Pool is a Constellix pool, answering with up to Return of its healthy values.
+type Pool struct {
+	ID              int         `json:"id,omitempty"`
+	Name            string      `json:"name"`
+	Type            string      `json:"type"`
+	Return          int         `json:"return"`
+	MinimumFailover int         `json:"minimumFailover"`
+	Enabled         bool        `json:"enabled"`
+	Values          []PoolValue `json:"values"`
+}
+
+// PoolValue is one of the values of a pool.
+type PoolValue struct {
+	Value        string `json:"value"`
+	Weight       int    `json:"weight"`
+	SonarCheckID int    `json:"sonarCheckId,omitempty"`
+	Enabled      bool   `json:"enabled"`
+}
+
+// Client is the subset of the Constellix DNS API v4 used by the provider.
+type Client interface {
+	ListDomains(ctx context.Context) ([]Domain, error)
+	ListRecords(ctx context.Context, domainID int) ([]Record, error)
+	CreateRecord(ctx context.Context, domainID int, record Record) error
+	UpdateRecord(ctx context.Context, domainID int, record Record) error
+	DeleteRecord(ctx context.Context, domainID, recordID int) error
+	GetPool(ctx context.Context, poolType string, poolID int) (Pool, error)
+	CreatePool(ctx context.Context, pool Pool) (Pool, error)
+	UpdatePool(ctx context.Context, pool Pool) error
+	DeletePool(ctx context.Context, poolType string, poolID int) error
+}
+
+// signingTransport signs requests to the Constellix API with an HMAC of the
+// request time keyed by the secret key, as the Authorization header
+// "Bearer <api key>:<hmac>:<time>".
+type signingTransport struct {
+	apiKey    string
+	secretKey string
+	now       func() time.Time
+	next      http.RoundTripper
+}
+
+// NewSigningTransport returns an http.RoundTripper signing requests to the
+// Constellix API with the given keys, for use by any Constellix API client.
+func NewSigningTransport(apiKey, secretKey string, next http.RoundTripper) http.RoundTripper {
+	if next == nil {
+		next = http.DefaultTransport
+	}
+	return &signingTransport{apiKey: apiKey, secretKey: secretKey, now: time.Now, next: next}
+}
+
+func (t *signingTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	timestamp := strconv.FormatInt(t.now().UnixMilli(), 10)
+	mac := hmac.New(sha1.New, []byte(t.secretKey))
+	mac.Write([]byte(timestamp))
+	signature := base64.StdEncoding.EncodeToString(mac.Sum(nil))
+
+	req = req.Clone(req.Context())
+	req.Header.Set("Authorization", fmt.Sprintf("Bearer %s:%s:%s", t.apiKey, signature, timestamp))
+	return t.next.RoundTrip(req)
+}
+
+type client struct {
+	apiURL     string
+	httpClient *http.Client
+}
+
+// NewClient returns a client authenticating with the given API and secret keys.
+func NewClient(apiKey, secretKey string) Client {
+	return &client{
+		apiURL:     defaultAPIURL,
+		httpClient: &http.Client{Transport: NewSigningTransport(apiKey, secretKey, nil)},
+	}
+}
+
+func (c *client) do(ctx context.Context, method, path string, query url.Values, body any, result any) error {
+	u := c.apiURL + path
+	if len(query) > 0 {
+		u += "?" + query.Encode()
+	}
+
+	var reader io.Reader
+	if body != nil {
+		data, err := json.Marshal(body)
+		if err != nil {
+			return err
+		}
+		reader = bytes.NewReader(data)
+	}
+	req, err := http.NewRequestWithContext(ctx, method, u, reader)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Accept", "application/json")
+	req.Header.Set("User-Agent", externaldns.UserAgent())
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		var apiErr struct {
+			Message string   `json:"message"`
+			Errors  []string `json:"errors"`
+		}
+		_ = json.NewDecoder(resp.Body).Decode(&apiErr)
+		if msg := strings.Join(append([]string{apiErr.Message}, apiErr.Errors...), " "); strings.TrimSpace(msg) != "" {
+			return fmt.Errorf("%s %s: %s (status %d)", method, path, strings.TrimSpace(msg), resp.StatusCode)
+		}
+		return fmt.Errorf("%s %s: unexpected status %s", method, path, resp.Status)
+	}
+	if result == nil {
+		return nil
+	}
+	return json.NewDecoder(resp.Body).Decode(result)
+}
+
+// list fetches all the pages of a collection.
+func list[T any](ctx context.Context, c *client, path string) ([]T, error) {
+	var all []T
+	for page := 1; ; page++ {
+		var resp struct {
+			Data []T `json:"data"`
+			Meta struct {
+				Pagination struct {
+					TotalPages int `json:"totalPages"`
+				} `json:"pagination"`
+			} `json:"meta"`
+		}
+		if err := c.do(ctx, http.MethodGet, path, url.Values{"page": {strconv.Itoa(page)}}, nil, &resp); err != nil {
+			return nil, err
+		}
+		all = append(all, resp.Data...)
+		if page >= resp.Meta.Pagination.TotalPages {
+			return all, nil
+		}
+	}
+}
+
+func (c *client) ListDomains(ctx context.Context) ([]Domain, error) {
+	return list[Domain](ctx, c, "/domains")
+}
+
+func (c *client) ListRecords(ctx context.Context, domainID int) ([]Record, error) {
+	return list[Record](ctx, c, fmt.Sprintf("/domains/%d/records", domainID))
+}
+
+func (c *client) CreateRecord(ctx context.Context, domainID int, record Record) error {
+	return c.do(ctx, http.MethodPost, fmt.Sprintf("/domains/%d/records", domainID), nil, record, nil)
+}
+
+func (c *client) UpdateRecord(ctx context.Context, domainID int, record Record) error {
+	return c.do(ctx, http.MethodPut, fmt.Sprintf("/domains/%d/records/%d", domainID, record.ID), nil, record, nil)
+}
+
+func (c *client) DeleteRecord(ctx context.Context, domainID, recordID int) error {
+	return c.do(ctx, http.MethodDelete, fmt.Sprintf("/domains/%d/records/%d", domainID, recordID), nil, nil, nil)
+}
+
+func (c *client) GetPool(ctx context.Context, poolType string, poolID int) (Pool, error) {
+	var resp struct {
+		Data Pool `json:"data"`
+	}
+	err := c.do(ctx, http.MethodGet, fmt.Sprintf("/pools/%s/%d", strings.ToLower(poolType), poolID), nil, nil, &resp)
+	return resp.Data, err
+}
+
+func (c *client) CreatePool(ctx context.Context, pool Pool) (Pool, error) {
+	var resp struct {
+		Data Pool `json:"data"`
+	}
+	err := c.do(ctx, http.MethodPost, "/pools/"+strings.ToLower(pool.Type), nil, pool, &resp)
+	return resp.Data, err
+}
+
+func (c *client) UpdatePool(ctx context.Context, pool Pool) error {
+	return c.do(ctx, http.MethodPut, fmt.Sprintf("/pools/%s/%d", strings.ToLower(pool.Type), pool.ID), nil, pool, nil)
+}
+
+func (c *client) DeletePool(ctx context.Context, poolType string, poolID int) error {
+	return c.do(ctx, http.MethodDelete, fmt.Sprintf("/pools/%s/%d", strings.ToLower(poolType), poolID), nil, nil, nil)
+}
@@ -0,0 +1,122 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package constellix
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSigningTransport(t *testing.T) {
+	var authorization string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		authorization = r.Header.Get("Authorization")
+	}))
+	defer server.Close()
+
+	transport := NewSigningTransport("api-key", "secret", server.Client().Transport).(*signingTransport)
+	transport.now = func() time.Time { return time.UnixMilli(1700000000000) }
+
+	resp, err := (&http.Client{Transport: transport}).Get(server.URL)
+	require.NoError(t, err)
+	resp.Body.Close()
+	// base64(HMAC-SHA1("secret", "1700000000000"))
+	assert.Equal(t, "Bearer api-key:GkxmUVZIPEAQC5SikuOBv4kZAhc=:1700000000000", authorization)
+}
+
+func TestClient(t *testing.T) {
+	var requests []string
+	var bodies []map[string]any
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests = append(requests, r.Method+" "+r.URL.RequestURI())
+		if data, _ := io.ReadAll(r.Body); len(data) > 0 {
+			var body map[string]any
+			require.NoError(t, json.Unmarshal(data, &body))
+			bodies = append(bodies, body)
+		}
+		switch r.Method + " " + r.URL.Path {
+		case "GET /domains":
+			if r.URL.Query().Get("page") == "1" {
+				_, _ = w.Write([]byte(`{"data":[{"id":1,"name":"example.com"}],"meta":{"pagination":{"totalPages":2}}}`))
+			} else {
+				_, _ = w.Write([]byte(`{"data":[{"id":2,"name":"example.org"}],"meta":{"pagination":{"totalPages":2}}}`))
+			}
+		case "GET /domains/1/records":
+			_, _ = w.Write([]byte(`{"data":[{"id":10,"name":"www","type":"A","ttl":300,"mode":"standard","region":"default","value":[{"value":"1.2.3.4","enabled":true}]}],"meta":{"pagination":{"totalPages":1}}}`))
+		case "GET /pools/a/5":
+			_, _ = w.Write([]byte(`{"data":{"id":5,"name":"pool","type":"A","return":2,"minimumFailover":1,"enabled":true,"values":[{"value":"1.2.3.4","weight":1,"enabled":true}]}}`))
+		case "POST /pools/a":
+			_, _ = w.Write([]byte(`{"data":{"id":6,"name":"pool","type":"A","return":1}}`))
+		case "DELETE /domains/1/records/11":
+			w.WriteHeader(http.StatusNotFound)
+			_, _ = w.Write([]byte(`{"message":"Record not found"}`))
+		}
+	}))
+	defer server.Close()
+
+	c := &client{apiURL: server.URL, httpClient: server.Client()}
+
+	domains, err := c.ListDomains(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, []Domain{{ID: 1, Name: "example.com"}, {ID: 2, Name: "example.org"}}, domains)
+
+	records, err := c.ListRecords(context.Background(), 1)
+	require.NoError(t, err)
+	require.Len(t, records, 1)
+	assert.Equal(t, modeStandard, records[0].Mode)
+	assert.JSONEq(t, `[{"value":"1.2.3.4","enabled":true}]`, string(records[0].Value))
+
+	pool, err := c.GetPool(context.Background(), "A", 5)
+	require.NoError(t, err)
+	assert.Equal(t, 2, pool.Return)
+
+	created, err := c.CreatePool(context.Background(), Pool{Name: "pool", Type: "A", Return: 1})
+	require.NoError(t, err)
+	assert.Equal(t, 6, created.ID)
+
+	require.NoError(t, c.CreateRecord(context.Background(), 1, Record{Name: "new", Type: "A", TTL: 60, Mode: modeStandard, Value: rawValue([]StandardValue{{Value: "4.3.2.1", Enabled: true}})}))
+	assert.Equal(t, "new", bodies[1]["name"])
+
+	require.NoError(t, c.UpdateRecord(context.Background(), 1, Record{ID: 10, Name: "www", Type: "A", TTL: 60, Mode: modeStandard}))
+	require.NoError(t, c.UpdatePool(context.Background(), Pool{ID: 6, Type: "A"}))
+	require.NoError(t, c.DeletePool(context.Background(), "A", 6))
+
+	err = c.DeleteRecord(context.Background(), 1, 11)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "Record not found")
+
+	assert.Equal(t, []string{
+		"GET /domains?page=1",
+		"GET /domains?page=2",
+		"GET /domains/1/records?page=1",
+		"GET /pools/a/5",
+		"POST /pools/a",
+		"POST /domains/1/records",
+		"PUT /domains/1/records/10",
+		"PUT /pools/a/6",
+		"DELETE /pools/a/6",
+		"DELETE /domains/1/records/11",
+	}, requests)
+}
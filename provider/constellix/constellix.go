@@ -0,0 +1,559 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package constellix
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"slices"
+	"strconv"
+	"strings"
+
+	log "github.com/sirupsen/logrus"
+
+	"sigs.k8s.io/external-dns/endpoint"
+	"sigs.k8s.io/external-dns/plan"
+	"sigs.k8s.io/external-dns/provider"
+)
+
+const (
+	// providerSpecificFailover makes a record answer with the first healthy of
+	// its targets, in the order they are given.
+	providerSpecificFailover = "constellix/failover"
+	// providerSpecificPoolReturn makes a record answer with up to that many of
+	// its healthy targets, through a pool managed alongside the record.
+	providerSpecificPoolReturn = "constellix/pool-return"
+	// providerSpecificHealthChecks attaches Sonar checks to the targets of a
+	// failover or pool record, as a comma separated list of target=check-id.
+	providerSpecificHealthChecks = "constellix/health-checks"
+
+	// defaultTTL is the TTL given to records created without one.
+	defaultTTL = 3600
+	// defaultRegion is the region of the records answering every query.
+	defaultRegion = "default"
+	// poolNamePrefix prefixes the names of the pools managed by ExternalDNS.
+	poolNamePrefix = "external-dns-"
+)
+
+// ConstellixProvider is an implementation of Provider for Constellix DNS.
+type ConstellixProvider struct {
+	provider.BaseProvider
+	client       Client
+	domainFilter endpoint.DomainFilter
+	dryRun       bool
+}
+
+// NewConstellixProvider initializes a new Constellix DNS based Provider.
+// The API keys are read from the CONSTELLIX_API_KEY and CONSTELLIX_SECRET_KEY env vars.
+func NewConstellixProvider(domainFilter endpoint.DomainFilter, dryRun bool) (*ConstellixProvider, error) {
+	apiKey, secretKey := os.Getenv("CONSTELLIX_API_KEY"), os.Getenv("CONSTELLIX_SECRET_KEY")
+	if apiKey == "" || secretKey == "" {
+		return nil, fmt.Errorf("no constellix api keys provided, you must set the CONSTELLIX_API_KEY and CONSTELLIX_SECRET_KEY env vars")
+	}
+
+	return &ConstellixProvider{
+		client:       NewClient(apiKey, secretKey),
+		domainFilter: domainFilter,
+		dryRun:       dryRun,
+	}, nil
+}
+
+// Records returns the list of records in all managed domains.
+func (p *ConstellixProvider) Records(ctx context.Context) ([]*endpoint.Endpoint, error) {
+	domains, err := p.domains(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var endpoints []*endpoint.Endpoint
+	for _, domain := range domains {
+		records, err := p.client.ListRecords(ctx, domain.ID)
+		if err != nil {
+			return nil, provider.NewSoftError(fmt.Errorf("failed to list records of domain %s: %w", domain.Name, err))
+		}
+		for _, record := range records {
+			if !p.SupportedRecordType(record.Type) || !isDefaultRegion(record) {
+				continue
+			}
+			ep, err := p.endpoint(ctx, domain.Name, record)
+			if err != nil {
+				return nil, provider.NewSoftError(err)
+			}
+			if ep != nil {
+				endpoints = append(endpoints, ep)
+			}
+		}
+	}
+
+	return endpoints, nil
+}
+
+// endpoint returns the endpoint of a record, reading its pool for records in pools mode.
+func (p *ConstellixProvider) endpoint(ctx context.Context, zone string, record Record) (*endpoint.Endpoint, error) {
+	ep := endpoint.NewEndpointWithTTL(fqdn(record.Name, zone), record.Type, endpoint.TTL(record.TTL))
+	checks := map[string]int{}
+
+	switch record.Mode {
+	case modeStandard, "":
+		var values []StandardValue
+		if err := json.Unmarshal(record.Value, &values); err != nil {
+			return nil, fmt.Errorf("failed to decode value of %s record %s: %w", record.Type, ep.DNSName, err)
+		}
+		for _, v := range values {
+			ep.Targets = append(ep.Targets, target(record.Type, v.Value))
+		}
+	case modeFailover:
+		var value FailoverValue
+		if err := json.Unmarshal(record.Value, &value); err != nil {
+			return nil, fmt.Errorf("failed to decode value of %s record %s: %w", record.Type, ep.DNSName, err)
+		}
+		slices.SortStableFunc(value.Values, func(a, b FailoverValueEntry) int { return a.Order - b.Order })
+		for _, v := range value.Values {
+			t := target(record.Type, v.Value)
+			ep.Targets = append(ep.Targets, t)
+			if v.SonarCheckID != 0 {
+				checks[t] = v.SonarCheckID
+			}
+		}
+		ep.SetProviderSpecificProperty(providerSpecificFailover, "true")
+	case modePools:
+		var poolIDs []int
+		if err := json.Unmarshal(record.Value, &poolIDs); err != nil {
+			return nil, fmt.Errorf("failed to decode value of %s record %s: %w", record.Type, ep.DNSName, err)
+		}
+		if len(poolIDs) != 1 {
+			log.Debugf("Skipping %s record %s because it does not use exactly one pool", record.Type, ep.DNSName)
+			return nil, nil
+		}
+		pool, err := p.client.GetPool(ctx, record.Type, poolIDs[0])
+		if err != nil {
+			return nil, fmt.Errorf("failed to get pool %d of %s record %s: %w", poolIDs[0], record.Type, ep.DNSName, err)
+		}
+		for _, v := range pool.Values {
+			t := target(record.Type, v.Value)
+			ep.Targets = append(ep.Targets, t)
+			if v.SonarCheckID != 0 {
+				checks[t] = v.SonarCheckID
+			}
+		}
+		ep.SetProviderSpecificProperty(providerSpecificPoolReturn, strconv.Itoa(pool.Return))
+	default:
+		log.Debugf("Skipping %s record %s in unsupported mode %s", record.Type, ep.DNSName, record.Mode)
+		return nil, nil
+	}
+
+	if len(checks) > 0 {
+		ep.SetProviderSpecificProperty(providerSpecificHealthChecks, formatHealthChecks(checks))
+	}
+	return ep, nil
+}
+
+// AdjustEndpoints validates the failover and pool properties of the endpoints, dropping
+// the ones that cannot apply, and normalizes their health checks.
+func (p *ConstellixProvider) AdjustEndpoints(endpoints []*endpoint.Endpoint) ([]*endpoint.Endpoint, error) {
+	for _, ep := range endpoints {
+		failover, hasFailover := ep.GetProviderSpecificProperty(providerSpecificFailover)
+		poolReturn, hasPool := ep.GetProviderSpecificProperty(providerSpecificPoolReturn)
+		checks, hasChecks := ep.GetProviderSpecificProperty(providerSpecificHealthChecks)
+
+		if !supportsFailover(ep.RecordType) {
+			if hasFailover || hasPool || hasChecks {
+				log.Warnf("Ignoring failover and pool settings of %s record %s, only A, AAAA and CNAME records support them", ep.RecordType, ep.DNSName)
+			}
+			ep.DeleteProviderSpecificProperty(providerSpecificFailover)
+			ep.DeleteProviderSpecificProperty(providerSpecificPoolReturn)
+			ep.DeleteProviderSpecificProperty(providerSpecificHealthChecks)
+			continue
+		}
+
+		if hasFailover && failover != "true" {
+			ep.DeleteProviderSpecificProperty(providerSpecificFailover)
+			hasFailover = false
+		}
+		if hasPool {
+			if n, err := strconv.Atoi(poolReturn); err != nil || n < 1 {
+				log.Warnf("Ignoring invalid %s %q of %s record %s", providerSpecificPoolReturn, poolReturn, ep.RecordType, ep.DNSName)
+				ep.DeleteProviderSpecificProperty(providerSpecificPoolReturn)
+				hasPool = false
+			} else {
+				ep.SetProviderSpecificProperty(providerSpecificPoolReturn, strconv.Itoa(n))
+			}
+		}
+		if hasPool && hasFailover {
+			log.Warnf("Ignoring %s of %s record %s, which also sets %s", providerSpecificFailover, ep.RecordType, ep.DNSName, providerSpecificPoolReturn)
+			ep.DeleteProviderSpecificProperty(providerSpecificFailover)
+			hasFailover = false
+		}
+
+		if !hasChecks {
+			continue
+		}
+		parsed, err := parseHealthChecks(checks)
+		if err != nil {
+			log.Warnf("Ignoring invalid %s of %s record %s: %v", providerSpecificHealthChecks, ep.RecordType, ep.DNSName, err)
+		}
+		for t := range parsed {
+			if !slices.Contains(ep.Targets, t) {
+				log.Warnf("Ignoring health check of %s, which is not a target of %s record %s", t, ep.RecordType, ep.DNSName)
+				delete(parsed, t)
+			}
+		}
+		if err != nil || len(parsed) == 0 || (!hasFailover && !hasPool) {
+			ep.DeleteProviderSpecificProperty(providerSpecificHealthChecks)
+			continue
+		}
+		ep.SetProviderSpecificProperty(providerSpecificHealthChecks, formatHealthChecks(parsed))
+	}
+	return endpoints, nil
+}
+
+// SupportedRecordType returns true if the record type is supported by the provider
+func (p *ConstellixProvider) SupportedRecordType(recordType string) bool {
+	switch recordType {
+	case endpoint.RecordTypeA, endpoint.RecordTypeAAAA, endpoint.RecordTypeCNAME, endpoint.RecordTypeTXT:
+		return true
+	default:
+		return false
+	}
+}
+
+// ApplyChanges applies the given changes, one API call per record and pool.
+func (p *ConstellixProvider) ApplyChanges(ctx context.Context, changes *plan.Changes) error {
+	domains, err := p.domains(ctx)
+	if err != nil {
+		return err
+	}
+	zoneNameIDMapper := p.NewZoneFinder()
+	for _, domain := range domains {
+		zoneNameIDMapper.Add(strconv.Itoa(domain.ID), domain.Name)
+	}
+
+	recordsByDomain := map[int][]Record{}
+	existing := func(domainID int, zone string, ep *endpoint.Endpoint) (Record, bool, error) {
+		records, ok := recordsByDomain[domainID]
+		if !ok {
+			var err error
+			if records, err = p.client.ListRecords(ctx, domainID); err != nil {
+				return Record{}, false, provider.NewSoftError(fmt.Errorf("failed to list records of domain %s: %w", zone, err))
+			}
+			recordsByDomain[domainID] = records
+		}
+		for _, record := range records {
+			if record.Type == ep.RecordType && fqdn(record.Name, zone) == ep.DNSName && isDefaultRegion(record) {
+				return record, true, nil
+			}
+		}
+		return Record{}, false, nil
+	}
+	findDomain := func(ep *endpoint.Endpoint) (int, string) {
+		id, zone := zoneNameIDMapper.FindZone(ep.DNSName)
+		if zone == "" {
+			log.Debugf("Skipping record %s because no domain matching record DNS Name was detected", ep.DNSName)
+			return 0, ""
+		}
+		domainID, _ := strconv.Atoi(id)
+		return domainID, zone
+	}
+
+	for _, ep := range changes.Delete {
+		domainID, zone := findDomain(ep)
+		if zone == "" {
+			continue
+		}
+		record, ok, err := existing(domainID, zone, ep)
+		if err != nil {
+			return err
+		}
+		if !ok {
+			continue
+		}
+		if err := p.deleteRecord(ctx, domainID, zone, ep, record); err != nil {
+			return err
+		}
+	}
+
+	for _, ep := range changes.UpdateNew {
+		domainID, zone := findDomain(ep)
+		if zone == "" {
+			continue
+		}
+		record, ok, err := existing(domainID, zone, ep)
+		if err != nil {
+			return err
+		}
+		if !ok {
+			if err := p.createRecord(ctx, domainID, zone, ep); err != nil {
+				return err
+			}
+			continue
+		}
+		if err := p.updateRecord(ctx, domainID, zone, ep, record); err != nil {
+			return err
+		}
+	}
+
+	for _, ep := range changes.Create {
+		domainID, zone := findDomain(ep)
+		if zone == "" {
+			continue
+		}
+		if err := p.createRecord(ctx, domainID, zone, ep); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (p *ConstellixProvider) domains(ctx context.Context) ([]Domain, error) {
+	all, err := p.client.ListDomains(ctx)
+	if err != nil {
+		return nil, provider.NewSoftError(fmt.Errorf("failed to list domains: %w", err))
+	}
+
+	var domains []Domain
+	for _, domain := range all {
+		if p.domainFilter.Match(domain.Name) {
+			domains = append(domains, domain)
+		}
+	}
+	return domains, nil
+}
+
+func (p *ConstellixProvider) createRecord(ctx context.Context, domainID int, zone string, ep *endpoint.Endpoint) error {
+	record, pool, err := newRecord(zone, ep)
+	if err != nil {
+		log.Warnf("Skipping %s record %s: %v", ep.RecordType, ep.DNSName, err)
+		return nil
+	}
+	log.Infof("Creating %s record %s in %s mode with targets %s in domain %s", ep.RecordType, ep.DNSName, record.Mode, ep.Targets, zone)
+	if p.dryRun {
+		return nil
+	}
+	if pool != nil {
+		created, err := p.client.CreatePool(ctx, *pool)
+		if err != nil {
+			return provider.NewSoftError(fmt.Errorf("failed to create pool of %s record %s: %w", ep.RecordType, ep.DNSName, err))
+		}
+		record.Value = rawValue([]int{created.ID})
+	}
+	if err := p.client.CreateRecord(ctx, domainID, record); err != nil {
+		return provider.NewSoftError(fmt.Errorf("failed to create %s record %s: %w", ep.RecordType, ep.DNSName, err))
+	}
+	return nil
+}
+
+// updateRecord replaces the value of an existing record, creating, updating or deleting
+// its pool as the record moves in and out of pools mode.
+func (p *ConstellixProvider) updateRecord(ctx context.Context, domainID int, zone string, ep *endpoint.Endpoint, current Record) error {
+	record, pool, err := newRecord(zone, ep)
+	if err != nil {
+		log.Warnf("Skipping %s record %s: %v", ep.RecordType, ep.DNSName, err)
+		return nil
+	}
+	record.ID = current.ID
+	currentPool := poolID(current)
+	log.Infof("Updating %s record %s in %s mode with targets %s in domain %s", ep.RecordType, ep.DNSName, record.Mode, ep.Targets, zone)
+	if p.dryRun {
+		return nil
+	}
+
+	if pool != nil {
+		if currentPool != 0 {
+			pool.ID = currentPool
+			if err := p.client.UpdatePool(ctx, *pool); err != nil {
+				return provider.NewSoftError(fmt.Errorf("failed to update pool of %s record %s: %w", ep.RecordType, ep.DNSName, err))
+			}
+		} else {
+			created, err := p.client.CreatePool(ctx, *pool)
+			if err != nil {
+				return provider.NewSoftError(fmt.Errorf("failed to create pool of %s record %s: %w", ep.RecordType, ep.DNSName, err))
+			}
+			pool.ID = created.ID
+		}
+		record.Value = rawValue([]int{pool.ID})
+	}
+	if err := p.client.UpdateRecord(ctx, domainID, record); err != nil {
+		return provider.NewSoftError(fmt.Errorf("failed to update %s record %s: %w", ep.RecordType, ep.DNSName, err))
+	}
+	if pool == nil && currentPool != 0 {
+		if err := p.client.DeletePool(ctx, current.Type, currentPool); err != nil {
+			return provider.NewSoftError(fmt.Errorf("failed to delete pool of %s record %s: %w", ep.RecordType, ep.DNSName, err))
+		}
+	}
+	return nil
+}
+
+func (p *ConstellixProvider) deleteRecord(ctx context.Context, domainID int, zone string, ep *endpoint.Endpoint, record Record) error {
+	log.Infof("Deleting %s record %s in domain %s", ep.RecordType, ep.DNSName, zone)
+	if p.dryRun {
+		return nil
+	}
+	if err := p.client.DeleteRecord(ctx, domainID, record.ID); err != nil {
+		return provider.NewSoftError(fmt.Errorf("failed to delete %s record %s: %w", ep.RecordType, ep.DNSName, err))
+	}
+	if id := poolID(record); id != 0 {
+		if err := p.client.DeletePool(ctx, record.Type, id); err != nil {
+			return provider.NewSoftError(fmt.Errorf("failed to delete pool of %s record %s: %w", ep.RecordType, ep.DNSName, err))
+		}
+	}
+	return nil
+}
+
+// newRecord returns the Constellix record of an endpoint, along with the pool it answers
+// from in pools mode. The value of a record in pools mode is only set once its pool exists.
+func newRecord(zone string, ep *endpoint.Endpoint) (Record, *Pool, error) {
+	record := Record{
+		Name:   relativeName(ep.DNSName, zone),
+		Type:   ep.RecordType,
+		TTL:    defaultTTL,
+		Mode:   modeStandard,
+		Region: defaultRegion,
+	}
+	if ep.RecordTTL.IsConfigured() {
+		record.TTL = int(ep.RecordTTL)
+	}
+
+	checks := map[string]int{}
+	if value, ok := ep.GetProviderSpecificProperty(providerSpecificHealthChecks); ok {
+		var err error
+		if checks, err = parseHealthChecks(value); err != nil {
+			return Record{}, nil, err
+		}
+	}
+
+	if value, ok := ep.GetProviderSpecificProperty(providerSpecificPoolReturn); ok {
+		n, err := strconv.Atoi(value)
+		if err != nil || n < 1 {
+			return Record{}, nil, fmt.Errorf("invalid %s %q", providerSpecificPoolReturn, value)
+		}
+		pool := &Pool{
+			Name:            poolNamePrefix + ep.DNSName + "-" + strings.ToLower(ep.RecordType),
+			Type:            ep.RecordType,
+			Return:          n,
+			MinimumFailover: 1,
+			Enabled:         true,
+		}
+		for _, t := range ep.Targets {
+			pool.Values = append(pool.Values, PoolValue{Value: constellixValue(ep.RecordType, t), Weight: 1, SonarCheckID: checks[t], Enabled: true})
+		}
+		record.Mode = modePools
+		return record, pool, nil
+	}
+
+	if value, ok := ep.GetProviderSpecificProperty(providerSpecificFailover); ok && value == "true" {
+		failover := FailoverValue{Enabled: true}
+		for i, t := range ep.Targets {
+			failover.Values = append(failover.Values, FailoverValueEntry{Value: constellixValue(ep.RecordType, t), Order: i + 1, SonarCheckID: checks[t], Enabled: true})
+		}
+		record.Mode = modeFailover
+		record.Value = rawValue(failover)
+		return record, nil, nil
+	}
+
+	values := make([]StandardValue, 0, len(ep.Targets))
+	for _, t := range ep.Targets {
+		values = append(values, StandardValue{Value: constellixValue(ep.RecordType, t), Enabled: true})
+	}
+	record.Value = rawValue(values)
+	return record, nil, nil
+}
+
+// poolID returns the ID of the pool a record answers from, or 0 if it is not in pools mode.
+func poolID(record Record) int {
+	if record.Mode != modePools {
+		return 0
+	}
+	var ids []int
+	if err := json.Unmarshal(record.Value, &ids); err != nil || len(ids) != 1 {
+		return 0
+	}
+	return ids[0]
+}
+
+// parseHealthChecks parses a list of target=check-id pairs.
+func parseHealthChecks(value string) (map[string]int, error) {
+	checks := map[string]int{}
+	for _, pair := range strings.Split(value, ",") {
+		pair = strings.TrimSpace(pair)
+		if pair == "" {
+			continue
+		}
+		t, id, ok := strings.Cut(pair, "=")
+		checkID, err := strconv.Atoi(strings.TrimSpace(id))
+		if !ok || err != nil || checkID < 1 {
+			return nil, fmt.Errorf("invalid health check %q, expected target=check-id", pair)
+		}
+		checks[strings.TrimSpace(t)] = checkID
+	}
+	return checks, nil
+}
+
+// formatHealthChecks formats health checks as a list of target=check-id pairs sorted by target.
+func formatHealthChecks(checks map[string]int) string {
+	pairs := make([]string, 0, len(checks))
+	for t, id := range checks {
+		pairs = append(pairs, t+"="+strconv.Itoa(id))
+	}
+	slices.Sort(pairs)
+	return strings.Join(pairs, ",")
+}
+
+func supportsFailover(recordType string) bool {
+	return recordType == endpoint.RecordTypeA || recordType == endpoint.RecordTypeAAAA || recordType == endpoint.RecordTypeCNAME
+}
+
+func isDefaultRegion(record Record) bool {
+	return record.Region == "" || record.Region == defaultRegion
+}
+
+func rawValue(v any) json.RawMessage {
+	data, _ := json.Marshal(v)
+	return data
+}
+
+// constellixValue returns the Constellix value of a target, which is fully qualified for CNAME records.
+func constellixValue(recordType, t string) string {
+	if recordType == endpoint.RecordTypeCNAME && !strings.HasSuffix(t, ".") {
+		return t + "."
+	}
+	return t
+}
+
+// target returns the target of a Constellix value.
+func target(recordType, v string) string {
+	if recordType == endpoint.RecordTypeCNAME {
+		return strings.TrimSuffix(v, ".")
+	}
+	return v
+}
+
+func fqdn(name, zone string) string {
+	if name == "" {
+		return zone
+	}
+	return name + "." + zone
+}
+
+func relativeName(dnsName, zone string) string {
+	if dnsName == zone {
+		return ""
+	}
+	return strings.TrimSuffix(dnsName, "."+zone)
+}
@@ -0,0 +1,285 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package constellix
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"sigs.k8s.io/external-dns/endpoint"
+	"sigs.k8s.io/external-dns/internal/testutils"
+	"sigs.k8s.io/external-dns/plan"
+)
+
+type mockClient struct {
+	domains      []Domain
+	records      map[int][]Record
+	pools        map[int]Pool
+	created      []Record
+	updated      []Record
+	deleted      []int
+	createdPools []Pool
+	updatedPools []Pool
+	deletedPools []int
+}
+
+func (m *mockClient) ListDomains(_ context.Context) ([]Domain, error) {
+	return m.domains, nil
+}
+
+func (m *mockClient) ListRecords(_ context.Context, domainID int) ([]Record, error) {
+	return m.records[domainID], nil
+}
+
+func (m *mockClient) CreateRecord(_ context.Context, _ int, record Record) error {
+	m.created = append(m.created, record)
+	return nil
+}
+
+func (m *mockClient) UpdateRecord(_ context.Context, _ int, record Record) error {
+	m.updated = append(m.updated, record)
+	return nil
+}
+
+func (m *mockClient) DeleteRecord(_ context.Context, _ int, recordID int) error {
+	m.deleted = append(m.deleted, recordID)
+	return nil
+}
+
+func (m *mockClient) GetPool(_ context.Context, _ string, poolID int) (Pool, error) {
+	return m.pools[poolID], nil
+}
+
+func (m *mockClient) CreatePool(_ context.Context, pool Pool) (Pool, error) {
+	m.createdPools = append(m.createdPools, pool)
+	pool.ID = 100 + len(m.createdPools)
+	return pool, nil
+}
+
+func (m *mockClient) UpdatePool(_ context.Context, pool Pool) error {
+	m.updatedPools = append(m.updatedPools, pool)
+	return nil
+}
+
+func (m *mockClient) DeletePool(_ context.Context, _ string, poolID int) error {
+	m.deletedPools = append(m.deletedPools, poolID)
+	return nil
+}
+
+func newMockClient() *mockClient {
+	return &mockClient{
+		domains: []Domain{{ID: 1, Name: "example.com"}, {ID: 2, Name: "example.org"}},
+		records: map[int][]Record{
+			1: {
+				{ID: 10, Name: "", Type: "A", TTL: 3600, Mode: modeStandard, Region: defaultRegion, Value: rawValue([]StandardValue{{Value: "1.2.3.4", Enabled: true}})},
+				{ID: 11, Name: "www", Type: "A", TTL: 300, Mode: modeStandard, Region: defaultRegion, Value: rawValue([]StandardValue{{Value: "1.2.3.4", Enabled: true}, {Value: "5.6.7.8", Enabled: true}})},
+				{ID: 12, Name: "app", Type: "A", TTL: 60, Mode: modeFailover, Region: defaultRegion, Value: rawValue(FailoverValue{Enabled: true, Values: []FailoverValueEntry{
+					{Value: "5.6.7.8", Order: 2, Enabled: true},
+					{Value: "1.2.3.4", Order: 1, SonarCheckID: 101, Enabled: true},
+				}})},
+				{ID: 13, Name: "api", Type: "A", TTL: 60, Mode: modePools, Region: defaultRegion, Value: rawValue([]int{5})},
+				{ID: 14, Name: "alias", Type: "CNAME", TTL: 300, Mode: modeStandard, Region: defaultRegion, Value: rawValue([]StandardValue{{Value: "www.example.com.", Enabled: true}})},
+				{ID: 15, Name: "www", Type: "A", TTL: 300, Mode: modeStandard, Region: "europe", Value: rawValue([]StandardValue{{Value: "9.9.9.9", Enabled: true}})},
+				{ID: 16, Name: "", Type: "NS", TTL: 86400, Mode: modeStandard, Value: rawValue([]StandardValue{{Value: "ns11.constellix.com.", Enabled: true}})},
+			},
+			2: {
+				{ID: 20, Name: "www", Type: "A", TTL: 300, Mode: modeStandard, Value: rawValue([]StandardValue{{Value: "9.9.9.9", Enabled: true}})},
+			},
+		},
+		pools: map[int]Pool{
+			5: {ID: 5, Name: "api", Type: "A", Return: 2, Values: []PoolValue{
+				{Value: "10.0.0.1", Weight: 1, SonarCheckID: 201, Enabled: true},
+				{Value: "10.0.0.2", Weight: 1, SonarCheckID: 202, Enabled: true},
+				{Value: "10.0.0.3", Weight: 1, Enabled: true},
+			}},
+		},
+	}
+}
+
+func TestConstellixRecords(t *testing.T) {
+	p := &ConstellixProvider{client: newMockClient(), domainFilter: endpoint.NewDomainFilter([]string{"example.com"})}
+
+	records, err := p.Records(context.Background())
+	require.NoError(t, err)
+
+	expected := []*endpoint.Endpoint{
+		endpoint.NewEndpointWithTTL("example.com", endpoint.RecordTypeA, 3600, "1.2.3.4"),
+		endpoint.NewEndpointWithTTL("www.example.com", endpoint.RecordTypeA, 300, "1.2.3.4", "5.6.7.8"),
+		endpoint.NewEndpointWithTTL("app.example.com", endpoint.RecordTypeA, 60, "1.2.3.4", "5.6.7.8").
+			WithProviderSpecific(providerSpecificFailover, "true").
+			WithProviderSpecific(providerSpecificHealthChecks, "1.2.3.4=101"),
+		endpoint.NewEndpointWithTTL("api.example.com", endpoint.RecordTypeA, 60, "10.0.0.1", "10.0.0.2", "10.0.0.3").
+			WithProviderSpecific(providerSpecificPoolReturn, "2").
+			WithProviderSpecific(providerSpecificHealthChecks, "10.0.0.1=201,10.0.0.2=202"),
+		endpoint.NewEndpointWithTTL("alias.example.com", endpoint.RecordTypeCNAME, 300, "www.example.com"),
+	}
+	assert.True(t, testutils.SameEndpoints(records, expected), "actual and expected endpoints don't match. %s:%s", records, expected)
+
+	for _, ep := range records {
+		if ep.DNSName == "app.example.com" {
+			assert.Equal(t, endpoint.Targets{"1.2.3.4", "5.6.7.8"}, ep.Targets, "failover targets are in failover order")
+		}
+	}
+}
+
+func TestConstellixAdjustEndpoints(t *testing.T) {
+	p := &ConstellixProvider{}
+	endpoints, err := p.AdjustEndpoints([]*endpoint.Endpoint{
+		endpoint.NewEndpoint("a.example.com", endpoint.RecordTypeA, "1.2.3.4", "5.6.7.8").
+			WithProviderSpecific(providerSpecificFailover, "true").
+			WithProviderSpecific(providerSpecificHealthChecks, "5.6.7.8=2, 1.2.3.4=1,9.9.9.9=3"),
+		endpoint.NewEndpoint("b.example.com", endpoint.RecordTypeTXT, "text").
+			WithProviderSpecific(providerSpecificFailover, "true"),
+		endpoint.NewEndpoint("c.example.com", endpoint.RecordTypeA, "1.2.3.4").
+			WithProviderSpecific(providerSpecificPoolReturn, "zero"),
+		endpoint.NewEndpoint("d.example.com", endpoint.RecordTypeA, "1.2.3.4").
+			WithProviderSpecific(providerSpecificFailover, "true").
+			WithProviderSpecific(providerSpecificPoolReturn, "01"),
+		endpoint.NewEndpoint("e.example.com", endpoint.RecordTypeA, "1.2.3.4").
+			WithProviderSpecific(providerSpecificHealthChecks, "1.2.3.4=1"),
+		endpoint.NewEndpoint("f.example.com", endpoint.RecordTypeA, "1.2.3.4").
+			WithProviderSpecific(providerSpecificFailover, "true").
+			WithProviderSpecific(providerSpecificHealthChecks, "1.2.3.4"),
+	})
+	require.NoError(t, err)
+
+	assert.Equal(t, endpoint.ProviderSpecific{
+		{Name: providerSpecificFailover, Value: "true"},
+		{Name: providerSpecificHealthChecks, Value: "1.2.3.4=1,5.6.7.8=2"},
+	}, endpoints[0].ProviderSpecific)
+	assert.Empty(t, endpoints[1].ProviderSpecific)
+	assert.Empty(t, endpoints[2].ProviderSpecific)
+	assert.Equal(t, endpoint.ProviderSpecific{{Name: providerSpecificPoolReturn, Value: "1"}}, endpoints[3].ProviderSpecific)
+	assert.Empty(t, endpoints[4].ProviderSpecific)
+	assert.Equal(t, endpoint.ProviderSpecific{{Name: providerSpecificFailover, Value: "true"}}, endpoints[5].ProviderSpecific)
+}
+
+func TestConstellixApplyChanges(t *testing.T) {
+	client := newMockClient()
+	p := &ConstellixProvider{client: client, domainFilter: endpoint.NewDomainFilter([]string{"example.com"})}
+
+	err := p.ApplyChanges(context.Background(), &plan.Changes{
+		Create: []*endpoint.Endpoint{
+			endpoint.NewEndpoint("new.example.com", endpoint.RecordTypeCNAME, "www.example.com"),
+			endpoint.NewEndpointWithTTL("lb.example.com", endpoint.RecordTypeA, 60, "10.0.0.1", "10.0.0.2").
+				WithProviderSpecific(providerSpecificPoolReturn, "1").
+				WithProviderSpecific(providerSpecificHealthChecks, "10.0.0.2=202"),
+			endpoint.NewEndpoint("www.example.org", endpoint.RecordTypeA, "1.1.1.1"),
+		},
+		UpdateOld: []*endpoint.Endpoint{
+			endpoint.NewEndpointWithTTL("www.example.com", endpoint.RecordTypeA, 300, "1.2.3.4", "5.6.7.8"),
+			endpoint.NewEndpointWithTTL("api.example.com", endpoint.RecordTypeA, 60, "10.0.0.1", "10.0.0.2", "10.0.0.3").
+				WithProviderSpecific(providerSpecificPoolReturn, "2"),
+		},
+		UpdateNew: []*endpoint.Endpoint{
+			endpoint.NewEndpointWithTTL("www.example.com", endpoint.RecordTypeA, 300, "5.6.7.8", "1.2.3.4").
+				WithProviderSpecific(providerSpecificFailover, "true").
+				WithProviderSpecific(providerSpecificHealthChecks, "5.6.7.8=301"),
+			endpoint.NewEndpointWithTTL("api.example.com", endpoint.RecordTypeA, 60, "10.0.0.1", "10.0.0.2"),
+		},
+		Delete: []*endpoint.Endpoint{
+			endpoint.NewEndpointWithTTL("example.com", endpoint.RecordTypeA, 3600, "1.2.3.4"),
+		},
+	})
+	require.NoError(t, err)
+
+	assert.Equal(t, []int{10}, client.deleted)
+
+	require.Len(t, client.updated, 2)
+	assert.Equal(t, 11, client.updated[0].ID)
+	assert.Equal(t, modeFailover, client.updated[0].Mode)
+	assert.JSONEq(t, `{"enabled":true,"values":[{"value":"5.6.7.8","order":1,"sonarCheckId":301,"enabled":true},{"value":"1.2.3.4","order":2,"enabled":true}]}`, string(client.updated[0].Value))
+	assert.Equal(t, 13, client.updated[1].ID)
+	assert.Equal(t, modeStandard, client.updated[1].Mode)
+	assert.JSONEq(t, `[{"value":"10.0.0.1","enabled":true},{"value":"10.0.0.2","enabled":true}]`, string(client.updated[1].Value))
+	assert.Equal(t, []int{5}, client.deletedPools, "the pool of a record leaving pools mode is deleted")
+
+	assert.Equal(t, []Pool{{
+		Name:            "external-dns-lb.example.com-a",
+		Type:            "A",
+		Return:          1,
+		MinimumFailover: 1,
+		Enabled:         true,
+		Values: []PoolValue{
+			{Value: "10.0.0.1", Weight: 1, Enabled: true},
+			{Value: "10.0.0.2", Weight: 1, SonarCheckID: 202, Enabled: true},
+		},
+	}}, client.createdPools)
+
+	require.Len(t, client.created, 2)
+	assert.Equal(t, "new", client.created[0].Name)
+	assert.JSONEq(t, `[{"value":"www.example.com.","enabled":true}]`, string(client.created[0].Value))
+	assert.Equal(t, "lb", client.created[1].Name)
+	assert.Equal(t, modePools, client.created[1].Mode)
+	assert.JSONEq(t, `[101]`, string(client.created[1].Value))
+}
+
+func TestConstellixApplyChangesUpdatesPool(t *testing.T) {
+	client := newMockClient()
+	p := &ConstellixProvider{client: client, domainFilter: endpoint.NewDomainFilter([]string{"example.com"})}
+
+	err := p.ApplyChanges(context.Background(), &plan.Changes{
+		UpdateOld: []*endpoint.Endpoint{
+			endpoint.NewEndpointWithTTL("api.example.com", endpoint.RecordTypeA, 60, "10.0.0.1", "10.0.0.2", "10.0.0.3").
+				WithProviderSpecific(providerSpecificPoolReturn, "2"),
+		},
+		UpdateNew: []*endpoint.Endpoint{
+			endpoint.NewEndpointWithTTL("api.example.com", endpoint.RecordTypeA, 60, "10.0.0.1", "10.0.0.4").
+				WithProviderSpecific(providerSpecificPoolReturn, "1"),
+		},
+	})
+	require.NoError(t, err)
+
+	require.Len(t, client.updatedPools, 1)
+	assert.Equal(t, 5, client.updatedPools[0].ID)
+	assert.Equal(t, 1, client.updatedPools[0].Return)
+	assert.Empty(t, client.createdPools)
+	assert.Empty(t, client.deletedPools)
+	require.Len(t, client.updated, 1)
+	assert.JSONEq(t, `[5]`, string(client.updated[0].Value))
+}
+
+func TestConstellixApplyChangesDryRun(t *testing.T) {
+	client := newMockClient()
+	p := &ConstellixProvider{client: client, domainFilter: endpoint.NewDomainFilter([]string{"example.com"}), dryRun: true}
+
+	err := p.ApplyChanges(context.Background(), &plan.Changes{
+		Create: []*endpoint.Endpoint{
+			endpoint.NewEndpoint("new.example.com", endpoint.RecordTypeA, "1.2.3.4").WithProviderSpecific(providerSpecificPoolReturn, "1"),
+		},
+		Delete: []*endpoint.Endpoint{endpoint.NewEndpoint("api.example.com", endpoint.RecordTypeA, "10.0.0.1")},
+	})
+	require.NoError(t, err)
+	assert.Empty(t, client.created)
+	assert.Empty(t, client.createdPools)
+	assert.Empty(t, client.deleted)
+	assert.Empty(t, client.deletedPools)
+}
+
+func TestNewConstellixProvider(t *testing.T) {
+	t.Setenv("CONSTELLIX_API_KEY", "key")
+	t.Setenv("CONSTELLIX_SECRET_KEY", "secret")
+	_, err := NewConstellixProvider(endpoint.NewDomainFilter([]string{"example.com"}), false)
+	require.NoError(t, err)
+
+	t.Setenv("CONSTELLIX_SECRET_KEY", "")
+	_, err = NewConstellixProvider(endpoint.NewDomainFilter([]string{"example.com"}), false)
+	require.Error(t, err)
+}
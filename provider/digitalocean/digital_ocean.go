@@ -29,6 +29,7 @@ import (
 
 	"sigs.k8s.io/external-dns/endpoint"
 	"sigs.k8s.io/external-dns/pkg/apis/externaldns"
+	extdnshttp "sigs.k8s.io/external-dns/pkg/http"
 	"sigs.k8s.io/external-dns/plan"
 	"sigs.k8s.io/external-dns/provider"
 )
@@ -82,7 +83,7 @@ func NewDigitalOceanProvider(ctx context.Context, domainFilter endpoint.DomainFi
 	if !ok {
 		return nil, fmt.Errorf("no token found")
 	}
-	oauthClient := oauth2.NewClient(ctx, oauth2.StaticTokenSource(&oauth2.Token{
+	oauthClient := oauth2.NewClient(context.WithValue(ctx, oauth2.HTTPClient, extdnshttp.NewClient("digitalocean")), oauth2.StaticTokenSource(&oauth2.Token{
 		AccessToken: token,
 	}))
 	client, err := godo.New(oauthClient, godo.SetUserAgent(externaldns.UserAgent()))
@@ -248,16 +249,16 @@ func (p *DigitalOceanProvider) fetchZones(ctx context.Context) ([]godo.Domain, e
 	return allZones, nil
 }
 
-func (p *DigitalOceanProvider) getRecordsByDomain(ctx context.Context) (map[string][]godo.DomainRecord, provider.ZoneIDName, error) {
+func (p *DigitalOceanProvider) getRecordsByDomain(ctx context.Context) (map[string][]godo.DomainRecord, provider.ZoneFinder, error) {
 	recordsByDomain := map[string][]godo.DomainRecord{}
 
 	zones, err := p.Zones(ctx)
 	if err != nil {
-		return nil, nil, err
+		return nil, provider.ZoneFinder{}, err
 	}
 
 	zonesByDomain := make(map[string]godo.Domain)
-	zoneNameIDMapper := provider.ZoneIDName{}
+	zoneNameIDMapper := p.NewZoneFinder()
 	for _, z := range zones {
 		zoneNameIDMapper.Add(z.Name, z.Name)
 		zonesByDomain[z.Name] = z
@@ -267,7 +268,7 @@ func (p *DigitalOceanProvider) getRecordsByDomain(ctx context.Context) (map[stri
 	for _, zone := range zones {
 		records, err := p.fetchRecords(ctx, zone.Name)
 		if err != nil {
-			return nil, nil, err
+			return nil, provider.ZoneFinder{}, err
 		}
 
 		recordsByDomain[zone.Name] = append(recordsByDomain[zone.Name], records...)
@@ -400,7 +401,7 @@ func getTTLFromEndpoint(ep *endpoint.Endpoint) int {
 	return defaultTTL
 }
 
-func endpointsByZone(zoneNameIDMapper provider.ZoneIDName, endpoints []*endpoint.Endpoint) map[string][]*endpoint.Endpoint {
+func endpointsByZone(zoneNameIDMapper provider.ZoneFinder, endpoints []*endpoint.Endpoint) map[string][]*endpoint.Endpoint {
 	endpointsByZone := make(map[string][]*endpoint.Endpoint)
 
 	for _, ep := range endpoints {
@@ -29,6 +29,7 @@ import (
 
 	"sigs.k8s.io/external-dns/endpoint"
 	"sigs.k8s.io/external-dns/pkg/apis/externaldns"
+	extdnshttp "sigs.k8s.io/external-dns/pkg/http"
 	"sigs.k8s.io/external-dns/plan"
 	"sigs.k8s.io/external-dns/provider"
 )
@@ -105,7 +106,7 @@ func NewDnsimpleProvider(domainFilter endpoint.DomainFilter, zoneIDFilter provid
 	}
 
 	ts := oauth2.StaticTokenSource(&oauth2.Token{AccessToken: oauthToken})
-	tc := oauth2.NewClient(context.Background(), ts)
+	tc := oauth2.NewClient(context.WithValue(context.Background(), oauth2.HTTPClient, extdnshttp.NewClient("dnsimple")), ts)
 
 	client := dnsimple.NewClient(tc)
 	client.SetUserAgent(externaldns.UserAgent())
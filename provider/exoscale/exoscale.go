@@ -18,6 +18,7 @@ package exoscale
 
 import (
 	"context"
+	"fmt"
 	"strings"
 
 	egoscale "github.com/exoscale/egoscale/v2"
@@ -126,6 +127,12 @@ func (ep *ExoscaleProvider) ApplyChanges(ctx context.Context, changes *plan.Chan
 			continue
 		}
 
+		content, priority, err := recordContent(epoint.RecordType, epoint.Targets[0])
+		if err != nil {
+			log.Warnf("Skipping record %s: %v", epoint.DNSName, err)
+			continue
+		}
+
 		// API does not accept 0 as default TTL but wants nil pointer instead
 		var ttl *int64
 		if epoint.RecordTTL != 0 {
@@ -133,12 +140,13 @@ func (ep *ExoscaleProvider) ApplyChanges(ctx context.Context, changes *plan.Chan
 			ttl = &t
 		}
 		record := egoscale.DNSDomainRecord{
-			Name:    &name,
-			Type:    &epoint.RecordType,
-			TTL:     ttl,
-			Content: &epoint.Targets[0],
+			Name:     &name,
+			Type:     &epoint.RecordType,
+			TTL:      ttl,
+			Content:  &content,
+			Priority: priority,
 		}
-		_, err := ep.client.CreateDNSDomainRecord(ctx, ep.apiZone, zoneID, &record)
+		_, err = ep.client.CreateDNSDomainRecord(ctx, ep.apiZone, zoneID, &record)
 		if err != nil {
 			return err
 		}
@@ -164,8 +172,15 @@ func (ep *ExoscaleProvider) ApplyChanges(ctx context.Context, changes *plan.Chan
 				continue
 			}
 
+			content, priority, err := recordContent(epoint.RecordType, epoint.Targets[0])
+			if err != nil {
+				log.Warnf("Skipping record %s: %v", epoint.DNSName, err)
+				break
+			}
+
 			record.Type = &epoint.RecordType
-			record.Content = &epoint.Targets[0]
+			record.Content = &content
+			record.Priority = priority
 			if epoint.RecordTTL != 0 {
 				ttl := int64(epoint.RecordTTL)
 				record.TTL = &ttl
@@ -236,13 +251,13 @@ func (ep *ExoscaleProvider) Records(ctx context.Context) ([]*endpoint.Endpoint,
 
 		for _, record := range records {
 			switch *record.Type {
-			case "A", "CNAME", "TXT":
+			case "A", "CNAME", "TXT", "MX", "SRV":
 				break
 			default:
 				continue
 			}
 
-			e := endpoint.NewEndpointWithTTL((*record.Name)+"."+(*domain.UnicodeName), *record.Type, endpoint.TTL(*record.TTL), *record.Content)
+			e := endpoint.NewEndpointWithTTL((*record.Name)+"."+(*domain.UnicodeName), *record.Type, endpoint.TTL(*record.TTL), endpointTarget(record))
 			endpoints = append(endpoints, e)
 		}
 	}
@@ -308,6 +323,42 @@ func (f *zoneFilter) EndpointZoneID(endpoint *endpoint.Endpoint, zones map[strin
 	return matchZoneID, name
 }
 
+// recordContent splits an endpoint target into the content and priority expected by the API,
+// which stores the priority of MX and SRV records apart from the rest of the RDATA.
+func recordContent(recordType, target string) (string, *int64, error) {
+	switch recordType {
+	case endpoint.RecordTypeMX:
+		mx, err := endpoint.NewMXTarget(target)
+		if err != nil {
+			return "", nil, err
+		}
+		priority := int64(mx.Priority)
+		return mx.Host, &priority, nil
+	case endpoint.RecordTypeSRV:
+		srv, err := endpoint.NewSRVTarget(target)
+		if err != nil {
+			return "", nil, err
+		}
+		priority := int64(srv.Priority)
+		return fmt.Sprintf("%d %d %s", srv.Weight, srv.Port, srv.Host), &priority, nil
+	default:
+		return target, nil, nil
+	}
+}
+
+// endpointTarget returns the target of a record in the RDATA form used by endpoints.
+func endpointTarget(record egoscale.DNSDomainRecord) string {
+	if record.Priority == nil {
+		return *record.Content
+	}
+	switch *record.Type {
+	case endpoint.RecordTypeMX, endpoint.RecordTypeSRV:
+		return fmt.Sprintf("%d %s", *record.Priority, *record.Content)
+	default:
+		return *record.Content
+	}
+}
+
 func merge(updateOld, updateNew []*endpoint.Endpoint) []*endpoint.Endpoint {
 	findMatch := func(template *endpoint.Endpoint) *endpoint.Endpoint {
 		for _, record := range updateNew {
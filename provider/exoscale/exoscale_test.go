@@ -203,6 +203,55 @@ func TestExoscaleApplyChanges(t *testing.T) {
 	assert.Equal(t, *groups[domainIDs[0]][0].ID, *updateExoscale[0].record.ID)
 }
 
+func TestExoscaleRecordContent(t *testing.T) {
+	content, priority, err := recordContent("MX", "10 mail.foo.com")
+	assert.NoError(t, err)
+	assert.Equal(t, "mail.foo.com", content)
+	assert.Equal(t, int64(10), *priority)
+
+	content, priority, err = recordContent("SRV", "10 5 5060 sip.foo.com")
+	assert.NoError(t, err)
+	assert.Equal(t, "5 5060 sip.foo.com", content)
+	assert.Equal(t, int64(10), *priority)
+
+	content, priority, err = recordContent("A", "1.2.3.4")
+	assert.NoError(t, err)
+	assert.Equal(t, "1.2.3.4", content)
+	assert.Nil(t, priority)
+
+	_, _, err = recordContent("MX", "mail.foo.com")
+	assert.Error(t, err)
+}
+
+func TestExoscaleEndpointTarget(t *testing.T) {
+	var priority int64 = 10
+	assert.Equal(t, "10 mail.foo.com", endpointTarget(egoscale.DNSDomainRecord{Type: strPtr("MX"), Content: strPtr("mail.foo.com"), Priority: &priority}))
+	assert.Equal(t, "10 5 5060 sip.foo.com", endpointTarget(egoscale.DNSDomainRecord{Type: strPtr("SRV"), Content: strPtr("5 5060 sip.foo.com"), Priority: &priority}))
+	assert.Equal(t, "1.2.3.4", endpointTarget(egoscale.DNSDomainRecord{Type: strPtr("A"), Content: strPtr("1.2.3.4"), Priority: &priority}))
+}
+
+func TestExoscaleApplyChangesPriority(t *testing.T) {
+	provider := NewExoscaleProviderWithClient(NewExoscaleClientStub(), "", "", false)
+	createExoscale = make([]createRecordExoscale, 0)
+
+	err := provider.ApplyChanges(context.Background(), &plan.Changes{
+		Create: []*endpoint.Endpoint{
+			{
+				DNSName:    "mail.foo.com",
+				RecordType: "MX",
+				RecordTTL:  600,
+				Targets:    []string{"20 mx.foo.com"},
+			},
+		},
+	})
+	assert.NoError(t, err)
+
+	assert.Equal(t, 1, len(createExoscale))
+	assert.Equal(t, "mx.foo.com", *createExoscale[0].record.Content)
+	assert.Equal(t, int64(20), *createExoscale[0].record.Priority)
+	assert.Equal(t, int64(600), *createExoscale[0].record.TTL)
+}
+
 func TestExoscaleMerge_NoUpdateOnTTL0Changes(t *testing.T) {
 	updateOld := []*endpoint.Endpoint{
 		{
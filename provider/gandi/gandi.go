@@ -276,7 +276,7 @@ func (p *GandiProvider) newGandiChanges(action string, endpoints []*endpoint.End
 
 func (p *GandiProvider) groupAndFilterByZone(zones []string, changes []*GandiChanges) map[string][]*GandiChanges {
 	change := make(map[string][]*GandiChanges)
-	zoneNameID := provider.ZoneIDName{}
+	zoneNameID := p.NewZoneFinder()
 
 	for _, z := range zones {
 		zoneNameID.Add(z, z)
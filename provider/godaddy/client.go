@@ -32,6 +32,7 @@ import (
 	"golang.org/x/time/rate"
 
 	"sigs.k8s.io/external-dns/pkg/apis/externaldns"
+	extdnshttp "sigs.k8s.io/external-dns/pkg/http"
 )
 
 const (
@@ -126,7 +127,7 @@ func NewClient(useOTE bool, apiKey, apiSecret string) (*Client, error) {
 		APIKey:      apiKey,
 		APISecret:   apiSecret,
 		APIEndPoint: endpoint,
-		Client:      &http.Client{},
+		Client:      extdnshttp.NewClient("godaddy"),
 		// Add one token every second
 		Ratelimiter: rate.NewLimiter(rate.Every(time.Second), 60),
 		Timeout:     DefaultTimeout,
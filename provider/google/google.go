@@ -19,6 +19,8 @@ package google
 import (
 	"context"
 	"fmt"
+	"net/http"
+	"os"
 	"sort"
 	"strings"
 	"time"
@@ -26,6 +28,7 @@ import (
 	"cloud.google.com/go/compute/metadata"
 	"github.com/linki/instrumented_http"
 	log "github.com/sirupsen/logrus"
+	"golang.org/x/oauth2"
 	"golang.org/x/oauth2/google"
 	dns "google.golang.org/api/dns/v1"
 	googleapi "google.golang.org/api/googleapi"
@@ -97,6 +100,30 @@ func (c changesService) Create(project string, managedZone string, change *dns.C
 	return c.service.Create(project, managedZone, change)
 }
 
+// googleProject holds the clients used to manage the zones of an additional project.
+type googleProject struct {
+	// The Google project owning the zones
+	name string
+	// A client for managing resource record sets
+	resourceRecordSetsClient resourceRecordSetsClientInterface
+	// A client for managing hosted zones
+	managedZonesClient managedZonesServiceInterface
+	// A client for managing change sets
+	changesClient changesServiceInterface
+}
+
+// quotaProjectTransport charges API quota to the given project instead of the one of the credentials.
+type quotaProjectTransport struct {
+	project string
+	base    http.RoundTripper
+}
+
+func (t *quotaProjectTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	req = req.Clone(req.Context())
+	req.Header.Set("X-Goog-User-Project", t.project)
+	return t.base.RoundTrip(req)
+}
+
 // GoogleProvider is an implementation of Provider for Google CloudDNS.
 type GoogleProvider struct {
 	provider.BaseProvider
@@ -120,25 +147,20 @@ type GoogleProvider struct {
 	managedZonesClient managedZonesServiceInterface
 	// A client for managing change sets
 	changesClient changesServiceInterface
+	// Other projects whose zones are managed as well
+	additionalProjects []googleProject
+	// The project owning each zone returned by Zones
+	zoneProjects map[string]string
 	// The context parameter to be passed for gcloud API calls.
 	ctx context.Context
 }
 
 // NewGoogleProvider initializes a new Google CloudDNS based Provider.
-func NewGoogleProvider(ctx context.Context, project string, domainFilter endpoint.DomainFilter, zoneIDFilter provider.ZoneIDFilter, batchChangeSize int, batchChangeInterval time.Duration, zoneVisibility string, dryRun bool) (*GoogleProvider, error) {
-	gcloud, err := google.DefaultClient(ctx, dns.NdevClouddnsReadwriteScope)
-	if err != nil {
-		return nil, err
-	}
-
-	gcloud = instrumented_http.NewClient(gcloud, &instrumented_http.Callbacks{
-		PathProcessor: func(path string) string {
-			parts := strings.Split(path, "/")
-			return parts[len(parts)-1]
-		},
-	})
-
-	dnsClient, err := dns.NewService(ctx, option.WithHTTPClient(gcloud))
+//
+// Each additional project is given as "project" or "project=/path/to/credentials.json". Its zones are managed with
+// the given credentials, or the default ones, and API quota is tracked against the project itself.
+func NewGoogleProvider(ctx context.Context, project string, additionalProjects []string, domainFilter endpoint.DomainFilter, zoneIDFilter provider.ZoneIDFilter, batchChangeSize int, batchChangeInterval time.Duration, zoneVisibility string, dryRun bool) (*GoogleProvider, error) {
+	dnsClient, err := newDNSService(ctx, "", "")
 	if err != nil {
 		return nil, err
 	}
@@ -152,6 +174,24 @@ func NewGoogleProvider(ctx context.Context, project string, domainFilter endpoin
 		project = mProject
 	}
 
+	var projects []googleProject
+	for _, additionalProject := range additionalProjects {
+		name, credentialsFile, _ := strings.Cut(additionalProject, "=")
+		if name == "" || name == project {
+			continue
+		}
+		projectClient, err := newDNSService(ctx, credentialsFile, name)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create client for project %s: %w", name, err)
+		}
+		projects = append(projects, googleProject{
+			name:                     name,
+			resourceRecordSetsClient: resourceRecordSetsService{projectClient.ResourceRecordSets},
+			managedZonesClient:       managedZonesService{projectClient.ManagedZones},
+			changesClient:            changesService{projectClient.Changes},
+		})
+	}
+
 	zoneTypeFilter := provider.NewZoneTypeFilter(zoneVisibility)
 
 	return &GoogleProvider{
@@ -165,40 +205,111 @@ func NewGoogleProvider(ctx context.Context, project string, domainFilter endpoin
 		resourceRecordSetsClient: resourceRecordSetsService{dnsClient.ResourceRecordSets},
 		managedZonesClient:       managedZonesService{dnsClient.ManagedZones},
 		changesClient:            changesService{dnsClient.Changes},
+		additionalProjects:       projects,
 		ctx:                      ctx,
 	}, nil
 }
 
+// newDNSService creates a Cloud DNS client, using the default credentials unless a credentials file is given.
+// A non-empty quota project makes API calls consume the quota of that project.
+func newDNSService(ctx context.Context, credentialsFile, quotaProject string) (*dns.Service, error) {
+	var gcloud *http.Client
+	if credentialsFile != "" {
+		data, err := os.ReadFile(credentialsFile)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read credentials file %s: %w", credentialsFile, err)
+		}
+		creds, err := google.CredentialsFromJSON(ctx, data, dns.NdevClouddnsReadwriteScope)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse credentials file %s: %w", credentialsFile, err)
+		}
+		gcloud = oauth2.NewClient(ctx, creds.TokenSource)
+	} else {
+		var err error
+		gcloud, err = google.DefaultClient(ctx, dns.NdevClouddnsReadwriteScope)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if quotaProject != "" {
+		gcloud.Transport = &quotaProjectTransport{project: quotaProject, base: gcloud.Transport}
+	}
+
+	gcloud = instrumented_http.NewClient(gcloud, &instrumented_http.Callbacks{
+		PathProcessor: func(path string) string {
+			parts := strings.Split(path, "/")
+			return parts[len(parts)-1]
+		},
+	})
+
+	return dns.NewService(ctx, option.WithHTTPClient(gcloud))
+}
+
+// projects returns every managed project, starting with the default one.
+func (p *GoogleProvider) projects() []googleProject {
+	projects := []googleProject{{
+		name:                     p.project,
+		resourceRecordSetsClient: p.resourceRecordSetsClient,
+		managedZonesClient:       p.managedZonesClient,
+		changesClient:            p.changesClient,
+	}}
+	return append(projects, p.additionalProjects...)
+}
+
+// projectOf returns the project owning the given zone, as seen by the last call to Zones.
+func (p *GoogleProvider) projectOf(zone string) googleProject {
+	projects := p.projects()
+	if name, ok := p.zoneProjects[zone]; ok {
+		for _, project := range projects {
+			if project.name == name {
+				return project
+			}
+		}
+	}
+	return projects[0]
+}
+
 // Zones returns the list of hosted zones.
 func (p *GoogleProvider) Zones(ctx context.Context) (map[string]*dns.ManagedZone, error) {
 	zones := make(map[string]*dns.ManagedZone)
+	zoneProjects := make(map[string]string)
 
-	f := func(resp *dns.ManagedZonesListResponse) error {
-		for _, zone := range resp.ManagedZones {
-			if zone.PeeringConfig == nil {
-				if p.domainFilter.Match(zone.DnsName) && p.zoneTypeFilter.Match(zone.Visibility) && (p.zoneIDFilter.Match(fmt.Sprintf("%v", zone.Id)) || p.zoneIDFilter.Match(fmt.Sprintf("%v", zone.Name))) {
-					zones[zone.Name] = zone
-					log.Debugf("Matched %s (zone: %s) (visibility: %s)", zone.DnsName, zone.Name, zone.Visibility)
+	log.Debugf("Matching zones against domain filters: %v", p.domainFilter)
+	for _, project := range p.projects() {
+		f := func(resp *dns.ManagedZonesListResponse) error {
+			for _, zone := range resp.ManagedZones {
+				if zone.PeeringConfig == nil {
+					if p.domainFilter.Match(zone.DnsName) && p.zoneTypeFilter.Match(zone.Visibility) && (p.zoneIDFilter.Match(fmt.Sprintf("%v", zone.Id)) || p.zoneIDFilter.Match(fmt.Sprintf("%v", zone.Name))) {
+						if owner, ok := zoneProjects[zone.Name]; ok && owner != project.name {
+							log.Warnf("Ignoring zone %s of project %s, a zone with the same name is already managed in project %s", zone.Name, project.name, owner)
+							continue
+						}
+						zones[zone.Name] = zone
+						zoneProjects[zone.Name] = project.name
+						log.Debugf("Matched %s (zone: %s) (visibility: %s) (project: %s)", zone.DnsName, zone.Name, zone.Visibility, project.name)
+					} else {
+						log.Debugf("Filtered %s (zone: %s) (visibility: %s)", zone.DnsName, zone.Name, zone.Visibility)
+					}
 				} else {
-					log.Debugf("Filtered %s (zone: %s) (visibility: %s)", zone.DnsName, zone.Name, zone.Visibility)
+					log.Debugf("Filtered peering zone %s (zone: %s) (visibility: %s)", zone.DnsName, zone.Name, zone.Visibility)
 				}
-			} else {
-				log.Debugf("Filtered peering zone %s (zone: %s) (visibility: %s)", zone.DnsName, zone.Name, zone.Visibility)
 			}
-		}
 
-		return nil
-	}
+			return nil
+		}
 
-	log.Debugf("Matching zones against domain filters: %v", p.domainFilter)
-	if err := p.managedZonesClient.List(p.project).Pages(ctx, f); err != nil {
-		return nil, provider.NewSoftError(fmt.Errorf("failed to list zones: %w", err))
+		if err := project.managedZonesClient.List(project.name).Pages(ctx, f); err != nil {
+			return nil, provider.NewSoftError(fmt.Errorf("failed to list zones in project %s: %w", project.name, err))
+		}
 	}
 
 	if len(zones) == 0 {
 		log.Warnf("No zones in the project, %s, match domain filters: %v", p.project, p.domainFilter)
 	}
 
+	p.zoneProjects = zoneProjects
+
 	for _, zone := range zones {
 		log.Debugf("Considering zone: %s (domain: %s)", zone.Name, zone.DnsName)
 	}
@@ -225,7 +336,8 @@ func (p *GoogleProvider) Records(ctx context.Context) (endpoints []*endpoint.End
 	}
 
 	for _, z := range zones {
-		if err := p.resourceRecordSetsClient.List(p.project, z.Name).Pages(ctx, f); err != nil {
+		project := p.projectOf(z.Name)
+		if err := project.resourceRecordSetsClient.List(project.name, z.Name).Pages(ctx, f); err != nil {
 			return nil, provider.NewSoftError(fmt.Errorf("failed to list records in zone %s: %w", z.Name, err))
 		}
 	}
@@ -233,6 +345,12 @@ func (p *GoogleProvider) Records(ctx context.Context) (endpoints []*endpoint.End
 	return endpoints, nil
 }
 
+// TXTEncoding reports that Cloud DNS takes and returns the targets of TXT records quoted, in character strings
+// of at most 255 bytes.
+func (p *GoogleProvider) TXTEncoding() string {
+	return provider.TXTEncodingQuoted
+}
+
 // ApplyChanges applies a given set of changes in a given zone.
 func (p *GoogleProvider) ApplyChanges(ctx context.Context, changes *plan.Changes) error {
 	change := &dns.Change{}
@@ -283,7 +401,7 @@ func (p *GoogleProvider) submitChange(ctx context.Context, change *dns.Change) e
 	}
 
 	// separate into per-zone change sets to be passed to the API.
-	changes := separateChange(zones, change)
+	changes := p.separateChange(zones, change)
 
 	for zone, change := range changes {
 		for batch, c := range batchChange(change, p.batchChangeSize) {
@@ -299,7 +417,8 @@ func (p *GoogleProvider) submitChange(ctx context.Context, change *dns.Change) e
 				continue
 			}
 
-			if _, err := p.changesClient.Create(p.project, zone, c).Do(); err != nil {
+			project := p.projectOf(zone)
+			if _, err := project.changesClient.Create(project.name, zone, c).Do(); err != nil {
 				return provider.NewSoftError(fmt.Errorf("failed to create changes: %w", err))
 			}
 
@@ -384,11 +503,11 @@ func batchChange(change *dns.Change, batchSize int) []*dns.Change {
 }
 
 // separateChange separates a multi-zone change into a single change per zone.
-func separateChange(zones map[string]*dns.ManagedZone, change *dns.Change) map[string]*dns.Change {
+func (p *GoogleProvider) separateChange(zones map[string]*dns.ManagedZone, change *dns.Change) map[string]*dns.Change {
 	changes := make(map[string]*dns.Change)
-	zoneNameIDMapper := provider.ZoneIDName{}
+	zoneNameIDMapper := p.NewZoneFinder()
 	for _, z := range zones {
-		zoneNameIDMapper[z.Name] = z.DnsName
+		zoneNameIDMapper.Add(z.Name, z.DnsName)
 		changes[z.Name] = &dns.Change{
 			Additions: []*dns.ResourceRecordSet{},
 			Deletions: []*dns.ResourceRecordSet{},
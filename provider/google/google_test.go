@@ -277,6 +277,71 @@ func TestGoogleRecords(t *testing.T) {
 	validateEndpoints(t, records, originalEndpoints)
 }
 
+func TestGoogleRecordsAdditionalProject(t *testing.T) {
+	p := newGoogleProvider(t, endpoint.NewDomainFilter([]string{"ext-dns-test-2.gcp.zalan.do."}), provider.NewZoneIDFilter([]string{""}), false, []*endpoint.Endpoint{}, nil, nil)
+	other := googleProject{
+		name:                     "zalando-external-dns-test-other",
+		resourceRecordSetsClient: &mockResourceRecordSetsClient{},
+		managedZonesClient:       &mockManagedZonesClient{},
+		changesClient:            &mockChangesClient{},
+	}
+	p.additionalProjects = []googleProject{other}
+
+	if _, err := other.managedZonesClient.Create(other.name, &dns.ManagedZone{
+		Name:    "zone-5-ext-dns-test-2-gcp-zalan-do",
+		DnsName: "zone-5.ext-dns-test-2.gcp.zalan.do.",
+	}).Do(); err != nil {
+		if err, ok := err.(*googleapi.Error); !ok || err.Code != http.StatusConflict {
+			require.NoError(t, err)
+		}
+	}
+
+	zones, err := p.Zones(context.Background())
+	require.NoError(t, err)
+	assert.Contains(t, zones, "zone-5-ext-dns-test-2-gcp-zalan-do")
+	assert.Equal(t, other.name, p.projectOf("zone-5-ext-dns-test-2-gcp-zalan-do").name)
+	assert.Equal(t, p.project, p.projectOf("zone-1-ext-dns-test-2-gcp-zalan-do").name)
+
+	created := []*endpoint.Endpoint{
+		endpoint.NewEndpointWithTTL("other.zone-5.ext-dns-test-2.gcp.zalan.do", endpoint.RecordTypeA, endpoint.TTL(60), "1.2.3.4"),
+	}
+	require.NoError(t, p.ApplyChanges(context.Background(), &plan.Changes{Create: created}))
+
+	_, ok := testRecords[zoneKey(other.name, "zone-5-ext-dns-test-2-gcp-zalan-do")]
+	assert.True(t, ok, "record should be created in the zone of the additional project")
+
+	records, err := p.Records(context.Background())
+	require.NoError(t, err)
+	validateEndpoints(t, records, created)
+
+	_, err = other.changesClient.Create(other.name, "zone-5-ext-dns-test-2-gcp-zalan-do", &dns.Change{Deletions: []*dns.ResourceRecordSet{newRecord(created[0])}}).Do()
+	require.NoError(t, err)
+}
+
+func TestQuotaProjectTransport(t *testing.T) {
+	var header string
+	transport := &quotaProjectTransport{
+		project: "quota-project",
+		base: roundTripperFunc(func(req *http.Request) (*http.Response, error) {
+			header = req.Header.Get("X-Goog-User-Project")
+			return &http.Response{StatusCode: http.StatusOK}, nil
+		}),
+	}
+	req, err := http.NewRequest(http.MethodGet, "https://dns.googleapis.com/", nil)
+	require.NoError(t, err)
+
+	_, err = transport.RoundTrip(req)
+	require.NoError(t, err)
+	assert.Equal(t, "quota-project", header)
+	assert.Empty(t, req.Header.Get("X-Goog-User-Project"), "original request should not be modified")
+}
+
+type roundTripperFunc func(*http.Request) (*http.Response, error)
+
+func (f roundTripperFunc) RoundTrip(req *http.Request) (*http.Response, error) {
+	return f(req)
+}
+
 func TestGoogleRecordsFilter(t *testing.T) {
 	originalEndpoints := []*endpoint.Endpoint{
 		endpoint.NewEndpointWithTTL("update-test.zone-1.ext-dns-test-2.gcp.zalan.do", endpoint.RecordTypeA, defaultTTL, "8.8.8.8"),
@@ -514,7 +579,7 @@ func TestSeparateChanges(t *testing.T) {
 		},
 	}
 
-	changes := separateChange(zones, change)
+	changes := (&GoogleProvider{}).separateChange(zones, change)
 	require.Len(t, changes, 2)
 
 	validateChange(t, changes["foo-example-org"], &dns.Change{
@@ -0,0 +1,155 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package hurricaneelectric
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/miekg/dns"
+
+	"sigs.k8s.io/external-dns/endpoint"
+	"sigs.k8s.io/external-dns/pkg/apis/externaldns"
+	extdnshttp "sigs.k8s.io/external-dns/pkg/http"
+)
+
+const (
+	// defaultDynEndpoint is the dynamic DNS update endpoint of dns.he.net.
+	defaultDynEndpoint = "https://dyn.dns.he.net/nic/update"
+	// defaultNameserver is a nameserver of the zones hosted by dns.he.net.
+	defaultNameserver = "ns1.he.net:53"
+)
+
+// dynClient updates the value of a dynamic record.
+type dynClient interface {
+	Update(ctx context.Context, hostname, recordType, value string) error
+}
+
+type httpDynClient struct {
+	endpoint   string
+	key        string
+	httpClient *http.Client
+}
+
+func newDynClient(endpoint, key string) *httpDynClient {
+	return &httpDynClient{
+		endpoint:   endpoint,
+		key:        key,
+		httpClient: extdnshttp.NewClient("hurricane-electric"),
+	}
+}
+
+// Update sets the value of a dynamic record. A/AAAA values are sent as the address of the
+// host, TXT values as the text content of the record.
+func (c *httpDynClient) Update(ctx context.Context, hostname, recordType, value string) error {
+	form := url.Values{}
+	form.Set("hostname", hostname)
+	form.Set("password", c.key)
+	switch recordType {
+	case endpoint.RecordTypeA, endpoint.RecordTypeAAAA:
+		form.Set("myip", value)
+	case endpoint.RecordTypeTXT:
+		form.Set("txt", value)
+	default:
+		return fmt.Errorf("record type %s cannot be updated through dynamic DNS", recordType)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, c.endpoint, strings.NewReader(form.Encode()))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	req.Header.Set("User-Agent", externaldns.UserAgent())
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(io.LimitReader(resp.Body, 1024))
+	if err != nil {
+		return err
+	}
+	return parseDynResponse(resp.StatusCode, string(body))
+}
+
+// parseDynResponse interprets the dyndns2 style answer of the update endpoint.
+func parseDynResponse(statusCode int, body string) error {
+	answer := strings.TrimSpace(body)
+	code, _, _ := strings.Cut(answer, " ")
+	switch code {
+	case "good", "nochg":
+		return nil
+	case "":
+		return fmt.Errorf("empty response from dynamic DNS endpoint (status %d)", statusCode)
+	default:
+		return fmt.Errorf("dynamic DNS update refused: %s", answer)
+	}
+}
+
+// recordResolver tells whether a record is still served by dns.he.net.
+type recordResolver interface {
+	Exists(ctx context.Context, hostname, recordType string) (bool, error)
+}
+
+// dnsExchanger sends DNS queries, as the resolvers of the resolver package do.
+type dnsExchanger interface {
+	ExchangeContext(ctx context.Context, m *dns.Msg, server string) (*dns.Msg, time.Duration, error)
+}
+
+type dnsRecordResolver struct {
+	client dnsExchanger
+	server string
+}
+
+func newRecordResolver(client dnsExchanger, server string) *dnsRecordResolver {
+	return &dnsRecordResolver{client: client, server: server}
+}
+
+// Exists asks the nameserver of dns.he.net, or the configured resolver, whether a record of the given
+// type is served for hostname.
+func (r *dnsRecordResolver) Exists(ctx context.Context, hostname, recordType string) (bool, error) {
+	qtype, ok := dns.StringToType[recordType]
+	if !ok {
+		return false, fmt.Errorf("record type %s cannot be looked up", recordType)
+	}
+	m := new(dns.Msg)
+	m.SetQuestion(dns.Fqdn(hostname), qtype)
+	in, _, err := r.client.ExchangeContext(ctx, m, r.server)
+	if err != nil {
+		return false, err
+	}
+	switch in.Rcode {
+	case dns.RcodeSuccess:
+		for _, rr := range in.Answer {
+			if rr.Header().Rrtype == qtype {
+				return true, nil
+			}
+		}
+		return false, nil
+	case dns.RcodeNameError:
+		return false, nil
+	default:
+		return false, fmt.Errorf("looking up %s record %s failed: %s", recordType, hostname, dns.RcodeToString[in.Rcode])
+	}
+}
@@ -0,0 +1,118 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package hurricaneelectric
+
+import (
+	"context"
+	"errors"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/miekg/dns"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"sigs.k8s.io/external-dns/endpoint"
+)
+
+func TestDynClientUpdate(t *testing.T) {
+	var received url.Values
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.NoError(t, r.ParseForm())
+		received = r.PostForm
+		if r.PostForm.Get("password") != "secret" {
+			_, _ = w.Write([]byte("badauth"))
+			return
+		}
+		_, _ = w.Write([]byte("good 1.2.3.4"))
+	}))
+	defer server.Close()
+
+	client := newDynClient(server.URL, "secret")
+	require.NoError(t, client.Update(context.Background(), "home.example.com", endpoint.RecordTypeA, "1.2.3.4"))
+	assert.Equal(t, "home.example.com", received.Get("hostname"))
+	assert.Equal(t, "1.2.3.4", received.Get("myip"))
+
+	require.NoError(t, client.Update(context.Background(), "home.example.com", endpoint.RecordTypeTXT, "heritage=external-dns"))
+	assert.Equal(t, "heritage=external-dns", received.Get("txt"))
+	assert.Empty(t, received.Get("myip"))
+
+	assert.Error(t, client.Update(context.Background(), "home.example.com", endpoint.RecordTypeCNAME, "example.com"))
+
+	client = newDynClient(server.URL, "wrong")
+	assert.Error(t, client.Update(context.Background(), "home.example.com", endpoint.RecordTypeA, "1.2.3.4"))
+}
+
+func TestParseDynResponse(t *testing.T) {
+	assert.NoError(t, parseDynResponse(http.StatusOK, "good 1.2.3.4"))
+	assert.NoError(t, parseDynResponse(http.StatusOK, "nochg 1.2.3.4\n"))
+	assert.Error(t, parseDynResponse(http.StatusOK, "badauth"))
+	assert.Error(t, parseDynResponse(http.StatusOK, "abuse"))
+	assert.Error(t, parseDynResponse(http.StatusInternalServerError, ""))
+}
+
+// mockExchanger answers the queries for app.example.com, failing for broken.example.com.
+type mockExchanger struct {
+	server string
+}
+
+func (m *mockExchanger) ExchangeContext(_ context.Context, req *dns.Msg, server string) (*dns.Msg, time.Duration, error) {
+	m.server = server
+	q := req.Question[0]
+	resp := new(dns.Msg)
+	resp.SetReply(req)
+	switch q.Name {
+	case "app.example.com.":
+		if q.Qtype == dns.TypeA {
+			resp.Answer = append(resp.Answer, &dns.A{Hdr: dns.RR_Header{Name: q.Name, Rrtype: dns.TypeA, Class: dns.ClassINET}, A: net.ParseIP("192.0.2.1")})
+		}
+	case "refused.example.com.":
+		resp.Rcode = dns.RcodeRefused
+	case "broken.example.com.":
+		return nil, 0, errors.New("timeout")
+	default:
+		resp.Rcode = dns.RcodeNameError
+	}
+	return resp, 0, nil
+}
+
+func TestRecordResolverExists(t *testing.T) {
+	client := &mockExchanger{}
+	r := newRecordResolver(client, defaultNameserver)
+
+	exists, err := r.Exists(context.Background(), "app.example.com", endpoint.RecordTypeA)
+	require.NoError(t, err)
+	assert.True(t, exists)
+	assert.Equal(t, defaultNameserver, client.server)
+
+	exists, err = r.Exists(context.Background(), "app.example.com", endpoint.RecordTypeTXT)
+	require.NoError(t, err)
+	assert.False(t, exists, "another type is served for the hostname")
+
+	exists, err = r.Exists(context.Background(), "gone.example.com", endpoint.RecordTypeA)
+	require.NoError(t, err)
+	assert.False(t, exists)
+
+	_, err = r.Exists(context.Background(), "refused.example.com", endpoint.RecordTypeA)
+	require.Error(t, err)
+	_, err = r.Exists(context.Background(), "broken.example.com", endpoint.RecordTypeA)
+	require.Error(t, err)
+}
@@ -0,0 +1,53 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package hurricaneelectric
+
+import (
+	"strings"
+
+	"sigs.k8s.io/external-dns/endpoint"
+	"sigs.k8s.io/external-dns/pkg/apis/externaldns"
+	"sigs.k8s.io/external-dns/source"
+)
+
+// NewHurricaneElectricProviderFromConfig returns the Hurricane Electric provider configured by the
+// --hurricane-electric-* flags of cfg, managing the records of domainFilter.
+func NewHurricaneElectricProviderFromConfig(cfg *externaldns.Config, domainFilter endpoint.DomainFilter) (*HurricaneElectricProvider, error) {
+	store, err := newStateStore(cfg)
+	if err != nil {
+		return nil, err
+	}
+	return NewHurricaneElectricProvider(domainFilter, store, cfg.DryRun)
+}
+
+// newStateStore returns the store of the records set by --hurricane-electric-state, nil when the
+// records are not persisted.
+func newStateStore(cfg *externaldns.Config) (StateStore, error) {
+	kind, ref, _ := strings.Cut(cfg.HurricaneElectricState, ":")
+	switch kind {
+	case "file":
+		return NewFileStateStore(ref), nil
+	case "configmap":
+		client, err := source.NewKubeClient(cfg.KubeConfig, cfg.APIServerURL, cfg.RequestTimeout)
+		if err != nil {
+			return nil, err
+		}
+		namespace, name, _ := strings.Cut(ref, "/")
+		return NewConfigMapStateStore(client, namespace, name), nil
+	}
+	return nil, nil
+}
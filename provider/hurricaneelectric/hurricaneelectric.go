@@ -0,0 +1,246 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package hurricaneelectric
+
+import (
+	"cmp"
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"slices"
+	"sync"
+
+	log "github.com/sirupsen/logrus"
+
+	"sigs.k8s.io/external-dns/endpoint"
+	"sigs.k8s.io/external-dns/pkg/resolver"
+	"sigs.k8s.io/external-dns/plan"
+	"sigs.k8s.io/external-dns/provider"
+)
+
+// HurricaneElectricProvider is an implementation of Provider for dns.he.net.
+//
+// dns.he.net only offers a dynamic DNS endpoint to automate changes: records must first be
+// created in the web interface with dynamic DNS enabled, after which their value can be updated.
+// Since records cannot be listed, the provider reports the records it has pushed itself, persisted
+// in its StateStore when set.
+type HurricaneElectricProvider struct {
+	provider.BaseProvider
+	client       dynClient
+	resolver     recordResolver
+	store        StateStore
+	domainFilter endpoint.DomainFilter
+	dryRun       bool
+
+	mutex    sync.Mutex
+	records  map[endpoint.EndpointKey]*endpoint.Endpoint
+	restored bool
+	changed  bool
+}
+
+// NewHurricaneElectricProvider initializes a new dns.he.net based Provider, persisting the pushed
+// records in store when not nil.
+// The dynamic DNS key shared by the managed records is read from HE_DDNS_KEY.
+func NewHurricaneElectricProvider(domainFilter endpoint.DomainFilter, store StateStore, dryRun bool) (*HurricaneElectricProvider, error) {
+	key, ok := os.LookupEnv("HE_DDNS_KEY")
+	if !ok || key == "" {
+		return nil, fmt.Errorf("no dynamic DNS key provided, you must set the HE_DDNS_KEY env var")
+	}
+
+	p := newHurricaneElectricProvider(newDynClient(defaultDynEndpoint, key), newRecordResolver(resolver.Default(), defaultNameserver), domainFilter, dryRun)
+	p.store = store
+	return p, nil
+}
+
+func newHurricaneElectricProvider(client dynClient, resolver recordResolver, domainFilter endpoint.DomainFilter, dryRun bool) *HurricaneElectricProvider {
+	return &HurricaneElectricProvider{
+		client:       client,
+		resolver:     resolver,
+		domainFilter: domainFilter,
+		dryRun:       dryRun,
+		records:      map[endpoint.EndpointKey]*endpoint.Endpoint{},
+	}
+}
+
+// Records returns the records pushed through dynamic DNS and not removed since, including the ones
+// pushed before a restart when they are persisted.
+func (p *HurricaneElectricProvider) Records(ctx context.Context) ([]*endpoint.Endpoint, error) {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+
+	if err := p.restore(ctx); err != nil {
+		return nil, provider.NewSoftError(err)
+	}
+	endpoints := make([]*endpoint.Endpoint, 0, len(p.records))
+	for _, ep := range p.records {
+		endpoints = append(endpoints, ep.DeepCopy())
+	}
+	return endpoints, nil
+}
+
+// AdjustEndpoints keeps the first target of the endpoints with several targets, as dynamic DNS records
+// hold a single value, so that the plan compares the value actually pushed.
+func (p *HurricaneElectricProvider) AdjustEndpoints(endpoints []*endpoint.Endpoint) ([]*endpoint.Endpoint, error) {
+	for _, ep := range endpoints {
+		if len(ep.Targets) > 1 && p.SupportedRecordType(ep.RecordType) {
+			log.Warnf("Dynamic DNS records hold a single value, only %s will be set for %s record %s", ep.Targets[0], ep.RecordType, ep.DNSName)
+			ep.Targets = endpoint.Targets{ep.Targets[0]}
+		}
+	}
+	return endpoints, nil
+}
+
+// ApplyChanges pushes the value of created and updated records. Deletions are not supported by the
+// dynamic DNS endpoint and have to be done in the web interface: deleted records are reported until
+// they are no longer served.
+func (p *HurricaneElectricProvider) ApplyChanges(ctx context.Context, changes *plan.Changes) error {
+	defer p.persist(ctx)
+
+	for _, ep := range changes.Delete {
+		if !p.domainFilter.Match(ep.DNSName) {
+			continue
+		}
+		p.delete(ctx, ep)
+	}
+
+	for _, endpoints := range [][]*endpoint.Endpoint{changes.Create, changes.UpdateNew} {
+		for _, ep := range endpoints {
+			if !p.domainFilter.Match(ep.DNSName) {
+				continue
+			}
+			if err := p.push(ctx, ep); err != nil {
+				return provider.NewSoftError(err)
+			}
+		}
+	}
+
+	return nil
+}
+
+// SupportedRecordType returns true if the record type can be updated through dynamic DNS.
+func (p *HurricaneElectricProvider) SupportedRecordType(recordType string) bool {
+	switch recordType {
+	case endpoint.RecordTypeA, endpoint.RecordTypeAAAA, endpoint.RecordTypeTXT:
+		return true
+	default:
+		return false
+	}
+}
+
+func (p *HurricaneElectricProvider) push(ctx context.Context, ep *endpoint.Endpoint) error {
+	if !p.SupportedRecordType(ep.RecordType) {
+		log.Warnf("Skipping %s record %s, only A, AAAA and TXT records can be updated through dynamic DNS", ep.RecordType, ep.DNSName)
+		return nil
+	}
+	if len(ep.Targets) == 0 {
+		return nil
+	}
+
+	log.Infof("Updating %s record %s to %s", ep.RecordType, ep.DNSName, ep.Targets[0])
+	if p.dryRun {
+		return nil
+	}
+
+	if err := p.client.Update(ctx, ep.DNSName, ep.RecordType, ep.Targets[0]); err != nil {
+		return fmt.Errorf("failed to update %s record %s: %w", ep.RecordType, ep.DNSName, err)
+	}
+
+	pushed := ep.DeepCopy()
+	pushed.Targets = endpoint.Targets{ep.Targets[0]}
+	p.mutex.Lock()
+	p.records[pushed.Key()] = pushed
+	p.changed = true
+	p.mutex.Unlock()
+	return nil
+}
+
+// delete forgets a deleted record once it is no longer served, it having to be removed in the
+// dns.he.net interface. Until then its deletion is rejected and it is still reported.
+func (p *HurricaneElectricProvider) delete(ctx context.Context, ep *endpoint.Endpoint) {
+	exists, err := p.resolver.Exists(ctx, ep.DNSName, ep.RecordType)
+	switch {
+	case err != nil:
+		log.Warnf("Cannot tell whether %s record %s was removed in the dns.he.net interface: %v", ep.RecordType, ep.DNSName, err)
+		return
+	case exists:
+		log.Warnf("Cannot delete %s record %s through dynamic DNS, it has to be removed in the dns.he.net interface", ep.RecordType, ep.DNSName)
+		return
+	}
+
+	log.Infof("Forgetting %s record %s removed in the dns.he.net interface", ep.RecordType, ep.DNSName)
+	if p.dryRun {
+		return
+	}
+	p.mutex.Lock()
+	delete(p.records, ep.Key())
+	p.changed = true
+	p.mutex.Unlock()
+}
+
+// restore loads the persisted records the first time it is called. Records that cannot be decoded
+// are ignored, as they would never be.
+func (p *HurricaneElectricProvider) restore(ctx context.Context) error {
+	if p.restored || p.store == nil {
+		return nil
+	}
+	data, err := p.store.Load(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to load the persisted records: %w", err)
+	}
+	p.restored = true
+	if data == nil {
+		return nil
+	}
+	var records []*endpoint.Endpoint
+	if err := json.Unmarshal(data, &records); err != nil {
+		log.Warnf("Ignoring the persisted records that cannot be decoded: %v", err)
+		return nil
+	}
+	for _, ep := range records {
+		p.records[ep.Key()] = ep
+	}
+	log.Infof("Restored %d records pushed through dynamic DNS", len(records))
+	return nil
+}
+
+// persist saves the records when they changed. Failures are logged, the records being saved again
+// after the next change.
+func (p *HurricaneElectricProvider) persist(ctx context.Context) {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+
+	if !p.changed || p.store == nil {
+		return
+	}
+	records := make([]*endpoint.Endpoint, 0, len(p.records))
+	for _, ep := range p.records {
+		records = append(records, ep)
+	}
+	slices.SortFunc(records, func(a, b *endpoint.Endpoint) int {
+		return cmp.Or(cmp.Compare(a.DNSName, b.DNSName), cmp.Compare(a.RecordType, b.RecordType), cmp.Compare(a.SetIdentifier, b.SetIdentifier))
+	})
+	data, err := json.Marshal(records)
+	if err == nil {
+		err = p.store.Save(ctx, data)
+	}
+	if err != nil {
+		log.Warnf("Failed to persist the records pushed through dynamic DNS: %v", err)
+		return
+	}
+	p.changed = false
+}
@@ -0,0 +1,235 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package hurricaneelectric
+
+import (
+	"context"
+	"errors"
+	"path/filepath"
+	"testing"
+
+	log "github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"sigs.k8s.io/external-dns/endpoint"
+	"sigs.k8s.io/external-dns/internal/testutils"
+	"sigs.k8s.io/external-dns/pkg/apis/externaldns"
+	"sigs.k8s.io/external-dns/plan"
+	"sigs.k8s.io/external-dns/provider"
+)
+
+type update struct {
+	hostname   string
+	recordType string
+	value      string
+}
+
+type mockDynClient struct {
+	updates []update
+	err     error
+}
+
+func (m *mockDynClient) Update(_ context.Context, hostname, recordType, value string) error {
+	if m.err != nil {
+		return m.err
+	}
+	m.updates = append(m.updates, update{hostname, recordType, value})
+	return nil
+}
+
+// mockRecordResolver serves the records whose hostname and type are set.
+type mockRecordResolver map[string]bool
+
+func (m mockRecordResolver) Exists(_ context.Context, hostname, recordType string) (bool, error) {
+	if exists, ok := m[hostname+" "+recordType]; ok {
+		return exists, nil
+	}
+	return false, errors.New("timeout")
+}
+
+func TestNewHurricaneElectricProvider(t *testing.T) {
+	t.Setenv("HE_DDNS_KEY", "secret")
+	_, err := NewHurricaneElectricProvider(endpoint.NewDomainFilter([]string{"example.com"}), nil, false)
+	require.NoError(t, err)
+
+	t.Setenv("HE_DDNS_KEY", "")
+	_, err = NewHurricaneElectricProvider(endpoint.NewDomainFilter([]string{"example.com"}), nil, false)
+	require.Error(t, err)
+}
+
+func TestNewHurricaneElectricProviderFromConfig(t *testing.T) {
+	t.Setenv("HE_DDNS_KEY", "secret")
+	cfg := externaldns.NewConfig()
+	require.NoError(t, cfg.ParseFlags([]string{"--provider=hurricane-electric", "--source=service", "--hurricane-electric-state=file:" + t.TempDir() + "/he.json"}))
+	p, err := NewHurricaneElectricProviderFromConfig(cfg, endpoint.NewDomainFilter([]string{"example.com"}))
+	require.NoError(t, err)
+	assert.IsType(t, &FileStateStore{}, p.store)
+}
+
+func TestHurricaneElectricApplyChanges(t *testing.T) {
+	client := &mockDynClient{}
+	resolver := mockRecordResolver{"home.example.com TXT": true}
+	p := newHurricaneElectricProvider(client, resolver, endpoint.NewDomainFilter([]string{"example.com"}), false)
+
+	err := p.ApplyChanges(context.Background(), &plan.Changes{
+		Create: []*endpoint.Endpoint{
+			endpoint.NewEndpoint("home.example.com", endpoint.RecordTypeA, "1.2.3.4"),
+			endpoint.NewEndpoint("home.example.com", endpoint.RecordTypeTXT, "heritage=external-dns"),
+			endpoint.NewEndpoint("alias.example.com", endpoint.RecordTypeCNAME, "home.example.com"),
+			endpoint.NewEndpoint("home.example.org", endpoint.RecordTypeA, "1.2.3.4"),
+		},
+		UpdateNew: []*endpoint.Endpoint{
+			endpoint.NewEndpoint("v6.example.com", endpoint.RecordTypeAAAA, "2001:db8::1", "2001:db8::2"),
+		},
+	})
+	require.NoError(t, err)
+
+	assert.Equal(t, []update{
+		{"home.example.com", endpoint.RecordTypeA, "1.2.3.4"},
+		{"home.example.com", endpoint.RecordTypeTXT, "heritage=external-dns"},
+		{"v6.example.com", endpoint.RecordTypeAAAA, "2001:db8::1"},
+	}, client.updates)
+
+	records, err := p.Records(context.Background())
+	require.NoError(t, err)
+	assert.Len(t, records, 3)
+
+	deleted := &plan.Changes{
+		Delete: []*endpoint.Endpoint{
+			endpoint.NewEndpoint("home.example.com", endpoint.RecordTypeTXT, "heritage=external-dns"),
+		},
+	}
+	hook := testutils.LogsUnderTestWithLogLevel(log.WarnLevel, t)
+	require.NoError(t, p.ApplyChanges(context.Background(), deleted))
+	testutils.TestHelperLogContains("Cannot delete TXT record home.example.com through dynamic DNS, it has to be removed in the dns.he.net interface", hook, t)
+
+	// the record is still served, so it is still reported
+	records, err = p.Records(context.Background())
+	require.NoError(t, err)
+	assert.Len(t, records, 3)
+
+	// until it is removed in the web interface
+	resolver["home.example.com TXT"] = false
+	require.NoError(t, p.ApplyChanges(context.Background(), deleted))
+	records, err = p.Records(context.Background())
+	require.NoError(t, err)
+	assert.Len(t, records, 2)
+	assert.Len(t, client.updates, 3, "deletions must not be pushed")
+
+	// records whose removal cannot be told are kept
+	require.NoError(t, p.ApplyChanges(context.Background(), &plan.Changes{
+		Delete: []*endpoint.Endpoint{endpoint.NewEndpoint("v6.example.com", endpoint.RecordTypeAAAA, "2001:db8::1")},
+	}))
+	records, err = p.Records(context.Background())
+	require.NoError(t, err)
+	assert.Len(t, records, 2)
+}
+
+func TestHurricaneElectricAdjustEndpoints(t *testing.T) {
+	p := newHurricaneElectricProvider(&mockDynClient{}, mockRecordResolver{}, endpoint.NewDomainFilter([]string{"example.com"}), false)
+
+	endpoints, err := p.AdjustEndpoints([]*endpoint.Endpoint{
+		endpoint.NewEndpoint("v6.example.com", endpoint.RecordTypeAAAA, "2001:db8::1", "2001:db8::2"),
+		endpoint.NewEndpoint("home.example.com", endpoint.RecordTypeA, "1.2.3.4"),
+	})
+	require.NoError(t, err)
+	assert.Equal(t, endpoint.Targets{"2001:db8::1"}, endpoints[0].Targets)
+	assert.Equal(t, endpoint.Targets{"1.2.3.4"}, endpoints[1].Targets)
+}
+
+func TestHurricaneElectricStatePersistence(t *testing.T) {
+	store := NewFileStateStore(filepath.Join(t.TempDir(), "he.json"))
+	client := &mockDynClient{}
+	p := newHurricaneElectricProvider(client, mockRecordResolver{}, endpoint.NewDomainFilter([]string{"example.com"}), false)
+	p.store = store
+
+	require.NoError(t, p.ApplyChanges(context.Background(), &plan.Changes{
+		Create: []*endpoint.Endpoint{endpoint.NewEndpoint("home.example.com", endpoint.RecordTypeA, "1.2.3.4")},
+	}))
+
+	// the records pushed before a restart are still reported
+	restarted := newHurricaneElectricProvider(client, mockRecordResolver{"home.example.com A": false}, endpoint.NewDomainFilter([]string{"example.com"}), false)
+	restarted.store = store
+	records, err := restarted.Records(context.Background())
+	require.NoError(t, err)
+	require.Len(t, records, 1)
+	assert.Equal(t, "home.example.com", records[0].DNSName)
+	assert.Equal(t, endpoint.Targets{"1.2.3.4"}, records[0].Targets)
+
+	// and the records forgotten since are no longer persisted
+	require.NoError(t, restarted.ApplyChanges(context.Background(), &plan.Changes{Delete: records}))
+	data, err := store.Load(context.Background())
+	require.NoError(t, err)
+	assert.JSONEq(t, "[]", string(data))
+}
+
+func TestHurricaneElectricStateRestoreFailure(t *testing.T) {
+	store := &mockStateStore{err: errors.New("unavailable")}
+	p := newHurricaneElectricProvider(&mockDynClient{}, mockRecordResolver{}, endpoint.NewDomainFilter([]string{"example.com"}), false)
+	p.store = store
+
+	// records are not reported as missing when they cannot be loaded
+	_, err := p.Records(context.Background())
+	require.ErrorIs(t, err, provider.SoftError)
+
+	// but are when they cannot be decoded
+	store.data, store.err = []byte("not json"), nil
+	records, err := p.Records(context.Background())
+	require.NoError(t, err)
+	assert.Empty(t, records)
+}
+
+type mockStateStore struct {
+	data []byte
+	err  error
+}
+
+func (s *mockStateStore) Load(_ context.Context) ([]byte, error) {
+	return s.data, s.err
+}
+
+func (s *mockStateStore) Save(_ context.Context, data []byte) error {
+	s.data = data
+	return s.err
+}
+
+func TestHurricaneElectricApplyChangesDryRun(t *testing.T) {
+	client := &mockDynClient{}
+	p := newHurricaneElectricProvider(client, mockRecordResolver{}, endpoint.NewDomainFilter([]string{"example.com"}), true)
+
+	err := p.ApplyChanges(context.Background(), &plan.Changes{
+		Create: []*endpoint.Endpoint{endpoint.NewEndpoint("home.example.com", endpoint.RecordTypeA, "1.2.3.4")},
+	})
+	require.NoError(t, err)
+	assert.Empty(t, client.updates)
+}
+
+func TestHurricaneElectricApplyChangesError(t *testing.T) {
+	client := &mockDynClient{err: errors.New("badauth")}
+	p := newHurricaneElectricProvider(client, mockRecordResolver{}, endpoint.NewDomainFilter([]string{"example.com"}), false)
+
+	err := p.ApplyChanges(context.Background(), &plan.Changes{
+		Create: []*endpoint.Endpoint{endpoint.NewEndpoint("home.example.com", endpoint.RecordTypeA, "1.2.3.4")},
+	})
+	require.Error(t, err)
+	assert.ErrorIs(t, err, provider.SoftError)
+
+	records, err := p.Records(context.Background())
+	require.NoError(t, err)
+	assert.Empty(t, records)
+}
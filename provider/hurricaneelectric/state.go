@@ -0,0 +1,127 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package hurricaneelectric
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+)
+
+// StateConfigMapKey is the data key of the ConfigMap holding the persisted records.
+const StateConfigMapKey = "records.json"
+
+// StateStore persists the records pushed through dynamic DNS, so that they are still reported, and
+// their deletion noticed, after a restart.
+type StateStore interface {
+	// Load returns the persisted data, nil when nothing was persisted yet.
+	Load(ctx context.Context) ([]byte, error)
+	// Save replaces the persisted data.
+	Save(ctx context.Context, data []byte) error
+}
+
+// FileStateStore persists the records in a local file, which should be on a volume surviving restarts.
+type FileStateStore struct {
+	path string
+}
+
+// NewFileStateStore returns a FileStateStore persisting the records in the file at path.
+func NewFileStateStore(path string) *FileStateStore {
+	return &FileStateStore{path: path}
+}
+
+func (s *FileStateStore) Load(_ context.Context) ([]byte, error) {
+	data, err := os.ReadFile(s.path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	return data, err
+}
+
+// Save writes the data to a temporary file renamed over the file, so that it is never partially written.
+func (s *FileStateStore) Save(_ context.Context, data []byte) error {
+	f, err := os.CreateTemp(filepath.Dir(s.path), filepath.Base(s.path)+".*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(f.Name())
+	if _, err := f.Write(data); err != nil {
+		f.Close()
+		return err
+	}
+	if err := f.Close(); err != nil {
+		return err
+	}
+	return os.Rename(f.Name(), s.path)
+}
+
+// ConfigMapStateStore persists the records in a ConfigMap, created when missing.
+type ConfigMapStateStore struct {
+	client    kubernetes.Interface
+	namespace string
+	name      string
+}
+
+// NewConfigMapStateStore returns a ConfigMapStateStore persisting the records in the given ConfigMap.
+func NewConfigMapStateStore(client kubernetes.Interface, namespace, name string) *ConfigMapStateStore {
+	return &ConfigMapStateStore{client: client, namespace: namespace, name: name}
+}
+
+func (s *ConfigMapStateStore) Load(ctx context.Context) ([]byte, error) {
+	cm, err := s.client.CoreV1().ConfigMaps(s.namespace).Get(ctx, s.name, metav1.GetOptions{})
+	if apierrors.IsNotFound(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	data, ok := cm.Data[StateConfigMapKey]
+	if !ok {
+		return nil, nil
+	}
+	return []byte(data), nil
+}
+
+func (s *ConfigMapStateStore) Save(ctx context.Context, data []byte) error {
+	configMaps := s.client.CoreV1().ConfigMaps(s.namespace)
+	cm, err := configMaps.Get(ctx, s.name, metav1.GetOptions{})
+	if apierrors.IsNotFound(err) {
+		_, err = configMaps.Create(ctx, &corev1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{Namespace: s.namespace, Name: s.name},
+			Data:       map[string]string{StateConfigMapKey: string(data)},
+		}, metav1.CreateOptions{})
+		return err
+	}
+	if err != nil {
+		return err
+	}
+	if cm.Data == nil {
+		cm.Data = map[string]string{}
+	}
+	cm.Data[StateConfigMapKey] = string(data)
+	if _, err := configMaps.Update(ctx, cm, metav1.UpdateOptions{}); err != nil {
+		return fmt.Errorf("updating ConfigMap %s/%s: %w", s.namespace, s.name, err)
+	}
+	return nil
+}
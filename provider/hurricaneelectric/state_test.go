@@ -0,0 +1,70 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package hurricaneelectric
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+func TestFileStateStore(t *testing.T) {
+	store := NewFileStateStore(filepath.Join(t.TempDir(), "he.json"))
+
+	data, err := store.Load(t.Context())
+	require.NoError(t, err)
+	assert.Nil(t, data, "nothing is loaded before the file is written")
+
+	require.NoError(t, store.Save(t.Context(), []byte("first")))
+	require.NoError(t, store.Save(t.Context(), []byte("second")))
+	data, err = store.Load(t.Context())
+	require.NoError(t, err)
+	assert.Equal(t, []byte("second"), data)
+}
+
+func TestConfigMapStateStore(t *testing.T) {
+	client := fake.NewClientset(&corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "external-dns", Name: "he-state"},
+		Data:       map[string]string{"note": "managed by external-dns"},
+	})
+	store := NewConfigMapStateStore(client, "external-dns", "he-state")
+
+	data, err := store.Load(t.Context())
+	require.NoError(t, err)
+	assert.Nil(t, data, "nothing is loaded before the records are saved")
+
+	require.NoError(t, store.Save(t.Context(), []byte("[]")))
+	data, err = store.Load(t.Context())
+	require.NoError(t, err)
+	assert.Equal(t, []byte("[]"), data)
+
+	cm, err := client.CoreV1().ConfigMaps("external-dns").Get(t.Context(), "he-state", metav1.GetOptions{})
+	require.NoError(t, err)
+	assert.Equal(t, map[string]string{"note": "managed by external-dns", StateConfigMapKey: "[]"}, cm.Data)
+
+	// the ConfigMap is created when missing
+	store = NewConfigMapStateStore(client, "external-dns", "other")
+	require.NoError(t, store.Save(t.Context(), []byte("[]")))
+	data, err = store.Load(t.Context())
+	require.NoError(t, err)
+	assert.Equal(t, []byte("[]"), data)
+}
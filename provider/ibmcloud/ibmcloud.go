@@ -575,7 +575,7 @@ func (p *IBMCloudProvider) activePrivateZone(ctx context.Context, zoneID, vpc st
 // changesByPrivateZone separates a multi-zone change into a single change per zone.
 func (p *IBMCloudProvider) changesByPrivateZone(ctx context.Context, zones []dnssvcsv1.Dnszone, changeSet []*ibmcloudChange) map[string][]*ibmcloudChange {
 	changes := make(map[string][]*ibmcloudChange)
-	zoneNameIDMapper := provider.ZoneIDName{}
+	zoneNameIDMapper := p.NewZoneFinder()
 	for _, z := range zones {
 		zoneNameIDMapper.Add(*z.ID, *z.Name)
 		changes[*z.ID] = []*ibmcloudChange{}
@@ -19,7 +19,10 @@ package inmemory
 import (
 	"context"
 	"errors"
+	"slices"
+	"sort"
 	"strings"
+	"sync"
 
 	log "github.com/sirupsen/logrus"
 	"k8s.io/apimachinery/pkg/util/sets"
@@ -53,6 +56,16 @@ type InMemoryProvider struct {
 	OnRecords      func()
 }
 
+// ZoneChange is a batch of changes applied to a single zone
+type ZoneChange struct {
+	// Zone is the ID of the zone the changes were applied to
+	Zone string
+	// Serial is the serial of the zone once the changes were applied
+	Serial uint32
+	// Changes are the changes applied to the zone, in the order creates, updates, deletes
+	Changes *plan.Changes
+}
+
 // InMemoryOption allows to extend in-memory provider
 type InMemoryOption func(*InMemoryProvider)
 
@@ -121,6 +134,18 @@ func (im *InMemoryProvider) Zones() map[string]string {
 	return im.filter.Zones(im.client.Zones())
 }
 
+// Serial returns the serial of the zone, similar to the serial of its SOA record.
+// A new zone starts at serial 1, which is then incremented by every non-empty batch of changes.
+func (im *InMemoryProvider) Serial(zone string) (uint32, error) {
+	return im.client.Serial(zone)
+}
+
+// History returns the batches of changes applied to the zones, in the order they were applied.
+// A call to ApplyChanges spanning several zones applies them in lexical order of their IDs.
+func (im *InMemoryProvider) History() []ZoneChange {
+	return im.client.History()
+}
+
 // Records returns the list of endpoints
 func (im *InMemoryProvider) Records(ctx context.Context) ([]*endpoint.Endpoint, error) {
 	defer im.OnRecords()
@@ -183,7 +208,13 @@ func (im *InMemoryProvider) ApplyChanges(ctx context.Context, changes *plan.Chan
 		perZoneChanges[zoneID].Delete = append(perZoneChanges[zoneID].Delete, ep)
 	}
 
+	zoneIDs := make([]string, 0, len(perZoneChanges))
 	for zoneID := range perZoneChanges {
+		zoneIDs = append(zoneIDs, zoneID)
+	}
+	sort.Strings(zoneIDs)
+
+	for _, zoneID := range zoneIDs {
 		change := &plan.Changes{
 			Create:    perZoneChanges[zoneID].Create,
 			UpdateNew: perZoneChanges[zoneID].UpdateNew,
@@ -213,6 +244,15 @@ func copyEndpoints(endpoints []*endpoint.Endpoint) []*endpoint.Endpoint {
 	return records
 }
 
+func copyChanges(changes *plan.Changes) *plan.Changes {
+	return &plan.Changes{
+		Create:    copyEndpoints(changes.Create),
+		UpdateOld: copyEndpoints(changes.UpdateOld),
+		UpdateNew: copyEndpoints(changes.UpdateNew),
+		Delete:    copyEndpoints(changes.Delete),
+	}
+}
+
 type filter struct {
 	domain string
 }
@@ -244,14 +284,19 @@ func (f *filter) EndpointZoneID(endpoint *endpoint.Endpoint, zones map[string]st
 type zone map[endpoint.EndpointKey]*endpoint.Endpoint
 
 type inMemoryClient struct {
-	zones map[string]zone
+	sync.RWMutex
+	zones   map[string]zone
+	serials map[string]uint32
+	history []ZoneChange
 }
 
 func newInMemoryClient() *inMemoryClient {
-	return &inMemoryClient{map[string]zone{}}
+	return &inMemoryClient{zones: map[string]zone{}, serials: map[string]uint32{}}
 }
 
 func (c *inMemoryClient) Records(zone string) ([]*endpoint.Endpoint, error) {
+	c.RLock()
+	defer c.RUnlock()
 	if _, ok := c.zones[zone]; !ok {
 		return nil, ErrZoneNotFound
 	}
@@ -264,6 +309,8 @@ func (c *inMemoryClient) Records(zone string) ([]*endpoint.Endpoint, error) {
 }
 
 func (c *inMemoryClient) Zones() map[string]string {
+	c.RLock()
+	defer c.RUnlock()
 	zones := map[string]string{}
 	for zone := range c.zones {
 		zones[zone] = zone
@@ -272,18 +319,64 @@ func (c *inMemoryClient) Zones() map[string]string {
 }
 
 func (c *inMemoryClient) CreateZone(zone string) error {
+	c.Lock()
+	defer c.Unlock()
 	if _, ok := c.zones[zone]; ok {
 		return ErrZoneAlreadyExists
 	}
 	c.zones[zone] = map[endpoint.EndpointKey]*endpoint.Endpoint{}
+	c.setSerial(zone, 1)
 
 	return nil
 }
 
+func (c *inMemoryClient) Serial(zone string) (uint32, error) {
+	c.RLock()
+	defer c.RUnlock()
+	if _, ok := c.zones[zone]; !ok {
+		return 0, ErrZoneNotFound
+	}
+	return c.serial(zone), nil
+}
+
+// serial returns the serial of an existing zone, defaulting to the initial
+// serial for zones that were not created through CreateZone.
+func (c *inMemoryClient) serial(zone string) uint32 {
+	if serial, ok := c.serials[zone]; ok {
+		return serial
+	}
+	return 1
+}
+
+func (c *inMemoryClient) setSerial(zone string, serial uint32) {
+	if c.serials == nil {
+		c.serials = map[string]uint32{}
+	}
+	c.serials[zone] = serial
+}
+
+func (c *inMemoryClient) History() []ZoneChange {
+	c.RLock()
+	defer c.RUnlock()
+	history := make([]ZoneChange, 0, len(c.history))
+	for _, h := range c.history {
+		history = append(history, ZoneChange{Zone: h.Zone, Serial: h.Serial, Changes: copyChanges(h.Changes)})
+	}
+	return history
+}
+
 func (c *inMemoryClient) ApplyChanges(ctx context.Context, zoneID string, changes *plan.Changes) error {
+	c.Lock()
+	defer c.Unlock()
 	if err := c.validateChangeBatch(zoneID, changes); err != nil {
 		return err
 	}
+	if len(changes.Create) == 0 && len(changes.UpdateNew) == 0 && len(changes.Delete) == 0 {
+		return nil
+	}
+	serial := c.serial(zoneID) + 1
+	c.setSerial(zoneID, serial)
+	c.history = append(c.history, ZoneChange{Zone: zoneID, Serial: serial, Changes: copyChanges(changes)})
 	for _, newEndpoint := range changes.Create {
 		c.zones[zoneID][newEndpoint.Key()] = newEndpoint
 	}
@@ -328,12 +421,12 @@ func (c *inMemoryClient) validateChangeBatch(zone string, changes *plan.Changes)
 		}
 	}
 	for _, updateOldEndpoint := range changes.UpdateOld {
-		if rec, ok := curZone[updateOldEndpoint.Key()]; !ok || rec.Targets[0] != updateOldEndpoint.Targets[0] {
+		if rec, ok := curZone[updateOldEndpoint.Key()]; !ok || !sameTargets(rec.Targets, updateOldEndpoint.Targets) {
 			return ErrRecordNotFound
 		}
 	}
 	for _, deleteEndpoint := range changes.Delete {
-		if rec, ok := curZone[deleteEndpoint.Key()]; !ok || rec.Targets[0] != deleteEndpoint.Targets[0] {
+		if rec, ok := curZone[deleteEndpoint.Key()]; !ok || !sameTargets(rec.Targets, deleteEndpoint.Targets) {
 			return ErrRecordNotFound
 		}
 		if err := c.updateMesh(mesh, deleteEndpoint); err != nil {
@@ -342,3 +435,9 @@ func (c *inMemoryClient) validateChangeBatch(zone string, changes *plan.Changes)
 	}
 	return nil
 }
+
+// sameTargets compares targets regardless of their order. Targets.Same sorts
+// its operands in place, so copies are compared to leave stored records untouched.
+func sameTargets(a, b endpoint.Targets) bool {
+	return slices.Clone(a).Same(slices.Clone(b))
+}
@@ -23,6 +23,7 @@ import (
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	"sigs.k8s.io/external-dns/endpoint"
+	"sigs.k8s.io/external-dns/internal/planfuzz"
 	"sigs.k8s.io/external-dns/internal/testutils"
 	"sigs.k8s.io/external-dns/plan"
 	"sigs.k8s.io/external-dns/provider"
@@ -36,6 +37,8 @@ func TestInMemoryProvider(t *testing.T) {
 	t.Run("ApplyChanges", testInMemoryApplyChanges)
 	t.Run("NewInMemoryProvider", testNewInMemoryProvider)
 	t.Run("CreateZone", testInMemoryCreateZone)
+	t.Run("Serial", testInMemorySerial)
+	t.Run("History", testInMemoryHistory)
 }
 
 func testInMemoryRecords(t *testing.T) {
@@ -573,6 +576,69 @@ func testInMemoryCreateZone(t *testing.T) {
 	assert.EqualError(t, err, ErrZoneAlreadyExists.Error())
 }
 
+func testInMemorySerial(t *testing.T) {
+	im := NewInMemoryProvider(InMemoryInitZones([]string{"org", "com"}))
+
+	_, err := im.Serial("net")
+	assert.EqualError(t, err, ErrZoneNotFound.Error())
+
+	serial, err := im.Serial("org")
+	require.NoError(t, err)
+	assert.Equal(t, uint32(1), serial)
+
+	create := endpoint.NewEndpoint("foo.org", endpoint.RecordTypeA, "1.1.1.1")
+	require.NoError(t, im.ApplyChanges(context.Background(), &plan.Changes{Create: []*endpoint.Endpoint{create}}))
+	serial, err = im.Serial("org")
+	require.NoError(t, err)
+	assert.Equal(t, uint32(2), serial)
+	serial, err = im.Serial("com")
+	require.NoError(t, err)
+	assert.Equal(t, uint32(1), serial, "zones without changes keep their serial")
+
+	// a rejected batch does not change the serial
+	require.Error(t, im.ApplyChanges(context.Background(), &plan.Changes{Create: []*endpoint.Endpoint{create}}))
+	require.NoError(t, im.ApplyChanges(context.Background(), &plan.Changes{}))
+	serial, err = im.Serial("org")
+	require.NoError(t, err)
+	assert.Equal(t, uint32(2), serial)
+}
+
+func testInMemoryHistory(t *testing.T) {
+	im := NewInMemoryProvider(InMemoryInitZones([]string{"org", "com", "net"}))
+	assert.Empty(t, im.History())
+
+	fooOrg := endpoint.NewEndpoint("foo.org", endpoint.RecordTypeA, "1.1.1.1")
+	fooCom := endpoint.NewEndpoint("foo.com", endpoint.RecordTypeA, "2.2.2.2")
+	fooComNew := endpoint.NewEndpoint("foo.com", endpoint.RecordTypeA, "3.3.3.3")
+	require.NoError(t, im.ApplyChanges(context.Background(), &plan.Changes{Create: []*endpoint.Endpoint{fooOrg, fooCom}}))
+	require.NoError(t, im.ApplyChanges(context.Background(), &plan.Changes{
+		UpdateOld: []*endpoint.Endpoint{fooCom},
+		UpdateNew: []*endpoint.Endpoint{fooComNew},
+		Delete:    []*endpoint.Endpoint{fooOrg},
+	}))
+
+	history := im.History()
+	require.Len(t, history, 4)
+	expected := []ZoneChange{
+		{Zone: "com", Serial: 2, Changes: &plan.Changes{Create: []*endpoint.Endpoint{fooCom}}},
+		{Zone: "org", Serial: 2, Changes: &plan.Changes{Create: []*endpoint.Endpoint{fooOrg}}},
+		{Zone: "com", Serial: 3, Changes: &plan.Changes{UpdateOld: []*endpoint.Endpoint{fooCom}, UpdateNew: []*endpoint.Endpoint{fooComNew}}},
+		{Zone: "org", Serial: 3, Changes: &plan.Changes{Delete: []*endpoint.Endpoint{fooOrg}}},
+	}
+	for i, h := range history {
+		assert.Equal(t, expected[i].Zone, h.Zone, i)
+		assert.Equal(t, expected[i].Serial, h.Serial, i)
+		assert.True(t, testutils.SameEndpoints(expected[i].Changes.Create, h.Changes.Create), i)
+		assert.True(t, testutils.SameEndpoints(expected[i].Changes.UpdateOld, h.Changes.UpdateOld), i)
+		assert.True(t, testutils.SameEndpoints(expected[i].Changes.UpdateNew, h.Changes.UpdateNew), i)
+		assert.True(t, testutils.SameEndpoints(expected[i].Changes.Delete, h.Changes.Delete), i)
+	}
+
+	// the history is a copy, which callers cannot alter
+	history[0].Changes.Create[0].Targets[0] = "9.9.9.9"
+	assert.Equal(t, "2.2.2.2", im.History()[0].Changes.Create[0].Targets[0])
+}
+
 func makeZone(s ...string) map[endpoint.EndpointKey]*endpoint.Endpoint {
 	if len(s)%3 != 0 {
 		panic("makeZone arguments must be multiple of 3")
@@ -586,3 +652,16 @@ func makeZone(s ...string) map[endpoint.EndpointKey]*endpoint.Endpoint {
 
 	return output
 }
+
+func FuzzInMemoryConvergence(f *testing.F) {
+	for seed := int64(0); seed < 50; seed++ {
+		f.Add(seed, uint8(5))
+	}
+	f.Fuzz(func(t *testing.T, seed int64, steps uint8) {
+		g := planfuzz.NewGenerator(seed, "example.com")
+		im := NewInMemoryProvider(InMemoryInitZones([]string{"example.com"}))
+		for i := 0; i < int(steps%10)+1; i++ {
+			require.NoError(t, planfuzz.Converge(context.Background(), im, g.Endpoints()), "step %d", i)
+		}
+	})
+}
@@ -0,0 +1,193 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package ionoscloud
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+
+	"sigs.k8s.io/external-dns/pkg/apis/externaldns"
+	extdnshttp "sigs.k8s.io/external-dns/pkg/http"
+)
+
+const (
+	defaultAPIURL = "https://dns.de-fra.ionos.com"
+	// pageSize is the number of items requested per page when listing zones and records.
+	pageSize = 1000
+)
+
+// Zone is an IONOS Cloud DNS zone.
+type Zone struct {
+	ID         string         `json:"id"`
+	Properties ZoneProperties `json:"properties"`
+}
+
+// ZoneProperties are the properties of an IONOS Cloud DNS zone.
+type ZoneProperties struct {
+	ZoneName string `json:"zoneName"`
+	Enabled  bool   `json:"enabled"`
+}
+
+// Record is an IONOS Cloud DNS record.
+type Record struct {
+	ID         string           `json:"id"`
+	Properties RecordProperties `json:"properties"`
+	Metadata   RecordMetadata   `json:"metadata"`
+}
+
+// RecordProperties are the properties of an IONOS Cloud DNS record. The name is
+// relative to the zone, and empty at its apex.
+type RecordProperties struct {
+	Name     string `json:"name"`
+	Type     string `json:"type"`
+	Content  string `json:"content"`
+	TTL      int    `json:"ttl,omitempty"`
+	Priority int    `json:"priority,omitempty"`
+	Enabled  bool   `json:"enabled"`
+}
+
+// RecordMetadata are the read-only attributes of an IONOS Cloud DNS record.
+type RecordMetadata struct {
+	FQDN   string `json:"fqdn"`
+	ZoneID string `json:"zoneId"`
+}
+
+// Client is the subset of the IONOS Cloud DNS API used by the provider.
+type Client interface {
+	ListZones(ctx context.Context) ([]Zone, error)
+	// ListRecords returns the records of all zones at once.
+	ListRecords(ctx context.Context) ([]Record, error)
+	CreateRecord(ctx context.Context, zoneID string, record RecordProperties) error
+	UpdateRecord(ctx context.Context, zoneID, recordID string, record RecordProperties) error
+	DeleteRecord(ctx context.Context, zoneID, recordID string) error
+}
+
+type recordRequest struct {
+	Properties RecordProperties `json:"properties"`
+}
+
+type client struct {
+	apiURL     string
+	token      string
+	httpClient *http.Client
+}
+
+// NewClient returns a client authenticating with the given API token.
+func NewClient(apiURL, token string) Client {
+	if apiURL == "" {
+		apiURL = defaultAPIURL
+	}
+	return &client{
+		apiURL:     apiURL,
+		token:      token,
+		httpClient: extdnshttp.NewClient("ionoscloud"),
+	}
+}
+
+func (c *client) do(ctx context.Context, method, path string, query url.Values, body any, result any) error {
+	u := c.apiURL + path
+	if len(query) > 0 {
+		u += "?" + query.Encode()
+	}
+
+	var reader io.Reader
+	if body != nil {
+		data, err := json.Marshal(body)
+		if err != nil {
+			return err
+		}
+		reader = bytes.NewReader(data)
+	}
+	req, err := http.NewRequestWithContext(ctx, method, u, reader)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Authorization", "Bearer "+c.token)
+	req.Header.Set("Accept", "application/json")
+	req.Header.Set("User-Agent", externaldns.UserAgent())
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		var apiErr struct {
+			Messages []struct {
+				ErrorCode string `json:"errorCode"`
+				Message   string `json:"message"`
+			} `json:"messages"`
+		}
+		_ = json.NewDecoder(resp.Body).Decode(&apiErr)
+		if len(apiErr.Messages) > 0 {
+			return fmt.Errorf("%s %s: %s (status %d)", method, path, apiErr.Messages[0].Message, resp.StatusCode)
+		}
+		return fmt.Errorf("%s %s: unexpected status %s", method, path, resp.Status)
+	}
+	if result == nil {
+		return nil
+	}
+	return json.NewDecoder(resp.Body).Decode(result)
+}
+
+// list fetches all the pages of a collection.
+func list[T any](ctx context.Context, c *client, path string) ([]T, error) {
+	var all []T
+	for offset := 0; ; offset += pageSize {
+		var page struct {
+			Items []T `json:"items"`
+		}
+		query := url.Values{"limit": {strconv.Itoa(pageSize)}, "offset": {strconv.Itoa(offset)}}
+		if err := c.do(ctx, http.MethodGet, path, query, nil, &page); err != nil {
+			return nil, err
+		}
+		all = append(all, page.Items...)
+		if len(page.Items) < pageSize {
+			return all, nil
+		}
+	}
+}
+
+func (c *client) ListZones(ctx context.Context) ([]Zone, error) {
+	return list[Zone](ctx, c, "/zones")
+}
+
+func (c *client) ListRecords(ctx context.Context) ([]Record, error) {
+	return list[Record](ctx, c, "/records")
+}
+
+func (c *client) CreateRecord(ctx context.Context, zoneID string, record RecordProperties) error {
+	return c.do(ctx, http.MethodPost, "/zones/"+url.PathEscape(zoneID)+"/records", nil, recordRequest{Properties: record}, nil)
+}
+
+func (c *client) UpdateRecord(ctx context.Context, zoneID, recordID string, record RecordProperties) error {
+	return c.do(ctx, http.MethodPut, "/zones/"+url.PathEscape(zoneID)+"/records/"+url.PathEscape(recordID), nil, recordRequest{Properties: record}, nil)
+}
+
+func (c *client) DeleteRecord(ctx context.Context, zoneID, recordID string) error {
+	return c.do(ctx, http.MethodDelete, "/zones/"+url.PathEscape(zoneID)+"/records/"+url.PathEscape(recordID), nil, nil, nil)
+}
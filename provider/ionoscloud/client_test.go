@@ -0,0 +1,89 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package ionoscloud
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestClient(t *testing.T) {
+	type request struct {
+		method, uri, body string
+	}
+	var requests []request
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "Bearer token", r.Header.Get("Authorization"))
+		body, _ := io.ReadAll(r.Body)
+		requests = append(requests, request{method: r.Method, uri: r.URL.RequestURI(), body: string(body)})
+		switch {
+		case r.URL.Path == "/zones":
+			_, _ = w.Write([]byte(`{"items":[{"id":"z1","properties":{"zoneName":"example.com","enabled":true}}]}`))
+		case r.URL.Path == "/records":
+			// Serve a full first page to check the next one is requested.
+			if r.URL.Query().Get("offset") == "0" {
+				items := make([]string, pageSize)
+				for i := range items {
+					items[i] = fmt.Sprintf(`{"id":"r%d","properties":{"name":"www","type":"A","content":"1.2.3.4","ttl":300,"enabled":true},"metadata":{"fqdn":"www.example.com","zoneId":"z1"}}`, i)
+				}
+				_, _ = w.Write([]byte(`{"items":[` + strings.Join(items, ",") + `]}`))
+				return
+			}
+			_, _ = w.Write([]byte(`{"items":[{"id":"mx","properties":{"name":"","type":"MX","content":"mail.example.com","ttl":3600,"priority":10,"enabled":true},"metadata":{"fqdn":"example.com","zoneId":"z1"}}]}`))
+		case r.Method == http.MethodDelete:
+			w.WriteHeader(http.StatusNotFound)
+			_, _ = w.Write([]byte(`{"httpStatus":404,"messages":[{"errorCode":"dns-404","message":"record not found"}]}`))
+		default:
+			_, _ = w.Write([]byte(`{}`))
+		}
+	}))
+	defer server.Close()
+
+	c := &client{apiURL: server.URL, token: "token", httpClient: server.Client()}
+
+	zones, err := c.ListZones(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, []Zone{{ID: "z1", Properties: ZoneProperties{ZoneName: "example.com", Enabled: true}}}, zones)
+
+	records, err := c.ListRecords(context.Background())
+	require.NoError(t, err)
+	require.Len(t, records, pageSize+1)
+	assert.Equal(t, Record{
+		ID:         "mx",
+		Properties: RecordProperties{Type: "MX", Content: "mail.example.com", TTL: 3600, Priority: 10, Enabled: true},
+		Metadata:   RecordMetadata{FQDN: "example.com", ZoneID: "z1"},
+	}, records[pageSize])
+	assert.Equal(t, "/records?limit=1000&offset=1000", requests[2].uri)
+
+	require.NoError(t, c.CreateRecord(context.Background(), "z1", RecordProperties{Name: "www", Type: "A", Content: "1.2.3.4", Enabled: true}))
+	assert.Equal(t, request{method: http.MethodPost, uri: "/zones/z1/records", body: `{"properties":{"name":"www","type":"A","content":"1.2.3.4","enabled":true}}`}, requests[3])
+
+	require.NoError(t, c.UpdateRecord(context.Background(), "z1", "r1", RecordProperties{Name: "www", Type: "A", Content: "1.2.3.4", TTL: 60, Enabled: true}))
+	assert.Equal(t, request{method: http.MethodPut, uri: "/zones/z1/records/r1", body: `{"properties":{"name":"www","type":"A","content":"1.2.3.4","ttl":60,"enabled":true}}`}, requests[4])
+
+	err = c.DeleteRecord(context.Background(), "z1", "r2")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "record not found")
+}
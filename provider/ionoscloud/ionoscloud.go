@@ -0,0 +1,313 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package ionoscloud
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"slices"
+	"strconv"
+	"strings"
+
+	log "github.com/sirupsen/logrus"
+
+	"sigs.k8s.io/external-dns/endpoint"
+	"sigs.k8s.io/external-dns/plan"
+	"sigs.k8s.io/external-dns/provider"
+)
+
+// IONOSCloudProvider is an implementation of Provider for IONOS Cloud DNS.
+type IONOSCloudProvider struct {
+	provider.BaseProvider
+	client       Client
+	domainFilter endpoint.DomainFilter
+	dryRun       bool
+}
+
+// NewIONOSCloudProvider initializes a new IONOS Cloud DNS based Provider.
+// The API token is read from the IONOS_TOKEN env var, and the API URL may be
+// overridden with the IONOS_API_URL env var to use another location.
+func NewIONOSCloudProvider(domainFilter endpoint.DomainFilter, dryRun bool) (*IONOSCloudProvider, error) {
+	token := os.Getenv("IONOS_TOKEN")
+	if token == "" {
+		return nil, fmt.Errorf("no ionos cloud api token provided, you must set the IONOS_TOKEN env var")
+	}
+
+	return &IONOSCloudProvider{
+		client:       NewClient(os.Getenv("IONOS_API_URL"), token),
+		domainFilter: domainFilter,
+		dryRun:       dryRun,
+	}, nil
+}
+
+// Records returns the list of records in all managed zones.
+func (p *IONOSCloudProvider) Records(ctx context.Context) ([]*endpoint.Endpoint, error) {
+	zones, err := p.zones(ctx)
+	if err != nil {
+		return nil, err
+	}
+	records, err := p.records(ctx, zones)
+	if err != nil {
+		return nil, err
+	}
+
+	var endpoints []*endpoint.Endpoint
+	byKey := map[endpoint.EndpointKey]*endpoint.Endpoint{}
+	for _, record := range records {
+		if !p.SupportedRecordType(record.Properties.Type) {
+			continue
+		}
+		key := endpoint.EndpointKey{DNSName: record.Metadata.FQDN, RecordType: record.Properties.Type}
+		if ep, ok := byKey[key]; ok {
+			ep.Targets = append(ep.Targets, target(record.Properties))
+			continue
+		}
+		ep := endpoint.NewEndpointWithTTL(key.DNSName, key.RecordType, endpoint.TTL(record.Properties.TTL), target(record.Properties))
+		byKey[key] = ep
+		endpoints = append(endpoints, ep)
+	}
+
+	return endpoints, nil
+}
+
+// SupportedRecordType returns true if the record type is supported by the provider
+func (p *IONOSCloudProvider) SupportedRecordType(recordType string) bool {
+	switch recordType {
+	case endpoint.RecordTypeMX:
+		return true
+	default:
+		return provider.SupportedRecordType(recordType)
+	}
+}
+
+// ApplyChanges applies the given changes. The records of all zones are listed at once,
+// then changed one API call per record.
+func (p *IONOSCloudProvider) ApplyChanges(ctx context.Context, changes *plan.Changes) error {
+	zones, err := p.zones(ctx)
+	if err != nil {
+		return err
+	}
+	records, err := p.records(ctx, zones)
+	if err != nil {
+		return err
+	}
+	zoneNameIDMapper := p.NewZoneFinder()
+	for _, zone := range zones {
+		zoneNameIDMapper.Add(zone.ID, zone.Properties.ZoneName)
+	}
+
+	for _, ep := range changes.Delete {
+		zoneID, _ := zoneNameIDMapper.FindZone(ep.DNSName)
+		if zoneID == "" {
+			log.Debugf("Skipping record %s because no zone matching record DNS Name was detected", ep.DNSName)
+			continue
+		}
+		for _, record := range matchingRecords(records, ep) {
+			if err := p.deleteRecord(ctx, zoneID, ep, record); err != nil {
+				return err
+			}
+		}
+	}
+
+	oldByKey := map[endpoint.EndpointKey]*endpoint.Endpoint{}
+	for _, ep := range changes.UpdateOld {
+		oldByKey[ep.Key()] = ep
+	}
+	for _, ep := range changes.UpdateNew {
+		zoneID, zoneName := zoneNameIDMapper.FindZone(ep.DNSName)
+		if zoneID == "" {
+			log.Debugf("Skipping record %s because no zone matching record DNS Name was detected", ep.DNSName)
+			continue
+		}
+		current := map[string]Record{}
+		for _, record := range matchingRecords(records, ep) {
+			current[target(record.Properties)] = record
+		}
+
+		for _, t := range ep.Targets {
+			desired, err := newRecord(zoneName, ep, t)
+			if err != nil {
+				log.Warnf("Skipping invalid target %q of %s record %s: %v", t, ep.RecordType, ep.DNSName, err)
+				continue
+			}
+			if record, ok := current[t]; ok {
+				delete(current, t)
+				if record.Properties.TTL == desired.TTL {
+					continue
+				}
+				if err := p.updateRecord(ctx, zoneID, ep, record.ID, desired); err != nil {
+					return err
+				}
+				continue
+			}
+			if err := p.createRecord(ctx, zoneID, ep, desired); err != nil {
+				return err
+			}
+		}
+
+		// Only delete the values ExternalDNS used to manage.
+		if old, ok := oldByKey[ep.Key()]; ok {
+			for t, record := range current {
+				if slices.Contains(old.Targets, t) {
+					if err := p.deleteRecord(ctx, zoneID, ep, record); err != nil {
+						return err
+					}
+				}
+			}
+		}
+	}
+
+	for _, ep := range changes.Create {
+		zoneID, zoneName := zoneNameIDMapper.FindZone(ep.DNSName)
+		if zoneID == "" {
+			log.Debugf("Skipping record %s because no zone matching record DNS Name was detected", ep.DNSName)
+			continue
+		}
+		for _, t := range ep.Targets {
+			record, err := newRecord(zoneName, ep, t)
+			if err != nil {
+				log.Warnf("Skipping invalid target %q of %s record %s: %v", t, ep.RecordType, ep.DNSName, err)
+				continue
+			}
+			if err := p.createRecord(ctx, zoneID, ep, record); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+func (p *IONOSCloudProvider) zones(ctx context.Context) ([]Zone, error) {
+	all, err := p.client.ListZones(ctx)
+	if err != nil {
+		return nil, provider.NewSoftError(fmt.Errorf("failed to list zones: %w", err))
+	}
+
+	var zones []Zone
+	for _, zone := range all {
+		if p.domainFilter.Match(zone.Properties.ZoneName) {
+			zones = append(zones, zone)
+		}
+	}
+	return zones, nil
+}
+
+// records lists the records of all zones at once, keeping the ones of the given zones.
+func (p *IONOSCloudProvider) records(ctx context.Context, zones []Zone) ([]Record, error) {
+	all, err := p.client.ListRecords(ctx)
+	if err != nil {
+		return nil, provider.NewSoftError(fmt.Errorf("failed to list records: %w", err))
+	}
+
+	zoneIDs := map[string]bool{}
+	for _, zone := range zones {
+		zoneIDs[zone.ID] = true
+	}
+	var records []Record
+	for _, record := range all {
+		if zoneIDs[record.Metadata.ZoneID] {
+			records = append(records, record)
+		}
+	}
+	return records, nil
+}
+
+func (p *IONOSCloudProvider) createRecord(ctx context.Context, zoneID string, ep *endpoint.Endpoint, record RecordProperties) error {
+	log.Infof("Creating %s record %s with target %s in zone %s", ep.RecordType, ep.DNSName, record.Content, zoneID)
+	if p.dryRun {
+		return nil
+	}
+	if err := p.client.CreateRecord(ctx, zoneID, record); err != nil {
+		return provider.NewSoftError(fmt.Errorf("failed to create %s record %s: %w", ep.RecordType, ep.DNSName, err))
+	}
+	return nil
+}
+
+func (p *IONOSCloudProvider) updateRecord(ctx context.Context, zoneID string, ep *endpoint.Endpoint, recordID string, record RecordProperties) error {
+	log.Infof("Updating %s record %s with target %s in zone %s", ep.RecordType, ep.DNSName, record.Content, zoneID)
+	if p.dryRun {
+		return nil
+	}
+	if err := p.client.UpdateRecord(ctx, zoneID, recordID, record); err != nil {
+		return provider.NewSoftError(fmt.Errorf("failed to update %s record %s: %w", ep.RecordType, ep.DNSName, err))
+	}
+	return nil
+}
+
+func (p *IONOSCloudProvider) deleteRecord(ctx context.Context, zoneID string, ep *endpoint.Endpoint, record Record) error {
+	log.Infof("Deleting %s record %s with target %s in zone %s", ep.RecordType, ep.DNSName, record.Properties.Content, zoneID)
+	if p.dryRun {
+		return nil
+	}
+	if err := p.client.DeleteRecord(ctx, zoneID, record.ID); err != nil {
+		return provider.NewSoftError(fmt.Errorf("failed to delete %s record %s: %w", ep.RecordType, ep.DNSName, err))
+	}
+	return nil
+}
+
+// matchingRecords returns the records with the name and type of the endpoint.
+func matchingRecords(records []Record, ep *endpoint.Endpoint) []Record {
+	var matched []Record
+	for _, record := range records {
+		if record.Properties.Type == ep.RecordType && record.Metadata.FQDN == ep.DNSName {
+			matched = append(matched, record)
+		}
+	}
+	return matched
+}
+
+// newRecord returns the IONOS Cloud record for one of the targets of an endpoint.
+func newRecord(zoneName string, ep *endpoint.Endpoint, t string) (RecordProperties, error) {
+	record := RecordProperties{
+		Name:    strings.TrimSuffix(strings.TrimSuffix(ep.DNSName, zoneName), "."),
+		Type:    ep.RecordType,
+		Content: t,
+		Enabled: true,
+	}
+	if ep.RecordTTL.IsConfigured() {
+		record.TTL = int(ep.RecordTTL)
+	}
+	switch ep.RecordType {
+	case endpoint.RecordTypeMX:
+		mx, err := endpoint.NewMXTarget(t)
+		if err != nil {
+			return RecordProperties{}, err
+		}
+		record.Content, record.Priority = mx.Host, int(mx.Priority)
+	case endpoint.RecordTypeSRV:
+		srv, err := endpoint.NewSRVTarget(t)
+		if err != nil {
+			return RecordProperties{}, err
+		}
+		// The priority of SRV records is set apart from their content.
+		record.Content = fmt.Sprintf("%d %d %s", srv.Weight, srv.Port, srv.Host)
+		record.Priority = int(srv.Priority)
+	}
+	return record, nil
+}
+
+// target returns the content of a record in the RDATA form used by endpoints.
+func target(record RecordProperties) string {
+	switch record.Type {
+	case endpoint.RecordTypeMX, endpoint.RecordTypeSRV:
+		return strconv.Itoa(record.Priority) + " " + record.Content
+	default:
+		return record.Content
+	}
+}
@@ -0,0 +1,156 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package ionoscloud
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"sigs.k8s.io/external-dns/endpoint"
+	"sigs.k8s.io/external-dns/internal/testutils"
+	"sigs.k8s.io/external-dns/plan"
+)
+
+type mockClient struct {
+	zones   []Zone
+	records []Record
+	created []RecordProperties
+	updated map[string]RecordProperties
+	deleted []string
+}
+
+func (m *mockClient) ListZones(_ context.Context) ([]Zone, error) {
+	return m.zones, nil
+}
+
+func (m *mockClient) ListRecords(_ context.Context) ([]Record, error) {
+	return m.records, nil
+}
+
+func (m *mockClient) CreateRecord(_ context.Context, _ string, record RecordProperties) error {
+	m.created = append(m.created, record)
+	return nil
+}
+
+func (m *mockClient) UpdateRecord(_ context.Context, _, recordID string, record RecordProperties) error {
+	m.updated[recordID] = record
+	return nil
+}
+
+func (m *mockClient) DeleteRecord(_ context.Context, _, recordID string) error {
+	m.deleted = append(m.deleted, recordID)
+	return nil
+}
+
+func newTestRecord(id, zoneID, fqdn, name, recordType, content string, ttl, priority int) Record {
+	return Record{
+		ID:         id,
+		Properties: RecordProperties{Name: name, Type: recordType, Content: content, TTL: ttl, Priority: priority, Enabled: true},
+		Metadata:   RecordMetadata{FQDN: fqdn, ZoneID: zoneID},
+	}
+}
+
+func newMockClient() *mockClient {
+	return &mockClient{
+		zones: []Zone{
+			{ID: "z1", Properties: ZoneProperties{ZoneName: "example.com", Enabled: true}},
+			{ID: "z2", Properties: ZoneProperties{ZoneName: "example.org", Enabled: true}},
+		},
+		records: []Record{
+			newTestRecord("r1", "z1", "example.com", "", "A", "1.2.3.4", 3600, 0),
+			newTestRecord("r2", "z1", "www.example.com", "www", "A", "1.2.3.4", 300, 0),
+			newTestRecord("r3", "z1", "www.example.com", "www", "A", "5.6.7.8", 300, 0),
+			newTestRecord("r4", "z1", "example.com", "", "MX", "mail.example.com", 3600, 10),
+			newTestRecord("r5", "z1", "_sip._tcp.example.com", "_sip._tcp", "SRV", "20 5060 sip.example.com", 3600, 10),
+			newTestRecord("r6", "z1", "example.com", "", "SOA", "ns-ic.ui-dns.com", 3600, 0),
+			newTestRecord("r7", "z2", "www.example.org", "www", "A", "9.9.9.9", 300, 0),
+		},
+		updated: map[string]RecordProperties{},
+	}
+}
+
+func TestIONOSCloudRecords(t *testing.T) {
+	p := &IONOSCloudProvider{client: newMockClient(), domainFilter: endpoint.NewDomainFilter([]string{"example.com"})}
+
+	records, err := p.Records(context.Background())
+	require.NoError(t, err)
+
+	expected := []*endpoint.Endpoint{
+		endpoint.NewEndpointWithTTL("example.com", endpoint.RecordTypeA, 3600, "1.2.3.4"),
+		endpoint.NewEndpointWithTTL("www.example.com", endpoint.RecordTypeA, 300, "1.2.3.4", "5.6.7.8"),
+		endpoint.NewEndpointWithTTL("example.com", endpoint.RecordTypeMX, 3600, "10 mail.example.com"),
+		endpoint.NewEndpointWithTTL("_sip._tcp.example.com", endpoint.RecordTypeSRV, 3600, "10 20 5060 sip.example.com"),
+	}
+	assert.True(t, testutils.SameEndpoints(records, expected), "actual and expected endpoints don't match. %s:%s", records, expected)
+}
+
+func TestIONOSCloudApplyChanges(t *testing.T) {
+	client := newMockClient()
+	p := &IONOSCloudProvider{client: client, domainFilter: endpoint.NewDomainFilter([]string{"example.com"})}
+
+	err := p.ApplyChanges(context.Background(), &plan.Changes{
+		Create: []*endpoint.Endpoint{
+			endpoint.NewEndpoint("new.example.com", endpoint.RecordTypeCNAME, "www.example.com"),
+			endpoint.NewEndpointWithTTL("_xmpp._tcp.example.com", endpoint.RecordTypeSRV, 3600, "5 0 5222 xmpp.example.com"),
+			endpoint.NewEndpoint("www.example.org", endpoint.RecordTypeA, "1.1.1.1"),
+		},
+		UpdateOld: []*endpoint.Endpoint{
+			endpoint.NewEndpointWithTTL("www.example.com", endpoint.RecordTypeA, 300, "1.2.3.4", "5.6.7.8"),
+		},
+		UpdateNew: []*endpoint.Endpoint{
+			endpoint.NewEndpointWithTTL("www.example.com", endpoint.RecordTypeA, 600, "1.2.3.4", "4.3.2.1"),
+		},
+		Delete: []*endpoint.Endpoint{
+			endpoint.NewEndpointWithTTL("example.com", endpoint.RecordTypeMX, 3600, "10 mail.example.com"),
+		},
+	})
+	require.NoError(t, err)
+
+	assert.Equal(t, []RecordProperties{
+		{Name: "www", Type: "A", Content: "4.3.2.1", TTL: 600, Enabled: true},
+		{Name: "new", Type: "CNAME", Content: "www.example.com", Enabled: true},
+		{Name: "_xmpp._tcp", Type: "SRV", Content: "0 5222 xmpp.example.com", TTL: 3600, Priority: 5, Enabled: true},
+	}, client.created)
+	assert.Equal(t, map[string]RecordProperties{"r2": {Name: "www", Type: "A", Content: "1.2.3.4", TTL: 600, Enabled: true}}, client.updated)
+	assert.ElementsMatch(t, []string{"r4", "r3"}, client.deleted)
+}
+
+func TestIONOSCloudApplyChangesDryRun(t *testing.T) {
+	client := newMockClient()
+	p := &IONOSCloudProvider{client: client, domainFilter: endpoint.NewDomainFilter([]string{"example.com"}), dryRun: true}
+
+	err := p.ApplyChanges(context.Background(), &plan.Changes{
+		Create: []*endpoint.Endpoint{endpoint.NewEndpoint("new.example.com", endpoint.RecordTypeA, "1.2.3.4")},
+		Delete: []*endpoint.Endpoint{endpoint.NewEndpoint("example.com", endpoint.RecordTypeA, "1.2.3.4")},
+	})
+	require.NoError(t, err)
+	assert.Empty(t, client.created)
+	assert.Empty(t, client.deleted)
+}
+
+func TestNewIONOSCloudProvider(t *testing.T) {
+	t.Setenv("IONOS_TOKEN", "token")
+	_, err := NewIONOSCloudProvider(endpoint.NewDomainFilter([]string{"example.com"}), false)
+	require.NoError(t, err)
+
+	t.Setenv("IONOS_TOKEN", "")
+	_, err = NewIONOSCloudProvider(endpoint.NewDomainFilter([]string{"example.com"}), false)
+	require.Error(t, err)
+}
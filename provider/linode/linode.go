@@ -29,6 +29,7 @@ import (
 	"golang.org/x/oauth2"
 
 	"sigs.k8s.io/external-dns/endpoint"
+	extdnshttp "sigs.k8s.io/external-dns/pkg/http"
 	"sigs.k8s.io/external-dns/plan"
 	"sigs.k8s.io/external-dns/provider"
 
@@ -90,6 +91,7 @@ func NewLinodeProvider(domainFilter endpoint.DomainFilter, dryRun bool) (*Linode
 	oauth2Client := &http.Client{
 		Transport: &oauth2.Transport{
 			Source: tokenSource,
+			Base:   extdnshttp.NewTransport("linode", nil),
 		},
 	}
 
@@ -129,7 +131,7 @@ func (p *LinodeProvider) Records(ctx context.Context) ([]*endpoint.Endpoint, err
 		}
 
 		for _, r := range records {
-			if provider.SupportedRecordType(string(r.Type)) {
+			if p.SupportedRecordType(string(r.Type)) {
 				name := fmt.Sprintf("%s.%s", r.Name, zone.Domain)
 
 				// root name is identified by the empty string and should be
@@ -138,7 +140,7 @@ func (p *LinodeProvider) Records(ctx context.Context) ([]*endpoint.Endpoint, err
 					name = zone.Domain
 				}
 
-				endpoints = append(endpoints, endpoint.NewEndpointWithTTL(name, string(r.Type), endpoint.TTL(r.TTLSec), r.Target))
+				endpoints = append(endpoints, endpoint.NewEndpointWithTTL(name, string(r.Type), endpoint.TTL(r.TTLSec), getRecordTarget(r)))
 			}
 		}
 	}
@@ -242,6 +244,16 @@ func (p *LinodeProvider) submitChanges(ctx context.Context, changes LinodeChange
 	return nil
 }
 
+// SupportedRecordType returns true if the record type is supported by the provider
+func (p *LinodeProvider) SupportedRecordType(recordType string) bool {
+	switch recordType {
+	case endpoint.RecordTypeMX:
+		return true
+	default:
+		return provider.SupportedRecordType(recordType)
+	}
+}
+
 func getWeight(recordType linodego.DomainRecordType) *int {
 	weight := 1
 
@@ -262,6 +274,42 @@ func getPriority() *int {
 	return &priority
 }
 
+// getRecordTarget returns the target of a record in the RDATA form used by endpoints, so that
+// the priority, weight and port of MX and SRV records are part of the target.
+func getRecordTarget(record linodego.DomainRecord) string {
+	switch record.Type {
+	case linodego.RecordTypeMX:
+		return endpoint.MXTarget{Priority: uint16(record.Priority), Host: record.Target}.String()
+	case linodego.RecordTypeSRV:
+		return endpoint.SRVTarget{Priority: uint16(record.Priority), Weight: uint16(record.Weight), Port: uint16(record.Port), Host: record.Target}.String()
+	default:
+		return record.Target
+	}
+}
+
+// getRecordData splits an endpoint target into the fields expected by the Linode API. MX and SRV
+// records carry their priority, weight and port in the target, other records use the defaults.
+func getRecordData(recordType linodego.DomainRecordType, target string) (host string, priority, weight, port *int, err error) {
+	switch recordType {
+	case linodego.RecordTypeMX:
+		mx, err := endpoint.NewMXTarget(target)
+		if err != nil {
+			return "", nil, nil, nil, err
+		}
+		priority := int(mx.Priority)
+		return mx.Host, &priority, getWeight(recordType), getPort(), nil
+	case linodego.RecordTypeSRV:
+		srv, err := endpoint.NewSRVTarget(target)
+		if err != nil {
+			return "", nil, nil, nil, err
+		}
+		priority, weight, port := int(srv.Priority), int(srv.Weight), int(srv.Port)
+		return srv.Host, &priority, &weight, &port, nil
+	default:
+		return target, getPriority(), getWeight(recordType), getPort(), nil
+	}
+}
+
 // ApplyChanges applies a given set of changes in a given zone.
 func (p *LinodeProvider) ApplyChanges(ctx context.Context, changes *plan.Changes) error {
 	recordsByZoneID := make(map[string][]linodego.DomainRecord)
@@ -273,7 +321,7 @@ func (p *LinodeProvider) ApplyChanges(ctx context.Context, changes *plan.Changes
 
 	zonesByID := make(map[string]linodego.Domain)
 
-	zoneNameIDMapper := provider.ZoneIDName{}
+	zoneNameIDMapper := p.NewZoneFinder()
 
 	for _, z := range zones {
 		zoneNameIDMapper.Add(strconv.Itoa(z.ID), z.Domain)
@@ -331,15 +379,27 @@ func (p *LinodeProvider) ApplyChanges(ctx context.Context, changes *plan.Changes
 			}
 
 			for _, target := range ep.Targets {
+				host, priority, weight, port, err := getRecordData(recordType, target)
+				if err != nil {
+					log.WithFields(log.Fields{
+						"zoneID":     zoneID,
+						"zoneName":   zone.Domain,
+						"dnsName":    ep.DNSName,
+						"recordType": ep.RecordType,
+						"target":     target,
+					}).Warnf("Skipping invalid target: %v", err)
+					continue
+				}
+
 				linodeCreates = append(linodeCreates, LinodeChangeCreate{
 					Domain: zone,
 					Options: linodego.DomainRecordCreateOptions{
-						Target:   target,
+						Target:   host,
 						Name:     getStrippedRecordName(zone, ep),
 						Type:     recordType,
-						Weight:   getWeight(recordType),
-						Port:     getPort(),
-						Priority: getPriority(),
+						Weight:   weight,
+						Port:     port,
+						Priority: priority,
 						TTLSec:   int(ep.RecordTTL),
 					},
 				})
@@ -381,10 +441,22 @@ func (p *LinodeProvider) ApplyChanges(ctx context.Context, changes *plan.Changes
 			matchedRecordsByTarget := make(map[string]linodego.DomainRecord)
 
 			for _, record := range matchedRecords {
-				matchedRecordsByTarget[record.Target] = record
+				matchedRecordsByTarget[getRecordTarget(record)] = record
 			}
 
 			for _, target := range ep.Targets {
+				host, priority, weight, port, err := getRecordData(recordType, target)
+				if err != nil {
+					log.WithFields(log.Fields{
+						"zoneID":     zoneID,
+						"dnsName":    ep.DNSName,
+						"zoneName":   zone.Domain,
+						"recordType": ep.RecordType,
+						"target":     target,
+					}).Warnf("Skipping invalid target: %v", err)
+					continue
+				}
+
 				if record, ok := matchedRecordsByTarget[target]; ok {
 					log.WithFields(log.Fields{
 						"zoneID":     zoneID,
@@ -398,12 +470,12 @@ func (p *LinodeProvider) ApplyChanges(ctx context.Context, changes *plan.Changes
 						Domain:       zone,
 						DomainRecord: record,
 						Options: linodego.DomainRecordUpdateOptions{
-							Target:   target,
+							Target:   host,
 							Name:     getStrippedRecordName(zone, ep),
 							Type:     recordType,
-							Weight:   getWeight(recordType),
-							Port:     getPort(),
-							Priority: getPriority(),
+							Weight:   weight,
+							Port:     port,
+							Priority: priority,
 							TTLSec:   int(ep.RecordTTL),
 						},
 					})
@@ -422,12 +494,12 @@ func (p *LinodeProvider) ApplyChanges(ctx context.Context, changes *plan.Changes
 					linodeCreates = append(linodeCreates, LinodeChangeCreate{
 						Domain: zone,
 						Options: linodego.DomainRecordCreateOptions{
-							Target:   target,
+							Target:   host,
 							Name:     getStrippedRecordName(zone, ep),
 							Type:     recordType,
-							Weight:   getWeight(recordType),
-							Port:     getPort(),
-							Priority: getPriority(),
+							Weight:   weight,
+							Port:     port,
+							Priority: priority,
 							TTLSec:   int(ep.RecordTTL),
 						},
 					})
@@ -494,7 +566,7 @@ func (p *LinodeProvider) ApplyChanges(ctx context.Context, changes *plan.Changes
 	})
 }
 
-func endpointsByZone(zoneNameIDMapper provider.ZoneIDName, endpoints []*endpoint.Endpoint) map[string][]endpoint.Endpoint {
+func endpointsByZone(zoneNameIDMapper provider.ZoneFinder, endpoints []*endpoint.Endpoint) map[string][]endpoint.Endpoint {
 	endpointsByZone := make(map[string][]endpoint.Endpoint)
 
 	for _, ep := range endpoints {
@@ -521,6 +593,8 @@ func convertRecordType(recordType string) (linodego.DomainRecordType, error) {
 		return linodego.RecordTypeTXT, nil
 	case "SRV":
 		return linodego.RecordTypeSRV, nil
+	case "MX":
+		return linodego.RecordTypeMX, nil
 	case "NS":
 		return linodego.RecordTypeNS, nil
 	default:
@@ -139,6 +139,10 @@ func TestLinodeConvertRecordType(t *testing.T) {
 	require.NoError(t, err)
 	assert.Equal(t, linodego.RecordTypeNS, record)
 
+	record, err = convertRecordType("MX")
+	require.NoError(t, err)
+	assert.Equal(t, linodego.RecordTypeMX, record)
+
 	_, err = convertRecordType("INVALID")
 	require.Error(t, err)
 }
@@ -280,6 +284,62 @@ func TestLinodeRecords(t *testing.T) {
 	assert.Equal(t, expected, actual)
 }
 
+func TestLinodeRecordTarget(t *testing.T) {
+	assert.Equal(t, "10 mail.foo.com", getRecordTarget(linodego.DomainRecord{Type: linodego.RecordTypeMX, Priority: 10, Target: "mail.foo.com"}))
+	assert.Equal(t, "10 5 5060 sip.foo.com", getRecordTarget(linodego.DomainRecord{Type: linodego.RecordTypeSRV, Priority: 10, Weight: 5, Port: 5060, Target: "sip.foo.com"}))
+	assert.Equal(t, "targetFoo", getRecordTarget(linodego.DomainRecord{Type: linodego.RecordTypeA, Priority: 10, Target: "targetFoo"}))
+}
+
+func TestLinodeApplyChangesPriority(t *testing.T) {
+	mockDomainClient := MockDomainClient{}
+
+	provider := &LinodeProvider{
+		Client:       &mockDomainClient,
+		domainFilter: endpoint.NewDomainFilter([]string{}),
+		DryRun:       false,
+	}
+
+	mockDomainClient.On("ListDomains", mock.Anything, mock.Anything).Return([]linodego.Domain{{ID: 1, Domain: "foo.com"}}, nil).Once()
+	mockDomainClient.On("ListDomainRecords", mock.Anything, 1, mock.Anything).Return([]linodego.DomainRecord{}, nil).Once()
+
+	priority, weight, port := 10, 5, 5060
+	mockDomainClient.On(
+		"CreateDomainRecord",
+		mock.Anything,
+		1,
+		linodego.DomainRecordCreateOptions{
+			Type: "MX", Name: "", Target: "mail.foo.com",
+			Priority: &priority, Weight: getWeight(linodego.RecordTypeMX), Port: getPort(), TTLSec: 600,
+		},
+	).Return(&linodego.DomainRecord{}, nil).Once()
+
+	mockDomainClient.On(
+		"CreateDomainRecord",
+		mock.Anything,
+		1,
+		linodego.DomainRecordCreateOptions{
+			Type: "SRV", Name: "_sip._tcp", Target: "sip.foo.com",
+			Priority: &priority, Weight: &weight, Port: &port, TTLSec: 0,
+		},
+	).Return(&linodego.DomainRecord{}, nil).Once()
+
+	err := provider.ApplyChanges(context.Background(), &plan.Changes{
+		Create: []*endpoint.Endpoint{{
+			DNSName:    "foo.com",
+			RecordType: "MX",
+			RecordTTL:  600,
+			Targets:    []string{"10 mail.foo.com"},
+		}, {
+			DNSName:    "_sip._tcp.foo.com",
+			RecordType: "SRV",
+			Targets:    []string{"10 5 5060 sip.foo.com", "invalid"},
+		}},
+	})
+	require.NoError(t, err)
+
+	mockDomainClient.AssertExpectations(t)
+}
+
 func TestLinodeApplyChanges(t *testing.T) {
 	mockDomainClient := MockDomainClient{}
 
@@ -0,0 +1,248 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package metrics
+
+import (
+	"context"
+	"errors"
+	"strconv"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+
+	"sigs.k8s.io/external-dns/endpoint"
+	"sigs.k8s.io/external-dns/pkg/metrics"
+	"sigs.k8s.io/external-dns/plan"
+	"sigs.k8s.io/external-dns/provider"
+)
+
+const (
+	// OperationRecords is the operation of listing the records of a provider.
+	OperationRecords = "records"
+	// OperationApplyChanges is the operation of applying changes to a provider.
+	OperationApplyChanges = "apply_changes"
+	// OperationAdjustEndpoints is the operation of adjusting endpoints to a provider.
+	OperationAdjustEndpoints = "adjust_endpoints"
+
+	// unknownErrorCode is the code of the errors carrying none.
+	unknownErrorCode = "unknown"
+)
+
+var (
+	apiCallsTotal = metrics.NewCounterVecWithOpts(
+		prometheus.CounterOpts{
+			Namespace: "external_dns",
+			Subsystem: "provider",
+			Name:      "api_calls_total",
+			Help:      "Number of calls to the DNS provider, by provider, operation and result.",
+		},
+		[]string{"provider", "operation", "result"},
+	)
+	applyDuration = metrics.NewHistogramVecWithOpts(
+		prometheus.HistogramOpts{
+			Namespace: "external_dns",
+			Subsystem: "provider",
+			Name:      "apply_duration_seconds",
+			Help:      "Duration in seconds of applying changes to the DNS provider.",
+			Buckets:   []float64{0.1, 0.5, 1, 2.5, 5, 10, 30, 60, 120, 300},
+		},
+		[]string{"provider"},
+	)
+	recordsManaged = metrics.NewGaugeVecWithOpts(
+		prometheus.GaugeOpts{
+			Namespace: "external_dns",
+			Subsystem: "provider",
+			Name:      "records_managed",
+			Help:      "Number of records listed by the DNS provider, by provider and record type.",
+		},
+		[]string{"provider", "record_type"},
+	)
+	errorsByCode = metrics.NewCounterVecWithOpts(
+		prometheus.CounterOpts{
+			Namespace: "external_dns",
+			Subsystem: "provider",
+			Name:      "errors_by_code_total",
+			Help:      "Number of errors returned by the DNS provider, by provider, operation and error code.",
+		},
+		[]string{"provider", "operation", "code"},
+	)
+)
+
+func init() {
+	metrics.RegisterMetric.MustRegister(apiCallsTotal)
+	metrics.RegisterMetric.MustRegister(applyDuration)
+	metrics.RegisterMetric.MustRegister(recordsManaged)
+	metrics.RegisterMetric.MustRegister(errorsByCode)
+}
+
+// ObserveAPICall counts a call to the DNS provider and, when it failed, its error by code.
+// Providers can use it for the calls to their API that the InstrumentedProvider does not see.
+func ObserveAPICall(providerName, operation string, err error) {
+	if err == nil {
+		apiCallsTotal.CounterVec.WithLabelValues(providerName, operation, "success").Inc()
+		return
+	}
+	apiCallsTotal.CounterVec.WithLabelValues(providerName, operation, "error").Inc()
+	errorsByCode.CounterVec.WithLabelValues(providerName, operation, ErrorCode(err)).Inc()
+}
+
+// ObserveApplyDuration records the time spent applying changes to the DNS provider since start.
+func ObserveApplyDuration(providerName string, start time.Time) {
+	applyDuration.HistogramVec.WithLabelValues(providerName).Observe(time.Since(start).Seconds())
+}
+
+// SetRecordsManaged sets the number of records listed by the DNS provider, by record type.
+func SetRecordsManaged(providerName string, endpoints []*endpoint.Endpoint) {
+	counts := map[string]int{}
+	countRecords(counts, endpoints)
+	setRecordsManagedCounts(providerName, counts)
+}
+
+// countRecords adds the number of records of the endpoints to counts, by record type.
+func countRecords(counts map[string]int, endpoints []*endpoint.Endpoint) {
+	for _, ep := range endpoints {
+		counts[ep.RecordType] += len(ep.Targets)
+	}
+}
+
+func setRecordsManagedCounts(providerName string, counts map[string]int) {
+	recordsManaged.GaugeVec.DeletePartialMatch(prometheus.Labels{"provider": providerName})
+	for recordType, count := range counts {
+		recordsManaged.GaugeVec.WithLabelValues(providerName, recordType).Set(float64(count))
+	}
+}
+
+// ErrorCode returns a low cardinality code for an error of a DNS provider: the API error code or the HTTP
+// status code it carries, whether it timed out or got canceled, or "unknown".
+func ErrorCode(err error) string {
+	var apiErr interface{ ErrorCode() string }
+	if errors.As(err, &apiErr) && apiErr.ErrorCode() != "" {
+		return apiErr.ErrorCode()
+	}
+	var httpErr interface{ HTTPStatusCode() int }
+	if errors.As(err, &httpErr) && httpErr.HTTPStatusCode() != 0 {
+		return strconv.Itoa(httpErr.HTTPStatusCode())
+	}
+	switch {
+	case errors.Is(err, context.DeadlineExceeded):
+		return "timeout"
+	case errors.Is(err, context.Canceled):
+		return "canceled"
+	}
+	return unknownErrorCode
+}
+
+// InstrumentedProvider records the standard provider metrics for the calls to the wrapped provider.
+type InstrumentedProvider struct {
+	provider.Provider
+	name string
+	// pageCounts counts the records of the pages listed so far by type, reported once the last page is listed.
+	pageCounts map[string]int
+}
+
+// NewInstrumentedProvider returns an InstrumentedProvider recording the metrics of the provider under the given name.
+func NewInstrumentedProvider(p provider.Provider, name string) *InstrumentedProvider {
+	return &InstrumentedProvider{Provider: p, name: name}
+}
+
+// Records lists the records of the wrapped provider.
+func (p *InstrumentedProvider) Records(ctx context.Context) ([]*endpoint.Endpoint, error) {
+	endpoints, err := p.Provider.Records(ctx)
+	ObserveAPICall(p.name, OperationRecords, err)
+	if err == nil {
+		SetRecordsManaged(p.name, endpoints)
+	}
+	return endpoints, err
+}
+
+// RecordsPage lists a page of the records of the wrapped provider, or all of them at once when it does not
+// list them page by page.
+func (p *InstrumentedProvider) RecordsPage(ctx context.Context, token string) (*provider.RecordsPage, error) {
+	pp, ok := p.Provider.(provider.PaginatedProvider)
+	if !ok {
+		endpoints, err := p.Records(ctx)
+		if err != nil {
+			return nil, err
+		}
+		return &provider.RecordsPage{Endpoints: endpoints}, nil
+	}
+	page, err := pp.RecordsPage(ctx, token)
+	ObserveAPICall(p.name, OperationRecords, err)
+	if err != nil {
+		return nil, err
+	}
+	if token == "" || p.pageCounts == nil {
+		p.pageCounts = map[string]int{}
+	}
+	countRecords(p.pageCounts, page.Endpoints)
+	if page.NextToken == "" {
+		setRecordsManagedCounts(p.name, p.pageCounts)
+		p.pageCounts = nil
+	}
+	return page, nil
+}
+
+// ApplyChanges applies the changes to the wrapped provider.
+func (p *InstrumentedProvider) ApplyChanges(ctx context.Context, changes *plan.Changes) error {
+	start := time.Now()
+	err := p.Provider.ApplyChanges(ctx, changes)
+	ObserveApplyDuration(p.name, start)
+	ObserveAPICall(p.name, OperationApplyChanges, err)
+	return err
+}
+
+// AdjustEndpoints adjusts the endpoints with the wrapped provider.
+func (p *InstrumentedProvider) AdjustEndpoints(endpoints []*endpoint.Endpoint) ([]*endpoint.Endpoint, error) {
+	adjusted, err := p.Provider.AdjustEndpoints(endpoints)
+	ObserveAPICall(p.name, OperationAdjustEndpoints, err)
+	return adjusted, err
+}
+
+// PersistsLabels reports whether the wrapped provider stores endpoint labels natively.
+func (p *InstrumentedProvider) PersistsLabels() bool {
+	return provider.PersistsLabels(p.Provider)
+}
+
+// SupportsViews reports whether the wrapped provider selects the DNS view of records.
+func (p *InstrumentedProvider) SupportsViews() bool {
+	return provider.SupportsViews(p.Provider)
+}
+
+// SupportsAlias reports whether the wrapped provider creates alias records.
+func (p *InstrumentedProvider) SupportsAlias() bool {
+	return provider.SupportsAlias(p.Provider)
+}
+
+// TXTEncoding returns the encoding of the TXT targets of the wrapped provider.
+func (p *InstrumentedProvider) TXTEncoding() string {
+	return provider.TXTEncoding(p.Provider)
+}
+
+// ZoneVersions returns the version of the zones read by the wrapped provider.
+func (p *InstrumentedProvider) ZoneVersions() map[string]string {
+	return provider.ZoneVersions(p.Provider)
+}
+
+// PropertyComparator returns the comparator of the provider specific properties of the wrapped provider.
+func (p *InstrumentedProvider) PropertyComparator() plan.PropertyComparator {
+	return provider.PropertyComparator(p.Provider)
+}
+
+// SetZoneCollisions gives the ZoneCollisions to the wrapped provider.
+func (p *InstrumentedProvider) SetZoneCollisions(c *provider.ZoneCollisions) {
+	provider.SetZoneCollisions(p.Provider, c)
+}
@@ -0,0 +1,234 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package metrics
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"sigs.k8s.io/external-dns/endpoint"
+	"sigs.k8s.io/external-dns/plan"
+	"sigs.k8s.io/external-dns/provider"
+)
+
+type fakeProvider struct {
+	provider.BaseProvider
+	records []*endpoint.Endpoint
+	err     error
+}
+
+func (p *fakeProvider) Records(_ context.Context) ([]*endpoint.Endpoint, error) {
+	return p.records, p.err
+}
+
+func (p *fakeProvider) ApplyChanges(_ context.Context, _ *plan.Changes) error {
+	return p.err
+}
+
+type apiError struct{ code string }
+
+func (e *apiError) Error() string     { return "api error" }
+func (e *apiError) ErrorCode() string { return e.code }
+
+type httpError struct{ code int }
+
+func (e *httpError) Error() string       { return "http error" }
+func (e *httpError) HTTPStatusCode() int { return e.code }
+
+func TestErrorCode(t *testing.T) {
+	for _, tt := range []struct {
+		err      error
+		expected string
+	}{
+		{err: &apiError{code: "Throttling"}, expected: "Throttling"},
+		{err: fmt.Errorf("listing records: %w", &apiError{code: "NoSuchHostedZone"}), expected: "NoSuchHostedZone"},
+		{err: provider.NewSoftError(&httpError{code: 503}), expected: "503"},
+		{err: fmt.Errorf("listing records: %w", context.DeadlineExceeded), expected: "timeout"},
+		{err: context.Canceled, expected: "canceled"},
+		{err: errors.New("boom"), expected: "unknown"},
+	} {
+		assert.Equal(t, tt.expected, ErrorCode(tt.err), tt.err.Error())
+	}
+}
+
+func TestInstrumentedProvider(t *testing.T) {
+	fake := &fakeProvider{records: []*endpoint.Endpoint{
+		endpoint.NewEndpoint("a.example.org", endpoint.RecordTypeA, "1.2.3.4", "5.6.7.8"),
+		endpoint.NewEndpoint("b.example.org", endpoint.RecordTypeA, "1.2.3.4"),
+		endpoint.NewEndpoint("c.example.org", endpoint.RecordTypeCNAME, "a.example.org"),
+	}}
+	p := NewInstrumentedProvider(fake, "instrumented-test")
+	ctx := context.Background()
+
+	_, err := p.Records(ctx)
+	require.NoError(t, err)
+	assert.InDelta(t, 1, testutil.ToFloat64(apiCallsTotal.CounterVec.WithLabelValues("instrumented-test", OperationRecords, "success")), 0)
+	assert.InDelta(t, 3, testutil.ToFloat64(recordsManaged.GaugeVec.WithLabelValues("instrumented-test", endpoint.RecordTypeA)), 0)
+	assert.InDelta(t, 1, testutil.ToFloat64(recordsManaged.GaugeVec.WithLabelValues("instrumented-test", endpoint.RecordTypeCNAME)), 0)
+
+	// record types that disappear are not reported anymore
+	fake.records = fake.records[:1]
+	_, err = p.Records(ctx)
+	require.NoError(t, err)
+	assert.InDelta(t, 2, testutil.ToFloat64(recordsManaged.GaugeVec.WithLabelValues("instrumented-test", endpoint.RecordTypeA)), 0)
+	assert.Equal(t, 1, testutil.CollectAndCount(recordsManaged.GaugeVec))
+
+	fake.err = &httpError{code: 429}
+	require.Error(t, p.ApplyChanges(ctx, &plan.Changes{}))
+	assert.InDelta(t, 1, testutil.ToFloat64(apiCallsTotal.CounterVec.WithLabelValues("instrumented-test", OperationApplyChanges, "error")), 0)
+	assert.InDelta(t, 1, testutil.ToFloat64(errorsByCode.CounterVec.WithLabelValues("instrumented-test", OperationApplyChanges, "429")), 0)
+	assert.Equal(t, 1, testutil.CollectAndCount(applyDuration.HistogramVec))
+
+	// failing to list the records keeps the last known counts
+	_, err = p.Records(ctx)
+	require.Error(t, err)
+	assert.InDelta(t, 2, testutil.ToFloat64(recordsManaged.GaugeVec.WithLabelValues("instrumented-test", endpoint.RecordTypeA)), 0)
+
+	_, err = p.AdjustEndpoints(nil)
+	require.NoError(t, err)
+	assert.InDelta(t, 1, testutil.ToFloat64(apiCallsTotal.CounterVec.WithLabelValues("instrumented-test", OperationAdjustEndpoints, "success")), 0)
+}
+
+type paginatedProvider struct {
+	fakeProvider
+	pages map[string]*provider.RecordsPage
+}
+
+func (p *paginatedProvider) RecordsPage(_ context.Context, token string) (*provider.RecordsPage, error) {
+	return p.pages[token], p.err
+}
+
+func TestInstrumentedProviderRecordsPage(t *testing.T) {
+	fake := &paginatedProvider{pages: map[string]*provider.RecordsPage{
+		"": {Endpoints: []*endpoint.Endpoint{
+			endpoint.NewEndpoint("a.example.org", endpoint.RecordTypeA, "1.2.3.4", "5.6.7.8"),
+		}, NextToken: "next"},
+		"next": {Endpoints: []*endpoint.Endpoint{
+			endpoint.NewEndpoint("b.example.org", endpoint.RecordTypeA, "1.2.3.4"),
+			endpoint.NewEndpoint("c.example.org", endpoint.RecordTypeCNAME, "a.example.org"),
+		}},
+	}}
+	p := NewInstrumentedProvider(fake, "paginated-test")
+
+	var records []*endpoint.Endpoint
+	require.NoError(t, provider.EachRecordsPage(context.Background(), p, func(page []*endpoint.Endpoint) error {
+		records = append(records, page...)
+		return nil
+	}))
+	assert.Len(t, records, 3)
+	assert.InDelta(t, 2, testutil.ToFloat64(apiCallsTotal.CounterVec.WithLabelValues("paginated-test", OperationRecords, "success")), 0)
+	assert.InDelta(t, 3, testutil.ToFloat64(recordsManaged.GaugeVec.WithLabelValues("paginated-test", endpoint.RecordTypeA)), 0)
+	assert.InDelta(t, 1, testutil.ToFloat64(recordsManaged.GaugeVec.WithLabelValues("paginated-test", endpoint.RecordTypeCNAME)), 0)
+
+	// providers not listing their records page by page list them all at once
+	page, err := NewInstrumentedProvider(&fakeProvider{records: records}, "instrumented-test").RecordsPage(context.Background(), "")
+	require.NoError(t, err)
+	assert.Equal(t, &provider.RecordsPage{Endpoints: records}, page)
+}
+
+type labelPersistingProvider struct {
+	fakeProvider
+}
+
+func (p *labelPersistingProvider) PersistsLabels() bool { return true }
+
+func TestInstrumentedProviderPersistsLabels(t *testing.T) {
+	assert.False(t, provider.PersistsLabels(NewInstrumentedProvider(&fakeProvider{}, "instrumented-test")))
+	assert.True(t, provider.PersistsLabels(NewInstrumentedProvider(&labelPersistingProvider{}, "instrumented-test")))
+	assert.True(t, provider.PersistsLabels(provider.NewCachedProvider(NewInstrumentedProvider(&labelPersistingProvider{}, "instrumented-test"), 0)))
+}
+
+// capableProvider implements every optional capability a provider can have.
+type capableProvider struct {
+	paginatedProvider
+	zoneCollisions *provider.ZoneCollisions
+}
+
+func (p *capableProvider) PersistsLabels() bool { return true }
+func (p *capableProvider) SupportsViews() bool  { return true }
+func (p *capableProvider) SupportsAlias() bool  { return true }
+func (p *capableProvider) TXTEncoding() string  { return "base64" }
+
+func (p *capableProvider) ZoneVersions() map[string]string {
+	return map[string]string{"example.org": "42"}
+}
+
+func (p *capableProvider) PropertyComparator() plan.PropertyComparator {
+	return func(_, _, _ string) bool { return true }
+}
+
+func (p *capableProvider) SetZoneCollisions(c *provider.ZoneCollisions) {
+	p.zoneCollisions = c
+}
+
+// TestWrappersForwardCapabilities checks that the capabilities of the providers survive the wrapping of
+// InstrumentedProvider and CachedProvider. BatchProvider is left out: it is only used by the providers to
+// apply their own changes.
+func TestWrappersForwardCapabilities(t *testing.T) {
+	for name, wrap := range map[string]func(provider.Provider) provider.Provider{
+		"instrumented": func(p provider.Provider) provider.Provider {
+			return NewInstrumentedProvider(p, "capabilities-test")
+		},
+		"cached": func(p provider.Provider) provider.Provider {
+			return provider.NewCachedProvider(p, time.Minute)
+		},
+		"cached instrumented": func(p provider.Provider) provider.Provider {
+			return provider.NewCachedProvider(NewInstrumentedProvider(p, "capabilities-test"), time.Minute)
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			capable := &capableProvider{paginatedProvider: paginatedProvider{pages: map[string]*provider.RecordsPage{
+				"": {Endpoints: []*endpoint.Endpoint{endpoint.NewEndpoint("a.example.org", endpoint.RecordTypeA, "1.2.3.4")}, NextToken: "next"},
+			}}}
+			p := wrap(capable)
+
+			assert.True(t, provider.PersistsLabels(p))
+			assert.True(t, provider.SupportsViews(p))
+			assert.True(t, provider.SupportsAlias(p))
+			assert.Equal(t, "base64", provider.TXTEncoding(p))
+			assert.Equal(t, map[string]string{"example.org": "42"}, provider.ZoneVersions(p))
+			require.NotNil(t, provider.PropertyComparator(p))
+			assert.True(t, provider.PropertyComparator(p)("name", "a", "b"))
+
+			pp, ok := p.(provider.PaginatedProvider)
+			require.True(t, ok)
+			page, err := pp.RecordsPage(context.Background(), "")
+			require.NoError(t, err)
+			assert.Equal(t, "next", page.NextToken)
+
+			collisions := provider.NewZoneCollisions(provider.ZoneCollisionFail)
+			provider.SetZoneCollisions(p, collisions)
+			assert.Same(t, collisions, capable.zoneCollisions)
+
+			// the capabilities the wrapped provider lacks are not made up
+			plain := wrap(&fakeProvider{})
+			assert.False(t, provider.PersistsLabels(plain))
+			assert.False(t, provider.SupportsViews(plain))
+			assert.False(t, provider.SupportsAlias(plain))
+			assert.Empty(t, provider.TXTEncoding(plain))
+			assert.Nil(t, provider.ZoneVersions(plain))
+			assert.Nil(t, provider.PropertyComparator(plain))
+		})
+	}
+}
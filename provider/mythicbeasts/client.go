@@ -0,0 +1,164 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package mythicbeasts
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+
+	"golang.org/x/oauth2"
+	"golang.org/x/oauth2/clientcredentials"
+
+	"sigs.k8s.io/external-dns/pkg/apis/externaldns"
+	extdnshttp "sigs.k8s.io/external-dns/pkg/http"
+)
+
+const (
+	defaultAPIURL  = "https://api.mythic-beasts.com/dns/v2"
+	defaultAuthURL = "https://auth.mythic-beasts.com/login"
+	// previewParam makes the API validate a change and report its outcome without applying it.
+	previewParam = "dry-run"
+)
+
+// Record is a DNS record of a Mythic Beasts zone.
+type Record struct {
+	Host        string `json:"host"`
+	Type        string `json:"type"`
+	TTL         int    `json:"ttl,omitempty"`
+	Data        string `json:"data"`
+	MXPriority  *int   `json:"mx_priority,omitempty"`
+	SRVPriority *int   `json:"srv_priority,omitempty"`
+	SRVWeight   *int   `json:"srv_weight,omitempty"`
+	SRVPort     *int   `json:"srv_port,omitempty"`
+}
+
+// Client is the subset of the Mythic Beasts DNS API v2 used by the provider.
+// Write operations only validate the change when preview is set.
+type Client interface {
+	ListZones(ctx context.Context) ([]string, error)
+	ListRecords(ctx context.Context, zone string) ([]Record, error)
+	AddRecords(ctx context.Context, zone string, records []Record, preview bool) error
+	ReplaceRecords(ctx context.Context, zone, host, recordType string, records []Record, preview bool) error
+	DeleteRecords(ctx context.Context, zone, host, recordType string, preview bool) error
+}
+
+type client struct {
+	apiURL     string
+	httpClient *http.Client
+}
+
+// NewClient returns a client authenticating with the given API key.
+func NewClient(ctx context.Context, keyID, secret string) Client {
+	cfg := clientcredentials.Config{
+		ClientID:     keyID,
+		ClientSecret: secret,
+		TokenURL:     defaultAuthURL,
+	}
+	return &client{
+		apiURL:     defaultAPIURL,
+		httpClient: cfg.Client(context.WithValue(ctx, oauth2.HTTPClient, extdnshttp.NewClient("mythicbeasts"))),
+	}
+}
+
+type recordsBody struct {
+	Records []Record `json:"records"`
+}
+
+func (c *client) do(ctx context.Context, method, path string, preview bool, body any, result any) error {
+	u := c.apiURL + path
+	if preview {
+		u += "?" + url.Values{previewParam: {"true"}}.Encode()
+	}
+
+	var reader io.Reader
+	if body != nil {
+		data, err := json.Marshal(body)
+		if err != nil {
+			return err
+		}
+		reader = bytes.NewReader(data)
+	}
+	req, err := http.NewRequestWithContext(ctx, method, u, reader)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Accept", "application/json")
+	req.Header.Set("User-Agent", externaldns.UserAgent())
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		var apiErr struct {
+			Error string `json:"error"`
+		}
+		_ = json.NewDecoder(resp.Body).Decode(&apiErr)
+		if apiErr.Error != "" {
+			return fmt.Errorf("%s %s: %s (status %d)", method, path, apiErr.Error, resp.StatusCode)
+		}
+		return fmt.Errorf("%s %s: unexpected status %s", method, path, resp.Status)
+	}
+	if result == nil {
+		return nil
+	}
+	return json.NewDecoder(resp.Body).Decode(result)
+}
+
+func (c *client) ListZones(ctx context.Context) ([]string, error) {
+	var result struct {
+		Zones []string `json:"zones"`
+	}
+	if err := c.do(ctx, http.MethodGet, "/zones", false, nil, &result); err != nil {
+		return nil, err
+	}
+	return result.Zones, nil
+}
+
+func (c *client) ListRecords(ctx context.Context, zone string) ([]Record, error) {
+	var result recordsBody
+	if err := c.do(ctx, http.MethodGet, "/zones/"+url.PathEscape(zone)+"/records", false, nil, &result); err != nil {
+		return nil, err
+	}
+	return result.Records, nil
+}
+
+func (c *client) AddRecords(ctx context.Context, zone string, records []Record, preview bool) error {
+	return c.do(ctx, http.MethodPost, "/zones/"+url.PathEscape(zone)+"/records", preview, recordsBody{Records: records}, nil)
+}
+
+func (c *client) ReplaceRecords(ctx context.Context, zone, host, recordType string, records []Record, preview bool) error {
+	return c.do(ctx, http.MethodPut, recordsPath(zone, host, recordType), preview, recordsBody{Records: records}, nil)
+}
+
+func (c *client) DeleteRecords(ctx context.Context, zone, host, recordType string, preview bool) error {
+	return c.do(ctx, http.MethodDelete, recordsPath(zone, host, recordType), preview, nil, nil)
+}
+
+func recordsPath(zone, host, recordType string) string {
+	return "/zones/" + url.PathEscape(zone) + "/records/" + url.PathEscape(host) + "/" + url.PathEscape(recordType)
+}
@@ -0,0 +1,71 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package mythicbeasts
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestClient(t *testing.T) {
+	type request struct {
+		method, uri, body string
+	}
+	var requests []request
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		requests = append(requests, request{method: r.Method, uri: r.URL.RequestURI(), body: string(body)})
+		switch {
+		case r.URL.Path == "/zones":
+			_, _ = w.Write([]byte(`{"zones":["example.com"]}`))
+		case r.Method == http.MethodGet:
+			_, _ = w.Write([]byte(`{"records":[{"host":"@","type":"MX","ttl":3600,"data":"mail.example.com","mx_priority":10}]}`))
+		case r.Method == http.MethodDelete:
+			w.WriteHeader(http.StatusNotFound)
+			_, _ = w.Write([]byte(`{"error":"no matching records"}`))
+		default:
+			_, _ = w.Write([]byte(`{"message":"1 record added"}`))
+		}
+	}))
+	defer server.Close()
+
+	c := &client{apiURL: server.URL, httpClient: server.Client()}
+
+	zones, err := c.ListZones(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, []string{"example.com"}, zones)
+
+	records, err := c.ListRecords(context.Background(), "example.com")
+	require.NoError(t, err)
+	assert.Equal(t, []Record{{Host: "@", Type: "MX", TTL: 3600, Data: "mail.example.com", MXPriority: intPtr(10)}}, records)
+
+	require.NoError(t, c.AddRecords(context.Background(), "example.com", []Record{{Host: "www", Type: "A", Data: "1.2.3.4"}}, true))
+	assert.Equal(t, request{method: http.MethodPost, uri: "/zones/example.com/records?dry-run=true", body: `{"records":[{"host":"www","type":"A","data":"1.2.3.4"}]}`}, requests[2])
+
+	require.NoError(t, c.ReplaceRecords(context.Background(), "example.com", "www", "A", []Record{{Host: "www", Type: "A", TTL: 300, Data: "1.2.3.4"}}, false))
+	assert.Equal(t, request{method: http.MethodPut, uri: "/zones/example.com/records/www/A", body: `{"records":[{"host":"www","type":"A","ttl":300,"data":"1.2.3.4"}]}`}, requests[3])
+
+	err = c.DeleteRecords(context.Background(), "example.com", "www", "A", false)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "no matching records")
+}
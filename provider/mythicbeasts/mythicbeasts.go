@@ -0,0 +1,251 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package mythicbeasts
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+
+	log "github.com/sirupsen/logrus"
+
+	"sigs.k8s.io/external-dns/endpoint"
+	"sigs.k8s.io/external-dns/plan"
+	"sigs.k8s.io/external-dns/provider"
+)
+
+// apexHost is the host Mythic Beasts gives to records at the apex of a zone.
+const apexHost = "@"
+
+// MythicBeastsProvider is an implementation of Provider for Mythic Beasts DNS.
+type MythicBeastsProvider struct {
+	provider.BaseProvider
+	client       Client
+	domainFilter endpoint.DomainFilter
+	dryRun       bool
+}
+
+// NewMythicBeastsProvider initializes a new Mythic Beasts DNS based Provider.
+// The API key is read from the MYTHICBEASTS_KEY_ID and MYTHICBEASTS_SECRET env vars.
+func NewMythicBeastsProvider(ctx context.Context, domainFilter endpoint.DomainFilter, dryRun bool) (*MythicBeastsProvider, error) {
+	keyID, secret := os.Getenv("MYTHICBEASTS_KEY_ID"), os.Getenv("MYTHICBEASTS_SECRET")
+	if keyID == "" || secret == "" {
+		return nil, fmt.Errorf("no mythic beasts api key provided, you must set the MYTHICBEASTS_KEY_ID and MYTHICBEASTS_SECRET env vars")
+	}
+
+	return &MythicBeastsProvider{
+		client:       NewClient(ctx, keyID, secret),
+		domainFilter: domainFilter,
+		dryRun:       dryRun,
+	}, nil
+}
+
+// Records returns the list of records in all discovered zones.
+func (p *MythicBeastsProvider) Records(ctx context.Context) ([]*endpoint.Endpoint, error) {
+	zones, err := p.zones(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var endpoints []*endpoint.Endpoint
+	for _, zone := range zones {
+		records, err := p.client.ListRecords(ctx, zone)
+		if err != nil {
+			return nil, provider.NewSoftError(fmt.Errorf("failed to list records of zone %s: %w", zone, err))
+		}
+
+		byKey := map[endpoint.EndpointKey]*endpoint.Endpoint{}
+		for _, record := range records {
+			if !p.SupportedRecordType(record.Type) {
+				continue
+			}
+			key := endpoint.EndpointKey{DNSName: fqdn(record.Host, zone), RecordType: record.Type}
+			if ep, ok := byKey[key]; ok {
+				ep.Targets = append(ep.Targets, target(record))
+				continue
+			}
+			ep := endpoint.NewEndpointWithTTL(key.DNSName, key.RecordType, endpoint.TTL(record.TTL), target(record))
+			byKey[key] = ep
+			endpoints = append(endpoints, ep)
+		}
+	}
+
+	return endpoints, nil
+}
+
+// SupportedRecordType returns true if the record type is supported by the provider
+func (p *MythicBeastsProvider) SupportedRecordType(recordType string) bool {
+	switch recordType {
+	case endpoint.RecordTypeMX:
+		return true
+	default:
+		return provider.SupportedRecordType(recordType)
+	}
+}
+
+// ApplyChanges applies the given changes, one API call per record set. In dry-run mode, changes
+// are still sent to the API in preview mode so that they are validated without being applied.
+func (p *MythicBeastsProvider) ApplyChanges(ctx context.Context, changes *plan.Changes) error {
+	zones, err := p.zones(ctx)
+	if err != nil {
+		return err
+	}
+	zoneNameIDMapper := p.NewZoneFinder()
+	for _, zone := range zones {
+		zoneNameIDMapper.Add(zone, zone)
+	}
+
+	for _, ep := range changes.Delete {
+		zone, _ := zoneNameIDMapper.FindZone(ep.DNSName)
+		if zone == "" {
+			log.Debugf("Skipping record %s because no zone matching record DNS Name was detected", ep.DNSName)
+			continue
+		}
+		host := relativeHost(ep.DNSName, zone)
+		p.logChange("Deleting %s record %s in zone %s", ep.RecordType, ep.DNSName, zone)
+		if err := p.client.DeleteRecords(ctx, zone, host, ep.RecordType, p.dryRun); err != nil {
+			return provider.NewSoftError(fmt.Errorf("failed to delete %s record %s: %w", ep.RecordType, ep.DNSName, err))
+		}
+	}
+
+	for _, ep := range changes.UpdateNew {
+		zone, _ := zoneNameIDMapper.FindZone(ep.DNSName)
+		if zone == "" {
+			log.Debugf("Skipping record %s because no zone matching record DNS Name was detected", ep.DNSName)
+			continue
+		}
+		records, err := newRecords(zone, ep)
+		if err != nil {
+			log.Warnf("Skipping %s record %s: %v", ep.RecordType, ep.DNSName, err)
+			continue
+		}
+		p.logChange("Updating %s record %s in zone %s to %s", ep.RecordType, ep.DNSName, zone, ep.Targets)
+		if err := p.client.ReplaceRecords(ctx, zone, relativeHost(ep.DNSName, zone), ep.RecordType, records, p.dryRun); err != nil {
+			return provider.NewSoftError(fmt.Errorf("failed to update %s record %s: %w", ep.RecordType, ep.DNSName, err))
+		}
+	}
+
+	creates := map[string][]Record{}
+	var zoneOrder []string
+	for _, ep := range changes.Create {
+		zone, _ := zoneNameIDMapper.FindZone(ep.DNSName)
+		if zone == "" {
+			log.Debugf("Skipping record %s because no zone matching record DNS Name was detected", ep.DNSName)
+			continue
+		}
+		records, err := newRecords(zone, ep)
+		if err != nil {
+			log.Warnf("Skipping %s record %s: %v", ep.RecordType, ep.DNSName, err)
+			continue
+		}
+		p.logChange("Creating %s record %s in zone %s with targets %s", ep.RecordType, ep.DNSName, zone, ep.Targets)
+		if _, ok := creates[zone]; !ok {
+			zoneOrder = append(zoneOrder, zone)
+		}
+		creates[zone] = append(creates[zone], records...)
+	}
+	for _, zone := range zoneOrder {
+		if err := p.client.AddRecords(ctx, zone, creates[zone], p.dryRun); err != nil {
+			return provider.NewSoftError(fmt.Errorf("failed to create records in zone %s: %w", zone, err))
+		}
+	}
+
+	return nil
+}
+
+// logChange logs a change, flagging the ones only sent to the API for validation.
+func (p *MythicBeastsProvider) logChange(format string, args ...any) {
+	if p.dryRun {
+		format = "[preview] " + format
+	}
+	log.Infof(format, args...)
+}
+
+func (p *MythicBeastsProvider) zones(ctx context.Context) ([]string, error) {
+	all, err := p.client.ListZones(ctx)
+	if err != nil {
+		return nil, provider.NewSoftError(fmt.Errorf("failed to list zones: %w", err))
+	}
+
+	var zones []string
+	for _, zone := range all {
+		if p.domainFilter.Match(zone) {
+			zones = append(zones, zone)
+		}
+	}
+	return zones, nil
+}
+
+// newRecords returns the Mythic Beasts records for all the targets of an endpoint.
+func newRecords(zone string, ep *endpoint.Endpoint) ([]Record, error) {
+	records := make([]Record, 0, len(ep.Targets))
+	for _, t := range ep.Targets {
+		record := Record{
+			Host: relativeHost(ep.DNSName, zone),
+			Type: ep.RecordType,
+			Data: t,
+		}
+		if ep.RecordTTL.IsConfigured() {
+			record.TTL = int(ep.RecordTTL)
+		}
+		switch ep.RecordType {
+		case endpoint.RecordTypeMX:
+			mx, err := endpoint.NewMXTarget(t)
+			if err != nil {
+				return nil, err
+			}
+			priority := int(mx.Priority)
+			record.Data, record.MXPriority = mx.Host, &priority
+		case endpoint.RecordTypeSRV:
+			srv, err := endpoint.NewSRVTarget(t)
+			if err != nil {
+				return nil, err
+			}
+			priority, weight, port := int(srv.Priority), int(srv.Weight), int(srv.Port)
+			record.Data, record.SRVPriority, record.SRVWeight, record.SRVPort = srv.Host, &priority, &weight, &port
+		}
+		records = append(records, record)
+	}
+	return records, nil
+}
+
+// target returns the data of a record in the RDATA form used by endpoints.
+func target(record Record) string {
+	switch {
+	case record.Type == endpoint.RecordTypeMX && record.MXPriority != nil:
+		return endpoint.MXTarget{Priority: uint16(*record.MXPriority), Host: record.Data}.String()
+	case record.Type == endpoint.RecordTypeSRV && record.SRVPriority != nil && record.SRVWeight != nil && record.SRVPort != nil:
+		return endpoint.SRVTarget{Priority: uint16(*record.SRVPriority), Weight: uint16(*record.SRVWeight), Port: uint16(*record.SRVPort), Host: record.Data}.String()
+	default:
+		return record.Data
+	}
+}
+
+func fqdn(host, zone string) string {
+	if host == "" || host == apexHost {
+		return zone
+	}
+	return host + "." + zone
+}
+
+func relativeHost(dnsName, zone string) string {
+	if dnsName == zone {
+		return apexHost
+	}
+	return strings.TrimSuffix(dnsName, "."+zone)
+}
@@ -0,0 +1,172 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package mythicbeasts
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"sigs.k8s.io/external-dns/endpoint"
+	"sigs.k8s.io/external-dns/internal/testutils"
+	"sigs.k8s.io/external-dns/plan"
+)
+
+type call struct {
+	method     string
+	zone       string
+	host       string
+	recordType string
+	records    []Record
+	preview    bool
+}
+
+type mockClient struct {
+	zones   []string
+	records map[string][]Record
+	calls   []call
+}
+
+func (m *mockClient) ListZones(_ context.Context) ([]string, error) {
+	return m.zones, nil
+}
+
+func (m *mockClient) ListRecords(_ context.Context, zone string) ([]Record, error) {
+	return m.records[zone], nil
+}
+
+func (m *mockClient) AddRecords(_ context.Context, zone string, records []Record, preview bool) error {
+	m.calls = append(m.calls, call{method: "add", zone: zone, records: records, preview: preview})
+	return nil
+}
+
+func (m *mockClient) ReplaceRecords(_ context.Context, zone, host, recordType string, records []Record, preview bool) error {
+	m.calls = append(m.calls, call{method: "replace", zone: zone, host: host, recordType: recordType, records: records, preview: preview})
+	return nil
+}
+
+func (m *mockClient) DeleteRecords(_ context.Context, zone, host, recordType string, preview bool) error {
+	m.calls = append(m.calls, call{method: "delete", zone: zone, host: host, recordType: recordType, preview: preview})
+	return nil
+}
+
+func intPtr(i int) *int {
+	return &i
+}
+
+func newMockClient() *mockClient {
+	return &mockClient{
+		zones: []string{"example.com", "example.org"},
+		records: map[string][]Record{
+			"example.com": {
+				{Host: "@", Type: "A", TTL: 3600, Data: "1.2.3.4"},
+				{Host: "www", Type: "A", TTL: 300, Data: "1.2.3.4"},
+				{Host: "www", Type: "A", TTL: 300, Data: "5.6.7.8"},
+				{Host: "@", Type: "MX", TTL: 3600, Data: "mail.example.com", MXPriority: intPtr(10)},
+				{Host: "_sip._tcp", Type: "SRV", TTL: 3600, Data: "sip.example.com", SRVPriority: intPtr(10), SRVWeight: intPtr(20), SRVPort: intPtr(5060)},
+				{Host: "@", Type: "SOA", TTL: 3600, Data: "ns1.mythic-beasts.com"},
+			},
+			"example.org": {
+				{Host: "www", Type: "A", TTL: 300, Data: "9.9.9.9"},
+			},
+		},
+	}
+}
+
+func TestMythicBeastsRecords(t *testing.T) {
+	p := &MythicBeastsProvider{client: newMockClient(), domainFilter: endpoint.NewDomainFilter([]string{"example.com"})}
+
+	records, err := p.Records(context.Background())
+	require.NoError(t, err)
+
+	expected := []*endpoint.Endpoint{
+		endpoint.NewEndpointWithTTL("example.com", endpoint.RecordTypeA, 3600, "1.2.3.4"),
+		endpoint.NewEndpointWithTTL("www.example.com", endpoint.RecordTypeA, 300, "1.2.3.4", "5.6.7.8"),
+		endpoint.NewEndpointWithTTL("example.com", endpoint.RecordTypeMX, 3600, "10 mail.example.com"),
+		endpoint.NewEndpointWithTTL("_sip._tcp.example.com", endpoint.RecordTypeSRV, 3600, "10 20 5060 sip.example.com"),
+	}
+	assert.True(t, testutils.SameEndpoints(records, expected), "actual and expected endpoints don't match. %s:%s", records, expected)
+}
+
+func TestMythicBeastsApplyChanges(t *testing.T) {
+	for _, dryRun := range []bool{false, true} {
+		client := newMockClient()
+		p := &MythicBeastsProvider{client: client, domainFilter: endpoint.NewDomainFilter([]string{"example.com"}), dryRun: dryRun}
+
+		err := p.ApplyChanges(context.Background(), &plan.Changes{
+			Create: []*endpoint.Endpoint{
+				endpoint.NewEndpoint("new.example.com", endpoint.RecordTypeCNAME, "www.example.com"),
+				endpoint.NewEndpointWithTTL("example.com", endpoint.RecordTypeMX, 3600, "20 backup.example.com"),
+				endpoint.NewEndpoint("www.example.org", endpoint.RecordTypeA, "1.1.1.1"),
+			},
+			UpdateOld: []*endpoint.Endpoint{
+				endpoint.NewEndpointWithTTL("www.example.com", endpoint.RecordTypeA, 300, "1.2.3.4", "5.6.7.8"),
+			},
+			UpdateNew: []*endpoint.Endpoint{
+				endpoint.NewEndpointWithTTL("www.example.com", endpoint.RecordTypeA, 900, "1.2.3.4", "4.3.2.1"),
+			},
+			Delete: []*endpoint.Endpoint{
+				endpoint.NewEndpointWithTTL("example.com", endpoint.RecordTypeA, 3600, "1.2.3.4"),
+			},
+		})
+		require.NoError(t, err)
+
+		assert.Equal(t, []call{
+			{method: "delete", zone: "example.com", host: "@", recordType: "A", preview: dryRun},
+			{method: "replace", zone: "example.com", host: "www", recordType: "A", records: []Record{
+				{Host: "www", Type: "A", TTL: 900, Data: "1.2.3.4"},
+				{Host: "www", Type: "A", TTL: 900, Data: "4.3.2.1"},
+			}, preview: dryRun},
+			{method: "add", zone: "example.com", records: []Record{
+				{Host: "new", Type: "CNAME", Data: "www.example.com"},
+				{Host: "@", Type: "MX", TTL: 3600, Data: "backup.example.com", MXPriority: intPtr(20)},
+			}, preview: dryRun},
+		}, client.calls)
+	}
+}
+
+func TestMythicBeastsApplyChangesInvalidTarget(t *testing.T) {
+	client := newMockClient()
+	p := &MythicBeastsProvider{client: client}
+
+	err := p.ApplyChanges(context.Background(), &plan.Changes{
+		Create: []*endpoint.Endpoint{
+			endpoint.NewEndpoint("example.com", endpoint.RecordTypeMX, "mail.example.com"),
+			endpoint.NewEndpoint("_sip._tcp.example.com", endpoint.RecordTypeSRV, "10 20 5060 sip.example.com"),
+		},
+	})
+	require.NoError(t, err)
+
+	assert.Equal(t, []call{
+		{method: "add", zone: "example.com", records: []Record{
+			{Host: "_sip._tcp", Type: "SRV", Data: "sip.example.com", SRVPriority: intPtr(10), SRVWeight: intPtr(20), SRVPort: intPtr(5060)},
+		}},
+	}, client.calls)
+}
+
+func TestNewMythicBeastsProvider(t *testing.T) {
+	t.Setenv("MYTHICBEASTS_KEY_ID", "key")
+	t.Setenv("MYTHICBEASTS_SECRET", "secret")
+	_, err := NewMythicBeastsProvider(context.Background(), endpoint.NewDomainFilter([]string{"example.com"}), false)
+	require.NoError(t, err)
+
+	t.Setenv("MYTHICBEASTS_SECRET", "")
+	_, err = NewMythicBeastsProvider(context.Background(), endpoint.NewDomainFilter([]string{"example.com"}), false)
+	require.Error(t, err)
+}
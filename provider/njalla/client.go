@@ -0,0 +1,170 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package njalla
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"sigs.k8s.io/external-dns/pkg/apis/externaldns"
+	extdnshttp "sigs.k8s.io/external-dns/pkg/http"
+)
+
+// defaultEndpoint is the JSON-RPC endpoint of the Njalla API.
+const defaultEndpoint = "https://njal.la/api/1/"
+
+// Domain is a domain managed in Njalla.
+type Domain struct {
+	Name string `json:"name"`
+}
+
+// Record is a DNS record of a Njalla domain.
+type Record struct {
+	ID      int    `json:"id,omitempty"`
+	Name    string `json:"name"`
+	Type    string `json:"type"`
+	Content string `json:"content"`
+	TTL     int    `json:"ttl"`
+	Prio    *int   `json:"prio,omitempty"`
+}
+
+// Client is the subset of the Njalla API used by the provider.
+type Client interface {
+	ListDomains(ctx context.Context) ([]Domain, error)
+	ListRecords(ctx context.Context, domain string) ([]Record, error)
+	AddRecord(ctx context.Context, domain string, record Record) error
+	EditRecord(ctx context.Context, domain string, record Record) error
+	RemoveRecord(ctx context.Context, domain string, id int) error
+}
+
+type client struct {
+	endpoint   string
+	token      string
+	httpClient *http.Client
+}
+
+// NewClient returns a Njalla API client authenticating with the given API token.
+func NewClient(token string) Client {
+	return &client{
+		endpoint:   defaultEndpoint,
+		token:      token,
+		httpClient: extdnshttp.NewClient("njalla"),
+	}
+}
+
+type rpcRequest struct {
+	Method string `json:"method"`
+	Params any    `json:"params"`
+}
+
+type rpcError struct {
+	Code    int    `json:"code"`
+	Message string `json:"message"`
+}
+
+type rpcResponse struct {
+	Result json.RawMessage `json:"result"`
+	Error  *rpcError       `json:"error"`
+}
+
+func (c *client) call(ctx context.Context, method string, params any, result any) error {
+	body, err := json.Marshal(rpcRequest{Method: method, Params: params})
+	if err != nil {
+		return err
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, c.endpoint, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Authorization", "Njalla "+c.token)
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Accept", "application/json")
+	req.Header.Set("User-Agent", externaldns.UserAgent())
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("njalla %s: unexpected status %s", method, resp.Status)
+	}
+	var rpcResp rpcResponse
+	if err := json.NewDecoder(resp.Body).Decode(&rpcResp); err != nil {
+		return fmt.Errorf("njalla %s: failed to decode response: %w", method, err)
+	}
+	if rpcResp.Error != nil {
+		return fmt.Errorf("njalla %s: %s (code %d)", method, rpcResp.Error.Message, rpcResp.Error.Code)
+	}
+	if result == nil || len(rpcResp.Result) == 0 {
+		return nil
+	}
+	return json.Unmarshal(rpcResp.Result, result)
+}
+
+func (c *client) ListDomains(ctx context.Context) ([]Domain, error) {
+	var result struct {
+		Domains []Domain `json:"domains"`
+	}
+	if err := c.call(ctx, "list-domains", map[string]any{}, &result); err != nil {
+		return nil, err
+	}
+	return result.Domains, nil
+}
+
+func (c *client) ListRecords(ctx context.Context, domain string) ([]Record, error) {
+	var result struct {
+		Records []Record `json:"records"`
+	}
+	if err := c.call(ctx, "list-records", map[string]any{"domain": domain}, &result); err != nil {
+		return nil, err
+	}
+	return result.Records, nil
+}
+
+func (c *client) AddRecord(ctx context.Context, domain string, record Record) error {
+	params := recordParams(domain, record)
+	return c.call(ctx, "add-record", params, nil)
+}
+
+func (c *client) EditRecord(ctx context.Context, domain string, record Record) error {
+	params := recordParams(domain, record)
+	params["id"] = record.ID
+	return c.call(ctx, "edit-record", params, nil)
+}
+
+func (c *client) RemoveRecord(ctx context.Context, domain string, id int) error {
+	return c.call(ctx, "remove-record", map[string]any{"domain": domain, "id": id}, nil)
+}
+
+func recordParams(domain string, record Record) map[string]any {
+	params := map[string]any{
+		"domain":  domain,
+		"name":    record.Name,
+		"type":    record.Type,
+		"content": record.Content,
+		"ttl":     record.TTL,
+	}
+	if record.Prio != nil {
+		params["prio"] = *record.Prio
+	}
+	return params
+}
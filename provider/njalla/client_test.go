@@ -0,0 +1,69 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package njalla
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestClient(t *testing.T) {
+	var calls []map[string]any
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "Njalla token", r.Header.Get("Authorization"))
+		var req map[string]any
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&req))
+		calls = append(calls, req)
+		switch req["method"] {
+		case "list-domains":
+			_, _ = w.Write([]byte(`{"result":{"domains":[{"name":"example.com","status":"active"}]}}`))
+		case "list-records":
+			_, _ = w.Write([]byte(`{"result":{"records":[{"id":1,"name":"@","type":"MX","content":"mail.example.com","ttl":3600,"prio":10}]}}`))
+		case "remove-record":
+			_, _ = w.Write([]byte(`{"error":{"code":404,"message":"record not found"}}`))
+		default:
+			_, _ = w.Write([]byte(`{"result":{}}`))
+		}
+	}))
+	defer server.Close()
+
+	c := &client{endpoint: server.URL, token: "token", httpClient: server.Client()}
+
+	domains, err := c.ListDomains(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, []Domain{{Name: "example.com"}}, domains)
+
+	records, err := c.ListRecords(context.Background(), "example.com")
+	require.NoError(t, err)
+	assert.Equal(t, []Record{{ID: 1, Name: "@", Type: "MX", Content: "mail.example.com", TTL: 3600, Prio: intPtr(10)}}, records)
+
+	require.NoError(t, c.AddRecord(context.Background(), "example.com", Record{Name: "www", Type: "A", Content: "1.2.3.4", TTL: 300}))
+	assert.Equal(t, map[string]any{"domain": "example.com", "name": "www", "type": "A", "content": "1.2.3.4", "ttl": float64(300)}, calls[2]["params"])
+
+	require.NoError(t, c.EditRecord(context.Background(), "example.com", Record{ID: 2, Name: "www", Type: "A", Content: "1.2.3.4", TTL: 60}))
+	assert.Equal(t, float64(2), calls[3]["params"].(map[string]any)["id"])
+
+	err = c.RemoveRecord(context.Background(), "example.com", 3)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "record not found")
+}
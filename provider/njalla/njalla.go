@@ -0,0 +1,345 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package njalla
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"slices"
+	"strings"
+
+	log "github.com/sirupsen/logrus"
+
+	"sigs.k8s.io/external-dns/endpoint"
+	"sigs.k8s.io/external-dns/plan"
+	"sigs.k8s.io/external-dns/provider"
+)
+
+const (
+	// defaultTTL is the TTL Njalla gives to records created without one.
+	defaultTTL = 10800
+	// apexName is the name Njalla gives to records at the apex of a domain.
+	apexName = "@"
+)
+
+// supportedTTLs are the only TTL values accepted by Njalla, in ascending order.
+var supportedTTLs = []endpoint.TTL{60, 300, 900, 3600, 10800, 21600, 86400}
+
+// NjallaProvider is an implementation of Provider for Njalla DNS.
+type NjallaProvider struct {
+	provider.BaseProvider
+	client       Client
+	domainFilter endpoint.DomainFilter
+	dryRun       bool
+}
+
+// NewNjallaProvider initializes a new Njalla DNS based Provider.
+func NewNjallaProvider(domainFilter endpoint.DomainFilter, dryRun bool) (*NjallaProvider, error) {
+	token := os.Getenv("NJALLA_API_TOKEN")
+	if token == "" {
+		return nil, fmt.Errorf("no njalla api token provided, you must set the NJALLA_API_TOKEN env var")
+	}
+
+	return &NjallaProvider{
+		client:       NewClient(token),
+		domainFilter: domainFilter,
+		dryRun:       dryRun,
+	}, nil
+}
+
+// Records returns the list of records in all managed domains.
+func (p *NjallaProvider) Records(ctx context.Context) ([]*endpoint.Endpoint, error) {
+	zones, err := p.zones(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var endpoints []*endpoint.Endpoint
+	for _, zone := range zones {
+		records, err := p.client.ListRecords(ctx, zone)
+		if err != nil {
+			return nil, provider.NewSoftError(fmt.Errorf("failed to list records of domain %s: %w", zone, err))
+		}
+
+		byKey := map[endpoint.EndpointKey]*endpoint.Endpoint{}
+		for _, record := range records {
+			if !p.SupportedRecordType(record.Type) {
+				continue
+			}
+			key := endpoint.EndpointKey{DNSName: fqdn(record.Name, zone), RecordType: record.Type}
+			if ep, ok := byKey[key]; ok {
+				ep.Targets = append(ep.Targets, target(record))
+				continue
+			}
+			ep := endpoint.NewEndpointWithTTL(key.DNSName, key.RecordType, endpoint.TTL(record.TTL), target(record))
+			byKey[key] = ep
+			endpoints = append(endpoints, ep)
+		}
+	}
+
+	return endpoints, nil
+}
+
+// AdjustEndpoints rounds TTLs up to the closest value supported by Njalla.
+func (p *NjallaProvider) AdjustEndpoints(endpoints []*endpoint.Endpoint) ([]*endpoint.Endpoint, error) {
+	for _, ep := range endpoints {
+		if ep.RecordTTL.IsConfigured() {
+			ep.RecordTTL = supportedTTL(ep.RecordTTL)
+		}
+	}
+	return endpoints, nil
+}
+
+// SupportedRecordType returns true if the record type is supported by the provider
+func (p *NjallaProvider) SupportedRecordType(recordType string) bool {
+	switch recordType {
+	case endpoint.RecordTypeA, endpoint.RecordTypeAAAA, endpoint.RecordTypeCNAME, endpoint.RecordTypeTXT, endpoint.RecordTypeMX:
+		return true
+	default:
+		return false
+	}
+}
+
+// ApplyChanges applies the given changes, one API call per record.
+func (p *NjallaProvider) ApplyChanges(ctx context.Context, changes *plan.Changes) error {
+	zones, err := p.zones(ctx)
+	if err != nil {
+		return err
+	}
+	zoneNameIDMapper := p.NewZoneFinder()
+	for _, zone := range zones {
+		zoneNameIDMapper.Add(zone, zone)
+	}
+
+	recordsByZone := map[string][]Record{}
+	existing := func(zone string) ([]Record, error) {
+		if records, ok := recordsByZone[zone]; ok {
+			return records, nil
+		}
+		records, err := p.client.ListRecords(ctx, zone)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list records of domain %s: %w", zone, err)
+		}
+		recordsByZone[zone] = records
+		return records, nil
+	}
+
+	for _, ep := range changes.Delete {
+		zone, _ := zoneNameIDMapper.FindZone(ep.DNSName)
+		if zone == "" {
+			log.Debugf("Skipping record %s because no domain matching record DNS Name was detected", ep.DNSName)
+			continue
+		}
+		records, err := existing(zone)
+		if err != nil {
+			return provider.NewSoftError(err)
+		}
+		for _, record := range matchingRecords(records, zone, ep) {
+			if err := p.removeRecord(ctx, zone, ep, record); err != nil {
+				return err
+			}
+		}
+	}
+
+	oldByKey := map[endpoint.EndpointKey]*endpoint.Endpoint{}
+	for _, ep := range changes.UpdateOld {
+		oldByKey[ep.Key()] = ep
+	}
+	for _, ep := range changes.UpdateNew {
+		zone, _ := zoneNameIDMapper.FindZone(ep.DNSName)
+		if zone == "" {
+			log.Debugf("Skipping record %s because no domain matching record DNS Name was detected", ep.DNSName)
+			continue
+		}
+		records, err := existing(zone)
+		if err != nil {
+			return provider.NewSoftError(err)
+		}
+		current := map[string]Record{}
+		for _, record := range matchingRecords(records, zone, ep) {
+			current[target(record)] = record
+		}
+
+		for _, t := range ep.Targets {
+			desired, err := newRecord(zone, ep, t)
+			if err != nil {
+				log.Warnf("Skipping invalid target %q of %s record %s: %v", t, ep.RecordType, ep.DNSName, err)
+				continue
+			}
+			if record, ok := current[t]; ok {
+				delete(current, t)
+				if record.TTL == desired.TTL {
+					continue
+				}
+				desired.ID = record.ID
+				if err := p.editRecord(ctx, zone, ep, desired); err != nil {
+					return err
+				}
+				continue
+			}
+			if err := p.addRecord(ctx, zone, ep, desired); err != nil {
+				return err
+			}
+		}
+
+		// Only remove the values ExternalDNS used to manage.
+		if old, ok := oldByKey[ep.Key()]; ok {
+			for t, record := range current {
+				if slices.Contains(old.Targets, t) {
+					if err := p.removeRecord(ctx, zone, ep, record); err != nil {
+						return err
+					}
+				}
+			}
+		}
+	}
+
+	for _, ep := range changes.Create {
+		zone, _ := zoneNameIDMapper.FindZone(ep.DNSName)
+		if zone == "" {
+			log.Debugf("Skipping record %s because no domain matching record DNS Name was detected", ep.DNSName)
+			continue
+		}
+		for _, t := range ep.Targets {
+			record, err := newRecord(zone, ep, t)
+			if err != nil {
+				log.Warnf("Skipping invalid target %q of %s record %s: %v", t, ep.RecordType, ep.DNSName, err)
+				continue
+			}
+			if err := p.addRecord(ctx, zone, ep, record); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+func (p *NjallaProvider) zones(ctx context.Context) ([]string, error) {
+	domains, err := p.client.ListDomains(ctx)
+	if err != nil {
+		return nil, provider.NewSoftError(fmt.Errorf("failed to list domains: %w", err))
+	}
+
+	var zones []string
+	for _, domain := range domains {
+		if p.domainFilter.Match(domain.Name) {
+			zones = append(zones, domain.Name)
+		}
+	}
+	return zones, nil
+}
+
+func (p *NjallaProvider) addRecord(ctx context.Context, zone string, ep *endpoint.Endpoint, record Record) error {
+	log.Infof("Creating %s record %s with target %s in domain %s", ep.RecordType, ep.DNSName, record.Content, zone)
+	if p.dryRun {
+		return nil
+	}
+	if err := p.client.AddRecord(ctx, zone, record); err != nil {
+		return provider.NewSoftError(fmt.Errorf("failed to create %s record %s: %w", ep.RecordType, ep.DNSName, err))
+	}
+	return nil
+}
+
+func (p *NjallaProvider) editRecord(ctx context.Context, zone string, ep *endpoint.Endpoint, record Record) error {
+	log.Infof("Updating %s record %s with target %s in domain %s", ep.RecordType, ep.DNSName, record.Content, zone)
+	if p.dryRun {
+		return nil
+	}
+	if err := p.client.EditRecord(ctx, zone, record); err != nil {
+		return provider.NewSoftError(fmt.Errorf("failed to update %s record %s: %w", ep.RecordType, ep.DNSName, err))
+	}
+	return nil
+}
+
+func (p *NjallaProvider) removeRecord(ctx context.Context, zone string, ep *endpoint.Endpoint, record Record) error {
+	log.Infof("Deleting %s record %s with target %s in domain %s", ep.RecordType, ep.DNSName, record.Content, zone)
+	if p.dryRun {
+		return nil
+	}
+	if err := p.client.RemoveRecord(ctx, zone, record.ID); err != nil {
+		return provider.NewSoftError(fmt.Errorf("failed to delete %s record %s: %w", ep.RecordType, ep.DNSName, err))
+	}
+	return nil
+}
+
+// matchingRecords returns the records of the zone with the name and type of the endpoint.
+func matchingRecords(records []Record, zone string, ep *endpoint.Endpoint) []Record {
+	var matched []Record
+	for _, record := range records {
+		if record.Type == ep.RecordType && fqdn(record.Name, zone) == ep.DNSName {
+			matched = append(matched, record)
+		}
+	}
+	return matched
+}
+
+// newRecord returns the Njalla record for one of the targets of an endpoint.
+func newRecord(zone string, ep *endpoint.Endpoint, t string) (Record, error) {
+	record := Record{
+		Name:    relativeName(ep.DNSName, zone),
+		Type:    ep.RecordType,
+		Content: t,
+		TTL:     defaultTTL,
+	}
+	if ep.RecordTTL.IsConfigured() {
+		record.TTL = int(supportedTTL(ep.RecordTTL))
+	}
+	if ep.RecordType == endpoint.RecordTypeMX {
+		mx, err := endpoint.NewMXTarget(t)
+		if err != nil {
+			return Record{}, err
+		}
+		prio := int(mx.Priority)
+		record.Content = mx.Host
+		record.Prio = &prio
+	}
+	return record, nil
+}
+
+// target returns the content of a record in the RDATA form used by endpoints.
+func target(record Record) string {
+	if record.Type == endpoint.RecordTypeMX && record.Prio != nil {
+		return endpoint.MXTarget{Priority: uint16(*record.Prio), Host: record.Content}.String()
+	}
+	return record.Content
+}
+
+// supportedTTL returns the smallest supported TTL not lower than the given one.
+func supportedTTL(ttl endpoint.TTL) endpoint.TTL {
+	for _, supported := range supportedTTLs {
+		if ttl <= supported {
+			return supported
+		}
+	}
+	return supportedTTLs[len(supportedTTLs)-1]
+}
+
+func fqdn(name, zone string) string {
+	if name == "" || name == apexName {
+		return zone
+	}
+	return name + "." + zone
+}
+
+func relativeName(dnsName, zone string) string {
+	if dnsName == zone {
+		return apexName
+	}
+	return strings.TrimSuffix(dnsName, "."+zone)
+}
@@ -0,0 +1,165 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package njalla
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"sigs.k8s.io/external-dns/endpoint"
+	"sigs.k8s.io/external-dns/internal/testutils"
+	"sigs.k8s.io/external-dns/plan"
+)
+
+type mockClient struct {
+	domains []Domain
+	records map[string][]Record
+	added   []Record
+	edited  []Record
+	removed []int
+}
+
+func (m *mockClient) ListDomains(_ context.Context) ([]Domain, error) {
+	return m.domains, nil
+}
+
+func (m *mockClient) ListRecords(_ context.Context, domain string) ([]Record, error) {
+	return m.records[domain], nil
+}
+
+func (m *mockClient) AddRecord(_ context.Context, _ string, record Record) error {
+	m.added = append(m.added, record)
+	return nil
+}
+
+func (m *mockClient) EditRecord(_ context.Context, _ string, record Record) error {
+	m.edited = append(m.edited, record)
+	return nil
+}
+
+func (m *mockClient) RemoveRecord(_ context.Context, _ string, id int) error {
+	m.removed = append(m.removed, id)
+	return nil
+}
+
+func intPtr(i int) *int {
+	return &i
+}
+
+func newMockClient() *mockClient {
+	return &mockClient{
+		domains: []Domain{{Name: "example.com"}, {Name: "example.org"}},
+		records: map[string][]Record{
+			"example.com": {
+				{ID: 1, Name: "@", Type: "A", Content: "1.2.3.4", TTL: 3600},
+				{ID: 2, Name: "www", Type: "A", Content: "1.2.3.4", TTL: 300},
+				{ID: 3, Name: "www", Type: "A", Content: "5.6.7.8", TTL: 300},
+				{ID: 4, Name: "@", Type: "MX", Content: "mail.example.com", TTL: 10800, Prio: intPtr(10)},
+				{ID: 5, Name: "@", Type: "NS", Content: "ns1.njalla.net", TTL: 10800},
+			},
+			"example.org": {
+				{ID: 6, Name: "www", Type: "A", Content: "9.9.9.9", TTL: 300},
+			},
+		},
+	}
+}
+
+func TestNjallaRecords(t *testing.T) {
+	p := &NjallaProvider{client: newMockClient(), domainFilter: endpoint.NewDomainFilter([]string{"example.com"})}
+
+	records, err := p.Records(context.Background())
+	require.NoError(t, err)
+
+	expected := []*endpoint.Endpoint{
+		endpoint.NewEndpointWithTTL("example.com", endpoint.RecordTypeA, 3600, "1.2.3.4"),
+		endpoint.NewEndpointWithTTL("www.example.com", endpoint.RecordTypeA, 300, "1.2.3.4", "5.6.7.8"),
+		endpoint.NewEndpointWithTTL("example.com", endpoint.RecordTypeMX, 10800, "10 mail.example.com"),
+	}
+	assert.True(t, testutils.SameEndpoints(records, expected), "actual and expected endpoints don't match. %s:%s", records, expected)
+}
+
+func TestNjallaAdjustEndpoints(t *testing.T) {
+	p := &NjallaProvider{}
+	endpoints, err := p.AdjustEndpoints([]*endpoint.Endpoint{
+		endpoint.NewEndpoint("a.example.com", endpoint.RecordTypeA, "1.2.3.4"),
+		endpoint.NewEndpointWithTTL("b.example.com", endpoint.RecordTypeA, 30, "1.2.3.4"),
+		endpoint.NewEndpointWithTTL("c.example.com", endpoint.RecordTypeA, 600, "1.2.3.4"),
+		endpoint.NewEndpointWithTTL("d.example.com", endpoint.RecordTypeA, 100000, "1.2.3.4"),
+	})
+	require.NoError(t, err)
+	assert.Equal(t, endpoint.TTL(0), endpoints[0].RecordTTL)
+	assert.Equal(t, endpoint.TTL(60), endpoints[1].RecordTTL)
+	assert.Equal(t, endpoint.TTL(900), endpoints[2].RecordTTL)
+	assert.Equal(t, endpoint.TTL(86400), endpoints[3].RecordTTL)
+}
+
+func TestNjallaApplyChanges(t *testing.T) {
+	client := newMockClient()
+	p := &NjallaProvider{client: client, domainFilter: endpoint.NewDomainFilter([]string{"example.com"})}
+
+	err := p.ApplyChanges(context.Background(), &plan.Changes{
+		Create: []*endpoint.Endpoint{
+			endpoint.NewEndpoint("new.example.com", endpoint.RecordTypeCNAME, "www.example.com"),
+			endpoint.NewEndpointWithTTL("example.com", endpoint.RecordTypeMX, 3600, "20 backup.example.com"),
+			endpoint.NewEndpoint("www.example.org", endpoint.RecordTypeA, "1.1.1.1"),
+		},
+		UpdateOld: []*endpoint.Endpoint{
+			endpoint.NewEndpointWithTTL("www.example.com", endpoint.RecordTypeA, 300, "1.2.3.4", "5.6.7.8"),
+		},
+		UpdateNew: []*endpoint.Endpoint{
+			endpoint.NewEndpointWithTTL("www.example.com", endpoint.RecordTypeA, 900, "1.2.3.4", "4.3.2.1"),
+		},
+		Delete: []*endpoint.Endpoint{
+			endpoint.NewEndpointWithTTL("example.com", endpoint.RecordTypeA, 3600, "1.2.3.4"),
+		},
+	})
+	require.NoError(t, err)
+
+	assert.Equal(t, []Record{
+		{Name: "www", Type: "A", Content: "4.3.2.1", TTL: 900},
+		{Name: "new", Type: "CNAME", Content: "www.example.com", TTL: defaultTTL},
+		{Name: "@", Type: "MX", Content: "backup.example.com", TTL: 3600, Prio: intPtr(20)},
+	}, client.added)
+	assert.Equal(t, []Record{{ID: 2, Name: "www", Type: "A", Content: "1.2.3.4", TTL: 900}}, client.edited)
+	assert.ElementsMatch(t, []int{1, 3}, client.removed)
+}
+
+func TestNjallaApplyChangesDryRun(t *testing.T) {
+	client := newMockClient()
+	p := &NjallaProvider{client: client, domainFilter: endpoint.NewDomainFilter([]string{"example.com"}), dryRun: true}
+
+	err := p.ApplyChanges(context.Background(), &plan.Changes{
+		Create: []*endpoint.Endpoint{endpoint.NewEndpoint("new.example.com", endpoint.RecordTypeA, "1.2.3.4")},
+		Delete: []*endpoint.Endpoint{endpoint.NewEndpoint("example.com", endpoint.RecordTypeA, "1.2.3.4")},
+	})
+	require.NoError(t, err)
+	assert.Empty(t, client.added)
+	assert.Empty(t, client.removed)
+}
+
+func TestNewNjallaProvider(t *testing.T) {
+	t.Setenv("NJALLA_API_TOKEN", "token")
+	_, err := NewNjallaProvider(endpoint.NewDomainFilter([]string{"example.com"}), false)
+	require.NoError(t, err)
+
+	t.Setenv("NJALLA_API_TOKEN", "")
+	_, err = NewNjallaProvider(endpoint.NewDomainFilter([]string{"example.com"}), false)
+	require.Error(t, err)
+}
@@ -208,7 +208,7 @@ func (p *NS1Provider) ns1SubmitChanges(changes []*ns1Change) error {
 	}
 
 	// separate into per-zone change sets to be passed to the API.
-	changesByZone := ns1ChangesByZone(zones, changes)
+	changesByZone := p.ns1ChangesByZone(zones, changes)
 	for zoneName, changes := range changesByZone {
 		for _, change := range changes {
 			record := p.ns1BuildRecord(zoneName, change)
@@ -303,9 +303,9 @@ func newNS1Changes(action string, endpoints []*endpoint.Endpoint) []*ns1Change {
 }
 
 // ns1ChangesByZone separates a multi-zone change into a single change per zone.
-func ns1ChangesByZone(zones []*dns.Zone, changeSets []*ns1Change) map[string][]*ns1Change {
+func (p *NS1Provider) ns1ChangesByZone(zones []*dns.Zone, changeSets []*ns1Change) map[string][]*ns1Change {
 	changes := make(map[string][]*ns1Change)
-	zoneNameIDMapper := provider.ZoneIDName{}
+	zoneNameIDMapper := p.NewZoneFinder()
 	for _, z := range zones {
 		zoneNameIDMapper.Add(z.Zone, z.Zone)
 		changes[z.Zone] = []*ns1Change{}
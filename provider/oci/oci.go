@@ -35,7 +35,19 @@ import (
 	"sigs.k8s.io/external-dns/provider"
 )
 
-const defaultTTL = 300
+const (
+	defaultTTL = 300
+
+	// ociViewIDProperty is the provider specific property selecting the private view
+	// holding the zone of a record, for zones with the same name in several views.
+	ociViewIDProperty = "oci/view-id"
+	// ociWeightProperty is the provider specific property holding the weight of a set
+	// identifier in the steering policy of a weighted record.
+	ociWeightProperty = "oci/weight"
+	// setIdentifierTXTPrefix prefixes the extra string appended to TXT records of weighted
+	// records, used to restore their set identifier when reading them back.
+	setIdentifierTXTPrefix = "external-dns/set-identifier="
+)
 
 // OCIAuthConfig holds connection parameters for the OCI API.
 type OCIAuthConfig struct {
@@ -68,6 +80,10 @@ type OCIProvider struct {
 	zoneScope    string
 	zoneCache    *zoneCache
 	dryRun       bool
+
+	// lastZones are the zones returned by the last zones call, used to default the
+	// private view of endpoints in AdjustEndpoints.
+	lastZones map[string]dns.ZoneSummary
 }
 
 // ociDNSClient is the subset of the OCI DNS API required by the OCI Provider.
@@ -75,6 +91,13 @@ type ociDNSClient interface {
 	ListZones(ctx context.Context, request dns.ListZonesRequest) (response dns.ListZonesResponse, err error)
 	GetZoneRecords(ctx context.Context, request dns.GetZoneRecordsRequest) (response dns.GetZoneRecordsResponse, err error)
 	PatchZoneRecords(ctx context.Context, request dns.PatchZoneRecordsRequest) (response dns.PatchZoneRecordsResponse, err error)
+	ListSteeringPolicyAttachments(ctx context.Context, request dns.ListSteeringPolicyAttachmentsRequest) (response dns.ListSteeringPolicyAttachmentsResponse, err error)
+	GetSteeringPolicy(ctx context.Context, request dns.GetSteeringPolicyRequest) (response dns.GetSteeringPolicyResponse, err error)
+	CreateSteeringPolicy(ctx context.Context, request dns.CreateSteeringPolicyRequest) (response dns.CreateSteeringPolicyResponse, err error)
+	UpdateSteeringPolicy(ctx context.Context, request dns.UpdateSteeringPolicyRequest) (response dns.UpdateSteeringPolicyResponse, err error)
+	DeleteSteeringPolicy(ctx context.Context, request dns.DeleteSteeringPolicyRequest) (response dns.DeleteSteeringPolicyResponse, err error)
+	CreateSteeringPolicyAttachment(ctx context.Context, request dns.CreateSteeringPolicyAttachmentRequest) (response dns.CreateSteeringPolicyAttachmentResponse, err error)
+	DeleteSteeringPolicyAttachment(ctx context.Context, request dns.DeleteSteeringPolicyAttachmentRequest) (response dns.DeleteSteeringPolicyAttachmentResponse, err error)
 }
 
 // LoadOCIConfig reads and parses the OCI ExternalDNS config file at the given
@@ -149,6 +172,7 @@ func NewOCIProvider(cfg OCIConfig, domainFilter endpoint.DomainFilter, zoneIDFil
 func (p *OCIProvider) zones(ctx context.Context) (map[string]dns.ZoneSummary, error) {
 	if !p.zoneCache.Expired() {
 		log.Debug("Using cached zones list")
+		p.lastZones = p.zoneCache.zones
 		return p.zoneCache.zones, nil
 	}
 	zones := make(map[string]dns.ZoneSummary)
@@ -167,15 +191,17 @@ func (p *OCIProvider) zones(ctx context.Context) (map[string]dns.ZoneSummary, er
 		log.Warnf("No zones in compartment %q match domain filters %v", p.cfg.CompartmentID, p.domainFilter)
 	}
 	p.zoneCache.Reset(zones)
+	p.lastZones = zones
 	return zones, nil
 }
 
-// Merge Endpoints with the same Name and Type into a single endpoint with multiple Targets.
+// Merge Endpoints with the same Name, Type, set identifier and view into a single endpoint with multiple Targets.
 func mergeEndpointsMultiTargets(endpoints []*endpoint.Endpoint) []*endpoint.Endpoint {
 	endpointsByNameType := map[string][]*endpoint.Endpoint{}
 
 	for _, ep := range endpoints {
-		key := fmt.Sprintf("%s-%s", ep.DNSName, ep.RecordType)
+		viewID, _ := ep.GetProviderSpecificProperty(ociViewIDProperty)
+		key := fmt.Sprintf("%s-%s-%s-%s", ep.DNSName, ep.RecordType, ep.SetIdentifier, viewID)
 		endpointsByNameType[key] = append(endpointsByNameType[key], ep)
 	}
 
@@ -196,7 +222,8 @@ func mergeEndpointsMultiTargets(endpoints []*endpoint.Endpoint) []*endpoint.Endp
 			targets[i] = e.Targets[0]
 		}
 
-		e := endpoint.NewEndpointWithTTL(dnsName, recordType, recordTTL, targets...)
+		e := endpoint.NewEndpointWithTTL(dnsName, recordType, recordTTL, targets...).WithSetIdentifier(ep[0].SetIdentifier)
+		e.ProviderSpecific = ep[0].ProviderSpecific
 		mergedEndpoints = append(mergedEndpoints, e)
 	}
 
@@ -237,8 +264,15 @@ func (p *OCIProvider) newFilteredRecordOperations(endpoints []*endpoint.Endpoint
 		if ep == nil {
 			continue
 		}
+		// Weighted records are managed through steering policies.
+		if isSteered(ep) {
+			continue
+		}
 		if p.domainFilter.Match(ep.DNSName) {
 			for _, t := range ep.Targets {
+				if ep.RecordType == endpoint.RecordTypeTXT && isWeighted(ep) {
+					t = fmt.Sprintf("%s \"%s%s\"", t, setIdentifierTXTPrefix, ep.SetIdentifier)
+				}
 				singleTargetEp := &endpoint.Endpoint{
 					DNSName:          ep.DNSName,
 					Targets:          []string{t},
@@ -261,6 +295,15 @@ func (p *OCIProvider) Records(ctx context.Context) ([]*endpoint.Endpoint, error)
 		return nil, provider.NewSoftError(fmt.Errorf("getting zones: %w", err))
 	}
 
+	policies, err := p.steeringPolicies(ctx, zones)
+	if err != nil {
+		return nil, err
+	}
+	steered := map[string]bool{}
+	for _, policy := range policies {
+		steered[policy.zoneID+"/"+policy.domain] = true
+	}
+
 	var endpoints []*endpoint.Endpoint
 	for _, zone := range zones {
 		var page *string
@@ -278,14 +321,24 @@ func (p *OCIProvider) Records(ctx context.Context) ([]*endpoint.Endpoint, error)
 				if !provider.SupportedRecordType(*record.Rtype) {
 					continue
 				}
-				endpoints = append(endpoints,
-					endpoint.NewEndpointWithTTL(
-						*record.Domain,
-						*record.Rtype,
-						endpoint.TTL(*record.Ttl),
-						*record.Rdata,
-					),
+				// Records of a domain with a steering policy are served by the policy.
+				if steered[*zone.Id+"/"+*record.Domain] && isSteerableType(*record.Rtype) {
+					continue
+				}
+				ep := endpoint.NewEndpointWithTTL(
+					*record.Domain,
+					*record.Rtype,
+					endpoint.TTL(*record.Ttl),
+					*record.Rdata,
 				)
+				if *record.Rtype == endpoint.RecordTypeTXT {
+					ep.Targets[0], ep.SetIdentifier = splitSetIdentifierTXT(*record.Rdata)
+				}
+				if zone.ViewId != nil {
+					ep.WithProviderSpecific(ociViewIDProperty, *zone.ViewId).
+						WithProviderSpecific(endpoint.DNSViewProperty, *zone.ViewId)
+				}
+				endpoints = append(endpoints, ep)
 			}
 
 			if page = resp.OpcNextPage; resp.OpcNextPage == nil {
@@ -295,6 +348,9 @@ func (p *OCIProvider) Records(ctx context.Context) ([]*endpoint.Endpoint, error)
 	}
 
 	endpoints = mergeEndpointsMultiTargets(endpoints)
+	for _, policy := range policies {
+		endpoints = append(endpoints, policy.endpoints()...)
+	}
 
 	return endpoints, nil
 }
@@ -303,15 +359,23 @@ func (p *OCIProvider) Records(ctx context.Context) ([]*endpoint.Endpoint, error)
 func (p *OCIProvider) ApplyChanges(ctx context.Context, changes *plan.Changes) error {
 	log.Debugf("Processing changes: %+v", changes)
 
-	var ops []dns.RecordOperation
-	ops = append(ops, p.newFilteredRecordOperations(changes.Create, dns.RecordOperationOperationAdd)...)
+	// Records with a private view are only matched against the zones of that view.
+	opsByView := map[string][]dns.RecordOperation{}
+	var count int
+	for viewID, viewChanges := range changesByView(changes) {
+		var ops []dns.RecordOperation
+		ops = append(ops, p.newFilteredRecordOperations(viewChanges.Create, dns.RecordOperationOperationAdd)...)
 
-	ops = append(ops, p.newFilteredRecordOperations(changes.UpdateNew, dns.RecordOperationOperationAdd)...)
-	ops = append(ops, p.newFilteredRecordOperations(changes.UpdateOld, dns.RecordOperationOperationRemove)...)
+		ops = append(ops, p.newFilteredRecordOperations(viewChanges.UpdateNew, dns.RecordOperationOperationAdd)...)
+		ops = append(ops, p.newFilteredRecordOperations(viewChanges.UpdateOld, dns.RecordOperationOperationRemove)...)
 
-	ops = append(ops, p.newFilteredRecordOperations(changes.Delete, dns.RecordOperationOperationRemove)...)
+		ops = append(ops, p.newFilteredRecordOperations(viewChanges.Delete, dns.RecordOperationOperationRemove)...)
 
-	if len(ops) == 0 {
+		opsByView[viewID] = ops
+		count += len(ops)
+	}
+
+	if count == 0 && !hasSteeredChanges(changes) {
 		log.Info("All records are already up to date")
 		return nil
 	}
@@ -322,7 +386,12 @@ func (p *OCIProvider) ApplyChanges(ctx context.Context, changes *plan.Changes) e
 	}
 
 	// Separate into per-zone change sets to be passed to OCI API.
-	opsByZone := operationsByZone(zones, ops)
+	opsByZone := make(map[string][]dns.RecordOperation)
+	for viewID, ops := range opsByView {
+		for zoneID, zoneOps := range p.operationsByZone(zonesInView(zones, viewID), ops) {
+			opsByZone[zoneID] = append(opsByZone[zoneID], zoneOps...)
+		}
+	}
 	for zoneID, ops := range opsByZone {
 		log.Infof("Change zone: %q", zoneID)
 		for _, op := range ops {
@@ -330,29 +399,59 @@ func (p *OCIProvider) ApplyChanges(ctx context.Context, changes *plan.Changes) e
 		}
 	}
 
-	if p.dryRun {
-		return nil
-	}
-
-	for zoneID, ops := range opsByZone {
-		if _, err := p.client.PatchZoneRecords(ctx, dns.PatchZoneRecordsRequest{
-			CompartmentId:           &p.cfg.CompartmentID,
-			ZoneNameOrId:            &zoneID,
-			PatchZoneRecordsDetails: dns.PatchZoneRecordsDetails{Items: ops},
-		}); err != nil {
-			return provider.NewSoftError(err)
+	if !p.dryRun {
+		for zoneID, ops := range opsByZone {
+			if _, err := p.client.PatchZoneRecords(ctx, dns.PatchZoneRecordsRequest{
+				CompartmentId:           &p.cfg.CompartmentID,
+				ZoneNameOrId:            &zoneID,
+				PatchZoneRecordsDetails: dns.PatchZoneRecordsDetails{Items: ops},
+			}); err != nil {
+				return provider.NewSoftError(err)
+			}
 		}
 	}
 
-	return nil
+	return p.applySteeringChanges(ctx, zones, changes)
 }
 
 // AdjustEndpoints modifies the endpoints as needed by the specific provider
 func (p *OCIProvider) AdjustEndpoints(endpoints []*endpoint.Endpoint) ([]*endpoint.Endpoint, error) {
+	zoneNameIDMapper := p.NewZoneFinder()
+	for _, z := range p.lastZones {
+		zoneNameIDMapper.Add(*z.Id, *z.Name)
+	}
+
 	var adjustedEndpoints []*endpoint.Endpoint
 	for _, e := range endpoints {
-		// OCI DNS does not support the set-identifier attribute, so we remove it to avoid plan failure
-		if e.SetIdentifier != "" {
+		// The generic dns-view property selects the private view like the oci-view-id annotation.
+		viewID, ok := e.GetProviderSpecificProperty(ociViewIDProperty)
+		if view := e.DNSView(); view != "" {
+			if !ok {
+				viewID, ok = view, true
+				e.WithProviderSpecific(ociViewIDProperty, viewID)
+			} else if view != viewID {
+				log.Warnf("Adjusting endpoint: %v. Ignoring annotation 'dns-view' %q conflicting with annotation 'oci-view-id'", *e, view)
+			}
+		}
+		// Records of zones in a private view are read with their view, default it to avoid plan failure.
+		if !ok {
+			if zoneID, _ := zoneNameIDMapper.FindZone(e.DNSName); zoneID != "" && p.lastZones[zoneID].ViewId != nil {
+				viewID, ok = *p.lastZones[zoneID].ViewId, true
+				e.WithProviderSpecific(ociViewIDProperty, viewID)
+			}
+		}
+		// Records are planned by view, which is read as both properties.
+		if ok {
+			e.SetProviderSpecificProperty(endpoint.DNSViewProperty, viewID)
+		}
+		if weight, ok := e.GetProviderSpecificProperty(ociWeightProperty); ok {
+			if err := validateWeight(e, weight); err != nil {
+				log.Warnf("Adjusting endpoint: %v. Ignoring invalid annotation 'oci-weight': %v", *e, err)
+				e.DeleteProviderSpecificProperty(ociWeightProperty)
+			}
+		}
+		// OCI DNS only supports the set-identifier attribute for weighted records, so we remove it otherwise to avoid plan failure
+		if e.SetIdentifier != "" && !isWeighted(e) {
 			log.Warnf("Adjusting endpont: %v. Ignoring unsupported annotation 'set-identifier': %s", *e, e.SetIdentifier)
 			e.SetIdentifier = ""
 		}
@@ -361,6 +460,67 @@ func (p *OCIProvider) AdjustEndpoints(endpoints []*endpoint.Endpoint) ([]*endpoi
 	return adjustedEndpoints, nil
 }
 
+// changesByView splits changes by the private view of their endpoints.
+func changesByView(changes *plan.Changes) map[string]*plan.Changes {
+	byView := map[string]*plan.Changes{}
+	get := func(ep *endpoint.Endpoint) *plan.Changes {
+		viewID, _ := ep.GetProviderSpecificProperty(ociViewIDProperty)
+		if _, ok := byView[viewID]; !ok {
+			byView[viewID] = &plan.Changes{}
+		}
+		return byView[viewID]
+	}
+	for _, ep := range changes.Create {
+		if ep != nil {
+			c := get(ep)
+			c.Create = append(c.Create, ep)
+		}
+	}
+	for _, ep := range changes.UpdateNew {
+		if ep != nil {
+			c := get(ep)
+			c.UpdateNew = append(c.UpdateNew, ep)
+		}
+	}
+	for _, ep := range changes.UpdateOld {
+		if ep != nil {
+			c := get(ep)
+			c.UpdateOld = append(c.UpdateOld, ep)
+		}
+	}
+	for _, ep := range changes.Delete {
+		if ep != nil {
+			c := get(ep)
+			c.Delete = append(c.Delete, ep)
+		}
+	}
+	return byView
+}
+
+// zonesInView returns the zones of the given private view, or all zones when no view is given.
+func zonesInView(zones map[string]dns.ZoneSummary, viewID string) map[string]dns.ZoneSummary {
+	if viewID == "" {
+		return zones
+	}
+	inView := make(map[string]dns.ZoneSummary)
+	for id, z := range zones {
+		if z.ViewId != nil && *z.ViewId == viewID {
+			inView[id] = z
+		}
+	}
+	return inView
+}
+
+// splitSetIdentifierTXT removes the set identifier string of a TXT record of a weighted record
+// from its rdata, and returns both.
+func splitSetIdentifierTXT(rdata string) (string, string) {
+	i := strings.LastIndex(rdata, ` "`+setIdentifierTXTPrefix)
+	if i < 0 || !strings.HasSuffix(rdata, `"`) {
+		return rdata, ""
+	}
+	return rdata[:i], strings.TrimSuffix(rdata[i+len(setIdentifierTXTPrefix)+2:], `"`)
+}
+
 // newRecordOperation returns a RecordOperation based on a given endpoint.
 func newRecordOperation(ep *endpoint.Endpoint, opType dns.RecordOperationOperationEnum) dns.RecordOperation {
 	targets := make([]string, len(ep.Targets))
@@ -385,10 +545,10 @@ func newRecordOperation(ep *endpoint.Endpoint, opType dns.RecordOperationOperati
 }
 
 // operationsByZone segments a slice of RecordOperations by their zone.
-func operationsByZone(zones map[string]dns.ZoneSummary, ops []dns.RecordOperation) map[string][]dns.RecordOperation {
+func (p *OCIProvider) operationsByZone(zones map[string]dns.ZoneSummary, ops []dns.RecordOperation) map[string][]dns.RecordOperation {
 	changes := make(map[string][]dns.RecordOperation)
 
-	zoneNameIDMapper := provider.ZoneIDName{}
+	zoneNameIDMapper := p.NewZoneFinder()
 	for _, z := range zones {
 		zoneNameIDMapper.Add(*z.Id, *z.Name)
 		changes[*z.Id] = []dns.RecordOperation{}
@@ -411,3 +571,8 @@ func operationsByZone(zones map[string]dns.ZoneSummary, ops []dns.RecordOperatio
 
 	return changes
 }
+
+// SupportsViews tells that the records of zones in private views are selected with the dns-view property.
+func (p *OCIProvider) SupportsViews() bool {
+	return true
+}
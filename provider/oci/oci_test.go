@@ -128,6 +128,35 @@ func (c *mockOCIDNSClient) PatchZoneRecords(ctx context.Context, request dns.Pat
 	return // Provider does not use the response so nothing to do here.
 }
 
+func (c *mockOCIDNSClient) ListSteeringPolicyAttachments(ctx context.Context, request dns.ListSteeringPolicyAttachmentsRequest) (response dns.ListSteeringPolicyAttachmentsResponse, err error) {
+	return
+}
+
+func (c *mockOCIDNSClient) GetSteeringPolicy(ctx context.Context, request dns.GetSteeringPolicyRequest) (response dns.GetSteeringPolicyResponse, err error) {
+	err = errors.New("steering policy not found")
+	return
+}
+
+func (c *mockOCIDNSClient) CreateSteeringPolicy(ctx context.Context, request dns.CreateSteeringPolicyRequest) (response dns.CreateSteeringPolicyResponse, err error) {
+	return
+}
+
+func (c *mockOCIDNSClient) UpdateSteeringPolicy(ctx context.Context, request dns.UpdateSteeringPolicyRequest) (response dns.UpdateSteeringPolicyResponse, err error) {
+	return
+}
+
+func (c *mockOCIDNSClient) DeleteSteeringPolicy(ctx context.Context, request dns.DeleteSteeringPolicyRequest) (response dns.DeleteSteeringPolicyResponse, err error) {
+	return
+}
+
+func (c *mockOCIDNSClient) CreateSteeringPolicyAttachment(ctx context.Context, request dns.CreateSteeringPolicyAttachmentRequest) (response dns.CreateSteeringPolicyAttachmentResponse, err error) {
+	return
+}
+
+func (c *mockOCIDNSClient) DeleteSteeringPolicyAttachment(ctx context.Context, request dns.DeleteSteeringPolicyAttachmentRequest) (response dns.DeleteSteeringPolicyAttachmentResponse, err error) {
+	return
+}
+
 // newOCIProvider creates an OCI provider with API calls mocked out.
 func newOCIProvider(client ociDNSClient, domainFilter endpoint.DomainFilter, zoneIDFilter provider.ZoneIDFilter, zoneScope string, dryRun bool) *OCIProvider {
 	return &OCIProvider{
@@ -518,21 +547,25 @@ func TestOperationsByZone(t *testing.T) {
 
 	for _, tc := range testCases {
 		t.Run(tc.name, func(t *testing.T) {
-			result := operationsByZone(tc.zones, tc.ops)
+			result := (&OCIProvider{}).operationsByZone(tc.zones, tc.ops)
 			require.Equal(t, tc.expected, result)
 		})
 	}
 }
 
 type mutableMockOCIDNSClient struct {
-	zones   map[string]dns.ZoneSummary
-	records map[string]map[string]dns.Record
+	zones       map[string]dns.ZoneSummary
+	records     map[string]map[string]dns.Record
+	policies    map[string]dns.SteeringPolicy
+	attachments map[string]dns.SteeringPolicyAttachmentSummary
 }
 
 func newMutableMockOCIDNSClient(zones []dns.ZoneSummary, recordsByZone map[string][]dns.Record) *mutableMockOCIDNSClient {
 	c := &mutableMockOCIDNSClient{
-		zones:   make(map[string]dns.ZoneSummary),
-		records: make(map[string]map[string]dns.Record),
+		zones:       make(map[string]dns.ZoneSummary),
+		records:     make(map[string]map[string]dns.Record),
+		policies:    make(map[string]dns.SteeringPolicy),
+		attachments: make(map[string]dns.SteeringPolicyAttachmentSummary),
 	}
 
 	for _, zone := range zones {
@@ -629,6 +662,79 @@ func (c *mutableMockOCIDNSClient) PatchZoneRecords(ctx context.Context, request
 	return
 }
 
+func (c *mutableMockOCIDNSClient) ListSteeringPolicyAttachments(ctx context.Context, request dns.ListSteeringPolicyAttachmentsRequest) (response dns.ListSteeringPolicyAttachmentsResponse, err error) {
+	for _, a := range c.attachments {
+		if request.ZoneId == nil || *request.ZoneId == *a.ZoneId {
+			response.Items = append(response.Items, a)
+		}
+	}
+	return
+}
+
+func (c *mutableMockOCIDNSClient) GetSteeringPolicy(ctx context.Context, request dns.GetSteeringPolicyRequest) (response dns.GetSteeringPolicyResponse, err error) {
+	policy, ok := c.policies[*request.SteeringPolicyId]
+	if !ok {
+		err = errors.New("steering policy not found")
+		return
+	}
+	response.SteeringPolicy = policy
+	return
+}
+
+func (c *mutableMockOCIDNSClient) CreateSteeringPolicy(ctx context.Context, request dns.CreateSteeringPolicyRequest) (response dns.CreateSteeringPolicyResponse, err error) {
+	id := fmt.Sprintf("ocid1.dns-steering-policy.oc1..%d", len(c.policies))
+	c.policies[id] = dns.SteeringPolicy{
+		Id:            &id,
+		CompartmentId: request.CompartmentId,
+		DisplayName:   request.DisplayName,
+		Ttl:           request.Ttl,
+		FreeformTags:  request.FreeformTags,
+		Answers:       request.Answers,
+		Rules:         request.Rules,
+	}
+	response.SteeringPolicy = c.policies[id]
+	return
+}
+
+func (c *mutableMockOCIDNSClient) UpdateSteeringPolicy(ctx context.Context, request dns.UpdateSteeringPolicyRequest) (response dns.UpdateSteeringPolicyResponse, err error) {
+	policy, ok := c.policies[*request.SteeringPolicyId]
+	if !ok {
+		err = errors.New("steering policy not found")
+		return
+	}
+	policy.Ttl, policy.Answers, policy.Rules = request.Ttl, request.Answers, request.Rules
+	c.policies[*request.SteeringPolicyId] = policy
+	response.SteeringPolicy = policy
+	return
+}
+
+func (c *mutableMockOCIDNSClient) DeleteSteeringPolicy(ctx context.Context, request dns.DeleteSteeringPolicyRequest) (response dns.DeleteSteeringPolicyResponse, err error) {
+	for _, a := range c.attachments {
+		if *a.SteeringPolicyId == *request.SteeringPolicyId {
+			err = errors.New("steering policy is attached")
+			return
+		}
+	}
+	delete(c.policies, *request.SteeringPolicyId)
+	return
+}
+
+func (c *mutableMockOCIDNSClient) CreateSteeringPolicyAttachment(ctx context.Context, request dns.CreateSteeringPolicyAttachmentRequest) (response dns.CreateSteeringPolicyAttachmentResponse, err error) {
+	id := fmt.Sprintf("ocid1.dns-steering-policy-attachment.oc1..%d", len(c.attachments))
+	c.attachments[id] = dns.SteeringPolicyAttachmentSummary{
+		Id:               &id,
+		SteeringPolicyId: request.SteeringPolicyId,
+		ZoneId:           request.ZoneId,
+		DomainName:       request.DomainName,
+	}
+	return
+}
+
+func (c *mutableMockOCIDNSClient) DeleteSteeringPolicyAttachment(ctx context.Context, request dns.DeleteSteeringPolicyAttachmentRequest) (response dns.DeleteSteeringPolicyAttachmentResponse, err error) {
+	delete(c.attachments, *request.SteeringPolicyAttachmentId)
+	return
+}
+
 // TestMutableMockOCIDNSClient exists because one must always test one's tests
 // right...?
 func TestMutableMockOCIDNSClient(t *testing.T) {
@@ -1050,3 +1156,104 @@ func TestOCIApplyChanges(t *testing.T) {
 		})
 	}
 }
+
+func TestOCIPrivateViews(t *testing.T) {
+	zones := []dns.ZoneSummary{{
+		Id:     common.String("ocid1.dns-zone.oc1..view1"),
+		Name:   common.String("foo.com"),
+		Scope:  dns.ScopePrivate,
+		ViewId: common.String("ocid1.dnsview.oc1..view1"),
+	}, {
+		Id:     common.String("ocid1.dns-zone.oc1..view2"),
+		Name:   common.String("foo.com"),
+		Scope:  dns.ScopePrivate,
+		ViewId: common.String("ocid1.dnsview.oc1..view2"),
+	}}
+	records := map[string][]dns.Record{
+		"ocid1.dns-zone.oc1..view1": {{
+			Domain: common.String("foo.foo.com"),
+			Rdata:  common.String("10.0.0.1"),
+			Rtype:  common.String(endpoint.RecordTypeA),
+			Ttl:    common.Int(defaultTTL),
+		}},
+	}
+	client := newMutableMockOCIDNSClient(zones, records)
+	p := newOCIProvider(client, endpoint.NewDomainFilter([]string{""}), provider.NewZoneIDFilter([]string{""}), "", false)
+	ctx := context.Background()
+
+	endpoints, err := p.Records(ctx)
+	require.NoError(t, err)
+	require.ElementsMatch(t, []*endpoint.Endpoint{
+		endpoint.NewEndpointWithTTL("foo.foo.com", endpoint.RecordTypeA, endpoint.TTL(defaultTTL), "10.0.0.1").
+			WithProviderSpecific(ociViewIDProperty, "ocid1.dnsview.oc1..view1").
+			WithProviderSpecific(endpoint.DNSViewProperty, "ocid1.dnsview.oc1..view1"),
+	}, endpoints)
+
+	err = p.ApplyChanges(ctx, &plan.Changes{
+		Create: []*endpoint.Endpoint{
+			endpoint.NewEndpointWithTTL("foo.foo.com", endpoint.RecordTypeA, endpoint.TTL(defaultTTL), "10.0.0.2").
+				WithProviderSpecific(ociViewIDProperty, "ocid1.dnsview.oc1..view2"),
+		},
+	})
+	require.NoError(t, err)
+	require.Len(t, client.records["ocid1.dns-zone.oc1..view1"], 1)
+	require.Len(t, client.records["ocid1.dns-zone.oc1..view2"], 1)
+
+	endpoints, err = p.Records(ctx)
+	require.NoError(t, err)
+	require.ElementsMatch(t, []*endpoint.Endpoint{
+		endpoint.NewEndpointWithTTL("foo.foo.com", endpoint.RecordTypeA, endpoint.TTL(defaultTTL), "10.0.0.1").
+			WithProviderSpecific(ociViewIDProperty, "ocid1.dnsview.oc1..view1").
+			WithProviderSpecific(endpoint.DNSViewProperty, "ocid1.dnsview.oc1..view1"),
+		endpoint.NewEndpointWithTTL("foo.foo.com", endpoint.RecordTypeA, endpoint.TTL(defaultTTL), "10.0.0.2").
+			WithProviderSpecific(ociViewIDProperty, "ocid1.dnsview.oc1..view2").
+			WithProviderSpecific(endpoint.DNSViewProperty, "ocid1.dnsview.oc1..view2"),
+	}, endpoints)
+}
+
+func TestOCIAdjustEndpoints(t *testing.T) {
+	p := newOCIProvider(&mockOCIDNSClient{}, endpoint.NewDomainFilter([]string{""}), provider.NewZoneIDFilter([]string{""}), "", false)
+	p.lastZones = map[string]dns.ZoneSummary{
+		"private": {Id: common.String("private"), Name: common.String("private.com"), Scope: dns.ScopePrivate, ViewId: common.String("view")},
+		"global":  {Id: common.String("global"), Name: common.String("global.com"), Scope: dns.ScopeGlobal},
+	}
+
+	endpoints, err := p.AdjustEndpoints([]*endpoint.Endpoint{
+		endpoint.NewEndpoint("a.private.com", endpoint.RecordTypeA, "10.0.0.1"),
+		endpoint.NewEndpoint("b.private.com", endpoint.RecordTypeA, "10.0.0.1").WithProviderSpecific(ociViewIDProperty, "other"),
+		endpoint.NewEndpoint("a.global.com", endpoint.RecordTypeA, "1.2.3.4").WithSetIdentifier("us"),
+		endpoint.NewEndpoint("b.global.com", endpoint.RecordTypeA, "1.2.3.4").WithSetIdentifier("us").WithProviderSpecific(ociWeightProperty, "10"),
+		endpoint.NewEndpoint("c.global.com", endpoint.RecordTypeA, "1.2.3.4").WithSetIdentifier("us").WithProviderSpecific(ociWeightProperty, "1000"),
+		endpoint.NewEndpoint("d.global.com", endpoint.RecordTypeMX, "10 mail.global.com").WithSetIdentifier("us").WithProviderSpecific(ociWeightProperty, "10"),
+		endpoint.NewEndpoint("c.private.com", endpoint.RecordTypeA, "10.0.0.1").WithSetIdentifier("us").WithProviderSpecific(ociWeightProperty, "10"),
+		endpoint.NewEndpoint("d.private.com", endpoint.RecordTypeA, "10.0.0.1").WithProviderSpecific(endpoint.DNSViewProperty, "other"),
+		endpoint.NewEndpoint("e.private.com", endpoint.RecordTypeA, "10.0.0.1").WithProviderSpecific(endpoint.DNSViewProperty, "other").WithProviderSpecific(ociViewIDProperty, "view"),
+	})
+	require.NoError(t, err)
+	require.Equal(t, []*endpoint.Endpoint{
+		endpoint.NewEndpoint("a.private.com", endpoint.RecordTypeA, "10.0.0.1").WithProviderSpecific(ociViewIDProperty, "view").WithProviderSpecific(endpoint.DNSViewProperty, "view"),
+		endpoint.NewEndpoint("b.private.com", endpoint.RecordTypeA, "10.0.0.1").WithProviderSpecific(ociViewIDProperty, "other").WithProviderSpecific(endpoint.DNSViewProperty, "other"),
+		endpoint.NewEndpoint("a.global.com", endpoint.RecordTypeA, "1.2.3.4"),
+		endpoint.NewEndpoint("b.global.com", endpoint.RecordTypeA, "1.2.3.4").WithSetIdentifier("us").WithProviderSpecific(ociWeightProperty, "10"),
+		{DNSName: "c.global.com", RecordType: endpoint.RecordTypeA, Targets: endpoint.Targets{"1.2.3.4"}, Labels: endpoint.Labels{}, ProviderSpecific: endpoint.ProviderSpecific{}},
+		{DNSName: "d.global.com", RecordType: endpoint.RecordTypeMX, Targets: endpoint.Targets{"10 mail.global.com"}, Labels: endpoint.Labels{}, ProviderSpecific: endpoint.ProviderSpecific{}},
+		{DNSName: "c.private.com", RecordType: endpoint.RecordTypeA, Targets: endpoint.Targets{"10.0.0.1"}, Labels: endpoint.Labels{}, ProviderSpecific: endpoint.ProviderSpecific{{Name: ociViewIDProperty, Value: "view"}, {Name: endpoint.DNSViewProperty, Value: "view"}}},
+		endpoint.NewEndpoint("d.private.com", endpoint.RecordTypeA, "10.0.0.1").WithProviderSpecific(endpoint.DNSViewProperty, "other").WithProviderSpecific(ociViewIDProperty, "other"),
+		endpoint.NewEndpoint("e.private.com", endpoint.RecordTypeA, "10.0.0.1").WithProviderSpecific(endpoint.DNSViewProperty, "view").WithProviderSpecific(ociViewIDProperty, "view"),
+	}, endpoints)
+	require.True(t, p.SupportsViews())
+}
+
+func TestSplitSetIdentifierTXT(t *testing.T) {
+	for _, tc := range []struct {
+		rdata, expectedRdata, expectedSetIdentifier string
+	}{
+		{`"heritage=external-dns"`, `"heritage=external-dns"`, ""},
+		{`"heritage=external-dns" "external-dns/set-identifier=us-east"`, `"heritage=external-dns"`, "us-east"},
+		{`heritage=external-dns "external-dns/set-identifier=us-east"`, `heritage=external-dns`, "us-east"},
+	} {
+		rdata, setIdentifier := splitSetIdentifierTXT(tc.rdata)
+		require.Equal(t, tc.expectedRdata, rdata)
+		require.Equal(t, tc.expectedSetIdentifier, setIdentifier)
+	}
+}
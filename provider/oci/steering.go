@@ -0,0 +1,393 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package oci
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strconv"
+
+	"github.com/oracle/oci-go-sdk/v65/common"
+	"github.com/oracle/oci-go-sdk/v65/dns"
+	log "github.com/sirupsen/logrus"
+
+	"sigs.k8s.io/external-dns/endpoint"
+	"sigs.k8s.io/external-dns/plan"
+	"sigs.k8s.io/external-dns/provider"
+)
+
+const (
+	// steeringPolicyTag is the freeform tag marking the steering policies managed by ExternalDNS.
+	steeringPolicyTag = "external-dns"
+	// maxWeight is the highest weight accepted by OCI weighted steering rules.
+	maxWeight = 255
+)
+
+// steeringPolicy is a load balancing steering policy serving the weighted records of a domain.
+type steeringPolicy struct {
+	id           string
+	attachmentID string
+	zoneID       string
+	domain       string
+	records      map[endpoint.EndpointKey]*endpoint.Endpoint
+}
+
+// isWeighted returns true if the endpoint is one of the weighted records of its domain.
+func isWeighted(ep *endpoint.Endpoint) bool {
+	_, ok := ep.GetProviderSpecificProperty(ociWeightProperty)
+	return ok && ep.SetIdentifier != ""
+}
+
+// isSteerableType returns true if records of the given type can be served by a steering policy.
+func isSteerableType(recordType string) bool {
+	switch recordType {
+	case endpoint.RecordTypeA, endpoint.RecordTypeAAAA, endpoint.RecordTypeCNAME:
+		return true
+	default:
+		return false
+	}
+}
+
+// isSteered returns true if the endpoint is managed through a steering policy.
+func isSteered(ep *endpoint.Endpoint) bool {
+	return isWeighted(ep) && isSteerableType(ep.RecordType)
+}
+
+func hasSteeredChanges(changes *plan.Changes) bool {
+	for _, endpoints := range [][]*endpoint.Endpoint{changes.Create, changes.UpdateOld, changes.UpdateNew, changes.Delete} {
+		for _, ep := range endpoints {
+			if ep != nil && isSteered(ep) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func validateWeight(ep *endpoint.Endpoint, weight string) error {
+	if ep.SetIdentifier == "" {
+		return fmt.Errorf("weighted records require a set identifier")
+	}
+	if !isSteerableType(ep.RecordType) {
+		return fmt.Errorf("weighted %s records are not supported", ep.RecordType)
+	}
+	if _, ok := ep.GetProviderSpecificProperty(ociViewIDProperty); ok {
+		return fmt.Errorf("weighted records are not supported in private zones")
+	}
+	w, err := strconv.Atoi(weight)
+	if err != nil || w < 0 || w > maxWeight {
+		return fmt.Errorf("weight %q must be an integer between 0 and %d", weight, maxWeight)
+	}
+	return nil
+}
+
+// poolCondition returns the answer condition selecting the answers of a set identifier.
+func poolCondition(setIdentifier string) string {
+	return fmt.Sprintf("answer.pool == '%s'", setIdentifier)
+}
+
+// steeringPolicies returns the steering policies managed by ExternalDNS attached to the
+// given zones, indexed by zone ID and domain. Private zones do not support steering policies.
+func (p *OCIProvider) steeringPolicies(ctx context.Context, zones map[string]dns.ZoneSummary) (map[string]*steeringPolicy, error) {
+	policies := make(map[string]*steeringPolicy)
+	for _, zone := range zones {
+		if zone.Scope == dns.ScopePrivate {
+			continue
+		}
+		var page *string
+		for {
+			resp, err := p.client.ListSteeringPolicyAttachments(ctx, dns.ListSteeringPolicyAttachmentsRequest{
+				CompartmentId: &p.cfg.CompartmentID,
+				ZoneId:        zone.Id,
+				Page:          page,
+			})
+			if err != nil {
+				return nil, provider.NewSoftError(fmt.Errorf("listing steering policy attachments for zone %q: %w", *zone.Id, err))
+			}
+			for _, attachment := range resp.Items {
+				policy, err := p.steeringPolicy(ctx, attachment)
+				if err != nil {
+					return nil, err
+				}
+				if policy != nil {
+					policies[policy.zoneID+"/"+policy.domain] = policy
+				}
+			}
+			if page = resp.OpcNextPage; resp.OpcNextPage == nil {
+				break
+			}
+		}
+	}
+	return policies, nil
+}
+
+// steeringPolicy returns the steering policy of the given attachment, or nil if the policy
+// is not managed by ExternalDNS.
+func (p *OCIProvider) steeringPolicy(ctx context.Context, attachment dns.SteeringPolicyAttachmentSummary) (*steeringPolicy, error) {
+	resp, err := p.client.GetSteeringPolicy(ctx, dns.GetSteeringPolicyRequest{SteeringPolicyId: attachment.SteeringPolicyId})
+	if err != nil {
+		return nil, provider.NewSoftError(fmt.Errorf("getting steering policy %q: %w", *attachment.SteeringPolicyId, err))
+	}
+	if _, ok := resp.FreeformTags[steeringPolicyTag]; !ok {
+		log.Debugf("Ignoring steering policy %q not managed by ExternalDNS", *attachment.SteeringPolicyId)
+		return nil, nil
+	}
+
+	weights := map[string]int{}
+	for _, rule := range resp.Rules {
+		if weighted, ok := rule.(dns.SteeringPolicyWeightedRule); ok {
+			for _, data := range weighted.DefaultAnswerData {
+				if data.AnswerCondition != nil && data.Value != nil {
+					weights[*data.AnswerCondition] = *data.Value
+				}
+			}
+		}
+	}
+
+	ttl := endpoint.TTL(defaultTTL)
+	if resp.Ttl != nil {
+		ttl = endpoint.TTL(*resp.Ttl)
+	}
+
+	policy := &steeringPolicy{
+		id:           *attachment.SteeringPolicyId,
+		attachmentID: *attachment.Id,
+		zoneID:       *attachment.ZoneId,
+		domain:       *attachment.DomainName,
+		records:      map[endpoint.EndpointKey]*endpoint.Endpoint{},
+	}
+	for _, answer := range resp.Answers {
+		if answer.Pool == nil {
+			continue
+		}
+		key := endpoint.EndpointKey{DNSName: policy.domain, RecordType: *answer.Rtype, SetIdentifier: *answer.Pool}
+		if ep, ok := policy.records[key]; ok {
+			ep.Targets = append(ep.Targets, *answer.Rdata)
+			continue
+		}
+		policy.records[key] = endpoint.NewEndpointWithTTL(policy.domain, *answer.Rtype, ttl, *answer.Rdata).
+			WithSetIdentifier(*answer.Pool).
+			WithProviderSpecific(ociWeightProperty, strconv.Itoa(weights[poolCondition(*answer.Pool)]))
+	}
+	return policy, nil
+}
+
+// endpoints returns the weighted records of the policy, sorted by set identifier and type.
+func (s *steeringPolicy) endpoints() []*endpoint.Endpoint {
+	endpoints := make([]*endpoint.Endpoint, 0, len(s.records))
+	for _, ep := range s.records {
+		endpoints = append(endpoints, ep)
+	}
+	sort.Slice(endpoints, func(i, j int) bool {
+		if endpoints[i].SetIdentifier != endpoints[j].SetIdentifier {
+			return endpoints[i].SetIdentifier < endpoints[j].SetIdentifier
+		}
+		return endpoints[i].RecordType < endpoints[j].RecordType
+	})
+	return endpoints
+}
+
+// ttl returns the TTL of the policy, taken from the first of its records with one.
+func (s *steeringPolicy) ttl() int {
+	for _, ep := range s.endpoints() {
+		if ep.RecordTTL.IsConfigured() {
+			return int(ep.RecordTTL)
+		}
+	}
+	return defaultTTL
+}
+
+// answers returns the answers and rules load balancing the records of the policy by weight.
+func (s *steeringPolicy) answers() ([]dns.SteeringPolicyAnswer, []dns.SteeringPolicyRule) {
+	var answers []dns.SteeringPolicyAnswer
+	var weights []dns.SteeringPolicyWeightedAnswerData
+	pools := map[string]bool{}
+	for _, ep := range s.endpoints() {
+		for i, target := range ep.Targets {
+			rdata := target
+			if ep.RecordType == endpoint.RecordTypeCNAME {
+				rdata = provider.EnsureTrailingDot(rdata)
+			}
+			answers = append(answers, dns.SteeringPolicyAnswer{
+				Name:  common.String(fmt.Sprintf("%s %s %d", ep.SetIdentifier, ep.RecordType, i)),
+				Rtype: common.String(ep.RecordType),
+				Rdata: common.String(rdata),
+				Pool:  common.String(ep.SetIdentifier),
+			})
+		}
+		if pools[ep.SetIdentifier] {
+			continue
+		}
+		pools[ep.SetIdentifier] = true
+		weight, _ := ep.GetProviderSpecificProperty(ociWeightProperty)
+		w, _ := strconv.Atoi(weight)
+		weights = append(weights, dns.SteeringPolicyWeightedAnswerData{
+			AnswerCondition: common.String(poolCondition(ep.SetIdentifier)),
+			Value:           common.Int(w),
+		})
+	}
+
+	rules := []dns.SteeringPolicyRule{
+		dns.SteeringPolicyFilterRule{
+			DefaultAnswerData: []dns.SteeringPolicyFilterAnswerData{{
+				AnswerCondition: common.String("answer.isDisabled != true"),
+				ShouldKeep:      common.Bool(true),
+			}},
+		},
+		dns.SteeringPolicyWeightedRule{DefaultAnswerData: weights},
+		dns.SteeringPolicyLimitRule{DefaultCount: common.Int(1)},
+	}
+	return answers, rules
+}
+
+// applySteeringChanges applies the changes of weighted records to the steering policies of their domains.
+func (p *OCIProvider) applySteeringChanges(ctx context.Context, zones map[string]dns.ZoneSummary, changes *plan.Changes) error {
+	if !hasSteeredChanges(changes) {
+		return nil
+	}
+
+	policies, err := p.steeringPolicies(ctx, zones)
+	if err != nil {
+		return err
+	}
+
+	zoneNameIDMapper := p.NewZoneFinder()
+	for _, z := range zones {
+		if z.Scope != dns.ScopePrivate {
+			zoneNameIDMapper.Add(*z.Id, *z.Name)
+		}
+	}
+	changed := map[string]*steeringPolicy{}
+	policyOf := func(ep *endpoint.Endpoint) *steeringPolicy {
+		zoneID, _ := zoneNameIDMapper.FindZone(ep.DNSName)
+		if zoneID == "" {
+			log.Warnf("No matching zone for weighted record %s %s (%s)", ep.RecordType, ep.DNSName, ep.SetIdentifier)
+			return nil
+		}
+		key := zoneID + "/" + ep.DNSName
+		if _, ok := policies[key]; !ok {
+			policies[key] = &steeringPolicy{zoneID: zoneID, domain: ep.DNSName, records: map[endpoint.EndpointKey]*endpoint.Endpoint{}}
+		}
+		changed[key] = policies[key]
+		return policies[key]
+	}
+
+	for _, endpoints := range [][]*endpoint.Endpoint{changes.Delete, changes.UpdateOld} {
+		for _, ep := range endpoints {
+			if ep == nil || !isSteered(ep) || !p.domainFilter.Match(ep.DNSName) {
+				continue
+			}
+			if policy := policyOf(ep); policy != nil {
+				delete(policy.records, ep.Key())
+			}
+		}
+	}
+	for _, endpoints := range [][]*endpoint.Endpoint{changes.Create, changes.UpdateNew} {
+		for _, ep := range endpoints {
+			if ep == nil || !isSteered(ep) || !p.domainFilter.Match(ep.DNSName) {
+				continue
+			}
+			if policy := policyOf(ep); policy != nil {
+				policy.records[ep.Key()] = ep
+			}
+		}
+	}
+
+	keys := make([]string, 0, len(changed))
+	for key := range changed {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		if err := p.applySteeringPolicy(ctx, changed[key]); err != nil {
+			return provider.NewSoftError(err)
+		}
+	}
+	return nil
+}
+
+// applySteeringPolicy creates, updates or deletes a steering policy and its attachment to
+// match the weighted records of its domain.
+func (p *OCIProvider) applySteeringPolicy(ctx context.Context, policy *steeringPolicy) error {
+	if len(policy.records) == 0 {
+		if policy.id == "" {
+			return nil
+		}
+		log.Infof("Deleting steering policy %q of %s in zone %q", policy.id, policy.domain, policy.zoneID)
+		if p.dryRun {
+			return nil
+		}
+		if _, err := p.client.DeleteSteeringPolicyAttachment(ctx, dns.DeleteSteeringPolicyAttachmentRequest{SteeringPolicyAttachmentId: &policy.attachmentID}); err != nil {
+			return fmt.Errorf("deleting steering policy attachment %q: %w", policy.attachmentID, err)
+		}
+		if _, err := p.client.DeleteSteeringPolicy(ctx, dns.DeleteSteeringPolicyRequest{SteeringPolicyId: &policy.id}); err != nil {
+			return fmt.Errorf("deleting steering policy %q: %w", policy.id, err)
+		}
+		return nil
+	}
+
+	answers, rules := policy.answers()
+	if policy.id != "" {
+		log.Infof("Updating steering policy %q of %s in zone %q with answers %v", policy.id, policy.domain, policy.zoneID, answers)
+		if p.dryRun {
+			return nil
+		}
+		if _, err := p.client.UpdateSteeringPolicy(ctx, dns.UpdateSteeringPolicyRequest{
+			SteeringPolicyId: &policy.id,
+			UpdateSteeringPolicyDetails: dns.UpdateSteeringPolicyDetails{
+				Ttl:      common.Int(policy.ttl()),
+				Template: dns.UpdateSteeringPolicyDetailsTemplateLoadBalance,
+				Answers:  answers,
+				Rules:    rules,
+			},
+		}); err != nil {
+			return fmt.Errorf("updating steering policy %q: %w", policy.id, err)
+		}
+		return nil
+	}
+
+	log.Infof("Creating steering policy for %s in zone %q with answers %v", policy.domain, policy.zoneID, answers)
+	if p.dryRun {
+		return nil
+	}
+	resp, err := p.client.CreateSteeringPolicy(ctx, dns.CreateSteeringPolicyRequest{
+		CreateSteeringPolicyDetails: dns.CreateSteeringPolicyDetails{
+			CompartmentId: &p.cfg.CompartmentID,
+			DisplayName:   common.String(policy.domain),
+			Ttl:           common.Int(policy.ttl()),
+			Template:      dns.CreateSteeringPolicyDetailsTemplateLoadBalance,
+			FreeformTags:  map[string]string{steeringPolicyTag: "true"},
+			Answers:       answers,
+			Rules:         rules,
+		},
+	})
+	if err != nil {
+		return fmt.Errorf("creating steering policy for %s: %w", policy.domain, err)
+	}
+	if _, err := p.client.CreateSteeringPolicyAttachment(ctx, dns.CreateSteeringPolicyAttachmentRequest{
+		CreateSteeringPolicyAttachmentDetails: dns.CreateSteeringPolicyAttachmentDetails{
+			SteeringPolicyId: resp.Id,
+			ZoneId:           &policy.zoneID,
+			DomainName:       &policy.domain,
+			DisplayName:      common.String(policy.domain),
+		},
+	}); err != nil {
+		return fmt.Errorf("attaching steering policy %q to %s: %w", *resp.Id, policy.domain, err)
+	}
+	return nil
+}
@@ -0,0 +1,145 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package oci
+
+import (
+	"context"
+	"testing"
+
+	"github.com/oracle/oci-go-sdk/v65/common"
+	"github.com/oracle/oci-go-sdk/v65/dns"
+	"github.com/stretchr/testify/require"
+
+	"sigs.k8s.io/external-dns/endpoint"
+	"sigs.k8s.io/external-dns/plan"
+	"sigs.k8s.io/external-dns/provider"
+)
+
+func newWeightedEndpoint(setIdentifier, weight string, targets ...string) *endpoint.Endpoint {
+	return endpoint.NewEndpointWithTTL("www.foo.com", endpoint.RecordTypeA, endpoint.TTL(60), targets...).
+		WithSetIdentifier(setIdentifier).
+		WithProviderSpecific(ociWeightProperty, weight)
+}
+
+func TestOCISteeringPolicies(t *testing.T) {
+	zones := []dns.ZoneSummary{{
+		Id:    common.String("ocid1.dns-zone.oc1..foo"),
+		Name:  common.String("foo.com"),
+		Scope: dns.ScopeGlobal,
+	}}
+	client := newMutableMockOCIDNSClient(zones, nil)
+	p := newOCIProvider(client, endpoint.NewDomainFilter([]string{""}), provider.NewZoneIDFilter([]string{""}), "", false)
+	ctx := context.Background()
+
+	// Create two weighted records, along with the TXT record of one of them.
+	txt := endpoint.NewEndpoint("a-www.foo.com", endpoint.RecordTypeTXT, `"heritage=external-dns"`).
+		WithSetIdentifier("us").
+		WithProviderSpecific(ociWeightProperty, "10")
+	err := p.ApplyChanges(ctx, &plan.Changes{
+		Create: []*endpoint.Endpoint{
+			newWeightedEndpoint("us", "10", "1.1.1.1", "2.2.2.2"),
+			newWeightedEndpoint("eu", "20", "3.3.3.3"),
+			txt,
+		},
+	})
+	require.NoError(t, err)
+	require.Len(t, client.policies, 1)
+	require.Len(t, client.attachments, 1)
+	for _, policy := range client.policies {
+		require.Equal(t, 60, *policy.Ttl)
+		require.Len(t, policy.Answers, 3)
+		require.Equal(t, dns.SteeringPolicyWeightedRule{DefaultAnswerData: []dns.SteeringPolicyWeightedAnswerData{
+			{AnswerCondition: common.String("answer.pool == 'eu'"), Value: common.Int(20)},
+			{AnswerCondition: common.String("answer.pool == 'us'"), Value: common.Int(10)},
+		}}, policy.Rules[1])
+	}
+
+	endpoints, err := p.Records(ctx)
+	require.NoError(t, err)
+	sortEndpointTargets(endpoints)
+	require.ElementsMatch(t, []*endpoint.Endpoint{
+		newWeightedEndpoint("us", "10", "1.1.1.1", "2.2.2.2"),
+		newWeightedEndpoint("eu", "20", "3.3.3.3"),
+		endpoint.NewEndpointWithTTL("a-www.foo.com", endpoint.RecordTypeTXT, endpoint.TTL(defaultTTL), `"heritage=external-dns"`).WithSetIdentifier("us"),
+	}, endpoints)
+
+	// Change the weight of one set identifier.
+	err = p.ApplyChanges(ctx, &plan.Changes{
+		UpdateOld: []*endpoint.Endpoint{newWeightedEndpoint("eu", "20", "3.3.3.3")},
+		UpdateNew: []*endpoint.Endpoint{newWeightedEndpoint("eu", "50", "3.3.3.3")},
+	})
+	require.NoError(t, err)
+	endpoints, err = p.Records(ctx)
+	require.NoError(t, err)
+	sortEndpointTargets(endpoints)
+	require.Contains(t, endpoints, newWeightedEndpoint("eu", "50", "3.3.3.3"))
+	require.Len(t, endpoints, 3)
+
+	// Delete all weighted records, removing the steering policy.
+	err = p.ApplyChanges(ctx, &plan.Changes{
+		Delete: []*endpoint.Endpoint{
+			newWeightedEndpoint("us", "10", "1.1.1.1", "2.2.2.2"),
+			newWeightedEndpoint("eu", "50", "3.3.3.3"),
+			txt,
+		},
+	})
+	require.NoError(t, err)
+	require.Empty(t, client.policies)
+	require.Empty(t, client.attachments)
+	endpoints, err = p.Records(ctx)
+	require.NoError(t, err)
+	require.Empty(t, endpoints)
+}
+
+func TestOCISteeringPoliciesDryRun(t *testing.T) {
+	zones := []dns.ZoneSummary{{
+		Id:   common.String("ocid1.dns-zone.oc1..foo"),
+		Name: common.String("foo.com"),
+	}}
+	client := newMutableMockOCIDNSClient(zones, nil)
+	p := newOCIProvider(client, endpoint.NewDomainFilter([]string{""}), provider.NewZoneIDFilter([]string{""}), "", true)
+
+	err := p.ApplyChanges(context.Background(), &plan.Changes{
+		Create: []*endpoint.Endpoint{newWeightedEndpoint("us", "10", "1.1.1.1")},
+	})
+	require.NoError(t, err)
+	require.Empty(t, client.policies)
+	require.Empty(t, client.attachments)
+}
+
+func TestOCISteeringPoliciesIgnoresUnmanaged(t *testing.T) {
+	zones := []dns.ZoneSummary{{
+		Id:   common.String("ocid1.dns-zone.oc1..foo"),
+		Name: common.String("foo.com"),
+	}}
+	client := newMutableMockOCIDNSClient(zones, nil)
+	client.policies["policy"] = dns.SteeringPolicy{
+		Id:      common.String("policy"),
+		Answers: []dns.SteeringPolicyAnswer{{Name: common.String("a"), Rtype: common.String("A"), Rdata: common.String("1.1.1.1"), Pool: common.String("a")}},
+	}
+	client.attachments["attachment"] = dns.SteeringPolicyAttachmentSummary{
+		Id:               common.String("attachment"),
+		SteeringPolicyId: common.String("policy"),
+		ZoneId:           common.String("ocid1.dns-zone.oc1..foo"),
+		DomainName:       common.String("www.foo.com"),
+	}
+	p := newOCIProvider(client, endpoint.NewDomainFilter([]string{""}), provider.NewZoneIDFilter([]string{""}), "", false)
+
+	endpoints, err := p.Records(context.Background())
+	require.NoError(t, err)
+	require.Empty(t, endpoints)
+}
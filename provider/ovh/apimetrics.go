@@ -0,0 +1,114 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package ovh
+
+import (
+	"net/http"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+
+	"sigs.k8s.io/external-dns/pkg/metrics"
+)
+
+var (
+	apiCallsTotal = metrics.NewCounterVecWithOpts(
+		prometheus.CounterOpts{
+			Namespace: "external_dns",
+			Subsystem: "ovh",
+			Name:      "api_calls_total",
+			Help:      "Number of calls to the OVHcloud API, by method, path and status code, or error when no response was received.",
+		},
+		[]string{"method", "path", "code"},
+	)
+	apiCallDuration = metrics.NewHistogramVecWithOpts(
+		prometheus.HistogramOpts{
+			Namespace: "external_dns",
+			Subsystem: "ovh",
+			Name:      "api_call_duration_seconds",
+			Help:      "Duration of the calls to the OVHcloud API, by method and path.",
+			Buckets:   prometheus.DefBuckets,
+		},
+		[]string{"method", "path"},
+	)
+	apiRateLimitWait = metrics.NewHistogramVecWithOpts(
+		prometheus.HistogramOpts{
+			Namespace: "external_dns",
+			Subsystem: "ovh",
+			Name:      "api_rate_limit_wait_seconds",
+			Help:      "Time the calls to the OVHcloud API waited for the rate limiter.",
+			Buckets:   prometheus.ExponentialBuckets(0.001, 4, 10),
+		},
+		nil,
+	)
+)
+
+func init() {
+	metrics.RegisterMetric.MustRegister(apiCallsTotal)
+	metrics.RegisterMetric.MustRegister(apiCallDuration)
+	metrics.RegisterMetric.MustRegister(apiRateLimitWait)
+}
+
+// apiVersionSegment matches the first segment of the paths of the API endpoints, their version.
+var apiVersionSegment = regexp.MustCompile(`^(v[0-9]+|[0-9]+\.[0-9]+)$`)
+
+// apiPathParameters are the placeholders of the segments of the API paths following the given ones.
+var apiPathParameters = map[string]string{
+	"zone":   "{zone}",
+	"record": "{id}",
+	"cart":   "{cartId}",
+	"item":   "{itemId}",
+}
+
+// apiPath returns the path of an API call without its version, with its parameters replaced by
+// placeholders so that the number of paths reported is bounded, e.g. /domain/zone/{zone}/record/{id}.
+func apiPath(path string) string {
+	segments := strings.Split(strings.Trim(path, "/"), "/")
+	if len(segments) > 0 && apiVersionSegment.MatchString(segments[0]) {
+		segments = segments[1:]
+	}
+	for i := 1; i < len(segments); i++ {
+		// the previous segment is already replaced when it is a parameter, e.g. a zone named record
+		if placeholder, ok := apiPathParameters[segments[i-1]]; ok {
+			segments[i] = placeholder
+		} else if strings.Trim(segments[i], "0123456789"+batchSeparator) == "" {
+			segments[i] = "{id}"
+		}
+	}
+	return "/" + strings.Join(segments, "/")
+}
+
+// metricsTransport reports the calls to the API, their status code and their duration.
+type metricsTransport struct {
+	next http.RoundTripper
+}
+
+func (t *metricsTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	path := apiPath(req.URL.Path)
+	start := time.Now()
+	resp, err := t.next.RoundTrip(req)
+	apiCallDuration.HistogramVec.WithLabelValues(req.Method, path).Observe(time.Since(start).Seconds())
+	code := "error"
+	if err == nil {
+		code = strconv.Itoa(resp.StatusCode)
+	}
+	apiCallsTotal.CounterVec.WithLabelValues(req.Method, path, code).Inc()
+	return resp, err
+}
@@ -0,0 +1,111 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package ovh
+
+import (
+	"errors"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAPIPath(t *testing.T) {
+	for _, tt := range []struct {
+		path     string
+		expected string
+	}{
+		{"/1.0/domain/zone", "/domain/zone"},
+		{"/1.0/domain/zone/example.org/record", "/domain/zone/{zone}/record"},
+		{"/1.0/domain/zone/example.org/record/42", "/domain/zone/{zone}/record/{id}"},
+		{"/1.0/domain/zone/example.org/record/1,2,3", "/domain/zone/{zone}/record/{id}"},
+		{"/1.0/domain/zone/record/record", "/domain/zone/{zone}/record"},
+		{"/v1/domain/zone/example.org/refresh", "/domain/zone/{zone}/refresh"},
+		{"/1.0/order/cart/abc-def/item/7/configuration", "/order/cart/{cartId}/item/{itemId}/configuration"},
+		{"/1.0/me", "/me"},
+	} {
+		t.Run(tt.path, func(t *testing.T) {
+			assert.Equal(t, tt.expected, apiPath(tt.path))
+		})
+	}
+}
+
+type roundTripFunc func(*http.Request) (*http.Response, error)
+
+func (f roundTripFunc) RoundTrip(req *http.Request) (*http.Response, error) {
+	return f(req)
+}
+
+func TestMetricsTransport(t *testing.T) {
+	failed := errors.New("connection refused")
+	transport := &metricsTransport{next: roundTripFunc(func(req *http.Request) (*http.Response, error) {
+		if req.Method == http.MethodDelete {
+			return nil, failed
+		}
+		return &http.Response{StatusCode: http.StatusTooManyRequests}, nil
+	})}
+
+	calls := apiCallsTotal.CounterVec.WithLabelValues(http.MethodGet, "/domain/zone/{zone}/soa", "429")
+	errored := apiCallsTotal.CounterVec.WithLabelValues(http.MethodDelete, "/domain/zone/{zone}/record/{id}", "error")
+	before, beforeErrored := testutil.ToFloat64(calls), testutil.ToFloat64(errored)
+
+	req, err := http.NewRequest(http.MethodGet, "https://eu.api.ovh.com/1.0/domain/zone/example.org/soa", nil)
+	require.NoError(t, err)
+	resp, err := transport.RoundTrip(req)
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusTooManyRequests, resp.StatusCode)
+
+	req, err = http.NewRequest(http.MethodDelete, "https://eu.api.ovh.com/1.0/domain/zone/example.org/record/42", nil)
+	require.NoError(t, err)
+	_, err = transport.RoundTrip(req)
+	assert.ErrorIs(t, err, failed)
+
+	assert.InDelta(t, before+1, testutil.ToFloat64(calls), 0)
+	assert.InDelta(t, beforeErrored+1, testutil.ToFloat64(errored), 0)
+	assert.Positive(t, testutil.CollectAndCount(apiCallDuration.HistogramVec, "external_dns_ovh_api_call_duration_seconds"))
+}
+
+func TestAdaptiveLimiterWaitMetric(t *testing.T) {
+	l, now, _ := newTestAdaptiveLimiter()
+	l.pausedUntil = now.Add(time.Minute)
+
+	apiRateLimitWait.HistogramVec.Reset()
+	require.NoError(t, l.Take(t.Context()))
+
+	expected := `
+# HELP external_dns_ovh_api_rate_limit_wait_seconds Time the calls to the OVHcloud API waited for the rate limiter.
+# TYPE external_dns_ovh_api_rate_limit_wait_seconds histogram
+external_dns_ovh_api_rate_limit_wait_seconds_bucket{le="0.001"} 0
+external_dns_ovh_api_rate_limit_wait_seconds_bucket{le="0.004"} 0
+external_dns_ovh_api_rate_limit_wait_seconds_bucket{le="0.016"} 0
+external_dns_ovh_api_rate_limit_wait_seconds_bucket{le="0.064"} 0
+external_dns_ovh_api_rate_limit_wait_seconds_bucket{le="0.256"} 0
+external_dns_ovh_api_rate_limit_wait_seconds_bucket{le="1.024"} 0
+external_dns_ovh_api_rate_limit_wait_seconds_bucket{le="4.096"} 0
+external_dns_ovh_api_rate_limit_wait_seconds_bucket{le="16.384"} 0
+external_dns_ovh_api_rate_limit_wait_seconds_bucket{le="65.536"} 1
+external_dns_ovh_api_rate_limit_wait_seconds_bucket{le="262.144"} 1
+external_dns_ovh_api_rate_limit_wait_seconds_bucket{le="+Inf"} 1
+external_dns_ovh_api_rate_limit_wait_seconds_sum 60
+external_dns_ovh_api_rate_limit_wait_seconds_count 1
+`
+	require.NoError(t, testutil.CollectAndCompare(apiRateLimitWait.HistogramVec, strings.NewReader(expected)))
+}
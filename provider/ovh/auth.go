@@ -0,0 +1,139 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package ovh
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/ovh/go-ovh/ovh"
+	log "github.com/sirupsen/logrus"
+	"gopkg.in/ini.v1"
+
+	"sigs.k8s.io/external-dns/pkg/secrets"
+)
+
+const (
+	// AuthMethodApplication authenticates with the key and secret of an application, and a consumer key.
+	AuthMethodApplication = "application"
+	// AuthMethodOAuth2 authenticates with the client ID and secret of a service account, getting short-lived
+	// access tokens with the OAuth2 client credentials flow.
+	AuthMethodOAuth2 = "oauth2"
+)
+
+// ovhCredentials are the credentials of the API client, those left empty being loaded by the client from the
+// environment or its configuration files.
+type ovhCredentials struct {
+	appKey       string
+	appSecret    string
+	consumerKey  string
+	clientID     string
+	clientSecret string
+}
+
+// lookupCredentials returns the credentials configured as secrets, overridden by those of the credentials file
+// when set. The file has the format of the ovh.conf configuration file: its keys, e.g. consumer_key, are read
+// from the section of the endpoint, else from outside of any section.
+func lookupCredentials(ctx context.Context, file, endpoint string) (ovhCredentials, error) {
+	var creds ovhCredentials
+	fields := []struct {
+		name, key string
+		value     *string
+	}{
+		{"OVH_APPLICATION_KEY", "application_key", &creds.appKey},
+		{"OVH_APPLICATION_SECRET", "application_secret", &creds.appSecret},
+		{"OVH_CONSUMER_KEY", "consumer_key", &creds.consumerKey},
+		{"OVH_CLIENT_ID", "client_id", &creds.clientID},
+		{"OVH_CLIENT_SECRET", "client_secret", &creds.clientSecret},
+	}
+	for _, field := range fields {
+		*field.value, _ = secrets.Lookup(ctx, field.name)
+	}
+	if file == "" {
+		return creds, nil
+	}
+
+	cfg, err := ini.Load(file)
+	if err != nil {
+		return ovhCredentials{}, fmt.Errorf("failed to read the credentials file %s: %w", file, err)
+	}
+	for _, field := range fields {
+		value := cfg.Section(endpoint).Key(field.key).String()
+		if value == "" {
+			value = cfg.Section(ini.DefaultSection).Key(field.key).String()
+		}
+		if value != "" {
+			*field.value = value
+		}
+	}
+	return creds, nil
+}
+
+// newOVHClient returns a client of the API at endpoint, authenticated with authMethod.
+func newOVHClient(endpoint, authMethod string, creds ovhCredentials) (*ovh.Client, error) {
+	if authMethod == AuthMethodOAuth2 {
+		return ovh.NewOAuth2Client(endpoint, creds.clientID, creds.clientSecret)
+	}
+	return ovh.NewClient(endpoint, creds.appKey, creds.appSecret, creds.consumerKey)
+}
+
+// refreshCredentials picks up the credentials configured as secrets or in the credentials file, which may have
+// been rotated. The client of rotated OAuth2 client credentials is replaced, its access tokens being those of
+// the former ones.
+func (p *OVHProvider) refreshCredentials(ctx context.Context) {
+	var client *ovh.Client
+	switch c := p.client.(type) {
+	case *ovh.Client:
+		client = c
+	case apiClient:
+		client = c.Client
+	default:
+		return
+	}
+
+	creds, err := lookupCredentials(ctx, p.credentialsFile, p.endpoint)
+	if err != nil {
+		log.Warnf("OVH: Keeping the former credentials: %v", err)
+		return
+	}
+
+	if p.authMethod == AuthMethodOAuth2 {
+		if (creds.clientID == "" || creds.clientID == client.ClientID) && (creds.clientSecret == "" || creds.clientSecret == client.ClientSecret) {
+			return
+		}
+		rotated, err := newOVHClient(p.endpoint, p.authMethod, creds)
+		if err != nil {
+			log.Warnf("OVH: Failed to authenticate with the rotated OAuth2 client credentials, keeping the former ones: %v", err)
+			return
+		}
+		rotated.UserAgent = client.UserAgent
+		rotated.Client = client.Client
+		p.client = apiClient{rotated}
+		log.Info("OVH: Authenticating with the rotated OAuth2 client credentials")
+		return
+	}
+
+	if creds.appKey != "" {
+		client.AppKey = creds.appKey
+	}
+	if creds.appSecret != "" {
+		client.AppSecret = creds.appSecret
+	}
+	if creds.consumerKey != "" {
+		client.ConsumerKey = creds.consumerKey
+	}
+}
@@ -0,0 +1,102 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package ovh
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"sigs.k8s.io/external-dns/endpoint"
+	"sigs.k8s.io/external-dns/pkg/secrets"
+)
+
+func TestNewOvhProviderOAuth2(t *testing.T) {
+	var domainFilter endpoint.DomainFilter
+
+	// both the client ID and secret are required
+	t.Setenv("OVH_CLIENT_ID", "client-id")
+	_, err := NewOVHProvider(t.Context(), OVHConfig{DomainFilter: domainFilter, Endpoint: "ovh-eu", APIRateLimit: 20, DryRun: true, RecordsFetchMode: RecordsFetchModeRecord, MaxAttempts: 3, RetryBackoff: time.Second, UseCache: true, AuthMethod: AuthMethodOAuth2})
+	require.Error(t, err)
+
+	t.Setenv("OVH_CLIENT_SECRET", "client-secret")
+	p, err := NewOVHProvider(t.Context(), OVHConfig{DomainFilter: domainFilter, Endpoint: "ovh-eu", APIRateLimit: 20, DryRun: true, RecordsFetchMode: RecordsFetchModeRecord, MaxAttempts: 3, RetryBackoff: time.Second, UseCache: true, AuthMethod: AuthMethodOAuth2})
+	require.NoError(t, err)
+	client := p.client.(apiClient).Client
+	assert.Equal(t, "client-id", client.ClientID)
+	assert.Equal(t, "client-secret", client.ClientSecret)
+	assert.Empty(t, client.AppKey)
+
+	// the OAuth2 flow is not available on every endpoint
+	_, err = NewOVHProvider(t.Context(), OVHConfig{DomainFilter: domainFilter, Endpoint: "kimsufi-eu", APIRateLimit: 20, DryRun: true, RecordsFetchMode: RecordsFetchModeRecord, MaxAttempts: 3, RetryBackoff: time.Second, UseCache: true, AuthMethod: AuthMethodOAuth2})
+	require.Error(t, err)
+}
+
+func TestOvhRefreshOAuth2Credentials(t *testing.T) {
+	dir := t.TempDir()
+	idPath, secretPath := filepath.Join(dir, "id"), filepath.Join(dir, "secret")
+	require.NoError(t, os.WriteFile(idPath, []byte("client-id"), 0o600))
+	require.NoError(t, os.WriteFile(secretPath, []byte("client-secret-1"), 0o600))
+	require.NoError(t, secrets.Configure(t.Context(), []string{"OVH_CLIENT_ID=file:" + idPath, "OVH_CLIENT_SECRET=file:" + secretPath}, time.Nanosecond))
+	t.Cleanup(func() { require.NoError(t, secrets.Configure(t.Context(), nil, 0)) })
+
+	p, err := NewOVHProvider(t.Context(), OVHConfig{Endpoint: "ovh-eu", APIRateLimit: 20, DryRun: true, RecordsFetchMode: RecordsFetchModeRecord, MaxAttempts: 3, RetryBackoff: time.Second, UseCache: true, AuthMethod: AuthMethodOAuth2})
+	require.NoError(t, err)
+	former := p.client.(apiClient).Client
+
+	// the client is kept as long as the credentials are unchanged
+	p.refreshCredentials(t.Context())
+	assert.Same(t, former, p.client.(apiClient).Client)
+
+	// the client of rotated credentials is replaced, keeping the HTTP client and user agent
+	require.NoError(t, os.WriteFile(secretPath, []byte("client-secret-2"), 0o600))
+	p.refreshCredentials(t.Context())
+	rotated := p.client.(apiClient).Client
+	assert.NotSame(t, former, rotated)
+	assert.Equal(t, "client-secret-2", rotated.ClientSecret)
+	assert.Same(t, former.Client, rotated.Client)
+	assert.Equal(t, former.UserAgent, rotated.UserAgent)
+}
+
+func TestOvhRefreshCredentialsFromFile(t *testing.T) {
+	file := filepath.Join(t.TempDir(), "ovh.conf")
+	require.NoError(t, os.WriteFile(file, []byte("application_key=app-key\napplication_secret=app-secret\n\n[ovh-eu]\nconsumer_key=consumer-key-1\n"), 0o600))
+
+	p, err := NewOVHProvider(t.Context(), OVHConfig{Endpoint: "ovh-eu", APIRateLimit: 20, DryRun: true, RecordsFetchMode: RecordsFetchModeRecord, MaxAttempts: 3, RetryBackoff: time.Second, UseCache: true, AuthMethod: AuthMethodApplication, CredentialsFile: file})
+	require.NoError(t, err)
+	client := p.client.(apiClient).Client
+	assert.Equal(t, "app-key", client.AppKey)
+	assert.Equal(t, "app-secret", client.AppSecret)
+	assert.Equal(t, "consumer-key-1", client.ConsumerKey)
+
+	// the rotated consumer key is picked up
+	require.NoError(t, os.WriteFile(file, []byte("[ovh-eu]\napplication_key=app-key\napplication_secret=app-secret\nconsumer_key=consumer-key-2\n"), 0o600))
+	p.refreshCredentials(t.Context())
+	assert.Equal(t, "consumer-key-2", client.ConsumerKey)
+
+	// the former credentials are kept while the file cannot be read
+	require.NoError(t, os.Remove(file))
+	p.refreshCredentials(t.Context())
+	assert.Equal(t, "consumer-key-2", client.ConsumerKey)
+
+	_, err = NewOVHProvider(t.Context(), OVHConfig{Endpoint: "ovh-eu", APIRateLimit: 20, DryRun: true, RecordsFetchMode: RecordsFetchModeRecord, MaxAttempts: 3, RetryBackoff: time.Second, UseCache: true, AuthMethod: AuthMethodApplication, CredentialsFile: file})
+	require.Error(t, err)
+}
@@ -0,0 +1,112 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package ovh
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"github.com/ovh/go-ovh/ovh"
+	log "github.com/sirupsen/logrus"
+)
+
+const (
+	// RecordsFetchModeRecord gets the records of a zone with one API call per record.
+	RecordsFetchModeRecord = "record"
+	// RecordsFetchModeBatch gets the records of a zone with batch API calls, falling back to one
+	// API call per record when they fail.
+	RecordsFetchModeBatch = "batch"
+
+	// recordsPerBatch is the number of records got by a single batch API call, bounded by the
+	// length of the URL listing their IDs.
+	recordsPerBatch = 100
+	// batchSeparator separates the IDs of the objects of a batch API call.
+	batchSeparator = ","
+)
+
+// ovhBatchClient gets many objects of the same kind with a single API call.
+type ovhBatchClient interface {
+	// GetBatchWithContext gets the objects whose IDs, separated by batchSeparator, end url.
+	GetBatchWithContext(ctx context.Context, url string, resType any) error
+}
+
+// apiClient is the OVHcloud API client, able to send batch API calls.
+type apiClient struct {
+	*ovh.Client
+}
+
+var _ ovhBatchClient = apiClient{}
+
+// GetBatchWithContext sends a batch API call, with the X-Ovh-Batch header telling the separator of the IDs.
+func (c apiClient) GetBatchWithContext(ctx context.Context, url string, resType any) error {
+	req, err := c.NewRequest(http.MethodGet, url, nil, true)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("X-Ovh-Batch", batchSeparator)
+	resp, err := c.Do(req.WithContext(ctx))
+	if err != nil {
+		return err
+	}
+	return c.UnmarshalResponse(resp, resType)
+}
+
+// ovhBatchResult is the result for one object of a batch API call.
+type ovhBatchResult[T any] struct {
+	Key   string `json:"key"`
+	Value *T     `json:"value"`
+	Error string `json:"error"`
+}
+
+// batchRecords gets the records of zone with the given IDs, with one API call per recordsPerBatch records.
+// Records deleted in the meantime are skipped.
+func (p *OVHProvider) batchRecords(ctx context.Context, client ovhBatchClient, zone string, recordsIds []uint64) ([]ovhRecord, error) {
+	ovhRecords := []ovhRecord{}
+	for start := 0; start < len(recordsIds); start += recordsPerBatch {
+		end := min(start+recordsPerBatch, len(recordsIds))
+		ids := make([]string, 0, end-start)
+		for _, id := range recordsIds[start:end] {
+			ids = append(ids, strconv.FormatUint(id, 10))
+		}
+
+		log.Debugf("OVH: Getting %d records for %s", len(ids), zone)
+		if err := p.apiRateLimiter.Take(ctx); err != nil {
+			return nil, err
+		}
+		var results []ovhBatchResult[ovhRecord]
+		if err := client.GetBatchWithContext(ctx, fmt.Sprintf("/domain/zone/%s/record/%s", url.PathEscape(zone), strings.Join(ids, batchSeparator)), &results); err != nil {
+			return nil, err
+		}
+		if len(results) != len(ids) {
+			return nil, fmt.Errorf("got %d results for %d records", len(results), len(ids))
+		}
+		for _, result := range results {
+			if result.Value == nil {
+				log.Debugf("OVH: Record %s for %s could not be got: %s", result.Key, zone, result.Error)
+				continue
+			}
+			if supportedRecordType(result.Value.FieldType) {
+				ovhRecords = append(ovhRecords, *result.Value)
+			}
+		}
+	}
+	return ovhRecords, nil
+}
@@ -0,0 +1,134 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package ovh
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/ovh/go-ovh/ovh"
+	"github.com/patrickmn/go-cache"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type mockOvhBatchClient struct {
+	mockOvhClient
+}
+
+func (c *mockOvhBatchClient) GetBatchWithContext(_ context.Context, endpoint string, output any) error {
+	stub := c.Called(endpoint)
+	data, _ := json.Marshal(stub.Get(0))
+	_ = json.Unmarshal(data, output)
+	return stub.Error(1)
+}
+
+func batchRecord(id uint64, fieldType, target string) ovhBatchResult[ovhRecord] {
+	return ovhBatchResult[ovhRecord]{
+		Key:   strconv.FormatUint(id, 10),
+		Value: &ovhRecord{ID: id, Zone: "example.org", ovhRecordFields: ovhRecordFields{FieldType: fieldType, ovhRecordFieldUpdate: ovhRecordFieldUpdate{SubDomain: "ovh", TTL: 10, Target: target}}},
+	}
+}
+
+func TestOvhZoneRecordsBatch(t *testing.T) {
+	client := new(mockOvhBatchClient)
+	provider := &OVHProvider{client: client, apiRateLimiter: newAdaptiveLimiter(10), cacheInstance: cache.New(cache.NoExpiration, cache.NoExpiration), RecordsFetchMode: RecordsFetchModeBatch}
+
+	ids := make([]uint64, 0, recordsPerBatch+2)
+	first := make([]ovhBatchResult[ovhRecord], 0, recordsPerBatch)
+	firstIDs := make([]string, 0, recordsPerBatch)
+	for id := uint64(1); id <= recordsPerBatch; id++ {
+		ids = append(ids, id)
+		firstIDs = append(firstIDs, strconv.FormatUint(id, 10))
+		first = append(first, batchRecord(id, "A", "203.0.113.42"))
+	}
+	ids = append(ids, 1001, 1002)
+	// the record 1002 was deleted in the meantime, and MX records are not supported
+	first[0] = batchRecord(1, "MX", "10 mx.example.org.")
+
+	client.On("GetWithContext", "/domain/zone").Return([]string{"example.org"}, nil).Once()
+	client.On("GetWithContext", "/domain/zone/example.org/record").Return(ids, nil).Once()
+	client.On("GetBatchWithContext", "/domain/zone/example.org/record/"+strings.Join(firstIDs, ",")).Return(first, nil).Once()
+	client.On("GetBatchWithContext", "/domain/zone/example.org/record/1001,1002").Return([]ovhBatchResult[ovhRecord]{
+		batchRecord(1001, "CNAME", "www.example.org."),
+		{Key: "1002", Error: "The requested object (id = 1002) does not exist"},
+	}, nil).Once()
+
+	_, _, records, err := provider.zonesRecords(t.Context())
+	require.NoError(t, err)
+	client.AssertExpectations(t)
+	assert.Len(t, records, recordsPerBatch)
+	for _, record := range records {
+		assert.NotEqual(t, uint64(1002), record.ID)
+	}
+}
+
+func TestOvhZoneRecordsBatchFallback(t *testing.T) {
+	client := new(mockOvhBatchClient)
+	provider := &OVHProvider{client: client, apiRateLimiter: newAdaptiveLimiter(10), cacheInstance: cache.New(cache.NoExpiration, cache.NoExpiration), RecordsFetchMode: RecordsFetchModeBatch}
+
+	client.On("GetWithContext", "/domain/zone").Return([]string{"example.org"}, nil).Once()
+	client.On("GetWithContext", "/domain/zone/example.org/record").Return([]uint64{24, 42}, nil).Once()
+	client.On("GetBatchWithContext", "/domain/zone/example.org/record/24,42").Return(nil, &ovh.APIError{Code: http.StatusBadRequest, Message: "batch not supported"}).Once()
+	client.On("GetWithContext", "/domain/zone/example.org/record/24").Return(batchRecord(24, "A", "203.0.113.24").Value, nil).Once()
+	client.On("GetWithContext", "/domain/zone/example.org/record/42").Return(batchRecord(42, "A", "203.0.113.42").Value, nil).Once()
+
+	_, _, records, err := provider.zonesRecords(t.Context())
+	require.NoError(t, err)
+	client.AssertExpectations(t)
+	assert.Len(t, records, 2)
+
+	// batch calls are not sent in the default mode
+	provider.RecordsFetchMode = ""
+	client.On("GetWithContext", "/domain/zone").Return([]string{"example.org"}, nil).Once()
+	client.On("GetWithContext", "/domain/zone/example.org/record").Return([]uint64{24}, nil).Once()
+	client.On("GetWithContext", "/domain/zone/example.org/record/24").Return(batchRecord(24, "A", "203.0.113.24").Value, nil).Once()
+	_, _, records, err = provider.zonesRecords(t.Context())
+	require.NoError(t, err)
+	client.AssertExpectations(t)
+	assert.Len(t, records, 1)
+}
+
+func TestAPIClientGetBatch(t *testing.T) {
+	svr := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/auth/time" {
+			fmt.Fprint(w, time.Now().Unix())
+			return
+		}
+		assert.Equal(t, "/domain/zone/example.org/record/24,42", r.URL.Path)
+		assert.Equal(t, ",", r.Header.Get("X-Ovh-Batch"))
+		assert.NotEmpty(t, r.Header.Get("X-Ovh-Signature"))
+		fmt.Fprint(w, `[{"key":"24","value":{"id":24,"zone":"example.org","fieldType":"A","subDomain":"www","target":"203.0.113.24","ttl":0},"error":""},{"key":"42","value":null,"error":"not found"}]`)
+	}))
+	defer svr.Close()
+
+	client, err := ovh.NewClient(svr.URL, "key", "secret", "consumer")
+	require.NoError(t, err)
+	var results []ovhBatchResult[ovhRecord]
+	require.NoError(t, apiClient{client}.GetBatchWithContext(t.Context(), "/domain/zone/example.org/record/24,42", &results))
+	require.Len(t, results, 2)
+	assert.Equal(t, "203.0.113.24", results[0].Value.Target)
+	assert.Nil(t, results[1].Value)
+	assert.Equal(t, "not found", results[1].Error)
+}
@@ -0,0 +1,85 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package ovh
+
+import (
+	"testing"
+
+	"github.com/patrickmn/go-cache"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"sigs.k8s.io/external-dns/endpoint"
+	"sigs.k8s.io/external-dns/plan"
+)
+
+func TestFormatCAATarget(t *testing.T) {
+	for target, expected := range map[string]string{
+		`0 issue "letsencrypt.org"`:                           `0 issue "letsencrypt.org"`,
+		"0 issue letsencrypt.org":                             `0 issue "letsencrypt.org"`,
+		"  128   ISSUEWILD   ;  ":                             `128 issuewild ";"`,
+		"0 iodef mailto:security@example.com":                 `0 iodef "mailto:security@example.com"`,
+		`0 issue "letsencrypt.org; validationmethods=dns-01"`: `0 issue "letsencrypt.org; validationmethods=dns-01"`,
+		"0 issue":         "0 issue",
+		"letsencrypt.org": "letsencrypt.org",
+	} {
+		assert.Equal(t, expected, formatCAATarget(target), target)
+	}
+}
+
+func TestOvhCAARecords(t *testing.T) {
+	client := new(mockOvhClient)
+	provider := &OVHProvider{client: client, apiRateLimiter: newAdaptiveLimiter(10), cacheInstance: cache.New(cache.NoExpiration, cache.NoExpiration)}
+
+	// CAA records are returned along with the other supported records
+	client.On("GetWithContext", "/domain/zone").Return([]string{"example.org"}, nil).Once()
+	client.On("GetWithContext", "/domain/zone/example.org/record").Return([]uint64{1, 2, 3}, nil).Once()
+	client.On("GetWithContext", "/domain/zone/example.org/record/1").Return(ovhRecord{ID: 1, Zone: "example.org", ovhRecordFields: ovhRecordFields{FieldType: "CAA", ovhRecordFieldUpdate: ovhRecordFieldUpdate{TTL: 60, Target: `0 issue "letsencrypt.org"`}}}, nil).Once()
+	client.On("GetWithContext", "/domain/zone/example.org/record/2").Return(ovhRecord{ID: 2, Zone: "example.org", ovhRecordFields: ovhRecordFields{FieldType: "CAA", ovhRecordFieldUpdate: ovhRecordFieldUpdate{TTL: 60, Target: `0 iodef "mailto:security@example.org"`}}}, nil).Once()
+	client.On("GetWithContext", "/domain/zone/example.org/record/3").Return(ovhRecord{ID: 3, Zone: "example.org", ovhRecordFields: ovhRecordFields{FieldType: "MX", ovhRecordFieldUpdate: ovhRecordFieldUpdate{TTL: 60, Target: "10 mx.example.org."}}}, nil).Once()
+	endpoints, err := provider.Records(t.Context())
+	require.NoError(t, err)
+	require.Len(t, endpoints, 1)
+	assert.Equal(t, "example.org", endpoints[0].DNSName)
+	assert.Equal(t, endpoint.RecordTypeCAA, endpoints[0].RecordType)
+	assert.ElementsMatch(t, endpoint.Targets{`0 issue "letsencrypt.org"`, `0 iodef "mailto:security@example.org"`}, endpoints[0].Targets)
+	client.AssertExpectations(t)
+
+	// desired targets are formatted the way OVHcloud stores them, so that they compare equal
+	desired, err := provider.AdjustEndpoints([]*endpoint.Endpoint{
+		endpoint.NewEndpoint("example.org", endpoint.RecordTypeCAA, "0 issue letsencrypt.org", "0 issuewild ;"),
+	})
+	require.NoError(t, err)
+	assert.Equal(t, endpoint.Targets{`0 issue "letsencrypt.org"`, `0 issuewild ";"`}, desired[0].Targets)
+
+	// CAA records are created, updated and deleted
+	client.On("PutWithContext", "/domain/zone/example.org/record/2", ovhRecordFieldUpdate{SubDomain: "", TTL: 60, Target: `0 issuewild ";"`}).Return(nil, nil).Once()
+	client.On("PostWithContext", "/domain/zone/example.org/record", ovhRecordFields{FieldType: "CAA", ovhRecordFieldUpdate: ovhRecordFieldUpdate{SubDomain: "www", TTL: 0, Target: `0 issue "pki.example.org"`}}).Return(nil, nil).Once()
+	client.On("PostWithContext", "/domain/zone/example.org/refresh", nil).Return(nil, nil).Once()
+	require.NoError(t, provider.ApplyChanges(t.Context(), &plan.Changes{
+		Create:    []*endpoint.Endpoint{endpoint.NewEndpoint("www.example.org", endpoint.RecordTypeCAA, "0 issue pki.example.org")},
+		UpdateOld: []*endpoint.Endpoint{endpoints[0]},
+		UpdateNew: []*endpoint.Endpoint{endpoint.NewEndpointWithTTL("example.org", endpoint.RecordTypeCAA, 60, `0 issue "letsencrypt.org"`, "0 issuewild ;")},
+	}))
+	client.AssertExpectations(t)
+}
+
+func TestParseZoneExportCAA(t *testing.T) {
+	records, err := parseZoneExport("example.org", "$TTL 3600\n@ IN CAA 0 issue \"letsencrypt.org\"\n")
+	require.NoError(t, err)
+	assert.Equal(t, []ovhRecord{exportRecord("CAA", "", 0, `0 issue "letsencrypt.org"`)}, records)
+}
@@ -0,0 +1,225 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package ovh
+
+import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"maps"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/patrickmn/go-cache"
+	log "github.com/sirupsen/logrus"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+)
+
+const (
+	// cacheFormatVersion is the version of the format of the persisted cache, whose other versions are ignored.
+	cacheFormatVersion = 1
+	// CacheConfigMapKey is the binary data key of the ConfigMap holding the persisted cache.
+	CacheConfigMapKey = "records.json.gz"
+)
+
+// CacheStore persists the records cache of the provider, so that the records of the zones whose SOA serial
+// did not change are not got again from the API after a restart.
+type CacheStore interface {
+	// Load returns the persisted data, nil when nothing was persisted yet.
+	Load(ctx context.Context) ([]byte, error)
+	// Save replaces the persisted data.
+	Save(ctx context.Context, data []byte) error
+}
+
+type persistedCache struct {
+	Version int                      `json:"version"`
+	Zones   map[string]persistedZone `json:"zones"`
+}
+
+type persistedZone struct {
+	Server  string      `json:"server"`
+	Serial  uint32      `json:"serial"`
+	Records []ovhRecord `json:"records"`
+}
+
+// restoreCache fills the records cache with the persisted one. Failures are logged, the records then
+// being got from the API.
+func (p *OVHProvider) restoreCache(ctx context.Context) {
+	data, err := p.cacheStore.Load(ctx)
+	if err != nil {
+		log.Warnf("OVH: loading the persisted records cache failed: %v", err)
+		return
+	}
+	if data == nil {
+		return
+	}
+	cached, err := decodeCache(data)
+	if err != nil {
+		log.Warnf("OVH: decoding the persisted records cache failed: %v", err)
+		return
+	}
+	if cached.Version != cacheFormatVersion {
+		log.Infof("OVH: ignoring the persisted records cache of version %d", cached.Version)
+		return
+	}
+	p.persistedSerials = map[string]uint32{}
+	for zone, z := range cached.Zones {
+		p.cacheInstance.Set(zone+"#soa", ovhSoa{Server: z.Server, Serial: z.Serial, records: z.Records}, cache.DefaultExpiration)
+		p.persistedSerials[zone] = z.Serial
+	}
+	log.Infof("OVH: restored the cached records of %d zones", len(cached.Zones))
+}
+
+// persistCache saves the records cache when the SOA serial of a zone changed since it was last saved.
+// Failures are logged, the cache being saved again at the next call.
+func (p *OVHProvider) persistCache(ctx context.Context) {
+	cached := persistedCache{Version: cacheFormatVersion, Zones: map[string]persistedZone{}}
+	serials := map[string]uint32{}
+	for key, item := range p.cacheInstance.Items() {
+		zone, ok := strings.CutSuffix(key, "#soa")
+		if !ok {
+			continue
+		}
+		soa := item.Object.(ovhSoa)
+		cached.Zones[zone] = persistedZone{Server: soa.Server, Serial: soa.Serial, Records: soa.records}
+		serials[zone] = soa.Serial
+	}
+	if maps.Equal(serials, p.persistedSerials) {
+		return
+	}
+	data, err := encodeCache(cached)
+	if err == nil {
+		err = p.cacheStore.Save(ctx, data)
+	}
+	if err != nil {
+		log.Warnf("OVH: saving the records cache failed: %v", err)
+		return
+	}
+	p.persistedSerials = serials
+	log.Debugf("OVH: saved the cached records of %d zones (%d bytes)", len(cached.Zones), len(data))
+}
+
+func encodeCache(cached persistedCache) ([]byte, error) {
+	var b bytes.Buffer
+	w := gzip.NewWriter(&b)
+	if err := json.NewEncoder(w).Encode(cached); err != nil {
+		return nil, err
+	}
+	if err := w.Close(); err != nil {
+		return nil, err
+	}
+	return b.Bytes(), nil
+}
+
+func decodeCache(data []byte) (persistedCache, error) {
+	var cached persistedCache
+	r, err := gzip.NewReader(bytes.NewReader(data))
+	if err != nil {
+		return cached, err
+	}
+	defer r.Close()
+	err = json.NewDecoder(r).Decode(&cached)
+	return cached, err
+}
+
+// FileCacheStore persists the records cache in a local file, which should be on a volume surviving restarts.
+type FileCacheStore struct {
+	path string
+}
+
+// NewFileCacheStore returns a FileCacheStore persisting the records cache in the file at path.
+func NewFileCacheStore(path string) *FileCacheStore {
+	return &FileCacheStore{path: path}
+}
+
+func (s *FileCacheStore) Load(_ context.Context) ([]byte, error) {
+	data, err := os.ReadFile(s.path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	return data, err
+}
+
+// Save writes the data to a temporary file renamed over the file, so that it is never partially written.
+func (s *FileCacheStore) Save(_ context.Context, data []byte) error {
+	f, err := os.CreateTemp(filepath.Dir(s.path), filepath.Base(s.path)+".*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(f.Name())
+	if _, err := f.Write(data); err != nil {
+		f.Close()
+		return err
+	}
+	if err := f.Close(); err != nil {
+		return err
+	}
+	return os.Rename(f.Name(), s.path)
+}
+
+// ConfigMapCacheStore persists the records cache in a ConfigMap, created when missing.
+type ConfigMapCacheStore struct {
+	client    kubernetes.Interface
+	namespace string
+	name      string
+}
+
+// NewConfigMapCacheStore returns a ConfigMapCacheStore persisting the records cache in the given ConfigMap.
+func NewConfigMapCacheStore(client kubernetes.Interface, namespace, name string) *ConfigMapCacheStore {
+	return &ConfigMapCacheStore{client: client, namespace: namespace, name: name}
+}
+
+func (s *ConfigMapCacheStore) Load(ctx context.Context) ([]byte, error) {
+	cm, err := s.client.CoreV1().ConfigMaps(s.namespace).Get(ctx, s.name, metav1.GetOptions{})
+	if apierrors.IsNotFound(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return cm.BinaryData[CacheConfigMapKey], nil
+}
+
+func (s *ConfigMapCacheStore) Save(ctx context.Context, data []byte) error {
+	configMaps := s.client.CoreV1().ConfigMaps(s.namespace)
+	cm, err := configMaps.Get(ctx, s.name, metav1.GetOptions{})
+	if apierrors.IsNotFound(err) {
+		_, err = configMaps.Create(ctx, &corev1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{Namespace: s.namespace, Name: s.name},
+			BinaryData: map[string][]byte{CacheConfigMapKey: data},
+		}, metav1.CreateOptions{})
+		return err
+	}
+	if err != nil {
+		return err
+	}
+	if cm.BinaryData == nil {
+		cm.BinaryData = map[string][]byte{}
+	}
+	cm.BinaryData[CacheConfigMapKey] = data
+	if _, err := configMaps.Update(ctx, cm, metav1.UpdateOptions{}); err != nil {
+		return fmt.Errorf("updating ConfigMap %s/%s: %w", s.namespace, s.name, err)
+	}
+	return nil
+}
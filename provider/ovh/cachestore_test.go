@@ -0,0 +1,168 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package ovh
+
+import (
+	"context"
+	"errors"
+	"path/filepath"
+	"testing"
+
+	"github.com/miekg/dns"
+	"github.com/patrickmn/go-cache"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+type failingCacheStore struct{}
+
+func (failingCacheStore) Load(context.Context) ([]byte, error) { return nil, errors.New("load failed") }
+
+func (failingCacheStore) Save(context.Context, []byte) error { return errors.New("save failed") }
+
+type countingCacheStore struct {
+	CacheStore
+	saves int
+}
+
+func (s *countingCacheStore) Save(ctx context.Context, data []byte) error {
+	s.saves++
+	return s.CacheStore.Save(ctx, data)
+}
+
+func newCacheTestProvider(client ovhClient, dnsClient dnsClient, store CacheStore) *OVHProvider {
+	p := &OVHProvider{
+		client:         client,
+		apiRateLimiter: newAdaptiveLimiter(0),
+		cacheInstance:  cache.New(cache.NoExpiration, cache.NoExpiration),
+		dnsClient:      dnsClient,
+		UseCache:       true,
+		cacheStore:     store,
+	}
+	p.restoreCache(context.Background())
+	return p
+}
+
+func TestFileCacheStore(t *testing.T) {
+	store := NewFileCacheStore(filepath.Join(t.TempDir(), "ovh.json.gz"))
+
+	data, err := store.Load(t.Context())
+	require.NoError(t, err)
+	assert.Nil(t, data, "nothing is loaded before the first save")
+
+	require.NoError(t, store.Save(t.Context(), []byte("first")))
+	require.NoError(t, store.Save(t.Context(), []byte("second")))
+	data, err = store.Load(t.Context())
+	require.NoError(t, err)
+	assert.Equal(t, []byte("second"), data)
+
+	assert.Error(t, NewFileCacheStore(filepath.Join(t.TempDir(), "missing", "ovh.json.gz")).Save(t.Context(), []byte("data")))
+}
+
+func TestConfigMapCacheStore(t *testing.T) {
+	client := fake.NewClientset()
+	store := NewConfigMapCacheStore(client, "external-dns", "ovh-cache")
+
+	data, err := store.Load(t.Context())
+	require.NoError(t, err)
+	assert.Nil(t, data, "nothing is loaded before the ConfigMap is created")
+
+	require.NoError(t, store.Save(t.Context(), []byte("first")))
+	require.NoError(t, store.Save(t.Context(), []byte("second")))
+	data, err = store.Load(t.Context())
+	require.NoError(t, err)
+	assert.Equal(t, []byte("second"), data)
+
+	cm, err := client.CoreV1().ConfigMaps("external-dns").Get(t.Context(), "ovh-cache", metav1.GetOptions{})
+	require.NoError(t, err)
+	assert.Equal(t, map[string][]byte{CacheConfigMapKey: []byte("second")}, cm.BinaryData)
+}
+
+func TestConfigMapCacheStoreKeepsOtherData(t *testing.T) {
+	client := fake.NewClientset(&corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "external-dns", Name: "ovh-cache"},
+		Data:       map[string]string{"note": "managed by external-dns"},
+	})
+	store := NewConfigMapCacheStore(client, "external-dns", "ovh-cache")
+
+	require.NoError(t, store.Save(t.Context(), []byte("data")))
+	cm, err := client.CoreV1().ConfigMaps("external-dns").Get(t.Context(), "ovh-cache", metav1.GetOptions{})
+	require.NoError(t, err)
+	assert.Equal(t, "managed by external-dns", cm.Data["note"])
+	assert.Equal(t, []byte("data"), cm.BinaryData[CacheConfigMapKey])
+}
+
+func TestOvhCachePersistence(t *testing.T) {
+	store := &countingCacheStore{CacheStore: NewFileCacheStore(filepath.Join(t.TempDir(), "ovh.json.gz"))}
+	record := ovhRecord{ID: 42, Zone: "example.org", ovhRecordFields: ovhRecordFields{FieldType: "A", ovhRecordFieldUpdate: ovhRecordFieldUpdate{SubDomain: "ovh", TTL: 10, Target: "203.0.113.42"}}}
+
+	// the records got from the API are persisted
+	client := new(mockOvhClient)
+	client.On("GetWithContext", "/domain/zone").Return([]string{"example.org"}, nil)
+	client.On("GetWithContext", "/domain/zone/example.org/soa").Return(ovhSoa{Server: "ns.example.org.", Serial: 2022090901}, nil).Once()
+	client.On("GetWithContext", "/domain/zone/example.org/record").Return([]uint64{42}, nil).Once()
+	client.On("GetWithContext", "/domain/zone/example.org/record/42").Return(record, nil).Once()
+	provider := newCacheTestProvider(client, nil, store)
+	_, err := provider.Records(t.Context())
+	require.NoError(t, err)
+	client.AssertExpectations(t)
+	assert.Equal(t, 1, store.saves)
+
+	// after a restart, the records of the zones whose serial did not change are served from the
+	// persisted cache, which is not saved again
+	client = new(mockOvhClient)
+	client.On("GetWithContext", "/domain/zone").Return([]string{"example.org"}, nil)
+	dnsClient := new(mockDnsClient)
+	dnsClient.On("ExchangeContext", mock.Anything, mock.AnythingOfType("*dns.Msg"), "ns.example.org:53").
+		Return(&dns.Msg{Answer: []dns.RR{&dns.SOA{Serial: 2022090901}}}, nil)
+	provider = newCacheTestProvider(client, dnsClient, store)
+	endpoints, err := provider.Records(t.Context())
+	require.NoError(t, err)
+	require.Len(t, endpoints, 1)
+	assert.Equal(t, "ovh.example.org", endpoints[0].DNSName)
+	client.AssertExpectations(t)
+	client.AssertNumberOfCalls(t, "GetWithContext", 1)
+	assert.Equal(t, 1, store.saves, "an unchanged cache is not saved again")
+}
+
+func TestOvhCachePersistenceFailures(t *testing.T) {
+	client := new(mockOvhClient)
+	client.On("GetWithContext", "/domain/zone").Return([]string{}, nil)
+	provider := newCacheTestProvider(client, nil, failingCacheStore{})
+	provider.cacheInstance.Set("example.org#soa", ovhSoa{Serial: 1}, cache.DefaultExpiration)
+
+	_, err := provider.Records(t.Context())
+	assert.NoError(t, err, "failing to persist the cache does not fail the records")
+	assert.Nil(t, provider.persistedSerials, "the cache is saved again at the next call")
+}
+
+func TestOvhCacheRestoreIgnoresInvalidData(t *testing.T) {
+	store := NewFileCacheStore(filepath.Join(t.TempDir(), "ovh.json.gz"))
+	require.NoError(t, store.Save(t.Context(), []byte("not gzip")))
+	provider := newCacheTestProvider(nil, nil, store)
+	assert.Zero(t, provider.cacheInstance.ItemCount())
+
+	data, err := encodeCache(persistedCache{Version: cacheFormatVersion + 1, Zones: map[string]persistedZone{"example.org": {Serial: 1}}})
+	require.NoError(t, err)
+	require.NoError(t, store.Save(t.Context(), data))
+	provider = newCacheTestProvider(nil, nil, store)
+	assert.Zero(t, provider.cacheInstance.ItemCount(), "caches of another version are ignored")
+}
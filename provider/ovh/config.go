@@ -0,0 +1,76 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package ovh
+
+import (
+	"context"
+	"strings"
+
+	"sigs.k8s.io/external-dns/endpoint"
+	"sigs.k8s.io/external-dns/pkg/apis/externaldns"
+	"sigs.k8s.io/external-dns/source"
+)
+
+// NewOVHProviderFromConfig returns the OVH provider configured by the --ovh-* flags of cfg, managing the zones
+// of domainFilter.
+func NewOVHProviderFromConfig(ctx context.Context, cfg *externaldns.Config, domainFilter endpoint.DomainFilter) (*OVHProvider, error) {
+	cacheStore, err := newCacheStore(cfg)
+	if err != nil {
+		return nil, err
+	}
+	return NewOVHProvider(ctx, OVHConfig{
+		DomainFilter:        domainFilter,
+		Endpoint:            cfg.OVHEndpoint,
+		APIRateLimit:        cfg.OVHApiRateLimit,
+		EnableCNAMERelative: cfg.OVHEnableCNAMERelative,
+		DryRun:              cfg.DryRun,
+		BatchSize:           cfg.ProviderBatchSize,
+		RecordsFetchMode:    cfg.OVHRecordsFetchMode,
+		DefaultTTL:          cfg.OVHDefaultTTL,
+		MaxAttempts:         cfg.OVHMaxAttempts,
+		RetryBackoff:        cfg.OVHRetryBackoff,
+		CacheStore:          cacheStore,
+		UseCache:            cfg.OVHCache,
+		CacheTTL:            cfg.OVHCacheTTL,
+		CacheExcludedZones:  cfg.OVHCacheExcludedZones,
+		SOACheck:            SOACheck{Resolver: cfg.OVHSOAResolver, Transport: cfg.OVHSOATransport, Timeout: cfg.OVHSOATimeout},
+		SkipFailedZones:     cfg.OVHSkipFailedZones,
+		DNSSEC:              DNSSEC{EnabledZones: cfg.OVHDNSSECEnabledZones, DisabledZones: cfg.OVHDNSSECDisabledZones},
+		CreateZones:         cfg.OVHCreateZones,
+		AuthMethod:          cfg.OVHAuthMethod,
+		CredentialsFile:     cfg.OVHCredentialsFile,
+		CheckZoneSerials:    cfg.OVHCheckZoneSerials,
+	})
+}
+
+// newCacheStore returns the store of the records cache set by --ovh-cache-persistence, nil when the cache
+// is not persisted.
+func newCacheStore(cfg *externaldns.Config) (CacheStore, error) {
+	kind, ref, _ := strings.Cut(cfg.OVHCachePersistence, ":")
+	switch kind {
+	case "file":
+		return NewFileCacheStore(ref), nil
+	case "configmap":
+		client, err := source.NewKubeClient(cfg.KubeConfig, cfg.APIServerURL, cfg.RequestTimeout)
+		if err != nil {
+			return nil, err
+		}
+		namespace, name, _ := strings.Cut(ref, "/")
+		return NewConfigMapCacheStore(client, namespace, name), nil
+	}
+	return nil, nil
+}
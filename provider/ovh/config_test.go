@@ -0,0 +1,41 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package ovh
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"sigs.k8s.io/external-dns/endpoint"
+	"sigs.k8s.io/external-dns/pkg/apis/externaldns"
+)
+
+func TestNewOVHProviderFromConfig(t *testing.T) {
+	t.Setenv("OVH_APPLICATION_KEY", "key")
+	t.Setenv("OVH_APPLICATION_SECRET", "secret")
+	t.Setenv("OVH_CONSUMER_KEY", "consumer")
+
+	cfg := externaldns.NewConfig()
+	require.NoError(t, cfg.ParseFlags([]string{"--provider=ovh", "--source=service", "--ovh-api-max-attempts=5", "--ovh-check-zone-serials", "--ovh-cache-persistence=file:" + t.TempDir() + "/cache.json"}))
+	p, err := NewOVHProviderFromConfig(t.Context(), cfg, endpoint.NewDomainFilter([]string{"example.org"}))
+	require.NoError(t, err)
+	assert.Equal(t, 5, p.MaxAttempts)
+	assert.True(t, p.CheckZoneSerials)
+	assert.IsType(t, &FileCacheStore{}, p.cacheStore)
+}
@@ -0,0 +1,144 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package ovh
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"slices"
+
+	"github.com/miekg/dns"
+	log "github.com/sirupsen/logrus"
+)
+
+const (
+	dnssecEnabled           = "enabled"
+	dnssecDisabled          = "disabled"
+	dnssecEnableInProgress  = "enableInProgress"
+	dnssecDisableInProgress = "disableInProgress"
+)
+
+// DNSSEC configures the zones whose DNSSEC signing is managed, the others being left as they are.
+type DNSSEC struct {
+	// EnabledZones are the zones signed with DNSSEC.
+	EnabledZones []string
+	// DisabledZones are the zones not signed with DNSSEC.
+	DisabledZones []string
+}
+
+// validate returns an error when a zone is both enabled and disabled.
+func (d DNSSEC) validate() error {
+	for _, zone := range d.EnabledZones {
+		if slices.Contains(d.DisabledZones, zone) {
+			return fmt.Errorf("DNSSEC cannot be both enabled and disabled on zone %s", zone)
+		}
+	}
+	return nil
+}
+
+// status returns the DNSSEC status of the given zone, and whether it is managed.
+func (d DNSSEC) status(zone string) (string, bool) {
+	switch {
+	case slices.Contains(d.EnabledZones, zone):
+		return dnssecEnabled, true
+	case slices.Contains(d.DisabledZones, zone):
+		return dnssecDisabled, true
+	default:
+		return "", false
+	}
+}
+
+type ovhDNSSEC struct {
+	Status string `json:"status"`
+}
+
+// reconcileDNSSEC enables or disables DNSSEC on the managed zones among the given ones, until they reach
+// their status, logging the DS records to publish in the parent zone of the signed zones. Failures are
+// logged and retried at the next synchronization, without failing it.
+func (p *OVHProvider) reconcileDNSSEC(ctx context.Context, zones []string) {
+	for _, zone := range zones {
+		want, ok := p.dnssec.status(zone)
+		if !ok || p.dnssecReconciled[zone] {
+			continue
+		}
+
+		path := "/domain/zone/" + url.PathEscape(zone) + "/dnssec"
+		var current ovhDNSSEC
+		if err := p.withRetry(ctx, http.MethodGet, func() error {
+			return p.client.GetWithContext(ctx, path, &current)
+		}); err != nil {
+			log.Warnf("OVH: zone %s: Failed to get the DNSSEC status: %v", zone, err)
+			continue
+		}
+		signed := 0.0
+		if current.Status == dnssecEnabled {
+			signed = 1
+		}
+		dnssecEnabledZones.GaugeVec.WithLabelValues(zone).Set(signed)
+
+		switch {
+		case current.Status == want:
+			log.Infof("OVH: zone %s: DNSSEC is %s", zone, want)
+			if want == dnssecEnabled {
+				p.logDSRecords(ctx, zone)
+			}
+			p.dnssecReconciled[zone] = true
+		case current.Status == dnssecEnableInProgress || current.Status == dnssecDisableInProgress:
+			log.Debugf("OVH: zone %s: DNSSEC status is %s", zone, current.Status)
+		case p.DryRun:
+			log.Infof("OVH: Dry-run: %q: DNSSEC is %s, would have changed it to %s", zone, current.Status, want)
+		default:
+			method, call := http.MethodPost, func() error { return p.client.PostWithContext(ctx, path, nil, nil) }
+			if want == dnssecDisabled {
+				method, call = http.MethodDelete, func() error { return p.client.DeleteWithContext(ctx, path, nil) }
+			}
+			if err := p.withRetry(ctx, method, call); err != nil {
+				log.Warnf("OVH: zone %s: Failed to change the DNSSEC status to %s: %v", zone, want, err)
+				continue
+			}
+			log.Infof("OVH: zone %s: DNSSEC was %s, changing it to %s", zone, current.Status, want)
+		}
+	}
+}
+
+// logDSRecords logs the DS records of the key signing keys of a signed zone, as published by its name
+// server, to be published in its parent zone when the domain is not registered with OVHcloud.
+func (p *OVHProvider) logDSRecords(ctx context.Context, zone string) {
+	var soa ovhSoa
+	if err := p.withRetry(ctx, http.MethodGet, func() error {
+		return p.client.GetWithContext(ctx, "/domain/zone/"+url.PathEscape(zone)+"/soa", &soa)
+	}); err != nil {
+		log.Warnf("OVH: zone %s: Failed to get the name server of the zone for its DS records: %v", zone, err)
+		return
+	}
+
+	m := new(dns.Msg)
+	m.SetQuestion(dns.Fqdn(zone), dns.TypeDNSKEY)
+	m.RecursionDesired = p.soaCheck.Resolver != ""
+	in, _, err := p.dnsClient.ExchangeContext(ctx, m, p.soaCheck.address(soa.Server))
+	if err != nil {
+		log.Warnf("OVH: zone %s: Failed to get the DNSKEY records for its DS records: %v", zone, err)
+		return
+	}
+	for _, rr := range in.Answer {
+		if key, ok := rr.(*dns.DNSKEY); ok && key.Flags&dns.SEP != 0 {
+			log.Infof("OVH: zone %s: DS record: %s", zone, key.ToDS(dns.SHA256))
+		}
+	}
+}
@@ -0,0 +1,104 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package ovh
+
+import (
+	"testing"
+
+	"github.com/miekg/dns"
+	"github.com/ovh/go-ovh/ovh"
+	"github.com/patrickmn/go-cache"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	log "github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"sigs.k8s.io/external-dns/internal/testutils"
+)
+
+func TestDNSSECValidate(t *testing.T) {
+	require.NoError(t, DNSSEC{EnabledZones: []string{"example.org"}, DisabledZones: []string{"example.net"}}.validate())
+	require.Error(t, DNSSEC{EnabledZones: []string{"example.org"}, DisabledZones: []string{"example.org"}}.validate())
+}
+
+func TestOvhReconcileDNSSEC(t *testing.T) {
+	client := new(mockOvhClient)
+	dnsClient := new(mockDnsClient)
+	provider := &OVHProvider{
+		client:           client,
+		apiRateLimiter:   newAdaptiveLimiter(10),
+		cacheInstance:    cache.New(cache.NoExpiration, cache.NoExpiration),
+		dnsClient:        dnsClient,
+		dnssec:           DNSSEC{EnabledZones: []string{"example.org"}, DisabledZones: []string{"example.net"}},
+		dnssecReconciled: map[string]bool{},
+	}
+
+	// DNSSEC is changed on the zones not at their status, the unmanaged zones being left as is
+	client.On("GetWithContext", "/domain/zone/example.org/dnssec").Return(ovhDNSSEC{Status: dnssecDisabled}, nil).Once()
+	client.On("PostWithContext", "/domain/zone/example.org/dnssec", nil).Return(nil, nil).Once()
+	client.On("GetWithContext", "/domain/zone/example.net/dnssec").Return(ovhDNSSEC{Status: dnssecEnabled}, nil).Once()
+	client.On("DeleteWithContext", "/domain/zone/example.net/dnssec").Return(nil, nil).Once()
+	provider.reconcileDNSSEC(t.Context(), []string{"example.org", "example.net", "example.com"})
+	client.AssertExpectations(t)
+	assert.Zero(t, testutil.ToFloat64(dnssecEnabledZones.GaugeVec.WithLabelValues("example.org")))
+	assert.Equal(t, 1.0, testutil.ToFloat64(dnssecEnabledZones.GaugeVec.WithLabelValues("example.net")))
+
+	// the DS records are logged once DNSSEC is enabled, and the zones at their status are not checked again
+	key := &dns.DNSKEY{Hdr: dns.RR_Header{Name: "example.org.", Rrtype: dns.TypeDNSKEY, Class: dns.ClassINET}, Flags: 257, Protocol: 3, Algorithm: dns.ECDSAP256SHA256}
+	_, err := key.Generate(256)
+	require.NoError(t, err)
+	hook := testutils.LogsUnderTestWithLogLevel(log.InfoLevel, t)
+	client.On("GetWithContext", "/domain/zone/example.org/dnssec").Return(ovhDNSSEC{Status: dnssecEnabled}, nil).Once()
+	client.On("GetWithContext", "/domain/zone/example.org/soa").Return(ovhSoa{Server: "ns.example.org.", Serial: 1}, nil).Once()
+	dnsClient.On("ExchangeContext", mock.Anything, mock.MatchedBy(func(m *dns.Msg) bool { return m.Question[0].Qtype == dns.TypeDNSKEY }), "ns.example.org:53").
+		Return(&dns.Msg{Answer: []dns.RR{key}}, nil).Once()
+	client.On("GetWithContext", "/domain/zone/example.net/dnssec").Return(ovhDNSSEC{Status: dnssecDisabled}, nil).Once()
+	provider.reconcileDNSSEC(t.Context(), []string{"example.org", "example.net"})
+	provider.reconcileDNSSEC(t.Context(), []string{"example.org", "example.net"})
+	client.AssertExpectations(t)
+	dnsClient.AssertExpectations(t)
+	testutils.TestHelperLogContains("OVH: zone example.org: DS record: "+key.ToDS(dns.SHA256).String(), hook, t)
+	assert.Equal(t, 1.0, testutil.ToFloat64(dnssecEnabledZones.GaugeVec.WithLabelValues("example.org")))
+}
+
+func TestOvhReconcileDNSSECFailures(t *testing.T) {
+	client := new(mockOvhClient)
+	provider := &OVHProvider{
+		client:           client,
+		apiRateLimiter:   newAdaptiveLimiter(10),
+		cacheInstance:    cache.New(cache.NoExpiration, cache.NoExpiration),
+		dnssec:           DNSSEC{EnabledZones: []string{"example.org"}},
+		dnssecReconciled: map[string]bool{},
+	}
+
+	// failures are retried at the next synchronization
+	client.On("GetWithContext", "/domain/zone/example.org/dnssec").Return(nil, ovh.ErrAPIDown).Once()
+	provider.reconcileDNSSEC(t.Context(), []string{"example.org"})
+	client.On("GetWithContext", "/domain/zone/example.org/dnssec").Return(ovhDNSSEC{Status: dnssecDisabled}, nil).Once()
+	client.On("PostWithContext", "/domain/zone/example.org/dnssec", nil).Return(nil, ovh.ErrAPIDown).Once()
+	provider.reconcileDNSSEC(t.Context(), []string{"example.org"})
+	assert.False(t, provider.dnssecReconciled["example.org"])
+
+	// changes are only logged in dry-run
+	provider.DryRun = true
+	hook := testutils.LogsUnderTestWithLogLevel(log.InfoLevel, t)
+	client.On("GetWithContext", "/domain/zone/example.org/dnssec").Return(ovhDNSSEC{Status: dnssecDisabled}, nil).Once()
+	provider.reconcileDNSSEC(t.Context(), []string{"example.org"})
+	client.AssertExpectations(t)
+	testutils.TestHelperLogContains(`OVH: Dry-run: "example.org": DNSSEC is disabled, would have changed it to enabled`, hook, t)
+}
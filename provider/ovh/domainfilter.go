@@ -0,0 +1,80 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package ovh
+
+import (
+	"context"
+	"slices"
+	"sync"
+
+	log "github.com/sirupsen/logrus"
+
+	"sigs.k8s.io/external-dns/endpoint"
+)
+
+// accountZones are the zones of the account matching the domain filter, safe for concurrent use. A nil
+// accountZones records none.
+type accountZones struct {
+	mu     sync.Mutex
+	zones  []string
+	listed bool
+}
+
+func (a *accountZones) set(zones []string) {
+	if a == nil {
+		return
+	}
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	a.zones = slices.Clone(zones)
+	a.listed = true
+}
+
+// get returns the zones, and false when they were never listed.
+func (a *accountZones) get() ([]string, bool) {
+	if a == nil {
+		return nil, false
+	}
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	return a.zones, a.listed
+}
+
+// GetDomainFilter returns a filter of the names of the zones of the account matching the domain filter, so
+// that no records, TXT registry ones included, are planned for names OVHcloud does not host. The zones are
+// those listed by the last call to Records, or listed on the first call. With CreateZones, the domain filter
+// is returned as is, the missing zones of its domains being ordered.
+func (p *OVHProvider) GetDomainFilter() endpoint.DomainFilterInterface {
+	if p.CreateZones {
+		return p.domainFilter
+	}
+	zones, ok := p.accountZones.get()
+	if !ok {
+		var err error
+		zones, err = p.zones(context.Background())
+		if err != nil {
+			log.Errorf("OVH: failed to list zones, not filtering the records by zone: %v", err)
+			return endpoint.DomainFilter{}
+		}
+		p.accountZones.set(zones)
+	}
+	if len(zones) == 0 {
+		// an empty filter would match all the names
+		return p.domainFilter
+	}
+	return endpoint.NewDomainFilter(zones)
+}
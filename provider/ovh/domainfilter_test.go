@@ -0,0 +1,80 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package ovh
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/patrickmn/go-cache"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"sigs.k8s.io/external-dns/endpoint"
+)
+
+func TestOvhGetDomainFilter(t *testing.T) {
+	client := new(mockOvhClient)
+	p := &OVHProvider{
+		client:         client,
+		apiRateLimiter: newAdaptiveLimiter(10),
+		cacheInstance:  cache.New(cache.NoExpiration, cache.NoExpiration),
+		domainFilter:   endpoint.NewDomainFilter([]string{"example.org", "example.net"}),
+		accountZones:   &accountZones{},
+	}
+
+	// the zones are listed on the first call
+	client.On("GetWithContext", "/domain/zone").Return([]string{"example.org", "example.com"}, nil).Once()
+	filter := p.GetDomainFilter()
+	assert.True(t, filter.Match("www.example.org"))
+	assert.False(t, filter.Match("www.example.net"))
+	assert.False(t, filter.Match("www.example.com"))
+	client.AssertExpectations(t)
+
+	// and then those listed along with the records are used
+	client.On("GetWithContext", "/domain/zone").Return([]string{"example.org", "example.net"}, nil).Once()
+	client.On("GetWithContext", "/domain/zone/example.org/record").Return([]uint64{}, nil).Once()
+	client.On("GetWithContext", "/domain/zone/example.net/record").Return([]uint64{}, nil).Once()
+	_, err := p.Records(t.Context())
+	require.NoError(t, err)
+	filter = p.GetDomainFilter()
+	assert.True(t, filter.Match("www.example.org"))
+	assert.True(t, filter.Match("www.example.net"))
+	assert.False(t, filter.Match("example.org.example.com"))
+	client.AssertExpectations(t)
+
+	// the zones of domains to create are not filtered out
+	p.CreateZones = true
+	assert.Equal(t, p.domainFilter, p.GetDomainFilter())
+}
+
+func TestOvhGetDomainFilterError(t *testing.T) {
+	client := new(mockOvhClient)
+	p := &OVHProvider{
+		client:         client,
+		apiRateLimiter: newAdaptiveLimiter(10),
+		accountZones:   &accountZones{},
+	}
+
+	client.On("GetWithContext", "/domain/zone").Return(nil, errors.New("unavailable")).Once()
+	assert.Equal(t, endpoint.DomainFilter{}, p.GetDomainFilter())
+
+	// the zones are listed again on the next call
+	client.On("GetWithContext", "/domain/zone").Return([]string{"example.org"}, nil).Once()
+	assert.False(t, p.GetDomainFilter().Match("www.example.com"))
+	client.AssertExpectations(t)
+}
@@ -0,0 +1,155 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package ovh
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"net/url"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/miekg/dns"
+	log "github.com/sirupsen/logrus"
+
+	"sigs.k8s.io/external-dns/plan"
+	"sigs.k8s.io/external-dns/provider"
+)
+
+// RecordsFetchModeExport gets the records of a zone by parsing its BIND export, with a single API call,
+// falling back to one API call per record when it fails. The IDs of the records are only got when
+// they are updated or deleted.
+const RecordsFetchModeExport = "export"
+
+// exportRecords gets the records of zone from its BIND export. The records have no ID.
+func (p *OVHProvider) exportRecords(ctx context.Context, zone string) ([]ovhRecord, error) {
+	log.Debugf("OVH: Getting the export of %s", zone)
+	if err := p.apiRateLimiter.Take(ctx); err != nil {
+		return nil, err
+	}
+	var export string
+	if err := p.client.GetWithContext(ctx, fmt.Sprintf("/domain/zone/%s/export", url.PathEscape(zone)), &export); err != nil {
+		return nil, err
+	}
+	return parseZoneExport(zone, export)
+}
+
+// parseZoneExport parses the BIND export of zone. Records without a TTL of their own get the default
+// TTL of the zone when exported, they are given a TTL of 0 as in the API.
+func parseZoneExport(zone, export string) ([]ovhRecord, error) {
+	origin := dns.Fqdn(zone)
+	defaultTTL := exportDefaultTTL(export)
+
+	records := []ovhRecord{}
+	zp := dns.NewZoneParser(strings.NewReader(export), origin, "")
+	for rr, ok := zp.Next(); ok; rr, ok = zp.Next() {
+		header := rr.Header()
+		fieldType := dns.TypeToString[header.Rrtype]
+		if !supportedRecordType(fieldType) {
+			continue
+		}
+		ttl := int64(header.Ttl)
+		if ttl == defaultTTL {
+			ttl = 0
+		}
+		records = append(records, ovhRecord{
+			Zone: zone,
+			ovhRecordFields: ovhRecordFields{
+				FieldType: fieldType,
+				ovhRecordFieldUpdate: ovhRecordFieldUpdate{
+					SubDomain: strings.TrimSuffix(strings.TrimSuffix(strings.ToLower(header.Name), origin), "."),
+					TTL:       ttl,
+					Target:    strings.TrimPrefix(rr.String(), header.String()),
+				},
+			},
+		})
+	}
+	if err := zp.Err(); err != nil {
+		return nil, err
+	}
+	return records, nil
+}
+
+// exportDefaultTTL returns the TTL of the $TTL directive of a BIND export, -1 without one.
+func exportDefaultTTL(export string) int64 {
+	scanner := bufio.NewScanner(strings.NewReader(export))
+	for scanner.Scan() {
+		fields := strings.Fields(scanner.Text())
+		if len(fields) >= 2 && strings.EqualFold(fields[0], "$TTL") {
+			if ttl, err := strconv.ParseUint(fields[1], 10, 32); err == nil {
+				return int64(ttl)
+			}
+		}
+	}
+	return -1
+}
+
+// resolveRecordIDs returns the records of zone where the records without ID, got from the zone export,
+// are replaced by the ones got from the API for the names and types that changes update or delete,
+// so that they can be mutated.
+func (p *OVHProvider) resolveRecordIDs(ctx context.Context, zone string, existingRecords []ovhRecord, changes *plan.Changes) ([]ovhRecord, error) {
+	unresolved := map[string]bool{}
+	for _, record := range existingRecords {
+		if record.ID == 0 && record.Zone == zone {
+			unresolved[record.FieldType+"//"+record.SubDomain] = true
+		}
+	}
+	if len(unresolved) == 0 {
+		return existingRecords, nil
+	}
+
+	resolved := map[string][]ovhRecord{}
+	for _, ep := range slices.Concat(changes.UpdateOld, changes.Delete) {
+		subDomain := convertDNSNameIntoSubDomain(ep.DNSName, zone)
+		key := ep.RecordType + "//" + subDomain
+		if _, ok := resolved[key]; ok || !unresolved[key] {
+			continue
+		}
+
+		log.Debugf("OVH: Getting the IDs of the %s records of %q in %s", ep.RecordType, subDomain, zone)
+		if err := p.apiRateLimiter.Take(ctx); err != nil {
+			return nil, err
+		}
+		var ids []uint64
+		query := url.Values{"fieldType": {ep.RecordType}, "subDomain": {subDomain}}
+		if err := p.client.GetWithContext(ctx, fmt.Sprintf("/domain/zone/%s/record?%s", url.PathEscape(zone), query.Encode()), &ids); err != nil {
+			return nil, provider.NewSoftError(err)
+		}
+		records, err := p.recordsOneByOne(ctx, &zone, ids)
+		if err != nil {
+			return nil, provider.NewSoftError(err)
+		}
+		resolved[key] = records
+	}
+	if len(resolved) == 0 {
+		return existingRecords, nil
+	}
+
+	result := make([]ovhRecord, 0, len(existingRecords))
+	for _, record := range existingRecords {
+		if _, ok := resolved[record.FieldType+"//"+record.SubDomain]; ok && record.Zone == zone {
+			continue
+		}
+		result = append(result, record)
+	}
+	for _, records := range resolved {
+		result = append(result, records...)
+	}
+	return result, nil
+}
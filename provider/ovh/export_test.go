@@ -0,0 +1,124 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package ovh
+
+import (
+	"testing"
+
+	"github.com/ovh/go-ovh/ovh"
+	"github.com/patrickmn/go-cache"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"sigs.k8s.io/external-dns/endpoint"
+	"sigs.k8s.io/external-dns/plan"
+)
+
+const testZoneExport = `$TTL 3600
+@	IN SOA dns200.anycast.me. tech.ovh.net. (2024010101 86400 3600 3600000 300)
+                 IN NS     dns200.anycast.me.
+                 IN NS     ns200.anycast.me.
+                 IN MX     1 mx1.mail.ovh.net.
+www              IN A      203.0.113.1
+www              IN A      203.0.113.2
+API        60    IN A      203.0.113.3
+api              IN AAAA   2001:db8::3
+blog             IN CNAME  www.example.org.
+_dmarc           IN TXT    "v=DMARC1; p=none"
+`
+
+func exportRecord(fieldType, subDomain string, ttl int64, target string) ovhRecord {
+	return ovhRecord{Zone: "example.org", ovhRecordFields: ovhRecordFields{FieldType: fieldType, ovhRecordFieldUpdate: ovhRecordFieldUpdate{SubDomain: subDomain, TTL: ttl, Target: target}}}
+}
+
+func TestParseZoneExport(t *testing.T) {
+	records, err := parseZoneExport("example.org", testZoneExport)
+	require.NoError(t, err)
+	assert.Equal(t, []ovhRecord{
+		exportRecord("NS", "", 0, "dns200.anycast.me."),
+		exportRecord("NS", "", 0, "ns200.anycast.me."),
+		exportRecord("A", "www", 0, "203.0.113.1"),
+		exportRecord("A", "www", 0, "203.0.113.2"),
+		exportRecord("A", "api", 60, "203.0.113.3"),
+		exportRecord("AAAA", "api", 0, "2001:db8::3"),
+		exportRecord("CNAME", "blog", 0, "www.example.org."),
+		exportRecord("TXT", "_dmarc", 0, `"v=DMARC1; p=none"`),
+	}, records)
+
+	_, err = parseZoneExport("example.org", "www IN A not-an-address")
+	assert.Error(t, err)
+}
+
+func TestOvhZoneRecordsExport(t *testing.T) {
+	client := new(mockOvhClient)
+	provider := &OVHProvider{client: client, apiRateLimiter: newAdaptiveLimiter(10), cacheInstance: cache.New(cache.NoExpiration, cache.NoExpiration), RecordsFetchMode: RecordsFetchModeExport}
+
+	// a single API call gets the records of the zone
+	client.On("GetWithContext", "/domain/zone").Return([]string{"example.org"}, nil).Once()
+	client.On("GetWithContext", "/domain/zone/example.org/export").Return(testZoneExport, nil).Once()
+	_, _, records, err := provider.zonesRecords(t.Context())
+	require.NoError(t, err)
+	client.AssertExpectations(t)
+	assert.Len(t, records, 8)
+
+	// the records are got one by one when the export cannot be got
+	client.On("GetWithContext", "/domain/zone").Return([]string{"example.org"}, nil).Once()
+	client.On("GetWithContext", "/domain/zone/example.org/export").Return(nil, ovh.ErrAPIDown).Once()
+	client.On("GetWithContext", "/domain/zone/example.org/record").Return([]uint64{42}, nil).Once()
+	client.On("GetWithContext", "/domain/zone/example.org/record/42").Return(exportRecord("A", "www", 0, "203.0.113.1"), nil).Once()
+	_, _, records, err = provider.zonesRecords(t.Context())
+	require.NoError(t, err)
+	client.AssertExpectations(t)
+	assert.Len(t, records, 1)
+}
+
+func TestOvhApplyChangesExport(t *testing.T) {
+	client := new(mockOvhClient)
+	provider := &OVHProvider{client: client, apiRateLimiter: newAdaptiveLimiter(10), cacheInstance: cache.New(cache.NoExpiration, cache.NoExpiration), RecordsFetchMode: RecordsFetchModeExport}
+
+	client.On("GetWithContext", "/domain/zone").Return([]string{"example.org"}, nil).Once()
+	client.On("GetWithContext", "/domain/zone/example.org/export").Return(testZoneExport, nil).Once()
+	_, err := provider.Records(t.Context())
+	require.NoError(t, err)
+
+	// only the IDs of the records updated or deleted are got
+	www1, www2 := exportRecord("A", "www", 0, "203.0.113.1"), exportRecord("A", "www", 0, "203.0.113.2")
+	www1.ID, www2.ID = 1, 2
+	blog := exportRecord("CNAME", "blog", 0, "www.example.org.")
+	blog.ID = 3
+	client.On("GetWithContext", "/domain/zone/example.org/record?fieldType=A&subDomain=www").Return([]uint64{1, 2}, nil).Once()
+	client.On("GetWithContext", "/domain/zone/example.org/record/1").Return(www1, nil).Once()
+	client.On("GetWithContext", "/domain/zone/example.org/record/2").Return(www2, nil).Once()
+	client.On("GetWithContext", "/domain/zone/example.org/record?fieldType=CNAME&subDomain=blog").Return([]uint64{3}, nil).Once()
+	client.On("GetWithContext", "/domain/zone/example.org/record/3").Return(blog, nil).Once()
+	client.On("PutWithContext", "/domain/zone/example.org/record/2", ovhRecordFieldUpdate{SubDomain: "www", TTL: 0, Target: "203.0.113.4"}).Return(nil, nil).Once()
+	client.On("DeleteWithContext", "/domain/zone/example.org/record/3").Return(nil, nil).Once()
+	client.On("PostWithContext", "/domain/zone/example.org/record", ovhRecordFields{FieldType: "A", ovhRecordFieldUpdate: ovhRecordFieldUpdate{SubDomain: "new", Target: "203.0.113.5"}}).Return(nil, nil).Once()
+	client.On("PostWithContext", "/domain/zone/example.org/refresh", nil).Return(nil, nil).Once()
+
+	require.NoError(t, provider.ApplyChanges(t.Context(), &plan.Changes{
+		Create: []*endpoint.Endpoint{endpoint.NewEndpoint("new.example.org", endpoint.RecordTypeA, "203.0.113.5")},
+		UpdateOld: []*endpoint.Endpoint{
+			endpoint.NewEndpoint("www.example.org", endpoint.RecordTypeA, "203.0.113.1", "203.0.113.2"),
+		},
+		UpdateNew: []*endpoint.Endpoint{
+			endpoint.NewEndpoint("www.example.org", endpoint.RecordTypeA, "203.0.113.1", "203.0.113.4"),
+		},
+		Delete: []*endpoint.Endpoint{endpoint.NewEndpoint("blog.example.org", endpoint.RecordTypeCNAME, "www.example.org")},
+	}))
+	client.AssertExpectations(t)
+}
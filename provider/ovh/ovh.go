@@ -20,26 +20,89 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"net/http"
 	"net/url"
 	"slices"
 	"strconv"
 	"strings"
 	"time"
+	"unicode"
 
 	"github.com/miekg/dns"
-	"github.com/ovh/go-ovh/ovh"
 	"github.com/patrickmn/go-cache"
+	"github.com/prometheus/client_golang/prometheus"
 	log "github.com/sirupsen/logrus"
 	"golang.org/x/sync/errgroup"
 
 	"sigs.k8s.io/external-dns/endpoint"
 	"sigs.k8s.io/external-dns/pkg/apis/externaldns"
+	extdnshttp "sigs.k8s.io/external-dns/pkg/http"
+	"sigs.k8s.io/external-dns/pkg/metrics"
+	"sigs.k8s.io/external-dns/pkg/resolver"
 	"sigs.k8s.io/external-dns/plan"
 	"sigs.k8s.io/external-dns/provider"
+)
+
+const (
+	// ovhTTLKey is the provider specific property setting the TTL of the records of an endpoint.
+	ovhTTLKey = "ovh/ttl"
+)
 
-	"go.uber.org/ratelimit"
+var (
+	cacheLookupsTotal = metrics.NewCounterVecWithOpts(
+		prometheus.CounterOpts{
+			Namespace: "external_dns",
+			Subsystem: "ovh",
+			Name:      "cache_lookups_total",
+			Help:      "Number of lookups of the records of a zone in the records cache, by result: hit or miss.",
+		},
+		[]string{"result"},
+	)
+	soaValidationsTotal = metrics.NewCounterVecWithOpts(
+		prometheus.CounterOpts{
+			Namespace: "external_dns",
+			Subsystem: "ovh",
+			Name:      "soa_validations_total",
+			Help:      "Number of checks of the SOA serial of cached zones, by result: valid, changed or failed.",
+		},
+		[]string{"result"},
+	)
+	cacheInvalidationsTotal = metrics.NewCounterWithOpts(
+		prometheus.CounterOpts{
+			Namespace: "external_dns",
+			Subsystem: "ovh",
+			Name:      "cache_invalidations_total",
+			Help:      "Number of invalidations of the cached records of a zone.",
+		},
+	)
+	zonesSkippedTotal = metrics.NewCounterVecWithOpts(
+		prometheus.CounterOpts{
+			Namespace: "external_dns",
+			Subsystem: "ovh",
+			Name:      "zones_skipped_total",
+			Help:      "Number of synchronizations skipping a zone whose records could not be got, by zone.",
+		},
+		[]string{"zone"},
+	)
+	dnssecEnabledZones = metrics.NewGaugeVecWithOpts(
+		prometheus.GaugeOpts{
+			Namespace: "external_dns",
+			Subsystem: "ovh",
+			Name:      "dnssec_enabled",
+			Help:      "Whether DNSSEC is enabled on a zone whose DNSSEC is managed (1) or not (0), by zone.",
+		},
+		[]string{"zone"},
+	)
 )
 
+func init() {
+	metrics.RegisterMetric.MustRegister(cacheLookupsTotal)
+	metrics.RegisterMetric.MustRegister(soaValidationsTotal)
+	metrics.RegisterMetric.MustRegister(cacheInvalidationsTotal)
+	metrics.RegisterMetric.MustRegister(zonesSkippedTotal)
+	metrics.RegisterMetric.MustRegister(dnssecEnabledZones)
+}
+
 const (
 	defaultTTL = 0
 	ovhCreate  = iota
@@ -57,8 +120,13 @@ type OVHProvider struct {
 	provider.BaseProvider
 
 	client ovhClient
+	// endpoint and authMethod are the ones of client, to replace it when its OAuth2 credentials are rotated.
+	endpoint   string
+	authMethod string
+	// credentialsFile, when set, is read for rotated credentials at every synchronization.
+	credentialsFile string
 
-	apiRateLimiter ratelimit.Limiter
+	apiRateLimiter *adaptiveLimiter
 
 	domainFilter endpoint.DomainFilter
 
@@ -77,12 +145,63 @@ type OVHProvider struct {
 	// your refresh rate/number of records is too big, which might cause issue with the
 	// provider.
 	// Default value: true
-	UseCache       bool
+	UseCache bool
+
+	// CacheExcludedZones are the zones whose records are never cached, e.g. because they change too
+	// often for the SOA checks to pay off.
+	CacheExcludedZones []string
+
+	// BatchSize is the maximum number of changes applied, and zones refreshed, at once, 0 meaning no limit.
+	BatchSize int
+
+	// RecordsFetchMode is how the records of the zones are got from the API, RecordsFetchModeRecord
+	// when empty.
+	RecordsFetchMode string
+
+	// MaxAttempts is the maximum number of attempts of the API calls failing with a transient error,
+	// retried with an exponential backoff starting at RetryBackoff; 0 or 1 meaning no retry.
+	MaxAttempts  int
+	RetryBackoff time.Duration
+
+	// DefaultTTL is the TTL of the records whose endpoint has no TTL nor ovh/ttl property, 0 meaning
+	// the TTL of the zone.
+	DefaultTTL int64
+
+	// SkipFailedZones skips the zones whose records could not be got until the next synchronization,
+	// instead of failing the synchronization of all the zones.
+	SkipFailedZones bool
+
+	// CreateZones orders the zones missing for the endpoints to create, the most specific domain of the
+	// domain filter they belong to; zonesOrdered are the zones ordered but not listed by Records yet,
+	// which are not ordered again.
+	CreateZones  bool
+	zonesOrdered map[string]bool
+
+	// CheckZoneSerials refuses to apply changes to a zone whose SOA serial changed since its records were got,
+	// lastRunSerials being the serials read along with the records.
+	CheckZoneSerials bool
+	lastRunSerials   *zoneSerials
+
+	// accountZones are the zones of the account matching the domain filter, listed along with the records.
+	accountZones *accountZones
+
 	lastRunRecords []ovhRecord
 	lastRunZones   []string
+	// lastRunSkippedZones are the zones of lastRunZones whose records could not be got, left unchanged.
+	lastRunSkippedZones []string
 
 	cacheInstance *cache.Cache
 	dnsClient     dnsClient
+	soaCheck      SOACheck
+
+	// dnssec are the zones whose DNSSEC is managed, dnssecReconciled those having reached their status.
+	dnssec           DNSSEC
+	dnssecReconciled map[string]bool
+
+	// cacheStore, when set, persists the records cache so that it survives restarts, persistedSerials
+	// being the SOA serials of the zones it was last saved with.
+	cacheStore       CacheStore
+	persistedSerials map[string]uint32
 }
 
 type ovhClient interface {
@@ -122,42 +241,168 @@ type ovhChange struct {
 	Action int
 }
 
+// OVHConfig contains the configuration to create a new OVH provider.
+type OVHConfig struct {
+	DomainFilter endpoint.DomainFilter
+	// Endpoint is the OVHcloud API endpoint, e.g. ovh-eu.
+	Endpoint            string
+	APIRateLimit        int
+	EnableCNAMERelative bool
+	DryRun              bool
+	BatchSize           int
+	RecordsFetchMode    string
+	DefaultTTL          int64
+	MaxAttempts         int
+	RetryBackoff        time.Duration
+	// CacheStore persists the records cache when set.
+	CacheStore CacheStore
+	// UseCache caches the records of the zones, false disabling the cache altogether.
+	UseCache bool
+	// CacheTTL is the time after which the cached records of a zone expire even when its SOA serial
+	// did not change, 0 meaning they never expire.
+	CacheTTL           time.Duration
+	CacheExcludedZones []string
+	// SOACheck configures how the SOA serials of the zones are checked.
+	SOACheck SOACheck
+	// SkipFailedZones skips the zones whose records could not be got until the next synchronization.
+	SkipFailedZones bool
+	// DNSSEC lists the zones to enable or disable DNSSEC on.
+	DNSSEC DNSSEC
+	// CreateZones orders the missing zones.
+	CreateZones bool
+	// AuthMethod is the way the client authenticates, AuthMethodApplication when empty.
+	AuthMethod string
+	// CredentialsFile is the file the credentials are read from when set, read again at every
+	// synchronization.
+	CredentialsFile string
+	// CheckZoneSerials refuses the changes to zones changed since their records were listed.
+	CheckZoneSerials bool
+}
+
 // NewOVHProvider initializes a new OVH DNS based Provider.
-func NewOVHProvider(ctx context.Context, domainFilter endpoint.DomainFilter, endpoint string, apiRateLimit int, enableCNAMERelative, dryRun bool) (*OVHProvider, error) {
-	client, err := ovh.NewEndpointClient(endpoint)
+func NewOVHProvider(ctx context.Context, ovhConfig OVHConfig) (*OVHProvider, error) {
+	var dnsClient dnsClient = resolver.Default()
+	if ovhConfig.SOACheck.Resolver != "" || !resolver.Default().Configured() {
+		// the resolver of the SOA checks takes precedence over the global one
+		soaClient, err := ovhConfig.SOACheck.newDNSClient()
+		if err != nil {
+			return nil, err
+		}
+		dnsClient = soaClient
+	}
+	if err := ovhConfig.DNSSEC.validate(); err != nil {
+		return nil, err
+	}
+
+	creds, err := lookupCredentials(ctx, ovhConfig.CredentialsFile, ovhConfig.Endpoint)
+	if err != nil {
+		return nil, err
+	}
+	client, err := newOVHClient(ovhConfig.Endpoint, ovhConfig.AuthMethod, creds)
 	if err != nil {
 		return nil, err
 	}
 
 	client.UserAgent = externaldns.UserAgent()
+	client.Client = extdnshttp.NewClient("ovh")
+	// calls slow down when the API reports its quota is close to exhaustion
+	limiter := newAdaptiveLimiter(ovhConfig.APIRateLimit)
+	client.Client.Transport = &metricsTransport{next: &quotaTransport{next: client.Client.Transport, limiter: limiter}}
+
+	p := &OVHProvider{
+		client:                    apiClient{client},
+		endpoint:                  ovhConfig.Endpoint,
+		authMethod:                ovhConfig.AuthMethod,
+		credentialsFile:           ovhConfig.CredentialsFile,
+		domainFilter:              ovhConfig.DomainFilter,
+		apiRateLimiter:            limiter,
+		DryRun:                    ovhConfig.DryRun,
+		cacheInstance:             cache.New(ovhConfig.CacheTTL, ovhConfig.CacheTTL),
+		dnsClient:                 dnsClient,
+		soaCheck:                  ovhConfig.SOACheck,
+		dnssec:                    ovhConfig.DNSSEC,
+		dnssecReconciled:          map[string]bool{},
+		UseCache:                  ovhConfig.UseCache,
+		CacheExcludedZones:        ovhConfig.CacheExcludedZones,
+		EnableCNAMERelativeTarget: ovhConfig.EnableCNAMERelative,
+		BatchSize:                 ovhConfig.BatchSize,
+		RecordsFetchMode:          ovhConfig.RecordsFetchMode,
+		DefaultTTL:                ovhConfig.DefaultTTL,
+		MaxAttempts:               ovhConfig.MaxAttempts,
+		RetryBackoff:              ovhConfig.RetryBackoff,
+		SkipFailedZones:           ovhConfig.SkipFailedZones,
+		CreateZones:               ovhConfig.CreateZones,
+		CheckZoneSerials:          ovhConfig.CheckZoneSerials,
+		lastRunSerials:            &zoneSerials{},
+		accountZones:              &accountZones{},
+		zonesOrdered:              map[string]bool{},
+		cacheStore:                ovhConfig.CacheStore,
+	}
+	if ovhConfig.CacheStore != nil && ovhConfig.UseCache {
+		p.restoreCache(ctx)
+	}
+	return p, nil
+}
 
-	return &OVHProvider{
-		client:                    client,
-		domainFilter:              domainFilter,
-		apiRateLimiter:            ratelimit.New(apiRateLimit),
-		DryRun:                    dryRun,
-		cacheInstance:             cache.New(cache.NoExpiration, cache.NoExpiration),
-		dnsClient:                 new(dns.Client),
-		UseCache:                  true,
-		EnableCNAMERelativeTarget: enableCNAMERelative,
-	}, nil
+// AdjustEndpoints sets the TTL of the endpoints without one to the one of their ovh/ttl property, else
+// to the default TTL, so that the records whose TTL differs get updated. The targets are formatted the
+// way the ones of the records are returned by Records: trimmed, without trailing dot but for NAPTR ones,
+// and the others formatted the way OVHcloud stores them.
+func (p *OVHProvider) AdjustEndpoints(endpoints []*endpoint.Endpoint) ([]*endpoint.Endpoint, error) {
+	for _, e := range endpoints {
+		// targets are compared with the ones of the records, formatted by OVHcloud
+		for i, target := range e.Targets {
+			target = formatRData(e.RecordType, target)
+			if e.RecordType != endpoint.RecordTypeNAPTR {
+				// like the targets of the records, whose trailing dot is dropped by their endpoint
+				target = strings.TrimSuffix(target, ".")
+			}
+			e.Targets[i] = target
+		}
+		value, ok := e.GetProviderSpecificProperty(ovhTTLKey)
+		if ok {
+			e.DeleteProviderSpecificProperty(ovhTTLKey)
+		}
+		if e.RecordTTL.IsConfigured() {
+			continue
+		}
+		if ok {
+			ttl, err := strconv.ParseInt(value, 10, 64)
+			if err == nil && ttl >= 0 {
+				e.RecordTTL = endpoint.TTL(ttl)
+				continue
+			}
+			log.Warnf("OVH: ignoring the invalid %s property %q of %s", ovhTTLKey, value, e.DNSName)
+		}
+		e.RecordTTL = endpoint.TTL(p.DefaultTTL)
+	}
+	return endpoints, nil
 }
 
 // Records returns the list of records in all relevant zones.
 func (p *OVHProvider) Records(ctx context.Context) ([]*endpoint.Endpoint, error) {
-	zones, records, err := p.zonesRecords(ctx)
+	p.refreshCredentials(ctx)
+	p.lastRunSerials.reset()
+	zones, skippedZones, records, err := p.zonesRecords(ctx)
 	if err != nil {
 		return nil, err
 	}
 	p.lastRunRecords = records
 	p.lastRunZones = zones
+	p.lastRunSkippedZones = skippedZones
+	p.accountZones.set(zones)
+	p.forgetCreatedZones(zones)
+	p.reconcileDNSSEC(ctx, zones)
+	if p.cacheStore != nil && p.UseCache {
+		p.persistCache(ctx)
+	}
 	endpoints := ovhGroupByNameAndType(records)
 	log.Infof("OVH: %d endpoints have been found", len(endpoints))
 	return endpoints, nil
 }
 
-func planChangesByZoneName(zones []string, changes *plan.Changes) map[string]*plan.Changes {
-	zoneNameIDMapper := provider.ZoneIDName{}
+func (p *OVHProvider) planChangesByZoneName(zones []string, changes *plan.Changes) map[string]*plan.Changes {
+	zoneNameIDMapper := p.NewZoneFinder()
 	for _, zone := range zones {
 		zoneNameIDMapper.Add(zone, zone)
 	}
@@ -211,7 +456,20 @@ func (p OVHProvider) computeSingleZoneChanges(_ context.Context, zoneName string
 }
 
 func (p *OVHProvider) handleSingleZoneUpdate(ctx context.Context, zoneName string, existingRecords []ovhRecord, changes *plan.Changes) error {
+	existingRecords, err := p.resolveRecordIDs(ctx, zoneName, existingRecords, changes)
+	if err != nil {
+		return err
+	}
 	allChanges := p.computeSingleZoneChanges(ctx, zoneName, existingRecords, changes)
+	if p.DryRun {
+		logDryRunChanges(zoneName, allChanges)
+		return nil
+	}
+	if p.CheckZoneSerials {
+		if err := p.checkSerial(ctx, zoneName); err != nil {
+			return err
+		}
+	}
 	log.Infof("OVH: %q: %d changes will be done", zoneName, len(allChanges))
 
 	eg, ctxErrGroup := errgroup.WithContext(ctx)
@@ -222,7 +480,7 @@ func (p *OVHProvider) handleSingleZoneUpdate(ctx context.Context, zoneName strin
 		})
 	}
 
-	err := eg.Wait()
+	err = eg.Wait()
 
 	// do not refresh zone if errors: some records might haven't been processed yet, hence the zone will be in an inconsistent state
 	// if modification of the zone was in error, invalidating the cache to make sure next run will start freshly
@@ -237,10 +495,12 @@ func (p *OVHProvider) handleSingleZoneUpdate(ctx context.Context, zoneName strin
 
 // ApplyChanges applies a given set of changes in a given zone.
 func (p *OVHProvider) ApplyChanges(ctx context.Context, changes *plan.Changes) (err error) {
-	zones, records := p.lastRunZones, p.lastRunRecords
+	p.refreshCredentials(ctx)
 	defer func() {
 		p.lastRunRecords = []ovhRecord{}
 		p.lastRunZones = []string{}
+		p.lastRunSkippedZones = nil
+		p.lastRunSerials.reset()
 	}()
 
 	if log.IsLevelEnabled(log.DebugLevel) {
@@ -258,12 +518,31 @@ func (p *OVHProvider) ApplyChanges(ctx context.Context, changes *plan.Changes) (
 		}
 	}
 
-	changesByZoneName := planChangesByZoneName(zones, changes)
+	return provider.ApplyChangesInBatches(ctx, p, changes)
+}
+
+// MaxBatchSize returns the maximum number of changes applied at once.
+func (p *OVHProvider) MaxBatchSize() int {
+	return p.BatchSize
+}
+
+// ApplyChangesBatch applies a batch of changes, using the zones and records of the last call to Records,
+// and refreshes the zones it changed.
+func (p *OVHProvider) ApplyChangesBatch(ctx context.Context, changes *plan.Changes) error {
+	changesByZoneName := p.planChangesByZoneName(p.lastRunZones, changes)
+	if missing, ok := changesByZoneName[""]; ok && p.CreateZones {
+		delete(changesByZoneName, "")
+		p.createZones(ctx, missing)
+	}
 	eg, ctx := errgroup.WithContext(ctx)
 
 	for zoneName, changes := range changesByZoneName {
+		if slices.Contains(p.lastRunSkippedZones, zoneName) {
+			log.Warnf("OVH: zone %s: Skipping its changes, its records could not be got", zoneName)
+			continue
+		}
 		eg.Go(func() error {
-			return p.handleSingleZoneUpdate(ctx, zoneName, records, changes)
+			return p.handleSingleZoneUpdate(ctx, zoneName, p.lastRunRecords, changes)
 		})
 	}
 
@@ -274,6 +553,32 @@ func (p *OVHProvider) ApplyChanges(ctx context.Context, changes *plan.Changes) (
 	return nil
 }
 
+// logDryRunChanges logs the API calls that would apply changes to zoneName, without doing them.
+func logDryRunChanges(zoneName string, changes []ovhChange) {
+	log.Infof("OVH: Dry-run: %q: %d changes would be done", zoneName, len(changes))
+	if len(changes) == 0 {
+		return
+	}
+	for _, change := range changes {
+		method, path := change.apiCall()
+		log.Infof("OVH: Dry-run: %q: would have called %s %s for %s", zoneName, method, path, change.ovhRecord)
+	}
+	log.Infof("OVH: Dry-run: %q: would have called POST /domain/zone/%s/refresh", zoneName, url.PathEscape(zoneName))
+}
+
+// apiCall returns the HTTP method and path of the API call applying the change.
+func (c ovhChange) apiCall() (string, string) {
+	switch c.Action {
+	case ovhCreate:
+		return http.MethodPost, fmt.Sprintf("/domain/zone/%s/record", url.PathEscape(c.Zone))
+	case ovhDelete:
+		return http.MethodDelete, fmt.Sprintf("/domain/zone/%s/record/%d", url.PathEscape(c.Zone), c.ID)
+	case ovhUpdate:
+		return http.MethodPut, fmt.Sprintf("/domain/zone/%s/record/%d", url.PathEscape(c.Zone), c.ID)
+	}
+	return "", ""
+}
+
 func (p *OVHProvider) refresh(ctx context.Context, zone string) error {
 	log.Debugf("OVH: Refresh %s zone", zone)
 
@@ -281,10 +586,8 @@ func (p *OVHProvider) refresh(ctx context.Context, zone string) error {
 	// so that the next run will reload it.
 	p.invalidateCache(zone)
 
-	p.apiRateLimiter.Take()
-	if p.DryRun {
-		log.Infof("OVH: Dry-run: Would have refresh DNS zone %q", zone)
-		return nil
+	if err := p.apiRateLimiter.Take(ctx); err != nil {
+		return err
 	}
 	if err := p.client.PostWithContext(ctx, fmt.Sprintf("/domain/zone/%s/refresh", url.PathEscape(zone)), nil, nil); err != nil {
 		return provider.NewSoftError(err)
@@ -294,74 +597,96 @@ func (p *OVHProvider) refresh(ctx context.Context, zone string) error {
 }
 
 func (p *OVHProvider) change(ctx context.Context, change ovhChange) error {
-	p.apiRateLimiter.Take()
+	if (change.Action == ovhDelete || change.Action == ovhUpdate) && change.ID == 0 {
+		return ErrRecordToMutateNotFound
+	}
 
+	method, path := change.apiCall()
 	switch change.Action {
 	case ovhCreate:
 		log.Debugf("OVH: Add an entry to %s", change.String())
-		if p.DryRun {
-			log.Infof("OVH: Dry-run: Would have created a DNS record for zone %s", change.Zone)
-			return nil
-		}
-		return p.client.PostWithContext(ctx, fmt.Sprintf("/domain/zone/%s/record", url.PathEscape(change.Zone)), change.ovhRecordFields, nil)
+		return p.withRetry(ctx, method, func() error {
+			return p.client.PostWithContext(ctx, path, change.ovhRecordFields, nil)
+		})
 	case ovhDelete:
-		if change.ID == 0 {
-			return ErrRecordToMutateNotFound
-		}
 		log.Debugf("OVH: Delete an entry to %s", change.String())
-		if p.DryRun {
-			log.Infof("OVH: Dry-run: Would have deleted a DNS record for zone %s", change.Zone)
-			return nil
-		}
-		return p.client.DeleteWithContext(ctx, fmt.Sprintf("/domain/zone/%s/record/%d", url.PathEscape(change.Zone), change.ID), nil)
+		return p.withRetry(ctx, method, func() error {
+			return p.client.DeleteWithContext(ctx, path, nil)
+		})
 	case ovhUpdate:
-		if change.ID == 0 {
-			return ErrRecordToMutateNotFound
-		}
 		log.Debugf("OVH: Update an entry to %s", change.String())
-		if p.DryRun {
-			log.Infof("OVH: Dry-run: Would have updated a DNS record for zone %s", change.Zone)
-			return nil
-		}
-		return p.client.PutWithContext(ctx, fmt.Sprintf("/domain/zone/%s/record/%d", url.PathEscape(change.Zone), change.ID), change.ovhRecordFieldUpdate, nil)
+		return p.withRetry(ctx, method, func() error {
+			return p.client.PutWithContext(ctx, path, change.ovhRecordFieldUpdate, nil)
+		})
 	default:
 		return nil
 	}
 }
 
 func (p *OVHProvider) invalidateCache(zone string) {
+	cacheInvalidationsTotal.Counter.Inc()
 	p.cacheInstance.Delete(zone + "#soa")
 }
 
-func (p *OVHProvider) zonesRecords(ctx context.Context) ([]string, []ovhRecord, error) {
+// cachesZone tells whether the records of zone are cached.
+func (p *OVHProvider) cachesZone(zone string) bool {
+	return p.UseCache && !slices.Contains(p.CacheExcludedZones, zone)
+}
+
+// zonesRecords returns the zones and their records. With SkipFailedZones, the zones whose records could not
+// be got are returned as skipped, without records, unless all of them failed.
+func (p *OVHProvider) zonesRecords(ctx context.Context) ([]string, []string, []ovhRecord, error) {
 	var allRecords []ovhRecord
 	zones, err := p.zones(ctx)
 	if err != nil {
-		return nil, nil, provider.NewSoftError(err)
+		return nil, nil, nil, provider.NewSoftError(err)
 	}
 
 	chRecords := make(chan []ovhRecord, len(zones))
-	eg, ctx := errgroup.WithContext(ctx)
+	chSkipped := make(chan string, len(zones))
+	eg, egCtx := errgroup.WithContext(ctx)
 	for _, zone := range zones {
 		zone := zone
-		eg.Go(func() error { return p.records(ctx, &zone, chRecords) })
+		if !p.SkipFailedZones {
+			eg.Go(func() error { return p.records(egCtx, &zone, chRecords) })
+			continue
+		}
+		// a failed zone must not cancel the others
+		eg.Go(func() error {
+			if err := p.records(ctx, &zone, chRecords); err != nil {
+				log.Errorf("OVH: zone %s: Skipping it until the next synchronization, its records could not be got: %v", zone, err)
+				zonesSkippedTotal.CounterVec.WithLabelValues(zone).Inc()
+				chSkipped <- zone
+			}
+			return nil
+		})
 	}
 	if err := eg.Wait(); err != nil {
-		return nil, nil, provider.NewSoftError(err)
+		return nil, nil, nil, provider.NewSoftError(err)
 	}
 	close(chRecords)
+	close(chSkipped)
 	for records := range chRecords {
 		allRecords = append(allRecords, records...)
 	}
-	return zones, allRecords, nil
+	var skippedZones []string
+	for zone := range chSkipped {
+		skippedZones = append(skippedZones, zone)
+	}
+	if len(skippedZones) > 0 && len(skippedZones) == len(zones) {
+		return nil, nil, nil, provider.NewSoftError(fmt.Errorf("the records of none of the %d zones could be got", len(zones)))
+	}
+	slices.Sort(skippedZones)
+	return zones, skippedZones, allRecords, nil
 }
 
 func (p *OVHProvider) zones(ctx context.Context) ([]string, error) {
 	var zones []string
 	var filteredZones []string
 
-	p.apiRateLimiter.Take()
-	if err := p.client.GetWithContext(ctx, "/domain/zone", &zones); err != nil {
+	if err := p.withRetry(ctx, http.MethodGet, func() error {
+		return p.client.GetWithContext(ctx, "/domain/zone", &zones)
+	}); err != nil {
 		return nil, err
 	}
 
@@ -382,10 +707,9 @@ type ovhSoa struct {
 
 func (p *OVHProvider) records(ctx context.Context, zone *string, records chan<- []ovhRecord) error {
 	var recordsIds []uint64
-	ovhRecords := make([]ovhRecord, len(recordsIds))
-	eg, ctxErrGroup := errgroup.WithContext(ctx)
 
-	if p.UseCache {
+	useCache := p.cachesZone(*zone)
+	if useCache {
 		if cachedSoaItf, ok := p.cacheInstance.Get(*zone + "#soa"); ok {
 			cachedSoa := cachedSoaItf.(ovhSoa)
 
@@ -393,54 +717,103 @@ func (p *OVHProvider) records(ctx context.Context, zone *string, records chan<-
 
 			m := new(dns.Msg)
 			m.SetQuestion(dns.Fqdn(*zone), dns.TypeSOA)
-			in, _, err := p.dnsClient.ExchangeContext(ctx, m, strings.TrimSuffix(cachedSoa.Server, ".")+":53")
-			if err == nil {
+			// a resolver answers for the zone on behalf of its SOA server
+			m.RecursionDesired = p.soaCheck.Resolver != ""
+			in, _, err := p.dnsClient.ExchangeContext(ctx, m, p.soaCheck.address(cachedSoa.Server))
+			result := "failed"
+			if err != nil {
+				log.Warnf("OVH: zone %s: Failed to check the SOA serial, getting the records again from the API: %v", *zone, err)
+			} else if len(in.Answer) > 0 {
 				if s, ok := in.Answer[0].(*dns.SOA); ok {
 					if s.Serial == cachedSoa.Serial {
 						log.Debugf("OVH: zone %s: SOA from cache is valid", *zone)
+						soaValidationsTotal.CounterVec.WithLabelValues("valid").Inc()
+						cacheLookupsTotal.CounterVec.WithLabelValues("hit").Inc()
+						p.lastRunSerials.set(*zone, cachedSoa.Serial)
 						records <- cachedSoa.records
 						return nil
 					}
+					result = "changed"
 				}
 			}
+			soaValidationsTotal.CounterVec.WithLabelValues(result).Inc()
 
 			p.invalidateCache(*zone)
 		}
+		cacheLookupsTotal.CounterVec.WithLabelValues("miss").Inc()
 	}
 
 	log.Debugf("OVH: Getting records for %s from API", *zone)
 
-	p.apiRateLimiter.Take()
 	var soa ovhSoa
-	if p.UseCache {
-		if err := p.client.GetWithContext(ctx, "/domain/zone/"+url.PathEscape(*zone)+"/soa", &soa); err != nil {
+	if useCache || p.CheckZoneSerials {
+		if err := p.withRetry(ctx, http.MethodGet, func() error {
+			return p.client.GetWithContext(ctx, "/domain/zone/"+url.PathEscape(*zone)+"/soa", &soa)
+		}); err != nil {
 			return err
 		}
+		p.lastRunSerials.set(*zone, soa.Serial)
 	}
 
-	if err := p.client.GetWithContext(ctx, fmt.Sprintf("/domain/zone/%s/record", url.PathEscape(*zone)), &recordsIds); err != nil {
-		return err
+	var ovhRecords []ovhRecord
+	fetched := false
+	if p.RecordsFetchMode == RecordsFetchModeExport {
+		var err error
+		if ovhRecords, err = p.exportRecords(ctx, *zone); err == nil {
+			fetched = true
+		} else {
+			log.Warnf("OVH: zone %s: parsing the zone export failed, fetching records one by one: %v", *zone, err)
+		}
+	}
+
+	if !fetched {
+		if err := p.withRetry(ctx, http.MethodGet, func() error {
+			return p.client.GetWithContext(ctx, fmt.Sprintf("/domain/zone/%s/record", url.PathEscape(*zone)), &recordsIds)
+		}); err != nil {
+			return err
+		}
+		if batch, ok := p.client.(ovhBatchClient); ok && p.RecordsFetchMode == RecordsFetchModeBatch {
+			var err error
+			if ovhRecords, err = p.batchRecords(ctx, batch, *zone, recordsIds); err == nil {
+				fetched = true
+			} else {
+				log.Warnf("OVH: zone %s: fetching records in batches failed, fetching them one by one: %v", *zone, err)
+			}
+		}
+	}
+	if !fetched {
+		var err error
+		if ovhRecords, err = p.recordsOneByOne(ctx, zone, recordsIds); err != nil {
+			return err
+		}
+	}
+
+	if useCache {
+		soa.records = ovhRecords
+		_ = p.cacheInstance.Add(*zone+"#soa", soa, cache.DefaultExpiration)
 	}
+
+	records <- ovhRecords
+	return nil
+}
+
+// recordsOneByOne gets the records of zone with the given IDs, with one API call per record.
+func (p *OVHProvider) recordsOneByOne(ctx context.Context, zone *string, recordsIds []uint64) ([]ovhRecord, error) {
+	ovhRecords := []ovhRecord{}
+	eg, ctxErrGroup := errgroup.WithContext(ctx)
 	chRecords := make(chan ovhRecord, len(recordsIds))
 	for _, id := range recordsIds {
 		id := id
 		eg.Go(func() error { return p.record(ctxErrGroup, zone, id, chRecords) })
 	}
 	if err := eg.Wait(); err != nil {
-		return err
+		return nil, err
 	}
 	close(chRecords)
 	for record := range chRecords {
 		ovhRecords = append(ovhRecords, record)
 	}
-
-	if p.UseCache {
-		soa.records = ovhRecords
-		_ = p.cacheInstance.Add(*zone+"#soa", soa, cache.DefaultExpiration)
-	}
-
-	records <- ovhRecords
-	return nil
+	return ovhRecords, nil
 }
 
 func (p *OVHProvider) record(ctx context.Context, zone *string, id uint64, records chan<- ovhRecord) error {
@@ -448,11 +821,12 @@ func (p *OVHProvider) record(ctx context.Context, zone *string, id uint64, recor
 
 	log.Debugf("OVH: Getting record %d for %s", id, *zone)
 
-	p.apiRateLimiter.Take()
-	if err := p.client.GetWithContext(ctx, fmt.Sprintf("/domain/zone/%s/record/%d", url.PathEscape(*zone), id), &record); err != nil {
+	if err := p.withRetry(ctx, http.MethodGet, func() error {
+		return p.client.GetWithContext(ctx, fmt.Sprintf("/domain/zone/%s/record/%d", url.PathEscape(*zone), id), &record)
+	}); err != nil {
 		return err
 	}
-	if provider.SupportedRecordType(record.FieldType) {
+	if supportedRecordType(record.FieldType) {
 		log.Debugf("OVH: Record %d for %s is %+v", id, *zone, record)
 		records <- record
 	}
@@ -478,7 +852,7 @@ func ovhGroupByNameAndType(records []ovhRecord) []*endpoint.Endpoint {
 	for _, records := range groups {
 		var targets []string
 		for _, record := range records {
-			targets = append(targets, record.Target)
+			targets = append(targets, formatRData(record.FieldType, record.Target))
 		}
 		ep := endpoint.NewEndpointWithTTL(
 			strings.TrimPrefix(records[0].SubDomain+"."+records[0].Zone, "."),
@@ -486,6 +860,10 @@ func ovhGroupByNameAndType(records []ovhRecord) []*endpoint.Endpoint {
 			endpoint.TTL(records[0].TTL),
 			targets...,
 		)
+		if ep.RecordType == endpoint.RecordTypeNAPTR {
+			// the replacement of NAPTR targets keeps its trailing dot
+			ep.Targets = targets
+		}
 		endpoints = append(endpoints, ep)
 	}
 
@@ -497,6 +875,10 @@ func (p OVHProvider) newOvhChangeCreateDelete(action int, endpoints []*endpoint.
 	var toDeleteIds []int
 
 	for _, e := range endpoints {
+		if action == ovhDelete && isZoneApexRecord(e.RecordType, convertDNSNameIntoSubDomain(e.DNSName, zone)) {
+			log.Warnf("OVH: zone %s: not deleting the %s records at the zone apex", zone, e.RecordType)
+			continue
+		}
 		for _, target := range e.Targets {
 			change := ovhChange{
 				Action: action,
@@ -506,7 +888,7 @@ func (p OVHProvider) newOvhChangeCreateDelete(action int, endpoints []*endpoint.
 						FieldType: e.RecordType,
 						ovhRecordFieldUpdate: ovhRecordFieldUpdate{
 							SubDomain: convertDNSNameIntoSubDomain(e.DNSName, zone),
-							TTL:       defaultTTL,
+							TTL:       p.DefaultTTL,
 							Target:    target,
 						},
 					},
@@ -521,7 +903,7 @@ func (p OVHProvider) newOvhChangeCreateDelete(action int, endpoints []*endpoint.
 			// same OVH record, we remove a record from the list when a match is found.
 			if action == ovhDelete {
 				for i, rec := range existingRecords {
-					if rec.Zone == change.Zone && rec.SubDomain == change.SubDomain && rec.FieldType == change.FieldType && rec.Target == change.Target && !slices.Contains(toDeleteIds, i) {
+					if rec.Zone == change.Zone && rec.SubDomain == change.SubDomain && rec.FieldType == change.FieldType && p.formatTarget(rec.FieldType, rec.Target) == change.Target && !slices.Contains(toDeleteIds, i) {
 						change.ID = rec.ID
 						toDeleteIds = append(toDeleteIds, i)
 						break
@@ -534,18 +916,26 @@ func (p OVHProvider) newOvhChangeCreateDelete(action int, endpoints []*endpoint.
 	}
 
 	if len(toDeleteIds) > 0 {
-		// Copy the records because we need to mutate the list.
-		existingRecords = slices.Clone(existingRecords)
-		alreadyRemoved := 0
-		for _, id := range toDeleteIds {
-			existingRecords = slices.Delete(existingRecords, id-alreadyRemoved, id-alreadyRemoved+1)
-			alreadyRemoved++
+		// Build a new list because the caller's records must not be mutated, and
+		// toDeleteIds follows the order of the endpoints rather than of the records.
+		remainingRecords := make([]ovhRecord, 0, len(existingRecords)-len(toDeleteIds))
+		for i, rec := range existingRecords {
+			if !slices.Contains(toDeleteIds, i) {
+				remainingRecords = append(remainingRecords, rec)
+			}
 		}
+		existingRecords = remainingRecords
 	}
 
 	return ovhChanges, existingRecords
 }
 
+// isZoneApexRecord returns whether the records of type fieldType at subDomain are NS or SOA records at the
+// zone apex, which are never updated nor deleted.
+func isZoneApexRecord(fieldType, subDomain string) bool {
+	return subDomain == "" && (fieldType == endpoint.RecordTypeNS || fieldType == endpoint.RecordTypeSOA)
+}
+
 func convertDNSNameIntoSubDomain(DNSName string, zoneName string) string {
 	if DNSName == zoneName {
 		return ""
@@ -555,7 +945,7 @@ func convertDNSNameIntoSubDomain(DNSName string, zoneName string) string {
 }
 
 func (p OVHProvider) newOvhChangeUpdate(endpointsOld []*endpoint.Endpoint, endpointsNew []*endpoint.Endpoint, zone string, existingRecords []ovhRecord) []ovhChange {
-	zoneNameIDMapper := provider.ZoneIDName{}
+	zoneNameIDMapper := p.NewZoneFinder()
 	zoneNameIDMapper.Add(zone, zone)
 
 	oldEndpointByTypeAndName := map[string]*endpoint.Endpoint{}
@@ -581,31 +971,49 @@ func (p OVHProvider) newOvhChangeUpdate(endpointsOld []*endpoint.Endpoint, endpo
 
 	var changes []ovhChange
 
-	for id := range oldEndpointByTypeAndName {
+	for id, e := range oldEndpointByTypeAndName {
+		if isZoneApexRecord(e.RecordType, convertDNSNameIntoSubDomain(e.DNSName, zone)) {
+			log.Warnf("OVH: zone %s: not updating the %s records at the zone apex", zone, e.RecordType)
+			continue
+		}
 		oldRecords := slices.Clone(oldRecordsInZone[id])
 		endpointsNew := newEndpointByTypeAndName[id]
 
+		recordTTL := p.DefaultTTL
+		if endpointsNew.RecordTTL.IsConfigured() {
+			recordTTL = int64(endpointsNew.RecordTTL)
+		}
+
 		var toInsertTarget []string
 
 		for _, target := range endpointsNew.Targets {
 			var toDelete = -1
 
 			for i, record := range oldRecords {
-				if target == record.Target {
+				if p.formatTarget(record.FieldType, target) == p.formatTarget(record.FieldType, record.Target) {
 					toDelete = i
 					break
 				}
 			}
 
 			if toDelete >= 0 {
+				// the target is kept, but the whole record set shares the desired TTL
+				if record := oldRecords[toDelete]; record.TTL != recordTTL {
+					record.TTL = recordTTL
+					changes = append(changes, ovhChange{
+						Action:    ovhUpdate,
+						ovhRecord: record,
+					})
+				}
 				oldRecords = slices.Delete(oldRecords, toDelete, toDelete+1)
 			} else {
 				toInsertTarget = append(toInsertTarget, target)
 			}
 		}
 
-		toInsertTargetToDelete := []int{}
-		for i, target := range toInsertTarget {
+		// reuse the records of removed targets for the new ones, in order
+		reused := 0
+		for _, target := range toInsertTarget {
 			if len(oldRecords) == 0 {
 				break
 			}
@@ -613,12 +1021,7 @@ func (p OVHProvider) newOvhChangeUpdate(endpointsOld []*endpoint.Endpoint, endpo
 			record := oldRecords[0]
 			oldRecords = slices.Delete(oldRecords, 0, 1)
 			record.Target = target
-
-			if endpointsNew.RecordTTL.IsConfigured() {
-				record.TTL = int64(endpointsNew.RecordTTL)
-			} else {
-				record.TTL = defaultTTL
-			}
+			record.TTL = recordTTL
 
 			change := ovhChange{
 				Action:    ovhUpdate,
@@ -626,19 +1029,12 @@ func (p OVHProvider) newOvhChangeUpdate(endpointsOld []*endpoint.Endpoint, endpo
 			}
 			p.formatCNAMETarget(&change)
 			changes = append(changes, change)
-			toInsertTargetToDelete = append(toInsertTargetToDelete, i)
-		}
-		for _, i := range toInsertTargetToDelete {
-			toInsertTarget = slices.Delete(toInsertTarget, i, i+1)
+			reused++
 		}
+		toInsertTarget = toInsertTarget[reused:]
 
 		if len(toInsertTarget) > 0 {
 			for _, target := range toInsertTarget {
-				recordTTL := int64(defaultTTL)
-				if endpointsNew.RecordTTL.IsConfigured() {
-					recordTTL = int64(endpointsNew.RecordTTL)
-				}
-
 				change := ovhChange{
 					Action: ovhCreate,
 					ovhRecord: ovhRecord{
@@ -691,17 +1087,139 @@ func (c *ovhChange) String() string {
 }
 
 func (p OVHProvider) formatCNAMETarget(change *ovhChange) {
-	if change.FieldType != endpoint.RecordTypeCNAME {
-		return
+	change.Target = p.formatTarget(change.FieldType, change.Target)
+}
+
+// formatTarget returns target the way it is stored by OVHcloud for a record of fieldType.
+func (p OVHProvider) formatTarget(fieldType, target string) string {
+	if fieldType != endpoint.RecordTypeCNAME {
+		return formatRData(fieldType, target)
 	}
 
 	if p.EnableCNAMERelativeTarget {
-		return
+		return target
 	}
 
-	if strings.HasSuffix(change.Target, ".") {
-		return
+	if strings.HasSuffix(target, ".") {
+		return target
 	}
 
-	change.Target += "."
+	return target + "."
+}
+
+// supportedRecordType returns whether the records of recordType are managed by the OVH provider,
+// which supports CAA, NAPTR, TLSA and SSHFP records on top of the common ones.
+func supportedRecordType(recordType string) bool {
+	switch recordType {
+	case endpoint.RecordTypeCAA, endpoint.RecordTypeNAPTR, endpoint.RecordTypeTLSA, endpoint.RecordTypeSSHFP:
+		return true
+	}
+	return provider.SupportedRecordType(recordType)
+}
+
+// formatRData returns a target of a record of fieldType the way it is stored by OVHcloud, trimmed, TXT
+// targets as quoted character strings and targets of types without a specific format as is.
+func formatRData(fieldType, target string) string {
+	target = strings.TrimSpace(target)
+	switch fieldType {
+	case endpoint.RecordTypeTXT:
+		// OVHcloud returns TXT targets quoted, whether they were given quoted or not
+		return endpoint.NewTXTTarget(target).String()
+	case endpoint.RecordTypeCAA:
+		return formatCAATarget(target)
+	case endpoint.RecordTypeNAPTR:
+		return formatNAPTRTarget(target)
+	case endpoint.RecordTypeTLSA:
+		return formatHexTarget(target, 3, (endpoint.Targets{target}).ValidateTLSARecord())
+	case endpoint.RecordTypeSSHFP:
+		return formatHexTarget(target, 2, (endpoint.Targets{target}).ValidateSSHFPRecord())
+	}
+	return target
+}
+
+// formatCAATarget returns a CAA target the way it is stored by OVHcloud: its flags, tag and quoted
+// value separated by single spaces, e.g. 0 issue "letsencrypt.org". Invalid targets are returned as is.
+func formatCAATarget(target string) string {
+	if !(endpoint.Targets{target}).ValidateCAARecord() {
+		return target
+	}
+	flags, rest, _ := strings.Cut(strings.TrimSpace(target), " ")
+	tag, value, _ := strings.Cut(strings.TrimSpace(rest), " ")
+	value = strings.TrimSpace(value)
+	if len(value) < 2 || !strings.HasPrefix(value, `"`) || !strings.HasSuffix(value, `"`) {
+		value = strconv.Quote(value)
+	}
+	return flags + " " + strings.ToLower(tag) + " " + value
+}
+
+// formatNAPTRTarget returns a NAPTR target the way it is stored by OVHcloud: its order, preference,
+// quoted flags, service and regexp, and fully qualified replacement separated by single spaces, e.g.
+// 100 10 "S" "SIP+D2U" "!^.*$!sip:info@example.com!" _sip._udp.example.com. Invalid targets are returned as is.
+func formatNAPTRTarget(target string) string {
+	fields, ok := splitNAPTRTarget(target)
+	if !ok || len(fields) < 5 || len(fields) > 6 {
+		return target
+	}
+	// endpoints drop the trailing dot of their targets, which may be the whole root replacement
+	if len(fields) == 5 {
+		fields = append(fields, "")
+	}
+	if !strings.HasSuffix(fields[5], ".") {
+		fields[5] += "."
+	}
+	for _, field := range fields[:2] {
+		if _, err := strconv.ParseUint(field, 10, 16); err != nil {
+			return target
+		}
+	}
+	return fmt.Sprintf(`%s %s "%s" "%s" "%s" %s`, fields[0], fields[1], fields[2], fields[3], fields[4], fields[5])
+}
+
+// splitNAPTRTarget splits a NAPTR target on whitespace, except within double quotes, and unquotes its
+// fields, escaped characters being kept as is. It returns false when a quote is not closed.
+func splitNAPTRTarget(target string) ([]string, bool) {
+	var fields []string
+	var field strings.Builder
+	inField, quoted, escaped := false, false, false
+	for _, r := range target {
+		switch {
+		case escaped:
+			field.WriteRune(r)
+			escaped = false
+		case r == '\\':
+			field.WriteRune(r)
+			escaped = true
+			inField = true
+		case r == '"':
+			quoted = !quoted
+			inField = true
+		case !quoted && unicode.IsSpace(r):
+			if inField {
+				fields = append(fields, field.String())
+				field.Reset()
+				inField = false
+			}
+		default:
+			field.WriteRune(r)
+			inField = true
+		}
+	}
+	if quoted || escaped {
+		return nil, false
+	}
+	if inField {
+		fields = append(fields, field.String())
+	}
+	return fields, true
+}
+
+// formatHexTarget returns a normalized TLSA or SSHFP target: its leading integers and its hexadecimal data,
+// lowercased and without whitespace, separated by single spaces, e.g. 3 1 1 0c72ac70b745ac19, so that
+// targets compare equal whatever the case and the spacing of their data. Invalid targets are returned as is.
+func formatHexTarget(target string, integers int, valid bool) string {
+	if !valid {
+		return target
+	}
+	fields := strings.Fields(target)
+	return strings.Join(fields[:integers], " ") + " " + strings.ToLower(strings.Join(fields[integers:], ""))
 }
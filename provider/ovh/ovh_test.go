@@ -20,6 +20,8 @@ import (
 	"context"
 	"encoding/json"
 	"errors"
+	"fmt"
+	"slices"
 	"sort"
 	"testing"
 	"time"
@@ -28,10 +30,15 @@ import (
 	"github.com/miekg/dns"
 	"github.com/ovh/go-ovh/ovh"
 	"github.com/patrickmn/go-cache"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	log "github.com/sirupsen/logrus"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
-	"go.uber.org/ratelimit"
+	"github.com/stretchr/testify/require"
 	"sigs.k8s.io/external-dns/endpoint"
+	"sigs.k8s.io/external-dns/internal/planfuzz"
+	"sigs.k8s.io/external-dns/internal/testutils"
+	"sigs.k8s.io/external-dns/pkg/resolver"
 	"sigs.k8s.io/external-dns/plan"
 )
 
@@ -85,7 +92,7 @@ func TestOvhZones(t *testing.T) {
 	client := new(mockOvhClient)
 	provider := &OVHProvider{
 		client:         client,
-		apiRateLimiter: ratelimit.New(10),
+		apiRateLimiter: newAdaptiveLimiter(10),
 		domainFilter:   endpoint.NewDomainFilter([]string{"com"}),
 		cacheInstance:  cache.New(cache.NoExpiration, cache.NoExpiration),
 		dnsClient:      new(mockDnsClient),
@@ -110,7 +117,7 @@ func TestOvhZones(t *testing.T) {
 func TestOvhZoneRecords(t *testing.T) {
 	assert := assert.New(t)
 	client := new(mockOvhClient)
-	provider := &OVHProvider{client: client, apiRateLimiter: ratelimit.New(10), cacheInstance: cache.New(cache.NoExpiration, cache.NoExpiration), dnsClient: nil, UseCache: true}
+	provider := &OVHProvider{client: client, apiRateLimiter: newAdaptiveLimiter(10), cacheInstance: cache.New(cache.NoExpiration, cache.NoExpiration), dnsClient: nil, UseCache: true}
 
 	// Basic zones records
 	t.Log("Basic zones records")
@@ -119,7 +126,7 @@ func TestOvhZoneRecords(t *testing.T) {
 	client.On("GetWithContext", "/domain/zone/example.org/record").Return([]uint64{24, 42}, nil).Once()
 	client.On("GetWithContext", "/domain/zone/example.org/record/24").Return(ovhRecord{ID: 24, Zone: "example.org", ovhRecordFields: ovhRecordFields{FieldType: "NS", ovhRecordFieldUpdate: ovhRecordFieldUpdate{SubDomain: "ovh", TTL: 10, Target: "203.0.113.42"}}}, nil).Once()
 	client.On("GetWithContext", "/domain/zone/example.org/record/42").Return(ovhRecord{ID: 42, Zone: "example.org", ovhRecordFields: ovhRecordFields{FieldType: "A", ovhRecordFieldUpdate: ovhRecordFieldUpdate{SubDomain: "ovh", TTL: 10, Target: "203.0.113.42"}}}, nil).Once()
-	zones, records, err := provider.zonesRecords(t.Context())
+	zones, _, records, err := provider.zonesRecords(t.Context())
 	assert.NoError(err)
 	assert.ElementsMatch(zones, []string{"example.org"})
 	assert.ElementsMatch(records, []ovhRecord{{ID: 42, Zone: "example.org", ovhRecordFields: ovhRecordFields{FieldType: "A", ovhRecordFieldUpdate: ovhRecordFieldUpdate{SubDomain: "ovh", TTL: 10, Target: "203.0.113.42"}}}, {ID: 24, Zone: "example.org", ovhRecordFields: ovhRecordFields{FieldType: "NS", ovhRecordFieldUpdate: ovhRecordFieldUpdate{SubDomain: "ovh", TTL: 10, Target: "203.0.113.42"}}}})
@@ -128,7 +135,7 @@ func TestOvhZoneRecords(t *testing.T) {
 	// Error on getting zones list
 	t.Log("Error on getting zones list")
 	client.On("GetWithContext", "/domain/zone").Return(nil, ovh.ErrAPIDown).Once()
-	zones, records, err = provider.zonesRecords(t.Context())
+	zones, _, records, err = provider.zonesRecords(t.Context())
 	assert.Error(err)
 	assert.Nil(zones)
 	assert.Nil(records)
@@ -139,7 +146,7 @@ func TestOvhZoneRecords(t *testing.T) {
 	provider.cacheInstance = cache.New(cache.NoExpiration, cache.NoExpiration)
 	client.On("GetWithContext", "/domain/zone").Return([]string{"example.org"}, nil).Once()
 	client.On("GetWithContext", "/domain/zone/example.org/soa").Return(nil, ovh.ErrAPIDown).Once()
-	zones, records, err = provider.zonesRecords(t.Context())
+	zones, _, records, err = provider.zonesRecords(t.Context())
 	assert.Error(err)
 	assert.Nil(zones)
 	assert.Nil(records)
@@ -150,7 +157,7 @@ func TestOvhZoneRecords(t *testing.T) {
 	client.On("GetWithContext", "/domain/zone").Return([]string{"example.org"}, nil).Once()
 	client.On("GetWithContext", "/domain/zone/example.org/soa").Return(ovhSoa{Server: "ns.example.org.", Serial: 2022090902}, nil).Once()
 	client.On("GetWithContext", "/domain/zone/example.org/record").Return(nil, ovh.ErrAPIDown).Once()
-	zones, records, err = provider.zonesRecords(t.Context())
+	zones, _, records, err = provider.zonesRecords(t.Context())
 	assert.Error(err)
 	assert.Nil(zones)
 	assert.Nil(records)
@@ -162,7 +169,7 @@ func TestOvhZoneRecords(t *testing.T) {
 	client.On("GetWithContext", "/domain/zone/example.org/soa").Return(ovhSoa{Server: "ns.example.org.", Serial: 2022090902}, nil).Once()
 	client.On("GetWithContext", "/domain/zone/example.org/record").Return([]uint64{42}, nil).Once()
 	client.On("GetWithContext", "/domain/zone/example.org/record/42").Return(nil, ovh.ErrAPIDown).Once()
-	zones, records, err = provider.zonesRecords(t.Context())
+	zones, _, records, err = provider.zonesRecords(t.Context())
 	assert.Error(err)
 	assert.Nil(zones)
 	assert.Nil(records)
@@ -173,7 +180,7 @@ func TestOvhZoneRecordsCache(t *testing.T) {
 	assert := assert.New(t)
 	client := new(mockOvhClient)
 	dnsClient := new(mockDnsClient)
-	provider := &OVHProvider{client: client, apiRateLimiter: ratelimit.New(10), cacheInstance: cache.New(cache.NoExpiration, cache.NoExpiration), dnsClient: dnsClient, UseCache: true}
+	provider := &OVHProvider{client: client, apiRateLimiter: newAdaptiveLimiter(10), cacheInstance: cache.New(cache.NoExpiration, cache.NoExpiration), dnsClient: dnsClient, UseCache: true}
 
 	// First call, cache miss
 	t.Log("First call, cache miss")
@@ -183,7 +190,7 @@ func TestOvhZoneRecordsCache(t *testing.T) {
 	client.On("GetWithContext", "/domain/zone/example.org/record/24").Return(ovhRecord{ID: 24, Zone: "example.org", ovhRecordFields: ovhRecordFields{FieldType: "NS", ovhRecordFieldUpdate: ovhRecordFieldUpdate{SubDomain: "ovh", TTL: 10, Target: "203.0.113.42"}}}, nil).Once()
 	client.On("GetWithContext", "/domain/zone/example.org/record/42").Return(ovhRecord{ID: 42, Zone: "example.org", ovhRecordFields: ovhRecordFields{FieldType: "A", ovhRecordFieldUpdate: ovhRecordFieldUpdate{SubDomain: "ovh", TTL: 10, Target: "203.0.113.42"}}}, nil).Once()
 
-	zones, records, err := provider.zonesRecords(t.Context())
+	zones, _, records, err := provider.zonesRecords(t.Context())
 	assert.NoError(err)
 	assert.ElementsMatch(zones, []string{"example.org"})
 	assert.ElementsMatch(records, []ovhRecord{{ID: 42, Zone: "example.org", ovhRecordFields: ovhRecordFields{FieldType: "A", ovhRecordFieldUpdate: ovhRecordFieldUpdate{SubDomain: "ovh", TTL: 10, Target: "203.0.113.42"}}}, {ID: 24, Zone: "example.org", ovhRecordFields: ovhRecordFields{FieldType: "NS", ovhRecordFieldUpdate: ovhRecordFieldUpdate{SubDomain: "ovh", TTL: 10, Target: "203.0.113.42"}}}})
@@ -200,7 +207,7 @@ func TestOvhZoneRecordsCache(t *testing.T) {
 	client.On("GetWithContext", "/domain/zone").Return([]string{"example.org"}, nil).Once()
 	dnsClient.On("ExchangeContext", mock.AnythingOfType("*context.cancelCtx"), mock.AnythingOfType("*dns.Msg"), "ns.example.org:53").
 		Return(&dns.Msg{Answer: []dns.RR{&dns.SOA{Serial: 2022090901}}}, nil)
-	zones, records, err = provider.zonesRecords(t.Context())
+	zones, _, records, err = provider.zonesRecords(t.Context())
 	assert.NoError(err)
 	assert.ElementsMatch(zones, []string{"example.org"})
 	assert.ElementsMatch(records, []ovhRecord{{ID: 42, Zone: "example.org", ovhRecordFields: ovhRecordFields{FieldType: "A", ovhRecordFieldUpdate: ovhRecordFieldUpdate{SubDomain: "ovh", TTL: 10, Target: "203.0.113.42"}}}, {ID: 24, Zone: "example.org", ovhRecordFields: ovhRecordFields{FieldType: "NS", ovhRecordFieldUpdate: ovhRecordFieldUpdate{SubDomain: "ovh", TTL: 10, Target: "203.0.113.42"}}}})
@@ -221,7 +228,7 @@ func TestOvhZoneRecordsCache(t *testing.T) {
 	client.On("GetWithContext", "/domain/zone/example.org/record").Return([]uint64{24}, nil).Once()
 	client.On("GetWithContext", "/domain/zone/example.org/record/24").Return(ovhRecord{ID: 24, Zone: "example.org", ovhRecordFields: ovhRecordFields{FieldType: "NS", ovhRecordFieldUpdate: ovhRecordFieldUpdate{SubDomain: "ovh", TTL: 10, Target: "203.0.113.42"}}}, nil).Once()
 
-	zones, records, err = provider.zonesRecords(t.Context())
+	zones, _, records, err = provider.zonesRecords(t.Context())
 	assert.NoError(err)
 	assert.ElementsMatch(zones, []string{"example.org"})
 	assert.ElementsMatch(records, []ovhRecord{{ID: 24, Zone: "example.org", ovhRecordFields: ovhRecordFields{FieldType: "NS", ovhRecordFieldUpdate: ovhRecordFieldUpdate{SubDomain: "ovh", TTL: 10, Target: "203.0.113.42"}}}})
@@ -239,7 +246,7 @@ func TestOvhZoneRecordsCache(t *testing.T) {
 	dnsClient.On("ExchangeContext", mock.AnythingOfType("*context.cancelCtx"), mock.AnythingOfType("*dns.Msg"), "ns.example.org:53").
 		Return(&dns.Msg{Answer: []dns.RR{&dns.SOA{Serial: 2022090902}}}, nil)
 
-	zones, records, err = provider.zonesRecords(t.Context())
+	zones, _, records, err = provider.zonesRecords(t.Context())
 	assert.NoError(err)
 	assert.ElementsMatch(zones, []string{"example.org"})
 	assert.ElementsMatch(records, []ovhRecord{{ID: 24, Zone: "example.org", ovhRecordFields: ovhRecordFields{FieldType: "NS", ovhRecordFieldUpdate: ovhRecordFieldUpdate{SubDomain: "ovh", TTL: 10, Target: "203.0.113.42"}}}})
@@ -261,7 +268,7 @@ func TestOvhZoneRecordsCache(t *testing.T) {
 	client.On("GetWithContext", "/domain/zone/example.org/record/24").Return(ovhRecord{ID: 24, Zone: "example.org", ovhRecordFields: ovhRecordFields{FieldType: "NS", ovhRecordFieldUpdate: ovhRecordFieldUpdate{SubDomain: "ovh", TTL: 10, Target: "203.0.113.42"}}}, nil).Once()
 	client.On("GetWithContext", "/domain/zone/example.org/record/42").Return(ovhRecord{ID: 42, Zone: "example.org", ovhRecordFields: ovhRecordFields{FieldType: "A", ovhRecordFieldUpdate: ovhRecordFieldUpdate{SubDomain: "ovh", TTL: 10, Target: "203.0.113.42"}}}, nil).Once()
 
-	zones, records, err = provider.zonesRecords(t.Context())
+	zones, _, records, err = provider.zonesRecords(t.Context())
 	assert.NoError(err)
 	assert.ElementsMatch(zones, []string{"example.org"})
 	assert.ElementsMatch(records, []ovhRecord{{ID: 42, Zone: "example.org", ovhRecordFields: ovhRecordFields{FieldType: "A", ovhRecordFieldUpdate: ovhRecordFieldUpdate{SubDomain: "ovh", TTL: 10, Target: "203.0.113.42"}}}, {ID: 24, Zone: "example.org", ovhRecordFields: ovhRecordFields{FieldType: "NS", ovhRecordFieldUpdate: ovhRecordFieldUpdate{SubDomain: "ovh", TTL: 10, Target: "203.0.113.42"}}}})
@@ -269,10 +276,103 @@ func TestOvhZoneRecordsCache(t *testing.T) {
 	dnsClient.AssertExpectations(t)
 }
 
+func TestOvhZoneRecordsCacheExcludedZone(t *testing.T) {
+	client := new(mockOvhClient)
+	dnsClient := new(mockDnsClient)
+	provider := &OVHProvider{client: client, apiRateLimiter: newAdaptiveLimiter(10), cacheInstance: cache.New(cache.NoExpiration, cache.NoExpiration), dnsClient: dnsClient, UseCache: true, CacheExcludedZones: []string{"example.org"}}
+	misses := testutil.ToFloat64(cacheLookupsTotal.CounterVec.WithLabelValues("miss"))
+
+	// the records of excluded zones are got at every call, without SOA
+	client.On("GetWithContext", "/domain/zone").Return([]string{"example.org"}, nil).Twice()
+	client.On("GetWithContext", "/domain/zone/example.org/record").Return([]uint64{42}, nil).Twice()
+	client.On("GetWithContext", "/domain/zone/example.org/record/42").Return(ovhRecord{ID: 42, Zone: "example.org", ovhRecordFields: ovhRecordFields{FieldType: "A", ovhRecordFieldUpdate: ovhRecordFieldUpdate{SubDomain: "ovh", TTL: 10, Target: "203.0.113.42"}}}, nil).Twice()
+	for range 2 {
+		_, _, records, err := provider.zonesRecords(t.Context())
+		require.NoError(t, err)
+		assert.Len(t, records, 1)
+	}
+	client.AssertExpectations(t)
+	dnsClient.AssertExpectations(t)
+	assert.Zero(t, provider.cacheInstance.ItemCount())
+	assert.Equal(t, misses, testutil.ToFloat64(cacheLookupsTotal.CounterVec.WithLabelValues("miss")), "excluded zones are not looked up")
+}
+
+func TestOvhZoneRecordsCacheMetrics(t *testing.T) {
+	client := new(mockOvhClient)
+	dnsClient := new(mockDnsClient)
+	provider := &OVHProvider{client: client, apiRateLimiter: newAdaptiveLimiter(10), cacheInstance: cache.New(cache.NoExpiration, cache.NoExpiration), dnsClient: dnsClient, UseCache: true}
+	provider.cacheInstance.Set("example.org#soa", ovhSoa{Server: "ns.example.org.", Serial: 1}, cache.DefaultExpiration)
+	hits := testutil.ToFloat64(cacheLookupsTotal.CounterVec.WithLabelValues("hit"))
+	misses := testutil.ToFloat64(cacheLookupsTotal.CounterVec.WithLabelValues("miss"))
+	valid := testutil.ToFloat64(soaValidationsTotal.CounterVec.WithLabelValues("valid"))
+	changed := testutil.ToFloat64(soaValidationsTotal.CounterVec.WithLabelValues("changed"))
+	invalidations := testutil.ToFloat64(cacheInvalidationsTotal.Counter)
+
+	client.On("GetWithContext", "/domain/zone").Return([]string{"example.org"}, nil).Twice()
+	dnsClient.On("ExchangeContext", mock.AnythingOfType("*context.cancelCtx"), mock.AnythingOfType("*dns.Msg"), "ns.example.org:53").
+		Return(&dns.Msg{Answer: []dns.RR{&dns.SOA{Serial: 2}}}, nil)
+	client.On("GetWithContext", "/domain/zone/example.org/soa").Return(ovhSoa{Server: "ns.example.org.", Serial: 2}, nil).Once()
+	client.On("GetWithContext", "/domain/zone/example.org/record").Return([]uint64{}, nil).Once()
+	for range 2 {
+		_, _, _, err := provider.zonesRecords(t.Context())
+		require.NoError(t, err)
+	}
+	client.AssertExpectations(t)
+
+	assert.Equal(t, hits+1, testutil.ToFloat64(cacheLookupsTotal.CounterVec.WithLabelValues("hit")))
+	assert.Equal(t, misses+1, testutil.ToFloat64(cacheLookupsTotal.CounterVec.WithLabelValues("miss")))
+	assert.Equal(t, valid+1, testutil.ToFloat64(soaValidationsTotal.CounterVec.WithLabelValues("valid")))
+	assert.Equal(t, changed+1, testutil.ToFloat64(soaValidationsTotal.CounterVec.WithLabelValues("changed")))
+	assert.Equal(t, invalidations+1, testutil.ToFloat64(cacheInvalidationsTotal.Counter))
+}
+
+func TestOvhSkipFailedZones(t *testing.T) {
+	client := new(mockOvhClient)
+	provider := &OVHProvider{client: client, apiRateLimiter: newAdaptiveLimiter(10), cacheInstance: cache.New(cache.NoExpiration, cache.NoExpiration), SkipFailedZones: true}
+	skipped := testutil.ToFloat64(zonesSkippedTotal.CounterVec.WithLabelValues("sub.example.net"))
+
+	client.On("GetWithContext", "/domain/zone").Return([]string{"example.net", "sub.example.net"}, nil).Once()
+	client.On("GetWithContext", "/domain/zone/example.net/record").Return([]uint64{42}, nil).Once()
+	client.On("GetWithContext", "/domain/zone/example.net/record/42").Return(ovhRecord{ID: 42, Zone: "example.net", ovhRecordFields: ovhRecordFields{FieldType: "A", ovhRecordFieldUpdate: ovhRecordFieldUpdate{SubDomain: "ovh", TTL: 10, Target: "203.0.113.43"}}}, nil).Once()
+	client.On("GetWithContext", "/domain/zone/sub.example.net/record").Return(nil, ovh.ErrAPIDown).Once()
+	endpoints, err := provider.Records(t.Context())
+	require.NoError(t, err)
+	assert.Len(t, endpoints, 1)
+	assert.Equal(t, []string{"sub.example.net"}, provider.lastRunSkippedZones)
+	assert.Equal(t, skipped+1, testutil.ToFloat64(zonesSkippedTotal.CounterVec.WithLabelValues("sub.example.net")))
+
+	// the changes of the skipped zone are not applied, nor to its parent zone
+	client.On("PostWithContext", "/domain/zone/example.net/record", ovhRecordFields{FieldType: "A", ovhRecordFieldUpdate: ovhRecordFieldUpdate{SubDomain: "www", TTL: 10, Target: "203.0.113.42"}}).Return(nil, nil).Once()
+	client.On("PostWithContext", "/domain/zone/example.net/refresh", nil).Return(nil, nil).Once()
+	require.NoError(t, provider.ApplyChanges(t.Context(), &plan.Changes{
+		Create: []*endpoint.Endpoint{
+			{DNSName: "www.example.net", RecordType: "A", RecordTTL: 10, Targets: []string{"203.0.113.42"}},
+			{DNSName: "www.sub.example.net", RecordType: "A", RecordTTL: 10, Targets: []string{"203.0.113.42"}},
+		},
+	}))
+	client.AssertExpectations(t)
+
+	// all the zones failing fails the synchronization
+	client.On("GetWithContext", "/domain/zone").Return([]string{"example.net", "sub.example.net"}, nil).Once()
+	client.On("GetWithContext", "/domain/zone/example.net/record").Return(nil, ovh.ErrAPIDown).Once()
+	client.On("GetWithContext", "/domain/zone/sub.example.net/record").Return(nil, ovh.ErrAPIDown).Once()
+	_, err = provider.Records(t.Context())
+	require.Error(t, err)
+	client.AssertExpectations(t)
+
+	// without SkipFailedZones, a zone failing fails the synchronization
+	provider.SkipFailedZones = false
+	client.On("GetWithContext", "/domain/zone").Return([]string{"sub.example.net"}, nil).Once()
+	client.On("GetWithContext", "/domain/zone/sub.example.net/record").Return(nil, ovh.ErrAPIDown).Once()
+	_, err = provider.Records(t.Context())
+	require.Error(t, err)
+	client.AssertExpectations(t)
+}
+
 func TestOvhRecords(t *testing.T) {
 	assert := assert.New(t)
 	client := new(mockOvhClient)
-	provider := &OVHProvider{client: client, apiRateLimiter: ratelimit.New(10), cacheInstance: cache.New(cache.NoExpiration, cache.NoExpiration)}
+	provider := &OVHProvider{client: client, apiRateLimiter: newAdaptiveLimiter(10), cacheInstance: cache.New(cache.NoExpiration, cache.NoExpiration)}
 
 	// Basic zones records
 	client.On("GetWithContext", "/domain/zone").Return([]string{"example.org", "example.net"}, nil).Once()
@@ -327,7 +427,7 @@ func TestOvhComputeChanges(t *testing.T) {
 		},
 	}
 
-	provider := &OVHProvider{client: nil, apiRateLimiter: ratelimit.New(10), cacheInstance: cache.New(cache.NoExpiration, cache.NoExpiration)}
+	provider := &OVHProvider{client: nil, apiRateLimiter: newAdaptiveLimiter(10), cacheInstance: cache.New(cache.NoExpiration, cache.NoExpiration)}
 	ovhChanges := provider.computeSingleZoneChanges(t.Context(), "example.net", existingRecords, &changes)
 	td.Cmp(t, ovhChanges, []ovhChange{
 		{
@@ -347,9 +447,71 @@ func TestOvhComputeChanges(t *testing.T) {
 
 }
 
+func TestOvhComputeChangesTTLAndDuplicates(t *testing.T) {
+	record := func(id uint64, target string, ttl int64) ovhRecord {
+		return ovhRecord{ID: id, Zone: "example.net", ovhRecordFields: ovhRecordFields{FieldType: "A", ovhRecordFieldUpdate: ovhRecordFieldUpdate{SubDomain: "www", TTL: ttl, Target: target}}}
+	}
+	existingRecords := []ovhRecord{
+		record(1, "203.0.113.1", 60),
+		record(2, "203.0.113.2", 60),
+		record(3, "203.0.113.3", 60),
+		record(4, "203.0.113.4", 60),
+	}
+
+	changes := plan.Changes{
+		UpdateOld: []*endpoint.Endpoint{
+			endpoint.NewEndpointWithTTL("www.example.net", "A", 60, "203.0.113.1", "203.0.113.2", "203.0.113.3", "203.0.113.4"),
+		},
+		UpdateNew: []*endpoint.Endpoint{
+			endpoint.NewEndpointWithTTL("www.example.net", "A", 300, "203.0.113.1", "203.0.113.5", "203.0.113.6", "203.0.113.7"),
+		},
+	}
+
+	provider := &OVHProvider{client: nil, apiRateLimiter: newAdaptiveLimiter(10), cacheInstance: cache.New(cache.NoExpiration, cache.NoExpiration)}
+	ovhChanges := provider.computeSingleZoneChanges(t.Context(), "example.net", existingRecords, &changes)
+	td.Cmp(t, ovhChanges, []ovhChange{
+		{Action: ovhUpdate, ovhRecord: record(1, "203.0.113.1", 300)},
+		{Action: ovhUpdate, ovhRecord: record(2, "203.0.113.5", 300)},
+		{Action: ovhUpdate, ovhRecord: record(3, "203.0.113.6", 300)},
+		{Action: ovhUpdate, ovhRecord: record(4, "203.0.113.7", 300)},
+	})
+}
+
+func TestOvhComputeChangesZoneApex(t *testing.T) {
+	record := func(id uint64, fieldType, subDomain, target string) ovhRecord {
+		return ovhRecord{ID: id, Zone: "example.net", ovhRecordFields: ovhRecordFields{FieldType: fieldType, ovhRecordFieldUpdate: ovhRecordFieldUpdate{SubDomain: subDomain, TTL: defaultTTL, Target: target}}}
+	}
+	existingRecords := []ovhRecord{
+		record(1, "NS", "", "dns1.ovh.net."),
+		record(2, "NS", "", "ns1.ovh.net."),
+		record(3, "SOA", "", "dns1.ovh.net. tech.ovh.net. 2025010100 86400 3600 3600000 300"),
+		record(4, "NS", "dev", "ns.dev.example.org"),
+	}
+
+	changes := plan.Changes{
+		Delete: []*endpoint.Endpoint{
+			endpoint.NewEndpoint("example.net", "NS", "dns1.ovh.net.", "ns1.ovh.net."),
+			endpoint.NewEndpoint("example.net", "SOA", "dns1.ovh.net. tech.ovh.net. 2025010100 86400 3600 3600000 300"),
+			endpoint.NewEndpoint("dev.example.net", "NS", "ns.dev.example.org"),
+		},
+		UpdateOld: []*endpoint.Endpoint{
+			endpoint.NewEndpoint("example.net", "NS", "dns1.ovh.net.", "ns1.ovh.net."),
+		},
+		UpdateNew: []*endpoint.Endpoint{
+			endpoint.NewEndpoint("example.net", "NS", "ns.example.org."),
+		},
+	}
+
+	provider := &OVHProvider{client: nil, apiRateLimiter: newAdaptiveLimiter(10), cacheInstance: cache.New(cache.NoExpiration, cache.NoExpiration)}
+	ovhChanges := provider.computeSingleZoneChanges(t.Context(), "example.net", existingRecords, &changes)
+	td.Cmp(t, ovhChanges, []ovhChange{
+		{Action: ovhDelete, ovhRecord: record(4, "NS", "dev", "ns.dev.example.org")},
+	})
+}
+
 func TestOvhRefresh(t *testing.T) {
 	client := new(mockOvhClient)
-	provider := &OVHProvider{client: client, apiRateLimiter: ratelimit.New(10), cacheInstance: cache.New(cache.NoExpiration, cache.NoExpiration)}
+	provider := &OVHProvider{client: client, apiRateLimiter: newAdaptiveLimiter(10), cacheInstance: cache.New(cache.NoExpiration, cache.NoExpiration)}
 
 	// Basic zone refresh
 	client.On("PostWithContext", "/domain/zone/example.net/refresh", nil).Return(nil, nil).Once()
@@ -358,7 +520,7 @@ func TestOvhRefresh(t *testing.T) {
 }
 
 func TestOvhNewChange(t *testing.T) {
-	provider := &OVHProvider{client: nil, apiRateLimiter: ratelimit.New(10), cacheInstance: cache.New(cache.NoExpiration, cache.NoExpiration)}
+	provider := &OVHProvider{client: nil, apiRateLimiter: newAdaptiveLimiter(10), cacheInstance: cache.New(cache.NoExpiration, cache.NoExpiration)}
 
 	endpoints := []*endpoint.Endpoint{
 		{DNSName: ".example.net", RecordType: "A", RecordTTL: 10, Targets: []string{"203.0.113.42"}},
@@ -399,7 +561,7 @@ func TestOvhNewChange(t *testing.T) {
 		{DNSName: "test.example.org"},
 	}
 
-	provider = &OVHProvider{client: nil, EnableCNAMERelativeTarget: true, apiRateLimiter: ratelimit.New(10), cacheInstance: cache.New(cache.NoExpiration, cache.NoExpiration)}
+	provider = &OVHProvider{client: nil, EnableCNAMERelativeTarget: true, apiRateLimiter: newAdaptiveLimiter(10), cacheInstance: cache.New(cache.NoExpiration, cache.NoExpiration)}
 	changes, _ = provider.newOvhChangeCreateDelete(ovhCreate, endpoints, "example.net", []ovhRecord{})
 	td.Cmp(t, changes, []ovhChange{
 		{Action: ovhCreate, ovhRecord: ovhRecord{Zone: "example.net", ovhRecordFields: ovhRecordFields{FieldType: "A", ovhRecordFieldUpdate: ovhRecordFieldUpdate{SubDomain: "", TTL: 10, Target: "203.0.113.42"}}}},
@@ -415,7 +577,7 @@ func TestOvhNewChange(t *testing.T) {
 		{DNSName: "test.example.org"},
 	}
 
-	provider = &OVHProvider{client: nil, EnableCNAMERelativeTarget: false, apiRateLimiter: ratelimit.New(10), cacheInstance: cache.New(cache.NoExpiration, cache.NoExpiration)}
+	provider = &OVHProvider{client: nil, EnableCNAMERelativeTarget: false, apiRateLimiter: newAdaptiveLimiter(10), cacheInstance: cache.New(cache.NoExpiration, cache.NoExpiration)}
 	changes, _ = provider.newOvhChangeCreateDelete(ovhCreate, endpoints, "example.net", []ovhRecord{})
 	td.Cmp(t, changes, []ovhChange{
 		{Action: ovhCreate, ovhRecord: ovhRecord{Zone: "example.net", ovhRecordFields: ovhRecordFields{FieldType: "A", ovhRecordFieldUpdate: ovhRecordFieldUpdate{SubDomain: "", TTL: 10, Target: "203.0.113.42"}}}},
@@ -424,9 +586,75 @@ func TestOvhNewChange(t *testing.T) {
 	})
 }
 
+func TestOvhNewChangeDefaultTTL(t *testing.T) {
+	provider := &OVHProvider{client: nil, DefaultTTL: 300, apiRateLimiter: newAdaptiveLimiter(10), cacheInstance: cache.New(cache.NoExpiration, cache.NoExpiration)}
+
+	endpoints := []*endpoint.Endpoint{
+		{DNSName: "ovh.example.net", RecordType: "A", RecordTTL: 10, Targets: []string{"203.0.113.42"}},
+		{DNSName: "ovh2.example.net", RecordType: "A", Targets: []string{"203.0.113.43"}},
+	}
+	changes, _ := provider.newOvhChangeCreateDelete(ovhCreate, endpoints, "example.net", []ovhRecord{})
+	td.Cmp(t, changes, []ovhChange{
+		{Action: ovhCreate, ovhRecord: ovhRecord{Zone: "example.net", ovhRecordFields: ovhRecordFields{FieldType: "A", ovhRecordFieldUpdate: ovhRecordFieldUpdate{SubDomain: "ovh", TTL: 10, Target: "203.0.113.42"}}}},
+		{Action: ovhCreate, ovhRecord: ovhRecord{Zone: "example.net", ovhRecordFields: ovhRecordFields{FieldType: "A", ovhRecordFieldUpdate: ovhRecordFieldUpdate{SubDomain: "ovh2", TTL: 300, Target: "203.0.113.43"}}}},
+	})
+}
+
+func TestOvhAdjustEndpoints(t *testing.T) {
+	provider := &OVHProvider{DefaultTTL: 300}
+
+	endpoints, err := provider.AdjustEndpoints([]*endpoint.Endpoint{
+		endpoint.NewEndpointWithTTL("ttl.example.net", endpoint.RecordTypeA, 10, "203.0.113.42").WithProviderSpecific(ovhTTLKey, "60"),
+		endpoint.NewEndpoint("annotated.example.net", endpoint.RecordTypeA, "203.0.113.42").WithProviderSpecific(ovhTTLKey, "60"),
+		endpoint.NewEndpoint("zone.example.net", endpoint.RecordTypeA, "203.0.113.42").WithProviderSpecific(ovhTTLKey, "0"),
+		endpoint.NewEndpoint("invalid.example.net", endpoint.RecordTypeA, "203.0.113.42").WithProviderSpecific(ovhTTLKey, "soon"),
+		endpoint.NewEndpoint("default.example.net", endpoint.RecordTypeA, "203.0.113.42"),
+	})
+	require.NoError(t, err)
+
+	ttls := map[string]endpoint.TTL{}
+	for _, e := range endpoints {
+		ttls[e.DNSName] = e.RecordTTL
+		_, ok := e.GetProviderSpecificProperty(ovhTTLKey)
+		assert.False(t, ok, "the ovh/ttl property is not passed along")
+	}
+	assert.Equal(t, map[string]endpoint.TTL{
+		"ttl.example.net":       10,
+		"annotated.example.net": 60,
+		"zone.example.net":      0,
+		"invalid.example.net":   300,
+		"default.example.net":   300,
+	}, ttls)
+}
+
+func TestOvhAdjustEndpointsTargets(t *testing.T) {
+	provider := &OVHProvider{}
+	records := ovhGroupByNameAndType([]ovhRecord{
+		{ID: 1, Zone: "example.net", ovhRecordFields: ovhRecordFields{FieldType: "TXT", ovhRecordFieldUpdate: ovhRecordFieldUpdate{SubDomain: "spf", TTL: 300, Target: `"v=spf1 -all"`}}},
+		{ID: 2, Zone: "example.net", ovhRecordFields: ovhRecordFields{FieldType: "TXT", ovhRecordFieldUpdate: ovhRecordFieldUpdate{SubDomain: "dkim", TTL: 300, Target: `"v=DKIM1; p=MIIB"`}}},
+		{ID: 3, Zone: "example.net", ovhRecordFields: ovhRecordFields{FieldType: "CNAME", ovhRecordFieldUpdate: ovhRecordFieldUpdate{SubDomain: "www", TTL: 300, Target: "target.example.org."}}},
+		{ID: 4, Zone: "example.net", ovhRecordFields: ovhRecordFields{FieldType: "MX", ovhRecordFieldUpdate: ovhRecordFieldUpdate{SubDomain: "", TTL: 300, Target: "10 mail.example.net."}}},
+	})
+
+	// desired endpoints whose targets only differ in their form from the ones of the records plan no changes
+	desired, err := provider.AdjustEndpoints([]*endpoint.Endpoint{
+		{DNSName: "spf.example.net", RecordType: "TXT", RecordTTL: 300, Targets: endpoint.Targets{"v=spf1 -all "}},
+		{DNSName: "dkim.example.net", RecordType: "TXT", RecordTTL: 300, Targets: endpoint.Targets{`"v=DKIM1; " "p=MIIB"`}},
+		{DNSName: "www.example.net", RecordType: "CNAME", RecordTTL: 300, Targets: endpoint.Targets{" target.example.org."}},
+		{DNSName: "example.net", RecordType: "MX", RecordTTL: 300, Targets: endpoint.Targets{"10 mail.example.net."}},
+	})
+	require.NoError(t, err)
+	changes := (&plan.Plan{
+		Current:        records,
+		Desired:        desired,
+		ManagedRecords: []string{endpoint.RecordTypeTXT, endpoint.RecordTypeCNAME, endpoint.RecordTypeMX},
+	}).Calculate().Changes
+	assert.False(t, changes.HasChanges(), "%+v", changes)
+}
+
 func TestOvhApplyChanges(t *testing.T) {
 	client := new(mockOvhClient)
-	provider := &OVHProvider{client: client, apiRateLimiter: ratelimit.New(10), cacheInstance: cache.New(cache.NoExpiration, cache.NoExpiration)}
+	provider := &OVHProvider{client: client, apiRateLimiter: newAdaptiveLimiter(10), cacheInstance: cache.New(cache.NoExpiration, cache.NoExpiration)}
 	changes := plan.Changes{
 		Create: []*endpoint.Endpoint{
 			{DNSName: "example.net", RecordType: "A", RecordTTL: 10, Targets: []string{"203.0.113.42"}},
@@ -484,7 +712,7 @@ func TestOvhApplyChanges(t *testing.T) {
 
 	// Test Dry-Run
 	client = new(mockOvhClient)
-	provider = &OVHProvider{client: client, apiRateLimiter: ratelimit.New(10), cacheInstance: cache.New(cache.NoExpiration, cache.NoExpiration), DryRun: true}
+	provider = &OVHProvider{client: client, apiRateLimiter: newAdaptiveLimiter(10), cacheInstance: cache.New(cache.NoExpiration, cache.NoExpiration), DryRun: true}
 	changes = plan.Changes{
 		Create: []*endpoint.Endpoint{
 			{DNSName: "example.net", RecordType: "A", RecordTTL: 10, Targets: []string{"203.0.113.42"}},
@@ -505,7 +733,7 @@ func TestOvhApplyChanges(t *testing.T) {
 
 	// Test Update
 	client = new(mockOvhClient)
-	provider = &OVHProvider{client: client, apiRateLimiter: ratelimit.New(10), cacheInstance: cache.New(cache.NoExpiration, cache.NoExpiration), DryRun: false}
+	provider = &OVHProvider{client: client, apiRateLimiter: newAdaptiveLimiter(10), cacheInstance: cache.New(cache.NoExpiration, cache.NoExpiration), DryRun: false}
 	changes = plan.Changes{
 		UpdateOld: []*endpoint.Endpoint{
 			{DNSName: "example.net", RecordType: "A", RecordTTL: 10, Targets: []string{"203.0.113.42"}},
@@ -528,7 +756,7 @@ func TestOvhApplyChanges(t *testing.T) {
 
 	// Test Update DryRun
 	client = new(mockOvhClient)
-	provider = &OVHProvider{client: client, apiRateLimiter: ratelimit.New(10), cacheInstance: cache.New(cache.NoExpiration, cache.NoExpiration), DryRun: true}
+	provider = &OVHProvider{client: client, apiRateLimiter: newAdaptiveLimiter(10), cacheInstance: cache.New(cache.NoExpiration, cache.NoExpiration), DryRun: true}
 	changes = plan.Changes{
 		UpdateOld: []*endpoint.Endpoint{
 			{DNSName: "example.net", RecordType: "A", RecordTTL: 10, Targets: []string{"203.0.113.42"}},
@@ -549,7 +777,7 @@ func TestOvhApplyChanges(t *testing.T) {
 
 	// Test Update 2 records => 1 record
 	client = new(mockOvhClient)
-	provider = &OVHProvider{client: client, apiRateLimiter: ratelimit.New(10), cacheInstance: cache.New(cache.NoExpiration, cache.NoExpiration), DryRun: false}
+	provider = &OVHProvider{client: client, apiRateLimiter: newAdaptiveLimiter(10), cacheInstance: cache.New(cache.NoExpiration, cache.NoExpiration), DryRun: false}
 	changes = plan.Changes{
 		UpdateOld: []*endpoint.Endpoint{
 			{DNSName: "example.net", RecordType: "A", RecordTTL: 10, Targets: []string{"203.0.113.42", "203.0.113.43"}},
@@ -572,10 +800,73 @@ func TestOvhApplyChanges(t *testing.T) {
 	client.AssertExpectations(t)
 }
 
+func TestOvhApplyChangesInBatches(t *testing.T) {
+	client := new(mockOvhClient)
+	provider := &OVHProvider{client: client, apiRateLimiter: newAdaptiveLimiter(10), cacheInstance: cache.New(cache.NoExpiration, cache.NoExpiration), BatchSize: 1}
+	changes := plan.Changes{
+		Create: []*endpoint.Endpoint{
+			{DNSName: "example.net", RecordType: "A", RecordTTL: 10, Targets: []string{"203.0.113.42"}},
+		},
+		Delete: []*endpoint.Endpoint{
+			{DNSName: "ovh.example.net", RecordType: "A", Targets: []string{"203.0.113.43"}},
+		},
+	}
+
+	client.On("GetWithContext", "/domain/zone").Return([]string{"example.net"}, nil).Once()
+	client.On("GetWithContext", "/domain/zone/example.net/record").Return([]uint64{42}, nil).Once()
+	client.On("GetWithContext", "/domain/zone/example.net/record/42").Return(ovhRecord{ID: 42, Zone: "example.net", ovhRecordFields: ovhRecordFields{FieldType: "A", ovhRecordFieldUpdate: ovhRecordFieldUpdate{SubDomain: "ovh", TTL: 10, Target: "203.0.113.43"}}}, nil).Once()
+	client.On("PostWithContext", "/domain/zone/example.net/record", ovhRecordFields{FieldType: "A", ovhRecordFieldUpdate: ovhRecordFieldUpdate{SubDomain: "", TTL: 10, Target: "203.0.113.42"}}).Return(nil, nil).Once()
+	client.On("DeleteWithContext", "/domain/zone/example.net/record/42").Return(nil, nil).Once()
+	// the zone is refreshed after each batch
+	client.On("PostWithContext", "/domain/zone/example.net/refresh", nil).Return(nil, nil).Twice()
+
+	_, err := provider.Records(t.Context())
+	td.CmpNoError(t, err)
+	td.CmpNoError(t, provider.ApplyChanges(t.Context(), &changes))
+	client.AssertExpectations(t)
+}
+
+func TestOvhApplyChangesDryRun(t *testing.T) {
+	hook := testutils.LogsUnderTestWithLogLevel(log.InfoLevel, t)
+	client := new(mockOvhClient)
+	provider := &OVHProvider{client: client, apiRateLimiter: newAdaptiveLimiter(10), cacheInstance: cache.New(cache.NoExpiration, cache.NoExpiration), DryRun: true}
+	changes := plan.Changes{
+		Create: []*endpoint.Endpoint{
+			{DNSName: "new.example.net", RecordType: "A", RecordTTL: 10, Targets: []string{"203.0.113.44"}},
+		},
+		UpdateOld: []*endpoint.Endpoint{
+			{DNSName: "example.net", RecordType: "A", RecordTTL: 10, Targets: []string{"203.0.113.42"}},
+		},
+		UpdateNew: []*endpoint.Endpoint{
+			{DNSName: "example.net", RecordType: "A", RecordTTL: 10, Targets: []string{"203.0.113.45"}},
+		},
+		Delete: []*endpoint.Endpoint{
+			{DNSName: "ovh.example.net", RecordType: "A", Targets: []string{"203.0.113.43"}},
+		},
+	}
+
+	// only reads reach the API
+	client.On("GetWithContext", "/domain/zone").Return([]string{"example.net"}, nil).Once()
+	client.On("GetWithContext", "/domain/zone/example.net/record").Return([]uint64{42, 43}, nil).Once()
+	client.On("GetWithContext", "/domain/zone/example.net/record/42").Return(ovhRecord{ID: 42, Zone: "example.net", ovhRecordFields: ovhRecordFields{FieldType: "A", ovhRecordFieldUpdate: ovhRecordFieldUpdate{SubDomain: "", TTL: 10, Target: "203.0.113.42"}}}, nil).Once()
+	client.On("GetWithContext", "/domain/zone/example.net/record/43").Return(ovhRecord{ID: 43, Zone: "example.net", ovhRecordFields: ovhRecordFields{FieldType: "A", ovhRecordFieldUpdate: ovhRecordFieldUpdate{SubDomain: "ovh", TTL: 10, Target: "203.0.113.43"}}}, nil).Once()
+
+	_, err := provider.Records(t.Context())
+	require.NoError(t, err)
+	require.NoError(t, provider.ApplyChanges(t.Context(), &changes))
+	client.AssertExpectations(t)
+
+	testutils.TestHelperLogContains(`OVH: Dry-run: "example.net": 3 changes would be done`, hook, t)
+	testutils.TestHelperLogContains(`OVH: Dry-run: "example.net": would have called POST /domain/zone/example.net/record for record#0: A | new => 203.0.113.44 (10)`, hook, t)
+	testutils.TestHelperLogContains(`OVH: Dry-run: "example.net": would have called DELETE /domain/zone/example.net/record/43 for record#43: A | ovh => 203.0.113.43 (0)`, hook, t)
+	testutils.TestHelperLogContains(`OVH: Dry-run: "example.net": would have called PUT /domain/zone/example.net/record/42 for record#42: A |  => 203.0.113.45 (10)`, hook, t)
+	testutils.TestHelperLogContains(`OVH: Dry-run: "example.net": would have called POST /domain/zone/example.net/refresh`, hook, t)
+}
+
 func TestOvhChange(t *testing.T) {
 	assert := assert.New(t)
 	client := new(mockOvhClient)
-	provider := &OVHProvider{client: client, apiRateLimiter: ratelimit.New(10), cacheInstance: cache.New(cache.NoExpiration, cache.NoExpiration)}
+	provider := &OVHProvider{client: client, apiRateLimiter: newAdaptiveLimiter(10), cacheInstance: cache.New(cache.NoExpiration, cache.NoExpiration)}
 
 	// Record creation
 	client.On("PostWithContext", "/domain/zone/example.net/record", ovhRecordFields{ovhRecordFieldUpdate: ovhRecordFieldUpdate{SubDomain: "ovh"}}).Return(nil, nil).Once()
@@ -609,13 +900,125 @@ func TestOvhRecordString(t *testing.T) {
 
 func TestNewOvhProvider(t *testing.T) {
 	var domainFilter endpoint.DomainFilter
-	_, err := NewOVHProvider(t.Context(), domainFilter, "ovh-eu", 20, false, true)
+	_, err := NewOVHProvider(t.Context(), OVHConfig{DomainFilter: domainFilter, Endpoint: "ovh-eu", APIRateLimit: 20, DryRun: true, RecordsFetchMode: RecordsFetchModeRecord, MaxAttempts: 3, RetryBackoff: time.Second, UseCache: true, AuthMethod: AuthMethodApplication})
 	td.CmpError(t, err)
 
 	t.Setenv("OVH_APPLICATION_KEY", "aaaaaa")
 	t.Setenv("OVH_APPLICATION_SECRET", "bbbbbb")
 	t.Setenv("OVH_CONSUMER_KEY", "cccccc")
 
-	_, err = NewOVHProvider(t.Context(), domainFilter, "ovh-eu", 20, false, true)
+	p, err := NewOVHProvider(t.Context(), OVHConfig{DomainFilter: domainFilter, Endpoint: "ovh-eu", APIRateLimit: 20, DryRun: true, RecordsFetchMode: RecordsFetchModeRecord, MaxAttempts: 3, RetryBackoff: time.Second, UseCache: true, AuthMethod: AuthMethodApplication})
+	td.CmpNoError(t, err)
+	td.CmpIsa(t, p.dnsClient, &dns.Client{})
+
+	// the global resolver is used for the SOA checks, unless they have a resolver of their own
+	require.NoError(t, resolver.Configure(resolver.Config{Address: "10.0.0.10"}))
+	t.Cleanup(func() { require.NoError(t, resolver.Configure(resolver.Config{})) })
+	p, err = NewOVHProvider(t.Context(), OVHConfig{DomainFilter: domainFilter, Endpoint: "ovh-eu", APIRateLimit: 20, DryRun: true, RecordsFetchMode: RecordsFetchModeRecord, MaxAttempts: 3, RetryBackoff: time.Second, UseCache: true, AuthMethod: AuthMethodApplication})
+	td.CmpNoError(t, err)
+	td.Cmp(t, p.dnsClient, resolver.Default())
+	p, err = NewOVHProvider(t.Context(), OVHConfig{DomainFilter: domainFilter, Endpoint: "ovh-eu", APIRateLimit: 20, DryRun: true, RecordsFetchMode: RecordsFetchModeRecord, MaxAttempts: 3, RetryBackoff: time.Second, UseCache: true, SOACheck: SOACheck{Resolver: "10.0.0.11"}, AuthMethod: AuthMethodApplication})
 	td.CmpNoError(t, err)
+	td.CmpIsa(t, p.dnsClient, &dns.Client{})
+}
+
+// ovhZoneSimulator applies the changes computed by an OVHProvider to an
+// in-memory list of records, the way the OVHcloud API would.
+type ovhZoneSimulator struct {
+	ovh     *OVHProvider
+	zone    string
+	records []ovhRecord
+	nextID  uint64
+}
+
+func (s *ovhZoneSimulator) Records(_ context.Context) ([]*endpoint.Endpoint, error) {
+	return ovhGroupByNameAndType(s.records), nil
+}
+
+func (s *ovhZoneSimulator) AdjustEndpoints(endpoints []*endpoint.Endpoint) ([]*endpoint.Endpoint, error) {
+	return s.ovh.AdjustEndpoints(endpoints)
+}
+
+func (s *ovhZoneSimulator) GetDomainFilter() endpoint.DomainFilterInterface {
+	return s.ovh.GetDomainFilter()
+}
+
+func (s *ovhZoneSimulator) ApplyChanges(ctx context.Context, changes *plan.Changes) error {
+	for zone, zoneChanges := range s.ovh.planChangesByZoneName([]string{s.zone}, changes) {
+		// changes are sent concurrently, so a record must not be touched twice
+		touched := map[uint64]bool{}
+		for _, change := range s.ovh.computeSingleZoneChanges(ctx, zone, s.records, zoneChanges) {
+			if change.ID != 0 {
+				if touched[change.ID] {
+					return fmt.Errorf("record %d changed twice: %s", change.ID, change.String())
+				}
+				touched[change.ID] = true
+			}
+			if err := s.apply(change); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func (s *ovhZoneSimulator) apply(change ovhChange) error {
+	if change.Action == ovhCreate {
+		if change.ID != 0 {
+			return fmt.Errorf("create with a record ID: %s", change.String())
+		}
+		s.add(change.ovhRecordFields)
+		return nil
+	}
+	i := slices.IndexFunc(s.records, func(r ovhRecord) bool { return change.ID != 0 && r.ID == change.ID })
+	if i < 0 {
+		return fmt.Errorf("record not found: %s", change.String())
+	}
+	if change.Action == ovhUpdate {
+		s.records[i].ovhRecordFields = change.ovhRecordFields
+		return nil
+	}
+	s.records = slices.Delete(s.records, i, i+1)
+	return nil
+}
+
+func (s *ovhZoneSimulator) add(fields ovhRecordFields) {
+	s.nextID++
+	s.records = append(s.records, ovhRecord{ID: s.nextID, Zone: s.zone, ovhRecordFields: fields})
+}
+
+func FuzzOvhConvergence(f *testing.F) {
+	for seed := int64(0); seed < 50; seed++ {
+		f.Add(seed, uint8(5))
+	}
+	f.Fuzz(func(t *testing.T, seed int64, steps uint8) {
+		g := planfuzz.NewGenerator(seed, "example.net")
+		s := &ovhZoneSimulator{
+			ovh:  &OVHProvider{apiRateLimiter: newAdaptiveLimiter(10), cacheInstance: cache.New(cache.NoExpiration, cache.NoExpiration)},
+			zone: "example.net",
+		}
+		// start from an existing zone, which may hold the same record several times
+		for _, e := range g.Endpoints() {
+			for _, target := range e.Targets {
+				if e.RecordType == endpoint.RecordTypeCNAME {
+					target += "."
+				}
+				fields := ovhRecordFields{
+					FieldType: e.RecordType,
+					ovhRecordFieldUpdate: ovhRecordFieldUpdate{
+						SubDomain: convertDNSNameIntoSubDomain(e.DNSName, s.zone),
+						TTL:       int64(e.RecordTTL),
+						Target:    target,
+					},
+				}
+				s.add(fields)
+				if g.Intn(4) == 0 {
+					s.add(fields)
+				}
+			}
+		}
+		for i := 0; i < int(steps%10)+1; i++ {
+			require.NoError(t, planfuzz.Converge(context.Background(), s, g.Endpoints()), "step %d", i)
+		}
+	})
 }
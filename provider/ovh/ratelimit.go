@@ -0,0 +1,216 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package ovh
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	log "github.com/sirupsen/logrus"
+	"go.uber.org/ratelimit"
+
+	"sigs.k8s.io/external-dns/pkg/metrics"
+)
+
+const (
+	// quotaLimitHeader, quotaRemainingHeader and quotaResetHeader are the headers of the API responses
+	// reporting the quota of calls, the calls left and when the quota resets, in seconds or as a Unix time.
+	quotaLimitHeader     = "X-Ratelimit-Limit"
+	quotaRemainingHeader = "X-Ratelimit-Remaining"
+	quotaResetHeader     = "X-Ratelimit-Reset"
+
+	// quotaLowShare is the share of the quota under which calls are spread until the quota resets.
+	quotaLowShare = 10
+	// quotaLowCalls is the number of calls left under which calls are spread, when the quota is unknown.
+	quotaLowCalls = 10
+	// defaultQuotaPause is how long calls are paused after a 429 response without Retry-After nor reset.
+	defaultQuotaPause = time.Second
+)
+
+var apiQuotaRemaining = metrics.NewGaugeWithOpts(
+	prometheus.GaugeOpts{
+		Namespace: "external_dns",
+		Subsystem: "ovh",
+		Name:      "api_quota_remaining",
+		Help:      "Number of calls left in the OVHcloud API quota, as last reported by the API.",
+	},
+)
+
+func init() {
+	metrics.RegisterMetric.MustRegister(apiQuotaRemaining)
+}
+
+// adaptiveLimiter limits the rate of API calls to a static rate, and slows them down when the API
+// reports its quota is close to exhaustion: the calls left are then spread until the quota resets,
+// and calls are paused after a 429 response.
+type adaptiveLimiter struct {
+	limiter ratelimit.Limiter
+
+	mu sync.Mutex
+	// limit and remaining are the quota and the calls left, -1 when unknown.
+	limit       int
+	remaining   int
+	resetAt     time.Time
+	pausedUntil time.Time
+	// next is the earliest time of the next call.
+	next time.Time
+
+	now   func() time.Time
+	sleep func(context.Context, time.Duration) error
+}
+
+// newAdaptiveLimiter returns an adaptiveLimiter allowing rate calls per second, any number when rate is 0.
+func newAdaptiveLimiter(rate int) *adaptiveLimiter {
+	limiter := ratelimit.NewUnlimited()
+	if rate > 0 {
+		limiter = ratelimit.New(rate)
+	}
+	return &adaptiveLimiter{
+		limiter:   limiter,
+		limit:     -1,
+		remaining: -1,
+		now:       time.Now,
+		sleep:     sleepContext,
+	}
+}
+
+// Take blocks until the next call is allowed, or ctx is done, in which case it returns the error of ctx.
+func (l *adaptiveLimiter) Take(ctx context.Context) error {
+	start := l.now()
+	defer func() { apiRateLimitWait.HistogramVec.WithLabelValues().Observe(l.now().Sub(start).Seconds()) }()
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	l.limiter.Take()
+
+	l.mu.Lock()
+	now := l.now()
+	at := now
+	if l.next.After(at) {
+		at = l.next
+	}
+	if l.pausedUntil.After(at) {
+		at = l.pausedUntil
+	}
+	if l.remaining == 0 && l.resetAt.After(at) {
+		at = l.resetAt
+	}
+	l.next = at.Add(l.interval(at))
+	if l.remaining > 0 {
+		// the calls taken before the next response are accounted for
+		l.remaining--
+	}
+	l.mu.Unlock()
+
+	if wait := at.Sub(now); wait > 0 {
+		log.Debugf("OVH: waiting %s for the API quota", wait)
+		return l.sleep(ctx, wait)
+	}
+	return nil
+}
+
+// sleepContext waits for d, or until ctx is done, in which case it returns the error of ctx.
+func sleepContext(ctx context.Context, d time.Duration) error {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
+
+// interval returns the interval to the call following one at the given time: the time until the quota
+// resets spread over the calls left when they are few, 0 otherwise.
+func (l *adaptiveLimiter) interval(at time.Time) time.Duration {
+	if l.remaining < 0 || !l.resetAt.After(at) {
+		return 0
+	}
+	low := quotaLowCalls
+	if l.limit > 0 {
+		low = l.limit / quotaLowShare
+	}
+	if l.remaining > low {
+		return 0
+	}
+	return l.resetAt.Sub(at) / time.Duration(max(l.remaining, 1))
+}
+
+// Observe updates the quota from the headers of an API response, and pauses the calls after a 429
+// response, or a 5xx response with a Retry-After header.
+func (l *adaptiveLimiter) Observe(resp *http.Response) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	now := l.now()
+	if limit, err := strconv.Atoi(resp.Header.Get(quotaLimitHeader)); err == nil {
+		l.limit = limit
+	}
+	if remaining, err := strconv.Atoi(resp.Header.Get(quotaRemainingHeader)); err == nil {
+		l.remaining = max(remaining, 0)
+		apiQuotaRemaining.Gauge.Set(float64(l.remaining))
+	}
+	if reset, err := strconv.ParseInt(resp.Header.Get(quotaResetHeader), 10, 64); err == nil {
+		l.resetAt = parseQuotaTime(now, reset)
+	}
+	retryAfter, err := strconv.ParseInt(resp.Header.Get("Retry-After"), 10, 64)
+	if resp.StatusCode >= http.StatusInternalServerError && err == nil {
+		// the retries of the call wait as long as the API asked
+		log.Warnf("OVH: API unavailable, pausing calls for %ds", retryAfter)
+		l.pausedUntil = now.Add(time.Duration(retryAfter) * time.Second)
+		return
+	}
+	if resp.StatusCode != http.StatusTooManyRequests {
+		return
+	}
+	pause := now.Add(defaultQuotaPause)
+	if err == nil {
+		pause = now.Add(time.Duration(retryAfter) * time.Second)
+	} else if l.resetAt.After(now) {
+		pause = l.resetAt
+	}
+	log.Warnf("OVH: API quota exhausted, pausing calls for %s", pause.Sub(now).Round(time.Millisecond))
+	l.pausedUntil = pause
+}
+
+// parseQuotaTime returns the time a quota resets at, reported in seconds from now or as a Unix time.
+func parseQuotaTime(now time.Time, value int64) time.Time {
+	// a number of seconds this large is a Unix time
+	if value > 1e9 {
+		return time.Unix(value, 0)
+	}
+	return now.Add(time.Duration(value) * time.Second)
+}
+
+// quotaTransport reports the headers of the API responses to an adaptiveLimiter.
+type quotaTransport struct {
+	next    http.RoundTripper
+	limiter *adaptiveLimiter
+}
+
+func (t *quotaTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	resp, err := t.next.RoundTrip(req)
+	if err == nil {
+		t.limiter.Observe(resp)
+	}
+	return resp, err
+}
@@ -0,0 +1,163 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package ovh
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestAdaptiveLimiter() (*adaptiveLimiter, *time.Time, *[]time.Duration) {
+	now := time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC)
+	waits := []time.Duration{}
+	l := newAdaptiveLimiter(1000)
+	l.now = func() time.Time { return now }
+	l.sleep = func(_ context.Context, d time.Duration) error {
+		waits = append(waits, d)
+		now = now.Add(d)
+		return nil
+	}
+	return l, &now, &waits
+}
+
+func quotaResponse(status int, headers map[string]string) *http.Response {
+	resp := &http.Response{StatusCode: status, Header: http.Header{}}
+	for name, value := range headers {
+		resp.Header.Set(name, value)
+	}
+	return resp
+}
+
+func TestAdaptiveLimiterUnknownQuota(t *testing.T) {
+	l, _, waits := newTestAdaptiveLimiter()
+	for range 5 {
+		require.NoError(t, l.Take(t.Context()))
+	}
+	assert.Empty(t, *waits, "calls are not slowed down without a reported quota")
+}
+
+func TestAdaptiveLimiterPlentyOfQuota(t *testing.T) {
+	l, _, waits := newTestAdaptiveLimiter()
+	l.Observe(quotaResponse(http.StatusOK, map[string]string{quotaLimitHeader: "1000", quotaRemainingHeader: "500", quotaResetHeader: "60"}))
+	for range 5 {
+		require.NoError(t, l.Take(t.Context()))
+	}
+	assert.Empty(t, *waits)
+}
+
+func TestAdaptiveLimiterLowQuota(t *testing.T) {
+	l, now, waits := newTestAdaptiveLimiter()
+	l.Observe(quotaResponse(http.StatusOK, map[string]string{quotaLimitHeader: "1000", quotaRemainingHeader: "4", quotaResetHeader: "60"}))
+
+	// the calls left are spread evenly until the quota resets
+	for range 4 {
+		require.NoError(t, l.Take(t.Context()))
+	}
+	assert.Equal(t, []time.Duration{15 * time.Second, 15 * time.Second, 15 * time.Second}, *waits)
+
+	// once none are left, calls wait for the quota to reset
+	*waits = nil
+	require.NoError(t, l.Take(t.Context()))
+	assert.Equal(t, []time.Duration{15 * time.Second}, *waits)
+	assert.Equal(t, time.Date(2025, 6, 1, 12, 1, 0, 0, time.UTC), *now)
+}
+
+func TestAdaptiveLimiterLowQuotaWithoutLimit(t *testing.T) {
+	l, _, waits := newTestAdaptiveLimiter()
+	l.Observe(quotaResponse(http.StatusOK, map[string]string{quotaRemainingHeader: "20", quotaResetHeader: "60"}))
+	require.NoError(t, l.Take(t.Context()))
+	assert.Empty(t, *waits, "calls are only spread under 10 calls left when the quota is unknown")
+
+	l.Observe(quotaResponse(http.StatusOK, map[string]string{quotaRemainingHeader: "10", quotaResetHeader: "60"}))
+	require.NoError(t, l.Take(t.Context()))
+	require.NoError(t, l.Take(t.Context()))
+	assert.Equal(t, []time.Duration{6 * time.Second}, *waits)
+}
+
+func TestAdaptiveLimiterResetAsUnixTime(t *testing.T) {
+	l, now, waits := newTestAdaptiveLimiter()
+	l.Observe(quotaResponse(http.StatusOK, map[string]string{quotaRemainingHeader: "0", quotaResetHeader: "1748779230"}))
+	require.NoError(t, l.Take(t.Context()))
+	assert.Equal(t, []time.Duration{30 * time.Second}, *waits)
+	assert.True(t, time.Unix(1748779230, 0).Equal(*now))
+}
+
+func TestAdaptiveLimiterTooManyRequests(t *testing.T) {
+	for _, tc := range []struct {
+		title    string
+		headers  map[string]string
+		expected time.Duration
+	}{
+		{title: "retry after", headers: map[string]string{"Retry-After": "5"}, expected: 5 * time.Second},
+		{title: "reset", headers: map[string]string{quotaResetHeader: "8"}, expected: 8 * time.Second},
+		{title: "no header", headers: map[string]string{}, expected: defaultQuotaPause},
+	} {
+		t.Run(tc.title, func(t *testing.T) {
+			l, _, waits := newTestAdaptiveLimiter()
+			l.Observe(quotaResponse(http.StatusTooManyRequests, tc.headers))
+			require.NoError(t, l.Take(t.Context()))
+			require.NoError(t, l.Take(t.Context()))
+			assert.Equal(t, []time.Duration{tc.expected}, *waits, "calls are paused once")
+		})
+	}
+}
+
+func TestAdaptiveLimiterServerError(t *testing.T) {
+	l, _, waits := newTestAdaptiveLimiter()
+	l.Observe(quotaResponse(http.StatusServiceUnavailable, map[string]string{}))
+	require.NoError(t, l.Take(t.Context()))
+	assert.Empty(t, *waits, "calls are not paused without Retry-After")
+
+	l.Observe(quotaResponse(http.StatusServiceUnavailable, map[string]string{"Retry-After": "3"}))
+	require.NoError(t, l.Take(t.Context()))
+	assert.Equal(t, []time.Duration{3 * time.Second}, *waits)
+}
+
+func TestAdaptiveLimiterContextDone(t *testing.T) {
+	l := newAdaptiveLimiter(1000)
+	l.pausedUntil = time.Now().Add(time.Hour)
+
+	ctx, cancel := context.WithTimeout(t.Context(), 10*time.Millisecond)
+	defer cancel()
+	assert.ErrorIs(t, l.Take(ctx), context.DeadlineExceeded, "calls stop waiting once the context is done")
+
+	canceled, cancel := context.WithCancel(t.Context())
+	cancel()
+	assert.ErrorIs(t, l.Take(canceled), context.Canceled)
+}
+
+func TestQuotaTransport(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.Header().Set(quotaRemainingHeader, "42")
+		w.Header().Set(quotaResetHeader, "60")
+	}))
+	defer server.Close()
+
+	l, _, _ := newTestAdaptiveLimiter()
+	client := &http.Client{Transport: &quotaTransport{next: http.DefaultTransport, limiter: l}}
+	resp, err := client.Get(server.URL)
+	require.NoError(t, err)
+	resp.Body.Close()
+	assert.Equal(t, 42, l.remaining)
+	assert.Equal(t, l.now().Add(time.Minute), l.resetAt)
+}
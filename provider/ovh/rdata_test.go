@@ -0,0 +1,112 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package ovh
+
+import (
+	"testing"
+
+	"github.com/patrickmn/go-cache"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"sigs.k8s.io/external-dns/endpoint"
+	"sigs.k8s.io/external-dns/plan"
+)
+
+func TestFormatNAPTRTarget(t *testing.T) {
+	for target, expected := range map[string]string{
+		`100 10 "S" "SIP+D2U" "!^.*$!sip:info@example.com!" _sip._udp.example.com.`: `100 10 "S" "SIP+D2U" "!^.*$!sip:info@example.com!" _sip._udp.example.com.`,
+		`100  10 S SIP+D2U !^.*$!sip:info@example.com! _sip._udp.example.com.`:      `100 10 "S" "SIP+D2U" "!^.*$!sip:info@example.com!" _sip._udp.example.com.`,
+		`10 0 "U" "E2U+sip" "!^\\+33(.*)$!sip:\\1@example.com!" .`:                  `10 0 "U" "E2U+sip" "!^\\+33(.*)$!sip:\\1@example.com!" .`,
+		`10 0 "" "" "" _sip._tcp.example.com.`:                                      `10 0 "" "" "" _sip._tcp.example.com.`,
+		`10 0 "U" "E2U+sip" "!^.*$!sip:a b!" .`:                                     `10 0 "U" "E2U+sip" "!^.*$!sip:a b!" .`,
+		`10 0 "U" "E2U+sip" "!^.*$!sip:info@example.com! .`:                         `10 0 "U" "E2U+sip" "!^.*$!sip:info@example.com! .`,
+		`65536 0 "U" "E2U+sip" "" .`:                                                `65536 0 "U" "E2U+sip" "" .`,
+	} {
+		assert.Equal(t, expected, formatNAPTRTarget(target), target)
+	}
+}
+
+func TestFormatRData(t *testing.T) {
+	for _, tc := range []struct {
+		fieldType string
+		target    string
+		expected  string
+	}{
+		{endpoint.RecordTypeTLSA, "3 1 1 0C72AC70B745AC19", "3 1 1 0c72ac70b745ac19"},
+		{endpoint.RecordTypeTLSA, " 3  1 1  0c72ac70 b745ac19 ", "3 1 1 0c72ac70b745ac19"},
+		{endpoint.RecordTypeTLSA, "3 1 1", "3 1 1"},
+		{endpoint.RecordTypeTLSA, "3 1 1 0c72zz", "3 1 1 0c72zz"},
+		{endpoint.RecordTypeSSHFP, "4 2 8E8EA5A6", "4 2 8e8ea5a6"},
+		{endpoint.RecordTypeSSHFP, "4 8e8ea5a6", "4 8e8ea5a6"},
+		{endpoint.RecordTypeCAA, "0 issue letsencrypt.org", `0 issue "letsencrypt.org"`},
+		{endpoint.RecordTypeNAPTR, `10 0 U E2U+sip "" .`, `10 0 "U" "E2U+sip" "" .`},
+		{endpoint.RecordTypeTXT, "3 1 1 0C72AC70", `"3 1 1 0C72AC70"`},
+		{endpoint.RecordTypeTXT, ` "v=spf1 -all" `, `"v=spf1 -all"`},
+		{endpoint.RecordTypeTXT, `"v=DKIM1; " "p=MIIB"`, `"v=DKIM1; p=MIIB"`},
+		{endpoint.RecordTypeA, " 192.0.2.1\n", "192.0.2.1"},
+	} {
+		assert.Equal(t, tc.expected, formatRData(tc.fieldType, tc.target), tc.target)
+	}
+}
+
+func TestOvhRDataRecords(t *testing.T) {
+	client := new(mockOvhClient)
+	provider := &OVHProvider{client: client, apiRateLimiter: newAdaptiveLimiter(10), cacheInstance: cache.New(cache.NoExpiration, cache.NoExpiration)}
+
+	// NAPTR, TLSA and SSHFP records are returned along with the other supported records, normalized
+	client.On("GetWithContext", "/domain/zone").Return([]string{"example.org"}, nil).Once()
+	client.On("GetWithContext", "/domain/zone/example.org/record").Return([]uint64{1, 2, 3, 4}, nil).Once()
+	client.On("GetWithContext", "/domain/zone/example.org/record/1").Return(ovhRecord{ID: 1, Zone: "example.org", ovhRecordFields: ovhRecordFields{FieldType: "NAPTR", ovhRecordFieldUpdate: ovhRecordFieldUpdate{SubDomain: "sip", TTL: 60, Target: `100 10 "S" "SIP+D2U" "" _sip._udp.example.org.`}}}, nil).Once()
+	client.On("GetWithContext", "/domain/zone/example.org/record/2").Return(ovhRecord{ID: 2, Zone: "example.org", ovhRecordFields: ovhRecordFields{FieldType: "TLSA", ovhRecordFieldUpdate: ovhRecordFieldUpdate{SubDomain: "_443._tcp.www", TTL: 60, Target: "3 1 1 0C72AC70B745AC19"}}}, nil).Once()
+	client.On("GetWithContext", "/domain/zone/example.org/record/3").Return(ovhRecord{ID: 3, Zone: "example.org", ovhRecordFields: ovhRecordFields{FieldType: "SSHFP", ovhRecordFieldUpdate: ovhRecordFieldUpdate{SubDomain: "host", TTL: 60, Target: "4 2 8e8ea5a6"}}}, nil).Once()
+	client.On("GetWithContext", "/domain/zone/example.org/record/4").Return(ovhRecord{ID: 4, Zone: "example.org", ovhRecordFields: ovhRecordFields{FieldType: "SSHFP", ovhRecordFieldUpdate: ovhRecordFieldUpdate{SubDomain: "host", TTL: 60, Target: "1 2 0a0b0c0d"}}}, nil).Once()
+	endpoints, err := provider.Records(t.Context())
+	require.NoError(t, err)
+	require.Len(t, endpoints, 3)
+	byType := map[string]*endpoint.Endpoint{}
+	for _, ep := range endpoints {
+		byType[ep.RecordType] = ep
+	}
+	assert.Equal(t, endpoint.Targets{`100 10 "S" "SIP+D2U" "" _sip._udp.example.org.`}, byType[endpoint.RecordTypeNAPTR].Targets)
+	assert.Equal(t, "_443._tcp.www.example.org", byType[endpoint.RecordTypeTLSA].DNSName)
+	assert.Equal(t, endpoint.Targets{"3 1 1 0c72ac70b745ac19"}, byType[endpoint.RecordTypeTLSA].Targets)
+	assert.ElementsMatch(t, endpoint.Targets{"4 2 8e8ea5a6", "1 2 0a0b0c0d"}, byType[endpoint.RecordTypeSSHFP].Targets)
+	client.AssertExpectations(t)
+
+	// desired targets are normalized, so that they compare equal
+	desired, err := provider.AdjustEndpoints([]*endpoint.Endpoint{
+		endpoint.NewEndpoint("_443._tcp.www.example.org", endpoint.RecordTypeTLSA, "3 1 1 0C72AC70 B745AC19"),
+		endpoint.NewEndpoint("sip.example.org", endpoint.RecordTypeNAPTR, `100 10 S SIP+D2U "" _sip._udp.example.org.`),
+	})
+	require.NoError(t, err)
+	assert.Equal(t, byType[endpoint.RecordTypeTLSA].Targets, desired[0].Targets)
+	assert.Equal(t, byType[endpoint.RecordTypeNAPTR].Targets, desired[1].Targets)
+
+	// records are matched whatever the case of their data, and created, updated and deleted
+	client.On("PutWithContext", "/domain/zone/example.org/record/2", ovhRecordFieldUpdate{SubDomain: "_443._tcp.www", TTL: 0, Target: "3 1 1 0C72AC70B745AC19"}).Return(nil, nil).Once()
+	client.On("DeleteWithContext", "/domain/zone/example.org/record/4").Return(nil, nil).Once()
+	client.On("PostWithContext", "/domain/zone/example.org/record", ovhRecordFields{FieldType: "SSHFP", ovhRecordFieldUpdate: ovhRecordFieldUpdate{SubDomain: "other", TTL: 0, Target: "4 2 8e8ea5a6"}}).Return(nil, nil).Once()
+	client.On("PostWithContext", "/domain/zone/example.org/refresh", nil).Return(nil, nil).Once()
+	require.NoError(t, provider.ApplyChanges(t.Context(), &plan.Changes{
+		Create:    []*endpoint.Endpoint{endpoint.NewEndpoint("other.example.org", endpoint.RecordTypeSSHFP, "4 2 8E8EA5A6")},
+		UpdateOld: []*endpoint.Endpoint{byType[endpoint.RecordTypeTLSA]},
+		UpdateNew: []*endpoint.Endpoint{endpoint.NewEndpoint("_443._tcp.www.example.org", endpoint.RecordTypeTLSA, "3 1 1 0c72ac70b745ac19")},
+		Delete:    []*endpoint.Endpoint{endpoint.NewEndpoint("host.example.org", endpoint.RecordTypeSSHFP, "1 2 0A0B0C0D")},
+	}))
+	client.AssertExpectations(t)
+}
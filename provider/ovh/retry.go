@@ -0,0 +1,78 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package ovh
+
+import (
+	"context"
+	"errors"
+	"math/rand/v2"
+	"net/http"
+	"time"
+
+	"github.com/ovh/go-ovh/ovh"
+	log "github.com/sirupsen/logrus"
+)
+
+// maxRetryBackoff caps the wait between two attempts of an API call.
+const maxRetryBackoff = 30 * time.Second
+
+// withRetry makes an API call with the given HTTP method, waiting for the rate limiter before each
+// attempt, and retries it with a jittered exponential backoff while it fails with a transient error,
+// up to MaxAttempts attempts. The Retry-After header of the failed responses is respected through the
+// rate limiter, which pauses the calls until then.
+func (p *OVHProvider) withRetry(ctx context.Context, method string, call func() error) error {
+	for attempt := 1; ; attempt++ {
+		if err := p.apiRateLimiter.Take(ctx); err != nil {
+			return err
+		}
+		err := call()
+		if err == nil || attempt >= p.MaxAttempts || !retryableError(method, err) {
+			return err
+		}
+		wait := p.retryBackoff(attempt)
+		log.Warnf("OVH: %v, retrying in %s (attempt %d/%d)", err, wait.Round(time.Millisecond), attempt+1, p.MaxAttempts)
+		select {
+		case <-ctx.Done():
+			return err
+		case <-time.After(wait):
+		}
+	}
+}
+
+// retryBackoff returns the wait after the given failed attempt: RetryBackoff doubled at each attempt,
+// up to maxRetryBackoff, and randomized over its upper half so that concurrent calls spread out.
+func (p *OVHProvider) retryBackoff(attempt int) time.Duration {
+	backoff := min(p.RetryBackoff<<(attempt-1), maxRetryBackoff)
+	if backoff <= 0 {
+		return 0
+	}
+	return backoff/2 + rand.N(backoff/2+1)
+}
+
+// retryableError tells if an API call with the given HTTP method failing with err may succeed when
+// retried: calls rejected because of the quota are, as well as those failing with a server error,
+// except creations which may have been applied anyway and would then be duplicated.
+func retryableError(method string, err error) bool {
+	var apiErr *ovh.APIError
+	if !errors.As(err, &apiErr) {
+		return false
+	}
+	if apiErr.Code == http.StatusTooManyRequests {
+		return true
+	}
+	return apiErr.Code >= http.StatusInternalServerError && method != http.MethodPost
+}
@@ -0,0 +1,131 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package ovh
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/ovh/go-ovh/ovh"
+	"github.com/patrickmn/go-cache"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func newRetryTestProvider(client ovhClient, maxAttempts int) *OVHProvider {
+	return &OVHProvider{
+		client:         client,
+		apiRateLimiter: newAdaptiveLimiter(0),
+		cacheInstance:  cache.New(cache.NoExpiration, cache.NoExpiration),
+		MaxAttempts:    maxAttempts,
+		RetryBackoff:   time.Millisecond,
+	}
+}
+
+func TestOvhRetryTransientErrors(t *testing.T) {
+	client := new(mockOvhClient)
+	provider := newRetryTestProvider(client, 3)
+
+	client.On("DeleteWithContext", "/domain/zone/example.net/record/42").Return(nil, &ovh.APIError{Code: http.StatusTooManyRequests}).Once()
+	client.On("DeleteWithContext", "/domain/zone/example.net/record/42").Return(nil, &ovh.APIError{Code: http.StatusBadGateway}).Once()
+	client.On("DeleteWithContext", "/domain/zone/example.net/record/42").Return(nil, nil).Once()
+	assert.NoError(t, provider.change(t.Context(), ovhChange{Action: ovhDelete, ovhRecord: ovhRecord{ID: 42, Zone: "example.net"}}))
+	client.AssertExpectations(t)
+
+	client.On("GetWithContext", "/domain/zone").Return(nil, &ovh.APIError{Code: http.StatusServiceUnavailable}).Once()
+	client.On("GetWithContext", "/domain/zone").Return([]string{"example.net"}, nil).Once()
+	zones, err := provider.zones(t.Context())
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"example.net"}, zones)
+	client.AssertExpectations(t)
+}
+
+func TestOvhRetryGivesUp(t *testing.T) {
+	client := new(mockOvhClient)
+	provider := newRetryTestProvider(client, 2)
+
+	client.On("GetWithContext", "/domain/zone").Return(nil, &ovh.APIError{Code: http.StatusInternalServerError}).Twice()
+	_, err := provider.zones(t.Context())
+	assert.Error(t, err)
+	client.AssertExpectations(t)
+}
+
+func TestOvhRetryNotRetried(t *testing.T) {
+	for _, tc := range []struct {
+		title  string
+		action int
+		method string
+		err    error
+	}{
+		{title: "client error", action: ovhDelete, method: "DeleteWithContext", err: &ovh.APIError{Code: http.StatusNotFound}},
+		{title: "non API error", action: ovhDelete, method: "DeleteWithContext", err: errors.New("connection reset")},
+		{title: "creation server error", action: ovhCreate, method: "PostWithContext", err: &ovh.APIError{Code: http.StatusInternalServerError}},
+	} {
+		t.Run(tc.title, func(t *testing.T) {
+			client := new(mockOvhClient)
+			provider := newRetryTestProvider(client, 3)
+			change := ovhChange{Action: tc.action, ovhRecord: ovhRecord{ID: 42, Zone: "example.net"}}
+
+			client.On("PostWithContext", "/domain/zone/example.net/record", change.ovhRecordFields).Return(nil, tc.err)
+			client.On("DeleteWithContext", "/domain/zone/example.net/record/42").Return(nil, tc.err)
+			assert.Error(t, provider.change(t.Context(), change))
+			client.AssertNumberOfCalls(t, tc.method, 1)
+		})
+	}
+}
+
+func TestOvhRetryCreationOnTooManyRequests(t *testing.T) {
+	client := new(mockOvhClient)
+	provider := newRetryTestProvider(client, 3)
+	change := ovhChange{Action: ovhCreate, ovhRecord: ovhRecord{Zone: "example.net"}}
+
+	client.On("PostWithContext", "/domain/zone/example.net/record", change.ovhRecordFields).Return(nil, &ovh.APIError{Code: http.StatusTooManyRequests}).Once()
+	client.On("PostWithContext", "/domain/zone/example.net/record", change.ovhRecordFields).Return(nil, nil).Once()
+	assert.NoError(t, provider.change(t.Context(), change))
+	client.AssertExpectations(t)
+}
+
+func TestOvhRetryBackoff(t *testing.T) {
+	provider := &OVHProvider{RetryBackoff: time.Second}
+	for attempt, expected := range map[int]time.Duration{1: time.Second, 2: 2 * time.Second, 3: 4 * time.Second, 10: maxRetryBackoff} {
+		backoff := provider.retryBackoff(attempt)
+		assert.GreaterOrEqual(t, backoff, expected/2, "attempt %d", attempt)
+		assert.LessOrEqual(t, backoff, expected, "attempt %d", attempt)
+	}
+
+	assert.Zero(t, (&OVHProvider{}).retryBackoff(1))
+}
+
+func TestOvhRetryCanceled(t *testing.T) {
+	client := new(mockOvhClient)
+	provider := newRetryTestProvider(client, 3)
+	provider.RetryBackoff = time.Hour
+	ctx, cancel := context.WithCancel(t.Context())
+
+	// the context is canceled during the first attempt, the retry is not waited for
+	client.On("GetWithContext", "/domain/zone").Return(nil, &ovh.APIError{Code: http.StatusServiceUnavailable}).Once().Run(func(mock.Arguments) { cancel() })
+	_, err := provider.zones(ctx)
+	assert.Error(t, err)
+	client.AssertExpectations(t)
+
+	// nor is the rate limiter once it is
+	_, err = provider.zones(ctx)
+	assert.ErrorIs(t, err, context.Canceled)
+}
@@ -0,0 +1,78 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package ovh
+
+import (
+	"fmt"
+	"net"
+	"strings"
+	"time"
+
+	"github.com/miekg/dns"
+)
+
+const (
+	// SOATransportUDP checks the SOA serials of the cached zones over UDP.
+	SOATransportUDP = "udp"
+	// SOATransportTCP checks the SOA serials of the cached zones over TCP.
+	SOATransportTCP = "tcp"
+	// SOATransportDoT checks the SOA serials of the cached zones over DNS over TLS.
+	SOATransportDoT = "dot"
+)
+
+// SOACheck configures how the SOA serials of the cached zones are checked.
+type SOACheck struct {
+	// Resolver is the address, as HOST or HOST:PORT, of the resolver queried for the SOA records, the SOA
+	// server of each zone when empty.
+	Resolver string
+	// Transport is the transport of the queries, SOATransportUDP when empty.
+	Transport string
+	// Timeout is the timeout of the queries, the one of the DNS client when 0.
+	Timeout time.Duration
+}
+
+// newDNSClient returns the client sending the SOA queries over the transport of the check.
+func (c SOACheck) newDNSClient() (*dns.Client, error) {
+	client := &dns.Client{Timeout: c.Timeout}
+	switch c.Transport {
+	case "", SOATransportUDP:
+	case SOATransportTCP:
+		client.Net = "tcp"
+	case SOATransportDoT:
+		client.Net = "tcp-tls"
+	default:
+		return nil, fmt.Errorf("unknown SOA check transport %q, expected one of %s, %s or %s", c.Transport, SOATransportUDP, SOATransportTCP, SOATransportDoT)
+	}
+	return client, nil
+}
+
+// address returns the address the SOA query of a zone whose SOA server is server is sent to, the port
+// defaulting to the one of the transport.
+func (c SOACheck) address(server string) string {
+	host := c.Resolver
+	if host == "" {
+		host = strings.TrimSuffix(server, ".")
+	}
+	if _, _, err := net.SplitHostPort(host); err == nil {
+		return host
+	}
+	port := "53"
+	if c.Transport == SOATransportDoT {
+		port = "853"
+	}
+	return net.JoinHostPort(strings.Trim(host, "[]"), port)
+}
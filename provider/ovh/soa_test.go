@@ -0,0 +1,81 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package ovh
+
+import (
+	"testing"
+	"time"
+
+	"github.com/miekg/dns"
+	"github.com/patrickmn/go-cache"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSOACheckAddress(t *testing.T) {
+	for _, tc := range []struct {
+		name     string
+		check    SOACheck
+		server   string
+		expected string
+	}{
+		{name: "SOA server", server: "ns.example.org.", expected: "ns.example.org:53"},
+		{name: "SOA server over DoT", check: SOACheck{Transport: SOATransportDoT}, server: "ns.example.org.", expected: "ns.example.org:853"},
+		{name: "resolver", check: SOACheck{Resolver: "10.0.0.10"}, server: "ns.example.org.", expected: "10.0.0.10:53"},
+		{name: "resolver with port", check: SOACheck{Resolver: "10.0.0.10:5353", Transport: SOATransportDoT}, server: "ns.example.org.", expected: "10.0.0.10:5353"},
+		{name: "IPv6 resolver", check: SOACheck{Resolver: "[2001:db8::53]", Transport: SOATransportTCP}, server: "ns.example.org.", expected: "[2001:db8::53]:53"},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.expected, tc.check.address(tc.server))
+		})
+	}
+}
+
+func TestSOACheckDNSClient(t *testing.T) {
+	client, err := SOACheck{}.newDNSClient()
+	require.NoError(t, err)
+	assert.Empty(t, client.Net)
+	assert.Zero(t, client.Timeout)
+
+	client, err = SOACheck{Transport: SOATransportTCP, Timeout: 5 * time.Second}.newDNSClient()
+	require.NoError(t, err)
+	assert.Equal(t, "tcp", client.Net)
+	assert.Equal(t, 5*time.Second, client.Timeout)
+
+	client, err = SOACheck{Transport: SOATransportDoT}.newDNSClient()
+	require.NoError(t, err)
+	assert.Equal(t, "tcp-tls", client.Net)
+
+	_, err = SOACheck{Transport: "quic"}.newDNSClient()
+	require.Error(t, err)
+}
+
+func TestOvhZoneRecordsCacheSOAResolver(t *testing.T) {
+	client := new(mockOvhClient)
+	dnsClient := new(mockDnsClient)
+	provider := &OVHProvider{client: client, apiRateLimiter: newAdaptiveLimiter(10), cacheInstance: cache.New(cache.NoExpiration, cache.NoExpiration), dnsClient: dnsClient, UseCache: true, soaCheck: SOACheck{Resolver: "10.0.0.10"}}
+	provider.cacheInstance.Set("example.org#soa", ovhSoa{Server: "ns.example.org.", Serial: 1}, cache.DefaultExpiration)
+
+	client.On("GetWithContext", "/domain/zone").Return([]string{"example.org"}, nil).Once()
+	dnsClient.On("ExchangeContext", mock.AnythingOfType("*context.cancelCtx"), mock.MatchedBy(func(m *dns.Msg) bool { return m.RecursionDesired }), "10.0.0.10:53").
+		Return(&dns.Msg{Answer: []dns.RR{&dns.SOA{Serial: 1}}}, nil).Once()
+	_, _, _, err := provider.zonesRecords(t.Context())
+	require.NoError(t, err)
+	client.AssertExpectations(t)
+	dnsClient.AssertExpectations(t)
+}
@@ -0,0 +1,150 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package ovh
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"slices"
+	"strings"
+
+	log "github.com/sirupsen/logrus"
+
+	"sigs.k8s.io/external-dns/plan"
+)
+
+type ovhMe struct {
+	OvhSubsidiary string `json:"ovhSubsidiary"`
+}
+
+type ovhCart struct {
+	OvhSubsidiary string `json:"ovhSubsidiary,omitempty"`
+	CartID        string `json:"cartId,omitempty"`
+}
+
+type ovhCartItem struct {
+	PlanCode    string `json:"planCode,omitempty"`
+	PricingMode string `json:"pricingMode,omitempty"`
+	Duration    string `json:"duration,omitempty"`
+	Quantity    int    `json:"quantity,omitempty"`
+	ItemID      uint64 `json:"itemId,omitempty"`
+}
+
+type ovhCartItemConfiguration struct {
+	Label string `json:"label"`
+	Value string `json:"value"`
+}
+
+type ovhCheckout struct {
+	AutoPayWithPreferredPaymentMethod bool   `json:"autoPayWithPreferredPaymentMethod,omitempty"`
+	WaiveRetractationPeriod           bool   `json:"waiveRetractationPeriod,omitempty"`
+	OrderID                           uint64 `json:"orderId,omitempty"`
+}
+
+// zoneToCreate returns the zone to create for a name belonging to no zone: the most specific domain of the
+// domain filter it belongs to.
+func (p *OVHProvider) zoneToCreate(name string) (string, bool) {
+	name = strings.ToLower(strings.TrimSuffix(name, "."))
+	zone := ""
+	for _, filter := range p.domainFilter.Filters {
+		domain := strings.TrimPrefix(filter, ".")
+		if (name == domain || strings.HasSuffix(name, "."+domain)) && len(domain) > len(zone) {
+			zone = domain
+		}
+	}
+	return zone, zone != ""
+}
+
+// createZones orders the zones missing for the endpoints to create, belonging to no zone. Zones are created
+// asynchronously by OVHcloud once ordered, their records being created at a later synchronization. Failures
+// are logged and retried at the next synchronization, without failing it.
+func (p *OVHProvider) createZones(ctx context.Context, changes *plan.Changes) {
+	var zones []string
+	for _, ep := range changes.Create {
+		zone, ok := p.zoneToCreate(ep.DNSName)
+		if !ok {
+			log.Warnf("OVH: No zone to create for %s, it belongs to no domain of the domain filter", ep.DNSName)
+			continue
+		}
+		if !slices.Contains(zones, zone) {
+			zones = append(zones, zone)
+		}
+	}
+
+	for _, zone := range zones {
+		switch {
+		case p.zonesOrdered[zone]:
+			log.Infof("OVH: zone %s: Waiting for the zone ordered to be created", zone)
+		case p.DryRun:
+			log.Infof("OVH: Dry-run: zone %s: would have ordered it", zone)
+		default:
+			orderID, err := p.orderZone(ctx, zone)
+			if err != nil {
+				log.Warnf("OVH: zone %s: Failed to order the zone: %v", zone, err)
+				continue
+			}
+			p.zonesOrdered[zone] = true
+			log.Infof("OVH: zone %s: Ordered the zone (order %d), its records are created once it is available", zone, orderID)
+		}
+	}
+}
+
+// forgetCreatedZones forgets the zones ordered that are listed among zones, so that they get ordered again
+// should they be deleted later on.
+func (p *OVHProvider) forgetCreatedZones(zones []string) {
+	for _, zone := range zones {
+		if p.zonesOrdered[zone] {
+			delete(p.zonesOrdered, zone)
+			log.Infof("OVH: zone %s: The zone ordered is created", zone)
+		}
+	}
+}
+
+// orderZone orders a DNS zone through a cart of the subsidiary of the account, and returns the ID of the
+// order. The order is paid right away with the preferred payment method of the account, waiving the
+// retraction period.
+func (p *OVHProvider) orderZone(ctx context.Context, zone string) (uint64, error) {
+	var me ovhMe
+	if err := p.withRetry(ctx, http.MethodGet, func() error {
+		return p.client.GetWithContext(ctx, "/me", &me)
+	}); err != nil {
+		return 0, fmt.Errorf("failed to get the subsidiary of the account: %w", err)
+	}
+
+	var cart ovhCart
+	if err := p.client.PostWithContext(ctx, "/order/cart", ovhCart{OvhSubsidiary: me.OvhSubsidiary}, &cart); err != nil {
+		return 0, fmt.Errorf("failed to create a cart: %w", err)
+	}
+	path := "/order/cart/" + url.PathEscape(cart.CartID)
+	if err := p.client.PostWithContext(ctx, path+"/assign", nil, nil); err != nil {
+		return 0, fmt.Errorf("failed to assign cart %s: %w", cart.CartID, err)
+	}
+	var item ovhCartItem
+	if err := p.client.PostWithContext(ctx, path+"/dns", ovhCartItem{PlanCode: "zone", PricingMode: "default", Duration: "P1M", Quantity: 1}, &item); err != nil {
+		return 0, fmt.Errorf("failed to add the zone to cart %s: %w", cart.CartID, err)
+	}
+	if err := p.client.PostWithContext(ctx, fmt.Sprintf("%s/item/%d/configuration", path, item.ItemID), ovhCartItemConfiguration{Label: "zone", Value: zone}, nil); err != nil {
+		return 0, fmt.Errorf("failed to configure the zone in cart %s: %w", cart.CartID, err)
+	}
+	var order ovhCheckout
+	if err := p.client.PostWithContext(ctx, path+"/checkout", ovhCheckout{AutoPayWithPreferredPaymentMethod: true, WaiveRetractationPeriod: true}, &order); err != nil {
+		return 0, fmt.Errorf("failed to check cart %s out: %w", cart.CartID, err)
+	}
+	return order.OrderID, nil
+}
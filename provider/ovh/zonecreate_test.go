@@ -0,0 +1,131 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package ovh
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/patrickmn/go-cache"
+	"github.com/stretchr/testify/assert"
+
+	"sigs.k8s.io/external-dns/endpoint"
+	"sigs.k8s.io/external-dns/plan"
+)
+
+func TestOvhZoneToCreate(t *testing.T) {
+	provider := &OVHProvider{domainFilter: endpoint.NewDomainFilter([]string{"example.org", "sub.example.org", ".example.net"})}
+
+	for name, zone := range map[string]string{
+		"example.org":          "example.org",
+		"www.example.org":      "example.org",
+		"www.sub.example.org.": "sub.example.org",
+		"www.example.net":      "example.net",
+		"www.example.com":      "",
+	} {
+		got, ok := provider.zoneToCreate(name)
+		assert.Equal(t, zone, got, name)
+		assert.Equal(t, zone != "", ok, name)
+	}
+}
+
+func TestOvhCreateZones(t *testing.T) {
+	client := new(mockOvhClient)
+	provider := &OVHProvider{
+		client:         client,
+		apiRateLimiter: newAdaptiveLimiter(10),
+		cacheInstance:  cache.New(cache.NoExpiration, cache.NoExpiration),
+		domainFilter:   endpoint.NewDomainFilter([]string{"example.org", "example.net"}),
+		CreateZones:    true,
+		zonesOrdered:   map[string]bool{},
+		lastRunZones:   []string{"example.com"},
+	}
+	changes := &plan.Changes{Create: []*endpoint.Endpoint{
+		endpoint.NewEndpoint("www.example.org", endpoint.RecordTypeA, "203.0.113.42"),
+		endpoint.NewEndpoint("api.example.org", endpoint.RecordTypeA, "203.0.113.43"),
+		endpoint.NewEndpoint("www.example.net", endpoint.RecordTypeA, "203.0.113.44"),
+	}}
+
+	// the missing zones are ordered, a failed order being retried at the next synchronization
+	client.On("GetWithContext", "/me").Return(ovhMe{OvhSubsidiary: "FR"}, nil).Twice()
+	client.On("PostWithContext", "/order/cart", ovhCart{OvhSubsidiary: "FR"}).Return(ovhCart{CartID: "cart-1"}, nil).Once()
+	client.On("PostWithContext", "/order/cart/cart-1/assign", nil).Return(nil, nil).Once()
+	client.On("PostWithContext", "/order/cart/cart-1/dns", ovhCartItem{PlanCode: "zone", PricingMode: "default", Duration: "P1M", Quantity: 1}).Return(ovhCartItem{ItemID: 7}, nil).Once()
+	client.On("PostWithContext", "/order/cart/cart-1/item/7/configuration", ovhCartItemConfiguration{Label: "zone", Value: "example.org"}).Return(nil, nil).Once()
+	client.On("PostWithContext", "/order/cart/cart-1/checkout", ovhCheckout{AutoPayWithPreferredPaymentMethod: true, WaiveRetractationPeriod: true}).Return(ovhCheckout{OrderID: 42}, nil).Once()
+	client.On("PostWithContext", "/order/cart", ovhCart{OvhSubsidiary: "FR"}).Return(nil, errors.New("quota exceeded")).Once()
+	assert.NoError(t, provider.ApplyChangesBatch(t.Context(), changes))
+	client.AssertExpectations(t)
+	assert.Equal(t, map[string]bool{"example.org": true}, provider.zonesOrdered)
+
+	// the zones ordered are not ordered again until they are created
+	client.On("GetWithContext", "/me").Return(nil, errors.New("unavailable")).Once()
+	assert.NoError(t, provider.ApplyChangesBatch(t.Context(), changes))
+	client.AssertExpectations(t)
+}
+
+func TestOvhCreateZonesRetry(t *testing.T) {
+	client := new(mockOvhClient)
+	provider := &OVHProvider{
+		client:         client,
+		apiRateLimiter: newAdaptiveLimiter(10),
+		domainFilter:   endpoint.NewDomainFilter([]string{"example.org"}),
+		CreateZones:    true,
+		zonesOrdered:   map[string]bool{},
+	}
+	changes := &plan.Changes{Create: []*endpoint.Endpoint{
+		endpoint.NewEndpoint("www.example.org", endpoint.RecordTypeA, "203.0.113.42"),
+	}}
+	client.On("GetWithContext", "/me").Return(ovhMe{OvhSubsidiary: "FR"}, nil).Twice()
+	client.On("PostWithContext", "/order/cart", ovhCart{OvhSubsidiary: "FR"}).Return(ovhCart{CartID: "cart-1"}, nil).Twice()
+	client.On("PostWithContext", "/order/cart/cart-1/assign", nil).Return(nil, nil).Twice()
+	client.On("PostWithContext", "/order/cart/cart-1/dns", ovhCartItem{PlanCode: "zone", PricingMode: "default", Duration: "P1M", Quantity: 1}).Return(ovhCartItem{ItemID: 7}, nil).Twice()
+	client.On("PostWithContext", "/order/cart/cart-1/item/7/configuration", ovhCartItemConfiguration{Label: "zone", Value: "example.org"}).Return(nil, nil).Twice()
+
+	// the checkout fails: the zone is not ordered
+	client.On("PostWithContext", "/order/cart/cart-1/checkout", ovhCheckout{AutoPayWithPreferredPaymentMethod: true, WaiveRetractationPeriod: true}).Return(nil, errors.New("payment refused")).Once()
+	provider.createZones(t.Context(), changes)
+	assert.Empty(t, provider.zonesOrdered)
+
+	// it is ordered again at the next synchronization
+	client.On("PostWithContext", "/order/cart/cart-1/checkout", ovhCheckout{AutoPayWithPreferredPaymentMethod: true, WaiveRetractationPeriod: true}).Return(ovhCheckout{OrderID: 42}, nil).Once()
+	provider.createZones(t.Context(), changes)
+	client.AssertExpectations(t)
+	assert.Equal(t, map[string]bool{"example.org": true}, provider.zonesOrdered)
+
+	// and forgotten once listed among the zones of the account
+	provider.forgetCreatedZones([]string{"example.com"})
+	assert.Equal(t, map[string]bool{"example.org": true}, provider.zonesOrdered)
+	provider.forgetCreatedZones([]string{"example.com", "example.org"})
+	assert.Empty(t, provider.zonesOrdered)
+}
+
+func TestOvhCreateZonesDryRun(t *testing.T) {
+	client := new(mockOvhClient)
+	provider := &OVHProvider{
+		client:       client,
+		domainFilter: endpoint.NewDomainFilter([]string{"example.org"}),
+		DryRun:       true,
+		CreateZones:  true,
+		zonesOrdered: map[string]bool{},
+	}
+	provider.createZones(t.Context(), &plan.Changes{Create: []*endpoint.Endpoint{
+		endpoint.NewEndpoint("www.example.org", endpoint.RecordTypeA, "203.0.113.42"),
+	}})
+	client.AssertExpectations(t)
+	assert.Empty(t, provider.zonesOrdered)
+}
@@ -0,0 +1,110 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package ovh
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"sync"
+
+	log "github.com/sirupsen/logrus"
+
+	"sigs.k8s.io/external-dns/provider"
+)
+
+// zoneSerials are the SOA serials of zones, safe for concurrent use. A nil zoneSerials records none.
+type zoneSerials struct {
+	mu      sync.Mutex
+	serials map[string]uint32
+}
+
+func (s *zoneSerials) set(zone string, serial uint32) {
+	if s == nil {
+		return
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.serials == nil {
+		s.serials = map[string]uint32{}
+	}
+	s.serials[zone] = serial
+}
+
+// take returns the serial of zone, forgetting it.
+func (s *zoneSerials) take(zone string) (uint32, bool) {
+	if s == nil {
+		return 0, false
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	serial, ok := s.serials[zone]
+	delete(s.serials, zone)
+	return serial, ok
+}
+
+func (s *zoneSerials) reset() {
+	if s == nil {
+		return
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.serials = nil
+}
+
+// ZoneVersions returns the SOA serials of the zones read by the last call to Records, known when their
+// records are cached or CheckZoneSerials is set.
+func (p *OVHProvider) ZoneVersions() map[string]string {
+	if p.lastRunSerials == nil {
+		return nil
+	}
+	p.lastRunSerials.mu.Lock()
+	defer p.lastRunSerials.mu.Unlock()
+	if len(p.lastRunSerials.serials) == 0 {
+		return nil
+	}
+	versions := make(map[string]string, len(p.lastRunSerials.serials))
+	for zone, serial := range p.lastRunSerials.serials {
+		versions[zone] = strconv.FormatUint(uint64(serial), 10)
+	}
+	return versions
+}
+
+// checkSerial returns an error wrapping provider.ErrZoneChanged when the SOA serial of zone changed since its
+// records were got, its cached records being invalidated. The serial is only checked before the first changes
+// applied to the zone, which change it.
+func (p *OVHProvider) checkSerial(ctx context.Context, zone string) error {
+	expected, ok := p.lastRunSerials.take(zone)
+	if !ok {
+		return nil
+	}
+
+	var soa ovhSoa
+	if err := p.withRetry(ctx, http.MethodGet, func() error {
+		return p.client.GetWithContext(ctx, "/domain/zone/"+url.PathEscape(zone)+"/soa", &soa)
+	}); err != nil {
+		return err
+	}
+	if soa.Serial != expected {
+		p.invalidateCache(zone)
+		return fmt.Errorf("zone %s: SOA serial %d instead of %d: %w", zone, soa.Serial, expected, provider.ErrZoneChanged)
+	}
+	log.Debugf("OVH: zone %s: SOA serial %d unchanged, applying the changes", zone, soa.Serial)
+	return nil
+}
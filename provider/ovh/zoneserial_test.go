@@ -0,0 +1,68 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package ovh
+
+import (
+	"testing"
+
+	"github.com/patrickmn/go-cache"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"sigs.k8s.io/external-dns/endpoint"
+	"sigs.k8s.io/external-dns/plan"
+	"sigs.k8s.io/external-dns/provider"
+)
+
+func TestOvhCheckZoneSerials(t *testing.T) {
+	client := new(mockOvhClient)
+	p := &OVHProvider{
+		client:           client,
+		apiRateLimiter:   newAdaptiveLimiter(10),
+		cacheInstance:    cache.New(cache.NoExpiration, cache.NoExpiration),
+		CheckZoneSerials: true,
+		lastRunSerials:   &zoneSerials{},
+	}
+	changes := &plan.Changes{Create: []*endpoint.Endpoint{endpoint.NewEndpoint("www.example.org", endpoint.RecordTypeA, "203.0.113.42")}}
+	listRecords := func(serial uint32) {
+		client.On("GetWithContext", "/domain/zone").Return([]string{"example.org"}, nil).Once()
+		client.On("GetWithContext", "/domain/zone/example.org/soa").Return(ovhSoa{Serial: serial}, nil).Once()
+		client.On("GetWithContext", "/domain/zone/example.org/record").Return([]uint64{}, nil).Once()
+		_, err := p.Records(t.Context())
+		require.NoError(t, err)
+	}
+
+	// the serial read along with the records is reported
+	listRecords(1)
+	assert.Equal(t, map[string]string{"example.org": "1"}, provider.ZoneVersions(p))
+
+	// changes to a zone changed in the meantime are refused
+	client.On("GetWithContext", "/domain/zone/example.org/soa").Return(ovhSoa{Serial: 2}, nil).Once()
+	err := p.ApplyChanges(t.Context(), changes)
+	require.ErrorIs(t, err, provider.ErrZoneChanged)
+	require.ErrorIs(t, err, provider.SoftError)
+	client.AssertExpectations(t)
+
+	// changes to a zone left unchanged are applied
+	listRecords(2)
+	client.On("GetWithContext", "/domain/zone/example.org/soa").Return(ovhSoa{Serial: 2}, nil).Once()
+	client.On("PostWithContext", "/domain/zone/example.org/record", ovhRecordFields{FieldType: "A", ovhRecordFieldUpdate: ovhRecordFieldUpdate{SubDomain: "www", Target: "203.0.113.42"}}).Return(nil, nil).Once()
+	client.On("PostWithContext", "/domain/zone/example.org/refresh", nil).Return(nil, nil).Once()
+	require.NoError(t, p.ApplyChanges(t.Context(), changes))
+	client.AssertExpectations(t)
+	assert.Nil(t, provider.ZoneVersions(p))
+}
@@ -24,7 +24,6 @@ import (
 	"errors"
 	"fmt"
 	"math"
-	"net"
 	"net/http"
 	"sort"
 	"strings"
@@ -34,6 +33,7 @@ import (
 	log "github.com/sirupsen/logrus"
 
 	"sigs.k8s.io/external-dns/endpoint"
+	extdnshttp "sigs.k8s.io/external-dns/pkg/http"
 	"sigs.k8s.io/external-dns/pkg/tlsutils"
 	"sigs.k8s.io/external-dns/plan"
 	"sigs.k8s.io/external-dns/provider"
@@ -78,36 +78,28 @@ type TLSConfig struct {
 	ClientCertKeyFilePath string
 }
 
+// setHTTPClient sets the client of the PDNS API, with the TLS settings of the provider when any,
+// the ones of the shared HTTP transport otherwise.
 func (tlsConfig *TLSConfig) setHTTPClient(pdnsClientConfig *pgo.Configuration) error {
-	log.Debug("Configuring TLS for PDNS Provider.")
-	tlsClientConfig, err := tlsutils.NewTLSConfig(
-		tlsConfig.ClientCertFilePath,
-		tlsConfig.ClientCertKeyFilePath,
-		tlsConfig.CAFilePath,
-		"",
-		tlsConfig.SkipTLSVerify,
-		tls.VersionTLS12,
-	)
-	if err != nil {
-		return err
+	var tlsClientConfig *tls.Config
+	if *tlsConfig != (TLSConfig{}) {
+		log.Debug("Configuring TLS for PDNS Provider.")
+		var err error
+		tlsClientConfig, err = tlsutils.NewTLSConfig(
+			tlsConfig.ClientCertFilePath,
+			tlsConfig.ClientCertKeyFilePath,
+			tlsConfig.CAFilePath,
+			"",
+			tlsConfig.SkipTLSVerify,
+			tls.VersionTLS12,
+		)
+		if err != nil {
+			return err
+		}
 	}
 
-	// Timeouts taken from net.http.DefaultTransport
-	transporter := &http.Transport{
-		Proxy: http.ProxyFromEnvironment,
-		DialContext: (&net.Dialer{
-			Timeout:   30 * time.Second,
-			KeepAlive: 30 * time.Second,
-			DualStack: true,
-		}).DialContext,
-		MaxIdleConns:          100,
-		IdleConnTimeout:       90 * time.Second,
-		TLSHandshakeTimeout:   10 * time.Second,
-		ExpectContinueTimeout: 1 * time.Second,
-		TLSClientConfig:       tlsClientConfig,
-	}
 	pdnsClientConfig.HTTPClient = &http.Client{
-		Transport: transporter,
+		Transport: extdnshttp.NewTransport("pdns", tlsClientConfig),
 	}
 
 	return nil
@@ -434,9 +426,16 @@ func (p *PDNSProvider) Records(ctx context.Context) (endpoints []*endpoint.Endpo
 }
 
 // AdjustEndpoints performs checks on the provided endpoints and will skip any potentially failing changes.
+// Alias endpoints are CNAME records, created as ALIAS records at zone apexes.
 func (p *PDNSProvider) AdjustEndpoints(endpoints []*endpoint.Endpoint) ([]*endpoint.Endpoint, error) {
 	var validEndpoints []*endpoint.Endpoint
 	for i := 0; i < len(endpoints); i++ {
+		if alias, ok := endpoints[i].GetProviderSpecificProperty("alias"); ok {
+			endpoints[i].DeleteProviderSpecificProperty("alias")
+			if alias == "true" {
+				endpoints[i].RecordType = endpoint.RecordTypeCNAME
+			}
+		}
 		if !endpoints[i].CheckEndpoint() {
 			log.Warnf("Ignoring Endpoint because of invalid %v record formatting: {Target: '%v'}", endpoints[i].RecordType, endpoints[i].Targets)
 			continue
@@ -446,6 +445,11 @@ func (p *PDNSProvider) AdjustEndpoints(endpoints []*endpoint.Endpoint) ([]*endpo
 	return validEndpoints, nil
 }
 
+// SupportsAlias reports that CNAME records at zone apexes are created as ALIAS records.
+func (p *PDNSProvider) SupportsAlias() bool {
+	return true
+}
+
 // ApplyChanges takes a list of changes (endpoints) and updates the PDNS server
 // by sending the correct HTTP PATCH requests to a matching zone
 func (p *PDNSProvider) ApplyChanges(ctx context.Context, changes *plan.Changes) error {
@@ -1170,6 +1170,17 @@ func (suite *NewPDNSProviderTestSuite) TestPDNSAdjustEndpoints() {
 			endpoints:   endpointsMultipleInvalidMXRecords,
 			expected:    []*endpoint.Endpoint([]*endpoint.Endpoint(nil)),
 		},
+		{
+			description: "Alias endpoints are CNAME endpoints",
+			endpoints: []*endpoint.Endpoint{
+				endpoint.NewEndpoint("example.com", endpoint.RecordTypeA, "lb.example.net").WithProviderSpecific("alias", "true"),
+				endpoint.NewEndpoint("www.example.com", endpoint.RecordTypeCNAME, "example.com").WithProviderSpecific("alias", "false"),
+			},
+			expected: []*endpoint.Endpoint{
+				{DNSName: "example.com", RecordType: endpoint.RecordTypeCNAME, Targets: endpoint.Targets{"lb.example.net"}, Labels: endpoint.Labels{}, ProviderSpecific: endpoint.ProviderSpecific{}},
+				{DNSName: "www.example.com", RecordType: endpoint.RecordTypeCNAME, Targets: endpoint.Targets{"example.com"}, Labels: endpoint.Labels{}, ProviderSpecific: endpoint.ProviderSpecific{}},
+			},
+		},
 	}
 
 	for _, tt := range tests {
@@ -1179,6 +1190,10 @@ func (suite *NewPDNSProviderTestSuite) TestPDNSAdjustEndpoints() {
 	}
 }
 
+func (suite *NewPDNSProviderTestSuite) TestPDNSSupportsAlias() {
+	assert.True(suite.T(), provider.SupportsAlias(&PDNSProvider{}))
+}
+
 func TestNewPDNSProviderTestSuite(t *testing.T) {
 	suite.Run(t, new(NewPDNSProviderTestSuite))
 }
@@ -26,6 +26,8 @@ import (
 	"github.com/Yamashou/gqlgenc/clientv2"
 	"github.com/pluralsh/gqlclient"
 	"github.com/pluralsh/gqlclient/pkg/utils"
+
+	extdnshttp "sigs.k8s.io/external-dns/pkg/http"
 )
 
 type authedTransport struct {
@@ -71,7 +73,7 @@ func NewClient(conf *Config) (Client, error) {
 	httpClient := http.Client{
 		Transport: &authedTransport{
 			key:     conf.Token,
-			wrapped: http.DefaultTransport,
+			wrapped: extdnshttp.NewTransport("plural", nil),
 		},
 	}
 	endpoint := base + "/gql"
@@ -57,7 +57,165 @@ type Provider interface {
 	GetDomainFilter() endpoint.DomainFilterInterface
 }
 
-type BaseProvider struct{}
+// LabelPersister is implemented by providers able to store the labels of an endpoint
+// alongside its DNS records, e.g. in record comments. The labels are returned by Records,
+// which allows registries to keep ownership without creating additional records.
+type LabelPersister interface {
+	PersistsLabels() bool
+}
+
+// PersistsLabels returns true if the provider stores endpoint labels natively.
+func PersistsLabels(p Provider) bool {
+	lp, ok := p.(LabelPersister)
+	return ok && lp.PersistsLabels()
+}
+
+// ViewSupporter is implemented by providers supporting split DNS, whose records are selected in a
+// DNS view with the dns-view provider specific property.
+type ViewSupporter interface {
+	SupportsViews() bool
+}
+
+// SupportsViews returns true if the provider selects the DNS view of records with the dns-view property.
+func SupportsViews(p Provider) bool {
+	vs, ok := p.(ViewSupporter)
+	return ok && vs.SupportsViews()
+}
+
+// AliasSupporter is implemented by providers creating alias records, i.e. records pointing at another name
+// where a CNAME record is not allowed, such as at a zone apex. The controller falls back to CNAME records, or
+// to address records at zone apexes, with other providers.
+type AliasSupporter interface {
+	SupportsAlias() bool
+}
+
+// SupportsAlias returns true if the provider creates alias records.
+func SupportsAlias(p Provider) bool {
+	as, ok := p.(AliasSupporter)
+	return ok && as.SupportsAlias()
+}
+
+// PropertyComparatorProvider is implemented by providers declaring how the provider specific properties
+// of their records compare, e.g. to ignore the properties they do not support, or to equal unset
+// properties to the values they default to.
+type PropertyComparatorProvider interface {
+	PropertyComparator() plan.PropertyComparator
+}
+
+// PropertyComparator returns the comparator of the provider specific properties of the provider,
+// nil when they must be exactly the same.
+func PropertyComparator(p Provider) plan.PropertyComparator {
+	if cp, ok := p.(PropertyComparatorProvider); ok {
+		return cp.PropertyComparator()
+	}
+	return nil
+}
+
+const (
+	// TXTEncodingQuoted is the presentation form of TXT targets, quoted character strings of at most 255 bytes,
+	// e.g. `"v=DKIM1; k=rsa; p=MIIB..." "...IDAQAB"`.
+	TXTEncodingQuoted = "quoted"
+	// TXTEncodingRaw is the value of TXT targets without quotes, split into character strings by the provider.
+	TXTEncodingRaw = "raw"
+)
+
+// TXTEncoder is implemented by providers taking the targets of TXT records in an encoding, TXTEncodingQuoted
+// or TXTEncodingRaw, and returning them in it. The controller encodes the targets of the desired TXT endpoints
+// accordingly, whether they are given quoted or not, so that long values get split and compare equal to
+// the records.
+type TXTEncoder interface {
+	TXTEncoding() string
+}
+
+// TXTEncoding returns the encoding of the TXT targets of the provider, empty when they are left as they are.
+func TXTEncoding(p Provider) string {
+	if te, ok := p.(TXTEncoder); ok {
+		return te.TXTEncoding()
+	}
+	return ""
+}
+
+// ErrZoneChanged is wrapped by the errors of the providers refusing to apply changes to a zone whose version
+// changed since its records were listed, i.e. that another actor changed in the meantime.
+var ErrZoneChanged = errors.New("zone changed since its records were listed")
+
+// ZoneVersioner is implemented by providers reporting the version of the zones read by Records, e.g. the
+// serial of their SOA record, and refusing to apply changes to a zone whose version changed since with an
+// error wrapping ErrZoneChanged, so that changes planned from stale records do not overwrite those of other
+// actors. The controller then plans the changes again from the current records.
+type ZoneVersioner interface {
+	ZoneVersions() map[string]string
+}
+
+// ZoneVersions returns the version of the zones read by the last call to Records of the provider, by zone
+// name, nil when it does not report them.
+func ZoneVersions(p Provider) map[string]string {
+	if zv, ok := p.(ZoneVersioner); ok {
+		return zv.ZoneVersions()
+	}
+	return nil
+}
+
+// ZoneCollisionsSetter is implemented by the providers finding the zones of hostnames with a ZoneFinder,
+// which resolve the ambiguous zone matches with the ZoneCollisions they are given.
+type ZoneCollisionsSetter interface {
+	SetZoneCollisions(c *ZoneCollisions)
+}
+
+// SetZoneCollisions gives c to the provider when it finds the zones of hostnames with a ZoneFinder.
+func SetZoneCollisions(p Provider, c *ZoneCollisions) {
+	if zs, ok := p.(ZoneCollisionsSetter); ok {
+		zs.SetZoneCollisions(c)
+	}
+}
+
+// RecordsPage is a page of the records of a provider, with the token of the next page, empty after the last one.
+type RecordsPage struct {
+	Endpoints []*endpoint.Endpoint
+	NextToken string
+}
+
+// PaginatedProvider is implemented by providers listing their records page by page, e.g. a zone or a few
+// thousand records at a time, the first page being got with an empty token. Registries process the records
+// of each page as they are listed, so that those of large zones are not all held in memory at once.
+type PaginatedProvider interface {
+	RecordsPage(ctx context.Context, token string) (*RecordsPage, error)
+}
+
+// EachRecordsPage calls fn with each page of the records of the provider in order, or once with all its
+// records when it does not list them page by page, and stops at the first error. Paginated providers may
+// implement Records with it.
+func EachRecordsPage(ctx context.Context, p Provider, fn func([]*endpoint.Endpoint) error) error {
+	pp, ok := p.(PaginatedProvider)
+	if !ok {
+		records, err := p.Records(ctx)
+		if err != nil {
+			return err
+		}
+		return fn(records)
+	}
+	token := ""
+	for {
+		page, err := pp.RecordsPage(ctx, token)
+		if err != nil {
+			return err
+		}
+		if err := fn(page.Endpoints); err != nil {
+			return err
+		}
+		if page.NextToken == "" {
+			return nil
+		}
+		if page.NextToken == token {
+			return fmt.Errorf("records page token %q does not advance", token)
+		}
+		token = page.NextToken
+	}
+}
+
+type BaseProvider struct {
+	zoneCollisions *ZoneCollisions
+}
 
 func (b BaseProvider) AdjustEndpoints(endpoints []*endpoint.Endpoint) ([]*endpoint.Endpoint, error) {
 	return endpoints, nil
@@ -67,6 +225,18 @@ func (b BaseProvider) GetDomainFilter() endpoint.DomainFilterInterface {
 	return endpoint.DomainFilter{}
 }
 
+// SetZoneCollisions makes the ZoneFinders returned by NewZoneFinder resolve their ambiguous zone
+// matches with c.
+func (b *BaseProvider) SetZoneCollisions(c *ZoneCollisions) {
+	b.zoneCollisions = c
+}
+
+// NewZoneFinder returns a ZoneFinder with no zones resolving its ambiguous zone matches with the
+// ZoneCollisions of the provider.
+func (b BaseProvider) NewZoneFinder() ZoneFinder {
+	return NewZoneFinder(b.zoneCollisions)
+}
+
 type contextKey struct {
 	name string
 }
@@ -17,12 +17,17 @@ limitations under the License.
 package provider
 
 import (
+	"context"
+	"errors"
 	"io"
 	"os"
 	"testing"
 
 	log "github.com/sirupsen/logrus"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"sigs.k8s.io/external-dns/endpoint"
 )
 
 func TestMain(m *testing.M) {
@@ -54,3 +59,69 @@ func TestDifference(t *testing.T) {
 	assert.Equal(t, remove, []string{"foo"})
 	assert.Equal(t, leave, []string{"bar"})
 }
+
+// testPaginatedProvider lists the pages of records keyed by their token.
+type testPaginatedProvider struct {
+	testProviderFunc
+	pages map[string]*RecordsPage
+	err   error
+}
+
+func (p *testPaginatedProvider) RecordsPage(_ context.Context, token string) (*RecordsPage, error) {
+	if p.err != nil {
+		return nil, p.err
+	}
+	return p.pages[token], nil
+}
+
+func TestEachRecordsPage(t *testing.T) {
+	a := endpoint.NewEndpoint("a.example.org", endpoint.RecordTypeA, "1.2.3.4")
+	b := endpoint.NewEndpoint("b.example.org", endpoint.RecordTypeA, "5.6.7.8")
+	collect := func(p Provider) ([][]*endpoint.Endpoint, error) {
+		var pages [][]*endpoint.Endpoint
+		err := EachRecordsPage(context.Background(), p, func(records []*endpoint.Endpoint) error {
+			pages = append(pages, records)
+			return nil
+		})
+		return pages, err
+	}
+
+	t.Run("not paginated", func(t *testing.T) {
+		pages, err := collect(&testProviderFunc{records: func(context.Context) ([]*endpoint.Endpoint, error) {
+			return []*endpoint.Endpoint{a, b}, nil
+		}})
+		require.NoError(t, err)
+		assert.Equal(t, [][]*endpoint.Endpoint{{a, b}}, pages)
+	})
+
+	t.Run("paginated", func(t *testing.T) {
+		pages, err := collect(&testPaginatedProvider{pages: map[string]*RecordsPage{
+			"":     {Endpoints: []*endpoint.Endpoint{a}, NextToken: "next"},
+			"next": {Endpoints: []*endpoint.Endpoint{b}},
+		}})
+		require.NoError(t, err)
+		assert.Equal(t, [][]*endpoint.Endpoint{{a}, {b}}, pages)
+	})
+
+	t.Run("failing page", func(t *testing.T) {
+		_, err := collect(&testPaginatedProvider{err: errors.New("failed")})
+		require.EqualError(t, err, "failed")
+	})
+
+	t.Run("token not advancing", func(t *testing.T) {
+		_, err := collect(&testPaginatedProvider{pages: map[string]*RecordsPage{
+			"":     {Endpoints: []*endpoint.Endpoint{a}, NextToken: "next"},
+			"next": {Endpoints: []*endpoint.Endpoint{b}, NextToken: "next"},
+		}})
+		require.EqualError(t, err, `records page token "next" does not advance`)
+	})
+
+	t.Run("failing callback", func(t *testing.T) {
+		err := EachRecordsPage(context.Background(), &testPaginatedProvider{pages: map[string]*RecordsPage{
+			"": {Endpoints: []*endpoint.Endpoint{a}, NextToken: "next"},
+		}}, func([]*endpoint.Endpoint) error {
+			return errors.New("stop")
+		})
+		require.EqualError(t, err, "stop")
+	})
+}
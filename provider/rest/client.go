@@ -0,0 +1,281 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package rest
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"sigs.k8s.io/external-dns/pkg/apis/externaldns"
+	extdnshttp "sigs.k8s.io/external-dns/pkg/http"
+)
+
+// Zone is a zone of the API.
+type Zone struct {
+	ID   string
+	Name string
+}
+
+// Record is a record of the API holding a single target. Its name is fully qualified.
+type Record struct {
+	ID     string
+	Name   string
+	Type   string
+	TTL    int
+	Target string
+}
+
+// Client is the DNS API described by a mapping.
+type Client interface {
+	ListZones(ctx context.Context) ([]Zone, error)
+	ListRecords(ctx context.Context, zone Zone) ([]Record, error)
+	CreateRecord(ctx context.Context, zone Zone, record Record) error
+	// UpdateRecord updates a record in place, returning false when the API cannot.
+	UpdateRecord(ctx context.Context, zone Zone, record Record) (bool, error)
+	DeleteRecord(ctx context.Context, zone Zone, record Record) error
+}
+
+type client struct {
+	mapping    *Mapping
+	httpClient *http.Client
+}
+
+// NewClient returns a client of the API described by the mapping.
+func NewClient(mapping *Mapping) Client {
+	return &client{mapping: mapping, httpClient: extdnshttp.NewClient("rest")}
+}
+
+func (c *client) do(ctx context.Context, request Request, zone Zone, recordID string, body any) (any, error) {
+	path := strings.NewReplacer(
+		"{zone}", url.PathEscape(zone.ID),
+		"{zoneName}", url.PathEscape(zone.Name),
+		"{id}", url.PathEscape(recordID),
+	).Replace(request.Path)
+
+	var reader io.Reader
+	if body != nil {
+		data, err := json.Marshal(body)
+		if err != nil {
+			return nil, err
+		}
+		reader = bytes.NewReader(data)
+	}
+	req, err := http.NewRequestWithContext(ctx, request.Method, c.mapping.BaseURL+path, reader)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Accept", "application/json")
+	req.Header.Set("User-Agent", externaldns.UserAgent())
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	for name, value := range c.mapping.Headers {
+		req.Header.Set(name, value)
+	}
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	data, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return nil, fmt.Errorf("%s %s: unexpected status %s: %s", request.Method, path, resp.Status, strings.TrimSpace(string(data)))
+	}
+	if len(bytes.TrimSpace(data)) == 0 {
+		return nil, nil
+	}
+	var result any
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+	if err := decoder.Decode(&result); err != nil {
+		return nil, fmt.Errorf("%s %s: failed to decode response: %w", request.Method, path, err)
+	}
+	return result, nil
+}
+
+// list returns the items of the response of a list request.
+func (c *client) list(ctx context.Context, request Request, zone Zone) ([]map[string]any, error) {
+	result, err := c.do(ctx, request, zone, "", nil)
+	if err != nil {
+		return nil, err
+	}
+	value, _ := getField(result, request.ItemsPath)
+	items, ok := value.([]any)
+	if !ok {
+		return nil, fmt.Errorf("%s %s: no list of items at %q of the response", request.Method, request.Path, request.ItemsPath)
+	}
+	objects := make([]map[string]any, 0, len(items))
+	for _, item := range items {
+		if object, ok := item.(map[string]any); ok {
+			objects = append(objects, object)
+		}
+	}
+	return objects, nil
+}
+
+func (c *client) ListZones(ctx context.Context) ([]Zone, error) {
+	if c.mapping.Zones.List == nil {
+		zones := make([]Zone, 0, len(c.mapping.Zones.Static))
+		for _, name := range c.mapping.Zones.Static {
+			zones = append(zones, Zone{ID: name, Name: name})
+		}
+		return zones, nil
+	}
+
+	items, err := c.list(ctx, *c.mapping.Zones.List, Zone{})
+	if err != nil {
+		return nil, err
+	}
+	zones := make([]Zone, 0, len(items))
+	for _, item := range items {
+		zones = append(zones, Zone{
+			ID:   stringField(item, c.mapping.Zones.Fields["id"]),
+			Name: strings.TrimSuffix(stringField(item, c.mapping.Zones.Fields["name"]), "."),
+		})
+	}
+	return zones, nil
+}
+
+func (c *client) ListRecords(ctx context.Context, zone Zone) ([]Record, error) {
+	items, err := c.list(ctx, c.mapping.Records.List, zone)
+	if err != nil {
+		return nil, err
+	}
+	fields := c.mapping.Records.Fields
+	records := make([]Record, 0, len(items))
+	for _, item := range items {
+		record := Record{
+			ID:     stringField(item, fields["id"]),
+			Name:   c.fqdn(stringField(item, fields["name"]), zone),
+			Type:   strings.ToUpper(stringField(item, fields["type"])),
+			Target: stringField(item, fields["target"]),
+		}
+		if fields["ttl"] != "" {
+			record.TTL, _ = strconv.Atoi(stringField(item, fields["ttl"]))
+		}
+		records = append(records, record)
+	}
+	return records, nil
+}
+
+func (c *client) CreateRecord(ctx context.Context, zone Zone, record Record) error {
+	_, err := c.do(ctx, c.mapping.Records.Create, zone, "", c.body(zone, record))
+	return err
+}
+
+func (c *client) UpdateRecord(ctx context.Context, zone Zone, record Record) (bool, error) {
+	if c.mapping.Records.Update == nil {
+		return false, nil
+	}
+	_, err := c.do(ctx, *c.mapping.Records.Update, zone, record.ID, c.body(zone, record))
+	return true, err
+}
+
+func (c *client) DeleteRecord(ctx context.Context, zone Zone, record Record) error {
+	_, err := c.do(ctx, c.mapping.Records.Delete, zone, record.ID, nil)
+	return err
+}
+
+// body returns the JSON representation of a record, without its ID.
+func (c *client) body(zone Zone, record Record) map[string]any {
+	fields := c.mapping.Records.Fields
+	body := map[string]any{}
+	setField(body, fields["name"], c.name(record.Name, zone))
+	setField(body, fields["type"], record.Type)
+	setField(body, fields["target"], record.Target)
+	if fields["ttl"] != "" && record.TTL > 0 {
+		setField(body, fields["ttl"], record.TTL)
+	}
+	return body
+}
+
+// fqdn returns the fully qualified name of a record from the name given by the API.
+func (c *client) fqdn(name string, zone Zone) string {
+	name = strings.TrimSuffix(name, ".")
+	if c.mapping.Records.NameFormat != nameFormatRelative {
+		return name
+	}
+	if name == "" || name == c.mapping.Records.ApexName {
+		return zone.Name
+	}
+	return name + "." + zone.Name
+}
+
+// name returns the name of a record as expected by the API.
+func (c *client) name(fqdn string, zone Zone) string {
+	if c.mapping.Records.NameFormat != nameFormatRelative {
+		return fqdn
+	}
+	if fqdn == zone.Name {
+		return c.mapping.Records.ApexName
+	}
+	return strings.TrimSuffix(fqdn, "."+zone.Name)
+}
+
+// getField returns the value at a dot separated path of a JSON value, the value itself for an empty path.
+func getField(value any, path string) (any, bool) {
+	if path == "" {
+		return value, true
+	}
+	for _, key := range strings.Split(path, ".") {
+		object, ok := value.(map[string]any)
+		if !ok {
+			return nil, false
+		}
+		if value, ok = object[key]; !ok {
+			return nil, false
+		}
+	}
+	return value, true
+}
+
+func stringField(object map[string]any, path string) string {
+	value, ok := getField(object, path)
+	if !ok || value == nil {
+		return ""
+	}
+	if s, ok := value.(string); ok {
+		return s
+	}
+	return fmt.Sprint(value)
+}
+
+// setField sets the value at a dot separated path of a JSON object, creating the intermediate objects.
+func setField(object map[string]any, path string, value any) {
+	keys := strings.Split(path, ".")
+	for _, key := range keys[:len(keys)-1] {
+		child, ok := object[key].(map[string]any)
+		if !ok {
+			child = map[string]any{}
+			object[key] = child
+		}
+		object = child
+	}
+	object[keys[len(keys)-1]] = value
+}
@@ -0,0 +1,120 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package rest
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestClient(t *testing.T) {
+	var requests []string
+	var bodies []map[string]any
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "Bearer secret", r.Header.Get("Authorization"))
+		requests = append(requests, r.Method+" "+r.URL.Path)
+		if data, _ := io.ReadAll(r.Body); len(data) > 0 {
+			var body map[string]any
+			require.NoError(t, json.Unmarshal(data, &body))
+			bodies = append(bodies, body)
+		}
+		switch r.Method + " " + r.URL.Path {
+		case "GET /zones":
+			_, _ = w.Write([]byte(`{"data":[{"uuid":"z1","domain":"example.com."}]}`))
+		case "GET /zones/z1/records":
+			_, _ = w.Write([]byte(`[
+				{"id":1,"name":"@","type":"a","ttl":300,"data":{"value":"1.2.3.4"}},
+				{"id":2,"name":"www","type":"CNAME","data":{"value":"example.com"}}
+			]`))
+		case "DELETE /zones/z1/records/3":
+			w.WriteHeader(http.StatusNotFound)
+			_, _ = w.Write([]byte(`record not found`))
+		}
+	}))
+	defer server.Close()
+
+	mapping := &Mapping{
+		BaseURL: server.URL,
+		Headers: map[string]string{"Authorization": "Bearer secret"},
+		Zones: ZonesMapping{
+			List:   &Request{Method: http.MethodGet, Path: "/zones", ItemsPath: "data"},
+			Fields: map[string]string{"id": "uuid", "name": "domain"},
+		},
+		Records: RecordsMapping{
+			List:       Request{Method: http.MethodGet, Path: "/zones/{zone}/records"},
+			Create:     Request{Method: http.MethodPost, Path: "/zones/{zone}/records"},
+			Update:     &Request{Method: http.MethodPatch, Path: "/zones/{zone}/records/{id}"},
+			Delete:     Request{Method: http.MethodDelete, Path: "/zones/{zone}/records/{id}"},
+			Fields:     map[string]string{"id": "id", "name": "name", "type": "type", "ttl": "ttl", "target": "data.value"},
+			NameFormat: nameFormatRelative,
+			ApexName:   "@",
+		},
+	}
+	c := &client{mapping: mapping, httpClient: server.Client()}
+
+	zones, err := c.ListZones(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, []Zone{{ID: "z1", Name: "example.com"}}, zones)
+
+	records, err := c.ListRecords(context.Background(), zones[0])
+	require.NoError(t, err)
+	assert.Equal(t, []Record{
+		{ID: "1", Name: "example.com", Type: "A", TTL: 300, Target: "1.2.3.4"},
+		{ID: "2", Name: "www.example.com", Type: "CNAME", Target: "example.com"},
+	}, records)
+
+	require.NoError(t, c.CreateRecord(context.Background(), zones[0], Record{Name: "new.example.com", Type: "A", TTL: 60, Target: "4.3.2.1"}))
+	updated, err := c.UpdateRecord(context.Background(), zones[0], Record{ID: "1", Name: "example.com", Type: "A", TTL: 600, Target: "1.2.3.4"})
+	require.NoError(t, err)
+	assert.True(t, updated)
+	assert.Equal(t, []map[string]any{
+		{"name": "new", "type": "A", "ttl": float64(60), "data": map[string]any{"value": "4.3.2.1"}},
+		{"name": "@", "type": "A", "ttl": float64(600), "data": map[string]any{"value": "1.2.3.4"}},
+	}, bodies)
+
+	err = c.DeleteRecord(context.Background(), zones[0], Record{ID: "3"})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "record not found")
+
+	assert.Equal(t, []string{
+		"GET /zones",
+		"GET /zones/z1/records",
+		"POST /zones/z1/records",
+		"PATCH /zones/z1/records/1",
+		"DELETE /zones/z1/records/3",
+	}, requests)
+
+	// Without an update request, records cannot be updated in place.
+	mapping.Records.Update = nil
+	updated, err = c.UpdateRecord(context.Background(), zones[0], Record{ID: "1"})
+	require.NoError(t, err)
+	assert.False(t, updated)
+}
+
+func TestClientStaticZones(t *testing.T) {
+	c := &client{mapping: &Mapping{Zones: ZonesMapping{Static: []string{"example.com", "example.org"}}}}
+	zones, err := c.ListZones(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, []Zone{{ID: "example.com", Name: "example.com"}, {ID: "example.org", Name: "example.org"}}, zones)
+}
@@ -0,0 +1,167 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package rest
+
+import (
+	"fmt"
+	"net/http"
+	"os"
+	"strings"
+
+	yaml "github.com/goccy/go-yaml"
+)
+
+const (
+	nameFormatFQDN     = "fqdn"
+	nameFormatRelative = "relative"
+)
+
+// Mapping describes a DNS API: the requests listing, creating, updating and deleting
+// records, and where the fields of records are found in their JSON representation.
+type Mapping struct {
+	// BaseURL is prepended to the path of every request.
+	BaseURL string `yaml:"baseURL"`
+	// Headers are sent with every request, e.g. for authentication.
+	// References to environment variables, like ${API_TOKEN}, are expanded.
+	Headers map[string]string `yaml:"headers"`
+	Zones   ZonesMapping      `yaml:"zones"`
+	Records RecordsMapping    `yaml:"records"`
+}
+
+// ZonesMapping describes how zones are discovered: either listed by a request, or
+// given statically when the API has no notion of zones.
+type ZonesMapping struct {
+	List   *Request          `yaml:"list"`
+	Fields map[string]string `yaml:"fields"`
+	// Static zone names, used as their IDs, when the API cannot list zones.
+	Static []string `yaml:"static"`
+}
+
+// RecordsMapping describes the requests on the records of a zone. Their paths can refer
+// to the ID and name of the zone as {zone} and {zoneName}, and to the ID of a record as {id}.
+// Records are updated by deleting and recreating them when no update request is given.
+type RecordsMapping struct {
+	List   Request  `yaml:"list"`
+	Create Request  `yaml:"create"`
+	Update *Request `yaml:"update"`
+	Delete Request  `yaml:"delete"`
+	// Fields maps the id, name, type, ttl and target of a record to the dot separated
+	// path of their JSON fields.
+	Fields map[string]string `yaml:"fields"`
+	// NameFormat is either fqdn, the default, or relative to the zone.
+	NameFormat string `yaml:"nameFormat"`
+	// ApexName is the relative name of records at the apex of a zone, "@" by default.
+	ApexName string `yaml:"apexName"`
+}
+
+// Request is an HTTP request of the API. ItemsPath is the dot separated path of the list
+// of items in the response of list requests, which is the response itself when empty.
+type Request struct {
+	Method    string `yaml:"method"`
+	Path      string `yaml:"path"`
+	ItemsPath string `yaml:"itemsPath"`
+}
+
+// LoadMapping reads and validates a mapping file.
+func LoadMapping(file string) (*Mapping, error) {
+	contents, err := os.ReadFile(file)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read mapping file %s: %w", file, err)
+	}
+	mapping := &Mapping{}
+	if err := yaml.Unmarshal(contents, mapping); err != nil {
+		return nil, fmt.Errorf("failed to parse mapping file %s: %w", file, err)
+	}
+	if err := mapping.validate(); err != nil {
+		return nil, fmt.Errorf("invalid mapping file %s: %w", file, err)
+	}
+	return mapping, nil
+}
+
+// validate checks the mapping is complete and fills in its defaults.
+func (m *Mapping) validate() error {
+	if m.BaseURL == "" {
+		return fmt.Errorf("baseURL is required")
+	}
+	m.BaseURL = strings.TrimSuffix(m.BaseURL, "/")
+	for name, value := range m.Headers {
+		m.Headers[name] = os.ExpandEnv(value)
+	}
+
+	if (m.Zones.List == nil) == (len(m.Zones.Static) == 0) {
+		return fmt.Errorf("exactly one of zones.list and zones.static is required")
+	}
+	if m.Zones.List != nil {
+		if err := m.Zones.List.validate("zones.list", http.MethodGet); err != nil {
+			return err
+		}
+		if m.Zones.Fields["name"] == "" {
+			return fmt.Errorf("zones.fields.name is required")
+		}
+		if m.Zones.Fields["id"] == "" {
+			m.Zones.Fields["id"] = m.Zones.Fields["name"]
+		}
+	}
+
+	for name, request := range map[string]*Request{
+		"records.list":   &m.Records.List,
+		"records.create": &m.Records.Create,
+		"records.update": m.Records.Update,
+		"records.delete": &m.Records.Delete,
+	} {
+		if request == nil {
+			continue
+		}
+		defaultMethod := map[string]string{
+			"records.list":   http.MethodGet,
+			"records.create": http.MethodPost,
+			"records.update": http.MethodPut,
+			"records.delete": http.MethodDelete,
+		}[name]
+		if err := request.validate(name, defaultMethod); err != nil {
+			return err
+		}
+	}
+	for _, field := range []string{"id", "name", "type", "target"} {
+		if m.Records.Fields[field] == "" {
+			return fmt.Errorf("records.fields.%s is required", field)
+		}
+	}
+
+	switch m.Records.NameFormat {
+	case "":
+		m.Records.NameFormat = nameFormatFQDN
+	case nameFormatFQDN, nameFormatRelative:
+	default:
+		return fmt.Errorf("records.nameFormat must be %s or %s", nameFormatFQDN, nameFormatRelative)
+	}
+	if m.Records.ApexName == "" {
+		m.Records.ApexName = "@"
+	}
+	return nil
+}
+
+func (r *Request) validate(name, defaultMethod string) error {
+	if r.Path == "" {
+		return fmt.Errorf("%s.path is required", name)
+	}
+	if r.Method == "" {
+		r.Method = defaultMethod
+	}
+	r.Method = strings.ToUpper(r.Method)
+	return nil
+}
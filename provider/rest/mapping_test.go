@@ -0,0 +1,125 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package rest
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func writeMapping(t *testing.T, contents string) string {
+	t.Helper()
+	file := filepath.Join(t.TempDir(), "mapping.yaml")
+	require.NoError(t, os.WriteFile(file, []byte(contents), 0o600))
+	return file
+}
+
+func TestLoadMapping(t *testing.T) {
+	t.Setenv("DNS_API_TOKEN", "secret")
+	mapping, err := LoadMapping(writeMapping(t, `
+baseURL: https://dns.example.com/api/
+headers:
+  Authorization: Bearer ${DNS_API_TOKEN}
+zones:
+  list:
+    path: /zones
+    itemsPath: data
+  fields:
+    name: domain
+records:
+  list:
+    path: /zones/{zone}/records
+  create:
+    path: /zones/{zone}/records
+  update:
+    method: patch
+    path: /zones/{zone}/records/{id}
+  delete:
+    path: /zones/{zone}/records/{id}
+  fields:
+    id: id
+    name: name
+    type: type
+    ttl: ttl
+    target: data.value
+  nameFormat: relative
+`))
+	require.NoError(t, err)
+
+	assert.Equal(t, "https://dns.example.com/api", mapping.BaseURL)
+	assert.Equal(t, map[string]string{"Authorization": "Bearer secret"}, mapping.Headers)
+	assert.Equal(t, "GET", mapping.Zones.List.Method)
+	assert.Equal(t, "domain", mapping.Zones.Fields["id"])
+	assert.Equal(t, "POST", mapping.Records.Create.Method)
+	assert.Equal(t, "PATCH", mapping.Records.Update.Method)
+	assert.Equal(t, "DELETE", mapping.Records.Delete.Method)
+	assert.Equal(t, "@", mapping.Records.ApexName)
+}
+
+func TestLoadMappingInvalid(t *testing.T) {
+	const records = `
+records:
+  list: {path: /records}
+  create: {path: /records}
+  delete: {path: "/records/{id}"}
+  fields: {id: id, name: name, type: type, target: content}
+`
+	for _, tc := range []struct {
+		title    string
+		contents string
+		err      string
+	}{
+		{
+			title:    "missing base URL",
+			contents: "zones: {static: [example.com]}" + records,
+			err:      "baseURL is required",
+		},
+		{
+			title:    "missing zones",
+			contents: "baseURL: https://dns.example.com" + records,
+			err:      "exactly one of zones.list and zones.static is required",
+		},
+		{
+			title:    "missing zone name field",
+			contents: "baseURL: https://dns.example.com\nzones: {list: {path: /zones}}" + records,
+			err:      "zones.fields.name is required",
+		},
+		{
+			title:    "missing record field",
+			contents: "baseURL: https://dns.example.com\nzones: {static: [example.com]}\nrecords:\n  list: {path: /records}\n  create: {path: /records}\n  delete: {path: /records}\n  fields: {id: id, name: name, type: type}\n",
+			err:      "records.fields.target is required",
+		},
+		{
+			title:    "invalid name format",
+			contents: "baseURL: https://dns.example.com\nzones: {static: [example.com]}" + records + "  nameFormat: short\n",
+			err:      "records.nameFormat must be fqdn or relative",
+		},
+	} {
+		t.Run(tc.title, func(t *testing.T) {
+			_, err := LoadMapping(writeMapping(t, tc.contents))
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tc.err)
+		})
+	}
+
+	_, err := LoadMapping(filepath.Join(t.TempDir(), "missing.yaml"))
+	require.Error(t, err)
+}
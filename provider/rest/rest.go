@@ -0,0 +1,292 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package rest
+
+import (
+	"context"
+	"fmt"
+	"slices"
+
+	log "github.com/sirupsen/logrus"
+
+	"sigs.k8s.io/external-dns/endpoint"
+	"sigs.k8s.io/external-dns/plan"
+	"sigs.k8s.io/external-dns/provider"
+)
+
+// RESTProvider is an implementation of Provider for DNS APIs described by a mapping file.
+type RESTProvider struct {
+	provider.BaseProvider
+	client       Client
+	domainFilter endpoint.DomainFilter
+	dryRun       bool
+}
+
+// NewRESTProvider initializes a new Provider for the DNS API described by the mapping file.
+func NewRESTProvider(mappingFile string, domainFilter endpoint.DomainFilter, dryRun bool) (*RESTProvider, error) {
+	if mappingFile == "" {
+		return nil, fmt.Errorf("no mapping file provided, you must set the --rest-mapping-file flag")
+	}
+	mapping, err := LoadMapping(mappingFile)
+	if err != nil {
+		return nil, err
+	}
+
+	return &RESTProvider{
+		client:       NewClient(mapping),
+		domainFilter: domainFilter,
+		dryRun:       dryRun,
+	}, nil
+}
+
+// Records returns the list of records in all managed zones.
+func (p *RESTProvider) Records(ctx context.Context) ([]*endpoint.Endpoint, error) {
+	zones, err := p.zones(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var endpoints []*endpoint.Endpoint
+	for _, zone := range zones {
+		records, err := p.client.ListRecords(ctx, zone)
+		if err != nil {
+			return nil, provider.NewSoftError(fmt.Errorf("failed to list records of zone %s: %w", zone.Name, err))
+		}
+
+		byKey := map[endpoint.EndpointKey]*endpoint.Endpoint{}
+		for _, record := range records {
+			if !p.SupportedRecordType(record.Type) {
+				continue
+			}
+			key := endpoint.EndpointKey{DNSName: record.Name, RecordType: record.Type}
+			if ep, ok := byKey[key]; ok {
+				ep.Targets = append(ep.Targets, record.Target)
+				continue
+			}
+			ep := endpoint.NewEndpointWithTTL(key.DNSName, key.RecordType, endpoint.TTL(record.TTL), record.Target)
+			byKey[key] = ep
+			endpoints = append(endpoints, ep)
+		}
+	}
+
+	return endpoints, nil
+}
+
+// SupportedRecordType returns true if the record type is supported by the provider
+func (p *RESTProvider) SupportedRecordType(recordType string) bool {
+	switch recordType {
+	case endpoint.RecordTypeMX:
+		return true
+	default:
+		return provider.SupportedRecordType(recordType)
+	}
+}
+
+// ApplyChanges applies the given changes, one API call per record.
+func (p *RESTProvider) ApplyChanges(ctx context.Context, changes *plan.Changes) error {
+	zones, err := p.zones(ctx)
+	if err != nil {
+		return err
+	}
+	zoneNameIDMapper := p.NewZoneFinder()
+	zonesByID := map[string]Zone{}
+	for _, zone := range zones {
+		zoneNameIDMapper.Add(zone.ID, zone.Name)
+		zonesByID[zone.ID] = zone
+	}
+
+	recordsByZone := map[string][]Record{}
+	existing := func(zone Zone) ([]Record, error) {
+		if records, ok := recordsByZone[zone.ID]; ok {
+			return records, nil
+		}
+		records, err := p.client.ListRecords(ctx, zone)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list records of zone %s: %w", zone.Name, err)
+		}
+		recordsByZone[zone.ID] = records
+		return records, nil
+	}
+	findZone := func(ep *endpoint.Endpoint) (Zone, bool) {
+		zoneID, _ := zoneNameIDMapper.FindZone(ep.DNSName)
+		if zoneID == "" {
+			log.Debugf("Skipping record %s because no zone matching record DNS Name was detected", ep.DNSName)
+			return Zone{}, false
+		}
+		return zonesByID[zoneID], true
+	}
+
+	for _, ep := range changes.Delete {
+		zone, ok := findZone(ep)
+		if !ok {
+			continue
+		}
+		records, err := existing(zone)
+		if err != nil {
+			return provider.NewSoftError(err)
+		}
+		for _, record := range matchingRecords(records, ep) {
+			if slices.Contains(ep.Targets, record.Target) {
+				if err := p.deleteRecord(ctx, zone, record); err != nil {
+					return err
+				}
+			}
+		}
+	}
+
+	oldByKey := map[endpoint.EndpointKey]*endpoint.Endpoint{}
+	for _, ep := range changes.UpdateOld {
+		oldByKey[ep.Key()] = ep
+	}
+	for _, ep := range changes.UpdateNew {
+		zone, ok := findZone(ep)
+		if !ok {
+			continue
+		}
+		records, err := existing(zone)
+		if err != nil {
+			return provider.NewSoftError(err)
+		}
+		current := map[string]Record{}
+		for _, record := range matchingRecords(records, ep) {
+			current[record.Target] = record
+		}
+
+		for _, t := range ep.Targets {
+			desired := newRecord(ep, t)
+			if record, ok := current[t]; ok {
+				delete(current, t)
+				if record.TTL == desired.TTL || !ep.RecordTTL.IsConfigured() {
+					continue
+				}
+				desired.ID = record.ID
+				if err := p.updateRecord(ctx, zone, record, desired); err != nil {
+					return err
+				}
+				continue
+			}
+			if err := p.createRecord(ctx, zone, desired); err != nil {
+				return err
+			}
+		}
+
+		// Only delete the targets ExternalDNS used to manage.
+		if old, ok := oldByKey[ep.Key()]; ok {
+			for t, record := range current {
+				if slices.Contains(old.Targets, t) {
+					if err := p.deleteRecord(ctx, zone, record); err != nil {
+						return err
+					}
+				}
+			}
+		}
+	}
+
+	for _, ep := range changes.Create {
+		zone, ok := findZone(ep)
+		if !ok {
+			continue
+		}
+		for _, t := range ep.Targets {
+			if err := p.createRecord(ctx, zone, newRecord(ep, t)); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+func (p *RESTProvider) zones(ctx context.Context) ([]Zone, error) {
+	all, err := p.client.ListZones(ctx)
+	if err != nil {
+		return nil, provider.NewSoftError(fmt.Errorf("failed to list zones: %w", err))
+	}
+
+	var zones []Zone
+	for _, zone := range all {
+		if p.domainFilter.Match(zone.Name) {
+			zones = append(zones, zone)
+		}
+	}
+	return zones, nil
+}
+
+func (p *RESTProvider) createRecord(ctx context.Context, zone Zone, record Record) error {
+	log.Infof("Creating %s record %s with target %s in zone %s", record.Type, record.Name, record.Target, zone.Name)
+	if p.dryRun {
+		return nil
+	}
+	if err := p.client.CreateRecord(ctx, zone, record); err != nil {
+		return provider.NewSoftError(fmt.Errorf("failed to create %s record %s: %w", record.Type, record.Name, err))
+	}
+	return nil
+}
+
+// updateRecord updates a record in place, or deletes and recreates it when the API cannot update records.
+func (p *RESTProvider) updateRecord(ctx context.Context, zone Zone, current, record Record) error {
+	log.Infof("Updating %s record %s with target %s in zone %s", record.Type, record.Name, record.Target, zone.Name)
+	if p.dryRun {
+		return nil
+	}
+	updated, err := p.client.UpdateRecord(ctx, zone, record)
+	if err != nil {
+		return provider.NewSoftError(fmt.Errorf("failed to update %s record %s: %w", record.Type, record.Name, err))
+	}
+	if updated {
+		return nil
+	}
+	if err := p.client.DeleteRecord(ctx, zone, current); err != nil {
+		return provider.NewSoftError(fmt.Errorf("failed to delete %s record %s: %w", record.Type, record.Name, err))
+	}
+	record.ID = ""
+	if err := p.client.CreateRecord(ctx, zone, record); err != nil {
+		return provider.NewSoftError(fmt.Errorf("failed to create %s record %s: %w", record.Type, record.Name, err))
+	}
+	return nil
+}
+
+func (p *RESTProvider) deleteRecord(ctx context.Context, zone Zone, record Record) error {
+	log.Infof("Deleting %s record %s with target %s in zone %s", record.Type, record.Name, record.Target, zone.Name)
+	if p.dryRun {
+		return nil
+	}
+	if err := p.client.DeleteRecord(ctx, zone, record); err != nil {
+		return provider.NewSoftError(fmt.Errorf("failed to delete %s record %s: %w", record.Type, record.Name, err))
+	}
+	return nil
+}
+
+// matchingRecords returns the records with the name and type of the endpoint.
+func matchingRecords(records []Record, ep *endpoint.Endpoint) []Record {
+	var matched []Record
+	for _, record := range records {
+		if record.Type == ep.RecordType && record.Name == ep.DNSName {
+			matched = append(matched, record)
+		}
+	}
+	return matched
+}
+
+// newRecord returns the record for one of the targets of an endpoint.
+func newRecord(ep *endpoint.Endpoint, t string) Record {
+	record := Record{Name: ep.DNSName, Type: ep.RecordType, Target: t}
+	if ep.RecordTTL.IsConfigured() {
+		record.TTL = int(ep.RecordTTL)
+	}
+	return record
+}
@@ -0,0 +1,186 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package rest
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"sigs.k8s.io/external-dns/endpoint"
+	"sigs.k8s.io/external-dns/internal/testutils"
+	"sigs.k8s.io/external-dns/plan"
+)
+
+type mockClient struct {
+	zones     []Zone
+	records   map[string][]Record
+	canUpdate bool
+	created   []Record
+	updated   []Record
+	deleted   []string
+}
+
+func (m *mockClient) ListZones(_ context.Context) ([]Zone, error) {
+	return m.zones, nil
+}
+
+func (m *mockClient) ListRecords(_ context.Context, zone Zone) ([]Record, error) {
+	return m.records[zone.ID], nil
+}
+
+func (m *mockClient) CreateRecord(_ context.Context, _ Zone, record Record) error {
+	m.created = append(m.created, record)
+	return nil
+}
+
+func (m *mockClient) UpdateRecord(_ context.Context, _ Zone, record Record) (bool, error) {
+	if !m.canUpdate {
+		return false, nil
+	}
+	m.updated = append(m.updated, record)
+	return true, nil
+}
+
+func (m *mockClient) DeleteRecord(_ context.Context, _ Zone, record Record) error {
+	m.deleted = append(m.deleted, record.ID)
+	return nil
+}
+
+func newMockClient() *mockClient {
+	return &mockClient{
+		zones: []Zone{{ID: "z1", Name: "example.com"}, {ID: "z2", Name: "example.org"}},
+		records: map[string][]Record{
+			"z1": {
+				{ID: "1", Name: "example.com", Type: "A", TTL: 3600, Target: "1.2.3.4"},
+				{ID: "2", Name: "www.example.com", Type: "A", TTL: 300, Target: "1.2.3.4"},
+				{ID: "3", Name: "www.example.com", Type: "A", TTL: 300, Target: "5.6.7.8"},
+				{ID: "4", Name: "example.com", Type: "MX", TTL: 3600, Target: "10 mail.example.com"},
+				{ID: "5", Name: "example.com", Type: "SOA", TTL: 3600, Target: "ns1.example.com"},
+			},
+			"z2": {
+				{ID: "6", Name: "www.example.org", Type: "A", TTL: 300, Target: "9.9.9.9"},
+			},
+		},
+		canUpdate: true,
+	}
+}
+
+func TestRESTRecords(t *testing.T) {
+	p := &RESTProvider{client: newMockClient(), domainFilter: endpoint.NewDomainFilter([]string{"example.com"})}
+
+	records, err := p.Records(context.Background())
+	require.NoError(t, err)
+
+	expected := []*endpoint.Endpoint{
+		endpoint.NewEndpointWithTTL("example.com", endpoint.RecordTypeA, 3600, "1.2.3.4"),
+		endpoint.NewEndpointWithTTL("www.example.com", endpoint.RecordTypeA, 300, "1.2.3.4", "5.6.7.8"),
+		endpoint.NewEndpointWithTTL("example.com", endpoint.RecordTypeMX, 3600, "10 mail.example.com"),
+	}
+	assert.True(t, testutils.SameEndpoints(records, expected), "actual and expected endpoints don't match. %s:%s", records, expected)
+}
+
+func TestRESTApplyChanges(t *testing.T) {
+	for _, tc := range []struct {
+		title     string
+		canUpdate bool
+		created   []Record
+		updated   []Record
+		deleted   []string
+	}{
+		{
+			title:     "in place updates",
+			canUpdate: true,
+			created: []Record{
+				{Name: "www.example.com", Type: "A", TTL: 900, Target: "4.3.2.1"},
+				{Name: "new.example.com", Type: "CNAME", Target: "www.example.com"},
+			},
+			updated: []Record{{ID: "2", Name: "www.example.com", Type: "A", TTL: 900, Target: "1.2.3.4"}},
+			deleted: []string{"1", "3"},
+		},
+		{
+			title:     "updates by recreation",
+			canUpdate: false,
+			created: []Record{
+				{Name: "www.example.com", Type: "A", TTL: 900, Target: "1.2.3.4"},
+				{Name: "www.example.com", Type: "A", TTL: 900, Target: "4.3.2.1"},
+				{Name: "new.example.com", Type: "CNAME", Target: "www.example.com"},
+			},
+			deleted: []string{"1", "2", "3"},
+		},
+	} {
+		t.Run(tc.title, func(t *testing.T) {
+			client := newMockClient()
+			client.canUpdate = tc.canUpdate
+			p := &RESTProvider{client: client, domainFilter: endpoint.NewDomainFilter([]string{"example.com"})}
+
+			err := p.ApplyChanges(context.Background(), &plan.Changes{
+				Create: []*endpoint.Endpoint{
+					endpoint.NewEndpoint("new.example.com", endpoint.RecordTypeCNAME, "www.example.com"),
+					endpoint.NewEndpoint("www.example.org", endpoint.RecordTypeA, "1.1.1.1"),
+				},
+				UpdateOld: []*endpoint.Endpoint{
+					endpoint.NewEndpointWithTTL("www.example.com", endpoint.RecordTypeA, 300, "1.2.3.4", "5.6.7.8"),
+				},
+				UpdateNew: []*endpoint.Endpoint{
+					endpoint.NewEndpointWithTTL("www.example.com", endpoint.RecordTypeA, 900, "1.2.3.4", "4.3.2.1"),
+				},
+				Delete: []*endpoint.Endpoint{
+					endpoint.NewEndpointWithTTL("example.com", endpoint.RecordTypeA, 3600, "1.2.3.4"),
+				},
+			})
+			require.NoError(t, err)
+
+			assert.Equal(t, tc.created, client.created)
+			assert.Equal(t, tc.updated, client.updated)
+			assert.ElementsMatch(t, tc.deleted, client.deleted)
+		})
+	}
+}
+
+func TestRESTApplyChangesDryRun(t *testing.T) {
+	client := newMockClient()
+	p := &RESTProvider{client: client, domainFilter: endpoint.NewDomainFilter([]string{"example.com"}), dryRun: true}
+
+	err := p.ApplyChanges(context.Background(), &plan.Changes{
+		Create: []*endpoint.Endpoint{endpoint.NewEndpoint("new.example.com", endpoint.RecordTypeA, "1.2.3.4")},
+		Delete: []*endpoint.Endpoint{endpoint.NewEndpoint("example.com", endpoint.RecordTypeA, "1.2.3.4")},
+	})
+	require.NoError(t, err)
+	assert.Empty(t, client.created)
+	assert.Empty(t, client.deleted)
+}
+
+func TestNewRESTProvider(t *testing.T) {
+	_, err := NewRESTProvider("", endpoint.NewDomainFilter(nil), false)
+	require.Error(t, err)
+
+	file := writeMapping(t, `
+baseURL: https://dns.example.com
+zones:
+  static: [example.com]
+records:
+  list: {path: /records}
+  create: {path: /records}
+  delete: {path: "/records/{id}"}
+  fields: {id: id, name: name, type: type, target: content}
+`)
+	_, err = NewRESTProvider(file, endpoint.NewDomainFilter(nil), false)
+	require.NoError(t, err)
+}
@@ -36,6 +36,7 @@ import (
 	log "github.com/sirupsen/logrus"
 
 	"sigs.k8s.io/external-dns/endpoint"
+	"sigs.k8s.io/external-dns/pkg/secrets"
 	"sigs.k8s.io/external-dns/pkg/tlsutils"
 	"sigs.k8s.io/external-dns/plan"
 	"sigs.k8s.io/external-dns/provider"
@@ -44,6 +45,8 @@ import (
 const (
 	// maximum time DNS client can be off from server for an update to succeed
 	clockSkew = 300
+	// tsigSecretName is the name the TSIG secret can be configured as a secret under.
+	tsigSecretName = "EXTERNAL_DNS_RFC2136_TSIG_SECRET"
 )
 
 // rfc2136 provider type
@@ -163,6 +166,9 @@ func NewRfc2136Provider(hosts []string, port int, zoneNames []string, insecure b
 	}
 
 	if !insecure {
+		if value, ok := secrets.Lookup(context.Background(), tsigSecretName); ok {
+			secret = value
+		}
 		r.tsigKeyName = dns.Fqdn(keyName)
 		r.tsigSecret = secret
 		r.tsigSecretAlg = secretAlgChecked
@@ -195,8 +201,19 @@ func (r *rfc2136Provider) KeyData(nameserver string) (keyName string, handle *gs
 	return keyName, handle, nil
 }
 
+// refreshTSIGSecret picks up the TSIG secret when configured as a secret, which may have been rotated.
+func (r *rfc2136Provider) refreshTSIGSecret(ctx context.Context) {
+	if r.tsigKeyName == "" {
+		return
+	}
+	if value, ok := secrets.Lookup(ctx, tsigSecretName); ok {
+		r.tsigSecret = value
+	}
+}
+
 // Records returns the list of records.
 func (r *rfc2136Provider) Records(ctx context.Context) ([]*endpoint.Endpoint, error) {
+	r.refreshTSIGSecret(ctx)
 	rrs, err := r.List()
 	if err != nil {
 		return nil, err
@@ -227,7 +244,8 @@ OuterLoop:
 			rrValues = []string{rr.(*dns.AAAA).AAAA.String()}
 			rrType = "AAAA"
 		case dns.TypeTXT:
-			rrValues = (rr.(*dns.TXT).Txt)
+			// the character strings of a record make up a single value
+			rrValues = []string{strings.Join(rr.(*dns.TXT).Txt, "")}
 			rrType = "TXT"
 		case dns.TypeNS:
 			rrValues = []string{rr.(*dns.NS).Ns}
@@ -360,6 +378,7 @@ func (r *rfc2136Provider) GenerateReverseRecord(ip string, hostname string) []*e
 
 // ApplyChanges applies a given set of changes in a given zone.
 func (r *rfc2136Provider) ApplyChanges(ctx context.Context, changes *plan.Changes) error {
+	r.refreshTSIGSecret(ctx)
 	log.Debugf("ApplyChanges (Create: %d, UpdateOld: %d, UpdateNew: %d, Delete: %d)", len(changes.Create), len(changes.UpdateOld), len(changes.UpdateNew), len(changes.Delete))
 
 	var errs []error
@@ -494,6 +513,21 @@ func (r *rfc2136Provider) UpdateRecord(m *dns.Msg, oldEp *endpoint.Endpoint, new
 	return r.AddRecord(m, newEp)
 }
 
+// TXTEncoding reports that the targets of TXT records are taken and returned as values without quotes, split
+// into character strings when sent.
+func (r *rfc2136Provider) TXTEncoding() string {
+	return provider.TXTEncodingRaw
+}
+
+// rrTarget returns a target in the presentation form of the records of its type, TXT targets being quoted
+// and split into character strings whether they are given quoted or not.
+func rrTarget(recordType, target string) string {
+	if recordType == endpoint.RecordTypeTXT {
+		return endpoint.NewTXTTarget(target).String()
+	}
+	return target
+}
+
 func (r *rfc2136Provider) AddRecord(m *dns.Msg, ep *endpoint.Endpoint) error {
 	log.Debugf("AddRecord.ep=%s", ep)
 
@@ -503,7 +537,7 @@ func (r *rfc2136Provider) AddRecord(m *dns.Msg, ep *endpoint.Endpoint) error {
 	}
 
 	for _, target := range ep.Targets {
-		newRR := fmt.Sprintf("%s %d %s %s", ep.DNSName, ttl, ep.RecordType, target)
+		newRR := fmt.Sprintf("%s %d %s %s", ep.DNSName, ttl, ep.RecordType, rrTarget(ep.RecordType, target))
 		log.Infof("Adding RR: %s", newRR)
 
 		rr, err := dns.NewRR(newRR)
@@ -520,7 +554,7 @@ func (r *rfc2136Provider) AddRecord(m *dns.Msg, ep *endpoint.Endpoint) error {
 func (r *rfc2136Provider) RemoveRecord(m *dns.Msg, ep *endpoint.Endpoint) error {
 	log.Debugf("RemoveRecord.ep=%s", ep)
 	for _, target := range ep.Targets {
-		newRR := fmt.Sprintf("%s %d %s %s", ep.DNSName, ep.RecordTTL, ep.RecordType, target)
+		newRR := fmt.Sprintf("%s %d %s %s", ep.DNSName, ep.RecordTTL, ep.RecordType, rrTarget(ep.RecordType, target))
 		log.Infof("Removing RR: %s", newRR)
 
 		rr, err := dns.NewRR(newRR)
@@ -22,6 +22,7 @@ import (
 	"fmt"
 	"math/rand"
 	"os"
+	"path/filepath"
 	"regexp"
 	"sort"
 	"strconv"
@@ -32,8 +33,10 @@ import (
 	"github.com/miekg/dns"
 	log "github.com/sirupsen/logrus"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 
 	"sigs.k8s.io/external-dns/endpoint"
+	"sigs.k8s.io/external-dns/pkg/secrets"
 	"sigs.k8s.io/external-dns/plan"
 	"sigs.k8s.io/external-dns/provider"
 )
@@ -276,6 +279,23 @@ func TestRfc2136GetRecordsMultipleTargets(t *testing.T) {
 	assert.Equal(t, 0, len(recs[0].ProviderSpecific), "expected no provider specific config")
 }
 
+func TestRfc2136TSIGSecretFromSecrets(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "tsig")
+	require.NoError(t, os.WriteFile(path, []byte("c2VjcmV0LTE="), 0o600))
+	require.NoError(t, secrets.Configure(context.Background(), []string{tsigSecretName + "=file:" + path}, time.Nanosecond))
+	t.Cleanup(func() { require.NoError(t, secrets.Configure(context.Background(), nil, 0)) })
+
+	stub := newStub()
+	p, err := createRfc2136StubProvider(stub)
+	require.NoError(t, err)
+	assert.Equal(t, "c2VjcmV0LTE=", p.(*rfc2136Provider).tsigSecret, "the secret replaces the flag")
+
+	require.NoError(t, os.WriteFile(path, []byte("c2VjcmV0LTI="), 0o600))
+	_, err = p.Records(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, "c2VjcmV0LTI=", p.(*rfc2136Provider).tsigSecret, "the rotated secret is picked up")
+}
+
 func TestRfc2136PTRCreation(t *testing.T) {
 	stub := newStub()
 	provider, err := createRfc2136StubProviderWithReverse(stub)
@@ -926,3 +946,29 @@ func TestRandomLoadBalancing(t *testing.T) {
 
 	assert.Greater(t, len(nameserverCounts), 1, "Expected multiple nameservers to be used in random strategy")
 }
+
+func TestRfc2136LongTXTRecords(t *testing.T) {
+	stub := newStub()
+	require.NoError(t, stub.setOutput([]string{`v1.foo.com 3600 TXT "v=DKIM1; " "p=MIIB"`}))
+	provider, err := createRfc2136StubProvider(stub)
+	require.NoError(t, err)
+
+	// the character strings of a record make up a single target
+	recs, err := provider.Records(context.Background())
+	require.NoError(t, err)
+	require.Len(t, recs, 1)
+	assert.Equal(t, endpoint.Targets{"v=DKIM1; p=MIIB"}, recs[0].Targets)
+
+	// long targets are split into character strings, whether they are quoted or not
+	long := strings.Repeat("k", 300)
+	err = provider.ApplyChanges(context.Background(), &plan.Changes{Create: []*endpoint.Endpoint{
+		endpoint.NewEndpoint("v2.foo.com", endpoint.RecordTypeTXT, long),
+		endpoint.NewEndpoint("v3.foo.com", endpoint.RecordTypeTXT, `"`+long+`"`),
+	}})
+	require.NoError(t, err)
+	require.NotEmpty(t, stub.createMsgs)
+	require.Len(t, stub.createMsgs[0].Ns, 2)
+	for _, rr := range stub.createMsgs[0].Ns {
+		assert.Equal(t, []string{long[:255], long[255:]}, rr.(*dns.TXT).Txt)
+	}
+}
@@ -119,6 +119,14 @@ func (p *ScalewayProvider) AdjustEndpoints(endpoints []*endpoint.Endpoint) ([]*e
 	return eps, nil
 }
 
+// PropertyComparator only compares the priority of the records, the only provider specific property they have.
+func (p *ScalewayProvider) PropertyComparator() plan.PropertyComparator {
+	return plan.PropertyRules{
+		Significant: []string{scalewayPriorityKey},
+		Defaults:    map[string]string{scalewayPriorityKey: fmt.Sprintf("%d", scalewayDefaultPriority)},
+	}.Comparator()
+}
+
 // Zones returns the list of hosted zones.
 func (p *ScalewayProvider) Zones(ctx context.Context) ([]*domain.DNSZone, error) {
 	res := []*domain.DNSZone{}
@@ -217,7 +225,7 @@ func (p *ScalewayProvider) generateApplyRequests(ctx context.Context, changes *p
 		return nil, err
 	}
 
-	zoneNameMapper := provider.ZoneIDName{}
+	zoneNameMapper := p.NewZoneFinder()
 	for _, zone := range dnsZones {
 		zoneName := getCompleteZoneName(zone)
 		zoneNameMapper.Add(zoneName, zoneName)
@@ -254,6 +254,14 @@ func TestScalewayProvider_AdjustEndpoints(t *testing.T) {
 	}
 }
 
+func TestScalewayProvider_PropertyComparator(t *testing.T) {
+	compare := (&ScalewayProvider{}).PropertyComparator()
+
+	assert.True(t, compare(scalewayPriorityKey, "", "0"))
+	assert.False(t, compare(scalewayPriorityKey, "0", "10"))
+	assert.True(t, compare("aws/weight", "", "10"))
+}
+
 func TestScalewayProvider_Zones(t *testing.T) {
 	mocked := mockScalewayDomain{nil}
 	provider := &ScalewayProvider{
@@ -26,7 +26,6 @@ import (
 
 	"sigs.k8s.io/external-dns/endpoint"
 	"sigs.k8s.io/external-dns/plan"
-	"sigs.k8s.io/external-dns/provider"
 )
 
 // DnsPod For Public Dns
@@ -144,7 +143,7 @@ func (p *TencentCloudProvider) applyChangesForDNS(changes *plan.Changes) error {
 		return err
 	}
 
-	zoneNameIDMapper := provider.ZoneIDName{}
+	zoneNameIDMapper := p.NewZoneFinder()
 	for _, recordsGroup := range recordsGroupMap {
 		if recordsGroup.Domain.DomainId != nil {
 			zoneNameIDMapper.Add(strconv.FormatUint(*recordsGroup.Domain.DomainId, 10), *recordsGroup.Domain.Name)
@@ -181,7 +180,7 @@ func (p *TencentCloudProvider) applyChangesForDNS(changes *plan.Changes) error {
 
 	// Apply Change Create
 	createEndpoints := make(map[string][]*endpoint.Endpoint)
-	for zoneId := range zoneNameIDMapper {
+	for zoneId := range zoneNameIDMapper.ZoneIDName {
 		createEndpoints[zoneId] = make([]*endpoint.Endpoint, 0)
 	}
 	for _, change := range [][]*endpoint.Endpoint{changes.Create, changes.UpdateNew} {
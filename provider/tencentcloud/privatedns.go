@@ -25,7 +25,6 @@ import (
 
 	"sigs.k8s.io/external-dns/endpoint"
 	"sigs.k8s.io/external-dns/plan"
-	"sigs.k8s.io/external-dns/provider"
 )
 
 // PrivateZone For Internal Dns
@@ -174,7 +173,7 @@ func (p *TencentCloudProvider) applyChangesForPrivateZone(changes *plan.Changes)
 		}
 	}
 
-	zoneNameIDMapper := provider.ZoneIDName{}
+	zoneNameIDMapper := p.NewZoneFinder()
 	for _, zoneGroup := range zoneGroups {
 		if zoneGroup.Zone.ZoneId != nil {
 			zoneNameIDMapper.Add(*zoneGroup.Zone.ZoneId, *zoneGroup.Zone.Domain)
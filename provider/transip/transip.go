@@ -44,7 +44,7 @@ type TransIPProvider struct {
 	domainFilter endpoint.DomainFilter
 	dryRun       bool
 
-	zoneMap provider.ZoneIDName
+	zoneMap provider.ZoneFinder
 }
 
 // NewTransIPProvider initializes a new TransIP Provider.
@@ -80,7 +80,7 @@ func NewTransIPProvider(accountName, privateKeyFile string, domainFilter endpoin
 		domainRepo:   domain.Repository{Client: client},
 		domainFilter: domainFilter,
 		dryRun:       dryRun,
-		zoneMap:      provider.ZoneIDName{},
+		zoneMap:      provider.NewZoneFinder(nil),
 	}, nil
 }
 
@@ -95,7 +95,7 @@ func (p *TransIPProvider) ApplyChanges(ctx context.Context, changes *plan.Change
 	}
 
 	// refresh zone mapping
-	zoneMap := provider.ZoneIDName{}
+	zoneMap := p.NewZoneFinder()
 	for _, zone := range zones {
 		// TransIP API doesn't expose a unique identifier for zones, other than than
 		// the domain name itself
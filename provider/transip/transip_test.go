@@ -34,7 +34,7 @@ import (
 
 func newProvider() *TransIPProvider {
 	return &TransIPProvider{
-		zoneMap: provider.ZoneIDName{},
+		zoneMap: provider.NewZoneFinder(nil),
 	}
 }
 
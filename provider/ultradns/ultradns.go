@@ -283,7 +283,7 @@ func (p *UltraDNSProvider) submitChanges(ctx context.Context, changes []*UltraDN
 	if err != nil {
 		return err
 	}
-	zoneChanges := seperateChangeByZone(zones, changes)
+	zoneChanges := p.seperateChangeByZone(zones, changes)
 
 	for zoneName, changes := range zoneChanges {
 		for _, change := range changes {
@@ -433,9 +433,9 @@ func newUltraDNSChanges(action string, endpoints []*endpoint.Endpoint) []*UltraD
 	return changes
 }
 
-func seperateChangeByZone(zones []udnssdk.Zone, changes []*UltraDNSChanges) map[string][]*UltraDNSChanges {
+func (p *UltraDNSProvider) seperateChangeByZone(zones []udnssdk.Zone, changes []*UltraDNSChanges) map[string][]*UltraDNSChanges {
 	change := make(map[string][]*UltraDNSChanges)
-	zoneNameID := provider.ZoneIDName{}
+	zoneNameID := p.NewZoneFinder()
 	for _, z := range zones {
 		zoneNameID.Add(z.Properties.Name, z.Properties.Name)
 		change[z.Properties.Name] = []*UltraDNSChanges{}
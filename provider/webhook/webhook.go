@@ -25,13 +25,12 @@ import (
 	"net/url"
 
 	"sigs.k8s.io/external-dns/endpoint"
-	"sigs.k8s.io/external-dns/pkg/metrics"
+	extdnshttp "sigs.k8s.io/external-dns/pkg/http"
 	"sigs.k8s.io/external-dns/plan"
 	"sigs.k8s.io/external-dns/provider"
 	webhookapi "sigs.k8s.io/external-dns/provider/webhook/api"
 
 	"github.com/cenkalti/backoff/v5"
-	"github.com/prometheus/client_golang/prometheus"
 	log "github.com/sirupsen/logrus"
 )
 
@@ -40,72 +39,12 @@ const (
 	maxRetries   = 5
 )
 
-var (
-	recordsErrorsGauge = metrics.NewGaugeWithOpts(
-		prometheus.GaugeOpts{
-			Namespace: "external_dns",
-			Subsystem: "webhook_provider",
-			Name:      "records_errors_total",
-			Help:      "Errors with Records method",
-		},
-	)
-	recordsRequestsGauge = metrics.NewGaugeWithOpts(
-		prometheus.GaugeOpts{
-			Namespace: "external_dns",
-			Subsystem: "webhook_provider",
-			Name:      "records_requests_total",
-			Help:      "Requests with Records method",
-		},
-	)
-	applyChangesErrorsGauge = metrics.NewGaugeWithOpts(
-		prometheus.GaugeOpts{
-			Namespace: "external_dns",
-			Subsystem: "webhook_provider",
-			Name:      "applychanges_errors_total",
-			Help:      "Errors with ApplyChanges method",
-		},
-	)
-	applyChangesRequestsGauge = metrics.NewGaugeWithOpts(
-		prometheus.GaugeOpts{
-			Namespace: "external_dns",
-			Subsystem: "webhook_provider",
-			Name:      "applychanges_requests_total",
-			Help:      "Requests with ApplyChanges method",
-		},
-	)
-	adjustEndpointsErrorsGauge = metrics.NewGaugeWithOpts(
-		prometheus.GaugeOpts{
-			Namespace: "external_dns",
-			Subsystem: "webhook_provider",
-			Name:      "adjustendpoints_errors_total",
-			Help:      "Errors with AdjustEndpoints method",
-		},
-	)
-	adjustEndpointsRequestsGauge = metrics.NewGaugeWithOpts(
-		prometheus.GaugeOpts{
-			Namespace: "external_dns",
-			Subsystem: "webhook_provider",
-			Name:      "adjustendpoints_requests_total",
-			Help:      "Requests with AdjustEndpoints method",
-		},
-	)
-)
-
 type WebhookProvider struct {
 	client          *http.Client
 	remoteServerURL *url.URL
 	DomainFilter    endpoint.DomainFilter
 }
 
-func init() {
-	metrics.RegisterMetric.MustRegister(recordsErrorsGauge)
-	metrics.RegisterMetric.MustRegister(recordsRequestsGauge)
-	metrics.RegisterMetric.MustRegister(applyChangesErrorsGauge)
-	metrics.RegisterMetric.MustRegister(applyChangesRequestsGauge)
-	metrics.RegisterMetric.MustRegister(adjustEndpointsErrorsGauge)
-	metrics.RegisterMetric.MustRegister(adjustEndpointsRequestsGauge)
-}
-
 func NewWebhookProvider(u string) (*WebhookProvider, error) {
 	parsedURL, err := url.Parse(u)
 	if err != nil {
@@ -119,7 +58,7 @@ func NewWebhookProvider(u string) (*WebhookProvider, error) {
 	}
 	req.Header.Set(acceptHeader, webhookapi.MediaTypeFormatAndVersion)
 
-	client := &http.Client{}
+	client := extdnshttp.NewClient("webhook")
 
 	resp, err := requestWithRetry(client, req)
 	if err != nil {
@@ -162,28 +101,24 @@ func requestWithRetry(client *http.Client, req *http.Request) (*http.Response, e
 
 // Records will make a GET call to remoteServerURL/records and return the results
 func (p WebhookProvider) Records(ctx context.Context) ([]*endpoint.Endpoint, error) {
-	recordsRequestsGauge.Gauge.Inc()
 	u := p.remoteServerURL.JoinPath("records").String()
 
 	req, err := http.NewRequest(http.MethodGet, u, nil)
 	if err != nil {
-		recordsErrorsGauge.Gauge.Inc()
 		log.Debugf("Failed to create request: %s", err.Error())
 		return nil, err
 	}
 	req.Header.Set(acceptHeader, webhookapi.MediaTypeFormatAndVersion)
 	resp, err := p.client.Do(req)
 	if err != nil {
-		recordsErrorsGauge.Gauge.Inc()
 		log.Debugf("Failed to perform request: %s", err.Error())
 		return nil, err
 	}
 	defer resp.Body.Close()
 
 	if resp.StatusCode != http.StatusOK {
-		recordsErrorsGauge.Gauge.Inc()
 		log.Debugf("Failed to get records with code %d", resp.StatusCode)
-		err := fmt.Errorf("failed to get records with code %d", resp.StatusCode)
+		err := &statusCodeError{operation: "get records", statusCode: resp.StatusCode}
 		if isRetryableError(resp.StatusCode) {
 			return nil, provider.NewSoftError(err)
 		}
@@ -192,7 +127,6 @@ func (p WebhookProvider) Records(ctx context.Context) ([]*endpoint.Endpoint, err
 
 	var endpoints []*endpoint.Endpoint
 	if err := json.NewDecoder(resp.Body).Decode(&endpoints); err != nil {
-		recordsErrorsGauge.Gauge.Inc()
 		log.Debugf("Failed to decode response body: %s", err.Error())
 		return nil, err
 	}
@@ -201,19 +135,16 @@ func (p WebhookProvider) Records(ctx context.Context) ([]*endpoint.Endpoint, err
 
 // ApplyChanges will make a POST to remoteServerURL/records with the changes
 func (p WebhookProvider) ApplyChanges(_ context.Context, changes *plan.Changes) error {
-	applyChangesRequestsGauge.Gauge.Inc()
 	u := p.remoteServerURL.JoinPath(webhookapi.UrlRecords).String()
 
 	b := new(bytes.Buffer)
 	if err := json.NewEncoder(b).Encode(changes); err != nil {
-		applyChangesErrorsGauge.Gauge.Inc()
 		log.Debugf("Failed to encode changes: %s", err.Error())
 		return err
 	}
 
 	req, err := http.NewRequest(http.MethodPost, u, b)
 	if err != nil {
-		applyChangesErrorsGauge.Gauge.Inc()
 		log.Debugf("Failed to create request: %s", err.Error())
 		return err
 	}
@@ -222,7 +153,6 @@ func (p WebhookProvider) ApplyChanges(_ context.Context, changes *plan.Changes)
 
 	resp, err := p.client.Do(req)
 	if err != nil {
-		applyChangesErrorsGauge.Gauge.Inc()
 		log.Debugf("Failed to perform request: %s", err.Error())
 		return err
 	}
@@ -230,9 +160,8 @@ func (p WebhookProvider) ApplyChanges(_ context.Context, changes *plan.Changes)
 	defer resp.Body.Close()
 
 	if resp.StatusCode != http.StatusNoContent {
-		applyChangesErrorsGauge.Gauge.Inc()
 		log.Debugf("Failed to apply changes with code %d", resp.StatusCode)
-		err := fmt.Errorf("failed to apply changes with code %d", resp.StatusCode)
+		err := &statusCodeError{operation: "apply changes", statusCode: resp.StatusCode}
 		if isRetryableError(resp.StatusCode) {
 			return provider.NewSoftError(err)
 		}
@@ -245,25 +174,21 @@ func (p WebhookProvider) ApplyChanges(_ context.Context, changes *plan.Changes)
 // based on a provider-specific requirement.
 // This method returns an empty slice in case there is a technical error on the provider's side so that no endpoints will be considered.
 func (p WebhookProvider) AdjustEndpoints(e []*endpoint.Endpoint) ([]*endpoint.Endpoint, error) {
-	adjustEndpointsRequestsGauge.Gauge.Inc()
 	var endpoints []*endpoint.Endpoint
 	u, err := url.JoinPath(p.remoteServerURL.String(), webhookapi.UrlAdjustEndpoints)
 	if err != nil {
-		adjustEndpointsErrorsGauge.Gauge.Inc()
 		log.Debugf("Failed to join path, %s", err)
 		return nil, err
 	}
 
 	b := new(bytes.Buffer)
 	if err := json.NewEncoder(b).Encode(e); err != nil {
-		adjustEndpointsErrorsGauge.Gauge.Inc()
 		log.Debugf("Failed to encode endpoints, %s", err)
 		return nil, err
 	}
 
 	req, err := http.NewRequest(http.MethodPost, u, b)
 	if err != nil {
-		adjustEndpointsErrorsGauge.Gauge.Inc()
 		log.Debugf("Failed to create new HTTP request, %s", err)
 		return nil, err
 	}
@@ -273,16 +198,14 @@ func (p WebhookProvider) AdjustEndpoints(e []*endpoint.Endpoint) ([]*endpoint.En
 
 	resp, err := p.client.Do(req)
 	if err != nil {
-		adjustEndpointsErrorsGauge.Gauge.Inc()
 		log.Debugf("Failed executing http request, %s", err)
 		return nil, err
 	}
 	defer resp.Body.Close()
 
 	if resp.StatusCode != http.StatusOK {
-		adjustEndpointsErrorsGauge.Gauge.Inc()
 		log.Debugf("Failed to AdjustEndpoints with code %d", resp.StatusCode)
-		err := fmt.Errorf("failed to AdjustEndpoints with code  %d", resp.StatusCode)
+		err := &statusCodeError{operation: "AdjustEndpoints", statusCode: resp.StatusCode}
 		if isRetryableError(resp.StatusCode) {
 			return nil, provider.NewSoftError(err)
 		}
@@ -290,7 +213,6 @@ func (p WebhookProvider) AdjustEndpoints(e []*endpoint.Endpoint) ([]*endpoint.En
 	}
 
 	if err := json.NewDecoder(resp.Body).Decode(&endpoints); err != nil {
-		adjustEndpointsErrorsGauge.Gauge.Inc()
 		log.Debugf("Failed to decode response body: %s", err.Error())
 		return nil, err
 	}
@@ -303,6 +225,21 @@ func (p WebhookProvider) GetDomainFilter() endpoint.DomainFilterInterface {
 	return p.DomainFilter
 }
 
+// statusCodeError is returned when the webhook responds with an unexpected status code.
+type statusCodeError struct {
+	operation  string
+	statusCode int
+}
+
+func (e *statusCodeError) Error() string {
+	return fmt.Sprintf("failed to %s with code %d", e.operation, e.statusCode)
+}
+
+// HTTPStatusCode returns the status code the webhook responded with.
+func (e *statusCodeError) HTTPStatusCode() int {
+	return e.statusCode
+}
+
 // isRetryableError returns true for HTTP status codes between 500 and 510 (inclusive)
 func isRetryableError(statusCode int) bool {
 	return statusCode >= http.StatusInternalServerError && statusCode <= http.StatusNotExtended
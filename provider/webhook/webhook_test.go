@@ -472,7 +472,7 @@ func TestAdjustEndpoints_NonOKStatusCode(t *testing.T) {
 
 	_, err := p.AdjustEndpoints(endpoints)
 	require.Error(t, err)
-	assert.Contains(t, err.Error(), "failed to AdjustEndpoints with code  511")
+	assert.Contains(t, err.Error(), "failed to AdjustEndpoints with code 511")
 }
 
 func TestAdjustEndpoints_DecodeError(t *testing.T) {
@@ -0,0 +1,315 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package yandex
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"golang.org/x/oauth2"
+
+	"sigs.k8s.io/external-dns/pkg/apis/externaldns"
+)
+
+const (
+	defaultAPIURL      = "https://dns.api.cloud.yandex.net/dns/v1"
+	defaultIAMURL      = "https://iam.api.cloud.yandex.net/iam/v1/tokens"
+	defaultMetadataURL = "http://169.254.169.254/computeMetadata/v1/instance/service-accounts/default/token"
+
+	// jwtLifetime is the lifetime of the JWTs exchanged for IAM tokens, at most an hour.
+	jwtLifetime = time.Hour
+	// tokenEarlyExpiry is how long before its expiry an IAM token is refreshed.
+	tokenEarlyExpiry = 5 * time.Minute
+)
+
+// Zone is a Yandex Cloud DNS zone. Zones with a public visibility are public, the
+// others are only visible from the networks of their private visibility.
+type Zone struct {
+	ID                string             `json:"id"`
+	FolderID          string             `json:"folderId"`
+	Name              string             `json:"name"`
+	Zone              string             `json:"zone"`
+	PublicVisibility  *struct{}          `json:"publicVisibility,omitempty"`
+	PrivateVisibility *PrivateVisibility `json:"privateVisibility,omitempty"`
+}
+
+// PrivateVisibility lists the networks a private zone is visible from.
+type PrivateVisibility struct {
+	NetworkIDs []string `json:"networkIds"`
+}
+
+// RecordSet is a set of records of a Yandex Cloud DNS zone sharing a name and type.
+type RecordSet struct {
+	Name string   `json:"name"`
+	Type string   `json:"type"`
+	TTL  int64    `json:"ttl,string"`
+	Data []string `json:"data"`
+}
+
+// RecordSetChanges are the record sets to delete and replace in a zone.
+type RecordSetChanges struct {
+	Deletions    []RecordSet `json:"deletions,omitempty"`
+	Replacements []RecordSet `json:"replacements,omitempty"`
+}
+
+// Client is the subset of the Yandex Cloud DNS API used by the provider.
+type Client interface {
+	ListZones(ctx context.Context, folderID string) ([]Zone, error)
+	ListRecordSets(ctx context.Context, zoneID string) ([]RecordSet, error)
+	UpsertRecordSets(ctx context.Context, zoneID string, changes RecordSetChanges) error
+}
+
+type client struct {
+	apiURL     string
+	httpClient *http.Client
+}
+
+// NewClient returns a client authenticating with IAM tokens from the given source,
+// which are cached and refreshed shortly before they expire.
+func NewClient(ctx context.Context, tokenSource oauth2.TokenSource) Client {
+	return &client{
+		apiURL:     defaultAPIURL,
+		httpClient: oauth2.NewClient(ctx, oauth2.ReuseTokenSourceWithExpiry(nil, tokenSource, tokenEarlyExpiry)),
+	}
+}
+
+func (c *client) do(ctx context.Context, method, path string, query url.Values, body any, result any) error {
+	u := c.apiURL + path
+	if len(query) > 0 {
+		u += "?" + query.Encode()
+	}
+
+	var reader io.Reader
+	if body != nil {
+		data, err := json.Marshal(body)
+		if err != nil {
+			return err
+		}
+		reader = bytes.NewReader(data)
+	}
+	req, err := http.NewRequestWithContext(ctx, method, u, reader)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Accept", "application/json")
+	req.Header.Set("User-Agent", externaldns.UserAgent())
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		var apiErr struct {
+			Message string `json:"message"`
+		}
+		_ = json.NewDecoder(resp.Body).Decode(&apiErr)
+		if apiErr.Message != "" {
+			return fmt.Errorf("%s %s: %s (status %d)", method, path, apiErr.Message, resp.StatusCode)
+		}
+		return fmt.Errorf("%s %s: unexpected status %s", method, path, resp.Status)
+	}
+	if result == nil {
+		return nil
+	}
+	return json.NewDecoder(resp.Body).Decode(result)
+}
+
+func (c *client) ListZones(ctx context.Context, folderID string) ([]Zone, error) {
+	var zones []Zone
+	query := url.Values{"folderId": {folderID}}
+	for {
+		var resp struct {
+			DNSZones      []Zone `json:"dnsZones"`
+			NextPageToken string `json:"nextPageToken"`
+		}
+		if err := c.do(ctx, http.MethodGet, "/zones", query, nil, &resp); err != nil {
+			return nil, err
+		}
+		zones = append(zones, resp.DNSZones...)
+		if resp.NextPageToken == "" {
+			return zones, nil
+		}
+		query.Set("pageToken", resp.NextPageToken)
+	}
+}
+
+func (c *client) ListRecordSets(ctx context.Context, zoneID string) ([]RecordSet, error) {
+	var recordSets []RecordSet
+	query := url.Values{}
+	for {
+		var resp struct {
+			RecordSets    []RecordSet `json:"recordSets"`
+			NextPageToken string      `json:"nextPageToken"`
+		}
+		if err := c.do(ctx, http.MethodGet, "/zones/"+url.PathEscape(zoneID)+":listRecordSets", query, nil, &resp); err != nil {
+			return nil, err
+		}
+		recordSets = append(recordSets, resp.RecordSets...)
+		if resp.NextPageToken == "" {
+			return recordSets, nil
+		}
+		query.Set("pageToken", resp.NextPageToken)
+	}
+}
+
+func (c *client) UpsertRecordSets(ctx context.Context, zoneID string, changes RecordSetChanges) error {
+	var operation struct {
+		Done  bool `json:"done"`
+		Error *struct {
+			Message string `json:"message"`
+		} `json:"error"`
+	}
+	if err := c.do(ctx, http.MethodPost, "/zones/"+url.PathEscape(zoneID)+":upsertRecordSets", nil, changes, &operation); err != nil {
+		return err
+	}
+	if operation.Error != nil {
+		return fmt.Errorf("upsert of record sets failed: %s", operation.Error.Message)
+	}
+	return nil
+}
+
+// authorizedKey is a service account authorized key, as created by
+// "yc iam key create --output key.json".
+type authorizedKey struct {
+	ID               string `json:"id"`
+	ServiceAccountID string `json:"service_account_id"`
+	PrivateKey       string `json:"private_key"`
+}
+
+// keyTokenSource exchanges JWTs signed with a service account authorized key for IAM tokens.
+type keyTokenSource struct {
+	ctx        context.Context
+	key        authorizedKey
+	iamURL     string
+	httpClient *http.Client
+	now        func() time.Time
+}
+
+// NewAuthorizedKeyTokenSource returns a source of IAM tokens of the service account
+// whose authorized key is stored in the given file.
+func NewAuthorizedKeyTokenSource(ctx context.Context, keyFile string) (oauth2.TokenSource, error) {
+	data, err := os.ReadFile(keyFile)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read authorized key file %s: %w", keyFile, err)
+	}
+	var key authorizedKey
+	if err := json.Unmarshal(data, &key); err != nil {
+		return nil, fmt.Errorf("failed to parse authorized key file %s: %w", keyFile, err)
+	}
+	if key.ID == "" || key.ServiceAccountID == "" || key.PrivateKey == "" {
+		return nil, fmt.Errorf("authorized key file %s must contain an id, a service_account_id and a private_key", keyFile)
+	}
+	return &keyTokenSource{ctx: ctx, key: key, iamURL: defaultIAMURL, httpClient: http.DefaultClient, now: time.Now}, nil
+}
+
+func (s *keyTokenSource) Token() (*oauth2.Token, error) {
+	// Recent keys prefix the PEM block with a comment line, which the PEM parser does not expect.
+	privateKey := s.key.PrivateKey
+	if i := strings.Index(privateKey, "-----BEGIN"); i > 0 {
+		privateKey = privateKey[i:]
+	}
+	rsaKey, err := jwt.ParseRSAPrivateKeyFromPEM([]byte(privateKey))
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse private key of authorized key %s: %w", s.key.ID, err)
+	}
+
+	now := s.now()
+	token := jwt.NewWithClaims(jwt.SigningMethodPS256, jwt.RegisteredClaims{
+		Issuer:    s.key.ServiceAccountID,
+		Audience:  jwt.ClaimStrings{s.iamURL},
+		IssuedAt:  jwt.NewNumericDate(now),
+		ExpiresAt: jwt.NewNumericDate(now.Add(jwtLifetime)),
+	})
+	token.Header["kid"] = s.key.ID
+	signed, err := token.SignedString(rsaKey)
+	if err != nil {
+		return nil, fmt.Errorf("failed to sign jwt with authorized key %s: %w", s.key.ID, err)
+	}
+
+	body, _ := json.Marshal(map[string]string{"jwt": signed})
+	req, err := http.NewRequestWithContext(s.ctx, http.MethodPost, s.iamURL, bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	var result struct {
+		IAMToken  string    `json:"iamToken"`
+		ExpiresAt time.Time `json:"expiresAt"`
+	}
+	if err := doTokenRequest(s.httpClient, req, &result); err != nil {
+		return nil, fmt.Errorf("failed to exchange jwt for an iam token: %w", err)
+	}
+	return &oauth2.Token{AccessToken: result.IAMToken, TokenType: "Bearer", Expiry: result.ExpiresAt}, nil
+}
+
+// metadataTokenSource gets the IAM tokens of the service account attached to the
+// compute instance from its metadata service.
+type metadataTokenSource struct {
+	ctx         context.Context
+	metadataURL string
+	httpClient  *http.Client
+	now         func() time.Time
+}
+
+// NewMetadataTokenSource returns a source of IAM tokens of the service account
+// attached to the compute instance ExternalDNS runs on.
+func NewMetadataTokenSource(ctx context.Context) oauth2.TokenSource {
+	return &metadataTokenSource{ctx: ctx, metadataURL: defaultMetadataURL, httpClient: http.DefaultClient, now: time.Now}
+}
+
+func (s *metadataTokenSource) Token() (*oauth2.Token, error) {
+	req, err := http.NewRequestWithContext(s.ctx, http.MethodGet, s.metadataURL, nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Metadata-Flavor", "Google")
+	var result struct {
+		AccessToken string `json:"access_token"`
+		ExpiresIn   int64  `json:"expires_in"`
+	}
+	if err := doTokenRequest(s.httpClient, req, &result); err != nil {
+		return nil, fmt.Errorf("failed to get an iam token from the metadata service: %w", err)
+	}
+	return &oauth2.Token{AccessToken: result.AccessToken, TokenType: "Bearer", Expiry: s.now().Add(time.Duration(result.ExpiresIn) * time.Second)}, nil
+}
+
+func doTokenRequest(httpClient *http.Client, req *http.Request, result any) error {
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		data, _ := io.ReadAll(io.LimitReader(resp.Body, 1024))
+		return fmt.Errorf("unexpected status %s: %s", resp.Status, strings.TrimSpace(string(data)))
+	}
+	return json.NewDecoder(resp.Body).Decode(result)
+}
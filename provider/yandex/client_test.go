@@ -0,0 +1,166 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package yandex
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/json"
+	"encoding/pem"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/oauth2"
+)
+
+func TestClient(t *testing.T) {
+	var requests []string
+	var upsert RecordSetChanges
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "Bearer iam-token", r.Header.Get("Authorization"))
+		requests = append(requests, r.Method+" "+r.URL.RequestURI())
+		switch r.Method + " " + r.URL.Path {
+		case "GET /zones":
+			if r.URL.Query().Get("pageToken") == "" {
+				_, _ = w.Write([]byte(`{"dnsZones":[{"id":"zone1","folderId":"folder","name":"example-com","zone":"example.com.","publicVisibility":{}}],"nextPageToken":"next"}`))
+			} else {
+				_, _ = w.Write([]byte(`{"dnsZones":[{"id":"zone2","folderId":"folder","name":"internal","zone":"internal.example.com.","privateVisibility":{"networkIds":["net1"]}}]}`))
+			}
+		case "GET /zones/zone1:listRecordSets":
+			_, _ = w.Write([]byte(`{"recordSets":[{"name":"www.example.com.","type":"A","ttl":"300","data":["1.2.3.4"]}]}`))
+		case "POST /zones/zone1:upsertRecordSets":
+			require.NoError(t, json.NewDecoder(r.Body).Decode(&upsert))
+			_, _ = w.Write([]byte(`{"id":"op1","done":true}`))
+		case "POST /zones/zone2:upsertRecordSets":
+			w.WriteHeader(http.StatusBadRequest)
+			_, _ = w.Write([]byte(`{"code":3,"message":"Record set already exists"}`))
+		}
+	}))
+	defer server.Close()
+
+	c := &client{
+		apiURL:     server.URL,
+		httpClient: oauth2.NewClient(context.Background(), oauth2.StaticTokenSource(&oauth2.Token{AccessToken: "iam-token"})),
+	}
+
+	zones, err := c.ListZones(context.Background(), "folder")
+	require.NoError(t, err)
+	require.Len(t, zones, 2)
+	assert.Equal(t, zoneTypePublic, zoneType(zones[0]))
+	assert.Equal(t, zoneTypePrivate, zoneType(zones[1]))
+	assert.Equal(t, []string{"net1"}, zones[1].PrivateVisibility.NetworkIDs)
+
+	recordSets, err := c.ListRecordSets(context.Background(), "zone1")
+	require.NoError(t, err)
+	assert.Equal(t, []RecordSet{{Name: "www.example.com.", Type: "A", TTL: 300, Data: []string{"1.2.3.4"}}}, recordSets)
+
+	changes := RecordSetChanges{Replacements: []RecordSet{{Name: "new.example.com.", Type: "A", TTL: 60, Data: []string{"4.3.2.1"}}}}
+	require.NoError(t, c.UpsertRecordSets(context.Background(), "zone1", changes))
+	assert.Equal(t, changes, upsert)
+
+	err = c.UpsertRecordSets(context.Background(), "zone2", changes)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "Record set already exists")
+
+	assert.Equal(t, []string{
+		"GET /zones?folderId=folder",
+		"GET /zones?folderId=folder&pageToken=next",
+		"GET /zones/zone1:listRecordSets",
+		"POST /zones/zone1:upsertRecordSets",
+		"POST /zones/zone2:upsertRecordSets",
+	}, requests)
+}
+
+func TestAuthorizedKeyTokenSource(t *testing.T) {
+	rsaKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+	der, err := x509.MarshalPKCS8PrivateKey(rsaKey)
+	require.NoError(t, err)
+	privateKey := "PLEASE DO NOT REMOVE THIS LINE! Yandex.Cloud SA Key ID <key-id>\n" + string(pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: der}))
+
+	keyFile := filepath.Join(t.TempDir(), "key.json")
+	data, err := json.Marshal(map[string]string{"id": "key-id", "service_account_id": "sa-id", "private_key": privateKey})
+	require.NoError(t, err)
+	require.NoError(t, os.WriteFile(keyFile, data, 0o600))
+
+	now := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req struct {
+			JWT string `json:"jwt"`
+		}
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&req))
+		var claims jwt.RegisteredClaims
+		token, err := jwt.ParseWithClaims(req.JWT, &claims, func(*jwt.Token) (any, error) { return &rsaKey.PublicKey, nil },
+			jwt.WithValidMethods([]string{"PS256"}), jwt.WithTimeFunc(func() time.Time { return now }))
+		require.NoError(t, err)
+		assert.Equal(t, "key-id", token.Header["kid"])
+		assert.Equal(t, "sa-id", claims.Issuer)
+		assert.Equal(t, now.Add(jwtLifetime), claims.ExpiresAt.UTC())
+		_, _ = w.Write([]byte(`{"iamToken":"iam-token","expiresAt":"2025-01-01T12:00:00Z"}`))
+	}))
+	defer server.Close()
+
+	source, err := NewAuthorizedKeyTokenSource(context.Background(), keyFile)
+	require.NoError(t, err)
+	source.(*keyTokenSource).iamURL = server.URL
+	source.(*keyTokenSource).now = func() time.Time { return now }
+
+	token, err := source.Token()
+	require.NoError(t, err)
+	assert.Equal(t, "iam-token", token.AccessToken)
+	assert.Equal(t, now.Add(12*time.Hour), token.Expiry.UTC())
+
+	_, err = NewAuthorizedKeyTokenSource(context.Background(), filepath.Join(t.TempDir(), "missing.json"))
+	require.Error(t, err)
+}
+
+func TestMetadataTokenSource(t *testing.T) {
+	calls := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		assert.Equal(t, "Google", r.Header.Get("Metadata-Flavor"))
+		_, _ = w.Write([]byte(`{"access_token":"iam-token","expires_in":3600,"token_type":"Bearer"}`))
+	}))
+	defer server.Close()
+
+	now := time.Now()
+	source := NewMetadataTokenSource(context.Background()).(*metadataTokenSource)
+	source.metadataURL = server.URL
+	source.now = func() time.Time { return now }
+
+	token, err := source.Token()
+	require.NoError(t, err)
+	assert.Equal(t, "iam-token", token.AccessToken)
+	assert.Equal(t, now.Add(time.Hour), token.Expiry)
+
+	// Tokens are reused until shortly before they expire.
+	reused := oauth2.ReuseTokenSourceWithExpiry(nil, source, tokenEarlyExpiry)
+	for range 3 {
+		_, err := reused.Token()
+		require.NoError(t, err)
+	}
+	assert.Equal(t, 2, calls)
+}
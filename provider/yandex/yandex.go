@@ -0,0 +1,244 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package yandex
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	log "github.com/sirupsen/logrus"
+	"golang.org/x/oauth2"
+
+	"sigs.k8s.io/external-dns/endpoint"
+	"sigs.k8s.io/external-dns/plan"
+	"sigs.k8s.io/external-dns/provider"
+)
+
+const (
+	// defaultTTL is the TTL given to record sets created without one.
+	defaultTTL = 600
+
+	zoneTypePublic  = "public"
+	zoneTypePrivate = "private"
+)
+
+// YandexProvider is an implementation of Provider for Yandex Cloud DNS.
+type YandexProvider struct {
+	provider.BaseProvider
+	client         Client
+	folderID       string
+	domainFilter   endpoint.DomainFilter
+	zoneTypeFilter provider.ZoneTypeFilter
+	dryRun         bool
+}
+
+// NewYandexProvider initializes a new Yandex Cloud DNS based Provider managing the zones of
+// the given folder. It authenticates with the service account authorized key stored in
+// authKeyFile or, when empty, with the service account attached to the compute instance.
+func NewYandexProvider(ctx context.Context, folderID, authKeyFile, zoneVisibility string, domainFilter endpoint.DomainFilter, dryRun bool) (*YandexProvider, error) {
+	if folderID == "" {
+		return nil, fmt.Errorf("no yandex cloud folder id provided, you must set the --yandex-folder-id flag")
+	}
+
+	var tokenSource oauth2.TokenSource
+	if authKeyFile != "" {
+		var err error
+		if tokenSource, err = NewAuthorizedKeyTokenSource(ctx, authKeyFile); err != nil {
+			return nil, err
+		}
+	} else {
+		tokenSource = NewMetadataTokenSource(ctx)
+	}
+
+	return &YandexProvider{
+		client:         NewClient(ctx, tokenSource),
+		folderID:       folderID,
+		domainFilter:   domainFilter,
+		zoneTypeFilter: provider.NewZoneTypeFilter(zoneVisibility),
+		dryRun:         dryRun,
+	}, nil
+}
+
+// Records returns the list of record sets in all managed zones.
+func (p *YandexProvider) Records(ctx context.Context) ([]*endpoint.Endpoint, error) {
+	zones, err := p.zones(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var endpoints []*endpoint.Endpoint
+	for _, zone := range zones {
+		recordSets, err := p.client.ListRecordSets(ctx, zone.ID)
+		if err != nil {
+			return nil, provider.NewSoftError(fmt.Errorf("failed to list record sets of zone %s: %w", zone.Name, err))
+		}
+		for _, recordSet := range recordSets {
+			if !p.SupportedRecordType(recordSet.Type) {
+				continue
+			}
+			targets := make([]string, 0, len(recordSet.Data))
+			for _, data := range recordSet.Data {
+				targets = append(targets, target(recordSet.Type, data))
+			}
+			endpoints = append(endpoints, endpoint.NewEndpointWithTTL(strings.TrimSuffix(recordSet.Name, "."), recordSet.Type, endpoint.TTL(recordSet.TTL), targets...))
+		}
+	}
+
+	return endpoints, nil
+}
+
+// SupportedRecordType returns true if the record type is supported by the provider
+func (p *YandexProvider) SupportedRecordType(recordType string) bool {
+	switch recordType {
+	case endpoint.RecordTypeMX:
+		return true
+	default:
+		return provider.SupportedRecordType(recordType)
+	}
+}
+
+// ApplyChanges applies the given changes with one call per zone, replacing the updated
+// and created record sets and deleting the deleted ones.
+func (p *YandexProvider) ApplyChanges(ctx context.Context, changes *plan.Changes) error {
+	zones, err := p.zones(ctx)
+	if err != nil {
+		return err
+	}
+	zoneNameIDMapper := p.NewZoneFinder()
+	zoneNames := map[string]string{}
+	for _, zone := range zones {
+		zoneNameIDMapper.Add(zone.ID, strings.TrimSuffix(zone.Zone, "."))
+		zoneNames[zone.ID] = zone.Name
+	}
+
+	changesByZone := map[string]*RecordSetChanges{}
+	var zoneOrder []string
+	zoneChanges := func(ep *endpoint.Endpoint) (*RecordSetChanges, string) {
+		zoneID, _ := zoneNameIDMapper.FindZone(ep.DNSName)
+		if zoneID == "" {
+			log.Debugf("Skipping record %s because no zone matching record DNS Name was detected", ep.DNSName)
+			return nil, ""
+		}
+		if _, ok := changesByZone[zoneID]; !ok {
+			changesByZone[zoneID] = &RecordSetChanges{}
+			zoneOrder = append(zoneOrder, zoneID)
+		}
+		return changesByZone[zoneID], zoneNames[zoneID]
+	}
+
+	for _, ep := range changes.Delete {
+		if c, zone := zoneChanges(ep); c != nil {
+			log.Infof("Deleting %s record %s in zone %s", ep.RecordType, ep.DNSName, zone)
+			c.Deletions = append(c.Deletions, newRecordSet(ep))
+		}
+	}
+	for _, ep := range changes.UpdateNew {
+		if c, zone := zoneChanges(ep); c != nil {
+			log.Infof("Updating %s record %s in zone %s to %s", ep.RecordType, ep.DNSName, zone, ep.Targets)
+			c.Replacements = append(c.Replacements, newRecordSet(ep))
+		}
+	}
+	for _, ep := range changes.Create {
+		if c, zone := zoneChanges(ep); c != nil {
+			log.Infof("Creating %s record %s in zone %s with targets %s", ep.RecordType, ep.DNSName, zone, ep.Targets)
+			c.Replacements = append(c.Replacements, newRecordSet(ep))
+		}
+	}
+
+	if p.dryRun {
+		return nil
+	}
+	for _, zoneID := range zoneOrder {
+		if err := p.client.UpsertRecordSets(ctx, zoneID, *changesByZone[zoneID]); err != nil {
+			return provider.NewSoftError(fmt.Errorf("failed to apply changes to zone %s: %w", zoneNames[zoneID], err))
+		}
+	}
+	return nil
+}
+
+// zones returns the zones of the folder matching the domain and zone visibility filters.
+func (p *YandexProvider) zones(ctx context.Context) ([]Zone, error) {
+	all, err := p.client.ListZones(ctx, p.folderID)
+	if err != nil {
+		return nil, provider.NewSoftError(fmt.Errorf("failed to list zones of folder %s: %w", p.folderID, err))
+	}
+
+	var zones []Zone
+	for _, zone := range all {
+		if !p.domainFilter.Match(strings.TrimSuffix(zone.Zone, ".")) {
+			continue
+		}
+		if !p.zoneTypeFilter.Match(zoneType(zone)) {
+			log.Debugf("Skipping %s zone %s because of the zone visibility filter", zoneType(zone), zone.Name)
+			continue
+		}
+		zones = append(zones, zone)
+	}
+	return zones, nil
+}
+
+// zoneType returns whether a zone is public or only visible from private networks.
+func zoneType(zone Zone) string {
+	if zone.PublicVisibility != nil {
+		return zoneTypePublic
+	}
+	return zoneTypePrivate
+}
+
+func newRecordSet(ep *endpoint.Endpoint) RecordSet {
+	recordSet := RecordSet{
+		Name: ep.DNSName + ".",
+		Type: ep.RecordType,
+		TTL:  defaultTTL,
+		Data: make([]string, 0, len(ep.Targets)),
+	}
+	if ep.RecordTTL.IsConfigured() {
+		recordSet.TTL = int64(ep.RecordTTL)
+	}
+	for _, t := range ep.Targets {
+		recordSet.Data = append(recordSet.Data, data(ep.RecordType, t))
+	}
+	return recordSet
+}
+
+// hasHostData returns true for the record types whose data ends with a host name,
+// which Yandex Cloud DNS fully qualifies.
+func hasHostData(recordType string) bool {
+	switch recordType {
+	case endpoint.RecordTypeCNAME, endpoint.RecordTypeNS, endpoint.RecordTypeMX, endpoint.RecordTypeSRV:
+		return true
+	default:
+		return false
+	}
+}
+
+// data returns the Yandex Cloud DNS data of a target.
+func data(recordType, t string) string {
+	if hasHostData(recordType) && !strings.HasSuffix(t, ".") {
+		return t + "."
+	}
+	return t
+}
+
+// target returns the target of a Yandex Cloud DNS record.
+func target(recordType, data string) string {
+	if hasHostData(recordType) {
+		return strings.TrimSuffix(data, ".")
+	}
+	return data
+}
@@ -0,0 +1,176 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package yandex
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"sigs.k8s.io/external-dns/endpoint"
+	"sigs.k8s.io/external-dns/internal/testutils"
+	"sigs.k8s.io/external-dns/plan"
+	"sigs.k8s.io/external-dns/provider"
+)
+
+type mockClient struct {
+	zones      []Zone
+	recordSets map[string][]RecordSet
+	upserts    map[string]RecordSetChanges
+}
+
+func (m *mockClient) ListZones(_ context.Context, _ string) ([]Zone, error) {
+	return m.zones, nil
+}
+
+func (m *mockClient) ListRecordSets(_ context.Context, zoneID string) ([]RecordSet, error) {
+	return m.recordSets[zoneID], nil
+}
+
+func (m *mockClient) UpsertRecordSets(_ context.Context, zoneID string, changes RecordSetChanges) error {
+	m.upserts[zoneID] = changes
+	return nil
+}
+
+func newMockClient() *mockClient {
+	return &mockClient{
+		zones: []Zone{
+			{ID: "public", Name: "example-com", Zone: "example.com.", PublicVisibility: &struct{}{}},
+			{ID: "private", Name: "internal", Zone: "internal.example.com.", PrivateVisibility: &PrivateVisibility{NetworkIDs: []string{"net1"}}},
+			{ID: "other", Name: "example-org", Zone: "example.org.", PublicVisibility: &struct{}{}},
+		},
+		recordSets: map[string][]RecordSet{
+			"public": {
+				{Name: "example.com.", Type: "SOA", TTL: 3600, Data: []string{"ns1.yandexcloud.net. mx.cloud.yandex.net. 1 10800 900 604800 86400"}},
+				{Name: "example.com.", Type: "NS", TTL: 3600, Data: []string{"ns1.yandexcloud.net.", "ns2.yandexcloud.net."}},
+				{Name: "example.com.", Type: "MX", TTL: 3600, Data: []string{"10 mail.example.com."}},
+				{Name: "www.example.com.", Type: "A", TTL: 300, Data: []string{"1.2.3.4", "5.6.7.8"}},
+				{Name: "alias.example.com.", Type: "CNAME", TTL: 600, Data: []string{"www.example.com."}},
+			},
+			"private": {
+				{Name: "db.internal.example.com.", Type: "A", TTL: 60, Data: []string{"10.0.0.1"}},
+			},
+		},
+		upserts: map[string]RecordSetChanges{},
+	}
+}
+
+func TestYandexRecords(t *testing.T) {
+	for _, tc := range []struct {
+		visibility string
+		expected   []*endpoint.Endpoint
+	}{
+		{
+			visibility: "",
+			expected: []*endpoint.Endpoint{
+				endpoint.NewEndpointWithTTL("example.com", endpoint.RecordTypeNS, 3600, "ns1.yandexcloud.net", "ns2.yandexcloud.net"),
+				endpoint.NewEndpointWithTTL("example.com", endpoint.RecordTypeMX, 3600, "10 mail.example.com"),
+				endpoint.NewEndpointWithTTL("www.example.com", endpoint.RecordTypeA, 300, "1.2.3.4", "5.6.7.8"),
+				endpoint.NewEndpointWithTTL("alias.example.com", endpoint.RecordTypeCNAME, 600, "www.example.com"),
+				endpoint.NewEndpointWithTTL("db.internal.example.com", endpoint.RecordTypeA, 60, "10.0.0.1"),
+			},
+		},
+		{
+			visibility: "private",
+			expected: []*endpoint.Endpoint{
+				endpoint.NewEndpointWithTTL("db.internal.example.com", endpoint.RecordTypeA, 60, "10.0.0.1"),
+			},
+		},
+	} {
+		t.Run(tc.visibility, func(t *testing.T) {
+			p := &YandexProvider{
+				client:         newMockClient(),
+				domainFilter:   endpoint.NewDomainFilter([]string{"example.com"}),
+				zoneTypeFilter: provider.NewZoneTypeFilter(tc.visibility),
+			}
+
+			records, err := p.Records(context.Background())
+			require.NoError(t, err)
+			assert.True(t, testutils.SameEndpoints(records, tc.expected), "actual and expected endpoints don't match. %s:%s", records, tc.expected)
+		})
+	}
+}
+
+func TestYandexApplyChanges(t *testing.T) {
+	client := newMockClient()
+	p := &YandexProvider{
+		client:         client,
+		domainFilter:   endpoint.NewDomainFilter([]string{"example.com"}),
+		zoneTypeFilter: provider.NewZoneTypeFilter(""),
+	}
+
+	err := p.ApplyChanges(context.Background(), &plan.Changes{
+		Create: []*endpoint.Endpoint{
+			endpoint.NewEndpoint("new.example.com", endpoint.RecordTypeCNAME, "www.example.com"),
+			endpoint.NewEndpointWithTTL("cache.internal.example.com", endpoint.RecordTypeA, 60, "10.0.0.2"),
+			endpoint.NewEndpoint("www.example.org", endpoint.RecordTypeA, "1.1.1.1"),
+		},
+		UpdateOld: []*endpoint.Endpoint{
+			endpoint.NewEndpointWithTTL("www.example.com", endpoint.RecordTypeA, 300, "1.2.3.4", "5.6.7.8"),
+		},
+		UpdateNew: []*endpoint.Endpoint{
+			endpoint.NewEndpointWithTTL("www.example.com", endpoint.RecordTypeA, 900, "1.2.3.4"),
+		},
+		Delete: []*endpoint.Endpoint{
+			endpoint.NewEndpointWithTTL("example.com", endpoint.RecordTypeMX, 3600, "10 mail.example.com"),
+		},
+	})
+	require.NoError(t, err)
+
+	assert.Equal(t, map[string]RecordSetChanges{
+		"public": {
+			Deletions: []RecordSet{{Name: "example.com.", Type: "MX", TTL: 3600, Data: []string{"10 mail.example.com."}}},
+			Replacements: []RecordSet{
+				{Name: "www.example.com.", Type: "A", TTL: 900, Data: []string{"1.2.3.4"}},
+				{Name: "new.example.com.", Type: "CNAME", TTL: defaultTTL, Data: []string{"www.example.com."}},
+			},
+		},
+		"private": {
+			Replacements: []RecordSet{{Name: "cache.internal.example.com.", Type: "A", TTL: 60, Data: []string{"10.0.0.2"}}},
+		},
+	}, client.upserts)
+}
+
+func TestYandexApplyChangesDryRun(t *testing.T) {
+	client := newMockClient()
+	p := &YandexProvider{
+		client:         client,
+		domainFilter:   endpoint.NewDomainFilter([]string{"example.com"}),
+		zoneTypeFilter: provider.NewZoneTypeFilter(""),
+		dryRun:         true,
+	}
+
+	err := p.ApplyChanges(context.Background(), &plan.Changes{
+		Create: []*endpoint.Endpoint{endpoint.NewEndpoint("new.example.com", endpoint.RecordTypeA, "1.2.3.4")},
+	})
+	require.NoError(t, err)
+	assert.Empty(t, client.upserts)
+}
+
+func TestNewYandexProvider(t *testing.T) {
+	_, err := NewYandexProvider(context.Background(), "", "", "", endpoint.NewDomainFilter(nil), false)
+	require.Error(t, err)
+
+	_, err = NewYandexProvider(context.Background(), "folder", "/does/not/exist.json", "", endpoint.NewDomainFilter(nil), false)
+	require.Error(t, err)
+
+	p, err := NewYandexProvider(context.Background(), "folder", "", "public", endpoint.NewDomainFilter(nil), false)
+	require.NoError(t, err)
+	assert.Equal(t, "folder", p.folderID)
+}
@@ -17,12 +17,76 @@ limitations under the License.
 package provider
 
 import (
+	"fmt"
+	"slices"
 	"strings"
+	"sync"
 
 	log "github.com/sirupsen/logrus"
 	"golang.org/x/net/idna"
 )
 
+// ZoneCollisionPolicy is what to do with a hostname whose longest matching zone name is configured
+// under several zone IDs.
+type ZoneCollisionPolicy string
+
+const (
+	// ZoneCollisionWarn uses the zone with the lowest ID.
+	ZoneCollisionWarn ZoneCollisionPolicy = "warn"
+	// ZoneCollisionFail finds no zone for the hostname, so that its endpoint is skipped.
+	ZoneCollisionFail ZoneCollisionPolicy = "fail"
+)
+
+// ZoneCollisions resolves the ambiguous zone matches of the ZoneFinders sharing it with its policy.
+// It warns once per zone name and set of zone IDs, and keeps the hostnames matched ambiguously
+// until they are taken, for Events to be recorded on their resources.
+type ZoneCollisions struct {
+	policy ZoneCollisionPolicy
+
+	mu        sync.Mutex
+	warned    map[string]bool
+	hostnames map[string]string
+}
+
+// NewZoneCollisions returns a ZoneCollisions resolving the ambiguous zone matches with policy.
+func NewZoneCollisions(policy ZoneCollisionPolicy) *ZoneCollisions {
+	return &ZoneCollisions{
+		policy:    policy,
+		warned:    map[string]bool{},
+		hostnames: map[string]string{},
+	}
+}
+
+// resolve returns the zone ID to use for hostname, matching zoneName under the sorted zoneIDs,
+// empty when the hostname is skipped.
+func (c *ZoneCollisions) resolve(hostname, zoneName string, zoneIDs []string) string {
+	zoneID, outcome, warning := zoneIDs[0], fmt.Sprintf("using %q", zoneIDs[0]), fmt.Sprintf("using %q for", zoneIDs[0])
+	if c.policy == ZoneCollisionFail {
+		zoneID, outcome, warning = "", "skipping it", "skipping"
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.hostnames[hostname] = fmt.Sprintf("Hostname %q matches zone %q under several zone IDs %v, %s", hostname, zoneName, zoneIDs, outcome)
+	key := zoneName + " " + strings.Join(zoneIDs, " ")
+	if !c.warned[key] {
+		c.warned[key] = true
+		log.Warnf("Zone %q is configured under several zone IDs %v, %s its hostnames such as %q", zoneName, zoneIDs, warning, hostname)
+	}
+	log.Debug(c.hostnames[hostname])
+	return zoneID
+}
+
+// Take returns the messages explaining how the hostnames matched ambiguously since the last call
+// were resolved, by hostname.
+func (c *ZoneCollisions) Take() map[string]string {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	hostnames := c.hostnames
+	c.hostnames = map[string]string{}
+	return hostnames
+}
+
 type ZoneIDName map[string]string
 
 func (z ZoneIDName) Add(zoneID, zoneName string) {
@@ -41,7 +105,20 @@ func (z ZoneIDName) Add(zoneID, zoneName string) {
 // SRV records as per RFC 2782, or TXT record for services) that are not
 // IDNA-aware and cannot represent non-ASCII labels. Skipping these labels
 // ensures compatibility with such use cases.
+//
+// When several zones match, the one with the longest name wins, so
+// "a.internal.example.com" goes to "internal.example.com" rather than
+// "example.com". If the longest name is configured under several zone IDs
+// the match is ambiguous: a warning is logged and the zone with the lowest ID
+// is returned. Providers resolve ambiguous matches with the policy they are
+// configured with through a ZoneFinder instead.
 func (z ZoneIDName) FindZone(hostname string) (suitableZoneID, suitableZoneName string) {
+	return z.findZone(hostname, nil)
+}
+
+// findZone finds the zone of hostname like FindZone, resolving the ambiguous matches with collisions
+// when set.
+func (z ZoneIDName) findZone(hostname string, collisions *ZoneCollisions) (suitableZoneID, suitableZoneName string) {
 	var name string
 	domain_labels := strings.Split(hostname, ".")
 	for i, label := range domain_labels {
@@ -57,13 +134,54 @@ func (z ZoneIDName) FindZone(hostname string) (suitableZoneID, suitableZoneName
 	}
 	name = strings.Join(domain_labels, ".")
 
+	var ambiguousZoneIDs []string
 	for zoneID, zoneName := range z {
-		if name == zoneName || strings.HasSuffix(name, "."+zoneName) {
-			if suitableZoneName == "" || len(zoneName) > len(suitableZoneName) {
-				suitableZoneID = zoneID
-				suitableZoneName = zoneName
-			}
+		if name != zoneName && !strings.HasSuffix(name, "."+zoneName) {
+			continue
 		}
+		switch {
+		case len(zoneName) > len(suitableZoneName):
+			suitableZoneID = zoneID
+			suitableZoneName = zoneName
+			ambiguousZoneIDs = nil
+		case zoneName == suitableZoneName:
+			ambiguousZoneIDs = append(ambiguousZoneIDs, zoneID)
+		}
+	}
+	if len(ambiguousZoneIDs) == 0 {
+		return
+	}
+
+	ambiguousZoneIDs = append(ambiguousZoneIDs, suitableZoneID)
+	slices.Sort(ambiguousZoneIDs)
+	if collisions == nil {
+		log.Warnf("Hostname %q matches zone %q under several zone IDs %v, using %q", hostname, suitableZoneName, ambiguousZoneIDs, ambiguousZoneIDs[0])
+		return ambiguousZoneIDs[0], suitableZoneName
 	}
-	return
+	if suitableZoneID = collisions.resolve(hostname, suitableZoneName, ambiguousZoneIDs); suitableZoneID == "" {
+		return "", ""
+	}
+	return suitableZoneID, suitableZoneName
+}
+
+// ZoneFinder finds the zones of hostnames among the zones of its ZoneIDName, resolving the ambiguous
+// matches with the policy of its ZoneCollisions.
+type ZoneFinder struct {
+	ZoneIDName
+	collisions *ZoneCollisions
+}
+
+// NewZoneFinder returns a ZoneFinder with no zones resolving the ambiguous matches with collisions,
+// or with the ZoneCollisionWarn policy when nil.
+func NewZoneFinder(collisions *ZoneCollisions) ZoneFinder {
+	if collisions == nil {
+		collisions = NewZoneCollisions(ZoneCollisionWarn)
+	}
+	return ZoneFinder{ZoneIDName: ZoneIDName{}, collisions: collisions}
+}
+
+// FindZone identifies the most suitable DNS zone for a given hostname as ZoneIDName.FindZone does,
+// resolving the ambiguous matches with the ZoneCollisions of the finder.
+func (f ZoneFinder) FindZone(hostname string) (suitableZoneID, suitableZoneName string) {
+	return f.findZone(hostname, f.collisions)
 }
@@ -17,11 +17,15 @@ limitations under the License.
 package provider
 
 import (
+	"context"
 	"testing"
 
 	log "github.com/sirupsen/logrus"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"sigs.k8s.io/external-dns/endpoint"
 	"sigs.k8s.io/external-dns/internal/testutils"
+	"sigs.k8s.io/external-dns/plan"
 )
 
 func TestZoneIDName(t *testing.T) {
@@ -81,3 +85,102 @@ func TestZoneIDName(t *testing.T) {
 
 	testutils.TestHelperLogContains("Failed to convert label '???' of hostname '???' to its Unicode form: idna: disallowed rune U+003F", hook, t)
 }
+
+func TestZoneIDNameAmbiguous(t *testing.T) {
+	z := ZoneIDName{}
+	z.Add("zone-b", "example.com")
+	z.Add("zone-a", "example.com")
+
+	hook := testutils.LogsUnderTestWithLogLevel(log.WarnLevel, t)
+
+	// same-named zones resolve to the lowest zone ID
+	zoneID, zoneName := z.FindZone("app.example.com")
+	assert.Equal(t, "example.com", zoneName)
+	assert.Equal(t, "zone-a", zoneID)
+	testutils.TestHelperLogContains(`Hostname "app.example.com" matches zone "example.com" under several zone IDs [zone-a zone-b], using "zone-a"`, hook, t)
+}
+
+func TestZoneFinderAmbiguous(t *testing.T) {
+	z := NewZoneFinder(nil)
+	z.Add("zone-b", "example.com")
+	z.Add("zone-a", "example.com")
+	z.Add("zone-c", "internal.example.com")
+
+	// the longest suffix wins over a shorter overlapping zone
+	zoneID, zoneName := z.FindZone("app.internal.example.com")
+	assert.Equal(t, "internal.example.com", zoneName)
+	assert.Equal(t, "zone-c", zoneID)
+
+	hook := testutils.LogsUnderTestWithLogLevel(log.WarnLevel, t)
+
+	// same-named zones resolve to the lowest zone ID
+	for range 10 {
+		zoneID, zoneName = z.FindZone("app.example.com")
+		assert.Equal(t, "example.com", zoneName)
+		assert.Equal(t, "zone-a", zoneID)
+	}
+	_, _ = z.FindZone("www.example.com")
+	// the collision is only warned about once
+	require.Len(t, hook.AllEntries(), 1)
+	assert.Equal(t, `Zone "example.com" is configured under several zone IDs [zone-a zone-b], using "zone-a" for its hostnames such as "app.example.com"`, hook.LastEntry().Message)
+
+	assert.Equal(t, map[string]string{
+		"app.example.com": `Hostname "app.example.com" matches zone "example.com" under several zone IDs [zone-a zone-b], using "zone-a"`,
+		"www.example.com": `Hostname "www.example.com" matches zone "example.com" under several zone IDs [zone-a zone-b], using "zone-a"`,
+	}, z.collisions.Take())
+	assert.Empty(t, z.collisions.Take())
+}
+
+func TestZoneFinderAmbiguousFail(t *testing.T) {
+	collisions := NewZoneCollisions(ZoneCollisionFail)
+	z := NewZoneFinder(collisions)
+	z.Add("zone-b", "example.com")
+	z.Add("zone-a", "example.com")
+	z.Add("zone-c", "internal.example.com")
+
+	hook := testutils.LogsUnderTestWithLogLevel(log.WarnLevel, t)
+
+	zoneID, zoneName := z.FindZone("app.example.com")
+	assert.Empty(t, zoneName)
+	assert.Empty(t, zoneID)
+	testutils.TestHelperLogContains(`Zone "example.com" is configured under several zone IDs [zone-a zone-b], skipping its hostnames such as "app.example.com"`, hook, t)
+	assert.Equal(t, map[string]string{
+		"app.example.com": `Hostname "app.example.com" matches zone "example.com" under several zone IDs [zone-a zone-b], skipping it`,
+	}, collisions.Take())
+
+	// unambiguous matches are unaffected
+	zoneID, _ = z.FindZone("app.internal.example.com")
+	assert.Equal(t, "zone-c", zoneID)
+
+	// the collisions are shared by the ZoneFinders of a provider, so it is still only warned about once
+	other := NewZoneFinder(collisions)
+	other.Add("zone-a", "example.com")
+	other.Add("zone-b", "example.com")
+	_, _ = other.FindZone("app.example.com")
+	require.Len(t, hook.AllEntries(), 1)
+}
+
+type zoneFindingProvider struct {
+	BaseProvider
+}
+
+func (p *zoneFindingProvider) Records(ctx context.Context) ([]*endpoint.Endpoint, error) {
+	return nil, nil
+}
+
+func (p *zoneFindingProvider) ApplyChanges(ctx context.Context, changes *plan.Changes) error {
+	return nil
+}
+
+func TestBaseProviderZoneCollisions(t *testing.T) {
+	p := &zoneFindingProvider{}
+	collisions := NewZoneCollisions(ZoneCollisionFail)
+	SetZoneCollisions(p, collisions)
+
+	z := p.NewZoneFinder()
+	z.Add("zone-a", "example.com")
+	z.Add("zone-b", "example.com")
+	zoneID, _ := z.FindZone("app.example.com")
+	assert.Empty(t, zoneID)
+	assert.Contains(t, collisions.Take(), "app.example.com")
+}
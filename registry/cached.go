@@ -0,0 +1,189 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package registry
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"maps"
+	"net"
+	"strings"
+	"time"
+
+	"github.com/miekg/dns"
+	"github.com/prometheus/client_golang/prometheus"
+	log "github.com/sirupsen/logrus"
+
+	"sigs.k8s.io/external-dns/endpoint"
+	"sigs.k8s.io/external-dns/pkg/metrics"
+	"sigs.k8s.io/external-dns/pkg/resolver"
+	"sigs.k8s.io/external-dns/plan"
+)
+
+var cachedRegistryRecordsCallsTotal = metrics.NewCounterVecWithOpts(
+	prometheus.CounterOpts{
+		Namespace: "external_dns",
+		Subsystem: "registry",
+		Name:      "cache_records_calls",
+		Help:      "Number of calls to the registry cache Records list.",
+	},
+	[]string{
+		"from_cache",
+	},
+)
+
+func init() {
+	metrics.RegisterMetric.MustRegister(cachedRegistryRecordsCallsTotal)
+}
+
+// ZoneSerialFunc returns the serial of the SOA record of a zone.
+type ZoneSerialFunc func(ctx context.Context, zone string) (uint32, error)
+
+// CachedRegistry caches the records of a registry, ownership included, so that they are not read again
+// from the provider on every synchronization. The cached records are used for at most maxStaleness, and
+// only as long as the serials of the SOA records of the given zones are unchanged, so that changes made to
+// the zones by others are picked up before then. Applying changes invalidates the cache.
+type CachedRegistry struct {
+	Registry
+	maxStaleness time.Duration
+	zones        []string
+	zoneSerial   ZoneSerialFunc
+
+	cache    []*endpoint.Endpoint
+	lastRead time.Time
+	serials  map[string]uint32
+}
+
+// NewCachedRegistry returns a CachedRegistry. The zone serials are read with zoneSerial, LookupZoneSerial when nil.
+func NewCachedRegistry(registry Registry, maxStaleness time.Duration, zones []string, zoneSerial ZoneSerialFunc) *CachedRegistry {
+	if zoneSerial == nil {
+		zoneSerial = LookupZoneSerial
+	}
+	return &CachedRegistry{
+		Registry:     registry,
+		maxStaleness: maxStaleness,
+		zones:        zones,
+		zoneSerial:   zoneSerial,
+	}
+}
+
+// Records returns the cached records while they are fresh, and reads them through the registry otherwise.
+func (c *CachedRegistry) Records(ctx context.Context) ([]*endpoint.Endpoint, error) {
+	serials, err := c.readSerials(ctx)
+	if err != nil {
+		log.Warnf("Registry cache: %v, not using the cached records", err)
+	} else if !c.lastRead.IsZero() && time.Since(c.lastRead) < c.maxStaleness {
+		if maps.Equal(serials, c.serials) {
+			log.Debug("Registry cache: using the cached records")
+			cachedRegistryRecordsCallsTotal.CounterVec.WithLabelValues("true").Inc()
+			return c.cache, nil
+		}
+		log.Info("Registry cache: the serial of a zone changed, refreshing the records")
+	}
+
+	records, err := c.Registry.Records(ctx)
+	if err != nil {
+		c.Reset()
+		return nil, err
+	}
+	cachedRegistryRecordsCallsTotal.CounterVec.WithLabelValues("false").Inc()
+	c.cache = records
+	c.lastRead = time.Now()
+	c.serials = serials
+	return records, nil
+}
+
+// ApplyChanges invalidates the cache before applying the changes through the registry.
+func (c *CachedRegistry) ApplyChanges(ctx context.Context, changes *plan.Changes) error {
+	if changes.HasChanges() {
+		c.Reset()
+	}
+	return c.Registry.ApplyChanges(ctx, changes)
+}
+
+// AdoptOwners forwards the adopted owners to the registry, when it supports it.
+func (c *CachedRegistry) AdoptOwners(ownerIDs []string) {
+	if adopter, ok := c.Registry.(OwnerAdopter); ok {
+		adopter.AdoptOwners(ownerIDs)
+	}
+}
+
+// TransferOwnership invalidates the cache before transferring the ownership through the registry.
+func (c *CachedRegistry) TransferOwnership(ctx context.Context, records []*endpoint.Endpoint, newOwnerID string) error {
+	transferrer, ok := c.Registry.(OwnershipTransferrer)
+	if !ok {
+		return errors.New("the registry does not support transferring ownership")
+	}
+	c.Reset()
+	return transferrer.TransferOwnership(ctx, records, newOwnerID)
+}
+
+// Reset invalidates the cache.
+func (c *CachedRegistry) Reset() {
+	c.cache = nil
+	c.lastRead = time.Time{}
+	c.serials = nil
+}
+
+func (c *CachedRegistry) readSerials(ctx context.Context) (map[string]uint32, error) {
+	serials := make(map[string]uint32, len(c.zones))
+	for _, zone := range c.zones {
+		serial, err := c.zoneSerial(ctx, zone)
+		if err != nil {
+			return nil, err
+		}
+		serials[zone] = serial
+	}
+	return serials, nil
+}
+
+// LookupZoneSerial returns the serial of the SOA record of the zone, as served by the first of its
+// authoritative name servers to answer, or by the configured resolver, see resolver.Default.
+func LookupZoneSerial(ctx context.Context, zone string) (uint32, error) {
+	r := resolver.Default()
+	// the configured resolver ignores the server, only named in the errors
+	servers := []string{"the resolver"}
+	if !r.Configured() {
+		nameServers, err := net.DefaultResolver.LookupNS(ctx, zone)
+		if err != nil {
+			return 0, fmt.Errorf("looking up the name servers of zone %q: %w", zone, err)
+		}
+		servers = servers[:0]
+		for _, ns := range nameServers {
+			servers = append(servers, net.JoinHostPort(strings.TrimSuffix(ns.Host, "."), "53"))
+		}
+	}
+
+	m := new(dns.Msg)
+	m.SetQuestion(dns.Fqdn(zone), dns.TypeSOA)
+	err := fmt.Errorf("zone %q has no name servers", zone)
+	for _, server := range servers {
+		var in *dns.Msg
+		in, _, err = r.ExchangeContext(ctx, m, server)
+		if err != nil {
+			continue
+		}
+		for _, rr := range in.Answer {
+			if soa, ok := rr.(*dns.SOA); ok {
+				return soa.Serial, nil
+			}
+		}
+		err = fmt.Errorf("no SOA record in the answer of %s", server)
+	}
+	return 0, fmt.Errorf("querying the SOA record of zone %q: %w", zone, err)
+}
@@ -0,0 +1,110 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package registry
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"sigs.k8s.io/external-dns/endpoint"
+	"sigs.k8s.io/external-dns/plan"
+)
+
+func TestCachedRegistryRecords(t *testing.T) {
+	stub := &stubRegistry{records: []*endpoint.Endpoint{
+		endpoint.NewEndpoint("foo.example.org", endpoint.RecordTypeA, "1.2.3.4"),
+	}}
+	r := NewCachedRegistry(stub, time.Hour, nil, nil)
+
+	for range 3 {
+		records, err := r.Records(context.Background())
+		require.NoError(t, err)
+		assert.Equal(t, stub.records, records)
+	}
+	assert.Equal(t, 1, stub.reads)
+
+	// Stale records are read again.
+	r.lastRead = time.Now().Add(-2 * time.Hour)
+	_, err := r.Records(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, 2, stub.reads)
+}
+
+func TestCachedRegistryApplyChanges(t *testing.T) {
+	stub := &stubRegistry{}
+	r := NewCachedRegistry(stub, time.Hour, nil, nil)
+
+	_, err := r.Records(context.Background())
+	require.NoError(t, err)
+
+	require.NoError(t, r.ApplyChanges(context.Background(), &plan.Changes{}))
+	_, err = r.Records(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, 1, stub.reads, "no changes keep the cache")
+
+	changes := &plan.Changes{Create: []*endpoint.Endpoint{endpoint.NewEndpoint("foo.example.org", endpoint.RecordTypeA, "1.2.3.4")}}
+	require.NoError(t, r.ApplyChanges(context.Background(), changes))
+	assert.Equal(t, changes, stub.applied)
+	_, err = r.Records(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, 2, stub.reads, "changes invalidate the cache")
+}
+
+func TestCachedRegistryZoneSerials(t *testing.T) {
+	serials := map[string]uint32{"example.org": 1, "example.com": 7}
+	var serialErr error
+	zoneSerial := func(_ context.Context, zone string) (uint32, error) {
+		return serials[zone], serialErr
+	}
+	stub := &stubRegistry{}
+	r := NewCachedRegistry(stub, time.Hour, []string{"example.org", "example.com"}, zoneSerial)
+
+	read := func() {
+		_, err := r.Records(context.Background())
+		require.NoError(t, err)
+	}
+
+	read()
+	read()
+	assert.Equal(t, 1, stub.reads)
+
+	serials["example.com"] = 8
+	read()
+	assert.Equal(t, 2, stub.reads, "a changed serial invalidates the cache")
+	read()
+	assert.Equal(t, 2, stub.reads)
+
+	serialErr = errors.New("timeout")
+	read()
+	assert.Equal(t, 3, stub.reads, "unknown serials do not use the cache")
+
+	serialErr = nil
+	read()
+	assert.Equal(t, 4, stub.reads, "records read without serials are not cached")
+	read()
+	assert.Equal(t, 4, stub.reads)
+}
+
+func TestCachedRegistryTransferOwnership(t *testing.T) {
+	r := NewCachedRegistry(&stubRegistry{}, time.Hour, nil, nil)
+	assert.EqualError(t, r.TransferOwnership(context.Background(), nil, "new-owner"), "the registry does not support transferring ownership")
+}
@@ -0,0 +1,282 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package registry
+
+import (
+	"context"
+	"errors"
+	"sort"
+	"strings"
+
+	"github.com/prometheus/client_golang/prometheus"
+	log "github.com/sirupsen/logrus"
+
+	"sigs.k8s.io/external-dns/endpoint"
+	"sigs.k8s.io/external-dns/pkg/metrics"
+	"sigs.k8s.io/external-dns/plan"
+	"sigs.k8s.io/external-dns/provider"
+)
+
+const (
+	// mismatchMissing counts records owned according to the primary registry only.
+	mismatchMissing = "missing"
+	// mismatchExtra counts records owned according to the secondary registry only.
+	mismatchExtra = "extra"
+	// mismatchOwner counts records owned by different owners according to each registry.
+	mismatchOwner = "owner"
+
+	// maxLoggedMismatches bounds the number of mismatching records named in the logs.
+	maxLoggedMismatches = 10
+)
+
+var (
+	secondaryMismatches = metrics.NewGaugeVecWithOpts(
+		prometheus.GaugeOpts{
+			Namespace: "external_dns",
+			Subsystem: "registry",
+			Name:      "secondary_mismatches",
+			Help:      "Number of records whose ownership differs between the primary and the secondary registry, by kind.",
+		},
+		[]string{"kind"},
+	)
+	secondaryErrorsTotal = metrics.NewCounterWithOpts(
+		prometheus.CounterOpts{
+			Namespace: "external_dns",
+			Subsystem: "registry",
+			Name:      "secondary_errors_total",
+			Help:      "Number of errors of the secondary registry.",
+		},
+	)
+)
+
+func init() {
+	metrics.RegisterMetric.MustRegister(secondaryMismatches)
+	metrics.RegisterMetric.MustRegister(secondaryErrorsTotal)
+}
+
+// DualRegistry runs two registries side by side, for the time of a migration from one to the other.
+// The primary registry owns the records: its ownership drives the plan and its changes are applied to
+// the provider. The secondary registry is written the same ownership, and its own is compared to the
+// primary one on each read, so that it can be checked before being made the primary. Failures of the
+// secondary registry are logged without failing the synchronization.
+type DualRegistry struct {
+	primary   Registry
+	secondary Registry
+	aesKey    []byte
+}
+
+// NewDualRegistry returns a DualRegistry. The secondary registry must have been created over a provider
+// returned by NewOwnershipRecordsProvider, so that the records are not changed twice. The AES key is that of
+// encrypted TXT records, to tell them apart from other TXT records.
+func NewDualRegistry(primary, secondary Registry, txtEncryptAESKey []byte) *DualRegistry {
+	return &DualRegistry{
+		primary:   primary,
+		secondary: secondary,
+		aesKey:    txtEncryptAESKey,
+	}
+}
+
+func (d *DualRegistry) GetDomainFilter() endpoint.DomainFilterInterface {
+	return d.primary.GetDomainFilter()
+}
+
+func (d *DualRegistry) OwnerID() string {
+	return d.primary.OwnerID()
+}
+
+// Records returns the records of the primary registry, after comparing their ownership with the secondary registry.
+// Records owned by this owner according to the primary registry only are written to the secondary registry.
+func (d *DualRegistry) Records(ctx context.Context) ([]*endpoint.Endpoint, error) {
+	records, err := d.primary.Records(ctx)
+	if err != nil {
+		return nil, err
+	}
+	// The ownership records of the secondary registry are not records to manage.
+	records = d.withoutOwnershipRecords(records)
+
+	secondaryRecords, err := d.secondary.Records(ctx)
+	if err != nil {
+		secondaryErrorsTotal.Counter.Inc()
+		log.Errorf("Failed to read the records of the secondary registry: %v", err)
+		return records, nil
+	}
+
+	missing := d.compare(records, d.withoutOwnershipRecords(secondaryRecords))
+	if len(missing) > 0 {
+		log.Infof("Writing the ownership of %d records to the secondary registry", len(missing))
+		if err := d.secondary.ApplyChanges(ctx, &plan.Changes{Create: missing}); err != nil {
+			secondaryErrorsTotal.Counter.Inc()
+			log.Errorf("Failed to write the ownership of records to the secondary registry: %v", err)
+		}
+	}
+
+	return records, nil
+}
+
+// compare reports the records whose ownership differs between both registries, and returns copies of the
+// records owned by this owner according to the primary registry only.
+func (d *DualRegistry) compare(primary, secondary []*endpoint.Endpoint) []*endpoint.Endpoint {
+	secondaryOwners := make(map[endpoint.EndpointKey]string, len(secondary))
+	for _, ep := range secondary {
+		// The labels of records not migrated yet to the DynamoDB registry are read from their TXT records.
+		if _, ok := ep.GetProviderSpecificProperty(dynamodbAttributeMigrate); ok {
+			continue
+		}
+		secondaryOwners[ep.Key()] = ep.Labels[endpoint.OwnerLabelKey]
+	}
+
+	mismatches := map[string][]string{}
+	var missing []*endpoint.Endpoint
+	for _, ep := range primary {
+		owner := ep.Labels[endpoint.OwnerLabelKey]
+		secondaryOwner := secondaryOwners[ep.Key()]
+		switch {
+		case owner == secondaryOwner:
+			continue
+		case secondaryOwner == "":
+			mismatches[mismatchMissing] = append(mismatches[mismatchMissing], ep.String())
+			if owner == d.OwnerID() {
+				missing = append(missing, ep.DeepCopy())
+			}
+		case owner == "":
+			mismatches[mismatchExtra] = append(mismatches[mismatchExtra], ep.String())
+		default:
+			mismatches[mismatchOwner] = append(mismatches[mismatchOwner], ep.String())
+		}
+	}
+
+	for _, kind := range []string{mismatchMissing, mismatchExtra, mismatchOwner} {
+		records := mismatches[kind]
+		secondaryMismatches.GaugeVec.WithLabelValues(kind).Set(float64(len(records)))
+		if len(records) == 0 {
+			continue
+		}
+		sort.Strings(records)
+		if len(records) > maxLoggedMismatches {
+			records = append(records[:maxLoggedMismatches], "...")
+		}
+		log.Warnf("%d records have a different ownership in the secondary registry (%s): %s", len(mismatches[kind]), kind, strings.Join(records, ", "))
+	}
+	return missing
+}
+
+// withoutOwnershipRecords drops the TXT records holding the labels of other records.
+func (d *DualRegistry) withoutOwnershipRecords(records []*endpoint.Endpoint) []*endpoint.Endpoint {
+	result := make([]*endpoint.Endpoint, 0, len(records))
+	for _, ep := range records {
+		if ep.RecordType == endpoint.RecordTypeTXT && len(ep.Targets) == 1 {
+			if _, err := endpoint.NewLabelsFromString(ep.Targets[0], d.aesKey); err == nil {
+				continue
+			}
+		}
+		result = append(result, ep)
+	}
+	return result
+}
+
+// ApplyChanges applies the changes through the primary registry, then writes them to the secondary registry.
+func (d *DualRegistry) ApplyChanges(ctx context.Context, changes *plan.Changes) error {
+	if err := d.primary.ApplyChanges(ctx, changes); err != nil {
+		return err
+	}
+	if err := d.secondary.ApplyChanges(ctx, changes); err != nil {
+		secondaryErrorsTotal.Counter.Inc()
+		log.Errorf("Failed to apply changes to the secondary registry: %v", err)
+	}
+	return nil
+}
+
+func (d *DualRegistry) AdjustEndpoints(endpoints []*endpoint.Endpoint) ([]*endpoint.Endpoint, error) {
+	return d.primary.AdjustEndpoints(endpoints)
+}
+
+// AdoptOwners forwards the adopted owners to both registries.
+func (d *DualRegistry) AdoptOwners(ownerIDs []string) {
+	for _, r := range []Registry{d.primary, d.secondary} {
+		if adopter, ok := r.(OwnerAdopter); ok {
+			adopter.AdoptOwners(ownerIDs)
+		}
+	}
+}
+
+// TransferOwnership transfers the ownership of the records in both registries, when they support it.
+func (d *DualRegistry) TransferOwnership(ctx context.Context, records []*endpoint.Endpoint, newOwnerID string) error {
+	transferrer, ok := d.primary.(OwnershipTransferrer)
+	if !ok {
+		return errors.New("the primary registry does not support transferring ownership")
+	}
+	if err := transferrer.TransferOwnership(ctx, records, newOwnerID); err != nil {
+		return err
+	}
+	if transferrer, ok := d.secondary.(OwnershipTransferrer); ok {
+		if err := transferrer.TransferOwnership(ctx, records, newOwnerID); err != nil {
+			secondaryErrorsTotal.Counter.Inc()
+			log.Errorf("Failed to transfer ownership in the secondary registry: %v", err)
+		}
+	}
+	return nil
+}
+
+// ownershipRecordsProvider is a provider letting only the ownership records of the TXT registry be changed.
+type ownershipRecordsProvider struct {
+	provider.Provider
+}
+
+// NewOwnershipRecordsProvider wraps the provider of a secondary registry, see NewDualRegistry: the changes to
+// records other than the TXT records of the TXT registry are dropped, as they are applied by the primary registry.
+func NewOwnershipRecordsProvider(p provider.Provider) provider.Provider {
+	return &ownershipRecordsProvider{Provider: p}
+}
+
+// Records returns copies of the records of the provider without labels, as the records can be shared with the
+// primary registry, for instance through the provider cache, which sets its own labels on them.
+func (p *ownershipRecordsProvider) Records(ctx context.Context) ([]*endpoint.Endpoint, error) {
+	records, err := p.Provider.Records(ctx)
+	if err != nil {
+		return nil, err
+	}
+	copies := make([]*endpoint.Endpoint, 0, len(records))
+	for _, r := range records {
+		c := r.DeepCopy()
+		c.Labels = endpoint.NewLabels()
+		copies = append(copies, c)
+	}
+	return copies, nil
+}
+
+func (p *ownershipRecordsProvider) ApplyChanges(ctx context.Context, changes *plan.Changes) error {
+	filtered := &plan.Changes{
+		Create:    ownershipRecords(changes.Create),
+		UpdateOld: ownershipRecords(changes.UpdateOld),
+		UpdateNew: ownershipRecords(changes.UpdateNew),
+		Delete:    ownershipRecords(changes.Delete),
+	}
+	if !filtered.HasChanges() {
+		return nil
+	}
+	return p.Provider.ApplyChanges(ctx, filtered)
+}
+
+func ownershipRecords(endpoints []*endpoint.Endpoint) []*endpoint.Endpoint {
+	var result []*endpoint.Endpoint
+	for _, ep := range endpoints {
+		if _, ok := ep.Labels[endpoint.OwnedRecordLabelKey]; ok {
+			result = append(result, ep)
+		}
+	}
+	return result
+}
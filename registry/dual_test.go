@@ -0,0 +1,169 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package registry
+
+import (
+	"context"
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"sigs.k8s.io/external-dns/endpoint"
+	"sigs.k8s.io/external-dns/plan"
+	"sigs.k8s.io/external-dns/provider/inmemory"
+)
+
+func ownersByName(records []*endpoint.Endpoint) map[string]string {
+	owners := map[string]string{}
+	for _, r := range records {
+		if r.RecordType != endpoint.RecordTypeTXT {
+			owners[r.DNSName] = r.Labels[endpoint.OwnerLabelKey]
+		}
+	}
+	return owners
+}
+
+func TestDualRegistry(t *testing.T) {
+	ctx := context.Background()
+	p := inmemory.NewInMemoryProvider()
+	require.NoError(t, p.CreateZone(testZone))
+
+	primary, err := NewTXTRegistry(p, "", "", "owner", 0, "", []string{}, []string{}, false, nil, false)
+	require.NoError(t, err)
+	secondary, err := NewTXTRegistry(NewOwnershipRecordsProvider(p), "new-", "", "owner", 0, "", []string{}, []string{}, false, nil, false)
+	require.NoError(t, err)
+	dual := NewDualRegistry(primary, secondary, nil)
+
+	// A record owned before the secondary registry was set up.
+	_, err = primary.Records(ctx)
+	require.NoError(t, err)
+	require.NoError(t, primary.ApplyChanges(ctx, &plan.Changes{
+		Create: []*endpoint.Endpoint{newEndpointWithOwner("foo.test-zone.example.org", "1.2.3.4", endpoint.RecordTypeA, "")},
+	}))
+
+	records, err := dual.Records(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, map[string]string{"foo.test-zone.example.org": "owner"}, ownersByName(records))
+	assert.InDelta(t, 1, testutil.ToFloat64(secondaryMismatches.GaugeVec.WithLabelValues(mismatchMissing)), 0)
+
+	// The ownership of the record was written to the secondary registry.
+	secondaryRecords, err := secondary.Records(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, map[string]string{"foo.test-zone.example.org": "owner"}, ownersByName(secondaryRecords))
+
+	// New records are created once, and owned in both registries.
+	require.NoError(t, dual.ApplyChanges(ctx, &plan.Changes{
+		Create: []*endpoint.Endpoint{newEndpointWithOwner("bar.test-zone.example.org", "5.6.7.8", endpoint.RecordTypeA, "")},
+	}))
+	secondaryRecords, err = secondary.Records(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, map[string]string{"foo.test-zone.example.org": "owner", "bar.test-zone.example.org": "owner"}, ownersByName(secondaryRecords))
+
+	records, err = dual.Records(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, map[string]string{"foo.test-zone.example.org": "owner", "bar.test-zone.example.org": "owner"}, ownersByName(records))
+	assert.InDelta(t, 0, testutil.ToFloat64(secondaryMismatches.GaugeVec.WithLabelValues(mismatchMissing)), 0)
+	for _, r := range records {
+		assert.NotEqual(t, endpoint.RecordTypeTXT, r.RecordType, "ownership record %s returned", r.DNSName)
+	}
+
+	// Deleted records lose their ownership records in both registries.
+	var bar *endpoint.Endpoint
+	for _, r := range records {
+		if r.DNSName == "bar.test-zone.example.org" {
+			bar = r
+		}
+	}
+	require.NotNil(t, bar)
+	require.NoError(t, dual.ApplyChanges(ctx, &plan.Changes{Delete: []*endpoint.Endpoint{bar}}))
+
+	all, err := p.Records(ctx)
+	require.NoError(t, err)
+	var names []string
+	for _, r := range all {
+		names = append(names, r.DNSName)
+	}
+	assert.ElementsMatch(t, []string{
+		"foo.test-zone.example.org",
+		"foo.test-zone.example.org",
+		"a-foo.test-zone.example.org",
+		"new-foo.test-zone.example.org",
+		"new-a-foo.test-zone.example.org",
+	}, names)
+}
+
+func TestDualRegistryOwnerMismatch(t *testing.T) {
+	primary := &stubRegistry{records: []*endpoint.Endpoint{
+		newEndpointWithOwner("foo.test-zone.example.org", "1.2.3.4", endpoint.RecordTypeA, "owner"),
+		newEndpointWithOwner("bar.test-zone.example.org", "1.2.3.4", endpoint.RecordTypeA, ""),
+	}}
+	secondary := &stubRegistry{records: []*endpoint.Endpoint{
+		newEndpointWithOwner("foo.test-zone.example.org", "1.2.3.4", endpoint.RecordTypeA, "other"),
+		newEndpointWithOwner("bar.test-zone.example.org", "1.2.3.4", endpoint.RecordTypeA, "other"),
+	}}
+
+	_, err := NewDualRegistry(primary, secondary, nil).Records(context.Background())
+	require.NoError(t, err)
+	assert.InDelta(t, 1, testutil.ToFloat64(secondaryMismatches.GaugeVec.WithLabelValues(mismatchOwner)), 0)
+	assert.InDelta(t, 1, testutil.ToFloat64(secondaryMismatches.GaugeVec.WithLabelValues(mismatchExtra)), 0)
+	// Records owned by another owner are not written to the secondary registry.
+	assert.Nil(t, secondary.applied)
+}
+
+func TestOwnershipRecordsProvider(t *testing.T) {
+	ctx := context.Background()
+	p := inmemory.NewInMemoryProvider()
+	require.NoError(t, p.CreateZone(testZone))
+
+	txt := newEndpointWithOwner("foo.test-zone.example.org", "\"heritage=external-dns,external-dns/owner=owner\"", endpoint.RecordTypeTXT, "")
+	txt.Labels[endpoint.OwnedRecordLabelKey] = "foo.test-zone.example.org"
+	require.NoError(t, NewOwnershipRecordsProvider(p).ApplyChanges(ctx, &plan.Changes{
+		Create: []*endpoint.Endpoint{
+			newEndpointWithOwner("foo.test-zone.example.org", "1.2.3.4", endpoint.RecordTypeA, "owner"),
+			txt,
+		},
+	}))
+
+	records, err := NewOwnershipRecordsProvider(p).Records(ctx)
+	require.NoError(t, err)
+	require.Len(t, records, 1)
+	assert.Equal(t, endpoint.RecordTypeTXT, records[0].RecordType)
+	assert.Empty(t, records[0].Labels)
+}
+
+type stubRegistry struct {
+	NoopRegistry
+	records []*endpoint.Endpoint
+	applied *plan.Changes
+	reads   int
+}
+
+func (r *stubRegistry) Records(context.Context) ([]*endpoint.Endpoint, error) {
+	r.reads++
+	return r.records, nil
+}
+
+func (r *stubRegistry) ApplyChanges(_ context.Context, changes *plan.Changes) error {
+	r.applied = changes
+	return nil
+}
+
+func (r *stubRegistry) OwnerID() string {
+	return "owner"
+}
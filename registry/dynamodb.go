@@ -21,6 +21,7 @@ import (
 	b64 "encoding/base64"
 	"errors"
 	"fmt"
+	"strconv"
 	"strings"
 	"time"
 
@@ -41,6 +42,10 @@ type DynamoDBAPI interface {
 	DescribeTable(context.Context, *dynamodb.DescribeTableInput, ...func(*dynamodb.Options)) (*dynamodb.DescribeTableOutput, error)
 	Scan(context.Context, *dynamodb.ScanInput, ...func(*dynamodb.Options)) (*dynamodb.ScanOutput, error)
 	BatchExecuteStatement(context.Context, *dynamodb.BatchExecuteStatementInput, ...func(*dynamodb.Options)) (*dynamodb.BatchExecuteStatementOutput, error)
+	CreateTable(context.Context, *dynamodb.CreateTableInput, ...func(*dynamodb.Options)) (*dynamodb.CreateTableOutput, error)
+	UpdateTable(context.Context, *dynamodb.UpdateTableInput, ...func(*dynamodb.Options)) (*dynamodb.UpdateTableOutput, error)
+	DescribeTimeToLive(context.Context, *dynamodb.DescribeTimeToLiveInput, ...func(*dynamodb.Options)) (*dynamodb.DescribeTimeToLiveOutput, error)
+	UpdateTimeToLive(context.Context, *dynamodb.UpdateTimeToLiveInput, ...func(*dynamodb.Options)) (*dynamodb.UpdateTimeToLiveOutput, error)
 }
 
 // DynamoDBRegistry implements registry interface with ownership implemented via an AWS DynamoDB table.
@@ -51,6 +56,14 @@ type DynamoDBRegistry struct {
 	dynamodbAPI DynamoDBAPI
 	table       string
 
+	// create the table with on-demand capacity if it does not exist, replicated to replicaRegions.
+	createTable    bool
+	replicaRegions []string
+
+	// when set, the items owned by us expire after itemTTL unless refreshed.
+	itemTTL  time.Duration
+	expiries map[endpoint.EndpointKey]int64
+
 	// For migration from TXT registry
 	mapper              nameMapper
 	wildcardReplacement string
@@ -73,14 +86,20 @@ const dynamodbAttributeMigrate = "dynamodb/needs-migration"
 // DynamoDB allows a maximum batch size of 25 items.
 var dynamodbMaxBatchSize uint8 = 25
 
+// dynamodbTableWaitTimeout bounds the wait for a created table or replica to become active.
+var dynamodbTableWaitTimeout = 10 * time.Minute
+
 // NewDynamoDBRegistry returns a new DynamoDBRegistry object.
-func NewDynamoDBRegistry(provider provider.Provider, ownerID string, dynamodbAPI DynamoDBAPI, table string, txtPrefix, txtSuffix, txtWildcardReplacement string, managedRecordTypes, excludeRecordTypes []string, txtEncryptAESKey []byte, cacheInterval time.Duration) (*DynamoDBRegistry, error) {
+func NewDynamoDBRegistry(provider provider.Provider, ownerID string, dynamodbAPI DynamoDBAPI, table string, txtPrefix, txtSuffix, txtWildcardReplacement string, managedRecordTypes, excludeRecordTypes []string, txtEncryptAESKey []byte, cacheInterval time.Duration, createTable bool, itemTTL time.Duration, replicaRegions []string) (*DynamoDBRegistry, error) {
 	if ownerID == "" {
 		return nil, errors.New("owner id cannot be empty")
 	}
 	if table == "" {
 		return nil, errors.New("table cannot be empty")
 	}
+	if itemTTL < 0 {
+		return nil, errors.New("item TTL cannot be negative")
+	}
 
 	if len(txtEncryptAESKey) == 0 {
 		txtEncryptAESKey = nil
@@ -94,13 +113,16 @@ func NewDynamoDBRegistry(provider provider.Provider, ownerID string, dynamodbAPI
 		return nil, errors.New("txt-prefix and txt-suffix are mutually exclusive")
 	}
 
-	mapper := newaffixNameMapper(txtPrefix, txtSuffix, txtWildcardReplacement)
+	mapper := newTemplatedNameMapper(txtPrefix, txtSuffix, txtWildcardReplacement, ownerID, provider.GetDomainFilter())
 
 	return &DynamoDBRegistry{
 		provider:            provider,
 		ownerID:             ownerID,
 		dynamodbAPI:         dynamodbAPI,
 		table:               table,
+		createTable:         createTable,
+		replicaRegions:      replicaRegions,
+		itemTTL:             itemTTL,
 		mapper:              mapper,
 		wildcardReplacement: txtWildcardReplacement,
 		managedRecordTypes:  managedRecordTypes,
@@ -133,6 +155,12 @@ func (im *DynamoDBRegistry) Records(ctx context.Context) ([]*endpoint.Endpoint,
 		}
 	}
 
+	if im.itemTTL > 0 {
+		if err := im.refreshExpiries(ctx); err != nil {
+			return nil, err
+		}
+	}
+
 	records, err := im.provider.Records(ctx)
 	if err != nil {
 		return nil, err
@@ -318,7 +346,7 @@ func (im *DynamoDBRegistry) ApplyChanges(ctx context.Context, changes *plan.Chan
 			context = fmt.Sprintf("inserting dynamodb record %q", record)
 		} else {
 			var record string
-			if err := attributevalue.Unmarshal(request.Parameters[1], &record); err != nil {
+			if err := attributevalue.Unmarshal(statementKey(request), &record); err != nil {
 				return fmt.Errorf("inserting dynamodb record: %w", err)
 			}
 			context = fmt.Sprintf("updating dynamodb record %q", record)
@@ -367,15 +395,13 @@ func (im *DynamoDBRegistry) AdjustEndpoints(endpoints []*endpoint.Endpoint) ([]*
 }
 
 func (im *DynamoDBRegistry) readLabels(ctx context.Context) error {
-	table, err := im.dynamodbAPI.DescribeTable(ctx, &dynamodb.DescribeTableInput{
-		TableName: aws.String(im.table),
-	})
+	table, err := im.describeTable(ctx)
 	if err != nil {
-		return fmt.Errorf("describing table %q: %w", im.table, err)
+		return err
 	}
 
 	foundKey := false
-	for _, def := range table.Table.AttributeDefinitions {
+	for _, def := range table.AttributeDefinitions {
 		if *def.AttributeName == "k" {
 			if def.AttributeType != dynamodbtypes.ScalarAttributeTypeS {
 				return fmt.Errorf("table %q attribute \"k\" must have type \"S\"", im.table)
@@ -387,21 +413,28 @@ func (im *DynamoDBRegistry) readLabels(ctx context.Context) error {
 		return fmt.Errorf("table %q must have attribute \"k\" of type \"S\"", im.table)
 	}
 
-	if *table.Table.KeySchema[0].AttributeName != "k" {
+	if *table.KeySchema[0].AttributeName != "k" {
 		return fmt.Errorf("table %q must have hash key \"k\"", im.table)
 	}
-	if len(table.Table.KeySchema) > 1 {
+	if len(table.KeySchema) > 1 {
 		return fmt.Errorf("table %q must not have a range key", im.table)
 	}
 
+	im.checkReplicas(table)
+
+	projection := "k,l"
+	if im.itemTTL > 0 {
+		projection = "k,l,e"
+	}
 	labels := map[endpoint.EndpointKey]endpoint.Labels{}
+	expiries := map[endpoint.EndpointKey]int64{}
 	scanPaginator := dynamodb.NewScanPaginator(im.dynamodbAPI, &dynamodb.ScanInput{
 		TableName:        aws.String(im.table),
 		FilterExpression: aws.String("o = :ownerval"),
 		ExpressionAttributeValues: map[string]dynamodbtypes.AttributeValue{
 			":ownerval": &dynamodbtypes.AttributeValueMemberS{Value: im.ownerID},
 		},
-		ProjectionExpression: aws.String("k,l"),
+		ProjectionExpression: aws.String(projection),
 		ConsistentRead:       aws.Bool(true),
 	})
 	for scanPaginator.HasMorePages() {
@@ -420,13 +453,206 @@ func (im *DynamoDBRegistry) readLabels(ctx context.Context) error {
 			}
 
 			labels[k] = l
+			if e, ok := item["e"]; ok {
+				var expiry int64
+				if err := attributevalue.Unmarshal(e, &expiry); err != nil {
+					return fmt.Errorf("querying dynamodb for expiry: %w", err)
+				}
+				expiries[k] = expiry
+			}
 		}
 	}
 
 	im.labels = labels
+	im.expiries = expiries
 	return nil
 }
 
+// describeTable describes the table, creating it first when it does not exist and createTable is set.
+func (im *DynamoDBRegistry) describeTable(ctx context.Context) (*dynamodbtypes.TableDescription, error) {
+	output, err := im.dynamodbAPI.DescribeTable(ctx, &dynamodb.DescribeTableInput{
+		TableName: aws.String(im.table),
+	})
+	if err == nil {
+		if im.itemTTL > 0 {
+			im.checkTimeToLive(ctx)
+		}
+		return output.Table, nil
+	}
+	var notFound *dynamodbtypes.ResourceNotFoundException
+	if !im.createTable || !errors.As(err, &notFound) {
+		return nil, fmt.Errorf("describing table %q: %w", im.table, err)
+	}
+
+	log.Infof("Creating dynamodb table %q with on-demand capacity", im.table)
+	_, err = im.dynamodbAPI.CreateTable(ctx, &dynamodb.CreateTableInput{
+		TableName:   aws.String(im.table),
+		BillingMode: dynamodbtypes.BillingModePayPerRequest,
+		AttributeDefinitions: []dynamodbtypes.AttributeDefinition{
+			{
+				AttributeName: aws.String("k"),
+				AttributeType: dynamodbtypes.ScalarAttributeTypeS,
+			},
+		},
+		KeySchema: []dynamodbtypes.KeySchemaElement{
+			{
+				AttributeName: aws.String("k"),
+				KeyType:       dynamodbtypes.KeyTypeHash,
+			},
+		},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("creating table %q: %w", im.table, err)
+	}
+	table, err := im.waitForTable(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if im.itemTTL > 0 {
+		_, err = im.dynamodbAPI.UpdateTimeToLive(ctx, &dynamodb.UpdateTimeToLiveInput{
+			TableName: aws.String(im.table),
+			TimeToLiveSpecification: &dynamodbtypes.TimeToLiveSpecification{
+				AttributeName: aws.String("e"),
+				Enabled:       aws.Bool(true),
+			},
+		})
+		if err != nil {
+			return nil, fmt.Errorf("enabling time to live on table %q: %w", im.table, err)
+		}
+	}
+
+	// DynamoDB adds a single replica to a global table at a time.
+	for _, region := range im.replicaRegions {
+		log.Infof("Adding replica of dynamodb table %q in region %q", im.table, region)
+		_, err = im.dynamodbAPI.UpdateTable(ctx, &dynamodb.UpdateTableInput{
+			TableName: aws.String(im.table),
+			ReplicaUpdates: []dynamodbtypes.ReplicationGroupUpdate{
+				{
+					Create: &dynamodbtypes.CreateReplicationGroupMemberAction{
+						RegionName: aws.String(region),
+					},
+				},
+			},
+		})
+		if err != nil {
+			return nil, fmt.Errorf("adding replica of table %q in region %q: %w", im.table, region, err)
+		}
+		if table, err = im.waitForTable(ctx); err != nil {
+			return nil, err
+		}
+	}
+	return table, nil
+}
+
+func (im *DynamoDBRegistry) waitForTable(ctx context.Context) (*dynamodbtypes.TableDescription, error) {
+	output, err := dynamodb.NewTableExistsWaiter(im.dynamodbAPI).WaitForOutput(ctx, &dynamodb.DescribeTableInput{
+		TableName: aws.String(im.table),
+	}, dynamodbTableWaitTimeout)
+	if err != nil {
+		return nil, fmt.Errorf("waiting for table %q to become active: %w", im.table, err)
+	}
+	return output.Table, nil
+}
+
+// checkTimeToLive warns when the expiry attribute of an existing table is not used as its time to live.
+func (im *DynamoDBRegistry) checkTimeToLive(ctx context.Context) {
+	output, err := im.dynamodbAPI.DescribeTimeToLive(ctx, &dynamodb.DescribeTimeToLiveInput{
+		TableName: aws.String(im.table),
+	})
+	if err != nil {
+		log.Warnf("Failed to describe time to live of dynamodb table %q: %v", im.table, err)
+		return
+	}
+	desc := output.TimeToLiveDescription
+	if desc == nil || aws.ToString(desc.AttributeName) != "e" ||
+		(desc.TimeToLiveStatus != dynamodbtypes.TimeToLiveStatusEnabled && desc.TimeToLiveStatus != dynamodbtypes.TimeToLiveStatusEnabling) {
+		log.Warnf("Time to live of dynamodb table %q is not enabled on attribute \"e\": expired ownership records will not be removed", im.table)
+	}
+}
+
+// checkReplicas logs the regions a global table is replicated to, and warns about the configured replica regions it is missing.
+func (im *DynamoDBRegistry) checkReplicas(table *dynamodbtypes.TableDescription) {
+	regions := sets.New[string]()
+	for _, replica := range table.Replicas {
+		regions.Insert(aws.ToString(replica.RegionName))
+	}
+	if regions.Len() > 0 {
+		log.Infof("Dynamodb table %q is a global table replicated in regions %v: ownership written by controllers in other regions is seen once replicated", im.table, sets.List(regions))
+	}
+	for _, region := range im.replicaRegions {
+		if !regions.Has(region) {
+			log.Warnf("Dynamodb table %q has no replica in region %q", im.table, region)
+		}
+	}
+}
+
+// refreshExpiries pushes back the expiry of the items owned by us before DynamoDB removes them,
+// and inserts again the items that were removed in the meantime.
+func (im *DynamoDBRegistry) refreshExpiries(ctx context.Context) error {
+	now := time.Now()
+	threshold := now.Add(im.itemTTL / 2).Unix()
+	expiry := now.Add(im.itemTTL).Unix()
+
+	statements := make([]dynamodbtypes.BatchStatementRequest, 0)
+	for key := range im.labels {
+		if im.expiries[key] < threshold {
+			statements = append(statements, dynamodbtypes.BatchStatementRequest{
+				Statement: aws.String(fmt.Sprintf("UPDATE %q SET \"e\"=? WHERE \"k\"=? AND \"o\"=?", im.table)),
+				Parameters: []dynamodbtypes.AttributeValue{
+					&dynamodbtypes.AttributeValueMemberN{Value: strconv.FormatInt(expiry, 10)},
+					toDynamoKey(key),
+					&dynamodbtypes.AttributeValueMemberS{Value: im.ownerID},
+				},
+			})
+			im.expiries[key] = expiry
+		}
+	}
+
+	var removed []endpoint.EndpointKey
+	err := im.executeStatements(ctx, statements, func(request dynamodbtypes.BatchStatementRequest, response dynamodbtypes.BatchStatementResponse) error {
+		if response.Error.Code == dynamodbtypes.BatchStatementErrorCodeEnumConditionalCheckFailed {
+			key, err := fromDynamoKey(statementKey(request))
+			if err != nil {
+				return err
+			}
+			removed = append(removed, key)
+			return nil
+		}
+		im.labels = nil
+		var record string
+		if err := attributevalue.Unmarshal(statementKey(request), &record); err != nil {
+			return fmt.Errorf("refreshing expiry of dynamodb record: %w", err)
+		}
+		return fmt.Errorf("refreshing expiry of dynamodb record %q: %s: %s", record, response.Error.Code, *response.Error.Message)
+	})
+	if err != nil || len(removed) == 0 {
+		return err
+	}
+
+	statements = make([]dynamodbtypes.BatchStatementRequest, 0, len(removed))
+	for _, key := range removed {
+		log.Infof("Dynamodb record for %v expired, inserting it again", key)
+		statements = im.appendInsert(statements, key, im.labels[key])
+	}
+	return im.executeStatements(ctx, statements, func(request dynamodbtypes.BatchStatementRequest, response dynamodbtypes.BatchStatementResponse) error {
+		key, err := fromDynamoKey(request.Parameters[0])
+		if err != nil {
+			return err
+		}
+		if response.Error.Code == dynamodbtypes.BatchStatementErrorCodeEnumDuplicateItem {
+			// Another owner took over the record after ours expired.
+			log.Warnf("Dynamodb record for %v expired and is now owned by another owner", key)
+		} else {
+			log.Warnf("Failed to insert again expired dynamodb record for %v: %s: %s", key, response.Error.Code, *response.Error.Message)
+		}
+		delete(im.labels, key)
+		delete(im.expiries, key)
+		im.recordsCache = nil
+		return nil
+	})
+}
+
 func fromDynamoKey(key dynamodbtypes.AttributeValue) (endpoint.EndpointKey, error) {
 	var ep string
 	if err := attributevalue.Unmarshal(key, &ep); err != nil {
@@ -466,7 +692,39 @@ func toDynamoLabels(labels endpoint.Labels) dynamodbtypes.AttributeValue {
 	return &dynamodbtypes.AttributeValueMemberM{Value: labelMap}
 }
 
+// statementKey returns the parameter holding the key of the item a statement applies to.
+func statementKey(request dynamodbtypes.BatchStatementRequest) dynamodbtypes.AttributeValue {
+	if setClause, _, found := strings.Cut(*request.Statement, " WHERE "); found && strings.HasPrefix(*request.Statement, "UPDATE") {
+		return request.Parameters[strings.Count(setClause, "?")]
+	}
+	return request.Parameters[0]
+}
+
+// expiry returns the expiry attribute of an item written now, and records it.
+func (im *DynamoDBRegistry) expiry(key endpoint.EndpointKey) dynamodbtypes.AttributeValue {
+	expiry := time.Now().Add(im.itemTTL).Unix()
+	if im.expiries == nil {
+		im.expiries = map[endpoint.EndpointKey]int64{}
+	}
+	im.expiries[key] = expiry
+	return &dynamodbtypes.AttributeValueMemberN{Value: strconv.FormatInt(expiry, 10)}
+}
+
 func (im *DynamoDBRegistry) appendInsert(statements []dynamodbtypes.BatchStatementRequest, key endpoint.EndpointKey, newL endpoint.Labels) []dynamodbtypes.BatchStatementRequest {
+	if im.itemTTL > 0 {
+		return append(statements, dynamodbtypes.BatchStatementRequest{
+			Statement:      aws.String(fmt.Sprintf("INSERT INTO %q VALUE {'k':?, 'o':?, 'l':?, 'e':?}", im.table)),
+			ConsistentRead: aws.Bool(true),
+			Parameters: []dynamodbtypes.AttributeValue{
+				toDynamoKey(key),
+				&dynamodbtypes.AttributeValueMemberS{
+					Value: im.ownerID,
+				},
+				toDynamoLabels(newL),
+				im.expiry(key),
+			},
+		})
+	}
 	return append(statements, dynamodbtypes.BatchStatementRequest{
 		Statement:      aws.String(fmt.Sprintf("INSERT INTO %q VALUE {'k':?, 'o':?, 'l':?}", im.table)),
 		ConsistentRead: aws.Bool(true),
@@ -494,6 +752,16 @@ func (im *DynamoDBRegistry) appendUpdate(statements []dynamodbtypes.BatchStateme
 		}
 	}
 
+	if im.itemTTL > 0 {
+		return append(statements, dynamodbtypes.BatchStatementRequest{
+			Statement: aws.String(fmt.Sprintf("UPDATE %q SET \"l\"=? SET \"e\"=? WHERE \"k\"=?", im.table)),
+			Parameters: []dynamodbtypes.AttributeValue{
+				toDynamoLabels(newE),
+				im.expiry(key),
+				toDynamoKey(key),
+			},
+		})
+	}
 	return append(statements, dynamodbtypes.BatchStatementRequest{
 		Statement: aws.String(fmt.Sprintf("UPDATE %q SET \"l\"=? WHERE \"k\"=?", im.table)),
 		Parameters: []dynamodbtypes.AttributeValue{
@@ -536,14 +804,8 @@ func (im *DynamoDBRegistry) executeStatements(ctx context.Context, statements []
 			if response.Error == nil {
 				op, _, _ := strings.Cut(*request.Statement, " ")
 				var key string
-				if op == "UPDATE" {
-					if err := attributevalue.Unmarshal(request.Parameters[1], &key); err != nil {
-						return err
-					}
-				} else {
-					if err := attributevalue.Unmarshal(request.Parameters[0], &key); err != nil {
-						return err
-					}
+				if err := attributevalue.Unmarshal(statementKey(request), &key); err != nil {
+					return err
 				}
 				log.Infof("%s dynamodb record %q", op, key)
 			} else {
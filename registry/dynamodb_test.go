@@ -18,6 +18,7 @@ package registry
 
 import (
 	"context"
+	"strconv"
 	"strings"
 	"testing"
 	"time"
@@ -39,31 +40,31 @@ import (
 func TestDynamoDBRegistryNew(t *testing.T) {
 	api, p := newDynamoDBAPIStub(t, nil)
 
-	_, err := NewDynamoDBRegistry(p, "test-owner", api, "test-table", "", "", "", []string{}, []string{}, []byte(""), time.Hour)
+	_, err := NewDynamoDBRegistry(p, "test-owner", api, "test-table", "", "", "", []string{}, []string{}, []byte(""), time.Hour, false, 0, nil)
 	require.NoError(t, err)
 
-	_, err = NewDynamoDBRegistry(p, "test-owner", api, "test-table", "testPrefix", "", "", []string{}, []string{}, []byte(""), time.Hour)
+	_, err = NewDynamoDBRegistry(p, "test-owner", api, "test-table", "testPrefix", "", "", []string{}, []string{}, []byte(""), time.Hour, false, 0, nil)
 	require.NoError(t, err)
 
-	_, err = NewDynamoDBRegistry(p, "test-owner", api, "test-table", "", "testSuffix", "", []string{}, []string{}, []byte(""), time.Hour)
+	_, err = NewDynamoDBRegistry(p, "test-owner", api, "test-table", "", "testSuffix", "", []string{}, []string{}, []byte(""), time.Hour, false, 0, nil)
 	require.NoError(t, err)
 
-	_, err = NewDynamoDBRegistry(p, "test-owner", api, "test-table", "", "", "testWildcard", []string{}, []string{}, []byte(""), time.Hour)
+	_, err = NewDynamoDBRegistry(p, "test-owner", api, "test-table", "", "", "testWildcard", []string{}, []string{}, []byte(""), time.Hour, false, 0, nil)
 	require.NoError(t, err)
 
-	_, err = NewDynamoDBRegistry(p, "test-owner", api, "test-table", "", "", "testWildcard", []string{}, []string{}, []byte(";k&l)nUC/33:{?d{3)54+,AD?]SX%yh^"), time.Hour)
+	_, err = NewDynamoDBRegistry(p, "test-owner", api, "test-table", "", "", "testWildcard", []string{}, []string{}, []byte(";k&l)nUC/33:{?d{3)54+,AD?]SX%yh^"), time.Hour, false, 0, nil)
 	require.NoError(t, err)
 
-	_, err = NewDynamoDBRegistry(p, "", api, "test-table", "", "", "", []string{}, []string{}, []byte(""), time.Hour)
+	_, err = NewDynamoDBRegistry(p, "", api, "test-table", "", "", "", []string{}, []string{}, []byte(""), time.Hour, false, 0, nil)
 	require.EqualError(t, err, "owner id cannot be empty")
 
-	_, err = NewDynamoDBRegistry(p, "test-owner", api, "", "", "", "", []string{}, []string{}, []byte(""), time.Hour)
+	_, err = NewDynamoDBRegistry(p, "test-owner", api, "", "", "", "", []string{}, []string{}, []byte(""), time.Hour, false, 0, nil)
 	require.EqualError(t, err, "table cannot be empty")
 
-	_, err = NewDynamoDBRegistry(p, "test-owner", api, "test-table", "", "", "", []string{}, []string{}, []byte(";k&l)nUC/33:{?d{3)54+,AD?]SX%yh^x"), time.Hour)
+	_, err = NewDynamoDBRegistry(p, "test-owner", api, "test-table", "", "", "", []string{}, []string{}, []byte(";k&l)nUC/33:{?d{3)54+,AD?]SX%yh^x"), time.Hour, false, 0, nil)
 	require.EqualError(t, err, "the AES Encryption key must be 32 bytes long, in either plain text or base64-encoded format")
 
-	_, err = NewDynamoDBRegistry(p, "test-owner", api, "test-table", "testPrefix", "testSuffix", "", []string{}, []string{}, []byte(""), time.Hour)
+	_, err = NewDynamoDBRegistry(p, "test-owner", api, "test-table", "testPrefix", "testSuffix", "", []string{}, []string{}, []byte(""), time.Hour, false, 0, nil)
 	require.EqualError(t, err, "txt-prefix and txt-suffix are mutually exclusive")
 }
 
@@ -96,7 +97,7 @@ func TestDynamoDBRegistryNew_EncryptionConfig(t *testing.T) {
 		},
 	}
 	for _, test := range tests {
-		actual, err := NewDynamoDBRegistry(p, "test-owner", api, "test-table", "", "", "", []string{}, []string{}, test.aesKeyRaw, time.Hour)
+		actual, err := NewDynamoDBRegistry(p, "test-owner", api, "test-table", "", "", "", []string{}, []string{}, test.aesKeyRaw, time.Hour, false, 0, nil)
 		if test.errorExpected {
 			require.Error(t, err)
 		} else {
@@ -152,7 +153,7 @@ func TestDynamoDBRegistryRecordsBadTable(t *testing.T) {
 			api, p := newDynamoDBAPIStub(t, nil)
 			tc.setup(&api.tableDescription)
 
-			r, _ := NewDynamoDBRegistry(p, "test-owner", api, "test-table", "", "", "", []string{}, []string{}, nil, time.Hour)
+			r, _ := NewDynamoDBRegistry(p, "test-owner", api, "test-table", "", "", "", []string{}, []string{}, nil, time.Hour, false, 0, nil)
 
 			_, err := r.Records(context.Background())
 			assert.EqualError(t, err, tc.expected)
@@ -160,6 +161,84 @@ func TestDynamoDBRegistryRecordsBadTable(t *testing.T) {
 	}
 }
 
+func TestDynamoDBRegistryRecordsMissingTable(t *testing.T) {
+	api, p := newDynamoDBAPIStub(t, nil)
+	api.tableMissing = true
+
+	r, _ := NewDynamoDBRegistry(p, "test-owner", api, "test-table", "", "", "", []string{}, []string{}, nil, time.Hour, false, 0, nil)
+
+	_, err := r.Records(context.Background())
+	assert.ErrorContains(t, err, "describing table \"test-table\"")
+	assert.Nil(t, api.createdTable)
+}
+
+func TestDynamoDBRegistryCreateTable(t *testing.T) {
+	api, p := newDynamoDBAPIStub(t, &DynamoDBStubConfig{})
+	api.tableMissing = true
+
+	r, _ := NewDynamoDBRegistry(p, "test-owner", api, "test-table", "", "", "", []string{}, []string{}, nil, time.Hour, true, 24*time.Hour, []string{"eu-west-1", "ap-south-1"})
+
+	_, err := r.Records(context.Background())
+	require.NoError(t, err)
+
+	require.NotNil(t, api.createdTable)
+	assert.Equal(t, dynamodbtypes.BillingModePayPerRequest, api.createdTable.BillingMode)
+	assert.Equal(t, "k", *api.createdTable.KeySchema[0].AttributeName)
+	assert.Equal(t, dynamodbtypes.KeyTypeHash, api.createdTable.KeySchema[0].KeyType)
+	require.NotNil(t, api.timeToLive)
+	assert.Equal(t, "e", *api.timeToLive.AttributeName)
+	assert.True(t, *api.timeToLive.Enabled)
+	assert.Equal(t, []string{"eu-west-1", "ap-south-1"}, api.replicaRegions)
+}
+
+func TestDynamoDBRegistryItemTTL(t *testing.T) {
+	api, p := newDynamoDBAPIStub(t, &DynamoDBStubConfig{
+		ExpectInsert: map[string]map[string]string{
+			"quux.test-zone.example.org#A#set-2": {endpoint.ResourceLabelKey: "ingress/default/quux-ingress"},
+		},
+		ExpectRefreshError: map[string]dynamodbtypes.BatchStatementErrorCodeEnum{
+			"quux.test-zone.example.org#A#set-2": dynamodbtypes.BatchStatementErrorCodeEnumConditionalCheckFailed,
+		},
+	})
+	api.timeToLive = &dynamodbtypes.TimeToLiveSpecification{AttributeName: aws.String("e"), Enabled: aws.Bool(true)}
+	api.expiries = map[string]int64{
+		"bar.test-zone.example.org#CNAME#":  time.Now().Add(20 * time.Hour).Unix(),
+		"baz.test-zone.example.org#A#set-1": time.Now().Add(time.Hour).Unix(),
+	}
+
+	r, _ := NewDynamoDBRegistry(p, "test-owner", api, "test-table", "", "", "", []string{}, []string{}, nil, 0, false, 24*time.Hour, nil)
+
+	_, err := r.Records(context.Background())
+	require.NoError(t, err)
+
+	assert.Equal(t, sets.New("baz.test-zone.example.org#A#set-1", "baz.test-zone.example.org#A#set-2", "quux.test-zone.example.org#A#set-2"), api.refreshed)
+	assert.Empty(t, api.stubConfig.ExpectInsert)
+	assert.Contains(t, r.labels, endpoint.EndpointKey{DNSName: "quux.test-zone.example.org", RecordType: endpoint.RecordTypeA, SetIdentifier: "set-2"})
+
+	// Refreshed expiries are not refreshed again until half of the TTL has elapsed.
+	api.refreshed = sets.New[string]()
+	_, err = r.Records(context.Background())
+	require.NoError(t, err)
+	assert.Empty(t, api.refreshed)
+}
+
+func TestDynamoDBRegistryItemTTLTakenOver(t *testing.T) {
+	api, p := newDynamoDBAPIStub(t, &DynamoDBStubConfig{
+		ExpectInsertError: map[string]dynamodbtypes.BatchStatementErrorCodeEnum{
+			"quux.test-zone.example.org#A#set-2": dynamodbtypes.BatchStatementErrorCodeEnumDuplicateItem,
+		},
+		ExpectRefreshError: map[string]dynamodbtypes.BatchStatementErrorCodeEnum{
+			"quux.test-zone.example.org#A#set-2": dynamodbtypes.BatchStatementErrorCodeEnumConditionalCheckFailed,
+		},
+	})
+
+	r, _ := NewDynamoDBRegistry(p, "test-owner", api, "test-table", "", "", "", []string{}, []string{}, nil, 0, false, 24*time.Hour, nil)
+
+	_, err := r.Records(context.Background())
+	require.NoError(t, err)
+	assert.NotContains(t, r.labels, endpoint.EndpointKey{DNSName: "quux.test-zone.example.org", RecordType: endpoint.RecordTypeA, SetIdentifier: "set-2"})
+}
+
 func TestDynamoDBRegistryRecords(t *testing.T) {
 	api, p := newDynamoDBAPIStub(t, nil)
 
@@ -238,7 +317,7 @@ func TestDynamoDBRegistryRecords(t *testing.T) {
 		},
 	}
 
-	r, _ := NewDynamoDBRegistry(p, "test-owner", api, "test-table", "txt.", "", "", []string{}, []string{}, nil, time.Hour)
+	r, _ := NewDynamoDBRegistry(p, "test-owner", api, "test-table", "txt.", "", "", []string{}, []string{}, nil, time.Hour, false, 0, nil)
 	_ = p.(*wrappedProvider).Provider.ApplyChanges(context.Background(), &plan.Changes{
 		Create: []*endpoint.Endpoint{
 			endpoint.NewEndpoint("migrate.test-zone.example.org", endpoint.RecordTypeA, "3.3.3.3").WithSetIdentifier("set-3"),
@@ -1078,7 +1157,7 @@ func TestDynamoDBRegistryApplyChanges(t *testing.T) {
 
 			ctx := context.Background()
 
-			r, _ := NewDynamoDBRegistry(p, "test-owner", api, "test-table", "txt.", "", "", []string{}, []string{}, nil, time.Hour)
+			r, _ := NewDynamoDBRegistry(p, "test-owner", api, "test-table", "txt.", "", "", []string{}, []string{}, nil, time.Hour, false, 0, nil)
 			_, err := r.Records(ctx)
 			require.Nil(t, err)
 
@@ -1115,14 +1194,22 @@ type DynamoDBStub struct {
 	stubConfig       *DynamoDBStubConfig
 	tableDescription dynamodbtypes.TableDescription
 	changesApplied   bool
+
+	tableMissing   bool
+	createdTable   *dynamodb.CreateTableInput
+	timeToLive     *dynamodbtypes.TimeToLiveSpecification
+	replicaRegions []string
+	expiries       map[string]int64
+	refreshed      sets.Set[string]
 }
 
 type DynamoDBStubConfig struct {
-	ExpectInsert      map[string]map[string]string
-	ExpectInsertError map[string]dynamodbtypes.BatchStatementErrorCodeEnum
-	ExpectUpdate      map[string]map[string]string
-	ExpectUpdateError map[string]dynamodbtypes.BatchStatementErrorCodeEnum
-	ExpectDelete      sets.Set[string]
+	ExpectInsert       map[string]map[string]string
+	ExpectInsertError  map[string]dynamodbtypes.BatchStatementErrorCodeEnum
+	ExpectUpdate       map[string]map[string]string
+	ExpectUpdateError  map[string]dynamodbtypes.BatchStatementErrorCodeEnum
+	ExpectDelete       sets.Set[string]
+	ExpectRefreshError map[string]dynamodbtypes.BatchStatementErrorCodeEnum
 }
 
 type wrappedProvider struct {
@@ -1140,6 +1227,7 @@ func newDynamoDBAPIStub(t *testing.T, stubConfig *DynamoDBStubConfig) (*DynamoDB
 	stub := &DynamoDBStub{
 		t:          t,
 		stubConfig: stubConfig,
+		refreshed:  sets.New[string](),
 		tableDescription: dynamodbtypes.TableDescription{
 			AttributeDefinitions: []dynamodbtypes.AttributeDefinition{
 				{
@@ -1174,11 +1262,58 @@ func newDynamoDBAPIStub(t *testing.T, stubConfig *DynamoDBStubConfig) (*DynamoDB
 func (r *DynamoDBStub) DescribeTable(ctx context.Context, input *dynamodb.DescribeTableInput, opts ...func(*dynamodb.Options)) (*dynamodb.DescribeTableOutput, error) {
 	assert.NotNil(r.t, ctx)
 	assert.Equal(r.t, "test-table", *input.TableName, "table name")
+	if r.tableMissing {
+		return nil, &dynamodbtypes.ResourceNotFoundException{Message: aws.String("testing error")}
+	}
 	return &dynamodb.DescribeTableOutput{
 		Table: &r.tableDescription,
 	}, nil
 }
 
+func (r *DynamoDBStub) CreateTable(ctx context.Context, input *dynamodb.CreateTableInput, opts ...func(*dynamodb.Options)) (*dynamodb.CreateTableOutput, error) {
+	assert.NotNil(r.t, ctx)
+	assert.True(r.t, r.tableMissing, "unexpected create of existing table")
+	r.createdTable = input
+	r.tableMissing = false
+	r.tableDescription = dynamodbtypes.TableDescription{
+		TableName:            input.TableName,
+		TableStatus:          dynamodbtypes.TableStatusActive,
+		AttributeDefinitions: input.AttributeDefinitions,
+		KeySchema:            input.KeySchema,
+	}
+	return &dynamodb.CreateTableOutput{TableDescription: &r.tableDescription}, nil
+}
+
+func (r *DynamoDBStub) UpdateTable(ctx context.Context, input *dynamodb.UpdateTableInput, opts ...func(*dynamodb.Options)) (*dynamodb.UpdateTableOutput, error) {
+	assert.NotNil(r.t, ctx)
+	assert.Equal(r.t, "test-table", *input.TableName, "table name")
+	assert.Len(r.t, input.ReplicaUpdates, 1)
+	region := *input.ReplicaUpdates[0].Create.RegionName
+	r.replicaRegions = append(r.replicaRegions, region)
+	r.tableDescription.Replicas = append(r.tableDescription.Replicas, dynamodbtypes.ReplicaDescription{RegionName: aws.String(region)})
+	return &dynamodb.UpdateTableOutput{TableDescription: &r.tableDescription}, nil
+}
+
+func (r *DynamoDBStub) DescribeTimeToLive(ctx context.Context, input *dynamodb.DescribeTimeToLiveInput, opts ...func(*dynamodb.Options)) (*dynamodb.DescribeTimeToLiveOutput, error) {
+	assert.NotNil(r.t, ctx)
+	assert.Equal(r.t, "test-table", *input.TableName, "table name")
+	desc := &dynamodbtypes.TimeToLiveDescription{TimeToLiveStatus: dynamodbtypes.TimeToLiveStatusDisabled}
+	if r.timeToLive != nil && *r.timeToLive.Enabled {
+		desc = &dynamodbtypes.TimeToLiveDescription{
+			AttributeName:    r.timeToLive.AttributeName,
+			TimeToLiveStatus: dynamodbtypes.TimeToLiveStatusEnabled,
+		}
+	}
+	return &dynamodb.DescribeTimeToLiveOutput{TimeToLiveDescription: desc}, nil
+}
+
+func (r *DynamoDBStub) UpdateTimeToLive(ctx context.Context, input *dynamodb.UpdateTimeToLiveInput, opts ...func(*dynamodb.Options)) (*dynamodb.UpdateTimeToLiveOutput, error) {
+	assert.NotNil(r.t, ctx)
+	assert.Equal(r.t, "test-table", *input.TableName, "table name")
+	r.timeToLive = input.TimeToLiveSpecification
+	return &dynamodb.UpdateTimeToLiveOutput{TimeToLiveSpecification: input.TimeToLiveSpecification}, nil
+}
+
 func (r *DynamoDBStub) Scan(ctx context.Context, input *dynamodb.ScanInput, opts ...func(*dynamodb.Options)) (*dynamodb.ScanOutput, error) {
 	assert.NotNil(r.t, ctx)
 	assert.Equal(r.t, "test-table", *input.TableName, "table name")
@@ -1187,9 +1322,9 @@ func (r *DynamoDBStub) Scan(ctx context.Context, input *dynamodb.ScanInput, opts
 	var owner string
 	assert.Nil(r.t, attributevalue.Unmarshal(input.ExpressionAttributeValues[":ownerval"], &owner))
 	assert.Equal(r.t, "test-owner", owner)
-	assert.Equal(r.t, "k,l", *input.ProjectionExpression)
+	assert.Contains(r.t, []string{"k,l", "k,l,e"}, *input.ProjectionExpression)
 	assert.True(r.t, *input.ConsistentRead)
-	return &dynamodb.ScanOutput{
+	output := &dynamodb.ScanOutput{
 		Items: []map[string]dynamodbtypes.AttributeValue{
 			{
 				"k": &dynamodbtypes.AttributeValueMemberS{Value: "bar.test-zone.example.org#CNAME#"},
@@ -1216,7 +1351,15 @@ func (r *DynamoDBStub) Scan(ctx context.Context, input *dynamodb.ScanInput, opts
 				}},
 			},
 		},
-	}, nil
+	}
+	if *input.ProjectionExpression == "k,l,e" {
+		for _, item := range output.Items {
+			if expiry, ok := r.expiries[item["k"].(*dynamodbtypes.AttributeValueMemberS).Value]; ok {
+				item["e"] = &dynamodbtypes.AttributeValueMemberN{Value: strconv.FormatInt(expiry, 10)}
+			}
+		}
+	}
+	return output, nil
 }
 
 func (r *DynamoDBStub) BatchExecuteStatement(context context.Context, input *dynamodb.BatchExecuteStatementInput, option ...func(*dynamodb.Options)) (*dynamodb.BatchExecuteStatementOutput, error) {
@@ -1243,8 +1386,11 @@ func (r *DynamoDBStub) BatchExecuteStatement(context context.Context, input *dyn
 
 			responses = append(responses, dynamodbtypes.BatchStatementResponse{})
 
-		case "INSERT INTO \"test-table\" VALUE {'k':?, 'o':?, 'l':?}":
+		case "INSERT INTO \"test-table\" VALUE {'k':?, 'o':?, 'l':?}", "INSERT INTO \"test-table\" VALUE {'k':?, 'o':?, 'l':?, 'e':?}":
 			assert.False(r.t, r.changesApplied, "unexpected insert after provider changes")
+			if len(statement.Parameters) > 3 {
+				r.assertExpiry(statement.Parameters[3])
+			}
 
 			var key string
 			assert.Nil(r.t, attributevalue.Unmarshal(statement.Parameters[0], &key))
@@ -1284,11 +1430,37 @@ func (r *DynamoDBStub) BatchExecuteStatement(context context.Context, input *dyn
 
 			responses = append(responses, dynamodbtypes.BatchStatementResponse{})
 
-		case "UPDATE \"test-table\" SET \"l\"=? WHERE \"k\"=?":
-			assert.False(r.t, r.changesApplied, "unexpected update after provider changes")
+		case "UPDATE \"test-table\" SET \"e\"=? WHERE \"k\"=? AND \"o\"=?":
+			r.assertExpiry(statement.Parameters[0])
 
 			var key string
 			assert.Nil(r.t, attributevalue.Unmarshal(statement.Parameters[1], &key))
+			r.refreshed.Insert(key)
+
+			var testOwner string
+			assert.Nil(r.t, attributevalue.Unmarshal(statement.Parameters[2], &testOwner))
+			assert.Equal(r.t, "test-owner", testOwner)
+
+			if code, exists := r.stubConfig.ExpectRefreshError[key]; exists {
+				delete(r.stubConfig.ExpectRefreshError, key)
+				responses = append(responses, dynamodbtypes.BatchStatementResponse{
+					Error: &dynamodbtypes.BatchStatementError{
+						Code:    code,
+						Message: aws.String("testing error"),
+					},
+				})
+				break
+			}
+			responses = append(responses, dynamodbtypes.BatchStatementResponse{})
+
+		case "UPDATE \"test-table\" SET \"l\"=? WHERE \"k\"=?", "UPDATE \"test-table\" SET \"l\"=? SET \"e\"=? WHERE \"k\"=?":
+			assert.False(r.t, r.changesApplied, "unexpected update after provider changes")
+			if len(statement.Parameters) > 2 {
+				r.assertExpiry(statement.Parameters[1])
+			}
+
+			var key string
+			assert.Nil(r.t, attributevalue.Unmarshal(statementKey(statement), &key))
 			if code, exists := r.stubConfig.ExpectUpdateError[key]; exists {
 				delete(r.stubConfig.ExpectInsertError, key)
 				responses = append(responses, dynamodbtypes.BatchStatementResponse{
@@ -1329,3 +1501,9 @@ func (r *DynamoDBStub) BatchExecuteStatement(context context.Context, input *dyn
 		Responses: responses,
 	}, nil
 }
+
+func (r *DynamoDBStub) assertExpiry(value dynamodbtypes.AttributeValue) {
+	var expiry int64
+	assert.Nil(r.t, attributevalue.Unmarshal(value, &expiry))
+	assert.InDelta(r.t, time.Now().Add(24*time.Hour).Unix(), expiry, 60, "expiry")
+}
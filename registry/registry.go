@@ -34,3 +34,15 @@ type Registry interface {
 	GetDomainFilter() endpoint.DomainFilterInterface
 	OwnerID() string
 }
+
+// OwnerAdopter is implemented by registries able to take over the records of other owners.
+type OwnerAdopter interface {
+	AdoptOwners(ownerIDs []string)
+}
+
+// OwnershipTransferrer is implemented by registries able to hand over records to another owner.
+type OwnershipTransferrer interface {
+	// TransferOwnership rewrites the ownership of the given records, as returned by Records, to the new owner.
+	// The records themselves are left untouched.
+	TransferOwnership(ctx context.Context, records []*endpoint.Endpoint, newOwnerID string) error
+}
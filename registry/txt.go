@@ -33,6 +33,8 @@ import (
 
 const (
 	recordTemplate              = "%{record_type}"
+	zoneTemplate                = "%{zone}"
+	ownerTemplate               = "%{owner}"
 	providerSpecificForceUpdate = "txt/force-update"
 )
 
@@ -60,6 +62,12 @@ type TXTRegistry struct {
 	txtEncryptAESKey  []byte
 
 	newFormatOnly bool
+
+	// owners whose records are managed as if owned by ownerID
+	adoptedOwnerIDs []string
+
+	// names of the registry TXT records seen when last listing records
+	txtRecords map[string]struct{}
 }
 
 // NewTXTRegistry returns a new TXTRegistry object. When newFormatOnly is true, it will only
@@ -91,7 +99,7 @@ func NewTXTRegistry(provider provider.Provider, txtPrefix, txtSuffix, ownerID st
 		return nil, errors.New("txt-prefix and txt-suffix are mutual exclusive")
 	}
 
-	mapper := newaffixNameMapper(txtPrefix, txtSuffix, txtWildcardReplacement)
+	mapper := newTemplatedNameMapper(txtPrefix, txtSuffix, txtWildcardReplacement, ownerID, provider.GetDomainFilter())
 
 	return &TXTRegistry{
 		provider:            provider,
@@ -130,63 +138,75 @@ func (im *TXTRegistry) Records(ctx context.Context) ([]*endpoint.Endpoint, error
 		return im.recordsCache, nil
 	}
 
-	records, err := im.provider.Records(ctx)
-	if err != nil {
-		return nil, err
-	}
-
 	endpoints := []*endpoint.Endpoint{}
 
-	labelMap := map[endpoint.EndpointKey]endpoint.Labels{}
+	// the labels of records are scoped to their DNS view, like their TXT records
+	labelMap := map[txtLabelsKey]endpoint.Labels{}
 	txtRecordsMap := map[string]struct{}{}
+	persistsLabels := provider.PersistsLabels(im.provider)
+
+	// the records are processed page by page, the TXT records being only kept as labels
+	err := provider.EachRecordsPage(ctx, im.provider, func(records []*endpoint.Endpoint) error {
+		for _, record := range records {
+			if record.RecordType != endpoint.RecordTypeTXT {
+				endpoints = append(endpoints, record)
+				continue
+			}
+			// We simply assume that TXT records for the registry will always have only one target.
+			// If there are no targets (e.g for routing policy based records in google), direct targets will be empty
+			if len(record.Targets) == 0 {
+				log.Errorf("TXT record has no targets %s", record.DNSName)
+				continue
+			}
+			labels, err := endpoint.NewLabelsFromString(record.Targets[0], im.txtEncryptAESKey)
+			if errors.Is(err, endpoint.ErrInvalidHeritage) {
+				// if no heritage is found or it is invalid
+				// case when value of txt record cannot be identified
+				// record will not be removed as it will have empty owner
+				endpoints = append(endpoints, record)
+				continue
+			}
+			if err != nil {
+				return err
+			}
 
-	for _, record := range records {
-		if record.RecordType != endpoint.RecordTypeTXT {
-			endpoints = append(endpoints, record)
-			continue
-		}
-		// We simply assume that TXT records for the registry will always have only one target.
-		// If there are no targets (e.g for routing policy based records in google), direct targets will be empty
-		if len(record.Targets) == 0 {
-			log.Errorf("TXT record has no targets %s", record.DNSName)
-			continue
-		}
-		labels, err := endpoint.NewLabelsFromString(record.Targets[0], im.txtEncryptAESKey)
-		if errors.Is(err, endpoint.ErrInvalidHeritage) {
-			// if no heritage is found or it is invalid
-			// case when value of txt record cannot be identified
-			// record will not be removed as it will have empty owner
-			endpoints = append(endpoints, record)
-			continue
-		}
-		if err != nil {
-			return nil, err
-		}
-
-		endpointName, recordType := im.mapper.toEndpointName(record.DNSName)
-		key := endpoint.EndpointKey{
-			DNSName:       endpointName,
-			RecordType:    recordType,
-			SetIdentifier: record.SetIdentifier,
+			endpointName, recordType := im.mapper.toEndpointName(record.DNSName)
+			key := txtLabelsKey{
+				EndpointKey: endpoint.EndpointKey{
+					DNSName:       endpointName,
+					RecordType:    recordType,
+					SetIdentifier: record.SetIdentifier,
+				},
+				view: record.DNSView(),
+			}
+			labelMap[key] = labels
+			txtRecordsMap[record.DNSName] = struct{}{}
 		}
-		labelMap[key] = labels
-		txtRecordsMap[record.DNSName] = struct{}{}
+		return nil
+	})
+	if err != nil {
+		return nil, err
 	}
 
 	for _, ep := range endpoints {
 		if ep.Labels == nil {
 			ep.Labels = endpoint.NewLabels()
 		}
+		// labels persisted by the provider take precedence over the TXT records
+		nativeLabels := persistsLabels && ep.Labels[endpoint.OwnerLabelKey] != ""
 		dnsNameSplit := strings.Split(ep.DNSName, ".")
 		// If specified, replace a leading asterisk in the generated txt record name with some other string
 		if im.wildcardReplacement != "" && dnsNameSplit[0] == "*" {
 			dnsNameSplit[0] = im.wildcardReplacement
 		}
 		dnsName := strings.Join(dnsNameSplit, ".")
-		key := endpoint.EndpointKey{
-			DNSName:       dnsName,
-			RecordType:    ep.RecordType,
-			SetIdentifier: ep.SetIdentifier,
+		key := txtLabelsKey{
+			EndpointKey: endpoint.EndpointKey{
+				DNSName:       dnsName,
+				RecordType:    ep.RecordType,
+				SetIdentifier: ep.SetIdentifier,
+			},
+			view: ep.DNSView(),
 		}
 
 		// AWS Alias records have "new" format encoded as type "cname"
@@ -200,12 +220,21 @@ func (im *TXTRegistry) Records(ctx context.Context) ([]*endpoint.Endpoint, error
 			key.RecordType = ""
 			labels, labelsExist = labelMap[key]
 		}
-		if labelsExist {
+		if labelsExist && !nativeLabels {
 			for k, v := range labels {
 				ep.Labels[k] = v
 			}
 		}
 
+		// Records only owned through TXT records are updated so that the provider persists their labels,
+		// the TXT records being removed along the way.
+		if persistsLabels {
+			if !nativeLabels && ep.Labels[endpoint.OwnerLabelKey] == im.ownerID && plan.IsManagedRecord(ep.RecordType, im.managedRecordTypes, im.excludeRecordTypes) {
+				ep.WithProviderSpecific(providerSpecificForceUpdate, "true")
+			}
+			continue
+		}
+
 		// Handle the migration of TXT records created before the new format (introduced in v0.12.0).
 		// The migration is done for the TXT records owned by this instance only.
 		if len(txtRecordsMap) > 0 && ep.Labels[endpoint.OwnerLabelKey] == im.ownerID {
@@ -221,6 +250,8 @@ func (im *TXTRegistry) Records(ctx context.Context) ([]*endpoint.Endpoint, error
 		}
 	}
 
+	im.txtRecords = txtRecordsMap
+
 	// Update the cache.
 	if im.cacheInterval > 0 {
 		im.recordsCache = endpoints
@@ -230,6 +261,12 @@ func (im *TXTRegistry) Records(ctx context.Context) ([]*endpoint.Endpoint, error
 	return endpoints, nil
 }
 
+// txtLabelsKey identifies the records whose labels a TXT record holds.
+type txtLabelsKey struct {
+	endpoint.EndpointKey
+	view string
+}
+
 // generateTXTRecord generates TXT records in either both formats (old and new) or new format only,
 // depending on the newFormatOnly configuration. The old format is maintained for backwards
 // compatibility but can be disabled to reduce the number of DNS records.
@@ -267,11 +304,15 @@ func (im *TXTRegistry) generateTXTRecord(r *endpoint.Endpoint) []*endpoint.Endpo
 // ApplyChanges updates dns provider with the changes
 // for each created/deleted record it will also take into account TXT records for creation/deletion
 func (im *TXTRegistry) ApplyChanges(ctx context.Context, changes *plan.Changes) error {
+	ownerIDs := append([]string{im.ownerID}, im.adoptedOwnerIDs...)
 	filteredChanges := &plan.Changes{
 		Create:    changes.Create,
-		UpdateNew: endpoint.FilterEndpointsByOwnerID(im.ownerID, changes.UpdateNew),
-		UpdateOld: endpoint.FilterEndpointsByOwnerID(im.ownerID, changes.UpdateOld),
-		Delete:    endpoint.FilterEndpointsByOwnerID(im.ownerID, changes.Delete),
+		UpdateNew: endpoint.FilterEndpointsByOwnerIDs(ownerIDs, changes.UpdateNew),
+		UpdateOld: endpoint.FilterEndpointsByOwnerIDs(ownerIDs, changes.UpdateOld),
+		Delete:    endpoint.FilterEndpointsByOwnerIDs(ownerIDs, changes.Delete),
+	}
+	if provider.PersistsLabels(im.provider) {
+		return im.applyChangesWithNativeLabels(ctx, filteredChanges)
 	}
 	for _, r := range filteredChanges.Create {
 		if r.Labels == nil {
@@ -324,6 +365,102 @@ func (im *TXTRegistry) ApplyChanges(ctx context.Context, changes *plan.Changes)
 	return im.provider.ApplyChanges(ctx, filteredChanges)
 }
 
+// applyChangesWithNativeLabels applies the changes through a provider persisting the labels itself.
+// No TXT record is created, the ones left from before are deleted along with their records.
+func (im *TXTRegistry) applyChangesWithNativeLabels(ctx context.Context, changes *plan.Changes) error {
+	var txtDeletes []*endpoint.Endpoint
+	for _, r := range changes.Create {
+		if r.Labels == nil {
+			r.Labels = make(map[string]string)
+		}
+		r.Labels[endpoint.OwnerLabelKey] = im.ownerID
+		if im.cacheInterval > 0 {
+			im.addToCache(r)
+		}
+	}
+	for _, r := range changes.Delete {
+		txtDeletes = append(txtDeletes, im.existingTXTRecords(r)...)
+		if im.cacheInterval > 0 {
+			im.removeFromCache(r)
+		}
+	}
+	for _, r := range changes.UpdateOld {
+		txtDeletes = append(txtDeletes, im.existingTXTRecords(r)...)
+		if im.cacheInterval > 0 {
+			im.removeFromCache(r)
+		}
+	}
+	for _, r := range changes.UpdateNew {
+		if im.cacheInterval > 0 {
+			im.addToCache(r)
+		}
+	}
+	changes.Delete = append(changes.Delete, txtDeletes...)
+
+	// when caching is enabled, disable the provider from using the cache
+	if im.cacheInterval > 0 {
+		ctx = context.WithValue(ctx, provider.RecordsContextKey, nil)
+	}
+	return im.provider.ApplyChanges(ctx, changes)
+}
+
+// existingTXTRecords returns the registry TXT records of the endpoint which were listed from the provider.
+func (im *TXTRegistry) existingTXTRecords(r *endpoint.Endpoint) []*endpoint.Endpoint {
+	var existing []*endpoint.Endpoint
+	for _, txt := range im.generateTXTRecord(r) {
+		if _, ok := im.txtRecords[txt.DNSName]; ok {
+			delete(im.txtRecords, txt.DNSName)
+			existing = append(existing, txt)
+		}
+	}
+	return existing
+}
+
+// AdoptOwners makes the registry apply changes to the records of the given owners, as it does to its own.
+// Their TXT records are rewritten to this owner when their records are updated.
+func (im *TXTRegistry) AdoptOwners(ownerIDs []string) {
+	im.adoptedOwnerIDs = ownerIDs
+}
+
+// TransferOwnership rewrites the TXT records of the given records to the new owner. With a provider
+// persisting the labels itself, the records are updated with their new labels instead.
+func (im *TXTRegistry) TransferOwnership(ctx context.Context, records []*endpoint.Endpoint, newOwnerID string) error {
+	changes := &plan.Changes{}
+	for _, r := range records {
+		old := r.DeepCopy()
+		old.DeleteProviderSpecificProperty(providerSpecificForceUpdate)
+		transferred := old.DeepCopy()
+		transferred.Labels[endpoint.OwnerLabelKey] = newOwnerID
+
+		if provider.PersistsLabels(im.provider) {
+			changes.UpdateOld = append(changes.UpdateOld, old)
+			changes.UpdateNew = append(changes.UpdateNew, transferred)
+		} else {
+			// both lists of TXT records have the same names in the same order, only their values differ
+			newTXTs := im.generateTXTRecord(transferred)
+			for i, txt := range im.generateTXTRecord(old) {
+				if _, ok := im.txtRecords[txt.DNSName]; ok {
+					changes.UpdateOld = append(changes.UpdateOld, txt)
+					changes.UpdateNew = append(changes.UpdateNew, newTXTs[i])
+				} else {
+					changes.Create = append(changes.Create, newTXTs[i])
+				}
+			}
+		}
+
+		if im.cacheInterval > 0 {
+			im.removeFromCache(r)
+			im.addToCache(transferred)
+		}
+	}
+
+	// when caching is enabled, disable the provider from using the cache
+	if im.cacheInterval > 0 {
+		ctx = context.WithValue(ctx, provider.RecordsContextKey, nil)
+	}
+	return im.provider.ApplyChanges(ctx, changes)
+}
+
 // AdjustEndpoints modifies the endpoints as needed by the specific provider
 func (im *TXTRegistry) AdjustEndpoints(endpoints []*endpoint.Endpoint) ([]*endpoint.Endpoint, error) {
 	return im.provider.AdjustEndpoints(endpoints)
@@ -345,6 +482,9 @@ type affixNameMapper struct {
 	prefix              string
 	suffix              string
 	wildcardReplacement string
+	// zones are the zones '%{zone}' expands to: the longest one a name belongs to, or the parent
+	// domain of names belonging to none of them.
+	zones []string
 }
 
 var _ nameMapper = affixNameMapper{}
@@ -353,6 +493,49 @@ func newaffixNameMapper(prefix, suffix, wildcardReplacement string) affixNameMap
 	return affixNameMapper{prefix: strings.ToLower(prefix), suffix: strings.ToLower(suffix), wildcardReplacement: strings.ToLower(wildcardReplacement)}
 }
 
+// newTemplatedNameMapper returns a name mapper where '%{owner}' expands to ownerID, and '%{zone}'
+// to the domains of domainFilter the names belong to.
+func newTemplatedNameMapper(prefix, suffix, wildcardReplacement, ownerID string, domainFilter endpoint.DomainFilterInterface) affixNameMapper {
+	mapper := newaffixNameMapper(strings.ReplaceAll(prefix, ownerTemplate, ownerID), strings.ReplaceAll(suffix, ownerTemplate, ownerID), wildcardReplacement)
+	if df, ok := domainFilter.(endpoint.DomainFilter); ok {
+		for _, zone := range df.Filters {
+			mapper.zones = append(mapper.zones, strings.TrimPrefix(zone, "."))
+		}
+	}
+	return mapper
+}
+
+func (pr affixNameMapper) zoneInAffix() bool {
+	return strings.Contains(pr.prefix, zoneTemplate) || strings.Contains(pr.suffix, zoneTemplate)
+}
+
+// zoneOf returns the zone name belongs to.
+func (pr affixNameMapper) zoneOf(name string) string {
+	name = strings.TrimSuffix(name, ".")
+	zone := ""
+	for _, z := range pr.zones {
+		if (name == z || strings.HasSuffix(name, "."+z)) && len(z) > len(zone) {
+			zone = z
+		}
+	}
+	if zone != "" {
+		return zone
+	}
+	if _, parent, ok := strings.Cut(name, "."); ok {
+		return parent
+	}
+	return name
+}
+
+// inZone returns the mapper of the names of zone, '%{zone}' being replaced by the zone name
+// with its dots replaced by dashes, so that it stays within a label.
+func (pr affixNameMapper) inZone(zone string) affixNameMapper {
+	label := strings.ReplaceAll(zone, ".", "-")
+	pr.prefix = strings.ReplaceAll(pr.prefix, zoneTemplate, label)
+	pr.suffix = strings.ReplaceAll(pr.suffix, zoneTemplate, label)
+	return pr
+}
+
 // extractRecordTypeDefaultPosition extracts record type from the default position
 // when not using '%{record_type}' in the prefix/suffix
 func extractRecordTypeDefaultPosition(name string) (baseName, recordType string) {
@@ -417,6 +600,18 @@ func (pr affixNameMapper) isSuffix() bool {
 func (pr affixNameMapper) toEndpointName(txtDNSName string) (endpointName string, recordType string) {
 	lowerDNSName := strings.ToLower(txtDNSName)
 
+	if pr.zoneInAffix() {
+		// the zone of the endpoint is one of the parent domains of its TXT record
+		for zone := lowerDNSName; zone != ""; {
+			name, rType := pr.inZone(zone).toEndpointName(lowerDNSName)
+			if name != "" && !strings.HasPrefix(name, ".") && pr.zoneOf(name) == zone {
+				return name, rType
+			}
+			_, zone, _ = strings.Cut(zone, ".")
+		}
+		return "", ""
+	}
+
 	// drop prefix
 	if pr.isPrefix() {
 		return pr.dropAffixExtractType(lowerDNSName)
@@ -438,6 +633,9 @@ func (pr affixNameMapper) toEndpointName(txtDNSName string) (endpointName string
 }
 
 func (pr affixNameMapper) toTXTName(endpointDNSName string) string {
+	if pr.zoneInAffix() {
+		return pr.inZone(pr.zoneOf(endpointDNSName)).toTXTName(endpointDNSName)
+	}
 	DNSName := strings.SplitN(endpointDNSName, ".", 2)
 
 	prefix := pr.dropAffixTemplate(pr.prefix)
@@ -471,6 +669,9 @@ func (pr affixNameMapper) normalizeAffixTemplate(afix, recordType string) string
 }
 
 func (pr affixNameMapper) toNewTXTName(endpointDNSName, recordType string) string {
+	if pr.zoneInAffix() {
+		return pr.inZone(pr.zoneOf(endpointDNSName)).toNewTXTName(endpointDNSName, recordType)
+	}
 	DNSName := strings.SplitN(endpointDNSName, ".", 2)
 	recordType = strings.ToLower(recordType)
 	recordT := recordType + "-"
@@ -506,7 +707,7 @@ func (im *TXTRegistry) removeFromCache(ep *endpoint.Endpoint) {
 	}
 
 	for i, e := range im.recordsCache {
-		if e.DNSName == ep.DNSName && e.RecordType == ep.RecordType && e.SetIdentifier == ep.SetIdentifier && e.Targets.Same(ep.Targets) {
+		if e.DNSName == ep.DNSName && e.RecordType == ep.RecordType && e.SetIdentifier == ep.SetIdentifier && e.DNSView() == ep.DNSView() && e.Targets.Same(ep.Targets) {
 			// We found a match delete the endpoint from the cache.
 			im.recordsCache = append(im.recordsCache[:i], im.recordsCache[i+1:]...)
 			return
@@ -18,7 +18,9 @@ package registry
 
 import (
 	"context"
+	"errors"
 	"reflect"
+	"strconv"
 	"strings"
 	"testing"
 	"time"
@@ -1400,6 +1402,41 @@ func TestToEndpointNameNewTXT(t *testing.T) {
 			recordType: "A",
 			txtDomain:  "example.fooa.bar.com",
 		},
+		{
+			name:       "zone templated prefix",
+			mapper:     newTemplatedNameMapper("%{zone}-%{record_type}-", "", "", "owner", endpoint.NewDomainFilter([]string{"example.com"})),
+			domain:     "www.sub.example.com",
+			recordType: "A",
+			txtDomain:  "example-com-a-www.sub.example.com",
+		},
+		{
+			name:       "zone templated prefix outside of the domain filter",
+			mapper:     newTemplatedNameMapper("%{zone}-%{record_type}-", "", "", "owner", endpoint.NewDomainFilter([]string{"example.org"})),
+			domain:     "www.sub.example.com",
+			recordType: "A",
+			txtDomain:  "sub-example-com-a-www.sub.example.com",
+		},
+		{
+			name:       "zone templated prefix with dots",
+			mapper:     newTemplatedNameMapper("_owner.%{zone}.", "", "", "owner", endpoint.NewDomainFilter([]string{"example.com"})),
+			domain:     "www.example.com",
+			recordType: "CNAME",
+			txtDomain:  "_owner.example-com.cname-www.example.com",
+		},
+		{
+			name:       "zone templated suffix",
+			mapper:     newTemplatedNameMapper("", "-%{zone}", "", "owner", endpoint.NewDomainFilter([]string{"example.com", "sub.example.com"})),
+			domain:     "www.sub.example.com",
+			recordType: "AAAA",
+			txtDomain:  "aaaa-www-sub-example-com.sub.example.com",
+		},
+		{
+			name:       "owner templated prefix",
+			mapper:     newTemplatedNameMapper("%{owner}-%{record_type}-", "", "", "Team1", nil),
+			domain:     "www.example.com",
+			recordType: "A",
+			txtDomain:  "team1-a-www.example.com",
+		},
 	}
 
 	for _, tc := range tests {
@@ -1413,6 +1450,19 @@ func TestToEndpointNameNewTXT(t *testing.T) {
 	}
 }
 
+func TestToEndpointNameZoneTemplate(t *testing.T) {
+	mapper := newTemplatedNameMapper("%{zone}-%{record_type}-", "", "", "owner", endpoint.NewDomainFilter([]string{"example.com", "sub.example.com"}))
+
+	// records of other zones are not mistaken for the ones of the zone
+	name, _ := mapper.toEndpointName("example-com-a-www.sub.example.com")
+	assert.Empty(t, name)
+	name, recordType := mapper.toEndpointName("sub-example-com-a-www.sub.example.com")
+	assert.Equal(t, "www.sub.example.com", name)
+	assert.Equal(t, endpoint.RecordTypeA, recordType)
+
+	assert.Equal(t, "example-com--www.example.com", mapper.toTXTName("www.example.com"))
+}
+
 func TestNewTXTScheme(t *testing.T) {
 	p := inmemory.NewInMemoryProvider()
 	p.CreateZone(testZone)
@@ -1838,3 +1888,225 @@ func TestTXTRegistryRecordsWithEmptyTargets(t *testing.T) {
 
 	testutils.TestHelperLogContains("TXT record has no targets empty-targets.test-zone.example.org", hook, t)
 }
+
+func TestTXTRegistryAdoptOwners(t *testing.T) {
+	ctx := context.Background()
+	p := inmemory.NewInMemoryProvider()
+	require.NoError(t, p.CreateZone(testZone))
+
+	blue, err := NewTXTRegistry(p, "", "", "blue", 0, "", []string{endpoint.RecordTypeA}, nil, false, nil, false)
+	require.NoError(t, err)
+	require.NoError(t, blue.ApplyChanges(ctx, &plan.Changes{
+		Create: []*endpoint.Endpoint{
+			newEndpointWithOwner("app."+testZone, "1.1.1.1", endpoint.RecordTypeA, ""),
+			newEndpointWithOwner("old."+testZone, "1.1.1.1", endpoint.RecordTypeA, ""),
+		},
+	}))
+
+	green, err := NewTXTRegistry(p, "", "", "green", 0, "", []string{endpoint.RecordTypeA}, nil, false, nil, false)
+	require.NoError(t, err)
+	records, err := green.Records(ctx)
+	require.NoError(t, err)
+	current := map[string]*endpoint.Endpoint{}
+	for _, r := range records {
+		if r.RecordType == endpoint.RecordTypeA {
+			require.Equal(t, "blue", r.Labels[endpoint.OwnerLabelKey])
+			current[r.DNSName] = r
+		}
+	}
+	require.Len(t, current, 2)
+	changes := func() *plan.Changes {
+		return &plan.Changes{
+			UpdateOld: []*endpoint.Endpoint{current["app."+testZone]},
+			UpdateNew: []*endpoint.Endpoint{newEndpointWithOwner("app."+testZone, "2.2.2.2", endpoint.RecordTypeA, "green")},
+			Delete:    []*endpoint.Endpoint{current["old."+testZone]},
+		}
+	}
+
+	// deleting records of other owners is dropped
+	require.NoError(t, green.ApplyChanges(ctx, &plan.Changes{Delete: changes().Delete}))
+	records, err = green.Records(ctx)
+	require.NoError(t, err)
+	assert.Len(t, records, 2)
+
+	green.AdoptOwners([]string{"blue"})
+	require.NoError(t, green.ApplyChanges(ctx, changes()))
+	records, err = green.Records(ctx)
+	require.NoError(t, err)
+	require.Len(t, records, 1)
+	assert.Equal(t, "app."+testZone, records[0].DNSName)
+	assert.Equal(t, "green", records[0].Labels[endpoint.OwnerLabelKey])
+	assert.Equal(t, endpoint.Targets{"2.2.2.2"}, records[0].Targets)
+}
+
+func TestTXTRegistryTransferOwnership(t *testing.T) {
+	ctx := context.Background()
+	p := inmemory.NewInMemoryProvider()
+	require.NoError(t, p.CreateZone(testZone))
+
+	newFormatOnly, err := NewTXTRegistry(p, "", "", "blue", 0, "", []string{endpoint.RecordTypeA}, nil, false, nil, true)
+	require.NoError(t, err)
+	require.NoError(t, newFormatOnly.ApplyChanges(ctx, &plan.Changes{
+		Create: []*endpoint.Endpoint{
+			newEndpointWithOwner("app."+testZone, "1.1.1.1", endpoint.RecordTypeA, ""),
+			newEndpointWithOwner("other."+testZone, "1.1.1.1", endpoint.RecordTypeA, ""),
+		},
+	}))
+
+	blue, err := NewTXTRegistry(p, "", "", "blue", 0, "", []string{endpoint.RecordTypeA}, nil, false, nil, false)
+	require.NoError(t, err)
+	records, err := blue.Records(ctx)
+	require.NoError(t, err)
+	var app *endpoint.Endpoint
+	for _, r := range records {
+		if r.DNSName == "app."+testZone {
+			app = r
+		}
+	}
+	require.NotNil(t, app)
+
+	// the TXT record in the old format is missing, it gets created along the way
+	require.NoError(t, blue.TransferOwnership(ctx, []*endpoint.Endpoint{app}, "green"))
+
+	all, err := p.Records(ctx)
+	require.NoError(t, err)
+	txts := map[string]string{}
+	for _, r := range all {
+		if r.RecordType == endpoint.RecordTypeTXT {
+			txts[r.DNSName] = r.Targets[0]
+		}
+	}
+	assert.Equal(t, map[string]string{
+		"a-app." + testZone:   "\"heritage=external-dns,external-dns/owner=green\"",
+		"app." + testZone:     "\"heritage=external-dns,external-dns/owner=green\"",
+		"a-other." + testZone: "\"heritage=external-dns,external-dns/owner=blue\"",
+	}, txts)
+
+	green, err := NewTXTRegistry(p, "", "", "green", 0, "", []string{endpoint.RecordTypeA}, nil, false, nil, false)
+	require.NoError(t, err)
+	records, err = green.Records(ctx)
+	require.NoError(t, err)
+	for _, r := range records {
+		if r.DNSName == "app."+testZone {
+			assert.Equal(t, "green", r.Labels[endpoint.OwnerLabelKey])
+			assert.Equal(t, endpoint.Targets{"1.1.1.1"}, r.Targets)
+		} else {
+			assert.Equal(t, "blue", r.Labels[endpoint.OwnerLabelKey])
+		}
+	}
+}
+
+type labelPersistingProvider struct {
+	provider.Provider
+}
+
+func (p labelPersistingProvider) PersistsLabels() bool {
+	return true
+}
+
+func TestTXTRegistryNativeLabels(t *testing.T) {
+	ctx := context.Background()
+	p := inmemory.NewInMemoryProvider()
+	require.NoError(t, p.CreateZone(testZone))
+
+	require.NoError(t, p.ApplyChanges(ctx, &plan.Changes{
+		Create: []*endpoint.Endpoint{
+			newEndpointWithOwner("legacy."+testZone, "1.1.1.1", endpoint.RecordTypeA, ""),
+			newEndpointWithOwner("a-legacy."+testZone, "\"heritage=external-dns,external-dns/owner=owner\"", endpoint.RecordTypeTXT, ""),
+		},
+	}))
+
+	r, err := NewTXTRegistry(labelPersistingProvider{p}, "", "", "owner", 0, "", []string{endpoint.RecordTypeA}, nil, false, nil, false)
+	require.NoError(t, err)
+	records, err := r.Records(ctx)
+	require.NoError(t, err)
+	require.Len(t, records, 1)
+	assert.Equal(t, "owner", records[0].Labels[endpoint.OwnerLabelKey])
+	forceUpdate, _ := records[0].GetProviderSpecificProperty(providerSpecificForceUpdate)
+	assert.Equal(t, "true", forceUpdate)
+
+	require.NoError(t, r.ApplyChanges(ctx, &plan.Changes{
+		Create:    []*endpoint.Endpoint{newEndpointWithOwner("new."+testZone, "2.2.2.2", endpoint.RecordTypeA, "")},
+		UpdateOld: []*endpoint.Endpoint{records[0]},
+		UpdateNew: []*endpoint.Endpoint{newEndpointWithOwner("legacy."+testZone, "1.1.1.1", endpoint.RecordTypeA, "owner")},
+	}))
+
+	all, err := p.Records(ctx)
+	require.NoError(t, err)
+	for _, ep := range all {
+		assert.NotEqual(t, endpoint.RecordTypeTXT, ep.RecordType, "unexpected TXT record %s", ep.DNSName)
+	}
+	records, err = r.Records(ctx)
+	require.NoError(t, err)
+	require.Len(t, records, 2)
+	for _, ep := range records {
+		assert.Equal(t, "owner", ep.Labels[endpoint.OwnerLabelKey])
+		_, found := ep.GetProviderSpecificProperty(providerSpecificForceUpdate)
+		assert.False(t, found)
+	}
+
+	// labels persisted by the provider take precedence over TXT records
+	require.NoError(t, p.ApplyChanges(ctx, &plan.Changes{
+		Create: []*endpoint.Endpoint{newEndpointWithOwner("txt.new."+testZone, "\"heritage=external-dns,external-dns/owner=other\"", endpoint.RecordTypeTXT, "")},
+	}))
+	records, err = r.Records(ctx)
+	require.NoError(t, err)
+	for _, ep := range records {
+		assert.Equal(t, "owner", ep.Labels[endpoint.OwnerLabelKey])
+	}
+}
+
+// paginatedProvider lists the records of the wrapped provider one per page, failing on Records.
+type paginatedProvider struct {
+	provider.Provider
+	records []*endpoint.Endpoint
+	pages   int
+}
+
+func (p *paginatedProvider) Records(_ context.Context) ([]*endpoint.Endpoint, error) {
+	return nil, errors.New("records are listed page by page")
+}
+
+func (p *paginatedProvider) RecordsPage(ctx context.Context, token string) (*provider.RecordsPage, error) {
+	i := 0
+	if token == "" {
+		records, err := p.Provider.Records(ctx)
+		if err != nil {
+			return nil, err
+		}
+		p.records = records
+	} else {
+		i, _ = strconv.Atoi(token)
+	}
+	p.pages++
+	page := &provider.RecordsPage{Endpoints: p.records[i : i+1]}
+	if i+1 < len(p.records) {
+		page.NextToken = strconv.Itoa(i + 1)
+	}
+	return page, nil
+}
+
+func TestTXTRegistryRecordsPages(t *testing.T) {
+	ctx := context.Background()
+	p := inmemory.NewInMemoryProvider()
+	require.NoError(t, p.CreateZone(testZone))
+	require.NoError(t, p.ApplyChanges(ctx, &plan.Changes{
+		Create: []*endpoint.Endpoint{
+			newEndpointWithOwner("foo."+testZone, "1.1.1.1", endpoint.RecordTypeA, ""),
+			newEndpointWithOwner("a-foo."+testZone, "\"heritage=external-dns,external-dns/owner=owner\"", endpoint.RecordTypeTXT, ""),
+			newEndpointWithOwner("bar."+testZone, "bar.loadbalancer.com", endpoint.RecordTypeCNAME, ""),
+		},
+	}))
+	pp := &paginatedProvider{Provider: p}
+
+	r, err := NewTXTRegistry(pp, "", "", "owner", 0, "", []string{}, []string{}, false, nil, false)
+	require.NoError(t, err)
+	records, err := r.Records(ctx)
+	require.NoError(t, err)
+
+	assert.Equal(t, 3, pp.pages)
+	assert.True(t, testutils.SameEndpoints(records, []*endpoint.Endpoint{
+		newEndpointWithOwner("foo."+testZone, "1.1.1.1", endpoint.RecordTypeA, "owner"),
+		newEndpointWithOwner("bar."+testZone, "bar.loadbalancer.com", endpoint.RecordTypeCNAME, ""),
+	}), "unexpected records %v", records)
+}
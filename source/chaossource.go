@@ -0,0 +1,83 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package source
+
+import (
+	"context"
+	"fmt"
+	"math/rand/v2"
+	"sync"
+
+	"sigs.k8s.io/external-dns/endpoint"
+)
+
+// chaosResource is the resource label of the synthetic endpoints of the chaosSource.
+const chaosResource = "chaos/flap"
+
+// chaosSource is a Source adding synthetic endpoints to the ones of its wrapped source, some of
+// them appearing or disappearing on every call, to rehearse how the rest of the pipeline and the
+// provider cope with churn. The synthetic endpoints are A records under a dedicated domain, pointing
+// at addresses reserved for documentation (192.0.2.0/24).
+type chaosSource struct {
+	source Source
+	domain string
+	rate   int
+
+	mutex sync.Mutex
+	// present tells which of the names of the pool currently have an endpoint.
+	present []bool
+}
+
+// NewChaosSource creates a new chaosSource wrapping the provided Source. On every call, rate
+// synthetic endpoints out of a pool of twice as many names under domain appear or disappear.
+func NewChaosSource(source Source, domain string, rate int) Source {
+	return &chaosSource{
+		source:  source,
+		domain:  domain,
+		rate:    rate,
+		present: make([]bool, 2*rate),
+	}
+}
+
+// Endpoints collects endpoints from its wrapped source and adds the synthetic endpoints, after flapping rate of them.
+func (cs *chaosSource) Endpoints(ctx context.Context) ([]*endpoint.Endpoint, error) {
+	endpoints, err := cs.source.Endpoints(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	cs.mutex.Lock()
+	defer cs.mutex.Unlock()
+
+	for _, i := range rand.Perm(len(cs.present))[:cs.rate] {
+		cs.present[i] = !cs.present[i]
+	}
+	for i, present := range cs.present {
+		if !present {
+			continue
+		}
+		ep := endpoint.NewEndpoint(fmt.Sprintf("flap-%d.%s", i, cs.domain), endpoint.RecordTypeA, fmt.Sprintf("192.0.2.%d", i%254+1))
+		ep.Labels[endpoint.ResourceLabelKey] = chaosResource
+		endpoints = append(endpoints, ep)
+	}
+
+	return endpoints, nil
+}
+
+func (cs *chaosSource) AddEventHandler(ctx context.Context, handler func()) {
+	cs.source.AddEventHandler(ctx, handler)
+}
@@ -0,0 +1,75 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package source
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"sigs.k8s.io/external-dns/endpoint"
+	"sigs.k8s.io/external-dns/internal/testutils"
+)
+
+func TestChaosSource(t *testing.T) {
+	existing := endpoint.NewEndpoint("www.example.org", endpoint.RecordTypeA, "1.2.3.4")
+	src := NewChaosSource(NewEchoSource([]*endpoint.Endpoint{existing}), "chaos.example.org", 3)
+
+	previous := map[string]bool{}
+	for range 5 {
+		res, err := src.Endpoints(context.Background())
+		require.NoError(t, err)
+		require.Contains(t, res, existing)
+
+		current := map[string]bool{}
+		for _, ep := range res {
+			if ep == existing {
+				continue
+			}
+			assert.Regexp(t, `^flap-[0-5]\.chaos\.example\.org$`, ep.DNSName)
+			assert.Equal(t, endpoint.RecordTypeA, ep.RecordType)
+			assert.Equal(t, chaosResource, ep.Labels[endpoint.ResourceLabelKey])
+			current[ep.DNSName] = true
+		}
+
+		// exactly rate names appeared or disappeared since the previous call
+		flapped := 0
+		for name := range current {
+			if !previous[name] {
+				flapped++
+			}
+		}
+		for name := range previous {
+			if !current[name] {
+				flapped++
+			}
+		}
+		assert.Equal(t, 3, flapped)
+		previous = current
+	}
+}
+
+func TestChaosSourceError(t *testing.T) {
+	mockSource := new(testutils.MockSource)
+	mockSource.On("Endpoints").Return([]*endpoint.Endpoint{}, errors.New("source error"))
+
+	_, err := NewChaosSource(mockSource, "chaos.example.org", 3).Endpoints(context.Background())
+	assert.EqualError(t, err, "source error")
+}
@@ -18,53 +18,226 @@ package source
 
 import (
 	"context"
+	"crypto/tls"
 	"encoding/gob"
+	"errors"
+	"fmt"
+	"io"
 	"net"
+	"os"
+	"strings"
 	"time"
 
 	log "github.com/sirupsen/logrus"
 
 	"sigs.k8s.io/external-dns/endpoint"
+	"sigs.k8s.io/external-dns/pkg/tlsutils"
 )
 
 const (
 	dialTimeout = 30 * time.Second
+	// exchangeTimeout bounds the time a connection to the remote server is kept open.
+	exchangeTimeout = 30 * time.Second
+	// connectorRetryInterval is the delay before the first reconnect, doubled on each further one.
+	connectorRetryInterval    = time.Second
+	connectorMaxRetryInterval = 30 * time.Second
 )
 
+// ConnectorProtocolVersion is the latest version of the protocol spoken by the connector source.
+//
+// In version 1, the remote server writes the gob encoded endpoints as soon as the connection is accepted.
+// In version 2, the connector source first writes a gob encoded ConnectorRequest, and the remote server
+// answers with a gob encoded ConnectorResponse.
+const ConnectorProtocolVersion = 2
+
+// ConnectorRequest is sent by the connector source to the remote server in protocol version 2.
+type ConnectorRequest struct {
+	Version int
+	// Token authenticates the connector source, it is empty when no token is configured.
+	Token string
+}
+
+// ConnectorResponse is sent by the remote server to the connector source in protocol version 2.
+type ConnectorResponse struct {
+	Version int
+	// Error is set when the remote server refuses the request, for instance when the token is invalid.
+	Error     string
+	Endpoints []*endpoint.Endpoint
+}
+
 // connectorSource is an implementation of Source that provides endpoints by connecting
 // to a remote tcp server. The encoding/decoding is done using encoder/gob package.
 type connectorSource struct {
-	remoteServer string
+	remoteServer    string
+	tlsConfig       *tls.Config
+	tokenFile       string
+	protocolVersion int
+	maxSize         int64
+	retries         int
+	retryInterval   time.Duration
 }
 
 // NewConnectorSource creates a new connectorSource with the given config.
-func NewConnectorSource(remoteServer string) (Source, error) {
+// The remote server is connected to over TLS when useTLS is set, using the optional CA and client certificate files.
+// Failed connections are retried up to retries times, and responses larger than maxSize bytes are refused unless it is zero.
+func NewConnectorSource(remoteServer string, useTLS bool, caFile, certFile, keyFile, tokenFile string, protocolVersion, maxSize, retries int) (Source, error) {
+	if protocolVersion < 1 || protocolVersion > ConnectorProtocolVersion {
+		return nil, fmt.Errorf("unsupported connector protocol version %d", protocolVersion)
+	}
+	if tokenFile != "" && protocolVersion < 2 {
+		return nil, errors.New("connector token authentication requires protocol version 2")
+	}
+
+	var tlsConfig *tls.Config
+	if useTLS {
+		var err error
+		tlsConfig, err = tlsutils.NewTLSConfig(certFile, keyFile, caFile, "", false, tls.VersionTLS12)
+		if err != nil {
+			return nil, err
+		}
+	}
+
 	return &connectorSource{
-		remoteServer: remoteServer,
+		remoteServer:    remoteServer,
+		tlsConfig:       tlsConfig,
+		tokenFile:       tokenFile,
+		protocolVersion: protocolVersion,
+		maxSize:         int64(maxSize),
+		retries:         retries,
+		retryInterval:   connectorRetryInterval,
 	}, nil
 }
 
 // Endpoints returns endpoint objects.
 func (cs *connectorSource) Endpoints(ctx context.Context) ([]*endpoint.Endpoint, error) {
-	endpoints := []*endpoint.Endpoint{}
+	interval := cs.retryInterval
+	for attempt := 0; ; attempt++ {
+		endpoints, retry, err := cs.fetch(ctx)
+		if err == nil {
+			log.Debugf("Received endpoints: %#v", endpoints)
+			return endpoints, nil
+		}
+		if !retry || attempt >= cs.retries {
+			log.Errorf("Connector source error: %v", err)
+			return nil, err
+		}
+
+		log.Warnf("Connector source error, reconnecting in %s (attempt %d of %d): %v", interval, attempt+1, cs.retries, err)
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-time.After(interval):
+		}
+		interval = min(2*interval, connectorMaxRetryInterval)
+	}
+}
 
-	conn, err := net.DialTimeout("tcp", cs.remoteServer, dialTimeout)
+// fetch gets the endpoints from the remote server once. It reports whether a failure is worth retrying,
+// which is not the case when the remote server refused the request or sent an invalid response.
+func (cs *connectorSource) fetch(ctx context.Context) ([]*endpoint.Endpoint, bool, error) {
+	token, err := cs.token()
 	if err != nil {
-		log.Errorf("Connection error: %v", err)
-		return nil, err
+		return nil, false, err
+	}
+
+	conn, err := cs.dial(ctx)
+	if err != nil {
+		// A certificate which cannot be verified will not be on the next attempt either.
+		var certErr *tls.CertificateVerificationError
+		return nil, !errors.As(err, &certErr), fmt.Errorf("connection error: %w", err)
 	}
 	defer conn.Close()
 
-	decoder := gob.NewDecoder(conn)
-	if err := decoder.Decode(&endpoints); err != nil {
-		log.Errorf("Decode error: %v", err)
-		return nil, err
+	deadline := time.Now().Add(exchangeTimeout)
+	if d, ok := ctx.Deadline(); ok && d.Before(deadline) {
+		deadline = d
+	}
+	if err := conn.SetDeadline(deadline); err != nil {
+		return nil, true, fmt.Errorf("connection error: %w", err)
+	}
+
+	var r io.Reader = conn
+	if cs.maxSize > 0 {
+		r = &limitedReader{r: conn, remaining: cs.maxSize}
+	}
+	decoder := gob.NewDecoder(r)
+
+	if cs.protocolVersion < 2 {
+		endpoints := []*endpoint.Endpoint{}
+		if err := decoder.Decode(&endpoints); err != nil {
+			return nil, isConnectionError(err), fmt.Errorf("decode error: %w", err)
+		}
+		return endpoints, false, nil
+	}
+
+	if err := gob.NewEncoder(conn).Encode(ConnectorRequest{Version: cs.protocolVersion, Token: token}); err != nil {
+		return nil, true, fmt.Errorf("encode error: %w", err)
 	}
+	var resp ConnectorResponse
+	if err := decoder.Decode(&resp); err != nil {
+		return nil, isConnectionError(err), fmt.Errorf("decode error: %w", err)
+	}
+	if resp.Version != cs.protocolVersion {
+		return nil, false, fmt.Errorf("remote server answered with protocol version %d instead of %d", resp.Version, cs.protocolVersion)
+	}
+	if resp.Error != "" {
+		return nil, false, fmt.Errorf("remote server refused the request: %s", resp.Error)
+	}
+	if resp.Endpoints == nil {
+		resp.Endpoints = []*endpoint.Endpoint{}
+	}
+	return resp.Endpoints, false, nil
+}
 
-	log.Debugf("Received endpoints: %#v", endpoints)
+func (cs *connectorSource) dial(ctx context.Context) (net.Conn, error) {
+	dialer := &net.Dialer{Timeout: dialTimeout}
+	if cs.tlsConfig == nil {
+		return dialer.DialContext(ctx, "tcp", cs.remoteServer)
+	}
+	tlsDialer := &tls.Dialer{NetDialer: dialer, Config: cs.tlsConfig}
+	return tlsDialer.DialContext(ctx, "tcp", cs.remoteServer)
+}
 
-	return endpoints, nil
+// token reads the token from its file, on each connection so that it can be rotated.
+func (cs *connectorSource) token() (string, error) {
+	if cs.tokenFile == "" {
+		return "", nil
+	}
+	b, err := os.ReadFile(cs.tokenFile)
+	if err != nil {
+		return "", fmt.Errorf("failed to read connector token: %w", err)
+	}
+	return strings.TrimSpace(string(b)), nil
 }
 
 func (cs *connectorSource) AddEventHandler(ctx context.Context, handler func()) {
 }
+
+var errResponseTooLarge = errors.New("response too large")
+
+// limitedReader fails reads past its limit, unlike io.LimitReader which would make them look like a truncated response.
+type limitedReader struct {
+	r         io.Reader
+	remaining int64
+}
+
+func (l *limitedReader) Read(p []byte) (int, error) {
+	if l.remaining <= 0 {
+		return 0, errResponseTooLarge
+	}
+	if int64(len(p)) > l.remaining {
+		p = p[:l.remaining]
+	}
+	n, err := l.r.Read(p)
+	l.remaining -= int64(n)
+	return n, err
+}
+
+// isConnectionError reports whether err comes from the connection rather than from the content of the response.
+func isConnectionError(err error) bool {
+	if errors.Is(err, errResponseTooLarge) {
+		return false
+	}
+	var netErr net.Error
+	return errors.Is(err, io.EOF) || errors.Is(err, io.ErrUnexpectedEOF) || errors.As(err, &netErr)
+}
@@ -18,11 +18,20 @@ package source
 
 import (
 	"context"
+	"crypto/tls"
 	"encoding/gob"
+	"encoding/pem"
 	"net"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"sync/atomic"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 	"github.com/stretchr/testify/suite"
 
 	"sigs.k8s.io/external-dns/endpoint"
@@ -61,6 +70,11 @@ func TestConnectorSource(t *testing.T) {
 	suite.Run(t, new(ConnectorSuite))
 	t.Run("Interface", testConnectorSourceImplementsSource)
 	t.Run("Endpoints", testConnectorSourceEndpoints)
+	t.Run("ProtocolVersion2", testConnectorSourceProtocolVersion2)
+	t.Run("MaxSize", testConnectorSourceMaxSize)
+	t.Run("Reconnect", testConnectorSourceReconnect)
+	t.Run("TLS", testConnectorSourceTLS)
+	t.Run("InvalidConfig", testConnectorSourceInvalidConfig)
 }
 
 // testConnectorSourceImplementsSource tests that connectorSource is a valid Source.
@@ -129,7 +143,7 @@ func testConnectorSourceEndpoints(t *testing.T) {
 				defer ln.Close()
 				addr = ln.Addr().String()
 			}
-			cs, _ := NewConnectorSource(addr)
+			cs, _ := NewConnectorSource(addr, false, "", "", "", "", 1, 0, 0)
 
 			endpoints, err := cs.Endpoints(context.Background())
 			if ti.expectError {
@@ -143,3 +157,154 @@ func testConnectorSourceEndpoints(t *testing.T) {
 		})
 	}
 }
+
+// startConnectorServer serves every connection accepted on ln with serve, and returns the number of accepted connections.
+func startConnectorServer(t *testing.T, ln net.Listener, serve func(conn net.Conn, n int32)) *atomic.Int32 {
+	t.Helper()
+	var accepted atomic.Int32
+	go func() {
+		for {
+			conn, err := ln.Accept()
+			if err != nil {
+				return
+			}
+			serve(conn, accepted.Add(1))
+			conn.Close()
+		}
+	}()
+	t.Cleanup(func() { ln.Close() })
+	return &accepted
+}
+
+// serveConnectorV2 answers protocol version 2 requests with the endpoints when they carry the token.
+func serveConnectorV2(token string, endpoints []*endpoint.Endpoint) func(conn net.Conn, n int32) {
+	return func(conn net.Conn, _ int32) {
+		var req ConnectorRequest
+		if err := gob.NewDecoder(conn).Decode(&req); err != nil {
+			return
+		}
+		resp := ConnectorResponse{Version: req.Version, Endpoints: endpoints}
+		if req.Token != token {
+			resp = ConnectorResponse{Version: req.Version, Error: "invalid token"}
+		}
+		gob.NewEncoder(conn).Encode(resp)
+	}
+}
+
+func listenLocal(t *testing.T) net.Listener {
+	t.Helper()
+	ln, err := net.Listen("tcp", "localhost:0")
+	require.NoError(t, err)
+	return ln
+}
+
+func writeTempFile(t *testing.T, name, content string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), name)
+	require.NoError(t, os.WriteFile(path, []byte(content), 0o600))
+	return path
+}
+
+var connectorTestEndpoints = []*endpoint.Endpoint{
+	{
+		DNSName:    "abc.example.org",
+		Targets:    endpoint.Targets{"1.2.3.4"},
+		RecordType: endpoint.RecordTypeA,
+		RecordTTL:  180,
+	},
+}
+
+func testConnectorSourceProtocolVersion2(t *testing.T) {
+	ln := listenLocal(t)
+	accepted := startConnectorServer(t, ln, serveConnectorV2("s3cr3t", connectorTestEndpoints))
+
+	cs, err := NewConnectorSource(ln.Addr().String(), false, "", "", "", writeTempFile(t, "token", "s3cr3t\n"), 2, 0, 3)
+	require.NoError(t, err)
+	endpoints, err := cs.Endpoints(context.Background())
+	require.NoError(t, err)
+	validateEndpoints(t, endpoints, connectorTestEndpoints)
+
+	// A refused request is not retried.
+	cs, err = NewConnectorSource(ln.Addr().String(), false, "", "", "", writeTempFile(t, "token", "wrong"), 2, 0, 3)
+	require.NoError(t, err)
+	_, err = cs.Endpoints(context.Background())
+	assert.EqualError(t, err, "remote server refused the request: invalid token")
+	assert.Equal(t, int32(2), accepted.Load())
+}
+
+func testConnectorSourceMaxSize(t *testing.T) {
+	ln := listenLocal(t)
+	accepted := startConnectorServer(t, ln, func(conn net.Conn, _ int32) {
+		gob.NewEncoder(conn).Encode(connectorTestEndpoints)
+	})
+
+	cs, err := NewConnectorSource(ln.Addr().String(), false, "", "", "", "", 1, 16, 3)
+	require.NoError(t, err)
+	_, err = cs.Endpoints(context.Background())
+	assert.ErrorIs(t, err, errResponseTooLarge)
+	assert.Equal(t, int32(1), accepted.Load())
+
+	cs, err = NewConnectorSource(ln.Addr().String(), false, "", "", "", "", 1, 1<<20, 3)
+	require.NoError(t, err)
+	endpoints, err := cs.Endpoints(context.Background())
+	require.NoError(t, err)
+	validateEndpoints(t, endpoints, connectorTestEndpoints)
+}
+
+func testConnectorSourceReconnect(t *testing.T) {
+	ln := listenLocal(t)
+	accepted := startConnectorServer(t, ln, func(conn net.Conn, n int32) {
+		// The first connections are closed without an answer.
+		if n < 3 {
+			return
+		}
+		gob.NewEncoder(conn).Encode(connectorTestEndpoints)
+	})
+
+	cs, err := NewConnectorSource(ln.Addr().String(), false, "", "", "", "", 1, 0, 1)
+	require.NoError(t, err)
+	cs.(*connectorSource).retryInterval = time.Millisecond
+	_, err = cs.Endpoints(context.Background())
+	assert.Error(t, err)
+	assert.Equal(t, int32(2), accepted.Load())
+
+	endpoints, err := cs.Endpoints(context.Background())
+	require.NoError(t, err)
+	validateEndpoints(t, endpoints, connectorTestEndpoints)
+}
+
+func testConnectorSourceTLS(t *testing.T) {
+	// httptest provides a certificate valid for 127.0.0.1.
+	srv := httptest.NewTLSServer(http.NotFoundHandler())
+	tlsConfig := &tls.Config{Certificates: srv.TLS.Certificates}
+	caFile := writeTempFile(t, "ca.pem", string(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: srv.Certificate().Raw})))
+	srv.Close()
+
+	ln, err := tls.Listen("tcp", "127.0.0.1:0", tlsConfig)
+	require.NoError(t, err)
+	startConnectorServer(t, ln, serveConnectorV2("", connectorTestEndpoints))
+
+	cs, err := NewConnectorSource(ln.Addr().String(), true, caFile, "", "", "", 2, 0, 0)
+	require.NoError(t, err)
+	endpoints, err := cs.Endpoints(context.Background())
+	require.NoError(t, err)
+	validateEndpoints(t, endpoints, connectorTestEndpoints)
+
+	// The server is not trusted without the CA, which is not retried.
+	cs, err = NewConnectorSource(ln.Addr().String(), true, "", "", "", "", 2, 0, 3)
+	require.NoError(t, err)
+	_, err = cs.Endpoints(context.Background())
+	var certErr *tls.CertificateVerificationError
+	assert.ErrorAs(t, err, &certErr)
+}
+
+func testConnectorSourceInvalidConfig(t *testing.T) {
+	_, err := NewConnectorSource("localhost:8080", false, "", "", "", "", 3, 0, 0)
+	assert.EqualError(t, err, "unsupported connector protocol version 3")
+
+	_, err = NewConnectorSource("localhost:8080", false, "", "", "", "/path/to/token", 1, 0, 0)
+	assert.EqualError(t, err, "connector token authentication requires protocol version 2")
+
+	_, err = NewConnectorSource("localhost:8080", true, "", "/path/to/cert", "", "", 1, 0, 0)
+	assert.Error(t, err)
+}
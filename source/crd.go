@@ -49,6 +49,7 @@ type crdSource struct {
 	annotationFilter string
 	labelSelector    labels.Selector
 	informer         *cache.SharedInformer
+	appliedVersions  AppliedVersions
 }
 
 func addKnownTypes(scheme *runtime.Scheme, groupVersion schema.GroupVersion) error {
@@ -108,7 +109,9 @@ func NewCRDClientForAPIVersionKind(client kubernetes.Interface, kubeConfig, apiS
 }
 
 // NewCRDSource creates a new crdSource with the given config.
-func NewCRDSource(crdClient rest.Interface, namespace, kind string, annotationFilter string, labelSelector labels.Selector, scheme *runtime.Scheme, startInformer bool) (Source, error) {
+// When appliedVersions is set, the observed generation written to the status of a DNSEndpoint is
+// the one incorporated by the last successful synchronization, rather than the one last read.
+func NewCRDSource(crdClient rest.Interface, namespace, kind string, annotationFilter string, labelSelector labels.Selector, scheme *runtime.Scheme, startInformer bool, appliedVersions AppliedVersions) (Source, error) {
 	sourceCrd := crdSource{
 		crdResource:      strings.ToLower(kind) + "s",
 		namespace:        namespace,
@@ -116,6 +119,7 @@ func NewCRDSource(crdClient rest.Interface, namespace, kind string, annotationFi
 		labelSelector:    labelSelector,
 		crdClient:        crdClient,
 		codec:            runtime.NewParameterCodec(scheme),
+		appliedVersions:  appliedVersions,
 	}
 	if startInformer {
 		// external-dns already runs its sync-handler periodically (controlled by `--interval` flag) to ensure any
@@ -204,6 +208,12 @@ func (cs *crdSource) Endpoints(ctx context.Context) ([]*endpoint.Endpoint, error
 				continue
 			}
 
+			for _, p := range ep.ProviderSpecific {
+				if err := endpoint.ValidateProviderSpecificProperty(p.Name, p.Value); err != nil {
+					log.Warnf("Endpoint %s with DNSName %s: %v", dnsEndpoint.Name, ep.DNSName, err)
+				}
+			}
+
 			if ep.Labels == nil {
 				ep.Labels = endpoint.NewLabels()
 			}
@@ -214,11 +224,19 @@ func (cs *crdSource) Endpoints(ctx context.Context) ([]*endpoint.Endpoint, error
 		cs.setResourceLabel(&dnsEndpoint, crdEndpoints)
 		endpoints = append(endpoints, crdEndpoints...)
 
-		if dnsEndpoint.Status.ObservedGeneration == dnsEndpoint.Generation {
+		generation := dnsEndpoint.Generation
+		if cs.appliedVersions != nil && len(crdEndpoints) > 0 {
+			applied, ok := cs.appliedVersions.AppliedVersion(crdEndpoints[0].Labels[endpoint.ResourceLabelKey])
+			if !ok {
+				continue
+			}
+			generation = applied.Generation
+		}
+		if dnsEndpoint.Status.ObservedGeneration >= generation {
 			continue
 		}
 
-		dnsEndpoint.Status.ObservedGeneration = dnsEndpoint.Generation
+		dnsEndpoint.Status.ObservedGeneration = generation
 		// Update the ObservedGeneration
 		_, err = cs.UpdateStatus(ctx, &dnsEndpoint)
 		if err != nil {
@@ -233,6 +251,7 @@ func (cs *crdSource) setResourceLabel(crd *endpoint.DNSEndpoint, endpoints []*en
 	for _, ep := range endpoints {
 		ep.Labels[endpoint.ResourceLabelKey] = fmt.Sprintf("crd/%s/%s", crd.Namespace, crd.Name)
 	}
+	setObservedVersion(crd, endpoints)
 }
 
 func (cs *crdSource) watch(ctx context.Context, opts *metav1.ListOptions) (watch.Interface, error) {
@@ -487,7 +487,7 @@ func testCRDSourceEndpoints(t *testing.T) {
 			// So don't start the informer during testing.
 			startInformer := false
 
-			cs, err := NewCRDSource(restClient, ti.namespace, ti.kind, ti.annotationFilter, labelSelector, scheme, startInformer)
+			cs, err := NewCRDSource(restClient, ti.namespace, ti.kind, ti.annotationFilter, labelSelector, scheme, startInformer, nil)
 			require.NoError(t, err)
 
 			receivedEndpoints, err := cs.Endpoints(context.Background())
@@ -526,3 +526,49 @@ func validateCRDResource(t *testing.T, src Source, expectError bool) {
 		}
 	}
 }
+
+type fakeAppliedVersions map[string]endpoint.ObservedVersion
+
+func (f fakeAppliedVersions) AppliedVersion(resource string) (endpoint.ObservedVersion, bool) {
+	version, ok := f[resource]
+	return version, ok
+}
+
+func TestCRDSourceAppliedVersions(t *testing.T) {
+	apiVersion := "test.k8s.io/v1alpha1"
+	endpoints := []*endpoint.Endpoint{
+		{
+			DNSName:    "abc.example.org",
+			Targets:    endpoint.Targets{"1.2.3.4"},
+			RecordType: endpoint.RecordTypeA,
+			RecordTTL:  180,
+		},
+	}
+	restClient := fakeRESTClient(endpoints, apiVersion, "DNSEndpoint", "foo", "test", nil, nil, t)
+	groupVersion, err := schema.ParseGroupVersion(apiVersion)
+	require.NoError(t, err)
+	scheme := runtime.NewScheme()
+	require.NoError(t, addKnownTypes(scheme, groupVersion))
+
+	applied := fakeAppliedVersions{}
+	cs, err := NewCRDSource(restClient, "foo", "DNSEndpoint", "", labels.Everything(), scheme, false, applied)
+	require.NoError(t, err)
+
+	observedGeneration := func() int64 {
+		result, err := cs.(*crdSource).List(context.Background(), &metav1.ListOptions{})
+		require.NoError(t, err)
+		require.Len(t, result.Items, 1)
+		return result.Items[0].Status.ObservedGeneration
+	}
+
+	received, err := cs.Endpoints(context.Background())
+	require.NoError(t, err)
+	require.Len(t, received, 1)
+	require.Equal(t, int64(1), received[0].ObservedVersion.Generation)
+	require.Equal(t, int64(0), observedGeneration(), "the generation is not observed before it got applied")
+
+	applied["crd/foo/test"] = endpoint.ObservedVersion{Generation: 1}
+	_, err = cs.Endpoints(context.Background())
+	require.NoError(t, err)
+	require.Equal(t, int64(1), observedGeneration())
+}
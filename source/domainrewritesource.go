@@ -0,0 +1,114 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package source
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	log "github.com/sirupsen/logrus"
+
+	"sigs.k8s.io/external-dns/endpoint"
+)
+
+// DomainRewrite replaces the From domain suffix of DNS names by the To domain.
+type DomainRewrite struct {
+	From string
+	To   string
+}
+
+// ParseDomainRewrites parses rules in the form "from=to", e.g. "cluster.local=example.com".
+// A leading "*." and trailing dots are ignored, so "*.cluster.local=*.example.com" is the same rule.
+func ParseDomainRewrites(rules []string) ([]DomainRewrite, error) {
+	rewrites := make([]DomainRewrite, 0, len(rules))
+	for _, rule := range rules {
+		from, to, ok := strings.Cut(rule, "=")
+		from, to = normalizeRewriteDomain(from), normalizeRewriteDomain(to)
+		if !ok || from == "" || to == "" {
+			return nil, fmt.Errorf("invalid domain rewrite %q, expected from=to", rule)
+		}
+		rewrites = append(rewrites, DomainRewrite{From: from, To: to})
+	}
+	return rewrites, nil
+}
+
+func normalizeRewriteDomain(domain string) string {
+	domain = strings.TrimPrefix(strings.TrimSpace(domain), "*.")
+	return strings.ToLower(strings.Trim(domain, "."))
+}
+
+// rewriteDomain returns the name with the suffix of the first matching rule replaced.
+func rewriteDomain(rewrites []DomainRewrite, name string) (string, bool) {
+	trimmed := strings.TrimSuffix(name, ".")
+	lower := strings.ToLower(trimmed)
+	for _, r := range rewrites {
+		switch {
+		case lower == r.From:
+			return r.To, true
+		case strings.HasSuffix(lower, "."+r.From):
+			return trimmed[:len(trimmed)-len(r.From)] + r.To, true
+		}
+	}
+	return name, false
+}
+
+// domainRewriteSource is a Source that maps the DNS names of its wrapped source from internal
+// domains to public ones.
+type domainRewriteSource struct {
+	source   Source
+	rewrites []DomainRewrite
+}
+
+// NewDomainRewriteSource creates a new domainRewriteSource wrapping the provided Source.
+// Rules are tried in order, the first one matching a DNS name applies.
+func NewDomainRewriteSource(source Source, rewrites []DomainRewrite) Source {
+	if len(rewrites) == 0 {
+		return source
+	}
+	return &domainRewriteSource{source: source, rewrites: rewrites}
+}
+
+// Endpoints collects endpoints from its wrapped source and rewrites their DNS names, as well as
+// the targets of CNAME endpoints, when they belong to a rewritten domain.
+func (rs *domainRewriteSource) Endpoints(ctx context.Context) ([]*endpoint.Endpoint, error) {
+	endpoints, err := rs.source.Endpoints(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, ep := range endpoints {
+		if name, ok := rewriteDomain(rs.rewrites, ep.DNSName); ok {
+			log.Debugf("Rewriting DNS name %q to %q", ep.DNSName, name)
+			ep.DNSName = name
+		}
+		if ep.RecordType != endpoint.RecordTypeCNAME {
+			continue
+		}
+		for i, target := range ep.Targets {
+			if rewritten, ok := rewriteDomain(rs.rewrites, target); ok {
+				ep.Targets[i] = rewritten
+			}
+		}
+	}
+
+	return endpoints, nil
+}
+
+func (rs *domainRewriteSource) AddEventHandler(ctx context.Context, handler func()) {
+	rs.source.AddEventHandler(ctx, handler)
+}
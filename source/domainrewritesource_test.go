@@ -0,0 +1,74 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package source
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"sigs.k8s.io/external-dns/endpoint"
+)
+
+func TestParseDomainRewrites(t *testing.T) {
+	rewrites, err := ParseDomainRewrites([]string{"cluster.local=example.com", "*.svc.internal.=*.public.example.org"})
+	require.NoError(t, err)
+	assert.Equal(t, []DomainRewrite{
+		{From: "cluster.local", To: "example.com"},
+		{From: "svc.internal", To: "public.example.org"},
+	}, rewrites)
+
+	for _, rule := range []string{"cluster.local", "=example.com", "cluster.local=", "*.=example.com"} {
+		_, err := ParseDomainRewrites([]string{rule})
+		assert.Error(t, err, rule)
+	}
+}
+
+func TestDomainRewriteSource(t *testing.T) {
+	rewrites := []DomainRewrite{
+		{From: "internal.cluster.local", To: "internal.example.com"},
+		{From: "cluster.local", To: "example.com"},
+	}
+	endpoints := []*endpoint.Endpoint{
+		endpoint.NewEndpoint("app.cluster.local", endpoint.RecordTypeA, "1.2.3.4"),
+		endpoint.NewEndpoint("cluster.local", endpoint.RecordTypeA, "1.2.3.4"),
+		endpoint.NewEndpoint("db.Internal.Cluster.Local", endpoint.RecordTypeA, "1.2.3.5"),
+		endpoint.NewEndpoint("www.cluster.local", endpoint.RecordTypeCNAME, "app.cluster.local"),
+		endpoint.NewEndpoint("txt.cluster.local", endpoint.RecordTypeTXT, "app.cluster.local"),
+		endpoint.NewEndpoint("app.notcluster.local", endpoint.RecordTypeA, "1.2.3.6"),
+		endpoint.NewEndpoint("other.example.org", endpoint.RecordTypeCNAME, "lb.example.net"),
+	}
+
+	res, err := NewDomainRewriteSource(NewEchoSource(endpoints), rewrites).Endpoints(context.Background())
+	require.NoError(t, err)
+
+	names := make([]string, 0, len(res))
+	targets := make([]string, 0, len(res))
+	for _, ep := range res {
+		names = append(names, ep.DNSName)
+		targets = append(targets, ep.Targets[0])
+	}
+	assert.Equal(t, []string{"app.example.com", "example.com", "db.internal.example.com", "www.example.com", "txt.example.com", "app.notcluster.local", "other.example.org"}, names)
+	assert.Equal(t, []string{"1.2.3.4", "1.2.3.4", "1.2.3.5", "app.example.com", "app.cluster.local", "1.2.3.6", "lb.example.net"}, targets)
+}
+
+func TestDomainRewriteSourceWithoutRules(t *testing.T) {
+	src := NewEchoSource(nil)
+	assert.Equal(t, src, NewDomainRewriteSource(src, nil))
+}
@@ -19,7 +19,9 @@ package source
 import (
 	"context"
 	"fmt"
+	"maps"
 	"net/netip"
+	"slices"
 	"sort"
 	"strings"
 	"text/template"
@@ -30,9 +32,9 @@ import (
 	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/apimachinery/pkg/util/wait"
-	kubeinformers "k8s.io/client-go/informers"
 	coreinformers "k8s.io/client-go/informers/core/v1"
 	cache "k8s.io/client-go/tools/cache"
+	"k8s.io/client-go/tools/record"
 	v1 "sigs.k8s.io/gateway-api/apis/v1"
 	v1beta1 "sigs.k8s.io/gateway-api/apis/v1beta1"
 	gateway "sigs.k8s.io/gateway-api/pkg/client/clientset/versioned"
@@ -70,18 +72,24 @@ type gatewayRouteInformer interface {
 	Informer() cache.SharedIndexInformer
 }
 
+// newGatewayInformerFactory returns the informer factory of Gateway API resources, shared by the
+// gateway sources watching the same namespace with the same label selector.
 func newGatewayInformerFactory(client gateway.Interface, namespace string, labelSelector labels.Selector) informers.SharedInformerFactory {
 	var opts []informers.SharedInformerOption
+	key := informerFactoryKey{client: client, namespace: namespace, stop: wait.NeverStop}
 	if namespace != "" {
 		opts = append(opts, informers.WithNamespace(namespace))
 	}
 	if labelSelector != nil && !labelSelector.Empty() {
 		lbls := labelSelector.String()
+		key.selector = lbls
 		opts = append(opts, informers.WithTweakListOptions(func(o *metav1.ListOptions) {
 			o.LabelSelector = lbls
 		}))
 	}
-	return informers.NewSharedInformerFactoryWithOptions(client, 0, opts...)
+	return sharedInformerFactory(key, func() informers.SharedInformerFactory {
+		return informers.NewSharedInformerFactoryWithOptions(client, 0, opts...)
+	})
 }
 
 type gatewayRouteSource struct {
@@ -101,6 +109,11 @@ type gatewayRouteSource struct {
 	fqdnTemplate             *template.Template
 	combineFQDNAnnotation    bool
 	ignoreHostnameAnnotation bool
+
+	// recorder, when set, records the events of the routes whose hostnames are not served as is by the
+	// listeners of their gateways, reportedHosts being the last hostnames reported for each route.
+	recorder      record.EventRecorder
+	reportedHosts map[types.NamespacedName]string
 }
 
 func newGatewayRouteSource(clients ClientGenerator, config *Config, kind string, newInformerFn newGatewayRouteInformerFunc) (Source, error) {
@@ -129,8 +142,8 @@ func newGatewayRouteSource(clients ClientGenerator, config *Config, kind string,
 	}
 
 	informerFactory := newGatewayInformerFactory(client, config.GatewayNamespace, gwLabels)
-	gwInformer := informerFactory.Gateway().V1beta1().Gateways() // TODO: Gateway informer should be shared across gateway sources.
-	gwInformer.Informer()                                        // Register with factory before starting.
+	gwInformer := informerFactory.Gateway().V1beta1().Gateways()
+	gwInformer.Informer() // Register with factory before starting.
 
 	rtInformerFactory := informerFactory
 	if config.Namespace != config.GatewayNamespace || !selectorsEqual(rtLabels, gwLabels) {
@@ -144,9 +157,9 @@ func newGatewayRouteSource(clients ClientGenerator, config *Config, kind string,
 		return nil, err
 	}
 
-	kubeInformerFactory := kubeinformers.NewSharedInformerFactory(kubeClient, 0)
-	nsInformer := kubeInformerFactory.Core().V1().Namespaces() // TODO: Namespace informer should be shared across gateway sources.
-	nsInformer.Informer()                                      // Register with factory before starting.
+	kubeInformerFactory := newKubeInformerFactory(kubeClient, "", wait.NeverStop)
+	nsInformer := kubeInformerFactory.Core().V1().Namespaces()
+	nsInformer.Informer() // Register with factory before starting.
 
 	informerFactory.Start(wait.NeverStop)
 	kubeInformerFactory.Start(wait.NeverStop)
@@ -182,6 +195,9 @@ func newGatewayRouteSource(clients ClientGenerator, config *Config, kind string,
 		combineFQDNAnnotation:    config.CombineFQDNAndAnnotation,
 		ignoreHostnameAnnotation: config.IgnoreHostnameAnnotation,
 	}
+	if config.GatewayRouteEvents {
+		src.recorder = NewEventRecorder(kubeClient)
+	}
 	return src, nil
 }
 
@@ -243,9 +259,12 @@ func (src *gatewayRouteSource) Endpoints(ctx context.Context) ([]*endpoint.Endpo
 			routeEndpoints = append(routeEndpoints, endpointsForHostname(host, targets, ttl, providerSpecific, setIdentifier, resource)...)
 		}
 		log.Debugf("Endpoints generated from %s %s/%s: %v", src.rtKind, meta.Namespace, meta.Name, routeEndpoints)
+		setObservedVersion(meta, routeEndpoints)
 
 		endpoints = append(endpoints, routeEndpoints...)
 	}
+	// the hostnames reported for the deleted routes are forgotten
+	src.reportedHosts = resolver.reportedHosts
 	return endpoints, nil
 }
 
@@ -257,11 +276,60 @@ type gatewayRouteResolver struct {
 	src *gatewayRouteSource
 	gws map[types.NamespacedName]gatewayListeners
 	nss map[string]*corev1.Namespace
+
+	reportedHosts map[types.NamespacedName]string
 }
 
 type gatewayListeners struct {
 	gateway   *v1beta1.Gateway
 	listeners map[v1.SectionName][]v1.Listener
+	// overrideTargets overrides the status addresses of the gateway, from its target annotation.
+	overrideTargets endpoint.Targets
+	// listenerTargets overrides the targets of some listeners, from the listener-targets annotation.
+	listenerTargets map[v1.SectionName]endpoint.Targets
+}
+
+// targetsOf returns the targets of a listener: those set for it by the listener-targets annotation,
+// else those of the target annotation, else the status addresses of the gateway.
+func (gw gatewayListeners) targetsOf(lis *v1.Listener) endpoint.Targets {
+	if targets, ok := gw.listenerTargets[lis.Name]; ok {
+		return targets
+	}
+	if len(gw.overrideTargets) > 0 {
+		return gw.overrideTargets
+	}
+	targets := make(endpoint.Targets, 0, len(gw.gateway.Status.Addresses))
+	for _, addr := range gw.gateway.Status.Addresses {
+		targets = append(targets, addr.Value)
+	}
+	return targets
+}
+
+// getListenerTargets parses the listener-targets annotation of a gateway, a comma separated list of
+// listener=target pairs, a listener being repeated to get several targets.
+func getListenerTargets(gw *v1beta1.Gateway) map[v1.SectionName]endpoint.Targets {
+	annotation, ok := gw.Annotations[listenerTargetsAnnotationKey]
+	if !ok {
+		return nil
+	}
+
+	listenerTargets := map[v1.SectionName]endpoint.Targets{}
+	for _, pair := range strings.Split(strings.ReplaceAll(annotation, " ", ""), ",") {
+		if pair == "" {
+			continue
+		}
+		name, target, found := strings.Cut(pair, "=")
+		if !found || name == "" || target == "" {
+			log.Warnf("Gateway %s/%s: ignoring %q in annotation %s, expected listener=target", gw.Namespace, gw.Name, pair, listenerTargetsAnnotationKey)
+			continue
+		}
+		if !slices.ContainsFunc(gw.Spec.Listeners, func(lis v1.Listener) bool { return string(lis.Name) == name }) {
+			log.Warnf("Gateway %s/%s: ignoring target %q of unknown listener %q in annotation %s", gw.Namespace, gw.Name, target, name, listenerTargetsAnnotationKey)
+			continue
+		}
+		listenerTargets[v1.SectionName(name)] = append(listenerTargets[v1.SectionName(name)], strings.TrimSuffix(target, "."))
+	}
+	return listenerTargets
 }
 
 func newGatewayRouteResolver(src *gatewayRouteSource, gateways []*v1beta1.Gateway, namespaces []*corev1.Namespace) *gatewayRouteResolver {
@@ -274,8 +342,10 @@ func newGatewayRouteResolver(src *gatewayRouteSource, gateways []*v1beta1.Gatewa
 		}
 		lss[""] = gw.Spec.Listeners
 		gws[namespacedName(gw.Namespace, gw.Name)] = gatewayListeners{
-			gateway:   gw,
-			listeners: lss,
+			gateway:         gw,
+			listeners:       lss,
+			overrideTargets: getTargetsFromTargetAnnotation(gw.Annotations),
+			listenerTargets: getListenerTargets(gw),
 		}
 	}
 	// Create Namespace lookup table.
@@ -284,9 +354,10 @@ func newGatewayRouteResolver(src *gatewayRouteSource, gateways []*v1beta1.Gatewa
 		nss[ns.Name] = ns
 	}
 	return &gatewayRouteResolver{
-		src: src,
-		gws: gws,
-		nss: nss,
+		src:           src,
+		gws:           gws,
+		nss:           nss,
+		reportedHosts: make(map[types.NamespacedName]string),
 	}
 }
 
@@ -305,6 +376,11 @@ func (c *gatewayRouteResolver) resolve(rt gatewayRoute) (map[string]endpoint.Tar
 	}
 
 	meta := rt.Metadata()
+	// served are the route hostnames matching a listener, narrowed those matching listeners with more
+	// specific hostnames, evaluated whether a gateway accepting the route was found.
+	served := make(map[string]bool)
+	narrowed := make(map[string][]string)
+	evaluated := false
 	for _, rps := range rt.RouteStatus().Parents {
 		// Confirm the Parent is the standard Gateway kind.
 		ref := rps.ParentRef
@@ -338,6 +414,7 @@ func (c *gatewayRouteResolver) resolve(rt gatewayRoute) (map[string]endpoint.Tar
 			log.Debugf("Gateway %s/%s has not accepted the current generation %s %s/%s", namespace, ref.Name, c.src.rtKind, meta.Namespace, meta.Name)
 			continue
 		}
+		evaluated = true
 
 		// Match the Route to all possible Listeners.
 		match := false
@@ -375,14 +452,12 @@ func (c *gatewayRouteResolver) resolve(rt gatewayRoute) (map[string]endpoint.Tar
 				if !ok {
 					continue
 				}
-				override := getTargetsFromTargetAnnotation(gw.gateway.Annotations)
-				hostTargets[host] = append(hostTargets[host], override...)
-				if len(override) == 0 {
-					for _, addr := range gw.gateway.Status.Addresses {
-						hostTargets[host] = append(hostTargets[host], addr.Value)
-					}
-				}
+				hostTargets[host] = append(hostTargets[host], gw.targetsOf(lis)...)
 				match = true
+				served[rtHost] = true
+				if rtHost != "" && host != toLowerCaseASCII(rtHost) && !slices.Contains(narrowed[rtHost], host) {
+					narrowed[rtHost] = append(narrowed[rtHost], host)
+				}
 			}
 		}
 		if !match {
@@ -394,9 +469,63 @@ func (c *gatewayRouteResolver) resolve(rt gatewayRoute) (map[string]endpoint.Tar
 	for host, targets := range hostTargets {
 		hostTargets[host] = uniqueTargets(targets)
 	}
+	if evaluated {
+		var rejected []string
+		for _, rtHost := range rtHosts {
+			if rtHost != "" && !served[rtHost] && !slices.Contains(rejected, rtHost) {
+				rejected = append(rejected, rtHost)
+			}
+		}
+		c.reportHostnames(rt, rejected, narrowed)
+	}
 	return hostTargets, nil
 }
 
+// reportHostnames records the events of a route whose hostnames are rejected, matching no listener of its
+// gateways, or narrowed to the more specific hostnames of the listeners they match, when they changed
+// since they were last reported.
+func (c *gatewayRouteResolver) reportHostnames(rt gatewayRoute, rejected []string, narrowed map[string][]string) {
+	if c.src.recorder == nil {
+		return
+	}
+	meta := rt.Metadata()
+	key := namespacedName(meta.Namespace, meta.Name)
+
+	var lines []string
+	for _, host := range rejected {
+		lines = append(lines, "rejected "+host)
+	}
+	narrowedHosts := slices.Sorted(maps.Keys(narrowed))
+	for _, host := range narrowedHosts {
+		slices.Sort(narrowed[host])
+		lines = append(lines, "narrowed "+host+" "+strings.Join(narrowed[host], ","))
+	}
+	report := strings.Join(lines, "\n")
+	previous, reported := c.src.reportedHosts[key]
+	if report != "" || reported {
+		c.reportedHosts[key] = report
+	}
+	if report == previous {
+		return
+	}
+
+	gvk := rt.Object().GetObjectKind().GroupVersionKind()
+	ref := &corev1.ObjectReference{
+		APIVersion:      gvk.GroupVersion().String(),
+		Kind:            c.src.rtKind,
+		Namespace:       meta.Namespace,
+		Name:            meta.Name,
+		UID:             meta.UID,
+		ResourceVersion: meta.ResourceVersion,
+	}
+	for _, host := range rejected {
+		c.src.recorder.Eventf(ref, corev1.EventTypeWarning, "HostnameNotServed", "Hostname %q matches no listener of the gateways of the %s, no DNS record is created for it", host, c.src.rtKind)
+	}
+	for _, host := range narrowedHosts {
+		c.src.recorder.Eventf(ref, corev1.EventTypeNormal, "HostnameNarrowed", "Hostname %q is narrowed to the hostnames of the listeners serving it: %s", host, strings.Join(narrowed[host], ", "))
+	}
+}
+
 func (c *gatewayRouteResolver) hosts(rt gatewayRoute) ([]string, error) {
 	var hostnames []string
 	for _, name := range rt.Hostnames() {
@@ -26,6 +26,7 @@ import (
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/labels"
 	kubefake "k8s.io/client-go/kubernetes/fake"
+	"k8s.io/client-go/tools/record"
 	"sigs.k8s.io/external-dns/endpoint"
 	"sigs.k8s.io/external-dns/internal/testutils"
 	v1 "sigs.k8s.io/gateway-api/apis/v1"
@@ -1400,6 +1401,76 @@ func TestGatewayHTTPRouteSourceEndpoints(t *testing.T) {
 				newTestEndpoint("test.example.internal", "A", "4.3.2.1"),
 			},
 		},
+		{
+			title: "ListenerTargetsOverride",
+			config: Config{
+				GatewayNamespace: "gateway-namespace",
+			},
+			namespaces: namespaces("gateway-namespace", "route-namespace"),
+			gateways: []*v1beta1.Gateway{
+				{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      "dual-lb-gateway",
+						Namespace: "gateway-namespace",
+						Annotations: map[string]string{
+							targetAnnotationKey:          "4.3.2.1",
+							listenerTargetsAnnotationKey: "public=nlb.example.com,static=5.6.7.8, static=6.7.8.9,unknown=9.9.9.9",
+						},
+					},
+					Spec: v1.GatewaySpec{
+						Listeners: []v1.Listener{
+							{Name: "public", Protocol: v1.HTTPProtocolType, AllowedRoutes: allowAllNamespaces},
+							{Name: "static", Protocol: v1.HTTPProtocolType, AllowedRoutes: allowAllNamespaces},
+							{Name: "other", Protocol: v1.HTTPProtocolType, AllowedRoutes: allowAllNamespaces},
+						},
+					},
+					Status: gatewayStatus("1.2.3.4"),
+				},
+			},
+			routes: []*v1beta1.HTTPRoute{
+				{
+					ObjectMeta: objectMeta("route-namespace", "public"),
+					Spec: v1.HTTPRouteSpec{
+						Hostnames: hostnames("public.example.internal"),
+						CommonRouteSpec: v1.CommonRouteSpec{
+							ParentRefs: []v1.ParentReference{
+								gwParentRef("gateway-namespace", "dual-lb-gateway", withSectionName("public")),
+							},
+						},
+					},
+					Status: httpRouteStatus(gwParentRef("gateway-namespace", "dual-lb-gateway", withSectionName("public"))),
+				},
+				{
+					ObjectMeta: objectMeta("route-namespace", "static"),
+					Spec: v1.HTTPRouteSpec{
+						Hostnames: hostnames("static.example.internal"),
+						CommonRouteSpec: v1.CommonRouteSpec{
+							ParentRefs: []v1.ParentReference{
+								gwParentRef("gateway-namespace", "dual-lb-gateway", withSectionName("static")),
+							},
+						},
+					},
+					Status: httpRouteStatus(gwParentRef("gateway-namespace", "dual-lb-gateway", withSectionName("static"))),
+				},
+				{
+					ObjectMeta: objectMeta("route-namespace", "other"),
+					Spec: v1.HTTPRouteSpec{
+						Hostnames: hostnames("other.example.internal"),
+						CommonRouteSpec: v1.CommonRouteSpec{
+							ParentRefs: []v1.ParentReference{
+								gwParentRef("gateway-namespace", "dual-lb-gateway", withSectionName("other")),
+							},
+						},
+					},
+					Status: httpRouteStatus(gwParentRef("gateway-namespace", "dual-lb-gateway", withSectionName("other"))),
+				},
+			},
+			endpoints: []*endpoint.Endpoint{
+				newTestEndpoint("public.example.internal", "CNAME", "nlb.example.com"),
+				newTestEndpoint("static.example.internal", "A", "5.6.7.8", "6.7.8.9"),
+				newTestEndpoint("other.example.internal", "A", "4.3.2.1"),
+			},
+		},
 		{
 			title: "MutlipleGatewaysOneAnnotationOverride",
 			config: Config{
@@ -1624,3 +1695,69 @@ func TestGatewayHTTPRouteSourceEndpoints(t *testing.T) {
 }
 
 func hostnamePtr(val v1.Hostname) *v1.Hostname { return &val }
+
+func TestGatewayHTTPRouteSourceHostnameEvents(t *testing.T) {
+	ctx := context.Background()
+	gwClient := gatewayfake.NewSimpleClientset()
+	_, err := gwClient.GatewayV1beta1().Gateways("default").Create(ctx, &v1beta1.Gateway{
+		ObjectMeta: metav1.ObjectMeta{Name: "test", Namespace: "default"},
+		Spec: v1.GatewaySpec{
+			Listeners: []v1.Listener{{
+				Hostname: hostnamePtr("foo.example.internal"),
+				Protocol: v1.HTTPProtocolType,
+			}},
+		},
+		Status: gatewayStatus("1.2.3.4"),
+	}, metav1.CreateOptions{})
+	require.NoError(t, err)
+	route := &v1beta1.HTTPRoute{
+		ObjectMeta: metav1.ObjectMeta{Name: "test", Namespace: "default"},
+		Spec: v1.HTTPRouteSpec{
+			Hostnames: []v1.Hostname{"*.example.internal", "test.other.internal"},
+			CommonRouteSpec: v1.CommonRouteSpec{
+				ParentRefs: []v1.ParentReference{gwParentRef("default", "test")},
+			},
+		},
+		Status: httpRouteStatus(gwParentRef("default", "test")),
+	}
+	_, err = gwClient.GatewayV1beta1().HTTPRoutes("default").Create(ctx, route, metav1.CreateOptions{})
+	require.NoError(t, err)
+	kubeClient := kubefake.NewSimpleClientset()
+	_, err = kubeClient.CoreV1().Namespaces().Create(ctx, &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "default"}}, metav1.CreateOptions{})
+	require.NoError(t, err)
+
+	clients := new(MockClientGenerator)
+	clients.On("GatewayClient").Return(gwClient, nil)
+	clients.On("KubeClient").Return(kubeClient, nil)
+	src, err := NewGatewayHTTPRouteSource(clients, &Config{})
+	require.NoError(t, err)
+	recorder := record.NewFakeRecorder(10)
+	src.(*gatewayRouteSource).recorder = recorder
+
+	endpoints, err := src.Endpoints(ctx)
+	require.NoError(t, err)
+	validateEndpoints(t, endpoints, []*endpoint.Endpoint{
+		newTestEndpoint("foo.example.internal", "A", "1.2.3.4"),
+	})
+	require.Equal(t, []string{
+		`Warning HostnameNotServed Hostname "test.other.internal" matches no listener of the gateways of the HTTPRoute, no DNS record is created for it`,
+		`Normal HostnameNarrowed Hostname "*.example.internal" is narrowed to the hostnames of the listeners serving it: foo.example.internal`,
+	}, drainEvents(recorder))
+
+	// unchanged hostnames are not reported again
+	_, err = src.Endpoints(ctx)
+	require.NoError(t, err)
+	require.Empty(t, drainEvents(recorder))
+}
+
+func drainEvents(recorder *record.FakeRecorder) []string {
+	var events []string
+	for {
+		select {
+		case event := <-recorder.Events:
+			events = append(events, event)
+		default:
+			return events
+		}
+	}
+}
@@ -0,0 +1,193 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package source
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/prometheus/client_golang/prometheus"
+	log "github.com/sirupsen/logrus"
+	"golang.org/x/net/idna"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/client-go/tools/record"
+
+	"sigs.k8s.io/external-dns/endpoint"
+	"sigs.k8s.io/external-dns/pkg/metrics"
+)
+
+const (
+	// maxHostnameLength is the maximum length of a DNS name in its text form, without the trailing dot, as per RFC 1035.
+	maxHostnameLength = 253
+	// maxLabelLength is the maximum length of a DNS label, as per RFC 1035.
+	maxLabelLength = 63
+)
+
+var (
+	rejectedEndpointsTotal = metrics.NewCounterVecWithOpts(
+		prometheus.CounterOpts{
+			Namespace: "external_dns",
+			Subsystem: "source",
+			Name:      "rejected_endpoints_total",
+			Help:      "Number of endpoints rejected because of an invalid DNS name, by reason.",
+		},
+		[]string{"reason"},
+	)
+)
+
+func init() {
+	metrics.RegisterMetric.MustRegister(rejectedEndpointsTotal)
+}
+
+// HostnameError tells why a DNS name is invalid.
+type HostnameError struct {
+	// Reason is one of too_long, empty_label, label_too_long, invalid_character and invalid_hyphen.
+	Reason string
+	Msg    string
+}
+
+func (e *HostnameError) Error() string {
+	return e.Msg
+}
+
+// hostnameValidationSource is a Source that removes endpoints with invalid DNS names from its wrapped source.
+type hostnameValidationSource struct {
+	source Source
+	// recorder, when set, records the events of the resources of the rejected endpoints, reported being the
+	// endpoints rejected by the last call to Endpoints.
+	recorder record.EventRecorder
+	reported map[string]bool
+}
+
+// NewHostnameValidationSource creates a new hostnameValidationSource wrapping the provided Source. With a
+// recorder, an Event is recorded on the resource of each endpoint rejected since the previous call.
+func NewHostnameValidationSource(source Source, recorder record.EventRecorder) Source {
+	return &hostnameValidationSource{source: source, recorder: recorder, reported: map[string]bool{}}
+}
+
+// Endpoints collects endpoints from its wrapped source and returns them without those whose DNS name
+// is invalid, see ValidateHostname, so that they are reported with the resource they come from rather
+// than failing later in the provider.
+func (hs *hostnameValidationSource) Endpoints(ctx context.Context) ([]*endpoint.Endpoint, error) {
+	endpoints, err := hs.source.Endpoints(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]*endpoint.Endpoint, 0, len(endpoints))
+	reported := map[string]bool{}
+	for _, ep := range endpoints {
+		if err := ValidateHostname(ep.DNSName); err != nil {
+			reason := "invalid"
+			var hostnameErr *HostnameError
+			if errors.As(err, &hostnameErr) {
+				reason = hostnameErr.Reason
+			}
+			rejectedEndpointsTotal.CounterVec.WithLabelValues(reason).Inc()
+			resource := ep.Labels[endpoint.ResourceLabelKey]
+			log.Warnf("Rejecting %s record %q from %s: %v", ep.RecordType, ep.DNSName, resource, err)
+			key := resource + " " + ep.DNSName
+			if !reported[key] && !hs.reported[key] {
+				hs.recordEvent(resource, ep.DNSName, err)
+			}
+			reported[key] = true
+			continue
+		}
+		result = append(result, ep)
+	}
+	hs.reported = reported
+
+	return result, nil
+}
+
+// recordEvent records a warning on the resource, labelled as kind/namespace/name, of a rejected DNS name.
+func (hs *hostnameValidationSource) recordEvent(resource, dnsName string, err error) {
+	if hs.recorder == nil {
+		return
+	}
+	ref := ResourceReference(resource)
+	if ref == nil {
+		return
+	}
+	hs.recorder.Eventf(ref, corev1.EventTypeWarning, "InvalidHostname", "Hostname %q is invalid, no DNS record is created for it: %v", dnsName, err)
+}
+
+func (hs *hostnameValidationSource) AddEventHandler(ctx context.Context, handler func()) {
+	hs.source.AddEventHandler(ctx, handler)
+}
+
+// ValidateHostname checks name is a valid DNS name as per RFC 1035 and RFC 1123: at most 253 characters,
+// optionally followed by a dot, made of labels of 1 to 63 letters, digits and hyphens, not starting or ending
+// with a hyphen. Underscores are accepted, as used by service labels, as is a wildcard first label.
+// Internationalized labels are checked in their ASCII form.
+func ValidateHostname(name string) error {
+	trimmed := strings.TrimSuffix(name, ".")
+	if trimmed == "" {
+		return &HostnameError{Reason: "empty_label", Msg: "empty DNS name"}
+	}
+
+	labels := strings.Split(trimmed, ".")
+	length := len(labels) - 1
+	for i, label := range labels {
+		if label == "" {
+			return &HostnameError{Reason: "empty_label", Msg: fmt.Sprintf("empty label at position %d", i+1)}
+		}
+		if label == "*" && i == 0 {
+			length++
+			continue
+		}
+		if !isASCII(label) {
+			converted, err := idna.Lookup.ToASCII(label)
+			if err != nil {
+				return &HostnameError{Reason: "invalid_character", Msg: fmt.Sprintf("label %q is not a valid internationalized label: %v", label, err)}
+			}
+			label = converted
+		}
+		if len(label) > maxLabelLength {
+			return &HostnameError{Reason: "label_too_long", Msg: fmt.Sprintf("label %q is longer than %d characters", label, maxLabelLength)}
+		}
+		for _, c := range label {
+			if !isLabelChar(c) {
+				return &HostnameError{Reason: "invalid_character", Msg: fmt.Sprintf("label %q has the invalid character %q", label, c)}
+			}
+		}
+		if label[0] == '-' || label[len(label)-1] == '-' {
+			return &HostnameError{Reason: "invalid_hyphen", Msg: fmt.Sprintf("label %q starts or ends with a hyphen", label)}
+		}
+		length += len(label)
+	}
+
+	if length > maxHostnameLength {
+		return &HostnameError{Reason: "too_long", Msg: fmt.Sprintf("DNS name is %d characters long, more than %d", length, maxHostnameLength)}
+	}
+	return nil
+}
+
+func isASCII(s string) bool {
+	for i := 0; i < len(s); i++ {
+		if s[i] >= 0x80 {
+			return false
+		}
+	}
+	return true
+}
+
+func isLabelChar(c rune) bool {
+	return c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z' || c >= '0' && c <= '9' || c == '-' || c == '_'
+}
@@ -0,0 +1,126 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package source
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"k8s.io/client-go/tools/record"
+
+	"sigs.k8s.io/external-dns/endpoint"
+)
+
+func TestValidateHostname(t *testing.T) {
+	for _, tt := range []struct {
+		name   string
+		reason string
+	}{
+		{name: "example.com"},
+		{name: "example.com."},
+		{name: "*.example.com"},
+		{name: "_sip._tcp.example.com"},
+		{name: "xn--bcher-kva.example.com"},
+		{name: "bücher.example.com"},
+		{name: "Foo-1.Example.com"},
+		{name: strings.Repeat("a", 63) + ".example.com"},
+		{name: strings.Repeat("a.", 126) + "a"},
+		{name: "", reason: "empty_label"},
+		{name: ".", reason: "empty_label"},
+		{name: "foo..example.com", reason: "empty_label"},
+		{name: ".example.com", reason: "empty_label"},
+		{name: "example.com..", reason: "empty_label"},
+		{name: strings.Repeat("a", 64) + ".example.com", reason: "label_too_long"},
+		{name: strings.Repeat("a.", 127) + "a", reason: "too_long"},
+		{name: "foo bar.example.com", reason: "invalid_character"},
+		{name: "foo/bar.example.com", reason: "invalid_character"},
+		{name: "foo.*.example.com", reason: "invalid_character"},
+		{name: "-foo.example.com", reason: "invalid_hyphen"},
+		{name: "foo-.example.com", reason: "invalid_hyphen"},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ValidateHostname(tt.name)
+			if tt.reason == "" {
+				assert.NoError(t, err)
+				return
+			}
+			var hostnameErr *HostnameError
+			require.ErrorAs(t, err, &hostnameErr)
+			assert.Equal(t, tt.reason, hostnameErr.Reason)
+		})
+	}
+}
+
+func TestHostnameValidationSource(t *testing.T) {
+	endpoints := []*endpoint.Endpoint{
+		endpoint.NewEndpoint("foo.example.com", endpoint.RecordTypeA, "1.2.3.4"),
+		endpoint.NewEndpoint("-foo.example.com", endpoint.RecordTypeA, "1.2.3.4"),
+		endpoint.NewEndpoint("foo_bar.example.com", endpoint.RecordTypeCNAME, "foo.example.com"),
+		endpoint.NewEndpoint("foo bar.example.com", endpoint.RecordTypeA, "1.2.3.4"),
+	}
+	before := testutil.ToFloat64(rejectedEndpointsTotal.CounterVec.WithLabelValues("invalid_hyphen"))
+
+	res, err := NewHostnameValidationSource(NewEchoSource(endpoints), nil).Endpoints(context.Background())
+	require.NoError(t, err)
+
+	names := make([]string, 0, len(res))
+	for _, ep := range res {
+		names = append(names, ep.DNSName)
+	}
+	assert.Equal(t, []string{"foo.example.com", "foo_bar.example.com"}, names)
+	assert.Equal(t, before+1, testutil.ToFloat64(rejectedEndpointsTotal.CounterVec.WithLabelValues("invalid_hyphen")))
+}
+
+func TestHostnameValidationSourceEvents(t *testing.T) {
+	withResource := func(ep *endpoint.Endpoint, resource string) *endpoint.Endpoint {
+		ep.Labels[endpoint.ResourceLabelKey] = resource
+		return ep
+	}
+	valid := withResource(endpoint.NewEndpoint("foo.example.com", endpoint.RecordTypeA, "1.2.3.4"), "ingress/default/foo")
+	invalid := []*endpoint.Endpoint{
+		withResource(endpoint.NewEndpoint("-foo.example.com", endpoint.RecordTypeA, "1.2.3.4"), "ingress/default/foo"),
+		withResource(endpoint.NewEndpoint("foo.*.example.com", endpoint.RecordTypeA, "1.2.3.4"), "service/default/bar"),
+		withResource(endpoint.NewEndpoint("bar-.example.com", endpoint.RecordTypeA, "1.2.3.4"), "prometheus-sd/10.0.0.1:9100"),
+	}
+
+	recorder := record.NewFakeRecorder(10)
+	src := NewHostnameValidationSource(NewEchoSource(append([]*endpoint.Endpoint{valid}, invalid...)), recorder).(*hostnameValidationSource)
+	_, err := src.Endpoints(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, []string{
+		`Warning InvalidHostname Hostname "-foo.example.com" is invalid, no DNS record is created for it: label "-foo" starts or ends with a hyphen`,
+		`Warning InvalidHostname Hostname "foo.*.example.com" is invalid, no DNS record is created for it: label "*" has the invalid character '*'`,
+	}, drainEvents(recorder))
+
+	// names still rejected are not reported again
+	_, err = src.Endpoints(context.Background())
+	require.NoError(t, err)
+	assert.Empty(t, drainEvents(recorder))
+
+	// but are once fixed and broken again
+	src.source = NewEchoSource([]*endpoint.Endpoint{valid})
+	_, err = src.Endpoints(context.Background())
+	require.NoError(t, err)
+	src.source = NewEchoSource(invalid[:1])
+	_, err = src.Endpoints(context.Background())
+	require.NoError(t, err)
+	assert.Len(t, drainEvents(recorder), 1)
+}
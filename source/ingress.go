@@ -28,7 +28,6 @@ import (
 	networkv1 "k8s.io/api/networking/v1"
 	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/apimachinery/pkg/selection"
-	kubeinformers "k8s.io/client-go/informers"
 	netinformers "k8s.io/client-go/informers/networking/v1"
 	"k8s.io/client-go/kubernetes"
 	"k8s.io/client-go/tools/cache"
@@ -41,6 +40,7 @@ const (
 	// Possible values for the ingress-hostname-source annotation
 	IngressHostnameSourceAnnotationOnlyValue   = "annotation-only"
 	IngressHostnameSourceDefinedHostsOnlyValue = "defined-hosts-only"
+	IngressHostnameSourceTLSHostsOnlyValue     = "tls-hosts-only"
 
 	IngressClassAnnotationKey = "kubernetes.io/ingress.class"
 )
@@ -87,7 +87,7 @@ func NewIngressSource(ctx context.Context, kubeClient kubernetes.Interface, name
 	}
 	// Use shared informer to listen for add/update/delete of ingresses in the specified namespace.
 	// Set resync period to 0, to prevent processing when nothing has changed.
-	informerFactory := kubeinformers.NewSharedInformerFactoryWithOptions(kubeClient, 0, kubeinformers.WithNamespace(namespace))
+	informerFactory := newKubeInformerFactory(kubeClient, namespace, ctx.Done())
 	ingressInformer := informerFactory.Networking().V1().Ingresses()
 
 	// Add default resource event handlers to properly initialize informer.
@@ -167,6 +167,7 @@ func (sc *ingressSource) Endpoints(ctx context.Context) ([]*endpoint.Endpoint, e
 		}
 
 		log.Debugf("Endpoints generated from ingress: %s/%s: %v", ing.Namespace, ing.Name, ingEndpoints)
+		setObservedVersion(ing, ingEndpoints)
 		endpoints = append(endpoints, ingEndpoints...)
 	}
 
@@ -295,6 +296,8 @@ func endpointsFromIngress(ing *networkv1.Ingress, ignoreHostnameAnnotation bool,
 		}
 	}
 
+	// Gather endpoints defined on the tls section of the ingress
+	var tlsHostsEndpoints []*endpoint.Endpoint
 	// Skip endpoints if we do not want entries from tls spec section
 	if !ignoreIngressTLSSpec {
 		for _, tls := range ing.Spec.TLS {
@@ -302,10 +305,11 @@ func endpointsFromIngress(ing *networkv1.Ingress, ignoreHostnameAnnotation bool,
 				if host == "" {
 					continue
 				}
-				definedHostsEndpoints = append(definedHostsEndpoints, endpointsForHostname(host, targets, ttl, providerSpecific, setIdentifier, resource)...)
+				tlsHostsEndpoints = append(tlsHostsEndpoints, endpointsForHostname(host, targets, ttl, providerSpecific, setIdentifier, resource)...)
 			}
 		}
 	}
+	definedHostsEndpoints = append(definedHostsEndpoints, tlsHostsEndpoints...)
 
 	// Gather endpoints defined on annotations in the ingress
 	var annotationEndpoints []*endpoint.Endpoint
@@ -329,6 +333,9 @@ func endpointsFromIngress(ing *networkv1.Ingress, ignoreHostnameAnnotation bool,
 	if strings.ToLower(hostnameSourceAnnotation) == IngressHostnameSourceAnnotationOnlyValue {
 		endpoints = append(endpoints, annotationEndpoints...)
 	}
+	if strings.ToLower(hostnameSourceAnnotation) == IngressHostnameSourceTLSHostsOnlyValue {
+		endpoints = append(endpoints, tlsHostsEndpoints...)
+	}
 	return endpoints
 }
 
@@ -381,6 +381,36 @@ func testEndpointsFromIngressHostnameSourceAnnotation(t *testing.T) {
 				},
 			},
 		},
+		{
+			title: "Ingress-hostname-source=tls-hosts-only, wildcard rule.host, tls hosts, one annotation host",
+			ingress: fakeIngress{
+				dnsnames:    []string{"*.foo.bar"},
+				tlsdnsnames: [][]string{{"a.foo.bar", "b.foo.bar"}},
+				annotations: map[string]string{hostnameAnnotationKey: "foo.baz", ingressHostnameSourceKey: "tls-hosts-only"},
+				hostnames:   []string{"lb.com"},
+			},
+			expected: []*endpoint.Endpoint{
+				{
+					DNSName:    "a.foo.bar",
+					RecordType: endpoint.RecordTypeCNAME,
+					Targets:    endpoint.Targets{"lb.com"},
+				},
+				{
+					DNSName:    "b.foo.bar",
+					RecordType: endpoint.RecordTypeCNAME,
+					Targets:    endpoint.Targets{"lb.com"},
+				},
+			},
+		},
+		{
+			title: "Ingress-hostname-source=tls-hosts-only, no tls hosts",
+			ingress: fakeIngress{
+				dnsnames:    []string{"foo.bar"},
+				annotations: map[string]string{ingressHostnameSourceKey: "tls-hosts-only"},
+				hostnames:   []string{"lb.com"},
+			},
+			expected: []*endpoint.Endpoint{},
+		},
 	} {
 		t.Run(ti.title, func(t *testing.T) {
 			realIngress := ti.ingress.Ingress()
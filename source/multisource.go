@@ -18,6 +18,10 @@ package source
 
 import (
 	"context"
+	"fmt"
+	"time"
+
+	log "github.com/sirupsen/logrus"
 
 	"sigs.k8s.io/external-dns/endpoint"
 )
@@ -26,17 +30,43 @@ import (
 type multiSource struct {
 	children       []Source
 	defaultTargets []string
+	// timeout bounds the time given to each child to return its endpoints. Zero means no timeout.
+	timeout time.Duration
+	// skipFailures makes Endpoints leave out the children that fail instead of failing as a whole.
+	skipFailures bool
+}
+
+// childResult holds what a child source returned.
+type childResult struct {
+	endpoints []*endpoint.Endpoint
+	err       error
 }
 
 // Endpoints collects endpoints of all nested Sources and returns them in a single slice.
+// The children are queried concurrently, and their endpoints are merged in the order of the children.
 func (ms *multiSource) Endpoints(ctx context.Context) ([]*endpoint.Endpoint, error) {
-	result := []*endpoint.Endpoint{}
+	results := make([]childResult, len(ms.children))
+	done := make(chan struct{}, len(ms.children))
+	for i, s := range ms.children {
+		go func() {
+			results[i] = ms.fetch(ctx, s)
+			done <- struct{}{}
+		}()
+	}
+	for range ms.children {
+		<-done
+	}
 
-	for _, s := range ms.children {
-		endpoints, err := s.Endpoints(ctx)
-		if err != nil {
-			return nil, err
+	result := []*endpoint.Endpoint{}
+	for idx, r := range results {
+		if r.err != nil {
+			if !ms.skipFailures {
+				return nil, r.err
+			}
+			log.Warnf("Skipping endpoints of source %d (%T): %v", idx, ms.children[idx], r.err)
+			continue
 		}
+		endpoints := r.endpoints
 		if len(ms.defaultTargets) > 0 {
 			for i := range endpoints {
 				eps := endpointsForHostname(endpoints[i].DNSName, ms.defaultTargets, endpoints[i].RecordTTL, endpoints[i].ProviderSpecific, endpoints[i].SetIdentifier, "")
@@ -53,13 +83,40 @@ func (ms *multiSource) Endpoints(ctx context.Context) ([]*endpoint.Endpoint, err
 	return result, nil
 }
 
+// fetch returns the endpoints of s, giving up once the timeout expires even if s does not honour
+// the cancellation of its context.
+func (ms *multiSource) fetch(ctx context.Context, s Source) childResult {
+	if ms.timeout <= 0 {
+		endpoints, err := s.Endpoints(ctx)
+		return childResult{endpoints, err}
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, ms.timeout)
+	defer cancel()
+
+	resultCh := make(chan childResult, 1)
+	go func() {
+		endpoints, err := s.Endpoints(ctx)
+		resultCh <- childResult{endpoints, err}
+	}()
+
+	select {
+	case r := <-resultCh:
+		return r
+	case <-ctx.Done():
+		return childResult{err: fmt.Errorf("fetching endpoints from %T: %w", s, ctx.Err())}
+	}
+}
+
 func (ms *multiSource) AddEventHandler(ctx context.Context, handler func()) {
 	for _, s := range ms.children {
 		s.AddEventHandler(ctx, handler)
 	}
 }
 
-// NewMultiSource creates a new multiSource.
-func NewMultiSource(children []Source, defaultTargets []string) Source {
-	return &multiSource{children: children, defaultTargets: defaultTargets}
+// NewMultiSource creates a new multiSource. The endpoints of the children are fetched concurrently,
+// each within timeout when it is positive. A child that fails or times out fails the whole fetch,
+// unless skipFailures is set, in which case its endpoints are left out.
+func NewMultiSource(children []Source, defaultTargets []string, timeout time.Duration, skipFailures bool) Source {
+	return &multiSource{children: children, defaultTargets: defaultTargets, timeout: timeout, skipFailures: skipFailures}
 }
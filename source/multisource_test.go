@@ -20,6 +20,7 @@ import (
 	"context"
 	"errors"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -35,6 +36,9 @@ func TestMultiSource(t *testing.T) {
 	t.Run("Endpoints", testMultiSourceEndpoints)
 	t.Run("EndpointsWithError", testMultiSourceEndpointsWithError)
 	t.Run("EndpointsDefaultTargets", testMultiSourceEndpointsDefaultTargets)
+	t.Run("EndpointsConcurrently", testMultiSourceEndpointsConcurrently)
+	t.Run("EndpointsTimeout", testMultiSourceEndpointsTimeout)
+	t.Run("EndpointsSkipFailures", testMultiSourceEndpointsSkipFailures)
 }
 
 // testMultiSourceImplementsSource tests that multiSource is a valid Source.
@@ -89,7 +93,7 @@ func testMultiSourceEndpoints(t *testing.T) {
 			}
 
 			// Create our object under test and get the endpoints.
-			source := NewMultiSource(sources, nil)
+			source := NewMultiSource(sources, nil, 0, false)
 
 			// Get endpoints from the source.
 			endpoints, err := source.Endpoints(context.Background())
@@ -116,7 +120,7 @@ func testMultiSourceEndpointsWithError(t *testing.T) {
 	src.On("Endpoints").Return(nil, errSomeError)
 
 	// Create our object under test and get the endpoints.
-	source := NewMultiSource([]Source{src}, nil)
+	source := NewMultiSource([]Source{src}, nil, 0, false)
 
 	// Get endpoints from our source.
 	_, err := source.Endpoints(context.Background())
@@ -156,7 +160,7 @@ func testMultiSourceEndpointsDefaultTargets(t *testing.T) {
 	src.On("Endpoints").Return(sourceEndpoints, nil)
 
 	// Create our object under test with non-empty defaultTargets and get the endpoints.
-	source := NewMultiSource([]Source{src}, defaultTargets)
+	source := NewMultiSource([]Source{src}, defaultTargets, 0, false)
 
 	// Get endpoints from our source.
 	endpoints, err := source.Endpoints(context.Background())
@@ -168,3 +172,71 @@ func testMultiSourceEndpointsDefaultTargets(t *testing.T) {
 	// Validate that the nested sources were called.
 	src.AssertExpectations(t)
 }
+
+// blockingSource returns its endpoints once release is closed, ignoring the cancellation of its context.
+type blockingSource struct {
+	endpoints []*endpoint.Endpoint
+	called    chan struct{}
+	release   chan struct{}
+}
+
+func newBlockingSource(endpoints ...*endpoint.Endpoint) *blockingSource {
+	return &blockingSource{endpoints: endpoints, called: make(chan struct{}), release: make(chan struct{})}
+}
+
+func (s *blockingSource) Endpoints(_ context.Context) ([]*endpoint.Endpoint, error) {
+	close(s.called)
+	<-s.release
+	return s.endpoints, nil
+}
+
+func (s *blockingSource) AddEventHandler(_ context.Context, _ func()) {}
+
+// testMultiSourceEndpointsConcurrently tests that children are queried concurrently and merged in order.
+func testMultiSourceEndpointsConcurrently(t *testing.T) {
+	foo := &endpoint.Endpoint{DNSName: "foo", Targets: endpoint.Targets{"8.8.8.8"}}
+	bar := &endpoint.Endpoint{DNSName: "bar", Targets: endpoint.Targets{"8.8.4.4"}}
+	first, second := newBlockingSource(foo), newBlockingSource(bar)
+
+	// the first source only returns once the second one has been queried
+	go func() {
+		<-second.called
+		close(second.release)
+		close(first.release)
+	}()
+
+	endpoints, err := NewMultiSource([]Source{first, second}, nil, 0, false).Endpoints(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, []*endpoint.Endpoint{foo, bar}, endpoints)
+}
+
+// testMultiSourceEndpointsTimeout tests that a child exceeding the timeout fails the whole fetch.
+func testMultiSourceEndpointsTimeout(t *testing.T) {
+	stuck := newBlockingSource()
+	defer close(stuck.release)
+
+	src := new(testutils.MockSource)
+	src.On("Endpoints").Return([]*endpoint.Endpoint{}, nil)
+
+	_, err := NewMultiSource([]Source{src, stuck}, nil, 10*time.Millisecond, false).Endpoints(context.Background())
+	require.ErrorIs(t, err, context.DeadlineExceeded)
+	assert.Contains(t, err.Error(), "*source.blockingSource")
+}
+
+// testMultiSourceEndpointsSkipFailures tests that failing children are left out when failures are skipped.
+func testMultiSourceEndpointsSkipFailures(t *testing.T) {
+	foo := &endpoint.Endpoint{DNSName: "foo", Targets: endpoint.Targets{"8.8.8.8"}}
+
+	stuck := newBlockingSource()
+	defer close(stuck.release)
+
+	failing := new(testutils.MockSource)
+	failing.On("Endpoints").Return(nil, errors.New("some error"))
+
+	healthy := new(testutils.MockSource)
+	healthy.On("Endpoints").Return([]*endpoint.Endpoint{foo}, nil)
+
+	endpoints, err := NewMultiSource([]Source{stuck, failing, healthy}, nil, 10*time.Millisecond, true).Endpoints(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, []*endpoint.Endpoint{foo}, endpoints)
+}
@@ -19,13 +19,14 @@ package source
 import (
 	"context"
 	"fmt"
+	"slices"
 	"text/template"
+	"time"
 
 	log "github.com/sirupsen/logrus"
 	v1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/labels"
-	kubeinformers "k8s.io/client-go/informers"
 	coreinformers "k8s.io/client-go/informers/core/v1"
 	"k8s.io/client-go/kubernetes"
 	"k8s.io/client-go/tools/cache"
@@ -44,10 +45,14 @@ type nodeSource struct {
 	labelSelector        labels.Selector
 	excludeUnschedulable bool
 	exposeInternalIPV6   bool
+	excludeTaints        []string
+	notReadyGracePeriod  time.Duration
 }
 
 // NewNodeSource creates a new nodeSource with the given config.
-func NewNodeSource(ctx context.Context, kubeClient kubernetes.Interface, annotationFilter, fqdnTemplate string, labelSelector labels.Selector, exposeInternalIPv6 bool, excludeUnschedulable bool) (Source, error) {
+// Nodes with a taint whose key is in excludeTaints are skipped, as are nodes which have not been ready
+// for longer than notReadyGracePeriod, unless it is zero.
+func NewNodeSource(ctx context.Context, kubeClient kubernetes.Interface, annotationFilter, fqdnTemplate string, labelSelector labels.Selector, exposeInternalIPv6 bool, excludeUnschedulable bool, excludeTaints []string, notReadyGracePeriod time.Duration) (Source, error) {
 	tmpl, err := fqdn.ParseTemplate(fqdnTemplate)
 	if err != nil {
 		return nil, err
@@ -55,7 +60,7 @@ func NewNodeSource(ctx context.Context, kubeClient kubernetes.Interface, annotat
 
 	// Use shared informers to listen for add/update/delete of nodes.
 	// Set resync period to 0, to prevent processing when nothing has changed
-	informerFactory := kubeinformers.NewSharedInformerFactoryWithOptions(kubeClient, 0)
+	informerFactory := newKubeInformerFactory(kubeClient, "", ctx.Done())
 	nodeInformer := informerFactory.Core().V1().Nodes()
 
 	// Add default resource event handler to properly initialize informer.
@@ -82,6 +87,8 @@ func NewNodeSource(ctx context.Context, kubeClient kubernetes.Interface, annotat
 		labelSelector:        labelSelector,
 		excludeUnschedulable: excludeUnschedulable,
 		exposeInternalIPV6:   exposeInternalIPv6,
+		excludeTaints:        excludeTaints,
+		notReadyGracePeriod:  notReadyGracePeriod,
 	}, nil
 }
 
@@ -114,6 +121,16 @@ func (ns *nodeSource) Endpoints(ctx context.Context) ([]*endpoint.Endpoint, erro
 			continue
 		}
 
+		if key, ok := ns.excludedTaint(node); ok {
+			log.Debugf("Skipping node %s because it has the taint %s", node.Name, key)
+			continue
+		}
+
+		if since, ok := ns.notReadySince(node); ok {
+			log.Debugf("Skipping node %s because it is not ready since %s", node.Name, since.Format(time.RFC3339))
+			continue
+		}
+
 		log.Debugf("creating endpoint for node %s", node.Name)
 
 		ttl := getTTLFromAnnotations(node.Annotations, fmt.Sprintf("node/%s", node.Name))
@@ -207,6 +224,36 @@ func (ns *nodeSource) nodeAddresses(node *v1.Node) ([]string, error) {
 	return nil, fmt.Errorf("could not find node address for %s", node.Name)
 }
 
+// excludedTaint returns the key of the first taint of the node which is excluded.
+func (ns *nodeSource) excludedTaint(node *v1.Node) (string, bool) {
+	for _, taint := range node.Spec.Taints {
+		if slices.Contains(ns.excludeTaints, taint.Key) {
+			return taint.Key, true
+		}
+	}
+	return "", false
+}
+
+// notReadySince returns when the node stopped being ready, if that was longer than the grace period ago.
+// Nodes which stopped being ready more recently are kept, so that a node briefly not ready does not make
+// its records flap. Nodes without a Ready condition are considered ready.
+func (ns *nodeSource) notReadySince(node *v1.Node) (time.Time, bool) {
+	if ns.notReadyGracePeriod <= 0 {
+		return time.Time{}, false
+	}
+	for _, cond := range node.Status.Conditions {
+		if cond.Type != v1.NodeReady {
+			continue
+		}
+		if cond.Status == v1.ConditionTrue {
+			return time.Time{}, false
+		}
+		since := cond.LastTransitionTime.Time
+		return since, time.Since(since) >= ns.notReadyGracePeriod
+	}
+	return time.Time{}, false
+}
+
 // filterByAnnotations filters a list of nodes by a given annotation selector.
 func (ns *nodeSource) filterByAnnotations(nodes []*v1.Node) ([]*v1.Node, error) {
 	labelSelector, err := metav1.ParseToLabelSelector(ns.annotationFilter)
@@ -19,6 +19,7 @@ package source
 import (
 	"context"
 	"testing"
+	"time"
 
 	log "github.com/sirupsen/logrus"
 	"github.com/sirupsen/logrus/hooks/test"
@@ -89,6 +90,8 @@ func testNodeSourceNewNodeSource(t *testing.T) {
 				labels.Everything(),
 				true,
 				true,
+				nil,
+				0,
 			)
 
 			if ti.expectError {
@@ -114,6 +117,10 @@ func testNodeSourceEndpoints(t *testing.T) {
 		excludeUnschedulable bool // default to false
 		exposeInternalIPv6   bool // default to true for this version. Change later when the next minor version is released.
 		unschedulable        bool // default to false
+		taints               []v1.Taint
+		excludeTaints        []string
+		conditions           []v1.NodeCondition
+		notReadyGracePeriod  time.Duration
 		expected             []*endpoint.Endpoint
 		expectError          bool
 		expectedLogs         []string
@@ -394,6 +401,85 @@ func testNodeSourceEndpoints(t *testing.T) {
 				"Skipping node node1 because it is unschedulable",
 			},
 		},
+		{
+			title:         "node with excluded taint returns nothing",
+			nodeName:      "node1",
+			nodeAddresses: []v1.NodeAddress{{Type: v1.NodeExternalIP, Address: "1.2.3.4"}},
+			taints:        []v1.Taint{{Key: "node.kubernetes.io/out-of-service", Effect: v1.TaintEffectNoExecute}},
+			excludeTaints: []string{"example.com/draining", "node.kubernetes.io/out-of-service"},
+			expected:      []*endpoint.Endpoint{},
+			expectedLogs: []string{
+				"Skipping node node1 because it has the taint node.kubernetes.io/out-of-service",
+			},
+		},
+		{
+			title:         "node with other taint returns node",
+			nodeName:      "node1",
+			nodeAddresses: []v1.NodeAddress{{Type: v1.NodeExternalIP, Address: "1.2.3.4"}},
+			taints:        []v1.Taint{{Key: "example.com/dedicated", Effect: v1.TaintEffectNoSchedule}},
+			excludeTaints: []string{"example.com/draining"},
+			expected: []*endpoint.Endpoint{
+				{RecordType: "A", DNSName: "node1", Targets: endpoint.Targets{"1.2.3.4"}},
+			},
+		},
+		{
+			title:         "node not ready for longer than the grace period returns nothing",
+			nodeName:      "node1",
+			nodeAddresses: []v1.NodeAddress{{Type: v1.NodeExternalIP, Address: "1.2.3.4"}},
+			conditions: []v1.NodeCondition{
+				{Type: v1.NodeReady, Status: v1.ConditionFalse, LastTransitionTime: metav1.NewTime(time.Now().Add(-10 * time.Minute))},
+			},
+			notReadyGracePeriod: 5 * time.Minute,
+			expected:            []*endpoint.Endpoint{},
+			expectedLogs: []string{
+				"Skipping node node1 because it is not ready since",
+			},
+		},
+		{
+			title:         "node with unknown readiness for longer than the grace period returns nothing",
+			nodeName:      "node1",
+			nodeAddresses: []v1.NodeAddress{{Type: v1.NodeExternalIP, Address: "1.2.3.4"}},
+			conditions: []v1.NodeCondition{
+				{Type: v1.NodeReady, Status: v1.ConditionUnknown, LastTransitionTime: metav1.NewTime(time.Now().Add(-10 * time.Minute))},
+			},
+			notReadyGracePeriod: 5 * time.Minute,
+			expected:            []*endpoint.Endpoint{},
+		},
+		{
+			title:         "node not ready within the grace period returns node",
+			nodeName:      "node1",
+			nodeAddresses: []v1.NodeAddress{{Type: v1.NodeExternalIP, Address: "1.2.3.4"}},
+			conditions: []v1.NodeCondition{
+				{Type: v1.NodeReady, Status: v1.ConditionFalse, LastTransitionTime: metav1.NewTime(time.Now().Add(-time.Minute))},
+			},
+			notReadyGracePeriod: 5 * time.Minute,
+			expected: []*endpoint.Endpoint{
+				{RecordType: "A", DNSName: "node1", Targets: endpoint.Targets{"1.2.3.4"}},
+			},
+		},
+		{
+			title:         "node not ready returns node without grace period",
+			nodeName:      "node1",
+			nodeAddresses: []v1.NodeAddress{{Type: v1.NodeExternalIP, Address: "1.2.3.4"}},
+			conditions: []v1.NodeCondition{
+				{Type: v1.NodeReady, Status: v1.ConditionFalse, LastTransitionTime: metav1.NewTime(time.Now().Add(-10 * time.Minute))},
+			},
+			expected: []*endpoint.Endpoint{
+				{RecordType: "A", DNSName: "node1", Targets: endpoint.Targets{"1.2.3.4"}},
+			},
+		},
+		{
+			title:         "ready node returns node with grace period",
+			nodeName:      "node1",
+			nodeAddresses: []v1.NodeAddress{{Type: v1.NodeExternalIP, Address: "1.2.3.4"}},
+			conditions: []v1.NodeCondition{
+				{Type: v1.NodeReady, Status: v1.ConditionTrue, LastTransitionTime: metav1.NewTime(time.Now().Add(-10 * time.Minute))},
+			},
+			notReadyGracePeriod: 5 * time.Minute,
+			expected: []*endpoint.Endpoint{
+				{RecordType: "A", DNSName: "node1", Targets: endpoint.Targets{"1.2.3.4"}},
+			},
+		},
 	} {
 		t.Run(tc.title, func(t *testing.T) {
 			hook := testutils.LogsUnderTestWithLogLevel(log.DebugLevel, t)
@@ -416,9 +502,11 @@ func testNodeSourceEndpoints(t *testing.T) {
 				},
 				Spec: v1.NodeSpec{
 					Unschedulable: tc.unschedulable,
+					Taints:        tc.taints,
 				},
 				Status: v1.NodeStatus{
-					Addresses: tc.nodeAddresses,
+					Addresses:  tc.nodeAddresses,
+					Conditions: tc.conditions,
 				},
 			}
 
@@ -434,6 +522,8 @@ func testNodeSourceEndpoints(t *testing.T) {
 				labelSelector,
 				tc.exposeInternalIPv6,
 				tc.excludeUnschedulable,
+				tc.excludeTaints,
+				tc.notReadyGracePeriod,
 			)
 			require.NoError(t, err)
 
@@ -551,6 +641,8 @@ func testNodeEndpointsWithIPv6(t *testing.T) {
 			labelSelector,
 			tc.exposeInternalIPv6,
 			tc.excludeUnschedulable,
+			nil,
+			0,
 		)
 		require.NoError(t, err)
 
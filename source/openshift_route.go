@@ -159,6 +159,7 @@ func (ors *ocpRouteSource) Endpoints(ctx context.Context) ([]*endpoint.Endpoint,
 		}
 
 		log.Debugf("Endpoints generated from OpenShift Route: %s/%s: %v", ocpRoute.Namespace, ocpRoute.Name, orEndpoints)
+		setObservedVersion(ocpRoute, orEndpoints)
 		endpoints = append(endpoints, orEndpoints...)
 	}
 
@@ -0,0 +1,151 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package source
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+	"golang.org/x/net/publicsuffix"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/validation"
+	"k8s.io/client-go/kubernetes"
+
+	"sigs.k8s.io/external-dns/endpoint"
+)
+
+// previewSource is a Source that moves the DNS names of the resources of preview environments,
+// namespaces carrying a label, under a preview domain.
+type previewSource struct {
+	source      Source
+	client      kubernetes.Interface
+	label       string
+	domain      string
+	maxLifetime time.Duration
+	now         func() time.Time
+}
+
+// NewPreviewSource creates a new previewSource wrapping the provided Source. The DNS names of the resources
+// of namespaces with the label gain the environment, the value of the label or else the name of the namespace,
+// and the domain as a suffix, e.g. app.example.com becomes app.pr-123.preview.example.com. The DNS names of
+// namespaces older than maxLifetime, when not zero, are dropped.
+func NewPreviewSource(source Source, client kubernetes.Interface, label, domain string, maxLifetime time.Duration) Source {
+	if label == "" {
+		return source
+	}
+	return &previewSource{
+		source:      source,
+		client:      client,
+		label:       label,
+		domain:      strings.ToLower(strings.Trim(domain, ".")),
+		maxLifetime: maxLifetime,
+		now:         time.Now,
+	}
+}
+
+// Endpoints collects endpoints from its wrapped source, moves the ones of preview environments under the
+// preview domain and drops the ones of expired or invalid preview environments.
+func (ps *previewSource) Endpoints(ctx context.Context) ([]*endpoint.Endpoint, error) {
+	environments, err := ps.environments(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	endpoints, err := ps.source.Endpoints(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]*endpoint.Endpoint, 0, len(endpoints))
+	for _, ep := range endpoints {
+		environment, ok := environments[resourceNamespace(ep)]
+		if !ok {
+			result = append(result, ep)
+			continue
+		}
+		if environment == "" {
+			log.Debugf("Dropping endpoint %s of an expired or invalid preview environment", ep.DNSName)
+			continue
+		}
+		name := previewName(ep.DNSName, environment, ps.domain)
+		log.Debugf("Moving DNS name %q of preview environment %s to %q", ep.DNSName, environment, name)
+		ep.DNSName = name
+		result = append(result, ep)
+	}
+	return result, nil
+}
+
+// environments returns the environments of the namespaces with the label by namespace, expired or
+// invalid ones being mapped to an empty environment.
+func (ps *previewSource) environments(ctx context.Context) (map[string]string, error) {
+	namespaces, err := ps.client.CoreV1().Namespaces().List(ctx, metav1.ListOptions{LabelSelector: ps.label})
+	if err != nil {
+		return nil, fmt.Errorf("listing the namespaces of preview environments: %w", err)
+	}
+
+	environments := map[string]string{}
+	for _, ns := range namespaces.Items {
+		environment := strings.ToLower(ns.Labels[ps.label])
+		if environment == "" {
+			environment = ns.Name
+		}
+		switch errs := validation.IsDNS1123Label(environment); {
+		case len(errs) > 0:
+			// its resources must not get published under their production names either
+			log.Warnf("Dropping the endpoints of preview environment %q of namespace %s, which is not a valid DNS label: %s", environment, ns.Name, strings.Join(errs, ", "))
+			environment = ""
+		case ps.maxLifetime > 0 && ps.now().Sub(ns.CreationTimestamp.Time) > ps.maxLifetime:
+			log.Warnf("Preview environment %s of namespace %s is older than %s: its records are deleted", environment, ns.Name, ps.maxLifetime)
+			environment = ""
+		}
+		environments[ns.Name] = environment
+	}
+	return environments, nil
+}
+
+func (ps *previewSource) AddEventHandler(ctx context.Context, handler func()) {
+	ps.source.AddEventHandler(ctx, handler)
+}
+
+// resourceNamespace returns the namespace of the resource of an endpoint, labelled as kind/namespace/name.
+func resourceNamespace(ep *endpoint.Endpoint) string {
+	parts := strings.Split(ep.Labels[endpoint.ResourceLabelKey], "/")
+	if len(parts) != 3 {
+		return ""
+	}
+	return parts[1]
+}
+
+// previewName returns the DNS name of a preview environment: name without its registered domain, followed
+// by the environment and the preview domain. Names already under the preview domain are returned as is.
+func previewName(name, environment, domain string) string {
+	name = strings.ToLower(strings.TrimSuffix(name, "."))
+	if name == domain || strings.HasSuffix(name, "."+domain) {
+		return name
+	}
+	prefix := name
+	if registered, err := publicsuffix.EffectiveTLDPlusOne(name); err == nil {
+		prefix = strings.TrimSuffix(strings.TrimSuffix(name, registered), ".")
+	}
+	if prefix == "" {
+		return environment + "." + domain
+	}
+	return prefix + "." + environment + "." + domain
+}
@@ -0,0 +1,98 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package source
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+
+	"sigs.k8s.io/external-dns/endpoint"
+	"sigs.k8s.io/external-dns/internal/testutils"
+)
+
+func TestPreviewName(t *testing.T) {
+	for name, expected := range map[string]string{
+		"app.example.com":                "app.pr-123.preview.example.com",
+		"api.app.example.com.":           "api.app.pr-123.preview.example.com",
+		"app.example.co.uk":              "app.pr-123.preview.example.com",
+		"example.com":                    "pr-123.preview.example.com",
+		"*.app.example.com":              "*.app.pr-123.preview.example.com",
+		"localhost":                      "localhost.pr-123.preview.example.com",
+		"App.Example.com":                "app.pr-123.preview.example.com",
+		"app.pr-123.preview.example.com": "app.pr-123.preview.example.com",
+	} {
+		assert.Equal(t, expected, previewName(name, "pr-123", "preview.example.com"), name)
+	}
+}
+
+func newResourceEndpoint(name, resource string) *endpoint.Endpoint {
+	ep := endpoint.NewEndpoint(name, endpoint.RecordTypeA, "1.2.3.4")
+	ep.Labels[endpoint.ResourceLabelKey] = resource
+	return ep
+}
+
+func TestPreviewSourceEndpoints(t *testing.T) {
+	now := time.Date(2025, 6, 10, 0, 0, 0, 0, time.UTC)
+	namespace := func(name string, labels map[string]string, age time.Duration) *v1.Namespace {
+		return &v1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: name, Labels: labels, CreationTimestamp: metav1.NewTime(now.Add(-age))}}
+	}
+	client := fake.NewClientset(
+		namespace("default", nil, 0),
+		namespace("pr-123", map[string]string{"preview": ""}, time.Hour),
+		namespace("feature", map[string]string{"preview": "Feature-X"}, time.Hour),
+		namespace("stale", map[string]string{"preview": "pr-1"}, 100*time.Hour),
+		namespace("invalid", map[string]string{"preview": "pr_2"}, time.Hour),
+	)
+
+	mockSource := new(testutils.MockSource)
+	mockSource.On("Endpoints").Return([]*endpoint.Endpoint{
+		newResourceEndpoint("app.example.com", "ingress/default/app"),
+		newResourceEndpoint("app.example.com", "ingress/pr-123/app"),
+		newResourceEndpoint("api.example.com", "service/feature/api"),
+		newResourceEndpoint("app.example.com", "ingress/stale/app"),
+		newResourceEndpoint("app.example.com", "ingress/invalid/app"),
+		endpoint.NewEndpoint("static.example.com", endpoint.RecordTypeA, "1.2.3.4"),
+	}, nil)
+
+	src := NewPreviewSource(mockSource, client, "preview", "preview.example.com.", 72*time.Hour)
+	src.(*previewSource).now = func() time.Time { return now }
+	endpoints, err := src.Endpoints(context.Background())
+	require.NoError(t, err)
+
+	names := map[string]string{}
+	for _, ep := range endpoints {
+		names[ep.Labels[endpoint.ResourceLabelKey]] = ep.DNSName
+	}
+	assert.Equal(t, map[string]string{
+		"ingress/default/app": "app.example.com",
+		"ingress/pr-123/app":  "app.pr-123.preview.example.com",
+		"service/feature/api": "api.feature-x.preview.example.com",
+		"":                    "static.example.com",
+	}, names, "the endpoints of the expired and invalid environments are dropped")
+}
+
+func TestPreviewSourceDisabled(t *testing.T) {
+	mockSource := new(testutils.MockSource)
+	assert.Same(t, mockSource, NewPreviewSource(mockSource, nil, "", "", 0))
+}
@@ -0,0 +1,373 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package source
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"os"
+	"regexp"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+	"sigs.k8s.io/yaml"
+
+	"sigs.k8s.io/external-dns/endpoint"
+)
+
+const (
+	// prometheusSDAddressLabel is the label holding the address of a target, set from its entry in the target group.
+	prometheusSDAddressLabel = "__address__"
+	// prometheusSDNameLabel holds the comma separated hostnames of the records of a target, which is skipped when it is empty.
+	prometheusSDNameLabel = "__dns_name__"
+	// prometheusSDTargetLabel holds the comma separated targets of the records, the host of the address by default.
+	prometheusSDTargetLabel = "__dns_target__"
+	// prometheusSDTTLLabel holds the TTL of the records, in seconds.
+	prometheusSDTTLLabel = "__dns_ttl__"
+
+	// prometheusSDMaxSize bounds the size of the target lists read, so that a faulty server cannot exhaust the memory.
+	prometheusSDMaxSize = 16 << 20
+)
+
+// PrometheusSDTargetGroup is a group of targets sharing labels, the unit of the target lists of the Prometheus
+// HTTP and file service discoveries.
+type PrometheusSDTargetGroup struct {
+	Targets []string          `json:"targets"`
+	Labels  map[string]string `json:"labels,omitempty"`
+}
+
+// PrometheusSDRelabelConfig is a rule relabeling the targets, with the semantics of the relabel_configs of
+// Prometheus, restricted to the replace, keep, drop and labelmap actions.
+type PrometheusSDRelabelConfig struct {
+	SourceLabels []string `json:"source_labels,omitempty"`
+	Separator    *string  `json:"separator,omitempty"`
+	Regex        *string  `json:"regex,omitempty"`
+	TargetLabel  string   `json:"target_label,omitempty"`
+	Replacement  *string  `json:"replacement,omitempty"`
+	Action       string   `json:"action,omitempty"`
+}
+
+// prometheusSDRelabelRule is a PrometheusSDRelabelConfig with its defaults applied and its regex compiled.
+type prometheusSDRelabelRule struct {
+	sourceLabels []string
+	separator    string
+	regex        *regexp.Regexp
+	targetLabel  string
+	replacement  string
+	action       string
+}
+
+// prometheusSDSource is an implementation of Source publishing records for the targets of Prometheus
+// service discoveries, read from HTTP SD endpoints and SD files, which relabeling rules select and name.
+type prometheusSDSource struct {
+	urls   []string
+	files  []string
+	rules  []prometheusSDRelabelRule
+	client *http.Client
+}
+
+// NewPrometheusSDSource creates a new prometheusSDSource getting the target groups from the given HTTP SD
+// URLs and SD files, in JSON or YAML, relabeled with the rules of the optional relabelConfigFile.
+func NewPrometheusSDSource(urls, files []string, relabelConfigFile string, requestTimeout time.Duration) (Source, error) {
+	if len(urls) == 0 && len(files) == 0 {
+		return nil, errors.New("prometheus-sd source requires at least one HTTP SD URL or SD file")
+	}
+
+	var rules []prometheusSDRelabelRule
+	if relabelConfigFile != "" {
+		b, err := os.ReadFile(relabelConfigFile)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read prometheus-sd relabel config: %w", err)
+		}
+		var configs []PrometheusSDRelabelConfig
+		if err := yaml.UnmarshalStrict(b, &configs); err != nil {
+			return nil, fmt.Errorf("failed to parse prometheus-sd relabel config %s: %w", relabelConfigFile, err)
+		}
+		rules, err = newPrometheusSDRelabelRules(configs)
+		if err != nil {
+			return nil, fmt.Errorf("invalid prometheus-sd relabel config %s: %w", relabelConfigFile, err)
+		}
+	}
+
+	return &prometheusSDSource{
+		urls:   urls,
+		files:  files,
+		rules:  rules,
+		client: &http.Client{Timeout: requestTimeout},
+	}, nil
+}
+
+func newPrometheusSDRelabelRules(configs []PrometheusSDRelabelConfig) ([]prometheusSDRelabelRule, error) {
+	rules := make([]prometheusSDRelabelRule, 0, len(configs))
+	for i, c := range configs {
+		rule := prometheusSDRelabelRule{
+			sourceLabels: c.SourceLabels,
+			separator:    ";",
+			targetLabel:  c.TargetLabel,
+			replacement:  "$1",
+			action:       strings.ToLower(c.Action),
+		}
+		if c.Separator != nil {
+			rule.separator = *c.Separator
+		}
+		if c.Replacement != nil {
+			rule.replacement = *c.Replacement
+		}
+		if rule.action == "" {
+			rule.action = "replace"
+		}
+		regex := "(.*)"
+		if c.Regex != nil {
+			regex = *c.Regex
+		}
+		// like Prometheus, the regex must match the whole value
+		re, err := regexp.Compile("^(?:" + regex + ")$")
+		if err != nil {
+			return nil, fmt.Errorf("rule %d: %w", i, err)
+		}
+		rule.regex = re
+
+		switch rule.action {
+		case "replace":
+			if rule.targetLabel == "" {
+				return nil, fmt.Errorf("rule %d: replace requires a target_label", i)
+			}
+		case "keep", "drop":
+			if len(rule.sourceLabels) == 0 {
+				return nil, fmt.Errorf("rule %d: %s requires source_labels", i, rule.action)
+			}
+		case "labelmap":
+		default:
+			return nil, fmt.Errorf("rule %d: unsupported action %q", i, c.Action)
+		}
+		rules = append(rules, rule)
+	}
+	return rules, nil
+}
+
+// relabel applies the rules to the labels of a target, modified in place, and returns false when the target is dropped.
+func relabel(rules []prometheusSDRelabelRule, labels map[string]string) bool {
+	for _, rule := range rules {
+		values := make([]string, 0, len(rule.sourceLabels))
+		for _, name := range rule.sourceLabels {
+			values = append(values, labels[name])
+		}
+		value := strings.Join(values, rule.separator)
+
+		switch rule.action {
+		case "keep":
+			if !rule.regex.MatchString(value) {
+				return false
+			}
+		case "drop":
+			if rule.regex.MatchString(value) {
+				return false
+			}
+		case "replace":
+			match := rule.regex.FindStringSubmatchIndex(value)
+			if match == nil {
+				continue
+			}
+			target := string(rule.regex.ExpandString(nil, rule.targetLabel, value, match))
+			replacement := string(rule.regex.ExpandString(nil, rule.replacement, value, match))
+			if replacement == "" {
+				delete(labels, target)
+			} else {
+				labels[target] = replacement
+			}
+		case "labelmap":
+			mapped := map[string]string{}
+			for name, v := range labels {
+				if match := rule.regex.FindStringSubmatchIndex(name); match != nil {
+					mapped[string(rule.regex.ExpandString(nil, rule.replacement, name, match))] = v
+				}
+			}
+			for name, v := range mapped {
+				labels[name] = v
+			}
+		}
+	}
+	return true
+}
+
+// Endpoints returns endpoint objects for each target labeled with hostnames after relabeling.
+func (ps *prometheusSDSource) Endpoints(ctx context.Context) ([]*endpoint.Endpoint, error) {
+	var groups []PrometheusSDTargetGroup
+	for _, u := range ps.urls {
+		g, err := ps.fetch(ctx, u)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get prometheus-sd targets from %s: %w", u, err)
+		}
+		groups = append(groups, g...)
+	}
+	for _, f := range ps.files {
+		g, err := readPrometheusSDFile(f)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read prometheus-sd targets from %s: %w", f, err)
+		}
+		groups = append(groups, g...)
+	}
+
+	var endpoints []*endpoint.Endpoint
+	for _, group := range groups {
+		for _, address := range group.Targets {
+			labels := make(map[string]string, len(group.Labels)+1)
+			for k, v := range group.Labels {
+				labels[k] = v
+			}
+			labels[prometheusSDAddressLabel] = address
+			if !relabel(ps.rules, labels) {
+				continue
+			}
+			endpoints = append(endpoints, prometheusSDEndpoints(labels)...)
+		}
+	}
+
+	return mergePrometheusSDEndpoints(endpoints), nil
+}
+
+// mergePrometheusSDEndpoints merges the targets of the endpoints of the same hostname and record type, given to
+// several targets, keeping the TTL of the first one, and sorts them so that the plan is stable.
+func mergePrometheusSDEndpoints(endpoints []*endpoint.Endpoint) []*endpoint.Endpoint {
+	merged := map[endpoint.EndpointKey]*endpoint.Endpoint{}
+	var result []*endpoint.Endpoint
+	for _, ep := range endpoints {
+		key := ep.Key()
+		existing, ok := merged[key]
+		if !ok {
+			merged[key] = ep
+			result = append(result, ep)
+			continue
+		}
+		for _, t := range ep.Targets {
+			if !slices.Contains(existing.Targets, t) {
+				existing.Targets = append(existing.Targets, t)
+			}
+		}
+	}
+	for _, ep := range result {
+		sort.Strings(ep.Targets)
+	}
+	sort.Slice(result, func(i, j int) bool {
+		if result[i].DNSName != result[j].DNSName {
+			return result[i].DNSName < result[j].DNSName
+		}
+		return result[i].RecordType < result[j].RecordType
+	})
+	return result
+}
+
+// prometheusSDEndpoints returns the endpoints of a relabeled target.
+func prometheusSDEndpoints(labels map[string]string) []*endpoint.Endpoint {
+	address := labels[prometheusSDAddressLabel]
+	names := splitPrometheusSDList(labels[prometheusSDNameLabel])
+	if len(names) == 0 {
+		log.Debugf("Skipping prometheus-sd target %s without %s label", address, prometheusSDNameLabel)
+		return nil
+	}
+
+	targets := endpoint.Targets(splitPrometheusSDList(labels[prometheusSDTargetLabel]))
+	if len(targets) == 0 {
+		host, _, err := net.SplitHostPort(address)
+		if err != nil {
+			host = address
+		}
+		if host == "" {
+			log.Debugf("Skipping prometheus-sd target %q without host", address)
+			return nil
+		}
+		targets = endpoint.Targets{host}
+	}
+
+	var ttl endpoint.TTL
+	if v := labels[prometheusSDTTLLabel]; v != "" {
+		seconds, err := strconv.ParseInt(v, 10, 64)
+		if err != nil || seconds < 0 {
+			log.Warnf("Ignoring invalid %s label %q of prometheus-sd target %s", prometheusSDTTLLabel, v, address)
+		} else {
+			ttl = endpoint.TTL(seconds)
+		}
+	}
+
+	var endpoints []*endpoint.Endpoint
+	for _, name := range names {
+		endpoints = append(endpoints, endpointsForHostname(name, targets, ttl, nil, "", "prometheus-sd/"+address)...)
+	}
+	return endpoints
+}
+
+func splitPrometheusSDList(value string) []string {
+	var items []string
+	for _, item := range strings.Split(value, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
+	}
+	return items
+}
+
+// fetch gets the target groups of an HTTP SD endpoint.
+func (ps *prometheusSDSource) fetch(ctx context.Context, url string) ([]PrometheusSDTargetGroup, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Accept", "application/json")
+	resp, err := ps.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("unexpected status %s", resp.Status)
+	}
+	b, err := io.ReadAll(&limitedReader{r: resp.Body, remaining: prometheusSDMaxSize})
+	if err != nil {
+		return nil, err
+	}
+	return parsePrometheusSDTargetGroups(b)
+}
+
+// readPrometheusSDFile reads the target groups of an SD file, in JSON or YAML. It is read on each
+// synchronization, so that the changes of the file are picked up.
+func readPrometheusSDFile(name string) ([]PrometheusSDTargetGroup, error) {
+	b, err := os.ReadFile(name)
+	if err != nil {
+		return nil, err
+	}
+	return parsePrometheusSDTargetGroups(b)
+}
+
+func parsePrometheusSDTargetGroups(b []byte) ([]PrometheusSDTargetGroup, error) {
+	var groups []PrometheusSDTargetGroup
+	// JSON being YAML, both formats are parsed alike
+	if err := yaml.Unmarshal(b, &groups); err != nil {
+		return nil, err
+	}
+	return groups, nil
+}
+
+func (ps *prometheusSDSource) AddEventHandler(ctx context.Context, handler func()) {
+}
@@ -0,0 +1,184 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package source
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"sigs.k8s.io/external-dns/endpoint"
+)
+
+const prometheusSDRelabelConfig = `
+- source_labels: [job]
+  regex: node|web
+  action: keep
+- source_labels: [env]
+  regex: staging
+  action: drop
+- source_labels: [__address__, job]
+  regex: '([^.:]+)[^:]*:\d+;(.*)'
+  target_label: __dns_name__
+  replacement: $1.$2.example.org
+- regex: __meta_(dns_.*)
+  action: labelmap
+  replacement: __${1}__
+`
+
+func writePrometheusSDFile(t *testing.T, name, content string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), name)
+	require.NoError(t, os.WriteFile(path, []byte(content), 0o600))
+	return path
+}
+
+func withPrometheusSDResource(ep *endpoint.Endpoint, address string) *endpoint.Endpoint {
+	ep.Labels[endpoint.ResourceLabelKey] = "prometheus-sd/" + address
+	return ep
+}
+
+func TestPrometheusSDSource(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "application/json", r.Header.Get("Accept"))
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`[
+			{"targets": ["10.0.0.1:9100", "10.0.0.2:9100"], "labels": {"job": "node"}},
+			{"targets": ["10.0.0.3:9100"], "labels": {"job": "node", "env": "staging"}},
+			{"targets": ["10.0.0.4:9090"], "labels": {"job": "prometheus"}}
+		]`))
+	}))
+	defer server.Close()
+
+	file := writePrometheusSDFile(t, "targets.yaml", `
+- targets: ["web-1.internal:8080", "web-2.internal:8080"]
+  labels:
+    job: web
+    __meta_dns_target: lb.example.org
+    __meta_dns_ttl: "60"
+`)
+	relabelConfig := writePrometheusSDFile(t, "relabel.yaml", prometheusSDRelabelConfig)
+
+	src, err := NewPrometheusSDSource([]string{server.URL}, []string{file}, relabelConfig, time.Second)
+	require.NoError(t, err)
+
+	endpoints, err := src.Endpoints(context.Background())
+	require.NoError(t, err)
+
+	validateEndpoints(t, endpoints, []*endpoint.Endpoint{
+		withPrometheusSDResource(endpoint.NewEndpoint("10.node.example.org", endpoint.RecordTypeA, "10.0.0.1", "10.0.0.2"), "10.0.0.1:9100"),
+		withPrometheusSDResource(endpoint.NewEndpointWithTTL("web-1.web.example.org", endpoint.RecordTypeCNAME, 60, "lb.example.org"), "web-1.internal:8080"),
+		withPrometheusSDResource(endpoint.NewEndpointWithTTL("web-2.web.example.org", endpoint.RecordTypeCNAME, 60, "lb.example.org"), "web-2.internal:8080"),
+	})
+}
+
+func TestPrometheusSDSourceWithoutRelabelConfig(t *testing.T) {
+	t.Parallel()
+
+	file := writePrometheusSDFile(t, "targets.json", `[
+		{"targets": ["10.0.0.1:9100", "[2001:db8::1]:9100"], "labels": {"__dns_name__": "node.example.org"}},
+		{"targets": ["10.0.0.2:9100"], "labels": {"__dns_name__": "a.example.org, b.example.org", "__dns_ttl__": "invalid"}},
+		{"targets": ["10.0.0.3:9100"]}
+	]`)
+
+	src, err := NewPrometheusSDSource(nil, []string{file}, "", time.Second)
+	require.NoError(t, err)
+
+	endpoints, err := src.Endpoints(context.Background())
+	require.NoError(t, err)
+
+	validateEndpoints(t, endpoints, []*endpoint.Endpoint{
+		withPrometheusSDResource(endpoint.NewEndpoint("a.example.org", endpoint.RecordTypeA, "10.0.0.2"), "10.0.0.2:9100"),
+		withPrometheusSDResource(endpoint.NewEndpoint("b.example.org", endpoint.RecordTypeA, "10.0.0.2"), "10.0.0.2:9100"),
+		withPrometheusSDResource(endpoint.NewEndpoint("node.example.org", endpoint.RecordTypeA, "10.0.0.1"), "10.0.0.1:9100"),
+		withPrometheusSDResource(endpoint.NewEndpoint("node.example.org", endpoint.RecordTypeAAAA, "2001:db8::1"), "[2001:db8::1]:9100"),
+	})
+}
+
+func TestPrometheusSDSourceErrors(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, "unavailable", http.StatusServiceUnavailable)
+	}))
+	defer server.Close()
+
+	for _, tt := range []struct {
+		title         string
+		urls          []string
+		files         []string
+		relabelConfig string
+		expectedError string
+	}{
+		{
+			title:         "no target lists",
+			expectedError: "requires at least one HTTP SD URL or SD file",
+		},
+		{
+			title:         "unsupported action",
+			files:         []string{"targets.json"},
+			relabelConfig: "- action: hashmod\n",
+			expectedError: `unsupported action "hashmod"`,
+		},
+		{
+			title:         "replace without target label",
+			files:         []string{"targets.json"},
+			relabelConfig: "- source_labels: [job]\n",
+			expectedError: "replace requires a target_label",
+		},
+		{
+			title:         "invalid regex",
+			files:         []string{"targets.json"},
+			relabelConfig: "- source_labels: [job]\n  regex: '('\n  action: keep\n",
+			expectedError: "rule 0",
+		},
+		{
+			title:         "unknown field",
+			files:         []string{"targets.json"},
+			relabelConfig: "- source_labels: [job]\n  modulus: 2\n",
+			expectedError: "failed to parse prometheus-sd relabel config",
+		},
+	} {
+		t.Run(tt.title, func(t *testing.T) {
+			relabelConfig := ""
+			if tt.relabelConfig != "" {
+				relabelConfig = writePrometheusSDFile(t, "relabel.yaml", tt.relabelConfig)
+			}
+			_, err := NewPrometheusSDSource(tt.urls, tt.files, relabelConfig, time.Second)
+			require.ErrorContains(t, err, tt.expectedError)
+		})
+	}
+
+	src, err := NewPrometheusSDSource([]string{server.URL}, nil, "", time.Second)
+	require.NoError(t, err)
+	_, err = src.Endpoints(context.Background())
+	require.ErrorContains(t, err, "unexpected status 503")
+
+	src, err = NewPrometheusSDSource(nil, []string{filepath.Join(t.TempDir(), "missing.json")}, "", time.Second)
+	require.NoError(t, err)
+	_, err = src.Endpoints(context.Background())
+	require.ErrorContains(t, err, "failed to read prometheus-sd targets")
+}
@@ -19,8 +19,9 @@ package source
 import (
 	"context"
 	"fmt"
-	"net"
+	"slices"
 	"sort"
+	"strconv"
 	"strings"
 	"text/template"
 
@@ -28,12 +29,12 @@ import (
 	v1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/labels"
-	kubeinformers "k8s.io/client-go/informers"
 	coreinformers "k8s.io/client-go/informers/core/v1"
 	"k8s.io/client-go/kubernetes"
 	"k8s.io/client-go/tools/cache"
 
 	"sigs.k8s.io/external-dns/endpoint"
+	"sigs.k8s.io/external-dns/pkg/resolver"
 	"sigs.k8s.io/external-dns/source/fqdn"
 )
 
@@ -73,8 +74,7 @@ func NewServiceSource(ctx context.Context, kubeClient kubernetes.Interface, name
 	}
 
 	// Use shared informers to listen for add/update/delete of services/pods/nodes in the specified namespace.
-	// Set resync period to 0, to prevent processing when nothing has changed
-	informerFactory := kubeinformers.NewSharedInformerFactoryWithOptions(kubeClient, 0, kubeinformers.WithNamespace(namespace))
+	informerFactory := newKubeInformerFactory(kubeClient, namespace, ctx.Done())
 	serviceInformer := informerFactory.Core().V1().Services()
 	endpointsInformer := informerFactory.Core().V1().Endpoints()
 	podInformer := informerFactory.Core().V1().Pods()
@@ -415,10 +415,53 @@ func (sc *serviceSource) endpoints(svc *v1.Service) []*endpoint.Endpoint {
 		for _, hostname := range internalHostnameList {
 			endpoints = append(endpoints, sc.generateEndpoints(svc, hostname, providerSpecific, setIdentifier, true)...)
 		}
+
+		for _, ph := range getPortHostnames(svc) {
+			// restricted to the port, so that only its SRV record is published for NodePort services
+			portSvc := *svc
+			portSvc.Spec.Ports = []v1.ServicePort{ph.port}
+			endpoints = append(endpoints, sc.generateEndpoints(&portSvc, ph.hostname, providerSpecific, setIdentifier, false)...)
+		}
 	}
 	return endpoints
 }
 
+// portHostname is a hostname published for a single port of a service.
+type portHostname struct {
+	port     v1.ServicePort
+	hostname string
+}
+
+// getPortHostnames returns the hostnames mapped to the ports of a service by the port-hostnames annotation,
+// a comma separated list of port=hostname pairs, ports being referred to by name or number.
+func getPortHostnames(svc *v1.Service) []portHostname {
+	annotation, ok := svc.Annotations[portHostnamesAnnotationKey]
+	if !ok {
+		return nil
+	}
+
+	var hostnames []portHostname
+	for _, pair := range splitHostnameAnnotation(annotation) {
+		if pair == "" {
+			continue
+		}
+		portRef, hostname, found := strings.Cut(pair, "=")
+		if !found || portRef == "" || hostname == "" {
+			log.Warnf("Service %s/%s: ignoring %q in annotation %s, expected port=hostname", svc.Namespace, svc.Name, pair, portHostnamesAnnotationKey)
+			continue
+		}
+		idx := slices.IndexFunc(svc.Spec.Ports, func(port v1.ServicePort) bool {
+			return port.Name == portRef || strconv.Itoa(int(port.Port)) == portRef
+		})
+		if idx < 0 {
+			log.Warnf("Service %s/%s: ignoring hostname %q of unknown port %q in annotation %s", svc.Namespace, svc.Name, hostname, portRef, portHostnamesAnnotationKey)
+			continue
+		}
+		hostnames = append(hostnames, portHostname{port: svc.Spec.Ports[idx], hostname: hostname})
+	}
+	return hostnames
+}
+
 // filterByAnnotations filters a list of services by a given annotation selector.
 func (sc *serviceSource) filterByAnnotations(services []*v1.Service) ([]*v1.Service, error) {
 	labelSelector, err := metav1.ParseToLabelSelector(sc.annotationFilter)
@@ -464,6 +507,7 @@ func (sc *serviceSource) setResourceLabel(service *v1.Service, endpoints []*endp
 	for _, ep := range endpoints {
 		ep.Labels[endpoint.ResourceLabelKey] = fmt.Sprintf("service/%s/%s", service.Namespace, service.Name)
 	}
+	setObservedVersion(service, endpoints)
 }
 
 func (sc *serviceSource) generateEndpoints(svc *v1.Service, hostname string, providerSpecific endpoint.ProviderSpecific, setIdentifier string, useClusterIP bool) (endpoints []*endpoint.Endpoint) {
@@ -535,21 +579,25 @@ func extractLoadBalancerTargets(svc *v1.Service, resolveLoadBalancerHostname boo
 
 	// Create a corresponding endpoint for each configured external entrypoint.
 	var targets endpoint.Targets
-	for _, lb := range svc.Status.LoadBalancer.Ingress {
-		if lb.IP != "" {
+	selection := newLoadBalancerIngressSelection(svc.Annotations[loadBalancerIngressAnnotationKey])
+	for i, lb := range svc.Status.LoadBalancer.Ingress {
+		selected := selection.selectsEntry(i, lb)
+		if lb.IP != "" && (selected || selection.selectsAddress(lb.IP)) {
 			targets = append(targets, lb.IP)
 		}
 		if lb.Hostname != "" {
-			if resolveLoadBalancerHostname {
-				ips, err := net.LookupIP(lb.Hostname)
+			if resolveLoadBalancerHostname && (selected || len(selection.families) > 0) {
+				addrs, err := resolver.Default().LookupIPAddr(context.Background(), lb.Hostname)
 				if err != nil {
 					log.Errorf("Unable to resolve %q: %v", lb.Hostname, err)
 					continue
 				}
-				for _, ip := range ips {
-					targets = append(targets, ip.String())
+				for _, addr := range addrs {
+					if selected || selection.selectsAddress(addr.IP.String()) {
+						targets = append(targets, addr.IP.String())
+					}
 				}
-			} else {
+			} else if selected && !resolveLoadBalancerHostname {
 				targets = append(targets, lb.Hostname)
 			}
 		}
@@ -558,6 +606,54 @@ func extractLoadBalancerTargets(svc *v1.Service, resolveLoadBalancerHostname boo
 	return targets
 }
 
+// loadBalancerIngressSelection selects the load balancer ingress entries of a service published as targets, as
+// given by the load-balancer-ingress annotation: by index in the status of the service, by IP or hostname, or
+// the addresses of an IP family, ipv4 or ipv6. All the entries are selected when the annotation is not set.
+type loadBalancerIngressSelection struct {
+	all      bool
+	indexes  map[int]bool
+	values   map[string]bool
+	families map[string]bool
+}
+
+func newLoadBalancerIngressSelection(annotation string) loadBalancerIngressSelection {
+	selection := loadBalancerIngressSelection{
+		indexes:  map[int]bool{},
+		values:   map[string]bool{},
+		families: map[string]bool{},
+	}
+	for _, selector := range strings.Split(annotation, ",") {
+		selector = strings.ToLower(strings.TrimSpace(selector))
+		switch selector {
+		case "":
+		case "ipv4":
+			selection.families[endpoint.RecordTypeA] = true
+		case "ipv6":
+			selection.families[endpoint.RecordTypeAAAA] = true
+		default:
+			if index, err := strconv.Atoi(selector); err == nil {
+				selection.indexes[index] = true
+			} else {
+				selection.values[strings.TrimSuffix(selector, ".")] = true
+			}
+		}
+	}
+	selection.all = len(selection.indexes) == 0 && len(selection.values) == 0 && len(selection.families) == 0
+	return selection
+}
+
+// selectsEntry tells whether the ingress entry at index i is selected altogether, by its index, IP or hostname.
+func (s loadBalancerIngressSelection) selectsEntry(i int, lb v1.LoadBalancerIngress) bool {
+	return s.all || s.indexes[i] ||
+		(lb.IP != "" && s.values[strings.ToLower(lb.IP)]) ||
+		(lb.Hostname != "" && s.values[strings.ToLower(strings.TrimSuffix(lb.Hostname, "."))])
+}
+
+// selectsAddress tells whether an address of an ingress entry not selected altogether is selected by its family.
+func (s loadBalancerIngressSelection) selectsAddress(ip string) bool {
+	return s.families[suitableType(ip)]
+}
+
 func isPodStatusReady(status v1.PodStatus) bool {
 	_, condition := getPodCondition(&status, v1.PodReady)
 	return condition != nil && condition.Status == v1.ConditionTrue
@@ -3805,3 +3805,165 @@ func BenchmarkServiceEndpoints(b *testing.B) {
 		require.NoError(b, err)
 	}
 }
+
+func TestServicePortHostnames(t *testing.T) {
+	t.Parallel()
+
+	for _, tc := range []struct {
+		title       string
+		svcType     v1.ServiceType
+		annotations map[string]string
+		expected    []*endpoint.Endpoint
+	}{
+		{
+			title:   "ports are published under their own hostnames along with the hostname annotation",
+			svcType: v1.ServiceTypeLoadBalancer,
+			annotations: map[string]string{
+				hostnameAnnotationKey:      "foo.example.org",
+				portHostnamesAnnotationKey: "grpc=grpc.example.org, http=www.example.org,8443=admin.example.org",
+			},
+			expected: []*endpoint.Endpoint{
+				{DNSName: "foo.example.org", RecordType: endpoint.RecordTypeA, Targets: endpoint.Targets{"1.2.3.4"}},
+				{DNSName: "grpc.example.org", RecordType: endpoint.RecordTypeA, Targets: endpoint.Targets{"1.2.3.4"}},
+				{DNSName: "www.example.org", RecordType: endpoint.RecordTypeA, Targets: endpoint.Targets{"1.2.3.4"}},
+				{DNSName: "admin.example.org", RecordType: endpoint.RecordTypeA, Targets: endpoint.Targets{"1.2.3.4"}},
+			},
+		},
+		{
+			title:   "unknown ports and invalid pairs are ignored",
+			svcType: v1.ServiceTypeLoadBalancer,
+			annotations: map[string]string{
+				portHostnamesAnnotationKey: "grpc=grpc.example.org,smtp=mail.example.org,www.example.org,http=",
+			},
+			expected: []*endpoint.Endpoint{
+				{DNSName: "grpc.example.org", RecordType: endpoint.RecordTypeA, Targets: endpoint.Targets{"1.2.3.4"}},
+			},
+		},
+		{
+			title:   "NodePort services only get the SRV record of the mapped port",
+			svcType: v1.ServiceTypeNodePort,
+			annotations: map[string]string{
+				portHostnamesAnnotationKey: "grpc=grpc.example.org",
+			},
+			expected: []*endpoint.Endpoint{
+				{DNSName: "_foo._tcp.grpc.example.org", RecordType: endpoint.RecordTypeSRV, Targets: endpoint.Targets{"0 50 30001 grpc.example.org"}},
+				{DNSName: "grpc.example.org", RecordType: endpoint.RecordTypeA, Targets: endpoint.Targets{"54.10.11.1"}},
+			},
+		},
+	} {
+		t.Run(tc.title, func(t *testing.T) {
+			t.Parallel()
+
+			kubernetes := fake.NewSimpleClientset()
+			node := &v1.Node{
+				ObjectMeta: metav1.ObjectMeta{Name: "node1"},
+				Status: v1.NodeStatus{
+					Addresses: []v1.NodeAddress{{Type: v1.NodeExternalIP, Address: "54.10.11.1"}},
+				},
+			}
+			_, err := kubernetes.CoreV1().Nodes().Create(context.Background(), node, metav1.CreateOptions{})
+			require.NoError(t, err)
+
+			service := &v1.Service{
+				ObjectMeta: metav1.ObjectMeta{
+					Namespace:   "testing",
+					Name:        "foo",
+					Annotations: tc.annotations,
+				},
+				Spec: v1.ServiceSpec{
+					Type:                  tc.svcType,
+					ExternalTrafficPolicy: v1.ServiceExternalTrafficPolicyTypeCluster,
+					Ports: []v1.ServicePort{
+						{Name: "grpc", Port: 9090, NodePort: 30001},
+						{Name: "http", Port: 80, NodePort: 30002},
+						{Name: "admin", Port: 8443, NodePort: 30003},
+					},
+				},
+				Status: v1.ServiceStatus{
+					LoadBalancer: v1.LoadBalancerStatus{
+						Ingress: []v1.LoadBalancerIngress{{IP: "1.2.3.4"}},
+					},
+				},
+			}
+			_, err = kubernetes.CoreV1().Services(service.Namespace).Create(context.Background(), service, metav1.CreateOptions{})
+			require.NoError(t, err)
+
+			client, err := NewServiceSource(
+				context.TODO(),
+				kubernetes,
+				"",
+				"",
+				"",
+				false,
+				"",
+				false,
+				false,
+				false,
+				[]string{},
+				false,
+				labels.Everything(),
+				false,
+				false,
+			)
+			require.NoError(t, err)
+
+			endpoints, err := client.Endpoints(context.Background())
+			require.NoError(t, err)
+			validateEndpoints(t, endpoints, tc.expected)
+		})
+	}
+}
+
+func TestExtractLoadBalancerTargetsSelection(t *testing.T) {
+	ingress := []v1.LoadBalancerIngress{
+		{IP: "192.0.2.1"},
+		{IP: "2001:db8::1"},
+		{Hostname: "lb.example.com"},
+		{IP: "192.0.2.2", Hostname: "lb2.example.com"},
+	}
+	for _, tt := range []struct {
+		title      string
+		annotation string
+		expected   endpoint.Targets
+	}{
+		{
+			title:    "all entries without annotation",
+			expected: endpoint.Targets{"192.0.2.1", "2001:db8::1", "lb.example.com", "192.0.2.2", "lb2.example.com"},
+		},
+		{
+			title:      "by index",
+			annotation: "0, 2",
+			expected:   endpoint.Targets{"192.0.2.1", "lb.example.com"},
+		},
+		{
+			title:      "by IP and hostname",
+			annotation: "2001:db8::1,LB2.example.com.",
+			expected:   endpoint.Targets{"2001:db8::1", "192.0.2.2", "lb2.example.com"},
+		},
+		{
+			title:      "by IP family",
+			annotation: "ipv4",
+			expected:   endpoint.Targets{"192.0.2.1", "192.0.2.2"},
+		},
+		{
+			title:      "by IP family and index",
+			annotation: "IPv6,2",
+			expected:   endpoint.Targets{"2001:db8::1", "lb.example.com"},
+		},
+		{
+			title:      "selecting nothing",
+			annotation: "7,lb3.example.com",
+		},
+	} {
+		t.Run(tt.title, func(t *testing.T) {
+			svc := &v1.Service{
+				ObjectMeta: metav1.ObjectMeta{Annotations: map[string]string{}},
+				Status:     v1.ServiceStatus{LoadBalancer: v1.LoadBalancerStatus{Ingress: ingress}},
+			}
+			if tt.annotation != "" {
+				svc.Annotations[loadBalancerIngressAnnotationKey] = tt.annotation
+			}
+			assert.Equal(t, tt.expected, extractLoadBalancerTargets(svc, false))
+		})
+	}
+}
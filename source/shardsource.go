@@ -0,0 +1,67 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package source
+
+import (
+	"context"
+	"hash/fnv"
+	"strings"
+
+	"sigs.k8s.io/external-dns/endpoint"
+)
+
+// shardSource is a Source that only keeps the endpoints of its wrapped source whose DNS name belongs to
+// a shard, so that several instances of ExternalDNS each manage a subset of the names.
+type shardSource struct {
+	source Source
+	index  int
+	count  int
+}
+
+// NewShardSource creates a new shardSource wrapping the provided Source, keeping the endpoints of the
+// shard index out of count shards.
+func NewShardSource(source Source, index, count int) Source {
+	return &shardSource{source: source, index: index, count: count}
+}
+
+// Endpoints collects endpoints from its wrapped source and keeps those of the shard.
+func (ss *shardSource) Endpoints(ctx context.Context) ([]*endpoint.Endpoint, error) {
+	endpoints, err := ss.source.Endpoints(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]*endpoint.Endpoint, 0, len(endpoints)/ss.count+1)
+	for _, ep := range endpoints {
+		if Shard(ep.DNSName, ss.count) == ss.index {
+			result = append(result, ep)
+		}
+	}
+	return result, nil
+}
+
+func (ss *shardSource) AddEventHandler(ctx context.Context, handler func()) {
+	ss.source.AddEventHandler(ctx, handler)
+}
+
+// Shard returns the shard, out of count shards, of the given DNS name: the FNV-1a hash of the name, in
+// lowercase without its trailing dot, modulo count. All the records of a name thus belong to the same shard.
+func Shard(dnsName string, count int) int {
+	h := fnv.New32a()
+	_, _ = h.Write([]byte(strings.ToLower(strings.TrimSuffix(dnsName, "."))))
+	return int(h.Sum32() % uint32(count))
+}
@@ -0,0 +1,67 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package source
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"sigs.k8s.io/external-dns/endpoint"
+	"sigs.k8s.io/external-dns/internal/testutils"
+)
+
+var _ Source = &shardSource{}
+
+func TestShardSource(t *testing.T) {
+	var endpoints []*endpoint.Endpoint
+	for i := range 100 {
+		name := fmt.Sprintf("app-%d.example.org", i)
+		endpoints = append(endpoints,
+			endpoint.NewEndpoint(name, endpoint.RecordTypeA, "192.0.2.1"),
+			endpoint.NewEndpoint(name, endpoint.RecordTypeAAAA, "2001:db8::1"),
+		)
+	}
+	mockSource := new(testutils.MockSource)
+	mockSource.On("Endpoints").Return(endpoints, nil)
+
+	seen := map[string]int{}
+	for index := range 3 {
+		sharded, err := NewShardSource(mockSource, index, 3).Endpoints(t.Context())
+		require.NoError(t, err)
+		assert.NotEmpty(t, sharded, "shard %d", index)
+		for _, ep := range sharded {
+			if shard, ok := seen[ep.DNSName]; ok {
+				assert.Equal(t, index, shard, "all the records of %s belong to the same shard", ep.DNSName)
+			}
+			seen[ep.DNSName] = index
+		}
+	}
+	assert.Len(t, seen, 100, "every name belongs to a shard")
+}
+
+func TestShard(t *testing.T) {
+	assert.Equal(t, Shard("app.example.org", 7), Shard("App.Example.org.", 7))
+	assert.Zero(t, Shard("app.example.org", 1))
+	for i := range 50 {
+		shard := Shard(fmt.Sprintf("app-%d.example.org", i), 4)
+		assert.GreaterOrEqual(t, shard, 0)
+		assert.Less(t, shard, 4)
+	}
+}
@@ -0,0 +1,76 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package source
+
+import (
+	"sync"
+
+	istioclient "istio.io/client-go/pkg/clientset/versioned"
+	istioinformers "istio.io/client-go/pkg/informers/externalversions"
+	kubeinformers "k8s.io/client-go/informers"
+	"k8s.io/client-go/kubernetes"
+)
+
+// informerFactoryKey identifies the informer factories that sources can share: the ones
+// created for the same client, namespace and label selector, and started with the same stop
+// channel.
+type informerFactoryKey struct {
+	client    any
+	namespace string
+	selector  string
+	stop      <-chan struct{}
+}
+
+var (
+	informerFactoriesMu sync.Mutex
+	informerFactories   = map[informerFactoryKey]any{}
+)
+
+// sharedInformerFactory returns the informer factory of the key, creating it with newFactory
+// on first use. Sources sharing a factory share its informers, so that enabling several
+// sources watching the same resources, e.g. the pods watched by both the service and pod
+// sources, does not multiply the watches on the API server. Informers are started by each
+// source; starting a factory again only starts the informers registered since.
+func sharedInformerFactory[T any](key informerFactoryKey, newFactory func() T) T {
+	informerFactoriesMu.Lock()
+	defer informerFactoriesMu.Unlock()
+	if factory, ok := informerFactories[key]; ok {
+		return factory.(T)
+	}
+	factory := newFactory()
+	informerFactories[key] = factory
+	return factory
+}
+
+// newKubeInformerFactory returns the shared informer factory of Kubernetes resources in the
+// namespace, all namespaces when empty.
+func newKubeInformerFactory(client kubernetes.Interface, namespace string, stop <-chan struct{}) kubeinformers.SharedInformerFactory {
+	key := informerFactoryKey{client: client, namespace: namespace, stop: stop}
+	return sharedInformerFactory(key, func() kubeinformers.SharedInformerFactory {
+		// Set resync period to 0, to prevent processing when nothing has changed
+		return kubeinformers.NewSharedInformerFactoryWithOptions(client, 0, kubeinformers.WithNamespace(namespace))
+	})
+}
+
+// newIstioInformerFactory returns the shared informer factory of Istio resources in the
+// namespace, all namespaces when empty.
+func newIstioInformerFactory(client istioclient.Interface, namespace string, stop <-chan struct{}) istioinformers.SharedInformerFactory {
+	key := informerFactoryKey{client: client, namespace: namespace, stop: stop}
+	return sharedInformerFactory(key, func() istioinformers.SharedInformerFactory {
+		return istioinformers.NewSharedInformerFactoryWithOptions(client, 0, istioinformers.WithNamespace(namespace))
+	})
+}
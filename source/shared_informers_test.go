@@ -0,0 +1,63 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package source
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+func TestNewKubeInformerFactoryIsShared(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	client := fake.NewClientset()
+
+	factory := newKubeInformerFactory(client, "default", ctx.Done())
+	assert.Same(t, factory, newKubeInformerFactory(client, "default", ctx.Done()))
+	assert.NotSame(t, factory, newKubeInformerFactory(client, "other", ctx.Done()))
+	assert.NotSame(t, factory, newKubeInformerFactory(fake.NewClientset(), "default", ctx.Done()))
+
+	otherCtx, otherCancel := context.WithCancel(context.Background())
+	defer otherCancel()
+	assert.NotSame(t, factory, newKubeInformerFactory(client, "default", otherCtx.Done()))
+}
+
+func TestSourcesShareWatches(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	client := fake.NewClientset()
+
+	_, err := NewServiceSource(ctx, client, "", "", "", false, "", false, false, false, nil, false, labels.Everything(), false, false)
+	require.NoError(t, err)
+	_, err = NewPodSource(ctx, client, "", "", false, "")
+	require.NoError(t, err)
+	_, err = NewNodeSource(ctx, client, "", "", labels.Everything(), true, true, nil, 0)
+	require.NoError(t, err)
+
+	watches := map[string]int{}
+	for _, action := range client.Actions() {
+		if action.GetVerb() == "watch" {
+			watches[action.GetResource().Resource]++
+		}
+	}
+	assert.Equal(t, map[string]int{"services": 1, "endpoints": 1, "pods": 1, "nodes": 1}, watches)
+}
@@ -30,10 +30,15 @@ import (
 	"unicode"
 
 	log "github.com/sirupsen/logrus"
+	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/kubernetes/scheme"
+	typedcorev1 "k8s.io/client-go/kubernetes/typed/core/v1"
+	"k8s.io/client-go/tools/record"
 
 	"sigs.k8s.io/external-dns/endpoint"
 )
@@ -60,6 +65,13 @@ const (
 	controllerAnnotationValue = "dns-controller"
 	// The annotation used for defining the desired hostname
 	internalHostnameAnnotationKey = "external-dns.alpha.kubernetes.io/internal-hostname"
+	// The annotation used for overriding the targets of the listeners of a gateway, e.g. "https=lb.example.com,dns=192.0.2.1"
+	listenerTargetsAnnotationKey = "external-dns.alpha.kubernetes.io/listener-targets"
+	// The annotation used for mapping the ports of a service to hostnames, e.g. "grpc=grpc.example.com,http=www.example.com"
+	portHostnamesAnnotationKey = "external-dns.alpha.kubernetes.io/port-hostnames"
+	// The annotation used for selecting the load balancer ingress entries of a service published as targets, by index,
+	// IP, hostname or IP family, e.g. "0,ipv6"
+	loadBalancerIngressAnnotationKey = "external-dns.alpha.kubernetes.io/load-balancer-ingress"
 )
 
 const (
@@ -69,6 +81,16 @@ const (
 
 // Provider-specific annotations
 const (
+	// The annotation used for pinning the records of the hostnames at their current values
+	PinnedKey = "external-dns.alpha.kubernetes.io/pinned"
+
+	// The annotations used for switching the targets of the records at a given time
+	ScheduledTargetsKey = "external-dns.alpha.kubernetes.io/scheduled-targets"
+	ScheduledAtKey      = "external-dns.alpha.kubernetes.io/scheduled-at"
+
+	// The annotation used for selecting the DNS view of the records, with the providers supporting split DNS
+	DNSViewKey = "external-dns.alpha.kubernetes.io/dns-view"
+
 	// The annotation used for determining if traffic will go through Cloudflare
 	CloudflareProxiedKey        = "external-dns.alpha.kubernetes.io/cloudflare-proxied"
 	CloudflareCustomHostnameKey = "external-dns.alpha.kubernetes.io/cloudflare-custom-hostname"
@@ -205,6 +227,30 @@ func getProviderSpecificAnnotations(ants map[string]string) (endpoint.ProviderSp
 			Value: "true",
 		})
 	}
+	if ants[PinnedKey] == "true" {
+		providerSpecificAnnotations = append(providerSpecificAnnotations, endpoint.ProviderSpecificProperty{
+			Name:  endpoint.PinnedProperty,
+			Value: "true",
+		})
+	}
+	if v, exists := ants[ScheduledTargetsKey]; exists {
+		providerSpecificAnnotations = append(providerSpecificAnnotations, endpoint.ProviderSpecificProperty{
+			Name:  endpoint.ScheduledTargetsProperty,
+			Value: v,
+		})
+	}
+	if v, exists := ants[ScheduledAtKey]; exists {
+		providerSpecificAnnotations = append(providerSpecificAnnotations, endpoint.ProviderSpecificProperty{
+			Name:  endpoint.ScheduledAtProperty,
+			Value: v,
+		})
+	}
+	if v, exists := ants[DNSViewKey]; exists {
+		providerSpecificAnnotations = append(providerSpecificAnnotations, endpoint.ProviderSpecificProperty{
+			Name:  endpoint.DNSViewProperty,
+			Value: v,
+		})
+	}
 	setIdentifier := ""
 	for k, v := range ants {
 		if k == SetIdentifierKey {
@@ -221,12 +267,36 @@ func getProviderSpecificAnnotations(ants map[string]string) (endpoint.ProviderSp
 				Name:  fmt.Sprintf("scw/%s", attr),
 				Value: v,
 			})
+		} else if strings.HasPrefix(k, "external-dns.alpha.kubernetes.io/alibabacloud-") {
+			attr := strings.TrimPrefix(k, "external-dns.alpha.kubernetes.io/alibabacloud-")
+			providerSpecificAnnotations = append(providerSpecificAnnotations, endpoint.ProviderSpecificProperty{
+				Name:  fmt.Sprintf("alibabacloud/%s", attr),
+				Value: v,
+			})
+		} else if strings.HasPrefix(k, "external-dns.alpha.kubernetes.io/constellix-") {
+			attr := strings.TrimPrefix(k, "external-dns.alpha.kubernetes.io/constellix-")
+			providerSpecificAnnotations = append(providerSpecificAnnotations, endpoint.ProviderSpecificProperty{
+				Name:  fmt.Sprintf("constellix/%s", attr),
+				Value: v,
+			})
 		} else if strings.HasPrefix(k, "external-dns.alpha.kubernetes.io/ibmcloud-") {
 			attr := strings.TrimPrefix(k, "external-dns.alpha.kubernetes.io/ibmcloud-")
 			providerSpecificAnnotations = append(providerSpecificAnnotations, endpoint.ProviderSpecificProperty{
 				Name:  fmt.Sprintf("ibmcloud-%s", attr),
 				Value: v,
 			})
+		} else if strings.HasPrefix(k, "external-dns.alpha.kubernetes.io/ovh-") {
+			attr := strings.TrimPrefix(k, "external-dns.alpha.kubernetes.io/ovh-")
+			providerSpecificAnnotations = append(providerSpecificAnnotations, endpoint.ProviderSpecificProperty{
+				Name:  fmt.Sprintf("ovh/%s", attr),
+				Value: v,
+			})
+		} else if strings.HasPrefix(k, "external-dns.alpha.kubernetes.io/oci-") {
+			attr := strings.TrimPrefix(k, "external-dns.alpha.kubernetes.io/oci-")
+			providerSpecificAnnotations = append(providerSpecificAnnotations, endpoint.ProviderSpecificProperty{
+				Name:  fmt.Sprintf("oci/%s", attr),
+				Value: v,
+			})
 		} else if strings.HasPrefix(k, "external-dns.alpha.kubernetes.io/webhook-") {
 			// Support for wildcard annotations for webhook providers
 			attr := strings.TrimPrefix(k, "external-dns.alpha.kubernetes.io/webhook-")
@@ -234,6 +304,16 @@ func getProviderSpecificAnnotations(ants map[string]string) (endpoint.ProviderSp
 				Name:  fmt.Sprintf("webhook/%s", attr),
 				Value: v,
 			})
+		} else if strings.HasPrefix(k, "external-dns.alpha.kubernetes.io/cloudflare-") && k != CloudflareProxiedKey && k != CloudflareCustomHostnameKey && k != CloudflareRegionKey {
+			// Only the known cloudflare annotations are copied above, report the others as typos.
+			if err := endpoint.ValidateProviderSpecificProperty(k, v); err != nil {
+				log.Warnf("Annotation %s: %v", k, err)
+			}
+		}
+	}
+	for _, p := range providerSpecificAnnotations {
+		if err := endpoint.ValidateProviderSpecificProperty(p.Name, p.Value); err != nil {
+			log.Warnf("Provider-specific annotation: %v", err)
 		}
 	}
 	return providerSpecificAnnotations, setIdentifier
@@ -269,6 +349,14 @@ func suitableType(target string) string {
 	return endpoint.RecordTypeCNAME
 }
 
+// setObservedVersion records on the endpoints the version of the resource they were generated from.
+func setObservedVersion(obj metav1.Object, endpoints []*endpoint.Endpoint) {
+	version := endpoint.ObservedVersion{Generation: obj.GetGeneration(), ResourceVersion: obj.GetResourceVersion()}
+	for _, ep := range endpoints {
+		ep.ObservedVersion = version
+	}
+}
+
 // endpointsForHostname returns the endpoint objects for each host-target combination.
 func endpointsForHostname(hostname string, targets endpoint.Targets, ttl endpoint.TTL, providerSpecific endpoint.ProviderSpecific, setIdentifier string, resource string) []*endpoint.Endpoint {
 	var endpoints []*endpoint.Endpoint
@@ -384,3 +472,41 @@ func waitForDynamicCacheSync(ctx context.Context, factory dynamicInformerFactory
 	}
 	return nil
 }
+
+// NewEventRecorder returns a recorder of the Events of external-dns, sent to the API server of kubeClient.
+func NewEventRecorder(kubeClient kubernetes.Interface) record.EventRecorder {
+	broadcaster := record.NewBroadcaster()
+	broadcaster.StartRecordingToSink(&typedcorev1.EventSinkImpl{Interface: kubeClient.CoreV1().Events("")})
+	return broadcaster.NewRecorder(scheme.Scheme, corev1.EventSource{Component: "external-dns"})
+}
+
+// resourceKinds are the kinds and API versions of the resources of the endpoints, by the kind their resource
+// label starts with.
+var resourceKinds = map[string]struct{ apiVersion, kind string }{
+	"crd":            {"externaldns.k8s.io/v1alpha1", "DNSEndpoint"},
+	"gateway":        {"networking.istio.io/v1", "Gateway"},
+	"grpcroute":      {"gateway.networking.k8s.io/v1", "GRPCRoute"},
+	"httproute":      {"gateway.networking.k8s.io/v1", "HTTPRoute"},
+	"HTTPProxy":      {"projectcontour.io/v1", "HTTPProxy"},
+	"ingress":        {"networking.k8s.io/v1", "Ingress"},
+	"ingressroute":   {"traefik.io/v1alpha1", "IngressRoute"},
+	"route":          {"route.openshift.io/v1", "Route"},
+	"service":        {"v1", "Service"},
+	"tcproute":       {"gateway.networking.k8s.io/v1alpha2", "TCPRoute"},
+	"tlsroute":       {"gateway.networking.k8s.io/v1alpha2", "TLSRoute"},
+	"udproute":       {"gateway.networking.k8s.io/v1alpha2", "UDPRoute"},
+	"virtualservice": {"networking.istio.io/v1", "VirtualService"},
+}
+
+// ResourceReference returns a reference to a resource labelled as kind/namespace/name, nil for other labels.
+func ResourceReference(resource string) *corev1.ObjectReference {
+	parts := strings.Split(resource, "/")
+	if len(parts) != 3 || parts[1] == "" || parts[2] == "" {
+		return nil
+	}
+	ref := &corev1.ObjectReference{Kind: parts[0], Namespace: parts[1], Name: parts[2]}
+	if k, ok := resourceKinds[parts[0]]; ok {
+		ref.APIVersion, ref.Kind = k.apiVersion, k.kind
+	}
+	return ref
+}
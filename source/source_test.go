@@ -21,9 +21,12 @@ import (
 	"strconv"
 	"testing"
 
+	log "github.com/sirupsen/logrus"
 	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
 
 	"sigs.k8s.io/external-dns/endpoint"
+	"sigs.k8s.io/external-dns/internal/testutils"
 )
 
 func TestGetTTLFromAnnotations(t *testing.T) {
@@ -275,6 +278,65 @@ func TestGetProviderSpecificAliasAnnotations(t *testing.T) {
 	}
 }
 
+func TestGetProviderSpecificPinnedAnnotations(t *testing.T) {
+	for _, tc := range []struct {
+		title       string
+		annotations map[string]string
+		expected    endpoint.ProviderSpecific
+	}{
+		{
+			title:       "pinned annotation is set to true",
+			annotations: map[string]string{PinnedKey: "true"},
+			expected:    endpoint.ProviderSpecific{{Name: endpoint.PinnedProperty, Value: "true"}},
+		},
+		{
+			title:       "pinned annotation is set to false",
+			annotations: map[string]string{PinnedKey: "false"},
+			expected:    endpoint.ProviderSpecific{},
+		},
+		{
+			title:       "pinned annotation is not set",
+			annotations: map[string]string{"random annotation": "random value"},
+			expected:    endpoint.ProviderSpecific{},
+		},
+	} {
+		t.Run(tc.title, func(t *testing.T) {
+			providerSpecificAnnotations, _ := getProviderSpecificAnnotations(tc.annotations)
+			assert.Equal(t, tc.expected, providerSpecificAnnotations)
+		})
+	}
+}
+
+func TestGetProviderSpecificScheduledAnnotations(t *testing.T) {
+	providerSpecificAnnotations, _ := getProviderSpecificAnnotations(map[string]string{
+		ScheduledTargetsKey: "2.2.2.2,3.3.3.3",
+		ScheduledAtKey:      "2025-06-01T12:00:00Z",
+	})
+	assert.Equal(t, endpoint.ProviderSpecific{
+		{Name: endpoint.ScheduledTargetsProperty, Value: "2.2.2.2,3.3.3.3"},
+		{Name: endpoint.ScheduledAtProperty, Value: "2025-06-01T12:00:00Z"},
+	}, providerSpecificAnnotations)
+}
+
+func TestGetProviderSpecificAnnotationsWarnsAboutTypos(t *testing.T) {
+	hook := testutils.LogsUnderTestWithLogLevel(log.WarnLevel, t)
+
+	providerSpecific, _ := getProviderSpecificAnnotations(map[string]string{
+		"external-dns.alpha.kubernetes.io/aws-wieght":            "10",
+		"external-dns.alpha.kubernetes.io/aws-failover":          "primary",
+		"external-dns.alpha.kubernetes.io/cloudflare-proxy":      "true",
+		"external-dns.alpha.kubernetes.io/webhook-whatever":      "1",
+		"external-dns.alpha.kubernetes.io/cloudflare-region-key": "eu",
+	})
+
+	// the properties are kept, providers ignore the ones they do not know
+	assert.Len(t, providerSpecific, 4)
+	testutils.TestHelperLogContainsWithLogLevel(`unknown aws property "aws/wieght", did you mean "aws/weight"?`, log.WarnLevel, hook, t)
+	testutils.TestHelperLogContainsWithLogLevel(`invalid value "primary" of aws property "aws/failover"`, log.WarnLevel, hook, t)
+	testutils.TestHelperLogContainsWithLogLevel(`did you mean "external-dns.alpha.kubernetes.io/cloudflare-proxied"?`, log.WarnLevel, hook, t)
+	assert.Len(t, hook.AllEntries(), 3)
+}
+
 func TestGetProviderSpecificIdentifierAnnotations(t *testing.T) {
 	for _, tc := range []struct {
 		title              string
@@ -321,6 +383,50 @@ func TestGetProviderSpecificIdentifierAnnotations(t *testing.T) {
 			},
 			expectedIdentifier: "id1",
 		},
+		{
+			title: "alibabacloud- provider specific annotations are set correctly",
+			annotations: map[string]string{
+				"external-dns.alpha.kubernetes.io/alibabacloud-vpc-ids": "vpc-a,cn-beijing:vpc-b",
+			},
+			expectedResult: map[string]string{
+				"alibabacloud/vpc-ids": "vpc-a,cn-beijing:vpc-b",
+			},
+		},
+		{
+			title: "constellix- provider specific annotations are set correctly",
+			annotations: map[string]string{
+				"external-dns.alpha.kubernetes.io/constellix-failover": "true",
+				SetIdentifierKey: "id1",
+				"external-dns.alpha.kubernetes.io/constellix-health-checks": "1.2.3.4=101",
+			},
+			expectedResult: map[string]string{
+				"constellix/failover":      "true",
+				"constellix/health-checks": "1.2.3.4=101",
+			},
+			expectedIdentifier: "id1",
+		},
+		{
+			title: "oci- provider specific annotations are set correctly",
+			annotations: map[string]string{
+				"external-dns.alpha.kubernetes.io/oci-view-id": "ocid1.dnsview.oc1..aaaa",
+				SetIdentifierKey: "id1",
+				"external-dns.alpha.kubernetes.io/oci-weight": "10",
+			},
+			expectedResult: map[string]string{
+				"oci/view-id": "ocid1.dnsview.oc1..aaaa",
+				"oci/weight":  "10",
+			},
+			expectedIdentifier: "id1",
+		},
+		{
+			title: "ovh- provider specific annotations are set correctly",
+			annotations: map[string]string{
+				"external-dns.alpha.kubernetes.io/ovh-ttl": "60",
+			},
+			expectedResult: map[string]string{
+				"ovh/ttl": "60",
+			},
+		},
 		{
 			title: "webhook- provider specific annotations are set correctly",
 			annotations: map[string]string{
@@ -354,3 +460,10 @@ func TestGetProviderSpecificIdentifierAnnotations(t *testing.T) {
 		})
 	}
 }
+
+func TestResourceReference(t *testing.T) {
+	assert.Equal(t, &corev1.ObjectReference{APIVersion: "networking.k8s.io/v1", Kind: "Ingress", Namespace: "default", Name: "foo"}, ResourceReference("ingress/default/foo"))
+	assert.Equal(t, &corev1.ObjectReference{Kind: "ambassadorhost", Namespace: "default", Name: "foo"}, ResourceReference("ambassadorhost/default/foo"))
+	assert.Nil(t, ResourceReference("prometheus-sd/10.0.0.1:9100"))
+	assert.Nil(t, ResourceReference(""))
+}
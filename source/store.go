@@ -38,6 +38,7 @@ import (
 	"k8s.io/client-go/tools/clientcmd"
 	gateway "sigs.k8s.io/gateway-api/pkg/client/clientset/versioned"
 
+	"sigs.k8s.io/external-dns/endpoint"
 	"sigs.k8s.io/external-dns/pkg/apis/externaldns"
 )
 
@@ -60,12 +61,24 @@ type Config struct {
 	GatewayName                    string
 	GatewayNamespace               string
 	GatewayLabelFilter             string
+	GatewayRouteEvents             bool
 	Compatibility                  string
 	PodSourceDomain                string
 	PublishInternal                bool
 	PublishHostIP                  bool
 	AlwaysPublishNotReadyAddresses bool
 	ConnectorServer                string
+	ConnectorTLS                   bool
+	ConnectorTLSCA                 string
+	ConnectorTLSClientCert         string
+	ConnectorTLSClientKey          string
+	ConnectorTokenFile             string
+	ConnectorProtocol              int
+	ConnectorMaxSize               int
+	ConnectorRetries               int
+	PrometheusSDURLs               []string
+	PrometheusSDFiles              []string
+	PrometheusSDRelabelConfig      string
 	CRDSourceAPIVersion            string
 	CRDSourceKind                  string
 	KubeConfig                     string
@@ -85,6 +98,18 @@ type Config struct {
 	TraefikDisableNew              bool
 	ExcludeUnschedulable           bool
 	ExposeInternalIPv6             bool
+	ExcludeNodeTaints              []string
+	ExcludeNotReadyNodesAfter      time.Duration
+	// AppliedVersions, when set, reports the version of the resources incorporated by the last
+	// successful synchronization, for sources writing it back to the status of their resources.
+	AppliedVersions AppliedVersions
+}
+
+// AppliedVersions reports the version of the resources whose endpoints were incorporated by the
+// last successful synchronization.
+type AppliedVersions interface {
+	// AppliedVersion returns the applied version of the resource identified by its resource label.
+	AppliedVersion(resource string) (endpoint.ObservedVersion, bool)
 }
 
 func NewSourceConfig(cfg *externaldns.Config) *Config {
@@ -105,12 +130,24 @@ func NewSourceConfig(cfg *externaldns.Config) *Config {
 		GatewayName:                    cfg.GatewayName,
 		GatewayNamespace:               cfg.GatewayNamespace,
 		GatewayLabelFilter:             cfg.GatewayLabelFilter,
+		GatewayRouteEvents:             cfg.GatewayRouteEvents,
 		Compatibility:                  cfg.Compatibility,
 		PodSourceDomain:                cfg.PodSourceDomain,
 		PublishInternal:                cfg.PublishInternal,
 		PublishHostIP:                  cfg.PublishHostIP,
 		AlwaysPublishNotReadyAddresses: cfg.AlwaysPublishNotReadyAddresses,
 		ConnectorServer:                cfg.ConnectorSourceServer,
+		ConnectorTLS:                   cfg.ConnectorSourceTLS,
+		ConnectorTLSCA:                 cfg.ConnectorSourceTLSCA,
+		ConnectorTLSClientCert:         cfg.ConnectorSourceTLSClientCert,
+		ConnectorTLSClientKey:          cfg.ConnectorSourceTLSClientKey,
+		ConnectorTokenFile:             cfg.ConnectorSourceTokenFile,
+		ConnectorProtocol:              cfg.ConnectorSourceProtocol,
+		ConnectorMaxSize:               cfg.ConnectorSourceMaxSize,
+		ConnectorRetries:               cfg.ConnectorSourceRetries,
+		PrometheusSDURLs:               cfg.PrometheusSDURLs,
+		PrometheusSDFiles:              cfg.PrometheusSDFiles,
+		PrometheusSDRelabelConfig:      cfg.PrometheusSDRelabelConfig,
 		CRDSourceAPIVersion:            cfg.CRDSourceAPIVersion,
 		CRDSourceKind:                  cfg.CRDSourceKind,
 		KubeConfig:                     cfg.KubeConfig,
@@ -130,6 +167,8 @@ func NewSourceConfig(cfg *externaldns.Config) *Config {
 		TraefikDisableNew:              cfg.TraefikDisableNew,
 		ExcludeUnschedulable:           cfg.ExcludeUnschedulable,
 		ExposeInternalIPv6:             cfg.ExposeInternalIPV6,
+		ExcludeNodeTaints:              cfg.ExcludeNodeTaints,
+		ExcludeNotReadyNodesAfter:      cfg.ExcludeNotReadyNodesAfter,
 	}
 }
 
@@ -267,7 +306,7 @@ func BuildWithConfig(ctx context.Context, source string, p ClientGenerator, cfg
 		if err != nil {
 			return nil, err
 		}
-		return NewNodeSource(ctx, client, cfg.AnnotationFilter, cfg.FQDNTemplate, cfg.LabelFilter, cfg.ExposeInternalIPv6, cfg.ExcludeUnschedulable)
+		return NewNodeSource(ctx, client, cfg.AnnotationFilter, cfg.FQDNTemplate, cfg.LabelFilter, cfg.ExposeInternalIPv6, cfg.ExcludeUnschedulable, cfg.ExcludeNodeTaints, cfg.ExcludeNotReadyNodesAfter)
 	case "service":
 		client, err := p.KubeClient()
 		if err != nil {
@@ -367,7 +406,9 @@ func BuildWithConfig(ctx context.Context, source string, p ClientGenerator, cfg
 	case "fake":
 		return NewFakeSource(cfg.FQDNTemplate)
 	case "connector":
-		return NewConnectorSource(cfg.ConnectorServer)
+		return NewConnectorSource(cfg.ConnectorServer, cfg.ConnectorTLS, cfg.ConnectorTLSCA, cfg.ConnectorTLSClientCert, cfg.ConnectorTLSClientKey, cfg.ConnectorTokenFile, cfg.ConnectorProtocol, cfg.ConnectorMaxSize, cfg.ConnectorRetries)
+	case "prometheus-sd":
+		return NewPrometheusSDSource(cfg.PrometheusSDURLs, cfg.PrometheusSDFiles, cfg.PrometheusSDRelabelConfig, cfg.RequestTimeout)
 	case "crd":
 		client, err := p.KubeClient()
 		if err != nil {
@@ -377,7 +418,7 @@ func BuildWithConfig(ctx context.Context, source string, p ClientGenerator, cfg
 		if err != nil {
 			return nil, err
 		}
-		return NewCRDSource(crdClient, cfg.Namespace, cfg.CRDSourceKind, cfg.AnnotationFilter, cfg.LabelFilter, scheme, cfg.UpdateEvents)
+		return NewCRDSource(crdClient, cfg.Namespace, cfg.CRDSourceKind, cfg.AnnotationFilter, cfg.LabelFilter, scheme, cfg.UpdateEvents, cfg.AppliedVersions)
 	case "skipper-routegroup":
 		apiServerURL := cfg.APIServerURL
 		tokenPath := ""